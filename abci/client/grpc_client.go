@@ -2,12 +2,17 @@ package abciclient
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"net"
+	"os"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/tendermint/tendermint/abci/types"
@@ -22,6 +27,7 @@ type grpcClient struct {
 	logger log.Logger
 
 	mustConnect bool
+	tlsConfig   *tls.Config
 
 	client types.ABCIApplicationClient
 	conn   *grpc.ClientConn
@@ -33,15 +39,57 @@ type grpcClient struct {
 
 var _ Client = (*grpcClient)(nil)
 
+// GRPCClientOption sets an optional parameter on a gRPC client.
+type GRPCClientOption func(*grpcClient)
+
+// WithTLSConfig makes the gRPC client secure its connection with TLS, using
+// the given configuration. See LoadTLSConfig.
+func WithTLSConfig(cfg *tls.Config) GRPCClientOption {
+	return func(cli *grpcClient) { cli.tlsConfig = cfg }
+}
+
+// LoadTLSConfig returns a TLS configuration that verifies the server
+// certificate against the CA certificates in the PEM file caFile, or against
+// the system roots if caFile is empty. If certFile and keyFile are not empty,
+// the client authenticates itself with the certificate and private key in
+// these PEM files, for mutual authentication.
+func LoadTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in CA file %q", caFile)
+		}
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
 // NewGRPCClient creates a gRPC client, which will connect to addr upon the
 // start. Note Client#Start returns an error if connection is unsuccessful and
 // mustConnect is true.
-func NewGRPCClient(logger log.Logger, addr string, mustConnect bool) Client {
+func NewGRPCClient(logger log.Logger, addr string, mustConnect bool, options ...GRPCClientOption) Client {
 	cli := &grpcClient{
 		logger:      logger,
 		addr:        addr,
 		mustConnect: mustConnect,
 	}
+	for _, opt := range options {
+		opt(cli)
+	}
 	cli.BaseService = *service.NewBaseService(logger, "grpcClient", cli)
 	return cli
 }
@@ -54,10 +102,25 @@ func (cli *grpcClient) OnStart(ctx context.Context) error {
 	timer := time.NewTimer(0)
 	defer timer.Stop()
 
+	creds := insecure.NewCredentials()
+	if cli.tlsConfig != nil {
+		// Verify the server certificate against the host of the address,
+		// unless the configuration names the expected server.
+		tlsConfig := cli.tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			if proto, addr := tmnet.ProtocolAndAddress(cli.addr); proto == "tcp" {
+				if host, _, err := net.SplitHostPort(addr); err == nil {
+					tlsConfig.ServerName = host
+				}
+			}
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
 RETRY_LOOP:
 	for {
 		conn, err := grpc.Dial(cli.addr,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithTransportCredentials(creds),
 			grpc.WithContextDialer(dialerFunc),
 		)
 		if err != nil {
@@ -2,14 +2,27 @@ package kvstore
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/fortytw2/leaktest"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/log"
+	tmnet "github.com/tendermint/tendermint/libs/net"
 	"github.com/tendermint/tendermint/libs/service"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
@@ -337,6 +350,90 @@ func TestClientServer(t *testing.T) {
 	runClientTests(ctx, t, gclient)
 }
 
+// writeTestCert writes to dir a PEM certificate and private key for the given
+// name, signed by parent, or self-signed if parent is nil.
+func writeTestCert(t *testing.T, dir, name string, parent *tls.Certificate) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signer, signerKey := tmpl, interface{}(key)
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	} else {
+		signer, err = x509.ParseCertificate(parent.Certificate[0])
+		require.NoError(t, err)
+		signerKey = parent.PrivateKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".crt"), certPEM, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"), keyPEM, 0600))
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestClientServerTLS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.NewNopLogger()
+
+	dir := t.TempDir()
+	ca := writeTestCert(t, dir, "ca", nil)
+	serverCert := writeTestCert(t, dir, "server", &ca)
+	writeTestCert(t, dir, "client", &ca)
+
+	caPool := x509.NewCertPool()
+	caPool.AppendCertsFromPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Certificate[0]}))
+	serverTLS := &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    caPool,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	port, err := tmnet.GetFreePort()
+	require.NoError(t, err)
+	addr := fmt.Sprintf("tcp://127.0.0.1:%d", port)
+	server := abciserver.NewGRPCServer(logger, addr, NewApplication(), abciserver.WithTLSConfig(serverTLS))
+	require.NoError(t, server.Start(ctx))
+	t.Cleanup(func() { cancel(); server.Wait() })
+
+	// A client without a certificate is rejected.
+	clientTLS, err := abciclient.LoadTLSConfig("", "", filepath.Join(dir, "ca.crt"))
+	require.NoError(t, err)
+	client := abciclient.NewGRPCClient(logger, addr, true, abciclient.WithTLSConfig(clientTLS))
+	sctx, scancel := context.WithTimeout(ctx, 500*time.Millisecond)
+	defer scancel()
+	require.Error(t, client.Start(sctx))
+
+	clientTLS, err = abciclient.LoadTLSConfig(
+		filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key"), filepath.Join(dir, "ca.crt"))
+	require.NoError(t, err)
+	client = abciclient.NewGRPCClient(logger, addr, true, abciclient.WithTLSConfig(clientTLS))
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(func() { cancel(); client.Wait() })
+
+	runClientTests(ctx, t, client)
+}
+
 func runClientTests(ctx context.Context, t *testing.T, client abciclient.Client) {
 	// run some tests....
 	key := testKey
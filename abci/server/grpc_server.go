@@ -2,9 +2,11 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 
 	"github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -20,11 +22,24 @@ type GRPCServer struct {
 	addr   string
 	server *grpc.Server
 
+	tlsConfig *tls.Config
+
 	app types.Application
 }
 
+// GRPCServerOption sets an optional parameter on a gRPC server.
+type GRPCServerOption func(*GRPCServer)
+
+// WithTLSConfig makes the gRPC server require TLS connections, using the
+// given configuration. To authenticate clients, set its ClientAuth to
+// tls.RequireAndVerifyClientCert and its ClientCAs to the accepted
+// certificate authorities.
+func WithTLSConfig(cfg *tls.Config) GRPCServerOption {
+	return func(s *GRPCServer) { s.tlsConfig = cfg }
+}
+
 // NewGRPCServer returns a new gRPC ABCI server
-func NewGRPCServer(logger log.Logger, protoAddr string, app types.Application) service.Service {
+func NewGRPCServer(logger log.Logger, protoAddr string, app types.Application, options ...GRPCServerOption) service.Service {
 	proto, addr := tmnet.ProtocolAndAddress(protoAddr)
 	s := &GRPCServer{
 		logger: logger,
@@ -32,6 +47,9 @@ func NewGRPCServer(logger log.Logger, protoAddr string, app types.Application) s
 		addr:   addr,
 		app:    app,
 	}
+	for _, opt := range options {
+		opt(s)
+	}
 	s.BaseService = *service.NewBaseService(logger, "ABCIServer", s)
 	return s
 }
@@ -43,7 +61,11 @@ func (s *GRPCServer) OnStart(ctx context.Context) error {
 		return err
 	}
 
-	s.server = grpc.NewServer()
+	var opts []grpc.ServerOption
+	if s.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	}
+	s.server = grpc.NewServer(opts...)
 	types.RegisterABCIApplicationServer(s.server, &gRPCApplication{Application: s.app})
 
 	s.logger.Info("Listening", "proto", s.proto, "addr", s.addr)
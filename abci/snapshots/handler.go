@@ -0,0 +1,67 @@
+package snapshots
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Handler returns an HTTP handler serving the snapshots of the store to the
+// nodes which have it as a state sync snapshot provider (see the statesync
+// snapshot-providers setting): the JSON list of the snapshots at
+// /snapshots.json, and each chunk at /<height>-<format>/<index>. Mount it
+// under a prefix with http.StripPrefix.
+func (s *Store) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.Path == "/snapshots.json" {
+			s.serveList(w)
+			return
+		}
+
+		var (
+			height uint64
+			format uint32
+			index  uint32
+		)
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		if _, err := fmt.Sscanf(path, "%d-%d/%d", &height, &format, &index); err != nil ||
+			path != fmt.Sprintf("%d-%d/%d", height, format, index) {
+			http.NotFound(w, r)
+			return
+		}
+		chunk, err := s.LoadChunk(height, format, index)
+		if errors.Is(err, ErrNotFound) {
+			http.NotFound(w, r)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(chunk)
+	})
+}
+
+func (s *Store) serveList(w http.ResponseWriter) {
+	snapshots, err := s.List()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	bz, err := json.Marshal(snapshots)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	// the snapshots change as they are created and pruned
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(bz)
+}
@@ -0,0 +1,381 @@
+// Package snapshots stores state sync snapshots on disk, so that the node can
+// serve them to peers on behalf of the application.
+//
+// An application creates a snapshot by passing its serialized state to
+// Store.Create, which splits it into chunks and records the hash of each
+// chunk. A node configured with the same directory (see the statesync
+// snapshot-dir setting) then answers ListSnapshots and LoadSnapshotChunk
+// requests from the store, without calling the application. The application
+// still restores snapshots itself, through OfferSnapshot and
+// ApplySnapshotChunk, and can check each chunk it receives with VerifyChunk.
+//
+// A store may be restricted to the snapshot formats the application supports
+// (see WithFormats and the statesync snapshot-formats setting): only those
+// formats are then advertised to peers, and the node rejects the offers of
+// snapshots in other formats without calling the application.
+//
+// The store directory contains one subdirectory per snapshot, named
+// "<height>-<format>", with the chunks in files named by their index and the
+// description of the snapshot in a snapshot.json file, which is written last.
+package snapshots
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// DefaultChunkSize is the default maximum size of a snapshot chunk. It is
+// well below the maximum size of the state sync chunk messages.
+const DefaultChunkSize = 10 << 20
+
+const descriptionFile = "snapshot.json"
+
+// ErrNotFound is returned when a snapshot or chunk is not in the store.
+var ErrNotFound = errors.New("snapshot not found")
+
+// description is the content of the snapshot.json file of a snapshot.
+type description struct {
+	Height      uint64   `json:"height,string"`
+	Format      uint32   `json:"format"`
+	ChunkHashes [][]byte `json:"chunk_hashes"`
+	Metadata    []byte   `json:"metadata"`
+}
+
+// Store is a directory of snapshots. It is safe for concurrent use, and
+// several processes may open the same directory.
+type Store struct {
+	dir        string
+	chunkSize  int
+	keepRecent int
+	formats    map[uint32]bool // nil if all the formats are supported
+
+	mtx sync.Mutex // serializes Create and Prune
+}
+
+// Option sets an optional parameter on a Store.
+type Option func(*Store)
+
+// WithChunkSize sets the maximum size of the chunks of new snapshots.
+func WithChunkSize(size int) Option {
+	return func(s *Store) { s.chunkSize = size }
+}
+
+// WithKeepRecent makes Create remove the snapshots of all but the n most
+// recent heights. Zero, the default, keeps all the snapshots.
+func WithKeepRecent(n int) Option {
+	return func(s *Store) { s.keepRecent = n }
+}
+
+// WithFormats restricts the store to the given snapshot formats, those that
+// the application supports. By default, all the formats are supported.
+func WithFormats(formats ...uint32) Option {
+	return func(s *Store) {
+		s.formats = make(map[uint32]bool, len(formats))
+		for _, format := range formats {
+			s.formats[format] = true
+		}
+	}
+}
+
+// NewStore opens the snapshot store in the given directory, creating the
+// directory if needed.
+func NewStore(dir string, options ...Option) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating snapshot directory: %w", err)
+	}
+	s := &Store{dir: dir, chunkSize: DefaultChunkSize}
+	for _, opt := range options {
+		opt(s)
+	}
+	if s.chunkSize <= 0 {
+		return nil, errors.New("chunk size must be positive")
+	}
+	if s.formats != nil && len(s.formats) == 0 {
+		return nil, errors.New("no snapshot format is supported")
+	}
+	return s, nil
+}
+
+// Formats returns the snapshot formats supported by the store in increasing
+// order, or nil if all the formats are.
+func (s *Store) Formats() []uint32 {
+	if s.formats == nil {
+		return nil
+	}
+	formats := make([]uint32, 0, len(s.formats))
+	for format := range s.formats {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i] < formats[j] })
+	return formats
+}
+
+// SupportsFormat reports whether the store supports the given snapshot format.
+func (s *Store) SupportsFormat(format uint32) bool {
+	return s.formats == nil || s.formats[format]
+}
+
+func (s *Store) path(height uint64, format uint32) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%d-%d", height, format))
+}
+
+// Create stores a snapshot of the given height and format, with the state read
+// from r and the given application metadata, and returns it. The metadata of
+// the returned snapshot also records the chunk hashes, see DecodeMetadata. A
+// snapshot of the same height and format must not already exist, and the
+// format must be supported by the store.
+func (s *Store) Create(height uint64, format uint32, metadata []byte, r io.Reader) (*abci.Snapshot, error) {
+	if height == 0 {
+		return nil, errors.New("snapshot height must be positive")
+	}
+	if !s.SupportsFormat(format) {
+		return nil, fmt.Errorf("snapshot format %d is not supported", format)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	dir := s.path(height, format)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("snapshot at height %d in format %d already exists", height, format)
+	}
+	tmpDir, err := os.MkdirTemp(s.dir, ".tmp-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir) // no-op once renamed
+
+	desc := description{Height: height, Format: format, Metadata: metadata}
+	buf := make([]byte, s.chunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			chunk := buf[:n]
+			name := filepath.Join(tmpDir, strconv.Itoa(len(desc.ChunkHashes)))
+			if err := os.WriteFile(name, chunk, 0644); err != nil {
+				return nil, fmt.Errorf("writing chunk: %w", err)
+			}
+			hash := sha256.Sum256(chunk)
+			desc.ChunkHashes = append(desc.ChunkHashes, hash[:])
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("reading snapshot: %w", err)
+		}
+	}
+	if len(desc.ChunkHashes) == 0 {
+		return nil, errors.New("snapshot is empty")
+	}
+
+	bz, err := json.Marshal(desc)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, descriptionFile), bz, 0644); err != nil {
+		return nil, fmt.Errorf("writing snapshot description: %w", err)
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return nil, err
+	}
+
+	if s.keepRecent > 0 {
+		if err := s.prune(s.keepRecent); err != nil {
+			return nil, err
+		}
+	}
+	return desc.snapshot(), nil
+}
+
+// List returns the snapshots in the store in the supported formats, ordered
+// by decreasing height and then increasing format. Incomplete snapshots are
+// ignored.
+func (s *Store) List() ([]*abci.Snapshot, error) {
+	descs, err := s.descriptions()
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*abci.Snapshot, 0, len(descs))
+	for _, desc := range descs {
+		if s.SupportsFormat(desc.Format) {
+			snapshots = append(snapshots, desc.snapshot())
+		}
+	}
+	return snapshots, nil
+}
+
+// LoadChunk returns the given chunk of the snapshot of the given height and
+// format, after checking its hash. It returns ErrNotFound if the snapshot or
+// chunk does not exist, or if the format is not supported.
+func (s *Store) LoadChunk(height uint64, format uint32, index uint32) ([]byte, error) {
+	if !s.SupportsFormat(format) {
+		return nil, ErrNotFound
+	}
+	desc, err := s.load(s.path(height, format))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if int(index) >= len(desc.ChunkHashes) {
+		return nil, ErrNotFound
+	}
+
+	chunk, err := os.ReadFile(filepath.Join(s.path(height, format), strconv.Itoa(int(index))))
+	if err != nil {
+		return nil, fmt.Errorf("reading chunk: %w", err)
+	}
+	if hash := sha256.Sum256(chunk); !bytes.Equal(hash[:], desc.ChunkHashes[index]) {
+		return nil, fmt.Errorf("chunk %d of snapshot at height %d in format %d is corrupted", index, height, format)
+	}
+	return chunk, nil
+}
+
+// Prune removes the snapshots of all but the n most recent heights.
+func (s *Store) Prune(n int) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.prune(n)
+}
+
+func (s *Store) prune(n int) error {
+	descs, err := s.descriptions()
+	if err != nil {
+		return err
+	}
+	var heights int
+	for i, desc := range descs {
+		if i == 0 || desc.Height != descs[i-1].Height {
+			heights++
+		}
+		if heights > n {
+			if err := os.RemoveAll(s.path(desc.Height, desc.Format)); err != nil {
+				return fmt.Errorf("removing snapshot: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// descriptions returns the descriptions of the complete snapshots in the
+// store, ordered by decreasing height and then increasing format.
+func (s *Store) descriptions() ([]*description, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot directory: %w", err)
+	}
+
+	var descs []*description
+	for _, e := range entries {
+		if !e.IsDir() || e.Name()[0] == '.' {
+			continue
+		}
+		desc, err := s.load(filepath.Join(s.dir, e.Name()))
+		if errors.Is(err, os.ErrNotExist) {
+			continue // not written yet
+		} else if err != nil {
+			return nil, err
+		}
+		descs = append(descs, desc)
+	}
+
+	sort.Slice(descs, func(i, j int) bool {
+		if descs[i].Height != descs[j].Height {
+			return descs[i].Height > descs[j].Height
+		}
+		return descs[i].Format < descs[j].Format
+	})
+	return descs, nil
+}
+
+func (s *Store) load(dir string) (*description, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, descriptionFile))
+	if err != nil {
+		return nil, err
+	}
+	var desc description
+	if err := json.Unmarshal(bz, &desc); err != nil {
+		return nil, fmt.Errorf("decoding snapshot description in %s: %w", dir, err)
+	}
+	return &desc, nil
+}
+
+// snapshot returns the snapshot described by desc. Its hash is the hash of the
+// concatenated chunk hashes.
+func (desc *description) snapshot() *abci.Snapshot {
+	hasher := sha256.New()
+	for _, h := range desc.ChunkHashes {
+		hasher.Write(h)
+	}
+	return &abci.Snapshot{
+		Height:   desc.Height,
+		Format:   desc.Format,
+		Chunks:   uint32(len(desc.ChunkHashes)),
+		Hash:     hasher.Sum(nil),
+		Metadata: encodeMetadata(desc.ChunkHashes, desc.Metadata),
+	}
+}
+
+// encodeMetadata encodes the chunk hashes and application metadata of a
+// snapshot as the number of chunks, as a uvarint, followed by the chunk hashes
+// and the application metadata.
+func encodeMetadata(chunkHashes [][]byte, metadata []byte) []byte {
+	bz := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(chunkHashes)*sha256.Size+len(metadata))
+	bz = bz[:binary.PutUvarint(bz, uint64(len(chunkHashes)))]
+	for _, h := range chunkHashes {
+		bz = append(bz, h...)
+	}
+	return append(bz, metadata...)
+}
+
+// DecodeMetadata decodes the metadata of a snapshot served from a Store into
+// its chunk hashes and the application metadata given to Create.
+func DecodeMetadata(snapshot *abci.Snapshot) (chunkHashes [][]byte, metadata []byte, err error) {
+	bz := snapshot.Metadata
+	n, read := binary.Uvarint(bz)
+	if read <= 0 || n != uint64(snapshot.Chunks) || uint64(len(bz)-read) < n*sha256.Size {
+		return nil, nil, errors.New("invalid snapshot metadata")
+	}
+	bz = bz[read:]
+	chunkHashes = make([][]byte, n)
+	for i := range chunkHashes {
+		chunkHashes[i], bz = bz[:sha256.Size], bz[sha256.Size:]
+	}
+
+	hasher := sha256.New()
+	for _, h := range chunkHashes {
+		hasher.Write(h)
+	}
+	if !bytes.Equal(hasher.Sum(nil), snapshot.Hash) {
+		return nil, nil, errors.New("snapshot metadata does not match its hash")
+	}
+	return chunkHashes, bz, nil
+}
+
+// VerifyChunk checks the given chunk of a snapshot served from a Store
+// against the chunk hashes in its metadata.
+func VerifyChunk(snapshot *abci.Snapshot, index uint32, chunk []byte) error {
+	chunkHashes, _, err := DecodeMetadata(snapshot)
+	if err != nil {
+		return err
+	}
+	if int(index) >= len(chunkHashes) {
+		return fmt.Errorf("chunk index %d out of range", index)
+	}
+	if hash := sha256.Sum256(chunk); !bytes.Equal(hash[:], chunkHashes[index]) {
+		return fmt.Errorf("chunk %d does not match its hash", index)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package snapshots
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewStore(dir, WithChunkSize(4), WithKeepRecent(2))
+	require.NoError(t, err)
+
+	state := []byte("0123456789")
+	snapshot, err := store.Create(1, 1, []byte("meta"), bytes.NewReader(state))
+	require.NoError(t, err)
+	require.EqualValues(t, 1, snapshot.Height)
+	require.EqualValues(t, 3, snapshot.Chunks)
+
+	_, err = store.Create(1, 1, nil, bytes.NewReader(state))
+	require.Error(t, err, "duplicate snapshot")
+	_, err = store.Create(1, 2, nil, bytes.NewReader(nil))
+	require.Error(t, err, "empty snapshot")
+
+	chunkHashes, metadata, err := DecodeMetadata(snapshot)
+	require.NoError(t, err)
+	require.Len(t, chunkHashes, 3)
+	require.Equal(t, []byte("meta"), metadata)
+
+	var restored []byte
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		chunk, err := store.LoadChunk(1, 1, i)
+		require.NoError(t, err)
+		require.NoError(t, VerifyChunk(snapshot, i, chunk))
+		restored = append(restored, chunk...)
+	}
+	require.Equal(t, state, restored)
+	require.Error(t, VerifyChunk(snapshot, 0, []byte("bad")))
+
+	_, err = store.LoadChunk(1, 1, 3)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = store.LoadChunk(1, 2, 0)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// A corrupted chunk is not served.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "1-1", "0"), []byte("bad!"), 0644))
+	_, err = store.LoadChunk(1, 1, 0)
+	require.Error(t, err)
+
+	// Snapshots are listed by decreasing height, and only the two most
+	// recent heights are kept.
+	_, err = store.Create(2, 2, nil, bytes.NewReader(state))
+	require.NoError(t, err)
+	_, err = store.Create(2, 1, nil, bytes.NewReader(state))
+	require.NoError(t, err)
+	_, err = store.Create(3, 1, nil, bytes.NewReader(state))
+	require.NoError(t, err)
+
+	list, err := store.List()
+	require.NoError(t, err)
+	var got [][2]uint64
+	for _, s := range list {
+		got = append(got, [2]uint64{s.Height, uint64(s.Format)})
+	}
+	require.Equal(t, [][2]uint64{{3, 1}, {2, 1}, {2, 2}}, got)
+
+	// Another store on the same directory sees the same snapshots.
+	other, err := NewStore(dir)
+	require.NoError(t, err)
+	otherList, err := other.List()
+	require.NoError(t, err)
+	require.Equal(t, list, otherList)
+}
+
+func TestStoreFormats(t *testing.T) {
+	dir := t.TempDir()
+	all, err := NewStore(dir)
+	require.NoError(t, err)
+	require.Nil(t, all.Formats())
+	for _, format := range []uint32{1, 2, 3} {
+		_, err := all.Create(1, format, nil, bytes.NewReader([]byte("state")))
+		require.NoError(t, err)
+	}
+
+	// Only the supported formats are listed and served.
+	store, err := NewStore(dir, WithFormats(3, 1))
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 3}, store.Formats())
+	require.True(t, store.SupportsFormat(3))
+	require.False(t, store.SupportsFormat(2))
+
+	list, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.EqualValues(t, 1, list[0].Format)
+	require.EqualValues(t, 3, list[1].Format)
+	_, err = store.LoadChunk(1, 2, 0)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = store.LoadChunk(1, 3, 0)
+	require.NoError(t, err)
+
+	_, err = store.Create(2, 2, nil, bytes.NewReader([]byte("state")))
+	require.Error(t, err)
+
+	_, err = NewStore(dir, WithFormats())
+	require.Error(t, err)
+}
@@ -670,6 +670,12 @@ type RequestInitChain struct {
 	Validators      []ValidatorUpdate       `protobuf:"bytes,4,rep,name=validators,proto3" json:"validators"`
 	AppStateBytes   []byte                  `protobuf:"bytes,5,opt,name=app_state_bytes,json=appStateBytes,proto3" json:"app_state_bytes,omitempty"`
 	InitialHeight   int64                   `protobuf:"varint,6,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
+	// app_state_chunk and app_state_chunks are set when the app state is too
+	// large for a single request and is delivered in app_state_chunks InitChain
+	// requests, with app_state_bytes holding its app_state_chunk-th chunk. Only
+	// the response to the last request is used.
+	AppStateChunk  uint32 `protobuf:"varint,7,opt,name=app_state_chunk,json=appStateChunk,proto3" json:"app_state_chunk,omitempty"`
+	AppStateChunks uint32 `protobuf:"varint,8,opt,name=app_state_chunks,json=appStateChunks,proto3" json:"app_state_chunks,omitempty"`
 }
 
 func (m *RequestInitChain) Reset()         { *m = RequestInitChain{} }
@@ -747,6 +753,20 @@ func (m *RequestInitChain) GetInitialHeight() int64 {
 	return 0
 }
 
+func (m *RequestInitChain) GetAppStateChunk() uint32 {
+	if m != nil {
+		return m.AppStateChunk
+	}
+	return 0
+}
+
+func (m *RequestInitChain) GetAppStateChunks() uint32 {
+	if m != nil {
+		return m.AppStateChunks
+	}
+	return 0
+}
+
 type RequestQuery struct {
 	Data   []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 	Path   string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -2203,6 +2223,7 @@ type ResponseCheckTx struct {
 	Codespace string `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
 	Sender    string `protobuf:"bytes,9,opt,name=sender,proto3" json:"sender,omitempty"`
 	Priority  int64  `protobuf:"varint,10,opt,name=priority,proto3" json:"priority,omitempty"`
+	Lane      string `protobuf:"bytes,12,opt,name=lane,proto3" json:"lane,omitempty"`
 }
 
 func (m *ResponseCheckTx) Reset()         { *m = ResponseCheckTx{} }
@@ -2280,6 +2301,13 @@ func (m *ResponseCheckTx) GetPriority() int64 {
 	return 0
 }
 
+func (m *ResponseCheckTx) GetLane() string {
+	if m != nil {
+		return m.Lane
+	}
+	return ""
+}
+
 type ResponseDeliverTx struct {
 	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -3828,211 +3856,209 @@ func init() {
 func init() { proto.RegisterFile("tendermint/abci/types.proto", fileDescriptor_252557cfdd89a31a) }
 
 var fileDescriptor_252557cfdd89a31a = []byte{
-	// 3257 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcd, 0x73, 0x23, 0xd5,
-	0x11, 0xd7, 0xe8, 0x5b, 0x2d, 0x4b, 0x1a, 0x3f, 0x9b, 0x45, 0x2b, 0x76, 0x6d, 0x33, 0x14, 0xb0,
-	0x2c, 0x60, 0x13, 0x6f, 0x16, 0x96, 0x2c, 0x84, 0x92, 0x65, 0x6d, 0x64, 0xaf, 0xd7, 0x36, 0x63,
-	0xd9, 0x14, 0xf9, 0x60, 0x18, 0x4b, 0xcf, 0xd6, 0xb0, 0x92, 0x66, 0x98, 0x19, 0x19, 0x99, 0x63,
-	0x12, 0xaa, 0x52, 0x1c, 0x52, 0xdc, 0xc2, 0x21, 0xdc, 0x92, 0xaa, 0xfc, 0x09, 0xc9, 0x25, 0xa7,
-	0x1c, 0x38, 0xe4, 0xc0, 0x29, 0x95, 0x13, 0x49, 0xc1, 0x8d, 0x7f, 0x20, 0xb7, 0x54, 0xea, 0x7d,
-	0xcc, 0x97, 0x34, 0xa3, 0x0f, 0xa0, 0xa8, 0x4a, 0x15, 0xb7, 0x79, 0x3d, 0xdd, 0xfd, 0xbe, 0xfa,
-	0x75, 0xf7, 0xaf, 0xdf, 0x83, 0xc7, 0x6c, 0xdc, 0x6f, 0x63, 0xb3, 0xa7, 0xf5, 0xed, 0x0d, 0xf5,
-	0xb4, 0xa5, 0x6d, 0xd8, 0x97, 0x06, 0xb6, 0xd6, 0x0d, 0x53, 0xb7, 0x75, 0x54, 0xf2, 0x7e, 0xae,
-	0x93, 0x9f, 0x95, 0xeb, 0x3e, 0xee, 0x96, 0x79, 0x69, 0xd8, 0xfa, 0x86, 0x61, 0xea, 0xfa, 0x19,
-	0xe3, 0xaf, 0x5c, 0x1b, 0xff, 0xfd, 0x10, 0x5f, 0x72, 0x6d, 0x01, 0x61, 0xda, 0xcb, 0x86, 0xa1,
-	0x9a, 0x6a, 0xcf, 0xf9, 0xbd, 0x7a, 0xae, 0xeb, 0xe7, 0x5d, 0xbc, 0x41, 0x5b, 0xa7, 0x83, 0xb3,
-	0x0d, 0x5b, 0xeb, 0x61, 0xcb, 0x56, 0x7b, 0x06, 0x67, 0x58, 0x3e, 0xd7, 0xcf, 0x75, 0xfa, 0xb9,
-	0x41, 0xbe, 0x18, 0x55, 0xfa, 0x4b, 0x0e, 0x32, 0x32, 0x7e, 0x77, 0x80, 0x2d, 0x1b, 0x6d, 0x42,
-	0x12, 0xb7, 0x3a, 0x7a, 0x59, 0x58, 0x13, 0x6e, 0xe4, 0x37, 0xaf, 0xad, 0x8f, 0x0c, 0x7f, 0x9d,
-	0xf3, 0xd5, 0x5b, 0x1d, 0xbd, 0x11, 0x93, 0x29, 0x2f, 0xba, 0x0d, 0xa9, 0xb3, 0xee, 0xc0, 0xea,
-	0x94, 0xe3, 0x54, 0xe8, 0x7a, 0x94, 0xd0, 0x3d, 0xc2, 0xd4, 0x88, 0xc9, 0x8c, 0x9b, 0x74, 0xa5,
-	0xf5, 0xcf, 0xf4, 0x72, 0x62, 0x72, 0x57, 0x3b, 0xfd, 0x33, 0xda, 0x15, 0xe1, 0x45, 0x5b, 0x00,
-	0x5a, 0x5f, 0xb3, 0x95, 0x56, 0x47, 0xd5, 0xfa, 0xe5, 0x24, 0x95, 0x7c, 0x3c, 0x5a, 0x52, 0xb3,
-	0x6b, 0x84, 0xb1, 0x11, 0x93, 0x73, 0x9a, 0xd3, 0x20, 0xc3, 0x7d, 0x77, 0x80, 0xcd, 0xcb, 0x72,
-	0x6a, 0xf2, 0x70, 0x5f, 0x27, 0x4c, 0x64, 0xb8, 0x94, 0x1b, 0xbd, 0x02, 0xd9, 0x56, 0x07, 0xb7,
-	0x1e, 0x2a, 0xf6, 0xb0, 0x9c, 0xa1, 0x92, 0xab, 0x51, 0x92, 0x35, 0xc2, 0xd7, 0x1c, 0x36, 0x62,
-	0x72, 0xa6, 0xc5, 0x3e, 0xd1, 0x1d, 0x48, 0xb7, 0xf4, 0x5e, 0x4f, 0xb3, 0xcb, 0x40, 0x65, 0x57,
-	0x22, 0x65, 0x29, 0x57, 0x23, 0x26, 0x73, 0x7e, 0xb4, 0x0f, 0xc5, 0xae, 0x66, 0xd9, 0x8a, 0xd5,
-	0x57, 0x0d, 0xab, 0xa3, 0xdb, 0x56, 0x39, 0x4f, 0x35, 0x3c, 0x19, 0xa5, 0x61, 0x4f, 0xb3, 0xec,
-	0x23, 0x87, 0xb9, 0x11, 0x93, 0x0b, 0x5d, 0x3f, 0x81, 0xe8, 0xd3, 0xcf, 0xce, 0xb0, 0xe9, 0x2a,
-	0x2c, 0x2f, 0x4c, 0xd6, 0x77, 0x40, 0xb8, 0x1d, 0x79, 0xa2, 0x4f, 0xf7, 0x13, 0xd0, 0xcf, 0x60,
-	0xa9, 0xab, 0xab, 0x6d, 0x57, 0x9d, 0xd2, 0xea, 0x0c, 0xfa, 0x0f, 0xcb, 0x05, 0xaa, 0xf4, 0x99,
-	0xc8, 0x41, 0xea, 0x6a, 0xdb, 0x51, 0x51, 0x23, 0x02, 0x8d, 0x98, 0xbc, 0xd8, 0x1d, 0x25, 0xa2,
-	0xb7, 0x60, 0x59, 0x35, 0x8c, 0xee, 0xe5, 0xa8, 0xf6, 0x22, 0xd5, 0x7e, 0x33, 0x4a, 0x7b, 0x95,
-	0xc8, 0x8c, 0xaa, 0x47, 0xea, 0x18, 0x15, 0x35, 0x41, 0x34, 0x4c, 0x6c, 0xa8, 0x26, 0x56, 0x0c,
-	0x53, 0x37, 0x74, 0x4b, 0xed, 0x96, 0x4b, 0x54, 0xf7, 0xd3, 0x51, 0xba, 0x0f, 0x19, 0xff, 0x21,
-	0x67, 0x6f, 0xc4, 0xe4, 0x92, 0x11, 0x24, 0x31, 0xad, 0x7a, 0x0b, 0x5b, 0x96, 0xa7, 0x55, 0x9c,
-	0xa6, 0x95, 0xf2, 0x07, 0xb5, 0x06, 0x48, 0xa8, 0x0e, 0x79, 0x3c, 0x24, 0xe2, 0xca, 0x85, 0x6e,
-	0xe3, 0xf2, 0x22, 0x55, 0x28, 0x45, 0x9e, 0x50, 0xca, 0x7a, 0xa2, 0xdb, 0xb8, 0x11, 0x93, 0x01,
-	0xbb, 0x2d, 0xa4, 0xc2, 0x23, 0x17, 0xd8, 0xd4, 0xce, 0x2e, 0xa9, 0x1a, 0x85, 0xfe, 0xb1, 0x34,
-	0xbd, 0x5f, 0x46, 0x54, 0xe1, 0xb3, 0x51, 0x0a, 0x4f, 0xa8, 0x10, 0x51, 0x51, 0x77, 0x44, 0x1a,
-	0x31, 0x79, 0xe9, 0x62, 0x9c, 0x4c, 0x4c, 0xec, 0x4c, 0xeb, 0xab, 0x5d, 0xed, 0x7d, 0xac, 0x9c,
-	0x76, 0xf5, 0xd6, 0xc3, 0xf2, 0xd2, 0x64, 0x13, 0xbb, 0xc7, 0xb9, 0xb7, 0x08, 0x33, 0x31, 0xb1,
-	0x33, 0x3f, 0x61, 0x2b, 0x03, 0xa9, 0x0b, 0xb5, 0x3b, 0xc0, 0xbb, 0xc9, 0x6c, 0x5a, 0xcc, 0xec,
-	0x26, 0xb3, 0x59, 0x31, 0xb7, 0x9b, 0xcc, 0xe6, 0x44, 0x90, 0x9e, 0x86, 0xbc, 0xcf, 0x25, 0xa1,
-	0x32, 0x64, 0x7a, 0xd8, 0xb2, 0xd4, 0x73, 0x4c, 0x3d, 0x58, 0x4e, 0x76, 0x9a, 0x52, 0x11, 0x16,
-	0xfc, 0x6e, 0x48, 0xfa, 0x48, 0x70, 0x25, 0x89, 0x87, 0x21, 0x92, 0x17, 0xd8, 0xa4, 0x0b, 0xc1,
-	0x25, 0x79, 0x13, 0x3d, 0x01, 0x05, 0x3a, 0x09, 0xc5, 0xf9, 0x4f, 0xdc, 0x5c, 0x52, 0x5e, 0xa0,
-	0xc4, 0x13, 0xce, 0xb4, 0x0a, 0x79, 0x63, 0xd3, 0x70, 0x59, 0x12, 0x94, 0x05, 0x8c, 0x4d, 0xc3,
-	0x61, 0x78, 0x1c, 0x16, 0xc8, 0x8c, 0x5d, 0x8e, 0x24, 0xed, 0x24, 0x4f, 0x68, 0x9c, 0x45, 0xfa,
-	0x7b, 0x1c, 0xc4, 0x51, 0xd7, 0x85, 0xee, 0x40, 0x92, 0x78, 0x71, 0xee, 0x90, 0x2b, 0xeb, 0xcc,
-	0xc5, 0xaf, 0x3b, 0x2e, 0x7e, 0xbd, 0xe9, 0xb8, 0xf8, 0xad, 0xec, 0xa7, 0x9f, 0xaf, 0xc6, 0x3e,
-	0xfa, 0xd7, 0xaa, 0x20, 0x53, 0x09, 0x74, 0x95, 0x38, 0x2c, 0x55, 0xeb, 0x2b, 0x5a, 0x9b, 0x0e,
-	0x39, 0x47, 0xbc, 0x91, 0xaa, 0xf5, 0x77, 0xda, 0x68, 0x0f, 0xc4, 0x96, 0xde, 0xb7, 0x70, 0xdf,
-	0x1a, 0x58, 0x0a, 0x0b, 0x21, 0xdc, 0x0d, 0x07, 0x9c, 0x29, 0x0b, 0x64, 0x35, 0x87, 0xf3, 0x90,
-	0x32, 0xca, 0xa5, 0x56, 0x90, 0x80, 0xee, 0x01, 0x5c, 0xa8, 0x5d, 0xad, 0xad, 0xda, 0xba, 0x69,
-	0x95, 0x93, 0x6b, 0x89, 0x1b, 0xf9, 0xcd, 0xb5, 0xb1, 0xad, 0x3e, 0x71, 0x58, 0x8e, 0x8d, 0xb6,
-	0x6a, 0xe3, 0xad, 0x24, 0x19, 0xae, 0xec, 0x93, 0x44, 0x4f, 0x41, 0x49, 0x35, 0x0c, 0xc5, 0xb2,
-	0x55, 0x1b, 0x2b, 0xa7, 0x97, 0x36, 0xb6, 0xa8, 0x8b, 0x5e, 0x90, 0x0b, 0xaa, 0x61, 0x1c, 0x11,
-	0xea, 0x16, 0x21, 0xa2, 0x27, 0xa1, 0x48, 0xbc, 0xb9, 0xa6, 0x76, 0x95, 0x0e, 0xd6, 0xce, 0x3b,
-	0x76, 0x39, 0xbd, 0x26, 0xdc, 0x48, 0xc8, 0x05, 0x4e, 0x6d, 0x50, 0xa2, 0xd4, 0x76, 0x77, 0x9c,
-	0x7a, 0x72, 0x84, 0x20, 0xd9, 0x56, 0x6d, 0x95, 0xae, 0xe4, 0x82, 0x4c, 0xbf, 0x09, 0xcd, 0x50,
-	0xed, 0x0e, 0x5f, 0x1f, 0xfa, 0x8d, 0xae, 0x40, 0x9a, 0xab, 0x4d, 0x50, 0xb5, 0xbc, 0x85, 0x96,
-	0x21, 0x65, 0x98, 0xfa, 0x05, 0xa6, 0x5b, 0x97, 0x95, 0x59, 0x43, 0x92, 0xa1, 0x18, 0xf4, 0xfa,
-	0xa8, 0x08, 0x71, 0x7b, 0xc8, 0x7b, 0x89, 0xdb, 0x43, 0xf4, 0x02, 0x24, 0xc9, 0x42, 0xd2, 0x3e,
-	0x8a, 0x21, 0x71, 0x8e, 0xcb, 0x35, 0x2f, 0x0d, 0x2c, 0x53, 0x4e, 0xa9, 0x04, 0x85, 0x40, 0x34,
-	0x90, 0xae, 0xc0, 0x72, 0x98, 0x73, 0x97, 0x3a, 0x2e, 0x3d, 0xe0, 0xa4, 0xd1, 0x6d, 0xc8, 0xba,
-	0xde, 0x9d, 0x19, 0xce, 0xd5, 0xb1, 0x6e, 0x1d, 0x66, 0xd9, 0x65, 0x25, 0x16, 0x43, 0x36, 0xa0,
-	0xa3, 0xf2, 0x58, 0xbe, 0x20, 0x67, 0x54, 0xc3, 0x68, 0xa8, 0x56, 0x47, 0x7a, 0x1b, 0xca, 0x51,
-	0x9e, 0xdb, 0xb7, 0x60, 0x02, 0x35, 0x7b, 0x67, 0xc1, 0xae, 0x40, 0xfa, 0x4c, 0x37, 0x7b, 0xaa,
-	0x4d, 0x95, 0x15, 0x64, 0xde, 0x22, 0x0b, 0xc9, 0xbc, 0x78, 0x82, 0x92, 0x59, 0x43, 0x52, 0xe0,
-	0x6a, 0xa4, 0xf7, 0x26, 0x22, 0x5a, 0xbf, 0x8d, 0xd9, 0xb2, 0x16, 0x64, 0xd6, 0xf0, 0x14, 0xb1,
-	0xc1, 0xb2, 0x06, 0xe9, 0xd6, 0xa2, 0x73, 0xa5, 0xfa, 0x73, 0x32, 0x6f, 0x49, 0x1f, 0x27, 0xe0,
-	0x4a, 0xb8, 0x0f, 0x47, 0x6b, 0xb0, 0xd0, 0x53, 0x87, 0x8a, 0x3d, 0xe4, 0x66, 0x27, 0xd0, 0x8d,
-	0x87, 0x9e, 0x3a, 0x6c, 0x0e, 0x99, 0xcd, 0x89, 0x90, 0xb0, 0x87, 0x56, 0x39, 0xbe, 0x96, 0xb8,
-	0xb1, 0x20, 0x93, 0x4f, 0x74, 0x0c, 0x8b, 0x5d, 0xbd, 0xa5, 0x76, 0x95, 0xae, 0x6a, 0xd9, 0x0a,
-	0x0f, 0xee, 0xec, 0x10, 0x3d, 0x31, 0xb6, 0xd8, 0xcc, 0x1b, 0xe3, 0x36, 0xdb, 0x4f, 0xe2, 0x70,
-	0xb8, 0xfd, 0x97, 0xa8, 0x8e, 0x3d, 0xd5, 0xd9, 0x6a, 0xb4, 0x0d, 0xf9, 0x9e, 0x66, 0x9d, 0xe2,
-	0x8e, 0x7a, 0xa1, 0xe9, 0x26, 0x3f, 0x4d, 0xe3, 0x46, 0xf3, 0xc0, 0xe3, 0xe1, 0x9a, 0xfc, 0x62,
-	0xbe, 0x2d, 0x49, 0x05, 0x6c, 0xd8, 0xf1, 0x26, 0xe9, 0xb9, 0xbd, 0xc9, 0x0b, 0xb0, 0xdc, 0xc7,
-	0x43, 0x5b, 0xf1, 0xce, 0x2b, 0xb3, 0x93, 0x0c, 0x5d, 0x7a, 0x44, 0xfe, 0xb9, 0x27, 0xdc, 0x22,
-	0x26, 0x83, 0x9e, 0xa1, 0x51, 0xd0, 0xd0, 0x2d, 0x6c, 0x2a, 0x6a, 0xbb, 0x6d, 0x62, 0xcb, 0x2a,
-	0x67, 0x29, 0x77, 0xc9, 0xa1, 0x57, 0x19, 0x59, 0xfa, 0x8d, 0x7f, 0x6b, 0x82, 0x51, 0x8f, 0x2f,
-	0xbc, 0xe0, 0x2d, 0xfc, 0x11, 0x2c, 0x73, 0xf9, 0x76, 0x60, 0xed, 0x59, 0xf6, 0xf9, 0xd8, 0xf8,
-	0xf9, 0x1a, 0x5d, 0x73, 0xe4, 0x88, 0x47, 0x2f, 0x7b, 0xe2, 0xeb, 0x2d, 0x3b, 0x82, 0x24, 0x5d,
-	0x94, 0x24, 0x73, 0x31, 0xe4, 0xfb, 0xff, 0x6d, 0x2b, 0x5e, 0x83, 0xc5, 0xb1, 0x0c, 0xc2, 0x9d,
-	0x97, 0x10, 0x3a, 0xaf, 0xb8, 0x7f, 0x5e, 0xd2, 0xef, 0x05, 0xa8, 0x44, 0xa7, 0x0c, 0xa1, 0xaa,
-	0x9e, 0x85, 0x45, 0x77, 0x2e, 0xee, 0xf8, 0xd8, 0x99, 0x16, 0xdd, 0x1f, 0x7c, 0x80, 0x91, 0xee,
-	0xf9, 0x49, 0x28, 0x8e, 0x24, 0x34, 0x6c, 0x17, 0x0a, 0x17, 0xfe, 0xfe, 0xa5, 0x5f, 0x27, 0x5c,
-	0x9f, 0x19, 0xc8, 0x3a, 0x42, 0x0c, 0xed, 0x75, 0x58, 0x6a, 0xe3, 0x96, 0xd6, 0xfe, 0xba, 0x76,
-	0xb6, 0xc8, 0xa5, 0xbf, 0x37, 0xb3, 0x71, 0x33, 0xfb, 0x2d, 0x40, 0x56, 0xc6, 0x96, 0x41, 0x52,
-	0x09, 0xb4, 0x05, 0x39, 0x3c, 0x6c, 0x61, 0xc3, 0x76, 0xb2, 0xaf, 0xf0, 0xbc, 0x96, 0x71, 0xd7,
-	0x1d, 0x4e, 0x82, 0xea, 0x5c, 0x31, 0x74, 0x8b, 0x03, 0xd7, 0x68, 0x0c, 0xca, 0xc5, 0xfd, 0xc8,
-	0xf5, 0x45, 0x07, 0xb9, 0x26, 0x22, 0x41, 0x19, 0x93, 0x1a, 0x81, 0xae, 0xb7, 0x38, 0x74, 0x4d,
-	0x4e, 0xe9, 0x2c, 0x80, 0x5d, 0x6b, 0x01, 0xec, 0x9a, 0x9a, 0x32, 0xcd, 0x08, 0xf0, 0xfa, 0xa2,
-	0x03, 0x5e, 0xd3, 0x53, 0x46, 0x3c, 0x82, 0x5e, 0x5f, 0xf5, 0xa1, 0xd7, 0x2c, 0x15, 0x5d, 0x8b,
-	0x14, 0x0d, 0x81, 0xaf, 0x2f, 0xbb, 0xf0, 0x35, 0x1f, 0x09, 0x7d, 0xb9, 0xf0, 0x28, 0x7e, 0x3d,
-	0x18, 0xc3, 0xaf, 0x0c, 0x6f, 0x3e, 0x15, 0xa9, 0x62, 0x0a, 0x80, 0x3d, 0x18, 0x03, 0xb0, 0x85,
-	0x29, 0x0a, 0xa7, 0x20, 0xd8, 0x9f, 0x87, 0x23, 0xd8, 0x68, 0x8c, 0xc9, 0x87, 0x39, 0x1b, 0x84,
-	0x55, 0x22, 0x20, 0x6c, 0x29, 0x12, 0x6e, 0x31, 0xf5, 0x33, 0x63, 0xd8, 0xe3, 0x10, 0x0c, 0xcb,
-	0xd0, 0xe6, 0x8d, 0x48, 0xe5, 0x33, 0x80, 0xd8, 0xe3, 0x10, 0x10, 0xbb, 0x38, 0x55, 0xed, 0x54,
-	0x14, 0x7b, 0x2f, 0x88, 0x62, 0x51, 0x44, 0xc2, 0xe4, 0x9d, 0xf6, 0x08, 0x18, 0x7b, 0x1a, 0x05,
-	0x63, 0x19, 0xd4, 0x7c, 0x2e, 0x52, 0xe3, 0x1c, 0x38, 0xf6, 0x60, 0x0c, 0xc7, 0x2e, 0x4f, 0xb1,
-	0xb4, 0xd9, 0x81, 0x6c, 0x46, 0xcc, 0x32, 0x08, 0xbb, 0x9b, 0xcc, 0x82, 0x98, 0x97, 0x9e, 0x21,
-	0x71, 0x77, 0xc4, 0xc3, 0x91, 0x04, 0x17, 0x9b, 0xa6, 0x6e, 0x72, 0x48, 0xca, 0x1a, 0xd2, 0x0d,
-	0x02, 0x6c, 0x3c, 0x6f, 0x36, 0x01, 0xf4, 0x52, 0x20, 0xe1, 0xf3, 0x60, 0xd2, 0x9f, 0x05, 0x4f,
-	0x96, 0xc2, 0x5e, 0x3f, 0x28, 0xca, 0x71, 0x50, 0xe4, 0x83, 0xc2, 0xf1, 0x20, 0x14, 0x5e, 0x85,
-	0x3c, 0x01, 0x08, 0x23, 0x28, 0x57, 0x35, 0x5c, 0x94, 0x7b, 0x13, 0x16, 0x69, 0xa8, 0x64, 0x80,
-	0x99, 0x07, 0xa4, 0x24, 0x0d, 0x48, 0x25, 0xf2, 0x83, 0xad, 0x0b, 0x8b, 0x4c, 0xcf, 0xc3, 0x92,
-	0x8f, 0xd7, 0x05, 0x1e, 0x0c, 0xf2, 0x89, 0x2e, 0x77, 0x95, 0x23, 0x90, 0xbf, 0x09, 0xde, 0x0a,
-	0x79, 0xf0, 0x38, 0x0c, 0xc9, 0x0a, 0xdf, 0x12, 0x92, 0x8d, 0x7f, 0x6d, 0x24, 0xeb, 0x07, 0x52,
-	0x89, 0x20, 0x90, 0xfa, 0x8f, 0xe0, 0xed, 0x89, 0x8b, 0x4b, 0x5b, 0x7a, 0x1b, 0x73, 0x68, 0x43,
-	0xbf, 0x49, 0x32, 0xd2, 0xd5, 0xcf, 0x39, 0x80, 0x21, 0x9f, 0x84, 0xcb, 0x0d, 0x39, 0x39, 0x1e,
-	0x51, 0x5c, 0x54, 0xc4, 0x42, 0x3e, 0x47, 0x45, 0x22, 0x24, 0x1e, 0x62, 0x16, 0x20, 0x16, 0x64,
-	0xf2, 0x49, 0xf8, 0xa8, 0xd9, 0xf1, 0xd0, 0xcd, 0x1a, 0xe8, 0x0e, 0xe4, 0x68, 0xe5, 0x59, 0xd1,
-	0x0d, 0x8b, 0xc7, 0x84, 0x40, 0x52, 0xc3, 0xca, 0xcf, 0xeb, 0x87, 0x84, 0xe7, 0xc0, 0xb0, 0xe4,
-	0xac, 0xc1, 0xbf, 0x7c, 0xb9, 0x46, 0x2e, 0x90, 0x6b, 0x5c, 0x83, 0x1c, 0x19, 0xbd, 0x65, 0xa8,
-	0x2d, 0x4c, 0xeb, 0x9c, 0x39, 0xd9, 0x23, 0x48, 0x9f, 0x0a, 0x50, 0x1a, 0x09, 0x31, 0xa1, 0x73,
-	0x77, 0x4c, 0x32, 0xee, 0xc3, 0xe9, 0xd7, 0x01, 0xce, 0x55, 0x4b, 0x79, 0x4f, 0xed, 0xdb, 0xb8,
-	0xcd, 0xa7, 0x9b, 0x3b, 0x57, 0xad, 0x37, 0x28, 0x21, 0xd8, 0x71, 0x76, 0xa4, 0x63, 0x1f, 0x20,
-	0xcc, 0xf9, 0x01, 0x21, 0xaa, 0x40, 0xd6, 0x30, 0x35, 0xdd, 0xd4, 0xec, 0x4b, 0x3a, 0xda, 0x84,
-	0xec, 0xb6, 0x77, 0x93, 0xd9, 0x84, 0x98, 0xdc, 0x4d, 0x66, 0x93, 0x62, 0xca, 0xad, 0x3a, 0xb1,
-	0x23, 0x9b, 0x17, 0x17, 0xa4, 0x0f, 0xe2, 0x9e, 0x2d, 0x6e, 0xe3, 0xae, 0x76, 0x81, 0xcd, 0x39,
-	0x26, 0x33, 0xdb, 0xe6, 0xae, 0x84, 0x4c, 0xd9, 0x47, 0x21, 0xa3, 0x27, 0xad, 0x81, 0x85, 0xdb,
-	0xbc, 0xfe, 0xe1, 0xb6, 0x51, 0x03, 0xd2, 0xf8, 0x02, 0xf7, 0x6d, 0xab, 0x9c, 0xa1, 0x36, 0x7c,
-	0x65, 0x1c, 0x90, 0x92, 0xdf, 0x5b, 0x65, 0x62, 0xb9, 0x5f, 0x7d, 0xbe, 0x2a, 0x32, 0xee, 0xe7,
-	0xf4, 0x9e, 0x66, 0xe3, 0x9e, 0x61, 0x5f, 0xca, 0x5c, 0x7e, 0xf2, 0xca, 0x4a, 0x55, 0x28, 0x06,
-	0xe3, 0x3e, 0x7a, 0x02, 0x0a, 0x26, 0xb6, 0x55, 0xad, 0xaf, 0x04, 0x92, 0xf4, 0x05, 0x46, 0x64,
-	0x27, 0x7f, 0x37, 0x99, 0x15, 0xc4, 0xf8, 0x6e, 0x32, 0x1b, 0x17, 0x13, 0xd2, 0x21, 0x3c, 0x12,
-	0x1a, 0xf7, 0xd1, 0x4b, 0x90, 0xf3, 0x52, 0x06, 0x81, 0x4e, 0x63, 0x42, 0x11, 0xc3, 0xe3, 0x95,
-	0xfe, 0x2a, 0x78, 0x2a, 0x83, 0x65, 0x91, 0x3a, 0xa4, 0x4d, 0x6c, 0x0d, 0xba, 0xac, 0x50, 0x51,
-	0xdc, 0x7c, 0x7e, 0xb6, 0x8c, 0x81, 0x50, 0x07, 0x5d, 0x5b, 0xe6, 0xc2, 0xd2, 0x5b, 0x90, 0x66,
-	0x14, 0x94, 0x87, 0xcc, 0xf1, 0xfe, 0xfd, 0xfd, 0x83, 0x37, 0xf6, 0xc5, 0x18, 0x02, 0x48, 0x57,
-	0x6b, 0xb5, 0xfa, 0x61, 0x53, 0x14, 0x50, 0x0e, 0x52, 0xd5, 0xad, 0x03, 0xb9, 0x29, 0xc6, 0x09,
-	0x59, 0xae, 0xef, 0xd6, 0x6b, 0x4d, 0x31, 0x81, 0x16, 0xa1, 0xc0, 0xbe, 0x95, 0x7b, 0x07, 0xf2,
-	0x83, 0x6a, 0x53, 0x4c, 0xfa, 0x48, 0x47, 0xf5, 0xfd, 0xed, 0xba, 0x2c, 0xa6, 0xa4, 0x1f, 0xc0,
-	0xd5, 0xc8, 0x1c, 0xc3, 0xab, 0x79, 0x08, 0xbe, 0x9a, 0x87, 0xf4, 0x71, 0x9c, 0x80, 0xae, 0xa8,
-	0xc4, 0x01, 0xed, 0x8e, 0x4c, 0x7c, 0x73, 0x8e, 0xac, 0x63, 0x64, 0xf6, 0x04, 0x67, 0x99, 0xf8,
-	0x0c, 0xdb, 0xad, 0x0e, 0x4b, 0x64, 0x98, 0x9f, 0x2c, 0xc8, 0x05, 0x4e, 0xa5, 0x42, 0x16, 0x63,
-	0x7b, 0x07, 0xb7, 0x6c, 0x85, 0x9d, 0x36, 0x8b, 0x82, 0x9d, 0x1c, 0x61, 0x23, 0xd4, 0x23, 0x46,
-	0x94, 0xde, 0x9e, 0x6b, 0x2d, 0x73, 0x90, 0x92, 0xeb, 0x4d, 0xf9, 0x4d, 0x31, 0x81, 0x10, 0x14,
-	0xe9, 0xa7, 0x72, 0xb4, 0x5f, 0x3d, 0x3c, 0x6a, 0x1c, 0x90, 0xb5, 0x5c, 0x82, 0x92, 0xb3, 0x96,
-	0x0e, 0x31, 0x25, 0xfd, 0x23, 0x0e, 0x8f, 0x46, 0xa4, 0x3d, 0xe8, 0x0e, 0x80, 0x3d, 0x54, 0x4c,
-	0xdc, 0xd2, 0xcd, 0x76, 0xb4, 0x91, 0x35, 0x87, 0x32, 0xe5, 0x90, 0x73, 0x36, 0xff, 0xb2, 0x26,
-	0x94, 0xca, 0xd0, 0x2b, 0x5c, 0x29, 0x99, 0x95, 0xc5, 0x21, 0xde, 0xf5, 0x90, 0x8a, 0x10, 0x6e,
-	0x11, 0xc5, 0x74, 0x6d, 0xa9, 0x62, 0xca, 0x8f, 0x1e, 0xf8, 0xb1, 0xf0, 0x80, 0x06, 0x98, 0x99,
-	0x6b, 0xaa, 0x3e, 0xb4, 0xcc, 0x08, 0x16, 0x7a, 0x13, 0x1e, 0x1d, 0x89, 0x8f, 0xae, 0xd2, 0xd4,
-	0xac, 0x61, 0xf2, 0x91, 0x60, 0x98, 0xe4, 0xaa, 0xa5, 0x3f, 0x24, 0xfc, 0x0b, 0x1b, 0xcc, 0xf2,
-	0x0e, 0x20, 0x6d, 0xd9, 0xaa, 0x3d, 0xb0, 0xb8, 0xc1, 0xbd, 0x34, 0x6b, 0xca, 0xb8, 0xee, 0x7c,
-	0x1c, 0x51, 0x71, 0x99, 0xab, 0xf9, 0x7e, 0xbd, 0x2d, 0xe9, 0x36, 0x14, 0x83, 0x8b, 0x13, 0x7d,
-	0x64, 0x3c, 0x9f, 0x13, 0x97, 0xee, 0x02, 0x1a, 0x4f, 0xa6, 0x43, 0xaa, 0x25, 0x42, 0x58, 0xb5,
-	0xe4, 0x8f, 0x02, 0x3c, 0x36, 0x21, 0x71, 0x46, 0xaf, 0x8f, 0xec, 0xf3, 0xcb, 0xf3, 0xa4, 0xdd,
-	0xeb, 0x8c, 0x16, 0xdc, 0x69, 0xe9, 0x16, 0x2c, 0xf8, 0xe9, 0xb3, 0x4d, 0xf2, 0xab, 0xb8, 0xe7,
-	0xf3, 0x83, 0x65, 0x1d, 0x2f, 0x14, 0x0a, 0xdf, 0x30, 0x14, 0x06, 0xed, 0x2c, 0x3e, 0xa7, 0x9d,
-	0x1d, 0x85, 0xd9, 0x59, 0x62, 0xae, 0x0c, 0x73, 0x2e, 0x6b, 0x4b, 0x7e, 0x33, 0x6b, 0x0b, 0x1c,
-	0xb8, 0x54, 0x30, 0x85, 0x7d, 0x13, 0xc0, 0xab, 0x75, 0x91, 0x80, 0x64, 0xea, 0x83, 0x7e, 0x9b,
-	0x5a, 0x40, 0x4a, 0x66, 0x0d, 0x74, 0x1b, 0x52, 0xc4, 0x92, 0x9c, 0x75, 0x1a, 0x77, 0xaa, 0xc4,
-	0x12, 0x7c, 0xb5, 0x32, 0xc6, 0x2d, 0x69, 0x80, 0xc6, 0x4b, 0xe5, 0x11, 0x5d, 0xbc, 0x1a, 0xec,
-	0xe2, 0xf1, 0xc8, 0xa2, 0x7b, 0x78, 0x57, 0xef, 0x43, 0x8a, 0xee, 0x3c, 0x49, 0xbe, 0xe8, 0xfd,
-	0x0c, 0x87, 0x40, 0xe4, 0x1b, 0xfd, 0x02, 0x40, 0xb5, 0x6d, 0x53, 0x3b, 0x1d, 0x78, 0x1d, 0xac,
-	0x86, 0x5b, 0x4e, 0xd5, 0xe1, 0xdb, 0xba, 0xc6, 0x4d, 0x68, 0xd9, 0x13, 0xf5, 0x99, 0x91, 0x4f,
-	0xa1, 0xb4, 0x0f, 0xc5, 0xa0, 0xac, 0x93, 0xb4, 0xb3, 0x31, 0x04, 0x93, 0x76, 0x86, 0xc1, 0x78,
-	0xd2, 0xee, 0xa6, 0xfc, 0x09, 0x76, 0x09, 0x45, 0x1b, 0xd2, 0x7f, 0x05, 0x58, 0xf0, 0x1b, 0xde,
-	0xb7, 0x9c, 0x8a, 0x4e, 0xc9, 0xbe, 0xaf, 0x8e, 0x65, 0xa2, 0x99, 0x73, 0xd5, 0x3a, 0xfe, 0x2e,
-	0x13, 0xd1, 0x0f, 0x04, 0xc8, 0xba, 0x93, 0x0f, 0xde, 0x47, 0x05, 0x2e, 0xf0, 0xd8, 0xda, 0xc5,
-	0xfd, 0x97, 0x48, 0xec, 0xba, 0x2e, 0xe1, 0x5e, 0xd7, 0xdd, 0x75, 0x73, 0xa5, 0xa8, 0xea, 0x9e,
-	0x7f, 0xa5, 0xb9, 0x4d, 0x39, 0xa9, 0xe1, 0xef, 0xf8, 0x38, 0x48, 0x92, 0x80, 0x7e, 0x04, 0x69,
-	0xb5, 0xe5, 0xd6, 0x34, 0x8b, 0x21, 0xc5, 0x3e, 0x87, 0x75, 0xbd, 0x39, 0xac, 0x52, 0x4e, 0x99,
-	0x4b, 0xf0, 0x51, 0xc5, 0x9d, 0x51, 0x49, 0xaf, 0x11, 0xbd, 0x8c, 0x27, 0xe8, 0x11, 0x8b, 0x00,
-	0xc7, 0xfb, 0x0f, 0x0e, 0xb6, 0x77, 0xee, 0xed, 0xd4, 0xb7, 0x79, 0xb6, 0xb4, 0xbd, 0x5d, 0xdf,
-	0x16, 0xe3, 0x84, 0x4f, 0xae, 0x3f, 0x38, 0x38, 0xa9, 0x6f, 0x8b, 0x09, 0xe9, 0x2e, 0xe4, 0x5c,
-	0xaf, 0x42, 0x10, 0xbe, 0x53, 0x9f, 0x15, 0xf8, 0xd9, 0xe6, 0xd5, 0xf5, 0x65, 0x48, 0x19, 0xfa,
-	0x7b, 0xfc, 0xee, 0x2c, 0x21, 0xb3, 0x86, 0xd4, 0x86, 0xd2, 0x88, 0x4b, 0x42, 0x77, 0x21, 0x63,
-	0x0c, 0x4e, 0x15, 0xc7, 0x68, 0x47, 0xaa, 0xd8, 0x0e, 0x76, 0x1c, 0x9c, 0x76, 0xb5, 0xd6, 0x7d,
-	0x7c, 0xe9, 0x2c, 0x93, 0x31, 0x38, 0xbd, 0xcf, 0x6c, 0x9b, 0xf5, 0x12, 0xf7, 0xf7, 0x72, 0x01,
-	0x59, 0xe7, 0xa8, 0xa2, 0x1f, 0x43, 0xce, 0xf5, 0x76, 0xee, 0xdd, 0x77, 0xa4, 0x9b, 0xe4, 0xea,
-	0x3d, 0x11, 0x74, 0x13, 0x16, 0x2d, 0xed, 0xbc, 0xef, 0x94, 0xee, 0x59, 0xf5, 0x26, 0x4e, 0xcf,
-	0x4c, 0x89, 0xfd, 0xd8, 0x73, 0x0a, 0x0c, 0x24, 0xc8, 0x89, 0xa3, 0xbe, 0xe2, 0xbb, 0x1c, 0x40,
-	0x48, 0x30, 0x4e, 0x84, 0x05, 0xe3, 0x5f, 0xc5, 0x21, 0xef, 0xbb, 0x19, 0x40, 0x3f, 0xf4, 0x39,
-	0xae, 0x62, 0x48, 0x14, 0xf1, 0xf1, 0x7a, 0x97, 0xcb, 0xc1, 0x89, 0xc5, 0xe7, 0x9f, 0x58, 0xd4,
-	0xfd, 0x8b, 0x73, 0xd1, 0x90, 0x9c, 0xfb, 0xa2, 0xe1, 0x39, 0x40, 0xb6, 0x6e, 0xab, 0x5d, 0xe5,
-	0x42, 0xb7, 0xb5, 0xfe, 0xb9, 0xc2, 0x4c, 0x83, 0xb9, 0x19, 0x91, 0xfe, 0x39, 0xa1, 0x3f, 0x0e,
-	0xa9, 0x95, 0xfc, 0x52, 0x80, 0xac, 0x8b, 0xe8, 0xe6, 0xbd, 0x7a, 0xbe, 0x02, 0x69, 0x0e, 0x5a,
-	0xd8, 0xdd, 0x33, 0x6f, 0x85, 0xde, 0xa8, 0x54, 0x20, 0xdb, 0xc3, 0xb6, 0x4a, 0x7d, 0x26, 0x8b,
-	0x80, 0x6e, 0xfb, 0xe6, 0xcb, 0x90, 0xf7, 0x5d, 0xdb, 0x13, 0x37, 0xba, 0x5f, 0x7f, 0x43, 0x8c,
-	0x55, 0x32, 0x1f, 0x7e, 0xb2, 0x96, 0xd8, 0xc7, 0xef, 0x91, 0x13, 0x26, 0xd7, 0x6b, 0x8d, 0x7a,
-	0xed, 0xbe, 0x28, 0x54, 0xf2, 0x1f, 0x7e, 0xb2, 0x96, 0x91, 0x31, 0x2d, 0xa6, 0xdf, 0xbc, 0x0f,
-	0xa5, 0x91, 0x8d, 0x09, 0x1e, 0x68, 0x04, 0xc5, 0xed, 0xe3, 0xc3, 0xbd, 0x9d, 0x5a, 0xb5, 0x59,
-	0x57, 0x4e, 0x0e, 0x9a, 0x75, 0x51, 0x40, 0x8f, 0xc2, 0xd2, 0xde, 0xce, 0x4f, 0x1a, 0x4d, 0xa5,
-	0xb6, 0xb7, 0x53, 0xdf, 0x6f, 0x2a, 0xd5, 0x66, 0xb3, 0x5a, 0xbb, 0x2f, 0xc6, 0x37, 0xff, 0x94,
-	0x87, 0x52, 0x75, 0xab, 0xb6, 0x43, 0x60, 0x9b, 0xd6, 0x52, 0xa9, 0x7b, 0xa8, 0x41, 0x92, 0x96,
-	0x05, 0x27, 0x3e, 0xde, 0xab, 0x4c, 0xbe, 0x21, 0x41, 0xf7, 0x20, 0x45, 0x2b, 0x86, 0x68, 0xf2,
-	0x6b, 0xbe, 0xca, 0x94, 0x2b, 0x13, 0x32, 0x18, 0x7a, 0x9c, 0x26, 0x3e, 0xef, 0xab, 0x4c, 0xbe,
-	0x41, 0x41, 0x7b, 0x90, 0x71, 0x0a, 0x46, 0xd3, 0xde, 0xdc, 0x55, 0xa6, 0x5e, 0x6b, 0x90, 0xa9,
-	0xb1, 0xc2, 0xdb, 0xe4, 0x97, 0x7f, 0x95, 0x29, 0x77, 0x2b, 0x68, 0x07, 0xd2, 0xbc, 0xe8, 0x31,
-	0xe5, 0x31, 0x5f, 0x65, 0xda, 0x6d, 0x09, 0x92, 0x21, 0xe7, 0x95, 0x34, 0xa7, 0xbf, 0x67, 0xac,
-	0xcc, 0x70, 0x6d, 0x84, 0xde, 0x82, 0x42, 0xb0, 0xa0, 0x32, 0xdb, 0x83, 0xc1, 0xca, 0x8c, 0xf7,
-	0x32, 0x44, 0x7f, 0xb0, 0xba, 0x32, 0xdb, 0x03, 0xc2, 0xca, 0x8c, 0xd7, 0x34, 0xe8, 0x1d, 0x58,
-	0x1c, 0xaf, 0x7e, 0xcc, 0xfe, 0x9e, 0xb0, 0x32, 0xc7, 0xc5, 0x0d, 0xea, 0x01, 0x0a, 0xa9, 0x9a,
-	0xcc, 0xf1, 0xbc, 0xb0, 0x32, 0xcf, 0x3d, 0x0e, 0x6a, 0x43, 0x69, 0xb4, 0x12, 0x31, 0xeb, 0x73,
-	0xc3, 0xca, 0xcc, 0x77, 0x3a, 0xac, 0x97, 0x20, 0x2c, 0x9f, 0xf5, 0xf9, 0x61, 0x65, 0xe6, 0x2b,
-	0x1e, 0x74, 0x0c, 0xe0, 0x83, 0x95, 0x33, 0x3c, 0x47, 0xac, 0xcc, 0x72, 0xd9, 0x83, 0x0c, 0x58,
-	0x0a, 0xc3, 0x9b, 0xf3, 0xbc, 0x4e, 0xac, 0xcc, 0x75, 0x07, 0x44, 0xec, 0x39, 0x88, 0x1c, 0x67,
-	0x7b, 0xad, 0x58, 0x99, 0xf1, 0x32, 0x68, 0xab, 0xfe, 0xe9, 0x17, 0x2b, 0xc2, 0x67, 0x5f, 0xac,
-	0x08, 0xff, 0xfe, 0x62, 0x45, 0xf8, 0xe8, 0xcb, 0x95, 0xd8, 0x67, 0x5f, 0xae, 0xc4, 0xfe, 0xf9,
-	0xe5, 0x4a, 0xec, 0xa7, 0xcf, 0x9e, 0x6b, 0x76, 0x67, 0x70, 0xba, 0xde, 0xd2, 0x7b, 0x1b, 0xfe,
-	0x07, 0xde, 0x61, 0xcf, 0xca, 0x4f, 0xd3, 0x34, 0xa0, 0xde, 0xfa, 0x5f, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xdc, 0x85, 0x19, 0x4e, 0x76, 0x2e, 0x00, 0x00,
+	// 3227 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcb, 0x73, 0x23, 0xd5,
+	0xd5, 0x57, 0xeb, 0xad, 0xa3, 0xa7, 0xaf, 0x3d, 0x83, 0x46, 0xcc, 0xd8, 0xa6, 0x29, 0xc0, 0x0c,
+	0x60, 0xf3, 0x79, 0x3e, 0x60, 0xf8, 0x06, 0x3e, 0xca, 0x96, 0xe5, 0xc8, 0x8c, 0xc7, 0x36, 0xd7,
+	0xb2, 0x29, 0xf2, 0xa0, 0x69, 0x4b, 0xd7, 0x56, 0x33, 0x92, 0xba, 0xe9, 0x6e, 0x19, 0x99, 0x65,
+	0x12, 0xaa, 0x52, 0xac, 0xd8, 0x85, 0x45, 0xc8, 0x2a, 0xa9, 0xe4, 0x4f, 0xc8, 0x2a, 0xab, 0x2c,
+	0x58, 0xb2, 0x4a, 0x65, 0x45, 0x52, 0xb0, 0xe3, 0x1f, 0xc8, 0x26, 0x95, 0x4a, 0xdd, 0x47, 0xb7,
+	0xba, 0xa5, 0x6e, 0x3d, 0x80, 0xa2, 0x2a, 0x55, 0xec, 0xee, 0x3d, 0x7d, 0xce, 0xb9, 0x8f, 0x3e,
+	0xf7, 0x9c, 0xf3, 0x3b, 0xf7, 0xc2, 0xa3, 0x36, 0xe9, 0xb5, 0x88, 0xd9, 0xd5, 0x7a, 0xf6, 0x86,
+	0x7a, 0xd6, 0xd4, 0x36, 0xec, 0x2b, 0x83, 0x58, 0xeb, 0x86, 0xa9, 0xdb, 0x3a, 0x2a, 0x0e, 0x3f,
+	0xae, 0xd3, 0x8f, 0x95, 0x5b, 0x1e, 0xee, 0xa6, 0x79, 0x65, 0xd8, 0xfa, 0x86, 0x61, 0xea, 0xfa,
+	0x39, 0xe7, 0xaf, 0xdc, 0x1c, 0xff, 0xfc, 0x90, 0x5c, 0x09, 0x6d, 0x3e, 0x61, 0x36, 0xca, 0x86,
+	0xa1, 0x9a, 0x6a, 0xd7, 0xf9, 0xbc, 0x72, 0xa1, 0xeb, 0x17, 0x1d, 0xb2, 0xc1, 0x7a, 0x67, 0xfd,
+	0xf3, 0x0d, 0x5b, 0xeb, 0x12, 0xcb, 0x56, 0xbb, 0x86, 0x60, 0x58, 0xba, 0xd0, 0x2f, 0x74, 0xd6,
+	0xdc, 0xa0, 0x2d, 0x4e, 0x95, 0xff, 0x90, 0x81, 0x14, 0x26, 0xef, 0xf5, 0x89, 0x65, 0xa3, 0x4d,
+	0x88, 0x93, 0x66, 0x5b, 0x2f, 0x4b, 0xab, 0xd2, 0x5a, 0x76, 0xf3, 0xe6, 0xfa, 0xc8, 0xf4, 0xd7,
+	0x05, 0x5f, 0xad, 0xd9, 0xd6, 0xeb, 0x11, 0xcc, 0x78, 0xd1, 0x0b, 0x90, 0x38, 0xef, 0xf4, 0xad,
+	0x76, 0x39, 0xca, 0x84, 0x6e, 0x85, 0x09, 0xed, 0x52, 0xa6, 0x7a, 0x04, 0x73, 0x6e, 0x3a, 0x94,
+	0xd6, 0x3b, 0xd7, 0xcb, 0xb1, 0xc9, 0x43, 0xed, 0xf5, 0xce, 0xd9, 0x50, 0x94, 0x17, 0x6d, 0x03,
+	0x68, 0x3d, 0xcd, 0x56, 0x9a, 0x6d, 0x55, 0xeb, 0x95, 0xe3, 0x4c, 0xf2, 0xb1, 0x70, 0x49, 0xcd,
+	0xae, 0x52, 0xc6, 0x7a, 0x04, 0x67, 0x34, 0xa7, 0x43, 0xa7, 0xfb, 0x5e, 0x9f, 0x98, 0x57, 0xe5,
+	0xc4, 0xe4, 0xe9, 0xbe, 0x41, 0x99, 0xe8, 0x74, 0x19, 0x37, 0x7a, 0x05, 0xd2, 0xcd, 0x36, 0x69,
+	0x3e, 0x54, 0xec, 0x41, 0x39, 0xc5, 0x24, 0x57, 0xc2, 0x24, 0xab, 0x94, 0xaf, 0x31, 0xa8, 0x47,
+	0x70, 0xaa, 0xc9, 0x9b, 0xe8, 0x2e, 0x24, 0x9b, 0x7a, 0xb7, 0xab, 0xd9, 0x65, 0x60, 0xb2, 0xcb,
+	0xa1, 0xb2, 0x8c, 0xab, 0x1e, 0xc1, 0x82, 0x1f, 0x1d, 0x40, 0xa1, 0xa3, 0x59, 0xb6, 0x62, 0xf5,
+	0x54, 0xc3, 0x6a, 0xeb, 0xb6, 0x55, 0xce, 0x32, 0x0d, 0x4f, 0x84, 0x69, 0xd8, 0xd7, 0x2c, 0xfb,
+	0xd8, 0x61, 0xae, 0x47, 0x70, 0xbe, 0xe3, 0x25, 0x50, 0x7d, 0xfa, 0xf9, 0x39, 0x31, 0x5d, 0x85,
+	0xe5, 0xdc, 0x64, 0x7d, 0x87, 0x94, 0xdb, 0x91, 0xa7, 0xfa, 0x74, 0x2f, 0x01, 0xfd, 0x04, 0x16,
+	0x3b, 0xba, 0xda, 0x72, 0xd5, 0x29, 0xcd, 0x76, 0xbf, 0xf7, 0xb0, 0x9c, 0x67, 0x4a, 0x9f, 0x0e,
+	0x9d, 0xa4, 0xae, 0xb6, 0x1c, 0x15, 0x55, 0x2a, 0x50, 0x8f, 0xe0, 0x85, 0xce, 0x28, 0x11, 0xbd,
+	0x0d, 0x4b, 0xaa, 0x61, 0x74, 0xae, 0x46, 0xb5, 0x17, 0x98, 0xf6, 0xdb, 0x61, 0xda, 0xb7, 0xa8,
+	0xcc, 0xa8, 0x7a, 0xa4, 0x8e, 0x51, 0x51, 0x03, 0x4a, 0x86, 0x49, 0x0c, 0xd5, 0x24, 0x8a, 0x61,
+	0xea, 0x86, 0x6e, 0xa9, 0x9d, 0x72, 0x91, 0xe9, 0x7e, 0x2a, 0x4c, 0xf7, 0x11, 0xe7, 0x3f, 0x12,
+	0xec, 0xf5, 0x08, 0x2e, 0x1a, 0x7e, 0x12, 0xd7, 0xaa, 0x37, 0x89, 0x65, 0x0d, 0xb5, 0x96, 0xa6,
+	0x69, 0x65, 0xfc, 0x7e, 0xad, 0x3e, 0x12, 0xaa, 0x41, 0x96, 0x0c, 0xa8, 0xb8, 0x72, 0xa9, 0xdb,
+	0xa4, 0xbc, 0xc0, 0x14, 0xca, 0xa1, 0x27, 0x94, 0xb1, 0x9e, 0xea, 0x36, 0xa9, 0x47, 0x30, 0x10,
+	0xb7, 0x87, 0x54, 0xb8, 0x76, 0x49, 0x4c, 0xed, 0xfc, 0x8a, 0xa9, 0x51, 0xd8, 0x17, 0x4b, 0xd3,
+	0x7b, 0x65, 0xc4, 0x14, 0x3e, 0x13, 0xa6, 0xf0, 0x94, 0x09, 0x51, 0x15, 0x35, 0x47, 0xa4, 0x1e,
+	0xc1, 0x8b, 0x97, 0xe3, 0x64, 0x6a, 0x62, 0xe7, 0x5a, 0x4f, 0xed, 0x68, 0x1f, 0x10, 0xe5, 0xac,
+	0xa3, 0x37, 0x1f, 0x96, 0x17, 0x27, 0x9b, 0xd8, 0xae, 0xe0, 0xde, 0xa6, 0xcc, 0xd4, 0xc4, 0xce,
+	0xbd, 0x84, 0xed, 0x14, 0x24, 0x2e, 0xd5, 0x4e, 0x9f, 0xc8, 0x4f, 0x41, 0xd6, 0xe3, 0x80, 0x50,
+	0x19, 0x52, 0x5d, 0x62, 0x59, 0xea, 0x05, 0x61, 0xfe, 0x2a, 0x83, 0x9d, 0xae, 0x5c, 0x80, 0x9c,
+	0xd7, 0xe9, 0xc8, 0x1f, 0x4b, 0x90, 0xf5, 0xf8, 0x13, 0x2a, 0x79, 0x49, 0x4c, 0xb6, 0x6c, 0x21,
+	0x29, 0xba, 0xe8, 0x71, 0xc8, 0xb3, 0x29, 0x2b, 0xce, 0x77, 0xea, 0xd4, 0xe2, 0x38, 0xc7, 0x88,
+	0xa7, 0x82, 0x69, 0x05, 0xb2, 0xc6, 0xa6, 0xe1, 0xb2, 0xc4, 0x18, 0x0b, 0x18, 0x9b, 0x86, 0xc3,
+	0xf0, 0x18, 0xe4, 0xe8, 0xfa, 0x5c, 0x8e, 0x38, 0x1b, 0x24, 0x4b, 0x69, 0x82, 0x45, 0xfe, 0x6d,
+	0x0c, 0x4a, 0xa3, 0x8e, 0x0a, 0xdd, 0x85, 0x38, 0xf5, 0xd9, 0xc2, 0xfd, 0x56, 0xd6, 0xb9, 0x43,
+	0x5f, 0x77, 0x1c, 0xfa, 0x7a, 0xc3, 0x71, 0xe8, 0xdb, 0xe9, 0xcf, 0xbe, 0x58, 0x89, 0x7c, 0xfc,
+	0xf7, 0x15, 0x09, 0x33, 0x09, 0x74, 0x83, 0xba, 0x27, 0x55, 0xeb, 0x29, 0x5a, 0x8b, 0x4d, 0x39,
+	0x43, 0x7d, 0x8f, 0xaa, 0xf5, 0xf6, 0x5a, 0x68, 0x1f, 0x4a, 0x4d, 0xbd, 0x67, 0x91, 0x9e, 0xd5,
+	0xb7, 0x14, 0x1e, 0x30, 0xca, 0xb1, 0x71, 0xd7, 0xc9, 0xc3, 0x56, 0xd5, 0xe1, 0x3c, 0x62, 0x8c,
+	0xb8, 0xd8, 0xf4, 0x13, 0xd0, 0x2e, 0xc0, 0xa5, 0xda, 0xd1, 0x5a, 0xaa, 0xad, 0x9b, 0x56, 0x39,
+	0xbe, 0x1a, 0x5b, 0xcb, 0x6e, 0xae, 0x8e, 0xfd, 0xd8, 0x53, 0x87, 0xe5, 0xc4, 0x68, 0xa9, 0x36,
+	0xd9, 0x8e, 0xd3, 0xe9, 0x62, 0x8f, 0x24, 0x7a, 0x12, 0x8a, 0xaa, 0x61, 0x28, 0x96, 0xad, 0xda,
+	0x44, 0x39, 0xbb, 0xb2, 0x89, 0xc5, 0x1c, 0x72, 0x0e, 0xe7, 0x55, 0xc3, 0x38, 0xa6, 0xd4, 0x6d,
+	0x4a, 0x44, 0x4f, 0x40, 0x81, 0xfa, 0x6e, 0x4d, 0xed, 0x28, 0x6d, 0xa2, 0x5d, 0xb4, 0xed, 0x72,
+	0x72, 0x55, 0x5a, 0x8b, 0xe1, 0xbc, 0xa0, 0xd6, 0x19, 0xd1, 0xaf, 0x8e, 0x3b, 0x09, 0xea, 0xa5,
+	0xf3, 0x43, 0x75, 0xfc, 0xc4, 0xaf, 0x41, 0x69, 0x84, 0xcf, 0x2a, 0xa7, 0x19, 0x63, 0xc1, 0xc7,
+	0x68, 0xc9, 0x2d, 0xc8, 0x79, 0x23, 0x01, 0x42, 0x10, 0x6f, 0xa9, 0xb6, 0xca, 0xfe, 0x4d, 0x0e,
+	0xb3, 0x36, 0xa5, 0x19, 0xaa, 0xdd, 0x16, 0x3b, 0xce, 0xda, 0xe8, 0x3a, 0x24, 0xc5, 0x44, 0x63,
+	0x6c, 0xa2, 0xa2, 0x87, 0x96, 0x20, 0x61, 0x98, 0xfa, 0x25, 0x61, 0xc6, 0x90, 0xc6, 0xbc, 0x23,
+	0x63, 0x28, 0xf8, 0xa3, 0x06, 0x2a, 0x40, 0xd4, 0x1e, 0x88, 0x51, 0xa2, 0xf6, 0x00, 0x3d, 0x0f,
+	0x71, 0xfa, 0x6b, 0xd8, 0x18, 0x85, 0x80, 0x38, 0x29, 0xe4, 0x1a, 0x57, 0x06, 0xc1, 0x8c, 0x53,
+	0x2e, 0x42, 0xde, 0x17, 0x4d, 0xe4, 0xeb, 0xb0, 0x14, 0x14, 0x1c, 0xe4, 0x36, 0x2c, 0x05, 0x39,
+	0x79, 0xf4, 0x02, 0xa4, 0xdd, 0xe8, 0xc0, 0x4d, 0xf1, 0xc6, 0xd8, 0xb0, 0x0e, 0x33, 0x76, 0x59,
+	0xa9, 0x0d, 0xd2, 0xbd, 0x6d, 0xab, 0x22, 0x17, 0xc8, 0xe1, 0x94, 0x6a, 0x18, 0x75, 0xd5, 0x6a,
+	0xcb, 0xef, 0x40, 0x39, 0xcc, 0xf3, 0x7b, 0x36, 0x4c, 0x62, 0x07, 0x49, 0xf4, 0x28, 0xfd, 0x5c,
+	0x37, 0xbb, 0xaa, 0xcd, 0x94, 0xe5, 0xb1, 0xe8, 0xd1, 0x8d, 0xe4, 0x3f, 0x38, 0xc6, 0xc8, 0xbc,
+	0x23, 0x2b, 0x70, 0x23, 0xd4, 0xfb, 0x53, 0x11, 0xad, 0xd7, 0x22, 0x7c, 0x5b, 0xf3, 0x98, 0x77,
+	0x86, 0x8a, 0xf8, 0x64, 0x79, 0x87, 0x0e, 0x6b, 0xb1, 0xb5, 0x32, 0xfd, 0x19, 0x2c, 0x7a, 0xf2,
+	0x27, 0x31, 0xb8, 0x1e, 0x1c, 0x03, 0xd0, 0x2a, 0xe4, 0xba, 0xea, 0x40, 0xb1, 0x07, 0xc2, 0x90,
+	0x25, 0xf6, 0xe3, 0xa1, 0xab, 0x0e, 0x1a, 0x03, 0x6e, 0xc5, 0x25, 0x88, 0xd9, 0x03, 0xab, 0x1c,
+	0x5d, 0x8d, 0xad, 0xe5, 0x30, 0x6d, 0xa2, 0x13, 0x58, 0xe8, 0xe8, 0x4d, 0xb5, 0xa3, 0x74, 0x54,
+	0xcb, 0x56, 0x44, 0x72, 0xc0, 0x8f, 0xe5, 0xe3, 0x63, 0x9b, 0xcd, 0xbd, 0x39, 0x69, 0xf1, 0xff,
+	0x49, 0x5d, 0x98, 0x38, 0x51, 0x45, 0xa6, 0x63, 0x5f, 0x75, 0x7e, 0x35, 0xda, 0x81, 0x6c, 0x57,
+	0xb3, 0xce, 0x48, 0x5b, 0xbd, 0xd4, 0x74, 0x53, 0x9c, 0xcf, 0x71, 0xa3, 0x79, 0x30, 0xe4, 0x11,
+	0x9a, 0xbc, 0x62, 0x9e, 0x5f, 0x92, 0xf0, 0xd9, 0xb0, 0xe3, 0x9f, 0x92, 0x73, 0xfb, 0xa7, 0xe7,
+	0x61, 0xa9, 0x47, 0x06, 0xb6, 0x32, 0xf4, 0x00, 0xdc, 0x4e, 0x52, 0x6c, 0xeb, 0x11, 0xfd, 0xe6,
+	0xfa, 0x0c, 0x8b, 0x9a, 0x0c, 0x7a, 0x9a, 0x45, 0x51, 0x43, 0xb7, 0x88, 0xa9, 0xa8, 0xad, 0x96,
+	0x49, 0x2c, 0x7e, 0x52, 0x73, 0xb8, 0xe8, 0xd0, 0xb7, 0x38, 0x59, 0xfe, 0x95, 0xf7, 0xd7, 0xf8,
+	0xa3, 0xa6, 0xd8, 0x78, 0x69, 0xb8, 0xf1, 0xc7, 0xb0, 0x24, 0xe4, 0x5b, 0xbe, 0xbd, 0xe7, 0xd9,
+	0xeb, 0xa3, 0xe3, 0xe7, 0x6b, 0x74, 0xcf, 0x91, 0x23, 0x1e, 0xbe, 0xed, 0xb1, 0x6f, 0xb6, 0xed,
+	0x08, 0xe2, 0x6c, 0x53, 0xe2, 0xdc, 0xc5, 0xd0, 0xf6, 0x7f, 0xdb, 0xaf, 0x78, 0x0d, 0x16, 0xc6,
+	0x32, 0x10, 0x77, 0x5d, 0x52, 0xe0, 0xba, 0xa2, 0xde, 0x75, 0xc9, 0xbf, 0x91, 0xa0, 0x12, 0x9e,
+	0x72, 0x04, 0xaa, 0x7a, 0x06, 0x16, 0xdc, 0xb5, 0xb8, 0xf3, 0xe3, 0x67, 0xba, 0xe4, 0x7e, 0x10,
+	0x13, 0x0c, 0x75, 0xcf, 0x4f, 0x40, 0x61, 0x24, 0x21, 0xe2, 0x7f, 0x21, 0x7f, 0xe9, 0x1d, 0x5f,
+	0xfe, 0x65, 0x0c, 0x96, 0x82, 0xb2, 0x96, 0x00, 0x43, 0x7b, 0x03, 0x16, 0x5b, 0xa4, 0xa9, 0xb5,
+	0xbe, 0xa9, 0x9d, 0x2d, 0x08, 0xe9, 0x1f, 0xcc, 0x6c, 0xdc, 0xcc, 0xfe, 0x95, 0x81, 0x34, 0x26,
+	0x96, 0xa1, 0xf7, 0x2c, 0x82, 0xb6, 0x21, 0x43, 0x06, 0x4d, 0x62, 0xd8, 0x4e, 0x3e, 0x17, 0x9c,
+	0x17, 0x73, 0xee, 0x9a, 0xc3, 0x49, 0x51, 0xa1, 0x2b, 0x86, 0xee, 0x08, 0xe0, 0x1b, 0x8e, 0x61,
+	0x85, 0xb8, 0x17, 0xf9, 0xbe, 0xe8, 0x20, 0xdf, 0x58, 0x28, 0xa8, 0xe3, 0x52, 0x23, 0xd0, 0xf7,
+	0x8e, 0x80, 0xbe, 0xf1, 0x29, 0x83, 0xf9, 0xb0, 0x6f, 0xd5, 0x87, 0x7d, 0x13, 0x53, 0x96, 0x19,
+	0x02, 0x7e, 0x5f, 0x74, 0xc0, 0x6f, 0x72, 0xca, 0x8c, 0x47, 0xd0, 0xef, 0xab, 0x1e, 0xf4, 0x9b,
+	0x5e, 0x95, 0x02, 0x73, 0x3e, 0x47, 0x34, 0x00, 0xfe, 0xbe, 0xec, 0xc2, 0xdf, 0x6c, 0x28, 0x74,
+	0x16, 0xc2, 0xa3, 0xf8, 0xf7, 0x70, 0x0c, 0xff, 0x72, 0xbc, 0xfa, 0x64, 0xa8, 0x8a, 0x29, 0x00,
+	0xf8, 0x70, 0x0c, 0x00, 0xe7, 0xa7, 0x28, 0x9c, 0x82, 0x80, 0x7f, 0x1a, 0x8c, 0x80, 0xc3, 0x31,
+	0xaa, 0x98, 0xe6, 0x6c, 0x10, 0x58, 0x09, 0x81, 0xc0, 0xc5, 0x50, 0xb8, 0xc6, 0xd5, 0xcf, 0x8c,
+	0x81, 0x4f, 0x02, 0x30, 0x30, 0x47, 0xab, 0x6b, 0xa1, 0xca, 0x67, 0x00, 0xc1, 0x27, 0x01, 0x20,
+	0x78, 0x61, 0xaa, 0xda, 0xa9, 0x28, 0x78, 0xd7, 0x8f, 0x82, 0x51, 0x48, 0xc2, 0x34, 0x3c, 0xed,
+	0x21, 0x30, 0xf8, 0x2c, 0x0c, 0x06, 0x73, 0xa8, 0xfa, 0x6c, 0xa8, 0xc6, 0x39, 0x70, 0xf0, 0xe1,
+	0x18, 0x0e, 0x5e, 0x9a, 0x62, 0x69, 0xb3, 0x02, 0xe1, 0xa7, 0x61, 0xc1, 0x11, 0x71, 0xfd, 0x19,
+	0x4d, 0x67, 0x89, 0x69, 0xea, 0xa6, 0x80, 0xb4, 0xbc, 0x23, 0xaf, 0x41, 0xce, 0x65, 0x9d, 0x0c,
+	0x9a, 0x19, 0x6c, 0xf0, 0xf8, 0x2b, 0xf9, 0x4f, 0x12, 0xe4, 0xbc, 0xae, 0xc8, 0x07, 0x81, 0x32,
+	0x02, 0x02, 0x79, 0xa0, 0x74, 0xd4, 0x0f, 0xa5, 0x57, 0x20, 0x4b, 0xe1, 0xc0, 0x08, 0x4a, 0x56,
+	0x0d, 0x17, 0x25, 0xdf, 0x86, 0x05, 0x16, 0x18, 0x39, 0xe0, 0x16, 0xe1, 0x27, 0xce, 0xc2, 0x4f,
+	0x91, 0x7e, 0xe0, 0xbb, 0xc0, 0xc8, 0xe8, 0x39, 0x58, 0xf4, 0xf0, 0xba, 0x30, 0x83, 0x43, 0xc6,
+	0x92, 0xcb, 0xbd, 0x25, 0xf0, 0xc6, 0x5f, 0x24, 0x58, 0x18, 0x73, 0x85, 0x81, 0x48, 0x58, 0xfa,
+	0x8e, 0x90, 0x70, 0xf4, 0x1b, 0x23, 0x61, 0x2f, 0x6c, 0x8a, 0xf9, 0x61, 0xd3, 0x3f, 0x25, 0xc8,
+	0xfb, 0x3c, 0x32, 0xfd, 0x05, 0x4d, 0xbd, 0x45, 0x04, 0x90, 0x61, 0x6d, 0x9a, 0x7a, 0x74, 0xf4,
+	0x0b, 0x01, 0x57, 0x68, 0x93, 0x72, 0xb9, 0x01, 0x26, 0x23, 0xe2, 0x87, 0x8b, 0x81, 0x78, 0x80,
+	0xe7, 0x1d, 0x2a, 0xfb, 0x90, 0xf0, 0x70, 0x90, 0xc3, 0xb4, 0x89, 0x96, 0x84, 0x91, 0x89, 0x40,
+	0xcd, 0x3b, 0xe8, 0x2e, 0x64, 0x58, 0x9d, 0x5a, 0xd1, 0x0d, 0xab, 0x9c, 0x1e, 0x4f, 0x61, 0x78,
+	0xb1, 0x7a, 0xfd, 0x88, 0xf2, 0x1c, 0x1a, 0x16, 0x4e, 0x1b, 0xa2, 0xe5, 0xc9, 0x2c, 0x32, 0xbe,
+	0xcc, 0xe2, 0x26, 0x64, 0xe8, 0xec, 0x2d, 0x43, 0x6d, 0x12, 0x56, 0x15, 0xcd, 0xe0, 0x21, 0x41,
+	0xfe, 0xb3, 0x04, 0xc5, 0x91, 0x80, 0x12, 0xb8, 0x76, 0xc7, 0x24, 0xa3, 0x1e, 0x54, 0x7e, 0x0b,
+	0xe0, 0x42, 0xb5, 0x94, 0xf7, 0xd5, 0x9e, 0x4d, 0x5a, 0x62, 0xb9, 0x99, 0x0b, 0xd5, 0x7a, 0x93,
+	0x11, 0xfc, 0x03, 0xa7, 0x47, 0x06, 0xf6, 0xc0, 0xbf, 0x8c, 0x17, 0xfe, 0xa1, 0x0a, 0xa4, 0x0d,
+	0x53, 0xd3, 0x4d, 0xcd, 0xbe, 0x62, 0xb3, 0x8d, 0x61, 0xb7, 0x4f, 0x27, 0xd1, 0x51, 0x7b, 0x84,
+	0x45, 0xa6, 0x0c, 0x66, 0x6d, 0xf9, 0xc3, 0xe8, 0xd0, 0x02, 0x77, 0x48, 0x47, 0xbb, 0x24, 0xe6,
+	0x1c, 0x4b, 0x98, 0xed, 0x97, 0x2e, 0x07, 0x2c, 0xd4, 0x43, 0xa1, 0x73, 0xa6, 0xbd, 0xbe, 0x45,
+	0x5a, 0xa2, 0x6a, 0xe2, 0xf6, 0x51, 0x1d, 0x92, 0xe4, 0x92, 0xf4, 0x6c, 0xab, 0x9c, 0x62, 0x96,
+	0x7b, 0x7d, 0x1c, 0x74, 0xd2, 0xcf, 0xdb, 0x65, 0x6a, 0xaf, 0x5f, 0x7f, 0xb1, 0x52, 0xe2, 0xdc,
+	0xcf, 0xea, 0x5d, 0xcd, 0x26, 0x5d, 0xc3, 0xbe, 0xc2, 0x42, 0x7e, 0xf2, 0x7e, 0xca, 0x2f, 0x40,
+	0xc1, 0xd9, 0x06, 0x91, 0xc4, 0x3e, 0x0e, 0x79, 0x93, 0xd8, 0xb4, 0x56, 0xe5, 0x4b, 0xc4, 0x73,
+	0x9c, 0xc8, 0xcf, 0xbb, 0x7c, 0x04, 0xd7, 0x02, 0xe3, 0x39, 0x7a, 0x09, 0x32, 0xc3, 0x54, 0x40,
+	0x5a, 0x8d, 0x4d, 0x2e, 0x4e, 0x0c, 0x79, 0xa9, 0x45, 0x5d, 0x0b, 0x8c, 0xe8, 0xa8, 0x06, 0x49,
+	0x93, 0x58, 0xfd, 0x0e, 0x2f, 0x40, 0x14, 0x36, 0x9f, 0x9b, 0x2d, 0x13, 0xa0, 0xd4, 0x7e, 0xc7,
+	0xc6, 0x42, 0x58, 0x7e, 0x1b, 0x92, 0x9c, 0x82, 0xb2, 0x90, 0x3a, 0x39, 0xb8, 0x7f, 0x70, 0xf8,
+	0xe6, 0x41, 0x29, 0x82, 0x00, 0x92, 0x5b, 0xd5, 0x6a, 0xed, 0xa8, 0x51, 0x92, 0x50, 0x06, 0x12,
+	0x5b, 0xdb, 0x87, 0xb8, 0x51, 0x8a, 0x52, 0x32, 0xae, 0xbd, 0x5e, 0xab, 0x36, 0x4a, 0x31, 0xb4,
+	0x00, 0x79, 0xde, 0x56, 0x76, 0x0f, 0xf1, 0x83, 0xad, 0x46, 0x29, 0xee, 0x21, 0x1d, 0xd7, 0x0e,
+	0x76, 0x6a, 0xb8, 0x94, 0x90, 0xff, 0x07, 0x6e, 0x38, 0xf3, 0x18, 0x2f, 0xa2, 0xb8, 0xb5, 0x0c,
+	0xc9, 0x53, 0xcb, 0x90, 0x3f, 0x89, 0x42, 0xc5, 0x91, 0x09, 0x28, 0x8b, 0xbc, 0x3e, 0xb2, 0xf0,
+	0xcd, 0x39, 0xb2, 0x89, 0x91, 0xd5, 0x53, 0xfc, 0x64, 0x92, 0x73, 0x62, 0x37, 0xdb, 0x4e, 0x59,
+	0x8d, 0x7a, 0xc4, 0x3c, 0xce, 0x0b, 0x2a, 0x13, 0xb2, 0x38, 0xdb, 0xbb, 0xa4, 0x69, 0x2b, 0xfc,
+	0x5c, 0x59, 0x0c, 0xc4, 0x64, 0x70, 0x9e, 0x53, 0x8f, 0x39, 0x51, 0x7e, 0x67, 0xae, 0xbd, 0xcc,
+	0x40, 0x02, 0xd7, 0x1a, 0xf8, 0xad, 0x52, 0x0c, 0x21, 0x28, 0xb0, 0xa6, 0x72, 0x7c, 0xb0, 0x75,
+	0x74, 0x5c, 0x3f, 0xa4, 0x7b, 0xb9, 0x08, 0x45, 0x67, 0x2f, 0x1d, 0x62, 0x42, 0xfe, 0x6b, 0x14,
+	0x1e, 0x09, 0x49, 0x67, 0xd0, 0x5d, 0x00, 0x7b, 0xa0, 0x98, 0xa4, 0xa9, 0x9b, 0xad, 0x70, 0x23,
+	0x6b, 0x0c, 0x30, 0xe3, 0xc0, 0x19, 0x5b, 0xb4, 0xac, 0x09, 0x25, 0x30, 0xf4, 0x8a, 0x50, 0x4a,
+	0x57, 0x65, 0x09, 0xe8, 0x76, 0x2b, 0xa0, 0xd2, 0x43, 0x9a, 0x54, 0x31, 0xdb, 0xdb, 0x8c, 0x2d,
+	0x5a, 0x16, 0x7a, 0xe0, 0xc5, 0xb8, 0x7d, 0x16, 0x4a, 0x66, 0xae, 0xbe, 0x7a, 0x50, 0x30, 0x27,
+	0x58, 0xe8, 0x2d, 0x78, 0x64, 0x24, 0x12, 0xba, 0x4a, 0x13, 0xb3, 0x06, 0xc4, 0x6b, 0xfe, 0x80,
+	0x28, 0x54, 0xcb, 0xbf, 0x8b, 0x79, 0x37, 0xd6, 0x9f, 0xbd, 0x1d, 0x42, 0xd2, 0xb2, 0x55, 0xbb,
+	0x6f, 0x09, 0x83, 0x7b, 0x69, 0xd6, 0x54, 0x70, 0xdd, 0x69, 0x1c, 0x33, 0x71, 0x2c, 0xd4, 0xfc,
+	0xb0, 0xdf, 0x16, 0x75, 0xb0, 0xfe, 0xcd, 0x09, 0x3f, 0x32, 0x43, 0x9f, 0x13, 0x95, 0xef, 0x01,
+	0x1a, 0x4f, 0x92, 0x03, 0xaa, 0x20, 0x52, 0x50, 0x15, 0xe4, 0xf7, 0x12, 0x3c, 0x3a, 0x21, 0x21,
+	0x46, 0x6f, 0x8c, 0xfc, 0xe7, 0x97, 0xe7, 0x49, 0xa7, 0xd7, 0x39, 0xcd, 0xff, 0xa7, 0xe5, 0x3b,
+	0x90, 0xf3, 0xd2, 0x67, 0x5b, 0xe4, 0xd7, 0x51, 0xb8, 0x16, 0x98, 0x5b, 0x7b, 0xc2, 0x9f, 0xf4,
+	0x2d, 0xc3, 0x9f, 0xdf, 0xce, 0xa2, 0x73, 0xda, 0xd9, 0x71, 0x90, 0x9d, 0xc5, 0xe6, 0xca, 0x25,
+	0xe7, 0xb2, 0xb6, 0xf8, 0xb7, 0xb3, 0x36, 0xdf, 0x81, 0x4b, 0xf8, 0x93, 0xd5, 0xb7, 0x00, 0x86,
+	0x35, 0x2c, 0x1a, 0x90, 0x4c, 0xbd, 0xdf, 0x6b, 0x31, 0x0b, 0x48, 0x60, 0xde, 0xa1, 0x97, 0xef,
+	0xd4, 0x92, 0x9c, 0x7d, 0x1a, 0x77, 0xaa, 0xd4, 0x12, 0x3c, 0x35, 0x30, 0xce, 0x2d, 0x6b, 0x80,
+	0xc6, 0x4b, 0xe0, 0x21, 0x43, 0xbc, 0xea, 0x1f, 0xe2, 0xb1, 0xd0, 0x62, 0x7a, 0xf0, 0x50, 0x1f,
+	0x40, 0x82, 0xfd, 0x79, 0x9a, 0x70, 0xb1, 0x7b, 0x17, 0x01, 0x76, 0x68, 0x1b, 0xfd, 0x0c, 0x40,
+	0xb5, 0x6d, 0x53, 0x3b, 0xeb, 0x0f, 0x07, 0x58, 0x09, 0xb6, 0x9c, 0x2d, 0x87, 0x6f, 0xfb, 0xa6,
+	0x30, 0xa1, 0xa5, 0xa1, 0xa8, 0xc7, 0x8c, 0x3c, 0x0a, 0xe5, 0x03, 0x28, 0xf8, 0x65, 0x9d, 0xf4,
+	0x9c, 0xcf, 0xc1, 0x9f, 0x9e, 0x73, 0xb4, 0xc5, 0x3b, 0xc3, 0xe4, 0x3e, 0xc6, 0x2f, 0x97, 0x58,
+	0x47, 0xfe, 0xb7, 0x04, 0x39, 0xaf, 0xe1, 0x7d, 0xc7, 0xe9, 0xe7, 0x94, 0x3c, 0xfb, 0xc6, 0x58,
+	0xf6, 0x99, 0xba, 0x50, 0xad, 0x93, 0xef, 0x33, 0xf9, 0xfc, 0x50, 0x82, 0xb4, 0xbb, 0x78, 0xff,
+	0x3d, 0x93, 0xef, 0x62, 0x8e, 0xef, 0x5d, 0xd4, 0x7b, 0x39, 0xc4, 0xaf, 0xe1, 0x62, 0xee, 0x35,
+	0xdc, 0x3d, 0x37, 0x57, 0x0a, 0xab, 0xda, 0x79, 0x77, 0x5a, 0xd8, 0x94, 0x93, 0x1a, 0xfe, 0x5a,
+	0xcc, 0x83, 0x26, 0x09, 0xe8, 0xff, 0x20, 0xa9, 0x36, 0xdd, 0x5a, 0x65, 0x21, 0xa0, 0x88, 0xe7,
+	0xb0, 0xae, 0x37, 0x06, 0x5b, 0x8c, 0x13, 0x0b, 0x09, 0x31, 0xab, 0xa8, 0x33, 0x2b, 0xf9, 0x35,
+	0x48, 0x3b, 0x3c, 0x7e, 0x8f, 0x58, 0x00, 0x38, 0x39, 0x78, 0x70, 0xb8, 0xb3, 0xb7, 0xbb, 0x57,
+	0xdb, 0x11, 0xd9, 0xd2, 0xce, 0x4e, 0x6d, 0xa7, 0x14, 0xa5, 0x7c, 0xb8, 0xf6, 0xe0, 0xf0, 0xb4,
+	0xb6, 0x53, 0x8a, 0xc9, 0xf7, 0x20, 0xe3, 0x7a, 0x15, 0x8a, 0xe5, 0x9d, 0xba, 0xab, 0x24, 0xce,
+	0x36, 0xef, 0xb2, 0xcb, 0x4b, 0xfd, 0x7d, 0x71, 0x27, 0x16, 0xc3, 0xbc, 0x23, 0xb7, 0xa0, 0x38,
+	0xe2, 0x92, 0xd0, 0x3d, 0x48, 0x19, 0xfd, 0x33, 0xc5, 0x31, 0xda, 0x91, 0xea, 0xb4, 0x83, 0x12,
+	0xfb, 0x67, 0x1d, 0xad, 0x79, 0x9f, 0x5c, 0x39, 0xdb, 0x64, 0xf4, 0xcf, 0xee, 0x73, 0xdb, 0xe6,
+	0xa3, 0x44, 0xbd, 0xa3, 0x5c, 0x42, 0xda, 0x39, 0xaa, 0xe8, 0xff, 0x21, 0xe3, 0x7a, 0x3b, 0xf7,
+	0x96, 0x3c, 0xd4, 0x4d, 0x0a, 0xf5, 0x43, 0x11, 0x5a, 0x72, 0xb0, 0xb4, 0x8b, 0x9e, 0x53, 0x92,
+	0xe7, 0x55, 0x99, 0x28, 0x3b, 0x33, 0x45, 0xfe, 0x61, 0xdf, 0x29, 0x25, 0xd0, 0x20, 0x57, 0x1a,
+	0xf5, 0x15, 0xdf, 0xe7, 0x04, 0x02, 0x82, 0x71, 0x2c, 0x28, 0x18, 0xff, 0x22, 0x0a, 0x59, 0x4f,
+	0xc5, 0x1f, 0xfd, 0xaf, 0xc7, 0x71, 0x15, 0x02, 0xa2, 0x88, 0x87, 0x77, 0x78, 0x69, 0xec, 0x5f,
+	0x58, 0x74, 0xfe, 0x85, 0x85, 0xdd, 0xab, 0x38, 0x17, 0x08, 0xf1, 0xb9, 0x2f, 0x10, 0x9e, 0x05,
+	0x64, 0xeb, 0xb6, 0xda, 0xa1, 0x15, 0x3a, 0xad, 0x77, 0xa1, 0x70, 0xd3, 0xe0, 0x6e, 0xa6, 0xc4,
+	0xbe, 0x9c, 0xb2, 0x0f, 0x47, 0xcc, 0x4a, 0x7e, 0x2e, 0x41, 0xda, 0x45, 0x74, 0xf3, 0x5e, 0x29,
+	0x5f, 0x87, 0xa4, 0x00, 0x2d, 0xfc, 0x4e, 0x59, 0xf4, 0x02, 0x6f, 0x4a, 0x2a, 0x90, 0xee, 0x12,
+	0x5b, 0x65, 0x3e, 0x93, 0x47, 0x40, 0xb7, 0x7f, 0xfb, 0x65, 0xc8, 0x7a, 0xae, 0xe3, 0xa9, 0x1b,
+	0x3d, 0xa8, 0xbd, 0x59, 0x8a, 0x54, 0x52, 0x1f, 0x7d, 0xba, 0x1a, 0x3b, 0x20, 0xef, 0xd3, 0x13,
+	0x86, 0x6b, 0xd5, 0x7a, 0xad, 0x7a, 0xbf, 0x24, 0x55, 0xb2, 0x1f, 0x7d, 0xba, 0x9a, 0xc2, 0x84,
+	0x15, 0xc9, 0x6f, 0xdf, 0x87, 0xe2, 0xc8, 0x8f, 0xf1, 0x1f, 0x68, 0x04, 0x85, 0x9d, 0x93, 0xa3,
+	0xfd, 0xbd, 0xea, 0x56, 0xa3, 0xa6, 0x9c, 0x1e, 0x36, 0x6a, 0x25, 0x09, 0x3d, 0x02, 0x8b, 0xfb,
+	0x7b, 0x3f, 0xaa, 0x37, 0x94, 0xea, 0xfe, 0x5e, 0xed, 0xa0, 0xa1, 0x6c, 0x35, 0x1a, 0x5b, 0xd5,
+	0xfb, 0xa5, 0xe8, 0xe6, 0x1f, 0xb3, 0x50, 0xdc, 0xda, 0xae, 0xee, 0x51, 0xd8, 0xa6, 0x35, 0x55,
+	0xe6, 0x1e, 0xaa, 0x10, 0x67, 0x05, 0xc0, 0x89, 0x8f, 0xfa, 0x2a, 0x93, 0x6f, 0x3e, 0xd0, 0x2e,
+	0x24, 0x58, 0x6d, 0x10, 0x4d, 0x7e, 0xe5, 0x57, 0x99, 0x72, 0x15, 0x42, 0x27, 0xc3, 0x8e, 0xd3,
+	0xc4, 0x67, 0x7f, 0x95, 0xc9, 0x37, 0x23, 0x68, 0x1f, 0x52, 0x4e, 0x69, 0x68, 0xda, 0x5b, 0xbc,
+	0xca, 0xd4, 0xeb, 0x0a, 0xba, 0x34, 0x5e, 0x62, 0x9b, 0xfc, 0x22, 0xb0, 0x32, 0xe5, 0xce, 0x04,
+	0xed, 0x41, 0x52, 0x14, 0x3a, 0xa6, 0x3c, 0xf2, 0xab, 0x4c, 0xbb, 0x05, 0x41, 0x18, 0x32, 0xc3,
+	0xe2, 0xe5, 0xf4, 0x77, 0x8e, 0x95, 0x19, 0xae, 0x83, 0xd0, 0xdb, 0x90, 0xf7, 0x17, 0x54, 0x66,
+	0x7b, 0x48, 0x58, 0x99, 0xf1, 0xbe, 0x85, 0xea, 0xf7, 0x57, 0x57, 0x66, 0x7b, 0x58, 0x58, 0x99,
+	0xf1, 0xfa, 0x05, 0xbd, 0x0b, 0x0b, 0xe3, 0xd5, 0x8f, 0xd9, 0xdf, 0x19, 0x56, 0xe6, 0xb8, 0x90,
+	0x41, 0x5d, 0x40, 0x01, 0x55, 0x93, 0x39, 0x9e, 0x1d, 0x56, 0xe6, 0xb9, 0x9f, 0x41, 0x2d, 0x28,
+	0x8e, 0x56, 0x22, 0x66, 0x7d, 0x86, 0x58, 0x99, 0xf9, 0xae, 0x86, 0x8f, 0xe2, 0x87, 0xe5, 0xb3,
+	0x3e, 0x4b, 0xac, 0xcc, 0x7c, 0x75, 0x83, 0x4e, 0x00, 0x3c, 0xb0, 0x72, 0x86, 0x67, 0x8a, 0x95,
+	0x59, 0x2e, 0x71, 0x90, 0x01, 0x8b, 0x41, 0x78, 0x73, 0x9e, 0x57, 0x8b, 0x95, 0xb9, 0xee, 0x76,
+	0xa8, 0x3d, 0xfb, 0x91, 0xe3, 0x6c, 0xaf, 0x18, 0x2b, 0x33, 0x5e, 0xf2, 0x6c, 0xd7, 0x3e, 0xfb,
+	0x72, 0x59, 0xfa, 0xfc, 0xcb, 0x65, 0xe9, 0x1f, 0x5f, 0x2e, 0x4b, 0x1f, 0x7f, 0xb5, 0x1c, 0xf9,
+	0xfc, 0xab, 0xe5, 0xc8, 0xdf, 0xbe, 0x5a, 0x8e, 0xfc, 0xf8, 0x99, 0x0b, 0xcd, 0x6e, 0xf7, 0xcf,
+	0xd6, 0x9b, 0x7a, 0x77, 0xc3, 0xfb, 0xf0, 0x3b, 0xe8, 0xb9, 0xf9, 0x59, 0x92, 0x05, 0xd4, 0x3b,
+	0xff, 0x19, 0x00, 0xa4, 0x55, 0xf3, 0x26, 0x8e, 0x2e, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -5151,6 +5177,16 @@ func (m *RequestInitChain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AppStateChunks != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppStateChunks))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.AppStateChunk != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppStateChunk))
+		i--
+		dAtA[i] = 0x38
+	}
 	if m.InitialHeight != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.InitialHeight))
 		i--
@@ -6511,6 +6547,13 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Lane) > 0 {
+		i -= len(m.Lane)
+		copy(dAtA[i:], m.Lane)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Lane)))
+		i--
+		dAtA[i] = 0x62
+	}
 	if m.Priority != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
 		i--
@@ -8012,6 +8055,12 @@ func (m *RequestInitChain) Size() (n int) {
 	if m.InitialHeight != 0 {
 		n += 1 + sovTypes(uint64(m.InitialHeight))
 	}
+	if m.AppStateChunk != 0 {
+		n += 1 + sovTypes(uint64(m.AppStateChunk))
+	}
+	if m.AppStateChunks != 0 {
+		n += 1 + sovTypes(uint64(m.AppStateChunks))
+	}
 	return n
 }
 
@@ -8656,6 +8705,10 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if m.Priority != 0 {
 		n += 1 + sovTypes(uint64(m.Priority))
 	}
+	l = len(m.Lane)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -10282,6 +10335,44 @@ func (m *RequestInitChain) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppStateChunk", wireType)
+			}
+			m.AppStateChunk = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppStateChunk |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppStateChunks", wireType)
+			}
+			m.AppStateChunks = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppStateChunks |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13881,6 +13972,38 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lane", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lane = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1,71 +1,78 @@
 package commands
 
 import (
-	"errors"
-	"path/filepath"
+	"fmt"
 	"sync"
 
 	"github.com/spf13/cobra"
-	"github.com/syndtr/goleveldb/leveldb"
-	"github.com/syndtr/goleveldb/leveldb/opt"
-	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/store/compact"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
 func MakeCompactDBCommand(cfg *config.Config, logger log.Logger) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "experimental-compact-goleveldb",
-		Short: "force compacts the tendermint storage engine (only GoLevelDB supported)",
+		Use:     "compact-db",
+		Aliases: []string{"experimental-compact-goleveldb"},
+		Short:   "force compacts the tendermint storage engine",
 		Long: `
-This is a temporary utility command that performs a force compaction on the state 
-and blockstores to reduce disk space for a pruning node. This should only be run 
-once the node has stopped. This command will likely be omitted in the future after
-the planned refactor to the storage engine.
+Performs a force compaction on the state and block stores, to reclaim the disk
+space of the heights removed by pruning. This should only be run once the node
+has stopped. To compact the stores of a running node after pruning, set
+storage.compact in the configuration instead.
 
-Currently, only GoLevelDB is supported.
+Only the goleveldb database backend, and rocksdb and cleveldb when built in,
+support compaction.
 	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if cfg.DBBackend != "goleveldb" {
-				return errors.New("compaction is currently only supported with goleveldb")
-			}
-
-			compactGoLevelDBs(cfg.RootDir, logger)
-			return nil
+			return compactDBs(cfg, logger)
 		},
 	}
 
 	return cmd
 }
 
-func compactGoLevelDBs(rootDir string, logger log.Logger) {
+func compactDBs(cfg *config.Config, logger log.Logger) error {
 	dbNames := []string{"state", "blockstore"}
-	o := &opt.Options{
-		DisableSeeksCompaction: true,
+	dbs := make([]dbm.DB, 0, len(dbNames))
+	defer func() {
+		for _, db := range dbs {
+			db.Close()
+		}
+	}()
+	for _, dbName := range dbNames {
+		db, err := config.DefaultDBProvider(&config.DBContext{ID: dbName, Config: cfg})
+		if err != nil {
+			return fmt.Errorf("failed to initialize %s db: %w", dbName, err)
+		}
+		dbs = append(dbs, db)
+		if !compact.Supported(db) {
+			return fmt.Errorf("compaction is not supported by the %s database backend", cfg.DBBackend)
+		}
 	}
-	wg := sync.WaitGroup{}
 
-	for _, dbName := range dbNames {
-		dbName := dbName
+	wg := sync.WaitGroup{}
+	errs := make([]error, len(dbs))
+	for i, db := range dbs {
+		i, db := i, db
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			dbPath := filepath.Join(rootDir, "data", dbName+".db")
-			store, err := leveldb.OpenFile(dbPath, o)
-			if err != nil {
-				logger.Error("failed to initialize tendermint db", "path", dbPath, "err", err)
-				return
-			}
-			defer store.Close()
-
-			logger.Info("starting compaction...", "db", dbPath)
-
-			err = store.CompactRange(util.Range{Start: nil, Limit: nil})
-			if err != nil {
-				logger.Error("failed to compact tendermint db", "path", dbPath, "err", err)
+			logger.Info("starting compaction...", "db", dbNames[i])
+			if err := compact.Range(db, nil, nil); err != nil {
+				errs[i] = fmt.Errorf("failed to compact %s db: %w", dbNames[i], err)
 			}
 		}()
 	}
 	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	logger.Info("compacted databases")
+	return nil
 }
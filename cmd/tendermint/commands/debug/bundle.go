@@ -0,0 +1,161 @@
+package debug
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/libs/log"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/version"
+)
+
+const manifestFile = "manifest.json"
+
+// bundleArgs are the sources and limits of the debugging data of a bundle.
+type bundleArgs struct {
+	conf        *config.Config
+	home        string
+	profAddr    string
+	walSegments int
+	logBytes    int64
+	rpcTimeout  time.Duration
+}
+
+// bundleManifest describes the contents of a bundle: when and by which
+// version it was collected, the files with their checksums, and the data
+// that could not be collected.
+type bundleManifest struct {
+	Version   string       `json:"version"`
+	CreatedAt time.Time    `json:"created_at"`
+	Files     []bundleFile `json:"files"`
+	Errors    []string     `json:"errors,omitempty"`
+}
+
+type bundleFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// parseBundleArgs reads the bundle flags of the debug command and the config
+// of the node in the home directory.
+func parseBundleArgs(cmd *cobra.Command) (bundleArgs, error) {
+	var args bundleArgs
+	var err error
+	if args.profAddr, err = cmd.Flags().GetString(flagProfAddr); err != nil {
+		return args, fmt.Errorf("flag %q not defined: %w", flagProfAddr, err)
+	}
+	if args.walSegments, err = cmd.Flags().GetInt(flagWALSegments); err != nil {
+		return args, fmt.Errorf("flag %q not defined: %w", flagWALSegments, err)
+	}
+	if args.logBytes, err = cmd.Flags().GetInt64(flagLogBytes); err != nil {
+		return args, fmt.Errorf("flag %q not defined: %w", flagLogBytes, err)
+	}
+	if args.rpcTimeout, err = cmd.Flags().GetDuration(flagRPCTimeout); err != nil {
+		return args, fmt.Errorf("flag %q not defined: %w", flagRPCTimeout, err)
+	}
+	if args.walSegments < 0 || args.logBytes < 0 || args.rpcTimeout < 0 {
+		return args, fmt.Errorf("%s, %s and %s can't be negative", flagWALSegments, flagLogBytes, flagRPCTimeout)
+	}
+
+	args.home = viper.GetString(cli.HomeFlag)
+	args.conf = config.DefaultConfig()
+	if err := viper.Unmarshal(args.conf); err != nil {
+		return args, fmt.Errorf("failed to read config: %w", err)
+	}
+	args.conf = args.conf.SetRoot(args.home)
+	config.EnsureRoot(args.conf.RootDir)
+	return args, nil
+}
+
+// collectBundle writes the debugging data of the node to dir. It collects as
+// much of the data as it can, as a node being debugged may not answer all the
+// requests, and returns the errors of the data it failed to collect.
+func collectBundle(ctx context.Context, logger log.Logger, rpc *rpchttp.HTTP, dir string, args bundleArgs) []string {
+	var errs []string
+	collect := func(what string, f func() error) {
+		logger.Info(fmt.Sprintf("getting node %s...", what))
+		if err := f(); err != nil {
+			logger.Error(fmt.Sprintf("failed to get node %s", what), "error", err)
+			errs = append(errs, fmt.Sprintf("%s: %v", what, err))
+		}
+	}
+	withTimeout := func(f func(context.Context) error) func() error {
+		return func() error {
+			ctx := ctx
+			if args.rpcTimeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, args.rpcTimeout)
+				defer cancel()
+			}
+			return f(ctx)
+		}
+	}
+
+	collect("status", withTimeout(func(ctx context.Context) error {
+		return dumpStatus(ctx, rpc, dir, "status.json")
+	}))
+	collect("network info", withTimeout(func(ctx context.Context) error {
+		return dumpNetInfo(ctx, rpc, dir, "net_info.json")
+	}))
+	collect("consensus state", withTimeout(func(ctx context.Context) error {
+		return dumpConsensusState(ctx, rpc, dir, "consensus_state.json")
+	}))
+	if args.profAddr != "" {
+		collect("goroutine profile", func() error { return dumpProfile(dir, args.profAddr, "goroutine", 2) })
+		collect("heap profile", func() error { return dumpProfile(dir, args.profAddr, "heap", 2) })
+	}
+	collect("WAL", func() error { return copyWALSegments(args.conf, dir, args.walSegments) })
+	collect("configuration", func() error { return copyRedactedConfig(args.home, dir) })
+	if path := args.conf.LogFilePath(); path != "" && args.logBytes > 0 {
+		collect("logs", func() error { return copyFileTail(path, dir, args.logBytes) })
+	}
+	return errs
+}
+
+// writeManifest writes the manifest of the files in dir, collected at
+// createdAt with the errors.
+func writeManifest(dir string, createdAt time.Time, errs []string) error {
+	manifest := bundleManifest{
+		Version:   version.TMVersion,
+		CreatedAt: createdAt,
+		Files:     []bundleFile{},
+		Errors:    errs,
+	}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		manifest.Files = append(manifest.Files, bundleFile{
+			Name:   filepath.ToSlash(name),
+			Size:   info.Size(),
+			SHA256: hex.EncodeToString(sum[:]),
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the bundle files: %w", err)
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool { return manifest.Files[i].Name < manifest.Files[j].Name })
+	return writeStateJSONToFile(manifest, dir, manifestFile)
+}
@@ -1,6 +1,8 @@
 package debug
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -10,12 +12,15 @@ const (
 	flagNodeRPCAddr = "rpc-laddr"
 	flagProfAddr    = "pprof-laddr"
 	flagFrequency   = "frequency"
+	flagWALSegments = "wal-segments"
+	flagLogBytes    = "log-bytes"
+	flagRPCTimeout  = "rpc-timeout"
 )
 
 func GetDebugCommand(logger log.Logger) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "debug",
-		Short: "A utility to kill or watch a Tendermint process while aggregating debugging data",
+		Short: "A utility to kill or watch a Tendermint process while aggregating debugging data, or replay its consensus messages",
 	}
 	cmd.PersistentFlags().SortFlags = true
 	cmd.PersistentFlags().String(
@@ -23,9 +28,30 @@ func GetDebugCommand(logger log.Logger) *cobra.Command {
 		"tcp://localhost:26657",
 		"the Tendermint node's RPC address <host>:<port>)",
 	)
+	cmd.PersistentFlags().String(
+		flagProfAddr,
+		"",
+		"the profiling server address (<host>:<port>)",
+	)
+	cmd.PersistentFlags().Int(
+		flagWALSegments,
+		2,
+		"the number of the most recent rotated WAL segments to collect, besides the head of the WAL",
+	)
+	cmd.PersistentFlags().Int64(
+		flagLogBytes,
+		10*1024*1024,
+		"the number of bytes of the most recent logs of the log-file of the node to collect, 0 to collect none",
+	)
+	cmd.PersistentFlags().Duration(
+		flagRPCTimeout,
+		10*time.Second,
+		"the timeout of each RPC request to the node, 0 for none",
+	)
 
 	cmd.AddCommand(getKillCmd(logger))
 	cmd.AddCommand(getDumpCmd(logger))
+	cmd.AddCommand(getReplayConsensusCmd(logger))
 	return cmd
 
 }
@@ -9,10 +9,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
-	"github.com/tendermint/tendermint/config"
-	"github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/libs/log"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 )
@@ -22,9 +19,11 @@ func getDumpCmd(logger log.Logger) *cobra.Command {
 		Use:   "dump [output-directory]",
 		Short: "Continuously poll a Tendermint process and dump debugging data into a single location",
 		Long: `Continuously poll a Tendermint process and dump debugging data into a single
-location at a specified frequency. At each frequency interval, an archived and compressed
-file will contain node debugging information including the goroutine and heap profiles
-if enabled.`,
+location at a specified frequency. At each frequency interval, a compressed
+tarball will contain node debugging information including the consensus and
+networking state, the goroutine and heap profiles if enabled, the recent WAL
+segments and logs, the configuration with its secrets redacted and a manifest
+of the files.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			outDir := args[0]
@@ -45,9 +44,9 @@ if enabled.`,
 				return fmt.Errorf("flag %q not defined: %w", flagNodeRPCAddr, err)
 			}
 
-			profAddr, err := cmd.Flags().GetString(flagProfAddr)
+			bundle, err := parseBundleArgs(cmd)
 			if err != nil {
-				return fmt.Errorf("flag %q not defined: %w", flagProfAddr, err)
+				return err
 			}
 
 			if _, err := os.Stat(outDir); os.IsNotExist(err) {
@@ -63,15 +62,9 @@ if enabled.`,
 
 			ctx := cmd.Context()
 
-			home := viper.GetString(cli.HomeFlag)
-			conf := config.DefaultConfig()
-			conf = conf.SetRoot(home)
-			config.EnsureRoot(conf.RootDir)
-
 			dumpArgs := dumpDebugDataArgs{
-				conf:     conf,
-				outDir:   outDir,
-				profAddr: profAddr,
+				bundle: bundle,
+				outDir: outDir,
 			}
 			dumpDebugData(ctx, logger, rpc, dumpArgs)
 
@@ -89,20 +82,13 @@ if enabled.`,
 		"the frequency (seconds) in which to poll, aggregate and dump Tendermint debug data",
 	)
 
-	cmd.Flags().String(
-		flagProfAddr,
-		"",
-		"the profiling server address (<host>:<port>)",
-	)
-
 	return cmd
 
 }
 
 type dumpDebugDataArgs struct {
-	conf     *config.Config
-	outDir   string
-	profAddr string
+	bundle bundleArgs
+	outDir string
 }
 
 func dumpDebugData(ctx context.Context, logger log.Logger, rpc *rpchttp.HTTP, args dumpDebugDataArgs) {
@@ -115,46 +101,14 @@ func dumpDebugData(ctx context.Context, logger log.Logger, rpc *rpchttp.HTTP, ar
 	}
 	defer os.RemoveAll(tmpDir)
 
-	logger.Info("getting node status...")
-	if err := dumpStatus(ctx, rpc, tmpDir, "status.json"); err != nil {
-		logger.Error("failed to dump node status", "error", err)
-		return
-	}
-
-	logger.Info("getting node network info...")
-	if err := dumpNetInfo(ctx, rpc, tmpDir, "net_info.json"); err != nil {
-		logger.Error("failed to dump node network info", "error", err)
-		return
-	}
-
-	logger.Info("getting node consensus state...")
-	if err := dumpConsensusState(ctx, rpc, tmpDir, "consensus_state.json"); err != nil {
-		logger.Error("failed to dump node consensus state", "error", err)
+	errs := collectBundle(ctx, logger, rpc, tmpDir, args.bundle)
+	if err := writeManifest(tmpDir, start, errs); err != nil {
+		logger.Error("failed to write the manifest", "error", err)
 		return
 	}
 
-	logger.Info("copying node WAL...")
-	if err := copyWAL(args.conf, tmpDir); err != nil {
-		logger.Error("failed to copy node WAL", "error", err)
-		return
-	}
-
-	if args.profAddr != "" {
-		logger.Info("getting node goroutine profile...")
-		if err := dumpProfile(tmpDir, args.profAddr, "goroutine", 2); err != nil {
-			logger.Error("failed to dump goroutine profile", "error", err)
-			return
-		}
-
-		logger.Info("getting node heap profile...")
-		if err := dumpProfile(tmpDir, args.profAddr, "heap", 2); err != nil {
-			logger.Error("failed to dump heap profile", "error", err)
-			return
-		}
-	}
-
-	outFile := filepath.Join(args.outDir, fmt.Sprintf("%s.zip", start.Format(time.RFC3339)))
-	if err := zipDir(tmpDir, outFile); err != nil {
+	outFile := filepath.Join(args.outDir, fmt.Sprintf("%s.tar.gz", start.Format(time.RFC3339)))
+	if err := tarDir(tmpDir, outFile, start); err != nil {
 		logger.Error("failed to create and compress archive", "file", outFile, "error", err)
 	}
 }
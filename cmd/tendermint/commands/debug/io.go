@@ -1,7 +1,8 @@
 package debug
 
 import (
-	"archive/zip"
+	"archive/tar"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,65 +10,75 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
-// zipDir zips all the contents found in src, including both files and
-// directories, into a destination file dest. It returns an error upon failure.
-// It assumes src is a directory.
-func zipDir(src, dest string) error {
-	zipFile, err := os.Create(dest)
+// tarDir archives all the contents found in src, including both files and
+// directories, into a gzip compressed tarball dest. The archive is
+// deterministic for the same contents: the entries are in lexical order, have
+// the modification time modTime and carry no owners. It returns an error upon
+// failure. It assumes src is a directory.
+func tarDir(src, dest string, modTime time.Time) error {
+	tarFile, err := os.Create(dest)
 	if err != nil {
 		return err
 	}
-	defer zipFile.Close()
+	defer tarFile.Close()
 
-	zipWriter := zip.NewWriter(zipFile)
-	defer zipWriter.Close()
+	gzipWriter := gzip.NewWriter(tarFile)
+	tarWriter := tar.NewWriter(gzipWriter)
 
-	dirName := filepath.Base(dest)
-	baseDir := strings.TrimSuffix(dirName, filepath.Ext(dirName))
+	// Each execution of this utility on a Tendermint process will result in a
+	// unique directory in the archive.
+	baseDir := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(dest), ".gz"), ".tar")
+	modTime = modTime.UTC().Truncate(time.Second)
 
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		header, err := zip.FileInfoHeader(info)
+		name, err := filepath.Rel(src, path)
 		if err != nil {
 			return err
 		}
-
-		// Each execution of this utility on a Tendermint process will result in a
-		// unique file.
-		header.Name = filepath.Join(baseDir, strings.TrimPrefix(path, src))
-
-		// Handle cases where the content to be zipped is a file or a directory,
-		// where a directory must have a '/' suffix.
+		header := &tar.Header{
+			Name:    filepath.ToSlash(filepath.Join(baseDir, name)),
+			ModTime: modTime,
+			Format:  tar.FormatPAX,
+		}
 		if info.IsDir() {
+			header.Typeflag = tar.TypeDir
 			header.Name += "/"
-		} else {
-			header.Method = zip.Deflate
+			header.Mode = 0755
+			return tarWriter.WriteHeader(header)
 		}
-
-		headerWriter, err := zipWriter.CreateHeader(header)
-		if err != nil {
+		header.Typeflag = tar.TypeReg
+		header.Mode = 0644
+		header.Size = info.Size()
+		if err := tarWriter.WriteHeader(header); err != nil {
 			return err
 		}
 
-		if info.IsDir() {
-			return nil
-		}
-
 		file, err := os.Open(path)
 		if err != nil {
 			return err
 		}
 		defer file.Close()
 
-		_, err = io.Copy(headerWriter, file)
+		_, err = io.Copy(tarWriter, file)
 		return err
 	})
-
+	if err != nil {
+		return err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	return tarFile.Close()
 }
 
 // copyFile copies a file from src to dest and returns an error upon failure. The
@@ -11,10 +11,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
-	"github.com/tendermint/tendermint/config"
-	"github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/libs/log"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 )
@@ -25,14 +22,20 @@ func getKillCmd(logger log.Logger) *cobra.Command {
 		Short: "Kill a Tendermint process while aggregating and packaging debugging data",
 		Long: `Kill a Tendermint process while also aggregating Tendermint process data
 such as the latest node state, including consensus and networking state,
-go-routine state, and the node's WAL and config information. This aggregated data
-is packaged into a compressed archive.
+go-routine state, the goroutine and heap profiles if enabled, the recent WAL
+segments and logs, and the node's config with its secrets redacted. This
+aggregated data is packaged into a compressed tarball with a manifest of the
+files.
+
+The data the node fails to return, e.g. as it is wedged, is listed in the
+errors of the manifest, and the process is killed regardless.
 
 Example:
-$ tendermint debug kill 34255 /path/to/tm-debug.zip`,
+$ tendermint debug kill 34255 /path/to/tm-debug.tar.gz`,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
+			start := time.Now().UTC()
 			// Using Atoi so that the size of an integer can be automatically inferred.
 			pid, err := strconv.Atoi(args[0])
 			if err != nil {
@@ -51,16 +54,16 @@ $ tendermint debug kill 34255 /path/to/tm-debug.zip`,
 				return fmt.Errorf("flag %q not defined: %w", flagNodeRPCAddr, err)
 			}
 
+			bundle, err := parseBundleArgs(cmd)
+			if err != nil {
+				return err
+			}
+
 			rpc, err := rpchttp.New(nodeRPCAddr)
 			if err != nil {
 				return fmt.Errorf("failed to create new http client: %w", err)
 			}
 
-			home := viper.GetString(cli.HomeFlag)
-			conf := config.DefaultConfig()
-			conf = conf.SetRoot(home)
-			config.EnsureRoot(conf.RootDir)
-
 			// Create a temporary directory which will contain all the state dumps and
 			// relevant files and directories that will be compressed into a file.
 			tmpDir, err := os.MkdirTemp(os.TempDir(), "tendermint_debug_tmp")
@@ -69,42 +72,19 @@ $ tendermint debug kill 34255 /path/to/tm-debug.zip`,
 			}
 			defer os.RemoveAll(tmpDir)
 
-			logger.Info("getting node status...")
-			if err := dumpStatus(ctx, rpc, tmpDir, "status.json"); err != nil {
-				return err
-			}
-
-			logger.Info("getting node network info...")
-			if err := dumpNetInfo(ctx, rpc, tmpDir, "net_info.json"); err != nil {
-				return err
-			}
+			errs := collectBundle(ctx, logger, rpc, tmpDir, bundle)
 
-			logger.Info("getting node consensus state...")
-			if err := dumpConsensusState(ctx, rpc, tmpDir, "consensus_state.json"); err != nil {
-				return err
-			}
-
-			logger.Info("copying node WAL...")
-			if err := copyWAL(conf, tmpDir); err != nil {
-				if !os.IsNotExist(err) {
-					return err
-				}
-
-				logger.Info("node WAL does not exist; continuing...")
-			}
-
-			logger.Info("copying node configuration...")
-			if err := copyConfig(home, tmpDir); err != nil {
+			logger.Info("killing Tendermint process")
+			if err := killProc(pid, tmpDir); err != nil {
 				return err
 			}
 
-			logger.Info("killing Tendermint process")
-			if err := killProc(pid, tmpDir); err != nil {
+			if err := writeManifest(tmpDir, start, errs); err != nil {
 				return err
 			}
 
 			logger.Info("archiving and compressing debug directory...")
-			return zipDir(tmpDir, outFile)
+			return tarDir(tmpDir, outFile, start)
 		},
 	}
 
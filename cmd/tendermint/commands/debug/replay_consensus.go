@@ -0,0 +1,156 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/consensus/simulation"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
+)
+
+const (
+	flagSeed              = "seed"
+	flagDropRate          = "drop-rate"
+	flagMaxDelay          = "max-delay"
+	flagByzantineVoteRate = "byzantine-vote-rate"
+	flagRotateIsolated    = "rotate-isolated"
+	flagKeys              = "keys"
+	flagChainID           = "chain-id"
+)
+
+func getReplayConsensusCmd(logger log.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay-consensus [wal-file] [output-file]",
+		Short: "Replay the consensus messages of a WAL file through a simulated network with faults",
+		Long: `Replay the consensus messages of a WAL file through a simulated network,
+injecting faults into the messages received from peers: drops, delays and
+conflicting votes. The messages as received through the network are written
+to the output file as a WAL, which the replay and replay-console commands can
+replay in place of the WAL of a node. The command only rewrites the WAL: it
+is replaying the output that runs it through consensus.
+
+The conflicting votes of the validators whose private validator key files are
+given with --keys, e.g. the ones of a local testnet, are signed with their
+keys for the chain of --chain-id, so that consensus reports them to the
+evidence pool. The conflicting votes of the other validators keep the
+signatures of the original votes, and consensus rejects them as invalid.
+
+Isolating peers changes the votes that are received, not the validator set.
+To replay a chain whose validator set changes at every height, record the WAL
+of a node running the rotating_kvstore proxy app, and replay the output with
+the same app.
+
+The faults are chosen deterministically from the seed, so that replaying the
+same WAL file with the same seed and faults always writes the same output.
+
+Example:
+$ tendermint debug replay-consensus --drop-rate 0.1 --max-delay 2s /path/to/wal /path/to/faulty-wal`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			seed, err := cmd.Flags().GetInt64(flagSeed)
+			if err != nil {
+				return fmt.Errorf("flag %q not defined: %w", flagSeed, err)
+			}
+			var faults simulation.Faults
+			if faults.DropRate, err = cmd.Flags().GetFloat64(flagDropRate); err != nil {
+				return fmt.Errorf("flag %q not defined: %w", flagDropRate, err)
+			}
+			if faults.MaxDelay, err = cmd.Flags().GetDuration(flagMaxDelay); err != nil {
+				return fmt.Errorf("flag %q not defined: %w", flagMaxDelay, err)
+			}
+			if faults.ByzantineVoteRate, err = cmd.Flags().GetFloat64(flagByzantineVoteRate); err != nil {
+				return fmt.Errorf("flag %q not defined: %w", flagByzantineVoteRate, err)
+			}
+			if faults.RotateIsolated, err = cmd.Flags().GetBool(flagRotateIsolated); err != nil {
+				return fmt.Errorf("flag %q not defined: %w", flagRotateIsolated, err)
+			}
+
+			network, err := simulation.NewNetwork(seed, faults)
+			if err != nil {
+				return fmt.Errorf("invalid faults: %w", err)
+			}
+			keyFiles, err := cmd.Flags().GetStringSlice(flagKeys)
+			if err != nil {
+				return fmt.Errorf("flag %q not defined: %w", flagKeys, err)
+			}
+			if len(keyFiles) > 0 {
+				chainID, err := cmd.Flags().GetString(flagChainID)
+				if err != nil {
+					return fmt.Errorf("flag %q not defined: %w", flagChainID, err)
+				}
+				if chainID == "" {
+					return fmt.Errorf("--%s is required to sign with --%s", flagChainID, flagKeys)
+				}
+				keys := make([]crypto.PrivKey, 0, len(keyFiles))
+				for _, keyFile := range keyFiles {
+					pv, err := privval.LoadFilePVEmptyState(keyFile, "")
+					if err != nil {
+						return fmt.Errorf("failed to load key file %s: %w", keyFile, err)
+					}
+					keys = append(keys, pv.Key.PrivKey)
+				}
+				network.SetKeys(chainID, keys...)
+			}
+
+			in, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open WAL file: %w", err)
+			}
+			defer in.Close()
+			trace, err := simulation.ReadTrace(in)
+			if err != nil {
+				return fmt.Errorf("failed to read WAL file: %w", err)
+			}
+
+			out, stats := network.Run(trace)
+
+			fp, err := os.Create(args[1])
+			if err != nil {
+				return fmt.Errorf("failed to create output file: %w", err)
+			}
+			if err := out.Write(fp); err != nil {
+				fp.Close()
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			if err := fp.Close(); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+
+			logger.Info("replayed consensus messages",
+				"messages", len(trace),
+				"peer_messages", stats.Messages,
+				"dropped", stats.Dropped,
+				"delayed", stats.Delayed,
+				"byzantine_votes", stats.ByzantineVotes,
+				"signed_byzantine_votes", stats.SignedByzantineVotes,
+			)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(flagSeed, 0, "the seed of the faults")
+	cmd.Flags().Float64(flagDropRate, 0, "the probability of dropping each message from a peer")
+	cmd.Flags().Duration(flagMaxDelay, 0, "the maximum delay of each message from a peer")
+	cmd.Flags().Float64(
+		flagByzantineVoteRate,
+		0,
+		"the probability of receiving a conflicting vote after each vote from a peer",
+	)
+	cmd.Flags().Bool(
+		flagRotateIsolated,
+		false,
+		"drop the messages of a different peer at each height, rotating through the peers",
+	)
+	cmd.Flags().StringSlice(
+		flagKeys,
+		nil,
+		"the private validator key files signing the conflicting votes of their validators",
+	)
+	cmd.Flags().String(flagChainID, "", "the chain ID the conflicting votes are signed for")
+
+	return cmd
+}
@@ -1,6 +1,7 @@
 package debug
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
@@ -8,6 +9,9 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/tendermint/tendermint/config"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
@@ -46,22 +50,126 @@ func dumpConsensusState(ctx context.Context, rpc *rpchttp.HTTP, dir, filename st
 	return writeStateJSONToFile(consDump, dir, filename)
 }
 
-// copyWAL copies the Tendermint node's WAL file. It returns an error if the
-// WAL file cannot be read or copied.
-func copyWAL(conf *config.Config, dir string) error {
+// copyWALSegments copies the head of the Tendermint node's WAL and its n most
+// recent rotated segments. It returns an error if the WAL cannot be read or
+// copied.
+func copyWALSegments(conf *config.Config, dir string, n int) error {
 	walPath := conf.Consensus.WalFile()
-	walFile := filepath.Base(walPath)
+	if err := copyFile(walPath, filepath.Join(dir, filepath.Base(walPath))); err != nil {
+		return err
+	}
 
-	return copyFile(walPath, filepath.Join(dir, walFile))
+	matches, err := filepath.Glob(walPath + ".*")
+	if err != nil {
+		return err
+	}
+	var indexes []int
+	for _, match := range matches {
+		if index, err := strconv.Atoi(strings.TrimPrefix(match, walPath+".")); err == nil {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Ints(indexes)
+	if len(indexes) > n {
+		indexes = indexes[len(indexes)-n:]
+	}
+	for _, index := range indexes {
+		segment := fmt.Sprintf("%s.%03d", walPath, index)
+		if err := copyFile(segment, filepath.Join(dir, filepath.Base(segment))); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// copyConfig copies the Tendermint node's config file. It returns an error if
-// the config file cannot be read or copied.
-func copyConfig(home, dir string) error {
+// copyRedactedConfig copies the Tendermint node's config file, with the values
+// of its secrets redacted. It returns an error if the config file cannot be
+// read or written.
+func copyRedactedConfig(home, dir string) error {
 	configFile := "config.toml"
-	configPath := filepath.Join(home, "config", configFile)
+	data, err := os.ReadFile(filepath.Join(home, "config", configFile))
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, configFile), redactConfig(data), 0600)
+}
+
+// redactedValue replaces the values of the secrets of the config.
+const redactedValue = `"<redacted>"`
+
+// redactConfig replaces the values of the secrets of a TOML config, such as
+// the privileged-auth-token and the psql-conn, with redactedValue. The keys of
+// the node and of the validator are in their own files, which are never
+// copied.
+func redactConfig(data []byte) []byte {
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		eq := strings.Index(trimmed, "=")
+		if eq < 0 {
+			continue
+		}
+		key := strings.Trim(strings.TrimSpace(trimmed[:eq]), `"`)
+		value := strings.TrimSpace(trimmed[eq+1:])
+		if !isSecretConfigKey(key) || value == `""` {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		lines[i] = fmt.Sprintf("%s%s = %s", indent, key, redactedValue)
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+func isSecretConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "psql-conn" {
+		return true
+	}
+	for _, word := range []string{"token", "password", "secret"} {
+		if strings.Contains(key, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// copyFileTail copies the lines of the last n bytes of the file at src into
+// dir, such as the recent logs of the node. It returns an error if the file cannot
+// be read or copied.
+func copyFileTail(src, dir string, n int64) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	info, err := srcFile.Stat()
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(srcFile)
+	if offset := info.Size() - n; offset > 0 {
+		if _, err := srcFile.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		// skip the partial first line
+		if _, err := reader.ReadString('\n'); err != nil && err != io.EOF {
+			return err
+		}
+	}
+
+	destFile, err := os.Create(filepath.Join(dir, filepath.Base(src)))
+	if err != nil {
+		return err
+	}
+	defer destFile.Close()
 
-	return copyFile(configPath, filepath.Join(dir, configFile))
+	_, err = io.Copy(destFile, reader)
+	return err
 }
 
 func dumpProfile(dir, addr, profile string, debug int) error {
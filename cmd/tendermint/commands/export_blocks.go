@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/store/archive"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// MakeExportBlocksCommand constructs a command to export blocks to a block
+// archive.
+func MakeExportBlocksCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	var (
+		startHeight int64
+		endHeight   int64
+		output      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-blocks",
+		Short: "Export blocks to a block archive",
+		Long: `
+export-blocks is an offline tool to write the blocks of the block store, with
+their commits and validator sets, to a block archive: a directory of checksummed
+segment files and a manifest, which import-blocks can import into another node.
+The node must be stopped. The default start-height is 0, meaning the base height
+of the block store, and the default end-height is 0, meaning its latest height.
+`,
+		Example: `
+	tendermint export-blocks --output /data/archive
+	tendermint export-blocks --start 1000 --end 2000 --output /data/archive
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				return errors.New("--output is required")
+			}
+			blockStore, stateStore, err := loadStateAndBlockStore(conf)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = blockStore.Close()
+				_ = stateStore.Close()
+			}()
+
+			start, end := startHeight, endHeight
+			if start == 0 {
+				start = blockStore.Base()
+			}
+			if end == 0 {
+				end = blockStore.Height()
+			}
+			logger.Info("exporting blocks", "from", start, "to", end, "output", output)
+			manifest, err := archive.Export(output, blockStore, stateStore, start, end)
+			if err != nil {
+				return fmt.Errorf("failed to export blocks: %w", err)
+			}
+			fmt.Printf("Exported blocks %d to %d in %d segments to %s\n",
+				manifest.StartHeight, manifest.EndHeight, len(manifest.Segments), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&startHeight, "start", 0, "the block height to start exporting from")
+	cmd.Flags().Int64Var(&endHeight, "end", 0, "the last block height to export")
+	cmd.Flags().StringVar(&output, "output", "", "the directory to write the archive to, which must not exist or be empty")
+
+	return cmd
+}
@@ -0,0 +1,168 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// MakeExportStateCommand constructs a command to export the state at a height.
+func MakeExportStateCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	var (
+		height int64
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-state",
+		Short: "Export the state at a height",
+		Long: `
+export-state is an offline tool to write the state after the block at a height,
+with its validators, consensus params and the results of the block, to a file
+from which import-state constructs the genesis doc of a new chain continuing
+the exported one, e.g. for a hard fork upgrade. The node must be stopped. The
+default height is 0, meaning the latest height. The state is encoded in JSON or
+protobuf, deterministically.
+`,
+		Example: `
+	tendermint export-state --height 1000 --output state.json
+	tendermint export-state --format proto --output state.pb
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "json" && format != "proto" {
+				return fmt.Errorf("unknown format %q, must be json or proto", format)
+			}
+			blockStore, stateStore, err := loadStateAndBlockStore(conf)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = blockStore.Close()
+				_ = stateStore.Close()
+			}()
+
+			exported, err := state.ExportState(blockStore, stateStore, height)
+			if err != nil {
+				return fmt.Errorf("failed to export state: %w", err)
+			}
+			var bz []byte
+			if format == "json" {
+				bz, err = exported.MarshalJSON()
+			} else {
+				bz, err = exported.Bytes()
+			}
+			if err != nil {
+				return err
+			}
+
+			if output == "" {
+				_, err = os.Stdout.Write(bz)
+				return err
+			}
+			if err := os.WriteFile(output, bz, 0644); err != nil {
+				return err
+			}
+			logger.Info("exported state", "height", exported.State.LastBlockHeight,
+				"app_hash", exported.State.AppHash, "output", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&height, "height", 0, "the height of the state to export")
+	cmd.Flags().StringVar(&format, "format", "json", "the encoding of the state: json or proto")
+	cmd.Flags().StringVar(&output, "output", "", "the file to write the state to, instead of stdout")
+
+	return cmd
+}
+
+// MakeImportStateCommand constructs a command to construct a genesis doc from
+// an exported state.
+func MakeImportStateCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	var (
+		input       string
+		chainID     string
+		genesisTime string
+		appState    string
+		output      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import-state",
+		Short: "Construct a genesis doc from an exported state",
+		Long: `
+import-state constructs the genesis doc of a new chain continuing the state
+exported by export-state: its initial height is the height after the state, and
+it has the validators, consensus params and app hash of the state. The chain ID
+defaults to the one of the state and the genesis time to the time of its last
+block. The app state, e.g. exported by the application at the same height, is
+read from the --app-state file. The genesis doc is written to the genesis file
+of the node unless --output is set.
+`,
+		Example: `
+	tendermint import-state --input state.json --chain-id chain-2 --app-state app.json
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if input == "" {
+				return errors.New("--input is required")
+			}
+			bz, err := os.ReadFile(input)
+			if err != nil {
+				return err
+			}
+			exported, err := state.ExportedStateFromBytes(bz)
+			if err != nil {
+				return err
+			}
+
+			var genTime time.Time
+			if genesisTime != "" {
+				genTime, err = time.Parse(time.RFC3339Nano, genesisTime)
+				if err != nil {
+					return fmt.Errorf("invalid --genesis-time: %w", err)
+				}
+			}
+			var appStateBz json.RawMessage
+			if appState != "" {
+				appStateBz, err = os.ReadFile(appState)
+				if err != nil {
+					return err
+				}
+				if !json.Valid(appStateBz) {
+					return fmt.Errorf("app state %s is not valid JSON", appState)
+				}
+			}
+
+			genDoc, err := exported.GenesisDoc(chainID, genTime, appStateBz)
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = conf.GenesisFile()
+			}
+			if err := genDoc.SaveAs(output); err != nil {
+				return err
+			}
+			logger.Info("constructed genesis doc", "chain_id", genDoc.ChainID,
+				"initial_height", genDoc.InitialHeight, "output", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&input, "input", "", "the file of the exported state, in JSON or protobuf")
+	cmd.Flags().StringVar(&chainID, "chain-id", "", "the chain ID of the new chain, by default the one of the state")
+	cmd.Flags().StringVar(&genesisTime, "genesis-time", "",
+		"the genesis time of the new chain in RFC3339, by default the time of the last block of the state")
+	cmd.Flags().StringVar(&appState, "app-state", "", "the JSON file of the app state of the genesis doc")
+	cmd.Flags().StringVar(&output, "output", "", "the file to write the genesis doc to, by default the genesis file of the node")
+
+	return cmd
+}
@@ -6,27 +6,45 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/provider"
 	"github.com/tendermint/tendermint/types"
 )
 
-// GenNodeKeyCmd allows the generation of a node key. It prints JSON-encoded
-// NodeKey to the standard output.
-var GenNodeKeyCmd = &cobra.Command{
-	Use:   "gen-node-key",
-	Short: "Generate a new node key",
-	RunE:  genNodeKey,
-}
-
-func genNodeKey(cmd *cobra.Command, args []string) error {
-	nodeKey := types.GenNodeKey()
+// MakeGenNodeKeyCommand allows the generation of a node key. It prints
+// JSON-encoded NodeKey to the standard output.
+func MakeGenNodeKeyCommand() *cobra.Command {
+	var providerName, providerConfig, label string
+	cmd := &cobra.Command{
+		Use:   "gen-node-key",
+		Short: "Generate a new node key",
+		Long: `Generate a new node key.
+
+With --provider, the key is generated by a crypto provider, e.g. an HSM, which
+keeps it and signs with it, and the private key of the printed node key is a
+reference to the key of the provider.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nodeKey := types.GenNodeKey()
+			if providerName != "" {
+				key, err := provider.GenPrivKey(providerName, providerConfig, label, ed25519.KeyType)
+				if err != nil {
+					return err
+				}
+				nodeKey = types.NodeKey{ID: types.NodeIDFromPubKey(key.PubKey()), PrivKey: key}
+			}
+
+			bz, err := json.Marshal(nodeKey)
+			if err != nil {
+				return fmt.Errorf("nodeKey -> json: %w", err)
+			}
+
+			fmt.Printf(`%v
+`, string(bz))
 
-	bz, err := json.Marshal(nodeKey)
-	if err != nil {
-		return fmt.Errorf("nodeKey -> json: %w", err)
+			return nil
+		},
 	}
+	addProviderFlags(cmd, &providerName, &providerConfig, &label, "node")
 
-	fmt.Printf(`%v
-`, string(bz))
-
-	return nil
+	return cmd
 }
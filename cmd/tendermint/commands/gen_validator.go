@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/tendermint/tendermint/crypto/provider"
 	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/types"
 )
@@ -13,12 +14,34 @@ import (
 // GenValidatorCmd allows the generation of a keypair for a
 // validator.
 func MakeGenValidatorCommand() *cobra.Command {
-	var keyType string
+	var (
+		keyType        string
+		providerName   string
+		providerConfig string
+		label          string
+	)
 	cmd := &cobra.Command{
 		Use:   "gen-validator",
 		Short: "Generate new validator keypair",
+		Long: `Generate new validator keypair.
+
+With --provider, the key is generated by a crypto provider, e.g. an HSM, which
+keeps it and signs with it, and the private key of the printed key file is a
+reference to the key of the provider.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			pv, err := privval.GenFilePV("", "", keyType)
+			var (
+				pv  *privval.FilePV
+				err error
+			)
+			if providerName != "" {
+				var key provider.PrivKey
+				key, err = provider.GenPrivKey(providerName, providerConfig, label, keyType)
+				if err == nil {
+					pv = privval.NewFilePV(key, "", "")
+				}
+			} else {
+				pv, err = privval.GenFilePV("", "", keyType)
+			}
 			if err != nil {
 				return err
 			}
@@ -36,6 +59,17 @@ func MakeGenValidatorCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeEd25519,
 		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	addProviderFlags(cmd, &providerName, &providerConfig, &label, "validator")
 
 	return cmd
 }
+
+// addProviderFlags adds the flags selecting the crypto provider generating a
+// key to the command.
+func addProviderFlags(cmd *cobra.Command, name, config, label *string, defaultLabel string) {
+	cmd.Flags().StringVar(name, "provider", "",
+		fmt.Sprintf("Crypto provider to generate the key with, e.g. pkcs11 if built in. Options: %v", provider.Registered()))
+	cmd.Flags().StringVar(config, "provider-config", "",
+		"Config of the crypto provider, e.g. the directory of the keys of the file provider")
+	cmd.Flags().StringVar(label, "label", defaultLabel, "Label of the key in the crypto provider")
+}
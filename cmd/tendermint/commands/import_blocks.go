@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// MakeImportBlocksCommand constructs a command to import blocks from a block
+// archive.
+func MakeImportBlocksCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import-blocks <dir>",
+		Short: "Import blocks from a block archive",
+		Long: `
+import-blocks is an offline tool to bootstrap a node from a block archive, as
+written by export-blocks, instead of fetching the blocks from peers with block
+sync. The node must be stopped. The blocks after the last block of the node are
+verified against its state, stored and executed by the application, which must
+be running, as for the node itself. The archive must start at or before the next
+height of the node.
+
+Events are not indexed while importing; use reindex-event to index them after.
+`,
+		Example: `
+	tendermint import-blocks /data/archive
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := consensus.RunImportBlocks(cmd.Context(), logger, conf, args[0])
+			if err != nil {
+				return fmt.Errorf("failed to import blocks: %w", err)
+			}
+			fmt.Printf("Imported blocks up to height %d\n", height)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,199 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+	"github.com/tendermint/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MakeInPlaceTestnetCommand constructs a command to fork the chain of a node
+// in place into a local testnet.
+func MakeInPlaceTestnetCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	var (
+		power   int64
+		keyFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "in-place-testnet [chain-id]",
+		Short: "Fork the chain of the node into a local testnet with the node as the only validator",
+		Long: `
+in-place-testnet is an offline tool turning the data directory of a node, such
+as a copy of a mainnet node, into a local testnet continuing from its state, to
+test upgrades against real state. The node must be stopped.
+
+It sets the chain ID of the state and of the genesis file to the given one, and
+replaces the validator set with a single validator with the given voting power:
+the one of the priv-validator key file given with --key, or else the validator
+of the node, from its own key file. The commit of the last block is signed
+again by that validator, so that the node can propose the next block on its
+own. The node should then be started without peers, with the key file of the
+validator as its priv-validator key-file. The signer state of the node, which
+records the signed commit, is reset first if --key is given.
+
+The application state is not changed: the application must accept the new
+validator set, for instance by not sending validator updates for the replaced
+validators, which would halt consensus.
+`,
+		Example: `
+	tendermint in-place-testnet local-testnet-1
+	tendermint in-place-testnet local-testnet-1 --power 100
+	tendermint in-place-testnet local-testnet-1 --key testnet_validator_key.json
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			state, err := InPlaceTestnet(cmd.Context(), conf, args[0], keyFile, power)
+			if err != nil {
+				return fmt.Errorf("failed to fork the chain: %w", err)
+			}
+			logger.Info("forked the chain into a testnet", "chainID", state.ChainID,
+				"height", state.LastBlockHeight, "validator", state.Validators.Validators[0].Address)
+			fmt.Printf("Forked the chain at height %d into testnet %s\n", state.LastBlockHeight, state.ChainID)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&power, "power", 100, "the voting power of the validator of the testnet")
+	cmd.Flags().StringVar(&keyFile, "key", "",
+		"the priv-validator key file of the validator of the testnet (default: the key file of the node)")
+
+	return cmd
+}
+
+// InPlaceTestnet rewrites the state, the seen commit of the last block and the genesis file of
+// a stopped node, to fork its chain into a testnet with the given chain ID, whose only validator
+// is the one of the given priv-validator key file, with the given voting power. If keyFile is
+// empty, the key file of the node is used. The signer state of the node records the signed
+// commit; it is reset first if another key file is given. It returns the forked state.
+// Note that this function does not affect application state.
+func InPlaceTestnet(ctx context.Context, conf *config.Config, chainID, keyFile string, power int64) (sm.State, error) {
+	if chainID == "" || len(chainID) > types.MaxChainIDLen {
+		return sm.State{}, fmt.Errorf("the chain ID must have 1 to %d characters", types.MaxChainIDLen)
+	}
+	if power <= 0 || power > types.MaxTotalVotingPower {
+		return sm.State{}, fmt.Errorf("the voting power must be between 1 and %d", types.MaxTotalVotingPower)
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	blockStore, stateStore, err := loadStateAndBlockStore(conf)
+	if err != nil {
+		return sm.State{}, err
+	}
+	defer func() {
+		_ = blockStore.Close()
+		_ = stateStore.Close()
+	}()
+
+	state, err := stateStore.Load()
+	if err != nil {
+		return sm.State{}, err
+	}
+	if state.IsEmpty() || state.LastBlockHeight == 0 {
+		return sm.State{}, errors.New("no state found, the node has no block to fork from")
+	}
+	height := state.LastBlockHeight
+	if storeHeight := blockStore.Height(); storeHeight != height {
+		return sm.State{}, fmt.Errorf("the block store height (%d) is not the state height (%d); "+
+			"start the node to have it catch up, or roll it back", storeHeight, height)
+	}
+	seenCommit := blockStore.LoadSeenCommit()
+	if seenCommit == nil || seenCommit.Height != height {
+		seenCommit = blockStore.LoadBlockCommit(height)
+	}
+	if seenCommit == nil {
+		return sm.State{}, fmt.Errorf("no commit found for the last block at height %d", height)
+	}
+
+	genDoc, err := types.GenesisDocFromFile(conf.GenesisFile())
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	var pv *privval.FilePV
+	if keyFile == "" {
+		pv, err = privval.LoadFilePV(conf.PrivValidator.KeyFile(), conf.PrivValidator.StateFile())
+	} else {
+		// The signer state of the node is that of its own key, so it starts
+		// over for the key of the validator of the testnet.
+		pv, err = privval.LoadFilePVEmptyState(keyFile, conf.PrivValidator.StateFile())
+	}
+	if err != nil {
+		return sm.State{}, err
+	}
+	pubKey, err := pv.GetPubKey(ctx)
+	if err != nil {
+		return sm.State{}, err
+	}
+	valSet := types.NewValidatorSet([]*types.Validator{types.NewValidator(pubKey, power)})
+
+	// Sign the last block again as the only validator, in the same round, at
+	// a time after the block's so that the next block's time is after it too.
+	timestamp := tmtime.Now()
+	if !timestamp.After(state.LastBlockTime) {
+		timestamp = state.LastBlockTime.Add(time.Millisecond)
+	}
+	vote := &types.Vote{
+		Type:             tmproto.PrecommitType,
+		Height:           height,
+		Round:            seenCommit.Round,
+		BlockID:          state.LastBlockID,
+		Timestamp:        timestamp,
+		ValidatorAddress: pubKey.Address(),
+		ValidatorIndex:   0,
+	}
+	pbVote := vote.ToProto()
+	if err := pv.SignVote(ctx, chainID, pbVote); err != nil {
+		return sm.State{}, fmt.Errorf("failed to sign the commit of height %d with the validator key, "+
+			"the signer state may need a reset: %w", height, err)
+	}
+	vote.Timestamp = pbVote.Timestamp
+	vote.Signature = pbVote.Signature
+	vote.ExtensionSignature = pbVote.ExtensionSignature
+
+	if state.ConsensusParams.ABCI.VoteExtensionsEnabled(height) {
+		err = blockStore.SaveExtendedCommit(height, &types.ExtendedCommit{
+			Height:             height,
+			Round:              vote.Round,
+			BlockID:            vote.BlockID,
+			ExtendedSignatures: []types.ExtendedCommitSig{vote.ExtendedCommitSig()},
+		})
+	} else {
+		err = blockStore.SaveSeenCommit(height, &types.Commit{
+			Height:     height,
+			Round:      vote.Round,
+			BlockID:    vote.BlockID,
+			Signatures: []types.CommitSig{vote.CommitSig()},
+		})
+	}
+	if err != nil {
+		return sm.State{}, fmt.Errorf("failed to save the commit of height %d: %w", height, err)
+	}
+
+	state.ChainID = chainID
+	state.LastValidators = valSet.Copy()
+	state.Validators = valSet.Copy()
+	state.NextValidators = valSet.CopyIncrementProposerPriority(1)
+	state.LastHeightValidatorsChanged = height + 1
+	if err := stateStore.Bootstrap(state); err != nil {
+		return sm.State{}, fmt.Errorf("failed to save the state: %w", err)
+	}
+
+	genDoc.ChainID = chainID
+	if err := genDoc.SaveAs(conf.GenesisFile()); err != nil {
+		return sm.State{}, fmt.Errorf("failed to save the genesis file: %w", err)
+	}
+	return state, nil
+}
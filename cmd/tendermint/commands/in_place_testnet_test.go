@@ -0,0 +1,103 @@
+package commands_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/cmd/tendermint/commands"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/rpc/client/local"
+	rpctest "github.com/tendermint/tendermint/rpc/test"
+	e2e "github.com/tendermint/tendermint/test/e2e/app"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestInPlaceTestnetIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	var height int64
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg, err := rpctest.CreateConfig(t, t.Name())
+	require.NoError(t, err)
+	cfg.BaseConfig.DBBackend = "goleveldb"
+
+	app, err := e2e.NewApplication(e2e.DefaultConfig(dir))
+	require.NoError(t, err)
+
+	t.Run("First run", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		node, _, err := rpctest.StartTendermint(ctx, cfg, app, rpctest.SuppressStdout)
+		require.NoError(t, err)
+		require.True(t, node.IsRunning())
+
+		time.Sleep(3 * time.Second)
+		cancel()
+		node.Wait()
+
+		require.False(t, node.IsRunning())
+	})
+	t.Run("Fork", func(t *testing.T) {
+		// the testnet is run by a validator which is not in the validator set,
+		// with a key file given instead of the key file of the node
+		keyFile := filepath.Join(dir, "testnet_validator_key.json")
+		pv, err := privval.GenFilePV(keyFile, "", types.ABCIPubKeyTypeEd25519)
+		require.NoError(t, err)
+		require.NoError(t, pv.Key.Save())
+
+		_, err = commands.InPlaceTestnet(ctx, cfg, "", keyFile, 10)
+		require.Error(t, err)
+
+		state, err := commands.InPlaceTestnet(ctx, cfg, "forked-chain", keyFile, 10)
+		require.NoError(t, err)
+		height = state.LastBlockHeight
+		require.Equal(t, "forked-chain", state.ChainID)
+		require.Equal(t, 1, state.Validators.Size())
+		require.Equal(t, pv.Key.Address, state.Validators.Validators[0].Address)
+		require.EqualValues(t, 10, state.Validators.TotalVotingPower())
+
+		genDoc, err := types.GenesisDocFromFile(cfg.GenesisFile())
+		require.NoError(t, err)
+		require.Equal(t, "forked-chain", genDoc.ChainID)
+
+		// the node is restarted as the validator of the testnet
+		cfg.PrivValidator.Key = keyFile
+	})
+	t.Run("Restart", func(t *testing.T) {
+		require.True(t, height > 0, "%d", height)
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		node2, _, err2 := rpctest.StartTendermint(ctx, cfg, app, rpctest.SuppressStdout)
+		require.NoError(t, err2)
+		t.Cleanup(node2.Wait)
+
+		client, err := local.New(log.NewNopLogger(), node2.(local.NodeService))
+		require.NoError(t, err)
+
+		ticker := time.NewTicker(200 * time.Millisecond)
+		for {
+			select {
+			case <-ctx.Done():
+				t.Fatalf("failed to make progress after 10 seconds. Min height: %d", height)
+			case <-ticker.C:
+				status, err := client.Status(ctx)
+				require.NoError(t, err)
+				require.Equal(t, "forked-chain", status.NodeInfo.Network)
+
+				if status.SyncInfo.LatestBlockHeight > height+1 {
+					return
+				}
+			}
+		}
+	})
+}
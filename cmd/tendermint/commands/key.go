@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/types"
+)
+
+// MakeKeyCommand constructs a command to encrypt and decrypt the private
+// validator key file, and to rotate the node key.
+func MakeKeyCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "key",
+		Short: "Encrypt or decrypt the private validator key file, or rotate the node key",
+		Long: fmt.Sprintf(`Encrypt or decrypt the private validator key file in place, or rotate the
+node key.
+
+The key file is encrypted with AES-256-GCM, under a key derived from a
+passphrase with argon2id. The passphrase is read from the %s
+environment variable, or else from the priv-validator.passphrase-file file, or
+else prompted.`, privval.KeyPassphraseEnv),
+	}
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "encrypt",
+			Short: "Encrypt the private validator key file with a passphrase",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				passphrase, err := privval.ReadKeyPassphrase(conf.PrivValidator.PassphraseFile(), func() ([]byte, error) {
+					p, err := privval.PromptPassphrase(cmd.InOrStdin(), cmd.ErrOrStderr(), "Enter passphrase: ")
+					if err != nil {
+						return nil, err
+					}
+					confirm, err := privval.PromptPassphrase(cmd.InOrStdin(), cmd.ErrOrStderr(), "Repeat passphrase: ")
+					if err != nil {
+						return nil, err
+					}
+					if !bytes.Equal(p, confirm) {
+						return nil, errors.New("passphrases do not match")
+					}
+					return p, nil
+				})
+				if err != nil {
+					return err
+				}
+
+				keyFile := conf.PrivValidator.KeyFile()
+				if err := privval.EncryptKeyFile(keyFile, passphrase); err != nil {
+					return err
+				}
+				logger.Info("Encrypted private validator key file", "path", keyFile)
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "decrypt",
+			Short: "Decrypt the private validator key file to plaintext",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				passphrase, err := privval.ReadKeyPassphrase(conf.PrivValidator.PassphraseFile(), func() ([]byte, error) {
+					return privval.PromptPassphrase(cmd.InOrStdin(), cmd.ErrOrStderr(), "Enter passphrase: ")
+				})
+				if err != nil {
+					return err
+				}
+
+				keyFile := conf.PrivValidator.KeyFile()
+				if err := privval.DecryptKeyFile(keyFile, passphrase); err != nil {
+					return err
+				}
+				logger.Info("Decrypted private validator key file", "path", keyFile)
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "rotate-node-key",
+			Short: "Replace the node key with a new one, keeping the peer records of the node",
+			Long: `Replace the node key, and so the node ID, with a newly generated one.
+
+The old node key is kept next to the node key file, suffixed with its node ID.
+A record of the rotation, signed by both the old and the new node key, is
+written to the node_key_rotation.json file next to the node key file. The node
+advertises it to its peers, which then move their records of the old node ID,
+such as its score, its addresses and its place in their persistent peers, to
+the new node ID.
+
+The node must be stopped during the rotation.`,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return rotateNodeKey(conf, logger, tmtime.Now())
+			},
+		},
+	)
+
+	return cmd
+}
+
+// loadFilePV loads the FilePV of the config, decrypting its key file with a
+// passphrase if it is encrypted.
+func loadFilePV(cmd *cobra.Command, conf *config.PrivValidatorConfig) (*privval.FilePV, error) {
+	encrypted, err := privval.IsEncryptedKeyFile(conf.KeyFile())
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return privval.LoadFilePV(conf.KeyFile(), conf.StateFile())
+	}
+
+	passphrase, err := privval.ReadKeyPassphrase(conf.PassphraseFile(), func() ([]byte, error) {
+		return privval.PromptPassphrase(cmd.InOrStdin(), cmd.ErrOrStderr(), "Enter passphrase: ")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return privval.LoadFilePVWithProvider(
+		&privval.EncryptedKeyFile{Path: conf.KeyFile(), Passphrase: passphrase},
+		conf.StateFile(),
+	)
+}
+
+// rotateNodeKey replaces the node key of the config with a new one, writing
+// the record of the rotation at the time and recording it in the peer store.
+func rotateNodeKey(conf *config.Config, logger log.Logger, now time.Time) error {
+	keyFile := conf.NodeKeyFile()
+	oldKey, err := types.LoadNodeKey(keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the node key: %w", err)
+	}
+	genDoc, err := types.GenesisDocFromFile(conf.GenesisFile())
+	if err != nil {
+		return fmt.Errorf("failed to load the genesis document: %w", err)
+	}
+
+	newKey := types.GenNodeKey()
+	rotation, err := types.NewNodeKeyRotation(oldKey, newKey, genDoc.ChainID, now)
+	if err != nil {
+		return err
+	}
+
+	// the new key is saved last, so that an interrupted rotation leaves the
+	// old key in place, and can be run again
+	oldKeyFile := fmt.Sprintf("%s.%s", keyFile, oldKey.ID)
+	if err := oldKey.SaveAs(oldKeyFile); err != nil {
+		return fmt.Errorf("failed to keep the old node key: %w", err)
+	}
+	if err := rotation.SaveAs(conf.NodeKeyRotationFile()); err != nil {
+		return fmt.Errorf("failed to save the node key rotation: %w", err)
+	}
+
+	peerDB, err := config.DefaultDBProvider(&config.DBContext{ID: "peerstore", Config: conf})
+	if err != nil {
+		return fmt.Errorf("failed to open the peer store: %w", err)
+	}
+	if err := p2p.RecordNodeKeyRotation(peerDB, rotation); err != nil {
+		peerDB.Close()
+		return fmt.Errorf("failed to record the node key rotation in the peer store: %w", err)
+	}
+	if err := peerDB.Close(); err != nil {
+		return err
+	}
+
+	if err := newKey.SaveAs(keyFile); err != nil {
+		return fmt.Errorf("failed to save the new node key: %w", err)
+	}
+	logger.Info("Rotated node key",
+		"old_id", oldKey.ID,
+		"new_id", newKey.ID,
+		"old_key", oldKeyFile,
+		"rotation", conf.NodeKeyRotationFile(),
+	)
+	return nil
+}
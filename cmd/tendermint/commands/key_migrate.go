@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,8 +15,19 @@ import (
 
 func MakeKeyMigrateCommand(conf *config.Config, logger log.Logger) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "key-migrate",
-		Short: "Run Database key migration",
+		Use:     "migrate-db",
+		Aliases: []string{"key-migrate"},
+		Short:   "Migrate the databases of the node to the ordered key layout",
+		Long: `Migrate the databases of the node to the ordered key layout.
+
+The block store, state store, evidence, light client and transaction index
+databases key their records with order-preserving binary keys, so that
+iterating a range of heights reads the keys in height order. This command
+converts the keys of databases written by earlier versions, which used
+formatted strings, in place. Migrating a database again is a no-op, so an
+interrupted migration can be resumed by running the command again.
+
+The node must be stopped during the migration.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return RunDatabaseMigration(cmd.Context(), logger, conf)
 		},
@@ -56,7 +68,7 @@ func RunDatabaseMigration(ctx context.Context, logger log.Logger, conf *config.C
 			return fmt.Errorf("constructing database handle: %w", err)
 		}
 
-		if err = keymigrate.Migrate(ctx, dbctx, db); err != nil {
+		if err = keymigrate.Migrate(ctx, dbctx, db, keymigrate.WithProgress(progressLogger(logger, dbctx))); err != nil {
 			return fmt.Errorf("running migration for context %q: %w",
 				dbctx, err)
 		}
@@ -73,3 +85,26 @@ func RunDatabaseMigration(ctx context.Context, logger log.Logger, conf *config.C
 
 	return nil
 }
+
+// migrationProgressInterval is the minimum time between two progress reports
+// of a database migration.
+const migrationProgressInterval = 10 * time.Second
+
+// progressLogger returns a progress function for keymigrate.Migrate that logs
+// the progress of the migration of dbctx at most every
+// migrationProgressInterval, and once the last key is migrated.
+func progressLogger(logger log.Logger, dbctx string) func(migrated, total int) {
+	last := time.Now()
+	return func(migrated, total int) {
+		if migrated < total && time.Since(last) < migrationProgressInterval {
+			return
+		}
+		last = time.Now()
+		logger.Info("migrating keys",
+			"dbctx", dbctx,
+			"migrated", migrated,
+			"total", total,
+			"percent", migrated*100/total,
+		)
+	}
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestRotateNodeKey(t *testing.T) {
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	logger := log.NewNopLogger()
+	cfg.EnsureRoot(dir)
+	require.NoError(t, initFilesWithConfig(context.Background(), config, logger, types.ABCIPubKeyTypeEd25519))
+	oldKey, err := types.LoadNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	genDoc, err := types.GenesisDocFromFile(config.GenesisFile())
+	require.NoError(t, err)
+
+	require.NoError(t, rotateNodeKey(config, logger, time.Now()))
+
+	newKey, err := types.LoadNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	require.NotEqual(t, oldKey.ID, newKey.ID)
+	keptKey, err := types.LoadNodeKey(fmt.Sprintf("%s.%s", config.NodeKeyFile(), oldKey.ID))
+	require.NoError(t, err)
+	require.Equal(t, oldKey.ID, keptKey.ID)
+
+	rotation, err := types.LoadNodeKeyRotation(config.NodeKeyRotationFile())
+	require.NoError(t, err)
+	require.NoError(t, rotation.Verify(genDoc.ChainID))
+	require.Equal(t, oldKey.ID, rotation.OldID())
+	require.Equal(t, newKey.ID, rotation.NewID())
+}
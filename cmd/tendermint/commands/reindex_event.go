@@ -4,18 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
-	"strings"
 
 	"github.com/spf13/cobra"
-	dbm "github.com/tendermint/tm-db"
 
 	abcitypes "github.com/tendermint/tendermint/abci/types"
 	tmcfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/libs/progressbar"
 	"github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
-	"github.com/tendermint/tendermint/internal/state/indexer/sink/kv"
-	"github.com/tendermint/tendermint/internal/state/indexer/sink/psql"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink"
 	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/os"
@@ -32,11 +29,13 @@ func MakeReindexEventCommand(conf *tmcfg.Config, logger log.Logger) *cobra.Comma
 	var (
 		startHeight int64
 		endHeight   int64
+		sinks       []string
 	)
 
 	cmd := &cobra.Command{
-		Use:   "reindex-event",
-		Short: "reindex events to the event store backends",
+		Use:     "reindex-event",
+		Aliases: []string{"reindex"},
+		Short:   "reindex events to the event store backends",
 		Long: `
 reindex-event is an offline tooling to re-index block and tx events to the eventsinks,
 you can run this command when the event store backend dropped/disconnected or you want to
@@ -44,12 +43,16 @@ replace the backend. The default start-height is 0, meaning the tooling will sta
 reindex from the base block height(inclusive); and the default end-height is 0, meaning
 the tooling will reindex until the latest block height(inclusive). User can omit
 either or both arguments.
+
+By default events are written to every sink listed in tx-index.indexer. Use
+--sinks to backfill only some of them, for example a newly added psql sink.
 	`,
 		Example: `
 	tendermint reindex-event
 	tendermint reindex-event --start-height 2
 	tendermint reindex-event --end-height 10
 	tendermint reindex-event --start-height 2 --end-height 10
+	tendermint reindex --sinks psql
 	`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			bs, ss, err := loadStateAndBlockStore(conf)
@@ -61,18 +64,21 @@ either or both arguments.
 				startHeight: startHeight,
 				endHeight:   endHeight,
 			}
-			if err := checkValidHeight(bs, cvhArgs); err != nil {
+			if err := checkValidHeight(bs, &cvhArgs); err != nil {
 				return fmt.Errorf("%s: %w", reindexFailed, err)
 			}
 
+			if len(sinks) != 0 {
+				conf.TxIndex.Indexer = sinks
+			}
 			es, err := loadEventSinks(conf)
 			if err != nil {
 				return fmt.Errorf("%s: %w", reindexFailed, err)
 			}
 
 			riArgs := eventReIndexArgs{
-				startHeight: startHeight,
-				endHeight:   endHeight,
+				startHeight: cvhArgs.startHeight,
+				endHeight:   cvhArgs.endHeight,
 				sinks:       es,
 				blockStore:  bs,
 				stateStore:  ss,
@@ -88,44 +94,20 @@ either or both arguments.
 
 	cmd.Flags().Int64Var(&startHeight, "start-height", 0, "the block height would like to start for re-index")
 	cmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
+	cmd.Flags().StringSliceVar(&sinks, "sinks", nil,
+		"the event sinks to re-index into, overriding tx-index.indexer (e.g. psql,custom:name)")
 	return cmd
 }
 
 func loadEventSinks(cfg *tmcfg.Config) ([]indexer.EventSink, error) {
-	// Check duplicated sinks.
-	sinks := map[string]bool{}
-	for _, s := range cfg.TxIndex.Indexer {
-		sl := strings.ToLower(s)
-		if sinks[sl] {
-			return nil, errors.New("found duplicated sinks, please check the tx-index section in the config.toml")
-		}
-		sinks[sl] = true
+	eventSinks, err := sink.EventSinksFromConfig(cfg, tmcfg.DefaultDBProvider, cfg.ChainID())
+	if err != nil {
+		return nil, err
 	}
 
-	eventSinks := []indexer.EventSink{}
-
-	for k := range sinks {
-		switch k {
-		case string(indexer.NULL):
+	for _, es := range eventSinks {
+		if es.Type() == indexer.NULL {
 			return nil, errors.New("found null event sink, please check the tx-index section in the config.toml")
-		case string(indexer.KV):
-			store, err := tmcfg.DefaultDBProvider(&tmcfg.DBContext{ID: "tx_index", Config: cfg})
-			if err != nil {
-				return nil, err
-			}
-			eventSinks = append(eventSinks, kv.NewEventSink(store))
-		case string(indexer.PSQL):
-			conn := cfg.TxIndex.PsqlConn
-			if conn == "" {
-				return nil, errors.New("the psql connection settings cannot be empty")
-			}
-			es, err := psql.NewEventSink(conn, cfg.ChainID())
-			if err != nil {
-				return nil, err
-			}
-			eventSinks = append(eventSinks, es)
-		default:
-			return nil, errors.New("unsupported event sink type")
 		}
 	}
 
@@ -142,14 +124,12 @@ func loadEventSinks(cfg *tmcfg.Config) ([]indexer.EventSink, error) {
 }
 
 func loadStateAndBlockStore(cfg *tmcfg.Config) (*store.BlockStore, state.Store, error) {
-	dbType := dbm.BackendType(cfg.DBBackend)
-
 	if !os.FileExists(filepath.Join(cfg.DBDir(), "blockstore.db")) {
 		return nil, nil, fmt.Errorf("no blockstore found in %v", cfg.DBDir())
 	}
 
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, cfg.DBDir())
+	blockStoreDB, err := tmcfg.DefaultDBProvider(&tmcfg.DBContext{ID: "blockstore", Config: cfg})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -160,7 +140,7 @@ func loadStateAndBlockStore(cfg *tmcfg.Config) (*store.BlockStore, state.Store,
 	}
 
 	// Get StateStore
-	stateDB, err := dbm.NewDB("state", dbType, cfg.DBDir())
+	stateDB, err := tmcfg.DefaultDBProvider(&tmcfg.DBContext{ID: "state", Config: cfg})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -203,6 +183,18 @@ func eventReIndex(cmd *cobra.Command, args eventReIndexArgs) error {
 				NumTxs:              int64(len(b.Txs)),
 				ResultFinalizeBlock: *r,
 			}
+			if len(r.ValidatorUpdates) > 0 {
+				updates, err := types.PB2TM.ValidatorUpdates(r.ValidatorUpdates)
+				if err != nil {
+					return fmt.Errorf("not able to decode the validator updates at height %d: %w", i, err)
+				}
+				// the updates apply to the next validators of the block
+				vals, err := args.stateStore.LoadValidators(i + 1)
+				if err != nil {
+					return fmt.Errorf("not able to load the validators at height %d from the statestore: %w", i+1, err)
+				}
+				e.ValidatorChanges = state.ValidatorSetChanges(vals, updates)
+			}
 
 			var batch *indexer.Batch
 			if e.NumTxs > 0 {
@@ -244,7 +236,10 @@ type checkValidHeightArgs struct {
 	endHeight   int64
 }
 
-func checkValidHeight(bs state.BlockStore, args checkValidHeightArgs) error {
+// checkValidHeight validates the requested height range against the block
+// store, replacing zero start and end heights with the store's base and
+// latest heights respectively.
+func checkValidHeight(bs state.BlockStore, args *checkValidHeightArgs) error {
 	base := bs.Base()
 
 	if args.startHeight == 0 {
@@ -71,7 +71,7 @@ func TestReIndexEventCheckHeight(t *testing.T) {
 	}
 
 	for _, tc := range testCases {
-		err := checkValidHeight(mockBlockStore, checkValidHeightArgs{startHeight: tc.startHeight, endHeight: tc.endHeight})
+		err := checkValidHeight(mockBlockStore, &checkValidHeightArgs{startHeight: tc.startHeight, endHeight: tc.endHeight})
 		if tc.validHeight {
 			require.NoError(t, err)
 		} else {
@@ -80,6 +80,21 @@ func TestReIndexEventCheckHeight(t *testing.T) {
 	}
 }
 
+func TestReIndexEventDefaultHeights(t *testing.T) {
+	mockBlockStore := &mocks.BlockStore{}
+	mockBlockStore.
+		On("Base").Return(base).
+		On("Height").Return(height)
+
+	args := checkValidHeightArgs{}
+	require.NoError(t, checkValidHeight(mockBlockStore, &args))
+	require.Equal(t, checkValidHeightArgs{startHeight: base, endHeight: height}, args)
+
+	args = checkValidHeightArgs{startHeight: base + 1, endHeight: height + 5}
+	require.NoError(t, checkValidHeight(mockBlockStore, &args))
+	require.Equal(t, checkValidHeightArgs{startHeight: base + 1, endHeight: height}, args)
+}
+
 func TestLoadEventSink(t *testing.T) {
 	testCases := []struct {
 		sinks   []string
@@ -1,43 +1,72 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/privval"
 )
 
 func MakeRollbackStateCommand(conf *config.Config) *cobra.Command {
-	return &cobra.Command{
+	var (
+		height       int64
+		removeBlocks bool
+	)
+
+	cmd := &cobra.Command{
 		Use:   "rollback",
-		Short: "rollback tendermint state by one height",
+		Short: "rollback tendermint state by one or more heights",
 		Long: `
 A state rollback is performed to recover from an incorrect application state transition,
 when Tendermint has persisted an incorrect app hash and is thus unable to make
-progress. Rollback overwrites a state at height n with the state at height n - 1.
-The application should also roll back to height n - 1. No blocks are removed, so upon
-restarting Tendermint the transactions in block n will be re-executed against the
-application.
+progress. Rollback overwrites a state at height n with the state at height n - 1,
+or with the state at the height given by --height. The application should also roll
+back to that height. Unless --hard is given, no blocks are removed, so upon
+restarting Tendermint the transactions of the rolled back blocks will be re-executed
+against the application. With --hard, the rolled back blocks are removed from the
+blockstore as well, along with their records in the consensus WAL, and consensus
+decides them again. A validator which signed any of these heights would have to
+sign them again, so --hard is refused if the sign state of the validator is above
+the height, or if the node uses a remote signer.
+
+The app hash of the rolled back state is checked against the one the application
+returned for its last block, if the responses to that block are still stored.
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			height, hash, err := RollbackState(conf)
+			height, hash, err := RollbackState(conf, height, removeBlocks)
 			if err != nil {
 				return fmt.Errorf("failed to rollback state: %w", err)
 			}
 
-			fmt.Printf("Rolled back state to height %d and hash %X", height, hash)
+			if removeBlocks {
+				fmt.Printf("Rolled back state and blocks to height %d and hash %X", height, hash)
+			} else {
+				fmt.Printf("Rolled back state to height %d and hash %X", height, hash)
+			}
 			return nil
 		},
 	}
 
+	cmd.Flags().Int64Var(&height, "height", 0,
+		"height to roll back to (defaults to the height below the latest block)")
+	cmd.Flags().BoolVar(&removeBlocks, "hard", false,
+		"remove the rolled back blocks from the blockstore as well")
+
+	return cmd
 }
 
 // RollbackState takes the state at the current height n and overwrites it with the state
-// at height n - 1. Note state here refers to tendermint state not application state.
+// at the given height, or at height n - 1 if height is 0, removing the blocks above it
+// and their records in the consensus WAL if removeBlocks is set. Note state here refers
+// to tendermint state not application state.
 // Returns the latest state height and app hash alongside an error if there was one.
-func RollbackState(config *config.Config) (int64, []byte, error) {
+func RollbackState(config *config.Config, height int64, removeBlocks bool) (int64, []byte, error) {
 	// use the parsed config to load the block and state store
 	blockStore, stateStore, err := loadStateAndBlockStore(config)
 	if err != nil {
@@ -48,6 +77,47 @@ func RollbackState(config *config.Config) (int64, []byte, error) {
 		_ = stateStore.Close()
 	}()
 
+	if removeBlocks {
+		if height == 0 {
+			height = blockStore.Height() - 1
+		}
+		if err := checkNotSignedAbove(config, height); err != nil {
+			return -1, nil, err
+		}
+	}
+
 	// rollback the last state
-	return state.Rollback(blockStore, stateStore)
+	height, hash, err := state.Rollback(blockStore, stateStore, height, removeBlocks)
+	if err != nil || !removeBlocks {
+		return height, hash, err
+	}
+	walFile := config.Consensus.WalFile()
+	if _, err := os.Stat(walFile); errors.Is(err, os.ErrNotExist) {
+		return height, hash, nil
+	}
+	if _, err := consensus.TruncateWAL(walFile, height); err != nil {
+		return -1, nil, fmt.Errorf("failed to remove the heights above %d from the WAL: %w", height, err)
+	}
+	return height, hash, nil
+}
+
+// checkNotSignedAbove reports an error if the validator of the node may have
+// signed a height above the given one, which it would have to sign again once
+// the blocks above it are removed: if its sign state is above the height, or
+// if it is a remote signer, whose sign state is not known.
+func checkNotSignedAbove(config *config.Config, height int64) error {
+	if config.PrivValidator.ListenAddr != "" {
+		return errors.New("cannot remove the blocks of a node with a remote signer, which may have signed them")
+	}
+	signState, err := privval.LoadFilePVSignState(config.PrivValidator.StateFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if signState.Height > height {
+		return fmt.Errorf("cannot remove the blocks above height %d: the validator signed at height %d, "+
+			"and would have to sign these heights again", height, signState.Height)
+	}
+	return nil
 }
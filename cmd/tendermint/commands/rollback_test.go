@@ -47,7 +47,10 @@ func TestRollbackIntegration(t *testing.T) {
 	t.Run("Rollback", func(t *testing.T) {
 		time.Sleep(time.Second)
 		require.NoError(t, app.Rollback())
-		height, _, err = commands.RollbackState(cfg)
+		// the validator signed the blocks, so they can't be removed
+		_, _, err = commands.RollbackState(cfg, 0, true)
+		require.Error(t, err)
+		height, _, err = commands.RollbackState(cfg, 0, false)
 		require.NoError(t, err, "%d", height)
 	})
 	t.Run("Restart", func(t *testing.T) {
@@ -12,6 +12,7 @@ import (
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
 )
 
 const ctxTimeout = 4 * time.Second
@@ -28,6 +29,9 @@ func ParseConfig(conf *config.Config) (*config.Config, error) {
 	if err := conf.ValidateBasic(); err != nil {
 		return nil, fmt.Errorf("error in config file: %w", err)
 	}
+	if err := conf.Consensus.ApplyLegacyTimeouts(); err != nil {
+		return nil, fmt.Errorf("error in config file: %w", err)
+	}
 	return conf, nil
 }
 
@@ -51,7 +55,20 @@ func RootCommand(conf *config.Config, logger log.Logger) *cobra.Command {
 			}
 			*conf = *pconf
 			config.EnsureRoot(conf.RootDir)
-			if err := log.OverrideWithNewLogger(logger, conf.LogFormat, conf.LogLevel); err != nil {
+			// every command indexing or serving txs identifies them by the
+			// tx hash function of the config, as the node does
+			if err := types.SetTxHashFunc(conf.TxHash); err != nil {
+				return fmt.Errorf("error in config file: %w", err)
+			}
+			var logOptions []log.Option
+			if path := conf.LogFilePath(); path != "" {
+				logFile, err := log.OpenRotatingFile(path, conf.LogFileMaxBytes, conf.LogFileMaxAge, conf.LogFileMaxFiles)
+				if err != nil {
+					return fmt.Errorf("failed to open log file: %w", err)
+				}
+				logOptions = append(logOptions, log.WithOutput(logFile))
+			}
+			if err := log.OverrideWithNewLogger(logger, conf.LogFormat, conf.LogLevel, logOptions...); err != nil {
 				return err
 			}
 			if warning := pconf.DeprecatedFieldWarning(); warning != nil {
@@ -6,19 +6,36 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"github.com/spf13/cobra"
 
 	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
 )
 
 var (
 	genesisHash []byte
 )
 
+// ExitCodePlannedHalt is the exit code of the start command when the node
+// stops on a planned halt of consensus, at the halt height or time.
+const ExitCodePlannedHalt = 3
+
+// ExitError is an error of a command with the exit code of the process.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+func (e *ExitError) Unwrap() error { return e.Err }
+
 // AddNodeFlags exposes some common configuration options from conf in the flag
 // set for cmd. This is a convenience for commands embedding a Tendermint node.
 func AddNodeFlags(cmd *cobra.Command, conf *cfg.Config) {
@@ -78,6 +95,12 @@ func AddNodeFlags(cmd *cobra.Command, conf *cfg.Config) {
 		conf.Consensus.CreateEmptyBlocksInterval.String(),
 		"the possible interval between empty blocks")
 
+	// indexer flags
+	cmd.Flags().Bool(
+		"tx-index.psql-no-migrate",
+		conf.TxIndex.PsqlNoMigrate,
+		"do not apply the psql event sink schema migrations on startup")
+
 	addDBFlags(cmd, conf)
 }
 
@@ -99,6 +122,11 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider, conf *cfg.Config, logger lo
 		Use:     "start",
 		Aliases: []string{"node", "run"},
 		Short:   "Run the tendermint node",
+		Long: `Run the tendermint node.
+
+The node does not prompt for the passphrase of an encrypted key file: it must
+be set with the ` + privval.KeyPassphraseEnv + ` environment variable or the
+priv-validator.passphrase-file option.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := checkGenesisHash(conf); err != nil {
 				return err
@@ -118,8 +146,30 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider, conf *cfg.Config, logger lo
 
 			logger.Info("started node", "chain", conf.ChainID())
 
-			<-ctx.Done()
-			return nil
+			stopped := make(chan struct{})
+			go func() {
+				n.Wait()
+				close(stopped)
+			}()
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-stopped:
+				// The node stops on its own when consensus halts.
+				rec, err := consensus.LoadHaltRecord(conf.Consensus.HaltFile())
+				if err != nil || rec == nil || !rec.Planned() {
+					return fmt.Errorf("node stopped; see the consensus halt record in %s", conf.Consensus.HaltFile())
+				}
+				logger.Info("node stopped on the planned halt", "height", rec.Height)
+				if err := runHaltHook(cmd, conf, rec); err != nil {
+					return fmt.Errorf("node stopped on the planned halt at height %d, but the halt hook failed: %w",
+						rec.Height, err)
+				}
+				return &ExitError{
+					Code: ExitCodePlannedHalt,
+					Err:  fmt.Errorf("node stopped on the planned halt at height %d", rec.Height),
+				}
+			}
 		},
 	}
 
@@ -127,6 +177,23 @@ func NewRunNodeCmd(nodeProvider cfg.ServiceProvider, conf *cfg.Config, logger lo
 	return cmd
 }
 
+// runHaltHook runs the halt-hook command, if any, after the node stopped on
+// the planned halt of the record.
+func runHaltHook(cmd *cobra.Command, conf *cfg.Config, rec *consensus.HaltRecord) error {
+	if conf.Consensus.HaltHook == "" {
+		return nil
+	}
+	hook := exec.Command("sh", "-c", conf.Consensus.HaltHook)
+	hook.Stdout = cmd.OutOrStdout()
+	hook.Stderr = cmd.ErrOrStderr()
+	hook.Env = append(os.Environ(),
+		"TM_HALT_HEIGHT="+strconv.FormatInt(rec.Height, 10),
+		"TM_HALT_TIME="+strconv.FormatInt(rec.Time.Unix(), 10),
+		"TM_HOME="+conf.RootDir,
+	)
+	return hook.Run()
+}
+
 func checkGenesisHash(config *cfg.Config) error {
 	if len(genesisHash) == 0 || config.Genesis == "" {
 		return nil
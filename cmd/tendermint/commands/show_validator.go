@@ -12,7 +12,6 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
 	tmos "github.com/tendermint/tendermint/libs/os"
-	"github.com/tendermint/tendermint/privval"
 	tmgrpc "github.com/tendermint/tendermint/privval/grpc"
 )
 
@@ -56,7 +55,7 @@ func MakeShowValidatorCommand(conf *config.Config, logger log.Logger) *cobra.Com
 					return fmt.Errorf("private validator file %s does not exist", keyFilePath)
 				}
 
-				pv, err := privval.LoadFilePV(keyFilePath, conf.PrivValidator.StateFile())
+				pv, err := loadFilePV(cmd, conf.PrivValidator)
 				if err != nil {
 					return err
 				}
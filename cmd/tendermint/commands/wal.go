@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// MakeWALCommand constructs a command to inspect and repair the consensus WAL
+// of a stopped node.
+func MakeWALCommand(conf *config.Config, logger log.Logger) *cobra.Command {
+	walCmd := &cobra.Command{
+		Use:   "wal",
+		Short: "Inspect and repair the consensus write-ahead log",
+	}
+
+	inspectCmd := &cobra.Command{
+		Use:   "inspect [file...]",
+		Short: "Decode the records of WAL files up to their first corrupt record",
+		Long: `Decode the records of WAL files up to their first corrupt record, and print
+their number, the last height they end and the corruption found, if any.
+The files default to the consensus.wal-file of the node; the rotated files of
+the WAL, named after it with a numeric suffix, may be given as well.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, path := range walFiles(conf, args) {
+				info, err := consensus.InspectWALFile(path)
+				if err != nil {
+					return err
+				}
+				printWALFileInfo(cmd.OutOrStdout(), info)
+			}
+			return nil
+		},
+	}
+
+	repairCmd := &cobra.Command{
+		Use:   "repair [file...]",
+		Short: "Truncate WAL files at their first corrupt record",
+		Long: `Truncate WAL files at their first corrupt record, after backing them up next
+to them, as the node does when it starts with consensus.wal-repair enabled.
+The files default to the consensus.wal-file of the node. The node must be
+stopped.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, path := range walFiles(conf, args) {
+				backup, info, err := consensus.RepairWALFile(path)
+				if err != nil {
+					return err
+				}
+				if backup == "" {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s: no corrupt record\n", path)
+					continue
+				}
+				logger.Info("repaired WAL file", "file", path, "backup", backup,
+					"offset", info.CorruptOffset, "dropped_bytes", info.Size-info.CorruptOffset)
+				fmt.Fprintf(cmd.OutOrStdout(), "%s: truncated at offset %d, backed up to %s\n",
+					path, info.CorruptOffset, backup)
+			}
+			return nil
+		},
+	}
+
+	walCmd.AddCommand(inspectCmd)
+	walCmd.AddCommand(repairCmd)
+
+	return walCmd
+}
+
+func walFiles(conf *config.Config, args []string) []string {
+	if len(args) == 0 {
+		return []string{conf.Consensus.WalFile()}
+	}
+	return args
+}
+
+func printWALFileInfo(w io.Writer, info *consensus.WALFileInfo) {
+	fmt.Fprintf(w, "%s:\n", info.Path)
+	fmt.Fprintf(w, "  size:         %d bytes\n", info.Size)
+	fmt.Fprintf(w, "  records:      %d (%d in the version 1 format)\n", info.Records, info.LegacyRecords)
+	if info.LastHeight >= 0 {
+		fmt.Fprintf(w, "  end height:   %d\n", info.LastHeight)
+	}
+	if info.Corrupted() {
+		fmt.Fprintf(w, "  corrupted at: offset %d, %d bytes to drop: %v\n",
+			info.CorruptOffset, info.Size-info.CorruptOffset, info.CorruptErr)
+	} else {
+		fmt.Fprintf(w, "  corrupted at: none\n")
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"os"
 
 	"github.com/tendermint/tendermint/cmd/tendermint/commands"
 	"github.com/tendermint/tendermint/cmd/tendermint/commands/debug"
@@ -33,18 +35,25 @@ func main() {
 		commands.MakeLightCommand(conf, logger),
 		commands.MakeReplayCommand(conf, logger),
 		commands.MakeReplayConsoleCommand(conf, logger),
+		commands.MakeExportBlocksCommand(conf, logger),
+		commands.MakeImportBlocksCommand(conf, logger),
+		commands.MakeExportStateCommand(conf, logger),
+		commands.MakeImportStateCommand(conf, logger),
 		commands.MakeResetCommand(conf, logger),
 		commands.MakeShowValidatorCommand(conf, logger),
+		commands.MakeKeyCommand(conf, logger),
 		commands.MakeTestnetFilesCommand(conf, logger),
 		commands.MakeShowNodeIDCommand(conf),
-		commands.GenNodeKeyCmd,
+		commands.MakeGenNodeKeyCommand(),
 		commands.VersionCmd,
 		commands.MakeInspectCommand(conf, logger),
 		commands.MakeRollbackStateCommand(conf),
+		commands.MakeInPlaceTestnetCommand(conf, logger),
 		commands.MakeKeyMigrateCommand(conf, logger),
 		debug.GetDebugCommand(logger),
 		commands.NewCompletionCmd(rcmd, true),
 		commands.MakeCompactDBCommand(conf, logger),
+		commands.MakeWALCommand(conf, logger),
 	)
 
 	// NOTE:
@@ -54,13 +63,19 @@ func main() {
 	//	* Supply a genesis doc file from another source
 	//	* Provide their own DB implementation
 	// can copy this file and use something other than the
-	// node.NewDefault function
+	// node.NewDefault function, e.g. node.Provider with the options
+	// adding their reactors or replacing the mempool, block store
+	// or event sinks of the node.
 	nodeFunc := node.NewDefault
 
 	// Create & start node
 	rcmd.AddCommand(commands.NewRunNodeCmd(nodeFunc, conf, logger))
 
 	if err := cli.RunWithTrace(ctx, rcmd); err != nil {
+		var exitErr *commands.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		panic(err)
 	}
 }
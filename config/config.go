@@ -5,12 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
+	dbm "github.com/tendermint/tm-db"
+
+	tmstrings "github.com/tendermint/tendermint/internal/libs/strings"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/types"
@@ -48,7 +56,8 @@ var (
 	defaultPrivValKeyName   = "priv_validator_key.json"
 	defaultPrivValStateName = "priv_validator_state.json"
 
-	defaultNodeKeyName = "node_key.json"
+	defaultNodeKeyName         = "node_key.json"
+	defaultNodeKeyRotationName = "node_key_rotation.json"
 
 	defaultConfigFilePath   = filepath.Join(defaultConfigDir, defaultConfigFileName)
 	defaultGenesisJSONPath  = filepath.Join(defaultConfigDir, defaultGenesisJSONName)
@@ -70,6 +79,7 @@ type Config struct {
 	StateSync       *StateSyncConfig       `mapstructure:"statesync"`
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx-index"`
+	Storage         *StorageConfig         `mapstructure:"storage"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
 	PrivValidator   *PrivValidatorConfig   `mapstructure:"priv-validator"`
 }
@@ -84,6 +94,7 @@ func DefaultConfig() *Config {
 		StateSync:       DefaultStateSyncConfig(),
 		Consensus:       DefaultConsensusConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
+		Storage:         DefaultStorageConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
 		PrivValidator:   DefaultPrivValidatorConfig(),
 	}
@@ -106,6 +117,7 @@ func TestConfig() *Config {
 		StateSync:       TestStateSyncConfig(),
 		Consensus:       TestConsensusConfig(),
 		TxIndex:         TestTxIndexConfig(),
+		Storage:         TestStorageConfig(),
 		Instrumentation: TestInstrumentationConfig(),
 		PrivValidator:   DefaultPrivValidatorConfig(),
 	}
@@ -140,6 +152,12 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [consensus] section: %w", err)
 	}
+	if err := cfg.TxIndex.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [tx-index] section: %w", err)
+	}
+	if err := cfg.Storage.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [storage] section: %w", err)
+	}
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
@@ -205,25 +223,75 @@ type BaseConfig struct { //nolint: maligned
 	// Database directory
 	DBPath string `mapstructure:"db-dir"`
 
-	// Output level for logging
+	// Output level for logging, either a level or a comma-separated list of
+	// module:level pairs, such as "p2p:debug,consensus:info,*:error"
 	LogLevel string `mapstructure:"log-level"`
 
 	// Output format: 'plain' (colored text) or 'json'
 	LogFormat string `mapstructure:"log-format"`
 
+	// Path to the file to log to, instead of stderr
+	LogFile string `mapstructure:"log-file"`
+
+	// Maximum size of the log file, in bytes, above which it is rotated
+	LogFileMaxBytes int64 `mapstructure:"log-file-max-bytes"`
+
+	// Age of the log file after which it is rotated
+	LogFileMaxAge time.Duration `mapstructure:"log-file-max-age"`
+
+	// Number of rotated log files kept
+	LogFileMaxFiles int `mapstructure:"log-file-max-files"`
+
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis-file"`
 
+	// If not 0, the maximum size in bytes of the app state of the genesis
+	// file sent to the application in an InitChain request. A larger app
+	// state is delivered in chunks, in several InitChain requests, which the
+	// application must support.
+	InitChainChunkSize int `mapstructure:"init-chain-chunk-size"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node-key-file"`
 
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// ABCITimeout, if non-zero, is the deadline of each call to the ABCI
+	// application. A call that misses it fails with a timeout error. Note
+	// that the node halts when a consensus call, such as FinalizeBlock,
+	// fails, which is still preferable to hanging forever.
+	ABCITimeout time.Duration `mapstructure:"abci-timeout"`
+
+	// ABCICheckTxTimeout, if non-zero, is the deadline of each CheckTx call,
+	// in place of ABCITimeout. A new transaction whose check times out is
+	// rejected by the mempool.
+	ABCICheckTxTimeout time.Duration `mapstructure:"abci-check-tx-timeout"`
+
+	// ABCITLSCAFile, if set, is the path to a PEM file with the certificate
+	// authorities used to verify the certificate of a grpc ABCI application,
+	// and enables TLS for the connection to it. Relative paths are relative
+	// to tendermint's config directory.
+	ABCITLSCAFile string `mapstructure:"abci-tls-ca-file"`
+
+	// ABCITLSCertFile and ABCITLSKeyFile, if set, are the paths to the PEM
+	// certificate and private key with which the node authenticates itself
+	// to a grpc ABCI application over TLS, for mutual authentication.
+	ABCITLSCertFile string `mapstructure:"abci-tls-cert-file"`
+	ABCITLSKeyFile  string `mapstructure:"abci-tls-key-file"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter-peers"` // false
 
+	// TxHash is the name of the function computing the IDs of transactions,
+	// by which the mempool, the indexer and the RPC identify them. It must be
+	// "sha256" or a function registered by the application with
+	// types.RegisterTxHashFunc, which must be collision resistant. It is used
+	// by every command indexing or serving transactions. Changing it requires
+	// reindexing the transactions.
+	TxHash string `mapstructure:"tx-hash"`
+
 	Other map[string]interface{} `mapstructure:",remain"`
 }
 
@@ -239,8 +307,12 @@ func DefaultBaseConfig() BaseConfig {
 		LogLevel:    DefaultLogLevel,
 		LogFormat:   log.LogFormatPlain,
 		FilterPeers: false,
-		DBBackend:   "goleveldb",
-		DBPath:      "data",
+		TxHash:      "sha256",
+
+		LogFileMaxBytes: 100 * 1024 * 1024, // 100MB
+		LogFileMaxFiles: 10,
+		DBBackend:       "goleveldb",
+		DBPath:          "data",
 	}
 }
 
@@ -258,6 +330,11 @@ func (cfg BaseConfig) ChainID() string {
 	return cfg.chainID
 }
 
+// ConfigFile returns the full path to the config.toml file
+func (cfg BaseConfig) ConfigFile() string {
+	return rootify(defaultConfigFilePath, cfg.RootDir)
+}
+
 // GenesisFile returns the full path to the genesis.json file
 func (cfg BaseConfig) GenesisFile() string {
 	return rootify(cfg.Genesis, cfg.RootDir)
@@ -268,6 +345,24 @@ func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// NodeKeyRotationFile returns the full path to the node_key_rotation.json
+// file, next to the node key file, recording the last change of the node key.
+func (cfg BaseConfig) NodeKeyRotationFile() string {
+	return filepath.Join(filepath.Dir(cfg.NodeKeyFile()), defaultNodeKeyRotationName)
+}
+
+// ABCITLSFiles returns the full paths to the configured ABCI TLS files, or
+// empty strings for those that are not set.
+func (cfg BaseConfig) ABCITLSFiles() (certFile, keyFile, caFile string) {
+	path := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return rootify(filepath.Join(defaultConfigDir, p), cfg.RootDir)
+	}
+	return path(cfg.ABCITLSCertFile), path(cfg.ABCITLSKeyFile), path(cfg.ABCITLSCAFile)
+}
+
 // LoadNodeKey loads NodeKey located in filePath.
 func (cfg BaseConfig) LoadNodeKeyID() (types.NodeID, error) {
 	jsonBytes, err := os.ReadFile(cfg.NodeKeyFile())
@@ -308,6 +403,14 @@ func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
 }
 
+// LogFilePath returns the full path to the log file, or "" to log to stderr.
+func (cfg BaseConfig) LogFilePath() string {
+	if cfg.LogFile == "" {
+		return ""
+	}
+	return rootify(cfg.LogFile, cfg.RootDir)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg BaseConfig) ValidateBasic() error {
@@ -316,6 +419,18 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log format (must be 'plain', 'text' or 'json')")
 	}
+	if err := log.ValidateLevel(cfg.LogLevel); err != nil {
+		return fmt.Errorf("invalid log-level: %w", err)
+	}
+	if cfg.LogFileMaxBytes < 0 {
+		return errors.New("log-file-max-bytes can't be negative")
+	}
+	if cfg.LogFileMaxAge < 0 {
+		return errors.New("log-file-max-age can't be negative")
+	}
+	if cfg.LogFileMaxFiles < 0 {
+		return errors.New("log-file-max-files can't be negative")
+	}
 
 	switch cfg.Mode {
 	case ModeFull, ModeValidator, ModeSeed:
@@ -326,6 +441,28 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return fmt.Errorf("unknown mode: %v", cfg.Mode)
 	}
 
+	if cfg.ABCITimeout < 0 {
+		return errors.New("abci-timeout can't be negative")
+	}
+	if cfg.InitChainChunkSize < 0 {
+		return errors.New("init-chain-chunk-size can't be negative")
+	}
+	if cfg.ABCICheckTxTimeout < 0 {
+		return errors.New("abci-check-tx-timeout can't be negative")
+	}
+	if (cfg.ABCITLSCertFile == "") != (cfg.ABCITLSKeyFile == "") {
+		return errors.New("abci-tls-cert-file and abci-tls-key-file must be set together")
+	}
+	if cfg.ABCITLSCertFile != "" && cfg.ABCITLSCAFile == "" {
+		return errors.New("abci-tls-cert-file requires abci-tls-ca-file")
+	}
+	if cfg.ABCITLSCAFile != "" && cfg.ABCI != "grpc" {
+		return errors.New("abci-tls-ca-file requires the grpc abci transport")
+	}
+	if cfg.TxHash == "" {
+		return errors.New("tx-hash can't be empty")
+	}
+
 	return nil
 }
 
@@ -355,6 +492,12 @@ type PrivValidatorConfig struct {
 
 	// Path Root Certificate Authority used to sign both client and server certificates
 	RootCA string `mapstructure:"root-ca-file"`
+
+	// Path to the file containing the passphrase of an encrypted key file.
+	// The TM_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable takes
+	// precedence over the file. The node does not prompt for the passphrase,
+	// so one of them is required to start with an encrypted key file.
+	Passphrase string `mapstructure:"passphrase-file"`
 }
 
 // DefaultBaseConfig returns a default private validator configuration
@@ -386,6 +529,15 @@ func (cfg *PrivValidatorConfig) KeyFile() string {
 	return rootify(cfg.Key, cfg.RootDir)
 }
 
+// PassphraseFile returns the full path to the file containing the passphrase
+// of an encrypted key file, or an empty string if not set.
+func (cfg *PrivValidatorConfig) PassphraseFile() string {
+	if cfg.Passphrase == "" {
+		return ""
+	}
+	return rootify(cfg.Passphrase, cfg.RootDir)
+}
+
 // StateFile returns the full path to the priv_validator_state.json file
 func (cfg *PrivValidatorConfig) StateFile() string {
 	return rootify(cfg.State, cfg.RootDir)
@@ -411,9 +563,25 @@ func (cfg *PrivValidatorConfig) AreSecurityOptionsPresent() bool {
 type RPCConfig struct {
 	RootDir string `mapstructure:"home"`
 
-	// TCP or UNIX socket address for the RPC server to listen on
+	// Comma separated list of TCP or UNIX socket addresses for the RPC server
+	// to listen on, e.g. an IPv4 and an IPv6 address
 	ListenAddress string `mapstructure:"laddr"`
 
+	// TCP or UNIX socket address for the gRPC event stream server to listen
+	// on. If empty (the default), the gRPC server is disabled.
+	GRPCListenAddress string `mapstructure:"grpc-laddr"`
+
+	// Maximum number of simultaneous gRPC connections.
+	// 0 - unlimited.
+	GRPCMaxOpenConnections int `mapstructure:"grpc-max-open-connections"`
+
+	// GRPCDataCompanion enables the data companion services on the gRPC
+	// server, which let an external process fetch blocks and block results
+	// and set retain heights for each. While enabled, the node only prunes the
+	// blocks, and the block results, below both the retain heights of the
+	// application and of the companion.
+	GRPCDataCompanion bool `mapstructure:"grpc-data-companion"`
+
 	// A list of origins a cross-domain request can be executed from.
 	// If the special '*' value is present in the list, all origins will be allowed.
 	// An origin may contain a wildcard (*) to replace 0 or more characters (i.e.: http://*.domain.com).
@@ -426,9 +594,38 @@ type RPCConfig struct {
 	// A list of non simple headers the client is allowed to use with cross-domain requests.
 	CORSAllowedHeaders []string `mapstructure:"cors-allowed-headers"`
 
-	// Activate unsafe RPC commands like /dial-persistent-peers and /unsafe-flush-mempool
+	// Origins a cross-domain request to a path can be executed from, as
+	// "path:origin", e.g. "/broadcast_tx_sync:https://wallet.example.com".
+	// For the paths listed, with or without the API version prefix, these
+	// origins replace CORSAllowedOrigins. The JSON-RPC requests, sent to the
+	// path "/", are allowed from the origins of "/" whatever their method, so
+	// that a method restricted here can still be called with JSON-RPC from the
+	// origins allowed for "/".
+	CORSPathAllowedOrigins []string `mapstructure:"cors-path-allowed-origins"`
+
+	// Activate unsafe RPC commands like /dial-persistent-peers, /unsafe-flush-mempool and /remove_tx
 	Unsafe bool `mapstructure:"unsafe"`
 
+	// RPC methods to disable on all the listeners, e.g. ["dump_consensus_state"]
+	DisabledMethods []string `mapstructure:"disabled-methods"`
+
+	// TCP or UNIX socket address of a separate RPC listener, serving all the
+	// methods including the unsafe ones to the clients authenticated with
+	// privileged-auth-token, privileged-tls-client-ca-file or both. If set,
+	// the unsafe methods are only served on this listener, whatever the value
+	// of unsafe.
+	PrivilegedListenAddress string `mapstructure:"privileged-laddr"`
+
+	// The bearer token required in the Authorization header of the requests
+	// to privileged-laddr.
+	PrivilegedAuthToken string `mapstructure:"privileged-auth-token"`
+
+	// The path to a file containing the certificate authorities of the client
+	// certificates required by privileged-laddr, which then requires
+	// tls-cert-file and tls-key-file. Might be either absolute path or path
+	// related to Tendermint's config directory.
+	PrivilegedTLSClientCAFile string `mapstructure:"privileged-tls-client-ca-file"`
+
 	// Maximum number of simultaneous connections (including WebSocket).
 	// If you want to accept a larger number than the default, make sure
 	// you increase your OS limits.
@@ -437,6 +634,28 @@ type RPCConfig struct {
 	// 1024 - 40 - 10 - 50 = 924 = ~900
 	MaxOpenConnections int `mapstructure:"max-open-connections"`
 
+	// Maximum number of simultaneous connections from each client IP address.
+	// 0 - unlimited.
+	MaxOpenConnectionsPerIP int `mapstructure:"max-open-connections-per-ip"`
+
+	// Average number of requests per second of each client, by IP address or
+	// API key, over HTTP and WebSocket. Requests over the limit get status 429
+	// Too Many Requests over HTTP, with a Retry-After header.
+	// 0 - unlimited.
+	RateLimit float64 `mapstructure:"rate-limit"`
+
+	// Maximum number of requests of each client at once, with rate-limit.
+	// A batch, or a method, costing more than the burst is always rejected.
+	RateLimitBurst int `mapstructure:"rate-limit-burst"`
+
+	// Costs of the methods that cost more, or less, than a single request
+	// with rate-limit, as "method:cost", e.g. "tx_search:10".
+	RateLimitMethodCosts []string `mapstructure:"rate-limit-method-costs"`
+
+	// API keys, sent by clients in the X-API-Key header, whose clients are
+	// limited per key with rate-limit, rather than per IP address.
+	RateLimitAPIKeys []string `mapstructure:"rate-limit-api-keys"`
+
 	// Maximum number of unique clientIDs that can /subscribe
 	// If you're using /broadcast_tx_commit, set to the estimated maximum number
 	// of broadcast_tx_commit calls per block.
@@ -447,6 +666,19 @@ type RPCConfig struct {
 	// to the estimated maximum number of broadcast_tx_commit calls per block.
 	MaxSubscriptionsPerClient int `mapstructure:"max-subscriptions-per-client"`
 
+	// Number of events buffered for each /subscribe subscription while the
+	// client is reading slower than events are published. 0 selects the
+	// default of 100.
+	SubscriptionBufferSize int `mapstructure:"subscription-buffer-size"`
+
+	// What to do with a /subscribe subscription when its buffer is full:
+	//   "disconnect" (default) - terminate the subscription
+	//   "drop-oldest" - discard the oldest buffered event
+	//   "drop-newest" - discard the event that does not fit
+	// Dropped events show up as gaps in the seq numbers of the events
+	// delivered.
+	SubscriptionOverflowPolicy string `mapstructure:"subscription-overflow-policy"`
+
 	// If true, disable the websocket interface to the RPC service.  This has
 	// the effect of disabling the /subscribe, /unsubscribe, and /unsubscribe_all
 	// methods for event subscription.
@@ -474,6 +706,12 @@ type RPCConfig struct {
 	// up to 2000, choose a value > 2000.
 	EventLogMaxItems int `mapstructure:"event-log-max-items"`
 
+	// The number of recent events retained so that a WebSocket client can
+	// resume a subscription after reconnecting, by passing the sequence
+	// number of the last event it received as the after_seq parameter of
+	// /subscribe. If 0 (the default), subscriptions cannot be resumed.
+	SubscriptionReplayWindow int `mapstructure:"subscription-replay-window"`
+
 	// How long to wait for a tx to be committed during /broadcast_tx_commit
 	// WARNING: Using a value larger than 10s will result in increasing the
 	// global HTTP write timeout, which applies to all connections and endpoints.
@@ -486,6 +724,33 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max-header-bytes"`
 
+	// Maximum number of requests in a JSON-RPC batch request, 0 for no limit
+	MaxRequestBatchSize int `mapstructure:"max-request-batch-size"`
+
+	// Compress the responses of at least 1KiB with zstd or gzip, for the
+	// clients that accept either of them
+	CompressResponses bool `mapstructure:"compress-responses"`
+
+	// The number of rounds of a height after which /health reports consensus
+	// as stalled, 0 to never report it
+	HealthStalledRounds int `mapstructure:"health-stalled-rounds"`
+
+	// How long the background probes of /health and /ready, every 5 seconds,
+	// wait for the application to answer an Info request before reporting it
+	// unresponsive
+	HealthABCITimeout time.Duration `mapstructure:"health-abci-timeout"`
+
+	// Enable /simulate_tx. It runs CheckTx like a new transaction, which an
+	// application updating its check state, e.g. with the sequence of the
+	// sender, treats as seen: the same transaction may then be rejected by
+	// CheckTx until the next block resets the check state
+	SimulateTx bool `mapstructure:"simulate-tx"`
+
+	// The path of the ABCI query by which /simulate_tx asks the application
+	// to simulate the execution of a transaction, e.g. for its gas used and
+	// events. If empty, /simulate_tx only runs CheckTx
+	SimulateQueryPath string `mapstructure:"simulate-query-path"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Might be either absolute path or path related to Tendermint's config directory.
 	//
@@ -504,6 +769,34 @@ type RPCConfig struct {
 	// Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls-key-file"`
 
+	// Domains for which the certificate of the HTTPS server is obtained, and
+	// renewed, automatically from an ACME certificate authority, instead of
+	// tls-cert-file and tls-key-file. The certificate authority validates the
+	// domains with the TLS-ALPN-01 challenge, so it must reach the RPC server
+	// on port 443 of the domains.
+	TLSACMEDomains []string `mapstructure:"tls-acme-domains"`
+
+	// The contact email of the ACME account, for the notices of the
+	// certificate authority.
+	TLSACMEEmail string `mapstructure:"tls-acme-email"`
+
+	// The directory URL of the ACME certificate authority. If empty, Let's
+	// Encrypt is used.
+	TLSACMEDirectoryURL string `mapstructure:"tls-acme-directory-url"`
+
+	// The directory caching the ACME account key and the certificates, so that
+	// they are not requested again on restart. Might be either absolute path
+	// or path related to Tendermint's home directory.
+	TLSACMECacheDir string `mapstructure:"tls-acme-cache-dir"`
+
+	// Serve HTTP/2: negotiated over TLS, and without TLS (h2c) with the
+	// clients knowing the server supports it. If false, only HTTP/1.1 is
+	// served. A connection has at most 100 concurrent streams, but the
+	// golang.org/x/net version in use predates the mitigation of the rapid
+	// reset attack (CVE-2023-44487), so it should only be enabled behind a
+	// proxy terminating HTTP/2.
+	HTTP2 bool `mapstructure:"http2"`
+
 	// pprof listen address (https://golang.org/pkg/net/http/pprof)
 	PprofListenAddress string `mapstructure:"pprof-laddr"`
 }
@@ -517,22 +810,42 @@ func DefaultRPCConfig() *RPCConfig {
 		CORSAllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "X-Server-Time"},
 
 		Unsafe:             false,
+		DisabledMethods:    []string{},
 		MaxOpenConnections: 900,
 
+		RateLimit:            0,
+		RateLimitBurst:       20,
+		RateLimitMethodCosts: []string{"tx_search:10", "block_search:10", "simulate_tx:10"},
+		RateLimitAPIKeys:     []string{},
+
+		GRPCListenAddress:      "",
+		GRPCMaxOpenConnections: 900,
+
 		// Settings for event subscription.
 		MaxSubscriptionClients:       100,
 		MaxSubscriptionsPerClient:    5,
+		SubscriptionBufferSize:       100,
+		SubscriptionOverflowPolicy:   "disconnect",
 		ExperimentalDisableWebsocket: false, // compatible with TM v0.35 and earlier
 		EventLogWindowSize:           30 * time.Second,
 		EventLogMaxItems:             0,
+		SubscriptionReplayWindow:     0,
 
 		TimeoutBroadcastTxCommit: 10 * time.Second,
 
-		MaxBodyBytes:   int64(1000000), // 1MB
-		MaxHeaderBytes: 1 << 20,        // same as the net/http default
+		MaxBodyBytes:        int64(1000000), // 1MB
+		MaxHeaderBytes:      1 << 20,        // same as the net/http default
+		MaxRequestBatchSize: 10,
+		CompressResponses:   true,
 
-		TLSCertFile: "",
-		TLSKeyFile:  "",
+		HealthStalledRounds: 3,
+		HealthABCITimeout:   time.Second,
+
+		TLSCertFile:     "",
+		TLSKeyFile:      "",
+		TLSACMEDomains:  []string{},
+		TLSACMECacheDir: filepath.Join(defaultDataDir, "acme"),
+		HTTP2:           false,
 	}
 }
 
@@ -547,21 +860,47 @@ func TestRPCConfig() *RPCConfig {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *RPCConfig) ValidateBasic() error {
+	if cfg.HealthStalledRounds < 0 {
+		return errors.New("health-stalled-rounds can't be negative")
+	}
+	if cfg.HealthABCITimeout < 0 {
+		return errors.New("health-abci-timeout can't be negative")
+	}
+	if cfg.SimulateQueryPath != "" && !strings.HasPrefix(cfg.SimulateQueryPath, "/") {
+		return errors.New("simulate-query-path must start with /")
+	}
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max-open-connections can't be negative")
 	}
+	if cfg.GRPCMaxOpenConnections < 0 {
+		return errors.New("grpc-max-open-connections can't be negative")
+	}
+	if cfg.GRPCDataCompanion && cfg.GRPCListenAddress == "" {
+		return errors.New("grpc-data-companion requires grpc-laddr")
+	}
 	if cfg.MaxSubscriptionClients < 0 {
 		return errors.New("max-subscription-clients can't be negative")
 	}
 	if cfg.MaxSubscriptionsPerClient < 0 {
 		return errors.New("max-subscriptions-per-client can't be negative")
 	}
+	if cfg.SubscriptionBufferSize < 0 {
+		return errors.New("subscription-buffer-size can't be negative")
+	}
+	switch cfg.SubscriptionOverflowPolicy {
+	case "", "disconnect", "drop-oldest", "drop-newest":
+	default:
+		return fmt.Errorf("unknown subscription-overflow-policy %q", cfg.SubscriptionOverflowPolicy)
+	}
 	if cfg.EventLogWindowSize < 0 {
 		return errors.New("event-log-window-size must not be negative")
 	}
 	if cfg.EventLogMaxItems < 0 {
 		return errors.New("event-log-max-items must not be negative")
 	}
+	if cfg.SubscriptionReplayWindow < 0 {
+		return errors.New("subscription-replay-window must not be negative")
+	}
 	if cfg.TimeoutBroadcastTxCommit < 0 {
 		return errors.New("timeout-broadcast-tx-commit can't be negative")
 	}
@@ -571,14 +910,83 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max-header-bytes can't be negative")
 	}
+	if cfg.MaxRequestBatchSize < 0 {
+		return errors.New("max-request-batch-size can't be negative")
+	}
+	if cfg.MaxOpenConnectionsPerIP < 0 {
+		return errors.New("max-open-connections-per-ip can't be negative")
+	}
+	if cfg.RateLimit < 0 {
+		return errors.New("rate-limit can't be negative")
+	}
+	if cfg.RateLimit > 0 && cfg.RateLimitBurst < 1 {
+		return errors.New("rate-limit-burst must be positive with rate-limit")
+	}
+	if _, err := cfg.MethodCosts(); err != nil {
+		return fmt.Errorf("invalid rate-limit-method-costs: %w", err)
+	}
+	if cfg.PrivilegedListenAddress != "" {
+		if cfg.PrivilegedAuthToken == "" && cfg.PrivilegedTLSClientCAFile == "" {
+			return errors.New("privileged-laddr requires privileged-auth-token or privileged-tls-client-ca-file")
+		}
+		if cfg.PrivilegedTLSClientCAFile != "" && !cfg.IsTLSEnabled() && !cfg.IsACMEEnabled() {
+			return errors.New("privileged-tls-client-ca-file requires tls-cert-file and tls-key-file, or tls-acme-domains")
+		}
+	}
+	if cfg.IsACMEEnabled() {
+		if cfg.TLSCertFile != "" || cfg.TLSKeyFile != "" {
+			return errors.New("tls-acme-domains can't be set with tls-cert-file and tls-key-file")
+		}
+		for _, domain := range cfg.TLSACMEDomains {
+			if domain == "" || strings.ContainsAny(domain, ":/ ") {
+				return fmt.Errorf("invalid tls-acme-domains domain %q", domain)
+			}
+		}
+		if cfg.TLSACMECacheDir == "" {
+			return errors.New("tls-acme-domains requires tls-acme-cache-dir")
+		}
+	}
+	if _, err := cfg.CORSPathOrigins(); err != nil {
+		return fmt.Errorf("invalid cors-path-allowed-origins: %w", err)
+	}
 	return nil
 }
 
+// MethodCosts returns the costs of the methods of RateLimitMethodCosts.
+func (cfg *RPCConfig) MethodCosts() (map[string]int, error) {
+	costs := make(map[string]int, len(cfg.RateLimitMethodCosts))
+	for _, s := range cfg.RateLimitMethodCosts {
+		parts := strings.SplitN(s, ":", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("%q is not of the form method:cost", s)
+		}
+		cost, err := strconv.Atoi(parts[1])
+		if err != nil || cost < 0 {
+			return nil, fmt.Errorf("invalid cost of %q: %q", parts[0], parts[1])
+		}
+		costs[parts[0]] = cost
+	}
+	return costs, nil
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
 	return len(cfg.CORSAllowedOrigins) != 0
 }
 
+// CORSPathOrigins returns the origins of each path of CORSPathAllowedOrigins.
+func (cfg *RPCConfig) CORSPathOrigins() (map[string][]string, error) {
+	origins := make(map[string][]string)
+	for _, s := range cfg.CORSPathAllowedOrigins {
+		parts := strings.SplitN(s, ":", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") || parts[1] == "" {
+			return nil, fmt.Errorf("%q is not of the form path:origin", s)
+		}
+		origins[parts[0]] = append(origins[parts[0]], parts[1])
+	}
+	return origins, nil
+}
+
 func (cfg RPCConfig) KeyFile() string {
 	path := cfg.TLSKeyFile
 	if filepath.IsAbs(path) {
@@ -595,10 +1003,28 @@ func (cfg RPCConfig) CertFile() string {
 	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
 }
 
+func (cfg RPCConfig) PrivilegedClientCAFile() string {
+	path := cfg.PrivilegedTLSClientCAFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
 func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
 
+// IsACMEEnabled returns true if the certificate of the HTTPS server is obtained
+// from an ACME certificate authority.
+func (cfg RPCConfig) IsACMEEnabled() bool {
+	return len(cfg.TLSACMEDomains) != 0
+}
+
+func (cfg RPCConfig) ACMECacheDir() string {
+	return rootify(cfg.TLSACMECacheDir, cfg.RootDir)
+}
+
 //-----------------------------------------------------------------------------
 // P2PConfig
 
@@ -606,10 +1032,12 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 type P2PConfig struct { //nolint: maligned
 	RootDir string `mapstructure:"home"`
 
-	// Address to listen for incoming connections
+	// Comma separated list of addresses to listen for incoming connections,
+	// e.g. an IPv4 and an IPv6 address, see ListenAddresses
 	ListenAddress string `mapstructure:"laddr"`
 
-	// Address to advertise to peers for them to dial
+	// Comma separated list of addresses to advertise to peers for them to
+	// dial, see ExternalAddresses
 	ExternalAddress string `mapstructure:"external-address"`
 
 	// Comma separated list of peers to be added to the peer store
@@ -620,7 +1048,8 @@ type P2PConfig struct { //nolint: maligned
 	// Comma separated list of nodes to keep persistent connections to
 	PersistentPeers string `mapstructure:"persistent-peers"`
 
-	// UPNP port forwarding
+	// Map the P2P port on the NAT gateway with UPnP or NAT-PMP, and advertise
+	// the mapped external address to peers. Ignored if ExternalAddress is set.
 	UPNP bool `mapstructure:"upnp"`
 
 	// MaxConnections defines the maximum number of connected peers (inbound and
@@ -642,6 +1071,19 @@ type P2PConfig struct { //nolint: maligned
 	// other peers)
 	PrivatePeerIDs string `mapstructure:"private-peer-ids"`
 
+	// Comma separated list of the IDs of persistent peers to peer with
+	// privately, like a validator and its sentries: they get dedicated
+	// connection slots, are always redialed and are never gossiped
+	PrivatePeeringIDs string `mapstructure:"private-peering-ids"`
+
+	// Comma separated list of node IDs, IPs and CIDR ranges of the only peers
+	// allowed to connect, or empty to allow all peers
+	AllowedPeers string `mapstructure:"allowed-peers"`
+
+	// Comma separated list of node IDs, IPs and CIDR ranges of the peers not
+	// allowed to connect, which takes precedence over AllowedPeers
+	BlockedPeers string `mapstructure:"blocked-peers"`
+
 	// Time to wait before flushing messages out on the connection
 	FlushThrottleTimeout time.Duration `mapstructure:"flush-throttle-timeout"`
 
@@ -654,6 +1096,19 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv-rate"`
 
+	// Rates at which packets can be sent on each channel of the consensus,
+	// mempool, blocksync and evidence reactors, in bytes/second, within
+	// SendRate. Zero means only limited by SendRate.
+	ConsensusSendRate int64 `mapstructure:"consensus-send-rate"`
+	MempoolSendRate   int64 `mapstructure:"mempool-send-rate"`
+	BlockSyncSendRate int64 `mapstructure:"blocksync-send-rate"`
+	EvidenceSendRate  int64 `mapstructure:"evidence-send-rate"`
+
+	// Whether to always send packets of the channel of highest priority first,
+	// rather than share the send rate between channels in proportion to their
+	// priorities.
+	StrictChannelPriority bool `mapstructure:"strict-channel-priority"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake-timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial-timeout"`
@@ -704,9 +1159,103 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv-rate can't be negative")
 	}
+	if cfg.ConsensusSendRate < 0 {
+		return errors.New("consensus-send-rate can't be negative")
+	}
+	if cfg.MempoolSendRate < 0 {
+		return errors.New("mempool-send-rate can't be negative")
+	}
+	if cfg.BlockSyncSendRate < 0 {
+		return errors.New("blocksync-send-rate can't be negative")
+	}
+	if cfg.EvidenceSendRate < 0 {
+		return errors.New("evidence-send-rate can't be negative")
+	}
 	if cfg.MaxOutgoingConnections > cfg.MaxConnections {
 		return errors.New("max-outgoing-connections cannot be larger than max-connections")
 	}
+	if err := cfg.validatePrivatePeeringIDs(); err != nil {
+		return err
+	}
+	for _, addr := range cfg.ListenAddresses() {
+		if err := validateHostPort(addr); err != nil {
+			return fmt.Errorf("invalid laddr %q: %w", addr, err)
+		}
+	}
+	for _, addr := range cfg.ExternalAddresses() {
+		if err := validateHostPort(addr); err != nil {
+			return fmt.Errorf("invalid external-address %q: %w", addr, err)
+		}
+	}
+	if err := validatePeerFilterList(cfg.AllowedPeers); err != nil {
+		return fmt.Errorf("invalid allowed-peers: %w", err)
+	}
+	if err := validatePeerFilterList(cfg.BlockedPeers); err != nil {
+		return fmt.Errorf("invalid blocked-peers: %w", err)
+	}
+	return nil
+}
+
+// ListenAddresses returns the addresses of laddr the node listens on for
+// incoming connections.
+func (cfg *P2PConfig) ListenAddresses() []string {
+	return tmstrings.SplitAndTrimEmpty(cfg.ListenAddress, ",", " ")
+}
+
+// ExternalAddresses returns the addresses of external-address the node
+// advertises to peers, or none if the node doesn't advertise its addresses.
+func (cfg *P2PConfig) ExternalAddresses() []string {
+	return tmstrings.SplitAndTrimEmpty(cfg.ExternalAddress, ",", " ")
+}
+
+// validateHostPort checks that the address, with an optional protocol, has a
+// host and a port. IPv6 hosts must be in brackets, e.g. "tcp://[::]:26656".
+func validateHostPort(addr string) error {
+	if i := strings.Index(addr, "://"); i >= 0 {
+		addr = addr[i+3:]
+	}
+	_, _, err := net.SplitHostPort(addr)
+	return err
+}
+
+// validatePrivatePeeringIDs checks that the private peering IDs are the IDs of
+// persistent peers.
+func (cfg *P2PConfig) validatePrivatePeeringIDs() error {
+	persistent := make(map[string]bool)
+	for _, addr := range tmstrings.SplitAndTrimEmpty(cfg.PersistentPeers, ",", " ") {
+		if i := strings.Index(addr, "://"); i >= 0 {
+			addr = addr[i+3:]
+		}
+		if i := strings.Index(addr, "@"); i >= 0 {
+			persistent[strings.ToLower(addr[:i])] = true
+		}
+	}
+	for _, id := range tmstrings.SplitAndTrimEmpty(cfg.PrivatePeeringIDs, ",", " ") {
+		nodeID, err := types.NewNodeID(id)
+		if err != nil {
+			return fmt.Errorf("invalid private-peering-ids: %w", err)
+		}
+		if !persistent[string(nodeID)] {
+			return fmt.Errorf("private peering ID %s is not the ID of a persistent peer", id)
+		}
+	}
+	return nil
+}
+
+// validatePeerFilterList checks that every entry of a comma separated list is
+// a node ID, an IP or a CIDR range.
+func validatePeerFilterList(list string) error {
+	for _, entry := range tmstrings.SplitAndTrimEmpty(list, ",", " ") {
+		if _, err := types.NewNodeID(entry); err == nil {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(entry); err == nil {
+			continue
+		}
+		if net.ParseIP(entry) == nil {
+			return fmt.Errorf("%q is neither a node ID, an IP nor a CIDR range", entry)
+		}
+	}
 	return nil
 }
 
@@ -744,6 +1293,10 @@ type MempoolConfig struct {
 	// valid again in the future.
 	KeepInvalidTxsInCache bool `mapstructure:"keep-invalid-txs-in-cache"`
 
+	// Number of the transactions evicted last from the mempool whose eviction
+	// is reported by the tx_status RPC method.
+	EvictedCacheSize int `mapstructure:"evicted-cache-size"`
+
 	// Maximum size of a single transaction
 	// NOTE: the max size of a tx transmitted over the network is {max-tx-bytes}.
 	MaxTxBytes int `mapstructure:"max-tx-bytes"`
@@ -768,6 +1321,173 @@ type MempoolConfig struct {
 	// has existed in the mempool at least TTLNumBlocks number of blocks or if
 	// it's insertion time into the mempool is beyond TTLDuration.
 	TTLNumBlocks int64 `mapstructure:"ttl-num-blocks"`
+
+	// RebroadcastInterval, if non-zero, enables the periodic rebroadcast of
+	// transactions submitted to this node (e.g. via RPC) that are still in the
+	// mempool. A local transaction is first rebroadcast to all connected peers
+	// this long after it was added, and the delay doubles after every
+	// rebroadcast, up to RebroadcastMaxInterval.
+	RebroadcastInterval time.Duration `mapstructure:"rebroadcast-interval"`
+
+	// RebroadcastMaxInterval caps the delay between two rebroadcasts of the
+	// same transaction.
+	RebroadcastMaxInterval time.Duration `mapstructure:"rebroadcast-max-interval"`
+
+	// DOGProtocolEnabled enables the dynamic optimal graph (DOG) gossip
+	// protocol, which reduces the number of duplicate transactions received
+	// from peers by asking them to stop forwarding transactions along
+	// redundant routes.
+	//
+	// Peers running a version of Tendermint that does not support the
+	// protocol disconnect from a node that has it enabled.
+	DOGProtocolEnabled bool `mapstructure:"dog-protocol-enabled"`
+
+	// DOGTargetRedundancy is the ratio of duplicate to first-time transactions
+	// received from peers that the DOG protocol aims to maintain. Lower values
+	// save more bandwidth, at the price of a lower resilience to faulty peers.
+	DOGTargetRedundancy float64 `mapstructure:"dog-target-redundancy"`
+
+	// DOGAdjustInterval is how often the DOG protocol measures the redundancy
+	// and adjusts the gossip routes.
+	DOGAdjustInterval time.Duration `mapstructure:"dog-adjust-interval"`
+
+	// AnnounceTxs enables the announcement of transactions by their keys to
+	// the peers which support it, which request the transactions they do not
+	// have, instead of sending them the full transactions, which most peers of
+	// a well connected node already have. It is negotiated with each peer as
+	// version 2 of the mempool channels, and the full transactions are sent
+	// to the peers that do not support it.
+	AnnounceTxs bool `mapstructure:"announce-txs"`
+
+	// PeerMaxTxsPerSecond, if non-zero, is the maximum number of transactions
+	// per second the mempool accepts from a single peer.
+	PeerMaxTxsPerSecond int `mapstructure:"peer-max-txs-per-second"`
+
+	// PeerMaxBytesPerSecond, if non-zero, is the maximum number of transaction
+	// bytes per second the mempool accepts from a single peer. It must not be
+	// less than MaxTxBytes.
+	PeerMaxBytesPerSecond int64 `mapstructure:"peer-max-bytes-per-second"`
+
+	// PeerMuteDuration is how long the mempool drops all transactions from a
+	// peer that exceeded one of the per-peer limits.
+	PeerMuteDuration time.Duration `mapstructure:"peer-mute-duration"`
+
+	// MaxTxsPerSender, if non-zero, is the maximum number of transactions in
+	// the mempool with the same sender, as assigned by the application in its
+	// CheckTx responses. Transactions without a sender are not limited. A
+	// sender over the limit has its lowest-priority transactions replaced by
+	// the ones with a higher priority.
+	MaxTxsPerSender int `mapstructure:"max-txs-per-sender"`
+
+	// MaxGasPerSender, if non-zero, is the maximum total gas wanted by the
+	// transactions in the mempool with the same sender.
+	//
+	// A transaction exceeding one of the per-sender limits evicts the
+	// lowest-priority transactions of its sender with a lower priority than
+	// its own, or is rejected if they are not enough to bring the sender
+	// within the limits.
+	MaxGasPerSender int64 `mapstructure:"max-gas-per-sender"`
+
+	// Lanes, if non-empty, splits the mempool into lanes, to which the
+	// application assigns transactions in its CheckTx responses. Each lane
+	// is given as "name:weight:size". Blocks are filled by taking, in turn,
+	// up to weight transactions from each lane in the listed order, and at
+	// most size transactions are kept in the lane (zero means no per-lane
+	// limit). Each lane is gossiped on its own p2p channel, with the weight
+	// as channel priority. At most MaxMempoolLanes lanes are supported.
+	Lanes []string `mapstructure:"lanes"`
+
+	// DefaultLane is the lane of the transactions for which the application
+	// does not assign one of the configured lanes. It must be set if Lanes
+	// is non-empty.
+	DefaultLane string `mapstructure:"default-lane"`
+
+	// RecheckConcurrency is the maximum number of CheckTx calls in flight
+	// while rechecking the transactions left in the mempool after a block.
+	// Zero means twice the number of CPUs.
+	RecheckConcurrency int `mapstructure:"recheck-concurrency"`
+
+	// RecheckConnections is the number of additional connections opened to a
+	// remote ABCI application, across which the rechecks are spread with the
+	// mempool connection. It has no effect with a builtin application, whose
+	// CheckTx calls are already made concurrently.
+	RecheckConnections int `mapstructure:"recheck-connections"`
+
+	// RecheckMode is either RecheckModeFull, to recheck all the transactions
+	// left in the mempool after a block, or RecheckModePartial, to only
+	// recheck those whose sender the application reports as affected by the
+	// block with recheck events in its transaction results.
+	RecheckMode string `mapstructure:"recheck-mode"`
+
+	// PersistInterval, if non-zero, enables the persistence of the mempool
+	// across restarts: its transactions are written to PersistFile this
+	// often and when the node stops, and checked again to restore them when
+	// it starts.
+	PersistInterval time.Duration `mapstructure:"persist-interval"`
+
+	// PersistFile is the path to the file the transactions of the mempool
+	// are persisted to, relative to the home directory if not absolute.
+	PersistFile string `mapstructure:"persist-file"`
+}
+
+// The modes of the recheck of the mempool, see MempoolConfig.RecheckMode.
+const (
+	RecheckModeFull    = "full"
+	RecheckModePartial = "partial"
+)
+
+// PersistFilePath returns the full path to the file the mempool is
+// persisted to.
+func (cfg *MempoolConfig) PersistFilePath() string {
+	return rootify(cfg.PersistFile, cfg.RootDir)
+}
+
+// MaxMempoolLanes is the maximum number of mempool lanes.
+const MaxMempoolLanes = 8
+
+// MempoolLane is a mempool lane, as configured in MempoolConfig.Lanes.
+type MempoolLane struct {
+	Name   string
+	Weight int
+	Size   int
+}
+
+// MempoolLanes parses the configured mempool lanes.
+func (cfg *MempoolConfig) MempoolLanes() ([]MempoolLane, error) {
+	if len(cfg.Lanes) > MaxMempoolLanes {
+		return nil, fmt.Errorf("at most %d lanes are supported", MaxMempoolLanes)
+	}
+
+	lanes := make([]MempoolLane, 0, len(cfg.Lanes))
+	seen := make(map[string]bool, len(cfg.Lanes))
+	for _, spec := range cfg.Lanes {
+		parts := strings.Split(spec, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("lane %q: must be name:weight:size", spec)
+		}
+		if seen[parts[0]] {
+			return nil, fmt.Errorf("lane %q: duplicate name", spec)
+		}
+		seen[parts[0]] = true
+
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 1 {
+			return nil, fmt.Errorf("lane %q: weight must be a positive integer", spec)
+		}
+		size, err := strconv.Atoi(parts[2])
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("lane %q: size must be a non-negative integer", spec)
+		}
+		lanes = append(lanes, MempoolLane{Name: parts[0], Weight: weight, Size: size})
+	}
+
+	switch {
+	case len(lanes) == 0 && cfg.DefaultLane != "":
+		return nil, errors.New("default-lane requires lanes")
+	case len(lanes) != 0 && !seen[cfg.DefaultLane]:
+		return nil, fmt.Errorf("default-lane %q is not one of the lanes", cfg.DefaultLane)
+	}
+	return lanes, nil
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool.
@@ -782,6 +1502,31 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxBytes:   1024 * 1024, // 1MB
 		TTLDuration:  0 * time.Second,
 		TTLNumBlocks: 0,
+
+		EvictedCacheSize: 10000,
+
+		RebroadcastInterval:    0 * time.Second,
+		RebroadcastMaxInterval: 10 * time.Minute,
+
+		DOGProtocolEnabled:  false,
+		DOGTargetRedundancy: 1,
+		DOGAdjustInterval:   time.Second,
+
+		AnnounceTxs: false,
+
+		PeerMaxTxsPerSecond:   0,
+		PeerMaxBytesPerSecond: 0,
+		PeerMuteDuration:      time.Minute,
+
+		MaxTxsPerSender: 1,
+		MaxGasPerSender: 0,
+
+		RecheckConcurrency: 0,
+		RecheckConnections: 0,
+		RecheckMode:        RecheckModeFull,
+
+		PersistInterval: 0 * time.Second,
+		PersistFile:     filepath.Join(defaultDataDir, "mempool.txs"),
 	}
 }
 
@@ -804,6 +1549,9 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.CacheSize < 0 {
 		return errors.New("cache-size can't be negative")
 	}
+	if cfg.EvictedCacheSize < 0 {
+		return errors.New("evicted-cache-size can't be negative")
+	}
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max-tx-bytes can't be negative")
 	}
@@ -813,6 +1561,62 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.TTLNumBlocks < 0 {
 		return errors.New("ttl-num-blocks can't be negative")
 	}
+	if cfg.RebroadcastInterval < 0 {
+		return errors.New("rebroadcast-interval can't be negative")
+	}
+	if cfg.RebroadcastMaxInterval < 0 {
+		return errors.New("rebroadcast-max-interval can't be negative")
+	}
+	if cfg.RebroadcastInterval > 0 && cfg.RebroadcastMaxInterval < cfg.RebroadcastInterval {
+		return errors.New("rebroadcast-max-interval can't be less than rebroadcast-interval")
+	}
+	if cfg.DOGProtocolEnabled {
+		if cfg.DOGTargetRedundancy <= 0 {
+			return errors.New("dog-target-redundancy must be positive")
+		}
+		if cfg.DOGAdjustInterval <= 0 {
+			return errors.New("dog-adjust-interval must be positive")
+		}
+	}
+	if cfg.PeerMaxTxsPerSecond < 0 {
+		return errors.New("peer-max-txs-per-second can't be negative")
+	}
+	if cfg.PeerMaxBytesPerSecond < 0 {
+		return errors.New("peer-max-bytes-per-second can't be negative")
+	}
+	if cfg.PeerMaxBytesPerSecond > 0 && cfg.PeerMaxBytesPerSecond < int64(cfg.MaxTxBytes) {
+		return errors.New("peer-max-bytes-per-second can't be less than max-tx-bytes")
+	}
+	if cfg.PeerMuteDuration < 0 {
+		return errors.New("peer-mute-duration can't be negative")
+	}
+	if cfg.MaxTxsPerSender < 0 {
+		return errors.New("max-txs-per-sender can't be negative")
+	}
+	if cfg.MaxGasPerSender < 0 {
+		return errors.New("max-gas-per-sender can't be negative")
+	}
+	if _, err := cfg.MempoolLanes(); err != nil {
+		return fmt.Errorf("lanes: %w", err)
+	}
+	if cfg.RecheckConcurrency < 0 {
+		return errors.New("recheck-concurrency can't be negative")
+	}
+	if cfg.RecheckConnections < 0 {
+		return errors.New("recheck-connections can't be negative")
+	}
+	switch cfg.RecheckMode {
+	case RecheckModeFull, RecheckModePartial:
+	default:
+		return fmt.Errorf("recheck-mode must be either %q or %q, got %q",
+			RecheckModeFull, RecheckModePartial, cfg.RecheckMode)
+	}
+	if cfg.PersistInterval < 0 {
+		return errors.New("persist-interval can't be negative")
+	}
+	if cfg.PersistInterval > 0 && cfg.PersistFile == "" {
+		return errors.New("persist-file must be set if persist-interval is non-zero")
+	}
 
 	return nil
 }
@@ -840,6 +1644,8 @@ type StateSyncConfig struct {
 	RPCServers []string `mapstructure:"rpc-servers"`
 
 	// The hash and height of a trusted block. Must be within the trust-period.
+	// If both are empty when using RPC, a recent block on which a quorum of the
+	// rpc-servers agree is trusted.
 	TrustHeight int64  `mapstructure:"trust-height"`
 	TrustHash   string `mapstructure:"trust-hash"`
 
@@ -852,8 +1658,10 @@ type StateSyncConfig struct {
 	DiscoveryTime time.Duration `mapstructure:"discovery-time"`
 
 	// Temporary directory for state sync snapshot chunks, defaults to os.TempDir().
-	// The synchronizer will create a new, randomly named directory within this directory
-	// and remove it when the sync is complete.
+	// The synchronizer will create a directory named after the snapshot within this
+	// directory and remove it when the sync is complete. If the sync is interrupted, e.g.
+	// by a restart, the chunks are kept and a later sync of the same snapshot resumes
+	// with them, so this should be a persistent directory.
 	TempDir string `mapstructure:"temp-dir"`
 
 	// The timeout duration before re-requesting a chunk, possibly from a different
@@ -862,6 +1670,34 @@ type StateSyncConfig struct {
 
 	// The number of concurrent chunk and block fetchers to run (default: 4).
 	Fetchers int32 `mapstructure:"fetchers"`
+
+	// The minimum number of blocks before the snapshot height to backfill after
+	// state sync, so that their headers and commits can be served to light
+	// clients. The node always backfills the blocks needed to verify evidence,
+	// as set by the evidence max-age consensus parameters (default: 0).
+	BackfillBlocks int64 `mapstructure:"backfill-blocks"`
+
+	// SnapshotDir, if set, is a directory of snapshots created by the
+	// application with the abci/snapshots package, from which the node
+	// serves snapshots to peers instead of calling the application's
+	// ListSnapshots and LoadSnapshotChunk. Relative paths are relative to
+	// the home directory.
+	SnapshotDir string `mapstructure:"snapshot-dir"`
+
+	// SnapshotFormats, if set with SnapshotDir, are the snapshot formats
+	// the application supports. Only the snapshots in those formats are
+	// served to peers, and the offers of snapshots in other formats are
+	// rejected without calling the application's OfferSnapshot.
+	SnapshotFormats []uint32 `mapstructure:"snapshot-formats"`
+
+	// SnapshotProviders are the base URLs of HTTP(S) servers from which
+	// snapshots and their chunks are fetched in addition to peers, such
+	// as "https://snapshots.example.com/chain-1". A provider serves the
+	// JSON list of its snapshots at <url>/snapshots.json and each chunk at
+	// <url>/<height>-<format>/<index>. They are verified like the
+	// snapshots of peers, against the app hash verified by the light
+	// client.
+	SnapshotProviders []string `mapstructure:"snapshot-providers"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -890,6 +1726,10 @@ func TestStateSyncConfig() *StateSyncConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
+	// The snapshots are served whether state sync is enabled or not.
+	if len(cfg.SnapshotFormats) > 0 && cfg.SnapshotDir == "" {
+		return errors.New("snapshot-formats requires snapshot-dir")
+	}
 	if !cfg.Enable {
 		return nil
 	}
@@ -916,17 +1756,24 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		return errors.New("trusted-period is required")
 	}
 
-	if cfg.TrustHeight <= 0 {
-		return errors.New("trusted-height is required")
+	if cfg.TrustHeight < 0 {
+		return errors.New("trusted-height can't be negative")
 	}
 
-	if len(cfg.TrustHash) == 0 {
-		return errors.New("trusted-hash is required")
-	}
+	// The trusted block is discovered from the RPC servers if none is set.
+	if cfg.UseP2P || cfg.TrustHeight != 0 || len(cfg.TrustHash) != 0 {
+		if cfg.TrustHeight == 0 {
+			return errors.New("trusted-height is required")
+		}
 
-	_, err := hex.DecodeString(cfg.TrustHash)
-	if err != nil {
-		return fmt.Errorf("invalid trusted-hash: %w", err)
+		if len(cfg.TrustHash) == 0 {
+			return errors.New("trusted-hash is required")
+		}
+
+		_, err := hex.DecodeString(cfg.TrustHash)
+		if err != nil {
+			return fmt.Errorf("invalid trusted-hash: %w", err)
+		}
 	}
 
 	if cfg.ChunkRequestTimeout < 5*time.Second {
@@ -937,6 +1784,17 @@ func (cfg *StateSyncConfig) ValidateBasic() error {
 		return errors.New("fetchers is required")
 	}
 
+	if cfg.BackfillBlocks < 0 {
+		return errors.New("backfill-blocks can't be negative")
+	}
+
+	for _, provider := range cfg.SnapshotProviders {
+		u, err := url.Parse(provider)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid snapshot-providers entry %q, expected an http or https URL", provider)
+		}
+	}
+
 	return nil
 }
 
@@ -960,6 +1818,53 @@ type ConsensusConfig struct {
 
 	DoubleSignCheckHeight int64 `mapstructure:"double-sign-check-height"`
 
+	// WalRepair truncates the WAL file at its first corrupt record, after
+	// backing it up, when consensus starts.
+	WalRepair bool `mapstructure:"wal-repair"`
+
+	// HaltPath is the path of the file of the record of the last halt of
+	// consensus on a consistency violation.
+	HaltPath string `mapstructure:"halt-file"`
+
+	// KeepRPCOnHalt keeps the node running, serving RPC, when consensus halts
+	// on a consistency violation, so that the halt can be inspected with the
+	// /status endpoint. Otherwise, the node stops.
+	KeepRPCOnHalt bool `mapstructure:"keep-rpc-on-halt"`
+
+	// HaltHeight, if positive, halts consensus once the block at the height
+	// is committed, and stops the node, e.g. for an upgrade of its binary.
+	HaltHeight int64 `mapstructure:"halt-height"`
+
+	// HaltTime, if positive, halts consensus once a block with at least the
+	// time, in Unix seconds, is committed, and stops the node.
+	HaltTime int64 `mapstructure:"halt-time"`
+
+	// HaltHook is a command run with sh -c by the start command after the
+	// node stops on a planned halt, such as to swap its binary, with the
+	// TM_HALT_HEIGHT, TM_HALT_TIME and TM_HOME environment variables set.
+	HaltHook string `mapstructure:"halt-hook"`
+
+	// ParallelVerification hashes the txs and parts of the blocks, and
+	// verifies their evidence, across GOMAXPROCS goroutines when validating
+	// them.
+	ParallelVerification bool `mapstructure:"parallel-verification"`
+
+	// CompactBlocks sends the proposal blocks to the peers which also enable
+	// it as compact blocks, of the keys of their transactions, from which the
+	// peers reconstruct the blocks with the transactions of their mempools.
+	// The block parts are sent to the peers which fail to do so.
+	CompactBlocks bool `mapstructure:"compact-blocks"`
+
+	// BlockSyncLag, if positive, switches the node back from consensus to
+	// block sync when it falls more than this number of blocks behind most of
+	// its peers, e.g. after a network outage. The node switches back to
+	// consensus once it catches up. Validators never switch.
+	BlockSyncLag int64 `mapstructure:"block-sync-lag"`
+
+	// BlockSyncCooldown is the minimum time the node runs consensus before it
+	// switches back to block sync, so that it does not switch back and forth.
+	BlockSyncCooldown time.Duration `mapstructure:"block-sync-cooldown"`
+
 	// TODO: The following fields are all temporary overrides that should exist only
 	// for the duration of the v0.36 release. The below fields should be completely
 	// removed in the v0.37 release of Tendermint.
@@ -993,6 +1898,15 @@ type ConsensusConfig struct {
 	// as soon as the node has gathered votes from all of the validators on the network.
 	UnsafeBypassCommitTimeoutOverride *bool `mapstructure:"unsafe-bypass-commit-timeout-override"`
 
+	// LegacyTimeouts, if true, applies the deprecated timeout parameters below
+	// as the overrides above that are not set, for compatibility with the
+	// configuration files of earlier releases. The timeout-prevote and
+	// timeout-precommit parameters, and their deltas, override the Vote
+	// timeout with the larger of the two.
+	//
+	// Deprecated: set the Timeout consensus parameters instead.
+	LegacyTimeouts bool `mapstructure:"legacy-timeouts"`
+
 	// Deprecated timeout parameters. These parameters are present in this struct
 	// so that they can be parsed so that validation can check if they have erroneously
 	// been included and provide a helpful error message.
@@ -1012,11 +1926,15 @@ type ConsensusConfig struct {
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
 		WalPath:                     filepath.Join(defaultDataDir, "cs.wal", "wal"),
+		HaltPath:                    filepath.Join(defaultDataDir, "consensus-halt.json"),
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 		DoubleSignCheckHeight:       int64(0),
+		WalRepair:                   true,
+		BlockSyncLag:                0,
+		BlockSyncCooldown:           time.Minute,
 	}
 }
 
@@ -1047,9 +1965,36 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
 }
 
+// HaltFile returns the full path to the file of the record of the last halt
+// of consensus
+func (cfg *ConsensusConfig) HaltFile() string {
+	return rootify(cfg.HaltPath, cfg.RootDir)
+}
+
+// VerificationWorkers returns the number of goroutines validating a block:
+// GOMAXPROCS with parallel verification, else 1.
+func (cfg *ConsensusConfig) VerificationWorkers() int {
+	if !cfg.ParallelVerification {
+		return 1
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
+	if cfg.HaltHeight < 0 {
+		return errors.New("halt-height can't be negative")
+	}
+	if cfg.HaltTime < 0 {
+		return errors.New("halt-time can't be negative")
+	}
+	if cfg.BlockSyncLag < 0 {
+		return errors.New("block-sync-lag can't be negative")
+	}
+	if cfg.BlockSyncCooldown < 0 {
+		return errors.New("block-sync-cooldown can't be negative")
+	}
 	if cfg.UnsafeProposeTimeoutOverride < 0 {
 		return errors.New("unsafe-propose-timeout-override can't be negative")
 	}
@@ -1077,9 +2022,84 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.DoubleSignCheckHeight < 0 {
 		return errors.New("double-sign-check-height can't be negative")
 	}
+	legacy := *cfg
+	if err := legacy.ApplyLegacyTimeouts(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// ApplyLegacyTimeouts sets the timeout overrides that are not set to the
+// deprecated timeout parameters, if LegacyTimeouts is true.
+func (cfg *ConsensusConfig) ApplyLegacyTimeouts() error {
+	if !cfg.LegacyTimeouts {
+		return nil
+	}
+	durations := []struct {
+		override *time.Duration
+		names    []string
+		values   []*interface{}
+	}{
+		{&cfg.UnsafeProposeTimeoutOverride, []string{"timeout-propose"},
+			[]*interface{}{cfg.DeprecatedTimeoutPropose}},
+		{&cfg.UnsafeProposeTimeoutDeltaOverride, []string{"timeout-propose-delta"},
+			[]*interface{}{cfg.DeprecatedTimeoutProposeDelta}},
+		{&cfg.UnsafeVoteTimeoutOverride, []string{"timeout-prevote", "timeout-precommit"},
+			[]*interface{}{cfg.DeprecatedTimeoutPrevote, cfg.DeprecatedTimeoutPrecommit}},
+		{&cfg.UnsafeVoteTimeoutDeltaOverride, []string{"timeout-prevote-delta", "timeout-precommit-delta"},
+			[]*interface{}{cfg.DeprecatedTimeoutPrevoteDelta, cfg.DeprecatedTimeoutPrecommitDelta}},
+		{&cfg.UnsafeCommitTimeoutOverride, []string{"timeout-commit"},
+			[]*interface{}{cfg.DeprecatedTimeoutCommit}},
+	}
+	for _, d := range durations {
+		if *d.override != 0 {
+			continue
+		}
+		for i, v := range d.values {
+			if v == nil {
+				continue
+			}
+			timeout, err := legacyDuration(*v)
+			if err != nil || timeout < 0 {
+				return fmt.Errorf("invalid %s: %v", d.names[i], *v)
+			}
+			if timeout > *d.override {
+				*d.override = timeout
+			}
+		}
+	}
+
+	if cfg.DeprecatedSkipTimeoutCommit != nil && cfg.UnsafeBypassCommitTimeoutOverride == nil {
+		var skip bool
+		switch v := (*cfg.DeprecatedSkipTimeoutCommit).(type) {
+		case bool:
+			skip = v
+		case string:
+			var err error
+			if skip, err = strconv.ParseBool(v); err != nil {
+				return fmt.Errorf("invalid skip-timeout-commit: %q", v)
+			}
+		default:
+			return fmt.Errorf("invalid skip-timeout-commit: %v", v)
+		}
+		cfg.UnsafeBypassCommitTimeoutOverride = &skip
+	}
+	return nil
+}
+
+// legacyDuration returns the duration of a deprecated timeout parameter, as
+// decoded from a configuration file.
+func legacyDuration(v interface{}) (time.Duration, error) {
+	switch d := v.(type) {
+	case time.Duration:
+		return d, nil
+	case string:
+		return time.ParseDuration(d)
+	default:
+		return 0, fmt.Errorf("invalid duration %v", v)
+	}
+}
+
 func (cfg *ConsensusConfig) DeprecatedFieldWarning() error {
 	var fields []string
 	if cfg.DeprecatedSkipTimeoutCommit != nil {
@@ -1109,6 +2129,12 @@ func (cfg *ConsensusConfig) DeprecatedFieldWarning() error {
 	if cfg.DeprecatedSkipTimeoutCommit != nil {
 		fields = append(fields, "skip-timeout-commit")
 	}
+	if cfg.LegacyTimeouts {
+		return fmt.Errorf("legacy-timeouts is set, applying the deprecated fields "+
+			"(%s) as timeout overrides. Timeout configuration has been moved to the "+
+			"ConsensusParams, and legacy-timeouts will be removed in v0.37. For more "+
+			"information see https://tinyurl.com/adr074", strings.Join(fields, ", "))
+	}
 	if len(fields) != 0 {
 		return fmt.Errorf("the following deprecated fields were set in the "+
 			"configuration file: %s. These fields were removed in v0.36. Timeout "+
@@ -1137,21 +2163,291 @@ type TxIndexConfig struct {
 	//   1) "null" (default) - no indexer services.
 	//   2) "kv" - a simple indexer backed by key-value storage (see DBBackend)
 	//   3) "psql" - the indexer services backed by PostgreSQL.
+	//   4) "custom:<name>" - a sink registered with the indexer sink registry.
 	Indexer []string `mapstructure:"indexer"`
 
 	// The PostgreSQL connection configuration, the connection format:
 	// postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 	PsqlConn string `mapstructure:"psql-conn"`
+
+	// The maximum number of rows the psql sink writes with a single
+	// multi-row INSERT statement. Zero selects the sink's default.
+	PsqlBatchSize int `mapstructure:"psql-batch-size"`
+
+	// If true, the psql sink does not apply its schema migrations on
+	// startup, and the operator is responsible for keeping the schema up to
+	// date.
+	PsqlNoMigrate bool `mapstructure:"psql-no-migrate"`
+
+	// The name of the database/sql driver of the psql sink: "postgres"
+	// (lib/pq), or a driver registered by the binary, such as "pgx".
+	PsqlDriver string `mapstructure:"psql-driver"`
+
+	// The pool of connections of the psql sink: the maximum number of open
+	// connections (zero means no limit), of idle connections (zero selects
+	// the default of database/sql), and the maximum time a connection is
+	// reused (zero means no limit).
+	PsqlMaxOpenConns    int           `mapstructure:"psql-max-open-conns"`
+	PsqlMaxIdleConns    int           `mapstructure:"psql-max-idle-conns"`
+	PsqlConnMaxLifetime time.Duration `mapstructure:"psql-conn-max-lifetime"`
+
+	// The timeout of each statement of the psql sink. Zero means no timeout.
+	PsqlStatementTimeout time.Duration `mapstructure:"psql-statement-timeout"`
+
+	// The number of times the psql sink retries a transaction aborted by a
+	// serialization failure, as CockroachDB reports under contention.
+	PsqlMaxRetries int `mapstructure:"psql-max-retries"`
+
+	// If true, blocks are indexed off the commit path by a worker for each
+	// sink, so a slow sink cannot stall block production until its queue of
+	// QueueSize blocks fills up.
+	Async bool `mapstructure:"async"`
+
+	// The number of complete blocks buffered for each sink when Async is set.
+	QueueSize int `mapstructure:"queue-size"`
+
+	// If non-empty, only the listed event types (e.g. "transfer") and
+	// attributes (e.g. "transfer.amount") are indexed by the kv and psql
+	// sinks.
+	IndexEvents []string `mapstructure:"index-events"`
+
+	// Event types and attributes, in the same form as IndexEvents, that are
+	// never indexed by the kv and psql sinks.
+	ExcludeEvents []string `mapstructure:"exclude-events"`
+
+	// If positive, the data indexed for blocks older than the latest
+	// RetainBlocks blocks is periodically pruned. Zero keeps everything.
+	RetainBlocks int64 `mapstructure:"retain-blocks"`
+
+	// If true, the data indexed for blocks pruned from the block store, e.g.
+	// below the retain height set by the application, is pruned as well.
+	PruneWithBlocks bool `mapstructure:"prune-with-blocks"`
+
+	// The maximum number of events of a transaction or block published to
+	// the sinks and subscribers. Zero means no limit.
+	MaxEvents int `mapstructure:"max-events"`
+
+	// The maximum size in bytes of an event type or attribute key. Zero means
+	// no limit.
+	MaxEventKeySize int `mapstructure:"max-event-key-size"`
+
+	// The maximum size in bytes of an event attribute value. Zero means no
+	// limit.
+	MaxEventValueSize int `mapstructure:"max-event-value-size"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
 func DefaultTxIndexConfig() *TxIndexConfig {
-	return &TxIndexConfig{Indexer: []string{"null"}}
+	return &TxIndexConfig{
+		Indexer:        []string{"null"},
+		PsqlBatchSize:  1000,
+		PsqlDriver:     "postgres",
+		PsqlMaxRetries: 3,
+		Async:          false,
+		QueueSize:      100,
+	}
 }
 
 // TestTxIndexConfig returns a default configuration for the transaction indexer.
 func TestTxIndexConfig() *TxIndexConfig {
-	return &TxIndexConfig{Indexer: []string{"kv"}}
+	cfg := DefaultTxIndexConfig()
+	cfg.Indexer = []string{"kv"}
+	return cfg
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *TxIndexConfig) ValidateBasic() error {
+	if cfg.PsqlBatchSize < 0 {
+		return errors.New("psql-batch-size can't be negative")
+	}
+	if cfg.PsqlDriver == "" {
+		return errors.New("psql-driver can't be empty")
+	}
+	if cfg.PsqlMaxOpenConns < 0 {
+		return errors.New("psql-max-open-conns can't be negative")
+	}
+	if cfg.PsqlMaxIdleConns < 0 {
+		return errors.New("psql-max-idle-conns can't be negative")
+	}
+	if cfg.PsqlConnMaxLifetime < 0 {
+		return errors.New("psql-conn-max-lifetime can't be negative")
+	}
+	if cfg.PsqlStatementTimeout < 0 {
+		return errors.New("psql-statement-timeout can't be negative")
+	}
+	if cfg.PsqlMaxRetries < 0 {
+		return errors.New("psql-max-retries can't be negative")
+	}
+	if cfg.QueueSize < 0 {
+		return errors.New("queue-size can't be negative")
+	}
+	if cfg.RetainBlocks < 0 {
+		return errors.New("retain-blocks can't be negative")
+	}
+	if cfg.MaxEvents < 0 {
+		return errors.New("max-events can't be negative")
+	}
+	if cfg.MaxEventKeySize < 0 {
+		return errors.New("max-event-key-size can't be negative")
+	}
+	if cfg.MaxEventValueSize < 0 {
+		return errors.New("max-event-value-size can't be negative")
+	}
+	for _, e := range append(cfg.IndexEvents, cfg.ExcludeEvents...) {
+		if strings.TrimSpace(e) == "" || strings.HasPrefix(e, ".") || strings.HasSuffix(e, ".") {
+			return fmt.Errorf("invalid event filter rule %q: expected <type> or <type>.<attribute>", e)
+		}
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// StorageConfig
+
+// StorageConfig defines the configuration for the block and state stores.
+type StorageConfig struct {
+	// Pruning configures the removal of the blocks, states and block results
+	// below the retain heights.
+	Pruning *PruningConfig `mapstructure:"pruning"`
+
+	// Audit configures the background audit of the block and state stores.
+	Audit *AuditConfig `mapstructure:"audit"`
+
+	// If true, the block store and state store databases are compacted after
+	// pruning, to reclaim the disk space of the pruned heights. Only the
+	// goleveldb backend, and rocksdb and cleveldb when built in, support it.
+	Compact bool `mapstructure:"compact"`
+
+	// The minimum time between two compactions with Compact. Compaction
+	// rewrites the databases, so it should run much less often than pruning.
+	CompactionInterval time.Duration `mapstructure:"compaction-interval"`
+
+	// The options of the individual databases of the node, see DBOptions.
+	BlockStore *DBOptions `mapstructure:"blockstore"`
+	State      *DBOptions `mapstructure:"state"`
+	Evidence   *DBOptions `mapstructure:"evidence"`
+	TxIndex    *DBOptions `mapstructure:"tx-index"`
+}
+
+// DBOptions defines the backend and tuning options of a database.
+type DBOptions struct {
+	// The database backend, or empty for the db-backend of the node.
+	Backend string `mapstructure:"backend"`
+
+	// The size of the block cache in MiB, or 0 for the default of the
+	// backend. Only goleveldb, and rocksdb when built in, support it.
+	CacheSize int `mapstructure:"cache-size"`
+
+	// The size of the write buffer in MiB, or 0 for the default of the
+	// backend. Only goleveldb, and rocksdb when built in, support it.
+	WriteBufferSize int `mapstructure:"write-buffer-size"`
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (opts *DBOptions) ValidateBasic() error {
+	switch dbm.BackendType(opts.Backend) {
+	case "", dbm.GoLevelDBBackend, dbm.CLevelDBBackend, dbm.BoltDBBackend, dbm.RocksDBBackend,
+		dbm.BadgerDBBackend, dbm.MemDBBackend:
+	default:
+		return fmt.Errorf("unknown backend %q", opts.Backend)
+	}
+	if opts.CacheSize < 0 {
+		return errors.New("cache-size can't be negative")
+	}
+	if opts.WriteBufferSize < 0 {
+		return errors.New("write-buffer-size can't be negative")
+	}
+	return nil
+}
+
+// PruningConfig defines the configuration for the background pruning of the
+// block and state stores.
+type PruningConfig struct {
+	// The time between two runs of the pruner. Each run removes the blocks,
+	// states and block results below the current retain heights, 1000 heights
+	// at a time.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// AuditConfig defines the configuration for the background audit of the
+// block and state stores.
+type AuditConfig struct {
+	// The time between two runs of the auditor, or 0 to disable it. Each run
+	// checks the latest state against the block store, and recomputes the
+	// hashes, verifies the commit and checks the state store references of a
+	// random sample of the stored heights.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// The number of heights audited by each run, always including the latest
+	// one.
+	SampleSize int `mapstructure:"sample-size"`
+}
+
+// DefaultStorageConfig returns a default configuration for the block and state
+// stores.
+func DefaultStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		Pruning: &PruningConfig{
+			Interval: 10 * time.Second,
+		},
+		Audit: &AuditConfig{
+			Interval:   time.Hour,
+			SampleSize: 10,
+		},
+		Compact:            false,
+		CompactionInterval: time.Hour,
+		BlockStore:         &DBOptions{},
+		State:              &DBOptions{},
+		Evidence:           &DBOptions{},
+		TxIndex:            &DBOptions{},
+	}
+}
+
+// TestStorageConfig returns a configuration for the block and state stores
+// used for testing.
+func TestStorageConfig() *StorageConfig {
+	cfg := DefaultStorageConfig()
+	cfg.Pruning.Interval = 100 * time.Millisecond
+	return cfg
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *StorageConfig) ValidateBasic() error {
+	if cfg.Pruning == nil {
+		return errors.New("missing [storage.pruning] section")
+	}
+	if cfg.Pruning.Interval <= 0 {
+		return errors.New("pruning.interval must be positive")
+	}
+	if cfg.Audit == nil {
+		return errors.New("missing [storage.audit] section")
+	}
+	if cfg.Audit.Interval < 0 {
+		return errors.New("audit.interval can't be negative")
+	}
+	if cfg.Audit.Interval > 0 && cfg.Audit.SampleSize <= 0 {
+		return errors.New("audit.sample-size must be positive with audit.interval")
+	}
+	if cfg.Compact && cfg.CompactionInterval <= 0 {
+		return errors.New("compaction-interval must be positive with compact")
+	}
+	for name, opts := range map[string]*DBOptions{
+		"blockstore": cfg.BlockStore,
+		"state":      cfg.State,
+		"evidence":   cfg.Evidence,
+		"tx-index":   cfg.TxIndex,
+	} {
+		if opts == nil {
+			continue
+		}
+		if err := opts.ValidateBasic(); err != nil {
+			return fmt.Errorf("error in [storage.%s] section: %w", name, err)
+		}
+	}
+	return nil
 }
 
 //-----------------------------------------------------------------------------
@@ -1175,6 +2471,25 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// When true, the metrics of peers, such as the bytes sent to and received
+	// from each peer, are labeled by peer ID. Otherwise, they're the totals of
+	// all the peers, with an empty peer_id label. The number of series of
+	// these metrics grows with the number of peers.
+	PeerMetrics bool `mapstructure:"peer-metrics"`
+
+	// When true, the metrics of each validator, such as its voting power and
+	// missed blocks, are recorded, labeled by validator address. The number of
+	// series of these metrics grows with the size of the validator set.
+	ValidatorMetrics bool `mapstructure:"validator-metrics"`
+
+	// URL of an OTLP/HTTP collector to export traces of consensus steps, ABCI
+	// calls and RPC requests to, such as "http://localhost:4318/v1/traces".
+	// "" disables tracing.
+	TraceEndpoint string `mapstructure:"trace-endpoint"`
+
+	// Fraction of the traces exported, from 0 to 1.
+	TraceSampleRate float64 `mapstructure:"trace-sample-rate"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
@@ -1185,6 +2500,10 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 		PrometheusListenAddr: ":26660",
 		MaxOpenConnections:   3,
 		Namespace:            "tendermint",
+		PeerMetrics:          false,
+		ValidatorMetrics:     true,
+		TraceEndpoint:        "",
+		TraceSampleRate:      1,
 	}
 }
 
@@ -1200,9 +2519,101 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max-open-connections can't be negative")
 	}
+	if cfg.TraceEndpoint != "" {
+		if u, err := url.Parse(cfg.TraceEndpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("trace-endpoint %q is not an http or https URL", cfg.TraceEndpoint)
+		}
+	}
+	if cfg.TraceSampleRate < 0 || cfg.TraceSampleRate > 1 {
+		return errors.New("trace-sample-rate must be between 0 and 1")
+	}
 	return nil
 }
 
+//-----------------------------------------------------------------------------
+// Changes
+
+// ChangedSettings returns the keys of the settings whose values differ between
+// cfg and other, as in the config file, such as "log-level" or
+// "p2p.persistent-peers", in the order of the config structure.
+func (cfg *Config) ChangedSettings(other *Config) []string {
+	return changedSettings("", reflect.ValueOf(cfg).Elem(), reflect.ValueOf(other).Elem())
+}
+
+// changedSettings returns the keys of the settings that differ between the
+// config structures a and b, prefixed with prefix.
+func changedSettings(prefix string, a, b reflect.Value) []string {
+	var changed []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+		fa, fb := a.Field(i), b.Field(i)
+
+		switch {
+		case field.Anonymous:
+			changed = append(changed, changedSettings(prefix, fa, fb)...)
+		case fa.Kind() == reflect.Ptr && fa.Type().Elem().Kind() == reflect.Struct:
+			if fa.IsNil() || fb.IsNil() {
+				if fa.IsNil() != fb.IsNil() {
+					changed = append(changed, prefix+name)
+				}
+				continue
+			}
+			changed = append(changed, changedSettings(prefix+name+".", fa.Elem(), fb.Elem())...)
+		case name == "" || name == "-":
+		case !settingEqual(fa, fb):
+			changed = append(changed, prefix+name)
+		}
+	}
+	return changed
+}
+
+// SetSettings sets the settings of cfg with the given keys, as returned by
+// ChangedSettings, to their values in other.
+func (cfg *Config) SetSettings(other *Config, keys []string) {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	setSettings("", reflect.ValueOf(cfg).Elem(), reflect.ValueOf(other).Elem(), set)
+}
+
+// setSettings sets the settings of the config structure a with the keys in
+// set, prefixed with prefix, to their values in b.
+func setSettings(prefix string, a, b reflect.Value, set map[string]bool) {
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+		fa, fb := a.Field(i), b.Field(i)
+
+		switch {
+		case field.Anonymous:
+			setSettings(prefix, fa, fb, set)
+		case set[prefix+name]:
+			fa.Set(fb)
+		case fa.Kind() == reflect.Ptr && fa.Type().Elem().Kind() == reflect.Struct:
+			if !fa.IsNil() && !fb.IsNil() {
+				setSettings(prefix+name+".", fa.Elem(), fb.Elem(), set)
+			}
+		}
+	}
+}
+
+// settingEqual reports whether the values of a setting are equal, with empty
+// lists equal to unset ones as in the config file.
+func settingEqual(a, b reflect.Value) bool {
+	if (a.Kind() == reflect.Slice || a.Kind() == reflect.Map) && a.Len() == 0 && b.Len() == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
+
 //-----------------------------------------------------------------------------
 // Utils
 
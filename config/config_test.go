@@ -34,6 +34,30 @@ func TestConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestConfigChangedSettings(t *testing.T) {
+	cfg := DefaultConfig()
+	other := DefaultConfig()
+	assert.Empty(t, cfg.ChangedSettings(other))
+
+	other.LogLevel = "debug"
+	other.RPC.RateLimitAPIKeys = []string{"key"}
+	other.P2P.PersistentPeers = "3e5e6f0c9a3f1f1c6a9d0f5d5b6d3e1c7a6b1c2d@10.0.0.1:26656"
+	other.PrivValidator.ListenAddr = "tcp://127.0.0.1:26659"
+	assert.Equal(t, []string{
+		"log-level",
+		"rpc.rate-limit-api-keys",
+		"p2p.persistent-peers",
+		"priv-validator.laddr",
+	}, cfg.ChangedSettings(other))
+
+	cfg.SetSettings(other, []string{"log-level", "p2p.persistent-peers"})
+	assert.Equal(t, "debug", cfg.LogLevel)
+	assert.Equal(t, []string{
+		"rpc.rate-limit-api-keys",
+		"priv-validator.laddr",
+	}, cfg.ChangedSettings(other))
+}
+
 func TestTLSConfiguration(t *testing.T) {
 	cfg := DefaultConfig()
 	cfg.SetRoot("/home/user")
@@ -56,6 +80,40 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	// module log levels
+	cfg = TestBaseConfig()
+	cfg.LogLevel = "p2p:debug,consensus:info,*:error"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.LogLevel = "p2p:verbose"
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.LogFileMaxBytes = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.ABCITimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.ABCICheckTxTimeout = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.ABCI = "grpc"
+	cfg.ABCITLSCAFile = "ca.pem"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ABCITLSCertFile = "cert.pem"
+	assert.Error(t, cfg.ValidateBasic(), "cert without key")
+	cfg.ABCITLSKeyFile = "key.pem"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.ABCI = "socket"
+	assert.Error(t, cfg.ValidateBasic(), "tls over socket")
+
+	cfg = TestBaseConfig()
+	cfg.TxHash = ""
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -64,11 +122,18 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 
 	fieldsToTest := []string{
 		"MaxOpenConnections",
+		"MaxOpenConnectionsPerIP",
+		"GRPCMaxOpenConnections",
 		"MaxSubscriptionClients",
 		"MaxSubscriptionsPerClient",
+		"SubscriptionReplayWindow",
+		"SubscriptionBufferSize",
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"MaxRequestBatchSize",
+		"HealthStalledRounds",
+		"HealthABCITimeout",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -76,6 +141,117 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.SubscriptionOverflowPolicy = "drop-oldest"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.SubscriptionOverflowPolicy = "block"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SubscriptionOverflowPolicy = ""
+
+	cfg.SimulateQueryPath = "app/simulate"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.SimulateQueryPath = "/app/simulate"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.SimulateQueryPath = ""
+
+	cfg.RateLimit = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RateLimit, cfg.RateLimitBurst = 5, 0
+	assert.Error(t, cfg.ValidateBasic(), "rate limit without burst")
+	cfg.RateLimitBurst = 10
+	assert.NoError(t, cfg.ValidateBasic())
+	for _, cost := range []string{"tx_search", "tx_search:", ":10", "tx_search:-1", "tx_search:ten"} {
+		cfg.RateLimitMethodCosts = []string{cost}
+		assert.Error(t, cfg.ValidateBasic(), cost)
+	}
+	cfg.RateLimitMethodCosts = []string{"tx_search:10", "health:0"}
+	costs, err := cfg.MethodCosts()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"tx_search": 10, "health": 0}, costs)
+
+	// the privileged listener requires authentication
+	cfg.PrivilegedListenAddress = "tcp://127.0.0.1:26659"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivilegedAuthToken = "secret"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PrivilegedTLSClientCAFile = "ca.pem"
+	assert.Error(t, cfg.ValidateBasic(), "mTLS requires TLS")
+	cfg.TLSCertFile, cfg.TLSKeyFile = "cert.pem", "key.pem"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// the certificates are either the files or obtained with ACME
+	cfg.TLSACMEDomains = []string{"rpc.example.com"}
+	assert.Error(t, cfg.ValidateBasic(), "ACME with certificate files")
+	cfg.TLSCertFile, cfg.TLSKeyFile = "", ""
+	assert.NoError(t, cfg.ValidateBasic(), "mTLS with ACME")
+	cfg.TLSACMEDomains = []string{"https://rpc.example.com"}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TLSACMEDomains = []string{"rpc.example.com"}
+	cfg.TLSACMECacheDir = ""
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TLSACMECacheDir = "data/acme"
+	cfg.RootDir = "/home/user"
+	assert.Equal(t, "/home/user/data/acme", cfg.ACMECacheDir())
+
+	for _, origin := range []string{"status", "/status", "/status:", "https://example.com"} {
+		cfg.CORSPathAllowedOrigins = []string{origin}
+		assert.Error(t, cfg.ValidateBasic(), origin)
+	}
+	cfg.CORSPathAllowedOrigins = []string{"/status:https://a.example.com", "/status:https://b.example.com", "/:*"}
+	origins, err := cfg.CORSPathOrigins()
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"/status": {"https://a.example.com", "https://b.example.com"},
+		"/":       {"*"},
+	}, origins)
+}
+
+func TestTxIndexConfigValidateBasic(t *testing.T) {
+	cfg := TestTxIndexConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.PsqlBatchSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PsqlBatchSize = 0
+
+	cfg.PsqlDriver = ""
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PsqlDriver = "pgx"
+
+	for _, n := range []*int{&cfg.PsqlMaxOpenConns, &cfg.PsqlMaxIdleConns, &cfg.PsqlMaxRetries} {
+		*n = -1
+		assert.Error(t, cfg.ValidateBasic())
+		*n = 10
+		assert.NoError(t, cfg.ValidateBasic())
+	}
+	for _, d := range []*time.Duration{&cfg.PsqlConnMaxLifetime, &cfg.PsqlStatementTimeout} {
+		*d = -time.Second
+		assert.Error(t, cfg.ValidateBasic())
+		*d = time.Minute
+		assert.NoError(t, cfg.ValidateBasic())
+	}
+
+	cfg.QueueSize = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.QueueSize = 0
+
+	cfg.RetainBlocks = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RetainBlocks = 0
+
+	for _, limit := range []*int{&cfg.MaxEvents, &cfg.MaxEventKeySize, &cfg.MaxEventValueSize} {
+		*limit = -1
+		assert.Error(t, cfg.ValidateBasic())
+		*limit = 100
+		assert.NoError(t, cfg.ValidateBasic())
+	}
+
+	cfg.IndexEvents = []string{"transfer", "message.sender"}
+	cfg.ExcludeEvents = []string{"transfer.memo"}
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.ExcludeEvents = []string{"transfer."}
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -86,7 +262,17 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"Size",
 		"MaxTxsBytes",
 		"CacheSize",
+		"EvictedCacheSize",
 		"MaxTxBytes",
+		"RebroadcastInterval",
+		"RebroadcastMaxInterval",
+		"PeerMaxTxsPerSecond",
+		"PeerMaxBytesPerSecond",
+		"PeerMuteDuration",
+		"MaxTxsPerSender",
+		"MaxGasPerSender",
+		"RecheckConcurrency",
+		"RecheckConnections",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -94,11 +280,117 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.RebroadcastInterval = time.Minute
+	cfg.RebroadcastMaxInterval = time.Second
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RebroadcastMaxInterval = time.Hour
+
+	cfg.DOGProtocolEnabled = true
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.DOGTargetRedundancy = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DOGTargetRedundancy = 1
+	cfg.DOGAdjustInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DOGAdjustInterval = time.Second
+
+	cfg.MaxTxBytes = 1024
+	cfg.PeerMaxBytesPerSecond = 1023
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PeerMaxBytesPerSecond = 1024
+
+	cfg.RecheckMode = RecheckModePartial
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.RecheckMode = "some"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.RecheckMode = RecheckModeFull
+
+	cfg.PersistInterval = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PersistInterval = time.Second
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PersistFile = ""
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PersistInterval = 0
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestMempoolConfigLanes(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lanes       []string
+		defaultLane string
+		want        []MempoolLane
+		wantErr     bool
+	}{
+		{"no lanes", nil, "", []MempoolLane{}, false},
+		{"lanes", []string{"oracle:3:100", "default:1:0"}, "default",
+			[]MempoolLane{{"oracle", 3, 100}, {"default", 1, 0}}, false},
+		{"missing default", []string{"oracle:3:100"}, "", nil, true},
+		{"unknown default", []string{"oracle:3:100"}, "default", nil, true},
+		{"default without lanes", nil, "default", nil, true},
+		{"duplicate", []string{"a:1:0", "a:2:0"}, "a", nil, true},
+		{"malformed", []string{"a:1"}, "a", nil, true},
+		{"zero weight", []string{"a:0:0"}, "a", nil, true},
+		{"negative size", []string{"a:1:-1"}, "a", nil, true},
+		{"too many", []string{"a:1:0", "b:1:0", "c:1:0", "d:1:0", "e:1:0", "f:1:0", "g:1:0", "h:1:0", "i:1:0"}, "a", nil, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := TestMempoolConfig()
+			cfg.Lanes = tc.lanes
+			cfg.DefaultLane = tc.defaultLane
+
+			lanes, err := cfg.MempoolLanes()
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Error(t, cfg.ValidateBasic())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, lanes)
+			assert.NoError(t, cfg.ValidateBasic())
+		})
+	}
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
 	cfg := TestStateSyncConfig()
 	require.NoError(t, cfg.ValidateBasic())
+
+	testcases := map[string]struct {
+		modify    func(*StateSyncConfig)
+		expectErr bool
+	}{
+		"trust options":             {func(c *StateSyncConfig) { c.TrustHeight, c.TrustHash = 1, "ABCD" }, false},
+		"discovered trust options":  {func(c *StateSyncConfig) {}, false},
+		"trust height without hash": {func(c *StateSyncConfig) { c.TrustHeight = 1 }, true},
+		"trust hash without height": {func(c *StateSyncConfig) { c.TrustHash = "ABCD" }, true},
+		"negative trust height":     {func(c *StateSyncConfig) { c.TrustHeight = -1 }, true},
+		"invalid trust hash":        {func(c *StateSyncConfig) { c.TrustHeight, c.TrustHash = 1, "XYZ" }, true},
+		"p2p without trust options": {func(c *StateSyncConfig) { c.UseP2P = true }, true},
+		"p2p with trust options":    {func(c *StateSyncConfig) { c.UseP2P, c.TrustHeight, c.TrustHash = true, 1, "ABCD" }, false},
+		"discovery with one server": {func(c *StateSyncConfig) { c.RPCServers = c.RPCServers[:1] }, true},
+		"snapshot providers": {func(c *StateSyncConfig) {
+			c.SnapshotProviders = []string{"https://snapshots.example.com/chain-1", "http://10.0.0.1:8080"}
+		}, false},
+		"invalid snapshot provider": {func(c *StateSyncConfig) { c.SnapshotProviders = []string{"snapshots.example.com"} }, true},
+	}
+	for desc, tc := range testcases {
+		tc := tc
+		t.Run(desc, func(t *testing.T) {
+			cfg := TestStateSyncConfig()
+			cfg.Enable = true
+			cfg.RPCServers = []string{"127.0.0.1:26657", "127.0.0.1:26658"}
+			tc.modify(cfg)
+			if tc.expectErr {
+				assert.Error(t, cfg.ValidateBasic())
+			} else {
+				assert.NoError(t, cfg.ValidateBasic())
+			}
+		})
+	}
 }
 
 func TestConsensusConfig_ValidateBasic(t *testing.T) {
@@ -121,6 +413,12 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":                {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative":       {func(c *ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":             {func(c *ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"HaltHeight":                                 {func(c *ConsensusConfig) { c.HaltHeight = 100 }, false},
+		"HaltHeight negative":                        {func(c *ConsensusConfig) { c.HaltHeight = -1 }, true},
+		"HaltTime":                                   {func(c *ConsensusConfig) { c.HaltTime = 1700000000 }, false},
+		"HaltTime negative":                          {func(c *ConsensusConfig) { c.HaltTime = -1 }, true},
+		"BlockSyncLag negative":                      {func(c *ConsensusConfig) { c.BlockSyncLag = -1 }, true},
+		"BlockSyncCooldown negative":                 {func(c *ConsensusConfig) { c.BlockSyncCooldown = -1 }, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -138,6 +436,70 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 	}
 }
 
+func TestConsensusConfigApplyLegacyTimeouts(t *testing.T) {
+	legacy := func(v interface{}) *interface{} { return &v }
+	cfg := DefaultConsensusConfig()
+	cfg.DeprecatedTimeoutPropose = legacy("3s")
+	cfg.DeprecatedTimeoutPrevote = legacy("1s")
+	cfg.DeprecatedTimeoutPrecommit = legacy("2s")
+	cfg.DeprecatedTimeoutCommit = legacy("5s")
+	cfg.DeprecatedSkipTimeoutCommit = legacy(true)
+	cfg.UnsafeProposeTimeoutOverride = time.Second
+
+	// the deprecated fields are ignored without legacy-timeouts
+	require.NoError(t, cfg.ApplyLegacyTimeouts())
+	assert.Zero(t, cfg.UnsafeCommitTimeoutOverride)
+	assert.Error(t, cfg.DeprecatedFieldWarning())
+
+	cfg.LegacyTimeouts = true
+	require.NoError(t, cfg.ValidateBasic())
+	require.NoError(t, cfg.ApplyLegacyTimeouts())
+	assert.Equal(t, time.Second, cfg.UnsafeProposeTimeoutOverride, "overrides are kept")
+	assert.Zero(t, cfg.UnsafeProposeTimeoutDeltaOverride)
+	assert.Equal(t, 2*time.Second, cfg.UnsafeVoteTimeoutOverride)
+	assert.Equal(t, 5*time.Second, cfg.UnsafeCommitTimeoutOverride)
+	require.NotNil(t, cfg.UnsafeBypassCommitTimeoutOverride)
+	assert.True(t, *cfg.UnsafeBypassCommitTimeoutOverride)
+	assert.Contains(t, cfg.DeprecatedFieldWarning().Error(), "legacy-timeouts")
+
+	cfg = DefaultConsensusConfig()
+	cfg.LegacyTimeouts = true
+	cfg.DeprecatedTimeoutCommit = legacy("soon")
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DeprecatedTimeoutCommit = legacy("-1s")
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DeprecatedTimeoutCommit = nil
+	cfg.DeprecatedSkipTimeoutCommit = legacy("maybe")
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestStorageConfigValidateBasic(t *testing.T) {
+	cfg := TestStorageConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Compact = true
+	cfg.CompactionInterval = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.CompactionInterval = time.Minute
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.Audit.SampleSize = 0
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Audit.Interval = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Audit.Interval = -time.Second
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Audit = nil
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestStorageConfig()
+	cfg.Pruning.Interval = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.Pruning = nil
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg := TestInstrumentationConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -145,6 +507,16 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+
+	// tamper with the trace settings
+	cfg = TestInstrumentationConfig()
+	cfg.TraceEndpoint = "http://localhost:4318/v1/traces"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.TraceEndpoint = "localhost:4318"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg = TestInstrumentationConfig()
+	cfg.TraceSampleRate = 1.5
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -156,6 +528,10 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"ConsensusSendRate",
+		"MempoolSendRate",
+		"BlockSyncSendRate",
+		"EvidenceSendRate",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -163,4 +539,34 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.AllowedPeers = "3e5e6f0c9a3f1f1c6a9d0f5d5b6d3e1c7a6b1c2d, 10.0.0.0/8, 192.168.1.1, ::1"
+	cfg.BlockedPeers = "10.1.0.0/16"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.AllowedPeers = "node@10.0.0.1"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.AllowedPeers = ""
+	cfg.BlockedPeers = "10.1.0.0/33"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.BlockedPeers = ""
+
+	cfg.PersistentPeers = "3E5E6F0C9A3F1F1C6A9D0F5D5B6D3E1C7A6B1C2D@10.0.0.1:26656"
+	cfg.PrivatePeeringIDs = "3e5e6f0c9a3f1f1c6a9d0f5d5b6d3e1c7a6b1c2d"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PrivatePeeringIDs = "4e5e6f0c9a3f1f1c6a9d0f5d5b6d3e1c7a6b1c2d"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivatePeeringIDs = "not-an-id"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PrivatePeeringIDs = ""
+
+	cfg.ListenAddress = "tcp://0.0.0.0:26656, tcp://[::]:26656"
+	cfg.ExternalAddress = "159.89.10.97:26656,[2001:db8::1]:26656"
+	assert.NoError(t, cfg.ValidateBasic())
+	assert.Equal(t, []string{"tcp://0.0.0.0:26656", "tcp://[::]:26656"}, cfg.ListenAddresses())
+	assert.Equal(t, []string{"159.89.10.97:26656", "[2001:db8::1]:26656"}, cfg.ExternalAddresses())
+	cfg.ExternalAddress = "2001:db8::1:26656"
+	assert.Error(t, cfg.ValidateBasic(), "IPv6 without brackets")
+	cfg.ExternalAddress = ""
+	cfg.ListenAddress = "tcp://0.0.0.0"
+	assert.Error(t, cfg.ValidateBasic(), "no port")
 }
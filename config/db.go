@@ -2,7 +2,9 @@ package config
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/syndtr/goleveldb/leveldb/opt"
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -21,10 +23,65 @@ type DBContext struct {
 // DBProvider takes a DBContext and returns an instantiated DB.
 type DBProvider func(*DBContext) (dbm.DB, error)
 
-// DefaultDBProvider returns a database using the DBBackend and DBDir
-// specified in the Config.
+// DefaultDBProvider returns a database in the DBDir specified in the Config,
+// using the options of the database with the ID of the DBContext, see
+// Config.DBOptions.
 func DefaultDBProvider(ctx *DBContext) (dbm.DB, error) {
-	dbType := dbm.BackendType(ctx.Config.DBBackend)
+	return NewDB(ctx.ID, ctx.Config.DBOptions(ctx.ID), ctx.Config.DBDir())
+}
+
+// DBOptions returns the options of the database with the given ID, with the
+// db-backend of the node if the options do not select one. IDs without
+// options in the [storage] section get the default options.
+func (cfg *Config) DBOptions(id string) DBOptions {
+	var opts *DBOptions
+	if cfg.Storage != nil {
+		switch id {
+		case "blockstore":
+			opts = cfg.Storage.BlockStore
+		case "state":
+			opts = cfg.Storage.State
+		case "evidence":
+			opts = cfg.Storage.Evidence
+		case "tx_index":
+			opts = cfg.Storage.TxIndex
+		}
+	}
+	var res DBOptions
+	if opts != nil {
+		res = *opts
+	}
+	if res.Backend == "" {
+		res.Backend = cfg.DBBackend
+	}
+	return res
+}
+
+// dbOpeners open the databases of the backends that support the tuning
+// options. Backends that require build tags add theirs in init.
+var dbOpeners = map[dbm.BackendType]func(name, dir string, opts DBOptions) (dbm.DB, error){
+	dbm.GoLevelDBBackend: openGoLevelDB,
+}
+
+// NewDB opens the database with the given name in dir, with the given
+// options. It returns an error if the options set tuning options that the
+// backend does not support.
+func NewDB(name string, opts DBOptions, dir string) (dbm.DB, error) {
+	backend := dbm.BackendType(opts.Backend)
+	if opts.CacheSize == 0 && opts.WriteBufferSize == 0 {
+		return dbm.NewDB(name, backend, dir)
+	}
+	open, ok := dbOpeners[backend]
+	if !ok {
+		return nil, fmt.Errorf("the %s backend of database %s does not support cache-size and write-buffer-size",
+			backend, name)
+	}
+	return open(name, dir, opts)
+}
 
-	return dbm.NewDB(ctx.ID, dbType, ctx.Config.DBDir())
+func openGoLevelDB(name, dir string, opts DBOptions) (dbm.DB, error) {
+	return dbm.NewGoLevelDBWithOpts(name, dir, &opt.Options{
+		BlockCacheCapacity: opts.CacheSize * opt.MiB,
+		WriteBuffer:        opts.WriteBufferSize * opt.MiB,
+	})
 }
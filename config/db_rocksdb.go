@@ -0,0 +1,35 @@
+//go:build rocksdb
+// +build rocksdb
+
+package config
+
+import (
+	"runtime"
+
+	"github.com/tecbot/gorocksdb"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func init() { dbOpeners[dbm.RocksDBBackend] = openRocksDB }
+
+// openRocksDB opens a RocksDB database with the defaults of tm-db, except for
+// the tuning options set.
+func openRocksDB(name, dir string, opts DBOptions) (dbm.DB, error) {
+	cacheSize := uint64(1 << 30)
+	if opts.CacheSize > 0 {
+		cacheSize = uint64(opts.CacheSize) << 20
+	}
+	bbto := gorocksdb.NewDefaultBlockBasedTableOptions()
+	bbto.SetBlockCache(gorocksdb.NewLRUCache(cacheSize))
+	bbto.SetFilterPolicy(gorocksdb.NewBloomFilter(10))
+
+	o := gorocksdb.NewDefaultOptions()
+	o.SetBlockBasedTableFactory(bbto)
+	o.SetCreateIfMissing(true)
+	o.IncreaseParallelism(runtime.NumCPU())
+	o.OptimizeLevelStyleCompaction(512 * 1024 * 1024)
+	if opts.WriteBufferSize > 0 {
+		o.SetWriteBufferSize(opts.WriteBufferSize << 20)
+	}
+	return dbm.NewRocksDBWithOptions(name, dir, o)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestDBOptions(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DBBackend = "goleveldb"
+	cfg.Storage.State = &DBOptions{Backend: "memdb"}
+	cfg.Storage.TxIndex = &DBOptions{CacheSize: 64, WriteBufferSize: 16}
+	cfg.Storage.Evidence = nil
+
+	assert.Equal(t, DBOptions{Backend: "goleveldb"}, cfg.DBOptions("blockstore"))
+	assert.Equal(t, DBOptions{Backend: "memdb"}, cfg.DBOptions("state"))
+	assert.Equal(t, DBOptions{Backend: "goleveldb"}, cfg.DBOptions("evidence"))
+	assert.Equal(t, DBOptions{Backend: "goleveldb", CacheSize: 64, WriteBufferSize: 16}, cfg.DBOptions("tx_index"))
+	assert.Equal(t, DBOptions{Backend: "goleveldb"}, cfg.DBOptions("peerstore"))
+}
+
+func TestNewDB(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := NewDB("tuned", DBOptions{Backend: "goleveldb", CacheSize: 16, WriteBufferSize: 4}, dir)
+	require.NoError(t, err)
+	require.IsType(t, &dbm.GoLevelDB{}, db)
+	require.NoError(t, db.Set([]byte("key"), []byte("value")))
+	require.NoError(t, db.Close())
+
+	db, err = NewDB("mem", DBOptions{Backend: "memdb"}, dir)
+	require.NoError(t, err)
+	require.IsType(t, &dbm.MemDB{}, db)
+
+	_, err = NewDB("mem", DBOptions{Backend: "memdb", CacheSize: 16}, dir)
+	require.Error(t, err, "memdb has no tuning options")
+}
+
+func TestDBOptionsValidateBasic(t *testing.T) {
+	assert.NoError(t, (&DBOptions{}).ValidateBasic())
+	assert.NoError(t, (&DBOptions{Backend: "rocksdb", CacheSize: 1, WriteBufferSize: 1}).ValidateBasic())
+	assert.Error(t, (&DBOptions{Backend: "pebbledb"}).ValidateBasic())
+	assert.Error(t, (&DBOptions{CacheSize: -1}).ValidateBasic())
+	assert.Error(t, (&DBOptions{WriteBufferSize: -1}).ValidateBasic())
+
+	cfg := TestStorageConfig()
+	cfg.BlockStore.Backend = "unknown"
+	assert.Error(t, cfg.ValidateBasic())
+}
@@ -127,27 +127,86 @@ db-backend = "{{ .BaseConfig.DBBackend }}"
 # Database directory
 db-dir = "{{ js .BaseConfig.DBPath }}"
 
-# Output level for logging, including package level options
+# Output level for logging: either a level (debug, info or error), or a
+# comma-separated list of module:level pairs, such as
+# "p2p:debug,consensus:info,*:error", where "*" sets the level of the
+# other modules (info if unset)
 log-level = "{{ .BaseConfig.LogLevel }}"
 
 # Output format: 'plain' (colored text) or 'json'
+# In the json format, each message is an object with the "level", "time" and
+# "message" fields, along with the key/value pairs of the message, such as
+# "module"
 log-format = "{{ .BaseConfig.LogFormat }}"
 
+# Path to the file to log to, instead of stderr, relative to the home
+# directory if not absolute
+log-file = "{{ js .BaseConfig.LogFile }}"
+
+# Maximum size of the log file, in bytes, above which it is rotated: it is
+# renamed with the time of the rotation as a suffix, and a new file is opened.
+# 0 disables the rotation by size
+log-file-max-bytes = {{ .BaseConfig.LogFileMaxBytes }}
+
+# Age of the log file after which it is rotated, such as "24h". 0 disables the
+# rotation by age
+log-file-max-age = "{{ .BaseConfig.LogFileMaxAge }}"
+
+# Number of rotated log files kept, removing the oldest ones. 0 keeps all of
+# them
+log-file-max-files = {{ .BaseConfig.LogFileMaxFiles }}
+
 ##### additional base config options #####
 
 # Path to the JSON file containing the initial validator set and other meta data
 genesis-file = "{{ js .BaseConfig.Genesis }}"
 
+# If not 0, the maximum size in bytes of the app state of the genesis file sent to the application
+# in an InitChain request. A larger app state is delivered in chunks, in several InitChain requests,
+# which the application must support. The ABCI socket and gRPC connections limit the size of
+# messages, so this is needed to launch chains with very large genesis states.
+init-chain-chunk-size = {{ .BaseConfig.InitChainChunkSize }}
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node-key-file = "{{ js .BaseConfig.NodeKey }}"
 
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# abci-timeout, if non-zero, is the deadline of each call to the ABCI
+# application. A call that misses it fails with a timeout error. Note that
+# the node halts when a consensus call, such as FinalizeBlock, fails, which
+# is still preferable to hanging forever.
+abci-timeout = "{{ .BaseConfig.ABCITimeout }}"
+
+# abci-check-tx-timeout, if non-zero, is the deadline of each CheckTx call,
+# in place of abci-timeout. A new transaction whose check times out is
+# rejected by the mempool.
+abci-check-tx-timeout = "{{ .BaseConfig.ABCICheckTxTimeout }}"
+
+# abci-tls-ca-file, if set, is the path to a PEM file with the certificate
+# authorities used to verify the certificate of a grpc ABCI application,
+# and enables TLS for the connection to it. Relative paths are relative to
+# tendermint's config directory.
+abci-tls-ca-file = "{{ js .BaseConfig.ABCITLSCAFile }}"
+
+# abci-tls-cert-file and abci-tls-key-file, if set, are the paths to the PEM
+# certificate and private key with which the node authenticates itself to a
+# grpc ABCI application over TLS, for mutual authentication.
+abci-tls-cert-file = "{{ js .BaseConfig.ABCITLSCertFile }}"
+abci-tls-key-file = "{{ js .BaseConfig.ABCITLSKeyFile }}"
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter-peers = {{ .BaseConfig.FilterPeers }}
 
+# The name of the function computing the IDs of transactions, by which the
+# mempool, the indexer and the RPC identify them: "sha256", or a function
+# registered by the application, which must be collision resistant. It is
+# used by every command indexing or serving transactions. Changing it requires
+# reindexing the transactions.
+tx-hash = "{{ .BaseConfig.TxHash }}"
+
 
 #######################################################
 ###       Priv Validator Configuration              ###
@@ -175,6 +234,13 @@ client-key-file = "{{ js .PrivValidator.ClientKey }}"
 # Path to the Root Certificate Authority used to sign both client and server certificates
 root-ca-file = "{{ js .PrivValidator.RootCA }}"
 
+# Path to the file containing the passphrase of the key file, if the key file
+# is encrypted with "tendermint key encrypt". The passphrase can also be set with
+# the TM_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable, which takes
+# precedence over the file. The node does not prompt for the passphrase: one of
+# them is required to start with an encrypted key file.
+passphrase-file = "{{ js .PrivValidator.Passphrase }}"
+
 
 #######################################################################
 ###                 Advanced Configuration Options                  ###
@@ -185,9 +251,27 @@ root-ca-file = "{{ js .PrivValidator.RootCA }}"
 #######################################################
 [rpc]
 
-# TCP or UNIX socket address for the RPC server to listen on
+# Comma separated list of TCP or UNIX socket addresses for the RPC server to
+# listen on, e.g. "tcp://127.0.0.1:26657,tcp://[::1]:26657"
 laddr = "{{ .RPC.ListenAddress }}"
 
+# TCP or UNIX socket address for the gRPC event stream server to listen on.
+# The server streams committed transactions and block events matching a query.
+# Leave empty (the default) to disable it.
+grpc-laddr = "{{ .RPC.GRPCListenAddress }}"
+
+# Maximum number of simultaneous gRPC connections.
+# 0 - unlimited.
+grpc-max-open-connections = {{ .RPC.GRPCMaxOpenConnections }}
+
+# Serve the data companion services on the gRPC server: BlockService and
+# BlockResultsService serve blocks and their results, and PruningService lets
+# the companion set retain heights for each. While enabled, the node only prunes
+# the blocks, and the block results, below both the retain heights of the
+# application and of the companion, and prunes nothing until the companion sets
+# them. Requires grpc-laddr.
+grpc-data-companion = {{ .RPC.GRPCDataCompanion }}
+
 # A list of origins a cross-domain request can be executed from
 # Default value '[]' disables cors support
 # Use '["*"]' to allow any origin
@@ -199,9 +283,37 @@ cors-allowed-methods = [{{ range .RPC.CORSAllowedMethods }}{{ printf "%q, " . }}
 # A list of non simple headers the client is allowed to use with cross-domain requests
 cors-allowed-headers = [{{ range .RPC.CORSAllowedHeaders }}{{ printf "%q, " . }}{{end}}]
 
-# Activate unsafe RPC commands like /dial-seeds and /unsafe-flush-mempool
+# Origins a cross-domain request to a path can be executed from, as
+# "path:origin", e.g. ["/broadcast_tx_sync:https://wallet.example.com"]. For
+# the paths listed, with or without the API version prefix, these origins
+# replace cors-allowed-origins. The JSON-RPC requests are sent to the path "/",
+# and are allowed from the origins of "/" whatever their method: a method
+# restricted here can still be called with JSON-RPC from those origins.
+cors-path-allowed-origins = [{{ range .RPC.CORSPathAllowedOrigins }}{{ printf "%q, " . }}{{end}}]
+
+# Activate unsafe RPC commands like /dial-seeds, /unsafe-flush-mempool and /remove_tx
 unsafe = {{ .RPC.Unsafe }}
 
+# RPC methods to disable on all the listeners, e.g. ["dump_consensus_state"]
+disabled-methods = [{{ range .RPC.DisabledMethods }}{{ printf "%q, " . }}{{end}}]
+
+# TCP or UNIX socket address of a separate RPC listener, serving all the
+# methods including the unsafe ones to the clients authenticated with
+# privileged-auth-token, privileged-tls-client-ca-file or both. If set, the
+# unsafe methods are only served on this listener, whatever the value of
+# unsafe.
+privileged-laddr = "{{ .RPC.PrivilegedListenAddress }}"
+
+# The bearer token required in the Authorization header of the requests to
+# privileged-laddr.
+privileged-auth-token = "{{ .RPC.PrivilegedAuthToken }}"
+
+# The path to a file containing the certificate authorities of the client
+# certificates required by privileged-laddr, which then requires tls-cert-file
+# and tls-key-file. Might be either absolute path or path related to
+# Tendermint's config directory.
+privileged-tls-client-ca-file = "{{ .RPC.PrivilegedTLSClientCAFile }}"
+
 # Maximum number of simultaneous connections (including WebSocket).
 # If you want to accept a larger number than the default, make sure
 # you increase your OS limits.
@@ -210,6 +322,29 @@ unsafe = {{ .RPC.Unsafe }}
 # 1024 - 40 - 10 - 50 = 924 = ~900
 max-open-connections = {{ .RPC.MaxOpenConnections }}
 
+# Maximum number of simultaneous connections from each client IP address.
+# 0 - unlimited.
+max-open-connections-per-ip = {{ .RPC.MaxOpenConnectionsPerIP }}
+
+# Average number of requests per second of each client, by IP address or API
+# key, over HTTP and WebSocket. Requests over the limit get status 429 Too Many
+# Requests over HTTP, with a Retry-After header. The privileged listener, if
+# any, is not limited.
+# 0 - unlimited.
+rate-limit = {{ .RPC.RateLimit }}
+
+# Maximum number of requests of each client at once, with rate-limit.
+# A batch, or a method, costing more than the burst is always rejected.
+rate-limit-burst = {{ .RPC.RateLimitBurst }}
+
+# Costs of the methods that cost more, or less, than a single request with
+# rate-limit, as "method:cost".
+rate-limit-method-costs = [{{ range .RPC.RateLimitMethodCosts }}{{ printf "%q, " . }}{{end}}]
+
+# API keys, sent by clients in the X-API-Key header, whose clients are limited
+# per key with rate-limit, rather than per IP address.
+rate-limit-api-keys = [{{ range .RPC.RateLimitAPIKeys }}{{ printf "%q, " . }}{{end}}]
+
 # Maximum number of unique clientIDs that can /subscribe
 # If you're using /broadcast_tx_commit, set to the estimated maximum number
 # of broadcast_tx_commit calls per block.
@@ -220,6 +355,19 @@ max-subscription-clients = {{ .RPC.MaxSubscriptionClients }}
 # to the estimated maximum number of broadcast_tx_commit calls per block.
 max-subscriptions-per-client = {{ .RPC.MaxSubscriptionsPerClient }}
 
+# Number of events buffered for each /subscribe subscription while the
+# client is reading slower than events are published. 0 selects the
+# default of 100.
+subscription-buffer-size = {{ .RPC.SubscriptionBufferSize }}
+
+# What to do with a /subscribe subscription when its buffer is full:
+#   "disconnect" (default) - terminate the subscription
+#   "drop-oldest" - discard the oldest buffered event
+#   "drop-newest" - discard the event that does not fit
+# Dropped events show up as gaps in the seq numbers of the events
+# delivered.
+subscription-overflow-policy = "{{ .RPC.SubscriptionOverflowPolicy }}"
+
 # If true, disable the websocket interface to the RPC service.  This has
 # the effect of disabling the /subscribe, /unsubscribe, and /unsubscribe_all
 # methods for event subscription.
@@ -247,6 +395,15 @@ event-log-window-size = "{{ .RPC.EventLogWindowSize }}"
 # up to 2000, choose a value > 2000.
 event-log-max-items = {{ .RPC.EventLogMaxItems }}
 
+# The number of recent events retained so that a WebSocket client can
+# resume a subscription after reconnecting, by passing the sequence
+# number of the last event it received as the after_seq parameter of
+# /subscribe. If 0 (the default), subscriptions cannot be resumed. The
+# sequence numbers keep increasing across restarts, counted by the
+# event_seq_epoch file of the data directory, and the events of an earlier
+# run of the node are not retained.
+subscription-replay-window = {{ .RPC.SubscriptionReplayWindow }}
+
 # How long to wait for a tx to be committed during /broadcast_tx_commit.
 # WARNING: Using a value larger than 10s will result in increasing the
 # global HTTP write timeout, which applies to all connections and endpoints.
@@ -259,6 +416,38 @@ max-body-bytes = {{ .RPC.MaxBodyBytes }}
 # Maximum size of request header, in bytes
 max-header-bytes = {{ .RPC.MaxHeaderBytes }}
 
+# Maximum number of requests in a JSON-RPC batch request, 0 for no limit
+max-request-batch-size = {{ .RPC.MaxRequestBatchSize }}
+
+# Compress the responses of at least 1KiB with zstd or gzip, for the clients
+# that accept either of them. The responses for blocks, block results, headers
+# and commits at explicit heights are also marked cacheable, with the
+# Cache-Control and ETag headers, whether or not they are compressed.
+compress-responses = {{ .RPC.CompressResponses }}
+
+# The number of rounds of a height after which /health reports consensus as
+# stalled, 0 to never report it.
+health-stalled-rounds = {{ .RPC.HealthStalledRounds }}
+
+# How long the background probes of /health and /ready, every 5 seconds, wait
+# for the application to answer an Info request before reporting it
+# unresponsive. The health checks report the result of the last probe, rather
+# than sending requests to the application themselves.
+health-abci-timeout = "{{ .RPC.HealthABCITimeout }}"
+
+# Enable /simulate_tx. It runs CheckTx like a new transaction, which an
+# application updating its check state, e.g. with the sequence of the sender,
+# treats as seen: the same transaction may then be rejected by CheckTx until
+# the next block resets the check state. It is best enabled on nodes which
+# don't serve broadcasts, and rate limited with rate-limit-method-costs.
+simulate-tx = {{ .RPC.SimulateTx }}
+
+# The path of the ABCI query by which /simulate_tx asks the application to
+# simulate the execution of a transaction, with the transaction as the data of
+# the query, e.g. for its gas used and events. If empty, /simulate_tx only runs
+# CheckTx.
+simulate-query-path = "{{ .RPC.SimulateQueryPath }}"
+
 # The path to a file containing certificate that is used to create the HTTPS server.
 # Might be either absolute path or path related to Tendermint's config directory.
 # If the certificate is signed by a certificate authority,
@@ -274,6 +463,34 @@ tls-cert-file = "{{ .RPC.TLSCertFile }}"
 # Otherwise, HTTP server is run.
 tls-key-file = "{{ .RPC.TLSKeyFile }}"
 
+# Domains for which the certificate of the HTTPS server is obtained, and
+# renewed, automatically from an ACME certificate authority, e.g.
+# ["rpc.example.com"], instead of tls-cert-file and tls-key-file. The
+# certificate authority validates the domains with the TLS-ALPN-01 challenge,
+# so it must reach the RPC server on port 443 of the domains.
+tls-acme-domains = [{{ range .RPC.TLSACMEDomains }}{{ printf "%q, " . }}{{end}}]
+
+# The contact email of the ACME account, for the notices of the certificate
+# authority.
+tls-acme-email = "{{ .RPC.TLSACMEEmail }}"
+
+# The directory URL of the ACME certificate authority. If empty, Let's Encrypt
+# is used.
+tls-acme-directory-url = "{{ .RPC.TLSACMEDirectoryURL }}"
+
+# The directory caching the ACME account key and the certificates, so that they
+# are not requested again on restart. Might be either absolute path or path
+# related to Tendermint's home directory.
+tls-acme-cache-dir = "{{ js .RPC.TLSACMECacheDir }}"
+
+# Serve HTTP/2: negotiated over TLS, and without TLS (h2c) with the clients
+# knowing the server supports it. If false, only HTTP/1.1 is served. A
+# connection has at most 100 concurrent streams, but the golang.org/x/net
+# version in use predates the mitigation of the rapid reset attack
+# (CVE-2023-44487), so it should only be enabled behind a proxy terminating
+# HTTP/2.
+http2 = {{ .RPC.HTTP2 }}
+
 # pprof listen address (https://golang.org/pkg/net/http/pprof)
 pprof-laddr = "{{ .RPC.PprofListenAddress }}"
 
@@ -287,14 +504,17 @@ pprof-laddr = "{{ .RPC.PprofListenAddress }}"
 # with the default being "simple-priority".
 queue-type = "{{ .P2P.QueueType }}"
 
-# Address to listen for incoming connections
+# Comma separated list of addresses to listen for incoming connections,
+# e.g. "tcp://0.0.0.0:26656,tcp://[::]:26656" for IPv4 and IPv6.
+# Depending on the OS, "tcp://[::]:26656" alone may listen on both.
 laddr = "{{ .P2P.ListenAddress }}"
 
-# Address to advertise to peers for them to dial
-# If empty, will use the same port as the laddr,
+# Comma separated list of addresses to advertise to peers for them to dial
+# If empty, will use the same port as the first laddr,
 # and will introspect on the listener or use UPnP
-# to figure out the address. ip and port are required
-# example: 159.89.10.97:26656
+# to figure out the address. ip and port are required,
+# IPv6 addresses must be in brackets
+# example: 159.89.10.97:26656,[2001:db8::1]:26656
 external-address = "{{ .P2P.ExternalAddress }}"
 
 # Comma separated list of peers to be added to the peer store
@@ -305,7 +525,8 @@ bootstrap-peers = "{{ .P2P.BootstrapPeers }}"
 # Comma separated list of nodes to keep persistent connections to
 persistent-peers = "{{ .P2P.PersistentPeers }}"
 
-# UPNP port forwarding
+# Map the P2P port on the NAT gateway with UPnP or NAT-PMP, and advertise
+# the mapped external address to peers. Ignored if external-address is set.
 upnp = {{ .P2P.UPNP }}
 
 # Maximum number of connections (inbound and outbound).
@@ -325,6 +546,25 @@ pex = {{ .P2P.PexReactor }}
 # Warning: IPs will be exposed at /net_info, for more information https://github.com/tendermint/tendermint/issues/3055
 private-peer-ids = "{{ .P2P.PrivatePeerIDs }}"
 
+# Comma separated list of the IDs of persistent peers to peer with privately,
+# like a validator and its sentries. They get dedicated connection slots, are
+# always redialed with a backoff of at most 30s, and are never gossiped.
+private-peering-ids = "{{ .P2P.PrivatePeeringIDs }}"
+
+# Comma separated list of node IDs, IPs and CIDR ranges of the only peers
+# allowed to connect, for permissioned networks. Empty allows all peers. The
+# IPs are matched against the IP of the connection: the connections from a
+# blocked IP, or from outside the allowed ranges when no node ID is allowed,
+# are rejected before their handshake.
+# The peer lists are reloaded from this file on SIGHUP or with the
+# unsafe_reload_peer_filter RPC method.
+# example: "3e5e6f0c9a3f1f1c6a9d0f5d5b6d3e1c7a6b1c2d,10.0.0.0/8"
+allowed-peers = "{{ .P2P.AllowedPeers }}"
+
+# Comma separated list of node IDs, IPs and CIDR ranges of the peers not
+# allowed to connect, which takes precedence over allowed-peers.
+blocked-peers = "{{ .P2P.BlockedPeers }}"
+
 # Peer connection configuration.
 handshake-timeout = "{{ .P2P.HandshakeTimeout }}"
 dial-timeout = "{{ .P2P.DialTimeout }}"
@@ -345,6 +585,23 @@ send-rate = {{ .P2P.SendRate }}
 # TODO: Remove once MConnConnection is removed.
 recv-rate = {{ .P2P.RecvRate }}
 
+# Rates at which packets can be sent on each channel of the consensus, mempool,
+# blocksync and evidence reactors, in bytes/second, within send-rate. 0 means
+# only limited by send-rate. Limiting the mempool send rate keeps floods of
+# transactions from delaying consensus messages.
+# TODO: Remove once MConnConnection is removed.
+consensus-send-rate = {{ .P2P.ConsensusSendRate }}
+mempool-send-rate = {{ .P2P.MempoolSendRate }}
+blocksync-send-rate = {{ .P2P.BlockSyncSendRate }}
+evidence-send-rate = {{ .P2P.EvidenceSendRate }}
+
+# If true, packets of the channel of highest priority are always sent first,
+# so that consensus votes and block parts go before transactions and evidence.
+# Otherwise, the send rate is shared between channels in proportion to their
+# priorities.
+# TODO: Remove once MConnConnection is removed.
+strict-channel-priority = {{ .P2P.StrictChannelPriority }}
+
 
 #######################################################
 ###          Mempool Configuration Option          ###
@@ -374,6 +631,10 @@ cache-size = {{ .Mempool.CacheSize }}
 # again in the future.
 keep-invalid-txs-in-cache = {{ .Mempool.KeepInvalidTxsInCache }}
 
+# Number of the transactions evicted last from the mempool, or removed after
+# failing a recheck, whose eviction is reported by the tx_status RPC method.
+evicted-cache-size = {{ .Mempool.EvictedCacheSize }}
+
 # Maximum size of a single transaction.
 # NOTE: the max size of a tx transmitted over the network is {max-tx-bytes}.
 max-tx-bytes = {{ .Mempool.MaxTxBytes }}
@@ -399,6 +660,117 @@ ttl-duration = "{{ .Mempool.TTLDuration }}"
 # it's insertion time into the mempool is beyond ttl-duration.
 ttl-num-blocks = {{ .Mempool.TTLNumBlocks }}
 
+# rebroadcast-interval, if non-zero, enables the periodic rebroadcast of
+# transactions submitted to this node (e.g. via RPC) that are still in the
+# mempool. A local transaction is first rebroadcast to all connected peers
+# this long after it was added, and the delay doubles after every
+# rebroadcast, up to rebroadcast-max-interval.
+rebroadcast-interval = "{{ .Mempool.RebroadcastInterval }}"
+
+# rebroadcast-max-interval caps the delay between two rebroadcasts of the
+# same transaction.
+rebroadcast-max-interval = "{{ .Mempool.RebroadcastMaxInterval }}"
+
+# dog-protocol-enabled enables the dynamic optimal graph (DOG) gossip
+# protocol, which reduces the number of duplicate transactions received
+# from peers by asking them to stop forwarding transactions along
+# redundant routes.
+#
+# Peers running a version of Tendermint that does not support the
+# protocol disconnect from a node that has it enabled.
+dog-protocol-enabled = {{ .Mempool.DOGProtocolEnabled }}
+
+# dog-target-redundancy is the ratio of duplicate to first-time transactions
+# received from peers that the DOG protocol aims to maintain. Lower values
+# save more bandwidth, at the price of a lower resilience to faulty peers.
+dog-target-redundancy = {{ .Mempool.DOGTargetRedundancy }}
+
+# dog-adjust-interval is how often the DOG protocol measures the redundancy
+# and adjusts the gossip routes.
+dog-adjust-interval = "{{ .Mempool.DOGAdjustInterval }}"
+
+# announce-txs enables the announcement of transactions by their keys to
+# the peers which support it, which request the transactions they do not
+# have, instead of sending them the full transactions, which most peers of
+# a well connected node already have. It is negotiated with each peer as
+# version 2 of the mempool channels, and the full transactions are sent to
+# the peers that do not support it.
+announce-txs = {{ .Mempool.AnnounceTxs }}
+
+# peer-max-txs-per-second, if non-zero, is the maximum number of transactions
+# per second the mempool accepts from a single peer.
+peer-max-txs-per-second = {{ .Mempool.PeerMaxTxsPerSecond }}
+
+# peer-max-bytes-per-second, if non-zero, is the maximum number of transaction
+# bytes per second the mempool accepts from a single peer. It must not be
+# less than max-tx-bytes.
+peer-max-bytes-per-second = {{ .Mempool.PeerMaxBytesPerSecond }}
+
+# peer-mute-duration is how long the mempool drops all transactions from a
+# peer that exceeded one of the per-peer limits.
+peer-mute-duration = "{{ .Mempool.PeerMuteDuration }}"
+
+# max-txs-per-sender, if non-zero, is the maximum number of transactions in
+# the mempool with the same sender, as assigned by the application in its
+# CheckTx responses. Transactions without a sender are not limited. A sender
+# over the limit has its lowest-priority transactions replaced by the ones with
+# a higher priority: with the default of 1, a transaction replaces the one of
+# its sender if its priority is higher, and is otherwise rejected.
+max-txs-per-sender = {{ .Mempool.MaxTxsPerSender }}
+
+# max-gas-per-sender, if non-zero, is the maximum total gas wanted by the
+# transactions in the mempool with the same sender.
+#
+# A transaction exceeding one of the per-sender limits evicts the
+# lowest-priority transactions of its sender with a lower priority than its
+# own, or is rejected if they are not enough to bring the sender within the
+# limits.
+max-gas-per-sender = {{ .Mempool.MaxGasPerSender }}
+
+# lanes, if non-empty, splits the mempool into lanes, to which the
+# application assigns transactions in its CheckTx responses. Each lane
+# is given as "name:weight:size". Blocks are filled by taking, in turn,
+# up to weight transactions from each lane in the listed order, and at
+# most size transactions are kept in the lane (zero means no per-lane
+# limit). Each lane is gossiped on its own p2p channel, with the weight
+# as channel priority. At most 8 lanes are supported.
+#
+# Example: lanes = ["oracle:3:1000", "default:1:0"]
+lanes = [{{ range $i, $e := .Mempool.Lanes }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
+# default-lane is the lane of the transactions for which the application
+# does not assign one of the configured lanes. It must be set if lanes
+# is non-empty.
+default-lane = "{{ .Mempool.DefaultLane }}"
+
+# recheck-concurrency is the maximum number of CheckTx calls in flight
+# while rechecking the transactions left in the mempool after a block.
+# Zero means twice the number of CPUs.
+recheck-concurrency = {{ .Mempool.RecheckConcurrency }}
+
+# recheck-connections is the number of additional connections opened to a
+# remote ABCI application, across which the rechecks are spread with the
+# mempool connection. It has no effect with a builtin application, whose
+# CheckTx calls are already made concurrently.
+recheck-connections = {{ .Mempool.RecheckConnections }}
+
+# recheck-mode is either "full", to recheck all the transactions left in
+# the mempool after a block, or "partial", to only recheck those whose
+# sender the application reports as affected by the block with "recheck"
+# events in its transaction results. Transactions without a sender are
+# always rechecked, and a block without any recheck event is rechecked in
+# full.
+recheck-mode = "{{ .Mempool.RecheckMode }}"
+
+# persist-interval, if non-zero, enables the persistence of the mempool
+# across restarts: its transactions are written to persist-file this often
+# and when the node stops, and checked again to restore them when it starts.
+persist-interval = "{{ .Mempool.PersistInterval }}"
+
+# persist-file is the path to the file the transactions of the mempool are
+# persisted to, relative to the home directory if not absolute.
+persist-file = "{{ js .Mempool.PersistFile }}"
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -420,6 +792,8 @@ use-p2p = {{ .StateSync.UseP2P }}
 rpc-servers = "{{ StringsJoin .StateSync.RPCServers "," }}"
 
 # The hash and height of a trusted block. Must be within the trust-period.
+# If both are empty when using RPC, a recent block on which a quorum of more than
+# two thirds of the rpc-servers agree is trusted.
 trust-height = {{ .StateSync.TrustHeight }}
 trust-hash = "{{ .StateSync.TrustHash }}"
 
@@ -432,8 +806,10 @@ trust-period = "{{ .StateSync.TrustPeriod }}"
 discovery-time = "{{ .StateSync.DiscoveryTime }}"
 
 # Temporary directory for state sync snapshot chunks, defaults to os.TempDir().
-# The synchronizer will create a new, randomly named directory within this directory
-# and remove it when the sync is complete.
+# The synchronizer will create a directory named after the snapshot within this
+# directory and remove it when the sync is complete. If the sync is interrupted, e.g.
+# by a restart, the chunks are kept and a later sync of the same snapshot resumes
+# with them, so this should be a persistent directory.
 temp-dir = "{{ .StateSync.TempDir }}"
 
 # The timeout duration before re-requesting a chunk, possibly from a different
@@ -443,6 +819,30 @@ chunk-request-timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk and block fetchers to run (default: 4).
 fetchers = "{{ .StateSync.Fetchers }}"
 
+# The minimum number of blocks before the snapshot height to backfill after state sync, so that
+# their headers and commits can be served to light clients. The node always backfills the blocks
+# needed to verify evidence, as set by the evidence max-age consensus parameters.
+backfill-blocks = {{ .StateSync.BackfillBlocks }}
+
+# snapshot-dir, if set, is a directory of snapshots created by the
+# application with the abci/snapshots package, from which the node serves
+# snapshots to peers instead of calling the application's ListSnapshots and
+# LoadSnapshotChunk. Relative paths are relative to the home directory.
+snapshot-dir = "{{ js .StateSync.SnapshotDir }}"
+
+# snapshot-formats, if set with snapshot-dir, are the snapshot formats the
+# application supports, such as [1, 2]. Only the snapshots in those formats
+# are served to peers, and the offers of snapshots in other formats are
+# rejected without calling the application's OfferSnapshot.
+snapshot-formats = [{{ range $i, $e := .StateSync.SnapshotFormats }}{{if $i}}, {{end}}{{ $e }}{{end}}]
+
+# Comma separated base URLs of HTTP(S) servers from which snapshots and their chunks are fetched
+# in addition to peers, e.g. "https://snapshots.example.com/chain-1". A provider serves the JSON
+# list of its snapshots at <url>/snapshots.json and each chunk at <url>/<height>-<format>/<index>,
+# as served by the handler of the abci/snapshots store. The snapshots are verified like the
+# snapshots of peers, against the app hash verified by the light client.
+snapshot-providers = "{{ StringsJoin .StateSync.SnapshotProviders "," }}"
+
 #######################################################
 ###         Consensus Configuration Options         ###
 #######################################################
@@ -456,6 +856,55 @@ wal-file = "{{ js .Consensus.WalPath }}"
 # So, validators should stop the state machine, wait for some blocks, and then restart the state machine to avoid panic.
 double-sign-check-height = {{ .Consensus.DoubleSignCheckHeight }}
 
+# Truncate the WAL file at its first corrupt record, e.g. one partially
+# written when the node crashed, when consensus starts. The file is backed
+# up next to it first. The "tendermint wal" command inspects and repairs the
+# WAL file of a stopped node as well.
+wal-repair = {{ .Consensus.WalRepair }}
+
+# The file of the machine-readable record of the last halt of consensus on a
+# consistency violation, such as a block with a wrong app hash
+halt-file = "{{ js .Consensus.HaltPath }}"
+
+# If true, the node keeps running and serving RPC when consensus halts, so that
+# the halt can be inspected with the /status endpoint. Otherwise, the node stops.
+keep-rpc-on-halt = {{ .Consensus.KeepRPCOnHalt }}
+
+# If positive, consensus halts once the block at this height is committed, and
+# the node stops, e.g. for a coordinated upgrade of its binary.
+halt-height = {{ .Consensus.HaltHeight }}
+
+# If positive, consensus halts once a block with at least this time, in Unix
+# seconds, is committed, and the node stops.
+halt-time = {{ .Consensus.HaltTime }}
+
+# A command run with "sh -c" after the node stops on a planned halt, such as to
+# swap its binary, with the TM_HALT_HEIGHT, TM_HALT_TIME and TM_HOME
+# environment variables set.
+halt-hook = "{{ js .Consensus.HaltHook }}"
+
+# If true, the txs and parts of the blocks are hashed, and their evidence is
+# verified, across GOMAXPROCS goroutines when validating them, reducing the
+# validation latency of large blocks.
+parallel-verification = {{ .Consensus.ParallelVerification }}
+
+# If true, the proposal blocks are sent to the peers which also enable it as
+# compact blocks, of the hashes of their txs, which the peers reconstruct from
+# the txs of their mempools, falling back to the block parts for the peers
+# missing some of the txs. This cuts the bandwidth of the proposals between
+# nodes which already hold most of their txs.
+compact-blocks = {{ .Consensus.CompactBlocks }}
+
+# If positive, the node switches back from consensus to block sync when it falls
+# more than this number of blocks behind most of its peers, e.g. after a network
+# outage, and back to consensus once it catches up. Validators never switch. 0
+# disables it.
+block-sync-lag = {{ .Consensus.BlockSyncLag }}
+
+# The minimum time the node runs consensus before it switches back to block
+# sync, so that it does not switch back and forth.
+block-sync-cooldown = "{{ .Consensus.BlockSyncCooldown }}"
+
 # EmptyBlocks mode and possible interval between empty blocks
 create-empty-blocks = {{ .Consensus.CreateEmptyBlocks }}
 create-empty-blocks-interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
@@ -508,6 +957,14 @@ peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 # as soon as the node has gathered votes from all of the validators on the network.
 # unsafe-bypass-commit-timeout-override =
 
+# If true, the deprecated timeout-propose, timeout-propose-delta, timeout-prevote,
+# timeout-prevote-delta, timeout-precommit, timeout-precommit-delta, timeout-commit
+# and skip-timeout-commit fields of the configuration files of earlier releases
+# are applied as the overrides above that are not set. timeout-prevote and
+# timeout-precommit, and their deltas, override the Vote timeout with the larger
+# of the two. This field is deprecated, and will be removed in v0.37.
+# legacy-timeouts = false
+
 #######################################################
 ###   Transaction Indexer Configuration Options     ###
 #######################################################
@@ -523,6 +980,9 @@ peer-query-maj23-sleep-duration = "{{ .Consensus.PeerQueryMaj23SleepDuration }}"
 #   1) "null" (default) - no indexer services.
 #   2) "kv" - a simple indexer backed by key-value storage (see DBBackend)
 #   3) "psql" - the indexer services backed by PostgreSQL.
+#   4) "custom:<name>" - a third-party sink registered with the sink registry
+#      under <name>, e.g. "custom:elasticsearch".
+# Events are delivered to every listed sink.
 # When "kv" or "psql" is chosen "tx.height" and "tx.hash" will always be indexed.
 indexer = [{{ range $i, $e := .TxIndex.Indexer }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
 
@@ -530,6 +990,136 @@ indexer = [{{ range $i, $e := .TxIndex.Indexer }}{{if $i}}, {{end}}{{ printf "%q
 #   postgresql://<user>:<password>@<host>:<port>/<db>?<opts>
 psql-conn = "{{ .TxIndex.PsqlConn }}"
 
+# The maximum number of rows the psql sink writes with a single multi-row
+# INSERT statement. All rows for a block are written in one database
+# transaction regardless of this setting.
+psql-batch-size = {{ .TxIndex.PsqlBatchSize }}
+
+# The psql sink creates its schema and applies any pending schema migrations
+# when it connects to the database, recording the applied versions in the
+# schema_version table. Set this to true to manage the schema by hand instead,
+# using the files in state/indexer/sink/psql/migrations.
+psql-no-migrate = {{ .TxIndex.PsqlNoMigrate }}
+
+# The database/sql driver of the psql sink: "postgres" (lib/pq), or a driver
+# registered by the binary, such as "pgx" (github.com/jackc/pgx/v4/stdlib).
+# The sink supports PostgreSQL and CockroachDB databases.
+psql-driver = "{{ .TxIndex.PsqlDriver }}"
+
+# The pool of connections of the psql sink: the maximum number of open
+# connections (0 means no limit), of idle connections (0 selects the default
+# of 2), and the maximum time a connection is reused (0 means no limit), e.g.
+# to rebalance the connections to the nodes of a CockroachDB cluster.
+psql-max-open-conns = {{ .TxIndex.PsqlMaxOpenConns }}
+psql-max-idle-conns = {{ .TxIndex.PsqlMaxIdleConns }}
+psql-conn-max-lifetime = "{{ .TxIndex.PsqlConnMaxLifetime }}"
+
+# The timeout of each statement of the psql sink, after which the database
+# aborts it. 0 means no timeout.
+psql-statement-timeout = "{{ .TxIndex.PsqlStatementTimeout }}"
+
+# The number of times the psql sink retries a transaction aborted by a
+# serialization failure, as CockroachDB reports under contention.
+psql-max-retries = {{ .TxIndex.PsqlMaxRetries }}
+
+# If true, blocks are indexed asynchronously by a separate worker for each
+# sink, so that a slow sink (e.g. psql over a WAN link) does not stall block
+# production. Each sink buffers up to queue-size complete blocks; once a queue
+# is full, block production waits for that sink to catch up.
+async = {{ .TxIndex.Async }}
+queue-size = {{ .TxIndex.QueueSize }}
+
+# Restrict which ABCI events are indexed by the kv and psql sinks. Each entry
+# is either an event type, e.g. "transfer", covering all of its attributes, or
+# a single attribute, e.g. "transfer.amount". If index-events is non-empty,
+# only the listed events and attributes are indexed; anything listed in
+# exclude-events is never indexed. Attributes must still be flagged for
+# indexing by the application. The built-in tx.height, tx.hash and
+# block.height keys are always indexed.
+index-events = [{{ range $i, $e := .TxIndex.IndexEvents }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+exclude-events = [{{ range $i, $e := .TxIndex.ExcludeEvents }}{{if $i}}, {{end}}{{ printf "%q" $e}}{{end}}]
+
+# If non-zero, the data indexed for blocks older than the latest retain-blocks
+# blocks is pruned from the kv and psql sinks. Pruning runs every 100 blocks,
+# on the indexing path, so consider enabling async indexing with it.
+retain-blocks = {{ .TxIndex.RetainBlocks }}
+
+# If true, the data indexed for blocks that have been pruned from the block
+# store, e.g. below the retain height set by the application, is pruned too.
+prune-with-blocks = {{ .TxIndex.PruneWithBlocks }}
+
+# Limits of the events of the application which are indexed and sent to
+# subscribers: the number of events of a transaction or block, and the size in
+# bytes of event types and attribute keys, and of attribute values. The events
+# of a transaction or block exceeding them, or which are not valid UTF-8, are
+# replaced by a single "invalid_events" event whose "error" attribute says why.
+# The block results stored and returned by the RPC are not changed. Zero means
+# no limit.
+max-events = {{ .TxIndex.MaxEvents }}
+max-event-key-size = {{ .TxIndex.MaxEventKeySize }}
+max-event-value-size = {{ .TxIndex.MaxEventValueSize }}
+
+#######################################################
+###       Storage Configuration Options             ###
+#######################################################
+[storage]
+
+# If true, the block store and state store databases are compacted after
+# pruning, to reclaim the disk space of the pruned heights, which LevelDB and
+# RocksDB otherwise keep. Only the goleveldb backend, and rocksdb and cleveldb
+# when built in, support it. The "tendermint compact-db" command compacts the
+# databases of a stopped node instead.
+compact = {{ .Storage.Compact }}
+
+# The minimum time between two compactions. Compaction rewrites the databases,
+# so it should run much less often than pruning.
+compaction-interval = "{{ .Storage.CompactionInterval }}"
+
+[storage.pruning]
+
+# The time between two runs of the pruner, which removes the blocks, states and
+# block results below the retain heights set by the application and, with
+# rpc.grpc-data-companion, by the data companion. Pruning runs in the
+# background, 1000 heights at a time, so that it does not delay consensus.
+interval = "{{ .Storage.Pruning.Interval }}"
+
+[storage.audit]
+
+# The time between two runs of the storage auditor, or 0 to disable it. Each
+# run checks the latest state against the block store and, for a random sample
+# of the stored heights, recomputes the block hashes, verifies the commit
+# signatures and checks the references to the state store, reporting any
+# corruption in the metrics and the /storage_health RPC endpoint.
+interval = "{{ .Storage.Audit.Interval }}"
+
+# The number of heights audited by each run, always including the latest one.
+sample-size = {{ .Storage.Audit.SampleSize }}
+
+# The backend and tuning options of each database of the node. An empty
+# backend selects db-backend. A zero cache-size or write-buffer-size, in MiB,
+# selects the default of the backend. Only goleveldb, and rocksdb when built
+# in, support the tuning options.
+[storage.blockstore]
+backend = "{{ .Storage.BlockStore.Backend }}"
+cache-size = {{ .Storage.BlockStore.CacheSize }}
+write-buffer-size = {{ .Storage.BlockStore.WriteBufferSize }}
+
+[storage.state]
+backend = "{{ .Storage.State.Backend }}"
+cache-size = {{ .Storage.State.CacheSize }}
+write-buffer-size = {{ .Storage.State.WriteBufferSize }}
+
+[storage.evidence]
+backend = "{{ .Storage.Evidence.Backend }}"
+cache-size = {{ .Storage.Evidence.CacheSize }}
+write-buffer-size = {{ .Storage.Evidence.WriteBufferSize }}
+
+# The database of the kv indexer sink.
+[storage.tx-index]
+backend = "{{ .Storage.TxIndex.Backend }}"
+cache-size = {{ .Storage.TxIndex.CacheSize }}
+write-buffer-size = {{ .Storage.TxIndex.WriteBufferSize }}
+
 #######################################################
 ###       Instrumentation Configuration Options     ###
 #######################################################
@@ -551,6 +1141,26 @@ max-open-connections = {{ .Instrumentation.MaxOpenConnections }}
 
 # Instrumentation namespace
 namespace = "{{ .Instrumentation.Namespace }}"
+
+# When true, the metrics of peers, such as the bytes sent to and received
+# from each peer, are labeled by peer ID. Otherwise, they're the totals of
+# all the peers, with an empty peer_id label. The number of series of
+# these metrics grows with the number of peers.
+peer-metrics = {{ .Instrumentation.PeerMetrics }}
+
+# When true, the metrics of each validator, such as its voting power and
+# missed blocks, are recorded, labeled by validator address. The number of
+# series of these metrics grows with the size of the validator set.
+validator-metrics = {{ .Instrumentation.ValidatorMetrics }}
+
+# URL of an OTLP/HTTP collector to export traces of consensus steps, ABCI
+# calls and RPC requests to, such as "http://localhost:4318/v1/traces".
+# The spans of a height share a trace, across all the nodes of the chain.
+# "" disables tracing.
+trace-endpoint = "{{ .Instrumentation.TraceEndpoint }}"
+
+# Fraction of the traces exported, from 0 to 1.
+trace-sample-rate = {{ .Instrumentation.TraceSampleRate }}
 `
 
 /****** these are for test settings ***********/
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/tendermint/tendermint/crypto"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 )
 
@@ -33,7 +34,17 @@ type Proof struct {
 // ProofsFromByteSlices computes inclusion proof for given items.
 // proofs[0] is the proof for items[0].
 func ProofsFromByteSlices(items [][]byte) (rootHash []byte, proofs []*Proof) {
-	trails, rootSPN := trailsFromByteSlices(items)
+	return ProofsFromByteSlicesParallel(items, 1)
+}
+
+// ProofsFromByteSlicesParallel is ProofsFromByteSlices hashing the items
+// across up to workers goroutines.
+func ProofsFromByteSlicesParallel(items [][]byte, workers int) (rootHash []byte, proofs []*Proof) {
+	leafHashes := make([][]byte, len(items))
+	tmsync.ForEach(len(items), workers, func(i int) {
+		leafHashes[i] = leafHash(items[i])
+	})
+	trails, rootSPN := trailsFromLeafHashes(leafHashes)
 	rootHash = rootSPN.Hash
 	proofs = make([]*Proof, len(items))
 	for i, trail := range trails {
@@ -217,17 +228,27 @@ func (spn *ProofNode) FlattenAunts() [][]byte {
 // trails[0].Hash is the leaf hash for items[0].
 // trails[i].Parent.Parent....Parent == root for all i.
 func trailsFromByteSlices(items [][]byte) (trails []*ProofNode, root *ProofNode) {
+	leafHashes := make([][]byte, len(items))
+	for i, item := range items {
+		leafHashes[i] = leafHash(item)
+	}
+	return trailsFromLeafHashes(leafHashes)
+}
+
+// trailsFromLeafHashes is trailsFromByteSlices with the leaf hashes of the
+// items.
+func trailsFromLeafHashes(leafHashes [][]byte) (trails []*ProofNode, root *ProofNode) {
 	// Recursive impl.
-	switch len(items) {
+	switch len(leafHashes) {
 	case 0:
 		return []*ProofNode{}, &ProofNode{emptyHash(), nil, nil, nil}
 	case 1:
-		trail := &ProofNode{leafHash(items[0]), nil, nil, nil}
+		trail := &ProofNode{leafHashes[0], nil, nil, nil}
 		return []*ProofNode{trail}, trail
 	default:
-		k := getSplitPoint(int64(len(items)))
-		lefts, leftRoot := trailsFromByteSlices(items[:k])
-		rights, rightRoot := trailsFromByteSlices(items[k:])
+		k := getSplitPoint(int64(len(leafHashes)))
+		lefts, leftRoot := trailsFromLeafHashes(leafHashes[:k])
+		rights, rightRoot := trailsFromLeafHashes(leafHashes[k:])
 		rootHash := innerHash(leftRoot.Hash, rightRoot.Hash)
 		root := &ProofNode{rootHash, nil, nil, nil}
 		leftRoot.Parent = root
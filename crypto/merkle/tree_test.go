@@ -100,6 +100,22 @@ func TestProof(t *testing.T) {
 	}
 }
 
+func TestProofsParallel(t *testing.T) {
+	for _, total := range []int{0, 1, 2, 7, 100} {
+		items := make([][]byte, total)
+		for i := range items {
+			items[i] = tmrand.Bytes(crypto.HashSize)
+		}
+
+		rootHash, proofs := ProofsFromByteSlices(items)
+		for _, workers := range []int{2, 8} {
+			rootHash2, proofs2 := ProofsFromByteSlicesParallel(items, workers)
+			require.Equal(t, rootHash, rootHash2)
+			require.Equal(t, proofs, proofs2)
+		}
+	}
+}
+
 func TestHashAlternatives(t *testing.T) {
 
 	total := 100
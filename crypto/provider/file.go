@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/internal/jsontypes"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+)
+
+// FileProviderName is the name of the file provider.
+const FileProviderName = "file"
+
+var labelRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// FileProvider is a provider keeping each key in a file of a directory, e.g.
+// one mounted from a secret store, apart from the home of the node. It stands
+// in for an HSM in tests and development. Each key is read from its file once,
+// and then kept in memory.
+type FileProvider struct {
+	dir string
+
+	mtx  sync.Mutex
+	keys map[string]crypto.PrivKey
+}
+
+var _ Provider = (*FileProvider)(nil)
+
+// OpenFileProvider opens the file provider of the directory of the config,
+// creating it if it does not exist.
+func OpenFileProvider(config string) (Provider, error) {
+	if config == "" {
+		return nil, errors.New("the config of the file provider must be the directory of its keys")
+	}
+	if err := os.MkdirAll(config, 0700); err != nil {
+		return nil, err
+	}
+	return &FileProvider{dir: config, keys: make(map[string]crypto.PrivKey)}, nil
+}
+
+// GenerateKey generates an ed25519 or secp256k1 key into the file of the label.
+func (p *FileProvider) GenerateKey(label, keyType string) (crypto.PubKey, error) {
+	path, err := p.keyPath(label)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("key %q already exists", label)
+	}
+
+	var privKey crypto.PrivKey
+	switch keyType {
+	case "", ed25519.KeyType:
+		privKey = ed25519.GenPrivKey()
+	case secp256k1.KeyType:
+		privKey = secp256k1.GenPrivKey()
+	default:
+		return nil, fmt.Errorf("key type %q is not supported", keyType)
+	}
+	bz, err := jsontypes.Marshal(privKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := tempfile.WriteFileAtomic(path, bz, 0600); err != nil {
+		return nil, err
+	}
+	p.mtx.Lock()
+	p.keys[label] = privKey
+	p.mtx.Unlock()
+	return privKey.PubKey(), nil
+}
+
+// PubKey returns the public key of the key of the label.
+func (p *FileProvider) PubKey(label string) (crypto.PubKey, error) {
+	privKey, err := p.loadKey(label)
+	if err != nil {
+		return nil, err
+	}
+	return privKey.PubKey(), nil
+}
+
+// Sign signs the message with the key of the label.
+func (p *FileProvider) Sign(label string, msg []byte) ([]byte, error) {
+	privKey, err := p.loadKey(label)
+	if err != nil {
+		return nil, err
+	}
+	return privKey.Sign(msg)
+}
+
+// loadKey returns the key of the label, reading it from its file the first
+// time.
+func (p *FileProvider) loadKey(label string) (crypto.PrivKey, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if privKey, ok := p.keys[label]; ok {
+		return privKey, nil
+	}
+
+	path, err := p.keyPath(label)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var privKey crypto.PrivKey
+	if err := jsontypes.Unmarshal(json.RawMessage(bz), &privKey); err != nil {
+		return nil, fmt.Errorf("decoding key %q: %w", label, err)
+	}
+	p.keys[label] = privKey
+	return privKey, nil
+}
+
+func (p *FileProvider) keyPath(label string) (string, error) {
+	if !labelRegexp.MatchString(label) {
+		return "", fmt.Errorf("invalid key label %q", label)
+	}
+	return filepath.Join(p.dir, label+".json"), nil
+}
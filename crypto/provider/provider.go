@@ -0,0 +1,238 @@
+// Package provider routes the signing with private keys to crypto providers,
+// which keep the keys out of the node, e.g. in a PKCS#11 HSM or a cloud KMS.
+//
+// The key of a provider is encoded in the node key and validator key files in
+// place of the private key, as a reference to the key:
+//
+//	{
+//	  "type": "tendermint/PrivKeyProvider",
+//	  "value": {
+//	    "provider": "pkcs11",
+//	    "config": "/etc/tendermint/hsm.toml",
+//	    "label": "validator",
+//	    "pub_key": {"type": "tendermint/PubKeyEd25519", "value": "..."}
+//	  }
+//	}
+//
+// Decoding the reference opens the provider with its config, so that the key
+// files of the provider keys are loaded as any other.
+//
+// Providers are registered by name with Register, usually from the init
+// function of a package linking the library of an HSM or the client of a KMS,
+// which is imported by the binary of the node. The file provider, keeping the
+// keys in a directory apart from the node, is built in. No HSM or KMS provider
+// is included: the pkcs11 provider of the example is one the binary registers.
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/jsontypes"
+)
+
+// PrivKeyName is the type tag of the provider keys in JSON.
+const PrivKeyName = "tendermint/PrivKeyProvider"
+
+// Provider generates and keeps private keys, which don't leave it, and signs
+// with them.
+type Provider interface {
+	// GenerateKey generates a key of the key type, e.g. "ed25519", under the
+	// label. It fails if there is a key with the label.
+	GenerateKey(label, keyType string) (crypto.PubKey, error)
+	// PubKey returns the public key of the key with the label.
+	PubKey(label string) (crypto.PubKey, error)
+	// Sign signs the message with the key with the label.
+	Sign(label string, msg []byte) ([]byte, error)
+}
+
+// Opener opens a provider with its config, whose format is up to the
+// provider, e.g. the path of a file with the PKCS#11 module and token to use.
+type Opener func(config string) (Provider, error)
+
+var registry = struct {
+	mtx     sync.Mutex
+	openers map[string]Opener
+	opened  map[[2]string]Provider
+}{
+	openers: make(map[string]Opener),
+	opened:  make(map[[2]string]Provider),
+}
+
+func init() {
+	jsontypes.MustRegister(PrivKey{})
+	MustRegister(FileProviderName, OpenFileProvider)
+}
+
+// Register registers the opener of the provider with the name. It reports an
+// error if the name is empty or already registered.
+func Register(name string, open Opener) error {
+	if name == "" {
+		return errors.New("crypto provider name cannot be empty")
+	}
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	if _, ok := registry.openers[name]; ok {
+		return fmt.Errorf("crypto provider %q is already registered", name)
+	}
+	registry.openers[name] = open
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func MustRegister(name string, open Opener) {
+	if err := Register(name, open); err != nil {
+		panic(err)
+	}
+}
+
+// Registered returns the sorted names of the registered providers.
+func Registered() []string {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	return registered()
+}
+
+func registered() []string {
+	names := make([]string, 0, len(registry.openers))
+	for name := range registry.openers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open returns the provider with the name opened with the config. A provider
+// is opened once per config, so that the keys of the node share its session.
+func Open(name, config string) (Provider, error) {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	open, ok := registry.openers[name]
+	if !ok {
+		return nil, fmt.Errorf("crypto provider %q is not registered (registered: %v)", name, registered())
+	}
+	if p, ok := registry.opened[[2]string{name, config}]; ok {
+		return p, nil
+	}
+	p, err := open(config)
+	if err != nil {
+		return nil, fmt.Errorf("opening crypto provider %q: %w", name, err)
+	}
+	registry.opened[[2]string{name, config}] = p
+	return p, nil
+}
+
+// PrivKey is a private key kept by a provider. It implements crypto.PrivKey,
+// signing with the provider, but its bytes are not exported.
+type PrivKey struct {
+	provider Provider
+	name     string
+	config   string
+	label    string
+	pubKey   crypto.PubKey
+}
+
+var _ crypto.PrivKey = PrivKey{}
+
+// GenPrivKey generates a key of the key type under the label with the
+// provider with the name, opened with the config.
+func GenPrivKey(name, config, label, keyType string) (PrivKey, error) {
+	p, err := Open(name, config)
+	if err != nil {
+		return PrivKey{}, err
+	}
+	pubKey, err := p.GenerateKey(label, keyType)
+	if err != nil {
+		return PrivKey{}, fmt.Errorf("generating key %q with crypto provider %q: %w", label, name, err)
+	}
+	return PrivKey{provider: p, name: name, config: config, label: label, pubKey: pubKey}, nil
+}
+
+// LoadPrivKey loads the key with the label of the provider with the name,
+// opened with the config.
+func LoadPrivKey(name, config, label string) (PrivKey, error) {
+	p, err := Open(name, config)
+	if err != nil {
+		return PrivKey{}, err
+	}
+	pubKey, err := p.PubKey(label)
+	if err != nil {
+		return PrivKey{}, fmt.Errorf("loading key %q of crypto provider %q: %w", label, name, err)
+	}
+	return PrivKey{provider: p, name: name, config: config, label: label, pubKey: pubKey}, nil
+}
+
+// Bytes returns nil, since the key does not leave the provider.
+func (k PrivKey) Bytes() []byte { return nil }
+
+// Sign signs the message with the provider.
+func (k PrivKey) Sign(msg []byte) ([]byte, error) {
+	if k.provider == nil {
+		return nil, errors.New("crypto provider key not loaded")
+	}
+	return k.provider.Sign(k.label, msg)
+}
+
+// PubKey returns the public key of the key.
+func (k PrivKey) PubKey() crypto.PubKey { return k.pubKey }
+
+// Equals reports whether the other key has the same public key.
+func (k PrivKey) Equals(other crypto.PrivKey) bool {
+	return k.pubKey != nil && other != nil && other.PubKey() != nil && k.pubKey.Equals(other.PubKey())
+}
+
+// Type returns the type of the public key.
+func (k PrivKey) Type() string {
+	if k.pubKey == nil {
+		return ""
+	}
+	return k.pubKey.Type()
+}
+
+// Label returns the label of the key in its provider.
+func (k PrivKey) Label() string { return k.label }
+
+// TypeTag satisfies the jsontypes.Tagged interface.
+func (PrivKey) TypeTag() string { return PrivKeyName }
+
+type privKeyJSON struct {
+	Provider string          `json:"provider"`
+	Config   string          `json:"config,omitempty"`
+	Label    string          `json:"label"`
+	PubKey   json.RawMessage `json:"pub_key"`
+}
+
+// MarshalJSON encodes the reference to the key in its provider.
+func (k PrivKey) MarshalJSON() ([]byte, error) {
+	pubKey, err := jsontypes.Marshal(k.pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(privKeyJSON{Provider: k.name, Config: k.config, Label: k.label, PubKey: pubKey})
+}
+
+// UnmarshalJSON loads the key of the reference from its provider, and checks
+// that its public key is the one of the reference.
+func (k *PrivKey) UnmarshalJSON(data []byte) error {
+	var ref privKeyJSON
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return err
+	}
+	var pubKey crypto.PubKey
+	if err := jsontypes.Unmarshal(ref.PubKey, &pubKey); err != nil {
+		return fmt.Errorf("decoding PubKey: %w", err)
+	}
+	key, err := LoadPrivKey(ref.Provider, ref.Config, ref.Label)
+	if err != nil {
+		return err
+	}
+	if pubKey != nil && !key.pubKey.Equals(pubKey) {
+		return fmt.Errorf("public key of key %q of crypto provider %q does not match the key file", ref.Label, ref.Provider)
+	}
+	*k = key
+	return nil
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/internal/jsontypes"
+)
+
+func TestFileProviderKey(t *testing.T) {
+	dir := t.TempDir()
+	msg := []byte("sign me")
+
+	for _, keyType := range []string{ed25519.KeyType, secp256k1.KeyType} {
+		key, err := GenPrivKey(FileProviderName, dir, "validator-"+keyType, keyType)
+		require.NoError(t, err)
+		require.Equal(t, keyType, key.Type())
+		require.Nil(t, key.Bytes(), "the key does not leave the provider")
+
+		sig, err := key.Sign(msg)
+		require.NoError(t, err)
+		require.True(t, key.PubKey().VerifySignature(msg, sig))
+
+		// the key files reference the key of the provider
+		bz, err := jsontypes.Marshal(key)
+		require.NoError(t, err)
+		require.Contains(t, string(bz), `"provider":"file"`)
+		require.NotContains(t, string(bz), "priv_key")
+
+		var decoded crypto.PrivKey
+		require.NoError(t, jsontypes.Unmarshal(bz, &decoded))
+		require.True(t, decoded.Equals(key))
+		sig, err = decoded.Sign(msg)
+		require.NoError(t, err)
+		require.True(t, key.PubKey().VerifySignature(msg, sig))
+	}
+
+	_, err := GenPrivKey(FileProviderName, dir, "validator-"+ed25519.KeyType, ed25519.KeyType)
+	require.Error(t, err, "the label is taken")
+	_, err = GenPrivKey(FileProviderName, dir, "../validator", ed25519.KeyType)
+	require.Error(t, err)
+	_, err = GenPrivKey(FileProviderName, dir, "sr", "sr25519")
+	require.Error(t, err)
+	_, err = LoadPrivKey(FileProviderName, dir, "missing")
+	require.Error(t, err)
+}
+
+func TestFileProviderLoadsKeyOnce(t *testing.T) {
+	dir := t.TempDir()
+	msg := []byte("sign me")
+
+	p, err := OpenFileProvider(dir)
+	require.NoError(t, err)
+	pubKey, err := p.GenerateKey("validator", ed25519.KeyType)
+	require.NoError(t, err)
+
+	// a provider reopening the directory reads the key once, and then signs
+	// without its file
+	p, err = OpenFileProvider(dir)
+	require.NoError(t, err)
+	_, err = p.PubKey("validator")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(dir, "validator.json")))
+	sig, err := p.Sign("validator", msg)
+	require.NoError(t, err)
+	require.True(t, pubKey.VerifySignature(msg, sig))
+}
+
+func TestPrivKeyJSON(t *testing.T) {
+	dir := t.TempDir()
+	key, err := GenPrivKey(FileProviderName, dir, "node", ed25519.KeyType)
+	require.NoError(t, err)
+
+	ref := privKeyJSON{Provider: FileProviderName, Config: dir, Label: "node"}
+	ref.PubKey, err = jsontypes.Marshal(ed25519.GenPrivKey().PubKey())
+	require.NoError(t, err)
+	bz, err := json.Marshal(ref)
+	require.NoError(t, err)
+	err = json.Unmarshal(bz, new(PrivKey))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match")
+
+	ref.PubKey, err = jsontypes.Marshal(key.PubKey())
+	require.NoError(t, err)
+	ref.Provider = "pkcs11"
+	bz, err = json.Marshal(ref)
+	require.NoError(t, err)
+	err = json.Unmarshal(bz, new(PrivKey))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `crypto provider "pkcs11" is not registered`)
+
+	_, err = PrivKey{}.Sign([]byte("msg"))
+	require.Error(t, err)
+}
+
+func TestRegister(t *testing.T) {
+	require.Error(t, Register(FileProviderName, OpenFileProvider), "already registered")
+	require.Error(t, Register("", OpenFileProvider))
+	require.Contains(t, Registered(), FileProviderName)
+
+	_, err := Open(FileProviderName, "")
+	require.Error(t, err)
+
+	// a provider is opened once per config
+	dir := t.TempDir()
+	p1, err := Open(FileProviderName, dir)
+	require.NoError(t, err)
+	p2, err := Open(FileProviderName, dir)
+	require.NoError(t, err)
+	require.Same(t, p1, p2)
+}
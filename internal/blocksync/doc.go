@@ -27,5 +27,12 @@ to its pool via AddBlock.
 Internally, v0 runs a poolRoutine that constantly checks for what blocks it needs
 and requests them. The poolRoutine is also responsible for taking blocks from the
 pool, saving and executing each block.
+
+The pool requests a window of up to maxTotalRequesters blocks from the peers in
+parallel. The peers are scored by the average latency of their deliveries and
+the blocks they did not have: each block is requested from the peer expected
+to deliver it first, and peers much slower than the fastest one are evicted.
+The remaining time of the sync is estimated from the rate of the last blocks.
+The scores of the peers and the remaining time are reported by the status RPC.
 */
 package blocksync
@@ -44,6 +44,20 @@ const (
 
 	// Maximum difference between current and new block's height.
 	maxDiffBetweenCurrentAndReceivedBlockHeight = 100
+
+	// A peer is evicted as too slow if its average latency is more than
+	// slowPeerLatencyFactor times the one of the fastest peer, and more than
+	// minSlowPeerLatency, once both have delivered minLatencySamples blocks.
+	slowPeerLatencyFactor = 5
+	minSlowPeerLatency    = time.Second
+	minLatencySamples     = 10
+
+	// Weight of a new latency sample in the average latency of a peer.
+	latencyWeight = 0.2
+	// Latency of the peers without deliveries, for them to be tried first.
+	initialLatency = time.Millisecond
+	// Maximum number of doublings of the cost of a peer for its failures.
+	maxFailurePenalty = 16
 )
 
 var peerTimeout = 15 * time.Second // not const so we can override with tests
@@ -57,6 +71,10 @@ var peerTimeout = 15 * time.Second // not const so we can override with tests
 	Requests are continuously made for blocks of higher heights until
 	the limit is reached. If most of the requests have no available peers, and we
 	are not at peer limits, we can probably switch to consensus reactor
+
+	Each request goes to the peer expected to deliver it first, by the average
+	latency of its deliveries and its pending requests, penalized for the
+	blocks it did not have. Peers much slower than the fastest are evicted.
 */
 
 // BlockRequest stores a block request identified by the block Height and the
@@ -154,6 +172,7 @@ func (pool *BlockPool) removeTimedoutPeers() {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	pool.evictSlowPeers()
 	for _, peer := range pool.peers {
 		// check if peer timed out
 		if !peer.didTimeout && peer.numPending > 0 {
@@ -301,6 +320,7 @@ func (pool *BlockPool) AddBlock(peerID types.NodeID, block *types.Block, extComm
 		peer := pool.peers[peerID]
 		if peer != nil {
 			peer.decrPending(blockSize)
+			peer.addLatency(time.Since(requester.getRequestedAt()))
 		}
 	} else {
 		err := errors.New("requester is different or block already exists")
@@ -311,6 +331,40 @@ func (pool *BlockPool) AddBlock(peerID types.NodeID, block *types.Block, extComm
 	return nil
 }
 
+// NoBlock handles the response of a peer not having the block at height. The
+// block is requested from another peer, and the peer is penalized.
+func (pool *BlockPool) NoBlock(peerID types.NodeID, height int64) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	requester := pool.requesters[height]
+	if requester == nil || requester.getPeerID() != peerID || requester.getBlock() != nil {
+		return
+	}
+	if peer := pool.peers[peerID]; peer != nil {
+		peer.decrPending(0)
+		peer.failures++
+	}
+	requester.redo(peerID)
+}
+
+// PeerScores returns the scores of the peers, by ID.
+func (pool *BlockPool) PeerScores() map[types.NodeID]PeerScore {
+	pool.mtx.RLock()
+	defer pool.mtx.RUnlock()
+
+	scores := make(map[types.NodeID]PeerScore, len(pool.peers))
+	for id, peer := range pool.peers {
+		scores[id] = PeerScore{
+			Latency:    peer.latency,
+			Delivered:  peer.delivered,
+			Failures:   peer.failures,
+			NumPending: peer.numPending,
+		}
+	}
+	return scores
+}
+
 // MaxPeerHeight returns the highest reported height.
 func (pool *BlockPool) MaxPeerHeight() int64 {
 	pool.mtx.RLock()
@@ -354,6 +408,18 @@ func (pool *BlockPool) SetPeerRange(peerID types.NodeID, base int64, height int6
 	}
 }
 
+// copyPeerRanges sets the ranges of the peers of another pool.
+func (pool *BlockPool) copyPeerRanges(other *BlockPool) {
+	other.mtx.RLock()
+	defer other.mtx.RUnlock()
+
+	for id, peer := range other.peers {
+		if !peer.didTimeout {
+			pool.SetPeerRange(id, peer.base, peer.height)
+		}
+	}
+}
+
 // RemovePeer removes the peer with peerID from the pool. If there's no peer
 // with peerID, function is a no-op.
 func (pool *BlockPool) RemovePeer(peerID types.NodeID) {
@@ -386,6 +452,35 @@ func (pool *BlockPool) removePeer(peerID types.NodeID) {
 	}
 }
 
+// evictSlowPeers marks the peers much slower than the fastest one as timed
+// out, and reports them.
+func (pool *BlockPool) evictSlowPeers() {
+	var fastest time.Duration
+	for _, peer := range pool.peers {
+		if peer.delivered >= minLatencySamples && (fastest == 0 || peer.latency < fastest) {
+			fastest = peer.latency
+		}
+	}
+	if fastest == 0 {
+		return
+	}
+
+	for _, peer := range pool.peers {
+		if peer.didTimeout || peer.delivered < minLatencySamples {
+			continue
+		}
+		if peer.latency > minSlowPeerLatency && peer.latency > slowPeerLatencyFactor*fastest {
+			err := errors.New("peer is too slow")
+			pool.sendError(err, peer.id)
+			pool.logger.Error("SendTimeout", "peer", peer.id,
+				"reason", err,
+				"latency", peer.latency,
+				"fastest_latency", fastest)
+			peer.didTimeout = true
+		}
+	}
+}
+
 // If no peers are left, maxPeerHeight is set to 0.
 func (pool *BlockPool) updateMaxPeerHeight() {
 	var max int64
@@ -397,12 +492,13 @@ func (pool *BlockPool) updateMaxPeerHeight() {
 	pool.maxPeerHeight = max
 }
 
-// Pick an available peer with the given height available.
-// If no peers are available, returns nil.
+// Pick the available peer with the given height available expected to deliver
+// the block first. If no peers are available, returns nil.
 func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	var best *bpPeer
 	for _, peer := range pool.peers {
 		if peer.didTimeout {
 			pool.removePeer(peer.id)
@@ -414,10 +510,14 @@ func (pool *BlockPool) pickIncrAvailablePeer(height int64) *bpPeer {
 		if height < peer.base || height > peer.height {
 			continue
 		}
-		peer.incrPending()
-		return peer
+		if best == nil || peer.cost() < best.cost() {
+			best = peer
+		}
 	}
-	return nil
+	if best != nil {
+		best.incrPending()
+	}
+	return best
 }
 
 func (pool *BlockPool) makeNextRequester(ctx context.Context) {
@@ -494,6 +594,17 @@ func (pool *BlockPool) getLastSyncRate() float64 {
 
 //-------------------------------------
 
+// PeerScore is the score of a block sync peer.
+type PeerScore struct {
+	// Latency is the average time the peer took to deliver a block.
+	Latency time.Duration
+	// Delivered is the number of blocks the peer delivered, and Failures the
+	// number of blocks requested from it it did not have.
+	Delivered  int64
+	Failures   int64
+	NumPending int32
+}
+
 type bpPeer struct {
 	didTimeout  bool
 	numPending  int32
@@ -503,6 +614,10 @@ type bpPeer struct {
 	id          types.NodeID
 	recvMonitor *flowrate.Monitor
 
+	latency   time.Duration // average delivery latency
+	delivered int64
+	failures  int64
+
 	timeout *time.Timer
 	startAt time.Time
 
@@ -541,6 +656,35 @@ func (peer *bpPeer) decrPending(recvSize int) {
 	}
 }
 
+// addLatency records the latency of a block delivered by the peer. A delivery
+// also offsets a block the peer did not have.
+func (peer *bpPeer) addLatency(latency time.Duration) {
+	if peer.delivered == 0 {
+		peer.latency = latency
+	} else {
+		peer.latency = time.Duration((1-latencyWeight)*float64(peer.latency) + latencyWeight*float64(latency))
+	}
+	peer.delivered++
+	if peer.failures > 0 {
+		peer.failures--
+	}
+}
+
+// cost is the expected time for the peer to deliver a new request, the lower
+// the better: its average latency for each of its pending requests and the new
+// one, doubled for each block it did not have.
+func (peer *bpPeer) cost() time.Duration {
+	latency := peer.latency
+	if peer.delivered == 0 {
+		latency = initialLatency
+	}
+	penalty := peer.failures
+	if penalty > maxFailurePenalty {
+		penalty = maxFailurePenalty
+	}
+	return latency * time.Duration(peer.numPending+1) << uint(penalty)
+}
+
 func (peer *bpPeer) onTimeout() {
 	peer.pool.mtx.Lock()
 	defer peer.pool.mtx.Unlock()
@@ -561,10 +705,11 @@ type bpRequester struct {
 	gotBlockCh chan struct{}
 	redoCh     chan types.NodeID // redo may send multitime, add peerId to identify repeat
 
-	mtx       sync.Mutex
-	peerID    types.NodeID
-	block     *types.Block
-	extCommit *types.ExtendedCommit
+	mtx         sync.Mutex
+	peerID      types.NodeID
+	requestedAt time.Time
+	block       *types.Block
+	extCommit   *types.ExtendedCommit
 }
 
 func newBPRequester(logger log.Logger, pool *BlockPool, height int64) *bpRequester {
@@ -621,6 +766,12 @@ func (bpr *bpRequester) getExtendedCommit() *types.ExtendedCommit {
 	return bpr.extCommit
 }
 
+func (bpr *bpRequester) getRequestedAt() time.Time {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+	return bpr.requestedAt
+}
+
 func (bpr *bpRequester) getPeerID() types.NodeID {
 	bpr.mtx.Lock()
 	defer bpr.mtx.Unlock()
@@ -679,6 +830,7 @@ OUTER_LOOP:
 		}
 		bpr.mtx.Lock()
 		bpr.peerID = peer.id
+		bpr.requestedAt = time.Now()
 		bpr.mtx.Unlock()
 
 		// Send request and wait.
@@ -235,3 +235,45 @@ func TestBlockPoolRemovePeer(t *testing.T) {
 
 	assert.EqualValues(t, 0, pool.MaxPeerHeight())
 }
+
+func TestBlockPoolPeerScoring(t *testing.T) {
+	requestsCh := make(chan BlockRequest, 10)
+	errorsCh := make(chan peerError, 10)
+	pool := NewBlockPool(log.NewNopLogger(), 1, requestsCh, errorsCh)
+
+	fast, slow := types.NodeID("fast"), types.NodeID("slow")
+	pool.SetPeerRange(fast, 1, 100)
+	pool.SetPeerRange(slow, 1, 100)
+
+	for i := 0; i < minLatencySamples; i++ {
+		pool.peers[fast].addLatency(100 * time.Millisecond)
+		pool.peers[slow].addLatency(250 * time.Millisecond)
+	}
+	scores := pool.PeerScores()
+	assert.Equal(t, 100*time.Millisecond, scores[fast].Latency)
+	assert.EqualValues(t, minLatencySamples, scores[slow].Delivered)
+
+	// the requests go to the peer expected to deliver them first
+	assert.Equal(t, fast, pool.pickIncrAvailablePeer(1).id)
+	assert.Equal(t, fast, pool.pickIncrAvailablePeer(2).id)
+	assert.Equal(t, slow, pool.pickIncrAvailablePeer(3).id, "the fast peer has two pending requests")
+
+	// a peer not having a requested block is penalized
+	requester := newBPRequester(pool.logger, pool, 4)
+	requester.peerID = fast
+	pool.requesters[4] = requester
+	pool.peers[fast].incrPending()
+	pool.NoBlock(fast, 4)
+	assert.EqualValues(t, 1, pool.PeerScores()[fast].Failures)
+	assert.EqualValues(t, 2, pool.PeerScores()[fast].NumPending)
+	assert.Equal(t, types.NodeID("fast"), <-requester.redoCh)
+	assert.Equal(t, slow, pool.pickIncrAvailablePeer(5).id)
+
+	// a peer much slower than the fastest is evicted
+	for i := 0; i < 20; i++ {
+		pool.peers[slow].addLatency(10 * time.Second)
+	}
+	pool.removeTimedoutPeers()
+	assert.NotContains(t, pool.PeerScores(), slow)
+	assert.Contains(t, pool.PeerScores(), fast)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -77,15 +78,19 @@ type Reactor struct {
 
 	blockExec   *sm.BlockExecutor
 	store       sm.BlockStore
-	pool        *BlockPool
 	consReactor consensusReactor
 	blockSync   *atomicBool
 
+	// the pool is replaced by a new one each time block sync is entered
+	mtx  sync.RWMutex
+	pool *BlockPool
+
 	chCreator  p2p.ChannelCreator
 	peerEvents p2p.PeerEventSubscriber
 
-	requestsCh <-chan BlockRequest
-	errorsCh   <-chan peerError
+	requestsCh    chan BlockRequest
+	errorsCh      chan peerError
+	startRequests sync.Once
 
 	metrics  *consensus.Metrics
 	eventBus *eventbus.EventBus
@@ -159,10 +164,10 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 	r.errorsCh = errorsCh
 
 	if r.blockSync.IsSet() {
-		if err := r.pool.Start(ctx); err != nil {
+		if err := r.getPool().Start(ctx); err != nil {
 			return err
 		}
-		go r.requestRoutine(ctx, blockSyncCh)
+		r.startRequests.Do(func() { go r.requestRoutine(ctx, blockSyncCh) })
 
 		go r.poolRoutine(ctx, false, blockSyncCh)
 	}
@@ -177,7 +182,7 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 // blocking until they all exit.
 func (r *Reactor) OnStop() {
 	if r.blockSync.IsSet() {
-		r.pool.Stop()
+		r.getPool().Stop()
 	}
 }
 
@@ -243,7 +248,7 @@ func (r *Reactor) handleMessage(ctx context.Context, envelope *p2p.Envelope, blo
 		case *bcproto.BlockRequest:
 			return r.respondToPeer(ctx, msg, envelope.From, blockSyncCh)
 		case *bcproto.BlockResponse:
-			block, err := types.BlockFromProto(msg.Block)
+			block, err := types.BlockFromProtoParallel(msg.Block, r.blockExec.VerificationWorkers())
 			if err != nil {
 				r.logger.Error("failed to convert block from proto",
 					"peer", envelope.From,
@@ -262,7 +267,7 @@ func (r *Reactor) handleMessage(ctx context.Context, envelope *p2p.Envelope, blo
 				}
 			}
 
-			if err := r.pool.AddBlock(envelope.From, block, extCommit, block.Size()); err != nil {
+			if err := r.getPool().AddBlock(envelope.From, block, extCommit, block.Size()); err != nil {
 				r.logger.Error("failed to add block", "err", err)
 			}
 
@@ -275,12 +280,13 @@ func (r *Reactor) handleMessage(ctx context.Context, envelope *p2p.Envelope, blo
 				},
 			})
 		case *bcproto.StatusResponse:
-			r.pool.SetPeerRange(envelope.From, msg.Base, msg.Height)
+			r.getPool().SetPeerRange(envelope.From, msg.Base, msg.Height)
 
 		case *bcproto.NoBlockResponse:
 			r.logger.Debug("peer does not have the requested block",
 				"peer", envelope.From,
 				"height", msg.Height)
+			r.getPool().NoBlock(envelope.From, msg.Height)
 
 		default:
 			return fmt.Errorf("received unknown message: %T", msg)
@@ -337,7 +343,7 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 				Height: r.store.Height(),
 			},
 		}); err != nil {
-			r.pool.RemovePeer(peerUpdate.NodeID)
+			r.getPool().RemovePeer(peerUpdate.NodeID)
 			if err := blockSyncCh.SendError(ctx, p2p.PeerError{
 				NodeID: peerUpdate.NodeID,
 				Err:    err,
@@ -347,7 +353,7 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 		}
 
 	case p2p.PeerStatusDown:
-		r.pool.RemovePeer(peerUpdate.NodeID)
+		r.getPool().RemovePeer(peerUpdate.NodeID)
 	}
 }
 
@@ -366,16 +372,25 @@ func (r *Reactor) processPeerUpdates(ctx context.Context, peerUpdates *p2p.PeerU
 }
 
 // SwitchToBlockSync is called by the state sync reactor when switching to fast
-// sync.
+// sync, and by the consensus reactor when the node falls behind its peers. The
+// blocks after the state are synced with a new pool, which knows the ranges of
+// the peers of the previous one.
 func (r *Reactor) SwitchToBlockSync(ctx context.Context, state sm.State) error {
-	r.blockSync.Set()
-	r.initialState = state
-	r.pool.height = state.LastBlockHeight + 1
+	if r.blockSync.IsSet() {
+		return errors.New("already block syncing")
+	}
 
-	if err := r.pool.Start(ctx); err != nil {
+	pool := NewBlockPool(r.logger, state.LastBlockHeight+1, r.requestsCh, r.errorsCh)
+	pool.copyPeerRanges(r.getPool())
+	if err := pool.Start(ctx); err != nil {
 		return err
 	}
+	r.mtx.Lock()
+	r.pool = pool
+	r.mtx.Unlock()
 
+	r.blockSync.Set()
+	r.initialState = state
 	r.syncStartTime = time.Now()
 
 	bsCh, err := r.chCreator(ctx, GetChannelDescriptor())
@@ -383,7 +398,7 @@ func (r *Reactor) SwitchToBlockSync(ctx context.Context, state sm.State) error {
 		return err
 	}
 
-	go r.requestRoutine(ctx, bsCh)
+	r.startRequests.Do(func() { go r.requestRoutine(ctx, bsCh) })
 	go r.poolRoutine(ctx, true, bsCh)
 
 	if err := r.PublishStatus(types.EventDataBlockSyncStatus{
@@ -465,8 +480,8 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 			return
 		case <-switchToConsensusTicker.C:
 			var (
-				height, numPending, lenRequesters = r.pool.GetStatus()
-				lastAdvance                       = r.pool.LastAdvance()
+				height, numPending, lenRequesters = r.getPool().GetStatus()
+				lastAdvance                       = r.getPool().LastAdvance()
 			)
 
 			r.logger.Debug("consensus ticker",
@@ -499,12 +514,12 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 					"height", height,
 					"last_block_height", state.LastBlockHeight,
 					"initial_height", state.InitialHeight,
-					"max_peer_height", r.pool.MaxPeerHeight(),
+					"max_peer_height", r.getPool().MaxPeerHeight(),
 					"timeout_in", syncTimeout-time.Since(lastAdvance),
 				)
 				continue
 
-			case r.pool.IsCaughtUp():
+			case r.getPool().IsCaughtUp():
 				r.logger.Info("switching to consensus reactor", "height", height)
 
 			case time.Since(lastAdvance) > syncTimeout:
@@ -514,13 +529,13 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 				r.logger.Info(
 					"not caught up yet",
 					"height", height,
-					"max_peer_height", r.pool.MaxPeerHeight(),
+					"max_peer_height", r.getPool().MaxPeerHeight(),
 					"timeout_in", syncTimeout-time.Since(lastAdvance),
 				)
 				continue
 			}
 
-			r.pool.Stop()
+			r.getPool().Stop()
 
 			r.blockSync.UnSet()
 
@@ -546,7 +561,7 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 			// TODO: Uncouple from request routine.
 
 			// see if there are any blocks to sync
-			first, second, extCommit := r.pool.PeekTwoBlocks()
+			first, second, extCommit := r.getPool().PeekTwoBlocks()
 			if first != nil && extCommit == nil &&
 				state.ConsensusParams.ABCI.VoteExtensionsEnabled(first.Height) {
 				// See https://github.com/tendermint/tendermint/pull/8433#discussion_r866790631
@@ -560,7 +575,7 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 			// try again quickly next loop
 			didProcessCh <- struct{}{}
 
-			firstParts, err := first.MakePartSet(types.BlockPartSizeBytes)
+			firstParts, err := first.MakePartSetParallel(types.BlockPartSizeBytes, r.blockExec.VerificationWorkers())
 			if err != nil {
 				r.logger.Error("failed to make ",
 					"height", first.Height,
@@ -601,7 +616,7 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 
 				// NOTE: We've already removed the peer's request, but we still need
 				// to clean up the rest.
-				peerID := r.pool.RedoRequest(first.Height)
+				peerID := r.getPool().RedoRequest(first.Height)
 				if serr := blockSyncCh.SendError(ctx, p2p.PeerError{
 					NodeID: peerID,
 					Err:    err,
@@ -609,7 +624,7 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 					return
 				}
 
-				peerID2 := r.pool.RedoRequest(second.Height)
+				peerID2 := r.getPool().RedoRequest(second.Height)
 				if peerID2 != peerID {
 					if serr := blockSyncCh.SendError(ctx, p2p.PeerError{
 						NodeID: peerID2,
@@ -621,7 +636,7 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 				return
 			}
 
-			r.pool.PopRequest()
+			r.getPool().PopRequest()
 
 			// TODO: batch saves so we do not persist to disk every block
 			if state.ConsensusParams.ABCI.VoteExtensionsEnabled(first.Height) {
@@ -649,9 +664,10 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 				lastRate = 0.9*lastRate + 0.1*(100/time.Since(lastHundred).Seconds())
 				r.logger.Info(
 					"block sync rate",
-					"height", r.pool.height,
-					"max_peer_height", r.pool.MaxPeerHeight(),
+					"height", r.getPool().height,
+					"max_peer_height", r.getPool().MaxPeerHeight(),
 					"blocks/s", lastRate,
+					"remaining_time", r.GetRemainingSyncTime(),
 				)
 
 				lastHundred = time.Now()
@@ -660,8 +676,24 @@ func (r *Reactor) poolRoutine(ctx context.Context, stateSynced bool, blockSyncCh
 	}
 }
 
+// getPool returns the pool of the current or last block sync.
+func (r *Reactor) getPool() *BlockPool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.pool
+}
+
 func (r *Reactor) GetMaxPeerBlockHeight() int64 {
-	return r.pool.MaxPeerHeight()
+	return r.getPool().MaxPeerHeight()
+}
+
+// GetPeerScores returns the scores of the peers blocks are requested from,
+// by ID, while block syncing.
+func (r *Reactor) GetPeerScores() map[types.NodeID]PeerScore {
+	if !r.blockSync.IsSet() {
+		return nil
+	}
+	return r.getPool().PeerScores()
 }
 
 func (r *Reactor) GetTotalSyncedTime() time.Duration {
@@ -676,9 +708,9 @@ func (r *Reactor) GetRemainingSyncTime() time.Duration {
 		return time.Duration(0)
 	}
 
-	targetSyncs := r.pool.targetSyncBlocks()
-	currentSyncs := r.store.Height() - r.pool.startHeight + 1
-	lastSyncRate := r.pool.getLastSyncRate()
+	targetSyncs := r.getPool().targetSyncBlocks()
+	currentSyncs := r.store.Height() - r.getPool().startHeight + 1
+	lastSyncRate := r.getPool().getLastSyncRate()
 	if currentSyncs < 0 || lastSyncRate < 0.001 {
 		return time.Duration(0)
 	}
@@ -0,0 +1,157 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	sm "github.com/tendermint/tendermint/internal/state"
+)
+
+// blockSyncCheckInterval is the interval at which the reactor compares its
+// height to the heights of its peers.
+var blockSyncCheckInterval = time.Second // not const so we can override with tests
+
+const (
+	// blockSyncBehindChecks is the number of consecutive checks the node must
+	// be behind its peers by more than the block sync lag to switch back to
+	// block sync, so that a peer briefly ahead does not trigger it.
+	blockSyncBehindChecks = 5
+)
+
+// SetBlockSyncReactor sets the block sync reactor the reactor switches back
+// to when the node falls more than the block-sync-lag of the config behind its
+// peers while running consensus. Without it, the node only catches up with
+// consensus.
+func (r *Reactor) SetBlockSyncReactor(bs BlockSyncReactor) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.blockSync = bs
+}
+
+// blockSyncRoutine switches the node back to block sync when it has been
+// behind most of its peers by more than the block sync lag for
+// blockSyncBehindChecks consecutive checks, at least block-sync-cooldown after
+// switching to consensus. Block sync switches back to consensus once the node
+// is caught up. A validator never switches, so that it keeps voting.
+func (r *Reactor) blockSyncRoutine(ctx context.Context) {
+	lag := r.state.config.BlockSyncLag
+	if lag <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(blockSyncCheckInterval)
+	defer ticker.Stop()
+
+	behind := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		r.mtx.RLock()
+		bs, waitSync, since := r.blockSync, r.waitSync, r.consensusSince
+		r.mtx.RUnlock()
+		if bs == nil || waitSync || time.Since(since) < r.state.config.BlockSyncCooldown || r.state.isValidator() {
+			behind = 0
+			continue
+		}
+
+		height := r.getRoundState().Height
+		peerHeight := r.majorityPeerHeight()
+		if peerHeight-height <= lag {
+			behind = 0
+			continue
+		}
+		if behind++; behind < blockSyncBehindChecks {
+			continue
+		}
+		behind = 0
+
+		r.logger.Info("fell behind the peers, switching to block sync",
+			"height", height, "peer_height", peerHeight, "lag", lag)
+		r.switchToBlockSync(ctx, bs)
+	}
+}
+
+// majorityPeerHeight returns the highest consensus height reached by a
+// majority of the peers, so that a single peer lying about its height can't
+// switch the node to block sync, or 0 without peers.
+func (r *Reactor) majorityPeerHeight() int64 {
+	r.mtx.RLock()
+	heights := make([]int64, 0, len(r.peers))
+	for _, ps := range r.peers {
+		heights = append(heights, ps.GetHeight())
+	}
+	r.mtx.RUnlock()
+
+	if len(heights) == 0 {
+		return 0
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights[len(heights)/2]
+}
+
+// switchToBlockSync pauses the consensus state machine and switches to block
+// sync, which switches back to consensus with SwitchToConsensus.
+func (r *Reactor) switchToBlockSync(ctx context.Context, bs BlockSyncReactor) {
+	state := r.state.pause()
+
+	r.mtx.Lock()
+	r.waitSync = true
+	r.readySignal = make(chan struct{})
+	r.mtx.Unlock()
+	r.Metrics.BlockSyncing.Set(1)
+
+	if err := bs.SwitchToBlockSync(ctx, state); err != nil {
+		r.logger.Error("failed to switch to block sync, resuming consensus", "err", err)
+		r.SwitchToConsensus(ctx, state, true)
+	}
+}
+
+// isValidator reports whether the node is a validator at the current height.
+func (cs *State) isValidator() bool {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return cs.privValidatorPubKey != nil && cs.Validators.HasAddress(cs.privValidatorPubKey.Address())
+}
+
+// pause pauses the state machine while the node block syncs, ignoring the
+// messages, timeouts and txs, and returns the state of the last block
+// committed.
+func (cs *State) pause() sm.State {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
+	cs.paused = true
+	return cs.state.Copy()
+}
+
+// resume resumes the state machine paused with pause, at the height after the
+// state of the last block synced. It returns an error if the state is past the
+// planned halt.
+func (cs *State) resume(state sm.State) error {
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+
+	cs.paused = false
+	if state.LastBlockHeight <= cs.state.LastBlockHeight {
+		// no block was synced: the round continues with the messages of
+		// the peers
+		return nil
+	}
+	if plan := cs.GetHaltPlan(); plan.Reached(state.LastBlockHeight, state.LastBlockTime) {
+		return fmt.Errorf("block sync committed the block at height %d past the planned halt at height %d and time %v",
+			state.LastBlockHeight, plan.Height, plan.Time)
+	}
+
+	// the blocks of the heights were committed by block sync instead
+	cs.CommitRound = -1
+	cs.reconstructLastCommit(state)
+	cs.updateToState(state)
+	cs.scheduleRound0(&cs.RoundState)
+	return nil
+}
@@ -0,0 +1,128 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/consensus/mocks"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestStatePauseResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := configSetup(t)
+	cs1, vss := makeState(ctx, t, makeStateArgs{config: cfg})
+	height, round := cs1.Height, cs1.Round
+
+	proposalCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryCompleteProposal)
+	newBlockCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryNewBlock)
+	startTestRound(ctx, cs1, height, round)
+	ensureNewProposal(t, proposalCh, height, round)
+
+	rs := cs1.GetRoundState()
+	blockID := types.BlockID{Hash: rs.ProposalBlock.Hash(), PartSetHeader: rs.ProposalBlockParts.Header()}
+
+	// the votes are ignored while paused
+	state := cs1.pause()
+	assert.Equal(t, height-1, state.LastBlockHeight)
+	signAddVotes(ctx, t, cs1, tmproto.PrecommitType, cfg.ChainID(), blockID, vss[1:]...)
+	ensureNoNewEventOnChannel(t, newBlockCh)
+
+	// without blocks synced, the round continues
+	require.NoError(t, cs1.resume(state))
+	signAddVotes(ctx, t, cs1, tmproto.PrecommitType, cfg.ChainID(), blockID, vss[1:]...)
+	ensureNewBlock(t, newBlockCh, height)
+
+	// the state past the planned halt is rejected
+	state = cs1.pause()
+	require.NoError(t, cs1.SetHaltPlan(HaltPlan{Height: height + 2}))
+	state.LastBlockHeight = height + 2
+	require.Error(t, cs1.resume(state))
+}
+
+func TestReactorBlockSyncSwitch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := configSetup(t)
+	cs, _ := makeState(ctx, t, makeStateArgs{config: cfg})
+	cs.config.BlockSyncLag = 10
+	cs.config.BlockSyncCooldown = 0
+
+	defer func(interval time.Duration) { blockSyncCheckInterval = interval }(blockSyncCheckInterval)
+	blockSyncCheckInterval = 10 * time.Millisecond
+
+	r := NewReactor(log.NewNopLogger(), cs, nil, nil, cs.eventBus, false, NopMetrics())
+	peers := make([]*PeerState, 3)
+	for i := range peers {
+		id := types.NodeID(fmt.Sprintf("peer%d", i))
+		peers[i] = NewPeerState(log.NewNopLogger(), id)
+		peers[i].ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: cs.Height, Step: 1})
+	}
+
+	switched := make(chan sm.State, 1)
+	bs := &mocks.BlockSyncReactor{}
+	bs.On("SwitchToBlockSync", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		switched <- args.Get(1).(sm.State)
+	})
+	r.SetBlockSyncReactor(bs)
+	go r.blockSyncRoutine(ctx)
+
+	ensureNoSwitch := func(msg string) {
+		t.Helper()
+		select {
+		case <-switched:
+			t.Fatal("switched to block sync " + msg)
+		case <-time.After(20 * blockSyncCheckInterval):
+		}
+		assert.False(t, r.WaitSync())
+	}
+
+	// a validator keeps running consensus
+	ahead := NewPeerState(log.NewNopLogger(), "ahead")
+	ahead.ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: cs.Height + 11, Step: 1})
+	r.mtx.Lock()
+	r.peers[ahead.peerID] = ahead
+	r.mtx.Unlock()
+	ensureNoSwitch("as a validator")
+	r.mtx.Lock()
+	delete(r.peers, ahead.peerID)
+	r.peers[peers[0].peerID] = peers[0]
+	r.mtx.Unlock()
+	cs.SetPrivValidator(ctx, types.NewMockPV())
+
+	// within the lag, the node keeps running consensus
+	peers[0].ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: cs.Height + 10, Step: 1})
+	ensureNoSwitch("within the lag")
+
+	// a minority of the peers ahead does not switch it
+	peers[0].ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: cs.Height + 11, Step: 1})
+	r.mtx.Lock()
+	r.peers[peers[1].peerID] = peers[1]
+	r.mtx.Unlock()
+	ensureNoSwitch("with a minority of the peers ahead")
+
+	peers[2].ApplyNewRoundStepMessage(&NewRoundStepMessage{Height: cs.Height + 11, Step: 1})
+	r.mtx.Lock()
+	r.peers[peers[2].peerID] = peers[2]
+	r.mtx.Unlock()
+	select {
+	case state := <-switched:
+		assert.Equal(t, cs.Height-1, state.LastBlockHeight)
+	case <-time.After(5 * time.Second):
+		t.Fatal("did not switch to block sync")
+	}
+	assert.True(t, r.WaitSync())
+	cs.mtx.RLock()
+	assert.True(t, cs.paused)
+	cs.mtx.RUnlock()
+}
@@ -469,6 +469,21 @@ func newStateWithConfigAndBlockStore(
 	blockStore *store.BlockStore,
 ) *State {
 	t.Helper()
+	return newStateWithConfigAndStores(ctx, t, logger, thisConfig, state, pv, app, blockStore, sm.NewStore(dbm.NewMemDB()))
+}
+
+func newStateWithConfigAndStores(
+	ctx context.Context,
+	t *testing.T,
+	logger log.Logger,
+	thisConfig *config.Config,
+	state sm.State,
+	pv types.PrivValidator,
+	app abci.Application,
+	blockStore *store.BlockStore,
+	stateStore sm.Store,
+) *State {
+	t.Helper()
 
 	// one for mempool, one for consensus
 	proxyAppConnMem := abciclient.NewLocalClient(logger, app)
@@ -489,8 +504,6 @@ func newStateWithConfigAndBlockStore(
 	evpool := sm.EmptyEvidencePool{}
 
 	// Make State
-	stateDB := dbm.NewMemDB()
-	stateStore := sm.NewStore(stateDB)
 	require.NoError(t, stateStore.Save(state))
 
 	eventBus := eventbus.NewDefault(logger.With("module", "events"))
@@ -0,0 +1,82 @@
+package consensus
+
+import (
+	"fmt"
+	"time"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// CompactBlockChannelVersion is the version of the DataChannel with which
+	// the proposal blocks are sent as compact blocks.
+	CompactBlockChannelVersion uint32 = 2
+
+	// compactBlockTimeout is how long the parts of a block are held back from
+	// a peer which was sent its compact block and did not reply to it.
+	compactBlockTimeout = time.Second
+)
+
+// txProvider is implemented by the mempools which look up their transactions
+// by key, so that compact blocks can be reconstructed from them.
+type txProvider interface {
+	GetTxByKey(types.TxKey) (types.Tx, bool)
+}
+
+// makeCompactBlockMessage returns the compact block of the complete proposal
+// block of the round state, or an error if it exceeds the maximum message
+// size.
+func makeCompactBlockMessage(rs *cstypes.RoundState) (*tmcons.CompactBlock, error) {
+	pb, err := rs.ProposalBlock.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pb.Data.Txs = nil
+
+	txKeys := make([][]byte, len(rs.ProposalBlock.Txs))
+	for i, tx := range rs.ProposalBlock.Txs {
+		key := tx.Key()
+		txKeys[i] = key[:]
+	}
+
+	header := rs.ProposalBlockParts.Header()
+	msg := &tmcons.CompactBlock{
+		Height:             rs.Height,
+		Round:              rs.Round,
+		BlockPartSetHeader: header.ToProto(),
+		Block:              *pb,
+		TxKeys:             txKeys,
+	}
+	if size := (&tmcons.Message{Sum: &tmcons.Message_CompactBlock{CompactBlock: msg}}).Size(); size > maxMsgSize {
+		return nil, fmt.Errorf("compact block of %d bytes exceeds the maximum message size of %d", size, maxMsgSize)
+	}
+	return msg, nil
+}
+
+// reconstructCompactBlock returns the parts of the block of the compact block,
+// restoring its transactions from the mempool. It returns false if any of the
+// transactions is missing, or if the parts don't match the part set header of
+// the compact block.
+func reconstructCompactBlock(msg *CompactBlockMessage, txs txProvider) (*types.PartSet, bool) {
+	pb := *msg.Block
+	pb.Data.Txs = make([][]byte, len(msg.TxKeys))
+	for i, key := range msg.TxKeys {
+		tx, ok := txs.GetTxByKey(key)
+		if !ok {
+			return nil, false
+		}
+		pb.Data.Txs[i] = tx
+	}
+
+	block, err := types.BlockFromProto(&pb)
+	if err != nil {
+		return nil, false
+	}
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil || !parts.HasHeader(msg.BlockPartSetHeader) {
+		return nil, false
+	}
+	return parts, true
+}
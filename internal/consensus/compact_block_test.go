@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	"github.com/tendermint/tendermint/types"
+)
+
+type mapTxProvider map[types.TxKey]types.Tx
+
+func (txs mapTxProvider) GetTxByKey(key types.TxKey) (types.Tx, bool) {
+	tx, ok := txs[key]
+	return tx, ok
+}
+
+func TestCompactBlock(t *testing.T) {
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}
+	block := types.MakeBlock(1, txs, &types.Commit{}, nil)
+	block.Header = *factory.MakeHeader(t, &block.Header)
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+
+	pb, err := makeCompactBlockMessage(&cstypes.RoundState{
+		Height:             1,
+		Round:              2,
+		ProposalBlock:      block,
+		ProposalBlockParts: parts,
+	})
+	require.NoError(t, err)
+	require.Empty(t, pb.Block.Data.Txs)
+	require.Len(t, pb.TxKeys, len(txs))
+
+	msgI, err := MsgFromProto(&tmcons.Message{Sum: &tmcons.Message_CompactBlock{CompactBlock: pb}})
+	require.NoError(t, err)
+	msg := msgI.(*CompactBlockMessage)
+	require.EqualValues(t, 2, msg.Round)
+
+	mempool := mapTxProvider{}
+	for _, tx := range txs {
+		mempool[tx.Key()] = tx
+	}
+	reconstructed, ok := reconstructCompactBlock(msg, mempool)
+	require.True(t, ok)
+	require.Equal(t, parts.Header(), reconstructed.Header())
+	for i := 0; i < int(parts.Total()); i++ {
+		require.Equal(t, parts.GetPart(i).Bytes, reconstructed.GetPart(i).Bytes)
+	}
+
+	// the compact block is not modified by the reconstruction
+	require.Empty(t, msg.Block.Data.Txs)
+
+	// a tx is missing from the mempool
+	delete(mempool, txs[1].Key())
+	_, ok = reconstructCompactBlock(msg, mempool)
+	require.False(t, ok)
+
+	// a tx differs from the one of the block
+	mempool[txs[1].Key()] = types.Tx("b=3")
+	_, ok = reconstructCompactBlock(msg, mempool)
+	require.False(t, ok)
+
+	// the parts don't match the part set header
+	mempool[txs[1].Key()] = txs[1]
+	msg.BlockPartSetHeader.Hash = factory.RandomHash()
+	_, ok = reconstructCompactBlock(msg, mempool)
+	require.False(t, ok)
+}
@@ -0,0 +1,213 @@
+package consensus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	tmtime "github.com/tendermint/tendermint/libs/time"
+)
+
+// HaltCode identifies the kind of consistency violation on which consensus
+// halted.
+type HaltCode string
+
+const (
+	// HaltCodeInvalidBlock is the code of a halt on a block that +2/3 of the
+	// validators prevoted or committed but that is invalid, such as a block
+	// with a wrong app hash.
+	HaltCodeInvalidBlock HaltCode = "invalid_block"
+
+	// HaltCodeConflictingCommit is the code of a halt on a commit that does
+	// not match the proposal block.
+	HaltCodeConflictingCommit HaltCode = "conflicting_commit"
+
+	// HaltCodeWAL is the code of a halt on a failure to write to the WAL.
+	HaltCodeWAL HaltCode = "wal"
+
+	// HaltCodeInternal is the code of a halt on any other failure of the
+	// state machine.
+	HaltCodeInternal HaltCode = "internal"
+
+	// HaltCodeUpgrade is the code of a planned halt at the halt height or
+	// time, e.g. for an upgrade of the binary, rather than on a violation.
+	HaltCodeUpgrade HaltCode = "upgrade"
+)
+
+// HaltRecord is the machine-readable record of a halt of consensus, persisted
+// to the halt file of the consensus config. The height of a planned halt is
+// the height of the last block committed.
+type HaltRecord struct {
+	Code   HaltCode  `json:"code"`
+	Height int64     `json:"height,string"`
+	Round  int32     `json:"round"`
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// Planned reports whether the halt is a planned one, at the halt height or
+// time.
+func (rec HaltRecord) Planned() bool { return rec.Code == HaltCodeUpgrade }
+
+// LoadHaltRecord loads the halt record of the given file, or returns nil if
+// consensus never halted.
+func LoadHaltRecord(file string) (*HaltRecord, error) {
+	bz, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var rec HaltRecord
+	if err := json.Unmarshal(bz, &rec); err != nil {
+		return nil, fmt.Errorf("decoding halt record: %w", err)
+	}
+	return &rec, nil
+}
+
+func saveHaltRecord(file string, rec *HaltRecord) error {
+	bz, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(file, bz, 0644)
+}
+
+// haltError is the value the state machine panics with on a consistency
+// violation, recovered by receiveRoutine to halt with its code.
+type haltError struct {
+	code   HaltCode
+	height int64 // the height of the record, if not the current one
+	err    error
+}
+
+func (e haltError) Error() string { return e.err.Error() }
+func (e haltError) Unwrap() error { return e.err }
+
+// halt panics with a haltError of the given code, to halt consensus.
+func halt(code HaltCode, format string, args ...interface{}) {
+	panic(haltError{code: code, err: fmt.Errorf(format, args...)})
+}
+
+// newHaltRecord returns the record of the halt of consensus at height and
+// round, on the value r recovered from a panic.
+func newHaltRecord(r interface{}, height int64, round int32) *HaltRecord {
+	rec := &HaltRecord{
+		Code:   HaltCodeInternal,
+		Height: height,
+		Round:  round,
+		Reason: fmt.Sprint(r),
+		Time:   tmtime.Now(),
+	}
+	if err, ok := r.(error); ok {
+		var he haltError
+		if errors.As(err, &he) {
+			rec.Code = he.code
+			if he.height > 0 {
+				rec.Height = he.height
+			}
+		}
+	}
+	return rec
+}
+
+// GetHaltRecord returns the record of the halt of consensus, or nil if
+// consensus has not halted.
+func (cs *State) GetHaltRecord() *HaltRecord {
+	cs.haltMtx.Lock()
+	defer cs.haltMtx.Unlock()
+	if cs.haltRecord == nil {
+		return nil
+	}
+	rec := *cs.haltRecord
+	return &rec
+}
+
+// recordHalt records the halt of consensus, and persists its record to the
+// halt file.
+func (cs *State) recordHalt(rec *HaltRecord) {
+	cs.haltMtx.Lock()
+	cs.haltRecord = rec
+	cs.haltMtx.Unlock()
+
+	if err := saveHaltRecord(cs.config.HaltFile(), rec); err != nil {
+		cs.logger.Error("failed to save halt record", "file", cs.config.HaltFile(), "err", err)
+	}
+}
+
+// HaltPlan is the plan of a halt of consensus after committing a block, such
+// as for a coordinated upgrade of the binary of the nodes of a chain.
+type HaltPlan struct {
+	// Height, if positive, halts consensus once the block at the height is
+	// committed.
+	Height int64
+	// Time, if not zero, halts consensus once a block with at least the time
+	// is committed.
+	Time time.Time
+}
+
+// NewHaltPlan returns the halt plan of the halt-height and halt-time of the
+// consensus config.
+func NewHaltPlan(cfg *config.ConsensusConfig) HaltPlan {
+	plan := HaltPlan{Height: cfg.HaltHeight}
+	if cfg.HaltTime > 0 {
+		plan.Time = time.Unix(cfg.HaltTime, 0).UTC()
+	}
+	return plan
+}
+
+// IsZero reports whether the plan halts at no height nor time.
+func (p HaltPlan) IsZero() bool { return p.Height <= 0 && p.Time.IsZero() }
+
+// Reached reports whether the block at height with time t is committed at or
+// after the planned halt.
+func (p HaltPlan) Reached(height int64, t time.Time) bool {
+	return (p.Height > 0 && height >= p.Height) || (!p.Time.IsZero() && !t.Before(p.Time))
+}
+
+// GetHaltPlan returns the plan of the halt of consensus.
+func (cs *State) GetHaltPlan() HaltPlan {
+	cs.haltMtx.Lock()
+	defer cs.haltMtx.Unlock()
+	return cs.haltPlan
+}
+
+// SetHaltPlan replaces the plan of the halt of consensus, which is not
+// persisted: after a restart, the plan is the one of the config again. A zero
+// plan cancels the halt. It returns an error if the last block committed is
+// already past the plan.
+func (cs *State) SetHaltPlan(plan HaltPlan) error {
+	state := cs.GetState()
+	if state.LastBlockHeight > 0 && plan.Reached(state.LastBlockHeight, state.LastBlockTime) {
+		return fmt.Errorf("the block at height %d with time %v is already committed past the halt plan",
+			state.LastBlockHeight, state.LastBlockTime)
+	}
+	cs.haltMtx.Lock()
+	cs.haltPlan = plan
+	cs.haltMtx.Unlock()
+	cs.logger.Info("set halt plan", "height", plan.Height, "time", plan.Time)
+	return nil
+}
+
+// haltIfPlanned halts consensus if the plan is reached by the block at height
+// with time t, which is committed. The WAL is flushed first, so that the node
+// restarts at the next height.
+func (cs *State) haltIfPlanned(height int64, t time.Time) {
+	plan := cs.GetHaltPlan()
+	if !plan.Reached(height, t) {
+		return
+	}
+	if err := cs.wal.FlushAndSync(); err != nil {
+		cs.logger.Error("failed to flush the WAL before the planned halt", "err", err)
+	}
+	panic(haltError{
+		code:   HaltCodeUpgrade,
+		height: height,
+		err: fmt.Errorf("reached the planned halt at height %d and time %v with the block at height %d",
+			plan.Height, plan.Time, height),
+	})
+}
@@ -0,0 +1,168 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestNewHaltRecord(t *testing.T) {
+	for _, tc := range []struct {
+		r    interface{}
+		code HaltCode
+	}{
+		{haltError{code: HaltCodeWAL, err: errors.New("disk full")}, HaltCodeWAL},
+		{fmt.Errorf("wrapped: %w", haltError{code: HaltCodeInvalidBlock, err: errors.New("wrong app hash")}), HaltCodeInvalidBlock},
+		{errors.New("other"), HaltCodeInternal},
+		{"unexpected vote type", HaltCodeInternal},
+	} {
+		rec := newHaltRecord(tc.r, 5, 1)
+		assert.Equal(t, tc.code, rec.Code)
+		assert.Equal(t, int64(5), rec.Height)
+		assert.Equal(t, int32(1), rec.Round)
+		assert.Equal(t, fmt.Sprint(tc.r), rec.Reason)
+	}
+
+	rec := newHaltRecord(haltError{code: HaltCodeUpgrade, height: 4, err: errors.New("planned")}, 5, 0)
+	assert.True(t, rec.Planned())
+	assert.Equal(t, int64(4), rec.Height)
+}
+
+func TestHaltPlanReached(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, HaltPlan{}.IsZero())
+	assert.False(t, HaltPlan{}.Reached(100, now))
+
+	plan := HaltPlan{Height: 10}
+	assert.False(t, plan.Reached(9, now))
+	assert.True(t, plan.Reached(10, now))
+	assert.True(t, plan.Reached(11, now))
+
+	plan = HaltPlan{Time: now}
+	assert.False(t, plan.Reached(100, now.Add(-time.Second)))
+	assert.True(t, plan.Reached(1, now))
+
+	plan = NewHaltPlan(&config.ConsensusConfig{HaltHeight: 10, HaltTime: now.Unix()})
+	assert.Equal(t, HaltPlan{Height: 10, Time: now}, plan)
+}
+
+func TestHaltRecordSaveLoad(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "halt.json")
+
+	rec, err := LoadHaltRecord(file)
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+
+	saved := &HaltRecord{
+		Code:   HaltCodeConflictingCommit,
+		Height: 10,
+		Round:  2,
+		Reason: "proposal block does not hash to commit hash",
+		Time:   time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, saveHaltRecord(file, saved))
+	rec, err = LoadHaltRecord(file)
+	require.NoError(t, err)
+	assert.Equal(t, saved, rec)
+}
+
+func TestStateHalt(t *testing.T) {
+	haltOnPrevote := func(cs *State) {
+		cs.doPrevote = func(ctx context.Context, height int64, round int32) {
+			halt(HaltCodeInvalidBlock, "+2/3 committed an invalid block at height %d", height)
+		}
+	}
+
+	t.Run("KeepRPC", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cs1, _ := makeState(ctx, t, makeStateArgs{config: configSetup(t)})
+		cs1.config.KeepRPCOnHalt = true
+		haltOnPrevote(cs1)
+		cs1.onHalt = func(HaltRecord) { t.Error("halt reported with keep-rpc-on-halt") }
+		height, round := cs1.Height, cs1.Round
+		assert.Nil(t, cs1.GetHaltRecord())
+
+		startTestRound(ctx, cs1, height, round)
+		require.Eventually(t, func() bool { return cs1.GetHaltRecord() != nil }, 10*time.Second, 10*time.Millisecond)
+
+		rec := cs1.GetHaltRecord()
+		assert.Equal(t, HaltCodeInvalidBlock, rec.Code)
+		assert.Equal(t, height, rec.Height)
+		assert.Equal(t, round, rec.Round)
+		assert.Contains(t, rec.Reason, "invalid block")
+
+		saved, err := LoadHaltRecord(cs1.config.HaltFile())
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, rec.Code, saved.Code)
+		assert.Equal(t, rec.Height, saved.Height)
+	})
+
+	t.Run("OnHalt", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cs1, _ := makeState(ctx, t, makeStateArgs{config: configSetup(t)})
+		haltOnPrevote(cs1)
+		halted := make(chan HaltRecord, 1)
+		cs1.onHalt = func(rec HaltRecord) { halted <- rec }
+
+		startTestRound(ctx, cs1, cs1.Height, cs1.Round)
+		select {
+		case rec := <-halted:
+			assert.Equal(t, HaltCodeInvalidBlock, rec.Code)
+			assert.Equal(t, &rec, cs1.GetHaltRecord())
+		case <-time.After(10 * time.Second):
+			t.Fatal("halt not reported")
+		}
+	})
+}
+
+func TestStatePlannedHalt(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cfg := configSetup(t)
+	cs1, vss := makeState(ctx, t, makeStateArgs{config: cfg})
+	cs1.config.KeepRPCOnHalt = true
+	height, round := cs1.Height, cs1.Round
+	halted := make(chan HaltRecord, 1)
+	cs1.onHalt = func(rec HaltRecord) { halted <- rec }
+
+	require.NoError(t, cs1.SetHaltPlan(HaltPlan{Height: height}))
+	assert.Equal(t, HaltPlan{Height: height}, cs1.GetHaltPlan())
+
+	proposalCh := subscribe(ctx, t, cs1.eventBus, types.EventQueryCompleteProposal)
+	startTestRound(ctx, cs1, height, round)
+	ensureNewProposal(t, proposalCh, height, round)
+
+	rs := cs1.GetRoundState()
+	signAddVotes(ctx, t, cs1, tmproto.PrecommitType, cfg.ChainID(), types.BlockID{
+		Hash:          rs.ProposalBlock.Hash(),
+		PartSetHeader: rs.ProposalBlockParts.Header(),
+	}, vss[1:]...)
+
+	// the planned halt is reported even with keep-rpc-on-halt
+	select {
+	case rec := <-halted:
+		assert.Equal(t, HaltCodeUpgrade, rec.Code)
+		assert.True(t, rec.Planned())
+		assert.Equal(t, height, rec.Height)
+	case <-time.After(10 * time.Second):
+		t.Fatal("planned halt not reported")
+	}
+	assert.Equal(t, height, cs1.GetState().LastBlockHeight)
+
+	// the plan can't be set before the committed block
+	require.Error(t, cs1.SetHaltPlan(HaltPlan{Height: height}))
+	require.NoError(t, cs1.SetHaltPlan(HaltPlan{Height: height + 1}))
+}
@@ -0,0 +1,188 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/proxy"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/store/archive"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+// RunImportBlocks imports the blocks of the archive in the given directory
+// into the node's block store, and replays them through the application. The
+// blocks after the last block of the node are verified against its state and
+// applied in order, as block sync would, so the node can later start from the
+// last imported block. It returns the height of the last block of the node.
+func RunImportBlocks(ctx context.Context, logger log.Logger, cfg *config.Config, dir string) (int64, error) {
+	r, err := archive.Open(dir)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	blockStoreDB, err := config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+	if err != nil {
+		return 0, err
+	}
+	defer blockStoreDB.Close()
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	stateDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: cfg})
+	if err != nil {
+		return 0, err
+	}
+	defer stateDB.Close()
+	stateStore := sm.NewStore(stateDB)
+
+	gdoc, err := sm.MakeGenesisDocFromFile(cfg.GenesisFile())
+	if err != nil {
+		return 0, err
+	}
+	state, err := stateStore.Load()
+	if err != nil {
+		return 0, err
+	}
+	if state.IsEmpty() {
+		if state, err = sm.MakeGenesisState(gdoc); err != nil {
+			return 0, err
+		}
+		if err := stateStore.Save(state); err != nil {
+			return 0, err
+		}
+	}
+
+	// The application connection and event bus stop with the context.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	grpcOptions, err := proxy.GRPCOptions(cfg.BaseConfig)
+	if err != nil {
+		return 0, err
+	}
+	client, _, err := proxy.ClientFactory(logger, cfg.ProxyApp, cfg.ABCI, cfg.DBDir(), grpcOptions...)
+	if err != nil {
+		return 0, err
+	}
+	proxyApp := proxy.New(client, logger, proxy.NopMetrics())
+	if err := proxyApp.Start(ctx); err != nil {
+		return 0, fmt.Errorf("starting proxy app conns: %w", err)
+	}
+
+	eventBus := eventbus.NewDefault(logger)
+	if err := eventBus.Start(ctx); err != nil {
+		return 0, fmt.Errorf("failed to start event bus: %w", err)
+	}
+
+	// Bring the application up to date with the block store first.
+	handshaker := NewHandshaker(logger, stateStore, state, blockStore, eventBus, gdoc)
+	if err := handshaker.Handshake(ctx, proxyApp); err != nil {
+		return 0, err
+	}
+	if state, err = stateStore.Load(); err != nil {
+		return 0, err
+	}
+
+	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp, emptyMempool{}, sm.EmptyEvidencePool{},
+		blockStore, eventBus, sm.NopMetrics())
+	state, err = importBlocks(ctx, logger, r, state, blockExec, blockStore)
+	return state.LastBlockHeight, err
+}
+
+// importBlocks verifies and applies the blocks of the archive after the last
+// block of the given state, and returns the resulting state. It returns the
+// state of the last block applied on errors.
+func importBlocks(
+	ctx context.Context,
+	logger log.Logger,
+	r *archive.Reader,
+	state sm.State,
+	blockExec *sm.BlockExecutor,
+	blockStore *store.BlockStore,
+) (sm.State, error) {
+	manifest := r.Manifest()
+	next := state.LastBlockHeight + 1
+	if state.LastBlockHeight == 0 {
+		next = state.InitialHeight
+	}
+	switch {
+	case manifest.ChainID != state.ChainID:
+		return state, fmt.Errorf("archive of chain %q can't be imported into chain %q", manifest.ChainID, state.ChainID)
+	case blockStore.Height() != state.LastBlockHeight:
+		return state, fmt.Errorf("block store height %d does not match state height %d",
+			blockStore.Height(), state.LastBlockHeight)
+	case manifest.StartHeight > next:
+		return state, fmt.Errorf("archive starts at height %d, after the next height %d of the node",
+			manifest.StartHeight, next)
+	case manifest.EndHeight < next:
+		logger.Info("no blocks to import", "height", state.LastBlockHeight, "archiveEndHeight", manifest.EndHeight)
+		return state, nil
+	}
+
+	logger.Info("importing blocks", "from", next, "to", manifest.EndHeight)
+	start := time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return state, err
+		}
+		entry, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return state, err
+		}
+		block := entry.Block
+		if block.Height < next {
+			continue
+		}
+
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		if err != nil {
+			return state, fmt.Errorf("making part set of block %d: %w", block.Height, err)
+		}
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		if err := state.Validators.VerifyCommitLight(state.ChainID, blockID, block.Height, entry.Commit); err != nil {
+			return state, fmt.Errorf("verifying commit of block %d: %w", block.Height, err)
+		}
+		if err := blockExec.ValidateBlock(ctx, state, block); err != nil {
+			return state, fmt.Errorf("validating block %d: %w", block.Height, err)
+		}
+
+		if state.ConsensusParams.ABCI.VoteExtensionsEnabled(block.Height) {
+			extCommit := entry.ExtendedCommit
+			if extCommit == nil {
+				return state, fmt.Errorf("block %d has no extended commit, but vote extensions are enabled", block.Height)
+			}
+			if err := extCommit.EnsureExtensions(); err != nil {
+				return state, fmt.Errorf("extended commit of block %d: %w", block.Height, err)
+			}
+			if !extCommit.BlockID.Equals(blockID) {
+				return state, fmt.Errorf("extended commit of block %d is for another block", block.Height)
+			}
+			blockStore.SaveBlockWithExtendedCommit(block, parts, extCommit)
+		} else {
+			blockStore.SaveBlock(block, parts, entry.Commit)
+		}
+
+		newState, err := blockExec.ApplyBlock(ctx, state, blockID, block)
+		if err != nil {
+			return state, fmt.Errorf("applying block %d: %w", block.Height, err)
+		}
+		state = newState
+
+		if block.Height%1000 == 0 {
+			logger.Info("imported blocks", "height", block.Height, "elapsed", time.Since(start))
+		}
+	}
+
+	logger.Info("imported blocks", "height", state.LastBlockHeight, "elapsed", time.Since(start))
+	return state, nil
+}
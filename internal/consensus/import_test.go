@@ -0,0 +1,139 @@
+package consensus
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/proxy"
+	sm "github.com/tendermint/tendermint/internal/state"
+	sf "github.com/tendermint/tendermint/internal/state/test/factory"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/store/archive"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+type importTestNode struct {
+	state      sm.State
+	blockStore *store.BlockStore
+	blockExec  *sm.BlockExecutor
+}
+
+func newImportTestNode(ctx context.Context, t *testing.T, genDoc *types.GenesisDoc) *importTestNode {
+	t.Helper()
+	logger := log.NewNopLogger()
+
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, stateStore.Save(state))
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	app := proxy.New(abciclient.NewLocalClient(logger, kvstore.NewApplication()), logger, proxy.NopMetrics())
+	require.NoError(t, app.Start(ctx))
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	return &importTestNode{
+		state:      state,
+		blockStore: blockStore,
+		blockExec: sm.NewBlockExecutor(stateStore, logger, app, emptyMempool{}, sm.EmptyEvidencePool{},
+			blockStore, eventBus, sm.NopMetrics()),
+	}
+}
+
+// writeImportTestArchive commits n blocks on the given node, and writes them
+// to an archive.
+func writeImportTestArchive(
+	ctx context.Context,
+	t *testing.T,
+	node *importTestNode,
+	privVal types.PrivValidator,
+	n int64,
+) string {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "archive")
+	w, err := archive.Create(dir, node.state.ChainID, archive.WithSegmentSize(3))
+	require.NoError(t, err)
+
+	lastExtCommit := &types.ExtendedCommit{}
+	for height := int64(1); height <= n; height++ {
+		block := sf.MakeBlock(node.state, height, lastExtCommit.ToCommit())
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		vote, err := factory.MakeVote(ctx, privVal, block.ChainID, 0, height, 0, 2, blockID, time.Now())
+		require.NoError(t, err)
+		extCommit := &types.ExtendedCommit{
+			Height:             height,
+			BlockID:            blockID,
+			ExtendedSignatures: []types.ExtendedCommitSig{vote.ExtendedCommitSig()},
+		}
+
+		require.NoError(t, w.Write(&archive.Entry{
+			Block:          block,
+			Commit:         extCommit.ToCommit(),
+			ValidatorSet:   node.state.Validators,
+			ExtendedCommit: extCommit,
+		}))
+		node.blockStore.SaveBlockWithExtendedCommit(block, parts, extCommit)
+		node.state, err = node.blockExec.ApplyBlock(ctx, node.state, blockID, block)
+		require.NoError(t, err)
+		lastExtCommit = extCommit
+	}
+	require.NoError(t, w.Close())
+	return dir
+}
+
+func TestImportBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg, err := config.ResetTestRoot(t.TempDir(), "import_blocks_test")
+	require.NoError(t, err)
+	valSet, privVals := factory.ValidatorSet(ctx, t, 1, 30)
+	genDoc := factory.GenesisDoc(cfg, time.Now(), valSet.Validators, factory.ConsensusParams())
+
+	source := newImportTestNode(ctx, t, genDoc)
+	dir := writeImportTestArchive(ctx, t, source, privVals[0], 10)
+
+	node := newImportTestNode(ctx, t, genDoc)
+	r, err := archive.Open(dir)
+	require.NoError(t, err)
+	defer r.Close()
+	state, err := importBlocks(ctx, log.NewNopLogger(), r, node.state, node.blockExec, node.blockStore)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), state.LastBlockHeight)
+	require.Equal(t, source.state.AppHash, state.AppHash)
+	require.Equal(t, int64(10), node.blockStore.Height())
+	require.NotNil(t, node.blockStore.LoadBlockExtendedCommit(10))
+
+	// Importing the archive again changes nothing.
+	r2, err := archive.Open(dir)
+	require.NoError(t, err)
+	defer r2.Close()
+	state2, err := importBlocks(ctx, log.NewNopLogger(), r2, state, node.blockExec, node.blockStore)
+	require.NoError(t, err)
+	require.Equal(t, state.LastBlockHeight, state2.LastBlockHeight)
+
+	// The archive of another chain can't be imported.
+	otherDoc := *genDoc
+	otherDoc.ChainID = "other-chain"
+	other := newImportTestNode(ctx, t, &otherDoc)
+	r3, err := archive.Open(dir)
+	require.NoError(t, err)
+	defer r3.Close()
+	_, err = importBlocks(ctx, log.NewNopLogger(), r3, other.state, other.blockExec, other.blockStore)
+	require.Error(t, err)
+}
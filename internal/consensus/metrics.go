@@ -5,8 +5,10 @@ import (
 	"time"
 
 	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
 
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/internal/libs/metricslabels"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -82,6 +84,10 @@ type Metrics struct {
 	// was relevant to the block the node is trying to gather or not.
 	BlockGossipPartsReceived metrics.Counter `metrics_labels:"matches_current"`
 
+	// Number of compact blocks received, separated by whether the block was
+	// reconstructed from the mempool or not.
+	CompactBlocksReceived metrics.Counter `metrics_labels:"reconstructed"`
+
 	// QuroumPrevoteMessageDelay is the interval in seconds between the proposal
 	// timestamp and the timestamp of the earliest prevote that achieved a quorum
 	// during the prevote step.
@@ -137,6 +143,23 @@ type Metrics struct {
 	LateVotes metrics.Counter `metrics_labels:"vote_type"`
 }
 
+// BlankPeerLabels blanks the peer_id label of the metrics of peers, so that
+// they are the totals of all the peers.
+func (m *Metrics) BlankPeerLabels() {
+	m.BlockParts = metricslabels.BlankCounter(m.BlockParts, "peer_id")
+}
+
+// DiscardValidatorMetrics discards the metrics of each validator, labeled by
+// validator or proposer address, whose number of series grows with the size
+// of the validator set.
+func (m *Metrics) DiscardValidatorMetrics() {
+	m.ValidatorLastSignedHeight = discard.NewGauge()
+	m.ValidatorPower = discard.NewGauge()
+	m.ValidatorMissedBlocks = discard.NewGauge()
+	m.QuorumPrevoteDelay = discard.NewGauge()
+	m.FullPrevoteDelay = discard.NewGauge()
+}
+
 // RecordConsMetrics uses for recording the block related metrics during fast-sync.
 func (m *Metrics) RecordConsMetrics(block *types.Block) {
 	m.NumTxs.Set(float64(len(block.Data.Txs)))
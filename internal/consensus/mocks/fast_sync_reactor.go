@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
 
 	state "github.com/tendermint/tendermint/internal/state"
@@ -57,13 +59,13 @@ func (_m *BlockSyncReactor) GetTotalSyncedTime() time.Duration {
 	return r0
 }
 
-// SwitchToBlockSync provides a mock function with given fields: _a0
-func (_m *BlockSyncReactor) SwitchToBlockSync(_a0 state.State) error {
-	ret := _m.Called(_a0)
+// SwitchToBlockSync provides a mock function with given fields: _a0, _a1
+func (_m *BlockSyncReactor) SwitchToBlockSync(_a0 context.Context, _a1 state.State) error {
+	ret := _m.Called(_a0, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(state.State) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, state.State) error); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,6 +32,8 @@ func init() {
 	jsontypes.MustRegister(&HasVoteMessage{})
 	jsontypes.MustRegister(&VoteSetMaj23Message{})
 	jsontypes.MustRegister(&VoteSetBitsMessage{})
+	jsontypes.MustRegister(&CompactBlockMessage{})
+	jsontypes.MustRegister(&CompactBlockResultMessage{})
 }
 
 // NewRoundStepMessage is sent for every step taken in the ConsensusState.
@@ -332,6 +334,75 @@ func (m *VoteSetBitsMessage) String() string {
 	return fmt.Sprintf("[VSB %v/%02d/%v %v %v]", m.Height, m.Round, m.Type, m.BlockID, m.Votes)
 }
 
+// CompactBlockMessage is sent instead of the parts of a proposed block to
+// peers which are likely to hold its transactions in their mempools. The block
+// is kept in its proto form, without its transactions, as it is not valid
+// until they are restored from their keys.
+type CompactBlockMessage struct {
+	Height             int64 `json:",string"`
+	Round              int32
+	BlockPartSetHeader types.PartSetHeader
+	Block              *tmproto.Block
+	TxKeys             []types.TxKey
+}
+
+func (*CompactBlockMessage) TypeTag() string { return "tendermint/CompactBlock" }
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if err := m.BlockPartSetHeader.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong BlockPartSetHeader: %w", err)
+	}
+	if m.Block == nil {
+		return errors.New("nil Block")
+	}
+	if m.Block.Header.Height != m.Height {
+		return fmt.Errorf("block height %d does not match height %d", m.Block.Header.Height, m.Height)
+	}
+	if len(m.Block.Data.Txs) > 0 {
+		return errors.New("block has txs")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockMessage) String() string {
+	return fmt.Sprintf("[CompactBlock H:%v R:%v BP:%v Txs:%d]",
+		m.Height, m.Round, m.BlockPartSetHeader, len(m.TxKeys))
+}
+
+// CompactBlockResultMessage is sent in reply to a CompactBlockMessage to
+// indicate whether the block was reconstructed from it.
+type CompactBlockResultMessage struct {
+	Height        int64 `json:",string"`
+	Round         int32
+	Reconstructed bool
+}
+
+func (*CompactBlockResultMessage) TypeTag() string { return "tendermint/CompactBlockResult" }
+
+// ValidateBasic performs basic validation.
+func (m *CompactBlockResultMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("negative Round")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CompactBlockResultMessage) String() string {
+	return fmt.Sprintf("[CompactBlockResult H:%v R:%v Reconstructed:%v]", m.Height, m.Round, m.Reconstructed)
+}
+
 // MsgToProto takes a consensus message type and returns the proto defined
 // consensus message.
 //
@@ -455,6 +526,32 @@ func MsgToProto(msg Message) (*tmcons.Message, error) {
 		pb = tmcons.Message{
 			Sum: vsb,
 		}
+	case *CompactBlockMessage:
+		txKeys := make([][]byte, len(msg.TxKeys))
+		for i := range msg.TxKeys {
+			txKeys[i] = msg.TxKeys[i][:]
+		}
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlock{
+				CompactBlock: &tmcons.CompactBlock{
+					Height:             msg.Height,
+					Round:              msg.Round,
+					BlockPartSetHeader: msg.BlockPartSetHeader.ToProto(),
+					Block:              *msg.Block,
+					TxKeys:             txKeys,
+				},
+			},
+		}
+	case *CompactBlockResultMessage:
+		pb = tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockResult{
+				CompactBlockResult: &tmcons.CompactBlockResult{
+					Height:        msg.Height,
+					Round:         msg.Round,
+					Reconstructed: msg.Reconstructed,
+				},
+			},
+		}
 
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
@@ -580,6 +677,31 @@ func MsgFromProto(msg *tmcons.Message) (Message, error) {
 			BlockID: *bi,
 			Votes:   bits,
 		}
+	case *tmcons.Message_CompactBlock:
+		pbPartSetHeader, err := types.PartSetHeaderFromProto(&msg.CompactBlock.BlockPartSetHeader)
+		if err != nil {
+			return nil, fmt.Errorf("parts header to proto error: %w", err)
+		}
+		txKeys := make([]types.TxKey, len(msg.CompactBlock.TxKeys))
+		for i, key := range msg.CompactBlock.TxKeys {
+			if len(key) != len(txKeys[i]) {
+				return nil, fmt.Errorf("tx key %d has %d bytes, expected %d", i, len(key), len(txKeys[i]))
+			}
+			copy(txKeys[i][:], key)
+		}
+		pb = &CompactBlockMessage{
+			Height:             msg.CompactBlock.Height,
+			Round:              msg.CompactBlock.Round,
+			BlockPartSetHeader: *pbPartSetHeader,
+			Block:              &msg.CompactBlock.Block,
+			TxKeys:             txKeys,
+		}
+	case *tmcons.Message_CompactBlockResult:
+		pb = &CompactBlockResultMessage{
+			Height:        msg.CompactBlockResult.Height,
+			Round:         msg.CompactBlockResult.Round,
+			Reconstructed: msg.CompactBlockResult.Reconstructed,
+		}
 	default:
 		return nil, fmt.Errorf("consensus: message not recognized: %T", msg)
 	}
@@ -71,6 +71,8 @@ func TestMsgToProto(t *testing.T) {
 	require.NoError(t, err)
 	pbVote := vote.ToProto()
 
+	txKey := types.Tx("tx").Key()
+
 	testsCases := []struct {
 		testName string
 		msg      Message
@@ -187,6 +189,36 @@ func TestMsgToProto(t *testing.T) {
 				},
 			},
 		}, false},
+		{"successful CompactBlock", &CompactBlockMessage{
+			Height:             1,
+			Round:              1,
+			BlockPartSetHeader: psh,
+			Block:              &tmproto.Block{Header: tmproto.Header{Height: 1}},
+			TxKeys:             []types.TxKey{types.Tx("tx").Key()},
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_CompactBlock{
+				CompactBlock: &tmcons.CompactBlock{
+					Height:             1,
+					Round:              1,
+					BlockPartSetHeader: pbPsh,
+					Block:              tmproto.Block{Header: tmproto.Header{Height: 1}},
+					TxKeys:             [][]byte{txKey[:]},
+				},
+			},
+		}, false},
+		{"successful CompactBlockResult", &CompactBlockResultMessage{
+			Height:        1,
+			Round:         1,
+			Reconstructed: true,
+		}, &tmcons.Message{
+			Sum: &tmcons.Message_CompactBlockResult{
+				CompactBlockResult: &tmcons.CompactBlockResult{
+					Height:        1,
+					Round:         1,
+					Reconstructed: true,
+				},
+			},
+		}, false},
 		{"failure", nil, &tmcons.Message{}, true},
 	}
 	for _, tt := range testsCases {
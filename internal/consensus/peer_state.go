@@ -47,6 +47,20 @@ type PeerState struct {
 	running bool
 	PRS     cstypes.PeerRoundState `json:"round_state"`
 	Stats   *peerStateStats        `json:"stats"`
+
+	// compactBlocks is true if the peer supports compact blocks, and
+	// compactBlock is the last one sent to it.
+	compactBlocks bool
+	compactBlock  compactBlockState
+}
+
+// compactBlockState is the state of the compact block sent to a peer for a
+// height and round.
+type compactBlockState struct {
+	height  int64
+	round   int32
+	sentAt  time.Time
+	replied bool
 }
 
 // NewPeerState returns a new PeerState for the given node ID.
@@ -159,6 +173,57 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int32, index in
 	ps.PRS.ProposalBlockParts.SetIndex(index, true)
 }
 
+// SetCompactBlocks sets whether the peer supports compact blocks.
+func (ps *PeerState) SetCompactBlocks(v bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlocks = v
+}
+
+// CompactBlockStatus returns whether the peer supports compact blocks, whether
+// the compact block of the given height and round was sent to it, and whether
+// the peer is still expected to reply to it, in which case the block parts are
+// held back from the peer.
+func (ps *PeerState) CompactBlockStatus(height int64, round int32) (supported, sent, pending bool) {
+	ps.mtx.RLock()
+	defer ps.mtx.RUnlock()
+
+	cb := ps.compactBlock
+	sent = cb.height == height && cb.round == round && !cb.sentAt.IsZero()
+	pending = sent && !cb.replied && time.Since(cb.sentAt) < compactBlockTimeout
+	return ps.compactBlocks, sent, pending
+}
+
+// SetCompactBlockSent records that the compact block of the given height and
+// round was sent to the peer.
+func (ps *PeerState) SetCompactBlockSent(height int64, round int32) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	ps.compactBlock = compactBlockState{height: height, round: round, sentAt: time.Now()}
+}
+
+// ApplyCompactBlockResultMessage updates the peer state for the reply of the
+// peer to the compact block sent to it. If the peer reconstructed the block,
+// it has all of its parts. Otherwise, they are no longer held back.
+func (ps *PeerState) ApplyCompactBlockResultMessage(msg *CompactBlockResultMessage) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.compactBlock.height != msg.Height || ps.compactBlock.round != msg.Round {
+		return
+	}
+	ps.compactBlock.replied = true
+
+	if !msg.Reconstructed || ps.PRS.Height != msg.Height || ps.PRS.Round != msg.Round {
+		return
+	}
+	for i := 0; i < ps.PRS.ProposalBlockParts.Size(); i++ {
+		ps.PRS.ProposalBlockParts.SetIndex(i, true)
+	}
+}
+
 // PickVoteToSend picks a vote to send to the peer. It will return true if a
 // vote was picked.
 //
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
@@ -99,3 +100,44 @@ func TestApplyHasVoteMessage(t *testing.T) {
 
 	// skip test cases like v & v3 in TestSetHasVote due to the same path
 }
+
+func TestCompactBlockStatus(t *testing.T) {
+	ps := peerStateSetup(1, 1, 1)
+	ps.PRS.ProposalBlockParts = bits.NewBitArray(3)
+
+	supported, sent, pending := ps.CompactBlockStatus(1, 1)
+	require.False(t, supported)
+	require.False(t, sent)
+	require.False(t, pending)
+
+	ps.SetCompactBlocks(true)
+	ps.SetCompactBlockSent(1, 1)
+	supported, sent, pending = ps.CompactBlockStatus(1, 1)
+	require.True(t, supported)
+	require.True(t, sent)
+	require.True(t, pending)
+	_, sent, _ = ps.CompactBlockStatus(1, 2)
+	require.False(t, sent)
+
+	// a result for another round is ignored
+	ps.ApplyCompactBlockResultMessage(&CompactBlockResultMessage{Height: 1, Round: 2, Reconstructed: true})
+	_, _, pending = ps.CompactBlockStatus(1, 1)
+	require.True(t, pending)
+	require.True(t, ps.PRS.ProposalBlockParts.IsEmpty())
+
+	// the peer has all the parts of a reconstructed block
+	ps.ApplyCompactBlockResultMessage(&CompactBlockResultMessage{Height: 1, Round: 1, Reconstructed: true})
+	_, sent, pending = ps.CompactBlockStatus(1, 1)
+	require.True(t, sent)
+	require.False(t, pending)
+	require.True(t, ps.PRS.ProposalBlockParts.IsFull())
+
+	// the parts are no longer held back when the peer fails to reconstruct
+	// the block
+	ps.SetCompactBlockSent(1, 1)
+	ps.PRS.ProposalBlockParts = bits.NewBitArray(3)
+	ps.ApplyCompactBlockResultMessage(&CompactBlockResultMessage{Height: 1, Round: 1})
+	_, _, pending = ps.CompactBlockStatus(1, 1)
+	require.False(t, pending)
+	require.True(t, ps.PRS.ProposalBlockParts.IsEmpty())
+}
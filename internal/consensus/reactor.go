@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	tmstrings "github.com/tendermint/tendermint/internal/libs/strings"
@@ -30,8 +31,8 @@ var (
 
 // GetChannelDescriptor produces an instance of a descriptor for this
 // package's required channels.
-func getChannelDescriptors() map[p2p.ChannelID]*p2p.ChannelDescriptor {
-	return map[p2p.ChannelID]*p2p.ChannelDescriptor{
+func getChannelDescriptors(cfg *config.ConsensusConfig) map[p2p.ChannelID]*p2p.ChannelDescriptor {
+	descs := map[p2p.ChannelID]*p2p.ChannelDescriptor{
 		StateChannel: {
 			ID:                  StateChannel,
 			MessageType:         new(tmcons.Message),
@@ -72,6 +73,10 @@ func getChannelDescriptors() map[p2p.ChannelID]*p2p.ChannelDescriptor {
 			Name:                "voteSet",
 		},
 	}
+	if cfg.CompactBlocks {
+		descs[DataChannel].Versions = []uint32{types.DefaultChannelVersion, CompactBlockChannelVersion}
+	}
+	return descs
 }
 
 const (
@@ -127,6 +132,11 @@ type Reactor struct {
 	rs          *cstypes.RoundState
 	readySignal chan struct{} // closed when the node is ready to start consensus
 
+	// the block sync reactor to switch back to when falling behind, and the
+	// time of the last switch to consensus
+	blockSync      BlockSyncReactor
+	consensusSince time.Time
+
 	peerEvents p2p.PeerEventSubscriber
 	chCreator  p2p.ChannelCreator
 }
@@ -160,6 +170,7 @@ func NewReactor(
 
 	if !r.waitSync {
 		close(r.readySignal)
+		r.consensusSince = time.Now()
 	}
 
 	return r
@@ -184,7 +195,7 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 	var chBundle channelBundle
 	var err error
 
-	chans := getChannelDescriptors()
+	chans := getChannelDescriptors(r.state.config)
 	chBundle.state, err = r.chCreator(ctx, chans[StateChannel])
 	if err != nil {
 		return err
@@ -222,6 +233,7 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 	}
 
 	go r.updateRoundStateRoutine(ctx)
+	go r.blockSyncRoutine(ctx)
 
 	go r.processStateCh(ctx, chBundle)
 	go r.processDataCh(ctx, chBundle)
@@ -252,30 +264,38 @@ func (r *Reactor) WaitSync() bool {
 }
 
 // SwitchToConsensus switches from block-sync mode to consensus mode. It resets
-// the state, turns off block-sync, and starts the consensus state-machine.
+// the state, turns off block-sync, and starts the consensus state-machine, or
+// resumes it if the node block synced after falling behind.
 func (r *Reactor) SwitchToConsensus(ctx context.Context, state sm.State, skipWAL bool) {
 	r.logger.Info("switching to consensus")
 
-	// we have no votes, so reconstruct LastCommit from SeenCommit
-	if state.LastBlockHeight > 0 {
-		r.state.reconstructLastCommit(state)
-	}
+	if r.state.IsRunning() {
+		if err := r.state.resume(state); err != nil {
+			panic(fmt.Sprintf("failed to resume consensus state: %v", err))
+		}
+	} else {
+		// we have no votes, so reconstruct LastCommit from SeenCommit
+		if state.LastBlockHeight > 0 {
+			r.state.reconstructLastCommit(state)
+		}
 
-	// NOTE: The line below causes broadcastNewRoundStepRoutine() to broadcast a
-	// NewRoundStepMessage.
-	r.state.updateToState(state)
-	if err := r.state.Start(ctx); err != nil {
-		panic(fmt.Sprintf(`failed to start consensus state: %v
+		// NOTE: The line below causes broadcastNewRoundStepRoutine() to broadcast a
+		// NewRoundStepMessage.
+		r.state.updateToState(state)
+		if err := r.state.Start(ctx); err != nil {
+			panic(fmt.Sprintf(`failed to start consensus state: %v
 
 conS:
 %+v
 
 conR:
 %+v`, err, r.state, r))
+		}
 	}
 
 	r.mtx.Lock()
 	r.waitSync = false
+	r.consensusSince = time.Now()
 	close(r.readySignal)
 	r.mtx.Unlock()
 
@@ -521,8 +541,12 @@ OUTER_LOOP:
 		rs := r.getRoundState()
 		prs := ps.GetRoundState()
 
+		// Send proposal block as a compact block, holding back its parts until
+		// the peer replies?
+		holdParts := r.gossipCompactBlock(ctx, rs, prs, ps, dataCh)
+
 		// Send proposal Block parts?
-		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+		if !holdParts && rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				partProto, err := part.ToProto()
@@ -631,6 +655,49 @@ OUTER_LOOP:
 	}
 }
 
+// gossipCompactBlock sends the complete proposal block to the peer as a compact
+// block, if the peer supports them and has none of the parts of the block. It
+// returns true if the parts of the block are to be held back from the peer, as
+// it was sent the compact block and has not replied to it yet.
+func (r *Reactor) gossipCompactBlock(
+	ctx context.Context,
+	rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState,
+	ps *PeerState,
+	dataCh p2p.Channel,
+) bool {
+	if rs.Height != prs.Height || rs.Round != prs.Round || rs.ProposalBlock == nil ||
+		!rs.ProposalBlockParts.IsComplete() || !rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartSetHeader) {
+		return false
+	}
+	supported, sent, pending := ps.CompactBlockStatus(rs.Height, rs.Round)
+	if !supported || sent {
+		return pending
+	}
+	if !prs.ProposalBlockParts.IsEmpty() {
+		return false
+	}
+
+	// the compact block is recorded as sent even if it is not, so that it is
+	// not made again for the peer
+	ps.SetCompactBlockSent(rs.Height, rs.Round)
+	msg, err := makeCompactBlockMessage(rs)
+	if err != nil {
+		r.logger.Debug("sending block parts instead of compact block", "height", rs.Height, "round", rs.Round, "err", err)
+		ps.ApplyCompactBlockResultMessage(&CompactBlockResultMessage{Height: rs.Height, Round: rs.Round})
+		return false
+	}
+
+	r.logger.Debug("sending compact block", "peer", ps.peerID, "height", rs.Height, "round", rs.Round)
+	// NOTE: Send only fails once the context is canceled, which ends the
+	// gossip routine.
+	_ = dataCh.Send(ctx, p2p.Envelope{
+		To:      ps.peerID,
+		Message: msg,
+	})
+	return true
+}
+
 // pickSendVote picks a vote and sends it to the peer. It will return true if
 // there is a vote to send and false otherwise.
 func (r *Reactor) pickSendVote(ctx context.Context, ps *PeerState, votes types.VoteSetReader, voteCh p2p.Channel) (bool, error) {
@@ -962,6 +1029,8 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 			r.peers[peerUpdate.NodeID] = ps
 		}
 
+		ps.SetCompactBlocks(peerUpdate.ChannelVersions[DataChannel] >= CompactBlockChannelVersion)
+
 		if !ps.IsRunning() {
 			// Set the peer state's closer to signal to all spawned goroutines to exit
 			// when the peer is removed. We also set the running state to ensure we
@@ -970,11 +1039,12 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 			ps.SetRunning(true)
 			ctx, ps.cancel = context.WithCancel(ctx)
 
+			readySignal := r.readySignal
 			go func() {
 				select {
 				case <-ctx.Done():
 					return
-				case <-r.readySignal:
+				case <-readySignal:
 				}
 				// do nothing if the peer has
 				// stopped while we've been waiting.
@@ -1104,7 +1174,7 @@ func (r *Reactor) handleStateMessage(ctx context.Context, envelope *p2p.Envelope
 // fail to find the peer state for the envelope sender, we perform a no-op and
 // return. This can happen when we process the envelope after the peer is
 // removed.
-func (r *Reactor) handleDataMessage(ctx context.Context, envelope *p2p.Envelope, msgI Message) error {
+func (r *Reactor) handleDataMessage(ctx context.Context, envelope *p2p.Envelope, msgI Message, dataCh p2p.Channel) error {
 	logger := r.logger.With("peer", envelope.From, "ch_id", "DataChannel")
 
 	ps, ok := r.GetPeerState(envelope.From)
@@ -1141,6 +1211,10 @@ func (r *Reactor) handleDataMessage(ctx context.Context, envelope *p2p.Envelope,
 		case <-ctx.Done():
 			return ctx.Err()
 		}
+	case *tmcons.CompactBlock:
+		return r.handleCompactBlockMessage(ctx, envelope, ps, msgI.(*CompactBlockMessage), dataCh)
+	case *tmcons.CompactBlockResult:
+		ps.ApplyCompactBlockResultMessage(msgI.(*CompactBlockResultMessage))
 
 	default:
 		return fmt.Errorf("received unknown message on DataChannel: %T", msg)
@@ -1149,6 +1223,61 @@ func (r *Reactor) handleDataMessage(ctx context.Context, envelope *p2p.Envelope,
 	return nil
 }
 
+// handleCompactBlockMessage reconstructs the block of a compact block from the
+// mempool, and passes its parts to the consensus state as if the peer sent
+// them, replying to the peer whether the block was reconstructed. If not, the
+// peer sends the parts of the block instead.
+func (r *Reactor) handleCompactBlockMessage(
+	ctx context.Context,
+	envelope *p2p.Envelope,
+	ps *PeerState,
+	msg *CompactBlockMessage,
+	dataCh p2p.Channel,
+) error {
+	logger := r.logger.With("peer", envelope.From, "height", msg.Height, "round", msg.Round)
+
+	var parts *types.PartSet
+	reconstructed := false
+	rs := r.getRoundState()
+	if rs.ProposalBlockParts.HasHeader(msg.BlockPartSetHeader) && rs.ProposalBlockParts.IsComplete() {
+		// the block is already complete
+		reconstructed = true
+	} else if txs, ok := r.state.txNotifier.(txProvider); ok && msg.Height == rs.Height {
+		parts, reconstructed = reconstructCompactBlock(msg, txs)
+	}
+	logger.Debug("received compact block", "txs", len(msg.TxKeys), "reconstructed", reconstructed)
+	r.Metrics.CompactBlocksReceived.With("reconstructed", fmt.Sprint(reconstructed)).Add(1)
+
+	if reconstructed {
+		for i := 0; i < int(msg.BlockPartSetHeader.Total); i++ {
+			ps.SetHasProposalBlockPart(msg.Height, msg.Round, i)
+		}
+	}
+	if err := dataCh.Send(ctx, p2p.Envelope{
+		To: envelope.From,
+		Message: &tmcons.CompactBlockResult{
+			Height:        msg.Height,
+			Round:         msg.Round,
+			Reconstructed: reconstructed,
+		},
+	}); err != nil {
+		return err
+	}
+
+	if parts == nil {
+		return nil
+	}
+	for i := 0; i < int(parts.Total()); i++ {
+		bpMsg := &BlockPartMessage{Height: msg.Height, Round: msg.Round, Part: parts.GetPart(i)}
+		select {
+		case r.state.peerMsgQueue <- msgInfo{bpMsg, envelope.From, tmtime.Now()}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 // handleVoteMessage handles envelopes sent from peers on the VoteChannel. If we
 // fail to find the peer state for the envelope sender, we perform a no-op and
 // return. This can happen when we process the envelope after the peer is
@@ -1288,7 +1417,7 @@ func (r *Reactor) handleMessage(ctx context.Context, envelope *p2p.Envelope, cha
 	case StateChannel:
 		err = r.handleStateMessage(ctx, envelope, msgI, chans.votSet)
 	case DataChannel:
-		err = r.handleDataMessage(ctx, envelope, msgI)
+		err = r.handleDataMessage(ctx, envelope, msgI, chans.data)
 	case VoteChannel:
 		err = r.handleVoteMessage(ctx, envelope, msgI)
 	case VoteSetBitsChannel:
@@ -78,7 +78,9 @@ func setup(
 	}
 
 	rts.stateChannels = rts.network.MakeChannelsNoCleanup(ctx, t, chDesc(StateChannel, size))
-	rts.dataChannels = rts.network.MakeChannelsNoCleanup(ctx, t, chDesc(DataChannel, size))
+	dataDesc := chDesc(DataChannel, size)
+	dataDesc.Versions = getChannelDescriptors(states[0].config)[DataChannel].Versions
+	rts.dataChannels = rts.network.MakeChannelsNoCleanup(ctx, t, dataDesc)
 	rts.voteChannels = rts.network.MakeChannelsNoCleanup(ctx, t, chDesc(VoteChannel, size))
 	rts.voteSetBitsChannels = rts.network.MakeChannelsNoCleanup(ctx, t, chDesc(VoteSetBitsChannel, size))
 
@@ -778,6 +780,78 @@ func TestReactorRecordsVotesAndBlockParts(t *testing.T) {
 	require.Greater(t, ps.VotesSent(), 0, "number of votes sent should've increased")
 }
 
+func TestReactorCompactBlocks(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// the nodes whose mempools hold the tx
+		holders int
+	}{
+		{"reconstructed", 2},
+		{"missing txs", 1},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			cfg := configSetup(t)
+
+			n := 2
+			states, cleanup := makeConsensusState(ctx, t,
+				cfg, n, "consensus_reactor_test",
+				newMockTickerFunc(true),
+				func(c *config.Config) {
+					c.Consensus.CompactBlocks = true
+				})
+			t.Cleanup(cleanup)
+
+			rts := setup(ctx, t, n, states, 100) // buffer must be large enough to not deadlock
+
+			tx := types.Tx("compact=block")
+			for _, state := range states[:tc.holders] {
+				require.NoError(t, assertMempool(t, state.txNotifier).CheckTx(ctx, tx, nil, mempool.TxInfo{}))
+			}
+
+			for _, reactor := range rts.reactors {
+				state := reactor.state.GetState()
+				reactor.SwitchToConsensus(ctx, state, false)
+			}
+
+			// wait till everyone commits the block of the tx
+			var wg sync.WaitGroup
+			for _, sub := range rts.subs {
+				wg.Add(1)
+				go func(s eventbus.Subscription) {
+					defer wg.Done()
+					for {
+						msg, err := s.Next(ctx)
+						if !assert.NoError(t, err) {
+							cancel()
+							return
+						}
+						if len(msg.Data().(types.EventDataNewBlock).Block.Txs) > 0 {
+							return
+						}
+					}
+				}(sub)
+			}
+			wg.Wait()
+
+			sent := false
+			for _, reactor := range rts.reactors {
+				for _, ps := range reactor.peers {
+					supported, _, _ := ps.CompactBlockStatus(0, 0)
+					require.True(t, supported, "compact blocks should've been negotiated")
+					ps.mtx.RLock()
+					sent = sent || !ps.compactBlock.sentAt.IsZero()
+					ps.mtx.RUnlock()
+				}
+			}
+			require.True(t, sent, "a compact block should've been sent")
+		})
+	}
+}
+
 func TestReactorVotingPowerChange(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode")
@@ -135,6 +135,13 @@ func (cs *State) catchupReplay(ctx context.Context, csHeight int64) error {
 	} else if err != nil {
 		return err
 	}
+	if !found && endHeight > 0 && cs.blockStore.Height() >= endHeight {
+		// The block of the last height is committed, and the handshake applied
+		// it if need be: the node crashed before writing #ENDHEIGHT, so
+		// complete the commit in the WAL. There is nothing left to replay.
+		cs.logger.Info("Replay: completing commit of last height in WAL", "height", endHeight)
+		return cs.wal.WriteSync(EndHeightMessage{endHeight})
+	}
 	if !found {
 		return fmt.Errorf("cannot replay height %d. WAL does not contain #ENDHEIGHT for %d", csHeight, endHeight)
 	}
@@ -143,7 +150,7 @@ func (cs *State) catchupReplay(ctx context.Context, csHeight int64) error {
 	cs.logger.Info("Catchup by replaying consensus messages", "height", csHeight)
 
 	var msg *TimedWALMessage
-	dec := WALDecoder{gr}
+	dec := NewWALDecoder(gr)
 
 LOOP:
 	for {
@@ -209,9 +216,22 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	// initChainChunkSize is the maximum size of the app state sent in an
+	// InitChain request, or 0 for no maximum.
+	initChainChunkSize int
+
 	nBlocks int // number of blocks applied to the state
 }
 
+// HandshakerOption sets an optional parameter on the Handshaker.
+type HandshakerOption func(*Handshaker)
+
+// HandshakerInitChainChunkSize delivers an app state larger than size bytes
+// to the application in chunks of size bytes, in several InitChain requests.
+func HandshakerInitChainChunkSize(size int) HandshakerOption {
+	return func(h *Handshaker) { h.initChainChunkSize = size }
+}
+
 func NewHandshaker(
 	logger log.Logger,
 	stateStore sm.Store,
@@ -219,8 +239,9 @@ func NewHandshaker(
 	store sm.BlockStore,
 	eventBus *eventbus.EventBus,
 	genDoc *types.GenesisDoc,
+	options ...HandshakerOption,
 ) *Handshaker {
-	return &Handshaker{
+	h := &Handshaker{
 		stateStore:   stateStore,
 		initialState: state,
 		store:        store,
@@ -228,6 +249,10 @@ func NewHandshaker(
 		genDoc:       genDoc,
 		logger:       logger,
 	}
+	for _, opt := range options {
+		opt(h)
+	}
+	return h
 }
 
 // NBlocks returns the number of blocks applied to the state.
@@ -262,6 +287,10 @@ func (h *Handshaker) Handshake(ctx context.Context, appClient abciclient.Client)
 		h.initialState.Version.Consensus.App = res.AppVersion
 	}
 
+	if err := h.recoverCommit(); err != nil {
+		return fmt.Errorf("error on commit recovery: %w", err)
+	}
+
 	// Replay blocks up to the latest in the blockstore.
 	_, err = h.ReplayBlocks(ctx, h.initialState, appHash, blockHeight, appClient)
 	if err != nil {
@@ -276,6 +305,35 @@ func (h *Handshaker) Handshake(ctx context.Context, appClient abciclient.Client)
 	return nil
 }
 
+// recoverCommit recovers from a crash in the middle of the commit of a block,
+// as recorded by the commit marker of the state store. If the block was saved
+// to the blockstore, the commit is completed by ReplayBlocks, which saves the
+// state and so clears the marker; otherwise the commit is discarded, and the
+// block is decided again from the WAL.
+func (h *Handshaker) recoverCommit() error {
+	height, err := h.stateStore.LoadCommitMarker()
+	if err != nil || height == 0 {
+		return err
+	}
+
+	storeHeight := h.store.Height()
+	switch {
+	case height <= h.initialState.LastBlockHeight:
+		h.logger.Info("clearing commit marker of committed block", "height", height)
+	case storeHeight < height:
+		h.logger.Info("discarding commit interrupted before saving the block", "height", height,
+			"storeHeight", storeHeight)
+	case storeHeight == height:
+		h.logger.Info("recovering commit interrupted after saving the block", "height", height,
+			"stateHeight", h.initialState.LastBlockHeight)
+		return nil
+	default:
+		return fmt.Errorf("commit marker at height %d is below the blockstore height %d",
+			height, storeHeight)
+	}
+	return h.stateStore.ClearCommitMarker()
+}
+
 // ReplayBlocks replays all blocks since appBlockHeight and ensures the result
 // matches the current state.
 // Returns the final AppHash or an error.
@@ -307,7 +365,7 @@ func (h *Handshaker) ReplayBlocks(
 		validatorSet := types.NewValidatorSet(validators)
 		nextVals := types.TM2PB.ValidatorUpdates(validatorSet)
 		pbParams := h.genDoc.ConsensusParams.ToProto()
-		res, err := appClient.InitChain(ctx, &abci.RequestInitChain{
+		res, err := h.initChain(ctx, appClient, &abci.RequestInitChain{
 			Time:            h.genDoc.GenesisTime,
 			ChainId:         h.genDoc.ChainID,
 			InitialHeight:   h.genDoc.InitialHeight,
@@ -451,6 +509,36 @@ func (h *Handshaker) ReplayBlocks(
 		appBlockHeight, storeBlockHeight, stateBlockHeight)
 }
 
+// initChain calls InitChain, delivering the app state in chunks in several
+// requests if it is larger than the chunk size, and returns the response to
+// the last request.
+func (h *Handshaker) initChain(ctx context.Context, appClient abciclient.Client, req *abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	appState, size := req.AppStateBytes, h.initChainChunkSize
+	if size == 0 || len(appState) <= size {
+		return appClient.InitChain(ctx, req)
+	}
+
+	chunks := (len(appState) + size - 1) / size
+	h.logger.Info("sending the app state to the application in chunks", "size", len(appState), "chunks", chunks)
+	var res *abci.ResponseInitChain
+	for i := 0; i < chunks; i++ {
+		end := (i + 1) * size
+		if end > len(appState) {
+			end = len(appState)
+		}
+		chunkReq := *req
+		chunkReq.AppStateBytes = appState[i*size : end]
+		chunkReq.AppStateChunk = uint32(i)
+		chunkReq.AppStateChunks = uint32(chunks)
+
+		var err error
+		if res, err = appClient.InitChain(ctx, &chunkReq); err != nil {
+			return nil, fmt.Errorf("sending app state chunk %d of %d: %w", i, chunks, err)
+		}
+	}
+	return res, nil
+}
+
 func (h *Handshaker) replayBlocks(
 	ctx context.Context,
 	state sm.State,
@@ -10,8 +10,6 @@ import (
 	"strconv"
 	"strings"
 
-	dbm "github.com/tendermint/tm-db"
-
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/proxy"
@@ -34,16 +32,15 @@ const (
 func RunReplayFile(
 	ctx context.Context,
 	logger log.Logger,
-	cfg config.BaseConfig,
-	csConfig *config.ConsensusConfig,
+	cfg *config.Config,
 	console bool,
 ) error {
-	consensusState, err := newConsensusStateForReplay(ctx, cfg, logger, csConfig)
+	consensusState, err := newConsensusStateForReplay(ctx, cfg, logger)
 	if err != nil {
 		return err
 	}
 
-	if err := consensusState.ReplayFile(ctx, csConfig.WalFile(), console); err != nil {
+	if err := consensusState.ReplayFile(ctx, cfg.Consensus.WalFile(), console); err != nil {
 		return fmt.Errorf("consensus replay: %w", err)
 	}
 
@@ -296,20 +293,18 @@ func (pb *playback) replayConsoleLoop(ctx context.Context) (int, error) {
 // convenience for replay mode
 func newConsensusStateForReplay(
 	ctx context.Context,
-	cfg config.BaseConfig,
+	cfg *config.Config,
 	logger log.Logger,
-	csConfig *config.ConsensusConfig,
 ) (*State, error) {
-	dbType := dbm.BackendType(cfg.DBBackend)
 	// Get BlockStore
-	blockStoreDB, err := dbm.NewDB("blockstore", dbType, cfg.DBDir())
+	blockStoreDB, err := config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
 	if err != nil {
 		return nil, err
 	}
 	blockStore := store.NewBlockStore(blockStoreDB)
 
 	// Get State
-	stateDB, err := dbm.NewDB("state", dbType, cfg.DBDir())
+	stateDB, err := config.DefaultDBProvider(&config.DBContext{ID: "state", Config: cfg})
 	if err != nil {
 		return nil, err
 	}
@@ -325,7 +320,11 @@ func newConsensusStateForReplay(
 		return nil, err
 	}
 
-	client, _, err := proxy.ClientFactory(logger, cfg.ProxyApp, cfg.ABCI, cfg.DBDir())
+	grpcOptions, err := proxy.GRPCOptions(cfg.BaseConfig)
+	if err != nil {
+		return nil, err
+	}
+	client, _, err := proxy.ClientFactory(logger, cfg.ProxyApp, cfg.ABCI, cfg.DBDir(), grpcOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -350,7 +349,7 @@ func newConsensusStateForReplay(
 	mempool, evpool := emptyMempool{}, sm.EmptyEvidencePool{}
 	blockExec := sm.NewBlockExecutor(stateStore, logger, proxyApp, mempool, evpool, blockStore, eventBus, sm.NopMetrics())
 
-	consensusState, err := NewState(logger, csConfig, stateStore, blockExec,
+	consensusState, err := NewState(logger, cfg.Consensus, stateStore, blockExec,
 		blockStore, mempool, evpool, eventBus)
 	if err != nil {
 		return nil, err
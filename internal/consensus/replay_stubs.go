@@ -43,6 +43,7 @@ func (emptyMempool) FlushAppConn(ctx context.Context) error { return nil }
 func (emptyMempool) TxsAvailable() <-chan struct{}          { return make(chan struct{}) }
 func (emptyMempool) EnableTxsAvailable()                    {}
 func (emptyMempool) SizeBytes() int64                       { return 0 }
+func (emptyMempool) TxStatus(types.TxKey) mempool.TxStatus  { return mempool.TxStatus{} }
 
 func (emptyMempool) TxsFront() *clist.CElement    { return nil }
 func (emptyMempool) TxsWaitChan() <-chan struct{} { return nil }
@@ -296,6 +296,122 @@ func (w *crashingWAL) Start(ctx context.Context) error { return w.next.Start(ctx
 func (w *crashingWAL) Stop()                           { w.next.Stop() }
 func (w *crashingWAL) Wait()                           { w.next.Wait() }
 
+// TestCommitCrashRecovery simulates a crash after each step of the commit of
+// the first block, and checks that the handshake brings the stores back in
+// line and that consensus then makes progress.
+func TestCommitCrashRecovery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	testCases := []struct {
+		name      string
+		step      commitStep
+		committed bool // whether the block is committed after recovery
+	}{
+		{"marked", commitStepMarked, false},
+		{"block saved", commitStepBlockSaved, true},
+		{"end height written", commitStepEndHeight, true},
+		{"applied", commitStepApplied, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cfg, err := ResetConfig(t.TempDir(), "commit_crash")
+			require.NoError(t, err)
+			logger := log.NewNopLogger()
+			genDoc, err := sm.MakeGenesisDocFromFile(cfg.GenesisFile())
+			require.NoError(t, err)
+			state, err := sm.MakeGenesisState(genDoc)
+			require.NoError(t, err)
+			privValidator := loadPrivValidator(t, cfg)
+			stateStore := sm.NewStore(dbm.NewMemDB())
+			require.NoError(t, stateStore.Save(state))
+			blockStore := store.NewBlockStore(dbm.NewMemDB())
+			app := kvstore.NewApplication()
+			appClient := abciclient.NewLocalClient(logger, app)
+			eventBus := eventbus.NewDefault(logger)
+			require.NoError(t, eventBus.Start(ctx))
+			handshake := func() sm.State {
+				state, err := stateStore.Load()
+				require.NoError(t, err)
+				handshaker := NewHandshaker(logger, stateStore, state, blockStore, eventBus, genDoc)
+				require.NoError(t, handshaker.Handshake(ctx, appClient))
+				state, err = stateStore.Load()
+				require.NoError(t, err)
+				return state
+			}
+
+			// crash in the middle of the commit of the first block
+			state = handshake()
+			cs := newStateWithConfigAndStores(ctx, t, logger, cfg, state, privValidator, app, blockStore, stateStore)
+			crashed := make(chan struct{})
+			cs.commitStepHook = func(height int64, step commitStep) {
+				if height == 1 && step == tc.step {
+					close(crashed)
+					runtime.Goexit()
+				}
+			}
+			require.NoError(t, cs.Start(ctx))
+			select {
+			case <-crashed:
+			case <-time.After(30 * time.Second):
+				t.Fatal("consensus did not reach the commit step")
+			}
+			cs.wal.Stop()
+			cs.wal.Wait()
+			cs.Stop()
+
+			// recover with the handshake
+			state = handshake()
+			marker, err := stateStore.LoadCommitMarker()
+			require.NoError(t, err)
+			require.Zero(t, marker, "the commit marker must be cleared")
+			require.Equal(t, blockStore.Height(), state.LastBlockHeight)
+			info, err := appClient.Info(ctx, &abci.RequestInfo{})
+			require.NoError(t, err)
+			require.Equal(t, state.LastBlockHeight, info.LastBlockHeight)
+			if tc.committed {
+				require.EqualValues(t, 1, state.LastBlockHeight)
+			} else {
+				require.Zero(t, state.LastBlockHeight)
+			}
+
+			// the WAL ends the committed height, and consensus makes progress
+			cs = newStateWithConfigAndStores(ctx, t, logger, cfg, state, privValidator, app, blockStore, stateStore)
+			newBlockSub, err := cs.eventBus.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+				ClientID: testSubscriber,
+				Query:    types.EventQueryNewBlock,
+			})
+			require.NoError(t, err)
+			require.NoError(t, cs.Start(ctx))
+			t.Cleanup(cs.Wait)
+			defer cs.Stop()
+
+			if tc.committed {
+				gr, found, err := cs.wal.SearchForEndHeight(1, &WALSearchOptions{})
+				require.NoError(t, err)
+				require.True(t, found, "the WAL must end height 1")
+				gr.Close()
+			}
+
+			ctxto, cancelto := context.WithTimeout(ctx, 30*time.Second)
+			defer cancelto()
+			for {
+				msg, err := newBlockSub.Next(ctxto)
+				require.NoError(t, err, "timed out waiting for a block")
+				if msg.Data().(types.EventDataNewBlock).Block.Height >= 2 {
+					break
+				}
+			}
+		})
+	}
+}
+
 //------------------------------------------------------------------------------------------
 type simulatorTestSuite struct {
 	GenesisState sm.State
@@ -1234,6 +1350,12 @@ func (bs *mockBlockStore) PruneBlocks(height int64) (uint64, error) {
 	return pruned, nil
 }
 
+func (bs *mockBlockStore) DeleteLatestBlock() error {
+	bs.chain = bs.chain[:len(bs.chain)-1]
+	bs.extCommits = bs.extCommits[:len(bs.extCommits)-1]
+	return nil
+}
+
 //---------------------------------------
 // Test handshake/init chain
 
@@ -1285,6 +1407,69 @@ func TestHandshakeUpdatesValidators(t *testing.T) {
 	assert.Equal(t, newValAddr, expectValAddr)
 }
 
+func TestHandshakeInitChainChunks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+	app := &initChainChunksApp{}
+	client := abciclient.NewLocalClient(logger, app)
+
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	cfg, err := ResetConfig(t.TempDir(), "handshake_test_")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(cfg.RootDir) })
+
+	privVal, err := privval.LoadFilePV(cfg.PrivValidator.KeyFile(), cfg.PrivValidator.StateFile())
+	require.NoError(t, err)
+	pubKey, err := privVal.GetPubKey(ctx)
+	require.NoError(t, err)
+	stateDB, state, store := stateAndStore(t, cfg, pubKey, 0x0)
+	stateStore := sm.NewStore(stateDB)
+
+	genDoc, err := sm.MakeGenesisDocFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	genDoc.AppState = []byte(`{"accounts":["alice","bob","carol"]}`)
+
+	proxyApp := proxy.New(client, logger, proxy.NopMetrics())
+	require.NoError(t, proxyApp.Start(ctx), "Error starting proxy app connections")
+
+	handshaker := NewHandshaker(logger, stateStore, state, store, eventBus, genDoc, HandshakerInitChainChunkSize(10))
+	require.NoError(t, handshaker.Handshake(ctx, proxyApp), "error on abci handshake")
+
+	require.Len(t, app.requests, 4)
+	for i, req := range app.requests {
+		require.EqualValues(t, i, req.AppStateChunk)
+		require.EqualValues(t, 4, req.AppStateChunks)
+		require.Equal(t, genDoc.ChainID, req.ChainId)
+		require.Len(t, req.Validators, len(genDoc.Validators))
+	}
+	require.Equal(t, string(genDoc.AppState), string(app.appState))
+
+	// the app hash of the response to the last request is used
+	state, err = stateStore.Load()
+	require.NoError(t, err)
+	require.Equal(t, []byte("app_hash"), []byte(state.AppHash))
+}
+
+// initChainChunksApp assembles the app state delivered in chunks on InitChain.
+type initChainChunksApp struct {
+	abci.BaseApplication
+	requests []*abci.RequestInitChain
+	appState []byte
+}
+
+func (app *initChainChunksApp) InitChain(_ context.Context, req *abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	app.requests = append(app.requests, req)
+	app.appState = append(app.appState, req.AppStateBytes...)
+	if req.AppStateChunk+1 < req.AppStateChunks {
+		return &abci.ResponseInitChain{}, nil
+	}
+	return &abci.ResponseInitChain{AppHash: []byte("app_hash")}, nil
+}
+
 // returns the vals on InitChain
 type initChainApp struct {
 	abci.BaseApplication
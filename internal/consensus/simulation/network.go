@@ -0,0 +1,279 @@
+package simulation
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Faults are the faults injected by a Network into the messages received from
+// peers. The messages of the local node, its timeouts and its steps are never
+// affected.
+type Faults struct {
+	// DropRate is the probability of dropping each message.
+	DropRate float64
+
+	// MaxDelay is the maximum delay of each message, chosen uniformly. Delayed
+	// messages may be received after the following ones.
+	MaxDelay time.Duration
+
+	// ByzantineVoteRate is the probability of receiving, after each vote, a
+	// conflicting vote of the same validator for another block, or for nil if
+	// the vote is for a block. The conflicting votes of the validators whose
+	// keys were given to the network with SetKeys are signed with them, so
+	// that a State replaying them reports them to the evidence pool. The keys
+	// of the other validators are not in the trace, so their conflicting
+	// votes keep the signatures of the original votes and are rejected as
+	// invalid.
+	ByzantineVoteRate float64
+
+	// RotateIsolated drops all the messages of a single peer at each height,
+	// rotating through the peers in the order in which they first appear in
+	// the trace, so that the validators whose messages are received change at
+	// every height. The validator set itself is the one of the trace: the
+	// traces of a rotating validator set are recorded from a chain running
+	// the simapp.Rotating application.
+	RotateIsolated bool
+}
+
+// Validate returns an error if the faults are invalid.
+func (f Faults) Validate() error {
+	if f.DropRate < 0 || f.DropRate > 1 {
+		return errors.New("drop rate must be between 0 and 1")
+	}
+	if f.MaxDelay < 0 {
+		return errors.New("max delay can't be negative")
+	}
+	if f.ByzantineVoteRate < 0 || f.ByzantineVoteRate > 1 {
+		return errors.New("byzantine vote rate must be between 0 and 1")
+	}
+	return nil
+}
+
+// Stats are the counts of the faults injected by a run of a Network.
+type Stats struct {
+	// Messages is the number of messages received from peers in the trace.
+	Messages int
+	// Dropped is the number of messages dropped, including the ones of
+	// isolated peers.
+	Dropped int
+	// Delayed is the number of messages delayed.
+	Delayed int
+	// ByzantineVotes is the number of conflicting votes added, and
+	// SignedByzantineVotes the number of the ones signed with the key of
+	// their validator.
+	ByzantineVotes       int
+	SignedByzantineVotes int
+}
+
+// Network is a simulated network between a node and its peers, injecting
+// faults into the messages of the peers. Its faults are chosen by a source of
+// random numbers seeded with its seed at each run, so that the runs of a
+// network on the same trace are identical.
+type Network struct {
+	seed    int64
+	faults  Faults
+	chainID string
+	signers map[string]types.PrivValidator
+}
+
+// NewNetwork returns a Network injecting the given faults, with a source of
+// random numbers seeded with seed.
+func NewNetwork(seed int64, faults Faults) (*Network, error) {
+	if err := faults.Validate(); err != nil {
+		return nil, err
+	}
+	return &Network{seed: seed, faults: faults, signers: make(map[string]types.PrivValidator)}, nil
+}
+
+// SetKeys sets the private keys signing the conflicting votes of their
+// validators, for the chain with the given ID, e.g. the keys of the
+// validators of a local testnet.
+func (n *Network) SetKeys(chainID string, keys ...crypto.PrivKey) {
+	n.chainID = chainID
+	n.signers = make(map[string]types.PrivValidator, len(keys))
+	for _, key := range keys {
+		// the sign state of the validator must not stop signing the votes
+		// conflicting with its own, so they are signed with a mock
+		n.signers[string(key.PubKey().Address())] = types.NewMockPVWithParams(key, false, false)
+	}
+}
+
+// Run returns the trace of the messages of trace as received by the node
+// through the network, and the counts of the injected faults.
+func (n *Network) Run(trace Trace) (Trace, Stats) {
+	r := &run{
+		faults:  n.faults,
+		chainID: n.chainID,
+		signers: n.signers,
+		rng:     rand.New(rand.NewSource(n.seed)), // nolint: gosec
+		out:     make(Trace, 0, len(trace)),
+		peerIdx: make(map[types.NodeID]int),
+	}
+	for _, msg := range trace {
+		if _, peerID, ok := consensus.MsgInfoFromWALMessage(msg.Msg); ok && peerID != "" {
+			if _, ok := r.peerIdx[peerID]; !ok {
+				r.peerIdx[peerID] = len(r.peers)
+				r.peers = append(r.peers, peerID)
+			}
+		}
+	}
+	for _, msg := range trace {
+		r.deliverUntil(msg.Time)
+		r.handle(msg)
+	}
+	r.deliverUntil(time.Time{})
+	return r.out, r.stats
+}
+
+// run is the state of a run of a Network.
+type run struct {
+	faults  Faults
+	chainID string
+	signers map[string]types.PrivValidator
+	rng     *rand.Rand
+	out     Trace
+	stats   Stats
+
+	// height is the height of the last step of the trace, and peers are the
+	// peers of the trace in the order in which they first appear.
+	height  int64
+	peers   []types.NodeID
+	peerIdx map[types.NodeID]int
+
+	pending delayedMessages
+	seq     int
+}
+
+// handle handles a message of the trace, received by the node at msg.Time.
+func (r *run) handle(msg *consensus.TimedWALMessage) {
+	if step, ok := msg.Msg.(types.EventDataRoundState); ok {
+		r.height = step.Height
+	}
+	m, peerID, ok := consensus.MsgInfoFromWALMessage(msg.Msg)
+	if !ok || peerID == "" {
+		r.out = append(r.out, msg)
+		return
+	}
+	r.stats.Messages++
+
+	if r.faults.RotateIsolated && r.isolated(peerID) {
+		r.stats.Dropped++
+		return
+	}
+	if r.faults.DropRate > 0 && r.rng.Float64() < r.faults.DropRate {
+		r.stats.Dropped++
+		return
+	}
+	msgs := []*consensus.TimedWALMessage{msg}
+	if vm, ok := m.(*consensus.VoteMessage); ok && r.faults.ByzantineVoteRate > 0 &&
+		r.rng.Float64() < r.faults.ByzantineVoteRate {
+		r.stats.ByzantineVotes++
+		msgs = append(msgs, &consensus.TimedWALMessage{
+			Time: msg.Time,
+			Msg:  consensus.NewMsgInfoWALMessage(&consensus.VoteMessage{Vote: r.conflictingVote(vm.Vote)}, peerID),
+		})
+	}
+
+	if r.faults.MaxDelay > 0 {
+		delay := time.Duration(r.rng.Int63n(int64(r.faults.MaxDelay)))
+		if delay > 0 {
+			r.stats.Delayed++
+			for _, m := range msgs {
+				r.seq++
+				heap.Push(&r.pending, &delayedMessage{
+					msg: &consensus.TimedWALMessage{Time: msg.Time.Add(delay), Msg: m.Msg},
+					seq: r.seq,
+				})
+			}
+			return
+		}
+	}
+	r.out = append(r.out, msgs...)
+}
+
+// isolated reports whether the peer is isolated at the current height.
+func (r *run) isolated(peerID types.NodeID) bool {
+	return int(r.height%int64(len(r.peers))) == r.peerIdx[peerID]
+}
+
+// conflictingVote returns a vote of the same validator as vote, at the same
+// height, round and step, for another block. It is signed with the key of the
+// validator if the network has it, and otherwise keeps the signatures of vote.
+func (r *run) conflictingVote(vote *types.Vote) *types.Vote {
+	v := vote.Copy()
+	if v.BlockID.IsNil() {
+		v.BlockID = types.BlockID{
+			Hash:          r.randomHash(),
+			PartSetHeader: types.PartSetHeader{Total: 1, Hash: r.randomHash()},
+		}
+	} else {
+		v.BlockID = types.BlockID{}
+		v.Extension = nil
+		v.ExtensionSignature = nil
+	}
+
+	signer, ok := r.signers[string(v.ValidatorAddress)]
+	if !ok {
+		return v
+	}
+	pbv := v.ToProto()
+	if err := signer.SignVote(context.Background(), r.chainID, pbv); err != nil {
+		return v
+	}
+	v.Signature = pbv.Signature
+	v.ExtensionSignature = pbv.ExtensionSignature
+	r.stats.SignedByzantineVotes++
+	return v
+}
+
+func (r *run) randomHash() []byte {
+	hash := make([]byte, crypto.HashSize)
+	_, _ = r.rng.Read(hash)
+	return hash
+}
+
+// deliverUntil appends the delayed messages received up to t, or all of them
+// if t is zero, to the output.
+func (r *run) deliverUntil(t time.Time) {
+	for r.pending.Len() > 0 {
+		next := r.pending[0]
+		if !t.IsZero() && next.msg.Time.After(t) {
+			return
+		}
+		heap.Pop(&r.pending)
+		r.out = append(r.out, next.msg)
+	}
+}
+
+type delayedMessage struct {
+	msg *consensus.TimedWALMessage
+	seq int
+}
+
+// delayedMessages is a heap of delayed messages, ordered by the time at which
+// they are received and then by their order in the trace.
+type delayedMessages []*delayedMessage
+
+func (d delayedMessages) Len() int { return len(d) }
+func (d delayedMessages) Less(i, j int) bool {
+	if d[i].msg.Time.Equal(d[j].msg.Time) {
+		return d[i].seq < d[j].seq
+	}
+	return d[i].msg.Time.Before(d[j].msg.Time)
+}
+func (d delayedMessages) Swap(i, j int)       { d[i], d[j] = d[j], d[i] }
+func (d *delayedMessages) Push(x interface{}) { *d = append(*d, x.(*delayedMessage)) }
+func (d *delayedMessages) Pop() interface{} {
+	old := *d
+	n := len(old)
+	x := old[n-1]
+	*d = old[:n-1]
+	return x
+}
@@ -0,0 +1,253 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/internal/evidence"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+var peers = []types.NodeID{
+	"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+	"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+	"cccccccccccccccccccccccccccccccccccccccc",
+}
+
+// makeTrace returns a trace of the given number of heights, with a prevote
+// for nil of each peer at each height.
+func makeTrace(heights int) Trace {
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	var trace Trace
+	add := func(msg consensus.WALMessage) {
+		trace = append(trace, &consensus.TimedWALMessage{
+			Time: start.Add(time.Duration(len(trace)) * time.Second),
+			Msg:  msg,
+		})
+	}
+	for h := int64(1); h <= int64(heights); h++ {
+		add(types.EventDataRoundState{Height: h, Step: "RoundStepPrevote"})
+		for i, peerID := range peers {
+			add(consensus.NewMsgInfoWALMessage(&consensus.VoteMessage{Vote: &types.Vote{
+				Type:             tmproto.PrevoteType,
+				Height:           h,
+				ValidatorAddress: bytes.Repeat([]byte{byte(i)}, 20),
+				ValidatorIndex:   int32(i),
+				Timestamp:        start,
+				Signature:        []byte("signature"),
+			}}, peerID))
+		}
+		add(consensus.EndHeightMessage{Height: h})
+	}
+	return trace
+}
+
+func votes(t *testing.T, trace Trace) []*types.Vote {
+	var votes []*types.Vote
+	for _, msg := range trace {
+		m, _, ok := consensus.MsgInfoFromWALMessage(msg.Msg)
+		if ok {
+			vm, ok := m.(*consensus.VoteMessage)
+			require.True(t, ok)
+			votes = append(votes, vm.Vote)
+		}
+	}
+	return votes
+}
+
+func TestNetworkNoFaults(t *testing.T) {
+	trace := makeTrace(3)
+	n, err := NewNetwork(1, Faults{})
+	require.NoError(t, err)
+	out, stats := n.Run(trace)
+	assert.Equal(t, trace, out)
+	assert.Equal(t, Stats{Messages: 9}, stats)
+}
+
+func TestNetworkDeterministic(t *testing.T) {
+	trace := makeTrace(10)
+	faults := Faults{DropRate: 0.3, MaxDelay: 3 * time.Second, ByzantineVoteRate: 0.3}
+	n, err := NewNetwork(42, faults)
+	require.NoError(t, err)
+	out1, stats1 := n.Run(trace)
+	out2, stats2 := n.Run(trace)
+	assert.Equal(t, out1, out2)
+	assert.Equal(t, stats1, stats2)
+	assert.Positive(t, stats1.Dropped)
+	assert.Positive(t, stats1.Delayed)
+	assert.Positive(t, stats1.ByzantineVotes)
+	assert.Len(t, votes(t, out1), stats1.Messages-stats1.Dropped+stats1.ByzantineVotes)
+
+	n, err = NewNetwork(43, faults)
+	require.NoError(t, err)
+	out3, _ := n.Run(trace)
+	assert.NotEqual(t, out1, out3)
+}
+
+func TestNetworkDropAll(t *testing.T) {
+	n, err := NewNetwork(1, Faults{DropRate: 1})
+	require.NoError(t, err)
+	out, stats := n.Run(makeTrace(2))
+	assert.Empty(t, votes(t, out))
+	assert.Len(t, out, 4, "steps and end heights are kept")
+	assert.Equal(t, Stats{Messages: 6, Dropped: 6}, stats)
+}
+
+func TestNetworkDelay(t *testing.T) {
+	trace := makeTrace(5)
+	n, err := NewNetwork(1, Faults{MaxDelay: 10 * time.Second})
+	require.NoError(t, err)
+	out, stats := n.Run(trace)
+	require.Len(t, out, len(trace))
+	assert.Positive(t, stats.Delayed)
+
+	// the local messages keep their order, and messages are received in order
+	// of time
+	var local Trace
+	for i, msg := range out {
+		if _, _, ok := consensus.MsgInfoFromWALMessage(msg.Msg); !ok {
+			local = append(local, msg)
+		}
+		if i > 0 {
+			assert.False(t, msg.Time.Before(out[i-1].Time), i)
+		}
+	}
+	assert.Len(t, local, 10)
+}
+
+func TestNetworkByzantineVotes(t *testing.T) {
+	n, err := NewNetwork(1, Faults{ByzantineVoteRate: 1})
+	require.NoError(t, err)
+	out, stats := n.Run(makeTrace(1))
+	assert.Equal(t, 3, stats.ByzantineVotes)
+
+	vs := votes(t, out)
+	require.Len(t, vs, 6)
+	for i := 0; i < len(vs); i += 2 {
+		vote, conflicting := vs[i], vs[i+1]
+		assert.True(t, vote.BlockID.IsNil())
+		assert.False(t, conflicting.BlockID.IsNil())
+		assert.Equal(t, vote.ValidatorAddress, conflicting.ValidatorAddress)
+		assert.Equal(t, vote.Height, conflicting.Height)
+		assert.Equal(t, vote.Type, conflicting.Type)
+	}
+}
+
+func TestNetworkSignedByzantineVotes(t *testing.T) {
+	const chainID = "simulation-chain"
+	ctx := context.Background()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	valSet, privVals := factory.ValidatorSet(ctx, t, 3, 10)
+	keys := make([]crypto.PrivKey, 0, 2)
+	for _, pv := range privVals[:2] {
+		keys = append(keys, pv.(types.MockPV).PrivKey)
+	}
+
+	var trace Trace
+	for _, typ := range []tmproto.SignedMsgType{tmproto.PrevoteType, tmproto.PrecommitType} {
+		for i, pv := range privVals {
+			pubKey, err := pv.GetPubKey(ctx)
+			require.NoError(t, err)
+			idx, _ := valSet.GetByAddress(pubKey.Address())
+			vote := &types.Vote{
+				Type:             typ,
+				Height:           1,
+				ValidatorAddress: pubKey.Address(),
+				ValidatorIndex:   idx,
+				Timestamp:        start,
+			}
+			pbv := vote.ToProto()
+			require.NoError(t, pv.SignVote(ctx, chainID, pbv))
+			vote.Signature = pbv.Signature
+			trace = append(trace, &consensus.TimedWALMessage{
+				Time: start,
+				Msg:  consensus.NewMsgInfoWALMessage(&consensus.VoteMessage{Vote: vote}, peers[i]),
+			})
+		}
+	}
+
+	n, err := NewNetwork(1, Faults{ByzantineVoteRate: 1})
+	require.NoError(t, err)
+	n.SetKeys(chainID, keys...)
+	out, stats := n.Run(trace)
+	assert.Equal(t, 6, stats.ByzantineVotes)
+	assert.Equal(t, 4, stats.SignedByzantineVotes)
+
+	vs := votes(t, out)
+	require.Len(t, vs, 12)
+	for i := 0; i < len(vs); i += 2 {
+		vote, conflicting := vs[i], vs[i+1]
+		_, val := valSet.GetByAddress(vote.ValidatorAddress)
+		require.NotNil(t, val)
+
+		// the vote sets of consensus report the signed conflicting votes,
+		// which a State sends to the evidence pool
+		voteSet := types.NewVoteSet(chainID, 1, 0, vote.Type, valSet)
+		if vote.Type == tmproto.PrecommitType {
+			voteSet = types.NewExtendedVoteSet(chainID, 1, 0, vote.Type, valSet)
+		}
+		added, err := voteSet.AddVote(vote)
+		require.NoError(t, err)
+		require.True(t, added)
+		_, err = voteSet.AddVote(conflicting)
+		if !bytes.Equal(vote.ValidatorAddress, keys[0].PubKey().Address()) &&
+			!bytes.Equal(vote.ValidatorAddress, keys[1].PubKey().Address()) {
+			assert.Error(t, err, "the conflicting vote of a validator without a key is invalid")
+			assert.Equal(t, vote.Signature, conflicting.Signature)
+			continue
+		}
+		var conflict *types.ErrVoteConflictingVotes
+		require.ErrorAs(t, err, &conflict)
+
+		ev, err := types.NewDuplicateVoteEvidence(conflict.VoteA, conflict.VoteB, start, valSet)
+		require.NoError(t, err)
+		assert.NoError(t, evidence.VerifyDuplicateVote(ev, chainID, valSet))
+		if vote.Type == tmproto.PrecommitType {
+			assert.NoError(t, conflicting.VerifyExtension(chainID, val.PubKey))
+		}
+	}
+}
+
+func TestNetworkRotateIsolated(t *testing.T) {
+	n, err := NewNetwork(1, Faults{RotateIsolated: true})
+	require.NoError(t, err)
+	out, stats := n.Run(makeTrace(3))
+	assert.Equal(t, Stats{Messages: 9, Dropped: 3}, stats)
+
+	// a different validator is missing at each height
+	missing := make(map[int64]int32)
+	for h := int64(1); h <= 3; h++ {
+		missing[h] = 0 + 1 + 2
+	}
+	for _, vote := range votes(t, out) {
+		missing[vote.Height] -= vote.ValidatorIndex
+	}
+	assert.Equal(t, map[int64]int32{1: 1, 2: 2, 3: 0}, missing)
+}
+
+func TestFaultsValidate(t *testing.T) {
+	assert.NoError(t, Faults{DropRate: 1, ByzantineVoteRate: 1}.Validate())
+	assert.Error(t, Faults{DropRate: 1.5}.Validate())
+	assert.Error(t, Faults{MaxDelay: -time.Second}.Validate())
+	assert.Error(t, Faults{ByzantineVoteRate: -1}.Validate())
+}
+
+func TestTraceReadWrite(t *testing.T) {
+	trace := makeTrace(2)
+	var buf bytes.Buffer
+	require.NoError(t, trace.Write(&buf))
+	read, err := ReadTrace(&buf)
+	require.NoError(t, err)
+	require.Len(t, read, len(trace))
+	assert.Equal(t, votes(t, trace), votes(t, read))
+}
@@ -0,0 +1,129 @@
+// Package simapp provides the ABCI applications of the consensus simulations.
+// They wrap an application to make the chain run through the conditions the
+// simulations replay, and are deterministic, so that replaying the blocks of
+// a chain recorded with them rebuilds the same states.
+package simapp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// Rotating is an ABCI application rotating the validator set of the chain at
+// every height. At height h, it removes validator h mod n of its n validators
+// from the set and restores the one it removed at the previous height with
+// its power, through the validator updates of FinalizeBlock. The updates take
+// effect two heights later, as all validator updates do.
+//
+// Its validators are the genesis validators, in order, and the ones the
+// wrapped application adds. The validators the wrapped application updates at
+// a height keep the updates of the application, and the ones it removes are
+// no longer rotated: removing the validator removed at the previous height
+// only keeps it out of the set. A chain of a single validator is not rotated.
+type Rotating struct {
+	abci.Application
+
+	mtx     sync.Mutex
+	keys    []string
+	vals    map[string]abci.ValidatorUpdate
+	removed string
+}
+
+// NewRotating returns an application rotating the validator set of the chain
+// of app.
+func NewRotating(app abci.Application) *Rotating {
+	return &Rotating{Application: app, vals: make(map[string]abci.ValidatorUpdate)}
+}
+
+// InitChain records the genesis validators, or the ones the wrapped
+// application replaces them with.
+func (r *Rotating) InitChain(ctx context.Context, req *abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	res, err := r.Application.InitChain(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	vals := req.Validators
+	if len(res.Validators) > 0 {
+		vals = res.Validators
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.keys = nil
+	r.vals = make(map[string]abci.ValidatorUpdate, len(vals))
+	r.removed = ""
+	for _, val := range vals {
+		key := val.PubKey.String()
+		if _, ok := r.vals[key]; ok {
+			return nil, fmt.Errorf("duplicate genesis validator %v", val.PubKey)
+		}
+		r.keys = append(r.keys, key)
+		r.vals[key] = val
+	}
+	return res, nil
+}
+
+// FinalizeBlock adds the rotation of the block height to the validator
+// updates of the wrapped application.
+func (r *Rotating) FinalizeBlock(ctx context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+	res, err := r.Application.FinalizeBlock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	updated := make(map[string]bool, len(res.ValidatorUpdates))
+	updates := make([]abci.ValidatorUpdate, 0, len(res.ValidatorUpdates)+2)
+	for _, val := range res.ValidatorUpdates {
+		key := val.PubKey.String()
+		updated[key] = true
+		// the validator removed at the previous height is not in the set the
+		// updates apply to, so removing it again would fail
+		if key != r.removed || val.Power > 0 {
+			updates = append(updates, val)
+		}
+		_, ok := r.vals[key]
+		switch {
+		case val.Power > 0 && !ok:
+			r.keys = append(r.keys, key)
+			r.vals[key] = val
+		case val.Power > 0:
+			r.vals[key] = val
+		case ok:
+			r.drop(key)
+		}
+	}
+	res.ValidatorUpdates = updates
+
+	if _, ok := r.vals[r.removed]; ok && !updated[r.removed] {
+		res.ValidatorUpdates = append(res.ValidatorUpdates, r.vals[r.removed])
+	}
+	r.removed = ""
+	if len(r.keys) < 2 {
+		return res, nil
+	}
+
+	key := r.keys[req.Height%int64(len(r.keys))]
+	if !updated[key] {
+		res.ValidatorUpdates = append(res.ValidatorUpdates, abci.ValidatorUpdate{PubKey: r.vals[key].PubKey})
+		r.removed = key
+	}
+	return res, nil
+}
+
+// drop removes the validator with the given key from the rotation.
+func (r *Rotating) drop(key string) {
+	delete(r.vals, key)
+	for i, k := range r.keys {
+		if k == key {
+			r.keys = append(r.keys[:i], r.keys[i+1:]...)
+			return
+		}
+	}
+}
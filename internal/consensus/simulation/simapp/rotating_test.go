@@ -0,0 +1,100 @@
+package simapp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/types"
+)
+
+// updatesApp is an application returning the validator updates of its
+// heights.
+type updatesApp struct {
+	abci.BaseApplication
+	updates map[int64][]abci.ValidatorUpdate
+}
+
+func (app *updatesApp) FinalizeBlock(_ context.Context, req *abci.RequestFinalizeBlock) (*abci.ResponseFinalizeBlock, error) {
+	return &abci.ResponseFinalizeBlock{ValidatorUpdates: app.updates[req.Height]}, nil
+}
+
+func validatorUpdates(n int) []abci.ValidatorUpdate {
+	vals := make([]abci.ValidatorUpdate, n)
+	for i := range vals {
+		vals[i] = abci.Ed25519ValidatorUpdate(ed25519.GenPrivKey().PubKey().Bytes(), 10)
+	}
+	return vals
+}
+
+// run finalizes the given number of blocks and returns the validator sets of
+// the heights, applying the updates of each height two heights later.
+func run(t *testing.T, app *Rotating, genesis []abci.ValidatorUpdate, heights int64) map[int64]*types.ValidatorSet {
+	ctx := context.Background()
+	_, err := app.InitChain(ctx, &abci.RequestInitChain{Validators: genesis})
+	require.NoError(t, err)
+
+	vals, err := types.PB2TM.ValidatorUpdates(genesis)
+	require.NoError(t, err)
+	next := types.NewValidatorSet(vals)
+	sets := map[int64]*types.ValidatorSet{1: next, 2: next}
+	for h := int64(1); h <= heights; h++ {
+		res, err := app.FinalizeBlock(ctx, &abci.RequestFinalizeBlock{Height: h})
+		require.NoError(t, err)
+		changes, err := types.PB2TM.ValidatorUpdates(res.ValidatorUpdates)
+		require.NoError(t, err)
+		next = next.Copy()
+		require.NoError(t, next.UpdateWithChangeSet(changes), h)
+		sets[h+2] = next
+	}
+	return sets
+}
+
+func TestRotating(t *testing.T) {
+	genesis := validatorUpdates(4)
+	sets := run(t, NewRotating(abci.NewBaseApplication()), genesis, 8)
+
+	assert.Equal(t, 4, sets[2].Size())
+	for h := int64(3); h <= 10; h++ {
+		// the validator removed at h-2 is missing at h
+		require.Equal(t, 3, sets[h].Size(), h)
+		removed := genesis[(h-2)%4].PubKey
+		for i, val := range genesis {
+			pk, err := types.PB2TM.ValidatorUpdates([]abci.ValidatorUpdate{val})
+			require.NoError(t, err)
+			assert.Equal(t, val.PubKey.Equal(removed), !sets[h].HasAddress(pk[0].Address), "height %d validator %d", h, i)
+		}
+	}
+}
+
+func TestRotatingAppUpdates(t *testing.T) {
+	genesis := validatorUpdates(3)
+	added := validatorUpdates(1)[0]
+	app := &updatesApp{updates: map[int64][]abci.ValidatorUpdate{
+		// add a validator, and remove validator 1 as it would be restored
+		2: {added, {PubKey: genesis[1].PubKey}},
+	}}
+	sets := run(t, NewRotating(app), genesis, 6)
+
+	for h := int64(5); h <= 8; h++ {
+		// validator 1 is no longer rotated, so validators 0, 2 and the added one
+		// take turns
+		assert.Equal(t, 2, sets[h].Size(), h)
+		assert.False(t, sets[h].HasAddress(pubKeyAddress(t, genesis[1])), h)
+	}
+	assert.True(t, sets[5].HasAddress(pubKeyAddress(t, added)))
+
+	// a single validator is never removed
+	sets = run(t, NewRotating(abci.NewBaseApplication()), genesis[:1], 4)
+	assert.Equal(t, 1, sets[6].Size())
+}
+
+func pubKeyAddress(t *testing.T, val abci.ValidatorUpdate) []byte {
+	vals, err := types.PB2TM.ValidatorUpdates([]abci.ValidatorUpdate{val})
+	require.NoError(t, err)
+	return vals[0].Address
+}
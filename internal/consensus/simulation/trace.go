@@ -0,0 +1,51 @@
+// Package simulation replays recorded consensus message traces through a
+// simulated network, with deterministic fault injection. A trace is the
+// sequence of messages of the WAL of a node, and the output of the network is
+// the trace of the messages as the node would have received them.
+//
+// The package only rewrites traces: it does not run any consensus State. The
+// rewritten traces are meant to be replayed by a State, e.g. with the replay
+// and replay-console commands, which are the ones checking how consensus
+// handles the faults. The traces of a chain whose validator set changes at
+// every height are recorded from nodes running the simapp.Rotating
+// application, e.g. as the rotating_kvstore proxy app, with which they are
+// replayed.
+package simulation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tendermint/tendermint/internal/consensus"
+)
+
+// Trace is a sequence of consensus WAL messages, in the order in which they
+// were handled by a node.
+type Trace []*consensus.TimedWALMessage
+
+// ReadTrace reads the trace of the WAL messages of r, up to its end.
+func ReadTrace(r io.Reader) (Trace, error) {
+	dec := consensus.NewWALDecoder(r)
+	var trace Trace
+	for {
+		msg, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return trace, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("reading message %d: %w", len(trace), err)
+		}
+		trace = append(trace, msg)
+	}
+}
+
+// Write writes the messages of the trace to w, in the WAL encoding.
+func (t Trace) Write(w io.Writer) error {
+	enc := consensus.NewWALEncoder(w)
+	for i, msg := range t {
+		if err := enc.Encode(msg); err != nil {
+			return fmt.Errorf("writing message %d: %w", i, err)
+		}
+	}
+	return nil
+}
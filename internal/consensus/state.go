@@ -23,10 +23,10 @@ import (
 	"github.com/tendermint/tendermint/internal/libs/autofile"
 	tmstrings "github.com/tendermint/tendermint/internal/libs/strings"
 	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/trace"
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
-	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 	"github.com/tendermint/tendermint/privval"
@@ -171,6 +171,8 @@ type State struct {
 	decideProposal func(ctx context.Context, height int64, round int32)
 	doPrevote      func(ctx context.Context, height int64, round int32)
 	setProposal    func(proposal *types.Proposal, t time.Time) error
+	// called after each step of finalizeCommit, to simulate crashes
+	commitStepHook func(height int64, step commitStep)
 
 	// synchronous pubsub between consensus state and reactor.
 	// state only emits EventNewRoundStep, EventValidBlock, and EventVote
@@ -181,6 +183,21 @@ type State struct {
 
 	// wait the channel event happening for shutting down the state gracefully
 	onStopCh chan *cstypes.RoundState
+
+	// spans of the consensus steps and of the work done in them, and the span
+	// of the current step, or nil
+	tracer   *trace.Tracer
+	stepSpan *trace.Span
+
+	// the record of the halt of consensus, the function called on it, and
+	// the plan of a halt
+	haltMtx    sync.Mutex
+	haltRecord *HaltRecord
+	onHalt     func(HaltRecord)
+	haltPlan   HaltPlan
+
+	// paused is set while the node block syncs after falling behind its peers
+	paused bool
 }
 
 // StateOption sets an optional parameter on the State.
@@ -208,6 +225,7 @@ func NewState(
 		eventBus:         eventBus,
 		logger:           logger,
 		config:           cfg,
+		haltPlan:         NewHaltPlan(cfg),
 		blockExec:        blockExec,
 		blockStore:       blockStore,
 		stateStore:       store,
@@ -221,6 +239,7 @@ func NewState(
 		evpool:           evpool,
 		evsw:             tmevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		tracer:           trace.NopTracer(),
 		onStopCh:         make(chan *cstypes.RoundState),
 	}
 
@@ -280,6 +299,19 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StateTracer sets the tracer of the consensus steps, and of the work done in
+// them, such as ABCI calls.
+func StateTracer(tracer *trace.Tracer) StateOption {
+	return func(cs *State) { cs.tracer = tracer }
+}
+
+// StateOnHalt sets the function called when consensus halts on a consistency
+// violation, unless the config keeps RPC serving on halts. Without it, the
+// state panics on halts.
+func StateOnHalt(onHalt func(HaltRecord)) StateOption {
+	return func(cs *State) { cs.onHalt = onHalt }
+}
+
 // String returns a string.
 func (cs *State) String() string {
 	// better not to access shared variables
@@ -327,6 +359,13 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return json.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// GetRoundVotesJSON returns a json of the RoundVotes of the current round.
+func (cs *State) GetRoundVotesJSON() ([]byte, error) {
+	cs.mtx.RLock()
+	defer cs.mtx.RUnlock()
+	return json.Marshal(cs.RoundState.RoundVotes())
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -344,7 +383,7 @@ func (cs *State) SetPrivValidator(ctx context.Context, priv types.PrivValidator)
 
 	if priv != nil {
 		switch t := priv.(type) {
-		case *privval.RetrySignerClient:
+		case *privval.RetrySignerClient, *privval.SignStateChecker:
 			cs.privValidatorType = types.RetrySignerClient
 		case *privval.FilePV:
 			cs.privValidatorType = types.FileSignerClient
@@ -400,6 +439,11 @@ func (cs *State) OnStart(ctx context.Context) error {
 	if err := cs.updateStateFromStore(); err != nil {
 		return err
 	}
+	plan := cs.GetHaltPlan()
+	if cs.state.LastBlockHeight > 0 && plan.Reached(cs.state.LastBlockHeight, cs.state.LastBlockTime) {
+		return fmt.Errorf("the block at height %d is committed past the planned halt at height %d and time %v; "+
+			"unset consensus.halt-height and consensus.halt-time to continue", cs.state.LastBlockHeight, plan.Height, plan.Time)
+	}
 
 	// We may set the WAL in testing before calling Start, so only OpenWAL if its
 	// still the nilWAL.
@@ -445,22 +489,12 @@ func (cs *State) OnStart(ctx context.Context) error {
 
 			repairAttempted = true
 
-			// 2) backup original WAL file
-			corruptedFile := fmt.Sprintf("%s.CORRUPTED", cs.config.WalFile())
-			if err := tmos.CopyFile(cs.config.WalFile(), corruptedFile); err != nil {
-				return err
-			}
-
-			cs.logger.Debug("backed up WAL file", "src", cs.config.WalFile(), "dst", corruptedFile)
-
-			// 3) try to repair (WAL file will be overwritten!)
-			if err := repairWalFile(corruptedFile, cs.config.WalFile()); err != nil {
+			// 2) back up the WAL file and truncate it at the corrupt record
+			if err := cs.repairWALFile(); err != nil {
 				cs.logger.Error("the WAL repair failed", "err", err)
 				return err
 			}
 
-			cs.logger.Info("successful WAL repair")
-
 			// reload WAL file
 			if err := cs.loadWalFile(ctx); err != nil {
 				return err
@@ -499,6 +533,13 @@ func (cs *State) startRoutines(ctx context.Context, maxSteps int) {
 
 // loadWalFile loads WAL data from file. It overwrites cs.wal.
 func (cs *State) loadWalFile(ctx context.Context) error {
+	if cs.config.WalRepair {
+		if err := cs.repairWALFile(); err != nil {
+			cs.logger.Error("the WAL repair failed", "err", err)
+			return err
+		}
+	}
+
 	wal, err := cs.OpenWAL(ctx, cs.config.WalFile())
 	if err != nil {
 		cs.logger.Error("failed to load state WAL", "err", err)
@@ -660,11 +701,28 @@ func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
 		if cs.Step != step {
 			cs.metrics.MarkStep(cs.Step)
 		}
+		if round != cs.Round || step != cs.Step || cs.stepSpan == nil {
+			cs.stepSpan.End()
+			_, cs.stepSpan = cs.tracer.Start(context.Background(), "consensus.step",
+				trace.Int64("height", cs.Height),
+				trace.Int64("round", int64(round)),
+				trace.String("step", step.String()))
+		}
 	}
 	cs.Round = round
 	cs.Step = step
 }
 
+// startSpan starts a span of the work done in a step of the given height and
+// round, with a context holding it. The span is not a child of the span of
+// the step it's entered from, if any. It starts none during replay.
+func (cs *State) startSpan(ctx context.Context, name string, height int64, round int32) (context.Context, *trace.Span) {
+	if cs.replayMode {
+		return ctx, nil
+	}
+	return cs.tracer.Start(trace.ContextWithoutSpan(ctx), name, trace.Int64("height", height), trace.Int64("round", int64(round)))
+}
+
 // enterNewRound(height, 0) at cs.StartTime.
 func (cs *State) scheduleRound0(rs *cstypes.RoundState) {
 	// cs.logger.Info("scheduleRound0", "now", tmtime.Now(), "startTime", cs.StartTime)
@@ -933,6 +991,25 @@ func (cs *State) receiveRoutine(ctx context.Context, maxSteps int) {
 				}
 			}
 
+			rs := cs.GetRoundState()
+			rec := newHaltRecord(r, rs.Height, rs.Round)
+			cs.recordHalt(rec)
+			cs.logger.Error("consensus halted", "code", rec.Code, "height", rec.Height, "round", rec.Round,
+				"reason", rec.Reason, "halt_file", cs.config.HaltFile())
+
+			// A planned halt stops the node, for its binary to be upgraded,
+			// even if it keeps serving RPC on violations.
+			if cs.config.KeepRPCOnHalt && !rec.Planned() {
+				return
+			}
+			if cs.onHalt != nil {
+				cs.onHalt(*rec)
+				return
+			}
+			if rec.Planned() {
+				return
+			}
+
 			// Re-panic to ensure the node terminates.
 			//
 			panic(r)
@@ -963,10 +1040,10 @@ func (cs *State) receiveRoutine(ctx context.Context, maxSteps int) {
 		case mi := <-cs.internalMsgQueue:
 			err := cs.wal.WriteSync(mi) // NOTE: fsync
 			if err != nil {
-				panic(fmt.Errorf(
+				halt(HaltCodeWAL,
 					"failed to write %v msg to consensus WAL due to %w; check your file system and restart the node",
 					mi, err,
-				))
+				)
 			}
 
 			// handles proposals, block parts, votes
@@ -994,6 +1071,9 @@ func (cs *State) receiveRoutine(ctx context.Context, maxSteps int) {
 func (cs *State) handleMsg(ctx context.Context, mi msgInfo) {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	if cs.paused {
+		return
+	}
 	var (
 		added bool
 		err   error
@@ -1102,6 +1182,9 @@ func (cs *State) handleTimeout(
 	// the timeout will now cause a state transition
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	if cs.paused {
+		return
+	}
 
 	switch ti.Step {
 	case cstypes.RoundStepNewHeight:
@@ -1143,6 +1226,9 @@ func (cs *State) handleTimeout(
 func (cs *State) handleTxsAvailable(ctx context.Context) {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
+	if cs.paused {
+		return
+	}
 
 	// We only need to do this for round 0.
 	if cs.Round != 0 {
@@ -1296,6 +1382,9 @@ func (cs *State) enterPropose(ctx context.Context, height int64, round int32) {
 		"round", cs.Round,
 		"step", cs.Step)
 
+	ctx, span := cs.startSpan(ctx, "consensus.propose", height, round)
+	defer span.End()
+
 	defer func() {
 		// Done enterPropose:
 		cs.updateRoundStep(round, cstypes.RoundStepPropose)
@@ -1486,6 +1575,9 @@ func (cs *State) enterPrevote(ctx context.Context, height int64, round int32) {
 		return
 	}
 
+	ctx, span := cs.startSpan(ctx, "consensus.prevote", height, round)
+	defer span.End()
+
 	defer func() {
 		// Done enterPrevote:
 		cs.updateRoundStep(round, cstypes.RoundStepPrevote)
@@ -1696,6 +1788,9 @@ func (cs *State) enterPrecommit(ctx context.Context, height int64, round int32)
 		"round", cs.Round,
 		"step", cs.Step)
 
+	ctx, span := cs.startSpan(ctx, "consensus.precommit", height, round)
+	defer span.End()
+
 	defer func() {
 		// Done enterPrecommit:
 		cs.updateRoundStep(round, cstypes.RoundStepPrecommit)
@@ -1771,7 +1866,7 @@ func (cs *State) enterPrecommit(ctx context.Context, height int64, round int32)
 
 		// Validate the block.
 		if err := cs.blockExec.ValidateBlock(ctx, cs.state, cs.ProposalBlock); err != nil {
-			panic(fmt.Sprintf("precommit step: +2/3 prevoted for an invalid block %v; relocking", err))
+			halt(HaltCodeInvalidBlock, "precommit step: +2/3 prevoted for an invalid block %v; relocking", err)
 		}
 
 		cs.LockedRound = round
@@ -1939,23 +2034,26 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 		return
 	}
 
+	ctx, span := cs.startSpan(ctx, "consensus.finalize_commit", height, cs.CommitRound)
+	defer span.End()
+
 	cs.calculatePrevoteMessageDelayMetrics()
 
 	blockID, ok := cs.Votes.Precommits(cs.CommitRound).TwoThirdsMajority()
 	block, blockParts := cs.ProposalBlock, cs.ProposalBlockParts
 
 	if !ok {
-		panic("cannot finalize commit; commit does not have 2/3 majority")
+		halt(HaltCodeConflictingCommit, "cannot finalize commit; commit does not have 2/3 majority")
 	}
 	if !blockParts.HasHeader(blockID.PartSetHeader) {
-		panic("expected ProposalBlockParts header to be commit header")
+		halt(HaltCodeConflictingCommit, "expected ProposalBlockParts header to be commit header")
 	}
 	if !block.HashesTo(blockID.Hash) {
-		panic("cannot finalize commit; proposal block does not hash to commit hash")
+		halt(HaltCodeConflictingCommit, "cannot finalize commit; proposal block does not hash to commit hash")
 	}
 
 	if err := cs.blockExec.ValidateBlock(ctx, cs.state, block); err != nil {
-		panic(fmt.Errorf("+2/3 committed an invalid block: %w", err))
+		halt(HaltCodeInvalidBlock, "+2/3 committed an invalid block: %w", err)
 	}
 
 	logger.Info(
@@ -1965,6 +2063,15 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 		"num_txs", len(block.Txs),
 	)
 
+	// Mark the commit of the block as in progress until the state of its
+	// height is saved, for the handshake to recover from a crash in between:
+	// the block store, the WAL and the state store are written in turn, and no
+	// write spans them.
+	if err := cs.stateStore.SaveCommitMarker(height); err != nil {
+		halt(HaltCodeInternal, "failed to save the commit marker: %w", err)
+	}
+	cs.commitStep(height, commitStepMarked)
+
 	// Save to blockStore.
 	if cs.blockStore.Height() < block.Height {
 		// NOTE: the seenCommit is local justification to commit this block,
@@ -1979,6 +2086,7 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 		// Happens during replay if we already saved the block but didn't commit
 		logger.Debug("calling finalizeCommit on already stored block", "height", block.Height)
 	}
+	cs.commitStep(height, commitStepBlockSaved)
 
 	// Write EndHeightMessage{} for this height, implying that the blockstore
 	// has saved the block.
@@ -1995,11 +2103,12 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 	// restart).
 	endMsg := EndHeightMessage{height}
 	if err := cs.wal.WriteSync(endMsg); err != nil { // NOTE: fsync
-		panic(fmt.Errorf(
+		halt(HaltCodeWAL,
 			"failed to write %v msg to consensus WAL due to %w; check your file system and restart the node",
 			endMsg, err,
-		))
+		)
 	}
+	cs.commitStep(height, commitStepEndHeight)
 
 	// Create a copy of the state for staging and an event cache for txs.
 	stateCopy := cs.state.Copy()
@@ -2018,12 +2127,14 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 		logger.Error("failed to apply block", "err", err)
 		return
 	}
+	cs.commitStep(height, commitStepApplied)
 
 	// must be called before we update state
 	cs.RecordMetrics(height, block)
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
+	cs.haltIfPlanned(height, block.Time)
 
 	// Private validator might have changed it's key pair => refetch pubkey.
 	if err := cs.updatePrivValidatorPubKey(ctx); err != nil {
@@ -2040,6 +2151,23 @@ func (cs *State) finalizeCommit(ctx context.Context, height int64) {
 	// * cs.StartTime is set to when we will start round0.
 }
 
+// commitStep is a step of finalizeCommit after which a crash leaves the stores
+// of the node partially updated.
+type commitStep int
+
+const (
+	commitStepMarked     commitStep = iota // the commit marker is saved
+	commitStepBlockSaved                   // the block is in the block store
+	commitStepEndHeight                    // the WAL has the EndHeightMessage
+	commitStepApplied                      // the state is saved, clearing the marker
+)
+
+func (cs *State) commitStep(height int64, step commitStep) {
+	if cs.commitStepHook != nil {
+		cs.commitStepHook(height, step)
+	}
+}
+
 func (cs *State) RecordMetrics(height int64, block *types.Block) {
 	cs.metrics.Validators.Set(float64(cs.Validators.Size()))
 	cs.metrics.ValidatorsPower.Set(float64(cs.Validators.TotalVotingPower()))
@@ -2235,7 +2363,7 @@ func (cs *State) addProposalBlockPart(
 			return added, err
 		}
 
-		block, err := types.BlockFromProto(pbb)
+		block, err := types.BlockFromProtoParallel(pbb, cs.blockExec.VerificationWorkers())
 		if err != nil {
 			return added, err
 		}
@@ -2578,6 +2706,12 @@ func (cs *State) signVote(
 			if err != nil {
 				return nil, err
 			}
+			// Peers reject precommits carrying oversized extensions, so
+			// refuse to sign one rather than broadcast an invalid vote.
+			if len(ext) > types.MaxVoteExtensionSize {
+				return nil, fmt.Errorf("vote extension of %d bytes returned by the application exceeds the maximum (%d)",
+					len(ext), types.MaxVoteExtensionSize)
+			}
 			vote.Extension = ext
 		}
 	}
@@ -2730,39 +2864,22 @@ func CompareHRS(h1 int64, r1 int32, s1 cstypes.RoundStepType, h2 int64, r2 int32
 	return 0
 }
 
-// repairWalFile decodes messages from src (until the decoder errors) and
-// writes them to dst.
-func repairWalFile(src, dst string) error {
-	in, err := os.Open(src)
-	if err != nil {
-		return err
+// repairWALFile truncates the WAL file at its first corrupt record, if any,
+// after backing it up. The WAL file may not exist yet.
+func (cs *State) repairWALFile() error {
+	walFile := cs.config.WalFile()
+	if _, err := os.Stat(walFile); errors.Is(err, os.ErrNotExist) {
+		return nil
 	}
-	defer in.Close()
-
-	out, err := os.Create(dst)
+	backup, info, err := RepairWALFile(walFile)
 	if err != nil {
 		return err
 	}
-	defer out.Close()
-
-	var (
-		dec = NewWALDecoder(in)
-		enc = NewWALEncoder(out)
-	)
-
-	// best-case repair (until first error is encountered)
-	for {
-		msg, err := dec.Decode()
-		if err != nil {
-			break
-		}
-
-		err = enc.Encode(msg)
-		if err != nil {
-			return fmt.Errorf("failed to encode msg: %w", err)
-		}
+	if backup != "" {
+		cs.logger.Error("repaired the corrupted WAL file",
+			"file", walFile, "backup", backup, "offset", info.CorruptOffset,
+			"dropped_bytes", info.Size-info.CorruptOffset, "err", info.CorruptErr)
 	}
-
 	return nil
 }
 
@@ -2799,7 +2916,7 @@ func (cs *State) voteTimeout(round int32) time.Duration {
 func (cs *State) commitTime(t time.Time) time.Time {
 	c := cs.state.ConsensusParams.Timeout.Commit
 	if cs.config.UnsafeCommitTimeoutOverride != 0 {
-		c = cs.config.UnsafeProposeTimeoutOverride
+		c = cs.config.UnsafeCommitTimeoutOverride
 	}
 	return t.Add(c)
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/tendermint/tendermint/libs/bits"
 	"github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/types"
 )
@@ -138,6 +139,80 @@ func (rs *RoundState) RoundStateSimple() RoundStateSimple {
 	}
 }
 
+// RoundVotes are the prevotes and precommits of each validator in the current
+// round, for use in RPC by monitoring tools to tell the silent validators.
+type RoundVotes struct {
+	Height            int64            `json:"height,string"`
+	Round             int32            `json:"round"`
+	Prevotes          *bits.BitArray   `json:"prevotes_bit_array"`
+	Precommits        *bits.BitArray   `json:"precommits_bit_array"`
+	Validators        []ValidatorVotes `json:"validators"`
+	MissingPrevotes   []bytes.HexBytes `json:"missing_prevotes"`
+	MissingPrecommits []bytes.HexBytes `json:"missing_precommits"`
+}
+
+// ValidatorVotes are the votes of a validator in a round, nil if missing.
+type ValidatorVotes struct {
+	Index       int32          `json:"index"`
+	Address     bytes.HexBytes `json:"address"`
+	VotingPower int64          `json:"voting_power,string"`
+	Prevote     *VoteSummary   `json:"prevote"`
+	Precommit   *VoteSummary   `json:"precommit"`
+}
+
+// VoteSummary is the block voted for, empty for nil, and the time of a vote.
+type VoteSummary struct {
+	BlockHash bytes.HexBytes `json:"block_hash"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// RoundVotes returns the votes of each validator in the current round.
+func (rs *RoundState) RoundVotes() RoundVotes {
+	prevotes := rs.Votes.Prevotes(rs.Round)
+	precommits := rs.Votes.Precommits(rs.Round)
+	size := rs.Validators.Size()
+
+	votes := RoundVotes{
+		Height:            rs.Height,
+		Round:             rs.Round,
+		Prevotes:          prevotes.BitArray(),
+		Precommits:        precommits.BitArray(),
+		Validators:        make([]ValidatorVotes, size),
+		MissingPrevotes:   []bytes.HexBytes{},
+		MissingPrecommits: []bytes.HexBytes{},
+	}
+	if votes.Prevotes == nil {
+		votes.Prevotes = bits.NewBitArray(size)
+	}
+	if votes.Precommits == nil {
+		votes.Precommits = bits.NewBitArray(size)
+	}
+	for i, val := range rs.Validators.Validators {
+		idx := int32(i)
+		votes.Validators[i] = ValidatorVotes{
+			Index:       idx,
+			Address:     val.Address,
+			VotingPower: val.VotingPower,
+			Prevote:     summarizeVote(prevotes.GetByIndex(idx)),
+			Precommit:   summarizeVote(precommits.GetByIndex(idx)),
+		}
+		if votes.Validators[i].Prevote == nil {
+			votes.MissingPrevotes = append(votes.MissingPrevotes, val.Address)
+		}
+		if votes.Validators[i].Precommit == nil {
+			votes.MissingPrecommits = append(votes.MissingPrecommits, val.Address)
+		}
+	}
+	return votes
+}
+
+func summarizeVote(vote *types.Vote) *VoteSummary {
+	if vote == nil {
+		return nil
+	}
+	return &VoteSummary{BlockHash: vote.BlockID.Hash, Timestamp: vote.Timestamp}
+}
+
 // NewRoundEvent returns the RoundState with proposer information as an event.
 func (rs *RoundState) NewRoundEvent() types.EventDataNewRound {
 	addr := rs.Validators.GetProposer().Address
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/bytes"
+)
+
+func TestRoundVotes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const chainID = "round_state_test"
+	valSet, privVals := factory.ValidatorSet(ctx, t, 4, 1)
+	rs := RoundState{
+		Height:     1,
+		Validators: valSet,
+		Votes:      NewExtendedHeightVoteSet(chainID, 1, valSet),
+	}
+
+	votes := []int32{0, 2}
+	for _, idx := range votes {
+		added, err := rs.Votes.AddVote(makeVoteHR(ctx, t, 1, idx, 0, privVals, chainID), "peer")
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	roundVotes := rs.RoundVotes()
+	require.EqualValues(t, 1, roundVotes.Height)
+	require.Equal(t, "BA{4:____}", roundVotes.Prevotes.String())
+	require.Equal(t, "BA{4:x_x_}", roundVotes.Precommits.String())
+	require.Len(t, roundVotes.Validators, 4)
+	require.Len(t, roundVotes.MissingPrevotes, 4)
+	require.Equal(t, []bytes.HexBytes{valSet.Validators[1].Address, valSet.Validators[3].Address},
+		roundVotes.MissingPrecommits)
+
+	for _, idx := range votes {
+		val := roundVotes.Validators[idx]
+		require.Equal(t, valSet.Validators[idx].Address, val.Address)
+		require.Nil(t, val.Prevote)
+		require.NotNil(t, val.Precommit)
+		vote := rs.Votes.Precommits(0).GetByIndex(idx)
+		require.Equal(t, vote.BlockID.Hash, val.Precommit.BlockHash)
+		require.Equal(t, vote.Timestamp, val.Precommit.Timestamp)
+	}
+	require.Nil(t, roundVotes.Validators[1].Precommit)
+
+	// the votes of a round without votes are all missing
+	rs.Round = 1
+	roundVotes = rs.RoundVotes()
+	require.Equal(t, "BA{4:____}", roundVotes.Precommits.String())
+	require.Len(t, roundVotes.MissingPrecommits, 4)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/tendermint/tendermint/libs/service"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 	tmcons "github.com/tendermint/tendermint/proto/tendermint/consensus"
+	"github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -27,6 +28,14 @@ const (
 
 	// how often the WAL should be sync'd during period sync'ing
 	walDefaultFlushInterval = 2 * time.Second
+
+	// walRecordV2 is the format version of the records written by the
+	// WALEncoder, stored in the top byte of their length field. The top byte
+	// is always zero in the version 1 records, which the WALDecoder still
+	// reads.
+	walRecordV2 = 2
+	// walLengthMask masks the version out of the length field of a record.
+	walLengthMask = 1<<24 - 1
 )
 
 //--------------------------------------------------------
@@ -48,6 +57,24 @@ func (EndHeightMessage) TypeTag() string { return "tendermint/wal/EndHeightMessa
 
 type WALMessage interface{}
 
+// NewMsgInfoWALMessage returns the WAL message recording the handling of msg,
+// received from the peer with the given ID, or sent by the local node if the
+// ID is empty.
+func NewMsgInfoWALMessage(msg Message, peerID types.NodeID) WALMessage {
+	return msgInfo{Msg: msg, PeerID: peerID}
+}
+
+// MsgInfoFromWALMessage returns the consensus message recorded by msg, and the
+// ID of the peer it was received from. ok is false if msg records something
+// else, such as a timeout or a new step.
+func MsgInfoFromWALMessage(msg WALMessage) (m Message, peerID types.NodeID, ok bool) {
+	mi, ok := msg.(msgInfo)
+	if !ok {
+		return nil, "", false
+	}
+	return mi.Msg, mi.PeerID, true
+}
+
 func init() {
 	jsontypes.MustRegister(msgInfo{})
 	jsontypes.MustRegister(timeoutInfo{})
@@ -129,6 +156,13 @@ func (wal *BaseWAL) OnStart(ctx context.Context) error {
 		if err := wal.WriteSync(EndHeightMessage{0}); err != nil {
 			return err
 		}
+	} else if wal.group.Buffered() == 0 {
+		// chain the new records to the last one of the WAL
+		info, err := InspectWALFile(wal.group.Head.Path)
+		if err != nil {
+			return err
+		}
+		wal.enc.prevCRC = info.lastCRC
 	}
 	err = wal.group.Start(ctx)
 	if err != nil {
@@ -287,14 +321,23 @@ func (wal *BaseWAL) SearchForEndHeight(
 
 // A WALEncoder writes custom-encoded WAL messages to an output stream.
 //
-// Format: 4 bytes CRC sum + 4 bytes length + arbitrary-length value
+// Format: 4 bytes CRC sum + 4 bytes version and length + 4 bytes CRC sum of
+// the previous record + arbitrary-length value. The version is the top byte of
+// the length field. The CRC sum covers everything but itself, chaining each
+// record to the previous one, so that a record lost or garbled in between is
+// detected. A record with a zero previous CRC sum starts a new chain.
+//
+// Version 1 records, written before, have a zero version and no previous CRC
+// sum, and their CRC sum only covers the value.
 type WALEncoder struct {
-	wr io.Writer
+	wr      io.Writer
+	prevCRC uint32
 }
 
-// NewWALEncoder returns a new encoder that writes to wr.
+// NewWALEncoder returns a new encoder that writes to wr, starting a new chain
+// of records.
 func NewWALEncoder(wr io.Writer) *WALEncoder {
-	return &WALEncoder{wr}
+	return &WALEncoder{wr: wr}
 }
 
 // Encode writes the custom encoding of v to the stream. It returns an error if
@@ -315,20 +358,24 @@ func (enc *WALEncoder) Encode(v *TimedWALMessage) error {
 		panic(fmt.Errorf("encode timed wall message failure: %w", err))
 	}
 
-	crc := crc32.Checksum(data, crc32c)
 	length := uint32(len(data))
 	if length > maxMsgSizeBytes {
 		return fmt.Errorf("msg is too big: %d bytes, max: %d bytes", length, maxMsgSizeBytes)
 	}
-	totalLength := 8 + int(length)
+	totalLength := 12 + int(length)
 
 	msg := make([]byte, totalLength)
+	binary.BigEndian.PutUint32(msg[4:8], walRecordV2<<24|length)
+	binary.BigEndian.PutUint32(msg[8:12], enc.prevCRC)
+	copy(msg[12:], data)
+	crc := crc32.Checksum(msg[4:], crc32c)
 	binary.BigEndian.PutUint32(msg[0:4], crc)
-	binary.BigEndian.PutUint32(msg[4:8], length)
-	copy(msg[8:], data)
 
-	_, err = enc.wr.Write(msg)
-	return err
+	if _, err := enc.wr.Write(msg); err != nil {
+		return err
+	}
+	enc.prevCRC = crc
+	return nil
 }
 
 // IsDataCorruptionError returns true if data has been corrupted inside WAL.
@@ -353,37 +400,47 @@ func (e DataCorruptionError) Cause() error {
 // A WALDecoder reads and decodes custom-encoded WAL messages from an input
 // stream. See WALEncoder for the format used.
 //
-// It will also compare the checksums and make sure data size is equal to the
-// length from the header. If that is not the case, error will be returned.
+// It will also compare the checksums, make sure data size is equal to the
+// length from the header, and that each record follows the previous one. If
+// that is not the case, error will be returned. The first record read isn't
+// checked to follow another, so that the decoder may start in the middle of a
+// stream.
 type WALDecoder struct {
 	rd io.Reader
+
+	// the CRC sum of the previous version 2 record, if chained
+	prevCRC uint32
+	chained bool
 }
 
 // NewWALDecoder returns a new decoder that reads from rd.
 func NewWALDecoder(rd io.Reader) *WALDecoder {
-	return &WALDecoder{rd}
+	return &WALDecoder{rd: rd}
 }
 
 // Decode reads the next custom-encoded value from its reader and returns it.
 func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
-	b := make([]byte, 4)
+	header := make([]byte, 12)
 
-	_, err := dec.rd.Read(b)
+	_, err := io.ReadFull(dec.rd, header[0:4])
 	if errors.Is(err, io.EOF) {
 		return nil, err
 	}
 	if err != nil {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read checksum: %w", err)}
 	}
-	crc := binary.BigEndian.Uint32(b)
+	crc := binary.BigEndian.Uint32(header[0:4])
 
-	b = make([]byte, 4)
-	_, err = dec.rd.Read(b)
+	_, err = io.ReadFull(dec.rd, header[4:8])
 	if err != nil {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read length: %w", err)}
 	}
-	length := binary.BigEndian.Uint32(b)
+	lengthField := binary.BigEndian.Uint32(header[4:8])
+	version, length := lengthField>>24, lengthField&walLengthMask
 
+	if version != 0 && version != walRecordV2 {
+		return nil, DataCorruptionError{fmt.Errorf("unknown record version %d", version)}
+	}
 	if length > maxMsgSizeBytes {
 		return nil, DataCorruptionError{fmt.Errorf(
 			"length %d exceeded maximum possible value of %d bytes",
@@ -391,18 +448,40 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 			maxMsgSizeBytes)}
 	}
 
+	var prevCRC uint32
+	if version == walRecordV2 {
+		if _, err := io.ReadFull(dec.rd, header[8:12]); err != nil {
+			return nil, DataCorruptionError{fmt.Errorf("failed to read previous checksum: %w", err)}
+		}
+		prevCRC = binary.BigEndian.Uint32(header[8:12])
+	}
+
 	data := make([]byte, length)
-	n, err := dec.rd.Read(data)
+	n, err := io.ReadFull(dec.rd, data)
 	if err != nil {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read data: %v (read: %d, wanted: %d)", err, n, length)}
 	}
 
 	// check checksum before decoding data
-	actualCRC := crc32.Checksum(data, crc32c)
+	var actualCRC uint32
+	if version == walRecordV2 {
+		actualCRC = crc32.Update(crc32.Checksum(header[4:12], crc32c), crc32c, data)
+	} else {
+		actualCRC = crc32.Checksum(data, crc32c)
+	}
 	if actualCRC != crc {
+		dec.chained = false
 		return nil, DataCorruptionError{fmt.Errorf("checksums do not match: read: %v, actual: %v", crc, actualCRC)}
 	}
 
+	// check the record follows the previous one
+	wasChained, lastCRC := dec.chained, dec.prevCRC
+	dec.prevCRC, dec.chained = crc, version == walRecordV2
+	if version == walRecordV2 && wasChained && prevCRC != 0 && prevCRC != lastCRC {
+		return nil, DataCorruptionError{fmt.Errorf(
+			"record doesn't follow the previous one: previous checksum: read: %v, actual: %v", prevCRC, lastCRC)}
+	}
+
 	var res = new(tmcons.TimedWALMessage)
 	err = proto.Unmarshal(data, res)
 	if err != nil {
@@ -0,0 +1,223 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	tmos "github.com/tendermint/tendermint/libs/os"
+)
+
+// WALFileInfo describes the records of a WAL file, up to the first corrupt
+// one.
+type WALFileInfo struct {
+	Path string
+	Size int64
+
+	// Records is the number of valid records, of which LegacyRecords are in
+	// the version 1 format.
+	Records       int
+	LegacyRecords int
+	// LastHeight is the height of the last EndHeightMessage, or -1 if none.
+	LastHeight int64
+
+	// CorruptOffset is the offset of the first corrupt record, or -1 if the
+	// file has none, in which case CorruptErr is nil.
+	CorruptOffset int64
+	CorruptErr    error
+
+	// the CRC sum of the last record, if of the version 2 format
+	lastCRC uint32
+}
+
+// Corrupted reports whether the file has a corrupt record.
+func (info *WALFileInfo) Corrupted() bool {
+	return info.CorruptOffset >= 0
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// InspectWALFile decodes the records of the WAL file at path until the first
+// corrupt one, if any. The file may be one of the rotated files of the WAL
+// group as well as its head.
+func InspectWALFile(path string) (*WALFileInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &WALFileInfo{
+		Path:          path,
+		Size:          stat.Size(),
+		LastHeight:    -1,
+		CorruptOffset: -1,
+	}
+	rd := &countingReader{rd: f}
+	dec := NewWALDecoder(rd)
+	for {
+		offset := rd.n
+		msg, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if IsDataCorruptionError(err) {
+			info.CorruptOffset, info.CorruptErr = offset, err
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		info.Records++
+		if !dec.chained {
+			info.LegacyRecords++
+		}
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			info.LastHeight = m.Height
+		}
+	}
+	if dec.chained && !info.Corrupted() {
+		info.lastCRC = dec.prevCRC
+	}
+	return info, nil
+}
+
+// RepairWALFile truncates the WAL file at path at its first corrupt record,
+// if any, after backing it up next to it. It returns the path of the backup,
+// or an empty string if the file had no corrupt record. The WAL must not be
+// open.
+func RepairWALFile(path string) (backup string, info *WALFileInfo, err error) {
+	info, err = InspectWALFile(path)
+	if err != nil || !info.Corrupted() {
+		return "", info, err
+	}
+
+	// the backup must not end with digits, which is how the rotated files of
+	// the WAL group are named
+	backup = fmt.Sprintf("%s.CORRUPTED-%s", path, time.Now().UTC().Format("20060102T150405Z"))
+	if err := tmos.CopyFile(path, backup); err != nil {
+		return "", info, fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := os.Truncate(path, info.CorruptOffset); err != nil {
+		return backup, info, fmt.Errorf("failed to truncate %s: %w", path, err)
+	}
+	return backup, info, nil
+}
+
+// walGroupFiles returns the paths of the files of the WAL group with the head
+// at path, oldest first: the rotated files, by index, and the head.
+func walGroupFiles(path string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	indexedFile := regexp.MustCompile(`^` + regexp.QuoteMeta(filepath.Base(path)) + `\.([0-9]{3,})$`)
+	indexes := map[int]string{}
+	for _, entry := range entries {
+		if m := indexedFile.FindStringSubmatch(entry.Name()); m != nil {
+			index, err := strconv.Atoi(m[1])
+			if err != nil {
+				return nil, err
+			}
+			indexes[index] = filepath.Join(filepath.Dir(path), entry.Name())
+		}
+	}
+	sorted := make([]int, 0, len(indexes))
+	for index := range indexes {
+		sorted = append(sorted, index)
+	}
+	sort.Ints(sorted)
+	files := make([]string, 0, len(indexes)+1)
+	for _, index := range sorted {
+		files = append(files, indexes[index])
+	}
+	return append(files, path), nil
+}
+
+// TruncateWAL removes the records of the heights above height from the WAL
+// group with the head at path: the records after the last EndHeightMessage
+// of a height up to height which precede the first EndHeightMessage of a
+// height above it, e.g. once the blocks above height are removed, so that
+// consensus decides them again. It returns whether anything was removed. The
+// WAL must not be open.
+func TruncateWAL(path string, height int64) (bool, error) {
+	files, err := walGroupFiles(path)
+	if err != nil {
+		return false, err
+	}
+
+	// find the end of the last EndHeightMessage up to height, as a file and
+	// an offset, before the first one above height
+	cutFile, cutOffset := 0, int64(0)
+	found := false
+	for i, file := range files {
+		if found {
+			break
+		}
+		f, err := os.Open(file)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		rd := &countingReader{rd: f}
+		dec := NewWALDecoder(rd)
+		for {
+			msg, err := dec.Decode()
+			if errors.Is(err, io.EOF) || IsDataCorruptionError(err) {
+				break
+			} else if err != nil {
+				f.Close()
+				return false, fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			m, ok := msg.Msg.(EndHeightMessage)
+			if !ok {
+				continue
+			}
+			if m.Height > height {
+				found = true
+				break
+			}
+			cutFile, cutOffset = i, rd.n
+		}
+		f.Close()
+	}
+	if !found {
+		return false, nil
+	}
+
+	if err := os.Truncate(files[cutFile], cutOffset); err != nil {
+		return false, fmt.Errorf("failed to truncate %s: %w", files[cutFile], err)
+	}
+	for _, file := range files[cutFile+1:] {
+		if file == path {
+			err = os.Truncate(file, 0)
+		} else {
+			err = os.Remove(file)
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return true, fmt.Errorf("failed to remove the records of %s: %w", file, err)
+		}
+	}
+	return true, nil
+}
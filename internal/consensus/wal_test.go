@@ -3,6 +3,9 @@ package consensus
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -101,6 +104,199 @@ func TestWALEncoderDecoder(t *testing.T) {
 	}
 }
 
+func TestWALDecoderChain(t *testing.T) {
+	now := tmtime.Now()
+	msgs := []TimedWALMessage{
+		{Time: now, Msg: EndHeightMessage{0}},
+		{Time: now, Msg: EndHeightMessage{1}},
+		{Time: now, Msg: EndHeightMessage{2}},
+	}
+
+	// encode each record separately, chained to the previous ones
+	enc := NewWALEncoder(nil)
+	records := make([][]byte, len(msgs))
+	for i := range msgs {
+		b := new(bytes.Buffer)
+		enc.wr = b
+		require.NoError(t, enc.Encode(&msgs[i]))
+		records[i] = b.Bytes()
+	}
+
+	decode := func(records ...[]byte) error {
+		dec := NewWALDecoder(bytes.NewReader(bytes.Join(records, nil)))
+		for {
+			if _, err := dec.Decode(); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+
+	require.NoError(t, decode(records...))
+	// the decoder may start in the middle of the chain
+	require.NoError(t, decode(records[1:]...))
+	// a record missing in between is detected
+	err := decode(records[0], records[2])
+	require.True(t, IsDataCorruptionError(err), err)
+	// a new chain may start after the previous one
+	b := new(bytes.Buffer)
+	require.NoError(t, NewWALEncoder(b).Encode(&msgs[2]))
+	require.NoError(t, decode(records[0], records[1], b.Bytes()))
+
+	// a garbled version is detected
+	garbled := append([]byte(nil), records[1]...)
+	garbled[4] = 3
+	err = decode(records[0], garbled)
+	require.True(t, IsDataCorruptionError(err), err)
+}
+
+func TestWALDecoderLegacyRecords(t *testing.T) {
+	msg := TimedWALMessage{Time: tmtime.Now(), Msg: EndHeightMessage{7}}
+
+	// encode a version 1 record
+	b := new(bytes.Buffer)
+	require.NoError(t, NewWALEncoder(b).Encode(&msg))
+	data := b.Bytes()[12:]
+	legacy := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint32(legacy[0:4], crc32.Checksum(data, crc32c))
+	binary.BigEndian.PutUint32(legacy[4:8], uint32(len(data)))
+	copy(legacy[8:], data)
+
+	// followed by version 2 records
+	stream := bytes.NewBuffer(legacy)
+	enc := NewWALEncoder(stream)
+	require.NoError(t, enc.Encode(&msg))
+	require.NoError(t, enc.Encode(&msg))
+
+	dec := NewWALDecoder(stream)
+	for i := 0; i < 3; i++ {
+		decoded, err := dec.Decode()
+		require.NoError(t, err)
+		require.Equal(t, msg.Msg, decoded.Msg)
+	}
+	_, err := dec.Decode()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestRepairWALFile(t *testing.T) {
+	walFile := filepath.Join(t.TempDir(), "wal")
+	f, err := os.Create(walFile)
+	require.NoError(t, err)
+	enc := NewWALEncoder(f)
+	for h := int64(0); h < 3; h++ {
+		require.NoError(t, enc.Encode(&TimedWALMessage{Time: tmtime.Now(), Msg: EndHeightMessage{h}}))
+	}
+	stat, err := f.Stat()
+	require.NoError(t, err)
+	validSize := stat.Size()
+
+	// a record partially written when crashing
+	b := new(bytes.Buffer)
+	require.NoError(t, NewWALEncoder(b).Encode(&TimedWALMessage{Time: tmtime.Now(), Msg: EndHeightMessage{3}}))
+	_, err = f.Write(b.Bytes()[:b.Len()/2])
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	info, err := InspectWALFile(walFile)
+	require.NoError(t, err)
+	require.Equal(t, 3, info.Records)
+	require.Zero(t, info.LegacyRecords)
+	require.Equal(t, int64(2), info.LastHeight)
+	require.True(t, info.Corrupted())
+	require.Equal(t, validSize, info.CorruptOffset)
+
+	backup, _, err := RepairWALFile(walFile)
+	require.NoError(t, err)
+	require.NotEmpty(t, backup)
+
+	backupInfo, err := InspectWALFile(backup)
+	require.NoError(t, err)
+	require.Equal(t, info.Size, backupInfo.Size)
+
+	info, err = InspectWALFile(walFile)
+	require.NoError(t, err)
+	require.False(t, info.Corrupted())
+	require.Equal(t, validSize, info.Size)
+	require.Equal(t, 3, info.Records)
+
+	// the repaired file has nothing left to repair
+	backup, _, err = RepairWALFile(walFile)
+	require.NoError(t, err)
+	require.Empty(t, backup)
+}
+
+func TestTruncateWAL(t *testing.T) {
+	walFile := filepath.Join(t.TempDir(), "wal")
+	writeFile := func(path string, msgs ...WALMessage) {
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		enc := NewWALEncoder(f)
+		for _, msg := range msgs {
+			require.NoError(t, enc.Encode(&TimedWALMessage{Time: tmtime.Now(), Msg: msg}))
+		}
+		require.NoError(t, f.Close())
+	}
+	roundState := func(h int64) WALMessage { return tmtypes.EventDataRoundState{Height: h} }
+	writeFile(walFile+".000", EndHeightMessage{0}, roundState(1), EndHeightMessage{1}, roundState(2))
+	writeFile(walFile+".001", EndHeightMessage{2}, roundState(3))
+	writeFile(walFile, EndHeightMessage{3}, roundState(4))
+
+	// nothing is above the last height
+	truncated, err := TruncateWAL(walFile, 3)
+	require.NoError(t, err)
+	require.False(t, truncated)
+
+	// the records after #ENDHEIGHT 1 are removed, up to the head
+	truncated, err = TruncateWAL(walFile, 1)
+	require.NoError(t, err)
+	require.True(t, truncated)
+	info, err := InspectWALFile(walFile + ".000")
+	require.NoError(t, err)
+	require.Equal(t, 3, info.Records)
+	require.Equal(t, int64(1), info.LastHeight)
+	_, err = os.Stat(walFile + ".001")
+	require.True(t, os.IsNotExist(err))
+	info, err = InspectWALFile(walFile)
+	require.NoError(t, err)
+	require.Zero(t, info.Size)
+
+	wal, err := NewWAL(context.Background(), log.NewNopLogger(), walFile)
+	require.NoError(t, err)
+	t.Cleanup(wal.Group().Close)
+	_, found, err := wal.SearchForEndHeight(2, &WALSearchOptions{})
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestWALRestartChain(t *testing.T) {
+	walFile := filepath.Join(t.TempDir(), "wal")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for h := int64(1); h <= 2; h++ {
+		wal, err := NewWAL(ctx, log.NewNopLogger(), walFile)
+		require.NoError(t, err)
+		require.NoError(t, wal.Start(ctx))
+		if h > 1 {
+			// chained to the last record written before the restart
+			require.NotZero(t, wal.enc.prevCRC)
+		}
+		require.NoError(t, wal.WriteSync(EndHeightMessage{h}))
+		wal.Stop()
+		wal.Wait()
+	}
+
+	// the records written after the restart follow the previous ones
+	info, err := InspectWALFile(walFile)
+	require.NoError(t, err)
+	require.False(t, info.Corrupted(), info.CorruptErr)
+	require.Equal(t, 3, info.Records)
+	require.Equal(t, int64(2), info.LastHeight)
+}
+
 func TestWALWrite(t *testing.T) {
 	walDir := t.TempDir()
 	walFile := filepath.Join(walDir, "wal")
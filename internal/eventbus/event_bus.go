@@ -17,6 +17,7 @@ import (
 type Subscription interface {
 	ID() string
 	Next(context.Context) (tmpubsub.Message, error)
+	StartSeq() int64
 }
 
 // EventBus is a common bus for all events going through the system.
@@ -27,10 +28,11 @@ type EventBus struct {
 	pubsub *tmpubsub.Server
 }
 
-// NewDefault returns a new event bus with default options.
-func NewDefault(l log.Logger) *EventBus {
+// NewDefault returns a new event bus with default options, and the given
+// options of its pubsub server.
+func NewDefault(l log.Logger, options ...tmpubsub.Option) *EventBus {
 	logger := l.With("module", "eventbus")
-	pubsub := tmpubsub.NewServer(l, tmpubsub.BufferCapacity(0))
+	pubsub := tmpubsub.NewServer(l, append([]tmpubsub.Option{tmpubsub.BufferCapacity(0)}, options...)...)
 	b := &EventBus{pubsub: pubsub}
 	b.BaseService = *service.NewBaseService(logger, "EventBus", b)
 	return b
@@ -136,7 +138,7 @@ func (b *EventBus) PublishEventTx(data types.EventDataTx) error {
 		Attributes: []abci.EventAttribute{
 			{
 				Key:   tokens[1],
-				Value: fmt.Sprintf("%X", types.Tx(data.Tx).Hash()),
+				Value: fmt.Sprintf("%X", types.Tx(data.Tx).ID()),
 			},
 		},
 	})
@@ -155,6 +157,34 @@ func (b *EventBus) PublishEventTx(data types.EventDataTx) error {
 	return b.pubsub.PublishWithEvents(data, events)
 }
 
+// PublishEventEvictedTx publishes the eviction of a transaction from the
+// mempool. Note it will add the predefined TxHashKey, so that a client can
+// watch for the eviction of a given transaction.
+func (b *EventBus) PublishEventEvictedTx(data types.EventDataEvictedTx) error {
+	tokens := strings.Split(types.TxHashKey, ".")
+	events := []abci.Event{
+		{
+			Type: strings.Split(types.EventTypeKey, ".")[0],
+			Attributes: []abci.EventAttribute{
+				{
+					Key:   strings.Split(types.EventTypeKey, ".")[1],
+					Value: types.EventEvictedTxValue,
+				},
+			},
+		},
+		{
+			Type: tokens[0],
+			Attributes: []abci.EventAttribute{
+				{
+					Key:   tokens[1],
+					Value: fmt.Sprintf("%X", data.Tx.ID()),
+				},
+			},
+		},
+	}
+	return b.pubsub.PublishWithEvents(data, events)
+}
+
 func (b *EventBus) PublishEventNewRoundStep(data types.EventDataRoundState) error {
 	return b.Publish(types.EventNewRoundStepValue, data)
 }
@@ -187,8 +217,16 @@ func (b *EventBus) PublishEventLock(data types.EventDataRoundState) error {
 	return b.Publish(types.EventLockValue, data)
 }
 
+// PublishEventValidatorSetUpdates publishes the updates of the validator set
+// with an event for each change, so that a client can watch for the changes
+// of a given validator.
 func (b *EventBus) PublishEventValidatorSetUpdates(data types.EventDataValidatorSetUpdates) error {
-	return b.Publish(types.EventValidatorSetUpdatesValue, data)
+	events := data.ABCIEvents()
+
+	// add Tendermint-reserved validator set updates event
+	events = append(events, types.EventValidatorSetUpdates)
+
+	return b.pubsub.PublishWithEvents(data, events)
 }
 
 func (b *EventBus) PublishEventEvidenceValidated(evidence types.EventDataEvidenceValidated) error {
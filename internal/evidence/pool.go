@@ -16,6 +16,7 @@ import (
 
 	"github.com/tendermint/tendermint/internal/eventbus"
 	clist "github.com/tendermint/tendermint/internal/libs/clist"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/libs/log"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -38,6 +39,10 @@ const (
 	prefixPending   = int64(10)
 )
 
+// maxLightBuffer is the maximum number of light client attacks buffered until
+// they can be verified.
+const maxLightBuffer = 64
+
 // Pool maintains a pool of valid evidence to be broadcasted and committed
 type Pool struct {
 	logger log.Logger
@@ -58,6 +63,10 @@ type Pool struct {
 	// before being flushed to the pool. This prevents broadcasting and proposing of
 	// evidence before the height with which the evidence happened is finished.
 	consensusBuffer []duplicateVoteSet
+	// light client attack evidence detected by the light client of the node is
+	// buffered to this slice, until the node has the blocks to verify it
+	// against, e.g. once it finished syncing.
+	lightBuffer []*types.LightClientAttackEvidence
 
 	pruningHeight int64
 	pruningTime   time.Time
@@ -67,22 +76,49 @@ type Pool struct {
 	// The eventBus must be started in order for event publishing not to block
 	eventBus *eventbus.EventBus
 
+	// the number of goroutines verifying the evidence of a block
+	verificationWorkers int
+
 	Metrics *Metrics
 }
 
+// PoolOption sets an optional parameter on the Pool.
+type PoolOption func(*Pool)
+
+// WithVerificationWorkers sets the number of goroutines verifying the
+// evidence of a block in CheckEvidence.
+func WithVerificationWorkers(workers int) PoolOption {
+	return func(evpool *Pool) {
+		evpool.verificationWorkers = workers
+	}
+}
+
 // NewPool creates an evidence pool. If using an existing evidence store,
 // it will add all pending evidence to the concurrent list.
-func NewPool(logger log.Logger, evidenceDB dbm.DB, stateStore sm.Store, blockStore BlockStore, metrics *Metrics, eventBus *eventbus.EventBus) *Pool {
-	return &Pool{
-		blockStore:      blockStore,
-		stateDB:         stateStore,
-		logger:          logger,
-		evidenceStore:   evidenceDB,
-		evidenceList:    clist.New(),
-		consensusBuffer: make([]duplicateVoteSet, 0),
-		Metrics:         metrics,
-		eventBus:        eventBus,
-	}
+func NewPool(
+	logger log.Logger,
+	evidenceDB dbm.DB,
+	stateStore sm.Store,
+	blockStore BlockStore,
+	metrics *Metrics,
+	eventBus *eventbus.EventBus,
+	options ...PoolOption,
+) *Pool {
+	evpool := &Pool{
+		blockStore:          blockStore,
+		stateDB:             stateStore,
+		logger:              logger,
+		evidenceStore:       evidenceDB,
+		evidenceList:        clist.New(),
+		consensusBuffer:     make([]duplicateVoteSet, 0),
+		Metrics:             metrics,
+		eventBus:            eventBus,
+		verificationWorkers: 1,
+	}
+	for _, option := range options {
+		option(evpool)
+	}
+	return evpool
 }
 
 // PendingEvidence is used primarily as part of block proposal and returns up to
@@ -129,6 +165,10 @@ func (evpool *Pool) Update(ctx context.Context, state sm.State, ev types.Evidenc
 	// update state
 	evpool.updateState(state)
 
+	// verify the light client attacks that became verifiable, adding them to
+	// the pool
+	evpool.processLightBuffer(ctx)
+
 	// move committed evidence out from the pending pool and into the committed pool
 	evpool.markEvidenceAsCommitted(ev, state.LastBlockHeight)
 
@@ -193,25 +233,136 @@ func (evpool *Pool) ReportConflictingVotes(voteA, voteB *types.Vote) {
 	})
 }
 
+// AddConflictingVotes forms duplicate vote evidence from two conflicting votes
+// detected outside of the node, e.g. by a monitoring tool, and adds it to the
+// pool once verified. Unlike ReportConflictingVotes, the votes must be from a
+// committed height, whose block time and validator set the evidence is formed
+// with.
+func (evpool *Pool) AddConflictingVotes(
+	ctx context.Context,
+	voteA, voteB *types.Vote,
+) (*types.DuplicateVoteEvidence, error) {
+	if voteA == nil || voteB == nil {
+		return nil, errors.New("missing vote")
+	}
+
+	state := evpool.State()
+	var (
+		blockTime time.Time
+		valSet    *types.ValidatorSet
+	)
+	switch {
+	case voteA.Height == state.LastBlockHeight:
+		blockTime, valSet = state.LastBlockTime, state.LastValidators
+	case voteA.Height < state.LastBlockHeight:
+		var err error
+		valSet, err = evpool.stateDB.LoadValidators(voteA.Height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load validator set at height %d: %w", voteA.Height, err)
+		}
+		blockMeta := evpool.blockStore.LoadBlockMeta(voteA.Height)
+		if blockMeta == nil {
+			return nil, fmt.Errorf("missing block for height %d", voteA.Height)
+		}
+		blockTime = blockMeta.Header.Time
+	default:
+		return nil, fmt.Errorf("votes are from height %d, above the last committed height %d",
+			voteA.Height, state.LastBlockHeight)
+	}
+
+	dve, err := types.NewDuplicateVoteEvidence(voteA, voteB, blockTime, valSet)
+	if err != nil {
+		return nil, err
+	}
+	if err := evpool.AddEvidence(ctx, dve); err != nil {
+		return nil, err
+	}
+	return dve, nil
+}
+
+// PendingEvidencePage returns up to limit pending evidence, ordered by height,
+// after skipping the first skip ones. The number of pending evidence is given
+// by Size.
+func (evpool *Pool) PendingEvidencePage(skip, limit int) ([]types.Evidence, error) {
+	iter, err := dbm.IteratePrefix(evpool.evidenceStore, prefixToBytes(prefixPending))
+	if err != nil {
+		return nil, fmt.Errorf("database error: %w", err)
+	}
+	defer iter.Close()
+
+	evidence := make([]types.Evidence, 0, limit)
+	for ; iter.Valid() && len(evidence) < limit; iter.Next() {
+		if skip > 0 {
+			skip--
+			continue
+		}
+		ev, err := bytesToEv(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, ev)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return evidence, nil
+}
+
+// ReportLightClientAttack takes light client attack evidence detected by the
+// light client of the node, adding it eventually to the evidence pool.
+//
+// The light client may detect attacks on blocks the node doesn't have yet, for
+// instance while state syncing, thus the evidence pool holds the evidence in
+// a buffer, verifying it on each `Update()` until the node has the blocks to
+// verify it against. Up to maxLightBuffer evidence is buffered, and the
+// evidence is dropped once it has expired or was found invalid.
+func (evpool *Pool) ReportLightClientAttack(ev *types.LightClientAttackEvidence) {
+	evpool.mtx.Lock()
+	defer evpool.mtx.Unlock()
+	for _, buffered := range evpool.lightBuffer {
+		if bytes.Equal(buffered.Hash(), ev.Hash()) {
+			return
+		}
+	}
+	if len(evpool.lightBuffer) >= maxLightBuffer {
+		evpool.logger.Error("light client attack buffer is full; dropping oldest evidence",
+			"evidence", evpool.lightBuffer[0])
+		evpool.lightBuffer = evpool.lightBuffer[1:]
+	}
+	evpool.lightBuffer = append(evpool.lightBuffer, ev)
+}
+
 // CheckEvidence takes an array of evidence from a block and verifies all the evidence there.
 // If it has already verified the evidence then it jumps to the next one. It ensures that no
 // evidence has already been committed or is being proposed twice. It also adds any
 // evidence that it doesn't currently have so that it can quickly form ABCI Evidence later.
 func (evpool *Pool) CheckEvidence(ctx context.Context, evList types.EvidenceList) error {
+	var verified []*error
+	if evpool.verificationWorkers > 1 {
+		verified = evpool.verifyParallel(ctx, evList)
+	}
+
 	hashes := make([][]byte, len(evList))
 	for idx, ev := range evList {
+		var mustVerify bool
+		if verified != nil {
+			mustVerify = verified[idx] != nil
+		} else {
+			mustVerify = evpool.shouldVerify(ev)
+		}
 
-		_, isLightEv := ev.(*types.LightClientAttackEvidence)
-
-		// We must verify light client attack evidence regardless because there could be a
-		// different conflicting block with the same hash.
-		if isLightEv || !evpool.isPending(ev) {
+		if mustVerify {
 			// check that the evidence isn't already committed
 			if evpool.isCommitted(ev) {
 				return &types.ErrInvalidEvidence{Evidence: ev, Reason: errors.New("evidence was already committed")}
 			}
 
-			err := evpool.verify(ctx, ev)
+			var err error
+			if verified != nil {
+				err = *verified[idx]
+			} else {
+				err = evpool.verify(ctx, ev)
+			}
 			if err != nil {
 				return err
 			}
@@ -237,6 +388,36 @@ func (evpool *Pool) CheckEvidence(ctx context.Context, evList types.EvidenceList
 	return nil
 }
 
+// shouldVerify reports whether CheckEvidence must verify the evidence.
+func (evpool *Pool) shouldVerify(ev types.Evidence) bool {
+	// We must verify light client attack evidence regardless because there could be a
+	// different conflicting block with the same hash.
+	_, isLightEv := ev.(*types.LightClientAttackEvidence)
+	return isLightEv || !evpool.isPending(ev)
+}
+
+// verifyParallel verifies the evidence of evList that must be verified,
+// across the verification workers. It returns by index the result of the
+// verification, or nil if the evidence need not be verified. Committed
+// evidence is not verified.
+func (evpool *Pool) verifyParallel(ctx context.Context, evList types.EvidenceList) []*error {
+	verified := make([]*error, len(evList))
+	tmsync.ForEach(len(evList), evpool.verificationWorkers, func(idx int) {
+		ev := evList[idx]
+		if !evpool.shouldVerify(ev) {
+			return
+		}
+		var err error
+		if evpool.isCommitted(ev) {
+			err = &types.ErrInvalidEvidence{Evidence: ev, Reason: errors.New("evidence was already committed")}
+		} else {
+			err = evpool.verify(ctx, ev)
+		}
+		verified[idx] = &err
+	})
+	return verified
+}
+
 // EvidenceFront goes to the first evidence in the clist
 func (evpool *Pool) EvidenceFront() *clist.CElement {
 	return evpool.evidenceList.Front()
@@ -545,6 +726,41 @@ func (evpool *Pool) updateState(state sm.State) {
 	evpool.state = state
 }
 
+// processLightBuffer tries to add the buffered light client attack evidence
+// to the pool, keeping the evidence that can't be verified yet.
+func (evpool *Pool) processLightBuffer(ctx context.Context) {
+	evpool.mtx.Lock()
+	buffer := evpool.lightBuffer
+	evpool.lightBuffer = nil
+	evpool.mtx.Unlock()
+
+	remaining := make([]*types.LightClientAttackEvidence, 0, len(buffer))
+	for _, ev := range buffer {
+		if evpool.isExpired(ev.Height(), ev.Time()) {
+			evpool.logger.Info("dropping expired light client attack evidence", "evidence", ev)
+			continue
+		}
+		err := evpool.AddEvidence(ctx, ev)
+		var invalidErr *types.ErrInvalidEvidence
+		switch {
+		case err == nil:
+		case errors.As(err, &invalidErr):
+			evpool.logger.Error("dropping invalid light client attack evidence", "evidence", ev, "err", err)
+		default:
+			// the node doesn't have the blocks to verify the evidence yet
+			evpool.logger.Debug("light client attack evidence not verifiable yet", "evidence", ev, "err", err)
+			remaining = append(remaining, ev)
+		}
+	}
+
+	evpool.mtx.Lock()
+	evpool.lightBuffer = append(remaining, evpool.lightBuffer...)
+	if n := len(evpool.lightBuffer); n > maxLightBuffer {
+		evpool.lightBuffer = evpool.lightBuffer[n-maxLightBuffer:]
+	}
+	evpool.mtx.Unlock()
+}
+
 // processConsensusBuffer converts all the duplicate votes witnessed from consensus
 // into DuplicateVoteEvidence. It sets the evidence timestamp to the block height
 // from the most recently committed block.
@@ -212,6 +212,49 @@ func TestReportConflictingVotes(t *testing.T) {
 	require.NotNil(t, next)
 }
 
+func TestAddConflictingVotes(t *testing.T) {
+	var height int64 = 10
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, pv, _ := defaultTestPool(ctx, t, height)
+
+	// the votes must be from a committed height
+	future, err := types.NewMockDuplicateVoteEvidenceWithValidator(ctx, height+1, defaultEvidenceTime, pv, evidenceChainID)
+	require.NoError(t, err)
+	_, err = pool.AddConflictingVotes(ctx, future.VoteA, future.VoteB)
+	require.Error(t, err)
+
+	_, err = pool.AddConflictingVotes(ctx, future.VoteA, nil)
+	require.Error(t, err)
+
+	// the evidence is formed with the block time and validator set of the
+	// height of the votes, and listed by height
+	var added []types.Evidence
+	for _, h := range []int64{6, 4, 8} {
+		ev, err := types.NewMockDuplicateVoteEvidenceWithValidator(ctx, h, defaultEvidenceTime, pv, evidenceChainID)
+		require.NoError(t, err)
+		dve, err := pool.AddConflictingVotes(ctx, ev.VoteA, ev.VoteB)
+		require.NoError(t, err)
+		require.Equal(t, h, dve.Height())
+		added = append(added, dve)
+	}
+	require.EqualValues(t, 3, pool.Size())
+
+	page, err := pool.PendingEvidencePage(0, 2)
+	require.NoError(t, err)
+	require.Equal(t, types.EvidenceList{added[1], added[0]}.Hash(), types.EvidenceList(page).Hash())
+
+	page, err = pool.PendingEvidencePage(2, 2)
+	require.NoError(t, err)
+	require.Equal(t, types.EvidenceList{added[2]}.Hash(), types.EvidenceList(page).Hash())
+
+	page, err = pool.PendingEvidencePage(3, 2)
+	require.NoError(t, err)
+	require.Empty(t, page)
+}
+
 func TestEvidencePoolUpdate(t *testing.T) {
 	height := int64(21)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -324,6 +367,48 @@ func TestVerifyDuplicatedEvidenceFails(t *testing.T) {
 	}
 }
 
+func TestCheckEvidenceParallel(t *testing.T) {
+	var height int64 = 10
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pool, val, _ := defaultTestPool(ctx, t, height, evidence.WithVerificationWorkers(4))
+
+	evList := make(types.EvidenceList, 5)
+	for i := range evList {
+		ev, err := types.NewMockDuplicateVoteEvidenceWithValidator(
+			ctx,
+			int64(i+1),
+			defaultEvidenceTime.Add(time.Duration(i+1)*time.Minute),
+			val,
+			evidenceChainID,
+		)
+		require.NoError(t, err)
+		evList[i] = ev
+	}
+	// the last evidence is already pending
+	require.NoError(t, pool.AddEvidence(ctx, evList[4]))
+
+	require.NoError(t, pool.CheckEvidence(ctx, evList))
+	require.Equal(t, uint32(len(evList)), pool.Size())
+
+	err := pool.CheckEvidence(ctx, append(evList, evList[1]))
+	if assert.Error(t, err) {
+		assert.Equal(t, "duplicate evidence", err.(*types.ErrInvalidEvidence).Reason.Error())
+	}
+
+	invalidEv, err := types.NewMockDuplicateVoteEvidenceWithValidator(
+		ctx,
+		6,
+		defaultEvidenceTime.Add(6*time.Minute),
+		val,
+		"wrong-chain-id",
+	)
+	require.NoError(t, err)
+	assert.Error(t, pool.CheckEvidence(ctx, append(evList, invalidEv)))
+}
+
 // Check that we generate events when evidence is added into the evidence pool
 func TestEventOnEvidenceValidated(t *testing.T) {
 	const height = 1
@@ -440,6 +525,61 @@ func TestLightClientAttackEvidenceLifecycle(t *testing.T) {
 	require.Empty(t, remaindingEv)
 }
 
+// check that light client attacks reported before the node has the blocks they
+// refer to are verified and added once it has them
+func TestReportLightClientAttack(t *testing.T) {
+	var (
+		height       int64 = 100
+		commonHeight int64 = 90
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ev, trusted, common := makeLunaticEvidence(ctx, t, height, commonHeight,
+		10, 5, 5, defaultEvidenceTime, defaultEvidenceTime.Add(1*time.Hour))
+
+	state := sm.State{
+		LastBlockTime:   defaultEvidenceTime.Add(2 * time.Hour),
+		LastBlockHeight: 110,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+
+	stateStore := &smmocks.Store{}
+	stateStore.On("LoadValidators", height).Return(trusted.ValidatorSet, nil)
+	stateStore.On("LoadValidators", commonHeight).Return(common.ValidatorSet, nil)
+	stateStore.On("Load").Return(state, nil)
+
+	// the node doesn't have the blocks yet
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", commonHeight).Return(nil).Once()
+
+	logger := log.NewNopLogger()
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	pool := evidence.NewPool(logger, dbm.NewMemDB(), stateStore, blockStore, evidence.NopMetrics(), eventBus)
+	require.NoError(t, pool.Start(state))
+
+	pool.ReportLightClientAttack(ev)
+	pool.ReportLightClientAttack(ev)
+
+	state.LastBlockHeight++
+	pool.Update(ctx, state, types.EvidenceList{})
+	pendingEv, _ := pool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	require.Empty(t, pendingEv)
+
+	// once the node has the blocks, the evidence is verified and added
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{Header: *trusted.Header})
+	blockStore.On("LoadBlockMeta", commonHeight).Return(&types.BlockMeta{Header: *common.Header})
+	blockStore.On("LoadBlockCommit", height).Return(trusted.Commit)
+	blockStore.On("LoadBlockCommit", commonHeight).Return(common.Commit)
+
+	state.LastBlockHeight++
+	pool.Update(ctx, state, types.EvidenceList{})
+	pendingEv, _ = pool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
+	require.Equal(t, []types.Evidence{ev}, pendingEv)
+}
+
 // Tests that restarting the evidence pool after a potential failure will recover the
 // pending evidence and continue to gossip it
 func TestRecoverPendingEvidence(t *testing.T) {
@@ -601,7 +741,12 @@ func makeExtCommit(height int64, valAddr []byte) *types.ExtendedCommit {
 	}
 }
 
-func defaultTestPool(ctx context.Context, t *testing.T, height int64) (*evidence.Pool, types.MockPV, *eventbus.EventBus) {
+func defaultTestPool(
+	ctx context.Context,
+	t *testing.T,
+	height int64,
+	options ...evidence.PoolOption,
+) (*evidence.Pool, types.MockPV, *eventbus.EventBus) {
 	t.Helper()
 	val := types.NewMockPV()
 	valAddress := val.PrivKey.PubKey().Address()
@@ -617,7 +762,7 @@ func defaultTestPool(ctx context.Context, t *testing.T, height int64) (*evidence
 	eventBus := eventbus.NewDefault(logger)
 	require.NoError(t, eventBus.Start(ctx))
 
-	pool := evidence.NewPool(logger, evidenceDB, stateStore, blockStore, evidence.NopMetrics(), eventBus)
+	pool := evidence.NewPool(logger, evidenceDB, stateStore, blockStore, evidence.NopMetrics(), eventBus, options...)
 	startPool(t, pool, stateStore)
 	return pool, val, eventBus
 }
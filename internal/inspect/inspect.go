@@ -32,21 +32,37 @@ type Inspector struct {
 	routes rpccore.RoutesMap
 
 	config *config.RPCConfig
+	genDoc *types.GenesisDoc
 
 	indexerService *indexer.Service
 	eventBus       *eventbus.EventBus
 	logger         log.Logger
 }
 
+// Option sets an optional parameter on the Inspector.
+type Option func(*Inspector)
+
+// WithGenesisDoc serves the genesis document of the node over the genesis and
+// genesis_chunked RPC methods.
+func WithGenesisDoc(genDoc *types.GenesisDoc) Option {
+	return func(ins *Inspector) { ins.genDoc = genDoc }
+}
+
 // New returns an Inspector that serves RPC on the specified BlockStore and StateStore.
 // The Inspector type does not modify the state or block stores.
 // The sinks are used to enable block and transaction querying via the RPC server.
 // The caller is responsible for starting and stopping the Inspector service.
-func New(cfg *config.RPCConfig, bs state.BlockStore, ss state.Store, es []indexer.EventSink, logger log.Logger) *Inspector {
+func New(
+	cfg *config.RPCConfig,
+	bs state.BlockStore,
+	ss state.Store,
+	es []indexer.EventSink,
+	logger log.Logger,
+	options ...Option,
+) *Inspector {
 	eb := eventbus.NewDefault(logger.With("module", "events"))
 
-	return &Inspector{
-		routes:   rpc.Routes(*cfg, ss, bs, es, logger),
+	ins := &Inspector{
 		config:   cfg,
 		logger:   logger,
 		eventBus: eb,
@@ -56,10 +72,19 @@ func New(cfg *config.RPCConfig, bs state.BlockStore, ss state.Store, es []indexe
 			Logger:   logger.With("module", "txindex"),
 		}),
 	}
+	for _, opt := range options {
+		opt(ins)
+	}
+	ins.routes = rpc.Routes(*cfg, ss, bs, es, ins.genDoc, logger)
+	return ins
 }
 
 // NewFromConfig constructs an Inspector using the values defined in the passed in config.
+// It selects the tx hash function of the config, by which the txs are served.
 func NewFromConfig(logger log.Logger, cfg *config.Config) (*Inspector, error) {
+	if err := types.SetTxHashFunc(cfg.TxHash); err != nil {
+		return nil, err
+	}
 	bsDB, err := config.DefaultDBProvider(&config.DBContext{ID: "blockstore", Config: cfg})
 	if err != nil {
 		return nil, err
@@ -78,7 +103,7 @@ func NewFromConfig(logger log.Logger, cfg *config.Config) (*Inspector, error) {
 		return nil, err
 	}
 	ss := state.NewStore(sDB)
-	return New(cfg.RPC, bs, ss, sinks, logger), nil
+	return New(cfg.RPC, bs, ss, sinks, logger, WithGenesisDoc(genDoc)), nil
 }
 
 // Run starts the Inspector servers and blocks until the servers shut down. The passed
@@ -580,6 +580,105 @@ func TestBlockSearch(t *testing.T) {
 	stateStoreMock.AssertExpectations(t)
 }
 
+func TestHeader(t *testing.T) {
+	testHeight := int64(1)
+	testHash := []byte("test hash")
+	stateStoreMock := &statemocks.Store{}
+	blockStoreMock := &statemocks.BlockStore{}
+	blockStoreMock.On("Height").Return(testHeight)
+	blockStoreMock.On("Base").Return(int64(0))
+	blockStoreMock.On("LoadBlockMeta", testHeight).Return(&types.BlockMeta{
+		Header: types.Header{
+			Height:  testHeight,
+			AppHash: testHash,
+		},
+	})
+	eventSinkMock := &indexermocks.EventSink{}
+	eventSinkMock.On("Stop").Return(nil)
+	eventSinkMock.On("Type").Return(indexer.EventSinkType("Mock"))
+
+	rpcConfig := config.TestRPCConfig()
+	l := log.NewNopLogger()
+	d := inspect.New(rpcConfig, blockStoreMock, stateStoreMock, []indexer.EventSink{eventSinkMock}, l)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	startedWG := &sync.WaitGroup{}
+	startedWG.Add(1)
+	go func() {
+		startedWG.Done()
+		defer wg.Done()
+		require.NoError(t, d.Run(ctx))
+	}()
+	// FIXME: used to induce context switch.
+	// Determine more deterministic method for prompting a context switch
+	startedWG.Wait()
+	requireConnect(t, rpcConfig.ListenAddress, 20)
+	cli, err := httpclient.New(rpcConfig.ListenAddress)
+	require.NoError(t, err)
+	res, err := cli.Header(ctx, &testHeight)
+	require.NoError(t, err)
+	require.NotNil(t, res.Header)
+	require.Equal(t, testHeight, res.Header.Height)
+	require.Equal(t, testHash, []byte(res.Header.AppHash))
+
+	// the genesis document is only served if given
+	_, err = cli.Genesis(ctx)
+	require.Error(t, err)
+
+	cancel()
+	wg.Wait()
+
+	blockStoreMock.AssertExpectations(t)
+	stateStoreMock.AssertExpectations(t)
+}
+
+func TestGenesis(t *testing.T) {
+	genDoc := &types.GenesisDoc{
+		ChainID:       "test-chain",
+		InitialHeight: 1,
+	}
+	stateStoreMock := &statemocks.Store{}
+	blockStoreMock := &statemocks.BlockStore{}
+	eventSinkMock := &indexermocks.EventSink{}
+	eventSinkMock.On("Stop").Return(nil)
+	eventSinkMock.On("Type").Return(indexer.EventSinkType("Mock"))
+
+	rpcConfig := config.TestRPCConfig()
+	l := log.NewNopLogger()
+	d := inspect.New(rpcConfig, blockStoreMock, stateStoreMock, []indexer.EventSink{eventSinkMock}, l,
+		inspect.WithGenesisDoc(genDoc))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	startedWG := &sync.WaitGroup{}
+	startedWG.Add(1)
+	go func() {
+		startedWG.Done()
+		defer wg.Done()
+		require.NoError(t, d.Run(ctx))
+	}()
+	// FIXME: used to induce context switch.
+	// Determine more deterministic method for prompting a context switch
+	startedWG.Wait()
+	requireConnect(t, rpcConfig.ListenAddress, 20)
+	cli, err := httpclient.New(rpcConfig.ListenAddress)
+	require.NoError(t, err)
+	res, err := cli.Genesis(ctx)
+	require.NoError(t, err)
+	require.Equal(t, genDoc.ChainID, res.Genesis.ChainID)
+	chunk, err := cli.GenesisChunked(ctx, 0)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, chunk.TotalChunks)
+
+	cancel()
+	wg.Wait()
+}
+
 func requireConnect(t testing.TB, addr string, retries int) {
 	parts := strings.SplitN(addr, "://", 2)
 	if len(parts) != 2 {
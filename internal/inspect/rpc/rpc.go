@@ -14,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	"github.com/tendermint/tendermint/types"
 )
 
 // Server defines parameters for running an Inspector rpc server.
@@ -28,27 +29,47 @@ type eventBusUnsubscriber interface {
 	UnsubscribeAll(ctx context.Context, subscriber string) error
 }
 
-// Routes returns the set of routes used by the Inspector server.
-func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, es []indexer.EventSink, logger log.Logger) core.RoutesMap {
+// Routes returns the set of routes used by the Inspector server. The genesis
+// routes are only served if genDoc is not nil.
+func Routes(
+	cfg config.RPCConfig,
+	s state.Store,
+	bs state.BlockStore,
+	es []indexer.EventSink,
+	genDoc *types.GenesisDoc,
+	logger log.Logger,
+) core.RoutesMap {
 	env := &core.Environment{
 		Config:     cfg,
 		EventSinks: es,
 		StateStore: s,
 		BlockStore: bs,
+		GenDoc:     genDoc,
 		Logger:     logger,
 	}
-	return core.RoutesMap{
+	routes := core.RoutesMap{
 		"blockchain":       server.NewRPCFunc(env.BlockchainInfo),
 		"consensus_params": server.NewRPCFunc(env.ConsensusParams),
 		"block":            server.NewRPCFunc(env.Block),
 		"block_by_hash":    server.NewRPCFunc(env.BlockByHash),
 		"block_results":    server.NewRPCFunc(env.BlockResults),
 		"commit":           server.NewRPCFunc(env.Commit),
+		"header":           server.NewRPCFunc(env.Header),
+		"header_by_hash":   server.NewRPCFunc(env.HeaderByHash),
 		"validators":       server.NewRPCFunc(env.Validators),
 		"tx":               server.NewRPCFunc(env.Tx),
 		"tx_search":        server.NewRPCFunc(env.TxSearch),
 		"block_search":     server.NewRPCFunc(env.BlockSearch),
 	}
+	if genDoc != nil {
+		if err := env.InitGenesisChunks(); err != nil {
+			logger.Error("failed to chunk the genesis document; not serving it", "err", err)
+			return routes
+		}
+		routes["genesis"] = server.NewRPCFunc(env.Genesis)
+		routes["genesis_chunked"] = server.NewRPCFunc(env.GenesisChunked)
+	}
+	return routes
 }
 
 // Handler returns the http.Handler configured for use with an Inspector server. Handler
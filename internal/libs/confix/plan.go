@@ -11,6 +11,26 @@ import (
 	"github.com/creachadair/tomledit/transform"
 )
 
+// ensureTable ensures the document has the named table, adding it at the end
+// with the given mappings if it is missing, or else adding those of the
+// mappings it does not contain.
+func ensureTable(name parser.Key, kvs ...*parser.KeyValue) transform.Func {
+	return func(_ context.Context, doc *tomledit.Document) error {
+		if tab := transform.FindTable(doc, name...); tab != nil {
+			for _, kv := range kvs {
+				transform.InsertMapping(tab.Section, kv, false)
+			}
+			return nil
+		}
+		sec := &tomledit.Section{Heading: &parser.Heading{Name: name}}
+		for _, kv := range kvs {
+			sec.Items = append(sec.Items, kv)
+		}
+		doc.Sections = append(doc.Sections, sec)
+		return nil
+	}
+}
+
 // The plan is the sequence of transformation steps that should be applied, in
 // the given order, to convert a configuration file to be compatible with the
 // current version of the config grammar.
@@ -234,4 +254,54 @@ var plan = transform.Plan{
 		T:       transform.Remove(parser.Key{"p2p", "seeds"}),
 		ErrorOK: true,
 	},
+	{
+		Desc: `Add top-level tx-hash setting (default "sha256")`,
+		T: transform.EnsureKey(nil, &parser.KeyValue{
+			Block: parser.Comments{"Function identifying transactions: sha256, or one registered by the application"},
+			Name:  parser.Key{"tx-hash"},
+			Value: parser.MustValue(`"sha256"`),
+		}),
+		ErrorOK: true,
+	},
+	{
+		Desc: `Add [mempool] recheck-mode setting (default "full")`,
+		T: transform.EnsureKey(parser.Key{"mempool"}, &parser.KeyValue{
+			Block: parser.Comments{"Recheck mode of the mempool: full | partial"},
+			Name:  parser.Key{"recheck-mode"},
+			Value: parser.MustValue(`"full"`),
+		}),
+		ErrorOK: true,
+	},
+	{
+		Desc: `Add [tx-index] psql-driver setting (default "postgres")`,
+		T: transform.EnsureKey(parser.Key{"tx-index"}, &parser.KeyValue{
+			Block: parser.Comments{"The database/sql driver of the psql sink"},
+			Name:  parser.Key{"psql-driver"},
+			Value: parser.MustValue(`"postgres"`),
+		}),
+		ErrorOK: true,
+	},
+	{
+		Desc: "Add [storage.pruning] interval setting",
+		T: ensureTable(parser.Key{"storage", "pruning"}, &parser.KeyValue{
+			Block: parser.Comments{"The time between two runs of the pruner."},
+			Name:  parser.Key{"interval"},
+			Value: parser.MustValue(`"10s"`),
+		}),
+	},
+	{
+		Desc: "Add [storage.audit] interval and sample-size settings",
+		T: ensureTable(parser.Key{"storage", "audit"},
+			&parser.KeyValue{
+				Block: parser.Comments{"The time between two runs of the storage auditor, or 0 to disable it."},
+				Name:  parser.Key{"interval"},
+				Value: parser.MustValue(`"1h0m0s"`),
+			},
+			&parser.KeyValue{
+				Block: parser.Comments{"The number of heights audited by each run."},
+				Name:  parser.Key{"sample-size"},
+				Value: parser.MustValue("10"),
+			},
+		),
+	},
 }
@@ -0,0 +1,75 @@
+// Package metricslabels limits the number of series of labeled metrics, by
+// blanking the values of their high-cardinality labels.
+package metricslabels
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+// blank returns a copy of the label values lvs, with the values of the given
+// labels set to "".
+func blank(labels map[string]bool, lvs []string) []string {
+	out := make([]string, len(lvs))
+	copy(out, lvs)
+	for i := 0; i+1 < len(out); i += 2 {
+		if labels[out[i]] {
+			out[i+1] = ""
+		}
+	}
+	return out
+}
+
+func labelSet(labels []string) map[string]bool {
+	set := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		set[label] = true
+	}
+	return set
+}
+
+type counter struct {
+	metrics.Counter
+	labels map[string]bool
+}
+
+// BlankCounter returns a counter adding to c, with the values of the given
+// labels blanked, so that the additions for all their values are recorded in a
+// single series.
+func BlankCounter(c metrics.Counter, labels ...string) metrics.Counter {
+	return &counter{Counter: c, labels: labelSet(labels)}
+}
+
+func (c *counter) With(lvs ...string) metrics.Counter {
+	return &counter{Counter: c.Counter.With(blank(c.labels, lvs)...), labels: c.labels}
+}
+
+type gauge struct {
+	metrics.Gauge
+	labels map[string]bool
+}
+
+// BlankGauge returns a gauge recording to g, with the values of the given
+// labels blanked. Only additions to the gauge add up across label values.
+func BlankGauge(g metrics.Gauge, labels ...string) metrics.Gauge {
+	return &gauge{Gauge: g, labels: labelSet(labels)}
+}
+
+func (g *gauge) With(lvs ...string) metrics.Gauge {
+	return &gauge{Gauge: g.Gauge.With(blank(g.labels, lvs)...), labels: g.labels}
+}
+
+type histogram struct {
+	metrics.Histogram
+	labels map[string]bool
+}
+
+// BlankHistogram returns a histogram recording to h, with the values of the
+// given labels blanked, so that the observations for all their values are
+// recorded in a single series.
+func BlankHistogram(h metrics.Histogram, labels ...string) metrics.Histogram {
+	return &histogram{Histogram: h, labels: labelSet(labels)}
+}
+
+func (h *histogram) With(lvs ...string) metrics.Histogram {
+	return &histogram{Histogram: h.Histogram.With(blank(h.labels, lvs)...), labels: h.labels}
+}
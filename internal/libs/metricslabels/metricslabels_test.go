@@ -0,0 +1,49 @@
+package metricslabels
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a metric recording the values added with each label values.
+type recorder struct {
+	lvs    []string
+	values map[string]float64
+}
+
+func (r *recorder) With(lvs ...string) metrics.Counter {
+	return &recorder{lvs: append(append([]string{}, r.lvs...), lvs...), values: r.values}
+}
+
+func (r *recorder) Add(delta float64) { r.values[key(r.lvs)] += delta }
+
+func key(lvs []string) string {
+	var k string
+	for _, lv := range lvs {
+		k += lv + ";"
+	}
+	return k
+}
+
+func TestBlankCounter(t *testing.T) {
+	values := make(map[string]float64)
+	c := BlankCounter(&recorder{values: values}, "peer_id")
+
+	c.With("peer_id", "a", "chID", "32").Add(1)
+	c.With("peer_id", "b", "chID", "32").Add(2)
+	c.With("chID", "48", "peer_id", "b").Add(4)
+	c.With("chID", "48").With("peer_id", "c").Add(8)
+
+	require.Equal(t, map[string]float64{
+		"peer_id;;chID;32;": 3,
+		"chID;48;peer_id;;": 12,
+	}, values)
+}
+
+func TestBlankKeepsLabelValues(t *testing.T) {
+	lvs := []string{"peer_id", "a", "chID"}
+	require.Equal(t, []string{"peer_id", "", "chID"}, blank(labelSet([]string{"peer_id", "chID"}), lvs))
+	require.Equal(t, []string{"peer_id", "a", "chID"}, lvs)
+}
@@ -0,0 +1,32 @@
+package sync
+
+import "sync/atomic"
+
+// ForEach calls f with each index of [0, n) across up to workers goroutines,
+// and returns once all the calls have returned. With a single worker, f is
+// called in order in the calling goroutine.
+func ForEach(n, workers int, f func(i int)) {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			f(i)
+		}
+		return
+	}
+
+	next := int64(-1)
+	doneCh := make(chan struct{}, workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			for i := int(atomic.AddInt64(&next, 1)); i < n; i = int(atomic.AddInt64(&next, 1)) {
+				f(i)
+			}
+			doneCh <- struct{}{}
+		}()
+	}
+	for w := 0; w < workers; w++ {
+		<-doneCh
+	}
+}
@@ -0,0 +1,29 @@
+package sync_test
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
+)
+
+func TestForEach(t *testing.T) {
+	for _, workers := range []int{0, 1, 4, 100} {
+		var (
+			calls int64
+			seen  = make([]int64, 50)
+		)
+		tmsync.ForEach(len(seen), workers, func(i int) {
+			atomic.AddInt64(&calls, 1)
+			atomic.AddInt64(&seen[i], 1)
+		})
+		require.EqualValues(t, len(seen), calls, "workers %d", workers)
+		for i, n := range seen {
+			require.EqualValues(t, 1, n, "index %d with workers %d", i, workers)
+		}
+	}
+
+	tmsync.ForEach(0, 4, func(int) { t.Fatal("unexpected call") })
+}
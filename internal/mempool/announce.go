@@ -0,0 +1,139 @@
+package mempool
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+// AnnounceChannelVersion is the version of the mempool channels with which
+// transactions are announced by their keys, see protomem.AnnounceTxs, rather
+// than sent in full. Peers negotiating the default version are sent the full
+// transactions.
+const AnnounceChannelVersion uint32 = 2
+
+// wantTxTimeout is how long a node waits for a transaction it requested from
+// a peer before requesting it from the next peer announcing it.
+const wantTxTimeout = 2 * time.Second
+
+// txAnnouncer is a peer which announced a transaction, with the channel it
+// announced it on.
+type txAnnouncer struct {
+	peer types.NodeID
+	ch   p2p.Channel
+}
+
+// txRequest is the pending request of an announced transaction: the peer it
+// was requested from and when, and the other peers which announced it since,
+// in the order of their announcements.
+type txRequest struct {
+	peer       types.NodeID
+	at         time.Time
+	announcers []txAnnouncer
+}
+
+// txRequests tracks the announced transactions requested from peers, so that
+// each transaction is requested from a single peer at a time, and from the
+// next peer which announced it if that peer does not answer in time.
+type txRequests struct {
+	mtx       sync.Mutex
+	timeout   time.Duration
+	requested map[types.TxKey]*txRequest
+}
+
+func newTxRequests(timeout time.Duration) *txRequests {
+	return &txRequests{
+		timeout:   timeout,
+		requested: make(map[types.TxKey]*txRequest),
+	}
+}
+
+// announced records the announcement of the transaction with the given key by
+// the announcer, and reports whether the transaction should be requested from
+// it now, i.e. it was not requested within the timeout. Otherwise the
+// announcer is queued, to be requested the transaction by retry if the pending
+// request times out.
+func (t *txRequests) announced(key types.TxKey, announcer txAnnouncer, now time.Time) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	req, ok := t.requested[key]
+	if !ok {
+		req = &txRequest{}
+		t.requested[key] = req
+	} else if now.Sub(req.at) < t.timeout {
+		if req.peer == announcer.peer {
+			return false
+		}
+		for _, a := range req.announcers {
+			if a.peer == announcer.peer {
+				return false
+			}
+		}
+		req.announcers = append(req.announcers, announcer)
+		return false
+	}
+	req.peer, req.at = announcer.peer, now
+	return true
+}
+
+// retry returns the keys of the transactions whose requests timed out, by the
+// next peer which announced them, and records their requests to those peers.
+// The requests of the transactions which no other peer announced are
+// forgotten.
+func (t *txRequests) retry(now time.Time) map[txAnnouncer][]types.TxKey {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	var retries map[txAnnouncer][]types.TxKey
+	for key, req := range t.requested {
+		if now.Sub(req.at) < t.timeout {
+			continue
+		}
+		if len(req.announcers) == 0 {
+			delete(t.requested, key)
+			continue
+		}
+		next := req.announcers[0]
+		req.announcers = req.announcers[1:]
+		req.peer, req.at = next.peer, now
+		if retries == nil {
+			retries = make(map[txAnnouncer][]types.TxKey)
+		}
+		retries[next] = append(retries[next], key)
+	}
+	return retries
+}
+
+// received forgets the request of the transaction with the given key, once
+// it is received.
+func (t *txRequests) received(key types.TxKey) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	delete(t.requested, key)
+}
+
+// len returns the number of pending requests.
+func (t *txRequests) len() int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return len(t.requested)
+}
+
+// parseTxKeys converts the keys of an AnnounceTxs or WantTxs message.
+func parseTxKeys(keys [][]byte) ([]types.TxKey, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("empty tx keys received from peer")
+	}
+	txKeys := make([]types.TxKey, len(keys))
+	for i, key := range keys {
+		if len(key) != len(txKeys[i]) {
+			return nil, fmt.Errorf("invalid tx key length %d", len(key))
+		}
+		copy(txKeys[i][:], key)
+	}
+	return txKeys, nil
+}
@@ -0,0 +1,55 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxRequests(t *testing.T) {
+	requests := newTxRequests(time.Second)
+	key1, key2 := types.Tx("tx1").Key(), types.Tx("tx2").Key()
+	peerA := txAnnouncer{peer: types.NodeID("aa")}
+	peerB := txAnnouncer{peer: types.NodeID("bb")}
+	peerC := txAnnouncer{peer: types.NodeID("cc")}
+	now := time.Now()
+
+	require.True(t, requests.announced(key1, peerA, now))
+	require.False(t, requests.announced(key1, peerB, now.Add(100*time.Millisecond)), "requested lately")
+	require.False(t, requests.announced(key1, peerB, now.Add(200*time.Millisecond)), "queued already")
+	require.False(t, requests.announced(key1, peerA, now.Add(200*time.Millisecond)), "requested from it")
+	require.False(t, requests.announced(key1, peerC, now.Add(300*time.Millisecond)))
+	require.True(t, requests.announced(key2, peerA, now.Add(500*time.Millisecond)))
+	require.Equal(t, 2, requests.len())
+	require.Empty(t, requests.retry(now.Add(900*time.Millisecond)))
+
+	// peerA never answers: key1 is requested from the peers which announced
+	// it next, and key2 is forgotten
+	require.Equal(t, map[txAnnouncer][]types.TxKey{peerB: {key1}}, requests.retry(now.Add(time.Second)))
+	require.Empty(t, requests.retry(now.Add(1500*time.Millisecond)))
+	require.Equal(t, 1, requests.len())
+	require.Equal(t, map[txAnnouncer][]types.TxKey{peerC: {key1}}, requests.retry(now.Add(2*time.Second)))
+	require.Empty(t, requests.retry(now.Add(3*time.Second)))
+	require.Zero(t, requests.len())
+
+	// a request which timed out is made again when announced again
+	require.True(t, requests.announced(key1, peerA, now.Add(3*time.Second)))
+	require.True(t, requests.announced(key1, peerB, now.Add(4*time.Second)))
+	requests.received(key1)
+	require.Zero(t, requests.len())
+}
+
+func TestParseTxKeys(t *testing.T) {
+	key := types.Tx("tx").Key()
+	keys, err := parseTxKeys([][]byte{key[:]})
+	require.NoError(t, err)
+	require.Equal(t, []types.TxKey{key}, keys)
+
+	_, err = parseTxKeys(nil)
+	require.Error(t, err)
+	_, err = parseTxKeys([][]byte{key[:], key[1:]})
+	require.Error(t, err)
+}
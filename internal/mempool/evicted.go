@@ -0,0 +1,100 @@
+package mempool
+
+import (
+	"container/list"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// TxStatus is the status of a transaction as known to the mempool, as
+// reported by Mempool.TxStatus.
+type TxStatus struct {
+	// InMempool reports whether the transaction is in the mempool, at
+	// Position in the order in which transactions are reaped. It was added at
+	// Timestamp, and checked at Height.
+	InMempool bool
+	Position  int
+	Timestamp time.Time
+	Height    int64
+
+	// Evicted reports whether the transaction was recently evicted from the
+	// mempool, at EvictedAt and EvictedHeight for EvictedReason, one of the
+	// Evicted* constants or RemovedInvalid.
+	Evicted       bool
+	EvictedReason string
+	EvictedAt     time.Time
+	EvictedHeight int64
+}
+
+// RemovedInvalid is the reason reported by TxStatus for a transaction removed
+// from the mempool because it failed the recheck after a block. It is not
+// reported to an EvictedFunc, as the transaction is no longer valid.
+const RemovedInvalid = "invalid"
+
+// evictedTx is the record of a recently evicted transaction.
+type evictedTx struct {
+	key    types.TxKey
+	id     string
+	reason string
+	time   time.Time
+	height int64
+}
+
+// evictedTxs is a bounded record of the most recently evicted transactions,
+// dropping the oldest records first. It is not thread-safe: the mempool
+// accesses it with its lock held.
+type evictedTxs struct {
+	size  int
+	byKey map[types.TxKey]*list.Element
+	byID  map[string]types.TxKey
+	list  *list.List
+}
+
+func newEvictedTxs(size int) *evictedTxs {
+	return &evictedTxs{
+		size:  size,
+		byKey: make(map[types.TxKey]*list.Element, size),
+		byID:  make(map[string]types.TxKey, size),
+		list:  list.New(),
+	}
+}
+
+// add records the eviction of the transaction with the given key and ID.
+func (e *evictedTxs) add(key types.TxKey, id []byte, reason string, now time.Time, height int64) {
+	if e.size <= 0 {
+		return
+	}
+	e.remove(key)
+	if e.list.Len() >= e.size {
+		e.remove(e.list.Front().Value.(*evictedTx).key)
+	}
+	e.byKey[key] = e.list.PushBack(&evictedTx{key: key, id: string(id), reason: reason, time: now, height: height})
+	e.byID[string(id)] = key
+}
+
+// get returns the record of the eviction of the transaction with the given
+// key, if any.
+func (e *evictedTxs) get(key types.TxKey) (*evictedTx, bool) {
+	elt, ok := e.byKey[key]
+	if !ok {
+		return nil, false
+	}
+	return elt.Value.(*evictedTx), true
+}
+
+// remove removes the record of the transaction with the given key, if any.
+func (e *evictedTxs) remove(key types.TxKey) {
+	if elt, ok := e.byKey[key]; ok {
+		delete(e.byKey, key)
+		delete(e.byID, elt.Value.(*evictedTx).id)
+		e.list.Remove(elt)
+	}
+}
+
+// keyByID returns the key of the recently evicted transaction with the given
+// ID, if any.
+func (e *evictedTxs) keyByID(id []byte) (types.TxKey, bool) {
+	key, ok := e.byID[string(id)]
+	return key, ok
+}
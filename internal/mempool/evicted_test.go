@@ -0,0 +1,56 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEvictedTxs(t *testing.T) {
+	e := newEvictedTxs(2)
+	key := func(s string) types.TxKey { return types.Tx(s).Key() }
+	id := func(s string) []byte { return []byte("id-" + s) }
+	now := time.Now()
+
+	e.add(key("a"), id("a"), EvictedTTLDuration, now, 1)
+	e.add(key("b"), id("b"), EvictedLowPriority, now, 2)
+	got, ok := e.get(key("a"))
+	require.True(t, ok)
+	require.Equal(t, EvictedTTLDuration, got.reason)
+	require.EqualValues(t, 1, got.height)
+
+	// adding a transaction again replaces its record, and makes it the newest
+	e.add(key("a"), id("a"), RemovedInvalid, now, 3)
+	got, ok = e.get(key("a"))
+	require.True(t, ok)
+	require.Equal(t, RemovedInvalid, got.reason)
+
+	// the oldest records are dropped first
+	e.add(key("c"), id("c"), EvictedLowPriority, now, 4)
+	_, ok = e.get(key("b"))
+	require.False(t, ok)
+	_, ok = e.keyByID(id("b"))
+	require.False(t, ok)
+	k, ok := e.keyByID(id("c"))
+	require.True(t, ok)
+	require.Equal(t, key("c"), k)
+	_, ok = e.get(key("a"))
+	require.True(t, ok)
+	_, ok = e.get(key("c"))
+	require.True(t, ok)
+
+	e.remove(key("a"))
+	_, ok = e.get(key("a"))
+	require.False(t, ok)
+	_, ok = e.keyByID(id("a"))
+	require.False(t, ok)
+
+	// nothing is recorded without a size
+	e = newEvictedTxs(0)
+	e.add(key("a"), id("a"), EvictedTTLDuration, now, 1)
+	_, ok = e.get(key("a"))
+	require.False(t, ok)
+}
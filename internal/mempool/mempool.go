@@ -41,6 +41,10 @@ type TxMempool struct {
 	metrics      *Metrics
 	cache        TxCache // seen transactions
 
+	// additional connections to the application for rechecks, see
+	// WithRecheckClients
+	recheckClients []abciclient.Client
+
 	// Atomically-updated fields
 	txsBytes int64 // atomic: the total size of all transactions in the mempool, in bytes
 
@@ -50,11 +54,23 @@ type TxMempool struct {
 	txsAvailable         chan struct{} // one value sent per height when mempool is not empty
 	preCheck             PreCheckFunc
 	postCheck            PostCheckFunc
-	height               int64 // the latest height passed to Update
-
-	txs        *clist.CList // valid transactions (passed CheckTx)
-	txByKey    map[types.TxKey]*clist.CElement
-	txBySender map[string]*clist.CElement // for sender != ""
+	evicted              EvictedFunc
+	pendingEvicted       []pendingEviction // reported to evicted by Unlock
+	evictedTxs           *evictedTxs       // recently evicted transactions, for TxStatus
+	height               int64             // the latest height passed to Update
+	maxTxs               int               // mempool.size, see SetLimits
+	maxTxsBytes          int64             // mempool.max-txs-bytes, see SetLimits
+
+	txs         *clist.CList // valid transactions (passed CheckTx)
+	txByKey     map[types.TxKey]*clist.CElement
+	txsBySender map[string][]*clist.CElement // for sender != ""
+	txKeyByID   map[string]types.TxKey       // by types.Tx.ID, for TxKeyByID
+
+	// The configured lanes, if any, and the number of transactions in each.
+	lanes       []config.MempoolLane
+	laneByName  map[string]int
+	defaultLane int
+	laneSizes   []int
 }
 
 // NewTxMempool constructs a new, empty priority mempool at the specified
@@ -75,12 +91,27 @@ func NewTxMempool(
 		txs:          clist.New(),
 		mtx:          new(sync.RWMutex),
 		txByKey:      make(map[types.TxKey]*clist.CElement),
-		txBySender:   make(map[string]*clist.CElement),
+		txsBySender:  make(map[string][]*clist.CElement),
+		txKeyByID:    make(map[string]types.TxKey),
+		evictedTxs:   newEvictedTxs(cfg.EvictedCacheSize),
+		maxTxs:       cfg.Size,
+		maxTxsBytes:  cfg.MaxTxsBytes,
 	}
 	if cfg.CacheSize > 0 {
 		txmp.cache = NewLRUTxCache(cfg.CacheSize)
 	}
 
+	// The lanes are checked by the config's ValidateBasic.
+	if lanes, err := cfg.MempoolLanes(); err == nil && len(lanes) != 0 {
+		txmp.lanes = lanes
+		txmp.laneByName = make(map[string]int, len(lanes))
+		for i, lane := range lanes {
+			txmp.laneByName[lane.Name] = i
+		}
+		txmp.defaultLane = txmp.laneByName[cfg.DefaultLane]
+		txmp.laneSizes = make([]int, len(lanes))
+	}
+
 	for _, opt := range options {
 		opt(txmp)
 	}
@@ -102,17 +133,53 @@ func WithPostCheck(f PostCheckFunc) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.postCheck = f }
 }
 
+// WithRecheckClients sets additional clients of the application, across which
+// the rechecks after a block are spread with the mempool connection, so that
+// they are checked concurrently by a remote application.
+func WithRecheckClients(clients ...abciclient.Client) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.recheckClients = clients }
+}
+
+// WithEvictedFunc sets a callback invoked for every valid transaction the
+// mempool evicts, either because its TTL expired or to make room for a
+// transaction of higher priority. It is invoked once the mempool lock is
+// released, so it may call the mempool.
+func WithEvictedFunc(f EvictedFunc) TxMempoolOption {
+	return func(txmp *TxMempool) { txmp.evicted = f }
+}
+
 // WithMetrics sets the mempool's metrics collector.
 func WithMetrics(metrics *Metrics) TxMempoolOption {
 	return func(txmp *TxMempool) { txmp.metrics = metrics }
 }
 
+// SetLimits changes the maximum number of transactions in the mempool and
+// their maximum total size, in bytes, set by the config. Lowering them evicts
+// no transaction: new transactions are rejected until the mempool is back
+// under the limits, unless they evict transactions of lower priority.
+func (txmp *TxMempool) SetLimits(maxTxs int, maxTxsBytes int64) {
+	txmp.mtx.Lock()
+	defer txmp.mtx.Unlock()
+	txmp.maxTxs = maxTxs
+	txmp.maxTxsBytes = maxTxsBytes
+}
+
 // Lock obtains a write-lock on the mempool. A caller must be sure to explicitly
 // release the lock when finished.
 func (txmp *TxMempool) Lock() { txmp.mtx.Lock() }
 
-// Unlock releases a write-lock on the mempool.
-func (txmp *TxMempool) Unlock() { txmp.mtx.Unlock() }
+// Unlock releases a write-lock on the mempool, and then reports the
+// transactions evicted while it was held to the evicted callback, so that the
+// callback is not run under the lock.
+func (txmp *TxMempool) Unlock() {
+	pending := txmp.pendingEvicted
+	txmp.pendingEvicted = nil
+	txmp.mtx.Unlock()
+
+	for _, e := range pending {
+		txmp.evicted(e.tx, e.reason)
+	}
+}
 
 // Size returns the number of valid transactions in the mempool. It is
 // thread-safe.
@@ -230,6 +297,8 @@ func (txmp *TxMempool) CheckTx(
 		hash:      tx.Key(),
 		timestamp: time.Now().UTC(),
 		height:    height,
+		local:     txInfo.SenderID == UnknownPeerID,
+		source:    txInfo.SenderNodeID,
 	}
 	wtx.SetPeer(txInfo.SenderID)
 	if cb != nil {
@@ -247,17 +316,63 @@ func (txmp *TxMempool) RemoveTxByKey(txKey types.TxKey) error {
 	return txmp.removeTxByKey(txKey)
 }
 
+// txSource reports the peer that first sent the transaction with the given
+// key, if it is in the mempool and was received from a peer.
+func (txmp *TxMempool) txSource(txKey types.TxKey) (types.NodeID, bool) {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+	if elt, ok := txmp.txByKey[txKey]; ok {
+		w := elt.Value.(*WrappedTx)
+		return w.source, w.source != ""
+	}
+	return "", false
+}
+
+// seenTx reports whether the transaction with the given key is in the mempool
+// or in the cache, recording that the peer with the given ID has it if it is
+// in the mempool.
+func (txmp *TxMempool) seenTx(txKey types.TxKey, peerID uint16) bool {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+	if elt, ok := txmp.txByKey[txKey]; ok {
+		elt.Value.(*WrappedTx).SetPeer(peerID)
+		return true
+	}
+	return txmp.cache.HasKey(txKey)
+}
+
+// getTx returns the transaction with the given key, if it is in the mempool.
+func (txmp *TxMempool) getTx(txKey types.TxKey) (*WrappedTx, bool) {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+	if elt, ok := txmp.txByKey[txKey]; ok {
+		return elt.Value.(*WrappedTx), true
+	}
+	return nil, false
+}
+
+// GetTxByKey returns the transaction with the given key, if it is in the
+// mempool.
+func (txmp *TxMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	if wtx, ok := txmp.getTx(txKey); ok {
+		return wtx.tx, true
+	}
+	return nil, false
+}
+
 // removeTxByKey removes the specified transaction key from the mempool.
 // The caller must hold txmp.mtx exclusively.
 func (txmp *TxMempool) removeTxByKey(key types.TxKey) error {
 	if elt, ok := txmp.txByKey[key]; ok {
 		w := elt.Value.(*WrappedTx)
 		delete(txmp.txByKey, key)
-		delete(txmp.txBySender, w.sender)
+		txmp.removeFromSender(w.sender, elt)
+		txmp.removeTxID(w)
 		txmp.txs.Remove(elt)
 		elt.DetachPrev()
 		elt.DetachNext()
 		atomic.AddInt64(&txmp.txsBytes, -w.Size())
+		txmp.addToLane(w.lane, -1)
 		return nil
 	}
 	return fmt.Errorf("transaction %x not found", key)
@@ -268,11 +383,23 @@ func (txmp *TxMempool) removeTxByKey(key types.TxKey) error {
 func (txmp *TxMempool) removeTxByElement(elt *clist.CElement) {
 	w := elt.Value.(*WrappedTx)
 	delete(txmp.txByKey, w.tx.Key())
-	delete(txmp.txBySender, w.sender)
+	txmp.removeFromSender(w.sender, elt)
+	txmp.removeTxID(w)
 	txmp.txs.Remove(elt)
 	elt.DetachPrev()
 	elt.DetachNext()
 	atomic.AddInt64(&txmp.txsBytes, -w.Size())
+	txmp.addToLane(w.lane, -1)
+}
+
+// addToLane adds n to the number of transactions in the given lane, if lanes
+// are configured. The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) addToLane(lane, n int) {
+	if txmp.laneSizes == nil {
+		return
+	}
+	txmp.laneSizes[lane] += n
+	txmp.metrics.LaneSize.With("lane", txmp.lanes[lane].Name).Set(float64(txmp.laneSizes[lane]))
 }
 
 // Flush purges the contents of the mempool and the cache, leaving both empty.
@@ -292,6 +419,54 @@ func (txmp *TxMempool) Flush() {
 	txmp.cache.Reset()
 }
 
+// TxStatus returns the status of the transaction with the given key in the
+// mempool: its position in the reap order if it is in the mempool, or the
+// reason of its eviction if it was among the mempool.evicted-cache-size
+// transactions evicted last.
+func (txmp *TxMempool) TxStatus(txKey types.TxKey) TxStatus {
+	txmp.mtx.RLock()
+	_, ok := txmp.txByKey[txKey]
+	txmp.mtx.RUnlock()
+
+	// the mempool is only sorted for the transactions it has
+	if ok {
+		for i, w := range txmp.reapOrder() {
+			if w.hash == txKey {
+				return TxStatus{
+					InMempool: true,
+					Position:  i,
+					Timestamp: w.timestamp,
+					Height:    w.height,
+				}
+			}
+		}
+	}
+
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+	if e, ok := txmp.evictedTxs.get(txKey); ok {
+		return TxStatus{
+			Evicted:       true,
+			EvictedReason: e.reason,
+			EvictedAt:     e.time,
+			EvictedHeight: e.height,
+		}
+	}
+	return TxStatus{}
+}
+
+// TxKeyByID returns the key of the transaction with the given ID, by the tx
+// hash function of the node, if it is in the mempool or among the recently
+// evicted transactions.
+func (txmp *TxMempool) TxKeyByID(id []byte) (types.TxKey, bool) {
+	txmp.mtx.RLock()
+	defer txmp.mtx.RUnlock()
+	if key, ok := txmp.txKeyByID[string(id)]; ok {
+		return key, true
+	}
+	return txmp.evictedTxs.keyByID(id)
+}
+
 // allEntriesSorted returns a slice of all the transactions currently in the
 // mempool, sorted in nonincreasing order by priority with ties broken by
 // increasing order of arrival time.
@@ -312,10 +487,40 @@ func (txmp *TxMempool) allEntriesSorted() []*WrappedTx {
 	return all
 }
 
+// reapOrder returns all the transactions currently in the mempool, in the
+// order in which they are reaped. Without lanes, this is the order of
+// allEntriesSorted. With lanes, the transactions of each lane are in that
+// order, and the lanes are interleaved by taking, in turn, up to the weight of
+// each lane transactions from it.
+func (txmp *TxMempool) reapOrder() []*WrappedTx {
+	all := txmp.allEntriesSorted()
+	if len(txmp.lanes) < 2 {
+		return all
+	}
+
+	byLane := make([][]*WrappedTx, len(txmp.lanes))
+	for _, w := range all {
+		byLane[w.lane] = append(byLane[w.lane], w)
+	}
+	out := make([]*WrappedTx, 0, len(all))
+	for len(out) < len(all) {
+		for i, lane := range txmp.lanes {
+			n := lane.Weight
+			if n > len(byLane[i]) {
+				n = len(byLane[i])
+			}
+			out = append(out, byLane[i][:n]...)
+			byLane[i] = byLane[i][n:]
+		}
+	}
+	return out
+}
+
 // ReapMaxBytesMaxGas returns a slice of valid transactions that fit within the
 // size and gas constraints. The results are ordered by nonincreasing priority,
-// with ties broken by increasing order of arrival.  Reaping transactions does
-// not remove them from the mempool.
+// with ties broken by increasing order of arrival, and interleaved by lane if
+// lanes are configured. Reaping transactions does not remove them from the
+// mempool.
 //
 // If maxBytes < 0, no limit is set on the total size in bytes.
 // If maxGas < 0, no limit is set on the total gas cost.
@@ -326,7 +531,7 @@ func (txmp *TxMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	var totalGas, totalBytes int64
 
 	var keep []types.Tx //nolint:prealloc
-	for _, w := range txmp.allEntriesSorted() {
+	for _, w := range txmp.reapOrder() {
 		// N.B. When computing byte size, we need to include the overhead for
 		// encoding as protobuf to send to the application.
 		totalGas += w.gasWanted
@@ -349,7 +554,8 @@ func (txmp *TxMempool) TxsFront() *clist.CElement { return txmp.txs.Front() }
 
 // ReapMaxTxs returns up to max transactions from the mempool. The results are
 // ordered by nonincreasing priority with ties broken by increasing order of
-// arrival. Reaping transactions does not remove them from the mempool.
+// arrival, and interleaved by lane if lanes are configured. Reaping
+// transactions does not remove them from the mempool.
 //
 // If max < 0, all transactions in the mempool are reaped.
 //
@@ -358,7 +564,7 @@ func (txmp *TxMempool) TxsFront() *clist.CElement { return txmp.txs.Front() }
 func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 	var keep []types.Tx //nolint:prealloc
 
-	for _, w := range txmp.allEntriesSorted() {
+	for _, w := range txmp.reapOrder() {
 		if max >= 0 && len(keep) >= max {
 			break
 		}
@@ -374,7 +580,10 @@ func (txmp *TxMempool) ReapMaxTxs(max int) types.Txs {
 //
 // If the configuration enables recheck, Update sends each remaining
 // transaction after removing blockTxs to the ABCI CheckTx method.  Any
-// transactions marked as invalid during recheck are also removed.
+// transactions marked as invalid during recheck are also removed. In the
+// partial recheck mode, only the transactions without a sender or whose sender
+// is reported as affected by the block in the recheck events of
+// deliverTxResponses are rechecked, see EventTypeRecheck.
 //
 // The caller must hold an exclusive mempool lock (by calling txmp.Lock) before
 // calling Update.
@@ -426,7 +635,7 @@ func (txmp *TxMempool) Update(
 	txmp.metrics.Size.Set(float64(size))
 	if size > 0 {
 		if recheck {
-			txmp.recheckTransactions(ctx)
+			txmp.recheckTransactions(ctx, deliverTxResponses)
 		} else {
 			txmp.notifyTxsAvailable()
 		}
@@ -449,7 +658,7 @@ func (txmp *TxMempool) Update(
 // Finally, the new transaction is added and size stats updated.
 func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.ResponseCheckTx) error {
 	txmp.mtx.Lock()
-	defer txmp.mtx.Unlock()
+	defer txmp.Unlock()
 
 	var err error
 	if txmp.postCheck != nil {
@@ -460,7 +669,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 		txmp.logger.Info(
 			"rejected bad transaction",
 			"priority", wtx.Priority(),
-			"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+			"tx", fmt.Sprintf("%X", wtx.tx.ID()),
 			"peer_id", wtx.peers,
 			"code", checkTxRes.Code,
 			"post_check_err", err,
@@ -486,24 +695,58 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 	priority := checkTxRes.Priority
 	sender := checkTxRes.Sender
 
-	// Disallow multiple concurrent transactions from the same sender assigned
-	// by the ABCI application. As a special case, an empty sender is not
-	// restricted.
+	// The transactions to evict to make room for the new one. They are only
+	// evicted once the new one is admitted, so that a rejected transaction
+	// never evicts any.
+	var evictions []eviction
+
+	// Limit the transactions of the same sender assigned by the ABCI
+	// application, by default to a single one. As a special case, an empty
+	// sender is not restricted.
 	if sender != "" {
-		elt, ok := txmp.txBySender[sender]
-		if ok {
-			w := elt.Value.(*WrappedTx)
+		victims, ok := txmp.senderVictims(sender, priority, checkTxRes.GasWanted)
+		if !ok {
+			txmp.cache.Remove(wtx.tx)
 			txmp.logger.Debug(
-				"rejected valid incoming transaction; tx already exists for sender",
-				"tx", fmt.Sprintf("%X", w.tx.Hash()),
+				"rejected valid incoming transaction; sender is over its quota",
+				"tx", tmstrings.LazySprintf("%X", wtx.tx.ID()),
 				"sender", sender,
 			)
 			txmp.metrics.RejectedTxs.Add(1)
-			// TODO(creachadair): Report an error for a duplicate sender.
+			// TODO(creachadair): Report an error for a sender over its quota.
 			// This is an API change, unfortunately, but should be made safe if it isn't.
-			// fmt.Errorf("transaction rejected: tx already exists for sender %q (%X)", sender, w.tx.Hash())
+			// fmt.Errorf("transaction rejected: sender %q is over its quota (%X)", sender, wtx.tx.ID())
 			return nil
 		}
+		for _, victim := range victims {
+			evictions = append(evictions, eviction{
+				elt:    victim,
+				reason: EvictedSenderQuota,
+				msg:    "evicted valid existing transaction; sender over its quota",
+			})
+		}
+	}
+
+	// If lanes are configured, the lane of the transaction must have room for
+	// it, possibly by evicting a lower-priority transaction from the lane.
+	wtx.lane = txmp.laneFor(checkTxRes.Lane)
+	victim, ok := txmp.laneVictim(wtx.lane, priority, evictions)
+	if !ok {
+		txmp.cache.Remove(wtx.tx)
+		txmp.logger.Error(
+			"rejected valid incoming transaction; mempool lane is full",
+			"tx", fmt.Sprintf("%X", wtx.tx.ID()),
+			"lane", txmp.lanes[wtx.lane].Name,
+		)
+		txmp.metrics.RejectedTxs.Add(1)
+		return nil
+	}
+	if victim != nil {
+		evictions = append(evictions, eviction{
+			elt:    victim,
+			reason: EvictedLowPriority,
+			msg:    "evicted valid existing transaction; mempool lane full",
+		})
 	}
 
 	// At this point the application has ruled the transaction valid, but the
@@ -511,72 +754,36 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 	// priority than the application assigned to this new one, and evict as many
 	// of them as necessary to make room for tx. If no such items exist, we
 	// discard tx.
-
-	if err := txmp.canAddTx(wtx); err != nil {
-		var victims []*clist.CElement // eligible transactions for eviction
-		var victimBytes int64         // total size of victims
-		for cur := txmp.txs.Front(); cur != nil; cur = cur.Next() {
-			cw := cur.Value.(*WrappedTx)
-			if cw.priority < priority {
-				victims = append(victims, cur)
-				victimBytes += cw.Size()
-			}
-		}
-
-		// If there are no suitable eviction candidates, or the total size of
-		// those candidates is not enough to make room for the new transaction,
-		// drop the new one.
-		if len(victims) == 0 || victimBytes < wtx.Size() {
-			txmp.cache.Remove(wtx.tx)
-			txmp.logger.Error(
-				"rejected valid incoming transaction; mempool is full",
-				"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
-				"err", err.Error(),
-			)
-			txmp.metrics.RejectedTxs.Add(1)
-			// TODO(creachadair): Report an error for a full mempool.
-			// This is an API change, unfortunately, but should be made safe if it isn't.
-			// fmt.Errorf("transaction rejected: mempool is full (%X)", wtx.tx.Hash())
-			return nil
-		}
-
+	victims, err := txmp.fullVictims(wtx, priority, evictions)
+	if err != nil {
+		txmp.cache.Remove(wtx.tx)
+		txmp.logger.Error(
+			"rejected valid incoming transaction; mempool is full",
+			"tx", fmt.Sprintf("%X", wtx.tx.ID()),
+			"err", err.Error(),
+		)
+		txmp.metrics.RejectedTxs.Add(1)
+		// TODO(creachadair): Report an error for a full mempool.
+		// This is an API change, unfortunately, but should be made safe if it isn't.
+		// fmt.Errorf("transaction rejected: mempool is full (%X)", wtx.tx.ID())
+		return nil
+	}
+	if len(victims) > 0 {
 		txmp.logger.Debug("evicting lower-priority transactions",
-			"new_tx", tmstrings.LazySprintf("%X", wtx.tx.Hash()),
+			"new_tx", tmstrings.LazySprintf("%X", wtx.tx.ID()),
 			"new_priority", priority,
 		)
-
-		// Sort lowest priority items first so they will be evicted first.  Break
-		// ties in favor of newer items (to maintain FIFO semantics in a group).
-		sort.Slice(victims, func(i, j int) bool {
-			iw := victims[i].Value.(*WrappedTx)
-			jw := victims[j].Value.(*WrappedTx)
-			if iw.Priority() == jw.Priority() {
-				return iw.timestamp.After(jw.timestamp)
-			}
-			return iw.Priority() < jw.Priority()
+	}
+	for _, victim := range victims {
+		evictions = append(evictions, eviction{
+			elt:    victim,
+			reason: EvictedLowPriority,
+			msg:    "evicted valid existing transaction; mempool full",
 		})
+	}
 
-		// Evict as many of the victims as necessary to make room.
-		var evictedBytes int64
-		for _, vic := range victims {
-			w := vic.Value.(*WrappedTx)
-
-			txmp.logger.Debug(
-				"evicted valid existing transaction; mempool full",
-				"old_tx", tmstrings.LazySprintf("%X", w.tx.Hash()),
-				"old_priority", w.priority,
-			)
-			txmp.removeTxByElement(vic)
-			txmp.cache.Remove(w.tx)
-			txmp.metrics.EvictedTxs.Add(1)
-
-			// We may not need to evict all the eligible transactions.  Bail out
-			// early if we have made enough room.
-			evictedBytes += w.Size()
-			if evictedBytes >= wtx.Size() {
-				break
-			}
-		}
+	for _, e := range evictions {
+		txmp.evict(e)
 	}
 
 	wtx.SetGasWanted(checkTxRes.GasWanted)
@@ -589,7 +796,7 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 	txmp.logger.Debug(
 		"inserted new valid transaction",
 		"priority", wtx.Priority(),
-		"tx", tmstrings.LazySprintf("%X", wtx.tx.Hash()),
+		"tx", tmstrings.LazySprintf("%X", wtx.tx.ID()),
 		"height", txmp.height,
 		"num_txs", txmp.Size(),
 	)
@@ -597,14 +804,235 @@ func (txmp *TxMempool) addNewTransaction(wtx *WrappedTx, checkTxRes *abci.Respon
 	return nil
 }
 
+// laneFor returns the index of the lane with the given name, or of the default
+// lane if there is no such lane.
+func (txmp *TxMempool) laneFor(name string) int {
+	if i, ok := txmp.laneByName[name]; ok {
+		return i
+	}
+	return txmp.defaultLane
+}
+
+// eviction is a transaction to evict to make room for a new one.
+type eviction struct {
+	elt    *clist.CElement
+	reason string // the reason reported to the evicted callback
+	msg    string // the message logged
+}
+
+// isEvicted reports whether elt is one of the evictions.
+func isEvicted(evictions []eviction, elt *clist.CElement) bool {
+	for _, e := range evictions {
+		if e.elt == elt {
+			return true
+		}
+	}
+	return false
+}
+
+// evict removes the transaction of e from the mempool and the cache, and
+// reports its eviction.
+//
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) evict(e eviction) {
+	w := e.elt.Value.(*WrappedTx)
+	txmp.logger.Debug(
+		e.msg,
+		"old_tx", tmstrings.LazySprintf("%X", w.tx.ID()),
+		"old_priority", w.priority,
+	)
+	txmp.removeTxByElement(e.elt)
+	txmp.cache.Remove(w.tx)
+	txmp.metrics.EvictedTxs.Add(1)
+	txmp.notifyEvicted(w, e.reason)
+}
+
+// laneVictim reports whether the given lane has room for a transaction with
+// the given priority once the given evictions are done. If the lane is full,
+// it returns the transaction with the lowest priority in the lane to evict to
+// make room, provided its priority is lower than the given one, or nil if
+// there is room. Ties are broken in favor of newer items.
+//
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) laneVictim(lane int, priority int64, evictions []eviction) (*clist.CElement, bool) {
+	if txmp.lanes == nil || txmp.lanes[lane].Size == 0 {
+		return nil, true
+	}
+	size := txmp.laneSizes[lane]
+	for _, e := range evictions {
+		if e.elt.Value.(*WrappedTx).lane == lane {
+			size--
+		}
+	}
+	if size < txmp.lanes[lane].Size {
+		return nil, true
+	}
+
+	var victim *clist.CElement
+	for cur := txmp.txs.Front(); cur != nil; cur = cur.Next() {
+		cw := cur.Value.(*WrappedTx)
+		if cw.lane != lane || cw.priority >= priority || isEvicted(evictions, cur) {
+			continue
+		}
+		if victim == nil {
+			victim = cur
+			continue
+		}
+		vw := victim.Value.(*WrappedTx)
+		if cw.priority < vw.priority || (cw.priority == vw.priority && cw.timestamp.After(vw.timestamp)) {
+			victim = cur
+		}
+	}
+	return victim, victim != nil
+}
+
+// fullVictims returns the transactions to evict for wtx, with the given
+// priority, to fit in the mempool once the given evictions are done: the
+// lowest-priority transactions with a lower priority than the given one,
+// breaking ties in favor of newer items. It returns an error if the mempool
+// is full and evicting all of them is not enough.
+//
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) fullVictims(wtx *WrappedTx, priority int64, evictions []eviction) ([]*clist.CElement, error) {
+	err := txmp.canAddTx(wtx)
+	if err == nil {
+		return nil, nil
+	}
+
+	numTxs, txBytes := txmp.Size(), txmp.SizeBytes()
+	for _, e := range evictions {
+		numTxs--
+		txBytes -= e.elt.Value.(*WrappedTx).Size()
+	}
+	fits := func() bool {
+		return numTxs < txmp.maxTxs && wtx.Size()+txBytes <= txmp.maxTxsBytes
+	}
+	if fits() {
+		return nil, nil
+	}
+
+	var victims []*clist.CElement // eligible transactions for eviction
+	for cur := txmp.txs.Front(); cur != nil; cur = cur.Next() {
+		if cur.Value.(*WrappedTx).priority < priority && !isEvicted(evictions, cur) {
+			victims = append(victims, cur)
+		}
+	}
+
+	// Sort lowest priority items first so they will be evicted first.  Break
+	// ties in favor of newer items (to maintain FIFO semantics in a group).
+	sort.Slice(victims, func(i, j int) bool {
+		iw := victims[i].Value.(*WrappedTx)
+		jw := victims[j].Value.(*WrappedTx)
+		if iw.Priority() == jw.Priority() {
+			return iw.timestamp.After(jw.timestamp)
+		}
+		return iw.Priority() < jw.Priority()
+	})
+
+	// We may not need to evict all the eligible transactions: stop as soon
+	// as there is enough room.
+	for i, victim := range victims {
+		numTxs--
+		txBytes -= victim.Value.(*WrappedTx).Size()
+		if fits() {
+			return victims[:i+1], nil
+		}
+	}
+	return nil, err
+}
+
+// senderVictims reports whether there is room in the mempool for a
+// transaction of the sender with the given priority and gas wanted, within the
+// max-txs-per-sender and max-gas-per-sender limits. If the sender is over
+// either limit with the transaction, it returns the lowest-priority
+// transactions of the sender to evict to make room, provided their priority is
+// lower than the given one and evicting them is enough. Ties are broken in
+// favor of newer items.
+//
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) senderVictims(sender string, priority, gasWanted int64) ([]*clist.CElement, bool) {
+	maxTxs, maxGas := txmp.config.MaxTxsPerSender, txmp.config.MaxGasPerSender
+	if maxGas > 0 && gasWanted > maxGas {
+		return nil, false
+	}
+	elts := txmp.txsBySender[sender]
+	numTxs, gas := len(elts)+1, gasWanted
+	for _, elt := range elts {
+		gas += elt.Value.(*WrappedTx).GasWanted()
+	}
+	overQuota := func() bool {
+		return (maxTxs > 0 && numTxs > maxTxs) || (maxGas > 0 && gas > maxGas)
+	}
+	if !overQuota() {
+		return nil, true
+	}
+
+	var victims []*clist.CElement
+	for _, elt := range elts {
+		if elt.Value.(*WrappedTx).priority < priority {
+			victims = append(victims, elt)
+		}
+	}
+	sort.Slice(victims, func(i, j int) bool {
+		iw := victims[i].Value.(*WrappedTx)
+		jw := victims[j].Value.(*WrappedTx)
+		if iw.priority == jw.priority {
+			return iw.timestamp.After(jw.timestamp)
+		}
+		return iw.priority < jw.priority
+	})
+	var evict int
+	for ; evict < len(victims) && overQuota(); evict++ {
+		numTxs--
+		gas -= victims[evict].Value.(*WrappedTx).GasWanted()
+	}
+	if overQuota() {
+		return nil, false
+	}
+	return victims[:evict], true
+}
+
+// removeFromSender removes the element from the transactions of the sender.
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) removeFromSender(sender string, elt *clist.CElement) {
+	if sender == "" {
+		return
+	}
+	elts := txmp.txsBySender[sender]
+	for i, e := range elts {
+		if e == elt {
+			elts = append(elts[:i], elts[i+1:]...)
+			break
+		}
+	}
+	if len(elts) == 0 {
+		delete(txmp.txsBySender, sender)
+		return
+	}
+	txmp.txsBySender[sender] = elts
+}
+
+// removeTxID removes the ID of w from txKeyByID, unless the ID, colliding
+// with the one of another tx, was since mapped to that tx.
+// The caller must hold txmp.mtx exclusively.
+func (txmp *TxMempool) removeTxID(w *WrappedTx) {
+	id := string(w.tx.ID())
+	if key, ok := txmp.txKeyByID[id]; ok && key == w.hash {
+		delete(txmp.txKeyByID, id)
+	}
+}
+
 func (txmp *TxMempool) insertTx(wtx *WrappedTx) {
 	elt := txmp.txs.PushBack(wtx)
 	txmp.txByKey[wtx.tx.Key()] = elt
+	txmp.txKeyByID[string(wtx.tx.ID())] = wtx.hash
+	txmp.evictedTxs.remove(wtx.hash)
 	if s := wtx.Sender(); s != "" {
-		txmp.txBySender[s] = elt
+		txmp.txsBySender[s] = append(txmp.txsBySender[s], elt)
 	}
 
 	atomic.AddInt64(&txmp.txsBytes, wtx.Size())
+	txmp.addToLane(wtx.lane, 1)
 }
 
 // handleRecheckResult handles the responses from ABCI CheckTx calls issued
@@ -641,11 +1069,12 @@ func (txmp *TxMempool) handleRecheckResult(tx types.Tx, checkTxRes *abci.Respons
 	txmp.logger.Debug(
 		"existing transaction no longer valid; failed re-CheckTx callback",
 		"priority", wtx.Priority(),
-		"tx", fmt.Sprintf("%X", wtx.tx.Hash()),
+		"tx", fmt.Sprintf("%X", wtx.tx.ID()),
 		"err", err,
 		"code", checkTxRes.Code,
 	)
 	txmp.removeTxByElement(elt)
+	txmp.evictedTxs.add(wtx.hash, wtx.tx.ID(), RemovedInvalid, time.Now(), txmp.height)
 	txmp.metrics.FailedTxs.Add(1)
 	if !txmp.config.KeepInvalidTxsInCache {
 		txmp.cache.Remove(wtx.tx)
@@ -653,55 +1082,85 @@ func (txmp *TxMempool) handleRecheckResult(tx types.Tx, checkTxRes *abci.Respons
 	txmp.metrics.Size.Set(float64(txmp.Size()))
 }
 
-// recheckTransactions initiates re-CheckTx ABCI calls for all the transactions
-// currently in the mempool. It reports the number of recheck calls that were
-// successfully initiated.
+// recheckTransactions initiates re-CheckTx ABCI calls for the transactions
+// currently in the mempool: all of them, or in the partial recheck mode those
+// affected by the block of the given transaction results. The calls are spread
+// across the mempool connection and the recheck clients.
 //
 // Precondition: The mempool is not empty.
 // The caller must hold txmp.mtx exclusively.
-func (txmp *TxMempool) recheckTransactions(ctx context.Context) {
+func (txmp *TxMempool) recheckTransactions(ctx context.Context, txResults []*abci.ExecTxResult) {
 	if txmp.Size() == 0 {
 		panic("mempool: cannot run recheck on an empty mempool")
 	}
-	txmp.logger.Debug(
-		"executing re-CheckTx for all remaining transactions",
-		"num_txs", txmp.Size(),
-		"height", txmp.height,
+
+	var (
+		senders map[string]bool
+		partial bool
 	)
+	if txmp.config.RecheckMode == config.RecheckModePartial {
+		senders, partial = recheckSenders(txResults)
+	}
 
 	// Collect transactions currently in the mempool requiring recheck.
 	wtxs := make([]*WrappedTx, 0, txmp.txs.Len())
 	for e := txmp.txs.Front(); e != nil; e = e.Next() {
-		wtxs = append(wtxs, e.Value.(*WrappedTx))
+		wtx := e.Value.(*WrappedTx)
+		if partial && wtx.Sender() != "" && !senders[wtx.Sender()] {
+			continue
+		}
+		wtxs = append(wtxs, wtx)
+	}
+	skipped := txmp.Size() - len(wtxs)
+	txmp.metrics.RecheckSkippedTxs.Add(float64(skipped))
+	txmp.logger.Debug(
+		"executing re-CheckTx for remaining transactions",
+		"num_txs", len(wtxs),
+		"num_skipped", skipped,
+		"height", txmp.height,
+	)
+	if len(wtxs) == 0 {
+		txmp.notifyTxsAvailable()
+		return
+	}
+
+	clients := append([]abciclient.Client{txmp.proxyAppConn}, txmp.recheckClients...)
+	concurrency := txmp.config.RecheckConcurrency
+	if concurrency == 0 {
+		concurrency = 2 * runtime.NumCPU()
 	}
 
 	// Issue CheckTx calls for each remaining transaction, and when all the
 	// rechecks are complete signal watchers that transactions may be available.
 	go func() {
-		g, start := taskgroup.New(nil).Limit(2 * runtime.NumCPU())
+		startTime := time.Now()
+		g, start := taskgroup.New(nil).Limit(concurrency)
 
-		for _, wtx := range wtxs {
-			wtx := wtx
+		for i, wtx := range wtxs {
+			wtx, client := wtx, clients[i%len(clients)]
 			start(func() error {
-				rsp, err := txmp.proxyAppConn.CheckTx(ctx, &abci.RequestCheckTx{
+				rsp, err := client.CheckTx(ctx, &abci.RequestCheckTx{
 					Tx:   wtx.tx,
 					Type: abci.CheckTxType_Recheck,
 				})
 				if err != nil {
 					txmp.logger.Error("failed to execute CheckTx during recheck",
-						"err", err, "hash", fmt.Sprintf("%x", wtx.tx.Hash()))
+						"err", err, "hash", fmt.Sprintf("%x", wtx.tx.ID()))
 				} else {
 					txmp.handleRecheckResult(wtx.tx, rsp)
 				}
 				return nil
 			})
 		}
-		if err := txmp.proxyAppConn.Flush(ctx); err != nil {
-			txmp.logger.Error("failed to flush transactions during recheck", "err", err)
+		for _, client := range clients {
+			if err := client.Flush(ctx); err != nil {
+				txmp.logger.Error("failed to flush transactions during recheck", "err", err)
+			}
 		}
 
 		// When recheck is complete, trigger a notification for more transactions.
 		_ = g.Wait()
+		txmp.metrics.RecheckDurationSeconds.Observe(time.Since(startTime).Seconds())
 		txmp.mtx.Lock()
 		defer txmp.mtx.Unlock()
 		txmp.notifyTxsAvailable()
@@ -711,16 +1170,18 @@ func (txmp *TxMempool) recheckTransactions(ctx context.Context) {
 // canAddTx returns an error if we cannot insert the provided *WrappedTx into
 // the mempool due to mempool configured constraints. Otherwise, nil is
 // returned and the transaction can be inserted into the mempool.
+//
+// The caller must hold txmp.mtx exclusively.
 func (txmp *TxMempool) canAddTx(wtx *WrappedTx) error {
 	numTxs := txmp.Size()
 	txBytes := txmp.SizeBytes()
 
-	if numTxs >= txmp.config.Size || wtx.Size()+txBytes > txmp.config.MaxTxsBytes {
+	if numTxs >= txmp.maxTxs || wtx.Size()+txBytes > txmp.maxTxsBytes {
 		return types.ErrMempoolIsFull{
 			NumTxs:      numTxs,
-			MaxTxs:      txmp.config.Size,
+			MaxTxs:      txmp.maxTxs,
 			TxsBytes:    txBytes,
-			MaxTxsBytes: txmp.config.MaxTxsBytes,
+			MaxTxsBytes: txmp.maxTxsBytes,
 		}
 	}
 
@@ -749,15 +1210,34 @@ func (txmp *TxMempool) purgeExpiredTxs(blockHeight int64) {
 			txmp.removeTxByElement(cur)
 			txmp.cache.Remove(w.tx)
 			txmp.metrics.EvictedTxs.Add(1)
+			txmp.notifyEvicted(w, EvictedTTLNumBlocks)
 		} else if txmp.config.TTLDuration > 0 && now.Sub(w.timestamp) > txmp.config.TTLDuration {
 			txmp.removeTxByElement(cur)
 			txmp.cache.Remove(w.tx)
 			txmp.metrics.EvictedTxs.Add(1)
+			txmp.notifyEvicted(w, EvictedTTLDuration)
 		}
 		cur = next
 	}
 }
 
+// notifyEvicted records the eviction of w, and queues it for the evicted
+// callback, if one is set, which is run by Unlock.
+//
+// The caller must hold txmp.mtx exclusively, and release it with Unlock.
+func (txmp *TxMempool) notifyEvicted(w *WrappedTx, reason string) {
+	txmp.evictedTxs.add(w.hash, w.tx.ID(), reason, time.Now(), txmp.height)
+	if txmp.evicted != nil {
+		txmp.pendingEvicted = append(txmp.pendingEvicted, pendingEviction{tx: w.tx, reason: reason})
+	}
+}
+
+// pendingEviction is an eviction awaiting the evicted callback.
+type pendingEviction struct {
+	tx     types.Tx
+	reason string
+}
+
 func (txmp *TxMempool) notifyTxsAvailable() {
 	if txmp.Size() == 0 {
 		return // nothing to do
@@ -26,7 +26,7 @@ func BenchmarkTxMempool_CheckTx(b *testing.B) {
 	// setup the cache and the mempool number for hitting GetEvictableTxs during the
 	// benchmark. 5000 is the current default mempool size in the TM config.
 	txmp := setup(b, client, 10000)
-	txmp.config.Size = 5000
+	txmp.SetLimits(5000, txmp.config.MaxTxsBytes)
 
 	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 	const peerID = 1
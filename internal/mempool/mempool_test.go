@@ -242,8 +242,7 @@ func TestTxMempool_Eviction(t *testing.T) {
 	t.Cleanup(client.Wait)
 
 	txmp := setup(t, client, 1000)
-	txmp.config.Size = 5
-	txmp.config.MaxTxsBytes = 60
+	txmp.SetLimits(5, 60)
 	txExists := func(spec string) bool {
 		txmp.Lock()
 		defer txmp.Unlock()
@@ -543,6 +542,73 @@ func TestTxMempool_CheckTxSameSender(t *testing.T) {
 	require.Equal(t, 1, txmp.Size())
 }
 
+func TestTxMempool_SenderQuota(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	evicted := make(map[string]string)
+	txmp := setup(t, client, 100, WithEvictedFunc(func(tx types.Tx, reason string) {
+		evicted[string(tx)] = reason
+	}))
+	txmp.config.MaxTxsPerSender = 2
+
+	mustCheckTx(ctx, t, txmp, "s=a=2")
+	mustCheckTx(ctx, t, txmp, "s=b=1")
+	mustCheckTx(ctx, t, txmp, "r=c=0") // another sender
+	require.Equal(t, 3, txmp.Size())
+
+	// The sender is at its quota: a transaction with a priority not higher
+	// than all the others of the sender is rejected.
+	mustCheckTx(ctx, t, txmp, "s=d=1")
+	require.Equal(t, 3, txmp.Size())
+	require.Empty(t, evicted)
+
+	// A transaction with a higher priority evicts the lowest one of the sender.
+	mustCheckTx(ctx, t, txmp, "s=e=3")
+	require.Equal(t, 3, txmp.Size())
+	require.Equal(t, map[string]string{"s=b=1": EvictedSenderQuota}, evicted)
+	require.Len(t, txmp.txsBySender["s"], 2)
+
+	// Removing transactions frees room in the quota of the sender.
+	require.NoError(t, txmp.RemoveTxByKey(types.Tx("s=a=2").Key()))
+	mustCheckTx(ctx, t, txmp, "s=f=0")
+	require.Equal(t, 3, txmp.Size())
+	require.Len(t, txmp.txsBySender["s"], 2)
+
+	// Each transaction of the test application wants 1 gas: the gas quota of
+	// the sender evicts the transactions with the lowest priority, newest
+	// first, until the new one fits.
+	txmp.config.MaxTxsPerSender = 0
+	txmp.config.MaxGasPerSender = 3
+	mustCheckTx(ctx, t, txmp, "s=g=0")
+	require.Equal(t, 4, txmp.Size())
+	evicted = make(map[string]string)
+	mustCheckTx(ctx, t, txmp, "s=h=1")
+	require.Equal(t, 4, txmp.Size())
+	require.Equal(t, map[string]string{"s=g=0": EvictedSenderQuota}, evicted)
+
+	// Without limits, the transactions of the sender are not restricted.
+	txmp.config.MaxGasPerSender = 0
+	mustCheckTx(ctx, t, txmp, "s=i=0")
+	require.Equal(t, 5, txmp.Size())
+	require.Len(t, txmp.txsBySender["s"], 4)
+
+	// A transaction making room in the quota of the sender, but not fitting
+	// in the full mempool, evicts nothing.
+	txmp.config.MaxTxsPerSender = 4
+	txmp.maxTxsBytes = txmp.SizeBytes()
+	evicted = make(map[string]string)
+	mustCheckTx(ctx, t, txmp, "s="+strings.Repeat("j", 40)+"=5")
+	require.Equal(t, 5, txmp.Size())
+	require.Empty(t, evicted)
+}
+
 func TestTxMempool_ConcurrentTxs(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode")
@@ -675,6 +741,129 @@ func TestTxMempool_ExpiredTxs_NumBlocks(t *testing.T) {
 	require.GreaterOrEqual(t, txmp.Size(), 45)
 }
 
+func TestTxMempool_EvictedFunc(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	// the callback is run once the mempool is unlocked, so it can call the
+	// mempool
+	var txmp *TxMempool
+	evicted := make(map[types.TxKey]string)
+	txmp = setup(t, client, 500, WithEvictedFunc(func(tx types.Tx, reason string) {
+		require.Equal(t, reason, txmp.TxStatus(tx.Key()).EvictedReason)
+		evicted[tx.Key()] = reason
+	}))
+	txmp.height = 100
+	txmp.config.TTLNumBlocks = 10
+
+	tTxs := checkTxs(ctx, t, txmp, 10, 0)
+	require.Equal(t, len(tTxs), txmp.Size())
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, txmp.height+11, nil, nil, nil, nil, false))
+	txmp.Unlock()
+
+	require.Zero(t, txmp.Size())
+	require.Len(t, evicted, len(tTxs))
+	for _, tx := range tTxs {
+		require.Equal(t, EvictedTTLNumBlocks, evicted[tx.tx.Key()])
+	}
+}
+
+func TestTxMempool_TxStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 500)
+	txmp.height = 100
+	txmp.config.TTLNumBlocks = 10
+
+	tTxs := checkTxs(ctx, t, txmp, 10, 0)
+	require.Equal(t, len(tTxs), txmp.Size())
+
+	// the positions are the ones in the reap order
+	for i, tx := range txmp.ReapMaxTxs(-1) {
+		status := txmp.TxStatus(tx.Key())
+		require.True(t, status.InMempool)
+		require.Equal(t, i, status.Position)
+		require.EqualValues(t, 100, status.Height)
+		require.False(t, status.Timestamp.IsZero())
+	}
+	require.Equal(t, TxStatus{}, txmp.TxStatus(types.Tx("unknown").Key()))
+
+	// committed transactions are not evicted, expired ones are
+	committed := types.Txs{tTxs[0].tx}
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, txmp.height+11, committed, []*abci.ExecTxResult{{Code: abci.CodeTypeOK}}, nil, nil, false))
+	txmp.Unlock()
+	require.Zero(t, txmp.Size())
+
+	require.Equal(t, TxStatus{}, txmp.TxStatus(tTxs[0].tx.Key()))
+	for _, tx := range tTxs[1:] {
+		status := txmp.TxStatus(tx.tx.Key())
+		require.False(t, status.InMempool)
+		require.True(t, status.Evicted)
+		require.Equal(t, EvictedTTLNumBlocks, status.EvictedReason)
+		require.EqualValues(t, 111, status.EvictedHeight)
+	}
+
+	// and a transaction checked again is no longer evicted
+	require.NoError(t, txmp.CheckTx(ctx, tTxs[1].tx, nil, TxInfo{}))
+	status := txmp.TxStatus(tTxs[1].tx.Key())
+	require.True(t, status.InMempool)
+	require.False(t, status.Evicted)
+}
+
+func TestTxMempool_TxKeyByID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_ = types.RegisterTxHashFunc("mempool-test", func(tx types.Tx) []byte { return append([]byte("id:"), tx...) })
+	require.NoError(t, types.SetTxHashFunc("mempool-test"))
+	t.Cleanup(func() { require.NoError(t, types.SetTxHashFunc(types.TxHashSHA256)) })
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	if err := client.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 500)
+	txmp.height = 100
+	txmp.config.TTLNumBlocks = 10
+
+	tTxs := checkTxs(ctx, t, txmp, 10, 0)
+	for _, tx := range tTxs {
+		key, ok := txmp.TxKeyByID(tx.tx.ID())
+		require.True(t, ok)
+		require.Equal(t, tx.tx.Key(), key)
+	}
+	_, ok := txmp.TxKeyByID(tTxs[0].tx.Hash())
+	require.False(t, ok)
+
+	// evicted transactions are still found, committed ones are not
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, txmp.height+11, types.Txs{tTxs[0].tx}, []*abci.ExecTxResult{{Code: abci.CodeTypeOK}}, nil, nil, false))
+	txmp.Unlock()
+	_, ok = txmp.TxKeyByID(tTxs[0].tx.ID())
+	require.False(t, ok)
+	key, ok := txmp.TxKeyByID(tTxs[1].tx.ID())
+	require.True(t, ok)
+	require.True(t, txmp.TxStatus(key).Evicted)
+}
+
 func TestTxMempool_CheckTxPostCheckError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -731,3 +920,248 @@ func TestTxMempool_CheckTxPostCheckError(t *testing.T) {
 		})
 	}
 }
+
+// laneApplication extends application by assigning each transaction to the
+// lane prefixing its sender, as in "lane/sender=key=priority".
+type laneApplication struct {
+	application
+}
+
+func (app *laneApplication) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := app.application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if i := bytes.IndexByte(req.Tx, '/'); i >= 0 {
+		res.Lane = string(req.Tx[:i])
+	}
+	return res, nil
+}
+
+func setupLanes(ctx context.Context, t *testing.T, lanes []string, options ...TxMempoolOption) *TxMempool {
+	t.Helper()
+
+	app := &laneApplication{application{Application: kvstore.NewApplication()}}
+	client := abciclient.NewLocalClient(log.NewNopLogger(), app)
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+
+	cfg := config.TestMempoolConfig()
+	cfg.Lanes = lanes
+	cfg.DefaultLane = strings.SplitN(lanes[0], ":", 2)[0]
+	require.NoError(t, cfg.ValidateBasic())
+
+	return NewTxMempool(log.NewNopLogger(), cfg, client, options...)
+}
+
+func TestTxMempool_LaneSize(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	evicted := make(map[string]string)
+	txmp := setupLanes(ctx, t, []string{"slow:1:0", "fast:1:2"}, WithEvictedFunc(func(tx types.Tx, reason string) {
+		evicted[string(tx)] = reason
+	}))
+
+	mustCheckTx(ctx, t, txmp, "fast/a=a=2")
+	mustCheckTx(ctx, t, txmp, "fast/b=b=1")
+	mustCheckTx(ctx, t, txmp, "slow/c=c=0")
+	mustCheckTx(ctx, t, txmp, "d=d=0") // default lane
+	require.Equal(t, 4, txmp.Size())
+
+	// The fast lane is full: a transaction with a lower priority than all the
+	// others in the lane is rejected, whatever the other lanes contain.
+	mustCheckTx(ctx, t, txmp, "fast/e=e=1")
+	require.Equal(t, 4, txmp.Size())
+	require.Empty(t, evicted)
+
+	// A transaction with a higher priority evicts the lowest one of its lane.
+	mustCheckTx(ctx, t, txmp, "fast/f=f=3")
+	require.Equal(t, 4, txmp.Size())
+	require.Equal(t, map[string]string{"fast/b=b=1": EvictedLowPriority}, evicted)
+	require.Equal(t, []int{2, 2}, txmp.laneSizes)
+
+	// Removing transactions frees room in their lane.
+	require.NoError(t, txmp.RemoveTxByKey(types.Tx("fast/a=a=2").Key()))
+	require.Equal(t, []int{2, 1}, txmp.laneSizes)
+	mustCheckTx(ctx, t, txmp, "fast/g=g=0")
+	require.Equal(t, 4, txmp.Size())
+
+	// A transaction making room in its lane, but not fitting in the full
+	// mempool, evicts nothing.
+	txmp.maxTxsBytes = txmp.SizeBytes()
+	mustCheckTx(ctx, t, txmp, "fast/h="+strings.Repeat("h", 40)+"=1")
+	require.Equal(t, 4, txmp.Size())
+	require.Len(t, evicted, 1)
+	require.Equal(t, []int{2, 2}, txmp.laneSizes)
+}
+
+func TestTxMempool_LaneReaping(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	txmp := setupLanes(ctx, t, []string{"a:2:0", "b:1:0"})
+
+	for i := 0; i < 5; i++ {
+		mustCheckTx(ctx, t, txmp, fmt.Sprintf("a/%d=a%d=%d", i, i, 10+i))
+		mustCheckTx(ctx, t, txmp, fmt.Sprintf("b/%d=b%d=%d", i, i, 20+i))
+	}
+
+	// Each lane is ordered by priority, and two transactions of lane a are
+	// taken for each of lane b, until lane a runs out.
+	want := []string{
+		"a/4=a4=14", "a/3=a3=13", "b/4=b4=24",
+		"a/2=a2=12", "a/1=a1=11", "b/3=b3=23",
+		"a/0=a0=10", "b/2=b2=22",
+		"b/1=b1=21",
+		"b/0=b0=20",
+	}
+	var got []string
+	for _, tx := range txmp.ReapMaxTxs(-1) {
+		got = append(got, string(tx))
+	}
+	require.Equal(t, want, got)
+
+	require.Len(t, txmp.ReapMaxBytesMaxGas(-1, 3), 3)
+	require.Equal(t, types.Tx(want[2]), txmp.ReapMaxBytesMaxGas(-1, 3)[2])
+}
+
+// recheckApplication extends application by recording the transactions it
+// rechecks. The transactions of the "anon" sender are checked without one.
+type recheckApplication struct {
+	application
+
+	mtx       sync.Mutex
+	rechecked []string
+}
+
+func (app *recheckApplication) CheckTx(ctx context.Context, req *abci.RequestCheckTx) (*abci.ResponseCheckTx, error) {
+	res, err := app.application.CheckTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Sender == "anon" {
+		res.Sender = ""
+	}
+	if req.Type == abci.CheckTxType_Recheck {
+		app.mtx.Lock()
+		app.rechecked = append(app.rechecked, string(req.Tx))
+		app.mtx.Unlock()
+	}
+	return res, nil
+}
+
+// takeRechecked returns the sorted transactions rechecked since the last
+// call.
+func (app *recheckApplication) takeRechecked() []string {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	rechecked := app.rechecked
+	app.rechecked = nil
+	sort.Strings(rechecked)
+	return rechecked
+}
+
+func newRecheckApplication(ctx context.Context, t *testing.T) (*recheckApplication, abciclient.Client) {
+	t.Helper()
+
+	app := &recheckApplication{application: application{Application: kvstore.NewApplication()}}
+	client := abciclient.NewLocalClient(log.NewNopLogger(), app)
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+	return app, client
+}
+
+// updateAndWait commits the transactions of a block with the given results
+// and waits for the recheck to complete.
+func updateAndWait(ctx context.Context, t *testing.T, txmp *TxMempool, txs types.Txs, results []*abci.ExecTxResult) {
+	t.Helper()
+
+	txmp.Lock()
+	require.NoError(t, txmp.Update(ctx, txmp.height+1, txs, results, nil, nil, true))
+	txmp.Unlock()
+	select {
+	case <-txmp.TxsAvailable():
+	case <-time.After(5 * time.Second):
+		t.Fatal("recheck did not complete")
+	}
+}
+
+func TestTxMempool_PartialRecheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app, client := newRecheckApplication(ctx, t)
+	txmp := setup(t, client, 0)
+	txmp.config.RecheckMode = config.RecheckModePartial
+	txmp.EnableTxsAvailable()
+
+	for _, tx := range []string{"alice=a=1", "bob=b=1", "carol=c=1", "anon=d=1"} {
+		mustCheckTx(ctx, t, txmp, tx)
+	}
+	<-txmp.TxsAvailable()
+
+	// only the transactions of the reported senders and without a sender are
+	// rechecked
+	blockTxs := types.Txs{types.Tx("dave=e=1")}
+	updateAndWait(ctx, t, txmp, blockTxs, []*abci.ExecTxResult{{
+		Code: abci.CodeTypeOK,
+		Events: []abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "sender", Value: "carol"}}},
+			{Type: EventTypeRecheck, Attributes: []abci.EventAttribute{
+				{Key: EventAttributeRecheckSender, Value: "bob"},
+				{Key: EventAttributeRecheckSender, Value: "dave"},
+			}},
+		},
+	}})
+	require.Equal(t, []string{"anon=d=1", "bob=b=1"}, app.takeRechecked())
+
+	// a block without recheck events is rechecked in full
+	updateAndWait(ctx, t, txmp, nil, []*abci.ExecTxResult{})
+	require.Len(t, app.takeRechecked(), 4)
+
+	// a recheck event without senders reports that no sender is affected
+	updateAndWait(ctx, t, txmp, types.Txs{types.Tx("dave=f=1")}, []*abci.ExecTxResult{{
+		Code:   abci.CodeTypeOK,
+		Events: []abci.Event{{Type: EventTypeRecheck}},
+	}})
+	require.Equal(t, []string{"anon=d=1"}, app.takeRechecked())
+
+	// the full recheck mode ignores the recheck events
+	txmp.config.RecheckMode = config.RecheckModeFull
+	updateAndWait(ctx, t, txmp, types.Txs{types.Tx("dave=g=1")}, []*abci.ExecTxResult{{
+		Code:   abci.CodeTypeOK,
+		Events: []abci.Event{{Type: EventTypeRecheck}},
+	}})
+	require.Len(t, app.takeRechecked(), 4)
+}
+
+func TestTxMempool_RecheckClients(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app, client := newRecheckApplication(ctx, t)
+	recheckApp, recheckClient := newRecheckApplication(ctx, t)
+	txmp := setup(t, client, 0, WithRecheckClients(recheckClient))
+	txmp.config.RecheckConcurrency = 1
+	txmp.EnableTxsAvailable()
+
+	txs := checkTxs(ctx, t, txmp, 10, 0)
+	<-txmp.TxsAvailable()
+
+	updateAndWait(ctx, t, txmp, nil, []*abci.ExecTxResult{})
+	rechecked := app.takeRechecked()
+	require.Len(t, rechecked, 5)
+	recheckedByClient := recheckApp.takeRechecked()
+	require.Len(t, recheckedByClient, 5)
+
+	all := append(rechecked, recheckedByClient...)
+	sort.Strings(all)
+	want := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		want = append(want, string(tx.tx))
+	}
+	sort.Strings(want)
+	require.Equal(t, want, all)
+	require.Equal(t, 10, txmp.Size())
+}
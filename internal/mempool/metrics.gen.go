@@ -20,6 +20,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "size",
 			Help:      "Number of uncommitted transactions in the mempool.",
 		}, labels).With(labelsAndValues...),
+		LaneSize: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "lane_size",
+			Help:      "Number of uncommitted transactions in each lane of the mempool, if lanes are configured.",
+		}, append(labels, "lane")).With(labelsAndValues...),
 		TxSizeBytes: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -52,16 +58,82 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+		RebroadcastTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rebroadcast_txs",
+			Help:      "Number of times local transactions were rebroadcast to peers.",
+		}, labels).With(labelsAndValues...),
+		DuplicateTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "duplicate_txs",
+			Help:      "Number of transactions received from peers that were already in the mempool cache.",
+		}, labels).With(labelsAndValues...),
+		Redundancy: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "redundancy",
+			Help:      "Ratio of duplicate to first-time transactions received from peers, as measured by the DOG gossip protocol.",
+		}, labels).With(labelsAndValues...),
+		DisabledRoutes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "disabled_routes",
+			Help:      "Number of gossip routes disabled by the DOG gossip protocol.",
+		}, labels).With(labelsAndValues...),
+		MutedPeers: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "muted_peers",
+			Help:      "Number of peers whose transactions are dropped for exceeding the per-peer rate limits.",
+		}, labels).With(labelsAndValues...),
+		RateLimitedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rate_limited_txs",
+			Help:      "Number of transactions dropped because their sender exceeded the per-peer rate limits.",
+		}, labels).With(labelsAndValues...),
+		RequestedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "requested_txs",
+			Help:      "Number of announced transactions requested from peers.",
+		}, labels).With(labelsAndValues...),
+		RecheckSkippedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "recheck_skipped_txs",
+			Help:      "Number of transactions not rechecked after a block, because the application did not report their sender as affected by the block.",
+		}, labels).With(labelsAndValues...),
+		RecheckDurationSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "recheck_duration_seconds",
+			Help:      "Duration in seconds of the recheck of the mempool after a block.",
+
+			Buckets: stdprometheus.ExponentialBuckets(0.001, 2, 12),
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Size:         discard.NewGauge(),
-		TxSizeBytes:  discard.NewHistogram(),
-		FailedTxs:    discard.NewCounter(),
-		RejectedTxs:  discard.NewCounter(),
-		EvictedTxs:   discard.NewCounter(),
-		RecheckTimes: discard.NewCounter(),
+		Size:                   discard.NewGauge(),
+		LaneSize:               discard.NewGauge(),
+		TxSizeBytes:            discard.NewHistogram(),
+		FailedTxs:              discard.NewCounter(),
+		RejectedTxs:            discard.NewCounter(),
+		EvictedTxs:             discard.NewCounter(),
+		RecheckTimes:           discard.NewCounter(),
+		RebroadcastTxs:         discard.NewCounter(),
+		DuplicateTxs:           discard.NewCounter(),
+		Redundancy:             discard.NewGauge(),
+		DisabledRoutes:         discard.NewGauge(),
+		MutedPeers:             discard.NewGauge(),
+		RateLimitedTxs:         discard.NewCounter(),
+		RequestedTxs:           discard.NewCounter(),
+		RecheckSkippedTxs:      discard.NewCounter(),
+		RecheckDurationSeconds: discard.NewHistogram(),
 	}
 }
@@ -18,6 +18,10 @@ type Metrics struct {
 	// Number of uncommitted transactions in the mempool.
 	Size metrics.Gauge
 
+	// Number of uncommitted transactions in each lane of the mempool, if
+	// lanes are configured.
+	LaneSize metrics.Gauge `metrics_labels:"lane"`
+
 	// Histogram of transaction sizes in bytes.
 	TxSizeBytes metrics.Histogram `metrics_buckettype:"exp" metrics_bucketsizes:"1,3,7"`
 
@@ -40,4 +44,36 @@ type Metrics struct {
 
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+
+	// Number of times local transactions were rebroadcast to peers.
+	RebroadcastTxs metrics.Counter
+
+	// Number of transactions received from peers that were already in the
+	// mempool cache.
+	DuplicateTxs metrics.Counter
+
+	// Ratio of duplicate to first-time transactions received from peers, as
+	// measured by the DOG gossip protocol.
+	Redundancy metrics.Gauge
+
+	// Number of gossip routes disabled by the DOG gossip protocol.
+	DisabledRoutes metrics.Gauge
+
+	// Number of peers whose transactions are dropped for exceeding the
+	// per-peer rate limits.
+	MutedPeers metrics.Gauge
+
+	// Number of transactions dropped because their sender exceeded the
+	// per-peer rate limits.
+	RateLimitedTxs metrics.Counter
+
+	// Number of announced transactions requested from peers.
+	RequestedTxs metrics.Counter
+
+	// Number of transactions not rechecked after a block, because the
+	// application did not report their sender as affected by the block.
+	RecheckSkippedTxs metrics.Counter
+
+	// Duration in seconds of the recheck of the mempool after a block.
+	RecheckDurationSeconds metrics.Histogram `metrics_buckettype:"exp" metrics_bucketsizes:"0.001,2,12"`
 }
@@ -136,6 +136,20 @@ func (_m *Mempool) SizeBytes() int64 {
 	return r0
 }
 
+// TxStatus provides a mock function with given fields: txKey
+func (_m *Mempool) TxStatus(txKey types.TxKey) mempool.TxStatus {
+	ret := _m.Called(txKey)
+
+	var r0 mempool.TxStatus
+	if rf, ok := ret.Get(0).(func(types.TxKey) mempool.TxStatus); ok {
+		r0 = rf(txKey)
+	} else {
+		r0 = ret.Get(0).(mempool.TxStatus)
+	}
+
+	return r0
+}
+
 // TxsAvailable provides a mock function with given fields:
 func (_m *Mempool) TxsAvailable() <-chan struct{} {
 	ret := _m.Called()
@@ -0,0 +1,83 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	protomem "github.com/tendermint/tendermint/proto/tendermint/mempool"
+	"github.com/tendermint/tendermint/types"
+)
+
+// SaveTxs writes the transactions of the mempool, in the order they were
+// added, to the file at path, which is replaced atomically. It returns the
+// number of transactions written.
+func (txmp *TxMempool) SaveTxs(path string) (int, error) {
+	txmp.mtx.RLock()
+	txs := make([][]byte, 0, txmp.Size())
+	for e := txmp.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*WrappedTx).tx)
+	}
+	txmp.mtx.RUnlock()
+
+	bz, err := (&protomem.Txs{Txs: txs}).Marshal()
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return 0, err
+	}
+	if err := tempfile.WriteFileAtomic(path, bz, 0600); err != nil {
+		return 0, fmt.Errorf("writing mempool transactions to %s: %w", path, err)
+	}
+	return len(txs), nil
+}
+
+// LoadTxs reads the transactions written by SaveTxs from the file at path. It
+// returns no transactions if the file does not exist.
+func LoadTxs(path string) (types.Txs, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var pb protomem.Txs
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("reading mempool transactions from %s: %w", path, err)
+	}
+	txs := make(types.Txs, len(pb.Txs))
+	for i, tx := range pb.Txs {
+		txs[i] = tx
+	}
+	return txs, nil
+}
+
+// RestoreTxs checks the transactions again, as submitted to this node, and
+// adds those which are still valid to the mempool. It returns the number of
+// transactions added, or an error if the context is cancelled.
+func (txmp *TxMempool) RestoreTxs(ctx context.Context, txs types.Txs) (int, error) {
+	restored := 0
+	for _, tx := range txs {
+		if err := ctx.Err(); err != nil {
+			return restored, err
+		}
+		if err := txmp.CheckTx(ctx, tx, nil, TxInfo{SenderID: UnknownPeerID}); err != nil {
+			txmp.logger.Debug("failed to restore transaction",
+				"tx", fmt.Sprintf("%X", tx.ID()), "err", err)
+			continue
+		}
+
+		txmp.mtx.RLock()
+		_, ok := txmp.txByKey[tx.Key()]
+		txmp.mtx.RUnlock()
+		if ok {
+			restored++
+		}
+	}
+	return restored, nil
+}
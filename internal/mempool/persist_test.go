@@ -0,0 +1,108 @@
+package mempool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTxMempool_SaveRestoreTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+
+	path := filepath.Join(t.TempDir(), "data", "mempool.txs")
+	txs, err := LoadTxs(path)
+	require.NoError(t, err)
+	require.Empty(t, txs)
+
+	txmp := setup(t, client, 100)
+	checked := checkTxs(ctx, t, txmp, 20, 0)
+	n, err := txmp.SaveTxs(path)
+	require.NoError(t, err)
+	require.Equal(t, 20, n)
+
+	txs, err = LoadTxs(path)
+	require.NoError(t, err)
+	require.Len(t, txs, 20)
+	for i, tx := range checked {
+		require.Equal(t, tx.tx, txs[i], "transactions are saved in the order they were added")
+	}
+
+	// the transactions which are no longer valid are not restored
+	txmp = setup(t, client, 100)
+	restored, err := txmp.RestoreTxs(ctx, append(txs, types.Tx("invalid")))
+	require.NoError(t, err)
+	require.Equal(t, 20, restored)
+	require.Equal(t, 20, txmp.Size())
+
+	// already restored transactions are in the cache
+	restored, err = txmp.RestoreTxs(ctx, txs)
+	require.NoError(t, err)
+	require.Zero(t, restored)
+
+	cctx, ccancel := context.WithCancel(ctx)
+	ccancel()
+	_, err = txmp.RestoreTxs(cctx, txs)
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0600))
+	_, err = LoadTxs(path)
+	require.Error(t, err)
+}
+
+func TestReactor_PersistTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := abciclient.NewLocalClient(log.NewNopLogger(), &application{Application: kvstore.NewApplication()})
+	require.NoError(t, client.Start(ctx))
+	t.Cleanup(client.Wait)
+
+	txmp := setup(t, client, 100)
+	txmp.config.PersistInterval = 10 * time.Millisecond
+	txmp.config.PersistFile = filepath.Join(t.TempDir(), "mempool.txs")
+	prev := setup(t, client, 100)
+	checked := checkTxs(ctx, t, prev, 10, 0)
+	_, err := prev.SaveTxs(txmp.config.PersistFilePath())
+	require.NoError(t, err)
+
+	r := NewReactor(log.NewNopLogger(), txmp.config, txmp, nil, nil)
+	r.restored = make(chan struct{})
+	rctx, rcancel := context.WithCancel(ctx)
+	go r.persistTxsRoutine(rctx)
+
+	select {
+	case <-r.restored:
+	case <-time.After(5 * time.Second):
+		t.Fatal("transactions were not restored")
+	}
+	require.Equal(t, 10, txmp.Size())
+
+	// new transactions are persisted periodically, and when the reactor stops
+	_ = checkTxs(ctx, t, txmp, 5, 1)
+	require.Eventually(t, func() bool {
+		txs, err := LoadTxs(txmp.config.PersistFilePath())
+		require.NoError(t, err)
+		return len(txs) == 15
+	}, 5*time.Second, 10*time.Millisecond)
+
+	rcancel()
+	require.NoError(t, txmp.RemoveTxByKey(checked[0].tx.Key()))
+	r.OnStop()
+	txs, err := LoadTxs(txmp.config.PersistFilePath())
+	require.NoError(t, err)
+	require.Len(t, txs, 14)
+}
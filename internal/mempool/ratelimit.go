@@ -0,0 +1,133 @@
+package mempool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// tokenBucket is a token bucket that refills at rate tokens per second, up to
+// a capacity of rate tokens.
+type tokenBucket struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// take removes n tokens from the bucket at the given time, and reports whether
+// enough tokens were available. A bucket with a zero rate is unlimited.
+func (b *tokenBucket) take(now time.Time, n float64) bool {
+	if b.rate == 0 {
+		return true
+	}
+	if b.last.IsZero() {
+		b.tokens = b.rate
+	} else if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens += elapsed * b.rate
+		if b.tokens > b.rate {
+			b.tokens = b.rate
+		}
+	}
+	b.last = now
+
+	if b.tokens < n {
+		return false
+	}
+	b.tokens -= n
+	return true
+}
+
+type peerRate struct {
+	txs        tokenBucket
+	bytes      tokenBucket
+	mutedUntil time.Time
+}
+
+// peerRateLimiter limits the rate of transactions and bytes the mempool
+// reactor accepts from each peer. A peer exceeding either limit is muted: all
+// of its transactions are dropped for the mute duration.
+type peerRateLimiter struct {
+	txsPerSecond   float64
+	bytesPerSecond float64
+	muteDuration   time.Duration
+	now            func() time.Time
+
+	mtx   sync.Mutex
+	peers map[types.NodeID]*peerRate
+	muted int // number of peers currently muted
+}
+
+func newPeerRateLimiter(txsPerSecond int, bytesPerSecond int64, muteDuration time.Duration) *peerRateLimiter {
+	return &peerRateLimiter{
+		txsPerSecond:   float64(txsPerSecond),
+		bytesPerSecond: float64(bytesPerSecond),
+		muteDuration:   muteDuration,
+		now:            time.Now,
+		peers:          make(map[types.NodeID]*peerRate),
+	}
+}
+
+// allow reports whether a transaction of the given size received from the
+// given peer may be checked. It also reports the number of muted peers.
+func (l *peerRateLimiter) allow(peerID types.NodeID, size int) (ok bool, muted int) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	if l.muted > 0 {
+		l.expireMutes(now)
+	}
+
+	pr, found := l.peers[peerID]
+	if !found {
+		pr = &peerRate{
+			txs:   tokenBucket{rate: l.txsPerSecond},
+			bytes: tokenBucket{rate: l.bytesPerSecond},
+		}
+		l.peers[peerID] = pr
+	}
+
+	if !pr.mutedUntil.IsZero() {
+		return false, l.muted
+	}
+
+	// Take from both buckets, so that the byte rate is enforced even for
+	// transactions within the transaction rate.
+	txsOK := pr.txs.take(now, 1)
+	bytesOK := pr.bytes.take(now, float64(size))
+	if txsOK && bytesOK {
+		return true, l.muted
+	}
+
+	pr.mutedUntil = now.Add(l.muteDuration)
+	l.muted++
+	return false, l.muted
+}
+
+// expireMutes unmutes the peers whose mute expired at the given time. Their
+// limits start afresh. The caller must hold l.mtx.
+func (l *peerRateLimiter) expireMutes(now time.Time) {
+	for _, pr := range l.peers {
+		if !pr.mutedUntil.IsZero() && !now.Before(pr.mutedUntil) {
+			pr.mutedUntil = time.Time{}
+			pr.txs = tokenBucket{rate: l.txsPerSecond}
+			pr.bytes = tokenBucket{rate: l.bytesPerSecond}
+			l.muted--
+		}
+	}
+}
+
+// removePeer forgets the given peer. It reports the number of muted peers.
+func (l *peerRateLimiter) removePeer(peerID types.NodeID) int {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if pr, ok := l.peers[peerID]; ok {
+		if !pr.mutedUntil.IsZero() {
+			l.muted--
+		}
+		delete(l.peers, peerID)
+	}
+	return l.muted
+}
@@ -0,0 +1,68 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPeerRateLimiter(t *testing.T) {
+	const peerA, peerB = types.NodeID("a"), types.NodeID("b")
+
+	now := time.Now()
+	l := newPeerRateLimiter(2, 100, time.Minute)
+	l.now = func() time.Time { return now }
+
+	allow := func(peerID types.NodeID, size int) bool {
+		ok, _ := l.allow(peerID, size)
+		return ok
+	}
+
+	// The limits allow a burst of one second worth of transactions.
+	require.True(t, allow(peerA, 10))
+	require.True(t, allow(peerA, 10))
+
+	// The third transaction mutes the peer, but not the others.
+	ok, muted := l.allow(peerA, 10)
+	require.False(t, ok)
+	require.Equal(t, 1, muted)
+	require.True(t, allow(peerB, 10))
+
+	// A muted peer stays muted even once its limits would allow it.
+	now = now.Add(30 * time.Second)
+	require.False(t, allow(peerA, 10))
+
+	// The mute expires after its duration.
+	now = now.Add(30 * time.Second)
+	ok, muted = l.allow(peerA, 10)
+	require.True(t, ok)
+	require.Zero(t, muted)
+
+	// The byte limit is enforced independently.
+	require.True(t, allow(peerB, 100))
+	ok, muted = l.allow(peerB, 1)
+	require.False(t, ok)
+	require.Equal(t, 1, muted)
+
+	require.Zero(t, l.removePeer(peerB))
+}
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Now()
+	b := tokenBucket{rate: 10}
+
+	require.True(t, b.take(now, 10))
+	require.False(t, b.take(now, 1))
+
+	// The bucket refills at its rate, up to its capacity.
+	require.True(t, b.take(now.Add(500*time.Millisecond), 5))
+	require.False(t, b.take(now.Add(500*time.Millisecond), 1))
+	require.True(t, b.take(now.Add(time.Hour), 10))
+	require.False(t, b.take(now.Add(time.Hour), 1))
+
+	unlimited := tokenBucket{}
+	require.True(t, unlimited.take(now, 1e9))
+}
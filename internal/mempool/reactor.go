@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"runtime/debug"
 	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/libs/clist"
@@ -42,6 +45,28 @@ type Reactor struct {
 
 	mtx          sync.Mutex
 	peerRoutines map[types.NodeID]context.CancelFunc
+
+	// routes and redundancy implement the DOG gossip protocol. The
+	// redundancy controller is nil if the protocol is disabled.
+	routes     *gossipRoutes
+	redundancy *redundancyController
+
+	// limiter enforces the per-peer rate limits, or is nil if there are none.
+	limiter *peerRateLimiter
+
+	// requests tracks the announced transactions requested from peers, or is
+	// nil if transaction announcements are disabled. The messages of the
+	// announcement and DOG protocols are sent to each peer through its queue,
+	// protected by mtx, so that handling a message never blocks on sending
+	// another. The peers have queues if either protocol is enabled.
+	requests   *txRequests
+	peerQueues map[types.NodeID]chan queuedEnvelope
+
+	// restored is closed once the persisted transactions are restored, if
+	// the persistence of the mempool is enabled. persistMtx serializes the
+	// writes of the persisted transactions.
+	restored   chan struct{}
+	persistMtx sync.Mutex
 }
 
 // NewReactor returns a reference to a new reactor.
@@ -61,6 +86,19 @@ func NewReactor(
 		peerEvents:   peerEvents,
 		peerRoutines: make(map[types.NodeID]context.CancelFunc),
 		observePanic: defaultObservePanic,
+		routes:       newGossipRoutes(),
+	}
+	if cfg.DOGProtocolEnabled {
+		r.redundancy = newRedundancyController(cfg.DOGTargetRedundancy)
+	}
+	if cfg.PeerMaxTxsPerSecond > 0 || cfg.PeerMaxBytesPerSecond > 0 {
+		r.limiter = newPeerRateLimiter(cfg.PeerMaxTxsPerSecond, cfg.PeerMaxBytesPerSecond, cfg.PeerMuteDuration)
+	}
+	if cfg.AnnounceTxs {
+		r.requests = newTxRequests(wantTxTimeout)
+	}
+	if cfg.AnnounceTxs || cfg.DOGProtocolEnabled {
+		r.peerQueues = make(map[types.NodeID]chan queuedEnvelope)
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "Mempool", r)
@@ -79,7 +117,7 @@ func getChannelDescriptor(cfg *config.MempoolConfig) *p2p.ChannelDescriptor {
 		},
 	}
 
-	return &p2p.ChannelDescriptor{
+	desc := &p2p.ChannelDescriptor{
 		ID:                  MempoolChannel,
 		MessageType:         new(protomem.Message),
 		Priority:            5,
@@ -87,6 +125,31 @@ func getChannelDescriptor(cfg *config.MempoolConfig) *p2p.ChannelDescriptor {
 		RecvBufferCapacity:  128,
 		Name:                "mempool",
 	}
+	if cfg.AnnounceTxs {
+		desc.Versions = []uint32{types.DefaultChannelVersion, AnnounceChannelVersion}
+	}
+	return desc
+}
+
+// getLaneChannelDescriptors produces the descriptors of the channels of the
+// configured mempool lanes, in lane order. The first lane uses MempoolChannel
+// and the following ones the subsequent channel IDs. Without lanes, the only
+// channel is the one produced by getChannelDescriptor.
+func getLaneChannelDescriptors(cfg *config.MempoolConfig) []*p2p.ChannelDescriptor {
+	lanes, err := cfg.MempoolLanes()
+	if err != nil || len(lanes) == 0 {
+		return []*p2p.ChannelDescriptor{getChannelDescriptor(cfg)}
+	}
+
+	descs := make([]*p2p.ChannelDescriptor, len(lanes))
+	for i, lane := range lanes {
+		desc := getChannelDescriptor(cfg)
+		desc.ID = MempoolChannel + p2p.ChannelID(i)
+		desc.Priority = lane.Weight
+		desc.Name = "mempool-" + lane.Name
+		descs[i] = desc
+	}
+	return descs
 }
 
 // OnStart starts separate go routines for each p2p Channel and listens for
@@ -98,26 +161,66 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 		r.logger.Info("tx broadcasting is disabled")
 	}
 
-	ch, err := r.chCreator(ctx, getChannelDescriptor(r.cfg))
-	if err != nil {
-		return err
+	descs := getLaneChannelDescriptors(r.cfg)
+	chs := make([]p2p.Channel, len(descs))
+	for i, desc := range descs {
+		ch, err := r.chCreator(ctx, desc)
+		if err != nil {
+			return err
+		}
+		chs[i] = ch
 	}
 
-	go r.processMempoolCh(ctx, ch)
-	go r.processPeerUpdates(ctx, r.peerEvents(ctx), ch)
+	for _, ch := range chs {
+		go r.processMempoolCh(ctx, ch)
+	}
+	go r.processPeerUpdates(ctx, r.peerEvents(ctx), chs)
+	if r.cfg.Broadcast && r.cfg.RebroadcastInterval > 0 {
+		go r.rebroadcastTxRoutine(ctx, chs)
+	}
+	if r.redundancy != nil {
+		go r.adjustRedundancyRoutine(ctx, chs[0])
+	}
+	if r.requests != nil {
+		go r.retryTxRequestsRoutine(ctx)
+	}
+	if r.cfg.PersistInterval > 0 {
+		r.restored = make(chan struct{})
+		go r.persistTxsRoutine(ctx)
+	}
 
 	return nil
 }
 
 // OnStop stops the reactor by signaling to all spawned goroutines to exit and
-// blocking until they all exit.
-func (r *Reactor) OnStop() {}
+// blocking until they all exit. If the persistence of the mempool is enabled,
+// it persists the transactions of the mempool, unless it stops before they
+// were restored.
+func (r *Reactor) OnStop() {
+	if r.restored == nil {
+		return
+	}
+	select {
+	case <-r.restored:
+		r.persistTxs()
+	default:
+	}
+}
 
 // handleMempoolMessage handles envelopes sent from peers on the MempoolChannel.
 // For every tx in the message, we execute CheckTx. It returns an error if an
 // empty set of txs are sent in an envelope or if we receive an unexpected
 // message type.
-func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelope) error {
+//
+// HaveTx and ResetRoute messages adjust the gossip routes of the DOG protocol.
+// They are accepted even if the protocol is disabled on this node.
+//
+// AnnounceTxs messages are answered with a WantTxs message requesting the
+// announced transactions which were not seen yet nor requested from another
+// peer lately, and WantTxs messages with the requested transactions. The
+// transactions whose request times out are requested from the next peer which
+// announced them, by retryTxRequestsRoutine.
+func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelope, mempoolCh p2p.Channel) error {
 	logger := r.logger.With("peer", envelope.From)
 
 	switch msg := envelope.Message.(type) {
@@ -133,14 +236,22 @@ func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelo
 		}
 
 		for _, tx := range protoTxs {
+			if r.requests != nil {
+				r.requests.received(types.Tx(tx).Key())
+			}
+			if !r.allowTx(envelope.From, tx) {
+				continue
+			}
 			if err := r.mempool.CheckTx(ctx, types.Tx(tx), nil, txInfo); err != nil {
 				if errors.Is(err, types.ErrTxInCache) {
 					// if the tx is in the cache,
 					// then we've been gossiped a
 					// Tx that we've already
-					// got. Gossip should be
-					// smarter, but it's not a
-					// problem.
+					// got. If the DOG protocol is
+					// enabled, this may prompt us
+					// to ask the peer to stop
+					// sending us such txs.
+					r.handleDuplicateTx(types.Tx(tx), envelope.From, mempoolCh)
 					continue
 				}
 				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
@@ -153,9 +264,80 @@ func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelo
 				}
 
 				logger.Error("checktx failed for tx",
-					"tx", fmt.Sprintf("%X", types.Tx(tx).Hash()),
+					"tx", fmt.Sprintf("%X", types.Tx(tx).ID()),
 					"err", err)
 			}
+			if r.redundancy != nil {
+				r.redundancy.firstTime()
+			}
+		}
+
+	case *protomem.HaveTx:
+		var txKey types.TxKey
+		if len(msg.GetTxKey()) != len(txKey) {
+			return fmt.Errorf("invalid tx key length %d", len(msg.GetTxKey()))
+		}
+		copy(txKey[:], msg.GetTxKey())
+		// Stop forwarding to the sender the txs we receive from the peer
+		// that first sent us this one, since the sender gets them already.
+		if source, ok := r.mempool.txSource(txKey); ok && source != envelope.From {
+			n := r.routes.disable(source, envelope.From)
+			r.mempool.metrics.DisabledRoutes.Set(float64(n))
+			logger.Debug("disabled gossip route", "from", source, "to", envelope.From)
+		}
+
+	case *protomem.ResetRoute:
+		n := r.routes.enableTo(envelope.From)
+		r.mempool.metrics.DisabledRoutes.Set(float64(n))
+		logger.Debug("reset gossip routes to peer")
+
+	case *protomem.AnnounceTxs:
+		txKeys, err := parseTxKeys(msg.GetTxKeys())
+		if err != nil {
+			return err
+		}
+		if r.requests == nil {
+			return nil
+		}
+
+		peerMempoolID := r.ids.GetForPeer(envelope.From)
+		announcer := txAnnouncer{peer: envelope.From, ch: mempoolCh}
+		now := time.Now()
+		want := make([][]byte, 0, len(txKeys))
+		for _, txKey := range txKeys {
+			if r.mempool.seenTx(txKey, peerMempoolID) || !r.requests.announced(txKey, announcer, now) {
+				continue
+			}
+			want = append(want, txKey[:])
+		}
+		if len(want) == 0 {
+			return nil
+		}
+		r.mempool.metrics.RequestedTxs.Add(float64(len(want)))
+		r.enqueue(mempoolCh, p2p.Envelope{
+			To:      envelope.From,
+			Message: &protomem.WantTxs{TxKeys: want},
+		})
+
+	case *protomem.WantTxs:
+		txKeys, err := parseTxKeys(msg.GetTxKeys())
+		if err != nil {
+			return err
+		}
+
+		// The transactions are sent one per message, as when gossiped, to
+		// keep within the capacity of the channel.
+		peerMempoolID := r.ids.GetForPeer(envelope.From)
+		for _, txKey := range txKeys {
+			wtx, ok := r.mempool.getTx(txKey)
+			if !ok {
+				continue
+			}
+			wtx.SetPeer(peerMempoolID)
+			r.enqueue(mempoolCh, p2p.Envelope{
+				To:      envelope.From,
+				Message: &protomem.Txs{Txs: [][]byte{wtx.tx}},
+			})
 		}
 
 	default:
@@ -168,7 +350,7 @@ func (r *Reactor) handleMempoolMessage(ctx context.Context, envelope *p2p.Envelo
 // handleMessage handles an Envelope sent from a peer on a specific p2p Channel.
 // It will handle errors and any possible panics gracefully. A caller can handle
 // any error returned by sending a PeerError on the respective channel.
-func (r *Reactor) handleMessage(ctx context.Context, envelope *p2p.Envelope) (err error) {
+func (r *Reactor) handleMessage(ctx context.Context, envelope *p2p.Envelope, mempoolCh p2p.Channel) (err error) {
 	defer func() {
 		if e := recover(); e != nil {
 			r.observePanic(e)
@@ -183,9 +365,9 @@ func (r *Reactor) handleMessage(ctx context.Context, envelope *p2p.Envelope) (er
 
 	r.logger.Debug("received message", "peer", envelope.From)
 
-	switch envelope.ChannelID {
-	case MempoolChannel:
-		err = r.handleMempoolMessage(ctx, envelope)
+	switch {
+	case envelope.ChannelID >= MempoolChannel && envelope.ChannelID < MempoolChannel+config.MaxMempoolLanes:
+		err = r.handleMempoolMessage(ctx, envelope, mempoolCh)
 	default:
 		err = fmt.Errorf("unknown channel ID (%d) for envelope (%T)", envelope.ChannelID, envelope.Message)
 	}
@@ -199,7 +381,7 @@ func (r *Reactor) processMempoolCh(ctx context.Context, mempoolCh p2p.Channel) {
 	iter := mempoolCh.Receive(ctx)
 	for iter.Next(ctx) {
 		envelope := iter.Envelope()
-		if err := r.handleMessage(ctx, envelope); err != nil {
+		if err := r.handleMessage(ctx, envelope, mempoolCh); err != nil {
 			r.logger.Error("failed to process message", "ch_id", envelope.ChannelID, "envelope", envelope, "err", err)
 			if serr := mempoolCh.SendError(ctx, p2p.PeerError{
 				NodeID: envelope.From,
@@ -216,7 +398,7 @@ func (r *Reactor) processMempoolCh(ctx context.Context, mempoolCh p2p.Channel) {
 // goroutine or not. If not, we start one for the newly added peer. For down or
 // removed peers, we remove the peer from the mempool peer ID set and signal to
 // stop the tx broadcasting goroutine.
-func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpdate, mempoolCh p2p.Channel) {
+func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpdate, chs []p2p.Channel) {
 	r.logger.Debug("received peer update", "peer", peerUpdate.NodeID, "status", peerUpdate.Status)
 
 	r.mtx.Lock()
@@ -231,6 +413,14 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 			return
 		}
 
+		announce := r.requests != nil &&
+			peerUpdate.ChannelVersions[MempoolChannel] >= AnnounceChannelVersion
+		if _, ok := r.peerQueues[peerUpdate.NodeID]; (announce || r.redundancy != nil) && !ok {
+			queue := make(chan queuedEnvelope, peerQueueSize)
+			r.peerQueues[peerUpdate.NodeID] = queue
+			go r.sendQueueRoutine(ctx, queue)
+		}
+
 		if r.cfg.Broadcast {
 			// Check if we've already started a goroutine for this peer, if not we create
 			// a new done channel so we can explicitly close the goroutine if the peer
@@ -243,13 +433,23 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 
 				r.ids.ReserveForPeer(peerUpdate.NodeID)
 
-				// start a broadcast routine ensuring all txs are forwarded to the peer
-				go r.broadcastTxRoutine(pctx, peerUpdate.NodeID, mempoolCh)
+				// start a broadcast routine ensuring all txs are forwarded to the
+				// peer, or announced if it negotiated transaction announcements
+				go r.broadcastTxRoutine(pctx, peerUpdate.NodeID, chs, announce)
 			}
 		}
 
 	case p2p.PeerStatusDown:
 		r.ids.Reclaim(peerUpdate.NodeID)
+		r.mempool.metrics.DisabledRoutes.Set(float64(r.routes.removePeer(peerUpdate.NodeID)))
+		if r.limiter != nil {
+			r.mempool.metrics.MutedPeers.Set(float64(r.limiter.removePeer(peerUpdate.NodeID)))
+		}
+
+		if queue, ok := r.peerQueues[peerUpdate.NodeID]; ok {
+			close(queue)
+			delete(r.peerQueues, peerUpdate.NodeID)
+		}
 
 		// Check if we've started a tx broadcasting goroutine for this peer.
 		// If we have, we signal to terminate the goroutine via the channel's closure.
@@ -265,18 +465,21 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 // processPeerUpdates initiates a blocking process where we listen for and handle
 // PeerUpdate messages. When the reactor is stopped, we will catch the signal and
 // close the p2p PeerUpdatesCh gracefully.
-func (r *Reactor) processPeerUpdates(ctx context.Context, peerUpdates *p2p.PeerUpdates, mempoolCh p2p.Channel) {
+func (r *Reactor) processPeerUpdates(ctx context.Context, peerUpdates *p2p.PeerUpdates, chs []p2p.Channel) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case peerUpdate := <-peerUpdates.Updates():
-			r.processPeerUpdate(ctx, peerUpdate, mempoolCh)
+			r.processPeerUpdate(ctx, peerUpdate, chs)
 		}
 	}
 }
 
-func (r *Reactor) broadcastTxRoutine(ctx context.Context, peerID types.NodeID, mempoolCh p2p.Channel) {
+// broadcastTxRoutine gossips the mempool transactions to the given peer, each
+// on the channel of its lane. If announce is set, the keys of the transactions
+// are sent instead, and the peer requests those it wants.
+func (r *Reactor) broadcastTxRoutine(ctx context.Context, peerID types.NodeID, chs []p2p.Channel, announce bool) {
 	peerMempoolID := r.ids.GetForPeer(peerID)
 	var nextGossipTx *clist.CElement
 
@@ -319,20 +522,23 @@ func (r *Reactor) broadcastTxRoutine(ctx context.Context, peerID types.NodeID, m
 
 		// NOTE: Transaction batching was disabled due to:
 		// https://github.com/tendermint/tendermint/issues/5796
-		if !memTx.HasPeer(peerMempoolID) {
+		if !memTx.HasPeer(peerMempoolID) && !r.routes.isDisabled(memTx.source, peerID) {
 			// Send the mempool tx to the corresponding peer. Note, the peer may be
 			// behind and thus would not be able to process the mempool tx correctly.
-			if err := mempoolCh.Send(ctx, p2p.Envelope{
-				To: peerID,
-				Message: &protomem.Txs{
-					Txs: [][]byte{memTx.tx},
-				},
+			var msg proto.Message = &protomem.Txs{Txs: [][]byte{memTx.tx}}
+			if announce {
+				txKey := memTx.hash
+				msg = &protomem.AnnounceTxs{TxKeys: [][]byte{txKey[:]}}
+			}
+			if err := chs[memTx.lane].Send(ctx, p2p.Envelope{
+				To:      peerID,
+				Message: msg,
 			}); err != nil {
 				return
 			}
 
 			r.logger.Debug("gossiped tx to peer",
-				"tx", tmstrings.LazySprintf("%X", memTx.tx.Hash()),
+				"tx", tmstrings.LazySprintf("%X", memTx.tx.ID()),
 				"peer", peerID,
 			)
 		}
@@ -345,3 +551,241 @@ func (r *Reactor) broadcastTxRoutine(ctx context.Context, peerID types.NodeID, m
 		}
 	}
 }
+
+// rebroadcastTxRoutine periodically re-sends the local transactions that are
+// still in the mempool to all connected peers, so that they propagate even if
+// the peers they were initially gossiped to dropped them or disconnected. The
+// delay between two rebroadcasts of a transaction grows exponentially, see
+// WrappedTx.dueForRebroadcast.
+func (r *Reactor) rebroadcastTxRoutine(ctx context.Context, chs []p2p.Channel) {
+	ticker := time.NewTicker(r.cfg.RebroadcastInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for e := r.mempool.TxsFront(); e != nil; e = e.Next() {
+				memTx := e.Value.(*WrappedTx)
+				if !memTx.dueForRebroadcast(now, r.cfg.RebroadcastInterval, r.cfg.RebroadcastMaxInterval) {
+					continue
+				}
+
+				if err := chs[memTx.lane].Send(ctx, p2p.Envelope{
+					Broadcast: true,
+					Message: &protomem.Txs{
+						Txs: [][]byte{memTx.tx},
+					},
+				}); err != nil {
+					return
+				}
+
+				r.mempool.metrics.RebroadcastTxs.Add(1)
+				r.logger.Debug("rebroadcast tx to peers",
+					"tx", tmstrings.LazySprintf("%X", memTx.tx.ID()),
+				)
+			}
+		}
+	}
+}
+
+// queuedEnvelope is an envelope queued to be sent to a peer on a channel.
+type queuedEnvelope struct {
+	ch       p2p.Channel
+	envelope p2p.Envelope
+}
+
+// peerQueueSize is the number of envelopes queued for a peer, beyond which
+// they are dropped. A transaction whose request or response is dropped is
+// requested from the next peer which announced it once the request times out,
+// and is not requested again if no other peer announced it.
+const peerQueueSize = 1024
+
+// retryTxRequestsRoutine periodically requests the announced transactions
+// whose requests timed out from the next peers which announced them.
+func (r *Reactor) retryTxRequestsRoutine(ctx context.Context) {
+	ticker := time.NewTicker(r.requests.timeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for announcer, txKeys := range r.requests.retry(now) {
+				want := make([][]byte, len(txKeys))
+				for i := range txKeys {
+					want[i] = txKeys[i][:]
+				}
+				r.mempool.metrics.RequestedTxs.Add(float64(len(want)))
+				r.enqueue(announcer.ch, p2p.Envelope{
+					To:      announcer.peer,
+					Message: &protomem.WantTxs{TxKeys: want},
+				})
+			}
+		}
+	}
+}
+
+// enqueue queues the envelope to be sent on the channel to its recipient, if
+// it has a queue, i.e. it negotiated transaction announcements or the DOG
+// protocol is enabled. It never blocks: the envelope is dropped if the queue
+// is full.
+func (r *Reactor) enqueue(ch p2p.Channel, envelope p2p.Envelope) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	queue, ok := r.peerQueues[envelope.To]
+	if !ok {
+		return
+	}
+	select {
+	case queue <- queuedEnvelope{ch: ch, envelope: envelope}:
+	default:
+		r.logger.Debug("dropped message to peer with a full queue",
+			"peer", envelope.To, "message", fmt.Sprintf("%T", envelope.Message))
+	}
+}
+
+// sendQueueRoutine sends the envelopes queued for a peer, until the queue is
+// closed when the peer goes down.
+func (r *Reactor) sendQueueRoutine(ctx context.Context, queue <-chan queuedEnvelope) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case queued, ok := <-queue:
+			if !ok {
+				return
+			}
+			if err := queued.ch.Send(ctx, queued.envelope); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// persistTxsRoutine restores the transactions persisted when the node last
+// stopped, and then periodically persists the transactions of the mempool, so
+// that they survive a restart.
+func (r *Reactor) persistTxsRoutine(ctx context.Context) {
+	path := r.cfg.PersistFilePath()
+	txs, err := LoadTxs(path)
+	if err != nil {
+		r.logger.Error("failed to load the persisted mempool transactions", "path", path, "err", err)
+	} else if len(txs) > 0 {
+		restored, err := r.mempool.RestoreTxs(ctx, txs)
+		if err != nil {
+			return
+		}
+		r.logger.Info("restored the persisted mempool transactions",
+			"num_txs", len(txs), "restored", restored)
+	}
+	close(r.restored)
+
+	ticker := time.NewTicker(r.cfg.PersistInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.persistTxs()
+		}
+	}
+}
+
+// persistTxs writes the transactions of the mempool to the persist file.
+func (r *Reactor) persistTxs() {
+	r.persistMtx.Lock()
+	defer r.persistMtx.Unlock()
+
+	path := r.cfg.PersistFilePath()
+	n, err := r.mempool.SaveTxs(path)
+	if err != nil {
+		r.logger.Error("failed to persist the mempool transactions", "path", path, "err", err)
+		return
+	}
+	r.logger.Debug("persisted the mempool transactions", "path", path, "num_txs", n)
+}
+
+// allowTx reports whether a transaction received from the given peer is
+// within the per-peer rate limits, and may be checked.
+func (r *Reactor) allowTx(peerID types.NodeID, tx []byte) bool {
+	if r.limiter == nil || peerID == "" {
+		return true
+	}
+
+	ok, muted := r.limiter.allow(peerID, len(tx))
+	r.mempool.metrics.MutedPeers.Set(float64(muted))
+	if !ok {
+		r.mempool.metrics.RateLimitedTxs.Add(1)
+		r.logger.Debug("dropped tx from rate-limited peer",
+			"peer", peerID,
+			"tx", tmstrings.LazySprintf("%X", types.Tx(tx).ID()),
+		)
+	}
+	return ok
+}
+
+// handleDuplicateTx records the receipt from the given peer of a transaction
+// already in the cache, and queues a HaveTx message to the peer if the DOG
+// protocol finds the redundancy too high.
+func (r *Reactor) handleDuplicateTx(tx types.Tx, from types.NodeID, mempoolCh p2p.Channel) {
+	r.mempool.metrics.DuplicateTxs.Add(1)
+	if r.redundancy == nil || from == "" || !r.redundancy.duplicate() {
+		return
+	}
+
+	txKey := tx.Key()
+	r.logger.Debug("sending have tx to peer", "peer", from, "tx", tmstrings.LazySprintf("%X", txKey[:]))
+	r.enqueue(mempoolCh, p2p.Envelope{
+		To:      from,
+		Message: &protomem.HaveTx{TxKey: txKey[:]},
+	})
+}
+
+// adjustRedundancyRoutine periodically measures the redundancy of the
+// transactions received from peers, and sends a ResetRoute message to a
+// random peer when it drops below the target of the DOG protocol, so that
+// routes disabled by earlier HaveTx messages are re-enabled.
+func (r *Reactor) adjustRedundancyRoutine(ctx context.Context, mempoolCh p2p.Channel) {
+	ticker := time.NewTicker(r.cfg.DOGAdjustInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			redundancy, resetRoute := r.redundancy.adjust()
+			r.mempool.metrics.Redundancy.Set(redundancy)
+			if !resetRoute {
+				continue
+			}
+
+			peerID, ok := r.randomPeer()
+			if !ok {
+				continue
+			}
+			r.logger.Debug("sending reset route to peer", "peer", peerID, "redundancy", redundancy)
+			r.enqueue(mempoolCh, p2p.Envelope{
+				To:      peerID,
+				Message: &protomem.ResetRoute{},
+			})
+		}
+	}
+}
+
+// randomPeer returns one of the peers the reactor gossips to.
+func (r *Reactor) randomPeer() (types.NodeID, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	// Map iteration order is randomized.
+	for peerID := range r.peerRoutines {
+		return peerID, true
+	}
+	return "", false
+}
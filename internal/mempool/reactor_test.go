@@ -40,12 +40,22 @@ type reactorTestSuite struct {
 	nodes []types.NodeID
 }
 
-func setupReactors(ctx context.Context, t *testing.T, logger log.Logger, numNodes int, chBuf uint) *reactorTestSuite {
+func setupReactors(
+	ctx context.Context,
+	t *testing.T,
+	logger log.Logger,
+	numNodes int,
+	chBuf uint,
+	configure ...func(*config.MempoolConfig),
+) *reactorTestSuite {
 	t.Helper()
 
 	cfg, err := config.ResetTestRoot(t.TempDir(), strings.ReplaceAll(t.Name(), "/", "|"))
 	require.NoError(t, err)
 	t.Cleanup(func() { os.RemoveAll(cfg.RootDir) })
+	for _, f := range configure {
+		f(cfg.Mempool)
+	}
 
 	rts := &reactorTestSuite{
 		logger:          log.NewNopLogger().With("testCase", t.Name()),
@@ -177,7 +187,7 @@ func TestReactorBroadcastDoesNotPanic(t *testing.T) {
 	// run the router
 	rts.start(ctx, t)
 
-	go primaryReactor.broadcastTxRoutine(ctx, secondary, rts.mempoolChannels[primary])
+	go primaryReactor.broadcastTxRoutine(ctx, secondary, []p2p.Channel{rts.mempoolChannels[primary]}, false)
 
 	wg := &sync.WaitGroup{}
 	for i := 0; i < 50; i++ {
@@ -219,6 +229,61 @@ func TestReactorBroadcastTxs(t *testing.T) {
 	rts.waitForTxns(t, convertTex(txs), secondaries...)
 }
 
+func TestReactorAnnounceTxs(t *testing.T) {
+	for _, announce := range []bool{true, false} {
+		announce := announce
+		t.Run(fmt.Sprintf("announce=%v", announce), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			rts := setupReactors(ctx, t, log.NewNopLogger(), 3, 64, func(cfg *config.MempoolConfig) {
+				cfg.AnnounceTxs = announce
+			})
+			primary := rts.nodes[0]
+			txs := checkTxs(ctx, t, rts.mempools[primary], 64, UnknownPeerID)
+			rts.start(ctx, t)
+			rts.waitForTxns(t, convertTex(txs), rts.nodes[1:]...)
+
+			for _, nodeID := range rts.nodes[1:] {
+				r := rts.reactors[nodeID]
+				r.mtx.Lock()
+				_, ok := r.peerQueues[primary]
+				r.mtx.Unlock()
+				require.Equal(t, announce, ok, "announcements negotiated with the primary")
+				if announce {
+					require.Zero(t, r.requests.len(), "all the requested txs were received")
+				}
+			}
+		})
+	}
+}
+
+func TestReactorAnnounceTxsSilentPeer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rts := setupReactors(ctx, t, log.NewNopLogger(), 2, 64, func(cfg *config.MempoolConfig) {
+		cfg.AnnounceTxs = true
+	})
+	primary, secondary := rts.nodes[0], rts.nodes[1]
+
+	// a peer which never answers announces the tx to the secondary first, so
+	// that it is requested from the primary once that request timed out
+	tx := types.Tx("silent")
+	txKey := tx.Key()
+	silent := types.NodeID(strings.Repeat("f", 40))
+	require.NoError(t, rts.reactors[secondary].handleMempoolMessage(ctx, &p2p.Envelope{
+		From:    silent,
+		Message: &protomem.AnnounceTxs{TxKeys: [][]byte{txKey[:]}},
+	}, rts.mempoolChannels[secondary]))
+	require.Equal(t, 1, rts.reactors[secondary].requests.len())
+
+	require.NoError(t, rts.mempools[primary].CheckTx(ctx, tx, nil, TxInfo{SenderID: UnknownPeerID}))
+	rts.start(ctx, t)
+	rts.waitForTxns(t, []types.Tx{tx}, secondary)
+	require.Zero(t, rts.reactors[secondary].requests.len())
+}
+
 // regression test for https://github.com/tendermint/tendermint/issues/5408
 func TestReactorConcurrency(t *testing.T) {
 	numTxs := 10
@@ -423,3 +488,49 @@ func TestBroadcastTxForPeerStopsWhenPeerStops(t *testing.T) {
 	require.Equal(t, 4, rts.mempools[primary].Size())
 	require.Equal(t, 0, rts.mempools[secondary].Size())
 }
+
+// blockingChannel is a channel on which every send blocks until its context
+// is done.
+type blockingChannel struct{ p2p.Channel }
+
+func (blockingChannel) Send(ctx context.Context, _ p2p.Envelope) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestReactorDuplicateTxQueued(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rts := setupReactors(ctx, t, log.NewNopLogger(), 1, 0, func(cfg *config.MempoolConfig) {
+		cfg.DOGProtocolEnabled = true
+		cfg.AnnounceTxs = false
+	})
+	reactor := rts.reactors[rts.nodes[0]]
+	require.NotNil(t, reactor.peerQueues, "the DOG protocol queues its messages")
+
+	peerID, err := types.NewNodeID("0011223344556677889900112233445566778899")
+	require.NoError(t, err)
+	queue := make(chan queuedEnvelope, 1)
+	reactor.mtx.Lock()
+	reactor.peerQueues[peerID] = queue
+	reactor.mtx.Unlock()
+
+	dup := types.Tx("dup=1")
+	require.NoError(t, reactor.mempool.CheckTx(ctx, dup, nil, TxInfo{SenderID: UnknownPeerID}))
+
+	// the HaveTx messages are queued rather than sent on the channel, which
+	// would block, and the one which doesn't fit in the queue is dropped
+	for i, tx := range [][]byte{[]byte("tx=2"), []byte("tx=3")} {
+		reactor.redundancy.haveTxAllowed = true
+		require.NoError(t, reactor.handleMempoolMessage(ctx, &p2p.Envelope{
+			From:    peerID,
+			Message: &protomem.Txs{Txs: [][]byte{dup, tx}},
+		}, blockingChannel{}))
+		require.Equal(t, i+2, reactor.mempool.Size())
+	}
+	require.Len(t, queue, 1)
+	queued := <-queue
+	require.Equal(t, peerID, queued.envelope.To)
+	require.IsType(t, &protomem.HaveTx{}, queued.envelope.Message)
+}
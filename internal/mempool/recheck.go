@@ -0,0 +1,41 @@
+package mempool
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// The events with which the application reports the senders affected by a
+// block, whose transactions are rechecked in the partial recheck mode, see
+// config.MempoolConfig.RecheckMode. Each EventTypeRecheck event of the results
+// of the transactions of a block lists affected senders in its
+// EventAttributeRecheckSender attributes, whose values are the senders of
+// ResponseCheckTx.
+const (
+	EventTypeRecheck            = "recheck"
+	EventAttributeRecheckSender = "sender"
+)
+
+// recheckSenders returns the senders reported as affected by the block in the
+// recheck events of the results of its transactions, and whether there is any
+// such event. Without one, the application does not report the affected
+// senders and all the transactions must be rechecked.
+func recheckSenders(txResults []*abci.ExecTxResult) (map[string]bool, bool) {
+	var (
+		senders = make(map[string]bool)
+		found   bool
+	)
+	for _, result := range txResults {
+		for _, event := range result.GetEvents() {
+			if event.Type != EventTypeRecheck {
+				continue
+			}
+			found = true
+			for _, attr := range event.Attributes {
+				if attr.Key == EventAttributeRecheckSender {
+					senders[attr.Value] = true
+				}
+			}
+		}
+	}
+	return senders, found
+}
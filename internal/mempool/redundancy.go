@@ -0,0 +1,161 @@
+package mempool
+
+import (
+	"sync"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// redundancyTolerance is the relative deviation from the target redundancy
+// within which the DOG protocol leaves the gossip routes unchanged.
+const redundancyTolerance = 0.2
+
+// gossipRoutes records the gossip routes disabled by the DOG protocol. A
+// disabled route from peer A to peer B means the reactor does not forward to B
+// the transactions it first received from A.
+type gossipRoutes struct {
+	mtx      sync.RWMutex
+	disabled map[types.NodeID]map[types.NodeID]struct{} // source -> targets
+	count    int
+}
+
+func newGossipRoutes() *gossipRoutes {
+	return &gossipRoutes{disabled: make(map[types.NodeID]map[types.NodeID]struct{})}
+}
+
+// disable disables the route from the given source to the given target. It
+// reports the number of disabled routes.
+func (gr *gossipRoutes) disable(from, to types.NodeID) int {
+	gr.mtx.Lock()
+	defer gr.mtx.Unlock()
+
+	targets, ok := gr.disabled[from]
+	if !ok {
+		targets = make(map[types.NodeID]struct{})
+		gr.disabled[from] = targets
+	}
+	if _, ok := targets[to]; !ok {
+		targets[to] = struct{}{}
+		gr.count++
+	}
+	return gr.count
+}
+
+// isDisabled reports whether the route from the given source to the given
+// target is disabled.
+func (gr *gossipRoutes) isDisabled(from, to types.NodeID) bool {
+	gr.mtx.RLock()
+	defer gr.mtx.RUnlock()
+	_, ok := gr.disabled[from][to]
+	return ok
+}
+
+// enableTo enables every route to the given target. It reports the number of
+// disabled routes.
+func (gr *gossipRoutes) enableTo(to types.NodeID) int {
+	gr.mtx.Lock()
+	defer gr.mtx.Unlock()
+
+	for from, targets := range gr.disabled {
+		if _, ok := targets[to]; ok {
+			delete(targets, to)
+			gr.count--
+		}
+		if len(targets) == 0 {
+			delete(gr.disabled, from)
+		}
+	}
+	return gr.count
+}
+
+// removePeer forgets every route from or to the given peer. It reports the
+// number of disabled routes.
+func (gr *gossipRoutes) removePeer(peer types.NodeID) int {
+	gr.mtx.Lock()
+	defer gr.mtx.Unlock()
+
+	gr.count -= len(gr.disabled[peer])
+	delete(gr.disabled, peer)
+	for from, targets := range gr.disabled {
+		if _, ok := targets[peer]; ok {
+			delete(targets, peer)
+			gr.count--
+		}
+		if len(targets) == 0 {
+			delete(gr.disabled, from)
+		}
+	}
+	return gr.count
+}
+
+// redundancyController measures the ratio of duplicate to first-time
+// transactions received from peers, and decides how the DOG protocol adjusts
+// the gossip routes to keep it close to a target. When the redundancy is too
+// high, it allows sending a single HaveTx message to the sender of the next
+// duplicate transaction, which disables one route. When it is too low, it
+// requests a ResetRoute message, which re-enables the routes to this node.
+type redundancyController struct {
+	lowerBound float64
+	upperBound float64
+
+	mtx           sync.Mutex
+	firstTimeTxs  int64
+	duplicateTxs  int64
+	haveTxAllowed bool
+}
+
+func newRedundancyController(target float64) *redundancyController {
+	return &redundancyController{
+		lowerBound: target * (1 - redundancyTolerance),
+		upperBound: target * (1 + redundancyTolerance),
+	}
+}
+
+// firstTime records the receipt of a transaction not seen before.
+func (rc *redundancyController) firstTime() {
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+	rc.firstTimeTxs++
+}
+
+// duplicate records the receipt of a transaction already seen, and reports
+// whether a HaveTx message should be sent to its sender.
+func (rc *redundancyController) duplicate() bool {
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+	rc.duplicateTxs++
+	if rc.haveTxAllowed {
+		rc.haveTxAllowed = false
+		return true
+	}
+	return false
+}
+
+// adjust computes the redundancy of the transactions received since the last
+// call and resets the counters. It reports the redundancy, and whether a
+// ResetRoute message should be sent to a peer. Nothing is adjusted if no
+// transactions were received.
+func (rc *redundancyController) adjust() (redundancy float64, resetRoute bool) {
+	rc.mtx.Lock()
+	defer rc.mtx.Unlock()
+
+	if rc.firstTimeTxs == 0 && rc.duplicateTxs == 0 {
+		return 0, false
+	}
+
+	first := rc.firstTimeTxs
+	if first == 0 {
+		first = 1
+	}
+	redundancy = float64(rc.duplicateTxs) / float64(first)
+	rc.firstTimeTxs, rc.duplicateTxs = 0, 0
+
+	switch {
+	case redundancy < rc.lowerBound:
+		rc.haveTxAllowed = false
+		return redundancy, true
+	case redundancy > rc.upperBound:
+		rc.haveTxAllowed = true
+	}
+	return redundancy, false
+}
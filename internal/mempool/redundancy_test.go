@@ -0,0 +1,73 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestGossipRoutes(t *testing.T) {
+	const a, b, c = types.NodeID("a"), types.NodeID("b"), types.NodeID("c")
+	gr := newGossipRoutes()
+
+	require.Equal(t, 1, gr.disable(a, b))
+	require.Equal(t, 1, gr.disable(a, b), "disabling twice must not count twice")
+	require.Equal(t, 2, gr.disable(c, b))
+	require.Equal(t, 3, gr.disable(b, c))
+	require.True(t, gr.isDisabled(a, b))
+	require.False(t, gr.isDisabled(b, a))
+
+	// Resetting the routes to b re-enables the routes from a and c.
+	require.Equal(t, 1, gr.enableTo(b))
+	require.False(t, gr.isDisabled(a, b))
+	require.False(t, gr.isDisabled(c, b))
+	require.True(t, gr.isDisabled(b, c))
+
+	require.Equal(t, 2, gr.disable(a, c))
+	require.Equal(t, 0, gr.removePeer(c))
+	require.False(t, gr.isDisabled(b, c))
+	require.False(t, gr.isDisabled(a, c))
+}
+
+func TestRedundancyController(t *testing.T) {
+	rc := newRedundancyController(1)
+
+	// Nothing received, nothing to adjust.
+	redundancy, reset := rc.adjust()
+	require.Zero(t, redundancy)
+	require.False(t, reset)
+
+	// Too much redundancy allows a single HaveTx.
+	rc.firstTime()
+	for i := 0; i < 3; i++ {
+		require.False(t, rc.duplicate())
+	}
+	redundancy, reset = rc.adjust()
+	require.Equal(t, 3.0, redundancy)
+	require.False(t, reset)
+	require.True(t, rc.duplicate())
+	require.False(t, rc.duplicate())
+
+	// Duplicates received without any first-time tx count as too redundant.
+	redundancy, reset = rc.adjust()
+	require.Equal(t, 2.0, redundancy)
+	require.False(t, reset)
+	require.True(t, rc.duplicate())
+
+	// Too little redundancy requests a route reset.
+	rc.firstTime()
+	rc.firstTime()
+	redundancy, reset = rc.adjust()
+	require.Equal(t, 0.5, redundancy)
+	require.True(t, reset)
+
+	// Redundancy within the tolerance changes nothing.
+	rc.firstTime()
+	rc.duplicate()
+	redundancy, reset = rc.adjust()
+	require.Equal(t, 1.0, redundancy)
+	require.False(t, reset)
+	require.False(t, rc.duplicate())
+}
@@ -22,16 +22,22 @@ type TxInfo struct {
 // WrappedTx defines a wrapper around a raw transaction with additional metadata
 // that is used for indexing.
 type WrappedTx struct {
-	tx        types.Tx    // the original transaction data
-	hash      types.TxKey // the transaction hash
-	height    int64       // height when this transaction was initially checked (for expiry)
-	timestamp time.Time   // time when transaction was entered (for TTL)
+	tx        types.Tx     // the original transaction data
+	hash      types.TxKey  // the transaction hash
+	height    int64        // height when this transaction was initially checked (for expiry)
+	timestamp time.Time    // time when transaction was entered (for TTL)
+	local     bool         // whether the transaction was submitted to this node (for rebroadcast)
+	source    types.NodeID // peer that first sent us this transaction, if any (for gossip routing)
+	lane      int          // index of the lane of this transaction, if lanes are configured
 
 	mtx       sync.Mutex
 	gasWanted int64           // app: gas required to execute this transaction
 	priority  int64           // app: priority value for this transaction
 	sender    string          // app: assigned sender label
 	peers     map[uint16]bool // peer IDs who have sent us this transaction
+
+	rebroadcasts    int       // number of times w was rebroadcast
+	lastRebroadcast time.Time // time of the latest rebroadcast of w
 }
 
 // Size reports the size of the raw transaction in bytes.
@@ -97,3 +103,34 @@ func (w *WrappedTx) Priority() int64 {
 	defer w.mtx.Unlock()
 	return w.priority
 }
+
+// dueForRebroadcast reports whether w is a local transaction due to be
+// rebroadcast at the given time, and if so records the rebroadcast. The delay
+// before the first rebroadcast is interval, and it doubles after every
+// rebroadcast up to maxInterval.
+func (w *WrappedTx) dueForRebroadcast(now time.Time, interval, maxInterval time.Duration) bool {
+	if !w.local || interval <= 0 {
+		return false
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	delay := maxInterval
+	if w.rebroadcasts < 32 {
+		if d := interval << w.rebroadcasts; d > 0 && d < maxInterval {
+			delay = d
+		}
+	}
+	last := w.lastRebroadcast
+	if last.IsZero() {
+		last = w.timestamp
+	}
+	if now.Sub(last) < delay {
+		return false
+	}
+
+	w.rebroadcasts++
+	w.lastRebroadcast = now
+	return true
+}
@@ -0,0 +1,28 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrappedTxDueForRebroadcast(t *testing.T) {
+	start := time.Now()
+	wtx := &WrappedTx{tx: []byte("tx"), timestamp: start, local: true}
+
+	const interval, maxInterval = time.Second, 3 * time.Second
+	require.False(t, wtx.dueForRebroadcast(start.Add(500*time.Millisecond), interval, maxInterval))
+
+	// The delay doubles after each rebroadcast, up to the maximum.
+	now := start
+	for _, delay := range []time.Duration{1, 2, 3, 3} {
+		now = now.Add(delay * time.Second)
+		require.False(t, wtx.dueForRebroadcast(now.Add(-time.Millisecond), interval, maxInterval))
+		require.True(t, wtx.dueForRebroadcast(now, interval, maxInterval))
+	}
+
+	// Transactions received from peers are never rebroadcast.
+	remote := &WrappedTx{tx: []byte("tx"), timestamp: start}
+	require.False(t, remote.dueForRebroadcast(start.Add(time.Hour), interval, maxInterval))
+}
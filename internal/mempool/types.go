@@ -100,6 +100,10 @@ type Mempool interface {
 
 	// SizeBytes returns the total size of all txs in the mempool.
 	SizeBytes() int64
+
+	// TxStatus returns the status of the transaction with the given key in
+	// the mempool.
+	TxStatus(txKey types.TxKey) TxStatus
 }
 
 // PreCheckFunc is an optional filter executed before CheckTx and rejects
@@ -112,6 +116,32 @@ type PreCheckFunc func(types.Tx) error
 // transaction doesn't require more gas than available for the block.
 type PostCheckFunc func(types.Tx, *abci.ResponseCheckTx) error
 
+// EvictedFunc is an optional callback executed when a valid transaction is
+// evicted from the mempool without being committed. The reason is one of the
+// Evicted* constants. It is not called under the mempool lock: the evictions
+// are queued while the lock is held and reported by TxMempool.Unlock once the
+// lock is released, so the callback may call back into the mempool, and an
+// eviction may only be reported by a later Unlock, e.g. the one ending the
+// next update of the mempool.
+type EvictedFunc func(tx types.Tx, reason string)
+
+// Reasons reported to an EvictedFunc.
+const (
+	// EvictedTTLNumBlocks indicates the transaction stayed in the mempool for
+	// longer than the configured ttl-num-blocks.
+	EvictedTTLNumBlocks = "ttl-num-blocks"
+	// EvictedTTLDuration indicates the transaction stayed in the mempool for
+	// longer than the configured ttl-duration.
+	EvictedTTLDuration = "ttl-duration"
+	// EvictedLowPriority indicates the transaction was evicted from a full
+	// mempool to make room for a transaction of higher priority.
+	EvictedLowPriority = "low-priority"
+	// EvictedSenderQuota indicates the transaction was evicted to make room
+	// for a transaction of higher priority of the same sender, which was at
+	// the max-txs-per-sender or max-gas-per-sender limit.
+	EvictedSenderQuota = "sender-quota"
+)
+
 // PreCheckMaxBytes checks that the size of the transaction is smaller or equal
 // to the expected maxBytes.
 func PreCheckMaxBytes(maxBytes int64) PreCheckFunc {
@@ -47,6 +47,10 @@ const (
 	defaultSendTimeout         = 10 * time.Second
 	defaultPingInterval        = 60 * time.Second
 	defaultPongTimeout         = 90 * time.Second
+
+	// how long to wait before sending again when only channels over their
+	// send rate have pending messages
+	channelThrottleInterval = 20 * time.Millisecond
 )
 
 type receiveCbFunc func(ctx context.Context, chID ChannelID, msgBytes []byte)
@@ -105,8 +109,9 @@ type MConnection struct {
 
 	cancel context.CancelFunc
 
-	flushTimer *timer.ThrottleTimer // flush writes as necessary but throttled.
-	pingTimer  *time.Ticker         // send pings periodically
+	flushTimer    *timer.ThrottleTimer // flush writes as necessary but throttled.
+	throttleTimer *timer.ThrottleTimer // wake the sendRoutine when channels are throttled.
+	pingTimer     *time.Ticker         // send pings periodically
 
 	// close conn if pong is not received in pongTimeout
 	lastMsgRecv struct {
@@ -140,6 +145,15 @@ type MConnConfig struct {
 
 	// Process/Transport Start time
 	StartTime time.Time `mapstructure:",omitempty"`
+
+	// Rates at which packets can be sent on channels, in bytes/second, within
+	// SendRate. Channels without a rate are only limited by SendRate.
+	ChannelSendRates map[ChannelID]int64 `mapstructure:"channel_send_rates"`
+
+	// Whether to always send on the pending channel of highest priority,
+	// rather than share the send rate between the pending channels in
+	// proportion to their priorities.
+	StrictChannelPriority bool `mapstructure:"strict_channel_priority"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -203,6 +217,7 @@ func NewMConnection(
 // OnStart implements BaseService
 func (c *MConnection) OnStart(ctx context.Context) error {
 	c.flushTimer = timer.NewThrottleTimer("flush", c.config.FlushThrottle)
+	c.throttleTimer = timer.NewThrottleTimer("throttle", channelThrottleInterval)
 	c.pingTimer = time.NewTicker(c.config.PingInterval)
 	c.chStatsTimer = time.NewTicker(updateStats)
 	c.quitSendRoutine = make(chan struct{})
@@ -249,6 +264,7 @@ func (c *MConnection) stopServices() (alreadyStopped bool) {
 	}
 
 	c.flushTimer.Stop()
+	c.throttleTimer.Stop()
 	c.pingTimer.Stop()
 	c.chStatsTimer.Stop()
 
@@ -351,6 +367,12 @@ FOR_LOOP:
 			for _, channel := range c.channels {
 				channel.updateStats()
 			}
+		case <-c.throttleTimer.Ch:
+			// Some channels may be within their send rate again.
+			select {
+			case c.send <- struct{}{}:
+			default:
+			}
 		case <-c.pingTimer.C:
 			_n, err = protoWriter.WriteMsg(mustWrapPacket(&tmp2p.PacketPing{}))
 			if err != nil {
@@ -429,24 +451,41 @@ func (c *MConnection) sendSomePacketMsgs(ctx context.Context) bool {
 // Returns true if messages from channels were exhausted.
 func (c *MConnection) sendPacketMsg(ctx context.Context) bool {
 	// Choose a channel to create a PacketMsg from.
-	// The chosen channel will be the one whose recentlySent/priority is the least.
+	// The chosen channel will be the one whose recentlySent/priority is the least,
+	// among the channels of highest priority if priorities are strict. Channels
+	// over their send rate are skipped.
 	var leastRatio float32 = math.MaxFloat32
 	var leastChannel *channel
+	var throttled bool
 	for _, channel := range c.channels {
 		// If nothing to send, skip this channel
 		if !channel.isSendPending() {
 			continue
 		}
+		if channel.isSendThrottled() {
+			throttled = true
+			continue
+		}
 		// Get ratio, and keep track of lowest ratio.
 		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
-		if ratio < leastRatio {
-			leastRatio = ratio
-			leastChannel = channel
+		switch {
+		case leastChannel == nil:
+		case c.config.StrictChannelPriority && channel.desc.Priority < leastChannel.desc.Priority:
+			continue
+		case c.config.StrictChannelPriority && channel.desc.Priority > leastChannel.desc.Priority:
+		case ratio >= leastRatio:
+			continue
 		}
+		leastRatio = ratio
+		leastChannel = channel
 	}
 
 	// Nothing to send?
 	if leastChannel == nil {
+		if throttled {
+			// Try again once the throttled channels may send.
+			c.throttleTimer.Set()
+		}
 		return true
 	}
 	// c.logger.Info("Found a msgPacket to send")
@@ -622,6 +661,11 @@ type ChannelDescriptor struct {
 	// Human readable name of the channel, used in logging and
 	// diagnostics.
 	Name string
+
+	// Versions are the wire format versions of the channel messages the
+	// reactor supports, negotiated with each peer during the handshake. If
+	// empty, only types.DefaultChannelVersion is supported.
+	Versions []uint32
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -652,6 +696,8 @@ type channel struct {
 	sendQueueSize int32 // atomic.
 	recving       []byte
 	sending       []byte
+	sendMonitor   *flowrate.Monitor
+	sendRate      int64 // zero if unlimited
 
 	maxPacketMsgPayloadSize int
 
@@ -668,6 +714,8 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *channel {
 		desc:                    desc,
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
+		sendMonitor:             flowrate.New(conn.config.StartTime, 0, 0),
+		sendRate:                conn.config.ChannelSendRates[desc.ID],
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 		logger:                  conn.logger,
 	}
@@ -701,6 +749,12 @@ func (ch *channel) isSendPending() bool {
 	return true
 }
 
+// Returns true if the channel is over its send rate.
+// Goroutine-safe
+func (ch *channel) isSendThrottled() bool {
+	return ch.sendRate > 0 && ch.sendMonitor.Limit(ch.maxPacketMsgPayloadSize, ch.sendRate, false) == 0
+}
+
 // Creates a new PacketMsg to send.
 // Not goroutine-safe
 func (ch *channel) nextPacketMsg() tmp2p.PacketMsg {
@@ -724,6 +778,7 @@ func (ch *channel) writePacketMsgTo(w io.Writer) (n int, err error) {
 	packet := ch.nextPacketMsg()
 	n, err = protoio.NewDelimitedWriter(w).WriteMsg(mustWrapPacket(&packet))
 	atomic.AddInt64(&ch.recentlySent, int64(n))
+	ch.sendMonitor.Update(n)
 	return
 }
 
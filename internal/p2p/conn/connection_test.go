@@ -1,11 +1,13 @@
 package conn
 
 import (
+	"bufio"
 	"context"
 	"encoding/hex"
 	"io"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/internal/libs/protoio"
+	"github.com/tendermint/tendermint/internal/libs/timer"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
@@ -653,6 +656,104 @@ func TestMConnectionChannelOverflow(t *testing.T) {
 
 }
 
+// newSchedulingTestMConnection returns an MConnection, not started, writing
+// its packets to a buffer.
+func newSchedulingTestMConnection(t *testing.T, cfg MConnConfig, chDescs []*ChannelDescriptor) *MConnection {
+	t.Helper()
+	server, client := net.Pipe()
+	t.Cleanup(closeAll(t, client, server))
+
+	onReceive := func(context.Context, ChannelID, []byte) {}
+	onError := func(context.Context, interface{}) {}
+	mconn := NewMConnection(log.NewNopLogger(), client, chDescs, onReceive, onError, cfg)
+	mconn.bufConnWriter = bufio.NewWriter(io.Discard)
+	mconn.flushTimer = timer.NewThrottleTimer("flush", time.Hour)
+	mconn.throttleTimer = timer.NewThrottleTimer("throttle", channelThrottleInterval)
+	t.Cleanup(func() {
+		mconn.flushTimer.Stop()
+		mconn.throttleTimer.Stop()
+	})
+	return mconn
+}
+
+// sendPackets sends n packets, or until the channels are exhausted, and
+// returns the IDs of the channels they were sent on.
+func sendPackets(ctx context.Context, mconn *MConnection, n int) []ChannelID {
+	var sent []ChannelID
+	for i := 0; i < n; i++ {
+		before := map[ChannelID]int64{}
+		for _, ch := range mconn.channels {
+			before[ch.desc.ID] = atomic.LoadInt64(&ch.recentlySent)
+		}
+		if mconn.sendPacketMsg(ctx) {
+			break
+		}
+		for _, ch := range mconn.channels {
+			if atomic.LoadInt64(&ch.recentlySent) != before[ch.desc.ID] {
+				sent = append(sent, ch.desc.ID)
+			}
+		}
+	}
+	return sent
+}
+
+func TestMConnectionStrictChannelPriority(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 5, SendQueueCapacity: 10},
+	}
+	for _, strict := range []bool{false, true} {
+		cfg := DefaultMConnConfig()
+		cfg.StrictChannelPriority = strict
+		mconn := newSchedulingTestMConnection(t, cfg, chDescs)
+		for i := 0; i < 3; i++ {
+			mconn.channelsIdx[0x01].sendQueue <- []byte("mempool")
+			mconn.channelsIdx[0x02].sendQueue <- []byte("vote")
+		}
+
+		sent := sendPackets(ctx, mconn, 10)
+		require.Len(t, sent, 6)
+		if strict {
+			assert.Equal(t, []ChannelID{0x02, 0x02, 0x02, 0x01, 0x01, 0x01}, sent)
+		} else {
+			// the low priority channel gets a share of the send rate
+			assert.NotEqual(t, []ChannelID{0x02, 0x02, 0x02, 0x01, 0x01, 0x01}, sent)
+		}
+	}
+}
+
+func TestMConnectionChannelSendRate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := DefaultMConnConfig()
+	cfg.ChannelSendRates = map[ChannelID]int64{0x01: 1}
+	mconn := newSchedulingTestMConnection(t, cfg, []*ChannelDescriptor{
+		{ID: 0x01, Priority: 10, SendQueueCapacity: 10},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 10},
+	})
+	for i := 0; i < 3; i++ {
+		mconn.channelsIdx[0x01].sendQueue <- []byte("mempool")
+	}
+	mconn.channelsIdx[0x02].sendQueue <- []byte("vote")
+
+	// the throttled channel sends a packet, and leaves the others pending
+	// until it is within its send rate again
+	assert.Equal(t, []ChannelID{0x01, 0x02}, sendPackets(ctx, mconn, 10))
+	assert.True(t, mconn.channelsIdx[0x01].isSendPending())
+	assert.True(t, mconn.channelsIdx[0x01].isSendThrottled())
+	assert.False(t, mconn.channelsIdx[0x02].isSendThrottled())
+
+	select {
+	case <-mconn.throttleTimer.Ch:
+	case <-time.After(time.Second):
+		t.Fatal("throttled channels did not wake the connection")
+	}
+}
+
 func waitAll(waiters ...service.Service) func() {
 	return func() {
 		switch len(waiters) {
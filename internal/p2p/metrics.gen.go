@@ -80,6 +80,24 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "peers_evicted",
 			Help:      "Number of peers evicted by this node.",
 		}, labels).With(labelsAndValues...),
+		PrivatePeersConnected: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "private_peers_connected",
+			Help:      "Number of private peering peers connected.",
+		}, labels).With(labelsAndValues...),
+		PrivatePeerDisconnects: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "private_peer_disconnects",
+			Help:      "Number of disconnections from private peering peers.",
+		}, labels).With(labelsAndValues...),
+		PrivatePeerDialFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "private_peer_dial_failures",
+			Help:      "Number of failed dials to private peering peers.",
+		}, labels).With(labelsAndValues...),
 		RouterPeerQueueRecv: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -110,26 +128,36 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "peer_queue_msg_size",
 			Help:      "The size of messages sent over a peer's queue for a specific p2p Channel.",
 		}, append(labels, "ch_id")).With(labelsAndValues...),
+		RouterChannelQueueDepth: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "router_channel_queue_depth",
+			Help:      "Number of messages received on a channel waiting to be consumed by its reactor.",
+		}, append(labels, "ch_id")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		PeersConnected:         discard.NewGauge(),
-		PeersStored:            discard.NewGauge(),
-		PeersInactivated:       discard.NewGauge(),
-		PeerReceiveBytesTotal:  discard.NewCounter(),
-		PeerSendBytesTotal:     discard.NewCounter(),
-		PeerPendingSendBytes:   discard.NewGauge(),
-		PeersConnectedSuccess:  discard.NewCounter(),
-		PeersConnectedFailure:  discard.NewCounter(),
-		PeersConnectedIncoming: discard.NewGauge(),
-		PeersConnectedOutgoing: discard.NewGauge(),
-		PeersEvicted:           discard.NewCounter(),
-		RouterPeerQueueRecv:    discard.NewHistogram(),
-		RouterPeerQueueSend:    discard.NewHistogram(),
-		RouterChannelQueueSend: discard.NewHistogram(),
-		PeerQueueDroppedMsgs:   discard.NewCounter(),
-		PeerQueueMsgSize:       discard.NewGauge(),
+		PeersConnected:          discard.NewGauge(),
+		PeersStored:             discard.NewGauge(),
+		PeersInactivated:        discard.NewGauge(),
+		PeerReceiveBytesTotal:   discard.NewCounter(),
+		PeerSendBytesTotal:      discard.NewCounter(),
+		PeerPendingSendBytes:    discard.NewGauge(),
+		PeersConnectedSuccess:   discard.NewCounter(),
+		PeersConnectedFailure:   discard.NewCounter(),
+		PeersConnectedIncoming:  discard.NewGauge(),
+		PeersConnectedOutgoing:  discard.NewGauge(),
+		PeersEvicted:            discard.NewCounter(),
+		PrivatePeersConnected:   discard.NewGauge(),
+		PrivatePeerDisconnects:  discard.NewCounter(),
+		PrivatePeerDialFailures: discard.NewCounter(),
+		RouterPeerQueueRecv:     discard.NewHistogram(),
+		RouterPeerQueueSend:     discard.NewHistogram(),
+		RouterChannelQueueSend:  discard.NewHistogram(),
+		PeerQueueDroppedMsgs:    discard.NewCounter(),
+		PeerQueueMsgSize:        discard.NewGauge(),
+		RouterChannelQueueDepth: discard.NewGauge(),
 	}
 }
@@ -7,6 +7,8 @@ import (
 	"sync"
 
 	"github.com/go-kit/kit/metrics"
+
+	"github.com/tendermint/tendermint/internal/libs/metricslabels"
 )
 
 const (
@@ -54,6 +56,13 @@ type Metrics struct {
 	// Number of peers evicted by this node.
 	PeersEvicted metrics.Counter
 
+	// Number of private peering peers connected.
+	PrivatePeersConnected metrics.Gauge
+	// Number of disconnections from private peering peers.
+	PrivatePeerDisconnects metrics.Counter
+	// Number of failed dials to private peering peers.
+	PrivatePeerDialFailures metrics.Counter
+
 	// RouterPeerQueueRecv defines the time taken to read off of a peer's queue
 	// before sending on the connection.
 	//metrics:The time taken to read off of a peer's queue before sending on the connection.
@@ -78,6 +87,19 @@ type Metrics struct {
 	// queue for a specific flow (i.e. Channel).
 	//metrics:The size of messages sent over a peer's queue for a specific p2p Channel.
 	PeerQueueMsgSize metrics.Gauge `metrics_labels:"ch_id" metric_name:"router_channel_queue_msg_size"`
+
+	// Number of messages received on a channel waiting to be consumed by its
+	// reactor.
+	RouterChannelQueueDepth metrics.Gauge `metrics_labels:"ch_id"`
+}
+
+// BlankPeerLabels blanks the peer_id label of the metrics of peers, so that
+// they are the totals of all the peers, and their number of series does not
+// grow with the number of peers.
+func (m *Metrics) BlankPeerLabels() {
+	m.PeerReceiveBytesTotal = metricslabels.BlankCounter(m.PeerReceiveBytesTotal, "peer_id")
+	m.PeerSendBytesTotal = metricslabels.BlankCounter(m.PeerSendBytesTotal, "peer_id")
+	m.PeerPendingSendBytes = metricslabels.BlankGauge(m.PeerPendingSendBytes, "peer_id")
 }
 
 type metricsLabelCache struct {
@@ -0,0 +1,163 @@
+package nat
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// The sources of the external address of a node.
+const (
+	SourceConfig = "config"
+	SourceUPnP   = "upnp"
+	SourceNATPMP = "nat-pmp"
+	SourcePeers  = "peers"
+)
+
+const (
+	mappingLifetime    = time.Hour
+	mappingDescription = "tendermint p2p"
+	deleteTimeout      = 5 * time.Second
+)
+
+// Detector detects the external address of a node behind a NAT, by mapping
+// its P2P port on the NAT gateway, or else from the IP its peers see it at.
+type Detector struct {
+	logger     log.Logger
+	configured string
+	port       int
+	observedIP func() net.IP
+	discover   func(context.Context) (NAT, error)
+
+	mtx          sync.Mutex
+	nat          NAT
+	externalPort int
+	mapped       string
+}
+
+// NewDetector returns a Detector for a node listening on port, with the
+// configured external address, if any. observedIP returns the IP the peers of
+// the node see it at, or nil if unknown.
+func NewDetector(logger log.Logger, configured string, port int, observedIP func() net.IP) *Detector {
+	return &Detector{
+		logger:     logger,
+		configured: configured,
+		port:       port,
+		observedIP: observedIP,
+		discover:   Discover,
+	}
+}
+
+// MapPort looks for a NAT gateway with UPnP or NAT-PMP, and maps the P2P port
+// of the node on it.
+func (d *Detector) MapPort(ctx context.Context) error {
+	nat, err := d.discover(ctx)
+	if err != nil {
+		return err
+	}
+	externalPort, err := nat.AddPortMapping(ctx, "tcp", d.port, d.port, mappingDescription, mappingLifetime)
+	if err != nil {
+		return fmt.Errorf("mapping port %d with %v: %w", d.port, nat, err)
+	}
+	ip, err := nat.ExternalIP(ctx)
+	if err != nil {
+		return fmt.Errorf("getting external IP with %v: %w", nat, err)
+	}
+
+	d.mtx.Lock()
+	d.nat = nat
+	d.externalPort = externalPort
+	d.mapped = formatAddress(ip, externalPort)
+	d.mtx.Unlock()
+
+	d.logger.Info("mapped P2P port on NAT gateway", "protocol", nat, "address", d.mapped)
+	return nil
+}
+
+// Run renews the port mapping made by MapPort until ctx is done, and then
+// deletes it.
+func (d *Detector) Run(ctx context.Context) {
+	d.mtx.Lock()
+	nat, externalPort := d.nat, d.externalPort
+	d.mtx.Unlock()
+	if nat == nil {
+		return
+	}
+
+	ticker := time.NewTicker(mappingLifetime / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			dctx, cancel := context.WithTimeout(context.Background(), deleteTimeout)
+			defer cancel()
+			if err := nat.DeletePortMapping(dctx, "tcp", d.port, externalPort); err != nil {
+				d.logger.Error("failed to delete port mapping", "protocol", nat, "err", err)
+			}
+			return
+
+		case <-ticker.C:
+			port, err := nat.AddPortMapping(ctx, "tcp", d.port, externalPort, mappingDescription, mappingLifetime)
+			if err != nil {
+				d.logger.Error("failed to renew port mapping", "protocol", nat, "err", err)
+				continue
+			}
+			ip, err := nat.ExternalIP(ctx)
+			if err != nil {
+				d.logger.Error("failed to get external IP", "protocol", nat, "err", err)
+				continue
+			}
+			externalPort = port
+
+			d.mtx.Lock()
+			d.externalPort = port
+			if addr := formatAddress(ip, port); addr != d.mapped {
+				d.logger.Info("external address changed", "address", addr, "previous", d.mapped)
+				d.mapped = addr
+			}
+			d.mtx.Unlock()
+		}
+	}
+}
+
+// MappedAddress returns the external address mapped on the NAT gateway, or an
+// empty string if the port is not mapped.
+func (d *Detector) MappedAddress() string {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.mapped
+}
+
+// ExternalAddress returns the external address of the node and its source:
+// the configured address, else the address mapped on the NAT gateway, else
+// the IP the peers see the node at with its P2P port. It returns empty strings
+// if the address is unknown.
+func (d *Detector) ExternalAddress() (string, string) {
+	if d.configured != "" {
+		return d.configured, SourceConfig
+	}
+
+	d.mtx.Lock()
+	nat, mapped := d.nat, d.mapped
+	d.mtx.Unlock()
+	if mapped != "" {
+		return mapped, strings.ToLower(nat.String())
+	}
+
+	if d.observedIP != nil {
+		if ip := d.observedIP(); ip != nil {
+			return formatAddress(ip, d.port), SourcePeers
+		}
+	}
+	return "", ""
+}
+
+func formatAddress(ip net.IP, port int) string {
+	return "tcp://" + net.JoinHostPort(ip.String(), strconv.Itoa(port))
+}
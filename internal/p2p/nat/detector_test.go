@@ -0,0 +1,92 @@
+package nat
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+type testNAT struct {
+	mtx      sync.Mutex
+	mappings map[int]int
+}
+
+func (n *testNAT) ExternalIP(context.Context) (net.IP, error) {
+	return net.ParseIP("203.0.113.7"), nil
+}
+
+func (n *testNAT) AddPortMapping(
+	_ context.Context,
+	_ string,
+	internalPort, externalPort int,
+	_ string,
+	_ time.Duration,
+) (int, error) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	n.mappings[externalPort+1] = internalPort
+	return externalPort + 1, nil
+}
+
+func (n *testNAT) DeletePortMapping(_ context.Context, _ string, _, externalPort int) error {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+	delete(n.mappings, externalPort)
+	return nil
+}
+
+func (n *testNAT) String() string { return "UPnP" }
+
+func TestDetector(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var observed net.IP
+	observedIP := func() net.IP { return observed }
+
+	// Without a mapping, the IP seen by peers is used.
+	d := NewDetector(log.NewNopLogger(), "", 26656, observedIP)
+	d.discover = func(context.Context) (NAT, error) { return nil, errors.New("no NAT") }
+	require.Error(t, d.MapPort(ctx))
+	addr, source := d.ExternalAddress()
+	require.Empty(t, addr)
+	require.Empty(t, source)
+
+	observed = net.ParseIP("198.51.100.1")
+	addr, source = d.ExternalAddress()
+	require.Equal(t, "tcp://198.51.100.1:26656", addr)
+	require.Equal(t, SourcePeers, source)
+
+	// The mapped address takes precedence.
+	nat := &testNAT{mappings: map[int]int{}}
+	d.discover = func(context.Context) (NAT, error) { return nat, nil }
+	require.NoError(t, d.MapPort(ctx))
+	require.Equal(t, "tcp://203.0.113.7:26657", d.MappedAddress())
+	addr, source = d.ExternalAddress()
+	require.Equal(t, "tcp://203.0.113.7:26657", addr)
+	require.Equal(t, SourceUPnP, source)
+
+	// The mapping is deleted when the detector stops.
+	rctx, rcancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		d.Run(rctx)
+		close(done)
+	}()
+	rcancel()
+	<-done
+	require.Empty(t, nat.mappings)
+
+	// The configured address takes precedence over everything.
+	d.configured = "tcp://192.0.2.1:26656"
+	addr, source = d.ExternalAddress()
+	require.Equal(t, "tcp://192.0.2.1:26656", addr)
+	require.Equal(t, SourceConfig, source)
+}
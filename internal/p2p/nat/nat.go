@@ -0,0 +1,75 @@
+// Package nat maps ports on NAT gateways with UPnP or NAT-PMP, so that nodes
+// behind a NAT can be dialed without forwarding their ports by hand.
+package nat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// NAT is a NAT gateway able to map ports to the node.
+type NAT interface {
+	// ExternalIP returns the external IP of the gateway.
+	ExternalIP(ctx context.Context) (net.IP, error)
+
+	// AddPortMapping maps the external port of the gateway to the internal
+	// port of the node for lifetime, and returns the mapped external port,
+	// which may differ from the requested one.
+	AddPortMapping(ctx context.Context, protocol string, internalPort, externalPort int,
+		description string, lifetime time.Duration) (int, error)
+
+	// DeletePortMapping removes a port mapping.
+	DeletePortMapping(ctx context.Context, protocol string, internalPort, externalPort int) error
+
+	// String returns the protocol used to talk to the gateway.
+	String() string
+}
+
+// Discover looks for a NAT gateway with UPnP and NAT-PMP at the same time, and
+// returns the first one found.
+func Discover(ctx context.Context) (NAT, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		nat NAT
+		err error
+	}
+	discoverers := []func(context.Context) (NAT, error){DiscoverUPnP, DiscoverNATPMP}
+	results := make(chan result, len(discoverers))
+	for _, discover := range discoverers {
+		discover := discover
+		go func() {
+			nat, err := discover(ctx)
+			results <- result{nat: nat, err: err}
+		}()
+	}
+
+	var errs []string
+	for range discoverers {
+		res := <-results
+		if res.err == nil {
+			return res.nat, nil
+		}
+		errs = append(errs, res.err.Error())
+	}
+	return nil, fmt.Errorf("no NAT gateway found: %s", strings.Join(errs, "; "))
+}
+
+// localIPTo returns the local IP the node uses to reach the host.
+func localIPTo(host string) (net.IP, error) {
+	conn, err := net.Dial("udp4", net.JoinHostPort(host, "1"))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	addr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return nil, errors.New("unexpected local address")
+	}
+	return addr.IP, nil
+}
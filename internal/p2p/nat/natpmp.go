@@ -0,0 +1,183 @@
+package nat
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	natpmpPort        = 5351
+	natpmpRetries     = 4
+	natpmpInitTimeout = 250 * time.Millisecond
+
+	natpmpOpExternalAddress = 0
+	natpmpOpMapUDP          = 1
+	natpmpOpMapTCP          = 2
+)
+
+// NATPMP is a NAT gateway mapping ports with NAT-PMP (RFC 6886).
+type NATPMP struct {
+	gateway string // host:port
+}
+
+var _ NAT = (*NATPMP)(nil)
+
+// DiscoverNATPMP looks for a NAT-PMP gateway at the default gateway of the
+// node, by asking for its external IP.
+func DiscoverNATPMP(ctx context.Context) (NAT, error) {
+	gateway, err := defaultGateway()
+	if err != nil {
+		return nil, err
+	}
+	n := newNATPMP(net.JoinHostPort(gateway.String(), fmt.Sprint(natpmpPort)))
+	if _, err := n.ExternalIP(ctx); err != nil {
+		return nil, fmt.Errorf("no NAT-PMP gateway found: %w", err)
+	}
+	return n, nil
+}
+
+func newNATPMP(gateway string) *NATPMP {
+	return &NATPMP{gateway: gateway}
+}
+
+func (n *NATPMP) String() string { return "NAT-PMP" }
+
+// ExternalIP implements NAT.
+func (n *NATPMP) ExternalIP(ctx context.Context) (net.IP, error) {
+	resp, err := n.call(ctx, []byte{0, natpmpOpExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(resp[8], resp[9], resp[10], resp[11]), nil
+}
+
+// AddPortMapping implements NAT.
+func (n *NATPMP) AddPortMapping(
+	ctx context.Context,
+	protocol string,
+	internalPort, externalPort int,
+	description string,
+	lifetime time.Duration,
+) (int, error) {
+	resp, err := n.mapPort(ctx, protocol, internalPort, externalPort, lifetime)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(resp[10:12])), nil
+}
+
+// DeletePortMapping implements NAT.
+func (n *NATPMP) DeletePortMapping(ctx context.Context, protocol string, internalPort, externalPort int) error {
+	// A mapping with a zero lifetime and external port is deleted.
+	_, err := n.mapPort(ctx, protocol, internalPort, 0, 0)
+	return err
+}
+
+func (n *NATPMP) mapPort(
+	ctx context.Context,
+	protocol string,
+	internalPort, externalPort int,
+	lifetime time.Duration,
+) ([]byte, error) {
+	var op byte
+	switch strings.ToUpper(protocol) {
+	case "TCP":
+		op = natpmpOpMapTCP
+	case "UDP":
+		op = natpmpOpMapUDP
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q", protocol)
+	}
+	req := make([]byte, 12)
+	req[1] = op
+	binary.BigEndian.PutUint16(req[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(req[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(req[8:12], uint32(lifetime/time.Second))
+	return n.call(ctx, req, 16)
+}
+
+// call sends the request to the gateway, retrying with a doubling timeout as
+// recommended by RFC 6886, and returns the response of size respSize.
+func (n *NATPMP) call(ctx context.Context, req []byte, respSize int) ([]byte, error) {
+	conn, err := net.Dial("udp4", n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	resp := make([]byte, 16)
+	timeout := natpmpInitTimeout
+	for i := 0; i < natpmpRetries; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if _, err := conn.Write(req); err != nil {
+			return nil, err
+		}
+		deadline := time.Now().Add(timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		timeout *= 2
+
+		size, err := conn.Read(resp)
+		var nerr net.Error
+		if errors.As(err, &nerr) && nerr.Timeout() {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if size < respSize || resp[0] != 0 || resp[1] != req[1]|0x80 {
+			continue // not a response to the request
+		}
+		if code := binary.BigEndian.Uint16(resp[2:4]); code != 0 {
+			return nil, fmt.Errorf("NAT-PMP request failed with result code %d", code)
+		}
+		return resp[:respSize], nil
+	}
+	return nil, errors.New("NAT-PMP request timed out")
+}
+
+// defaultGateway returns the IPv4 default gateway of the node. It is only
+// known on Linux, from the routing table.
+func defaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, fmt.Errorf("can't find the default gateway: %w", err)
+	}
+	defer f.Close()
+	return parseDefaultGateway(bufio.NewScanner(f))
+}
+
+// parseDefaultGateway returns the gateway of the default route of a Linux
+// routing table, in the format of /proc/net/route.
+func parseDefaultGateway(scanner *bufio.Scanner) (net.IP, error) {
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		bz, err := hex.DecodeString(fields[2])
+		if err != nil || len(bz) != 4 {
+			continue
+		}
+		// The addresses of the routing table are in host byte order, which
+		// is little endian on the platforms tendermint runs on.
+		return net.IPv4(bz[3], bz[2], bz[1], bz[0]), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("no default gateway")
+}
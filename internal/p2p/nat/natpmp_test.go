@@ -0,0 +1,105 @@
+package nat
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestNATPMPGateway runs a NAT-PMP gateway on localhost with the external
+// IP 203.0.113.7, which maps internal ports to themselves plus 1000.
+func newTestNATPMPGateway(t *testing.T) (addr string, requests <-chan []byte) {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	reqCh := make(chan []byte, 16)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, from, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			req := append([]byte{}, buf[:n]...)
+			reqCh <- req
+
+			resp := make([]byte, 16)
+			resp[1] = req[1] | 0x80
+			switch req[1] {
+			case natpmpOpExternalAddress:
+				copy(resp[8:12], net.IPv4(203, 0, 113, 7).To4())
+				resp = resp[:12]
+			case natpmpOpMapTCP, natpmpOpMapUDP:
+				internal := binary.BigEndian.Uint16(req[4:6])
+				copy(resp[8:10], req[4:6])
+				binary.BigEndian.PutUint16(resp[10:12], internal+1000)
+				copy(resp[12:16], req[8:12])
+			}
+			_, _ = conn.WriteTo(resp, from)
+		}
+	}()
+	return conn.LocalAddr().String(), reqCh
+}
+
+func TestNATPMP(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	gateway, requests := newTestNATPMPGateway(t)
+	n := newNATPMP(gateway)
+
+	ip, err := n.ExternalIP(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.7", ip.String())
+	<-requests
+
+	port, err := n.AddPortMapping(ctx, "tcp", 26656, 26656, "test", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, 27656, port)
+	req := <-requests
+	require.EqualValues(t, natpmpOpMapTCP, req[1])
+	require.EqualValues(t, 3600, binary.BigEndian.Uint32(req[8:12]))
+
+	require.NoError(t, n.DeletePortMapping(ctx, "tcp", 26656, port))
+	req = <-requests
+	require.EqualValues(t, 0, binary.BigEndian.Uint16(req[6:8]))
+	require.EqualValues(t, 0, binary.BigEndian.Uint32(req[8:12]))
+
+	_, err = n.AddPortMapping(ctx, "sctp", 26656, 26656, "test", time.Hour)
+	require.Error(t, err)
+}
+
+func TestNATPMPTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// Nothing answers on the port of a closed connection.
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	gateway := conn.LocalAddr().String()
+	require.NoError(t, conn.Close())
+
+	_, err = newNATPMP(gateway).ExternalIP(ctx)
+	require.Error(t, err)
+}
+
+func TestParseDefaultGateway(t *testing.T) {
+	table := strings.Join([]string{
+		"Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\t\tMTU\tWindow\tIRTT",
+		"eth0\t0000A8C0\t00000000\t0001\t0\t0\t0\t00FFFFFF\t0\t0\t0",
+		"eth0\t00000000\t0101A8C0\t0003\t0\t0\t0\t00000000\t0\t0\t0",
+	}, "\n")
+	ip, err := parseDefaultGateway(bufio.NewScanner(strings.NewReader(table)))
+	require.NoError(t, err)
+	require.Equal(t, "192.168.1.1", ip.String())
+
+	_, err = parseDefaultGateway(bufio.NewScanner(strings.NewReader(strings.Split(table, "\n")[1])))
+	require.Error(t, err)
+}
@@ -0,0 +1,273 @@
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	ssdpAddr          = "239.255.255.250:1900"
+	upnpDeviceType    = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+	upnpSearchTimeout = 3 * time.Second
+)
+
+// The services of Internet gateway devices able to map ports.
+var upnpServiceTypes = []string{
+	"urn:schemas-upnp-org:service:WANIPConnection:1",
+	"urn:schemas-upnp-org:service:WANIPConnection:2",
+	"urn:schemas-upnp-org:service:WANPPPConnection:1",
+}
+
+// UPnP is a NAT gateway mapping ports with the UPnP Internet Gateway Device
+// protocol.
+type UPnP struct {
+	serviceType string
+	controlURL  string
+	localIP     net.IP
+	client      *http.Client
+}
+
+var _ NAT = (*UPnP)(nil)
+
+// DiscoverUPnP looks for an Internet gateway device on the local network with
+// SSDP.
+func DiscoverUPnP(ctx context.Context) (NAT, error) {
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	dst, err := net.ResolveUDPAddr("udp4", ssdpAddr)
+	if err != nil {
+		return nil, err
+	}
+	search := strings.Join([]string{
+		"M-SEARCH * HTTP/1.1",
+		"HOST: " + ssdpAddr,
+		"ST: " + upnpDeviceType,
+		`MAN: "ssdp:discover"`,
+		"MX: 2",
+		"", "",
+	}, "\r\n")
+
+	deadline := time.Now().Add(upnpSearchTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	// SSDP runs over UDP, so the search is sent a few times.
+	for i := 0; i < 3; i++ {
+		if _, err := conn.WriteTo([]byte(search), dst); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := make([]byte, 2048)
+	for ctx.Err() == nil {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return nil, fmt.Errorf("no UPnP gateway found: %w", err)
+		}
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(buf[:n])), nil)
+		if err != nil {
+			continue
+		}
+		resp.Body.Close()
+		if resp.Header.Get("St") != upnpDeviceType || resp.Header.Get("Location") == "" {
+			continue
+		}
+		if nat, err := newUPnP(ctx, resp.Header.Get("Location")); err == nil {
+			return nat, nil
+		}
+	}
+	return nil, ctx.Err()
+}
+
+type upnpDevice struct {
+	Services []struct {
+		ServiceType string `xml:"serviceType"`
+		ControlURL  string `xml:"controlURL"`
+	} `xml:"serviceList>service"`
+	Devices []upnpDevice `xml:"deviceList>device"`
+}
+
+// newUPnP returns the UPnP gateway with the device description at location.
+func newUPnP(ctx context.Context, location string) (*UPnP, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching UPnP device description: %s", resp.Status)
+	}
+
+	var root struct {
+		URLBase string     `xml:"URLBase"`
+		Device  upnpDevice `xml:"device"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid UPnP device description: %w", err)
+	}
+
+	serviceType, controlPath := findUPnPService(root.Device)
+	if controlPath == "" {
+		return nil, errors.New("UPnP device has no WAN connection service")
+	}
+	base := root.URLBase
+	if base == "" {
+		base = location
+	}
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	controlURL, err := baseURL.Parse(controlPath)
+	if err != nil {
+		return nil, err
+	}
+
+	localIP, err := localIPTo(controlURL.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	return &UPnP{
+		serviceType: serviceType,
+		controlURL:  controlURL.String(),
+		localIP:     localIP,
+		client:      client,
+	}, nil
+}
+
+// findUPnPService returns the type and control URL of the first service of the
+// device, or of its embedded devices, able to map ports.
+func findUPnPService(device upnpDevice) (string, string) {
+	for _, service := range device.Services {
+		for _, serviceType := range upnpServiceTypes {
+			if service.ServiceType == serviceType {
+				return service.ServiceType, service.ControlURL
+			}
+		}
+	}
+	for _, d := range device.Devices {
+		if serviceType, controlURL := findUPnPService(d); controlURL != "" {
+			return serviceType, controlURL
+		}
+	}
+	return "", ""
+}
+
+func (u *UPnP) String() string { return "UPnP" }
+
+// ExternalIP implements NAT.
+func (u *UPnP) ExternalIP(ctx context.Context) (net.IP, error) {
+	var resp struct {
+		IP string `xml:"Body>GetExternalIPAddressResponse>NewExternalIPAddress"`
+	}
+	if err := u.call(ctx, "GetExternalIPAddress", "", &resp); err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(strings.TrimSpace(resp.IP))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid external IP %q", resp.IP)
+	}
+	return ip, nil
+}
+
+// AddPortMapping implements NAT.
+func (u *UPnP) AddPortMapping(
+	ctx context.Context,
+	protocol string,
+	internalPort, externalPort int,
+	description string,
+	lifetime time.Duration,
+) (int, error) {
+	args := fmt.Sprintf("<NewRemoteHost></NewRemoteHost>"+
+		"<NewExternalPort>%d</NewExternalPort>"+
+		"<NewProtocol>%s</NewProtocol>"+
+		"<NewInternalPort>%d</NewInternalPort>"+
+		"<NewInternalClient>%s</NewInternalClient>"+
+		"<NewEnabled>1</NewEnabled>"+
+		"<NewPortMappingDescription>%s</NewPortMappingDescription>"+
+		"<NewLeaseDuration>%d</NewLeaseDuration>",
+		externalPort, strings.ToUpper(protocol), internalPort, u.localIP, xmlEscape(description),
+		int(lifetime/time.Second))
+	if err := u.call(ctx, "AddPortMapping", args, nil); err != nil {
+		return 0, err
+	}
+	return externalPort, nil
+}
+
+// DeletePortMapping implements NAT.
+func (u *UPnP) DeletePortMapping(ctx context.Context, protocol string, internalPort, externalPort int) error {
+	args := fmt.Sprintf("<NewRemoteHost></NewRemoteHost>"+
+		"<NewExternalPort>%d</NewExternalPort>"+
+		"<NewProtocol>%s</NewProtocol>",
+		externalPort, strings.ToUpper(protocol))
+	return u.call(ctx, "DeletePortMapping", args, nil)
+}
+
+// call calls the SOAP action of the service with the XML arguments, and
+// decodes the response envelope into resp, if not nil.
+func (u *UPnP) call(ctx context.Context, action, args string, resp interface{}) error {
+	body := `<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" ` +
+		`s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/"><s:Body>` +
+		fmt.Sprintf(`<u:%s xmlns:u="%s">%s</u:%s>`, action, u.serviceType, args, action) +
+		`</s:Body></s:Envelope>`
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.controlURL, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", fmt.Sprintf(`"%s#%s"`, u.serviceType, action))
+
+	res, err := u.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	bz, err := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		var fault struct {
+			Code        int    `xml:"Body>Fault>detail>UPnPError>errorCode"`
+			Description string `xml:"Body>Fault>detail>UPnPError>errorDescription"`
+		}
+		if xml.Unmarshal(bz, &fault) == nil && fault.Code != 0 {
+			return fmt.Errorf("UPnP %s failed: %s (%d)", action, fault.Description, fault.Code)
+		}
+		return fmt.Errorf("UPnP %s failed: %s", action, res.Status)
+	}
+	if resp == nil {
+		return nil
+	}
+	return xml.Unmarshal(bz, resp)
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
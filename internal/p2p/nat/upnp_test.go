@@ -0,0 +1,107 @@
+package nat
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDeviceDescription = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+    <deviceList>
+      <device>
+        <deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+        <deviceList>
+          <device>
+            <deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+            <serviceList>
+              <service>
+                <serviceType>urn:schemas-upnp-org:service:WANIPConnection:1</serviceType>
+                <controlURL>/control</controlURL>
+              </service>
+            </serviceList>
+          </device>
+        </deviceList>
+      </device>
+    </deviceList>
+  </device>
+</root>`
+
+func TestUPnP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var actions []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/description.xml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testDeviceDescription)
+	})
+	mux.HandleFunc("/control", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		action := r.Header.Get("SOAPAction")
+		actions = append(actions, action)
+
+		switch {
+		case strings.HasSuffix(action, `#GetExternalIPAddress"`):
+			fmt.Fprint(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>`+
+				`<u:GetExternalIPAddressResponse xmlns:u="urn:schemas-upnp-org:service:WANIPConnection:1">`+
+				`<NewExternalIPAddress>203.0.113.7</NewExternalIPAddress>`+
+				`</u:GetExternalIPAddressResponse></s:Body></s:Envelope>`)
+		case strings.HasSuffix(action, `#AddPortMapping"`):
+			require.Contains(t, string(body), "<NewExternalPort>26656</NewExternalPort>")
+			require.Contains(t, string(body), "<NewInternalClient>127.0.0.1</NewInternalClient>")
+			require.Contains(t, string(body), "<NewLeaseDuration>3600</NewLeaseDuration>")
+			fmt.Fprint(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body/></s:Envelope>`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body><s:Fault>`+
+				`<detail><UPnPError><errorCode>714</errorCode>`+
+				`<errorDescription>NoSuchEntryInArray</errorDescription></UPnPError></detail>`+
+				`</s:Fault></s:Body></s:Envelope>`)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	u, err := newUPnP(ctx, srv.URL+"/description.xml")
+	require.NoError(t, err)
+	require.Equal(t, "urn:schemas-upnp-org:service:WANIPConnection:1", u.serviceType)
+	require.Equal(t, srv.URL+"/control", u.controlURL)
+
+	ip, err := u.ExternalIP(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "203.0.113.7", ip.String())
+
+	port, err := u.AddPortMapping(ctx, "tcp", 26656, 26656, "test", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, 26656, port)
+
+	err = u.DeletePortMapping(ctx, "tcp", 26656, 26656)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NoSuchEntryInArray (714)")
+
+	require.Len(t, actions, 3)
+}
+
+func TestUPnPNoService(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `<root><device><serviceList></serviceList></device></root>`)
+	}))
+	defer srv.Close()
+
+	_, err := newUPnP(ctx, srv.URL)
+	require.Error(t, err)
+}
@@ -113,6 +113,7 @@ func (n *Network) Start(ctx context.Context, t *testing.T) {
 				require.Fail(t, "operation canceled")
 			case peerUpdate := <-targetSub.Updates():
 				peerUpdate.Channels = nil
+				peerUpdate.ChannelVersions = nil
 				require.Equal(t, p2p.PeerUpdate{
 					NodeID: sourceNode.NodeID,
 					Status: p2p.PeerStatusUp,
@@ -269,7 +270,7 @@ func (n *Network) MakeNode(ctx context.Context, t *testing.T, opts NodeOptions)
 		peerManager,
 		func() *types.NodeInfo { return &nodeInfo },
 		transport,
-		ep,
+		[]*p2p.Endpoint{ep},
 		p2p.RouterOptions{},
 	)
 
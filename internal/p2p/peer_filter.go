@@ -0,0 +1,136 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+// PeerFilter allows and blocks peers by node ID or IP range, for permissioned
+// networks. A peer is rejected if its node ID or IP matches a blocked entry,
+// or if there are allowed entries and neither its node ID nor its IP matches
+// one of them. The lists can be updated while the node runs.
+type PeerFilter struct {
+	mtx     sync.RWMutex
+	allowed peerFilterList
+	blocked peerFilterList
+}
+
+type peerFilterList struct {
+	ids  map[types.NodeID]struct{}
+	nets []*net.IPNet
+}
+
+// NewPeerFilter returns a PeerFilter with the allowed and blocked entries,
+// which are node IDs, IPs or CIDR ranges.
+func NewPeerFilter(allowed, blocked []string) (*PeerFilter, error) {
+	f := &PeerFilter{}
+	if err := f.Update(allowed, blocked); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Update replaces the allowed and blocked entries of the filter. The filter
+// is left unchanged if an entry is invalid.
+func (f *PeerFilter) Update(allowed, blocked []string) error {
+	allowedList, err := parsePeerFilterList(allowed)
+	if err != nil {
+		return fmt.Errorf("invalid allowed peer: %w", err)
+	}
+	blockedList, err := parsePeerFilterList(blocked)
+	if err != nil {
+		return fmt.Errorf("invalid blocked peer: %w", err)
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.allowed = allowedList
+	f.blocked = blockedList
+	return nil
+}
+
+// Filter returns an error if the peer with the node ID, connected from the
+// IP, is rejected. The IP may be nil if unknown, in which case only the node
+// ID is matched.
+func (f *PeerFilter) Filter(id types.NodeID, ip net.IP) error {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+
+	if f.blocked.matches(id, ip) {
+		return fmt.Errorf("peer %v at %v is blocked", id, ip)
+	}
+	if !f.allowed.empty() && !f.allowed.matches(id, ip) {
+		return fmt.Errorf("peer %v at %v is not allowed", id, ip)
+	}
+	return nil
+}
+
+// FilterIP returns an error if the peers connecting from the IP are rejected
+// whatever their node ID: if the IP is blocked, or if only IP ranges are
+// allowed and the IP is in none of them. It is checked as a connection is
+// accepted, before the handshake, and the peer is then checked with Filter.
+func (f *PeerFilter) FilterIP(ip net.IP) error {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+
+	if ip == nil {
+		return nil
+	}
+	if f.blocked.matchesIP(ip) {
+		return fmt.Errorf("peers at %v are blocked", ip)
+	}
+	if len(f.allowed.ids) == 0 && len(f.allowed.nets) != 0 && !f.allowed.matchesIP(ip) {
+		return fmt.Errorf("peers at %v are not allowed", ip)
+	}
+	return nil
+}
+
+func (l peerFilterList) empty() bool {
+	return len(l.ids) == 0 && len(l.nets) == 0
+}
+
+func (l peerFilterList) matches(id types.NodeID, ip net.IP) bool {
+	if _, ok := l.ids[id]; ok {
+		return true
+	}
+	return l.matchesIP(ip)
+}
+
+func (l peerFilterList) matchesIP(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, n := range l.nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePeerFilterList(entries []string) (peerFilterList, error) {
+	l := peerFilterList{ids: make(map[types.NodeID]struct{})}
+	for _, entry := range entries {
+		if id, err := types.NewNodeID(entry); err == nil {
+			l.ids[id] = struct{}{}
+			continue
+		}
+		if _, n, err := net.ParseCIDR(entry); err == nil {
+			l.nets = append(l.nets, n)
+			continue
+		}
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return peerFilterList{}, fmt.Errorf("%q is neither a node ID, an IP nor a CIDR range", entry)
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		l.nets = append(l.nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+	}
+	return l, nil
+}
@@ -0,0 +1,81 @@
+package p2p_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestPeerFilter(t *testing.T) {
+	a := types.NodeID(strings.Repeat("a", 40))
+	b := types.NodeID(strings.Repeat("b", 40))
+	c := types.NodeID(strings.Repeat("c", 40))
+	ip := net.ParseIP
+
+	// An empty filter allows all peers.
+	filter, err := p2p.NewPeerFilter(nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, filter.Filter(a, ip("10.0.0.1")))
+	require.NoError(t, filter.Filter(a, nil))
+
+	// Peers are allowed by node ID or IP, and blocked entries take
+	// precedence.
+	filter, err = p2p.NewPeerFilter(
+		[]string{string(a), "10.0.0.0/8", "2001:db8::1"},
+		[]string{string(b), "10.1.0.0/16"},
+	)
+	require.NoError(t, err)
+	require.NoError(t, filter.Filter(a, ip("192.168.1.1")))
+	require.NoError(t, filter.Filter(a, nil))
+	require.NoError(t, filter.Filter(c, ip("10.0.0.1")))
+	require.NoError(t, filter.Filter(c, ip("2001:db8::1")))
+	require.Error(t, filter.Filter(c, ip("2001:db8::2")))
+	require.Error(t, filter.Filter(c, ip("192.168.1.1")))
+	require.Error(t, filter.Filter(c, nil))
+	require.Error(t, filter.Filter(b, ip("10.0.0.1")))
+	require.Error(t, filter.Filter(a, ip("10.1.2.3")))
+
+	// A single IPv4 entry only matches that IP.
+	filter, err = p2p.NewPeerFilter(nil, []string{"192.168.1.1"})
+	require.NoError(t, err)
+	require.Error(t, filter.Filter(a, ip("192.168.1.1")))
+	require.NoError(t, filter.Filter(a, ip("192.168.1.2")))
+
+	// Updates are atomic, and invalid entries leave the filter unchanged.
+	require.Error(t, filter.Update([]string{"not-a-peer"}, nil))
+	require.Error(t, filter.Filter(a, ip("192.168.1.1")))
+	require.NoError(t, filter.Update(nil, []string{string(a)}))
+	require.NoError(t, filter.Filter(c, ip("192.168.1.1")))
+	require.Error(t, filter.Filter(a, ip("192.168.1.2")))
+
+	_, err = p2p.NewPeerFilter([]string{"10.0.0.0/33"}, nil)
+	require.Error(t, err)
+}
+
+func TestPeerFilterIP(t *testing.T) {
+	a := types.NodeID(strings.Repeat("a", 40))
+	ip := net.ParseIP
+
+	// The blocked IPs are rejected before the handshake.
+	filter, err := p2p.NewPeerFilter(nil, []string{"10.1.0.0/16", string(a)})
+	require.NoError(t, err)
+	require.Error(t, filter.FilterIP(ip("10.1.2.3")))
+	require.NoError(t, filter.FilterIP(ip("10.2.0.1")))
+	require.NoError(t, filter.FilterIP(nil))
+
+	// With only IP ranges allowed, the other IPs are rejected.
+	filter, err = p2p.NewPeerFilter([]string{"10.0.0.0/8"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, filter.FilterIP(ip("10.0.0.1")))
+	require.Error(t, filter.FilterIP(ip("192.168.1.1")))
+
+	// With allowed node IDs, any IP may be the one of an allowed peer.
+	filter, err = p2p.NewPeerFilter([]string{string(a), "10.0.0.0/8"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, filter.FilterIP(ip("192.168.1.1")))
+}
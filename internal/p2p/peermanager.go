@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"net"
 	"sort"
 	"sync"
 	"time"
@@ -53,11 +54,28 @@ const (
 	MaxPeerScoreNotPersistent PeerScore = PeerScorePersistent - 1
 )
 
+// minObservedIPPeers is the number of peers that must see us at the same IP
+// for it to be considered our external IP.
+const minObservedIPPeers = 2
+
+// The uptime and dial latency of a peer adjust its score by a point per unit,
+// up to a cap.
+const (
+	peerScoreUptimeUnit  = time.Hour
+	peerScoreUptimeCap   = 24
+	peerScoreLatencyUnit = 100 * time.Millisecond
+	peerScoreLatencyCap  = 10
+)
+
 // PeerUpdate is a peer update event sent via PeerUpdates.
 type PeerUpdate struct {
 	NodeID   types.NodeID
 	Status   PeerStatus
 	Channels ChannelIDSet
+
+	// ChannelVersions are the wire format versions of the channels negotiated
+	// with the peer, set when the peer is up.
+	ChannelVersions map[ChannelID]uint32
 }
 
 // PeerUpdates is a peer update subscription with notifications about peer
@@ -140,6 +158,19 @@ type PeerManagerOptions struct {
 	// peers listed in PersistentPeers. 0 uses MaxRetryTime instead.
 	MaxRetryTimePersistent time.Duration
 
+	// PrivatePeeringPeers are persistent peers, typically the sentries of a
+	// validator or the validator behind them, with dedicated connection
+	// slots. They don't count towards MaxConnected and
+	// MaxOutgoingConnections, are never evicted to make room for other peers,
+	// are redialed however often dials fail, and are never gossiped. They
+	// must also be listed in PersistentPeers.
+	PrivatePeeringPeers []types.NodeID
+
+	// MaxRetryTimePrivatePeering is the maximum time to wait between retries
+	// for peers listed in PrivatePeeringPeers, to which the RetryTimeJitter is
+	// always added. 0 uses MaxRetryTimePersistent instead.
+	MaxRetryTimePrivatePeering time.Duration
+
 	// RetryTimeJitter is the upper bound of a random interval added to
 	// retry times, to avoid thundering herds. 0 disables jitter.
 	RetryTimeJitter time.Duration
@@ -156,14 +187,18 @@ type PeerManagerOptions struct {
 	// consider private and never gossip.
 	PrivatePeers map[types.NodeID]struct{}
 
-	// SelfAddress is the address that will be advertised to peers for them to dial back to us.
-	// If Hostname and Port are unset, Advertise() will include no self-announcement
-	SelfAddress NodeAddress
+	// SelfAddresses are the addresses that will be advertised to peers for them to dial back to us,
+	// e.g. an IPv4 and an IPv6 address. Addresses without Hostname and Port are not advertised.
+	SelfAddresses []NodeAddress
 
 	// persistentPeers provides fast PersistentPeers lookups. It is built
 	// by optimize().
 	persistentPeers map[types.NodeID]bool
 
+	// privatePeeringPeers provides fast PrivatePeeringPeers lookups. It is
+	// built by optimize().
+	privatePeeringPeers map[types.NodeID]bool
+
 	// Peer Metrics
 	Metrics *Metrics
 }
@@ -218,6 +253,26 @@ func (o *PeerManagerOptions) Validate() error {
 		return errors.New("cannot set MaxOutgoingConnections to a value larger than MaxConnected")
 	}
 
+	persistent := make(map[types.NodeID]bool, len(o.PersistentPeers))
+	for _, id := range o.PersistentPeers {
+		persistent[id] = true
+	}
+	for _, id := range o.PrivatePeeringPeers {
+		if !persistent[id] {
+			return fmt.Errorf("private peering peer %q is not a persistent peer", id)
+		}
+	}
+
+	if o.MaxRetryTimePrivatePeering > 0 {
+		if o.MinRetryTime == 0 {
+			return errors.New("can't set MaxRetryTimePrivatePeering without MinRetryTime")
+		}
+		if o.MinRetryTime > o.MaxRetryTimePrivatePeering {
+			return fmt.Errorf("MinRetryTime %v is greater than MaxRetryTimePrivatePeering %v",
+				o.MinRetryTime, o.MaxRetryTimePrivatePeering)
+		}
+	}
+
 	return nil
 }
 
@@ -230,6 +285,24 @@ func (o *PeerManagerOptions) isPersistent(id types.NodeID) bool {
 	return o.persistentPeers[id]
 }
 
+// isPrivatePeering checks if a peer is in PrivatePeeringPeers. It will panic
+// if called before optimize().
+func (o *PeerManagerOptions) isPrivatePeering(id types.NodeID) bool {
+	if o.privatePeeringPeers == nil {
+		panic("isPrivatePeering() called before optimize()")
+	}
+	return o.privatePeeringPeers[id]
+}
+
+// isPrivate checks if a peer must never be gossiped, i.e. if it is in
+// PrivatePeers or PrivatePeeringPeers.
+func (o *PeerManagerOptions) isPrivate(id types.NodeID) bool {
+	if _, ok := o.PrivatePeers[id]; ok {
+		return true
+	}
+	return o.isPrivatePeering(id)
+}
+
 // optimize optimizes operations by pregenerating lookup structures. It's a
 // separate method instead of memoizing during calls to avoid dealing with
 // concurrency and mutex overhead.
@@ -238,6 +311,10 @@ func (o *PeerManagerOptions) optimize() {
 	for _, p := range o.PersistentPeers {
 		o.persistentPeers[p] = true
 	}
+	o.privatePeeringPeers = make(map[types.NodeID]bool, len(o.PrivatePeeringPeers))
+	for _, p := range o.PrivatePeeringPeers {
+		o.privatePeeringPeers[p] = true
+	}
 }
 
 // PeerManager manages peer lifecycle information, using a peerStore for
@@ -295,11 +372,16 @@ type PeerManager struct {
 	store         *peerStore
 	subscriptions map[*PeerUpdates]*PeerUpdates            // keyed by struct identity (address)
 	dialing       map[types.NodeID]bool                    // peers being dialed (DialNext → Dialed/DialFail)
+	dialStarted   map[types.NodeID]time.Time               // when the dials of peers being dialed started
 	upgrading     map[types.NodeID]types.NodeID            // peers claimed for upgrade (DialNext → Dialed/DialFail)
 	connected     map[types.NodeID]peerConnectionDirection // connected peers (Dialed/Accepted → Disconnected)
 	ready         map[types.NodeID]bool                    // ready peers (Ready → Disconnected)
 	evict         map[types.NodeID]bool                    // peers scheduled for eviction (Connected → EvictNext)
 	evicting      map[types.NodeID]bool                    // peers being evicted (EvictNext → Disconnected)
+	remoteIPs     map[types.NodeID]net.IP                  // IPs of connected peers (Dialed/Accepted → Disconnected)
+	chVersions    map[types.NodeID]map[ChannelID]uint32    // negotiated channel versions (Dialed/Accepted → Disconnected)
+	peerVersions  map[types.NodeID]string                  // software versions of connected peers (Dialed/Accepted → Disconnected)
+	observedIPs   map[types.NodeID]string                  // our IP as seen by connected peers
 }
 
 // NewPeerManager creates a new peer manager.
@@ -328,11 +410,16 @@ func NewPeerManager(selfID types.NodeID, peerDB dbm.DB, options PeerManagerOptio
 
 		store:         store,
 		dialing:       map[types.NodeID]bool{},
+		dialStarted:   map[types.NodeID]time.Time{},
 		upgrading:     map[types.NodeID]types.NodeID{},
 		connected:     map[types.NodeID]peerConnectionDirection{},
 		ready:         map[types.NodeID]bool{},
 		evict:         map[types.NodeID]bool{},
 		evicting:      map[types.NodeID]bool{},
+		remoteIPs:     map[types.NodeID]net.IP{},
+		chVersions:    map[types.NodeID]map[ChannelID]uint32{},
+		peerVersions:  map[types.NodeID]string{},
+		observedIPs:   map[types.NodeID]string{},
 		subscriptions: map[*PeerUpdates]*PeerUpdates{},
 	}
 
@@ -359,7 +446,7 @@ func (m *PeerManager) configurePeers() error {
 
 	configure := map[types.NodeID]bool{}
 	for _, id := range m.options.PersistentPeers {
-		configure[id] = true
+		configure[m.store.ResolveRotation(id)] = true
 	}
 	for id := range m.options.PeerScores {
 		configure[id] = true
@@ -379,11 +466,25 @@ func (m *PeerManager) configurePeers() error {
 
 // configurePeer configures a peer with ephemeral runtime configuration.
 func (m *PeerManager) configurePeer(peer peerInfo) peerInfo {
-	peer.Persistent = m.options.isPersistent(peer.ID)
+	peer.Persistent = m.isPersistent(peer.ID)
 	peer.FixedScore = m.options.PeerScores[peer.ID]
 	return peer
 }
 
+// isPersistent checks if a peer is in PersistentPeers, or rotated its node
+// key from the node ID of one of them. The caller must hold the mutex lock.
+func (m *PeerManager) isPersistent(id types.NodeID) bool {
+	if m.options.isPersistent(id) {
+		return true
+	}
+	for _, persistentID := range m.options.PersistentPeers {
+		if persistentID != id && m.store.ResolveRotation(persistentID) == id {
+			return true
+		}
+	}
+	return false
+}
+
 // newPeerInfo creates a peerInfo for a new peer.
 func (m *PeerManager) newPeerInfo(id types.NodeID) peerInfo {
 	peerInfo := peerInfo{
@@ -424,14 +525,44 @@ func (m *PeerManager) isConnected(peerID types.NodeID) bool {
 	return ok
 }
 
+// numConnected returns the number of connected peers using regular connection
+// slots, i.e. all but private peering peers. The caller must hold the mutex
+// lock.
+func (m *PeerManager) numConnected() int {
+	n := 0
+	for peerID := range m.connected {
+		if !m.options.isPrivatePeering(peerID) {
+			n++
+		}
+	}
+	return n
+}
+
+// numDialing returns the number of peers being dialed using regular
+// connection slots. The caller must hold the mutex lock.
+func (m *PeerManager) numDialing() int {
+	n := 0
+	for peerID := range m.dialing {
+		if !m.options.isPrivatePeering(peerID) {
+			n++
+		}
+	}
+	return n
+}
+
 type connectionStats struct {
 	incoming uint16
 	outgoing uint16
 }
 
+// getConnectedInfo returns the number of incoming and outgoing connections
+// using regular connection slots.
 func (m *PeerManager) getConnectedInfo() connectionStats {
 	out := connectionStats{}
-	for _, direction := range m.connected {
+	for peerID, direction := range m.connected {
+		if m.options.isPrivatePeering(peerID) {
+			continue
+		}
 		switch direction {
 		case peerConnectionIncoming:
 			out.incoming++
@@ -444,7 +575,8 @@ func (m *PeerManager) getConnectedInfo() connectionStats {
 
 // Add adds a peer to the manager, given as an address. If the peer already
 // exists, the address is added to it if it isn't already present. This will push
-// low scoring peers out of the address book if it exceeds the maximum size.
+// low scoring peers out of the address book if it exceeds the maximum size. The
+// address of a peer which rotated its node key is added with its new node ID.
 func (m *PeerManager) Add(address NodeAddress) (bool, error) {
 	if err := address.Validate(); err != nil {
 		return false, err
@@ -456,6 +588,11 @@ func (m *PeerManager) Add(address NodeAddress) (bool, error) {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	address.NodeID = m.store.ResolveRotation(address.NodeID)
+	if address.NodeID == m.selfID {
+		return false, fmt.Errorf("can't add self (%v) to peer store", m.selfID)
+	}
+
 	peer, ok := m.store.Get(address.NodeID)
 	if !ok {
 		peer = m.newPeerInfo(address.NodeID)
@@ -483,6 +620,60 @@ func (m *PeerManager) Add(address NodeAddress) (bool, error) {
 	return true, nil
 }
 
+// SetPersistentPeers replaces the persistent peers with the ones at the given
+// addresses, which are added to the peer store. The peers no longer persistent
+// are scored like any other, and may be evicted for better-scored peers.
+func (m *PeerManager) SetPersistentPeers(addresses []NodeAddress) error {
+	ids := make([]types.NodeID, 0, len(addresses))
+	for _, address := range addresses {
+		if err := address.Validate(); err != nil {
+			return err
+		}
+		if address.NodeID == m.selfID {
+			return fmt.Errorf("can't add self (%v) as a persistent peer", m.selfID)
+		}
+		ids = append(ids, address.NodeID)
+	}
+
+	m.mtx.Lock()
+	options := m.options
+	options.PersistentPeers = ids
+	if err := options.Validate(); err != nil {
+		m.mtx.Unlock()
+		return err
+	}
+	options.optimize()
+
+	// reconfigure the peers added to or removed from the persistent peers
+	configure := map[types.NodeID]bool{}
+	for id := range options.persistentPeers {
+		configure[m.store.ResolveRotation(id)] = true
+	}
+	for id := range m.options.persistentPeers {
+		configure[m.store.ResolveRotation(id)] = true
+	}
+	m.options.PersistentPeers = options.PersistentPeers
+	m.options.persistentPeers = options.persistentPeers
+	for id := range configure {
+		if peer, ok := m.store.Get(id); ok {
+			if err := m.store.Set(m.configurePeer(peer)); err != nil {
+				m.mtx.Unlock()
+				return err
+			}
+		}
+	}
+	m.mtx.Unlock()
+
+	for _, address := range addresses {
+		if _, err := m.Add(address); err != nil {
+			return err
+		}
+	}
+	m.dialWaker.Wake()
+	m.evictWaker.Wake()
+	return nil
+}
+
 // PeerRatio returns the ratio of peer addresses stored to the maximum size.
 func (m *PeerManager) PeerRatio() float64 {
 	m.mtx.Lock()
@@ -499,7 +690,7 @@ func (m *PeerManager) HasMaxPeerCapacity() bool {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
-	return len(m.connected) >= int(m.options.MaxConnected)
+	return m.numConnected() >= int(m.options.MaxConnected)
 }
 
 func (m *PeerManager) HasDialedMaxPeers() bool {
@@ -536,10 +727,24 @@ func (m *PeerManager) TryDialNext() NodeAddress {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
+	// Private peering peers have dedicated connection slots, so they are
+	// dialed regardless of the connection limits.
+	for _, id := range m.options.PrivatePeeringPeers {
+		peer, ok := m.store.Get(id)
+		if !ok {
+			continue
+		}
+		if address := m.dialableAddress(&peer); (address != NodeAddress{}) {
+			m.dialing[peer.ID] = true
+			m.dialStarted[peer.ID] = time.Now()
+			return address
+		}
+	}
+
 	// We allow dialing MaxConnected+MaxConnectedUpgrade peers. Including
 	// MaxConnectedUpgrade allows us to probe additional peers that have a
 	// higher score than any other peers, and if successful evict it.
-	if m.options.MaxConnected > 0 && len(m.connected)+len(m.dialing) >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
+	if m.options.MaxConnected > 0 && m.numConnected()+m.numDialing() >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
 		return NodeAddress{}
 	}
 
@@ -549,45 +754,72 @@ func (m *PeerManager) TryDialNext() NodeAddress {
 	}
 
 	for _, peer := range m.store.Ranked() {
-		if m.dialing[peer.ID] || m.isConnected(peer.ID) {
+		if m.options.isPrivatePeering(peer.ID) {
 			continue
 		}
-
-		if !peer.LastDisconnected.IsZero() && time.Since(peer.LastDisconnected) < m.options.DisconnectCooldownPeriod {
+		address := m.dialableAddress(peer)
+		if (address == NodeAddress{}) {
 			continue
 		}
 
-		for _, addressInfo := range peer.AddressInfo {
-			if time.Since(addressInfo.LastDialFailure) < m.retryDelay(addressInfo.DialFailures, peer.Persistent) {
-				continue
+		// We now have an eligible address to dial. If we're full but have
+		// upgrade capacity (as checked above), we find a lower-scored peer
+		// we can replace and mark it as upgrading so noone else claims it.
+		//
+		// If we don't find one, there is no point in trying additional
+		// peers, since they will all have the same or lower score than this
+		// peer (since they're ordered by score via peerStore.Ranked).
+		if m.options.MaxConnected > 0 && m.numConnected() >= int(m.options.MaxConnected) {
+			upgradeFromPeer := m.findUpgradeCandidate(peer.ID, peer.Score())
+			if upgradeFromPeer == "" {
+				return NodeAddress{}
 			}
+			m.upgrading[upgradeFromPeer] = peer.ID
+		}
 
-			if id, ok := m.store.Resolve(addressInfo.Address); ok && (m.isConnected(id) || m.dialing[id]) {
-				continue
-			}
+		m.dialing[peer.ID] = true
+		m.dialStarted[peer.ID] = time.Now()
+		return address
+	}
+	return NodeAddress{}
+}
 
-			// We now have an eligible address to dial. If we're full but have
-			// upgrade capacity (as checked above), we find a lower-scored peer
-			// we can replace and mark it as upgrading so noone else claims it.
-			//
-			// If we don't find one, there is no point in trying additional
-			// peers, since they will all have the same or lower score than this
-			// peer (since they're ordered by score via peerStore.Ranked).
-			if m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected) {
-				upgradeFromPeer := m.findUpgradeCandidate(peer.ID, peer.Score())
-				if upgradeFromPeer == "" {
-					return NodeAddress{}
-				}
-				m.upgrading[upgradeFromPeer] = peer.ID
-			}
+// dialableAddress returns an address of the peer that can be dialed now, or
+// an empty address if the peer is connected, being dialed, cooling down after
+// a disconnection, or waiting to retry all of its addresses. The caller must
+// hold the mutex lock.
+func (m *PeerManager) dialableAddress(peer *peerInfo) NodeAddress {
+	if m.dialing[peer.ID] || m.isConnected(peer.ID) {
+		return NodeAddress{}
+	}
 
-			m.dialing[peer.ID] = true
-			return addressInfo.Address
+	if !peer.LastDisconnected.IsZero() && time.Since(peer.LastDisconnected) < m.options.DisconnectCooldownPeriod {
+		return NodeAddress{}
+	}
+
+	for _, addressInfo := range peer.AddressInfo {
+		if time.Since(addressInfo.LastDialFailure) < m.retryDelay(addressInfo.DialFailures, peer) {
+			continue
+		}
+
+		if id, ok := m.store.Resolve(addressInfo.Address); ok && (m.isConnected(id) || m.dialing[id]) {
+			continue
 		}
+
+		return addressInfo.Address
 	}
 	return NodeAddress{}
 }
 
+// averageLatency returns the exponential moving average of the dial latency of
+// a peer, given its previous average and the latency of a new dial.
+func averageLatency(avg, latency time.Duration) time.Duration {
+	if avg == 0 {
+		return latency
+	}
+	return (3*avg + latency) / 4
+}
+
 // DialFailed reports a failed dial attempt. This will make the peer available
 // for dialing again when appropriate (possibly after a retry timeout).
 func (m *PeerManager) DialFailed(ctx context.Context, address NodeAddress) error {
@@ -596,6 +828,7 @@ func (m *PeerManager) DialFailed(ctx context.Context, address NodeAddress) error
 	m.metrics.PeersConnectedFailure.Add(1)
 
 	delete(m.dialing, address.NodeID)
+	delete(m.dialStarted, address.NodeID)
 	for from, to := range m.upgrading {
 		if to == address.NodeID {
 			delete(m.upgrading, from) // Unmark failed upgrade attempt.
@@ -613,6 +846,9 @@ func (m *PeerManager) DialFailed(ctx context.Context, address NodeAddress) error
 
 	addressInfo.LastDialFailure = time.Now().UTC()
 	addressInfo.DialFailures++
+	if m.options.isPrivatePeering(peer.ID) {
+		m.metrics.PrivatePeerDialFailures.Add(1)
+	}
 
 	if err := m.store.Set(peer); err != nil {
 		return err
@@ -622,7 +858,7 @@ func (m *PeerManager) DialFailed(ctx context.Context, address NodeAddress) error
 	// timeout has elapsed, so that we can consider dialing it again. We
 	// calculate the retry delay outside the goroutine, since it must hold
 	// the mutex lock.
-	if d := m.retryDelay(addressInfo.DialFailures, peer.Persistent); d != 0 && d != retryNever {
+	if d := m.retryDelay(addressInfo.DialFailures, &peer); d != 0 && d != retryNever {
 		go func() {
 			// Use an explicit timer with deferred cleanup instead of
 			// time.After(), to avoid leaking goroutines on PeerManager.Close().
@@ -649,7 +885,9 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 
 	m.metrics.PeersConnectedSuccess.Add(1)
 
+	dialStarted, dialTimed := m.dialStarted[address.NodeID]
 	delete(m.dialing, address.NodeID)
+	delete(m.dialStarted, address.NodeID)
 
 	var upgradeFromPeer types.NodeID
 	for from, to := range m.upgrading {
@@ -666,8 +904,9 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 	if m.isConnected(address.NodeID) {
 		return fmt.Errorf("peer %v is already connected", address.NodeID)
 	}
-	if m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected) {
-		if upgradeFromPeer == "" || len(m.connected) >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
+	privatePeering := m.options.isPrivatePeering(address.NodeID)
+	if !privatePeering && m.options.MaxConnected > 0 && m.numConnected() >= int(m.options.MaxConnected) {
+		if upgradeFromPeer == "" || m.numConnected() >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
 			return fmt.Errorf("already connected to maximum number of peers")
 		}
 	}
@@ -683,6 +922,9 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 	peer.Inactive = false
 
 	peer.LastConnected = now
+	if dialTimed {
+		peer.Latency = averageLatency(peer.Latency, now.Sub(dialStarted))
+	}
 	if addressInfo, ok := peer.AddressInfo[address]; ok {
 		addressInfo.DialFailures = 0
 		addressInfo.LastDialSuccess = now
@@ -693,7 +935,7 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 		return err
 	}
 
-	if upgradeFromPeer != "" && m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected) {
+	if upgradeFromPeer != "" && m.options.MaxConnected > 0 && m.numConnected() >= int(m.options.MaxConnected) {
 		// Look for an even lower-scored peer that may have appeared since we
 		// started the upgrade.
 		if p, ok := m.store.Get(upgradeFromPeer); ok {
@@ -706,6 +948,9 @@ func (m *PeerManager) Dialed(address NodeAddress) error {
 	}
 
 	m.metrics.PeersConnectedOutgoing.Add(1)
+	if privatePeering {
+		m.metrics.PrivatePeersConnected.Add(1)
+	}
 	m.connected[peer.ID] = peerConnectionOutgoing
 
 	return nil
@@ -738,7 +983,8 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 	if m.isConnected(peerID) {
 		return fmt.Errorf("peer %q is already connected", peerID)
 	}
-	if m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
+	privatePeering := m.options.isPrivatePeering(peerID)
+	if !privatePeering && m.options.MaxConnected > 0 && m.numConnected() >= int(m.options.MaxConnected)+int(m.options.MaxConnectedUpgrade) {
 		return fmt.Errorf("already connected to maximum number of peers")
 	}
 
@@ -756,7 +1002,7 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 	// above that we have upgrade capacity), then we can look for a lower-scored
 	// peer to replace and if found accept the connection anyway and evict it.
 	var upgradeFromPeer types.NodeID
-	if m.options.MaxConnected > 0 && len(m.connected) >= int(m.options.MaxConnected) {
+	if !privatePeering && m.options.MaxConnected > 0 && m.numConnected() >= int(m.options.MaxConnected) {
 		upgradeFromPeer = m.findUpgradeCandidate(peer.ID, peer.Score())
 		if upgradeFromPeer == "" {
 			return fmt.Errorf("already connected to maximum number of peers")
@@ -773,6 +1019,9 @@ func (m *PeerManager) Accepted(peerID types.NodeID) error {
 	}
 
 	m.metrics.PeersConnectedIncoming.Add(1)
+	if privatePeering {
+		m.metrics.PrivatePeersConnected.Add(1)
+	}
 	m.connected[peerID] = peerConnectionIncoming
 	if upgradeFromPeer != "" {
 		m.evict[upgradeFromPeer] = true
@@ -795,9 +1044,10 @@ func (m *PeerManager) Ready(ctx context.Context, peerID types.NodeID, channels C
 	if m.isConnected(peerID) {
 		m.ready[peerID] = true
 		m.broadcast(ctx, PeerUpdate{
-			NodeID:   peerID,
-			Status:   PeerStatusUp,
-			Channels: channels,
+			NodeID:          peerID,
+			Status:          PeerStatusUp,
+			Channels:        channels,
+			ChannelVersions: m.chVersions[peerID],
 		})
 	}
 }
@@ -834,9 +1084,16 @@ func (m *PeerManager) TryEvictNext() (types.NodeID, error) {
 		}
 	}
 
-	// If we're below capacity, we don't need to evict anything.
+	// If we're below capacity, we don't need to evict anything. Private
+	// peering peers don't use the regular connection slots.
+	evicting := 0
+	for peerID := range m.evicting {
+		if !m.options.isPrivatePeering(peerID) {
+			evicting++
+		}
+	}
 	if m.options.MaxConnected == 0 ||
-		len(m.connected)-len(m.evicting) <= int(m.options.MaxConnected) {
+		m.numConnected()-evicting <= int(m.options.MaxConnected) {
 		return "", nil
 	}
 
@@ -845,6 +1102,9 @@ func (m *PeerManager) TryEvictNext() (types.NodeID, error) {
 	ranked := m.store.Ranked()
 	for i := len(ranked) - 1; i >= 0; i-- {
 		peer := ranked[i]
+		if m.options.isPrivatePeering(peer.ID) {
+			continue
+		}
 		if m.isConnected(peer.ID) && !m.evicting[peer.ID] {
 			m.evicting[peer.ID] = true
 			return peer.ID, nil
@@ -868,15 +1128,27 @@ func (m *PeerManager) Disconnected(ctx context.Context, peerID types.NodeID) {
 	}
 
 	ready := m.ready[peerID]
+	_, wasConnected := m.connected[peerID]
+	if wasConnected && m.options.isPrivatePeering(peerID) {
+		m.metrics.PrivatePeersConnected.Add(-1)
+		m.metrics.PrivatePeerDisconnects.Add(1)
+	}
 
 	delete(m.connected, peerID)
 	delete(m.upgrading, peerID)
 	delete(m.evict, peerID)
 	delete(m.evicting, peerID)
 	delete(m.ready, peerID)
+	delete(m.remoteIPs, peerID)
+	delete(m.chVersions, peerID)
+	delete(m.peerVersions, peerID)
+	delete(m.observedIPs, peerID)
 
 	if peer, ok := m.store.Get(peerID); ok {
 		peer.LastDisconnected = time.Now()
+		if wasConnected && !peer.LastConnected.IsZero() {
+			peer.Uptime += peer.LastDisconnected.Sub(peer.LastConnected)
+		}
 		_ = m.store.Set(peer)
 		// launch a thread to ping the dialWaker when the
 		// disconnected peer can be dialed again.
@@ -922,13 +1194,14 @@ func (m *PeerManager) Errored(peerID types.NodeID, err error) {
 
 // Inactivate marks a peer as inactive which means we won't attempt to
 // dial this peer again. A peer can be reactivated by successfully
-// dialing and connecting to the node.
+// dialing and connecting to the node. Private peering peers are never
+// inactivated.
 func (m *PeerManager) Inactivate(peerID types.NodeID) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
 	peer, ok := m.store.peers[peerID]
-	if !ok {
+	if !ok || m.options.isPrivatePeering(peerID) {
 		return nil
 	}
 
@@ -937,6 +1210,76 @@ func (m *PeerManager) Inactivate(peerID types.NodeID) error {
 	return m.store.Set(*peer)
 }
 
+// NodeKeyRotated records a peer changing its node key, moving the record of
+// its old node ID, with its score, uptime and addresses, to the new node ID,
+// which then also takes the place of the old one in PersistentPeers. The
+// rotation must be verified, as by NodeInfo.Validate. Recording a rotation
+// again is a no-op.
+//
+// A recorded rotation is final: a rotation of the same old node ID to another
+// one is rejected, so that whoever holds a leaked old node key can't take the
+// place of the peer. Only the rotations of the stored and persistent peers
+// are recorded, up to maxNodeKeyRotations for the non-persistent ones, so that
+// a peer generating node keys can't grow the peer store without bounds; the
+// others are ignored.
+func (m *PeerManager) NodeKeyRotated(rotation *types.NodeKeyRotation) error {
+	oldID, newID := rotation.OldID(), rotation.NewID()
+	if oldID == m.selfID || newID == m.selfID {
+		return fmt.Errorf("can't rotate the node key of self (%v)", m.selfID)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if _, ok := m.connected[oldID]; ok {
+		return fmt.Errorf("peer %v rotated its node key to %v, but is still connected", oldID, newID)
+	}
+	if rotatedID, ok := m.store.rotations[oldID]; ok {
+		if rotatedID != newID {
+			return fmt.Errorf("peer %v already rotated its node key to %v, not %v", oldID, rotatedID, newID)
+		}
+	} else {
+		_, known := m.store.peers[oldID]
+		persistent := m.isPersistent(oldID)
+		if !known && !persistent {
+			return nil
+		}
+		if !persistent && len(m.store.rotations) >= maxNodeKeyRotations {
+			return nil
+		}
+	}
+
+	size := m.store.Size()
+	changed, err := m.store.Rotate(oldID, newID)
+	if err != nil || !changed {
+		return err
+	}
+	if peer, ok := m.store.Get(newID); ok {
+		if err := m.store.Set(m.configurePeer(peer)); err != nil {
+			return err
+		}
+	}
+	m.metrics.PeersStored.Add(float64(m.store.Size() - size))
+	m.dialWaker.Wake()
+	return nil
+}
+
+// maxNodeKeyRotations is the maximum number of node key rotations of peers
+// recorded by the peer manager, besides those of its persistent peers.
+const maxNodeKeyRotations = 1024
+
+// RecordNodeKeyRotation records the node changing its own node key in its
+// peer database, so that the peer manager rejects the addresses of its old
+// node ID, as those of itself.
+func RecordNodeKeyRotation(peerDB dbm.DB, rotation *types.NodeKeyRotation) error {
+	store, err := newPeerStore(peerDB)
+	if err != nil {
+		return err
+	}
+	_, err = store.Rotate(rotation.OldID(), rotation.NewID())
+	return err
+}
+
 // Advertise returns a list of peer addresses to advertise to a peer.
 //
 // It sorts all peers in the peer store, and assembles a list of peers
@@ -949,8 +1292,10 @@ func (m *PeerManager) Advertise(peerID types.NodeID, limit uint16) []NodeAddress
 
 	// advertise ourselves, to let everyone know how to dial us back
 	// and enable mutual address discovery
-	if m.options.SelfAddress.Hostname != "" && m.options.SelfAddress.Port != 0 {
-		addresses = append(addresses, m.options.SelfAddress)
+	for _, self := range m.options.SelfAddresses {
+		if self.Hostname != "" && self.Port != 0 {
+			addresses = append(addresses, self)
+		}
 	}
 
 	var numAddresses int
@@ -973,7 +1318,7 @@ func (m *PeerManager) Advertise(peerID types.NodeID, limit uint16) []NodeAddress
 
 		scores[peer.ID] = score
 		for addr := range peer.AddressInfo {
-			if _, ok := m.options.PrivatePeers[addr.NodeID]; !ok {
+			if !m.options.isPrivate(addr.NodeID) {
 				numAddresses++
 			}
 		}
@@ -1019,7 +1364,7 @@ func (m *PeerManager) Advertise(peerID types.NodeID, limit uint16) []NodeAddress
 				}
 
 				// only add non-private NodeIDs
-				if _, ok := m.options.PrivatePeers[nodeAddr.NodeID]; !ok {
+				if !m.options.isPrivate(nodeAddr.NodeID) {
 					// add the peer if the total number of ranked addresses is
 					// will fit within the limit, or otherwise adding
 					// addresses based on a coin flip.
@@ -1112,21 +1457,32 @@ func (m *PeerManager) processPeerEvent(ctx context.Context, pu PeerUpdate) {
 		return
 	}
 
-	if _, ok := m.store.peers[pu.NodeID]; !ok {
-		m.store.peers[pu.NodeID] = &peerInfo{}
+	peer, ok := m.store.peers[pu.NodeID]
+	if !ok {
+		peer = &peerInfo{}
+		m.store.peers[pu.NodeID] = peer
 	}
 
 	switch pu.Status {
 	case PeerStatusBad:
-		if m.store.peers[pu.NodeID].MutableScore == math.MinInt16 {
+		if peer.MutableScore == math.MinInt16 {
 			return
 		}
-		m.store.peers[pu.NodeID].MutableScore--
+		peer.MutableScore--
 	case PeerStatusGood:
-		if m.store.peers[pu.NodeID].MutableScore == math.MaxInt16 {
+		if peer.MutableScore == math.MaxInt16 {
 			return
 		}
-		m.store.peers[pu.NodeID].MutableScore++
+		peer.MutableScore++
+	default:
+		return
+	}
+
+	// The score changed in place, so the ranking is stale. The score is
+	// persisted for known peers, so that it survives restarts.
+	m.store.ranked = nil
+	if peer.ID != "" {
+		_ = m.store.Set(*peer)
 	}
 }
 
@@ -1189,6 +1545,160 @@ func (m *PeerManager) Scores() map[types.NodeID]PeerScore {
 	return scores
 }
 
+// AddressBookEntry describes a peer in the address book of the peer manager.
+type AddressBookEntry struct {
+	ID               types.NodeID
+	Addresses        []AddressBookAddress
+	Score            PeerScore
+	MisbehaviorScore int64 // the score adjusted by the router on good or bad peer behavior
+	Uptime           time.Duration
+	Latency          time.Duration
+	LastConnected    time.Time
+	Connected        bool
+	Persistent       bool
+	Inactive         bool
+}
+
+// AddressBookAddress describes an address of a peer in the address book, with
+// its dial statistics.
+type AddressBookAddress struct {
+	Address         NodeAddress
+	LastDialSuccess time.Time
+	LastDialFailure time.Time
+	DialFailures    uint32
+}
+
+// AddressBook returns the address book entries of all known peers, ordered by
+// score (better peers first). The private peers, of PrivatePeers and
+// PrivatePeeringPeers, are left out, as they are never gossiped either.
+func (m *PeerManager) AddressBook() []AddressBookEntry {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	entries := []AddressBookEntry{}
+	for _, peer := range m.store.Ranked() {
+		if peer.ID == "" {
+			continue // only known from peer updates
+		}
+		if m.options.isPrivate(peer.ID) {
+			continue
+		}
+		entry := AddressBookEntry{
+			ID:               peer.ID,
+			Addresses:        make([]AddressBookAddress, 0, len(peer.AddressInfo)),
+			Score:            peer.Score(),
+			MisbehaviorScore: peer.MutableScore,
+			Uptime:           peer.Uptime,
+			Latency:          peer.Latency,
+			LastConnected:    peer.LastConnected,
+			Connected:        m.isConnected(peer.ID),
+			Persistent:       peer.Persistent,
+			Inactive:         peer.Inactive,
+		}
+		for _, addressInfo := range peer.AddressInfo {
+			entry.Addresses = append(entry.Addresses, AddressBookAddress{
+				Address:         addressInfo.Address,
+				LastDialSuccess: addressInfo.LastDialSuccess,
+				LastDialFailure: addressInfo.LastDialFailure,
+				DialFailures:    addressInfo.DialFailures,
+			})
+		}
+		sort.Slice(entry.Addresses, func(i, j int) bool {
+			return entry.Addresses[i].Address.String() < entry.Addresses[j].Address.String()
+		})
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// setRemoteIP records the IP of the connection with a connected peer: the IP
+// of an inbound connection, which is the external IP of the peer if it is
+// behind a NAT, or the IP an outbound connection was dialed at.
+func (m *PeerManager) setRemoteIP(peerID types.NodeID, ip net.IP) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.isConnected(peerID) && ip != nil {
+		m.remoteIPs[peerID] = ip
+	}
+}
+
+// RemoteIP returns the IP of the connection with a connected peer, or nil if
+// unknown.
+func (m *PeerManager) RemoteIP(peerID types.NodeID) net.IP {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.remoteIPs[peerID]
+}
+
+// setChannelVersions records the channel versions negotiated with a connected
+// peer during the handshake.
+func (m *PeerManager) setChannelVersions(peerID types.NodeID, versions map[ChannelID]uint32) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.isConnected(peerID) {
+		m.chVersions[peerID] = versions
+	}
+}
+
+// ChannelVersions returns the channel versions negotiated with a connected
+// peer, or nil if unknown.
+func (m *PeerManager) ChannelVersions(peerID types.NodeID) map[ChannelID]uint32 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.chVersions[peerID]
+}
+
+// setPeerVersion records the software version a connected peer reported in
+// the handshake.
+func (m *PeerManager) setPeerVersion(peerID types.NodeID, version string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.isConnected(peerID) && version != "" {
+		m.peerVersions[peerID] = version
+	}
+}
+
+// PeerVersion returns the software version of a connected peer, or an empty
+// string if unknown.
+func (m *PeerManager) PeerVersion(peerID types.NodeID) string {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.peerVersions[peerID]
+}
+
+// ObserveSelfIP records the IP a connected peer sees us at.
+func (m *PeerManager) ObserveSelfIP(peerID types.NodeID, ip net.IP) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.isConnected(peerID) && ip != nil {
+		m.observedIPs[peerID] = ip.String()
+	}
+}
+
+// ObservedIP returns the public IP the most connected peers see us at, if
+// at least minObservedIPPeers of them agree, or else nil. Like a STUN probe,
+// this finds the external IP of a node behind a NAT.
+func (m *PeerManager) ObservedIP() net.IP {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	counts := map[string]int{}
+	for _, ip := range m.observedIPs {
+		counts[ip]++
+	}
+	var best string
+	for ip, count := range counts {
+		if count > counts[best] || (count == counts[best] && ip < best) {
+			best = ip
+		}
+	}
+	ip := net.ParseIP(best)
+	if counts[best] < minObservedIPPeers || ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return nil
+	}
+	return ip
+}
+
 // Status returns the status for a peer, primarily for testing.
 func (m *PeerManager) Status(id types.NodeID) PeerStatus {
 	m.mtx.Lock()
@@ -1221,6 +1731,7 @@ func (m *PeerManager) findUpgradeCandidate(id types.NodeID, score PeerScore) typ
 		case candidate.Score() >= score:
 			return "" // no further peers can be scored lower, due to sorting
 		case !m.isConnected(candidate.ID):
+		case m.options.isPrivatePeering(candidate.ID):
 		case m.evict[candidate.ID]:
 		case m.evicting[candidate.ID]:
 		case m.upgrading[candidate.ID] != "":
@@ -1235,7 +1746,7 @@ func (m *PeerManager) findUpgradeCandidate(id types.NodeID, score PeerScore) typ
 // retry settings in PeerManagerOptions. If retries are disabled (i.e.
 // MinRetryTime is 0), this returns retryNever (i.e. an infinite retry delay).
 // The caller must hold the mutex lock (for m.rand which is not thread-safe).
-func (m *PeerManager) retryDelay(failures uint32, persistent bool) time.Duration {
+func (m *PeerManager) retryDelay(failures uint32, peer *peerInfo) time.Duration {
 	if failures == 0 {
 		return 0
 	}
@@ -1243,12 +1754,16 @@ func (m *PeerManager) retryDelay(failures uint32, persistent bool) time.Duration
 		return retryNever
 	}
 	maxDelay := m.options.MaxRetryTime
-	if persistent && m.options.MaxRetryTimePersistent > 0 {
+	if peer.Persistent && m.options.MaxRetryTimePersistent > 0 {
 		maxDelay = m.options.MaxRetryTimePersistent
 	}
+	privatePeering := m.options.isPrivatePeering(peer.ID)
+	if privatePeering && m.options.MaxRetryTimePrivatePeering > 0 {
+		maxDelay = m.options.MaxRetryTimePrivatePeering
+	}
 
 	delay := m.options.MinRetryTime * time.Duration(failures)
-	if m.options.RetryTimeJitter > 0 {
+	if m.options.RetryTimeJitter > 0 && !privatePeering {
 		delay += time.Duration(m.rand.Int63n(int64(m.options.RetryTimeJitter)))
 	}
 
@@ -1256,6 +1771,12 @@ func (m *PeerManager) retryDelay(failures uint32, persistent bool) time.Duration
 		delay = maxDelay
 	}
 
+	// The jitter of private peering peers is added after capping the delay,
+	// so that peers reconnecting to the same sentry keep being spread out.
+	if m.options.RetryTimeJitter > 0 && privatePeering {
+		delay += time.Duration(m.rand.Int63n(int64(m.options.RetryTimeJitter)))
+	}
+
 	return delay
 }
 
@@ -1267,10 +1788,11 @@ func (m *PeerManager) retryDelay(failures uint32, persistent bool) time.Duration
 // from disk on initialization, and any changes are written back to disk
 // (without fsync, since we can afford to lose recent writes).
 type peerStore struct {
-	db     dbm.DB
-	peers  map[types.NodeID]*peerInfo
-	index  map[NodeAddress]types.NodeID
-	ranked []*peerInfo // cache for Ranked(), nil invalidates cache
+	db        dbm.DB
+	peers     map[types.NodeID]*peerInfo
+	index     map[NodeAddress]types.NodeID
+	rotations map[types.NodeID]types.NodeID // old → new node IDs of rotated node keys
+	ranked    []*peerInfo                   // cache for Ranked(), nil invalidates cache
 }
 
 // newPeerStore creates a new peer store, loading all persisted peers from the
@@ -1283,9 +1805,43 @@ func newPeerStore(db dbm.DB) (*peerStore, error) {
 	if err := store.loadPeers(); err != nil {
 		return nil, err
 	}
+	if err := store.loadRotations(); err != nil {
+		return nil, err
+	}
 	return store, nil
 }
 
+// loadRotations loads the node key rotations from the database into memory.
+func (s *peerStore) loadRotations() error {
+	rotations := map[types.NodeID]types.NodeID{}
+
+	start, end := keyNodeKeyRotationRange()
+	iter, err := s.db.Iterator(start, end)
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var (
+			prefix int64
+			oldID  string
+		)
+		if _, err := orderedcode.Parse(string(iter.Key()), &prefix, &oldID); err != nil {
+			return fmt.Errorf("invalid node key rotation key: %w", err)
+		}
+		newID := types.NodeID(iter.Value())
+		if err := newID.Validate(); err != nil {
+			return fmt.Errorf("invalid node key rotation of %v: %w", oldID, err)
+		}
+		rotations[types.NodeID(oldID)] = newID
+	}
+	if iter.Error() != nil {
+		return iter.Error()
+	}
+	s.rotations = rotations
+	return nil
+}
+
 // loadPeers loads all peers from the database into memory.
 func (s *peerStore) loadPeers() error {
 	peers := map[types.NodeID]*peerInfo{}
@@ -1338,6 +1894,77 @@ func (s *peerStore) Resolve(addr NodeAddress) (types.NodeID, bool) {
 	return id, ok
 }
 
+// ResolveRotation returns the current node ID of a peer, following the node
+// key rotations from the given node ID, or the node ID itself if it was never
+// rotated.
+func (s *peerStore) ResolveRotation(id types.NodeID) types.NodeID {
+	// the rotations can't form a cycle, as each one is to a new key, but are
+	// bounded regardless
+	for i := 0; i < len(s.rotations); i++ {
+		newID, ok := s.rotations[id]
+		if !ok {
+			break
+		}
+		id = newID
+	}
+	return id
+}
+
+// Rotate records the node key rotation of a peer from oldID to newID, and
+// merges the peer data of oldID into the one of newID: the scores and uptimes
+// are added up, and the addresses are moved to newID. It returns whether
+// anything changed, and an error if oldID was already rotated to another ID.
+func (s *peerStore) Rotate(oldID, newID types.NodeID) (bool, error) {
+	old, hasOld := s.peers[oldID]
+	if rotatedID, ok := s.rotations[oldID]; ok && rotatedID != newID {
+		return false, fmt.Errorf("node key of %v already rotated to %v", oldID, rotatedID)
+	}
+	if s.rotations[oldID] == newID && !hasOld {
+		return false, nil
+	}
+
+	if err := s.db.Set(keyNodeKeyRotation(oldID), []byte(newID)); err != nil {
+		return false, err
+	}
+	s.rotations[oldID] = newID
+	if !hasOld {
+		return true, nil
+	}
+
+	peer, ok := s.Get(newID)
+	if !ok {
+		peer = peerInfo{ID: newID, AddressInfo: map[NodeAddress]*peerAddressInfo{}}
+	}
+	peer.MutableScore += old.MutableScore
+	peer.Uptime += old.Uptime
+	if old.LastConnected.After(peer.LastConnected) {
+		peer.LastConnected = old.LastConnected
+	}
+	if old.LastDisconnected.After(peer.LastDisconnected) {
+		peer.LastDisconnected = old.LastDisconnected
+	}
+	if peer.Latency == 0 {
+		peer.Latency = old.Latency
+	}
+	for address, addressInfo := range old.AddressInfo {
+		address.NodeID = newID
+		if _, ok := peer.AddressInfo[address]; ok {
+			continue
+		}
+		addressInfoCopy := addressInfo.Copy()
+		addressInfoCopy.Address = address
+		peer.AddressInfo[address] = &addressInfoCopy
+	}
+
+	if err := s.Delete(oldID); err != nil {
+		return false, err
+	}
+	if err := s.Set(peer); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Set stores peer data. The input data will be copied, and can safely be reused
 // by the caller.
 func (s *peerStore) Set(peer peerInfo) error {
@@ -1440,23 +2067,27 @@ type peerInfo struct {
 	AddressInfo      map[NodeAddress]*peerAddressInfo
 	LastConnected    time.Time
 	LastDisconnected time.Time
+	MutableScore     int64         // updated by router
+	Uptime           time.Duration // total time connected
+	Latency          time.Duration // moving average of the dial latency
+	Inactive         bool
 
 	// These fields are ephemeral, i.e. not persisted to the database.
 	Persistent bool
 	Height     int64
 	FixedScore PeerScore // mainly for tests
-
-	MutableScore int64 // updated by router
-	Inactive     bool
 }
 
 // peerInfoFromProto converts a Protobuf PeerInfo message to a peerInfo,
 // erroring if the data is invalid.
 func peerInfoFromProto(msg *p2pproto.PeerInfo) (*peerInfo, error) {
 	p := &peerInfo{
-		ID:          types.NodeID(msg.ID),
-		AddressInfo: map[NodeAddress]*peerAddressInfo{},
-		Inactive:    msg.Inactive,
+		ID:           types.NodeID(msg.ID),
+		AddressInfo:  map[NodeAddress]*peerAddressInfo{},
+		MutableScore: msg.MutableScore,
+		Uptime:       msg.Uptime,
+		Latency:      msg.Latency,
+		Inactive:     msg.Inactive,
 	}
 	if msg.LastConnected != nil {
 		p.LastConnected = *msg.LastConnected
@@ -1481,6 +2112,9 @@ func (p *peerInfo) ToProto() *p2pproto.PeerInfo {
 		ID:            string(p.ID),
 		Inactive:      p.Inactive,
 		LastConnected: &p.LastConnected,
+		MutableScore:  p.MutableScore,
+		Uptime:        p.Uptime,
+		Latency:       p.Latency,
 	}
 	for _, addressInfo := range p.AddressInfo {
 		msg.AddressInfo = append(msg.AddressInfo, addressInfo.ToProto())
@@ -1547,6 +2181,10 @@ func (p *peerInfo) LastDialed() (time.Time, bool) {
 
 // Score calculates a score for the peer. Higher-scored peers will be
 // preferred over lower scores.
+//
+// The score starts from the misbehavior score reported by the router, gains a
+// point per hour the peer has been connected and loses a point per 100ms of
+// dial latency, each up to a cap, and loses a point per dial failure.
 func (p *peerInfo) Score() PeerScore {
 	if p.FixedScore > 0 {
 		return p.FixedScore
@@ -1556,9 +2194,11 @@ func (p *peerInfo) Score() PeerScore {
 	}
 
 	score := p.MutableScore
+	score += minInt64(int64(p.Uptime/peerScoreUptimeUnit), peerScoreUptimeCap)
 	if score > int64(MaxPeerScoreNotPersistent) {
 		score = int64(MaxPeerScoreNotPersistent)
 	}
+	score -= minInt64(int64(p.Latency/peerScoreLatencyUnit), peerScoreLatencyCap)
 
 	for _, addr := range p.AddressInfo {
 		// DialFailures is reset when dials succeed, so this
@@ -1573,6 +2213,13 @@ func (p *peerInfo) Score() PeerScore {
 	return PeerScore(score)
 }
 
+func minInt64(a, b int64) int64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 // Validate validates the peer info.
 func (p *peerInfo) Validate() error {
 	if p.ID == "" {
@@ -1638,7 +2285,8 @@ func (a *peerAddressInfo) Validate() error {
 
 // Database key prefixes.
 const (
-	prefixPeerInfo int64 = 1
+	prefixPeerInfo        int64 = 1
+	prefixNodeKeyRotation int64 = 2
 )
 
 // keyPeerInfo generates a peerInfo database key.
@@ -1662,3 +2310,27 @@ func keyPeerInfoRange() ([]byte, []byte) {
 	}
 	return start, end
 }
+
+// keyNodeKeyRotation generates a node key rotation database key, for the old
+// node ID.
+func keyNodeKeyRotation(oldID types.NodeID) []byte {
+	key, err := orderedcode.Append(nil, prefixNodeKeyRotation, string(oldID))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// keyNodeKeyRotationRange generates start/end keys for the entire node key
+// rotation key range.
+func keyNodeKeyRotationRange() ([]byte, []byte) {
+	start, err := orderedcode.Append(nil, prefixNodeKeyRotation, "")
+	if err != nil {
+		panic(err)
+	}
+	end, err := orderedcode.Append(nil, prefixNodeKeyRotation, orderedcode.Infinity)
+	if err != nil {
+		panic(err)
+	}
+	return start, end
+}
@@ -98,6 +98,91 @@ func TestPeerScoring(t *testing.T) {
 	})
 }
 
+func TestPeerScoreUptimeLatency(t *testing.T) {
+	for _, test := range []struct {
+		Name  string
+		Peer  peerInfo
+		Score PeerScore
+	}{
+		{"Zero", peerInfo{}, 0},
+		{"Uptime", peerInfo{MutableScore: 1, Uptime: 3*time.Hour + time.Minute}, 4},
+		{"UptimeCap", peerInfo{Uptime: 1000 * time.Hour}, peerScoreUptimeCap},
+		{"Latency", peerInfo{MutableScore: 1, Latency: 350 * time.Millisecond}, -2},
+		{"LatencyCap", peerInfo{Latency: time.Minute}, -peerScoreLatencyCap},
+		{"Misbehavior", peerInfo{MutableScore: -5, Uptime: 2 * time.Hour}, -3},
+		{"MaxScore", peerInfo{MutableScore: int64(MaxPeerScoreNotPersistent), Uptime: 5 * time.Hour}, MaxPeerScoreNotPersistent},
+		{"Persistent", peerInfo{Persistent: true, Latency: time.Minute}, PeerScorePersistent},
+	} {
+		t.Run(test.Name, func(t *testing.T) {
+			require.Equal(t, test.Score, test.Peer.Score())
+		})
+	}
+}
+
+func TestPeerScoringPersistence(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	selfID := types.NodeIDFromPubKey(ed25519.GenPrivKey().PubKey())
+	db := dbm.NewMemDB()
+	peerManager, err := NewPeerManager(selfID, db, PeerManagerOptions{})
+	require.NoError(t, err)
+
+	a := NodeAddress{NodeID: types.NodeID(strings.Repeat("a1", 20)), Protocol: "memory"}
+	b := NodeAddress{NodeID: types.NodeID(strings.Repeat("b1", 20)), Protocol: "memory"}
+	for _, addr := range []NodeAddress{a, b} {
+		added, err := peerManager.Add(addr)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	// a misbehaves, while b is dialed and stays connected for a while.
+	peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: a.NodeID, Status: PeerStatusBad})
+	peerManager.processPeerEvent(ctx, PeerUpdate{NodeID: a.NodeID, Status: PeerStatusBad})
+	require.Equal(t, b, peerManager.TryDialNext())
+	require.NoError(t, peerManager.Dialed(b))
+	peerManager.mtx.Lock()
+	peer := peerManager.store.peers[b.NodeID]
+	peer.LastConnected = peer.LastConnected.Add(-2 * time.Hour)
+	peerManager.mtx.Unlock()
+	peerManager.Disconnected(ctx, b.NodeID)
+
+	scores := map[types.NodeID]PeerScore{a.NodeID: -2, b.NodeID: 2}
+	require.Equal(t, scores, peerManager.Scores())
+
+	// The scores are retained across restarts, and the best peer is dialed
+	// first.
+	peerManager, err = NewPeerManager(selfID, db, PeerManagerOptions{})
+	require.NoError(t, err)
+	require.Equal(t, scores, peerManager.Scores())
+	require.Equal(t, b, peerManager.TryDialNext())
+
+	book := peerManager.AddressBook()
+	require.Len(t, book, 2)
+	require.Equal(t, b.NodeID, book[0].ID)
+	require.Equal(t, []AddressBookAddress{{Address: b, LastDialSuccess: book[0].Addresses[0].LastDialSuccess}},
+		book[0].Addresses)
+	require.False(t, book[0].Addresses[0].LastDialSuccess.IsZero())
+	require.GreaterOrEqual(t, book[0].Uptime, 2*time.Hour)
+	require.Equal(t, a.NodeID, book[1].ID)
+	require.EqualValues(t, -2, book[1].MisbehaviorScore)
+	require.EqualValues(t, -2, book[1].Score)
+
+	// The private peers are not in the address book.
+	peerManager, err = NewPeerManager(selfID, db, PeerManagerOptions{
+		PrivatePeers: map[types.NodeID]struct{}{a.NodeID: {}},
+	})
+	require.NoError(t, err)
+	book = peerManager.AddressBook()
+	require.Len(t, book, 1)
+	require.Equal(t, b.NodeID, book[0].ID)
+}
+
+func TestAverageLatency(t *testing.T) {
+	require.Equal(t, 100*time.Millisecond, averageLatency(0, 100*time.Millisecond))
+	require.Equal(t, 125*time.Millisecond, averageLatency(100*time.Millisecond, 200*time.Millisecond))
+}
+
 func makeMockPeerStore(t *testing.T, peers ...peerInfo) *peerStore {
 	t.Helper()
 	s, err := newPeerStore(dbm.NewMemDB())
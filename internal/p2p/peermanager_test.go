@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"strings"
 	"testing"
 	"time"
@@ -269,6 +270,97 @@ func TestPeerManager_Add(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestPeerManager_SetPersistentPeers(t *testing.T) {
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers: []types.NodeID{a.NodeID},
+		MaxConnected:    1,
+	})
+	require.NoError(t, err)
+	added, err := peerManager.Add(a)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.Equal(t, p2p.PeerScorePersistent, peerManager.Scores()[a.NodeID])
+
+	// b replaces a as the persistent peer, and is added to the peer store.
+	require.NoError(t, peerManager.SetPersistentPeers([]p2p.NodeAddress{b}))
+	require.ElementsMatch(t, []types.NodeID{a.NodeID, b.NodeID}, peerManager.Peers())
+	scores := peerManager.Scores()
+	require.Equal(t, p2p.PeerScorePersistent, scores[b.NodeID])
+	require.Less(t, scores[a.NodeID], p2p.PeerScorePersistent)
+
+	// Invalid persistent peers are rejected, keeping the current ones.
+	require.Error(t, peerManager.SetPersistentPeers([]p2p.NodeAddress{a, b}))
+	require.Error(t, peerManager.SetPersistentPeers([]p2p.NodeAddress{{Protocol: "memory", NodeID: selfID}}))
+	require.Equal(t, p2p.PeerScorePersistent, peerManager.Scores()[b.NodeID])
+}
+
+func TestPeerManager_NodeKeyRotated(t *testing.T) {
+	oldKey, newKey := types.GenNodeKey(), types.GenNodeKey()
+	rotation, err := types.NewNodeKeyRotation(oldKey, newKey, "test", time.Now())
+	require.NoError(t, err)
+	oldAddress := p2p.NodeAddress{Protocol: "memory", NodeID: oldKey.ID}
+	newAddress := p2p.NodeAddress{Protocol: "memory", NodeID: newKey.ID}
+
+	db := dbm.NewMemDB()
+	options := p2p.PeerManagerOptions{PersistentPeers: []types.NodeID{oldKey.ID}}
+	peerManager, err := p2p.NewPeerManager(selfID, db, options)
+	require.NoError(t, err)
+	added, err := peerManager.Add(oldAddress)
+	require.NoError(t, err)
+	require.True(t, added)
+
+	// the record of the old node ID moves to the new one, which is persistent
+	require.NoError(t, peerManager.NodeKeyRotated(rotation))
+	require.Equal(t, []types.NodeID{newKey.ID}, peerManager.Peers())
+	require.Equal(t, []p2p.NodeAddress{newAddress}, peerManager.Addresses(newKey.ID))
+	require.Equal(t, p2p.PeerScorePersistent, peerManager.Scores()[newKey.ID])
+	require.NoError(t, peerManager.NodeKeyRotated(rotation))
+
+	// the addresses of the old node ID are added with the new one
+	added, err = peerManager.Add(oldAddress)
+	require.NoError(t, err)
+	require.False(t, added)
+	require.Equal(t, []types.NodeID{newKey.ID}, peerManager.Peers())
+
+	// the rotation is persisted
+	peerManager, err = p2p.NewPeerManager(selfID, db, options)
+	require.NoError(t, err)
+	require.Equal(t, []types.NodeID{newKey.ID}, peerManager.Peers())
+	require.Equal(t, p2p.PeerScorePersistent, peerManager.Scores()[newKey.ID])
+
+	// a recorded rotation can't be replaced by another one of the old node key
+	otherKey := types.GenNodeKey()
+	otherRotation, err := types.NewNodeKeyRotation(oldKey, otherKey, "test", time.Now())
+	require.NoError(t, err)
+	require.Error(t, peerManager.NodeKeyRotated(otherRotation))
+	require.Equal(t, []types.NodeID{newKey.ID}, peerManager.Peers())
+
+	// the rotations of unknown peers are ignored
+	unknownKey := types.GenNodeKey()
+	unknownRotation, err := types.NewNodeKeyRotation(unknownKey, otherKey, "test", time.Now())
+	require.NoError(t, err)
+	require.NoError(t, peerManager.NodeKeyRotated(unknownRotation))
+	added, err = peerManager.Add(p2p.NodeAddress{Protocol: "memory", NodeID: unknownKey.ID})
+	require.NoError(t, err)
+	require.True(t, added)
+	require.ElementsMatch(t, []types.NodeID{newKey.ID, unknownKey.ID}, peerManager.Peers())
+
+	// the rotations of self are rejected, and the addresses of the old node ID
+	// of self are not added once recorded
+	selfRotation, err := types.NewNodeKeyRotation(types.GenNodeKey(), types.NodeKey{ID: selfID, PrivKey: selfKey}, "test", time.Now())
+	require.NoError(t, err)
+	require.Error(t, peerManager.NodeKeyRotated(selfRotation))
+	db = dbm.NewMemDB()
+	require.NoError(t, p2p.RecordNodeKeyRotation(db, selfRotation))
+	peerManager, err = p2p.NewPeerManager(selfID, db, p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	_, err = peerManager.Add(p2p.NodeAddress{Protocol: "memory", NodeID: selfRotation.OldID()})
+	require.Error(t, err)
+}
+
 func TestPeerManager_DialNext(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -1830,15 +1922,120 @@ func TestPeerManager_Advertise_Self(t *testing.T) {
 	dID := types.NodeID(strings.Repeat("d", 40))
 
 	self := p2p.NodeAddress{Protocol: "tcp", NodeID: selfID, Hostname: "2001:db8::1", Port: 26657}
+	selfV4 := p2p.NodeAddress{Protocol: "tcp", NodeID: selfID, Hostname: "192.0.2.1", Port: 26657}
 
-	// Create a peer manager with SelfAddress defined.
+	// Create a peer manager with SelfAddresses defined.
 	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
-		SelfAddress: self,
+		SelfAddresses: []p2p.NodeAddress{self, selfV4, {Protocol: "tcp", NodeID: selfID}},
 	})
 	require.NoError(t, err)
 
-	// peer manager should always advertise its SelfAddress.
+	// peer manager should always advertise its SelfAddresses, except the
+	// ones without hostname and port.
 	require.ElementsMatch(t, []p2p.NodeAddress{
-		self,
+		self, selfV4,
 	}, peerManager.Advertise(dID, 100))
 }
+
+func TestPeerManager_ObservedIP(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+
+	public := net.ParseIP("203.0.113.7")
+	other := net.ParseIP("198.51.100.1")
+
+	// Disconnected peers are ignored.
+	peerManager.ObserveSelfIP(a.NodeID, public)
+	require.Nil(t, peerManager.ObservedIP())
+
+	for _, addr := range []p2p.NodeAddress{a, b, c} {
+		require.NoError(t, peerManager.Accepted(addr.NodeID))
+	}
+
+	// A single peer isn't trusted.
+	peerManager.ObserveSelfIP(a.NodeID, public)
+	require.Nil(t, peerManager.ObservedIP())
+
+	// The IP most peers agree on is returned.
+	peerManager.ObserveSelfIP(b.NodeID, other)
+	peerManager.ObserveSelfIP(c.NodeID, public)
+	require.Equal(t, public.String(), peerManager.ObservedIP().String())
+
+	// It is forgotten when peers disconnect.
+	peerManager.Disconnected(ctx, c.NodeID)
+	require.Nil(t, peerManager.ObservedIP())
+
+	// Private IPs are never returned.
+	peerManager, err = p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	for _, addr := range []p2p.NodeAddress{a, b} {
+		require.NoError(t, peerManager.Accepted(addr.NodeID))
+		peerManager.ObserveSelfIP(addr.NodeID, net.ParseIP("10.0.0.1"))
+	}
+	require.Nil(t, peerManager.ObservedIP())
+}
+
+func TestPeerManager_PrivatePeering(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("a", 40))}
+	b := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("b", 40))}
+	c := p2p.NodeAddress{Protocol: "memory", NodeID: types.NodeID(strings.Repeat("c", 40))}
+
+	// Private peering peers must be persistent peers.
+	_, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PrivatePeeringPeers: []types.NodeID{a.NodeID},
+	})
+	require.Error(t, err)
+
+	peerManager, err := p2p.NewPeerManager(selfID, dbm.NewMemDB(), p2p.PeerManagerOptions{
+		PersistentPeers:            []types.NodeID{a.NodeID},
+		PrivatePeeringPeers:        []types.NodeID{a.NodeID},
+		MaxConnected:               1,
+		MinRetryTime:               time.Millisecond,
+		MaxRetryTime:               time.Hour,
+		MaxRetryTimePrivatePeering: 5 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	for _, addr := range []p2p.NodeAddress{a, b, c} {
+		added, err := peerManager.Add(addr)
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+
+	// The private peering peer doesn't use up the only connection slot.
+	require.NoError(t, peerManager.Accepted(b.NodeID))
+	require.Equal(t, a, peerManager.TryDialNext())
+	require.Zero(t, peerManager.TryDialNext())
+
+	// Its dials are retried after a short backoff, however many fail.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, peerManager.DialFailed(ctx, a))
+	}
+	require.Eventually(t, func() bool {
+		return peerManager.TryDialNext() == a
+	}, time.Second, time.Millisecond)
+	require.NoError(t, peerManager.Dialed(a))
+
+	// It is never evicted, inactivated nor gossiped.
+	evict, err := peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Zero(t, evict)
+	require.NoError(t, peerManager.Inactivate(a.NodeID))
+	for _, addr := range peerManager.Advertise(c.NodeID, 100) {
+		require.NotEqual(t, a.NodeID, addr.NodeID)
+	}
+
+	// Incoming connections from it are accepted too while all slots are full.
+	peerManager.Disconnected(ctx, a.NodeID)
+	require.NoError(t, peerManager.Accepted(a.NodeID))
+	require.Error(t, peerManager.Accepted(c.NodeID))
+}
@@ -0,0 +1,23 @@
+package pex
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "pex"
+)
+
+//go:generate go run ../../../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of nodes in the crawled network topology.
+	TopologyNodes metrics.Gauge
+	// Number of peer links in the crawled network topology.
+	TopologyEdges metrics.Gauge
+	// Number of nodes in the crawled network topology running a version.
+	TopologyVersionNodes metrics.Gauge `metrics_labels:"version"`
+}
@@ -2,7 +2,9 @@ package pex
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -100,6 +102,33 @@ type Reactor struct {
 
 	// the total number of unique peers added
 	totalPeers int
+
+	// topology records the crawled network topology, if not nil.
+	topology *Topology
+
+	// crawl makes the reactor crawl the network, as seed nodes do.
+	crawl bool
+}
+
+// errCrawled is the reason a crawling reactor disconnects from a peer once it
+// received its addresses.
+var errCrawled = errors.New("peer crawled")
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithTopology records the network topology crawled by the reactor: the peers
+// each peer advertises, and the software versions of the peers.
+func WithTopology(topology *Topology) ReactorOption {
+	return func(r *Reactor) { r.topology = topology }
+}
+
+// WithCrawling makes the reactor actively crawl the network, as seed nodes
+// do: it requests addresses from its peers at the fastest allowed pace, and
+// disconnects from each peer once it sent its addresses, to make room for
+// connections to the other nodes of the network.
+func WithCrawling() ReactorOption {
+	return func(r *Reactor) { r.crawl = true }
 }
 
 // NewReactor returns a reference to a new reactor.
@@ -108,6 +137,7 @@ func NewReactor(
 	peerManager *p2p.PeerManager,
 	channelCreator p2p.ChannelCreator,
 	peerEvents p2p.PeerEventSubscriber,
+	options ...ReactorOption,
 ) *Reactor {
 	r := &Reactor{
 		logger:               logger,
@@ -118,6 +148,9 @@ func NewReactor(
 		requestsSent:         make(map[types.NodeID]struct{}),
 		lastReceivedRequests: make(map[types.NodeID]time.Time),
 	}
+	for _, opt := range options {
+		opt(r)
+	}
 
 	r.BaseService = *service.NewBaseService(logger, "PEX", r)
 	return r
@@ -243,9 +276,13 @@ func (r *Reactor) handlePexMessage(ctx context.Context, envelope *p2p.Envelope,
 				URL: addr.String(),
 			}
 		}
+		resp := &protop2p.PexResponse{Addresses: pexAddresses}
+		if ip := r.peerManager.RemoteIP(envelope.From); ip != nil {
+			resp.ObservedIP = ip.String()
+		}
 		return 0, pexCh.Send(ctx, p2p.Envelope{
 			To:      envelope.From,
-			Message: &protop2p.PexResponse{Addresses: pexAddresses},
+			Message: resp,
 		})
 
 	case *protop2p.PexResponse:
@@ -260,12 +297,18 @@ func (r *Reactor) handlePexMessage(ctx context.Context, envelope *p2p.Envelope,
 				len(msg.Addresses), maxAddresses)
 		}
 
+		if ip := net.ParseIP(msg.ObservedIP); ip != nil {
+			r.peerManager.ObserveSelfIP(envelope.From, ip)
+		}
+
 		var numAdded int
+		peers := make([]types.NodeID, 0, len(msg.Addresses))
 		for _, pexAddress := range msg.Addresses {
 			peerAddress, err := p2p.ParseNodeAddress(pexAddress.URL)
 			if err != nil {
 				continue
 			}
+			peers = append(peers, peerAddress.NodeID)
 			added, err := r.peerManager.Add(peerAddress)
 			if err != nil {
 				logger.Error("failed to add PEX address", "address", peerAddress, "err", err)
@@ -277,6 +320,16 @@ func (r *Reactor) handlePexMessage(ctx context.Context, envelope *p2p.Envelope,
 			}
 		}
 
+		if r.topology != nil {
+			r.topology.recordPeers(envelope.From, peers, time.Now())
+		}
+		if r.crawl {
+			r.mtx.Lock()
+			delete(r.availablePeers, envelope.From)
+			r.mtx.Unlock()
+			r.peerManager.Errored(envelope.From, errCrawled)
+		}
+
 		return r.calculateNextRequestTime(numAdded), nil
 
 	default:
@@ -295,6 +348,9 @@ func (r *Reactor) processPeerUpdate(peerUpdate p2p.PeerUpdate) {
 	switch peerUpdate.Status {
 	case p2p.PeerStatusUp:
 		r.availablePeers[peerUpdate.NodeID] = struct{}{}
+		if r.topology != nil {
+			r.topology.recordVersion(peerUpdate.NodeID, r.peerManager.PeerVersion(peerUpdate.NodeID), time.Now())
+		}
 	case p2p.PeerStatusDown:
 		delete(r.availablePeers, peerUpdate.NodeID)
 		delete(r.requestsSent, peerUpdate.NodeID)
@@ -355,6 +411,14 @@ func (r *Reactor) calculateNextRequestTime(added int) time.Duration {
 
 	r.totalPeers += added
 
+	// When crawling, poll as often as allowed while there are peers to query.
+	if r.crawl {
+		if len(r.availablePeers) == 0 {
+			return noAvailablePeersWaitPeriod
+		}
+		return minReceiveRequestInterval
+	}
+
 	// If the peer store is nearly full, wait the maximum interval.
 	if ratio := r.peerManager.PeerRatio(); ratio >= 0.95 {
 		r.logger.Debug("Peer manager is nearly full",
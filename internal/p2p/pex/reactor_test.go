@@ -100,6 +100,48 @@ func TestReactorSendsRequestsTooOften(t *testing.T) {
 	require.Equal(t, badNode, peerErr.NodeID)
 }
 
+func TestReactorCrawling(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	topology := pex.NewTopology(pex.NopMetrics())
+	r := setupSingle(ctx, t, pex.WithTopology(topology), pex.WithCrawling())
+
+	peer := p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: newNodeID(t, "b")}
+	added, err := r.manager.Add(peer)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.NoError(t, r.manager.Accepted(peer.NodeID))
+	r.peerCh <- p2p.PeerUpdate{NodeID: peer.NodeID, Status: p2p.PeerStatusUp}
+
+	// the crawling reactor requests the addresses of the peer...
+	req := <-r.pexOutCh
+	require.Equal(t, peer.NodeID, req.To)
+	require.IsType(t, &p2pproto.PexRequest{}, req.Message)
+
+	c, d := newNodeID(t, "c"), newNodeID(t, "d")
+	r.pexInCh <- p2p.Envelope{
+		From: peer.NodeID,
+		Message: &p2pproto.PexResponse{Addresses: []p2pproto.PexAddress{
+			{URL: p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: d}.String()},
+			{URL: p2p.NodeAddress{Protocol: p2p.MemoryProtocol, NodeID: c}.String()},
+		}},
+	}
+
+	// ...records them in the topology and disconnects from it.
+	require.Eventually(t, func() bool {
+		nodes := topology.Nodes()
+		return len(nodes) == 1 && len(nodes[0].Peers) == 2
+	}, shortWait, 10*time.Millisecond)
+	nodes := topology.Nodes()
+	require.Equal(t, peer.NodeID, nodes[0].ID)
+	require.Equal(t, []types.NodeID{c, d}, nodes[0].Peers)
+
+	evict, err := r.manager.EvictNext(ctx)
+	require.NoError(t, err)
+	require.Equal(t, peer.NodeID, evict)
+}
+
 func TestReactorSendsResponseWithoutRequest(t *testing.T) {
 	t.Skip("This test needs updated https://github.com/tendermint/tendermint/issue/7634")
 	ctx, cancel := context.WithCancel(context.Background())
@@ -280,7 +322,7 @@ type singleTestReactor struct {
 	manager  *p2p.PeerManager
 }
 
-func setupSingle(ctx context.Context, t *testing.T) *singleTestReactor {
+func setupSingle(ctx context.Context, t *testing.T, options ...pex.ReactorOption) *singleTestReactor {
 	t.Helper()
 	nodeID := newNodeID(t, "a")
 	chBuf := 2
@@ -306,7 +348,7 @@ func setupSingle(ctx context.Context, t *testing.T) *singleTestReactor {
 		return pexCh, nil
 	}
 
-	reactor := pex.NewReactor(log.NewNopLogger(), peerManager, chCreator, func(_ context.Context) *p2p.PeerUpdates { return peerUpdates })
+	reactor := pex.NewReactor(log.NewNopLogger(), peerManager, chCreator, func(_ context.Context) *p2p.PeerUpdates { return peerUpdates }, options...)
 
 	require.NoError(t, reactor.Start(ctx))
 	t.Cleanup(reactor.Wait)
@@ -0,0 +1,177 @@
+package pex
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+const (
+	// the maximum number of nodes recorded in the topology
+	maxTopologyNodes = 10000
+
+	// how long a node is kept in the topology after it was last crawled
+	topologyNodeTTL = 24 * time.Hour
+)
+
+// TopologyNode is a node of the crawled network topology.
+type TopologyNode struct {
+	ID types.NodeID
+	// Version is the software version of the node, if it was connected to.
+	Version string
+	// LastSeen is when the node was last connected to or sent its peers.
+	LastSeen time.Time
+	// Peers are the peers the node last advertised.
+	Peers []types.NodeID
+}
+
+// Topology records the peer graph of the network as crawled by the PEX
+// reactor: the peers each node advertises, and the software version of the
+// nodes connected to. It is safe for concurrent use.
+type Topology struct {
+	metrics *Metrics
+
+	mtx      sync.Mutex
+	nodes    map[types.NodeID]*topologyNode
+	versions map[string]struct{} // versions reported to the metrics
+}
+
+type topologyNode struct {
+	version  string
+	lastSeen time.Time
+	peers    map[types.NodeID]struct{}
+}
+
+// NewTopology returns an empty topology, reporting its size to metrics.
+func NewTopology(metrics *Metrics) *Topology {
+	return &Topology{
+		metrics:  metrics,
+		nodes:    make(map[types.NodeID]*topologyNode),
+		versions: make(map[string]struct{}),
+	}
+}
+
+// recordVersion records the software version of a connected node.
+func (t *Topology) recordVersion(id types.NodeID, version string, now time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	node := t.node(id, now)
+	if version != "" {
+		node.version = version
+	}
+	t.prune(now)
+}
+
+// recordPeers records the peers advertised by a node, replacing the ones it
+// advertised before.
+func (t *Topology) recordPeers(id types.NodeID, peers []types.NodeID, now time.Time) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	node := t.node(id, now)
+	node.peers = make(map[types.NodeID]struct{}, len(peers))
+	for _, peer := range peers {
+		if peer != id {
+			node.peers[peer] = struct{}{}
+		}
+	}
+	t.prune(now)
+}
+
+// node returns the node with the ID, adding it if needed, and marks it as
+// seen. The caller must hold the mutex.
+func (t *Topology) node(id types.NodeID, now time.Time) *topologyNode {
+	node, ok := t.nodes[id]
+	if !ok {
+		node = &topologyNode{}
+		t.nodes[id] = node
+	}
+	node.lastSeen = now
+	return node
+}
+
+// prune removes the nodes not seen within topologyNodeTTL, and the least
+// recently seen nodes beyond maxTopologyNodes, and updates the metrics. The
+// caller must hold the mutex.
+func (t *Topology) prune(now time.Time) {
+	for id, node := range t.nodes {
+		if now.Sub(node.lastSeen) > topologyNodeTTL {
+			delete(t.nodes, id)
+		}
+	}
+	if len(t.nodes) > maxTopologyNodes {
+		ids := make([]types.NodeID, 0, len(t.nodes))
+		for id := range t.nodes {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			return t.nodes[ids[i]].lastSeen.Before(t.nodes[ids[j]].lastSeen)
+		})
+		for _, id := range ids[:len(ids)-maxTopologyNodes] {
+			delete(t.nodes, id)
+		}
+	}
+
+	edges := 0
+	versions := make(map[string]int)
+	for _, node := range t.nodes {
+		edges += len(node.peers)
+		if node.version != "" {
+			versions[node.version]++
+		}
+	}
+	t.metrics.TopologyNodes.Set(float64(len(t.nodes)))
+	t.metrics.TopologyEdges.Set(float64(edges))
+	for version := range t.versions {
+		if _, ok := versions[version]; !ok {
+			t.metrics.TopologyVersionNodes.With("version", version).Set(0)
+			delete(t.versions, version)
+		}
+	}
+	for version, count := range versions {
+		t.metrics.TopologyVersionNodes.With("version", version).Set(float64(count))
+		t.versions[version] = struct{}{}
+	}
+}
+
+// Nodes returns the nodes of the topology, ordered by ID, with their peers
+// ordered by ID.
+func (t *Topology) Nodes() []TopologyNode {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	nodes := make([]TopologyNode, 0, len(t.nodes))
+	for id, node := range t.nodes {
+		peers := make([]types.NodeID, 0, len(node.peers))
+		for peer := range node.peers {
+			peers = append(peers, peer)
+		}
+		sort.Slice(peers, func(i, j int) bool { return peers[i] < peers[j] })
+		nodes = append(nodes, TopologyNode{
+			ID:       id,
+			Version:  node.version,
+			LastSeen: node.lastSeen,
+			Peers:    peers,
+		})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes
+}
+
+// Versions returns the number of nodes of the topology running each known
+// software version.
+func (t *Topology) Versions() map[string]int {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	versions := make(map[string]int)
+	for _, node := range t.nodes {
+		if node.version != "" {
+			versions[node.version]++
+		}
+	}
+	return versions
+}
@@ -0,0 +1,40 @@
+package pex
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestTopology(t *testing.T) {
+	a := types.NodeID(strings.Repeat("a", 40))
+	b := types.NodeID(strings.Repeat("b", 40))
+	c := types.NodeID(strings.Repeat("c", 40))
+	now := time.Now()
+
+	topology := NewTopology(NopMetrics())
+	topology.recordVersion(a, "0.36.0", now)
+	topology.recordPeers(a, []types.NodeID{c, b, a}, now)
+	topology.recordVersion(b, "0.35.0", now)
+	topology.recordVersion(c, "0.36.0", now)
+
+	require.Equal(t, []TopologyNode{
+		{ID: a, Version: "0.36.0", LastSeen: now, Peers: []types.NodeID{b, c}},
+		{ID: b, Version: "0.35.0", LastSeen: now, Peers: []types.NodeID{}},
+		{ID: c, Version: "0.36.0", LastSeen: now, Peers: []types.NodeID{}},
+	}, topology.Nodes())
+	require.Equal(t, map[string]int{"0.36.0": 2, "0.35.0": 1}, topology.Versions())
+
+	// the peers advertised by a node replace the previous ones, and the nodes
+	// not seen for a while are removed
+	later := now.Add(topologyNodeTTL + time.Second)
+	topology.recordPeers(a, []types.NodeID{c}, later)
+	require.Equal(t, []TopologyNode{
+		{ID: a, Version: "0.36.0", LastSeen: later, Peers: []types.NodeID{c}},
+	}, topology.Nodes())
+	require.Equal(t, map[string]int{"0.36.0": 1}, topology.Versions())
+}
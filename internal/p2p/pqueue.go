@@ -148,6 +148,11 @@ func (s *pqScheduler) dequeue() <-chan Envelope  { return s.dequeueCh }
 func (s *pqScheduler) close()                    { s.closeFn() }
 func (s *pqScheduler) closed() <-chan struct{}   { return s.done }
 
+// len returns the number of envelopes buffered in the enqueue and dequeue
+// channels. The envelopes held in the priority queue by the process goroutine,
+// waiting for room in the dequeue channel, are not counted.
+func (s *pqScheduler) len() int { return len(s.enqueueCh) + len(s.dequeueCh) }
+
 // process starts a block process where we listen for Envelopes to enqueue. If
 // there is sufficient capacity, it will be enqueued into the priority queue,
 // otherwise, we attempt to dequeue enough elements from the priority queue to
@@ -26,6 +26,9 @@ type queue interface {
 
 	// closed returns a channel that's closed when the scheduler is closed.
 	closed() <-chan struct{}
+
+	// len returns the number of envelopes waiting in the queue.
+	len() int
 }
 
 // fifoQueue is a simple unbuffered lossless queue that passes messages through
@@ -51,3 +54,4 @@ func (q *fifoQueue) enqueue() chan<- Envelope { return q.queueCh }
 func (q *fifoQueue) dequeue() <-chan Envelope { return q.queueCh }
 func (q *fifoQueue) close()                   { q.closeFn() }
 func (q *fifoQueue) closed() <-chan struct{}  { return q.closeCh }
+func (q *fifoQueue) len() int                 { return len(q.queueCh) }
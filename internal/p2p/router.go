@@ -7,6 +7,7 @@ import (
 	"io"
 	"net"
 	"runtime"
+	"strconv"
 	"sync"
 	"time"
 
@@ -21,6 +22,10 @@ import (
 
 const queueBufferDefault = 32
 
+// channelQueueDepthInterval is the interval of the records of the depths of
+// the channel queues.
+const channelQueueDepthInterval = time.Second
+
 // RouterOptions specifies options for a Router.
 type RouterOptions struct {
 	// ResolveTimeout is the timeout for resolving NodeAddress URLs.
@@ -62,6 +67,11 @@ type RouterOptions struct {
 	// return an error to reject the peer.
 	FilterPeerByID func(context.Context, types.NodeID) error
 
+	// PeerFilter, if set, rejects the peers it blocks or doesn't allow, by
+	// node ID and IP, for both incoming and outgoing connections once the
+	// peer is known.
+	PeerFilter *PeerFilter
+
 	// NumConcrruentDials controls how many parallel go routines
 	// are used to dial peers. This defaults to the value of
 	// runtime.NumCPU.
@@ -151,7 +161,7 @@ type Router struct {
 	peerManager *PeerManager
 	chDescs     []*ChannelDescriptor
 	transport   Transport
-	endpoint    *Endpoint
+	endpoints   []*Endpoint
 	connTracker connectionTracker
 
 	peerMtx    sync.RWMutex
@@ -169,9 +179,8 @@ type Router struct {
 	channelMessages map[ChannelID]proto.Message
 }
 
-// NewRouter creates a new Router. The given Transports must already be
-// listening on appropriate interfaces, and will be closed by the Router when it
-// stops.
+// NewRouter creates a new Router. The Router makes the transport listen on
+// each of the endpoints when it starts, and closes it when it stops.
 func NewRouter(
 	logger log.Logger,
 	metrics *Metrics,
@@ -179,7 +188,7 @@ func NewRouter(
 	peerManager *PeerManager,
 	nodeInfoProducer func() *types.NodeInfo,
 	transport Transport,
-	endpoint *Endpoint,
+	endpoints []*Endpoint,
 	options RouterOptions,
 ) (*Router, error) {
 
@@ -199,7 +208,7 @@ func NewRouter(
 		),
 		chDescs:         make([]*ChannelDescriptor, 0),
 		transport:       transport,
-		endpoint:        endpoint,
+		endpoints:       endpoints,
 		peerManager:     peerManager,
 		options:         options,
 		channelQueues:   map[ChannelID]queue{},
@@ -273,8 +282,9 @@ func (r *Router) OpenChannel(ctx context.Context, chDesc *ChannelDescriptor) (Ch
 	r.channelQueues[id] = queue
 	r.channelMessages[id] = messageType
 
-	// add the channel to the nodeInfo if it's not already there.
-	r.nodeInfoProducer().AddChannel(uint16(chDesc.ID))
+	// add the channel to the nodeInfo if it's not already there, with the
+	// versions it supports.
+	r.nodeInfoProducer().SetChannelVersions(uint16(chDesc.ID), chDesc.Versions)
 
 	r.transport.AddChannelDescriptors([]*ChannelDescriptor{chDesc})
 
@@ -439,6 +449,24 @@ func (r *Router) filterPeersID(ctx context.Context, id types.NodeID) error {
 	return r.options.FilterPeerByID(ctx, id)
 }
 
+func (r *Router) filterPeer(id types.NodeID, ip net.IP) error {
+	if r.options.PeerFilter == nil {
+		return nil
+	}
+
+	return r.options.PeerFilter.Filter(id, ip)
+}
+
+// filterIP rejects the connections from the IPs of the peer filter before
+// their handshake, whatever the node ID of the peer.
+func (r *Router) filterIP(ip net.IP) error {
+	if r.options.PeerFilter == nil {
+		return nil
+	}
+
+	return r.options.PeerFilter.FilterIP(ip)
+}
+
 // acceptPeers accepts inbound connections from peers on the given transport,
 // and spawns goroutines that route messages to/from them.
 func (r *Router) acceptPeers(ctx context.Context, transport Transport) {
@@ -458,6 +486,15 @@ func (r *Router) acceptPeers(ctx context.Context, transport Transport) {
 		}
 
 		incomingIP := conn.RemoteEndpoint().IP
+		if err := r.filterIP(incomingIP); err != nil {
+			closeErr := conn.Close()
+			r.logger.Info("peer rejected by peer filter",
+				"ip", tmstrings.LazyStringer(incomingIP),
+				"err", err,
+				"close_err", closeErr,
+			)
+			continue
+		}
 		if err := r.connTracker.AddConn(incomingIP); err != nil {
 			closeErr := conn.Close()
 			r.logger.Debug("rate limiting incoming peer",
@@ -514,14 +551,20 @@ func (r *Router) openConnection(ctx context.Context, conn Connection) {
 		r.logger.Debug("peer filtered by node ID", "node", peerInfo.NodeID, "err", err)
 		return
 	}
+	if err := r.filterPeer(peerInfo.NodeID, incomingIP); err != nil {
+		r.logger.Info("peer rejected by peer filter", "node", peerInfo.NodeID, "err", err)
+		return
+	}
 
 	if err := r.runWithPeerMutex(func() error { return r.peerManager.Accepted(peerInfo.NodeID) }); err != nil {
 		r.logger.Error("failed to accept connection",
 			"op", "incoming/accepted", "peer", peerInfo.NodeID, "err", err)
 		return
 	}
+	r.peerManager.setRemoteIP(peerInfo.NodeID, incomingIP)
+	r.peerManager.setPeerVersion(peerInfo.NodeID, peerInfo.Version)
 
-	r.routePeer(ctx, peerInfo.NodeID, conn, toChannelIDs(peerInfo.Channels))
+	r.routePeer(ctx, peerInfo.NodeID, conn, r.negotiateChannels(peerInfo))
 }
 
 // dialPeers maintains outbound connections to peers by dialing them.
@@ -586,6 +629,17 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 		return
 	}
 
+	if r.options.PeerFilter != nil {
+		if err := r.filterPeer(address.NodeID, conn.RemoteEndpoint().IP); err != nil {
+			r.logger.Info("peer rejected by peer filter", "peer", address, "err", err)
+			if err = r.peerManager.DialFailed(ctx, address); err != nil {
+				r.logger.Error("failed to report dial failure", "peer", address, "err", err)
+			}
+			conn.Close()
+			return
+		}
+	}
+
 	peerInfo, err := r.handshakePeer(ctx, conn, address.NodeID)
 	switch {
 	case errors.Is(err, context.Canceled):
@@ -606,9 +660,11 @@ func (r *Router) connectPeer(ctx context.Context, address NodeAddress) {
 		conn.Close()
 		return
 	}
+	r.peerManager.setRemoteIP(peerInfo.NodeID, conn.RemoteEndpoint().IP)
+	r.peerManager.setPeerVersion(peerInfo.NodeID, peerInfo.Version)
 
 	// routePeer (also) calls connection close
-	go r.routePeer(ctx, address.NodeID, conn, toChannelIDs(peerInfo.Channels))
+	go r.routePeer(ctx, address.NodeID, conn, r.negotiateChannels(peerInfo))
 }
 
 func (r *Router) getOrMakeQueue(peerID types.NodeID, channels ChannelIDSet) queue {
@@ -691,7 +747,19 @@ func (r *Router) handshakePeer(
 		return peerInfo, fmt.Errorf("peer's public key did not match its node ID %q (expected %q)",
 			peerInfo.NodeID, types.NodeIDFromPubKey(peerKey))
 	}
+	// the rotation of the node key of the peer is verified by Validate, and
+	// moves the records of its old node ID, which we may have dialed, to the
+	// new one
+	if peerInfo.KeyRotation != nil && peerInfo.Network == nodeInfo.Network {
+		if err := r.peerManager.NodeKeyRotated(peerInfo.KeyRotation); err != nil {
+			return peerInfo, fmt.Errorf("failed to record the node key rotation of peer %q: %w", peerInfo.NodeID, err)
+		}
+	}
 	if expectID != "" && expectID != peerInfo.NodeID {
+		if peerInfo.KeyRotation != nil && peerInfo.KeyRotation.OldID() == expectID {
+			return peerInfo, fmt.Errorf("peer %q rotated its node key to %q, to be dialed with it",
+				expectID, peerInfo.NodeID)
+		}
 		return peerInfo, fmt.Errorf("expected to connect with peer %q, got %q",
 			expectID, peerInfo.NodeID)
 	}
@@ -900,6 +968,26 @@ func (r *Router) evictPeers(ctx context.Context) {
 	}
 }
 
+// recordChannelQueueDepths records the number of messages waiting in the
+// queue of each channel every channelQueueDepthInterval, until ctx is done.
+func (r *Router) recordChannelQueueDepths(ctx context.Context) {
+	ticker := time.NewTicker(channelQueueDepthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.channelMtx.RLock()
+			for chID, queue := range r.channelQueues {
+				r.metrics.RouterChannelQueueDepth.With("ch_id", strconv.Itoa(int(chID))).Set(float64(queue.len()))
+			}
+			r.channelMtx.RUnlock()
+		}
+	}
+}
+
 func (r *Router) setupQueueFactory(ctx context.Context) error {
 	qf, err := r.createQueueFactory(ctx)
 	if err != nil {
@@ -916,13 +1004,16 @@ func (r *Router) OnStart(ctx context.Context) error {
 		return err
 	}
 
-	if err := r.transport.Listen(r.endpoint); err != nil {
-		return err
+	for _, endpoint := range r.endpoints {
+		if err := r.transport.Listen(endpoint); err != nil {
+			return fmt.Errorf("failed to listen on %v: %w", endpoint, err)
+		}
 	}
 
 	go r.dialPeers(ctx)
 	go r.evictPeers(ctx)
 	go r.acceptPeers(ctx, r.transport)
+	go r.recordChannelQueueDepths(ctx)
 
 	return nil
 }
@@ -967,6 +1058,36 @@ func (cs ChannelIDSet) Contains(id ChannelID) bool {
 	return ok
 }
 
+// negotiateChannels negotiates the versions of the channels common to the
+// node and the connected peer, records them in the peer manager, and returns
+// the channels of the peer, without the common channels that have no version
+// supported by both.
+func (r *Router) negotiateChannels(peerInfo types.NodeInfo) ChannelIDSet {
+	nodeInfo := r.nodeInfoProducer()
+	negotiated := nodeInfo.NegotiateChannelVersions(peerInfo)
+
+	channels := toChannelIDs(peerInfo.Channels)
+	versions := make(map[ChannelID]uint32, len(negotiated))
+	for _, ch := range nodeInfo.Channels {
+		id := ChannelID(ch)
+		if !channels.Contains(id) {
+			continue
+		}
+		version, ok := negotiated[uint16(ch)]
+		if !ok {
+			r.logger.Info("no common version for channel with peer",
+				"peer", peerInfo.NodeID, "channel", id,
+				"versions", nodeInfo.SupportedChannelVersions(uint16(ch)),
+				"peer_versions", peerInfo.SupportedChannelVersions(uint16(ch)))
+			delete(channels, id)
+			continue
+		}
+		versions[id] = version
+	}
+	r.peerManager.setChannelVersions(peerInfo.NodeID, versions)
+	return channels
+}
+
 func toChannelIDs(bytes []byte) ChannelIDSet {
 	c := make(map[ChannelID]struct{}, len(bytes))
 	for _, b := range bytes {
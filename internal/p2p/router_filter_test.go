@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestConnectionFiltering(t *testing.T) {
@@ -32,3 +34,18 @@ func TestConnectionFiltering(t *testing.T) {
 	router.openConnection(ctx, &MemoryConnection{logger: logger, closeFn: func() {}})
 	require.Equal(t, 1, filterByIPCount)
 }
+
+func TestConnectionPeerFilter(t *testing.T) {
+	id := types.NodeID(strings.Repeat("a", 40))
+	filter, err := NewPeerFilter(nil, []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	router := &Router{options: RouterOptions{}}
+	require.NoError(t, router.filterPeer(id, net.ParseIP("10.0.0.1")))
+
+	router.options.PeerFilter = filter
+	require.Error(t, router.filterPeer(id, net.ParseIP("10.0.0.1")))
+	require.NoError(t, router.filterPeer(id, net.ParseIP("192.168.0.1")))
+	require.Error(t, router.filterIP(net.ParseIP("10.0.0.1")))
+	require.NoError(t, router.filterIP(net.ParseIP("192.168.0.1")))
+}
@@ -118,7 +118,7 @@ func TestRouter_Channel_Basic(t *testing.T) {
 		peerManager,
 		func() *types.NodeInfo { return &selfInfo },
 		testnet.RandomNode().Transport,
-		&p2p.Endpoint{},
+		[]*p2p.Endpoint{{}},
 		p2p.RouterOptions{},
 	)
 	require.NoError(t, err)
@@ -266,6 +266,44 @@ func TestRouter_Channel_Broadcast(t *testing.T) {
 	p2ptest.RequireEmpty(ctx, t, a, b, c, d)
 }
 
+func TestRouter_ChannelVersions(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	network := p2ptest.MakeNetwork(ctx, t, p2ptest.NetworkOptions{NumNodes: 2})
+	ids := network.NodeIDs()
+	aID, bID := ids[0], ids[1]
+	a, b := network.Nodes[aID], network.Nodes[bID]
+
+	// Channel 1 is negotiated to version 2, channel 2 has no common version,
+	// and channel 3 has the default version on both nodes.
+	makeChannel := func(node *p2ptest.Node, id p2p.ChannelID, versions ...uint32) {
+		desc := p2ptest.MakeChannelDesc(id)
+		desc.Versions = versions
+		node.MakeChannel(ctx, t, desc)
+	}
+	makeChannel(a, 1, 1, 2)
+	makeChannel(b, 1, 2, 3)
+	makeChannel(a, 2, 1)
+	makeChannel(b, 2, 2)
+	makeChannel(a, 3)
+	makeChannel(b, 3)
+
+	sub := a.PeerManager.Subscribe(ctx)
+	network.Start(ctx, t)
+
+	expected := map[p2p.ChannelID]uint32{1: 2, 3: 1}
+	require.Equal(t, expected, a.PeerManager.ChannelVersions(bID))
+	require.Equal(t, expected, b.PeerManager.ChannelVersions(aID))
+
+	peerUpdate := <-sub.Updates()
+	require.Equal(t, bID, peerUpdate.NodeID)
+	require.Equal(t, expected, peerUpdate.ChannelVersions)
+	require.Equal(t, p2p.ChannelIDSet{1: struct{}{}, 3: struct{}{}}, peerUpdate.Channels)
+}
+
 func TestRouter_Channel_Wrapper(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode")
@@ -429,7 +467,7 @@ func TestRouter_AcceptPeers(t *testing.T) {
 				peerManager,
 				func() *types.NodeInfo { return &selfInfo },
 				mockTransport,
-				nil,
+				[]*p2p.Endpoint{{}},
 				p2p.RouterOptions{},
 			)
 			require.NoError(t, err)
@@ -491,7 +529,7 @@ func TestRouter_AcceptPeers_Errors(t *testing.T) {
 				peerManager,
 				func() *types.NodeInfo { return &selfInfo },
 				mockTransport,
-				nil,
+				[]*p2p.Endpoint{{}},
 				p2p.RouterOptions{},
 			)
 			require.NoError(t, err)
@@ -544,7 +582,7 @@ func TestRouter_AcceptPeers_HeadOfLineBlocking(t *testing.T) {
 		peerManager,
 		func() *types.NodeInfo { return &selfInfo },
 		mockTransport,
-		nil,
+		[]*p2p.Endpoint{{}},
 		p2p.RouterOptions{},
 	)
 	require.NoError(t, err)
@@ -610,6 +648,7 @@ func TestRouter_DialPeers(t *testing.T) {
 				mockConnection.On("Handshake", mock.Anything, mock.Anything, selfInfo, selfKey).
 					Return(tc.peerInfo, tc.peerKey, nil)
 				mockConnection.On("Close").Run(func(_ mock.Arguments) { connCancel() }).Return(nil).Maybe()
+				mockConnection.On("RemoteEndpoint").Return(p2p.Endpoint{}).Maybe()
 			}
 			if tc.ok {
 				mockConnection.On("ReceiveMessage", mock.Anything).Return(chID, nil, io.EOF).Maybe()
@@ -647,7 +686,7 @@ func TestRouter_DialPeers(t *testing.T) {
 				peerManager,
 				func() *types.NodeInfo { return &selfInfo },
 				mockTransport,
-				nil,
+				[]*p2p.Endpoint{{}},
 				p2p.RouterOptions{},
 			)
 			require.NoError(t, err)
@@ -732,7 +771,7 @@ func TestRouter_DialPeers_Parallel(t *testing.T) {
 		peerManager,
 		func() *types.NodeInfo { return &selfInfo },
 		mockTransport,
-		nil,
+		[]*p2p.Endpoint{{}},
 		p2p.RouterOptions{
 			NumConcurrentDials: func() int {
 				ncpu := runtime.NumCPU()
@@ -805,7 +844,7 @@ func TestRouter_EvictPeers(t *testing.T) {
 		peerManager,
 		func() *types.NodeInfo { return &selfInfo },
 		mockTransport,
-		nil,
+		[]*p2p.Endpoint{{}},
 		p2p.RouterOptions{},
 	)
 	require.NoError(t, err)
@@ -871,7 +910,7 @@ func TestRouter_ChannelCompatability(t *testing.T) {
 		peerManager,
 		func() *types.NodeInfo { return &selfInfo },
 		mockTransport,
-		nil,
+		[]*p2p.Endpoint{{}},
 		p2p.RouterOptions{},
 	)
 	require.NoError(t, err)
@@ -926,7 +965,7 @@ func TestRouter_DontSendOnInvalidChannel(t *testing.T) {
 		peerManager,
 		func() *types.NodeInfo { return &selfInfo },
 		mockTransport,
-		nil,
+		[]*p2p.Endpoint{{}},
 		p2p.RouterOptions{},
 	)
 	require.NoError(t, err)
@@ -4,6 +4,7 @@ import (
 	"container/heap"
 	"context"
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -17,6 +18,8 @@ type simpleQueue struct {
 
 	maxSize int
 	chDescs []*ChannelDescriptor
+
+	sorting int64 // number of envelopes being sorted, accessed atomically
 }
 
 func newSimplePriorityQueue(ctx context.Context, size int, chDescs []*ChannelDescriptor) *simpleQueue {
@@ -41,6 +44,9 @@ func (q *simpleQueue) enqueue() chan<- Envelope { return q.input }
 func (q *simpleQueue) dequeue() <-chan Envelope { return q.output }
 func (q *simpleQueue) close()                   { q.closeFn() }
 func (q *simpleQueue) closed() <-chan struct{}  { return q.closeCh }
+func (q *simpleQueue) len() int {
+	return len(q.input) + len(q.output) + int(atomic.LoadInt64(&q.sorting))
+}
 
 func (q *simpleQueue) run(ctx context.Context) {
 	defer q.closeFn()
@@ -59,6 +65,8 @@ func (q *simpleQueue) run(ctx context.Context) {
 	// to the heap
 	signal := make(chan struct{}, 1)
 	for {
+		atomic.StoreInt64(&q.sorting, int64(len(pq)))
+
 		select {
 		case <-ctx.Done():
 			return
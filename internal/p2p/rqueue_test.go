@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestSimpleQueue(t *testing.T) {
@@ -45,3 +47,25 @@ RETRY:
 	}
 
 }
+
+func TestQueueLen(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fifo := newFIFOQueue(4)
+	fifo.enqueue() <- Envelope{From: "merlin"}
+	fifo.enqueue() <- Envelope{From: "merlin"}
+	require.Equal(t, 2, fifo.len())
+	<-fifo.dequeue()
+	require.Equal(t, 1, fifo.len())
+
+	sq := newSimplePriorityQueue(ctx, 4, nil)
+	for i := 0; i < 2; i++ {
+		sq.enqueue() <- Envelope{From: "merlin"}
+	}
+	require.Eventually(t, func() bool { return sq.len() == 2 }, time.Second, 10*time.Millisecond)
+	for i := 0; i < 2; i++ {
+		<-sq.dequeue()
+	}
+	require.Eventually(t, func() bool { return sq.len() == 0 }, time.Second, 10*time.Millisecond)
+}
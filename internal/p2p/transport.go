@@ -23,6 +23,10 @@ const (
 type Protocol string
 
 // Transport is a connection-oriented mechanism for exchanging data with a peer.
+//
+// Nodes only use the MConnTransport, with the MemoryTransport for tests. There
+// is no QUIC transport, as the module has no QUIC implementation among its
+// dependencies: one would implement this interface with its own Protocol.
 type Transport interface {
 	// Listen starts the transport on the specified endpoint.
 	Listen(*Endpoint) error
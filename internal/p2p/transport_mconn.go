@@ -11,8 +11,6 @@ import (
 	"sync"
 	"time"
 
-	"golang.org/x/net/netutil"
-
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/libs/protoio"
 	"github.com/tendermint/tendermint/internal/p2p/conn"
@@ -48,7 +46,18 @@ type MConnTransport struct {
 
 	closeOnce sync.Once
 	doneCh    chan struct{}
-	listener  net.Listener
+	acceptCh  chan acceptResult
+
+	mtx       sync.Mutex
+	listeners []net.Listener
+	// the semaphore of MaxAcceptedConnections, shared by the listeners
+	acceptSem chan struct{}
+}
+
+// acceptResult is a connection accepted by a listener, or its error.
+type acceptResult struct {
+	conn net.Conn
+	err  error
 }
 
 // NewMConnTransport sets up a new MConnection transport. This uses the
@@ -65,6 +74,7 @@ func NewMConnTransport(
 		options:      options,
 		mConnConfig:  mConnConfig,
 		doneCh:       make(chan struct{}),
+		acceptCh:     make(chan acceptResult),
 		channelDescs: channelDescs,
 	}
 }
@@ -79,9 +89,22 @@ func (m *MConnTransport) Protocols() []Protocol {
 	return []Protocol{MConnProtocol, TCPProtocol}
 }
 
-// Endpoint implements Transport.
+// Endpoint implements Transport. It returns the first endpoint the transport
+// listens on, see Endpoints.
 func (m *MConnTransport) Endpoint() (*Endpoint, error) {
-	if m.listener == nil {
+	endpoints, err := m.Endpoints()
+	if err != nil {
+		return nil, err
+	}
+	return endpoints[0], nil
+}
+
+// Endpoints returns the endpoints the transport listens on, in the order of
+// the calls to Listen.
+func (m *MConnTransport) Endpoints() ([]*Endpoint, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if len(m.listeners) == 0 {
 		return nil, errors.New("listenter not defined")
 	}
 	select {
@@ -90,30 +113,36 @@ func (m *MConnTransport) Endpoint() (*Endpoint, error) {
 	default:
 	}
 
-	endpoint := &Endpoint{
-		Protocol: MConnProtocol,
-	}
-	if addr, ok := m.listener.Addr().(*net.TCPAddr); ok {
-		endpoint.IP = addr.IP
-		endpoint.Port = uint16(addr.Port)
+	endpoints := make([]*Endpoint, 0, len(m.listeners))
+	for _, listener := range m.listeners {
+		endpoint := &Endpoint{
+			Protocol: MConnProtocol,
+		}
+		if addr, ok := listener.Addr().(*net.TCPAddr); ok {
+			endpoint.IP = addr.IP
+			endpoint.Port = uint16(addr.Port)
+		}
+		endpoints = append(endpoints, endpoint)
 	}
-	return endpoint, nil
+	return endpoints, nil
 }
 
 // Listen asynchronously listens for inbound connections on the given endpoint.
-// It must be called exactly once before calling Accept(), and the caller must
-// call Close() to shut down the listener.
-//
-// FIXME: Listen currently only supports listening on a single endpoint, it
-// might be useful to support listening on multiple addresses (e.g. IPv4 and
-// IPv6, or a private and public address) via multiple Listen() calls.
+// It must be called before calling Accept(), once for each endpoint to listen
+// on, e.g. an IPv4 and an IPv6 address, or several interfaces, and the caller
+// must call Close() to shut down the listeners. Accept returns the connections
+// of all the endpoints, with MaxAcceptedConnections shared by them.
 func (m *MConnTransport) Listen(endpoint *Endpoint) error {
-	if m.listener != nil {
-		return errors.New("transport is already listening")
-	}
 	if err := m.validateEndpoint(endpoint); err != nil {
 		return err
 	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	select {
+	case <-m.doneCh:
+		return errors.New("transport closed")
+	default:
+	}
 
 	listener, err := net.Listen("tcp", net.JoinHostPort(
 		endpoint.IP.String(), strconv.Itoa(int(endpoint.Port))))
@@ -126,48 +155,70 @@ func (m *MConnTransport) Listen(endpoint *Endpoint) error {
 		// return an error to the remote peer or close the connection. This is
 		// also a DoS vector since the connection will take up kernel resources.
 		// This was just carried over from the legacy P2P stack.
-		listener = netutil.LimitListener(listener, int(m.options.MaxAcceptedConnections))
+		if m.acceptSem == nil {
+			m.acceptSem = make(chan struct{}, m.options.MaxAcceptedConnections)
+		}
+		listener = &limitListener{Listener: listener, sem: m.acceptSem, done: make(chan struct{})}
 	}
-	m.listener = listener
+	m.listeners = append(m.listeners, listener)
+	go m.acceptRoutine(listener)
 
 	return nil
 }
 
+// acceptRoutine accepts the connections of the listener, and passes them to
+// Accept, until the listener or the transport is closed.
+func (m *MConnTransport) acceptRoutine(listener net.Listener) {
+	for {
+		tcpConn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		select {
+		case m.acceptCh <- acceptResult{conn: tcpConn, err: err}:
+		case <-m.doneCh:
+			if tcpConn != nil {
+				tcpConn.Close()
+			}
+			return
+		}
+	}
+}
+
+// closeListeners closes all the listeners of the transport.
+func (m *MConnTransport) closeListeners() error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	var err error
+	for _, listener := range m.listeners {
+		if lerr := listener.Close(); lerr != nil && err == nil {
+			err = lerr
+		}
+	}
+	return err
+}
+
 // Accept implements Transport.
 func (m *MConnTransport) Accept(ctx context.Context) (Connection, error) {
-	if m.listener == nil {
+	m.mtx.Lock()
+	listening := len(m.listeners) > 0
+	m.mtx.Unlock()
+	if !listening {
 		return nil, errors.New("transport is not listening")
 	}
 
-	conCh := make(chan net.Conn)
-	errCh := make(chan error)
-	go func() {
-		tcpConn, err := m.listener.Accept()
-		if err != nil {
-			select {
-			case errCh <- err:
-			case <-ctx.Done():
-			}
-		}
-		select {
-		case conCh <- tcpConn:
-		case <-ctx.Done():
-		}
-	}()
-
 	select {
 	case <-ctx.Done():
-		m.listener.Close()
+		_ = m.closeListeners()
 		return nil, io.EOF
 	case <-m.doneCh:
-		m.listener.Close()
 		return nil, io.EOF
-	case err := <-errCh:
-		return nil, err
-	case tcpConn := <-conCh:
-		return newMConnConnection(m.logger, tcpConn, m.mConnConfig, m.channelDescs), nil
+	case res := <-m.acceptCh:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return newMConnConnection(m.logger, res.conn, m.mConnConfig, m.channelDescs), nil
 	}
-
 }
 
 // Dial implements Transport.
@@ -199,13 +250,54 @@ func (m *MConnTransport) Close() error {
 	var err error
 	m.closeOnce.Do(func() {
 		close(m.doneCh)
-		if m.listener != nil {
-			err = m.listener.Close()
-		}
+		err = m.closeListeners()
 	})
 	return err
 }
 
+// limitListener is a net.Listener accepting connections only while there is
+// room in the semaphore sem, which several listeners may share, as
+// netutil.LimitListener does for a single listener. The semaphore is released
+// when the accepted connection is closed.
+type limitListener struct {
+	net.Listener
+	sem       chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	select {
+	case l.sem <- struct{}{}:
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+	c, err := l.Listener.Accept()
+	if err != nil {
+		<-l.sem
+		return nil, err
+	}
+	return &limitListenerConn{Conn: c, release: func() { <-l.sem }}, nil
+}
+
+func (l *limitListener) Close() error {
+	err := l.Listener.Close()
+	l.closeOnce.Do(func() { close(l.done) })
+	return err
+}
+
+type limitListenerConn struct {
+	net.Conn
+	releaseOnce sync.Once
+	release     func()
+}
+
+func (c *limitListenerConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+	return err
+}
+
 // SetChannels sets the channel descriptors to be used when
 // establishing a connection.
 //
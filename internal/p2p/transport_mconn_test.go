@@ -219,8 +219,8 @@ func TestMConnTransport_Listen(t *testing.T) {
 			_, _, err = peerConn.ReceiveMessage(ctx)
 			require.Error(t, err)
 
-			// Trying to listen again should error.
-			err = transport.Listen(tc.endpoint)
+			// Trying to listen again on the bound endpoint should error.
+			err = transport.Listen(endpoint)
 			require.Error(t, err)
 
 			// close the transport
@@ -232,3 +232,48 @@ func TestMConnTransport_Listen(t *testing.T) {
 		})
 	}
 }
+
+func TestMConnTransport_ListenMultiple(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transport := p2p.NewMConnTransport(
+		log.NewNopLogger(),
+		conn.DefaultMConnConfig(),
+		[]*p2p.ChannelDescriptor{{ID: chID, Priority: 1}},
+		p2p.MConnTransportOptions{},
+	)
+	t.Cleanup(func() { _ = transport.Close() })
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, transport.Listen(&p2p.Endpoint{
+			Protocol: p2p.MConnProtocol,
+			IP:       net.IPv4(127, 0, 0, 1),
+		}))
+	}
+	endpoints, err := transport.Endpoints()
+	require.NoError(t, err)
+	require.Len(t, endpoints, 2)
+	require.NotEqual(t, endpoints[0].Port, endpoints[1].Port)
+
+	// The first endpoint is the one of Endpoint.
+	endpoint, err := transport.Endpoint()
+	require.NoError(t, err)
+	require.Equal(t, endpoints[0], endpoint)
+
+	// Connections are accepted on all the endpoints.
+	for _, endpoint := range endpoints {
+		dial, err := transport.Dial(ctx, endpoint)
+		require.NoError(t, err)
+		defer dial.Close()
+		accept, err := transport.Accept(ctx)
+		require.NoError(t, err)
+		defer accept.Close()
+		require.Equal(t, dial.LocalEndpoint(), accept.RemoteEndpoint())
+	}
+
+	// Accept returns io.EOF once the transport is closed.
+	require.NoError(t, transport.Close())
+	_, err = transport.Accept(ctx)
+	require.Equal(t, io.EOF, err)
+}
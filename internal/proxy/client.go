@@ -2,6 +2,8 @@ package proxy
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
 	"syscall"
@@ -11,25 +13,40 @@ import (
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/abci/snapshots"
 	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus/simulation/simapp"
+	"github.com/tendermint/tendermint/internal/trace"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	e2e "github.com/tendermint/tendermint/test/e2e/app"
 )
 
 // ClientFactory returns a client object, which will create a local
-// client if addr is one of: 'kvstore', 'persistent_kvstore', 'e2e',
-// or 'noop', otherwise - a remote client.
+// client if addr is one of: 'kvstore', 'persistent_kvstore',
+// 'rotating_kvstore', 'e2e', or 'noop', otherwise - a remote client. The
+// rotating_kvstore application is a kvstore rotating the validator set at
+// every height, for the consensus simulations.
 //
 // The Closer is a noop except for persistent_kvstore applications,
 // which will clean up the store.
-func ClientFactory(logger log.Logger, addr, transport, dbDir string) (abciclient.Client, io.Closer, error) {
+//
+// The gRPC options only apply to a remote client using the grpc transport.
+func ClientFactory(
+	logger log.Logger,
+	addr, transport, dbDir string,
+	grpcOptions ...abciclient.GRPCClientOption,
+) (abciclient.Client, io.Closer, error) {
 	switch addr {
 	case "kvstore":
 		return abciclient.NewLocalClient(logger, kvstore.NewApplication()), noopCloser{}, nil
 	case "persistent_kvstore":
 		app := kvstore.NewPersistentKVStoreApplication(logger, dbDir)
 		return abciclient.NewLocalClient(logger, app), app, nil
+	case "rotating_kvstore":
+		app := simapp.NewRotating(kvstore.NewApplication())
+		return abciclient.NewLocalClient(logger, app), noopCloser{}, nil
 	case "e2e":
 		app, err := e2e.NewApplication(e2e.DefaultConfig(dbDir))
 		if err != nil {
@@ -40,6 +57,9 @@ func ClientFactory(logger log.Logger, addr, transport, dbDir string) (abciclient
 		return abciclient.NewLocalClient(logger, types.NewBaseApplication()), noopCloser{}, nil
 	default:
 		const mustConnect = false // loop retrying
+		if transport == "grpc" {
+			return abciclient.NewGRPCClient(logger, addr, mustConnect, grpcOptions...), noopCloser{}, nil
+		}
 		client, err := abciclient.NewClient(logger, addr, transport, mustConnect)
 		if err != nil {
 			return nil, noopCloser{}, err
@@ -49,6 +69,31 @@ func ClientFactory(logger log.Logger, addr, transport, dbDir string) (abciclient
 	}
 }
 
+// IsBuiltin reports whether addr is the name of an application compiled in
+// with Tendermint, for which ClientFactory returns a local client.
+func IsBuiltin(addr string) bool {
+	switch addr {
+	case "kvstore", "persistent_kvstore", "rotating_kvstore", "e2e", "noop":
+		return true
+	default:
+		return false
+	}
+}
+
+// GRPCOptions returns the options of a gRPC client to the ABCI application
+// configured in cfg.
+func GRPCOptions(cfg config.BaseConfig) ([]abciclient.GRPCClientOption, error) {
+	certFile, keyFile, caFile := cfg.ABCITLSFiles()
+	if caFile == "" {
+		return nil, nil
+	}
+	tlsConfig, err := abciclient.LoadTLSConfig(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, fmt.Errorf("abci tls: %w", err)
+	}
+	return []abciclient.GRPCClientOption{abciclient.WithTLSConfig(tlsConfig)}, nil
+}
+
 type noopCloser struct{}
 
 func (noopCloser) Close() error { return nil }
@@ -60,19 +105,103 @@ type proxyClient struct {
 
 	client  abciclient.Client
 	metrics *Metrics
+
+	timeout        time.Duration
+	checkTxTimeout time.Duration
+
+	snapshots *snapshots.Store
+	tracer    *trace.Tracer
+}
+
+// Option sets an optional parameter on the proxy client.
+type Option func(*proxyClient)
+
+// WithTimeout sets the deadline of each ABCI call. Zero, the default, means
+// no deadline.
+func WithTimeout(timeout time.Duration) Option {
+	return func(app *proxyClient) { app.timeout = timeout }
+}
+
+// WithCheckTxTimeout sets the deadline of each CheckTx call, in place of the
+// one set by WithTimeout.
+func WithCheckTxTimeout(timeout time.Duration) Option {
+	return func(app *proxyClient) { app.checkTxTimeout = timeout }
+}
+
+// WithSnapshotStore makes the proxy client answer ListSnapshots and
+// LoadSnapshotChunk calls from the given snapshot store, instead of calling
+// the application. Offers of snapshots in formats the store does not support
+// are rejected without calling the application.
+func WithSnapshotStore(store *snapshots.Store) Option {
+	return func(app *proxyClient) { app.snapshots = store }
+}
+
+// WithTracer makes the proxy client record a span named "abci.<method>" for
+// each ABCI call, as a child of the span of the context of the call if any.
+func WithTracer(tracer *trace.Tracer) Option {
+	return func(app *proxyClient) { app.tracer = tracer }
 }
 
 // New creates a proxy application interface.
-func New(client abciclient.Client, logger log.Logger, metrics *Metrics) abciclient.Client {
+func New(client abciclient.Client, logger log.Logger, metrics *Metrics, options ...Option) abciclient.Client {
 	conn := &proxyClient{
 		logger:  logger,
 		metrics: metrics,
 		client:  client,
 	}
+	for _, opt := range options {
+		opt(conn)
+	}
 	conn.BaseService = *service.NewBaseService(logger, "proxyClient", conn)
 	return conn
 }
 
+// TimeoutError is returned by an ABCI call that missed its deadline.
+type TimeoutError struct {
+	Method  string
+	Timeout time.Duration
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("abci %s call timed out after %v", e.Method, e.Timeout)
+}
+
+// Unwrap makes a TimeoutError match context.DeadlineExceeded.
+func (e *TimeoutError) Unwrap() error { return context.DeadlineExceeded }
+
+// withTimeout derives from ctx the context of a call to the given ABCI method,
+// with the configured deadline and the span of the call, which has the given
+// attributes. The returned function must be called with the result of the
+// call: it releases the context, ends the span, and replaces the error of a
+// call that missed its deadline with a TimeoutError.
+func (app *proxyClient) withTimeout(ctx context.Context, method string, attrs ...trace.Attribute) (context.Context, func(error) error) {
+	ctx, span := app.tracer.Start(ctx, "abci."+method, attrs...)
+	timeout := app.timeout
+	if method == "check_tx" && app.checkTxTimeout > 0 {
+		timeout = app.checkTxTimeout
+	}
+	if timeout <= 0 {
+		return ctx, func(err error) error {
+			span.SetError(err)
+			span.End()
+			return err
+		}
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, timeout)
+	return cctx, func(err error) error {
+		defer cancel()
+		defer span.End()
+		if err != nil && ctx.Err() == nil && errors.Is(cctx.Err(), context.DeadlineExceeded) {
+			app.metrics.MethodTimeouts.With("method", method).Add(1)
+			app.logger.Error("ABCI call timed out", "method", method, "timeout", timeout)
+			err = &TimeoutError{Method: method, Timeout: timeout}
+		}
+		span.SetError(err)
+		return err
+	}
+}
+
 func (app *proxyClient) OnStop()      { tryCallStop(app.client) }
 func (app *proxyClient) Error() error { return app.client.Error() }
 
@@ -126,82 +255,132 @@ func kill() error {
 
 func (app *proxyClient) InitChain(ctx context.Context, req *types.RequestInitChain) (*types.ResponseInitChain, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "init_chain", "type", "sync"))()
-	return app.client.InitChain(ctx, req)
+	ctx, done := app.withTimeout(ctx, "init_chain")
+	res, err := app.client.InitChain(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) PrepareProposal(ctx context.Context, req *types.RequestPrepareProposal) (*types.ResponsePrepareProposal, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "prepare_proposal", "type", "sync"))()
-	return app.client.PrepareProposal(ctx, req)
+	ctx, done := app.withTimeout(ctx, "prepare_proposal", trace.Int64("height", req.Height))
+	res, err := app.client.PrepareProposal(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) ProcessProposal(ctx context.Context, req *types.RequestProcessProposal) (*types.ResponseProcessProposal, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "process_proposal", "type", "sync"))()
-	return app.client.ProcessProposal(ctx, req)
+	ctx, done := app.withTimeout(ctx, "process_proposal", trace.Int64("height", req.Height))
+	res, err := app.client.ProcessProposal(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) ExtendVote(ctx context.Context, req *types.RequestExtendVote) (*types.ResponseExtendVote, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "extend_vote", "type", "sync"))()
-	return app.client.ExtendVote(ctx, req)
+	ctx, done := app.withTimeout(ctx, "extend_vote", trace.Int64("height", req.Height))
+	res, err := app.client.ExtendVote(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) VerifyVoteExtension(ctx context.Context, req *types.RequestVerifyVoteExtension) (*types.ResponseVerifyVoteExtension, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "verify_vote_extension", "type", "sync"))()
-	return app.client.VerifyVoteExtension(ctx, req)
+	ctx, done := app.withTimeout(ctx, "verify_vote_extension")
+	res, err := app.client.VerifyVoteExtension(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) FinalizeBlock(ctx context.Context, req *types.RequestFinalizeBlock) (*types.ResponseFinalizeBlock, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "finalize_block", "type", "sync"))()
-	return app.client.FinalizeBlock(ctx, req)
+	ctx, done := app.withTimeout(ctx, "finalize_block", trace.Int64("height", req.Height))
+	res, err := app.client.FinalizeBlock(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) Commit(ctx context.Context) (*types.ResponseCommit, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "commit", "type", "sync"))()
-	return app.client.Commit(ctx)
+	ctx, done := app.withTimeout(ctx, "commit")
+	res, err := app.client.Commit(ctx)
+	return res, done(err)
 }
 
 func (app *proxyClient) Flush(ctx context.Context) error {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "flush", "type", "sync"))()
-	return app.client.Flush(ctx)
+	ctx, done := app.withTimeout(ctx, "flush")
+	return done(app.client.Flush(ctx))
 }
 
 func (app *proxyClient) CheckTx(ctx context.Context, req *types.RequestCheckTx) (*types.ResponseCheckTx, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "check_tx", "type", "sync"))()
-	return app.client.CheckTx(ctx, req)
+	ctx, done := app.withTimeout(ctx, "check_tx")
+	res, err := app.client.CheckTx(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) Echo(ctx context.Context, msg string) (*types.ResponseEcho, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "echo", "type", "sync"))()
-	return app.client.Echo(ctx, msg)
+	ctx, done := app.withTimeout(ctx, "echo")
+	res, err := app.client.Echo(ctx, msg)
+	return res, done(err)
 }
 
 func (app *proxyClient) Info(ctx context.Context, req *types.RequestInfo) (*types.ResponseInfo, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "info", "type", "sync"))()
-	return app.client.Info(ctx, req)
+	ctx, done := app.withTimeout(ctx, "info")
+	res, err := app.client.Info(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) Query(ctx context.Context, req *types.RequestQuery) (*types.ResponseQuery, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "query", "type", "sync"))()
-	return app.client.Query(ctx, req)
+	ctx, done := app.withTimeout(ctx, "query")
+	res, err := app.client.Query(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) ListSnapshots(ctx context.Context, req *types.RequestListSnapshots) (*types.ResponseListSnapshots, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "list_snapshots", "type", "sync"))()
-	return app.client.ListSnapshots(ctx, req)
+	if app.snapshots != nil {
+		list, err := app.snapshots.List()
+		if err != nil {
+			return nil, err
+		}
+		return &types.ResponseListSnapshots{Snapshots: list}, nil
+	}
+	ctx, done := app.withTimeout(ctx, "list_snapshots")
+	res, err := app.client.ListSnapshots(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) OfferSnapshot(ctx context.Context, req *types.RequestOfferSnapshot) (*types.ResponseOfferSnapshot, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "offer_snapshot", "type", "sync"))()
-	return app.client.OfferSnapshot(ctx, req)
+	if app.snapshots != nil && req.Snapshot != nil && !app.snapshots.SupportsFormat(req.Snapshot.Format) {
+		return &types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_REJECT_FORMAT}, nil
+	}
+	ctx, done := app.withTimeout(ctx, "offer_snapshot")
+	res, err := app.client.OfferSnapshot(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) LoadSnapshotChunk(ctx context.Context, req *types.RequestLoadSnapshotChunk) (*types.ResponseLoadSnapshotChunk, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "load_snapshot_chunk", "type", "sync"))()
-	return app.client.LoadSnapshotChunk(ctx, req)
+	if app.snapshots != nil {
+		chunk, err := app.snapshots.LoadChunk(req.Height, req.Format, req.Chunk)
+		if errors.Is(err, snapshots.ErrNotFound) {
+			return &types.ResponseLoadSnapshotChunk{}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &types.ResponseLoadSnapshotChunk{Chunk: chunk}, nil
+	}
+	ctx, done := app.withTimeout(ctx, "load_snapshot_chunk")
+	res, err := app.client.LoadSnapshotChunk(ctx, req)
+	return res, done(err)
 }
 
 func (app *proxyClient) ApplySnapshotChunk(ctx context.Context, req *types.RequestApplySnapshotChunk) (*types.ResponseApplySnapshotChunk, error) {
 	defer addTimeSample(app.metrics.MethodTiming.With("method", "apply_snapshot_chunk", "type", "sync"))()
-	return app.client.ApplySnapshotChunk(ctx, req)
+	ctx, done := app.withTimeout(ctx, "apply_snapshot_chunk")
+	res, err := app.client.ApplySnapshotChunk(ctx, req)
+	return res, done(err)
 }
 
 // addTimeSample returns a function that, when called, adds an observation to m.
@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -20,7 +21,9 @@ import (
 	abcimocks "github.com/tendermint/tendermint/abci/client/mocks"
 	"github.com/tendermint/tendermint/abci/example/kvstore"
 	"github.com/tendermint/tendermint/abci/server"
+	"github.com/tendermint/tendermint/abci/snapshots"
 	"github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/trace"
 	"github.com/tendermint/tendermint/libs/log"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 )
@@ -233,3 +236,117 @@ func TestAppConns_Failure(t *testing.T) {
 		t.Fatal("expected process to receive SIGTERM signal")
 	}
 }
+
+// blockingClient is a client whose CheckTx and Query calls block until their
+// context is done.
+type blockingClient struct {
+	abciclient.Client
+}
+
+func (blockingClient) CheckTx(ctx context.Context, _ *types.RequestCheckTx) (*types.ResponseCheckTx, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingClient) Query(ctx context.Context, _ *types.RequestQuery) (*types.ResponseQuery, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestAppConns_Timeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	appConn := New(blockingClient{}, log.NewNopLogger(), NopMetrics(),
+		WithTimeout(time.Hour),
+		WithCheckTxTimeout(10*time.Millisecond),
+	)
+
+	_, err := appConn.CheckTx(ctx, &types.RequestCheckTx{})
+	var terr *TimeoutError
+	require.True(t, errors.As(err, &terr))
+	require.Equal(t, "check_tx", terr.Method)
+	require.Equal(t, 10*time.Millisecond, terr.Timeout)
+	require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// Cancelling the caller's context is not a timeout.
+	qctx, qcancel := context.WithCancel(ctx)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		qcancel()
+	}()
+	_, err = appConn.Query(qctx, &types.RequestQuery{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.False(t, errors.As(err, &terr))
+}
+
+func TestAppConns_SnapshotStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := snapshots.NewStore(t.TempDir(), snapshots.WithChunkSize(2))
+	require.NoError(t, err)
+	snapshot, err := store.Create(5, 1, nil, bytes.NewReader([]byte("abc")))
+	require.NoError(t, err)
+
+	// The application is never called.
+	appConn := New(blockingClient{}, log.NewNopLogger(), NopMetrics(), WithSnapshotStore(store))
+
+	res, err := appConn.ListSnapshots(ctx, &types.RequestListSnapshots{})
+	require.NoError(t, err)
+	require.Equal(t, []*types.Snapshot{snapshot}, res.Snapshots)
+
+	chunk, err := appConn.LoadSnapshotChunk(ctx, &types.RequestLoadSnapshotChunk{Height: 5, Format: 1, Chunk: 1})
+	require.NoError(t, err)
+	require.Equal(t, []byte("c"), chunk.Chunk)
+
+	chunk, err = appConn.LoadSnapshotChunk(ctx, &types.RequestLoadSnapshotChunk{Height: 4, Format: 1})
+	require.NoError(t, err)
+	require.Empty(t, chunk.Chunk)
+}
+
+func TestAppConns_SnapshotStoreFormats(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, err := snapshots.NewStore(t.TempDir(), snapshots.WithFormats(1))
+	require.NoError(t, err)
+
+	// The offers of unsupported formats are rejected without calling the
+	// application, the others are passed on.
+	clientMock := &abcimocks.Client{}
+	clientMock.On("OfferSnapshot", mock.Anything, mock.Anything).
+		Return(&types.ResponseOfferSnapshot{Result: types.ResponseOfferSnapshot_ACCEPT}, nil).Once()
+	appConn := New(clientMock, log.NewNopLogger(), NopMetrics(), WithSnapshotStore(store))
+
+	res, err := appConn.OfferSnapshot(ctx, &types.RequestOfferSnapshot{Snapshot: &types.Snapshot{Height: 5, Format: 2}})
+	require.NoError(t, err)
+	require.Equal(t, types.ResponseOfferSnapshot_REJECT_FORMAT, res.Result)
+
+	res, err = appConn.OfferSnapshot(ctx, &types.RequestOfferSnapshot{Snapshot: &types.Snapshot{Height: 5, Format: 1}})
+	require.NoError(t, err)
+	require.Equal(t, types.ResponseOfferSnapshot_ACCEPT, res.Result)
+	clientMock.AssertExpectations(t)
+}
+
+func TestAppConns_Tracer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tracer := trace.NewTracer(log.NewNopLogger(), "http://localhost", 1, "test-chain")
+	parentCtx, parent := tracer.Start(ctx, "consensus.finalize_commit", trace.Int64("height", 3))
+	defer parent.End()
+
+	// The application is called with the span of the call, a child of the
+	// span of the caller.
+	clientMock := &abcimocks.Client{}
+	clientMock.On("FinalizeBlock", mock.MatchedBy(func(ctx context.Context) bool {
+		span := trace.SpanFromContext(ctx)
+		return span != nil && span != parent
+	}), mock.Anything).Return(&types.ResponseFinalizeBlock{}, nil)
+
+	appConn := New(clientMock, log.NewNopLogger(), NopMetrics(), WithTracer(tracer))
+	_, err := appConn.FinalizeBlock(parentCtx, &types.RequestFinalizeBlock{Height: 3})
+	require.NoError(t, err)
+	clientMock.AssertExpectations(t)
+}
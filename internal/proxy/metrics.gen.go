@@ -22,11 +22,18 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 			Buckets: []float64{.0001, .0004, .002, .009, .02, .1, .65, 2, 6, 25},
 		}, append(labels, "method", "type")).With(labelsAndValues...),
+		MethodTimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "method_timeouts",
+			Help:      "Number of ABCI calls that missed their deadline, by method.",
+		}, append(labels, "method")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		MethodTiming: discard.NewHistogram(),
+		MethodTiming:   discard.NewHistogram(),
+		MethodTimeouts: discard.NewCounter(),
 	}
 }
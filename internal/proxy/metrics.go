@@ -16,4 +16,7 @@ const (
 type Metrics struct {
 	// Timing for each ABCI method.
 	MethodTiming metrics.Histogram `metrics_bucketsizes:".0001,.0004,.002,.009,.02,.1,.65,2,6,25" metrics_labels:"method, type"`
+
+	// Number of ABCI calls that missed their deadline, by method.
+	MethodTimeouts metrics.Counter `metrics_labels:"method"`
 }
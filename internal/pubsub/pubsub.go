@@ -63,10 +63,55 @@ var (
 
 // SubscribeArgs are the parameters to create a new subscription.
 type SubscribeArgs struct {
-	ClientID string       // Client ID
-	Query    *query.Query // filter query for events (required)
-	Limit    int          // subscription queue capacity limit (0 means 1)
-	Quota    int          // subscription queue soft quota (0 uses Limit)
+	ClientID string         // Client ID
+	Query    *query.Query   // filter query for events (required)
+	Limit    int            // subscription queue capacity limit (0 means 1)
+	Quota    int            // subscription queue soft quota (0 uses Limit)
+	Overflow OverflowPolicy // what to do when the queue is full (default Disconnect)
+}
+
+// OverflowPolicy determines what happens to a subscription whose queue cannot
+// accept a message, because the subscriber is not keeping up with publishers.
+// Dropped messages can be detected by gaps in the sequence numbers of the
+// messages received (see Message.Seq).
+type OverflowPolicy int
+
+const (
+	// Disconnect terminates the subscription with ErrTerminated.
+	Disconnect OverflowPolicy = iota
+
+	// DropOldest discards the oldest message in the queue to make room.
+	DropOldest
+
+	// DropNewest discards the message that does not fit.
+	DropNewest
+)
+
+var overflowPolicyNames = map[OverflowPolicy]string{
+	Disconnect: "disconnect",
+	DropOldest: "drop-oldest",
+	DropNewest: "drop-newest",
+}
+
+func (p OverflowPolicy) String() string {
+	if name, ok := overflowPolicyNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+}
+
+// ParseOverflowPolicy returns the policy with the given name, as reported by
+// its String method. The empty string selects Disconnect.
+func ParseOverflowPolicy(name string) (OverflowPolicy, error) {
+	if name == "" {
+		return Disconnect, nil
+	}
+	for p, pname := range overflowPolicyNames {
+		if pname == name {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown overflow policy %q", name)
 }
 
 // UnsubscribeArgs are the parameters to remove a subscription.
@@ -98,6 +143,10 @@ type Server struct {
 	pubs   sync.RWMutex    // excl: shutdown; shared: active publisher
 	exited chan struct{}   // server exited
 
+	// The sequence number of the last message sent. It is only accessed by
+	// the sender goroutine.
+	lastSeq int64
+
 	// All subscriptions currently known.
 	// Lock exclusive to add, remove, or cancel subscriptions.
 	// Lock shared to look up or publish to subscriptions.
@@ -150,6 +199,17 @@ func BufferCapacity(cap int) Option {
 	return func(s *Server) { s.queueCap = cap }
 }
 
+// InitialSeq sets the sequence number after which the server numbers its
+// messages: the first message published has sequence number seq+1. It lets
+// the sequence numbers keep increasing across restarts. This function will
+// panic if seq < 0.
+func InitialSeq(seq int64) Option {
+	if seq < 0 {
+		panic("negative initial sequence number")
+	}
+	return func(s *Server) { s.lastSeq = seq }
+}
+
 // BufferCapacity returns capacity of the publication queue.
 func (s *Server) BufferCapacity() int { return cap(s.queue) }
 
@@ -207,10 +267,12 @@ func (s *Server) SubscribeWithArgs(ctx context.Context, args SubscribeArgs) (*Su
 	if args.Limit == 0 {
 		args.Limit = 1
 	}
-	sub, err := newSubscription(args.Quota, args.Limit)
+	sub, err := newSubscription(args.Quota, args.Limit, args.Overflow)
 	if err != nil {
 		return nil, err
 	}
+	// The sender only updates lastSeq while holding the lock shared.
+	sub.startSeq = s.lastSeq
 	s.subs.index.add(&subInfo{
 		clientID: args.ClientID,
 		query:    args.Query,
@@ -387,11 +449,15 @@ func (s *Server) send(data types.EventData, events []abci.Event) error {
 	s.subs.RLock()
 	defer s.subs.RUnlock()
 
+	s.lastSeq++
+	seq := s.lastSeq
+
 	// If an observer is defined, give it control of the message before
 	// attempting to deliver it to any matching subscribers. If the observer
 	// fails, the message will not be forwarded.
 	if s.subs.observe != nil {
 		err := s.subs.observe(Message{
+			seq:    seq,
 			data:   data,
 			events: events,
 		})
@@ -410,6 +476,7 @@ func (s *Server) send(data types.EventData, events []abci.Event) error {
 		// subscription from the index.
 		if err := si.sub.publish(Message{
 			subID:  si.sub.id,
+			seq:    seq,
 			data:   data,
 			events: events,
 		}); err != nil {
@@ -56,6 +56,63 @@ func TestSubscribeWithArgs(t *testing.T) {
 	})
 }
 
+func TestMessageSeq(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := newTestServer(ctx, t, log.NewNopLogger())
+
+	sub := newTestSub(t).must(s.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+		ClientID: clientID,
+		Query:    query.MustCompile(`tm.events.type='NewBlock'`),
+		Limit:    10,
+	}))
+
+	events := []abci.Event{{
+		Type:       "tm",
+		Attributes: []abci.EventAttribute{{Key: "events.type", Value: "NewBlock"}},
+	}}
+	require.NoError(t, s.PublishWithEvents(pubstring("one"), events))
+	require.NoError(t, s.Publish(pubstring("skipped")))
+	require.NoError(t, s.PublishWithEvents(pubstring("three"), events))
+
+	// Messages that did not match the subscription still consume a sequence
+	// number, so the gap is visible to the subscriber.
+	for _, want := range []int64{1, 3} {
+		msg, err := sub.Next(ctx)
+		require.NoError(t, err)
+		require.Equal(t, want, msg.Seq())
+	}
+}
+
+func TestInitialSeq(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := pubsub.NewServer(log.NewNopLogger(), pubsub.InitialSeq(100))
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(s.Wait)
+
+	first := newTestSub(t).must(s.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+		ClientID: clientID,
+		Query:    query.All,
+		Limit:    10,
+	}))
+	require.Equal(t, int64(100), first.StartSeq())
+	require.NoError(t, s.Publish(pubstring("one")))
+	msg, err := first.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(101), msg.Seq())
+
+	// A later subscription starts after the messages already published.
+	second := newTestSub(t).must(s.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+		ClientID: "other-client",
+		Query:    query.All,
+		Limit:    10,
+	}))
+	require.Equal(t, int64(101), second.StartSeq())
+}
+
 func TestObserver(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -159,6 +216,67 @@ func TestSlowSubscriber(t *testing.T) {
 	sub.mustFail(ctx, pubsub.ErrTerminated)
 }
 
+func TestOverflowPolicy(t *testing.T) {
+	testCases := []struct {
+		policy pubsub.OverflowPolicy
+		want   []pubstring
+	}{
+		{pubsub.DropOldest, []pubstring{"Mister Fear", "Nightmare"}},
+		{pubsub.DropNewest, []pubstring{"Kingpin", "Bullseye"}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s := newTestServer(ctx, t, log.NewNopLogger())
+
+			sub := newTestSub(t).must(s.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+				ClientID: clientID,
+				Query:    query.All,
+				Limit:    2,
+				Overflow: tc.policy,
+			}))
+			// Messages are sent in order, so once the last one reaches this
+			// subscription, all of them have been offered to sub.
+			done := newTestSub(t).must(s.SubscribeWithArgs(ctx, pubsub.SubscribeArgs{
+				ClientID: clientID + "-done",
+				Query:    query.All,
+				Limit:    10,
+			}))
+
+			msgs := []pubstring{"Kingpin", "Bullseye", "Mister Fear", "Nightmare"}
+			for _, msg := range msgs {
+				require.NoError(t, s.Publish(msg))
+			}
+			for _, msg := range msgs {
+				done.mustReceive(ctx, msg)
+			}
+
+			for _, want := range tc.want {
+				sub.mustReceive(ctx, want)
+			}
+			require.NoError(t, s.Publish(pubstring("Elektra")))
+			sub.mustReceive(ctx, pubstring("Elektra"))
+		})
+	}
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	for _, p := range []pubsub.OverflowPolicy{pubsub.Disconnect, pubsub.DropOldest, pubsub.DropNewest} {
+		got, err := pubsub.ParseOverflowPolicy(p.String())
+		require.NoError(t, err)
+		require.Equal(t, p, got)
+	}
+	got, err := pubsub.ParseOverflowPolicy("")
+	require.NoError(t, err)
+	require.Equal(t, pubsub.Disconnect, got)
+
+	_, err = pubsub.ParseOverflowPolicy("block")
+	require.Error(t, err)
+}
+
 func TestDifferentClients(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -5,6 +5,7 @@
 // strings like:
 //
 //    abci.invoice.number = 22 AND abci.invoice.owner = 'Ivan'
+//    tx.height > 100 AND NOT transfer.sender = 'spam' OR tx.height = 5
 //
 // Query expressions can handle attribute values encoding numbers, strings,
 // dates, and timestamps.  The complete query grammar is described in the
@@ -13,6 +14,7 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -26,19 +28,20 @@ import (
 // All is a query that matches all events.
 var All *Query
 
-// A Query is the compiled form of a query.
+// A Query is the compiled form of a query. A query matches if all of the
+// conditions of any one of its alternatives (separated by OR) match.
 type Query struct {
-	ast   syntax.Query
-	conds []condition
+	expr  syntax.Expr
+	conds [][]condition
 }
 
 // New parses and compiles the query expression into an executable query.
 func New(query string) (*Query, error) {
-	ast, err := syntax.Parse(query)
+	expr, err := syntax.ParseExpr(query)
 	if err != nil {
 		return nil, err
 	}
-	return Compile(ast)
+	return CompileExpr(expr)
 }
 
 // MustCompile compiles the query expression into an executable query.
@@ -56,15 +59,28 @@ func MustCompile(query string) *Query {
 
 // Compile compiles the given query AST so it can be used to match events.
 func Compile(ast syntax.Query) (*Query, error) {
-	conds := make([]condition, len(ast))
-	for i, q := range ast {
-		cond, err := compileCondition(q)
-		if err != nil {
-			return nil, fmt.Errorf("compile %s: %w", q, err)
+	return CompileExpr(syntax.Expr{ast})
+}
+
+// CompileExpr compiles the given expression AST so it can be used to match
+// events.
+func CompileExpr(expr syntax.Expr) (*Query, error) {
+	if len(expr) == 0 {
+		return nil, errors.New("empty query expression")
+	}
+	alts := make([][]condition, len(expr))
+	for i, ast := range expr {
+		conds := make([]condition, len(ast))
+		for j, q := range ast {
+			cond, err := compileCondition(q)
+			if err != nil {
+				return nil, fmt.Errorf("compile %s: %w", q, err)
+			}
+			conds[j] = cond
 		}
-		conds[i] = cond
+		alts[i] = conds
 	}
-	return &Query{ast: ast, conds: conds}, nil
+	return &Query{expr: expr, conds: alts}, nil
 }
 
 // Matches reports whether q matches the given events. If q == nil, the query
@@ -73,12 +89,25 @@ func (q *Query) Matches(events []types.Event) bool {
 	if q == nil {
 		return true
 	}
-	for _, cond := range q.conds {
-		if !cond.matchesAny(events) {
+	if len(events) == 0 {
+		return false
+	}
+	for _, conds := range q.conds {
+		if matchesAll(conds, events) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAll reports whether every condition in conds matches events.
+func matchesAll(conds []condition, events []types.Event) bool {
+	for _, cond := range conds {
+		if cond.matchesAny(events) == cond.not {
 			return false
 		}
 	}
-	return len(events) != 0
+	return true
 }
 
 // String matches part of the pubsub.Query interface.
@@ -86,23 +115,36 @@ func (q *Query) String() string {
 	if q == nil {
 		return "<empty>"
 	}
-	return q.ast.String()
+	return q.expr.String()
 }
 
-// Syntax returns the syntax tree representation of q.
+// Syntax returns the syntax tree representation of q. If q has more than one
+// alternative (i.e., uses OR), Syntax returns nil; use Expr instead.
 func (q *Query) Syntax() syntax.Query {
+	if q == nil || len(q.expr) != 1 {
+		return nil
+	}
+	return q.expr[0]
+}
+
+// Expr returns the syntax tree representation of q as a disjunction of one or
+// more conjunctive queries.
+func (q *Query) Expr() syntax.Expr {
 	if q == nil {
 		return nil
 	}
-	return q.ast
+	return q.expr
 }
 
 // A condition is a compiled match condition.  A condition matches an event if
 // the event has the designated type, contains an attribute with the given
-// name, and the match function returns true for the attribute value.
+// name, and the match function returns true for the attribute value. A negated
+// condition matches a collection of events if the condition does not match
+// any of them.
 type condition struct {
 	tag   string // e.g., "tx.hash"
 	match func(s string) bool
+	not   bool
 }
 
 // findAttr returns a slice of attribute values from event matching the
@@ -157,7 +199,7 @@ func (c condition) matchesEvent(event types.Event) bool {
 }
 
 func compileCondition(cond syntax.Condition) (condition, error) {
-	out := condition{tag: cond.Tag}
+	out := condition{tag: cond.Tag, not: cond.Not}
 
 	// Handle existence checks separately to simplify the logic below for
 	// comparisons that take arguments.
@@ -89,6 +89,27 @@ func TestCompiledMatches(t *testing.T) {
 		{`tx.gas > 7 AND tx.gas < 9`,
 			newTestEvents(`tx|gas=8`),
 			true},
+		{`tx.gas > 10 OR tx.gas < 9`,
+			newTestEvents(`tx|gas=8`),
+			true},
+		{`tx.gas > 10 OR tx.gas < 5`,
+			newTestEvents(`tx|gas=8`),
+			false},
+		{`tx.gas > 7 AND tx.gas < 8 OR tx.gas = 8`,
+			newTestEvents(`tx|gas=8`),
+			true},
+		{`NOT tx.gas > 7`,
+			newTestEvents(`tx|gas=8`),
+			false},
+		{`NOT tx.gas > 9`,
+			newTestEvents(`tx|gas=8`),
+			true},
+		{`tx.gas EXISTS AND NOT tx.fee EXISTS`,
+			newTestEvents(`tx|gas=8`),
+			true},
+		{`NOT transfer.sender = 'spam' OR tx.gas = 1`,
+			newTestEvents(`transfer|sender=spam`, `tx|gas=8`),
+			false},
 		{`body.weight >= 3.5`,
 			newTestEvents(`body|weight=3.5`),
 			true},
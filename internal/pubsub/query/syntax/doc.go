@@ -5,15 +5,19 @@
 //
 // The grammar of the query language is defined by the following EBNF:
 //
-//   query      = conditions EOF
+//   query      = conditions {"OR" conditions} EOF
 //   conditions = condition {"AND" condition}
-//   condition  = tag comparison
+//   condition  = ["NOT"] tag comparison
 //   comparison = equal / order / contains / "EXISTS"
 //   equal      = "=" (date / number / time / value)
 //   order      = cmp (date / number / time)
 //   contains   = "CONTAINS" value
 //   cmp        = "<" / "<=" / ">" / ">="
 //
+// AND binds more tightly than OR, so "a = 1 AND b = 2 OR c = 3" selects events
+// matching both of the first two conditions, or the third. NOT negates only
+// the single condition it precedes.
+//
 // The lexical terms are defined here using RE2 regular expression notation:
 //
 //   // The name of an event attribute (type.value)
@@ -9,12 +9,20 @@ import (
 	"time"
 )
 
-// Parse parses the specified query string. It is shorthand for constructing a
-// parser for s and calling its Parse method.
+// Parse parses the specified query string, which must not contain any OR
+// operators. It is shorthand for constructing a parser for s and calling its
+// Parse method.
 func Parse(s string) (Query, error) {
 	return NewParser(strings.NewReader(s)).Parse()
 }
 
+// ParseExpr parses the specified query string, which may contain OR operators.
+// It is shorthand for constructing a parser for s and calling its ParseExpr
+// method.
+func ParseExpr(s string) (Expr, error) {
+	return NewParser(strings.NewReader(s)).ParseExpr()
+}
+
 // Query is the root of the parse tree for a query.  A query is the conjunction
 // of one or more conditions.
 type Query []Condition
@@ -27,13 +35,27 @@ func (q Query) String() string {
 	return strings.Join(ss, " AND ")
 }
 
+// Expr is the root of the parse tree for a query that may contain OR
+// operators. An expression is the disjunction of one or more queries, each of
+// which is a conjunction of conditions.
+type Expr []Query
+
+func (e Expr) String() string {
+	ss := make([]string, len(e))
+	for i, q := range e {
+		ss[i] = q.String()
+	}
+	return strings.Join(ss, " OR ")
+}
+
 // A Condition is a single conditional expression, consisting of a tag, a
 // comparison operator, and an optional argument. The type of the argument
-// depends on the operator.
+// depends on the operator. If Not is true, the condition is negated.
 type Condition struct {
 	Tag string
 	Op  Token
 	Arg *Arg
+	Not bool
 
 	opText string
 }
@@ -41,7 +63,10 @@ type Condition struct {
 func (c Condition) String() string {
 	s := c.Tag + " " + c.opText
 	if c.Arg != nil {
-		return s + " " + c.Arg.String()
+		s += " " + c.Arg.String()
+	}
+	if c.Not {
+		return "NOT " + s
 	}
 	return s
 }
@@ -120,32 +145,59 @@ func NewParser(r io.Reader) *Parser {
 	return &Parser{scanner: NewScanner(r)}
 }
 
-// Parse parses the complete input and returns the resulting query.
+// Parse parses the complete input and returns the resulting query. It is an
+// error for the input to contain an OR operator; use ParseExpr to accept them.
 func (p *Parser) Parse() (Query, error) {
+	expr, err := p.ParseExpr()
+	if err != nil {
+		return nil, err
+	} else if len(expr) != 1 {
+		return nil, fmt.Errorf("query has %d alternatives, %v is not supported here", len(expr), TOr)
+	}
+	return expr[0], nil
+}
+
+// ParseExpr parses the complete input and returns the resulting expression.
+func (p *Parser) ParseExpr() (Expr, error) {
 	cond, err := p.parseCond()
 	if err != nil {
 		return nil, err
 	}
-	conds := []Condition{cond}
+	expr := Expr{{cond}}
 	for p.scanner.Next() != io.EOF {
-		if tok := p.scanner.Token(); tok != TAnd {
-			return nil, fmt.Errorf("offset %d: got %v, want %v", p.scanner.Pos(), tok, TAnd)
+		switch tok := p.scanner.Token(); tok {
+		case TAnd:
+			cond, err := p.parseCond()
+			if err != nil {
+				return nil, err
+			}
+			last := len(expr) - 1
+			expr[last] = append(expr[last], cond)
+		case TOr:
+			cond, err := p.parseCond()
+			if err != nil {
+				return nil, err
+			}
+			expr = append(expr, Query{cond})
+		default:
+			return nil, fmt.Errorf("offset %d: got %v, wanted %s", p.scanner.Pos(), tok, tokLabel([]Token{TAnd, TOr}))
 		}
-		cond, err := p.parseCond()
-		if err != nil {
-			return nil, err
-		}
-		conds = append(conds, cond)
 	}
-	return conds, nil
+	return expr, nil
 }
 
-// parseCond parses a conditional expression: tag OP value.
+// parseCond parses a conditional expression: [NOT] tag OP value.
 func (p *Parser) parseCond() (Condition, error) {
 	var cond Condition
-	if err := p.require(TTag); err != nil {
+	if err := p.require(TTag, TNot); err != nil {
 		return cond, err
 	}
+	if p.scanner.Token() == TNot {
+		cond.Not = true
+		if err := p.require(TTag); err != nil {
+			return cond, err
+		}
+	}
 	cond.Tag = p.scanner.Text()
 	if err := p.require(TLeq, TGeq, TLt, TGt, TEq, TContains, TExists); err != nil {
 		return cond, err
@@ -28,6 +28,8 @@ const (
 	TLeq             // operator: <=
 	TGt              // operator: >
 	TGeq             // operator: >=
+	TOr              // operator: OR
+	TNot             // operator: NOT
 
 	// Do not reorder these values without updating the scanner code.
 )
@@ -47,6 +49,8 @@ var tString = [...]string{
 	TLeq:      "<= operator",
 	TGt:       "> operator",
 	TGeq:      ">= operator",
+	TOr:       "OR operator",
+	TNot:      "NOT operator",
 }
 
 func (t Token) String() string {
@@ -224,6 +228,10 @@ func (s *Scanner) scanTagLike(first rune) error {
 		s.tok = TTag
 	case "AND":
 		s.tok = TAnd
+	case "OR":
+		s.tok = TOr
+	case "NOT":
+		s.tok = TNot
 	case "EXISTS":
 		s.tok = TExists
 	case "CONTAINS":
@@ -1,6 +1,7 @@
 package syntax_test
 
 import (
+	"fmt"
 	"io"
 	"reflect"
 	"strings"
@@ -167,6 +168,13 @@ func TestParseValid(t *testing.T) {
 
 		{"hash='136E18F7E4C348B780CF873A0BF43922E5BAFA63'", true},
 		{"hash=136E18F7E4C348B780CF873A0BF43922E5BAFA63", false},
+
+		{"NOT account.balance=100", true},
+		{"slashing.amount EXISTS AND NOT account.balance=100", true},
+		{"NOT NOT account.balance=100", false},
+		{"account.balance NOT =100", false},
+		{"account.balance=100 NOT", false},
+		{"account.balance=100 OR slashing.amount EXISTS", false}, // Parse rejects OR
 	}
 
 	for _, test := range tests {
@@ -188,3 +196,50 @@ func TestParseValid(t *testing.T) {
 		}
 	}
 }
+
+func TestParseExpr(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []int // number of conditions in each alternative; nil if invalid
+	}{
+		{"a.b = 1", []int{1}},
+		{"a.b = 1 AND c.d = 2", []int{2}},
+		{"a.b = 1 OR c.d = 2", []int{1, 1}},
+		{"a.b = 1 AND c.d = 2 OR e.f EXISTS", []int{2, 1}},
+		{"a.b = 1 OR c.d = 2 AND NOT e.f EXISTS", []int{1, 2}},
+		{"NOT a.b = 1 OR NOT c.d <= 2", []int{1, 1}},
+		{"a.b = 1 OR", nil},
+		{"OR a.b = 1", nil},
+		{"a.b = 1 OR OR c.d = 2", nil},
+		{"a.b = 1 AND OR c.d = 2", nil},
+	}
+	for _, test := range tests {
+		expr, err := syntax.ParseExpr(test.input)
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("ParseExpr %#q: got %v, want error", test.input, expr)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("ParseExpr %#q: unexpected error: %v", test.input, err)
+			continue
+		}
+
+		var got []int
+		for _, q := range expr {
+			got = append(got, len(q))
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("ParseExpr %#q: got shape %v, want %v", test.input, got, test.want)
+		}
+
+		// Check that the expression round-trips.
+		estr := expr.String()
+		r, err := syntax.ParseExpr(estr)
+		if err != nil {
+			t.Errorf("Reparse %#q failed: %v", estr, err)
+		} else if rstr := r.String(); rstr != estr {
+			t.Errorf("Reparse diff\nold: %#q\nnew: %#q", estr, rstr)
+		}
+	}
+}
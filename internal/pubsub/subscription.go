@@ -22,13 +22,16 @@ var (
 
 // A Subscription represents a client subscription for a particular query.
 type Subscription struct {
-	id      string
-	queue   *queue.Queue // open until the subscription ends
-	stopErr error        // after queue is closed, the reason why
+	id       string
+	queue    *queue.Queue // open until the subscription ends
+	overflow OverflowPolicy
+	stopErr  error // after queue is closed, the reason why
+	startSeq int64 // the sequence number of the last message before s
 }
 
-// newSubscription returns a new subscription with the given queue capacity.
-func newSubscription(quota, limit int) (*Subscription, error) {
+// newSubscription returns a new subscription with the given queue capacity
+// and overflow policy.
+func newSubscription(quota, limit int, overflow OverflowPolicy) (*Subscription, error) {
 	queue, err := queue.New(queue.Options{
 		SoftQuota: quota,
 		HardLimit: limit,
@@ -37,8 +40,9 @@ func newSubscription(quota, limit int) (*Subscription, error) {
 		return nil, err
 	}
 	return &Subscription{
-		id:    uuid.NewString(),
-		queue: queue,
+		id:       uuid.NewString(),
+		queue:    queue,
+		overflow: overflow,
 	}, nil
 }
 
@@ -59,9 +63,31 @@ func (s *Subscription) Next(ctx context.Context) (Message, error) {
 // ID returns the unique subscription identifier for s.
 func (s *Subscription) ID() string { return s.id }
 
+// StartSeq returns the sequence number of the last message published before s
+// was created. s receives the matching messages with greater sequence numbers.
+func (s *Subscription) StartSeq() int64 { return s.startSeq }
+
 // publish transmits msg to the subscriber. It reports a queue error if the
-// queue cannot accept any further messages.
-func (s *Subscription) publish(msg Message) error { return s.queue.Add(msg) }
+// queue cannot accept any further messages and the overflow policy of the
+// subscription is Disconnect, or if the queue is closed.
+func (s *Subscription) publish(msg Message) error {
+	err := s.queue.Add(msg)
+	if err == nil || errors.Is(err, queue.ErrQueueClosed) {
+		return err
+	}
+
+	switch s.overflow {
+	case DropOldest:
+		s.queue.Remove()
+		// If the queue is still over quota, msg is dropped as well.
+		_ = s.queue.Add(msg)
+		return nil
+	case DropNewest:
+		return nil
+	default:
+		return err
+	}
+}
 
 // stop terminates the subscription with the given error reason.
 func (s *Subscription) stop(err error) {
@@ -75,6 +101,7 @@ func (s *Subscription) stop(err error) {
 // Message glues data and events together.
 type Message struct {
 	subID  string
+	seq    int64
 	data   types.EventData
 	events []abci.Event
 }
@@ -83,6 +110,11 @@ type Message struct {
 // that produced this message.
 func (msg Message) SubscriptionID() string { return msg.subID }
 
+// Seq returns the sequence number assigned to the message by the server.
+// Sequence numbers start at 1 and increase by one with each message published,
+// whether or not it matched the subscription.
+func (msg Message) Seq() int64 { return msg.seq }
+
 // Data returns an original data published.
 func (msg Message) Data() types.EventData { return msg.data }
 
@@ -3,7 +3,6 @@ package core
 import (
 	"context"
 	"fmt"
-	"sort"
 
 	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
 	"github.com/tendermint/tendermint/internal/state/indexer"
@@ -181,7 +180,7 @@ func (env *Environment) Commit(ctx context.Context, req *coretypes.RequestBlockI
 //
 // Results are for the height of the block containing the txs.
 // More: https://docs.tendermint.com/master/rpc/#/Info/block_results
-func (env *Environment) BlockResults(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultBlockResults, error) {
+func (env *Environment) BlockResults(ctx context.Context, req *coretypes.RequestBlockResults) (*coretypes.ResultBlockResults, error) {
 	height, err := env.getHeight(env.BlockStore.Height(), (*int64)(req.Height))
 	if err != nil {
 		return nil, err
@@ -197,14 +196,18 @@ func (env *Environment) BlockResults(ctx context.Context, req *coretypes.Request
 		totalGasUsed += res.GetGasUsed()
 	}
 
-	return &coretypes.ResultBlockResults{
+	res := &coretypes.ResultBlockResults{
 		Height:                height,
 		TxsResults:            results.TxResults,
 		TotalGasUsed:          totalGasUsed,
 		FinalizeBlockEvents:   results.Events,
 		ValidatorUpdates:      results.ValidatorUpdates,
 		ConsensusParamUpdates: results.ConsensusParamUpdates,
-	}, nil
+	}
+	if err := res.ApplyArgs(req); err != nil {
+		return nil, err
+	}
+	return res, nil
 }
 
 // BlockSearch searches for a paginated set of blocks matching the provided query.
@@ -225,38 +228,28 @@ func (env *Environment) BlockSearch(ctx context.Context, req *coretypes.RequestB
 		}
 	}
 
-	results, err := kvsink.SearchBlockEvents(ctx, q)
+	perPage := env.validatePerPage(req.PerPage.IntPtr())
+	pageReq, skipCount, err := searchPageRequest(req.Page.IntPtr(), perPage, req.OrderBy, req.Cursor)
 	if err != nil {
 		return nil, err
 	}
 
-	// sort results (must be done before pagination)
-	switch req.OrderBy {
-	case "desc", "":
-		sort.Slice(results, func(i, j int) bool { return results[i] > results[j] })
-
-	case "asc":
-		sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
-
-	default:
-		return nil, fmt.Errorf("expected order_by to be either `asc` or `desc` or empty: %w", coretypes.ErrInvalidRequest)
-	}
-
-	// paginate results
-	totalCount := len(results)
-	perPage := env.validatePerPage(req.PerPage.IntPtr())
-
-	page, err := validatePage(req.Page.IntPtr(), perPage, totalCount)
+	bp, err := indexer.SearchBlockPage(ctx, kvsink, q, pageReq)
 	if err != nil {
 		return nil, err
 	}
 
-	skipCount := validateSkipCount(page, perPage)
-	pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
+	// paginate results
+	if req.Cursor == "" {
+		if _, err := validatePage(req.Page.IntPtr(), perPage, bp.Total); err != nil {
+			return nil, err
+		}
+	}
+	results := bp.Heights[tmmath.MinInt(skipCount, len(bp.Heights)):]
 
-	apiResults := make([]*coretypes.ResultBlock, 0, pageSize)
-	for i := skipCount; i < skipCount+pageSize; i++ {
-		block := env.BlockStore.LoadBlock(results[i])
+	apiResults := make([]*coretypes.ResultBlock, 0, len(results))
+	for _, height := range results {
+		block := env.BlockStore.LoadBlock(height)
 		if block != nil {
 			blockMeta := env.BlockStore.LoadBlockMeta(block.Height)
 			if blockMeta != nil {
@@ -268,5 +261,9 @@ func (env *Environment) BlockSearch(ctx context.Context, req *coretypes.RequestB
 		}
 	}
 
-	return &coretypes.ResultBlockSearch{Blocks: apiResults, TotalCount: totalCount}, nil
+	res := &coretypes.ResultBlockSearch{Blocks: apiResults, TotalCount: bp.Total}
+	if bp.Next != nil {
+		res.NextCursor = bp.Next.String()
+	}
+	return res, nil
 }
@@ -71,10 +71,13 @@ func TestBlockchainInfo(t *testing.T) {
 func TestBlockResults(t *testing.T) {
 	results := &abci.ResponseFinalizeBlock{
 		TxResults: []*abci.ExecTxResult{
-			{Code: 0, Data: []byte{0x01}, Log: "ok", GasUsed: 10},
-			{Code: 0, Data: []byte{0x02}, Log: "ok", GasUsed: 5},
+			{Code: 0, Data: []byte{0x01}, Log: "ok", GasUsed: 10, Events: []abci.Event{
+				transferEvent("alice", "5"), {Type: "message", Attributes: []abci.EventAttribute{{Key: "action", Value: "send"}}},
+			}},
+			{Code: 0, Data: []byte{0x02}, Log: "ok", GasUsed: 5, Events: []abci.Event{transferEvent("bob", "50")}},
 			{Code: 1, Log: "not ok", GasUsed: 0},
 		},
+		Events: []abci.Event{transferEvent("fees", "1")},
 	}
 
 	env := &Environment{}
@@ -106,7 +109,7 @@ func TestBlockResults(t *testing.T) {
 
 	ctx := context.Background()
 	for _, tc := range testCases {
-		res, err := env.BlockResults(ctx, &coretypes.RequestBlockInfo{
+		res, err := env.BlockResults(ctx, &coretypes.RequestBlockResults{
 			Height: (*coretypes.Int64)(&tc.height),
 		})
 		if tc.wantErr {
@@ -116,4 +119,53 @@ func TestBlockResults(t *testing.T) {
 			assert.Equal(t, tc.wantRes, res)
 		}
 	}
+
+	// the encoded events are returned if requested
+	height := coretypes.Int64(100)
+	res, err := env.BlockResults(ctx, &coretypes.RequestBlockResults{Height: &height, Events: true})
+	require.NoError(t, err)
+	assert.Equal(t, coretypes.NewBlockEvents(results.Events, results.TxResults), res.Events)
+
+	// only the events matching match_events are returned
+	res, err = env.BlockResults(ctx, &coretypes.RequestBlockResults{
+		Height:      &height,
+		MatchEvents: "transfer.amount > 2",
+		Events:      true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res.FinalizeBlockEvents)
+	require.Len(t, res.TxsResults, 3)
+	assert.Equal(t, []abci.Event{transferEvent("alice", "5")}, res.TxsResults[0].Events)
+	assert.Equal(t, []abci.Event{transferEvent("bob", "50")}, res.TxsResults[1].Events)
+	assert.Empty(t, res.TxsResults[2].Events)
+	assert.Equal(t, results.TxResults[0].Data, res.TxsResults[0].Data)
+	assert.Len(t, results.TxResults[0].Events, 2, "stored results unchanged")
+	assert.Equal(t, coretypes.NewBlockEvents(nil, res.TxsResults), res.Events)
+
+	_, err = env.BlockResults(ctx, &coretypes.RequestBlockResults{Height: &height, MatchEvents: "transfer.amount >"})
+	assert.Error(t, err)
+
+	// the matching events are also returned by composite key if requested
+	assert.Nil(t, res.CompositeEvents)
+	res, err = env.BlockResults(ctx, &coretypes.RequestBlockResults{
+		Height:          &height,
+		MatchEvents:     "transfer.amount > 2",
+		CompositeEvents: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &coretypes.CompositeBlockEvents{
+		FinalizeBlock: map[string][]string{},
+		Txs: []map[string][]string{
+			{"transfer.sender": {"alice"}, "transfer.amount": {"5"}},
+			{"transfer.sender": {"bob"}, "transfer.amount": {"50"}},
+			{},
+		},
+	}, res.CompositeEvents)
+}
+
+func transferEvent(sender, amount string) abci.Event {
+	return abci.Event{Type: "transfer", Attributes: []abci.EventAttribute{
+		{Key: "sender", Value: sender, Index: true},
+		{Key: "amount", Value: amount},
+	}}
 }
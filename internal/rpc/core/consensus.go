@@ -2,6 +2,9 @@ package core
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
 
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/rpc/coretypes"
@@ -9,14 +12,21 @@ import (
 
 // Validators gets the validator set at the given block height.
 //
-// If no height is provided, it will fetch the latest validator set. Note the
+// If no height is provided, it will fetch the validator set of the block being
+// committed. The validator set of the block after it can be fetched too. Note the
 // validators are sorted by their voting power - this is the canonical order
 // for the validators in the set as used in computing their Merkle root.
 //
 // More: https://docs.tendermint.com/master/rpc/#/Info/validators
 func (env *Environment) Validators(ctx context.Context, req *coretypes.RequestValidators) (*coretypes.ResultValidators, error) {
-	// The latest validator that we know is the NextValidator of the last block.
-	height, err := env.getHeight(env.latestUncommittedHeight(), (*int64)(req.Height))
+	// The latest validator that we know is the NextValidator of the last block,
+	// but by default those of the block being committed are returned.
+	heightPtr := (*int64)(req.Height)
+	if heightPtr == nil {
+		latest := env.latestUncommittedHeight()
+		heightPtr = &latest
+	}
+	height, err := env.getHeight(env.latestValidatorsHeight(), heightPtr)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +55,68 @@ func (env *Environment) Validators(ctx context.Context, req *coretypes.RequestVa
 	}, nil
 }
 
+// ValidatorHistory gets the voting power of a validator at the height from and
+// each of its changes up to the height to, with aggregates over the range for
+// uptime and slashing analytics. To defaults to the height of the block being
+// committed and from to to, so that by default the current power is returned.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/validator_history
+func (env *Environment) ValidatorHistory(ctx context.Context, req *coretypes.RequestValidatorHistory) (*coretypes.ResultValidatorHistory, error) {
+	if len(req.Address) != crypto.AddressSize {
+		return nil, fmt.Errorf("invalid validator address %X: expected %d bytes", req.Address, crypto.AddressSize)
+	}
+	latest := env.latestValidatorsHeight()
+	to := env.latestUncommittedHeight()
+	if req.To != nil {
+		to = int64(*req.To)
+	}
+	from := to
+	if req.From != nil {
+		from = int64(*req.From)
+	}
+	if from <= 0 {
+		return nil, fmt.Errorf("%w (requested height: %d)", coretypes.ErrZeroOrNegativeHeight, from)
+	}
+	if to > latest {
+		return nil, fmt.Errorf("%w (requested height: %d, blockchain height: %d)",
+			coretypes.ErrHeightExceedsChainHead, to, latest)
+	}
+	if from > to {
+		return nil, fmt.Errorf("from height %d is greater than to height %d", from, to)
+	}
+
+	powers, err := env.StateStore.LoadValidatorPowers(req.Address, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &coretypes.ResultValidatorHistory{
+		Address:    req.Address,
+		FromHeight: from,
+		ToHeight:   to,
+		Powers:     make([]coretypes.ValidatorPower, len(powers)),
+		MinPower:   powers[0].Power,
+		MaxPower:   powers[0].Power,
+	}
+	for i, power := range powers {
+		result.Powers[i] = coretypes.ValidatorPower{Height: power.Height, Power: power.Power}
+		end := to + 1
+		if i+1 < len(powers) {
+			end = powers[i+1].Height
+		}
+		if power.Power > 0 {
+			result.ActiveHeights += end - power.Height
+		}
+		if power.Power < result.MinPower {
+			result.MinPower = power.Power
+		}
+		if power.Power > result.MaxPower {
+			result.MaxPower = power.Power
+		}
+	}
+	return result, nil
+}
+
 // DumpConsensusState dumps consensus state.
 // UNSTABLE
 // More: https://docs.tendermint.com/master/rpc/#/Info/dump_consensus_state
@@ -81,8 +153,13 @@ func (env *Environment) DumpConsensusState(ctx context.Context) (*coretypes.Resu
 	if err != nil {
 		return nil, err
 	}
+	roundVotes, err := env.ConsensusState.GetRoundVotesJSON()
+	if err != nil {
+		return nil, err
+	}
 	return &coretypes.ResultDumpConsensusState{
 		RoundState: roundState,
+		RoundVotes: roundVotes,
 		Peers:      peerStates,
 	}, nil
 }
@@ -93,7 +170,14 @@ func (env *Environment) DumpConsensusState(ctx context.Context) (*coretypes.Resu
 func (env *Environment) GetConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error) {
 	// Get self round state.
 	bz, err := env.ConsensusState.GetRoundStateSimpleJSON()
-	return &coretypes.ResultConsensusState{RoundState: bz}, err
+	if err != nil {
+		return nil, err
+	}
+	votes, err := env.ConsensusState.GetRoundVotesJSON()
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultConsensusState{RoundState: bz, RoundVotes: votes}, nil
 }
 
 // ConsensusParams gets the consensus parameters at the given block height.
@@ -119,3 +203,50 @@ func (env *Environment) ConsensusParams(ctx context.Context, req *coretypes.Requ
 		BlockHeight:     height,
 		ConsensusParams: consensusParams}, nil
 }
+
+// ConsensusParamsHistory gets the consensus params at the height from,
+// followed by each change of the params up to the height to, e.g. to audit
+// the limits which applied at any height. To defaults to the latest height
+// and from to to, so that by default the current params are returned. The
+// heights whose states were pruned are not available.
+//
+// More: https://docs.tendermint.com/master/rpc/#/Info/consensus_params_history
+func (env *Environment) ConsensusParamsHistory(ctx context.Context, req *coretypes.RequestConsensusParamsHistory) (*coretypes.ResultConsensusParamsHistory, error) {
+	latest := env.latestUncommittedHeight()
+	to := latest
+	if req.To != nil {
+		to = int64(*req.To)
+	}
+	from := to
+	if req.From != nil {
+		from = int64(*req.From)
+	}
+	if from <= 0 {
+		return nil, fmt.Errorf("%w (requested height: %d)", coretypes.ErrZeroOrNegativeHeight, from)
+	}
+	if to > latest {
+		return nil, fmt.Errorf("%w (requested height: %d, blockchain height: %d)",
+			coretypes.ErrHeightExceedsChainHead, to, latest)
+	}
+	if from > to {
+		return nil, fmt.Errorf("from height %d is greater than to height %d", from, to)
+	}
+
+	changes, err := env.StateStore.LoadConsensusParamsHistory(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &coretypes.ResultConsensusParamsHistory{
+		FromHeight: from,
+		ToHeight:   to,
+		Changes:    make([]coretypes.ConsensusParamsChange, len(changes)),
+	}
+	for i, change := range changes {
+		params := change.Params
+		params.Synchrony = params.Synchrony.SynchronyParamsOrDefaults()
+		params.Timeout = params.Timeout.TimeoutParamsOrDefaults()
+		result.Changes[i] = coretypes.ConsensusParamsChange{Height: change.Height, ConsensusParams: params}
+	}
+	return result, nil
+}
@@ -2,7 +2,11 @@ package core
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/tendermint/tendermint/internal/consensus"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 )
 
@@ -11,3 +15,70 @@ func (env *Environment) UnsafeFlushMempool(ctx context.Context) (*coretypes.Resu
 	env.Mempool.Flush()
 	return &coretypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeReloadPeerFilter reloads the allowed and blocked peers from the config
+// file, and disconnects the connected peers they reject.
+// More: https://docs.tendermint.com/master/rpc/#/Unsafe/unsafe_reload_peer_filter
+func (env *Environment) UnsafeReloadPeerFilter(ctx context.Context) (*coretypes.ResultUnsafeReloadPeerFilter, error) {
+	if env.PeerFilter == nil {
+		return nil, errors.New("peer filter is not available")
+	}
+	if err := env.PeerFilter.ReloadPeerFilter(); err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultUnsafeReloadPeerFilter{}, nil
+}
+
+// UnsafeReloadConfig reloads the settings which don't take a restart from the
+// config file, like on SIGHUP, and reports the changed settings applied and
+// those which take a restart. If it fails part way, the error reports the
+// settings applied until then.
+// More: https://docs.tendermint.com/master/rpc/#/Unsafe/unsafe_reload_config
+func (env *Environment) UnsafeReloadConfig(ctx context.Context) (*coretypes.ResultReloadConfig, error) {
+	if env.ConfigReloader == nil {
+		return nil, errors.New("config reloader is not available")
+	}
+	applied, requireRestart, err := env.ConfigReloader.ReloadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("%w (applied: %v, require restart: %v)", err, applied, requireRestart)
+	}
+	return &coretypes.ResultReloadConfig{Applied: applied, RequireRestart: requireRestart}, nil
+}
+
+// RemoveTx removes the transaction with the given key from the mempool. The
+// transaction is kept in the mempool cache, so it is not accepted again if it
+// is resubmitted or gossiped back to the node.
+// More: https://docs.tendermint.com/master/rpc/#/Unsafe/remove_tx
+func (env *Environment) RemoveTx(ctx context.Context, req *coretypes.RequestRemoveTx) error {
+	return env.Mempool.RemoveTxByKey(req.TxKey)
+}
+
+// UnsafeSetHalt sets the height and time, in Unix seconds, of the planned halt
+// of consensus, until the node restarts. Zero unsets them.
+// More: https://docs.tendermint.com/master/rpc/#/Unsafe/unsafe_set_halt
+func (env *Environment) UnsafeSetHalt(ctx context.Context, req *coretypes.RequestSetHalt) (*coretypes.ResultSetHalt, error) {
+	if env.ConsensusState == nil {
+		return nil, errors.New("consensus is not available")
+	}
+	if req.Height < 0 || req.Time < 0 {
+		return nil, errors.New("height and time can't be negative")
+	}
+	plan := consensus.HaltPlan{Height: int64(req.Height)}
+	if req.Time > 0 {
+		plan.Time = time.Unix(int64(req.Time), 0).UTC()
+	}
+	if err := env.ConsensusState.SetHaltPlan(plan); err != nil {
+		return nil, err
+	}
+	return &coretypes.ResultSetHalt{HaltPlan: *haltPlanInfo(plan)}, nil
+}
+
+// haltPlanInfo returns the info about the halt plan.
+func haltPlanInfo(plan consensus.HaltPlan) *coretypes.HaltPlanInfo {
+	info := &coretypes.HaltPlanInfo{Height: plan.Height}
+	if !plan.Time.IsZero() {
+		t := plan.Time
+		info.Time = &t
+	}
+	return info
+}
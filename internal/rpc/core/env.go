@@ -2,34 +2,45 @@ package core
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/rs/cors"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/blocksync"
 	"github.com/tendermint/tendermint/internal/consensus"
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/eventlog"
-	"github.com/tendermint/tendermint/internal/libs/strings"
+	tmstrings "github.com/tendermint/tendermint/internal/libs/strings"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/p2p/pex"
 	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
 	"github.com/tendermint/tendermint/internal/pubsub/query"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/statesync"
+	"github.com/tendermint/tendermint/internal/trace"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	coregrpc "github.com/tendermint/tendermint/rpc/grpc"
 	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
 )
 
 const (
@@ -53,13 +64,47 @@ type consensusState interface {
 	GetState() sm.State
 	GetValidators() (int64, []*types.Validator)
 	GetLastHeight() int64
+	GetRoundState() *cstypes.RoundState
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetRoundVotesJSON() ([]byte, error)
+	GetHaltRecord() *consensus.HaltRecord
+	GetHaltPlan() consensus.HaltPlan
+	SetHaltPlan(consensus.HaltPlan) error
 }
 
 type peerManager interface {
 	Peers() []types.NodeID
 	Addresses(types.NodeID) []p2p.NodeAddress
+	AddressBook() []p2p.AddressBookEntry
+	ChannelVersions(types.NodeID) map[p2p.ChannelID]uint32
+}
+
+type externalAddressDetector interface {
+	ExternalAddress() (address, source string)
+}
+
+type networkTopology interface {
+	Nodes() []pex.TopologyNode
+	Versions() map[string]int
+}
+
+type peerFilter interface {
+	ReloadPeerFilter() error
+}
+
+type configReloader interface {
+	ReloadConfig() (applied, requireRestart []string, err error)
+}
+
+type storageAuditor interface {
+	LastReport() *sm.AuditReport
+}
+
+type evidenceAuditor interface {
+	Size() uint32
+	PendingEvidencePage(skip, limit int) ([]types.Evidence, error)
+	AddConflictingVotes(ctx context.Context, voteA, voteB *types.Vote) (*types.DuplicateVoteEvidence, error)
 }
 
 //----------------------------------------------
@@ -73,6 +118,7 @@ type Environment struct {
 	StateStore       sm.Store
 	BlockStore       sm.BlockStore
 	EvidencePool     sm.EvidencePool
+	EvidenceAuditor  evidenceAuditor
 	ConsensusState   consensusState
 	ConsensusReactor *consensus.Reactor
 	BlockSyncReactor *blocksync.Reactor
@@ -82,10 +128,20 @@ type Environment struct {
 	NodeInfo    types.NodeInfo
 
 	// interfaces for new p2p interfaces
-	PeerManager peerManager
+	PeerManager     peerManager
+	ExternalAddress externalAddressDetector
+	PeerFilter      peerFilter
+	Topology        networkTopology
+
+	// reloads the settings that don't take a restart from the config file
+	ConfigReloader configReloader
+
+	// audits the block and state stores, or nil if disabled
+	StorageAuditor storageAuditor
 
 	// objects
 	PubKey            crypto.PubKey
+	PrivValidator     types.PrivValidator
 	GenDoc            *types.GenesisDoc // cache the genesis structure
 	EventSinks        []indexer.EventSink
 	EventBus          *eventbus.EventBus // thread safe
@@ -95,10 +151,26 @@ type Environment struct {
 
 	Logger log.Logger
 
+	// records a span of each call, or nil
+	Tracer *trace.Tracer
+
 	Config config.RPCConfig
 
-	// cache of chunked genesis data.
-	genChunks []string
+	// the JSON encoding of the genesis document served in chunks, as parts
+	// referencing the app state of GenDoc rather than copying it, and their
+	// total size. genesisParts is nil until InitGenesisChunks is called.
+	genesisParts [][]byte
+	genesisSize  int
+
+	// recent events for resumed subscriptions, or nil if disabled.
+	replay *replayBuffer
+
+	// rate limit of the public listeners, or nil if disabled.
+	rateLimiter *rpcserver.RateLimiter
+
+	// probes the application for the health checks, or nil until the
+	// service is started.
+	abciProbe *abciProbe
 }
 
 //----------------------------------------------
@@ -125,6 +197,45 @@ func validatePage(pagePtr *int, perPage, totalCount int) (int, error) {
 	return page, nil
 }
 
+// searchPageRequest builds the indexer page request for a tx or block search
+// from the RPC pagination parameters. Results are selected either by page
+// number or, if cursor is set, as the perPage results following the cursor;
+// the two are mutually exclusive. The returned skip count is the number of
+// leading results of the page to discard, which is non-zero only when
+// paginating by page number.
+func searchPageRequest(pagePtr *int, perPage int, orderBy, cursor string) (indexer.PageRequest, int, error) {
+	req := indexer.PageRequest{Limit: perPage}
+	switch orderBy {
+	case "desc", "":
+		req.Descending = true
+	case "asc":
+	default:
+		return req, 0, fmt.Errorf("expected order_by to be either `asc` or `desc` or empty: %w", coretypes.ErrInvalidRequest)
+	}
+
+	if cursor == "" {
+		var skipCount int
+		if pagePtr != nil {
+			skipCount = validateSkipCount(*pagePtr, perPage)
+		}
+		req.Limit += skipCount
+		return req, skipCount, nil
+	}
+
+	if pagePtr != nil {
+		return req, 0, fmt.Errorf("page and cursor cannot both be set: %w", coretypes.ErrInvalidRequest)
+	}
+	c, err := indexer.ParseCursor(cursor)
+	if err != nil {
+		return req, 0, fmt.Errorf("%v: %w", err, coretypes.ErrInvalidRequest)
+	}
+	if c.Descending != req.Descending {
+		return req, 0, fmt.Errorf("cursor was issued for a different order_by: %w", coretypes.ErrInvalidRequest)
+	}
+	req.After = &c
+	return req, 0, nil
+}
+
 func (env *Environment) validatePerPage(perPagePtr *int) int {
 	if perPagePtr == nil { // no per_page parameter
 		return defaultPerPage
@@ -144,7 +255,7 @@ func (env *Environment) validatePerPage(perPagePtr *int) int {
 // InitGenesisChunks configures the environment and should be called on service
 // startup.
 func (env *Environment) InitGenesisChunks() error {
-	if env.genChunks != nil {
+	if env.genesisParts != nil {
 		return nil
 	}
 
@@ -152,22 +263,58 @@ func (env *Environment) InitGenesisChunks() error {
 		return nil
 	}
 
-	data, err := json.Marshal(env.GenDoc)
+	// The app state, which can be huge, is the last field of the encoding of
+	// the genesis document: encode the other fields, and add the app state as
+	// is rather than copying it.
+	doc := *env.GenDoc
+	appState := doc.AppState
+	doc.AppState = nil
+	data, err := json.Marshal(doc)
 	if err != nil {
 		return err
 	}
+	if len(appState) == 0 {
+		env.genesisParts = [][]byte{data}
+	} else {
+		env.genesisParts = [][]byte{data[:len(data)-1], []byte(`,"app_state":`), appState, []byte("}")}
+	}
+	for _, part := range env.genesisParts {
+		env.genesisSize += len(part)
+	}
 
-	for i := 0; i < len(data); i += genesisChunkSize {
-		end := i + genesisChunkSize
+	return nil
+}
 
-		if end > len(data) {
-			end = len(data)
-		}
+// genesisChunks returns the number of chunks of the genesis document.
+func (env *Environment) genesisChunks() int {
+	return (env.genesisSize + genesisChunkSize - 1) / genesisChunkSize
+}
 
-		env.genChunks = append(env.genChunks, base64.StdEncoding.EncodeToString(data[i:end]))
+// genesisChunk returns the base64 encoding of the given chunk of the genesis
+// document.
+func (env *Environment) genesisChunk(id int) string {
+	start := id * genesisChunkSize
+	end := start + genesisChunkSize
+	if end > env.genesisSize {
+		end = env.genesisSize
 	}
 
-	return nil
+	chunk := make([]byte, 0, end-start)
+	offset := 0
+	for _, part := range env.genesisParts {
+		if offset+len(part) > start && offset < end {
+			from, to := start-offset, end-offset
+			if from < 0 {
+				from = 0
+			}
+			if to > len(part) {
+				to = len(part)
+			}
+			chunk = append(chunk, part[from:to]...)
+		}
+		offset += len(part)
+	}
+	return base64.StdEncoding.EncodeToString(chunk)
 }
 
 func validateSkipCount(page, perPage int) int {
@@ -211,6 +358,31 @@ func (env *Environment) latestUncommittedHeight() int64 {
 	return env.BlockStore.Height() + 1
 }
 
+// latestValidatorsHeight returns the greatest height whose validators are
+// known: those of the block after the one being committed are decided by the
+// latest block.
+func (env *Environment) latestValidatorsHeight() int64 {
+	height := env.latestUncommittedHeight()
+	if height > env.BlockStore.Height() {
+		return height + 1
+	}
+	return height
+}
+
+// p2pListeners describes the p2p listeners of the node in NetInfo, one per
+// external address.
+func p2pListeners(conf *config.P2PConfig) []string {
+	addrs := conf.ExternalAddresses()
+	if len(addrs) == 0 {
+		addrs = []string{""}
+	}
+	listeners := make([]string, len(addrs))
+	for i, addr := range addrs {
+		listeners[i] = fmt.Sprintf("Listener(@%v)", addr)
+	}
+	return listeners
+}
+
 // StartService constructs and starts listeners for the RPC service
 // according to the config object, returning an error if the service
 // cannot be constructed or started. The listeners, which provide
@@ -220,19 +392,28 @@ func (env *Environment) StartService(ctx context.Context, conf *config.Config) (
 		return nil, err
 	}
 
-	env.Listeners = []string{
-		fmt.Sprintf("Listener(@%v)", conf.P2P.ExternalAddress),
+	env.Listeners = p2pListeners(conf.P2P)
+
+	if env.ProxyApp != nil {
+		env.abciProbe = newABCIProbe(env.ProxyApp, conf.RPC.HealthABCITimeout)
+		go env.abciProbe.run(ctx)
 	}
 
-	listenAddrs := strings.SplitAndTrimEmpty(conf.RPC.ListenAddress, ",", " ")
+	listenAddrs := tmstrings.SplitAndTrimEmpty(conf.RPC.ListenAddress, ",", " ")
+	if err := checkMethods(env, conf.RPC.DisabledMethods); err != nil {
+		return nil, fmt.Errorf("invalid disabled-methods: %w", err)
+	}
+	// With a privileged listener, the unsafe methods are only served there.
 	routes := NewRoutesMap(env, &RouteOptions{
-		Unsafe: conf.RPC.Unsafe,
+		Unsafe:   conf.RPC.Unsafe && conf.RPC.PrivilegedListenAddress == "",
+		Disabled: conf.RPC.DisabledMethods,
 	})
 
 	cfg := rpcserver.DefaultConfig()
 	cfg.MaxBodyBytes = conf.RPC.MaxBodyBytes
 	cfg.MaxHeaderBytes = conf.RPC.MaxHeaderBytes
 	cfg.MaxOpenConnections = conf.RPC.MaxOpenConnections
+	setTLSAndHTTP2(cfg, conf.RPC)
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/tendermint/tendermint/issues/3435
@@ -276,79 +457,370 @@ func (env *Environment) StartService(ctx context.Context, conf *config.Config) (
 		env.Logger.Info("Event log subscription enabled")
 	}
 
+	// If subscription replay is enabled, retain the most recent events so
+	// that WebSocket clients can resume a subscription after reconnecting.
+	if size := conf.RPC.SubscriptionReplayWindow; size > 0 {
+		const subscriberID = "subscription-replay-subscriber"
+		sub, err := env.EventBus.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
+			ClientID: subscriberID,
+			Query:    query.All,
+			Limit:    1 << 16, // essentially "no limit"
+		})
+		if err != nil {
+			return nil, fmt.Errorf("subscription replay subscribe: %w", err)
+		}
+		env.replay = newReplayBuffer(size, sub)
+		go func() {
+			// N.B. Use background for unsubscribe, ctx is already terminated.
+			defer env.EventBus.UnsubscribeAll(context.Background(), subscriberID) // nolint:errcheck
+			for {
+				msg, err := sub.Next(ctx)
+				if err != nil {
+					env.Logger.Error("Subscription terminated", "err", err)
+					return
+				}
+				env.replay.add(msg)
+			}
+		}()
+
+		env.Logger.Info("Subscription replay enabled", "window", size)
+	}
+
+	// The clients of the public listeners share a single rate limit.
+	public := &listenerLimits{maxOpenConnectionsPerIP: conf.RPC.MaxOpenConnectionsPerIP}
+	if conf.RPC.RateLimit > 0 {
+		costs, err := conf.RPC.MethodCosts()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate-limit-method-costs: %w", err)
+		}
+		public.rateLimiter = rpcserver.NewRateLimiter(rpcserver.RateLimitConfig{
+			Rate:        conf.RPC.RateLimit,
+			Burst:       conf.RPC.RateLimitBurst,
+			MethodCosts: costs,
+			APIKeys:     conf.RPC.RateLimitAPIKeys,
+		})
+		env.rateLimiter = public.rateLimiter
+	}
+
 	// We may expose the RPC over both TCP and a Unix-domain socket.
-	listeners := make([]net.Listener, len(listenAddrs))
-	for i, listenAddr := range listenAddrs {
-		mux := http.NewServeMux()
-		rpcLogger := env.Logger.With("module", "rpc-server")
-		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
-
-		if conf.RPC.ExperimentalDisableWebsocket {
-			rpcLogger.Info("Disabling websocket endpoints (experimental-disable-websocket=true)")
-		} else {
-			rpcLogger.Info("WARNING: Websocket RPC access is deprecated and will be removed " +
-				"in Tendermint v0.37. See https://tinyurl.com/adr075 for more information.")
-			wmLogger := rpcLogger.With("protocol", "websocket")
-			wm := rpcserver.NewWebsocketManager(wmLogger, routes,
-				rpcserver.OnDisconnect(func(remoteAddr string) {
-					err := env.EventBus.UnsubscribeAll(context.Background(), remoteAddr)
-					if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
-						wmLogger.Error("Failed to unsubscribe addr from events", "addr", remoteAddr, "err", err)
-					}
-				}),
-				rpcserver.ReadLimit(cfg.MaxBodyBytes),
-			)
-			mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	listeners := make([]net.Listener, 0, len(listenAddrs)+1)
+	for _, listenAddr := range listenAddrs {
+		listener, err := env.serveRPC(ctx, conf, listenAddr, routes, cfg, public, nil)
+		if err != nil {
+			return nil, err
 		}
+		listeners = append(listeners, listener)
+	}
 
-		listener, err := rpcserver.Listen(
-			listenAddr,
-			cfg.MaxOpenConnections,
-		)
+	// The privileged listener serves all the methods, to authenticated
+	// clients only, without limits.
+	if addr := conf.RPC.PrivilegedListenAddress; addr != "" {
+		privilegedRoutes := NewRoutesMap(env, &RouteOptions{
+			Unsafe:   true,
+			Disabled: conf.RPC.DisabledMethods,
+		})
+		privilegedCfg := *cfg
+		if conf.RPC.PrivilegedTLSClientCAFile != "" {
+			pem, err := os.ReadFile(conf.RPC.PrivilegedClientCAFile())
+			if err != nil {
+				return nil, fmt.Errorf("reading privileged-tls-client-ca-file: %w", err)
+			}
+			privilegedCfg.ClientCAs = x509.NewCertPool()
+			if !privilegedCfg.ClientCAs.AppendCertsFromPEM(pem) {
+				return nil, errors.New("no certificates in privileged-tls-client-ca-file")
+			}
+		}
+		var auth func(http.Handler) http.Handler
+		if token := conf.RPC.PrivilegedAuthToken; token != "" {
+			auth = func(h http.Handler) http.Handler { return rpcserver.RequireBearerToken(token, h) }
+		}
+		listener, err := env.serveRPC(ctx, conf, addr, privilegedRoutes, &privilegedCfg, &listenerLimits{}, auth)
 		if err != nil {
 			return nil, err
 		}
+		listeners = append(listeners, listener)
+	}
 
-		var rootHandler http.Handler = mux
-		if conf.RPC.IsCorsEnabled() {
-			corsMiddleware := cors.New(cors.Options{
-				AllowedOrigins: conf.RPC.CORSAllowedOrigins,
-				AllowedMethods: conf.RPC.CORSAllowedMethods,
-				AllowedHeaders: conf.RPC.CORSAllowedHeaders,
-			})
-			rootHandler = corsMiddleware.Handler(mux)
+	if addr := conf.RPC.GRPCListenAddress; addr != "" {
+		listener, err := rpcserver.Listen(addr, conf.RPC.GRPCMaxOpenConnections)
+		if err != nil {
+			return nil, err
 		}
-		if conf.RPC.IsTLSEnabled() {
-			go func() {
-				if err := rpcserver.ServeTLS(
-					ctx,
-					listener,
-					rootHandler,
-					conf.RPC.CertFile(),
-					conf.RPC.KeyFile(),
-					rpcLogger,
-					cfg,
-				); err != nil {
-					env.Logger.Error("error serving server with TLS", "err", err)
-				}
-			}()
-		} else {
-			go func() {
-				if err := rpcserver.Serve(
-					ctx,
-					listener,
-					rootHandler,
-					rpcLogger,
-					cfg,
-				); err != nil {
-					env.Logger.Error("error serving server", "err", err)
-				}
-			}()
+		grpcLogger := env.Logger.With("module", "grpc-server")
+		srv := coregrpc.NewEventStreamServer(grpcLogger, env.EventBus)
+		var options []coregrpc.ServeOption
+		if conf.RPC.GRPCDataCompanion {
+			options = append(options, coregrpc.WithDataCompanion(
+				coregrpc.NewDataCompanionServer(grpcLogger, env.BlockStore, env.StateStore, env.EventBus)))
 		}
+		go func() {
+			if err := coregrpc.Serve(ctx, listener, srv, options...); err != nil {
+				grpcLogger.Error("error serving gRPC server", "err", err)
+			}
+		}()
+		grpcLogger.Info("gRPC event stream server started", "addr", addr, "dataCompanion", conf.RPC.GRPCDataCompanion)
 
-		listeners[i] = listener
+		listeners = append(listeners, listener)
 	}
 
 	return listeners, nil
 
 }
+
+// networkMethods are the RPC methods served by seed nodes, which only run the
+// p2p layer.
+var networkMethods = []string{"health", "net_info", "address_book", "net_topology"}
+
+// StartNetworkService starts the RPC server of a seed node, which only serves
+// the methods about the p2p network, without websocket. The environment only
+// needs the logger and the p2p fields to be set.
+func (env *Environment) StartNetworkService(ctx context.Context, conf *config.Config) ([]net.Listener, error) {
+	env.Listeners = p2pListeners(conf.P2P)
+
+	all := NewRoutesMap(env, &RouteOptions{Disabled: conf.RPC.DisabledMethods})
+	routes := make(RoutesMap, len(networkMethods))
+	for _, method := range networkMethods {
+		if route, ok := all[method]; ok {
+			routes[method] = route
+		}
+	}
+
+	rpcConf := *conf.RPC
+	rpcConf.ExperimentalDisableWebsocket = true
+	seedConf := *conf
+	seedConf.RPC = &rpcConf
+
+	cfg := rpcserver.DefaultConfig()
+	cfg.MaxBodyBytes = conf.RPC.MaxBodyBytes
+	cfg.MaxHeaderBytes = conf.RPC.MaxHeaderBytes
+	cfg.MaxOpenConnections = conf.RPC.MaxOpenConnections
+	setTLSAndHTTP2(cfg, conf.RPC)
+	limits := &listenerLimits{maxOpenConnectionsPerIP: conf.RPC.MaxOpenConnectionsPerIP}
+
+	listenAddrs := tmstrings.SplitAndTrimEmpty(conf.RPC.ListenAddress, ",", " ")
+	listeners := make([]net.Listener, 0, len(listenAddrs))
+	for _, listenAddr := range listenAddrs {
+		listener, err := env.serveRPC(ctx, &seedConf, listenAddr, routes, cfg, limits, nil)
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, listener)
+	}
+	return listeners, nil
+}
+
+// SetRateLimit applies the rate limit settings of conf to the clients of the
+// public listeners. It reports false, leaving the rate limit unchanged, if
+// the rate limit was disabled when the service started or conf disables it,
+// which takes a restart.
+func (env *Environment) SetRateLimit(conf *config.RPCConfig) (bool, error) {
+	if env.rateLimiter == nil || conf.RateLimit == 0 {
+		return false, nil
+	}
+	costs, err := conf.MethodCosts()
+	if err != nil {
+		return false, fmt.Errorf("invalid rate-limit-method-costs: %w", err)
+	}
+	env.rateLimiter.SetConfig(rpcserver.RateLimitConfig{
+		Rate:        conf.RateLimit,
+		Burst:       conf.RateLimitBurst,
+		MethodCosts: costs,
+		APIKeys:     conf.RateLimitAPIKeys,
+	})
+	return true, nil
+}
+
+// traceCall starts the span of a call of the given RPC method, and returns the
+// function ending it.
+func (env *Environment) traceCall(ctx context.Context, method string) (context.Context, func(error)) {
+	ctx, span := env.Tracer.Start(ctx, "rpc."+method)
+	return ctx, func(err error) {
+		span.SetError(err)
+		span.End()
+	}
+}
+
+// setTLSAndHTTP2 sets the HTTP/2 support of the RPC config on the server
+// config, and the TLS configuration obtaining the certificates of the
+// tls-acme-domains from the ACME certificate authority, if any.
+func setTLSAndHTTP2(cfg *rpcserver.Config, conf *config.RPCConfig) {
+	cfg.H2C = conf.HTTP2
+	cfg.DisableHTTP2 = !conf.HTTP2
+	if !conf.IsACMEEnabled() {
+		return
+	}
+	m := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(conf.ACMECacheDir()),
+		HostPolicy: autocert.HostWhitelist(conf.TLSACMEDomains...),
+		Email:      conf.TLSACMEEmail,
+	}
+	if url := conf.TLSACMEDirectoryURL; url != "" {
+		m.Client = &acme.Client{DirectoryURL: url}
+	}
+	cfg.TLSConfig = m.TLSConfig()
+}
+
+// corsHandler wraps h to serve the cross-origin requests: from the origins of
+// cors-path-allowed-origins for the paths listed, with or without the API
+// version prefix, and from the cors-allowed-origins for the other paths. It
+// returns h if neither allows any origin. The JSON-RPC requests are all sent
+// to "/", so the origins of a method path don't apply to them.
+func corsHandler(conf *config.RPCConfig, h http.Handler) (http.Handler, error) {
+	pathOrigins, err := conf.CORSPathOrigins()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cors-path-allowed-origins: %w", err)
+	}
+	withOrigins := func(origins []string) http.Handler {
+		return cors.New(cors.Options{
+			AllowedOrigins: origins,
+			AllowedMethods: conf.CORSAllowedMethods,
+			AllowedHeaders: conf.CORSAllowedHeaders,
+		}).Handler(h)
+	}
+
+	// An empty cors.Options.AllowedOrigins allows all the origins, so the
+	// paths without origins are not wrapped.
+	other := h
+	if conf.IsCorsEnabled() {
+		other = withOrigins(conf.CORSAllowedOrigins)
+	}
+	if len(pathOrigins) == 0 {
+		return other, nil
+	}
+	paths := make(map[string]http.Handler, len(pathOrigins))
+	for path, origins := range pathOrigins {
+		paths[path] = withOrigins(origins)
+	}
+	prefix := "/" + APIVersion
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if rest := strings.TrimPrefix(path, prefix); rest != path && (rest == "" || rest[0] == '/') {
+			path = rest
+		}
+		if path == "" {
+			path = "/"
+		}
+		if ph, ok := paths[path]; ok {
+			ph.ServeHTTP(w, r)
+			return
+		}
+		other.ServeHTTP(w, r)
+	}), nil
+}
+
+// listenerLimits are the limits of the clients of an RPC listener.
+type listenerLimits struct {
+	rateLimiter             *rpcserver.RateLimiter // nil for no rate limit
+	maxOpenConnectionsPerIP int                    // 0 for no limit
+}
+
+// serveRPC serves the routes on a listener at listenAddr until ctx ends, with
+// the given limits and the handler wrapped by auth if it is not nil, and
+// returns the listener.
+func (env *Environment) serveRPC(
+	ctx context.Context,
+	conf *config.Config,
+	listenAddr string,
+	routes RoutesMap,
+	cfg *rpcserver.Config,
+	limits *listenerLimits,
+	auth func(http.Handler) http.Handler,
+) (net.Listener, error) {
+	openAPIHandler, err := rpcserver.NewOpenAPIHandler(routes, rpcserver.OpenAPIInfo{
+		Title:      "Tendermint RPC",
+		Version:    version.TMVersion,
+		PathPrefix: "/" + APIVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	rpcLogger := env.Logger.With("module", "rpc-server")
+	handlerOptions := []rpcserver.HandlerOption{
+		rpcserver.MaxBatchSize(conf.RPC.MaxRequestBatchSize),
+		rpcserver.CompressResponses(conf.RPC.CompressResponses),
+		rpcserver.OnCall(env.traceCall),
+	}
+	if limits.rateLimiter != nil {
+		handlerOptions = append(handlerOptions, rpcserver.RateLimit(limits.rateLimiter))
+	}
+	rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger, handlerOptions...)
+	rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger,
+		append(handlerOptions, rpcserver.PathPrefix("/"+APIVersion))...)
+	mux.HandleFunc("/openapi.json", openAPIHandler)
+
+	if conf.RPC.ExperimentalDisableWebsocket {
+		rpcLogger.Info("Disabling websocket endpoints (experimental-disable-websocket=true)")
+	} else {
+		rpcLogger.Info("WARNING: Websocket RPC access is deprecated and will be removed " +
+			"in Tendermint v0.37. See https://tinyurl.com/adr075 for more information.")
+		wmLogger := rpcLogger.With("protocol", "websocket")
+		wm := rpcserver.NewWebsocketManager(wmLogger, routes,
+			rpcserver.OnDisconnect(func(remoteAddr string) {
+				err := env.EventBus.UnsubscribeAll(context.Background(), remoteAddr)
+				if err != nil && err != tmpubsub.ErrSubscriptionNotFound {
+					wmLogger.Error("Failed to unsubscribe addr from events", "addr", remoteAddr, "err", err)
+				}
+			}),
+			rpcserver.ReadLimit(cfg.MaxBodyBytes),
+			rpcserver.WSRateLimit(limits.rateLimiter),
+			rpcserver.WSOnCall(env.traceCall),
+		)
+		mux.HandleFunc("/websocket", wm.WebsocketHandler)
+		mux.HandleFunc("/"+APIVersion+"/websocket", wm.WebsocketHandler)
+	}
+
+	listener, err := rpcserver.Listen(
+		listenAddr,
+		cfg.MaxOpenConnections,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if n := limits.maxOpenConnectionsPerIP; n > 0 {
+		listener = rpcserver.LimitListenerPerIP(listener, n)
+	}
+
+	rootHandler, err := corsHandler(conf.RPC, mux)
+	if err != nil {
+		return nil, err
+	}
+	if auth != nil {
+		rootHandler = auth(rootHandler)
+	}
+	if conf.RPC.IsTLSEnabled() || conf.RPC.IsACMEEnabled() {
+		// with ACME, the certificates are the ones of cfg.TLSConfig
+		var certFile, keyFile string
+		if conf.RPC.IsTLSEnabled() {
+			certFile, keyFile = conf.RPC.CertFile(), conf.RPC.KeyFile()
+		}
+		go func() {
+			if err := rpcserver.ServeTLS(
+				ctx,
+				listener,
+				rootHandler,
+				certFile,
+				keyFile,
+				rpcLogger,
+				cfg,
+			); err != nil {
+				env.Logger.Error("error serving server with TLS", "err", err)
+			}
+		}()
+	} else {
+		go func() {
+			if err := rpcserver.Serve(
+				ctx,
+				listener,
+				rootHandler,
+				rpcLogger,
+				cfg,
+			); err != nil {
+				env.Logger.Error("error serving server", "err", err)
+			}
+		}()
+	}
+	return listener, nil
+}
@@ -1,10 +1,21 @@
 package core
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
 )
 
 func TestPaginationPage(t *testing.T) {
@@ -88,3 +99,90 @@ func TestPaginationPerPage(t *testing.T) {
 	assert.Equal(t, perPage, p)
 	env.Config.Unsafe = false
 }
+
+func TestGenesisChunks(t *testing.T) {
+	ctx := context.Background()
+	for name, appState := range map[string]string{
+		"no app state": "",
+		"small":        `{"accounts":[]}`,
+		"large":        `"` + strings.Repeat("a", 2*genesisChunkSize+100) + `"`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			genDoc := &types.GenesisDoc{
+				ChainID:         "test-chain",
+				InitialHeight:   1,
+				ConsensusParams: types.DefaultConsensusParams(),
+				AppState:        json.RawMessage(appState),
+			}
+			env := &Environment{GenDoc: genDoc}
+			_, err := env.GenesisChunked(ctx, &coretypes.RequestGenesisChunked{})
+			require.Error(t, err, "chunks not initialized")
+			require.NoError(t, env.InitGenesisChunks())
+
+			first, err := env.GenesisChunked(ctx, &coretypes.RequestGenesisChunked{})
+			require.NoError(t, err)
+			var data []byte
+			for i := 0; i < first.TotalChunks; i++ {
+				chunk, err := env.GenesisChunked(ctx, &coretypes.RequestGenesisChunked{Chunk: coretypes.Int64(i)})
+				require.NoError(t, err)
+				require.Equal(t, i, chunk.ChunkNumber)
+				bz, err := base64.StdEncoding.DecodeString(chunk.Data)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(bz), genesisChunkSize)
+				data = append(data, bz...)
+			}
+			_, err = env.GenesisChunked(ctx, &coretypes.RequestGenesisChunked{Chunk: coretypes.Int64(first.TotalChunks)})
+			require.Error(t, err)
+
+			expect, err := json.Marshal(genDoc)
+			require.NoError(t, err)
+			require.Equal(t, string(expect), string(data))
+
+			_, err = env.Genesis(ctx)
+			if first.TotalChunks > 1 {
+				require.Equal(t, 3, first.TotalChunks)
+				require.Error(t, err, "the genesis document is too large")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCORSHandler(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	allowedOrigin := func(t *testing.T, h http.Handler, path, origin string) string {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Origin", origin)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	conf := config.DefaultRPCConfig()
+	handler, err := corsHandler(conf, h)
+	require.NoError(t, err)
+	assert.Empty(t, allowedOrigin(t, handler, "/status", "https://a.example.com"), "CORS is disabled by default")
+
+	conf.CORSPathAllowedOrigins = []string{"/broadcast_tx_sync:https://wallet.example.com"}
+	handler, err = corsHandler(conf, h)
+	require.NoError(t, err)
+	for _, path := range []string{"/broadcast_tx_sync", "/v1/broadcast_tx_sync"} {
+		assert.Equal(t, "https://wallet.example.com", allowedOrigin(t, handler, path, "https://wallet.example.com"), path)
+		assert.Empty(t, allowedOrigin(t, handler, path, "https://a.example.com"), path)
+	}
+	assert.Empty(t, allowedOrigin(t, handler, "/status", "https://wallet.example.com"),
+		"the other paths have no allowed origins")
+	assert.Empty(t, allowedOrigin(t, handler, "/v1broadcast_tx_sync", "https://wallet.example.com"))
+
+	conf.CORSAllowedOrigins = []string{"https://a.example.com"}
+	handler, err = corsHandler(conf, h)
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.example.com", allowedOrigin(t, handler, "/status", "https://a.example.com"))
+	assert.Empty(t, allowedOrigin(t, handler, "/broadcast_tx_sync", "https://a.example.com"),
+		"the origins of the path replace the allowed origins")
+
+	conf.CORSPathAllowedOrigins = []string{"broadcast_tx_sync:*"}
+	_, err = corsHandler(conf, h)
+	require.Error(t, err)
+}
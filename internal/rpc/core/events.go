@@ -16,8 +16,9 @@ import (
 )
 
 const (
-	// Buffer on the Tendermint (server) side to allow some slowness in clients.
-	subBufferSize = 100
+	// Default buffer on the Tendermint (server) side to allow some slowness
+	// in clients, see RPCConfig.SubscriptionBufferSize.
+	defaultSubBufferSize = 100
 
 	// maxQueryLength is the maximum length of a query string that will be
 	// accepted. This is just a safety check to avoid outlandish queries.
@@ -46,6 +47,18 @@ func (env *Environment) Subscribe(ctx context.Context, req *coretypes.RequestSub
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse query: %w", err)
 	}
+	overflow, err := tmpubsub.ParseOverflowPolicy(env.Config.SubscriptionOverflowPolicy)
+	if err != nil {
+		return nil, err
+	}
+	bufferSize := env.Config.SubscriptionBufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultSubBufferSize
+	}
+	afterSeq := int64(req.AfterSeq)
+	if afterSeq > 0 && env.replay == nil {
+		return nil, errors.New("subscription replay is not enabled on this node")
+	}
 
 	subCtx, cancel := context.WithTimeout(ctx, SubscribeTimeout)
 	defer cancel()
@@ -53,18 +66,56 @@ func (env *Environment) Subscribe(ctx context.Context, req *coretypes.RequestSub
 	sub, err := env.EventBus.SubscribeWithArgs(subCtx, tmpubsub.SubscribeArgs{
 		ClientID: addr,
 		Query:    q,
-		Limit:    subBufferSize,
+		Limit:    bufferSize,
+		Overflow: overflow,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	// Collect the missed events only once subscribed, up to the start of the
+	// subscription, so that none published in between are lost. Events
+	// received both ways are filtered out by sequence number below.
+	var missed []tmpubsub.Message
+	if afterSeq > 0 {
+		missed, err = env.replay.missed(subCtx, afterSeq, sub, q)
+		if err != nil {
+			_ = env.EventBus.Unsubscribe(ctx, tmpubsub.UnsubscribeArgs{Subscriber: addr, ID: sub.ID()})
+			return nil, err
+		}
+	}
+
 	// Capture the current ID, since it can change in the future.
 	subscriptionID := callInfo.RPCRequest.ID
 	go func() {
 		opctx, opcancel := context.WithCancel(context.TODO())
 		defer opcancel()
 
+		lastSeq := afterSeq
+		deliver := func(msg tmpubsub.Message) {
+			if msg.Seq() <= lastSeq {
+				return // already delivered
+			}
+			lastSeq = msg.Seq()
+
+			resp := callInfo.RPCRequest.MakeResponse(&coretypes.ResultEvent{
+				Query:  req.Query,
+				Seq:    msg.Seq(),
+				Data:   msg.Data(),
+				Events: msg.Events(),
+			})
+			wctx, cancel := context.WithTimeout(opctx, 10*time.Second)
+			err := callInfo.WSConn.WriteRPCResponse(wctx, resp)
+			cancel()
+			if err != nil {
+				env.Logger.Info("Unable to write response (slow client)",
+					"to", addr, "subscriptionID", subscriptionID, "err", err)
+			}
+		}
+
+		for _, msg := range missed {
+			deliver(msg)
+		}
 		for {
 			msg, err := sub.Next(opctx)
 			if errors.Is(err, tmpubsub.ErrUnsubscribed) {
@@ -82,18 +133,7 @@ func (env *Environment) Subscribe(ctx context.Context, req *coretypes.RequestSub
 			}
 
 			// We have a message to deliver to the client.
-			resp := callInfo.RPCRequest.MakeResponse(&coretypes.ResultEvent{
-				Query:  req.Query,
-				Data:   msg.Data(),
-				Events: msg.Events(),
-			})
-			wctx, cancel := context.WithTimeout(opctx, 10*time.Second)
-			err = callInfo.WSConn.WriteRPCResponse(wctx, resp)
-			cancel()
-			if err != nil {
-				env.Logger.Info("Unable to write response (slow client)",
-					"to", addr, "subscriptionID", subscriptionID, "err", err)
-			}
+			deliver(msg)
 		}
 	}()
 
@@ -2,9 +2,11 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
 )
 
 // BroadcastEvidence broadcasts evidence of the misbehavior.
@@ -21,3 +23,47 @@ func (env *Environment) BroadcastEvidence(ctx context.Context, req *coretypes.Re
 	}
 	return &coretypes.ResultBroadcastEvidence{Hash: req.Evidence.Hash()}, nil
 }
+
+// Evidence returns the pending evidence of the node, i.e. the verified
+// evidence not committed yet, ordered by height.
+// More: https://docs.tendermint.com/master/rpc/#/Evidence/evidence
+func (env *Environment) Evidence(ctx context.Context, req *coretypes.RequestEvidence) (*coretypes.ResultEvidence, error) {
+	if env.EvidenceAuditor == nil {
+		return nil, errors.New("evidence pool is not available")
+	}
+
+	totalCount := int(env.EvidenceAuditor.Size())
+	perPage := env.validatePerPage(req.PerPage.IntPtr())
+	page, err := validatePage(req.Page.IntPtr(), perPage, totalCount)
+	if err != nil {
+		return nil, err
+	}
+
+	evidence, err := env.EvidenceAuditor.PendingEvidencePage(validateSkipCount(page, perPage), perPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending evidence: %w", err)
+	}
+	return &coretypes.ResultEvidence{
+		Count:    len(evidence),
+		Total:    totalCount,
+		Evidence: types.EvidenceList(evidence),
+	}, nil
+}
+
+// SubmitEvidence forms duplicate vote evidence from two conflicting votes of
+// a committed height detected outside of the node, and broadcasts it once
+// verified.
+// More: https://docs.tendermint.com/master/rpc/#/Unsafe/submit_evidence
+func (env *Environment) SubmitEvidence(ctx context.Context, req *coretypes.RequestSubmitEvidence) (*coretypes.ResultBroadcastEvidence, error) {
+	if env.EvidenceAuditor == nil {
+		return nil, errors.New("evidence pool is not available")
+	}
+	if req.VoteA == nil || req.VoteB == nil {
+		return nil, fmt.Errorf("%w: two conflicting votes must be provided", coretypes.ErrInvalidRequest)
+	}
+	ev, err := env.EvidenceAuditor.AddConflictingVotes(ctx, req.VoteA, req.VoteB)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add evidence: %w", err)
+	}
+	return &coretypes.ResultBroadcastEvidence{Hash: ev.Hash()}, nil
+}
@@ -2,13 +2,201 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/internal/proxy"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 )
 
-// Health gets node health. Returns empty result (200 OK) on success, no
-// response - in case of an error.
+// abciProbeInterval is the interval between the Info requests probing the
+// application for the health checks.
+const abciProbeInterval = 5 * time.Second
+
+// Health gets node health: "ok", or "degraded" while the node is catching up,
+// state syncing or consensus is stalled, or the storage audit found
+// corruption, or "unhealthy" if consensus halted or the application is
+// unresponsive, with the reasons. The GET responses of the
+// unhealthy node have status 503 Service Unavailable.
 // More: https://docs.tendermint.com/master/rpc/#/Info/health
 func (env *Environment) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
-	return &coretypes.ResultHealth{}, nil
+	reasons, unhealthy := env.healthReasons(ctx)
+	result := &coretypes.ResultHealth{Status: coretypes.HealthOK, Reasons: reasons}
+	switch {
+	case unhealthy:
+		result.Status = coretypes.HealthUnhealthy
+	case len(reasons) > 0:
+		result.Status = coretypes.HealthDegraded
+	}
+	return result, nil
+}
+
+// Ready reports whether the node is ready to serve requests, for the health
+// checks of load balancers: it is not while it is catching up or state
+// syncing, consensus halted, the application is unresponsive or the storage
+// audit found corruption. A stalled
+// consensus does not make the node unready, as it stalls the whole network.
+// The GET responses of the unready node have status 503 Service Unavailable.
+// More: https://docs.tendermint.com/master/rpc/#/Info/ready
+func (env *Environment) Ready(ctx context.Context) (*coretypes.ResultReady, error) {
+	reasons, _ := env.healthReasons(ctx)
+	result := &coretypes.ResultReady{Ready: true}
+	for _, reason := range reasons {
+		if reason.Code != coretypes.HealthReasonConsensusStalled {
+			result.Ready = false
+			result.Reasons = append(result.Reasons, reason)
+		}
+	}
+	return result, nil
+}
+
+// healthReasons returns the reasons why the node is not healthy, and whether
+// any of them makes it unhealthy rather than degraded.
+func (env *Environment) healthReasons(ctx context.Context) (reasons []coretypes.HealthReason, unhealthy bool) {
+	if env.abciProbe != nil {
+		if err := env.abciProbe.check(time.Now()); err != nil {
+			unhealthy = true
+			reasons = append(reasons, coretypes.HealthReason{
+				Code:    coretypes.HealthReasonABCIUnresponsive,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if env.ConsensusState != nil {
+		if rec := env.ConsensusState.GetHaltRecord(); rec != nil {
+			unhealthy = true
+			reasons = append(reasons, coretypes.HealthReason{
+				Code:    coretypes.HealthReasonConsensusHalted,
+				Message: fmt.Sprintf("consensus halted with code %s at height %d: %s", rec.Code, rec.Height, rec.Reason),
+			})
+		}
+	}
+
+	if env.StorageAuditor != nil {
+		if report := env.StorageAuditor.LastReport(); report != nil && !report.Healthy() {
+			reasons = append(reasons, coretypes.HealthReason{
+				Code:    coretypes.HealthReasonStorageCorrupted,
+				Message: fmt.Sprintf("the storage audit found %d failed checks, see storage_health", len(report.Failures)),
+			})
+		}
+	}
+
+	syncing := env.ConsensusReactor != nil && env.ConsensusReactor.WaitSync()
+	switch {
+	case syncing && env.StateSyncMetricer != nil && env.StateSyncMetricer.IsSyncing():
+		reasons = append(reasons, coretypes.HealthReason{
+			Code:    coretypes.HealthReasonStateSync,
+			Message: fmt.Sprintf("state sync in progress, at snapshot height %d", env.StateSyncMetricer.SnapshotHeight()),
+		})
+	case syncing:
+		var maxPeerHeight int64
+		if env.BlockSyncReactor != nil {
+			maxPeerHeight = env.BlockSyncReactor.GetMaxPeerBlockHeight()
+		}
+		reasons = append(reasons, coretypes.HealthReason{
+			Code: coretypes.HealthReasonCatchingUp,
+			Message: fmt.Sprintf("block sync in progress, at height %d of the peer height %d",
+				env.BlockStore.Height(), maxPeerHeight),
+		})
+	case env.ConsensusState != nil && env.Config.HealthStalledRounds > 0:
+		if rs := env.ConsensusState.GetRoundState(); rs.Round >= int32(env.Config.HealthStalledRounds) {
+			reasons = append(reasons, coretypes.HealthReason{
+				Code:    coretypes.HealthReasonConsensusStalled,
+				Message: fmt.Sprintf("consensus is at round %d of height %d", rs.Round, rs.Height),
+			})
+		}
+	}
+	return reasons, unhealthy
+}
+
+// abciProbe probes the application with Info requests in the background, so
+// that the health checks report the result of the last probe rather than
+// sending requests to the application, which would wait for it and queue
+// behind the other requests of the connection.
+type abciProbe struct {
+	client  abciclient.Client
+	timeout time.Duration // of each request, or 0 for none
+
+	mtx        sync.Mutex
+	err        error     // of the last probe
+	lastAnswer time.Time // of the application, or the start of the probes
+}
+
+func newABCIProbe(client abciclient.Client, timeout time.Duration) *abciProbe {
+	return &abciProbe{client: client, timeout: timeout, lastAnswer: time.Now()}
+}
+
+// run probes the application at each interval until ctx ends.
+func (p *abciProbe) run(ctx context.Context) {
+	ticker := time.NewTicker(abciProbeInterval)
+	defer ticker.Stop()
+	for {
+		p.probe(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// probe sends an Info request to the application, and records its result.
+func (p *abciProbe) probe(ctx context.Context) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	_, err := p.client.Info(ctx, &proxy.RequestInfo)
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.err = err
+	if err == nil {
+		p.lastAnswer = time.Now()
+	}
+}
+
+// check returns an error if the last probe failed, or if the application has
+// not answered a probe for longer than the interval and the timeout of the
+// probes, e.g. because a probe is stuck.
+func (p *abciProbe) check(now time.Time) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.err != nil {
+		return fmt.Errorf("the application did not answer an Info request: %w", p.err)
+	}
+	if since := now.Sub(p.lastAnswer); p.timeout > 0 && since > abciProbeInterval+p.timeout {
+		return fmt.Errorf("the application has not answered an Info request for %v", since.Round(time.Second))
+	}
+	return nil
+}
+
+// healthStatus is the HTTP status of the GET responses of Health.
+func healthStatus(result interface{}) int {
+	if res, ok := result.(*coretypes.ResultHealth); ok && res.Status == coretypes.HealthUnhealthy {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// storageHealthStatus is the HTTP status of the GET responses of
+// StorageHealth.
+func storageHealthStatus(result interface{}) int {
+	if res, ok := result.(*coretypes.ResultStorageHealth); ok && !res.Healthy {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
+
+// readyStatus is the HTTP status of the GET responses of Ready.
+func readyStatus(result interface{}) int {
+	if res, ok := result.(*coretypes.ResultReady); ok && !res.Ready {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
 }
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client/mocks"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
+	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+// healthConsensusState is the part of the consensus state the health checks
+// use.
+type healthConsensusState struct {
+	consensusState
+	round int32
+	halt  *consensus.HaltRecord
+}
+
+func (cs *healthConsensusState) GetRoundState() *cstypes.RoundState {
+	return &cstypes.RoundState{Height: 10, Round: cs.round}
+}
+
+func (cs *healthConsensusState) GetHaltRecord() *consensus.HaltRecord { return cs.halt }
+
+type healthStorageAuditor struct{ report *sm.AuditReport }
+
+func (a *healthStorageAuditor) LastReport() *sm.AuditReport { return a.report }
+
+func TestHealth(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := abciclient.NewClient(t)
+	appErr := errors.New("connection refused")
+	infoCall := app.On("Info", mock.Anything, mock.Anything).Return(&abci.ResponseInfo{}, nil)
+	cs := &healthConsensusState{}
+	env := &Environment{ProxyApp: app, ConsensusState: cs, Config: *config.DefaultRPCConfig()}
+	env.abciProbe = newABCIProbe(app, env.Config.HealthABCITimeout)
+	env.abciProbe.probe(ctx)
+
+	check := func(status string, ready bool, codes ...string) {
+		t.Helper()
+		health, err := env.Health(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, status, health.Status)
+		var got []string
+		for _, reason := range health.Reasons {
+			got = append(got, reason.Code)
+		}
+		assert.Equal(t, codes, got)
+
+		res, err := env.Ready(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, ready, res.Ready)
+		if status == coretypes.HealthUnhealthy {
+			assert.Equal(t, http.StatusServiceUnavailable, healthStatus(health))
+		} else {
+			assert.Equal(t, http.StatusOK, healthStatus(health))
+		}
+		if ready {
+			assert.Equal(t, http.StatusOK, readyStatus(res))
+		} else {
+			assert.Equal(t, http.StatusServiceUnavailable, readyStatus(res))
+		}
+	}
+
+	check(coretypes.HealthOK, true)
+
+	// a stalled consensus degrades the node, which is still ready
+	cs.round = 3
+	check(coretypes.HealthDegraded, true, coretypes.HealthReasonConsensusStalled)
+	env.Config.HealthStalledRounds = 0
+	check(coretypes.HealthOK, true)
+
+	cs.halt = &consensus.HaltRecord{Code: consensus.HaltCodeInvalidBlock, Height: 10}
+	check(coretypes.HealthUnhealthy, false, coretypes.HealthReasonConsensusHalted)
+	cs.halt = nil
+
+	// a corrupted storage degrades the node, which is not ready
+	auditor := &healthStorageAuditor{}
+	env.StorageAuditor = auditor
+	check(coretypes.HealthOK, true)
+	auditor.report = &sm.AuditReport{Failures: []sm.AuditFailure{{Height: 5, Check: sm.AuditCheckBlock, Err: errors.New("invalid block")}}}
+	check(coretypes.HealthDegraded, false, coretypes.HealthReasonStorageCorrupted)
+	storage, err := env.StorageHealth(ctx)
+	require.NoError(t, err)
+	assert.False(t, storage.Healthy)
+	assert.Equal(t, []coretypes.StorageAuditFailure{{Height: 5, Check: "block", Error: "invalid block"}}, storage.Failures)
+	assert.Equal(t, http.StatusServiceUnavailable, storageHealthStatus(storage))
+	env.StorageAuditor = nil
+
+	// the health checks report the last probe of the application
+	infoCall.Return(nil, appErr)
+	check(coretypes.HealthOK, true)
+	env.abciProbe.probe(ctx)
+	check(coretypes.HealthUnhealthy, false, coretypes.HealthReasonABCIUnresponsive)
+}
+
+func TestABCIProbe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	app := abciclient.NewClient(t)
+	app.On("Info", mock.Anything, mock.Anything).Return(&abci.ResponseInfo{}, nil)
+	probe := newABCIProbe(app, time.Second)
+	probe.probe(ctx)
+	require.NoError(t, probe.check(time.Now()))
+
+	// a probe stuck past its interval and timeout reports the application
+	// unresponsive
+	require.Error(t, probe.check(time.Now().Add(abciProbeInterval+2*time.Second)))
+	probe.timeout = 0
+	require.NoError(t, probe.check(time.Now().Add(abciProbeInterval+2*time.Second)))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
 )
 
 //-----------------------------------------------------------------------------
@@ -25,7 +26,7 @@ import (
 func (env *Environment) BroadcastTxAsync(ctx context.Context, req *coretypes.RequestBroadcastTx) (*coretypes.ResultBroadcastTx, error) {
 	go func() { _ = env.Mempool.CheckTx(ctx, req.Tx, nil, mempool.TxInfo{}) }()
 
-	return &coretypes.ResultBroadcastTx{Hash: req.Tx.Hash()}, nil
+	return &coretypes.ResultBroadcastTx{Hash: req.Tx.ID()}, nil
 }
 
 // Deprecated and should be remove in 0.37
@@ -61,7 +62,7 @@ func (env *Environment) BroadcastTx(ctx context.Context, req *coretypes.RequestB
 			Code:      r.Code,
 			Data:      r.Data,
 			Codespace: r.Codespace,
-			Hash:      req.Tx.Hash(),
+			Hash:      req.Tx.ID(),
 		}, nil
 	}
 }
@@ -92,14 +93,14 @@ func (env *Environment) BroadcastTxCommit(ctx context.Context, req *coretypes.Re
 		if r.Code != abci.CodeTypeOK {
 			return &coretypes.ResultBroadcastTxCommit{
 				CheckTx: *r,
-				Hash:    req.Tx.Hash(),
+				Hash:    req.Tx.ID(),
 			}, fmt.Errorf("wrong ABCI CodeType, got (%d) instead of OK", r.Code)
 		}
 
 		if !indexer.KVSinkEnabled(env.EventSinks) {
 			return &coretypes.ResultBroadcastTxCommit{
 					CheckTx: *r,
-					Hash:    req.Tx.Hash(),
+					Hash:    req.Tx.ID(),
 				},
 				errors.New("cannot confirm transaction because kvEventSink is not enabled")
 		}
@@ -118,12 +119,12 @@ func (env *Environment) BroadcastTxCommit(ctx context.Context, req *coretypes.Re
 					"err", err)
 				return &coretypes.ResultBroadcastTxCommit{
 						CheckTx: *r,
-						Hash:    req.Tx.Hash(),
+						Hash:    req.Tx.ID(),
 					}, fmt.Errorf("timeout waiting for commit of tx %s (%s)",
-						req.Tx.Hash(), time.Since(startAt))
+						req.Tx.ID(), time.Since(startAt))
 			case <-timer.C:
 				txres, err := env.Tx(ctx, &coretypes.RequestTx{
-					Hash:  req.Tx.Hash(),
+					Hash:  req.Tx.ID(),
 					Prove: false,
 				})
 				if err != nil {
@@ -136,7 +137,7 @@ func (env *Environment) BroadcastTxCommit(ctx context.Context, req *coretypes.Re
 				return &coretypes.ResultBroadcastTxCommit{
 					CheckTx:  *r,
 					TxResult: txres.TxResult,
-					Hash:     req.Tx.Hash(),
+					Hash:     req.Tx.ID(),
 					Height:   txres.Height,
 				}, nil
 			}
@@ -144,6 +145,95 @@ func (env *Environment) BroadcastTxCommit(ctx context.Context, req *coretypes.Re
 	}
 }
 
+// TxStatus reports where a transaction is in its lifecycle: in the mempool,
+// evicted from it, committed, or not found. Committed transactions are only
+// found with the kv event sink, and evictions only among the
+// mempool.evicted-cache-size transactions evicted last. The hash is the ID of
+// the transaction by the tx hash function of the node.
+func (env *Environment) TxStatus(ctx context.Context, req *coretypes.RequestTxStatus) (*coretypes.ResultTxStatus, error) {
+	key, found, err := env.txKey(req.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	var status mempool.TxStatus
+	if found {
+		status = env.Mempool.TxStatus(key)
+	}
+	if status.InMempool {
+		return &coretypes.ResultTxStatus{
+			Hash:   req.Hash,
+			Status: coretypes.TxStatusMempool,
+			Mempool: &coretypes.TxMempoolStatus{
+				Position: status.Position,
+				Time:     status.Timestamp,
+				Height:   status.Height,
+			},
+		}, nil
+	}
+
+	// A transaction may be committed after its eviction from this mempool,
+	// if it was proposed by another node.
+	for _, sink := range env.EventSinks {
+		if sink.Type() != indexer.KV {
+			continue
+		}
+		r, err := sink.GetTxByHash(req.Hash)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			return &coretypes.ResultTxStatus{
+				Hash:   req.Hash,
+				Status: coretypes.TxStatusCommitted,
+				Committed: &coretypes.TxCommittedStatus{
+					Height: r.Height,
+					Index:  r.Index,
+					Code:   r.Result.Code,
+				},
+			}, nil
+		}
+	}
+
+	if status.Evicted {
+		return &coretypes.ResultTxStatus{
+			Hash:   req.Hash,
+			Status: coretypes.TxStatusEvicted,
+			Evicted: &coretypes.TxEvictedStatus{
+				Reason: status.EvictedReason,
+				Time:   status.EvictedAt,
+				Height: status.EvictedHeight,
+			},
+		}, nil
+	}
+	return &coretypes.ResultTxStatus{Hash: req.Hash, Status: coretypes.TxStatusNotFound}, nil
+}
+
+// txKeyResolver is implemented by the mempools which look up transactions by
+// their IDs, for a custom tx hash function.
+type txKeyResolver interface {
+	TxKeyByID(id []byte) (types.TxKey, bool)
+}
+
+// txKey returns the mempool key of the transaction with the given ID. With the
+// default tx hash function the ID is the key.
+func (env *Environment) txKey(id []byte) (key types.TxKey, found bool, err error) {
+	if types.TxHashFuncName() == types.TxHashSHA256 {
+		if len(id) != len(key) {
+			return key, false, fmt.Errorf("invalid tx hash length %d, expected %d", len(id), len(key))
+		}
+		copy(key[:], id)
+		return key, true, nil
+	}
+	if len(id) == 0 {
+		return key, false, errors.New("tx hash cannot be empty")
+	}
+	if r, ok := env.Mempool.(txKeyResolver); ok {
+		key, found = r.TxKeyByID(id)
+	}
+	return key, found, nil
+}
+
 // UnconfirmedTxs gets unconfirmed transactions from the mempool in order of priority
 // More: https://docs.tendermint.com/master/rpc/#/Info/unconfirmed_txs
 func (env *Environment) UnconfirmedTxs(ctx context.Context, req *coretypes.RequestUnconfirmedTxs) (*coretypes.ResultUnconfirmedTxs, error) {
@@ -187,6 +277,34 @@ func (env *Environment) CheckTx(ctx context.Context, req *coretypes.RequestCheck
 	return &coretypes.ResultCheckTx{ResponseCheckTx: *res}, nil
 }
 
-func (env *Environment) RemoveTx(ctx context.Context, req *coretypes.RequestRemoveTx) error {
-	return env.Mempool.RemoveTxByKey(req.TxKey)
+// SimulateTx checks the transaction like CheckTx, without adding it to the
+// mempool, and if it passes asks the application to simulate its execution
+// with an ABCI query at the rpc.simulate-query-path, with the transaction as
+// the data of the query. The response of the query, e.g. with the gas used and
+// the events of the transaction, is encoded by the application. Wallets use
+// it to estimate the gas of transactions. It is only served with
+// rpc.simulate-tx, as CheckTx may update the check state of the application.
+// More: https://docs.tendermint.com/master/rpc/#/Tx/simulate_tx
+func (env *Environment) SimulateTx(ctx context.Context, req *coretypes.RequestSimulateTx) (*coretypes.ResultSimulateTx, error) {
+	if !env.Config.SimulateTx {
+		return nil, errors.New("simulate_tx is disabled, see rpc.simulate-tx in the config")
+	}
+	res, err := env.ProxyApp.CheckTx(ctx, &abci.RequestCheckTx{Tx: req.Tx})
+	if err != nil {
+		return nil, err
+	}
+	result := &coretypes.ResultSimulateTx{CheckTx: *res}
+	if res.IsErr() || env.Config.SimulateQueryPath == "" {
+		return result, nil
+	}
+
+	resQuery, err := env.ProxyApp.Query(ctx, &abci.RequestQuery{
+		Path: env.Config.SimulateQueryPath,
+		Data: req.Tx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Simulation = resQuery
+	return result, nil
 }
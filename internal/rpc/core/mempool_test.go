@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client/mocks"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestSimulateTx(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tx := types.Tx("key=value")
+	app := abciclient.NewClient(t)
+	checkCall := app.On("CheckTx", mock.Anything, &abci.RequestCheckTx{Tx: tx}).
+		Return(&abci.ResponseCheckTx{Code: abci.CodeTypeOK, GasWanted: 10}, nil)
+	env := &Environment{ProxyApp: app, Config: *config.DefaultRPCConfig()}
+
+	// disabled by default
+	_, err := env.SimulateTx(ctx, &coretypes.RequestSimulateTx{Tx: tx})
+	require.Error(t, err)
+	env.Config.SimulateTx = true
+
+	// without a simulate-query-path, only CheckTx runs
+	res, err := env.SimulateTx(ctx, &coretypes.RequestSimulateTx{Tx: tx})
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, res.CheckTx.GasWanted)
+	assert.Nil(t, res.Simulation)
+
+	env.Config.SimulateQueryPath = "/app/simulate"
+	app.On("Query", mock.Anything, &abci.RequestQuery{Path: "/app/simulate", Data: tx}).
+		Return(&abci.ResponseQuery{Code: abci.CodeTypeOK, Value: []byte("gas_used=7")}, nil).Once()
+	res, err = env.SimulateTx(ctx, &coretypes.RequestSimulateTx{Tx: tx})
+	require.NoError(t, err)
+	require.NotNil(t, res.Simulation)
+	assert.Equal(t, []byte("gas_used=7"), res.Simulation.Value)
+
+	// a tx failing CheckTx is not simulated
+	checkCall.Return(&abci.ResponseCheckTx{Code: 1}, nil)
+	res, err = env.SimulateTx(ctx, &coretypes.RequestSimulateTx{Tx: tx})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.CheckTx.Code)
+	assert.Nil(t, res.Simulation)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/tendermint/tendermint/rpc/coretypes"
 )
@@ -20,9 +21,19 @@ func (env *Environment) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo,
 			continue
 		}
 
+		var versions []coretypes.PeerChannelVersion
+		for ch, version := range env.PeerManager.ChannelVersions(peer) {
+			versions = append(versions, coretypes.PeerChannelVersion{
+				ChannelID: uint16(ch),
+				Version:   version,
+			})
+		}
+		sort.Slice(versions, func(i, j int) bool { return versions[i].ChannelID < versions[j].ChannelID })
+
 		peers = append(peers, coretypes.Peer{
-			ID:  peer,
-			URL: addrs[0].String(),
+			ID:              peer,
+			URL:             addrs[0].String(),
+			ChannelVersions: versions,
 		})
 	}
 
@@ -34,10 +45,87 @@ func (env *Environment) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo,
 	}, nil
 }
 
+// AddressBook returns the peers of the address book, ordered by score, with
+// their dial statistics. The private peers are not listed.
+// More: https://docs.tendermint.com/master/rpc/#/Info/address_book
+func (env *Environment) AddressBook(ctx context.Context) (*coretypes.ResultAddressBook, error) {
+	entries := env.PeerManager.AddressBook()
+
+	peers := make([]coretypes.AddressBookPeer, 0, len(entries))
+	for _, entry := range entries {
+		addrs := make([]coretypes.AddressBookAddress, 0, len(entry.Addresses))
+		for _, addr := range entry.Addresses {
+			addrs = append(addrs, coretypes.AddressBookAddress{
+				URL:             addr.Address.String(),
+				LastDialSuccess: addr.LastDialSuccess,
+				LastDialFailure: addr.LastDialFailure,
+				DialFailures:    addr.DialFailures,
+			})
+		}
+		peers = append(peers, coretypes.AddressBookPeer{
+			ID:               entry.ID,
+			Addresses:        addrs,
+			Score:            int(entry.Score),
+			MisbehaviorScore: entry.MisbehaviorScore,
+			Uptime:           entry.Uptime,
+			Latency:          entry.Latency,
+			LastConnected:    entry.LastConnected,
+			Connected:        entry.Connected,
+			Persistent:       entry.Persistent,
+			Inactive:         entry.Inactive,
+		})
+	}
+
+	return &coretypes.ResultAddressBook{
+		NPeers: len(peers),
+		Peers:  peers,
+	}, nil
+}
+
+// NetTopology returns the network topology crawled by the PEX reactor, with
+// the number of nodes running each software version.
+// More: https://docs.tendermint.com/master/rpc/#/Info/net_topology
+func (env *Environment) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	if env.Topology == nil {
+		return nil, errors.New("the network topology is not recorded, PEX is disabled")
+	}
+
+	topology := env.Topology.Nodes()
+	nodes := make([]coretypes.TopologyNode, 0, len(topology))
+	edges := 0
+	for _, node := range topology {
+		nodes = append(nodes, coretypes.TopologyNode{
+			ID:       node.ID,
+			Version:  node.Version,
+			LastSeen: node.LastSeen,
+			Peers:    node.Peers,
+		})
+		edges += len(node.Peers)
+	}
+
+	versions := make([]coretypes.VersionNodes, 0)
+	for version, count := range env.Topology.Versions() {
+		versions = append(versions, coretypes.VersionNodes{Version: version, NNodes: count})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		if versions[i].NNodes != versions[j].NNodes {
+			return versions[i].NNodes > versions[j].NNodes
+		}
+		return versions[i].Version < versions[j].Version
+	})
+
+	return &coretypes.ResultNetTopology{
+		NNodes:   len(nodes),
+		NEdges:   edges,
+		Nodes:    nodes,
+		Versions: versions,
+	}, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.tendermint.com/master/rpc/#/Info/genesis
 func (env *Environment) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
-	if len(env.genChunks) > 1 {
+	if env.genesisChunks() > 1 {
 		return nil, errors.New("genesis response is large, please use the genesis_chunked API instead")
 	}
 
@@ -45,23 +133,24 @@ func (env *Environment) Genesis(ctx context.Context) (*coretypes.ResultGenesis,
 }
 
 func (env *Environment) GenesisChunked(ctx context.Context, req *coretypes.RequestGenesisChunked) (*coretypes.ResultGenesisChunk, error) {
-	if env.genChunks == nil {
+	if env.genesisParts == nil {
 		return nil, fmt.Errorf("service configuration error, genesis chunks are not initialized")
 	}
 
-	if len(env.genChunks) == 0 {
+	total := env.genesisChunks()
+	if total == 0 {
 		return nil, fmt.Errorf("service configuration error, there are no chunks")
 	}
 
 	id := int(req.Chunk)
 
-	if id > len(env.genChunks)-1 {
-		return nil, fmt.Errorf("there are %d chunks, %d is invalid", len(env.genChunks)-1, id)
+	if id < 0 || id > total-1 {
+		return nil, fmt.Errorf("there are %d chunks, %d is invalid", total-1, id)
 	}
 
 	return &coretypes.ResultGenesisChunk{
-		TotalChunks: len(env.genChunks),
+		TotalChunks: total,
 		ChunkNumber: id,
-		Data:        env.genChunks[id],
+		Data:        env.genesisChunk(id),
 	}, nil
 }
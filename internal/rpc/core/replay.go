@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/tendermint/internal/eventbus"
+	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
+	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
+)
+
+// replayBuffer retains the most recent messages published to the event bus,
+// so that a WebSocket client that resubscribes with after_seq can be sent the
+// events it missed while it was disconnected.
+type replayBuffer struct {
+	mu      sync.Mutex
+	msgs    []tmpubsub.Message // ring buffer, oldest at next once full
+	next    int                // slot for the next message
+	full    bool
+	from    int64         // all the messages after this seq are retained
+	last    int64         // the seq of the last message added
+	updated chan struct{} // closed, and replaced, when a message is added
+}
+
+// newReplayBuffer returns a buffer of size messages, which are added from
+// sub: the buffer retains the messages after its StartSeq.
+func newReplayBuffer(size int, sub eventbus.Subscription) *replayBuffer {
+	return &replayBuffer{
+		msgs:    make([]tmpubsub.Message, size),
+		from:    sub.StartSeq(),
+		last:    sub.StartSeq(),
+		updated: make(chan struct{}),
+	}
+}
+
+// add records msg, discarding the oldest message if the buffer is full.
+// Messages must be added in sequence order.
+func (b *replayBuffer) add(msg tmpubsub.Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.full {
+		b.from = b.msgs[b.next].Seq()
+	}
+	b.msgs[b.next] = msg
+	b.next = (b.next + 1) % len(b.msgs)
+	if b.next == 0 {
+		b.full = true
+	}
+	b.last = msg.Seq()
+	close(b.updated)
+	b.updated = make(chan struct{})
+}
+
+// missed returns, oldest first, the retained messages matching q that sub,
+// a new subscription, did not receive: those after seq up to its StartSeq. It
+// waits for the buffer to catch up with the start of sub, so that the replay
+// and the messages of sub have no gap between them. It reports an error if
+// seq is ahead of the start of sub, or if some of the messages after seq have
+// already been discarded, e.g. before a restart of the node, in which case the
+// replay would be incomplete.
+func (b *replayBuffer) missed(
+	ctx context.Context,
+	seq int64,
+	sub eventbus.Subscription,
+	q *tmquery.Query,
+) ([]tmpubsub.Message, error) {
+	start := sub.StartSeq()
+	if seq > start {
+		return nil, fmt.Errorf("after_seq %d is ahead of the last event seq %d", seq, start)
+	}
+	for {
+		b.mu.Lock()
+		last, updated := b.last, b.updated
+		b.mu.Unlock()
+		if last >= start {
+			break
+		}
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for the events up to seq %d: %w", start, ctx.Err())
+		}
+	}
+
+	msgs, ok := b.since(seq, start, q)
+	if !ok {
+		return nil, fmt.Errorf("events after seq %d are no longer retained; "+
+			"use tx_search or block_search to catch up", seq)
+	}
+	return msgs, nil
+}
+
+// since returns, oldest first, the retained messages with a sequence number
+// greater than seq, and at most until, that match q. It reports false if
+// some of the messages after seq have already been discarded, in which case
+// the replay would be incomplete.
+func (b *replayBuffer) since(seq, until int64, q *tmquery.Query) ([]tmpubsub.Message, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if seq < b.from {
+		return nil, false
+	}
+	retained := b.msgs[:b.next]
+	if b.full {
+		retained = append(append([]tmpubsub.Message(nil), b.msgs[b.next:]...), b.msgs[:b.next]...)
+	}
+
+	var out []tmpubsub.Message
+	for _, msg := range retained {
+		if msg.Seq() > seq && msg.Seq() <= until && q.Matches(msg.Events()) {
+			out = append(out, msg)
+		}
+	}
+	return out, true
+}
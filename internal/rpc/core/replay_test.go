@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
+	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestReplayBuffer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Sequence numbers are assigned by the pubsub server.
+	s := tmpubsub.NewServer(log.NewNopLogger())
+	require.NoError(t, s.Start(ctx))
+	sub, err := s.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
+		ClientID: "test",
+		Query:    tmquery.All,
+		Limit:    10,
+	})
+	require.NoError(t, err)
+
+	const size = 4
+	b := newReplayBuffer(size, sub)
+	for i := 1; i <= 6; i++ {
+		events := []abci.Event{{
+			Type:       "tx",
+			Attributes: []abci.EventAttribute{{Key: "height", Value: fmt.Sprint(i)}},
+		}}
+		require.NoError(t, s.PublishWithEvents(types.EventDataString(fmt.Sprint(i)), events))
+		msg, err := sub.Next(ctx)
+		require.NoError(t, err)
+		b.add(msg)
+	}
+
+	seqs := func(msgs []tmpubsub.Message) []int64 {
+		var out []int64
+		for _, msg := range msgs {
+			out = append(out, msg.Seq())
+		}
+		return out
+	}
+
+	// Events 3 to 6 are retained.
+	msgs, ok := b.since(2, 6, tmquery.All)
+	require.True(t, ok)
+	assert.Equal(t, []int64{3, 4, 5, 6}, seqs(msgs))
+
+	msgs, ok = b.since(4, 6, tmquery.MustCompile(`tx.height > 4`))
+	require.True(t, ok)
+	assert.Equal(t, []int64{5, 6}, seqs(msgs))
+
+	msgs, ok = b.since(3, 4, tmquery.All)
+	require.True(t, ok)
+	assert.Equal(t, []int64{4}, seqs(msgs))
+
+	msgs, ok = b.since(6, 6, tmquery.All)
+	require.True(t, ok)
+	assert.Empty(t, msgs)
+
+	// Event 2 was discarded, so a replay after 1 would be incomplete.
+	_, ok = b.since(1, 6, tmquery.All)
+	assert.False(t, ok)
+}
+
+func TestReplayBufferMissed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := tmpubsub.NewServer(log.NewNopLogger())
+	require.NoError(t, s.Start(ctx))
+	subscribe := func(clientID string) *tmpubsub.Subscription {
+		sub, err := s.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
+			ClientID: clientID,
+			Query:    tmquery.All,
+			Limit:    100,
+		})
+		require.NoError(t, err)
+		return sub
+	}
+	publish := func(n int) {
+		for i := 0; i < n; i++ {
+			require.NoError(t, s.Publish(types.EventDataString(fmt.Sprint(i))))
+		}
+	}
+	seqs := func(msgs []tmpubsub.Message) []int64 {
+		var out []int64
+		for _, msg := range msgs {
+			out = append(out, msg.Seq())
+		}
+		return out
+	}
+
+	replaySub := subscribe("replay")
+	b := newReplayBuffer(10, replaySub)
+
+	// Events 1 to 3 are published before the client resubscribes, but the
+	// buffer has not added them yet.
+	publish(3)
+	sub := subscribe("client")
+	require.Equal(t, int64(3), sub.StartSeq())
+	publish(2)
+
+	_, err := b.missed(ctx, 4, sub, tmquery.All)
+	require.Error(t, err, "after_seq ahead of the start of the subscription")
+
+	type result struct {
+		msgs []tmpubsub.Message
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		msgs, err := b.missed(ctx, 1, sub, tmquery.All)
+		done <- result{msgs, err}
+	}()
+	select {
+	case <-done:
+		t.Fatal("missed returned before the buffer caught up with the subscription")
+	case <-time.After(50 * time.Millisecond):
+	}
+	go func() {
+		for {
+			msg, err := replaySub.Next(ctx)
+			if err != nil {
+				return
+			}
+			b.add(msg)
+		}
+	}()
+	res := <-done
+	require.NoError(t, res.err)
+
+	// The replay and the subscription cross at its start, without a gap or a
+	// duplicate.
+	got := seqs(res.msgs)
+	for i := 0; i < 2; i++ {
+		msg, err := sub.Next(ctx)
+		require.NoError(t, err)
+		got = append(got, msg.Seq())
+	}
+	assert.Equal(t, []int64{2, 3, 4, 5}, got)
+
+	// After a restart, the sequence numbers start after the ones of the
+	// previous run, whose events are not retained.
+	restarted := tmpubsub.NewServer(log.NewNopLogger(), tmpubsub.InitialSeq(1<<40))
+	require.NoError(t, restarted.Start(ctx))
+	replaySub, err = restarted.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{ClientID: "replay", Query: tmquery.All, Limit: 10})
+	require.NoError(t, err)
+	b = newReplayBuffer(10, replaySub)
+	sub, err = restarted.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{ClientID: "client", Query: tmquery.All, Limit: 10})
+	require.NoError(t, err)
+	_, err = b.missed(ctx, 5, sub, tmquery.All)
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	rpc "github.com/tendermint/tendermint/rpc/jsonrpc/server"
@@ -11,16 +12,34 @@ import (
 
 type RoutesMap map[string]*rpc.RPCFunc
 
+// APIVersion is the version of the RPC routes, which are served under it, e.g.
+// at "/v1/status". A breaking change to the routes gets a new version, served
+// alongside the previous ones. The routes are also served at the root, e.g. at
+// "/status", for the clients of the unversioned routes.
+const APIVersion = "v1"
+
+// The tags grouping the routes in the OpenAPI document.
+const (
+	tagABCI     = "ABCI"
+	tagEvents   = "Events"
+	tagEvidence = "Evidence"
+	tagInfo     = "Info"
+	tagTx       = "Tx"
+	tagUnsafe   = "Unsafe"
+)
+
 // RouteOptions provide optional settings to NewRoutesMap.  A nil *RouteOptions
 // is ready for use and provides defaults as specified.
 type RouteOptions struct {
-	Unsafe bool // include "unsafe" methods (default false)
+	Unsafe   bool     // include "unsafe" methods (default false)
+	Disabled []string // methods to leave out (default none)
 }
 
 // NewRoutesMap constructs an RPC routing map for the given service
 // implementation. If svc implements RPCUnsafe and opts.Unsafe is true, the
-// "unsafe" methods will also be added to the map. The caller may also edit the
-// map after construction; each call to NewRoutesMap returns a fresh map.
+// "unsafe" methods will also be added to the map. The methods of opts.Disabled
+// are left out. The caller may also edit the map after construction; each
+// call to NewRoutesMap returns a fresh map.
 func NewRoutesMap(svc RPCService, opts *RouteOptions) RoutesMap {
 	if opts == nil {
 		opts = new(RouteOptions)
@@ -28,65 +47,154 @@ func NewRoutesMap(svc RPCService, opts *RouteOptions) RoutesMap {
 	out := RoutesMap{
 		// Event subscription. Note that subscribe, unsubscribe, and
 		// unsubscribe_all are only available via the websocket endpoint.
-		"events":          rpc.NewRPCFunc(svc.Events).Timeout(0),
-		"subscribe":       rpc.NewWSRPCFunc(svc.Subscribe),
-		"unsubscribe":     rpc.NewWSRPCFunc(svc.Unsubscribe),
-		"unsubscribe_all": rpc.NewWSRPCFunc(svc.UnsubscribeAll),
+		"events": rpc.NewRPCFunc(svc.Events).Timeout(0).
+			Doc(tagEvents, "Fetch events posted by the consensus node"),
+		"subscribe": rpc.NewWSRPCFunc(svc.Subscribe).
+			Doc(tagEvents, "Subscribe for events via WebSocket"),
+		"unsubscribe": rpc.NewWSRPCFunc(svc.Unsubscribe).
+			Doc(tagEvents, "Unsubscribe from event on WebSocket"),
+		"unsubscribe_all": rpc.NewWSRPCFunc(svc.UnsubscribeAll).
+			Doc(tagEvents, "Unsubscribe from all events via WebSocket"),
 
 		// info API
-		"health":               rpc.NewRPCFunc(svc.Health),
-		"status":               rpc.NewRPCFunc(svc.Status),
-		"net_info":             rpc.NewRPCFunc(svc.NetInfo),
-		"blockchain":           rpc.NewRPCFunc(svc.BlockchainInfo),
-		"genesis":              rpc.NewRPCFunc(svc.Genesis),
-		"genesis_chunked":      rpc.NewRPCFunc(svc.GenesisChunked),
-		"header":               rpc.NewRPCFunc(svc.Header),
-		"header_by_hash":       rpc.NewRPCFunc(svc.HeaderByHash),
-		"block":                rpc.NewRPCFunc(svc.Block),
-		"block_by_hash":        rpc.NewRPCFunc(svc.BlockByHash),
-		"block_results":        rpc.NewRPCFunc(svc.BlockResults),
-		"commit":               rpc.NewRPCFunc(svc.Commit),
-		"check_tx":             rpc.NewRPCFunc(svc.CheckTx),
-		"remove_tx":            rpc.NewRPCFunc(svc.RemoveTx),
-		"tx":                   rpc.NewRPCFunc(svc.Tx),
-		"tx_search":            rpc.NewRPCFunc(svc.TxSearch),
-		"block_search":         rpc.NewRPCFunc(svc.BlockSearch),
-		"validators":           rpc.NewRPCFunc(svc.Validators),
-		"dump_consensus_state": rpc.NewRPCFunc(svc.DumpConsensusState),
-		"consensus_state":      rpc.NewRPCFunc(svc.GetConsensusState),
-		"consensus_params":     rpc.NewRPCFunc(svc.ConsensusParams),
-		"unconfirmed_txs":      rpc.NewRPCFunc(svc.UnconfirmedTxs),
-		"num_unconfirmed_txs":  rpc.NewRPCFunc(svc.NumUnconfirmedTxs),
+		"health":   rpc.NewRPCFunc(svc.Health).HTTPStatus(healthStatus).Doc(tagInfo, "Node health"),
+		"ready":    rpc.NewRPCFunc(svc.Ready).HTTPStatus(readyStatus).Doc(tagInfo, "Node readiness to serve requests"),
+		"status":   rpc.NewRPCFunc(svc.Status).Doc(tagInfo, "Node status"),
+		"net_info": rpc.NewRPCFunc(svc.NetInfo).Doc(tagInfo, "Network information"),
+		"address_book": rpc.NewRPCFunc(svc.AddressBook).
+			Doc(tagInfo, "Get the peers of the address book with their scores and dial statistics"),
+		"net_topology": rpc.NewRPCFunc(svc.NetTopology).
+			Doc(tagInfo, "Get the network topology crawled by the node"),
+		"signing_state": rpc.NewRPCFunc(svc.SigningState).
+			Doc(tagInfo, "Get the last sign state of the node's validator and of its remote signer"),
+		"storage_health": rpc.NewRPCFunc(svc.StorageHealth).HTTPStatus(storageHealthStatus).
+			Doc(tagInfo, "Get the corruption of the block and state stores found by the storage auditor"),
+		"blockchain": rpc.NewRPCFunc(svc.BlockchainInfo).
+			Doc(tagInfo, "Get block headers (max: 20) for minHeight <= height <= maxHeight"),
+		"genesis": rpc.NewRPCFunc(svc.Genesis).Doc(tagInfo, "Get genesis"),
+		"genesis_chunked": rpc.NewRPCFunc(svc.GenesisChunked).
+			Doc(tagInfo, "Get genesis in paginated chunks"),
+		"header": rpc.NewRPCFunc(svc.Header).Immutable(atHeight).
+			Doc(tagInfo, "Get the header at a specified height"),
+		"header_by_hash": rpc.NewRPCFunc(svc.HeaderByHash).
+			Doc(tagInfo, "Get header by hash"),
+		"block": rpc.NewRPCFunc(svc.Block).Immutable(atHeight).
+			Doc(tagInfo, "Get block at a specified height"),
+		"block_by_hash": rpc.NewRPCFunc(svc.BlockByHash).Doc(tagInfo, "Get block by hash"),
+		"block_results": rpc.NewRPCFunc(svc.BlockResults).Immutable(atHeight).
+			Doc(tagInfo, "Get block results at a specified height"),
+		"commit": rpc.NewRPCFunc(svc.Commit).Immutable(canonicalCommit).
+			Doc(tagInfo, "Get commit results at a specified height"),
+		"check_tx":  rpc.NewRPCFunc(svc.CheckTx).Doc(tagTx, "Check the transaction without executing it"),
+		"tx":        rpc.NewRPCFunc(svc.Tx).Doc(tagInfo, "Get transactions by hash"),
+		"tx_search": rpc.NewRPCFunc(svc.TxSearch).Doc(tagInfo, "Search for transactions"),
+		"tx_status": rpc.NewRPCFunc(svc.TxStatus).
+			Doc(tagTx, "Get the status of a transaction in the mempool or the chain"),
+		"simulate_tx": rpc.NewRPCFunc(svc.SimulateTx).
+			Doc(tagTx, "Check the transaction and simulate its execution, without adding it to the mempool"),
+		"block_search": rpc.NewRPCFunc(svc.BlockSearch).
+			Doc(tagInfo, "Search for blocks by BeginBlock and EndBlock events"),
+		"validators": rpc.NewRPCFunc(svc.Validators).
+			Doc(tagInfo, "Get validator set at a specified height"),
+		"validator_history": rpc.NewRPCFunc(svc.ValidatorHistory).
+			Doc(tagInfo, "Get the voting power of a validator and its changes over a range of heights"),
+		"dump_consensus_state": rpc.NewRPCFunc(svc.DumpConsensusState).
+			Doc(tagInfo, "Get the full consensus state"),
+		"consensus_state": rpc.NewRPCFunc(svc.GetConsensusState).
+			Doc(tagInfo, "Get consensus state"),
+		"consensus_params": rpc.NewRPCFunc(svc.ConsensusParams).
+			Doc(tagInfo, "Get consensus parameters"),
+		"consensus_params_history": rpc.NewRPCFunc(svc.ConsensusParamsHistory).
+			Doc(tagInfo, "Get the consensus parameters and their changes over a range of heights"),
+		"unconfirmed_txs": rpc.NewRPCFunc(svc.UnconfirmedTxs).
+			Doc(tagInfo, "Get the list of unconfirmed transactions"),
+		"num_unconfirmed_txs": rpc.NewRPCFunc(svc.NumUnconfirmedTxs).
+			Doc(tagInfo, "Get data about unconfirmed transactions"),
 
 		// tx broadcast API
-		"broadcast_tx": rpc.NewRPCFunc(svc.BroadcastTx),
+		"broadcast_tx": rpc.NewRPCFunc(svc.BroadcastTx).
+			Doc(tagTx, "Return with the response from CheckTx, without waiting for DeliverTx"),
 		// TODO remove after 0.36
 		// deprecated broadcast tx methods:
-		"broadcast_tx_commit": rpc.NewRPCFunc(svc.BroadcastTxCommit),
-		"broadcast_tx_sync":   rpc.NewRPCFunc(svc.BroadcastTx),
-		"broadcast_tx_async":  rpc.NewRPCFunc(svc.BroadcastTxAsync),
+		"broadcast_tx_commit": rpc.NewRPCFunc(svc.BroadcastTxCommit).
+			Doc(tagTx, "Deprecated: return with the responses from CheckTx and DeliverTx"),
+		"broadcast_tx_sync": rpc.NewRPCFunc(svc.BroadcastTx).
+			Doc(tagTx, "Deprecated: use broadcast_tx"),
+		"broadcast_tx_async": rpc.NewRPCFunc(svc.BroadcastTxAsync).
+			Doc(tagTx, "Deprecated: return right away, without waiting for CheckTx nor DeliverTx"),
 
 		// abci API
-		"abci_query": rpc.NewRPCFunc(svc.ABCIQuery),
-		"abci_info":  rpc.NewRPCFunc(svc.ABCIInfo),
+		"abci_query": rpc.NewRPCFunc(svc.ABCIQuery).Doc(tagABCI, "Query the application for some information"),
+		"abci_info":  rpc.NewRPCFunc(svc.ABCIInfo).Doc(tagABCI, "Get some info about the application"),
 
 		// evidence API
-		"broadcast_evidence": rpc.NewRPCFunc(svc.BroadcastEvidence),
+		"broadcast_evidence": rpc.NewRPCFunc(svc.BroadcastEvidence).
+			Doc(tagEvidence, "Broadcast evidence of the misbehavior"),
+		"evidence": rpc.NewRPCFunc(svc.Evidence).
+			Doc(tagEvidence, "Get the pending evidence of the node"),
 	}
 	if u, ok := svc.(RPCUnsafe); ok && opts.Unsafe {
-		out["unsafe_flush_mempool"] = rpc.NewRPCFunc(u.UnsafeFlushMempool)
+		out["unsafe_flush_mempool"] = rpc.NewRPCFunc(u.UnsafeFlushMempool).
+			Doc(tagUnsafe, "Flush mempool of all unconfirmed transactions")
+		out["remove_tx"] = rpc.NewRPCFunc(u.RemoveTx).
+			Doc(tagUnsafe, "Remove a transaction from the mempool")
+		out["unsafe_reload_peer_filter"] = rpc.NewRPCFunc(u.UnsafeReloadPeerFilter).
+			Doc(tagUnsafe, "Reload the allowed and blocked peers from the config file")
+		out["unsafe_reload_config"] = rpc.NewRPCFunc(u.UnsafeReloadConfig).
+			Doc(tagUnsafe, "Reload the settings which don't take a restart from the config file")
+		out["submit_evidence"] = rpc.NewRPCFunc(u.SubmitEvidence).
+			Doc(tagUnsafe, "Submit two conflicting votes detected outside of the node as evidence")
+		out["unsafe_set_halt"] = rpc.NewRPCFunc(u.UnsafeSetHalt).
+			Doc(tagUnsafe, "Set the height and time of the planned halt of consensus")
+	}
+	for _, name := range opts.Disabled {
+		delete(out, name)
 	}
 	return out
 }
 
+// checkMethods returns an error if any of names is not the name of a method
+// of the routes of svc, including the unsafe ones.
+func checkMethods(svc RPCService, names []string) error {
+	all := NewRoutesMap(svc, &RouteOptions{Unsafe: true})
+	for _, name := range names {
+		if _, ok := all[name]; !ok {
+			return fmt.Errorf("unknown RPC method %q", name)
+		}
+	}
+	return nil
+}
+
+// atHeight reports whether the result of a call with a RequestBlockInfo or
+// RequestBlockResults param is immutable, because the param has an explicit height. The latest height is
+// the default, whose result changes with each block.
+func atHeight(param, _ interface{}) bool {
+	var height *coretypes.Int64
+	switch req := param.(type) {
+	case *coretypes.RequestBlockInfo:
+		height = req.Height
+	case *coretypes.RequestBlockResults:
+		height = req.Height
+	}
+	return height != nil && *height > 0
+}
+
+// canonicalCommit reports whether the result of a call to Commit is immutable,
+// because it is the canonical commit at an explicit height. The commit at the
+// latest height is the one seen by the node, which may change.
+func canonicalCommit(param, result interface{}) bool {
+	return atHeight(param, result) && result.(*coretypes.ResultCommit).CanonicalCommit
+}
+
 // RPCService defines the set of methods exported by the RPC service
 // implementation, for use in constructing a routing table.
 type RPCService interface {
 	ABCIInfo(ctx context.Context) (*coretypes.ResultABCIInfo, error)
 	ABCIQuery(ctx context.Context, req *coretypes.RequestABCIQuery) (*coretypes.ResultABCIQuery, error)
+	AddressBook(ctx context.Context) (*coretypes.ResultAddressBook, error)
 	Block(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultBlock, error)
 	BlockByHash(ctx context.Context, req *coretypes.RequestBlockByHash) (*coretypes.ResultBlock, error)
-	BlockResults(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultBlockResults, error)
+	BlockResults(ctx context.Context, req *coretypes.RequestBlockResults) (*coretypes.ResultBlockResults, error)
 	BlockSearch(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error)
 	BlockchainInfo(ctx context.Context, req *coretypes.RequestBlockchainInfo) (*coretypes.ResultBlockchainInfo, error)
 	BroadcastEvidence(ctx context.Context, req *coretypes.RequestBroadcastEvidence) (*coretypes.ResultBroadcastEvidence, error)
@@ -97,8 +205,10 @@ type RPCService interface {
 	CheckTx(ctx context.Context, req *coretypes.RequestCheckTx) (*coretypes.ResultCheckTx, error)
 	Commit(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultCommit, error)
 	ConsensusParams(ctx context.Context, req *coretypes.RequestConsensusParams) (*coretypes.ResultConsensusParams, error)
+	ConsensusParamsHistory(ctx context.Context, req *coretypes.RequestConsensusParamsHistory) (*coretypes.ResultConsensusParamsHistory, error)
 	DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error)
 	Events(ctx context.Context, req *coretypes.RequestEvents) (*coretypes.ResultEvents, error)
+	Evidence(ctx context.Context, req *coretypes.RequestEvidence) (*coretypes.ResultEvidence, error)
 	Genesis(ctx context.Context) (*coretypes.ResultGenesis, error)
 	GenesisChunked(ctx context.Context, req *coretypes.RequestGenesisChunked) (*coretypes.ResultGenesisChunk, error)
 	GetConsensusState(ctx context.Context) (*coretypes.ResultConsensusState, error)
@@ -106,20 +216,31 @@ type RPCService interface {
 	HeaderByHash(ctx context.Context, req *coretypes.RequestBlockByHash) (*coretypes.ResultHeader, error)
 	Health(ctx context.Context) (*coretypes.ResultHealth, error)
 	NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error)
+	NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error)
 	NumUnconfirmedTxs(ctx context.Context) (*coretypes.ResultUnconfirmedTxs, error)
-	RemoveTx(ctx context.Context, req *coretypes.RequestRemoveTx) error
+	Ready(ctx context.Context) (*coretypes.ResultReady, error)
+	SigningState(ctx context.Context) (*coretypes.ResultSigningState, error)
+	SimulateTx(ctx context.Context, req *coretypes.RequestSimulateTx) (*coretypes.ResultSimulateTx, error)
 	Status(ctx context.Context) (*coretypes.ResultStatus, error)
+	StorageHealth(ctx context.Context) (*coretypes.ResultStorageHealth, error)
 	Subscribe(ctx context.Context, req *coretypes.RequestSubscribe) (*coretypes.ResultSubscribe, error)
 	Tx(ctx context.Context, req *coretypes.RequestTx) (*coretypes.ResultTx, error)
+	TxStatus(ctx context.Context, req *coretypes.RequestTxStatus) (*coretypes.ResultTxStatus, error)
 	TxSearch(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error)
 	UnconfirmedTxs(ctx context.Context, req *coretypes.RequestUnconfirmedTxs) (*coretypes.ResultUnconfirmedTxs, error)
 	Unsubscribe(ctx context.Context, req *coretypes.RequestUnsubscribe) (*coretypes.ResultUnsubscribe, error)
 	UnsubscribeAll(ctx context.Context) (*coretypes.ResultUnsubscribe, error)
+	ValidatorHistory(ctx context.Context, req *coretypes.RequestValidatorHistory) (*coretypes.ResultValidatorHistory, error)
 	Validators(ctx context.Context, req *coretypes.RequestValidators) (*coretypes.ResultValidators, error)
 }
 
 // RPCUnsafe defines the set of "unsafe" methods that may optionally be
 // exported by the RPC service.
 type RPCUnsafe interface {
+	RemoveTx(ctx context.Context, req *coretypes.RequestRemoveTx) error
+	SubmitEvidence(ctx context.Context, req *coretypes.RequestSubmitEvidence) (*coretypes.ResultBroadcastEvidence, error)
 	UnsafeFlushMempool(ctx context.Context) (*coretypes.ResultUnsafeFlushMempool, error)
+	UnsafeReloadConfig(ctx context.Context) (*coretypes.ResultReloadConfig, error)
+	UnsafeReloadPeerFilter(ctx context.Context) (*coretypes.ResultUnsafeReloadPeerFilter, error)
+	UnsafeSetHalt(ctx context.Context, req *coretypes.RequestSetHalt) (*coretypes.ResultSetHalt, error)
 }
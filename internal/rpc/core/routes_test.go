@@ -0,0 +1,55 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+func TestRoutesMapUnsafe(t *testing.T) {
+	env := &Environment{}
+
+	safe := NewRoutesMap(env, nil)
+	assert.Contains(t, safe, "broadcast_tx")
+	assert.NotContains(t, safe, "unsafe_flush_mempool")
+	assert.NotContains(t, safe, "remove_tx")
+	assert.Contains(t, safe, "evidence")
+	assert.NotContains(t, safe, "submit_evidence")
+	assert.NotContains(t, safe, "unsafe_set_halt")
+
+	unsafe := NewRoutesMap(env, &RouteOptions{Unsafe: true})
+	assert.Contains(t, unsafe, "unsafe_flush_mempool")
+	assert.Contains(t, unsafe, "remove_tx")
+	assert.Contains(t, unsafe, "submit_evidence")
+	assert.Contains(t, unsafe, "unsafe_set_halt")
+}
+
+func TestRoutesMapDisabled(t *testing.T) {
+	env := &Environment{}
+
+	routes := NewRoutesMap(env, &RouteOptions{Unsafe: true, Disabled: []string{"dump_consensus_state", "remove_tx"}})
+	assert.NotContains(t, routes, "dump_consensus_state")
+	assert.NotContains(t, routes, "remove_tx")
+	assert.Contains(t, routes, "unsafe_flush_mempool")
+
+	assert.NoError(t, checkMethods(env, []string{"dump_consensus_state", "remove_tx"}))
+	assert.Error(t, checkMethods(env, []string{"dial_peers"}))
+}
+
+func TestRoutesImmutable(t *testing.T) {
+	height := coretypes.Int64(5)
+	zero := coretypes.Int64(0)
+
+	assert.True(t, atHeight(&coretypes.RequestBlockInfo{Height: &height}, nil))
+	assert.False(t, atHeight(&coretypes.RequestBlockInfo{Height: &zero}, nil))
+	assert.False(t, atHeight(&coretypes.RequestBlockInfo{}, nil))
+	assert.True(t, atHeight(&coretypes.RequestBlockResults{Height: &height}, nil))
+	assert.False(t, atHeight(&coretypes.RequestBlockResults{}, nil))
+
+	canonical := &coretypes.ResultCommit{CanonicalCommit: true}
+	assert.True(t, canonicalCommit(&coretypes.RequestBlockInfo{Height: &height}, canonical))
+	assert.False(t, canonicalCommit(&coretypes.RequestBlockInfo{}, canonical))
+	assert.False(t, canonicalCommit(&coretypes.RequestBlockInfo{Height: &height}, &coretypes.ResultCommit{}))
+}
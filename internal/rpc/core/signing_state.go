@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+// SigningState returns the last sign state of the node's validator and, if the
+// node uses a remote signer, the last sign state reported by the signer and
+// whether the signer is behind the node.
+// More: https://docs.tendermint.com/master/rpc/#/Info/signing_state
+func (env *Environment) SigningState(ctx context.Context) (*coretypes.ResultSigningState, error) {
+	switch pv := env.PrivValidator.(type) {
+	case nil:
+		return nil, errors.New("node is not a validator")
+
+	case *privval.SignStateChecker:
+		local, remote, err := pv.SigningState(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result := &coretypes.ResultSigningState{Local: toSignState(local)}
+		if remote != nil {
+			rs := toSignState(*remote)
+			result.Remote = &rs
+			result.Regression = remote.Compare(local) < 0
+		}
+		return result, nil
+
+	case *privval.FilePV:
+		local, err := pv.GetSignState(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &coretypes.ResultSigningState{Local: toSignState(local)}, nil
+
+	default:
+		return nil, errors.New("the private validator of the node does not report its sign state")
+	}
+}
+
+func toSignState(s privval.SignState) coretypes.SignState {
+	return coretypes.SignState{Height: s.Height, Round: s.Round, Step: s.Step}
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
+	"github.com/tendermint/tendermint/internal/blocksync"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	"github.com/tendermint/tendermint/rpc/coretypes"
 	"github.com/tendermint/tendermint/types"
@@ -90,10 +92,35 @@ func (env *Environment) Status(ctx context.Context) (*coretypes.ResultStatus, er
 		result.SyncInfo.CatchingUp = env.ConsensusReactor.WaitSync()
 	}
 
+	if env.ConsensusState != nil {
+		if rec := env.ConsensusState.GetHaltRecord(); rec != nil {
+			result.ConsensusHalt = &coretypes.HaltInfo{
+				Code:   string(rec.Code),
+				Height: rec.Height,
+				Round:  rec.Round,
+				Reason: rec.Reason,
+				Time:   rec.Time,
+			}
+		}
+		if plan := env.ConsensusState.GetHaltPlan(); !plan.IsZero() {
+			result.HaltPlan = haltPlanInfo(plan)
+		}
+	}
+
+	if env.ExternalAddress != nil {
+		if addr, source := env.ExternalAddress.ExternalAddress(); addr != "" {
+			result.ExternalAddress = &coretypes.ExternalAddressInfo{
+				Address: addr,
+				Source:  source,
+			}
+		}
+	}
+
 	if env.BlockSyncReactor != nil {
 		result.SyncInfo.MaxPeerBlockHeight = env.BlockSyncReactor.GetMaxPeerBlockHeight()
 		result.SyncInfo.TotalSyncedTime = env.BlockSyncReactor.GetTotalSyncedTime()
 		result.SyncInfo.RemainingTime = env.BlockSyncReactor.GetRemainingSyncTime()
+		result.SyncInfo.BlockSyncPeers = blockSyncPeers(env.BlockSyncReactor.GetPeerScores())
 	}
 
 	if env.StateSyncMetricer != nil {
@@ -102,6 +129,8 @@ func (env *Environment) Status(ctx context.Context) (*coretypes.ResultStatus, er
 		result.SyncInfo.SnapshotHeight = env.StateSyncMetricer.SnapshotHeight()
 		result.SyncInfo.SnapshotChunksCount = env.StateSyncMetricer.SnapshotChunksCount()
 		result.SyncInfo.SnapshotChunksTotal = env.StateSyncMetricer.SnapshotChunksTotal()
+		result.SyncInfo.SnapshotChunksFetched = env.StateSyncMetricer.SnapshotChunksFetched()
+		result.SyncInfo.SnapshotRemainingTime = env.StateSyncMetricer.SnapshotRemainingTime()
 		result.SyncInfo.BackFilledBlocks = env.StateSyncMetricer.BackFilledBlocks()
 		result.SyncInfo.BackFillBlocksTotal = env.StateSyncMetricer.BackFillBlocksTotal()
 	}
@@ -135,3 +164,22 @@ func (env *Environment) validatorAtHeight(h int64) *types.Validator {
 	_, val := valsWithH.GetByAddress(privValAddress)
 	return val
 }
+
+// blockSyncPeers returns the scores of the block sync peers, by ID.
+func blockSyncPeers(scores map[types.NodeID]blocksync.PeerScore) []coretypes.BlockSyncPeerInfo {
+	if len(scores) == 0 {
+		return nil
+	}
+	peers := make([]coretypes.BlockSyncPeerInfo, 0, len(scores))
+	for id, score := range scores {
+		peers = append(peers, coretypes.BlockSyncPeerInfo{
+			NodeID:    id,
+			Latency:   score.Latency,
+			Delivered: score.Delivered,
+			Failures:  score.Failures,
+			Pending:   score.NumPending,
+		})
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].NodeID < peers[j].NodeID })
+	return peers
+}
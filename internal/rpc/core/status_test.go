@@ -0,0 +1,25 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tendermint/tendermint/internal/blocksync"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestBlockSyncPeers(t *testing.T) {
+	assert.Nil(t, blockSyncPeers(nil))
+
+	peers := blockSyncPeers(map[types.NodeID]blocksync.PeerScore{
+		"bb": {Latency: time.Second, Delivered: 10, Failures: 1, NumPending: 2},
+		"aa": {Latency: time.Millisecond, Delivered: 20},
+	})
+	assert.Equal(t, []coretypes.BlockSyncPeerInfo{
+		{NodeID: "aa", Latency: time.Millisecond, Delivered: 20},
+		{NodeID: "bb", Latency: time.Second, Delivered: 10, Failures: 1, Pending: 2},
+	}, peers)
+}
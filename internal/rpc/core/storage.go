@@ -0,0 +1,37 @@
+package core
+
+import (
+	"context"
+
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+// StorageHealth returns the health of the block and state stores found by the
+// last run of the storage auditor: the audited heights and the checks which
+// failed, with the heights of the corrupted data. The GET responses for the
+// corrupted stores have status 503 Service Unavailable.
+// More: https://docs.tendermint.com/master/rpc/#/Info/storage_health
+func (env *Environment) StorageHealth(ctx context.Context) (*coretypes.ResultStorageHealth, error) {
+	result := &coretypes.ResultStorageHealth{Healthy: true}
+	if env.StorageAuditor == nil {
+		return result, nil
+	}
+	result.Enabled = true
+	report := env.StorageAuditor.LastReport()
+	if report == nil {
+		return result, nil
+	}
+	result.Healthy = report.Healthy()
+	result.LastAudit = &report.Time
+	for _, height := range report.Heights {
+		result.AuditedHeights = append(result.AuditedHeights, coretypes.Int64(height))
+	}
+	for _, f := range report.Failures {
+		result.Failures = append(result.Failures, coretypes.StorageAuditFailure{
+			Height: f.Height,
+			Check:  f.Check,
+			Error:  f.Err.Error(),
+		})
+	}
+	return result, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"sort"
 
 	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
 	"github.com/tendermint/tendermint/internal/state/indexer"
@@ -67,47 +66,27 @@ func (env *Environment) TxSearch(ctx context.Context, req *coretypes.RequestTxSe
 
 	for _, sink := range env.EventSinks {
 		if sink.Type() == indexer.KV {
-			results, err := sink.SearchTxEvents(ctx, q)
+			perPage := env.validatePerPage(req.PerPage.IntPtr())
+			pageReq, skipCount, err := searchPageRequest(req.Page.IntPtr(), perPage, req.OrderBy, req.Cursor)
 			if err != nil {
 				return nil, err
 			}
 
-			// sort results (must be done before pagination)
-			switch req.OrderBy {
-			case "desc", "":
-				sort.Slice(results, func(i, j int) bool {
-					if results[i].Height == results[j].Height {
-						return results[i].Index > results[j].Index
-					}
-					return results[i].Height > results[j].Height
-				})
-			case "asc":
-				sort.Slice(results, func(i, j int) bool {
-					if results[i].Height == results[j].Height {
-						return results[i].Index < results[j].Index
-					}
-					return results[i].Height < results[j].Height
-				})
-			default:
-				return nil, fmt.Errorf("expected order_by to be either `asc` or `desc` or empty: %w", coretypes.ErrInvalidRequest)
-			}
-
-			// paginate results
-			totalCount := len(results)
-			perPage := env.validatePerPage(req.PerPage.IntPtr())
-
-			page, err := validatePage(req.Page.IntPtr(), perPage, totalCount)
+			tp, err := indexer.SearchTxPage(ctx, sink, q, pageReq)
 			if err != nil {
 				return nil, err
 			}
 
-			skipCount := validateSkipCount(page, perPage)
-			pageSize := tmmath.MinInt(perPage, totalCount-skipCount)
-
-			apiResults := make([]*coretypes.ResultTx, 0, pageSize)
-			for i := skipCount; i < skipCount+pageSize; i++ {
-				r := results[i]
+			// paginate results
+			if req.Cursor == "" {
+				if _, err := validatePage(req.Page.IntPtr(), perPage, tp.Total); err != nil {
+					return nil, err
+				}
+			}
+			results := tp.Txs[tmmath.MinInt(skipCount, len(tp.Txs)):]
 
+			apiResults := make([]*coretypes.ResultTx, 0, len(results))
+			for _, r := range results {
 				var proof types.TxProof
 				if req.Prove {
 					block := env.BlockStore.LoadBlock(r.Height)
@@ -115,7 +94,7 @@ func (env *Environment) TxSearch(ctx context.Context, req *coretypes.RequestTxSe
 				}
 
 				apiResults = append(apiResults, &coretypes.ResultTx{
-					Hash:     types.Tx(r.Tx).Hash(),
+					Hash:     types.Tx(r.Tx).ID(),
 					Height:   r.Height,
 					Index:    r.Index,
 					TxResult: r.Result,
@@ -124,7 +103,11 @@ func (env *Environment) TxSearch(ctx context.Context, req *coretypes.RequestTxSe
 				})
 			}
 
-			return &coretypes.ResultTxSearch{Txs: apiResults, TotalCount: totalCount}, nil
+			res := &coretypes.ResultTxSearch{Txs: apiResults, TotalCount: tp.Total}
+			if tp.Next != nil {
+				res.NextCursor = tp.Next.String()
+			}
+			return res, nil
 		}
 	}
 
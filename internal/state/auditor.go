@@ -0,0 +1,347 @@
+package state
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// The checks of the Auditor, as reported in the failures of an AuditReport
+// and labeled in the metrics.
+const (
+	// AuditCheckBlock recomputes the hashes of a block: its data, last commit
+	// and evidence hashes against its header, and its hash and part set hash
+	// against its block meta.
+	AuditCheckBlock = "block"
+	// AuditCheckCommit verifies the signatures of the commit of a block by
+	// the validators of its height.
+	AuditCheckCommit = "commit"
+	// AuditCheckReferences checks the references of a block to the state
+	// store and to the previous block: its validators, next validators and
+	// consensus params hashes, and the last block ID of the next block.
+	AuditCheckReferences = "references"
+	// AuditCheckState checks the latest state against the block store: its
+	// height and its last block ID.
+	AuditCheckState = "state"
+)
+
+// AuditFailure is a check of the Auditor that failed, at a height or, for
+// AuditCheckState, for the latest state.
+type AuditFailure struct {
+	Height int64
+	Check  string
+	Err    error
+}
+
+// AuditReport is the result of a run of the Auditor.
+type AuditReport struct {
+	Time     time.Time
+	Heights  []int64 // the audited heights, in increasing order
+	Failures []AuditFailure
+}
+
+// Healthy reports whether the audit found no corruption.
+func (r *AuditReport) Healthy() bool {
+	return len(r.Failures) == 0
+}
+
+// Auditor is a service that periodically audits the block and state stores
+// in the background, so that their corruption is reported, by the metrics
+// and by LastReport, before the node serves or relies on the corrupted data.
+// Each run checks the latest state against the block store, and a random
+// sample of the stored heights, always including the latest one, with the
+// AuditCheckBlock, AuditCheckCommit and AuditCheckReferences checks. The
+// heights backfilled by state sync have only their header and commit stored,
+// so that their blocks and consensus params are not checked.
+type Auditor struct {
+	service.BaseService
+	logger log.Logger
+
+	stateStore Store
+	blockStore BlockStore
+	metrics    *Metrics
+	interval   time.Duration
+	sampleSize int
+	rand       *rand.Rand
+
+	mtx        sync.Mutex
+	lastReport *AuditReport
+}
+
+// NewAuditor returns an Auditor that audits sampleSize heights of the given
+// stores every interval.
+func NewAuditor(
+	logger log.Logger,
+	stateStore Store,
+	blockStore BlockStore,
+	interval time.Duration,
+	sampleSize int,
+	metrics *Metrics,
+) *Auditor {
+	a := &Auditor{
+		logger:     logger,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		metrics:    metrics,
+		interval:   interval,
+		sampleSize: sampleSize,
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec
+	}
+	a.BaseService = *service.NewBaseService(logger, "Auditor", a)
+	return a
+}
+
+// OnStart starts auditing in the background, right away and then every
+// interval. It implements service.Service.
+func (a *Auditor) OnStart(ctx context.Context) error {
+	go a.run(ctx)
+	return nil
+}
+
+// OnStop implements service.Service.
+func (a *Auditor) OnStop() {}
+
+// LastReport returns the report of the last completed audit, or nil if none
+// completed yet.
+func (a *Auditor) LastReport() *AuditReport {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.lastReport
+}
+
+func (a *Auditor) run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		a.audit(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// audit runs the checks on the latest state and on a sample of the heights,
+// and records their report.
+func (a *Auditor) audit(ctx context.Context) {
+	start := time.Now()
+	report := &AuditReport{Time: start}
+	fail := func(height int64, check string, err error) {
+		a.logger.Error("storage audit failed", "height", height, "check", check, "err", err)
+		a.metrics.StorageAuditFailures.With("check", check).Add(1)
+		report.Failures = append(report.Failures, AuditFailure{Height: height, Check: check, Err: err})
+	}
+
+	state, err := a.stateStore.Load()
+	if err != nil {
+		fail(0, AuditCheckState, fmt.Errorf("failed to load state: %w", err))
+	} else if !state.IsEmpty() && a.blockStore.Height() > 0 {
+		if err := recoverLoad(func() error { return a.checkState(state) }); err != nil {
+			fail(state.LastBlockHeight, AuditCheckState, err)
+		}
+	}
+
+	for _, height := range a.sample() {
+		if ctx.Err() != nil {
+			return
+		}
+		errs := map[string]error{
+			AuditCheckBlock:      recoverLoad(func() error { return a.checkBlock(height) }),
+			AuditCheckCommit:     recoverLoad(func() error { return a.checkCommit(state.ChainID, height) }),
+			AuditCheckReferences: recoverLoad(func() error { return a.checkReferences(height) }),
+		}
+		failed := errs[AuditCheckBlock] != nil || errs[AuditCheckCommit] != nil || errs[AuditCheckReferences] != nil
+		// the height may have been pruned while it was audited
+		if failed && height < a.blockStore.Base() {
+			continue
+		}
+		for _, check := range []string{AuditCheckBlock, AuditCheckCommit, AuditCheckReferences} {
+			if errs[check] != nil {
+				fail(height, check, errs[check])
+			}
+		}
+		report.Heights = append(report.Heights, height)
+	}
+
+	a.metrics.StorageAuditDuration.Observe(time.Since(start).Seconds())
+	a.metrics.StorageAuditHeights.Add(float64(len(report.Heights)))
+	if report.Healthy() {
+		a.metrics.StorageCorrupted.Set(0)
+	} else {
+		a.metrics.StorageCorrupted.Set(1)
+	}
+	a.mtx.Lock()
+	a.lastReport = report
+	a.mtx.Unlock()
+}
+
+// recoverLoad calls check, reporting as an error the panic of a store failing
+// to decode its corrupted data.
+func recoverLoad(check func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to load: %v", r)
+		}
+	}()
+	return check()
+}
+
+// isBackfilled reports whether the block of meta was backfilled by state sync,
+// with only its header and commit stored.
+func isBackfilled(meta *types.BlockMeta) bool {
+	return meta.BlockSize == -1
+}
+
+// sample returns up to sampleSize distinct heights of the block store, in
+// increasing order: the latest height and random other ones.
+func (a *Auditor) sample() []int64 {
+	base, height := a.blockStore.Base(), a.blockStore.Height()
+	if height <= 0 || a.sampleSize <= 0 {
+		return nil
+	}
+	if base <= 0 {
+		base = 1
+	}
+	n := a.sampleSize
+	if int64(n) > height-base+1 {
+		n = int(height - base + 1)
+	}
+	picked := map[int64]bool{height: true}
+	for len(picked) < n {
+		picked[base+a.rand.Int63n(height-base)] = true
+	}
+	heights := make([]int64, 0, n)
+	for h := range picked {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+// checkState checks that the latest state is at the height of the block
+// store, or one below while the state of the latest block is not saved yet,
+// and that its last block ID is the one of the stored block.
+func (a *Auditor) checkState(state State) error {
+	height := a.blockStore.Height()
+	if state.LastBlockHeight != height && state.LastBlockHeight != height-1 {
+		return fmt.Errorf("state is at height %d but the block store is at height %d", state.LastBlockHeight, height)
+	}
+	if state.LastBlockHeight < a.blockStore.Base() {
+		return nil
+	}
+	meta := a.blockStore.LoadBlockMeta(state.LastBlockHeight)
+	if meta == nil {
+		return fmt.Errorf("missing block meta of the state height %d", state.LastBlockHeight)
+	}
+	if !meta.BlockID.Equals(state.LastBlockID) {
+		return fmt.Errorf("state has last block ID %v but the block store has %v", state.LastBlockID, meta.BlockID)
+	}
+	return nil
+}
+
+// checkBlock recomputes the hashes of the block at the height.
+func (a *Auditor) checkBlock(height int64) error {
+	meta := a.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return errors.New("missing block meta")
+	}
+	if isBackfilled(meta) {
+		return nil
+	}
+	block := a.blockStore.LoadBlock(height)
+	if block == nil {
+		return errors.New("missing block")
+	}
+	if err := block.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid block: %w", err)
+	}
+	if hash := block.Hash(); !bytes.Equal(hash, meta.BlockID.Hash) {
+		return fmt.Errorf("block hash %X does not match the block ID hash %X", hash, meta.BlockID.Hash)
+	}
+	parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	if err != nil {
+		return fmt.Errorf("failed to make the block parts: %w", err)
+	}
+	if header := parts.Header(); !header.Equals(meta.BlockID.PartSetHeader) {
+		return fmt.Errorf("block part set header %v does not match the block ID %v", header, meta.BlockID.PartSetHeader)
+	}
+	return nil
+}
+
+// checkCommit verifies the commit of the block at the height: the canonical
+// commit, or the seen commit for the latest height.
+func (a *Auditor) checkCommit(chainID string, height int64) error {
+	meta := a.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return errors.New("missing block meta")
+	}
+	commit := a.blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		if seen := a.blockStore.LoadSeenCommit(); seen != nil && seen.Height == height {
+			commit = seen
+		}
+	}
+	if commit == nil {
+		return errors.New("missing commit")
+	}
+	vals, err := a.stateStore.LoadValidators(height)
+	if err != nil {
+		return fmt.Errorf("failed to load validators: %w", err)
+	}
+	if err := vals.VerifyCommit(chainID, meta.BlockID, height, commit); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+	return nil
+}
+
+// checkReferences checks the hashes in the header of the block at the height
+// against the state store, and the last block ID of the next block.
+func (a *Auditor) checkReferences(height int64) error {
+	meta := a.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return errors.New("missing block meta")
+	}
+	vals, err := a.stateStore.LoadValidators(height)
+	if err != nil {
+		return fmt.Errorf("failed to load validators: %w", err)
+	}
+	if hash := vals.Hash(); !bytes.Equal(hash, meta.Header.ValidatorsHash) {
+		return fmt.Errorf("validators hash %X does not match the header %X", hash, meta.Header.ValidatorsHash)
+	}
+	nextVals, err := a.stateStore.LoadValidators(height + 1)
+	if err != nil {
+		return fmt.Errorf("failed to load next validators: %w", err)
+	}
+	if hash := nextVals.Hash(); !bytes.Equal(hash, meta.Header.NextValidatorsHash) {
+		return fmt.Errorf("next validators hash %X does not match the header %X", hash, meta.Header.NextValidatorsHash)
+	}
+	if !isBackfilled(meta) {
+		params, err := a.stateStore.LoadConsensusParams(height)
+		if err != nil {
+			return fmt.Errorf("failed to load consensus params: %w", err)
+		}
+		if hash := params.HashConsensusParams(); !bytes.Equal(hash, meta.Header.ConsensusHash) {
+			return fmt.Errorf("consensus params hash %X does not match the header %X", hash, meta.Header.ConsensusHash)
+		}
+	}
+	if height < a.blockStore.Height() {
+		next := a.blockStore.LoadBlockMeta(height + 1)
+		if next == nil {
+			return errors.New("missing block meta of the next height")
+		}
+		if !next.Header.LastBlockID.Equals(meta.BlockID) {
+			return fmt.Errorf("next block has last block ID %v but the block ID is %v", next.Header.LastBlockID, meta.BlockID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+// tamperedBlockStore is a block store which returns the blocks and commits of
+// the tampered height modified by the tamper functions.
+type tamperedBlockStore struct {
+	*store.BlockStore
+	height int64
+	block  func(*types.Block)
+	commit func(*types.Commit)
+}
+
+func (bs tamperedBlockStore) LoadBlock(height int64) *types.Block {
+	block := bs.BlockStore.LoadBlock(height)
+	if block != nil && height == bs.height && bs.block != nil {
+		bs.block(block)
+	}
+	return block
+}
+
+func (bs tamperedBlockStore) LoadBlockCommit(height int64) *types.Commit {
+	commit := bs.BlockStore.LoadBlockCommit(height)
+	if commit != nil && height == bs.height && bs.commit != nil {
+		bs.commit(commit)
+	}
+	return commit
+}
+
+// makeAuditedChain saves a chain of blocks with valid commits up to the
+// height in a block store and a state store.
+func makeAuditedChain(ctx context.Context, t *testing.T, height int64) (sm.State, sm.Store, *store.BlockStore) {
+	t.Helper()
+	state, stateDB, privVals := makeState(t, 2, 1)
+	stateStore := sm.NewStore(stateDB)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	lastCommit := &types.Commit{}
+	for h := int64(1); h <= height; h++ {
+		block := state.MakeBlock(h, factory.MakeNTxs(h, 2), lastCommit, nil, state.Validators.GetProposer().Address)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		extCommit, _ := makeValidCommit(ctx, t, h, blockID, state.Validators, privVals)
+		lastCommit = extCommit.ToCommit()
+		blockStore.SaveBlock(block, parts, lastCommit)
+
+		state.LastBlockHeight = h
+		state.LastBlockID = blockID
+		state.LastValidators = state.Validators.Copy()
+		require.NoError(t, stateStore.Save(state))
+	}
+	return state, stateStore, blockStore
+}
+
+func TestAuditor(t *testing.T) {
+	testCases := map[string]struct {
+		tamper func(sm.State, sm.Store, *tamperedBlockStore)
+		expect []sm.AuditFailure // without the errors
+	}{
+		"healthy": {nil, nil},
+		"tampered block": {func(_ sm.State, _ sm.Store, bs *tamperedBlockStore) {
+			bs.height = 3
+			bs.block = func(b *types.Block) { b.Data.Txs[0] = types.Tx("tampered") }
+		}, []sm.AuditFailure{{Height: 3, Check: sm.AuditCheckBlock}}},
+		"tampered header": {func(_ sm.State, _ sm.Store, bs *tamperedBlockStore) {
+			bs.height = 2
+			bs.block = func(b *types.Block) { b.Header.AppHash = []byte("tampered") }
+		}, []sm.AuditFailure{{Height: 2, Check: sm.AuditCheckBlock}}},
+		"corrupted block": {func(_ sm.State, _ sm.Store, bs *tamperedBlockStore) {
+			bs.height = 3
+			bs.block = func(b *types.Block) { panic("unmarshal to tmproto.Block failed") }
+		}, []sm.AuditFailure{{Height: 3, Check: sm.AuditCheckBlock}}},
+		"tampered commit": {func(_ sm.State, _ sm.Store, bs *tamperedBlockStore) {
+			bs.height = 4
+			bs.commit = func(c *types.Commit) { c.Signatures[0].Signature[0] ^= 0xff }
+		}, []sm.AuditFailure{{Height: 4, Check: sm.AuditCheckCommit}}},
+		"state not matching the block store": {func(state sm.State, stateStore sm.Store, _ *tamperedBlockStore) {
+			state.LastBlockID = factory.MakeBlockID()
+			require.NoError(t, stateStore.Save(state))
+		}, []sm.AuditFailure{{Height: 5, Check: sm.AuditCheckState}}},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			state, stateStore, blockStore := makeAuditedChain(ctx, t, 5)
+			bs := &tamperedBlockStore{BlockStore: blockStore}
+			if tc.tamper != nil {
+				tc.tamper(state, stateStore, bs)
+			}
+
+			auditor := sm.NewAuditor(log.NewNopLogger(), stateStore, bs, time.Hour, 10, sm.NopMetrics())
+			require.Nil(t, auditor.LastReport())
+			auditor.Audit(ctx)
+			report := auditor.LastReport()
+			require.NotNil(t, report)
+			assert.Equal(t, []int64{1, 2, 3, 4, 5}, report.Heights)
+
+			var failures []sm.AuditFailure
+			for _, f := range report.Failures {
+				require.Error(t, f.Err)
+				failures = append(failures, sm.AuditFailure{Height: f.Height, Check: f.Check})
+			}
+			assert.Equal(t, tc.expect, failures, "%+v", report.Failures)
+			assert.Equal(t, tc.expect == nil, report.Healthy())
+		})
+	}
+}
+
+func TestAuditorBackfilled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the heights up to 3 are backfilled by state sync, with only their header
+	// and commit, and the next ones are synced
+	_, stateStore, chain := makeAuditedChain(ctx, t, 5)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	for h := int64(1); h <= 3; h++ {
+		meta := chain.LoadBlockMeta(h)
+		sh := &types.SignedHeader{Header: &meta.Header, Commit: chain.LoadBlockCommit(h)}
+		require.NoError(t, blockStore.SaveSignedHeader(sh, meta.BlockID))
+	}
+	for h := int64(4); h <= 5; h++ {
+		block := chain.LoadBlock(h)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		seenCommit := chain.LoadBlockCommit(h)
+		if h == 5 {
+			seenCommit = chain.LoadSeenCommit()
+		}
+		blockStore.SaveBlock(block, parts, seenCommit)
+	}
+
+	auditor := sm.NewAuditor(log.NewNopLogger(), stateStore, blockStore, time.Hour, 10, sm.NopMetrics())
+	auditor.Audit(ctx)
+	report := auditor.LastReport()
+	assert.True(t, report.Healthy(), "%+v", report.Failures)
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, report.Heights)
+}
+
+func TestAuditorSample(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, stateStore, blockStore := makeAuditedChain(ctx, t, 10)
+	auditor := sm.NewAuditor(log.NewNopLogger(), stateStore, blockStore, time.Hour, 3, sm.NopMetrics())
+	auditor.Audit(ctx)
+	report := auditor.LastReport()
+	require.True(t, report.Healthy(), "%+v", report.Failures)
+	require.Len(t, report.Heights, 3)
+	assert.Equal(t, int64(10), report.Heights[2], "the latest height is always audited")
+	assert.True(t, report.Heights[0] < report.Heights[1] && report.Heights[1] < report.Heights[2])
+}
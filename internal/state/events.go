@@ -0,0 +1,117 @@
+package state
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// EventTypeInvalidEvents is the type of the event replacing the events of a
+// transaction or block which are not valid, see EventLimits.
+const EventTypeInvalidEvents = "invalid_events"
+
+// EventLimits limits the events of the transactions and blocks of the
+// application that the BlockExecutor publishes, to be indexed and sent to
+// subscribers. Zero values mean no limit. Event types, attribute keys
+// and values must always be valid UTF-8.
+//
+// The events of a transaction, or of a block, which are not valid are replaced
+// by a single EventTypeInvalidEvents event, whose error attribute describes
+// the first violation. The results stored for the block are not changed.
+type EventLimits struct {
+	// MaxEvents is the maximum number of events of a transaction or block.
+	MaxEvents int
+
+	// MaxAttributeKeySize is the maximum size in bytes of the type of an
+	// event and of the key of an attribute.
+	MaxAttributeKeySize int
+
+	// MaxAttributeValueSize is the maximum size in bytes of the value of an
+	// attribute.
+	MaxAttributeValueSize int
+}
+
+// Validate returns an error if the events exceed the limits or are not valid
+// UTF-8, naming the first event or attribute at fault by its index.
+func (l EventLimits) Validate(events []abci.Event) error {
+	if l.MaxEvents > 0 && len(events) > l.MaxEvents {
+		return fmt.Errorf("%d events exceed the maximum of %d", len(events), l.MaxEvents)
+	}
+	for i, event := range events {
+		if err := l.validateKey(event.Type); err != nil {
+			return fmt.Errorf("event %d: type %w", i, err)
+		}
+		for j, attr := range event.Attributes {
+			if err := l.validateKey(attr.Key); err != nil {
+				return fmt.Errorf("event %d attribute %d: key %w", i, j, err)
+			}
+			if l.MaxAttributeValueSize > 0 && len(attr.Value) > l.MaxAttributeValueSize {
+				return fmt.Errorf("event %d attribute %d: value of %d bytes exceeds the maximum of %d",
+					i, j, len(attr.Value), l.MaxAttributeValueSize)
+			}
+			if !utf8.ValidString(attr.Value) {
+				return fmt.Errorf("event %d attribute %d: value is not valid UTF-8", i, j)
+			}
+		}
+	}
+	return nil
+}
+
+func (l EventLimits) validateKey(key string) error {
+	if l.MaxAttributeKeySize > 0 && len(key) > l.MaxAttributeKeySize {
+		return fmt.Errorf("of %d bytes exceeds the maximum of %d", len(key), l.MaxAttributeKeySize)
+	}
+	if !utf8.ValidString(key) {
+		return fmt.Errorf("is not valid UTF-8")
+	}
+	return nil
+}
+
+// sanitize returns the events, or the EventTypeInvalidEvents event replacing
+// them and the violation if they are not valid.
+func (l EventLimits) sanitize(events []abci.Event) ([]abci.Event, error) {
+	err := l.Validate(events)
+	if err == nil {
+		return events, nil
+	}
+	return []abci.Event{{
+		Type: EventTypeInvalidEvents,
+		Attributes: []abci.EventAttribute{
+			{Key: "error", Value: err.Error(), Index: true},
+			{Key: "count", Value: fmt.Sprint(len(events)), Index: false},
+		},
+	}}, err
+}
+
+// sanitizeFinalizeBlock returns the response with the events of the block and
+// of its transactions sanitized. The response is copied if any changes, so that
+// the original one, which is stored, is not modified.
+func (l EventLimits) sanitizeFinalizeBlock(
+	logger log.Logger,
+	height int64,
+	rsp *abci.ResponseFinalizeBlock,
+) *abci.ResponseFinalizeBlock {
+	sanitized := *rsp
+	if events, err := l.sanitize(rsp.Events); err != nil {
+		logger.Error("replacing invalid block events", "height", height, "err", err)
+		sanitized.Events = events
+	}
+	copiedTxResults := false
+	for i, txResult := range rsp.TxResults {
+		events, err := l.sanitize(txResult.Events)
+		if err == nil {
+			continue
+		}
+		logger.Error("replacing invalid tx events", "height", height, "index", i, "err", err)
+		if !copiedTxResults {
+			sanitized.TxResults = append([]*abci.ExecTxResult(nil), rsp.TxResults...)
+			copiedTxResults = true
+		}
+		result := *txResult
+		result.Events = events
+		sanitized.TxResults[i] = &result
+	}
+	return &sanitized
+}
@@ -0,0 +1,84 @@
+package state_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	sm "github.com/tendermint/tendermint/internal/state"
+)
+
+func TestEventLimitsValidate(t *testing.T) {
+	event := func(typ, key, value string) abci.Event {
+		return abci.Event{Type: typ, Attributes: []abci.EventAttribute{{Key: key, Value: value, Index: true}}}
+	}
+	limits := sm.EventLimits{MaxEvents: 2, MaxAttributeKeySize: 8, MaxAttributeValueSize: 16}
+
+	testCases := []struct {
+		name   string
+		limits sm.EventLimits
+		events []abci.Event
+		err    string
+	}{
+		{"no events", limits, nil, ""},
+		{"valid", limits, []abci.Event{event("transfer", "amount", "100"), event("message", "sender", "addr")}, ""},
+		{"too many events", limits, []abci.Event{{}, {}, {}}, "3 events exceed the maximum of 2"},
+		{"type too long", limits, []abci.Event{event("transfers", "amount", "100")}, "event 0: type of 9 bytes"},
+		{"key too long", limits, []abci.Event{{}, event("transfer", "recipient", "addr")}, "event 1 attribute 0: key of 9 bytes"},
+		{"value too long", limits, []abci.Event{event("transfer", "memo", strings.Repeat("a", 17))}, "value of 17 bytes"},
+		{"invalid type", limits, []abci.Event{event("\xff", "key", "value")}, "type is not valid UTF-8"},
+		{"invalid key", limits, []abci.Event{event("type", "\xc3", "value")}, "key is not valid UTF-8"},
+		{"invalid value", limits, []abci.Event{event("type", "key", "va\xe2lue")}, "value is not valid UTF-8"},
+		{"invalid without limits", sm.EventLimits{}, []abci.Event{event("type", "key", "\xff")}, "not valid UTF-8"},
+		{"no limits", sm.EventLimits{}, []abci.Event{event(strings.Repeat("t", 1000), "key", strings.Repeat("v", 1<<20))}, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.limits.Validate(tc.events)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestEventLimitsSanitizeFinalizeBlock(t *testing.T) {
+	valid := []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "amount", Value: "100"}}}}
+	invalid := []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "memo", Value: "\xff"}}}}
+	limits := sm.EventLimits{MaxEvents: 1}
+
+	rsp := &abci.ResponseFinalizeBlock{
+		Events: valid,
+		TxResults: []*abci.ExecTxResult{
+			{Code: 0, Events: valid},
+			{Code: 1, Events: valid},
+		},
+		AppHash: []byte("app_hash"),
+	}
+	require.Equal(t, rsp, limits.SanitizeFinalizeBlock(rsp))
+
+	rsp.Events = invalid
+	rsp.TxResults[1].Events = append(valid, valid...)
+	sanitized := limits.SanitizeFinalizeBlock(rsp)
+	require.Equal(t, []byte("app_hash"), sanitized.AppHash)
+	require.Equal(t, valid, sanitized.TxResults[0].Events)
+	require.Same(t, rsp.TxResults[0], sanitized.TxResults[0])
+	for _, events := range [][]abci.Event{sanitized.Events, sanitized.TxResults[1].Events} {
+		require.Len(t, events, 1)
+		require.Equal(t, sm.EventTypeInvalidEvents, events[0].Type)
+		require.Equal(t, "error", events[0].Attributes[0].Key)
+		require.True(t, events[0].Attributes[0].Index)
+	}
+	require.Equal(t, "2 events exceed the maximum of 1", sanitized.TxResults[1].Events[0].Attributes[0].Value)
+	require.Equal(t, "2", sanitized.TxResults[1].Events[0].Attributes[1].Value)
+	require.EqualValues(t, 1, sanitized.TxResults[1].Code)
+
+	// the response stored for the block is not modified
+	require.Equal(t, invalid, rsp.Events)
+	require.Len(t, rsp.TxResults[1].Events, 2)
+}
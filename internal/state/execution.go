@@ -47,6 +47,31 @@ type BlockExecutor struct {
 
 	// cache the verification results over a single height
 	cache map[string]struct{}
+
+	// the number of goroutines verifying a block
+	verificationWorkers int
+
+	// the limits of the events published for the blocks
+	eventLimits EventLimits
+}
+
+// BlockExecutorOption sets an optional parameter on the BlockExecutor.
+type BlockExecutorOption func(*BlockExecutor)
+
+// BlockExecutorWithVerificationWorkers sets the number of goroutines
+// verifying the blocks received by the users of the BlockExecutor.
+func BlockExecutorWithVerificationWorkers(workers int) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.verificationWorkers = workers
+	}
+}
+
+// BlockExecutorWithEventLimits sets the limits of the events of the
+// application published for the blocks, see EventLimits.
+func BlockExecutorWithEventLimits(limits EventLimits) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.eventLimits = limits
+	}
 }
 
 // NewBlockExecutor returns a new BlockExecutor with the passed-in EventBus.
@@ -59,24 +84,35 @@ func NewBlockExecutor(
 	blockStore BlockStore,
 	eventBus *eventbus.EventBus,
 	metrics *Metrics,
+	options ...BlockExecutorOption,
 ) *BlockExecutor {
-	return &BlockExecutor{
-		eventBus:   eventBus,
-		store:      stateStore,
-		appClient:  appClient,
-		mempool:    pool,
-		evpool:     evpool,
-		logger:     logger,
-		metrics:    metrics,
-		cache:      make(map[string]struct{}),
-		blockStore: blockStore,
-	}
+	blockExec := &BlockExecutor{
+		eventBus:            eventBus,
+		store:               stateStore,
+		appClient:           appClient,
+		mempool:             pool,
+		evpool:              evpool,
+		logger:              logger,
+		metrics:             metrics,
+		cache:               make(map[string]struct{}),
+		blockStore:          blockStore,
+		verificationWorkers: 1,
+	}
+	for _, option := range options {
+		option(blockExec)
+	}
+	return blockExec
 }
 
 func (blockExec *BlockExecutor) Store() Store {
 	return blockExec.store
 }
 
+// VerificationWorkers returns the number of goroutines verifying a block.
+func (blockExec *BlockExecutor) VerificationWorkers() int {
+	return blockExec.verificationWorkers
+}
+
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allcoated for maximum sized evidence.
@@ -132,9 +168,15 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 		return nil, err
 	}
 
+	if n := len(txrSet.AddedTxs()); n != 0 {
+		blockExec.metrics.PrepareProposalTxs.With("action", "added").Add(float64(n))
+	}
+	if n := len(txrSet.RemovedTxs()); n != 0 {
+		blockExec.metrics.PrepareProposalTxs.With("action", "removed").Add(float64(n))
+	}
 	for _, rtx := range txrSet.RemovedTxs() {
 		if err := blockExec.mempool.RemoveTxByKey(rtx.Key()); err != nil {
-			blockExec.logger.Debug("error removing transaction from the mempool", "error", err, "tx hash", rtx.Hash())
+			blockExec.logger.Debug("error removing transaction from the mempool", "error", err, "tx hash", rtx.ID())
 		}
 	}
 	itxs := txrSet.IncludedTxs()
@@ -264,6 +306,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, fmt.Errorf("marshaling TxResults: %w", err)
 	}
 	h := merkle.HashFromByteSlices(rs)
+	nextVals := state.NextValidators
 	state, err = state.Update(blockID, &block.Header, h, fBlockRes.ConsensusParamUpdates, validatorUpdates)
 	if err != nil {
 		return state, fmt.Errorf("commit failed for application: %w", err)
@@ -284,13 +327,10 @@ func (blockExec *BlockExecutor) ApplyBlock(
 		return state, err
 	}
 
-	// Prune old heights, if requested by ABCI app.
+	// Save the retain height requested by the ABCI app, for the Pruner.
 	if retainHeight > 0 {
-		pruned, err := blockExec.pruneBlocks(retainHeight)
-		if err != nil {
-			blockExec.logger.Error("failed to prune blocks", "retain_height", retainHeight, "err", err)
-		} else {
-			blockExec.logger.Debug("pruned blocks", "pruned", pruned, "retain_height", retainHeight)
+		if err := blockExec.saveRetainHeight(retainHeight); err != nil {
+			blockExec.logger.Error("failed to save retain height", "retain_height", retainHeight, "err", err)
 		}
 	}
 
@@ -299,7 +339,7 @@ func (blockExec *BlockExecutor) ApplyBlock(
 
 	// Events are fired after everything else.
 	// NOTE: if we crash between Commit and Save, events wont be fired during replay
-	fireEvents(blockExec.logger, blockExec.eventBus, block, blockID, fBlockRes, validatorUpdates)
+	fireEvents(blockExec.logger, blockExec.eventBus, blockExec.eventLimits, block, blockID, fBlockRes, nextVals, validatorUpdates)
 
 	return state, nil
 }
@@ -600,11 +640,19 @@ func (state State) Update(
 func fireEvents(
 	logger log.Logger,
 	eventBus types.BlockEventPublisher,
+	eventLimits EventLimits,
 	block *types.Block,
 	blockID types.BlockID,
 	finalizeBlockResponse *abci.ResponseFinalizeBlock,
+	nextVals *types.ValidatorSet,
 	validatorUpdates []*types.Validator,
 ) {
+	finalizeBlockResponse = eventLimits.sanitizeFinalizeBlock(logger, block.Height, finalizeBlockResponse)
+	var validatorChanges []types.ValidatorChange
+	if len(finalizeBlockResponse.ValidatorUpdates) > 0 {
+		validatorChanges = ValidatorSetChanges(nextVals, validatorUpdates)
+	}
+
 	if err := eventBus.PublishEventNewBlock(types.EventDataNewBlock{
 		Block:               block,
 		BlockID:             blockID,
@@ -617,6 +665,7 @@ func fireEvents(
 		Header:              block.Header,
 		NumTxs:              int64(len(block.Txs)),
 		ResultFinalizeBlock: *finalizeBlockResponse,
+		ValidatorChanges:    validatorChanges,
 	}); err != nil {
 		logger.Error("failed publishing new block header", "err", err)
 	}
@@ -652,13 +701,49 @@ func fireEvents(
 	}
 
 	if len(finalizeBlockResponse.ValidatorUpdates) > 0 {
-		if err := eventBus.PublishEventValidatorSetUpdates(
-			types.EventDataValidatorSetUpdates{ValidatorUpdates: validatorUpdates}); err != nil {
+		if err := eventBus.PublishEventValidatorSetUpdates(types.EventDataValidatorSetUpdates{
+			ValidatorUpdates: validatorUpdates,
+			Height:           block.Height,
+			Changes:          validatorChanges,
+		}); err != nil {
 			logger.Error("failed publishing event", "err", err)
 		}
 	}
 }
 
+// ValidatorSetChanges returns the changes of the validator set vals made by
+// the updates, leaving out the updates which don't change the voting power of
+// a validator.
+func ValidatorSetChanges(vals *types.ValidatorSet, updates []*types.Validator) []types.ValidatorChange {
+	changes := make([]types.ValidatorChange, 0, len(updates))
+	for _, update := range updates {
+		var previous *types.Validator
+		if vals != nil {
+			_, previous = vals.GetByAddress(update.Address)
+		}
+
+		change := types.ValidatorChange{Validator: update}
+		switch {
+		case previous == nil && update.VotingPower > 0:
+			change.Type = types.ValidatorChangeAdded
+		case previous == nil:
+			continue
+		case update.VotingPower == 0:
+			change.Type = types.ValidatorChangeRemoved
+			change.PreviousPower = previous.VotingPower
+			// the update of a removal only has the public key of the validator
+			change.Validator = types.NewValidator(previous.PubKey, 0)
+		case update.VotingPower != previous.VotingPower:
+			change.Type = types.ValidatorChangePowerChanged
+			change.PreviousPower = previous.VotingPower
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
 //----------------------------------------------------------------------------------------------------
 // Execute block without state. TODO: eliminate
 
@@ -711,7 +796,7 @@ func ExecCommitBlock(
 		}
 
 		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
-		fireEvents(be.logger, be.eventBus, block, blockID, finalizeBlockResponse, validatorUpdates)
+		fireEvents(be.logger, be.eventBus, be.eventLimits, block, blockID, finalizeBlockResponse, s.NextValidators, validatorUpdates)
 	}
 
 	// Commit block
@@ -725,19 +810,16 @@ func ExecCommitBlock(
 	return finalizeBlockResponse.AppHash, nil
 }
 
-func (blockExec *BlockExecutor) pruneBlocks(retainHeight int64) (uint64, error) {
-	base := blockExec.blockStore.Base()
-	if retainHeight <= base {
-		return 0, nil
-	}
-	pruned, err := blockExec.blockStore.PruneBlocks(retainHeight)
+// saveRetainHeight saves the retain height requested by the application, if
+// it is above the one saved before: the heights below it may already be
+// pruned.
+func (blockExec *BlockExecutor) saveRetainHeight(retainHeight int64) error {
+	savedRetainHeight, err := blockExec.store.LoadApplicationRetainHeight()
 	if err != nil {
-		return 0, fmt.Errorf("failed to prune block store: %w", err)
+		return err
 	}
-
-	err = blockExec.Store().PruneStates(retainHeight)
-	if err != nil {
-		return 0, fmt.Errorf("failed to prune state store: %w", err)
+	if retainHeight <= savedRetainHeight {
+		return nil
 	}
-	return pruned, nil
+	return blockExec.store.SaveApplicationRetainHeight(retainHeight)
 }
@@ -3,6 +3,7 @@ package state_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -571,6 +572,43 @@ func TestFinalizeBlockValidatorUpdates(t *testing.T) {
 		assert.Equal(t, pubkey, event.ValidatorUpdates[0].PubKey)
 		assert.EqualValues(t, 10, event.ValidatorUpdates[0].VotingPower)
 	}
+	assert.EqualValues(t, 1, event.Height)
+	if assert.Len(t, event.Changes, 1) {
+		assert.Equal(t, types.ValidatorChangeAdded, event.Changes[0].Type)
+		assert.Equal(t, pubkey, event.Changes[0].Validator.PubKey)
+		assert.EqualValues(t, 0, event.Changes[0].PreviousPower)
+	}
+	assert.Contains(t, msg.Events(), abci.Event{
+		Type: types.EventTypeValidatorChange,
+		Attributes: []abci.EventAttribute{
+			{Key: "type", Value: types.ValidatorChangeAdded, Index: true},
+			{Key: "address", Value: pubkey.Address().String(), Index: true},
+			{Key: "pub_key", Value: fmt.Sprintf("%X", pubkey.Bytes()), Index: true},
+			{Key: "power", Value: "10", Index: true},
+			{Key: "previous_power", Value: "0", Index: true},
+		},
+	})
+}
+
+func TestValidatorSetChanges(t *testing.T) {
+	val1 := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+	val2 := types.NewValidator(ed25519.GenPrivKey().PubKey(), 20)
+	val3 := types.NewValidator(ed25519.GenPrivKey().PubKey(), 30)
+	vals := types.NewValidatorSet([]*types.Validator{val1, val2, val3})
+	added := types.NewValidator(ed25519.GenPrivKey().PubKey(), 5)
+
+	changes := sm.ValidatorSetChanges(vals, []*types.Validator{
+		added,
+		types.NewValidator(val1.PubKey, 0),
+		types.NewValidator(val2.PubKey, 25),
+		types.NewValidator(val3.PubKey, 30),                  // unchanged
+		types.NewValidator(ed25519.GenPrivKey().PubKey(), 0), // not in the set
+	})
+	require.Equal(t, []types.ValidatorChange{
+		{Type: types.ValidatorChangeAdded, Validator: added},
+		{Type: types.ValidatorChangeRemoved, Validator: types.NewValidator(val1.PubKey, 0), PreviousPower: 10},
+		{Type: types.ValidatorChangePowerChanged, Validator: types.NewValidator(val2.PubKey, 25), PreviousPower: 20},
+	}, changes)
 }
 
 // TestFinalizeBlockValidatorUpdatesResultingInEmptySet checks that processing validator updates that
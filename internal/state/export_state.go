@@ -0,0 +1,155 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ExportedState is the state after the block at a height, with the responses
+// of the application to that block, from which the genesis doc of a new chain
+// continuing the exported one, e.g. after a hard fork, is constructed.
+type ExportedState struct {
+	State State
+	// LastResults are the responses to FinalizeBlock of the last block of
+	// the state, nil if they are pruned.
+	LastResults *abci.ResponseFinalizeBlock
+}
+
+// ExportState returns the state after the block at the height, or the latest
+// state if height is 0. The states at past heights are rebuilt from the
+// stored validator sets, consensus params and blocks, as by Rollback, so they
+// must not be pruned.
+func ExportState(bs BlockStore, ss Store, height int64) (*ExportedState, error) {
+	state, err := ss.Load()
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEmpty() {
+		return nil, errors.New("no state found")
+	}
+	if height == 0 {
+		height = state.LastBlockHeight
+	}
+	if height > state.LastBlockHeight || height < state.InitialHeight {
+		return nil, fmt.Errorf("cannot export the state at height %d, outside of the initial height %d "+
+			"and the statestore height %d", height, state.InitialHeight, state.LastBlockHeight)
+	}
+	for state.LastBlockHeight > height {
+		state, err = rollbackState(bs, ss, state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := ss.LoadFinalizeBlockResponses(height)
+	if errors.Is(err, ErrNoFinalizeBlockResponsesForHeight{height}) {
+		results = nil
+	} else if err != nil {
+		return nil, err
+	}
+	return &ExportedState{State: state, LastResults: results}, nil
+}
+
+// ToProto converts the exported state to protobuf.
+func (es *ExportedState) ToProto() (*tmstate.ExportedState, error) {
+	pb, err := es.State.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &tmstate.ExportedState{State: *pb, LastResults: es.LastResults}, nil
+}
+
+// ExportedStateFromProto converts an exported state from protobuf.
+func ExportedStateFromProto(pb *tmstate.ExportedState) (*ExportedState, error) {
+	state, err := FromProto(&pb.State)
+	if err != nil {
+		return nil, err
+	}
+	return &ExportedState{State: *state, LastResults: pb.LastResults}, nil
+}
+
+// Bytes returns the deterministic protobuf encoding of the exported state.
+func (es *ExportedState) Bytes() ([]byte, error) {
+	pb, err := es.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pb)
+}
+
+// MarshalJSON returns the JSON encoding of the protobuf of the exported
+// state, with the fields in order.
+func (es *ExportedState) MarshalJSON() ([]byte, error) {
+	pb, err := es.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	s, err := (&jsonpb.Marshaler{OrigName: true, EmitDefaults: true, Indent: "  "}).MarshalToString(pb)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+// ExportedStateFromBytes decodes an exported state from its protobuf or its
+// JSON encoding.
+func ExportedStateFromBytes(bz []byte) (*ExportedState, error) {
+	pb := new(tmstate.ExportedState)
+	var err error
+	if json.Valid(bz) {
+		err = jsonpb.UnmarshalString(string(bz), pb)
+	} else {
+		err = proto.Unmarshal(bz, pb)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding exported state: %w", err)
+	}
+	return ExportedStateFromProto(pb)
+}
+
+// GenesisDoc constructs the genesis doc of a new chain continuing from the
+// exported state: its initial height is the next height, with the validators,
+// consensus params and app hash of the state. The chain ID defaults to the
+// one of the state, and the genesis time to the time of its last block.
+func (es *ExportedState) GenesisDoc(chainID string, genesisTime time.Time, appState json.RawMessage) (*types.GenesisDoc, error) {
+	state := es.State
+	if chainID == "" {
+		chainID = state.ChainID
+	}
+	if genesisTime.IsZero() {
+		genesisTime = state.LastBlockTime
+	}
+
+	validators := make([]types.GenesisValidator, len(state.Validators.Validators))
+	for i, val := range state.Validators.Validators {
+		validators[i] = types.GenesisValidator{
+			Address: val.Address,
+			PubKey:  val.PubKey,
+			Power:   val.VotingPower,
+			Name:    fmt.Sprintf("validator-%d", i),
+		}
+	}
+	params := state.ConsensusParams
+	genDoc := &types.GenesisDoc{
+		GenesisTime:     genesisTime,
+		ChainID:         chainID,
+		InitialHeight:   state.LastBlockHeight + 1,
+		ConsensusParams: &params,
+		Validators:      validators,
+		AppHash:         state.AppHash,
+		AppState:        appState,
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("invalid genesis doc: %w", err)
+	}
+	return genDoc, nil
+}
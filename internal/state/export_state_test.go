@@ -0,0 +1,117 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestExportState(t *testing.T) {
+	var (
+		height     int64 = 100
+		nextHeight int64 = 101
+	)
+
+	blockStore := &mocks.BlockStore{}
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	results := &abci.ResponseFinalizeBlock{AppHash: initialState.AppHash}
+	require.NoError(t, stateStore.SaveFinalizeBlockResponses(height, results))
+
+	nextState := initialState.Copy()
+	nextState.LastBlockHeight = nextHeight
+	nextState.LastBlockID = factory.MakeBlockID()
+	nextState.AppHash = factory.RandomHash()
+	nextState.LastValidators = initialState.Validators
+	nextState.Validators = initialState.NextValidators
+	nextState.NextValidators = initialState.NextValidators.CopyIncrementProposerPriority(1)
+	require.NoError(t, stateStore.Save(nextState))
+
+	block := &types.BlockMeta{
+		BlockID: initialState.LastBlockID,
+		Header: types.Header{
+			Height:          height,
+			Time:            initialState.LastBlockTime,
+			LastResultsHash: factory.RandomHash(),
+		},
+	}
+	nextBlock := &types.BlockMeta{
+		BlockID: nextState.LastBlockID,
+		Header: types.Header{
+			Height:          nextHeight,
+			AppHash:         initialState.AppHash,
+			LastResultsHash: initialState.LastResultsHash,
+		},
+	}
+	blockStore.On("LoadBlockMeta", height).Return(block)
+	blockStore.On("LoadBlockMeta", nextHeight).Return(nextBlock)
+
+	// the latest state, whose results are pruned
+	exported, err := state.ExportState(blockStore, stateStore, 0)
+	require.NoError(t, err)
+	require.Equal(t, nextState, exported.State)
+	require.Nil(t, exported.LastResults)
+
+	// a past state is rebuilt
+	exported, err = state.ExportState(blockStore, stateStore, height)
+	require.NoError(t, err)
+	require.EqualValues(t, initialState, exported.State)
+	require.Equal(t, results, exported.LastResults)
+
+	// the encodings round trip, and are deterministic
+	pb, err := exported.Bytes()
+	require.NoError(t, err)
+	js, err := exported.MarshalJSON()
+	require.NoError(t, err)
+	for _, bz := range [][]byte{pb, js} {
+		decoded, err := state.ExportedStateFromBytes(bz)
+		require.NoError(t, err)
+		require.EqualValues(t, initialState, decoded.State)
+		require.Equal(t, results.AppHash, decoded.LastResults.AppHash)
+		js2, err := decoded.MarshalJSON()
+		require.NoError(t, err)
+		require.Equal(t, js, js2)
+	}
+
+	_, err = state.ExportState(blockStore, stateStore, nextHeight+1)
+	require.Error(t, err)
+	_, err = state.ExportState(blockStore, stateStore, initialState.InitialHeight-1)
+	require.Error(t, err)
+}
+
+func TestExportedStateGenesisDoc(t *testing.T) {
+	stateStore := setupStateStore(t, 100)
+	st, err := stateStore.Load()
+	require.NoError(t, err)
+	st.LastBlockTime = time.Now().UTC().Truncate(time.Second)
+	exported := &state.ExportedState{State: st}
+
+	genDoc, err := exported.GenesisDoc("", time.Time{}, []byte(`{"accounts":[]}`))
+	require.NoError(t, err)
+	require.Equal(t, st.ChainID, genDoc.ChainID)
+	require.Equal(t, st.LastBlockTime, genDoc.GenesisTime)
+	require.EqualValues(t, 101, genDoc.InitialHeight)
+	require.EqualValues(t, st.AppHash, genDoc.AppHash)
+	require.Equal(t, st.ConsensusParams, *genDoc.ConsensusParams)
+	require.JSONEq(t, `{"accounts":[]}`, string(genDoc.AppState))
+
+	// the new chain starts with the validators of the next block
+	genState, err := state.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	require.Equal(t, st.Validators.Hash(), genState.Validators.Hash())
+
+	genTime := st.LastBlockTime.Add(time.Hour)
+	genDoc, err = exported.GenesisDoc("chain-2", genTime, nil)
+	require.NoError(t, err)
+	require.Equal(t, "chain-2", genDoc.ChainID)
+	require.Equal(t, genTime, genDoc.GenesisTime)
+}
@@ -1,7 +1,11 @@
 package state
 
 import (
+	"context"
+	"time"
+
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -10,3 +14,27 @@ import (
 func ValidateValidatorUpdates(abciUpdates []abci.ValidatorUpdate, params types.ValidatorParams) error {
 	return validateValidatorUpdates(abciUpdates, params)
 }
+
+// Prune is an alias for the prune method of Pruner exported from pruner.go,
+// exclusively and explicitly for testing.
+func (p *Pruner) Prune(ctx context.Context) {
+	p.prune(ctx)
+}
+
+// Audit is an alias for the audit method of Auditor exported from
+// auditor.go, exclusively and explicitly for testing.
+func (a *Auditor) Audit(ctx context.Context) {
+	a.audit(ctx)
+}
+
+// LastCompaction returns the time of the last compaction of the Pruner,
+// exclusively and explicitly for testing.
+func (p *Pruner) LastCompaction() time.Time {
+	return p.lastCompaction
+}
+
+// SanitizeFinalizeBlock is an alias for the sanitizeFinalizeBlock method of
+// EventLimits exported from events.go, exclusively and explicitly for testing.
+func (l EventLimits) SanitizeFinalizeBlock(rsp *abci.ResponseFinalizeBlock) *abci.ResponseFinalizeBlock {
+	return l.sanitizeFinalizeBlock(log.NewNopLogger(), 1, rsp)
+}
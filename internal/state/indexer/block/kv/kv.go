@@ -20,17 +20,34 @@ import (
 
 var _ indexer.BlockIndexer = (*BlockerIndexer)(nil)
 
+// finalizeBlockType is the event type suffix of the FinalizeBlock event keys.
+const finalizeBlockType = "finalize_block"
+
 // BlockerIndexer implements a block indexer, indexing FinalizeBlock
 // events with an underlying KV store. Block events are indexed by their height,
 // such that matching search criteria returns the respective block height(s).
 type BlockerIndexer struct {
-	store dbm.DB
+	store  dbm.DB
+	filter *indexer.EventFilter
 }
 
-func New(store dbm.DB) *BlockerIndexer {
-	return &BlockerIndexer{
+// Option sets an optional parameter on the BlockerIndexer.
+type Option func(*BlockerIndexer)
+
+// WithEventFilter restricts the event attributes that the BlockerIndexer
+// indexes.
+func WithEventFilter(f *indexer.EventFilter) Option {
+	return func(idx *BlockerIndexer) { idx.filter = f }
+}
+
+func New(store dbm.DB, opts ...Option) *BlockerIndexer {
+	idx := &BlockerIndexer{
 		store: store,
 	}
+	for _, opt := range opts {
+		opt(idx)
+	}
+	return idx
 }
 
 // Has returns true if the given height has been indexed. An error is returned
@@ -49,6 +66,7 @@ func (idx *BlockerIndexer) Has(height int64) (bool, error) {
 //
 // primary key: encode(block.height | height) => encode(height)
 // FinalizeBlock events: encode(eventType.eventAttr|eventValue|height|finalize_block) => encode(height)
+// event keys of the block: encode(block_event_keys | height) => encode(event keys)
 func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 	batch := idx.store.NewBatch()
 	defer batch.Close()
@@ -65,18 +83,114 @@ func (idx *BlockerIndexer) Index(bh types.EventDataNewBlockHeader) error {
 	}
 
 	// 2. index FinalizeBlock events
-	if err := idx.indexEvents(batch, bh.ResultFinalizeBlock.Events, "finalize_block", height); err != nil {
+	eventKeys, err := idx.indexEvents(batch, bh.ResultFinalizeBlock.Events, finalizeBlockType, height)
+	if err != nil {
 		return fmt.Errorf("failed to index FinalizeBlock events: %w", err)
 	}
 
+	// 3. index the changes of the validator set made by FinalizeBlock
+	changeKeys, err := idx.indexEvents(batch, bh.ValidatorChangeEvents(), finalizeBlockType, height)
+	if err != nil {
+		return fmt.Errorf("failed to index validator change events: %w", err)
+	}
+
+	// 4. record the event keys of the block by its height, to prune them
+	if eventKeys = append(eventKeys, changeKeys...); len(eventKeys) > 0 {
+		key, err := eventKeysKey(height)
+		if err != nil {
+			return fmt.Errorf("failed to create block event keys key: %w", err)
+		}
+		if err := batch.Set(key, encodeEventKeys(eventKeys)); err != nil {
+			return err
+		}
+	}
+
 	return batch.WriteSync()
 }
 
+// Prune removes the events of all blocks below retainHeight from the index.
+// Block event keys are ordered by event rather than by height, so they are
+// found from the keys recorded for each height as the block was indexed.
+// The events of blocks indexed by an earlier version, which recorded no event
+// keys, are left in the index.
+func (idx *BlockerIndexer) Prune(retainHeight int64) error {
+	var keys [][]byte
+
+	start, err := heightKey(0)
+	if err != nil {
+		return err
+	}
+	end, err := heightKey(retainHeight)
+	if err != nil {
+		return err
+	}
+	if err := idx.iterate(start, end, func(key, _ []byte) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	start, err = eventKeysKey(0)
+	if err != nil {
+		return err
+	}
+	end, err = eventKeysKey(retainHeight)
+	if err != nil {
+		return err
+	}
+	if err := idx.iterate(start, end, func(key, value []byte) error {
+		eventKeys, err := decodeEventKeys(value)
+		if err != nil {
+			return fmt.Errorf("failed to decode block event keys: %w", err)
+		}
+		keys = append(append(keys, key), eventKeys...)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	batch := idx.store.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
+// iterate calls fn with copies of the keys and values of the store in the
+// range [start, end).
+func (idx *BlockerIndexer) iterate(start, end []byte, fn func(key, value []byte) error) error {
+	it, err := idx.store.Iterator(start, end)
+	if err != nil {
+		return err
+	}
+	for ; it.Valid(); it.Next() {
+		if err := fn(append([]byte(nil), it.Key()...), append([]byte(nil), it.Value()...)); err != nil {
+			it.Close()
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		it.Close()
+		return err
+	}
+	return it.Close()
+}
+
 // Search performs a query for block heights that match a given FinalizeBlock
 // The given query can match against zero or more block heights. In the case
 // of height queries, i.e. block.height=H, if the height is indexed, that height
 // alone will be returned. An error and nil slice is returned. Otherwise, a
 // non-nil slice and nil error is returned.
+//
+// Each alternative of a query using OR is resolved separately, and the union
+// of the matching heights is returned in ascending order.
 func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
 	results := make([]int64, 0)
 	select {
@@ -86,27 +200,58 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 	default:
 	}
 
-	conditions := q.Syntax()
-
-	// If there is an exact height query, return the result immediately
-	// (if it exists).
-	height, ok := lookForHeight(conditions)
-	if ok {
-		ok, err := idx.Has(height)
+	matchedHeights := make(map[string][]byte)
+	for _, conditions := range q.Expr() {
+		heights, err := idx.searchConditions(ctx, conditions)
 		if err != nil {
 			return nil, err
 		}
+		for k, v := range heights {
+			matchedHeights[k] = v
+		}
+	}
+
+	// fetch matching heights
+	results = make([]int64, 0, len(matchedHeights))
+heights:
+	for _, hBz := range matchedHeights {
+		h := int64FromBytes(hBz)
 
+		ok, err := idx.Has(h)
+		if err != nil {
+			return nil, err
+		}
 		if ok {
-			return []int64{height}, nil
+			results = append(results, h)
 		}
 
-		return results, nil
+		select {
+		case <-ctx.Done():
+			break heights
+
+		default:
+		}
 	}
 
+	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+
+	return results, nil
+}
+
+// searchConditions returns the encoded heights of the blocks matching all of
+// the given conditions.
+func (idx *BlockerIndexer) searchConditions(ctx context.Context, conditions []syntax.Condition) (map[string][]byte, error) {
 	var heightsInitialized bool
 	filteredHeights := make(map[string][]byte)
 
+	// If there is an exact height query, return the result immediately
+	// (if it exists).
+	height, ok := lookForHeight(conditions)
+	if ok {
+		filteredHeights[string(int64ToBytes(height))] = int64ToBytes(height)
+		return filteredHeights, nil
+	}
+
 	// conditions to skip because they're handled before "everything else"
 	skipIndexes := make([]int, 0)
 
@@ -146,7 +291,7 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 
 	// for all other conditions
 	for i, c := range conditions {
-		if intInSlice(i, skipIndexes) {
+		if c.Not || intInSlice(i, skipIndexes) {
 			continue
 		}
 
@@ -176,31 +321,85 @@ func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64,
 		}
 	}
 
-	// fetch matching heights
-	results = make([]int64, 0, len(filteredHeights))
-heights:
-	for _, hBz := range filteredHeights {
-		h := int64FromBytes(hBz)
+	// Finally, remove the matches of any negated conditions. If there were no
+	// positive conditions to start from, every indexed height is a candidate.
+	for _, c := range conditions {
+		if !c.Not {
+			continue
+		}
+		if !heightsInitialized {
+			all, err := idx.allHeights(ctx)
+			if err != nil {
+				return nil, err
+			}
+			filteredHeights = all
+			heightsInitialized = true
+		}
+		if len(filteredHeights) == 0 {
+			break
+		}
 
-		ok, err := idx.Has(h)
+		excluded, err := idx.matchCondition(ctx, c)
 		if err != nil {
 			return nil, err
 		}
-		if ok {
-			results = append(results, h)
+		for k := range excluded {
+			delete(filteredHeights, k)
 		}
+	}
 
-		select {
-		case <-ctx.Done():
-			break heights
+	return filteredHeights, nil
+}
 
-		default:
+// matchCondition returns the heights matching the positive form of the
+// single condition c.
+func (idx *BlockerIndexer) matchCondition(ctx context.Context, c syntax.Condition) (map[string][]byte, error) {
+	if c.Tag == types.BlockHeightKey && c.Op == syntax.TEq {
+		h := int64ToBytes(int64(c.Arg.Number()))
+		return map[string][]byte{string(h): h}, nil
+	}
+
+	if indexer.IsRangeOperation(c.Op) {
+		qr := indexer.RangeForCondition(c)
+		prefix, err := orderedcode.Append(nil, qr.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prefix key: %w", err)
 		}
+		return idx.matchRange(ctx, qr, prefix, nil, true)
 	}
 
-	sort.Slice(results, func(i, j int) bool { return results[i] < results[j] })
+	startKey, err := orderedcode.Append(nil, c.Tag, c.Arg.Value())
+	if err != nil {
+		return nil, err
+	}
+	return idx.match(ctx, c, startKey, nil, true)
+}
 
-	return results, nil
+// allHeights returns the encoded heights of all indexed blocks.
+func (idx *BlockerIndexer) allHeights(ctx context.Context) (map[string][]byte, error) {
+	prefix, err := orderedcode.Append(nil, types.BlockHeightKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prefix key: %w", err)
+	}
+
+	it, err := dbm.IteratePrefix(idx.store, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prefix iterator: %w", err)
+	}
+	defer it.Close()
+
+	heights := make(map[string][]byte)
+	for ; it.Valid(); it.Next() {
+		heights[string(it.Value())] = it.Value()
+
+		if err := ctx.Err(); err != nil {
+			break
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return heights, nil
 }
 
 // matchRange returns all matching block heights that match a given QueryRange
@@ -448,8 +647,9 @@ func (idx *BlockerIndexer) match(
 	return filteredHeights, nil
 }
 
-func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ string, height int64) error {
+func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ string, height int64) ([][]byte, error) {
 	heightBz := int64ToBytes(height)
+	var keys [][]byte
 
 	for _, event := range events {
 		// only index events with a non-empty type
@@ -462,24 +662,26 @@ func (idx *BlockerIndexer) indexEvents(batch dbm.Batch, events []abci.Event, typ
 				continue
 			}
 
-			// index iff the event specified index:true and it's not a reserved event
+			// index iff the event specified index:true, it's not a reserved
+			// event and the filter allows it
 			compositeKey := fmt.Sprintf("%s.%s", event.Type, attr.Key)
 			if compositeKey == types.BlockHeightKey {
-				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeKey)
+				return nil, fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeKey)
 			}
 
-			if attr.GetIndex() {
+			if attr.GetIndex() && idx.filter.IndexAttribute(event.Type, attr.Key) {
 				key, err := eventKey(compositeKey, typ, attr.Value, height)
 				if err != nil {
-					return fmt.Errorf("failed to create block index key: %w", err)
+					return nil, fmt.Errorf("failed to create block index key: %w", err)
 				}
 
 				if err := batch.Set(key, heightBz); err != nil {
-					return err
+					return nil, err
 				}
+				keys = append(keys, key)
 			}
 		}
 	}
 
-	return nil
+	return keys, nil
 }
@@ -9,6 +9,7 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/internal/pubsub/query"
 	blockidxkv "github.com/tendermint/tendermint/internal/state/indexer/block/kv"
 	"github.com/tendermint/tendermint/types"
@@ -17,9 +18,13 @@ import (
 func TestBlockIndexer(t *testing.T) {
 	store := dbm.NewPrefixDB(dbm.NewMemDB(), []byte("block_events"))
 	indexer := blockidxkv.New(store)
+	val := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
 
 	require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
 		Header: types.Header{Height: 1},
+		ValidatorChanges: []types.ValidatorChange{
+			{Type: types.ValidatorChangeAdded, Validator: val},
+		},
 		ResultFinalizeBlock: abci.ResponseFinalizeBlock{
 			Events: []abci.Event{
 				{
@@ -120,6 +125,34 @@ func TestBlockIndexer(t *testing.T) {
 			q:       query.MustCompile(`finalize_event1.proposer CONTAINS 'FCAA001'`),
 			results: []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
 		},
+		"block.height = 3 OR finalize_event2.foo >= 100": {
+			q:       query.MustCompile(`block.height = 3 OR finalize_event2.foo >= 100`),
+			results: []int64{1, 3},
+		},
+		"block.height < 3 OR finalize_event2.foo = 10": {
+			q:       query.MustCompile(`block.height < 3 OR finalize_event2.foo = 10`),
+			results: []int64{1, 2, 10},
+		},
+		"finalize_event2.foo EXISTS AND NOT finalize_event2.foo > 4": {
+			q:       query.MustCompile(`finalize_event2.foo EXISTS AND NOT finalize_event2.foo > 4`),
+			results: []int64{2, 4},
+		},
+		"NOT finalize_event2.foo EXISTS": {
+			q:       query.MustCompile(`NOT finalize_event2.foo EXISTS`),
+			results: []int64{3, 5, 7, 9, 11},
+		},
+		"block.height > 8 AND NOT block.height = 10": {
+			q:       query.MustCompile(`block.height > 8 AND NOT block.height = 10`),
+			results: []int64{9, 11},
+		},
+		"validator_change.address = <address>": {
+			q:       query.MustCompile(fmt.Sprintf(`validator_change.address = '%s'`, val.Address)),
+			results: []int64{1},
+		},
+		"validator_change.type = 'removed'": {
+			q:       query.MustCompile(`validator_change.type = 'removed'`),
+			results: []int64{},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -134,3 +167,49 @@ func TestBlockIndexer(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockIndexerPrune(t *testing.T) {
+	store := dbm.NewMemDB()
+	indexer := blockidxkv.New(store)
+
+	for i := int64(1); i <= 10; i++ {
+		require.NoError(t, indexer.Index(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: i},
+			ResultFinalizeBlock: abci.ResponseFinalizeBlock{
+				Events: []abci.Event{{
+					Type: "finalize_event",
+					Attributes: []abci.EventAttribute{
+						{Key: "proposer", Value: "FCAA001", Index: true},
+						{Key: "foo", Value: fmt.Sprint(i), Index: true},
+					},
+				}},
+			},
+		}))
+	}
+
+	require.NoError(t, indexer.Prune(6))
+
+	ctx := context.Background()
+	for _, q := range []string{"finalize_event.proposer = 'FCAA001'", "block.height > 0", "finalize_event.foo >= 1"} {
+		results, err := indexer.Search(ctx, query.MustCompile(q))
+		require.NoError(t, err)
+		require.Equal(t, []int64{6, 7, 8, 9, 10}, results, q)
+	}
+	for i := int64(1); i <= 10; i++ {
+		ok, err := indexer.Has(i)
+		require.NoError(t, err)
+		require.Equal(t, i >= 6, ok, "height %d", i)
+	}
+
+	// Each retained block keeps its height key, its two event keys and the
+	// record of those, and nothing is left of the pruned ones.
+	it, err := store.Iterator(nil, nil)
+	require.NoError(t, err)
+	defer it.Close()
+	var n int
+	for ; it.Valid(); it.Next() {
+		n++
+	}
+	require.NoError(t, it.Error())
+	require.Equal(t, 5*4, n)
+}
@@ -86,9 +86,41 @@ func parseValueFromEventKey(key []byte) (string, error) {
 	return eventValue, nil
 }
 
+// blockEventKeysKey prefixes the keys recording the event keys of each block.
+const blockEventKeysKey = "block_event_keys"
+
+func eventKeysKey(height int64) ([]byte, error) {
+	return orderedcode.Append(
+		nil,
+		blockEventKeysKey,
+		height,
+	)
+}
+
+func encodeEventKeys(keys [][]byte) []byte {
+	var bz []byte
+	for _, key := range keys {
+		bz, _ = orderedcode.Append(bz, string(key))
+	}
+	return bz
+}
+
+func decodeEventKeys(bz []byte) ([][]byte, error) {
+	var keys [][]byte
+	for remaining := string(bz); len(remaining) > 0; {
+		var key string
+		var err error
+		if remaining, err = orderedcode.Parse(remaining, &key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, []byte(key))
+	}
+	return keys, nil
+}
+
 func lookForHeight(conditions []syntax.Condition) (int64, bool) {
 	for _, c := range conditions {
-		if c.Tag == types.BlockHeightKey && c.Op == syntax.TEq {
+		if c.Tag == types.BlockHeightKey && c.Op == syntax.TEq && !c.Not {
 			return int64(c.Arg.Number()), true
 		}
 	}
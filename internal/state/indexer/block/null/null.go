@@ -25,3 +25,8 @@ func (idx *BlockerIndexer) Index(types.EventDataNewBlockHeader) error {
 func (idx *BlockerIndexer) Search(ctx context.Context, q *query.Query) ([]int64, error) {
 	return []int64{}, nil
 }
+
+// Prune is a noop and always returns nil.
+func (idx *BlockerIndexer) Prune(retainHeight int64) error {
+	return nil
+}
@@ -20,6 +20,15 @@ Example:
 	[tx-index]
 	indexer = ["kv", "psql"]
 
+Additional sinks (for example Elasticsearch or ClickHouse) can be plugged in
+by registering a factory with the sink registry, typically from an init
+function, and then selecting it with the "custom:" prefix:
+
+	sink.MustRegister("elasticsearch", newElasticSink)
+
+	[tx-index]
+	indexer = ["kv", "custom:elasticsearch"]
+
 If an operator wants to completely disable indexing, they may simply just provide
 the "null" sink option in the configuration. All other sinks will be ignored if
 "null" is provided.
@@ -39,15 +48,13 @@ must ensure the following:
 
 1. The "psql" indexing sink is provided in Tendermint's configuration.
 2. A 'tx-index.psql-conn' value is provided that contains the PostgreSQL connection URI.
-3. The block and transaction event schemas have been created in the PostgreSQL database.
-
-Tendermint provides the block and transaction event schemas in the following
-path: state/indexer/sink/psql/schema.sql
 
-To create the schema in a PostgreSQL database, perform the schema query
-manually or invoke schema creation via the CLI:
+The block and transaction event schemas are defined by the versioned migrations
+in state/indexer/sink/psql/migrations. The sink applies any pending migrations
+when it connects to the database, unless 'tx-index.psql-no-migrate' is set, in
+which case the operator must apply them by hand, in order:
 
-	$ psql <flags> -f state/indexer/sink/psql/schema.sql
+	$ psql <flags> -f state/indexer/sink/psql/migrations/0001_initial_schema.sql
 
 The "psql" indexing sink prohibits queries via RPC. When using a PostgreSQL sink,
 queries can and should be made directly against the database using SQL.
@@ -0,0 +1,88 @@
+package indexer
+
+import (
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// EventFilter restricts which ABCI events and attributes are indexed. Each
+// rule is either an event type (e.g. "transfer"), which covers every
+// attribute of that type, or a composite key (e.g. "transfer.amount"), which
+// covers a single attribute.
+//
+// When the include list is non-empty, only attributes covered by one of its
+// rules are indexed. Attributes covered by a rule of the exclude list are
+// never indexed. A nil *EventFilter indexes everything.
+type EventFilter struct {
+	include map[string]bool
+	exclude map[string]bool
+}
+
+// NewEventFilter constructs a filter from the given include and exclude
+// rules. It returns nil, which indexes everything, if both are empty.
+func NewEventFilter(include, exclude []string) *EventFilter {
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+	return &EventFilter{
+		include: ruleSet(include),
+		exclude: ruleSet(exclude),
+	}
+}
+
+func ruleSet(rules []string) map[string]bool {
+	set := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			set[rule] = true
+		}
+	}
+	return set
+}
+
+// IndexAttribute reports whether the attribute with the given key of an event
+// of the given type may be indexed.
+func (f *EventFilter) IndexAttribute(eventType, key string) bool {
+	if f == nil {
+		return true
+	}
+	compositeKey := eventType + "." + key
+	if f.exclude[eventType] || f.exclude[compositeKey] {
+		return false
+	}
+	return len(f.include) == 0 || f.include[eventType] || f.include[compositeKey]
+}
+
+// Filter returns the events with the attributes that may not be indexed
+// removed. Events whose attributes were all removed are dropped, unless their
+// type is explicitly included. The input slice and events are not modified.
+func (f *EventFilter) Filter(events []abci.Event) []abci.Event {
+	if f == nil {
+		return events
+	}
+
+	out := make([]abci.Event, 0, len(events))
+	for _, event := range events {
+		if f.exclude[event.Type] {
+			continue
+		}
+
+		attrs := make([]abci.EventAttribute, 0, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			if f.IndexAttribute(event.Type, attr.Key) {
+				attrs = append(attrs, attr)
+			}
+		}
+		switch {
+		case len(attrs) == len(event.Attributes):
+			if len(attrs) == 0 && len(f.include) != 0 && !f.include[event.Type] {
+				continue
+			}
+			out = append(out, event)
+		case len(attrs) != 0 || f.include[event.Type]:
+			out = append(out, abci.Event{Type: event.Type, Attributes: attrs})
+		}
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+)
+
+func TestEventFilter(t *testing.T) {
+	events := []abci.Event{
+		{Type: "transfer", Attributes: []abci.EventAttribute{
+			{Key: "sender", Value: "a", Index: true},
+			{Key: "memo", Value: "m", Index: true},
+		}},
+		{Type: "message", Attributes: []abci.EventAttribute{
+			{Key: "action", Value: "send", Index: true},
+		}},
+		{Type: "marker"},
+	}
+
+	testCases := []struct {
+		name             string
+		include, exclude []string
+		want             []abci.Event
+	}{
+		{"no rules", nil, nil, events},
+		{"exclude type", nil, []string{"message"}, []abci.Event{events[0], events[2]}},
+		{"exclude attribute", nil, []string{"transfer.memo"}, []abci.Event{
+			{Type: "transfer", Attributes: events[0].Attributes[:1]},
+			events[1],
+			events[2],
+		}},
+		{"include attribute", []string{"transfer.sender"}, nil, []abci.Event{
+			{Type: "transfer", Attributes: events[0].Attributes[:1]},
+		}},
+		{"include type", []string{"message", "marker"}, nil, []abci.Event{events[1], events[2]}},
+		{"exclude wins", []string{"transfer"}, []string{"transfer.sender"}, []abci.Event{
+			{Type: "transfer", Attributes: events[0].Attributes[1:]},
+		}},
+		{"all attributes excluded", nil, []string{"message.action"}, []abci.Event{events[0], events[2]}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := indexer.NewEventFilter(tc.include, tc.exclude)
+			assert.Equal(t, tc.want, f.Filter(events))
+		})
+	}
+
+	assert.Nil(t, indexer.NewEventFilter(nil, []string{}))
+	var f *indexer.EventFilter
+	assert.True(t, f.IndexAttribute("any", "key"))
+}
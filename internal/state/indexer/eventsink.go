@@ -8,6 +8,9 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
+// EventSinkType identifies the implementation of an EventSink. Custom sinks
+// selected through the sink registry report their own type, conventionally
+// the name they were registered under.
 type EventSinkType string
 
 const (
@@ -54,3 +57,12 @@ type EventSink interface {
 	// Stop will close the data store connection, if the eventsink supports it.
 	Stop() error
 }
+
+// Pruner is an optional interface implemented by event sinks that can delete
+// the indexed data of old blocks. The indexer service uses it to bound the
+// size of the index, see ServiceArgs.RetainBlocks.
+type Pruner interface {
+	// Prune removes the block and transaction events of all blocks below
+	// retainHeight.
+	Prune(retainHeight int64) error
+}
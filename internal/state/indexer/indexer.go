@@ -22,6 +22,10 @@ type TxIndexer interface {
 
 	// Search allows you to query for transactions.
 	Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error)
+
+	// Prune removes the transactions of all blocks below retainHeight from
+	// the index.
+	Prune(retainHeight int64) error
 }
 
 // BlockIndexer defines an interface contract for indexing block events.
@@ -36,6 +40,10 @@ type BlockIndexer interface {
 	// Search performs a query for block heights that match a given FinalizeBlock
 	// event search criteria.
 	Search(ctx context.Context, q *query.Query) ([]int64, error)
+
+	// Prune removes the events of all blocks below retainHeight from the
+	// index.
+	Prune(retainHeight int64) error
 }
 
 // Batch groups together multiple Index operations to be performed at the same time.
@@ -2,6 +2,7 @@ package indexer
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -27,8 +28,36 @@ type Service struct {
 		height int64
 		batch  *Batch
 	}
+
+	// When async is set, completed blocks are handed to a per-sink worker
+	// through a bounded queue rather than being indexed by the publisher.
+	async     bool
+	queueSize int
+	queues    []chan queuedBlock
+	quit      chan struct{}
+	workers   sync.WaitGroup
+
+	// Sinks implementing Pruner are pruned every pruneInterval blocks below
+	// the retain height, see ServiceArgs.
+	retainBlocks   int64
+	blockStoreBase func() int64
+}
+
+// queuedBlock is a complete block awaiting asynchronous indexing.
+type queuedBlock struct {
+	header types.EventDataNewBlockHeader
+	ops    []*abci.TxResult
+	queued time.Time
 }
 
+// DefaultQueueSize is the default number of complete blocks buffered for
+// each sink when indexing asynchronously.
+const DefaultQueueSize = 100
+
+// pruneInterval is the number of blocks between prunings of the index, so
+// that the cost of a pruning pass is amortized over many blocks.
+const pruneInterval = 100
+
 // NewService constructs a new indexer service from the given arguments.
 func NewService(args ServiceArgs) *Service {
 	is := &Service{
@@ -36,10 +65,18 @@ func NewService(args ServiceArgs) *Service {
 		eventSinks: args.Sinks,
 		eventBus:   args.EventBus,
 		metrics:    args.Metrics,
+		async:      args.Async,
+		queueSize:  args.QueueSize,
+
+		retainBlocks:   args.RetainBlocks,
+		blockStoreBase: args.BlockStoreBase,
 	}
 	if is.metrics == nil {
 		is.metrics = NopMetrics()
 	}
+	if is.queueSize <= 0 {
+		is.queueSize = DefaultQueueSize
+	}
 	is.BaseService = *service.NewBaseService(args.Logger, "IndexerService", is)
 	return is
 }
@@ -83,37 +120,15 @@ func (is *Service) publish(msg pubsub.Message) error {
 
 	if curr.Pending == 0 {
 		// INDEX: We have all the transactions we expect for the current block.
-		for _, sink := range is.eventSinks {
-			start := time.Now()
-			if err := sink.IndexBlockEvents(is.currentBlock.header); err != nil {
-				is.logger.Error("failed to index block header",
-					"height", is.currentBlock.height, "err", err)
-			} else {
-				is.metrics.BlockEventsSeconds.Observe(time.Since(start).Seconds())
-				is.metrics.BlocksIndexed.Add(1)
-				is.logger.Debug("indexed block",
-					"height", is.currentBlock.height, "sink", sink.Type())
-			}
-
-			if curr.Size() != 0 {
-				start := time.Now()
-
-				var err error
-				curr.Ops, err = DeduplicateBatch(curr.Ops, sink)
-				if err != nil {
-					is.logger.Error("failed to deduplicate batch", "height", is.currentBlock.height, "error", err)
-				}
-
-				err = sink.IndexTxEvents(curr.Ops)
-				if err != nil {
-					is.logger.Error("failed to index block txs",
-						"height", is.currentBlock.height, "err", err)
-				} else {
-					is.metrics.TxEventsSeconds.Observe(time.Since(start).Seconds())
-					is.metrics.TransactionsIndexed.Add(float64(curr.Size()))
-					is.logger.Debug("indexed txs",
-						"height", is.currentBlock.height, "sink", sink.Type())
-				}
+		if is.async {
+			is.enqueue(queuedBlock{
+				header: is.currentBlock.header,
+				ops:    curr.Ops,
+				queued: time.Now(),
+			})
+		} else {
+			for _, sink := range is.eventSinks {
+				is.indexBlock(sink, is.currentBlock.header, curr.Ops)
 			}
 		}
 		is.currentBlock.batch = nil // return to the WAIT state for the next block
@@ -122,6 +137,142 @@ func (is *Service) publish(msg pubsub.Message) error {
 	return nil
 }
 
+// indexBlock writes the events of a single complete block to sink. Errors are
+// logged rather than reported, so that one failing sink does not prevent the
+// others from indexing the block.
+func (is *Service) indexBlock(sink EventSink, header types.EventDataNewBlockHeader, ops []*abci.TxResult) {
+	height := header.Header.Height
+	sinkType := string(sink.Type())
+	indexed := true
+
+	start := time.Now()
+	if err := sink.IndexBlockEvents(header); err != nil {
+		indexed = false
+		is.metrics.SinkErrors.With("sink", sinkType, "op", "block").Add(1)
+		is.logger.Error("failed to index block header",
+			"height", height, "sink", sinkType, "err", err)
+	} else {
+		is.metrics.BlockEventsSeconds.With("sink", sinkType).Observe(time.Since(start).Seconds())
+		is.metrics.BlocksIndexed.With("sink", sinkType).Add(1)
+		is.logger.Debug("indexed block",
+			"height", height, "sink", sinkType)
+	}
+
+	if len(ops) != 0 {
+		start := time.Now()
+
+		deduped, err := DeduplicateBatch(ops, sink)
+		if err != nil {
+			is.logger.Error("failed to deduplicate batch", "height", height, "error", err)
+		}
+
+		err = sink.IndexTxEvents(deduped)
+		if err != nil {
+			indexed = false
+			is.metrics.SinkErrors.With("sink", sinkType, "op", "tx").Add(1)
+			is.logger.Error("failed to index block txs",
+				"height", height, "sink", sinkType, "err", err)
+		} else {
+			is.metrics.TxEventsSeconds.With("sink", sinkType).Observe(time.Since(start).Seconds())
+			is.metrics.TransactionsIndexed.With("sink", sinkType).Add(float64(len(deduped)))
+			is.logger.Debug("indexed txs",
+				"height", height, "sink", sinkType)
+		}
+	}
+
+	if indexed {
+		is.metrics.IndexedHeight.With("sink", sinkType).Set(float64(height))
+	}
+	if height%pruneInterval == 0 {
+		is.prune(sink, height)
+	}
+}
+
+// retainHeight returns the lowest height whose index data must be kept after
+// indexing the block at height, or 0 if nothing is to be pruned.
+func (is *Service) retainHeight(height int64) int64 {
+	var retain int64
+	if is.retainBlocks > 0 {
+		retain = height - is.retainBlocks + 1
+	}
+	if is.blockStoreBase != nil {
+		if base := is.blockStoreBase(); base > retain {
+			retain = base
+		}
+	}
+	if retain <= 1 {
+		return 0
+	}
+	return retain
+}
+
+// prune removes the index data below the retain height from sink, if it
+// supports pruning.
+func (is *Service) prune(sink EventSink, height int64) {
+	pruner, ok := sink.(Pruner)
+	if !ok {
+		return
+	}
+	retain := is.retainHeight(height)
+	if retain == 0 {
+		return
+	}
+
+	sinkType := string(sink.Type())
+	start := time.Now()
+	if err := pruner.Prune(retain); err != nil {
+		is.metrics.SinkErrors.With("sink", sinkType, "op", "prune").Add(1)
+		is.logger.Error("failed to prune index",
+			"retain_height", retain, "sink", sinkType, "err", err)
+		return
+	}
+	is.metrics.PruneSeconds.With("sink", sinkType).Observe(time.Since(start).Seconds())
+	is.logger.Debug("pruned index", "retain_height", retain,
+		"sink", sinkType, "duration", time.Since(start))
+}
+
+// enqueue hands blk to the worker of every sink. If a sink's queue is full,
+// enqueue blocks until the worker catches up, applying backpressure to the
+// publisher rather than growing without bound.
+func (is *Service) enqueue(blk queuedBlock) {
+	for i, q := range is.queues {
+		sinkType := string(is.eventSinks[i].Type())
+		select {
+		case q <- blk:
+		default:
+			is.logger.Info("indexer queue is full, waiting for sink",
+				"height", blk.header.Header.Height, "sink", sinkType)
+			select {
+			case q <- blk:
+			case <-is.quit:
+				return
+			}
+		}
+		is.metrics.QueueDepth.With("sink", sinkType).Set(float64(len(q)))
+	}
+}
+
+// runWorker indexes the blocks delivered on q into sink until the service
+// stops. Blocks are indexed in the order they were queued.
+func (is *Service) runWorker(sink EventSink, q <-chan queuedBlock) {
+	defer is.workers.Done()
+	sinkType := string(sink.Type())
+	for {
+		select {
+		case <-is.quit:
+			if n := len(q); n != 0 {
+				is.logger.Info("indexer stopped with blocks still queued; use reindex-event to backfill",
+					"sink", sinkType, "blocks", n)
+			}
+			return
+		case blk := <-q:
+			is.metrics.QueueDepth.With("sink", sinkType).Set(float64(len(q)))
+			is.indexBlock(sink, blk.header, blk.ops)
+			is.metrics.LagSeconds.With("sink", sinkType).Observe(time.Since(blk.queued).Seconds())
+		}
+	}
+}
+
 // OnStart implements part of service.Service. It registers an observer for the
 // indexer if the underlying event sinks support indexing.
 //
@@ -130,6 +281,16 @@ func (is *Service) OnStart(ctx context.Context) error {
 	// If the event sinks support indexing, register an observer to capture
 	// block header data for the indexer.
 	if IndexingEnabled(is.eventSinks) {
+		if is.async {
+			is.quit = make(chan struct{})
+			is.queues = make([]chan queuedBlock, len(is.eventSinks))
+			for i, sink := range is.eventSinks {
+				is.queues[i] = make(chan queuedBlock, is.queueSize)
+				is.workers.Add(1)
+				go is.runWorker(sink, is.queues[i])
+			}
+		}
+
 		err := is.eventBus.Observe(ctx, is.publish,
 			types.EventQueryNewBlockHeader, types.EventQueryTx)
 		if err != nil {
@@ -139,8 +300,13 @@ func (is *Service) OnStart(ctx context.Context) error {
 	return nil
 }
 
-// OnStop implements service.Service by closing the event sinks.
+// OnStop implements service.Service by stopping any indexing workers and
+// closing the event sinks.
 func (is *Service) OnStop() {
+	if is.quit != nil {
+		close(is.quit)
+		is.workers.Wait()
+	}
 	for _, sink := range is.eventSinks {
 		if err := sink.Stop(); err != nil {
 			is.logger.Error("failed to close eventsink", "eventsink", sink.Type(), "err", err)
@@ -154,6 +320,20 @@ type ServiceArgs struct {
 	EventBus *eventbus.EventBus
 	Metrics  *Metrics
 	Logger   log.Logger
+
+	// If Async is true, blocks are indexed by a separate worker for each
+	// sink, so a slow sink does not delay the publisher until its queue of
+	// QueueSize blocks (default DefaultQueueSize) fills up.
+	Async     bool
+	QueueSize int
+
+	// If RetainBlocks is positive, the data indexed for blocks older than
+	// the latest RetainBlocks blocks is periodically pruned from the sinks
+	// that support it. If BlockStoreBase is set, data for blocks below the
+	// base it reports, e.g. those pruned at the application's retain height,
+	// is pruned as well.
+	RetainBlocks   int64
+	BlockStoreBase func() int64
 }
 
 // KVSinkEnabled returns the given eventSinks is containing KVEventSink.
@@ -168,9 +348,10 @@ func KVSinkEnabled(sinks []EventSink) bool {
 }
 
 // IndexingEnabled returns the given eventSinks is supporting the indexing services.
+// Any sink other than the null sink, including custom sinks, supports indexing.
 func IndexingEnabled(sinks []EventSink) bool {
 	for _, sink := range sinks {
-		if sink.Type() == KV || sink.Type() == PSQL {
+		if sink.Type() != NULL {
 			return true
 		}
 	}
@@ -189,7 +370,7 @@ func DeduplicateBatch(ops []*abci.TxResult, sink EventSink) ([]*abci.TxResult, e
 	var successfulTxsInThisBlock = make(map[string]struct{})
 
 	for _, txResult := range ops {
-		hash := types.Tx(txResult.Tx).Hash()
+		hash := types.Tx(txResult.Tx).ID()
 
 		if txResult.Result.IsOK() {
 			successfulTxsInThisBlock[string(hash)] = struct{}{}
@@ -5,10 +5,11 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
-	"github.com/adlio/schema"
+	"github.com/go-kit/kit/metrics"
 	"github.com/ory/dockertest"
 	"github.com/ory/dockertest/docker"
 	"github.com/stretchr/testify/assert"
@@ -109,6 +110,150 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	assert.Nil(t, teardown(t, pool))
 }
 
+// slowSink wraps an event sink so that each block index operation must be
+// released by the test.
+type slowSink struct {
+	indexer.EventSink
+	release chan struct{}
+}
+
+func (s slowSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	<-s.release
+	return s.EventSink.IndexBlockEvents(h)
+}
+
+func TestIndexerServiceAsync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := tmlog.NewNopLogger()
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+	t.Cleanup(eventBus.Wait)
+
+	fast := kv.NewEventSink(dbm.NewMemDB())
+	slow := slowSink{EventSink: kv.NewEventSink(dbm.NewMemDB()), release: make(chan struct{})}
+
+	service := indexer.NewService(indexer.ServiceArgs{
+		Logger:    logger,
+		Sinks:     []indexer.EventSink{fast, slow},
+		EventBus:  eventBus,
+		Async:     true,
+		QueueSize: 4,
+	})
+	require.NoError(t, service.Start(ctx))
+	t.Cleanup(service.Wait)
+
+	// Publishing must not wait for the slow sink while its queue has room.
+	const numBlocks = 3
+	for h := int64(1); h <= numBlocks; h++ {
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+			NumTxs: 1,
+		}))
+		require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: h,
+			Tx:     types.Tx(fmt.Sprintf("tx%d", h)),
+			Result: abci.ExecTxResult{Code: abci.CodeTypeOK},
+		}}))
+	}
+
+	// The fast sink catches up independently of the slow one.
+	require.Eventually(t, func() bool {
+		ok, err := fast.HasBlock(numBlocks)
+		return err == nil && ok
+	}, time.Second, 10*time.Millisecond)
+	ok, err := slow.HasBlock(1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Once released, the slow sink indexes every block in order.
+	for h := 1; h <= numBlocks; h++ {
+		slow.release <- struct{}{}
+	}
+	require.Eventually(t, func() bool {
+		ok, err := slow.HasBlock(numBlocks)
+		return err == nil && ok
+	}, time.Second, 10*time.Millisecond)
+
+	for h := 1; h <= numBlocks; h++ {
+		res, err := slow.GetTxByHash(types.Tx(fmt.Sprintf("tx%d", h)).Hash())
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, int64(h), res.Height)
+	}
+}
+
+// txCounter is a counter of all label values at once.
+type txCounter struct {
+	mtx sync.Mutex
+	n   float64
+}
+
+func (c *txCounter) With(...string) metrics.Counter { return c }
+
+func (c *txCounter) Add(delta float64) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.n += delta
+}
+
+func (c *txCounter) value() float64 {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.n
+}
+
+func TestIndexerServiceCountsDeduplicatedTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := tmlog.NewNopLogger()
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+	t.Cleanup(eventBus.Wait)
+
+	sink := kv.NewEventSink(dbm.NewMemDB())
+	counter := &txCounter{}
+	metrics := indexer.NopMetrics()
+	metrics.TransactionsIndexed = counter
+	service := indexer.NewService(indexer.ServiceArgs{
+		Logger:   logger,
+		Sinks:    []indexer.EventSink{sink},
+		EventBus: eventBus,
+		Metrics:  metrics,
+	})
+	require.NoError(t, service.Start(ctx))
+	t.Cleanup(service.Wait)
+
+	// The failed copy of a tx that succeeded in an earlier block is skipped,
+	// and not counted as indexed.
+	blocks := [][]abci.TxResult{
+		{{Height: 1, Tx: types.Tx("tx1"), Result: abci.ExecTxResult{Code: abci.CodeTypeOK}}},
+		{
+			{Height: 2, Tx: types.Tx("tx1"), Result: abci.ExecTxResult{Code: abci.CodeTypeOK + 1}},
+			{Height: 2, Index: 1, Tx: types.Tx("tx2"), Result: abci.ExecTxResult{Code: abci.CodeTypeOK}},
+		},
+	}
+	for i, txs := range blocks {
+		require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: int64(i + 1)},
+			NumTxs: int64(len(txs)),
+		}))
+		for _, tx := range txs {
+			require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: tx}))
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		ok, err := sink.HasBlock(2)
+		return err == nil && ok
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return counter.value() == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
 func TestTxIndexDuplicatedTx(t *testing.T) {
 	var mockTx = types.Tx("MOCK_TX_HASH")
 
@@ -268,29 +413,6 @@ func TestTxIndexDuplicatedTx(t *testing.T) {
 	}
 }
 
-func readSchema() ([]*schema.Migration, error) {
-	filename := "./sink/psql/schema.sql"
-	contents, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read sql file from '%s': %w", filename, err)
-	}
-
-	mg := &schema.Migration{}
-	mg.ID = time.Now().Local().String() + " db schema"
-	mg.Script = string(contents)
-	return append([]*schema.Migration{}, mg), nil
-}
-
-func resetDB(t *testing.T) {
-	q := "DROP TABLE IF EXISTS block_events,tx_events,tx_results"
-	_, err := psqldb.Exec(q)
-	assert.NoError(t, err)
-
-	q = "DROP TYPE IF EXISTS block_event_type"
-	_, err = psqldb.Exec(q)
-	assert.NoError(t, err)
-}
-
 func setupDB(t *testing.T) *dockertest.Pool {
 	t.Helper()
 	pool, err := dockertest.NewPool(os.Getenv("DOCKER_URL"))
@@ -336,15 +458,7 @@ func setupDB(t *testing.T) *dockertest.Pool {
 		return psqldb.Ping()
 	}))
 
-	resetDB(t)
-
-	sm, err := readSchema()
-	assert.NoError(t, err)
-
-	migrator := schema.NewMigrator()
-	err = migrator.Apply(psqldb, sm)
-	assert.NoError(t, err)
-
+	// The container is fresh, so connecting the sink installed the schema.
 	return pool
 }
 
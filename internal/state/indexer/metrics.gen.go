@@ -19,25 +19,57 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Subsystem: MetricsSubsystem,
 			Name:      "block_events_seconds",
 			Help:      "Latency for indexing block events.",
-		}, labels).With(labelsAndValues...),
+		}, append(labels, "sink")).With(labelsAndValues...),
 		TxEventsSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "tx_events_seconds",
 			Help:      "Latency for indexing transaction events.",
-		}, labels).With(labelsAndValues...),
+		}, append(labels, "sink")).With(labelsAndValues...),
 		BlocksIndexed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "blocks_indexed",
 			Help:      "Number of complete blocks indexed.",
-		}, labels).With(labelsAndValues...),
+		}, append(labels, "sink")).With(labelsAndValues...),
 		TransactionsIndexed: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "transactions_indexed",
 			Help:      "Number of transactions indexed.",
-		}, labels).With(labelsAndValues...),
+		}, append(labels, "sink")).With(labelsAndValues...),
+		IndexedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "indexed_height",
+			Help:      "Height of the latest block indexed by each sink. Comparing it to the consensus height shows how far a sink has fallen behind.",
+		}, append(labels, "sink")).With(labelsAndValues...),
+		SinkErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sink_errors",
+			Help:      "Number of failed sink operations, by operation: block, tx or prune.",
+		}, append(labels, "sink", "op")).With(labelsAndValues...),
+		PruneSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "prune_seconds",
+			Help:      "Latency for pruning old data from a sink.",
+		}, append(labels, "sink")).With(labelsAndValues...),
+		QueueDepth: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "queue_depth",
+			Help:      "Number of complete blocks waiting to be indexed by each sink when indexing asynchronously.",
+		}, append(labels, "sink")).With(labelsAndValues...),
+		LagSeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "lag_seconds",
+			Help:      "Time from a block being queued until each sink finished indexing it.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.01, 100, 10),
+		}, append(labels, "sink")).With(labelsAndValues...),
 	}
 }
 
@@ -47,5 +79,10 @@ func NopMetrics() *Metrics {
 		TxEventsSeconds:     discard.NewHistogram(),
 		BlocksIndexed:       discard.NewCounter(),
 		TransactionsIndexed: discard.NewCounter(),
+		IndexedHeight:       discard.NewGauge(),
+		SinkErrors:          discard.NewCounter(),
+		PruneSeconds:        discard.NewHistogram(),
+		QueueDepth:          discard.NewGauge(),
+		LagSeconds:          discard.NewHistogram(),
 	}
 }
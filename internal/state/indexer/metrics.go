@@ -12,14 +12,31 @@ const MetricsSubsystem = "indexer"
 // Metrics contains metrics exposed by this package.
 type Metrics struct {
 	// Latency for indexing block events.
-	BlockEventsSeconds metrics.Histogram
+	BlockEventsSeconds metrics.Histogram `metrics_labels:"sink"`
 
 	// Latency for indexing transaction events.
-	TxEventsSeconds metrics.Histogram
+	TxEventsSeconds metrics.Histogram `metrics_labels:"sink"`
 
 	// Number of complete blocks indexed.
-	BlocksIndexed metrics.Counter
+	BlocksIndexed metrics.Counter `metrics_labels:"sink"`
 
 	// Number of transactions indexed.
-	TransactionsIndexed metrics.Counter
+	TransactionsIndexed metrics.Counter `metrics_labels:"sink"`
+
+	// Height of the latest block indexed by each sink. Comparing it to the
+	// consensus height shows how far a sink has fallen behind.
+	IndexedHeight metrics.Gauge `metrics_labels:"sink"`
+
+	// Number of failed sink operations, by operation: block, tx or prune.
+	SinkErrors metrics.Counter `metrics_labels:"sink, op"`
+
+	// Latency for pruning old data from a sink.
+	PruneSeconds metrics.Histogram `metrics_labels:"sink"`
+
+	// Number of complete blocks waiting to be indexed by each sink when
+	// indexing asynchronously.
+	QueueDepth metrics.Gauge `metrics_labels:"sink"`
+
+	// Time from a block being queued until each sink finished indexing it.
+	LagSeconds metrics.Histogram `metrics_labels:"sink" metrics_buckettype:"exprange" metrics_bucketsizes:"0.01, 100, 10"`
 }
@@ -0,0 +1,215 @@
+package indexer
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+)
+
+// cursorVersion is prepended to every encoded cursor so the format can be
+// changed without misinterpreting cursors handed out by older nodes.
+const cursorVersion = "v1"
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor identifies the last search result returned to a client, so that a
+// subsequent search can resume strictly after it. Block search results only
+// use the Height.
+//
+// Cursors are handed to clients as opaque strings, see String and
+// ParseCursor.
+type Cursor struct {
+	Height     int64
+	Index      uint32
+	Descending bool
+}
+
+// String encodes the cursor as an opaque, URL-safe string.
+func (c Cursor) String() string {
+	order := "asc"
+	if c.Descending {
+		order = "desc"
+	}
+	raw := fmt.Sprintf("%s/%s/%d/%d", cursorVersion, order, c.Height, c.Index)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodes a cursor previously produced by Cursor.String.
+func ParseCursor(s string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	fields := strings.Split(string(raw), "/")
+	if len(fields) != 4 {
+		return Cursor{}, ErrInvalidCursor
+	}
+	if fields[0] != cursorVersion {
+		return Cursor{}, fmt.Errorf("%w: unsupported version %q", ErrInvalidCursor, fields[0])
+	}
+
+	var c Cursor
+	switch fields[1] {
+	case "asc":
+	case "desc":
+		c.Descending = true
+	default:
+		return Cursor{}, fmt.Errorf("%w: unknown order %q", ErrInvalidCursor, fields[1])
+	}
+
+	if c.Height, err = strconv.ParseInt(fields[2], 10, 64); err != nil || c.Height <= 0 {
+		return Cursor{}, fmt.Errorf("%w: bad height %q", ErrInvalidCursor, fields[2])
+	}
+	index, err := strconv.ParseUint(fields[3], 10, 32)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: bad index %q", ErrInvalidCursor, fields[3])
+	}
+	c.Index = uint32(index)
+
+	return c, nil
+}
+
+// PageRequest selects a window of search results. Results are ordered by
+// height (and by index within a block for transactions), ascending unless
+// Descending is set.
+type PageRequest struct {
+	// After, if set, restricts the results to those strictly after the
+	// cursor in the requested order.
+	After *Cursor
+	// Limit is the maximum number of results to return.
+	Limit int
+	// Descending reverses the order of the results.
+	Descending bool
+}
+
+// TxPage is a window of transaction search results.
+type TxPage struct {
+	Txs []*abci.TxResult
+	// Total is the number of transactions matching the query, regardless of
+	// the requested window.
+	Total int
+	// Next is the cursor to resume from, or nil if there are no more results.
+	Next *Cursor
+}
+
+// BlockPage is a window of block search results.
+type BlockPage struct {
+	Heights []int64
+	// Total is the number of blocks matching the query, regardless of the
+	// requested window.
+	Total int
+	// Next is the cursor to resume from, or nil if there are no more results.
+	Next *Cursor
+}
+
+// PagedSearcher is an optional interface implemented by event sinks that can
+// paginate search results themselves, typically without loading every match.
+// Sinks that do not implement it are paginated by SearchTxPage and
+// SearchBlockPage after a full search.
+type PagedSearcher interface {
+	SearchTxEventsPage(ctx context.Context, q *query.Query, page PageRequest) (TxPage, error)
+	SearchBlockEventsPage(ctx context.Context, q *query.Query, page PageRequest) (BlockPage, error)
+}
+
+// SearchTxPage returns the requested window of transactions matching q from
+// the given sink.
+func SearchTxPage(ctx context.Context, es EventSink, q *query.Query, page PageRequest) (TxPage, error) {
+	if ps, ok := es.(PagedSearcher); ok {
+		return ps.SearchTxEventsPage(ctx, q, page)
+	}
+
+	results, err := es.SearchTxEvents(ctx, q)
+	if err != nil {
+		return TxPage{}, err
+	}
+	return PaginateTxResults(results, page), nil
+}
+
+// SearchBlockPage returns the requested window of block heights matching q
+// from the given sink.
+func SearchBlockPage(ctx context.Context, es EventSink, q *query.Query, page PageRequest) (BlockPage, error) {
+	if ps, ok := es.(PagedSearcher); ok {
+		return ps.SearchBlockEventsPage(ctx, q, page)
+	}
+
+	heights, err := es.SearchBlockEvents(ctx, q)
+	if err != nil {
+		return BlockPage{}, err
+	}
+	return PaginateHeights(heights, page), nil
+}
+
+// TxResultLess reports whether the transaction at (h1, i1) sorts before the
+// one at (h2, i2) in ascending order.
+func TxResultLess(h1 int64, i1 uint32, h2 int64, i2 uint32) bool {
+	if h1 == h2 {
+		return i1 < i2
+	}
+	return h1 < h2
+}
+
+// PaginateTxResults sorts results in the requested order and returns the
+// window selected by page. The results slice is reordered in place.
+func PaginateTxResults(results []*abci.TxResult, page PageRequest) TxPage {
+	before := func(a, b *abci.TxResult) bool {
+		if page.Descending {
+			return TxResultLess(b.Height, b.Index, a.Height, a.Index)
+		}
+		return TxResultLess(a.Height, a.Index, b.Height, b.Index)
+	}
+	sort.Slice(results, func(i, j int) bool { return before(results[i], results[j]) })
+
+	start := 0
+	if c := page.After; c != nil {
+		pos := &abci.TxResult{Height: c.Height, Index: c.Index}
+		start = sort.Search(len(results), func(i int) bool { return before(pos, results[i]) })
+	}
+	end := windowEnd(start, page.Limit, len(results))
+
+	tp := TxPage{Txs: results[start:end], Total: len(results)}
+	if end < len(results) && end > start {
+		last := results[end-1]
+		tp.Next = &Cursor{Height: last.Height, Index: last.Index, Descending: page.Descending}
+	}
+	return tp
+}
+
+// PaginateHeights sorts heights in the requested order and returns the
+// window selected by page. The heights slice is reordered in place.
+func PaginateHeights(heights []int64, page PageRequest) BlockPage {
+	before := func(a, b int64) bool {
+		if page.Descending {
+			return a > b
+		}
+		return a < b
+	}
+	sort.Slice(heights, func(i, j int) bool { return before(heights[i], heights[j]) })
+
+	start := 0
+	if c := page.After; c != nil {
+		start = sort.Search(len(heights), func(i int) bool { return before(c.Height, heights[i]) })
+	}
+	end := windowEnd(start, page.Limit, len(heights))
+
+	bp := BlockPage{Heights: heights[start:end], Total: len(heights)}
+	if end < len(heights) && end > start {
+		bp.Next = &Cursor{Height: heights[end-1], Descending: page.Descending}
+	}
+	return bp
+}
+
+func windowEnd(start, limit, n int) int {
+	if limit <= 0 || start+limit > n {
+		return n
+	}
+	return start + limit
+}
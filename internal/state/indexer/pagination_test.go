@@ -0,0 +1,74 @@
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+)
+
+func TestCursor(t *testing.T) {
+	for _, c := range []indexer.Cursor{
+		{Height: 1},
+		{Height: 100, Index: 7, Descending: true},
+	} {
+		got, err := indexer.ParseCursor(c.String())
+		require.NoError(t, err)
+		assert.Equal(t, c, got)
+	}
+
+	for _, s := range []string{"", "!!", "djEvYXNjLzA", indexer.Cursor{Height: -1}.String()} {
+		_, err := indexer.ParseCursor(s)
+		assert.ErrorIs(t, err, indexer.ErrInvalidCursor, "cursor %q", s)
+	}
+}
+
+func TestPaginateHeights(t *testing.T) {
+	heights := []int64{5, 1, 4, 2, 3}
+
+	bp := indexer.PaginateHeights(heights, indexer.PageRequest{Limit: 2})
+	assert.Equal(t, []int64{1, 2}, bp.Heights)
+	assert.Equal(t, 5, bp.Total)
+	require.NotNil(t, bp.Next)
+
+	bp = indexer.PaginateHeights(heights, indexer.PageRequest{After: bp.Next, Limit: 2})
+	assert.Equal(t, []int64{3, 4}, bp.Heights)
+	require.NotNil(t, bp.Next)
+
+	bp = indexer.PaginateHeights(heights, indexer.PageRequest{After: bp.Next, Limit: 2})
+	assert.Equal(t, []int64{5}, bp.Heights)
+	assert.Nil(t, bp.Next)
+
+	// a cursor for a height that is no longer in the results still resumes
+	// at the right position
+	bp = indexer.PaginateHeights(heights, indexer.PageRequest{
+		After:      &indexer.Cursor{Height: 4, Descending: true},
+		Descending: true,
+	})
+	assert.Equal(t, []int64{3, 2, 1}, bp.Heights)
+	assert.Nil(t, bp.Next)
+}
+
+func TestPaginateTxResults(t *testing.T) {
+	results := []*abci.TxResult{
+		{Height: 2, Index: 0},
+		{Height: 1, Index: 1},
+		{Height: 2, Index: 1},
+		{Height: 1, Index: 0},
+	}
+
+	tp := indexer.PaginateTxResults(results, indexer.PageRequest{Limit: 3, Descending: true})
+	require.Len(t, tp.Txs, 3)
+	assert.Equal(t, &abci.TxResult{Height: 2, Index: 1}, tp.Txs[0])
+	assert.Equal(t, &abci.TxResult{Height: 1, Index: 1}, tp.Txs[2])
+	require.NotNil(t, tp.Next)
+	assert.Equal(t, indexer.Cursor{Height: 1, Index: 1, Descending: true}, *tp.Next)
+
+	tp = indexer.PaginateTxResults(results, indexer.PageRequest{After: tp.Next, Limit: 3, Descending: true})
+	assert.Equal(t, []*abci.TxResult{{Height: 1, Index: 0}}, tp.Txs)
+	assert.Equal(t, 4, tp.Total)
+	assert.Nil(t, tp.Next)
+}
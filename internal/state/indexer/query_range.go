@@ -76,38 +76,87 @@ func (qr QueryRange) UpperBoundValue() interface{} {
 }
 
 // LookForRanges returns a mapping of QueryRanges and the matching indexes in
-// the provided query conditions.
+// the provided query conditions. Negated conditions are not included. If a
+// key has more than one lower or upper bound, the most restrictive one is
+// used.
 func LookForRanges(conditions []syntax.Condition) (ranges QueryRanges, indexes []int) {
 	ranges = make(QueryRanges)
 	for i, c := range conditions {
-		if IsRangeOperation(c.Op) {
+		if IsRangeOperation(c.Op) && !c.Not {
 			r, ok := ranges[c.Tag]
 			if !ok {
 				r = QueryRange{Key: c.Tag}
 			}
+			ranges[c.Tag] = r.withCondition(c)
+			indexes = append(indexes, i)
+		}
+	}
 
-			switch c.Op {
-			case syntax.TGt:
-				r.LowerBound = conditionArg(c)
+	return ranges, indexes
+}
 
-			case syntax.TGeq:
-				r.IncludeLowerBound = true
-				r.LowerBound = conditionArg(c)
+// RangeForCondition returns the QueryRange selected by a single range
+// condition, ignoring whether the condition is negated.
+func RangeForCondition(c syntax.Condition) QueryRange {
+	return QueryRange{Key: c.Tag}.withCondition(c)
+}
 
-			case syntax.TLt:
-				r.UpperBound = conditionArg(c)
+// withCondition returns a copy of qr narrowed by the range condition c.
+func (qr QueryRange) withCondition(c syntax.Condition) QueryRange {
+	arg := conditionArg(c)
+	switch c.Op {
+	case syntax.TGt, syntax.TGeq:
+		next := QueryRange{LowerBound: arg, IncludeLowerBound: c.Op == syntax.TGeq}
+		if qr.LowerBound == nil || compareBounds(next.LowerBoundValue(), qr.LowerBoundValue()) > 0 {
+			qr.LowerBound = next.LowerBound
+			qr.IncludeLowerBound = next.IncludeLowerBound
+		}
 
-			case syntax.TLeq:
-				r.IncludeUpperBound = true
-				r.UpperBound = conditionArg(c)
-			}
+	case syntax.TLt, syntax.TLeq:
+		next := QueryRange{UpperBound: arg, IncludeUpperBound: c.Op == syntax.TLeq}
+		if qr.UpperBound == nil || compareBounds(next.UpperBoundValue(), qr.UpperBoundValue()) < 0 {
+			qr.UpperBound = next.UpperBound
+			qr.IncludeUpperBound = next.IncludeUpperBound
+		}
+	}
+	return qr
+}
 
-			ranges[c.Tag] = r
-			indexes = append(indexes, i)
+// compareBounds compares two bound values as returned by LowerBoundValue or
+// UpperBoundValue, returning -1, 0, or 1. The bounds of TIME and DATE
+// conditions are a time.Time when inclusive and Unix seconds when exclusive,
+// so a time.Time compared with an int64 is compared in Unix seconds. Values
+// of differing or unsupported types compare equal, so the existing bound is
+// kept.
+func compareBounds(a, b interface{}) int {
+	at, aTime := a.(time.Time)
+	bt, bTime := b.(time.Time)
+	switch {
+	case aTime && bTime:
+		switch {
+		case at.Before(bt):
+			return -1
+		case at.After(bt):
+			return 1
+		default:
+			return 0
 		}
+	case aTime:
+		a = at.Unix()
+	case bTime:
+		b = bt.Unix()
 	}
 
-	return ranges, indexes
+	av, aok := a.(int64)
+	bv, bok := b.(int64)
+	switch {
+	case !aok || !bok || av == bv:
+		return 0
+	case av < bv:
+		return -1
+	default:
+		return 1
+	}
 }
 
 // IsRangeOperation returns a boolean signifying if a query Operator is a range
@@ -0,0 +1,60 @@
+package indexer_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+)
+
+func TestLookForRangesTimeBounds(t *testing.T) {
+	date := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return v
+	}
+
+	testCases := []struct {
+		query                string
+		lower, upper         time.Time
+		inclLower, inclUpper bool
+	}{
+		{
+			query: "tx.time >= TIME 2022-01-01T00:00:00Z AND tx.time >= TIME 2022-03-01T00:00:00Z AND " +
+				"tx.time <= TIME 2022-12-01T00:00:00Z AND tx.time <= TIME 2022-06-01T00:00:00Z",
+			lower: date("2022-03-01T00:00:00Z"), inclLower: true,
+			upper: date("2022-06-01T00:00:00Z"), inclUpper: true,
+		},
+		{
+			query: "tx.time > DATE 2022-03-01 AND tx.time >= DATE 2022-01-01 AND " +
+				"tx.time < DATE 2022-06-01 AND tx.time <= DATE 2022-12-01",
+			lower: date("2022-03-01T00:00:00Z"), inclLower: false,
+			upper: date("2022-06-01T00:00:00Z"), inclUpper: false,
+		},
+		{
+			// an inclusive bound a second after an exclusive one is as
+			// restrictive, so the first one is kept
+			query: "tx.time > TIME 2022-03-01T00:00:00Z AND tx.time >= TIME 2022-03-01T00:00:01Z AND " +
+				"tx.time < TIME 2022-06-01T00:00:00Z AND tx.time <= TIME 2022-05-01T00:00:00Z",
+			lower: date("2022-03-01T00:00:00Z"), inclLower: false,
+			upper: date("2022-05-01T00:00:00Z"), inclUpper: true,
+		},
+	}
+	for _, tc := range testCases {
+		q, err := query.New(tc.query)
+		require.NoError(t, err)
+		ranges, indexes := indexer.LookForRanges(q.Syntax())
+		assert.Len(t, indexes, 4, tc.query)
+
+		r, ok := ranges["tx.time"]
+		require.True(t, ok, tc.query)
+		assert.True(t, tc.lower.Equal(r.LowerBound.(time.Time)), "%s: lower bound %v", tc.query, r.LowerBound)
+		assert.Equal(t, tc.inclLower, r.IncludeLowerBound, tc.query)
+		assert.True(t, tc.upper.Equal(r.UpperBound.(time.Time)), "%s: upper bound %v", tc.query, r.UpperBound)
+		assert.Equal(t, tc.inclUpper, r.IncludeUpperBound, tc.query)
+	}
+}
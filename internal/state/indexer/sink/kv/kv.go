@@ -13,22 +13,37 @@ import (
 	"github.com/tendermint/tendermint/types"
 )
 
-var _ indexer.EventSink = (*EventSink)(nil)
+var (
+	_ indexer.EventSink     = (*EventSink)(nil)
+	_ indexer.PagedSearcher = (*EventSink)(nil)
+	_ indexer.Pruner        = (*EventSink)(nil)
+)
 
 // The EventSink is an aggregator for redirecting the call path of the tx/block kvIndexer.
 // For the implementation details please see the kv.go in the indexer/block and indexer/tx folder.
 type EventSink struct {
-	txi   *kvt.TxIndex
-	bi    *kvb.BlockerIndexer
-	store dbm.DB
+	txi    *kvt.TxIndex
+	bi     *kvb.BlockerIndexer
+	store  dbm.DB
+	filter *indexer.EventFilter
+}
+
+// Option sets an optional parameter on the EventSink.
+type Option func(*EventSink)
+
+// WithEventFilter restricts the event attributes that the sink indexes.
+func WithEventFilter(f *indexer.EventFilter) Option {
+	return func(kves *EventSink) { kves.filter = f }
 }
 
-func NewEventSink(store dbm.DB) indexer.EventSink {
-	return &EventSink{
-		txi:   kvt.NewTxIndex(store),
-		bi:    kvb.New(store),
-		store: store,
+func NewEventSink(store dbm.DB, opts ...Option) indexer.EventSink {
+	kves := &EventSink{store: store}
+	for _, opt := range opts {
+		opt(kves)
 	}
+	kves.txi = kvt.NewTxIndex(store, kvt.WithEventFilter(kves.filter))
+	kves.bi = kvb.New(store, kvb.WithEventFilter(kves.filter))
+	return kves
 }
 
 func (kves *EventSink) Type() indexer.EventSinkType {
@@ -51,6 +66,18 @@ func (kves *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*a
 	return kves.txi.Search(ctx, q)
 }
 
+func (kves *EventSink) SearchBlockEventsPage(ctx context.Context, q *query.Query, page indexer.PageRequest) (indexer.BlockPage, error) {
+	heights, err := kves.bi.Search(ctx, q)
+	if err != nil {
+		return indexer.BlockPage{}, err
+	}
+	return indexer.PaginateHeights(heights, page), nil
+}
+
+func (kves *EventSink) SearchTxEventsPage(ctx context.Context, q *query.Query, page indexer.PageRequest) (indexer.TxPage, error) {
+	return kves.txi.SearchPage(ctx, q, page)
+}
+
 func (kves *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
 	return kves.txi.Get(hash)
 }
@@ -59,6 +86,13 @@ func (kves *EventSink) HasBlock(h int64) (bool, error) {
 	return kves.bi.Has(h)
 }
 
+func (kves *EventSink) Prune(retainHeight int64) error {
+	if err := kves.txi.Prune(retainHeight); err != nil {
+		return err
+	}
+	return kves.bi.Prune(retainHeight)
+}
+
 func (kves *EventSink) Stop() error {
 	return kves.store.Close()
 }
@@ -0,0 +1,168 @@
+package psql
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationFS holds the versioned schema migrations of the sink. Each file is
+// named "<version>_<description>.sql", with versions numbered consecutively
+// from 1. Once released, a migration must never be changed; schema changes
+// are made by adding a new file.
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+const (
+	migrationDir       = "migrations"
+	tableSchemaVersion = "schema_version"
+
+	// migrationLockID is the key of the advisory lock that serializes
+	// migrations by several nodes sharing one database.
+	migrationLockID = 0x746d5f6d6967 // "tm_mig"
+)
+
+// A migration is a single versioned step of the database schema.
+type migration struct {
+	version int
+	name    string
+	script  string
+}
+
+// loadMigrations reads the migrations in the migrations directory of fsys,
+// ordered by version.
+func loadMigrations(fsys fs.FS) ([]migration, error) {
+	entries, err := fs.ReadDir(fsys, migrationDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	var ms []migration
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		prefix := strings.SplitN(e.Name(), "_", 2)[0]
+		version, err := strconv.Atoi(prefix)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("migration %q: name must start with a positive version number", e.Name())
+		}
+		script, err := fs.ReadFile(fsys, path.Join(migrationDir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading migration %q: %w", e.Name(), err)
+		}
+		ms = append(ms, migration{
+			version: version,
+			name:    strings.TrimSuffix(e.Name(), ".sql"),
+			script:  string(script),
+		})
+	}
+
+	sort.Slice(ms, func(i, j int) bool { return ms[i].version < ms[j].version })
+	for i, m := range ms {
+		if m.version != i+1 {
+			return nil, fmt.Errorf("migration %q: expected version %d", m.name, i+1)
+		}
+	}
+	return ms, nil
+}
+
+// Migrate brings the schema of db up to date by applying, in a single
+// database transaction, every embedded migration that has not been applied
+// yet. Applied versions are recorded in the schema_version table.
+//
+// A database whose schema was installed by hand from the original schema.sql,
+// before migrations were introduced, is recognized and treated as being at
+// version 1.
+//
+// CockroachDB has no advisory locks; concurrent migrations of its serializable
+// transactions conflict on the schema_version table instead, and all but one
+// fail.
+func Migrate(db *sql.DB) error {
+	ms, err := loadMigrations(migrationFS)
+	if err != nil {
+		return err
+	}
+	return applyMigrations(db, ms)
+}
+
+func applyMigrations(db *sql.DB, ms []migration) error {
+	return runInTransaction(db, func(dbtx *sql.Tx) error {
+		cockroach, err := isCockroachDB(dbtx)
+		if err != nil {
+			return err
+		}
+		if !cockroach {
+			if _, err := dbtx.Exec(`SELECT pg_advisory_xact_lock($1);`, migrationLockID); err != nil {
+				return fmt.Errorf("locking schema: %w", err)
+			}
+		}
+		if _, err := dbtx.Exec(`
+CREATE TABLE IF NOT EXISTS ` + tableSchemaVersion + ` (
+  version    INTEGER PRIMARY KEY,
+  name       VARCHAR NOT NULL,
+  applied_at TIMESTAMPTZ NOT NULL
+);`); err != nil {
+			return fmt.Errorf("creating %s table: %w", tableSchemaVersion, err)
+		}
+
+		current, err := currentVersion(dbtx)
+		if err != nil {
+			return err
+		}
+		if current == 0 && len(ms) != 0 {
+			var baseline bool
+			if err := dbtx.QueryRow(`SELECT to_regclass($1) IS NOT NULL;`, tableBlocks).Scan(&baseline); err != nil {
+				return fmt.Errorf("checking for an existing schema: %w", err)
+			}
+			if baseline {
+				if err := recordVersion(dbtx, ms[0]); err != nil {
+					return err
+				}
+				current = ms[0].version
+			}
+		}
+		if current > len(ms) {
+			return fmt.Errorf("database schema version %d is newer than the latest known version %d",
+				current, len(ms))
+		}
+
+		for _, m := range ms[current:] {
+			if _, err := dbtx.Exec(m.script); err != nil {
+				return fmt.Errorf("applying migration %q: %w", m.name, err)
+			}
+			if err := recordVersion(dbtx, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// currentVersion reports the latest schema version applied, or 0 if none.
+func currentVersion(q interface {
+	QueryRow(string, ...interface{}) *sql.Row
+}) (int, error) {
+	var version int
+	err := q.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM ` + tableSchemaVersion + `;`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("reading schema version: %w", err)
+	}
+	return version, nil
+}
+
+func recordVersion(dbtx *sql.Tx, m migration) error {
+	_, err := dbtx.Exec(`INSERT INTO `+tableSchemaVersion+` (version, name, applied_at) VALUES ($1, $2, $3);`,
+		m.version, m.name, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("recording migration %q: %w", m.name, err)
+	}
+	return nil
+}
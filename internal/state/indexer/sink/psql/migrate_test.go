@@ -0,0 +1,81 @@
+package psql
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	ms, err := loadMigrations(migrationFS)
+	require.NoError(t, err)
+	require.NotEmpty(t, ms)
+	assert.Equal(t, "0001_initial_schema", ms[0].name)
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"gap": {
+			"migrations/0001_a.sql": {Data: []byte("SELECT 1;")},
+			"migrations/0003_c.sql": {Data: []byte("SELECT 1;")},
+		},
+		"duplicate": {
+			"migrations/0001_a.sql": {Data: []byte("SELECT 1;")},
+			"migrations/1_b.sql":    {Data: []byte("SELECT 1;")},
+		},
+		"unnumbered": {
+			"migrations/initial.sql": {Data: []byte("SELECT 1;")},
+		},
+	} {
+		_, err := loadMigrations(fsys)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestMigrateUpgrade(t *testing.T) {
+	db := testDB()
+	t.Cleanup(func() {
+		// Leave the database with the current schema for the other tests.
+		require.NoError(t, resetDatabase(db))
+		require.NoError(t, Migrate(db))
+	})
+
+	current, err := loadMigrations(migrationFS)
+	require.NoError(t, err)
+	next := append(append([]migration(nil), current...), migration{
+		version: len(current) + 1,
+		name:    "test_upgrade",
+		script:  `CREATE TABLE upgrade_test (id INTEGER);`,
+	})
+	version := func() int {
+		v, err := currentVersion(db)
+		require.NoError(t, err)
+		return v
+	}
+
+	// A fresh database is migrated to the latest version, and re-applying
+	// the same migrations is a no-op.
+	require.NoError(t, resetDatabase(db))
+	require.NoError(t, applyMigrations(db, current))
+	assert.Equal(t, len(current), version())
+	require.NoError(t, applyMigrations(db, current))
+	assert.Equal(t, len(current), version())
+
+	// A new migration is applied on top of the existing schema.
+	require.NoError(t, applyMigrations(db, next))
+	assert.Equal(t, len(next), version())
+	_, err = db.Exec(`SELECT id FROM upgrade_test;`)
+	require.NoError(t, err)
+	_, err = db.Exec(`DROP TABLE upgrade_test;`)
+	require.NoError(t, err)
+
+	// A schema newer than the binary is refused.
+	assert.Error(t, applyMigrations(db, current))
+
+	// A schema installed by hand before migrations is adopted as version 1.
+	require.NoError(t, resetDatabase(db))
+	_, err = db.Exec(current[0].script)
+	require.NoError(t, err)
+	require.NoError(t, applyMigrations(db, current))
+	assert.Equal(t, len(current), version())
+}
@@ -1,7 +1,9 @@
 /*
-  This file defines the database schema for the PostgresQL ("psql") event sink
-  implementation in Tendermint. The operator must create a database and install
-  this schema before using the database to index events.
+  This file defines the initial database schema for the PostgresQL ("psql")
+  event sink implementation in Tendermint. The sink applies it, followed by any
+  later migrations in this directory, when it connects to the database; see
+  migrate.go. An operator who disables automatic migrations must apply the
+  files in order before using the database to index events.
  */
 
 -- The blocks table records metadata about each block.
@@ -0,0 +1,26 @@
+/*
+  The consensus_params table records the changes of the consensus parameters
+  returned by the application in FinalizeBlock, so that the limits which
+  applied at any height can be reconstructed from the genesis parameters.
+ */
+
+CREATE TABLE consensus_params (
+  rowid      BIGSERIAL PRIMARY KEY,
+
+  -- The height of the block whose results changed the parameters. The new
+  -- parameters apply from the next height on.
+  height     BIGINT NOT NULL,
+  chain_id   VARCHAR NOT NULL,
+
+  -- When this change was logged into the sink, in UTC.
+  created_at TIMESTAMPTZ NOT NULL,
+  -- The JSON encoding of the ConsensusParams update, with only the changed
+  -- fields set.
+  params     JSONB NOT NULL,
+
+  UNIQUE (height, chain_id)
+);
+
+-- The changes are deliberately not pruned with their blocks, since they are
+-- few and needed to reconstruct the parameters of the later heights.
+CREATE INDEX idx_consensus_params_height_chain ON consensus_params(height, chain_id);
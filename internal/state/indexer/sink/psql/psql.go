@@ -21,32 +21,148 @@ const (
 	tableTxResults  = "tx_results"
 	tableEvents     = "events"
 	tableAttributes = "attributes"
+	tableParams     = "consensus_params"
 	driverName      = "postgres"
 )
 
+// DefaultBatchSize is the default maximum number of rows written by a single
+// multi-row INSERT statement.
+const DefaultBatchSize = 1000
+
+// maxBindParams is the maximum number of bind parameters PostgreSQL accepts
+// in a single statement.
+const maxBindParams = 65535
+
+// sqlStateSerializationFailure is the SQLSTATE of a transaction aborted by a
+// serialization failure, which must be retried. CockroachDB reports it for
+// the transactions conflicting with concurrent ones.
+const sqlStateSerializationFailure = "40001"
+
+// retryBackoff is the delay before the first retry of a transaction, which
+// grows linearly with the following ones.
+const retryBackoff = 10 * time.Millisecond
+
 // EventSink is an indexer backend providing the tx/block index services.  This
 // implementation stores records in a PostgreSQL database using the schema
-// defined in state/indexer/sink/psql/schema.sql.
+// defined by the migrations in state/indexer/sink/psql/migrations.
 type EventSink struct {
-	store   *sql.DB
-	chainID string
+	store     *sql.DB
+	chainID   string
+	batchSize int
+	filter    *indexer.EventFilter
+	noMigrate bool
+
+	driver           string
+	maxOpenConns     int
+	maxIdleConns     int
+	connMaxLifetime  time.Duration
+	statementTimeout time.Duration
+	maxRetries       int
+
+	// cockroach reports whether the database is CockroachDB, whose row IDs
+	// are not allocated from sequences.
+	cockroach bool
+}
+
+// Option sets an optional parameter on the EventSink.
+type Option func(*EventSink)
+
+// WithBatchSize sets the maximum number of rows the sink writes with a single
+// INSERT statement. Values less than 1 select DefaultBatchSize.
+func WithBatchSize(n int) Option {
+	return func(es *EventSink) { es.batchSize = n }
+}
+
+// WithEventFilter restricts the event attributes that the sink indexes.
+func WithEventFilter(f *indexer.EventFilter) Option {
+	return func(es *EventSink) { es.filter = f }
+}
+
+// WithoutMigrations disables the schema migrations NewEventSink otherwise
+// applies, for operators who manage the database schema themselves.
+func WithoutMigrations() Option {
+	return func(es *EventSink) { es.noMigrate = true }
+}
+
+// WithDriver sets the name of the database/sql driver the sink connects with,
+// "postgres" (lib/pq) by default. Another driver, such as the "pgx" driver of
+// github.com/jackc/pgx/v4/stdlib, must be registered by the binary.
+func WithDriver(name string) Option {
+	return func(es *EventSink) { es.driver = name }
+}
+
+// WithMaxOpenConns sets the maximum number of open connections of the pool of
+// the sink. Zero means no limit.
+func WithMaxOpenConns(n int) Option {
+	return func(es *EventSink) { es.maxOpenConns = n }
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept by the
+// pool of the sink. Zero selects the default of database/sql.
+func WithMaxIdleConns(n int) Option {
+	return func(es *EventSink) { es.maxIdleConns = n }
+}
+
+// WithConnMaxLifetime sets the maximum time a connection of the pool of the
+// sink is reused, e.g. to rebalance the connections to the nodes of a
+// cluster behind a load balancer. Zero means no limit.
+func WithConnMaxLifetime(d time.Duration) Option {
+	return func(es *EventSink) { es.connMaxLifetime = d }
+}
+
+// WithStatementTimeout sets the timeout of each statement of the sink, after
+// which the database aborts it and the sink reports an error. Zero means no
+// timeout.
+func WithStatementTimeout(d time.Duration) Option {
+	return func(es *EventSink) { es.statementTimeout = d }
+}
+
+// WithMaxRetries sets the number of times the sink retries a transaction
+// aborted by a serialization failure. Zero disables the retries.
+func WithMaxRetries(n int) Option {
+	return func(es *EventSink) { es.maxRetries = n }
 }
 
 // NewEventSink constructs an event sink associated with the PostgreSQL
 // database specified by connStr. Events written to the sink are attributed to
-// the specified chainID.
-func NewEventSink(connStr, chainID string) (*EventSink, error) {
-	db, err := sql.Open(driverName, connStr)
+// the specified chainID. Unless disabled with WithoutMigrations, any pending
+// schema migrations are applied to the database, see Migrate. The database
+// may be PostgreSQL or CockroachDB.
+func NewEventSink(connStr, chainID string, opts ...Option) (*EventSink, error) {
+	es := &EventSink{
+		chainID: chainID,
+		driver:  driverName,
+	}
+	for _, opt := range opts {
+		opt(es)
+	}
+
+	db, err := sql.Open(es.driver, connStr)
 	if err != nil {
 		return nil, err
-	} else if err := db.Ping(); err != nil {
+	}
+	db.SetMaxOpenConns(es.maxOpenConns)
+	if es.maxIdleConns > 0 {
+		db.SetMaxIdleConns(es.maxIdleConns)
+	}
+	db.SetConnMaxLifetime(es.connMaxLifetime)
+	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, err
 	}
+	es.store = db
 
-	return &EventSink{
-		store:   db,
-		chainID: chainID,
-	}, nil
+	if es.cockroach, err = isCockroachDB(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if !es.noMigrate {
+		if err := Migrate(db); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("migrating database schema: %w", err)
+		}
+	}
+	return es, nil
 }
 
 // DB returns the underlying Postgres connection used by the sink.
@@ -56,6 +172,19 @@ func (es *EventSink) DB() *sql.DB { return es.store }
 // Type returns the structure type for this sink, which is Postgres.
 func (es *EventSink) Type() indexer.EventSinkType { return indexer.PSQL }
 
+// rowsPerInsert reports how many rows of ncols columns may be written by one
+// INSERT statement.
+func (es *EventSink) rowsPerInsert(ncols int) int {
+	n := es.batchSize
+	if n < 1 {
+		n = DefaultBatchSize
+	}
+	if max := maxBindParams / ncols; n > max {
+		n = max
+	}
+	return n
+}
+
 // runInTransaction executes query in a fresh database transaction.
 // If query reports an error, the transaction is rolled back and the
 // error from query is reported to the caller.
@@ -72,59 +201,188 @@ func runInTransaction(db *sql.DB, query func(*sql.Tx) error) error {
 	return dbtx.Commit()
 }
 
+// runTx executes query in a fresh database transaction with runInTransaction,
+// bounding its statements by the statement timeout of the sink. A transaction
+// aborted by a serialization failure is retried up to the maximum number of
+// retries of the sink.
+func (es *EventSink) runTx(query func(*sql.Tx) error) error {
+	for attempt := 0; ; attempt++ {
+		err := runInTransaction(es.store, func(dbtx *sql.Tx) error {
+			if es.statementTimeout > 0 {
+				// SET LOCAL does not accept bind parameters.
+				if _, err := dbtx.Exec(fmt.Sprintf(`SET LOCAL statement_timeout = %d;`,
+					es.statementTimeout.Milliseconds())); err != nil {
+					return fmt.Errorf("setting statement timeout: %w", err)
+				}
+			}
+			return query(dbtx)
+		})
+		if err == nil || attempt >= es.maxRetries || !isSerializationFailure(err) {
+			return err
+		}
+		time.Sleep(time.Duration(attempt+1) * retryBackoff)
+	}
+}
+
+// isSerializationFailure reports whether err is a serialization failure of
+// the database. The errors of both lib/pq and pgx report their SQLSTATE.
+func isSerializationFailure(err error) bool {
+	var sqlErr interface{ SQLState() string }
+	return errors.As(err, &sqlErr) && sqlErr.SQLState() == sqlStateSerializationFailure
+}
+
+// isCockroachDB reports whether the database is CockroachDB.
+func isCockroachDB(q interface {
+	QueryRow(string, ...interface{}) *sql.Row
+}) (bool, error) {
+	var version string
+	if err := q.QueryRow(`SELECT version();`).Scan(&version); err != nil {
+		return false, fmt.Errorf("reading database version: %w", err)
+	}
+	return strings.Contains(version, "CockroachDB"), nil
+}
+
 // queryWithID executes the specified SQL query with the given arguments,
 // expecting a single-row, single-column result containing an ID. If the query
 // succeeds, the ID from the result is returned.
-func queryWithID(tx *sql.Tx, query string, args ...interface{}) (uint32, error) {
-	var id uint32
+func queryWithID(tx *sql.Tx, query string, args ...interface{}) (int64, error) {
+	var id int64
 	if err := tx.QueryRow(query, args...).Scan(&id); err != nil {
 		return 0, err
 	}
 	return id, nil
 }
 
-// insertEvents inserts a slice of events and any indexed attributes of those
-// events into the database associated with dbtx.
-//
-// If txID > 0, the event is attributed to the Tendermint transaction with that
-// ID; otherwise it is recorded as a block event.
-func insertEvents(dbtx *sql.Tx, blockID, txID uint32, evts []abci.Event) error {
+// multiRowInsert builds a statement inserting len(rows) rows into the given
+// columns of table, followed by suffix (e.g. an ON CONFLICT or RETURNING
+// clause), and returns it along with its flattened arguments.
+func multiRowInsert(table string, columns []string, rows [][]interface{}, suffix string) (string, []interface{}) {
+	var sb strings.Builder
+	args := make([]interface{}, 0, len(rows)*len(columns))
+
+	sb.WriteString("INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES ")
+	for i, row := range rows {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteByte('(')
+		for j, v := range row {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			args = append(args, v)
+			fmt.Fprintf(&sb, "$%d", len(args))
+		}
+		sb.WriteByte(')')
+	}
+	sb.WriteString(suffix)
+	return sb.String(), args
+}
+
+// insertRows writes rows into table using as few multi-row INSERT statements
+// as the configured batch size permits.
+func (es *EventSink) insertRows(dbtx *sql.Tx, table string, columns []string, rows [][]interface{}) error {
+	n := es.rowsPerInsert(len(columns))
+	for len(rows) > 0 {
+		if n > len(rows) {
+			n = len(rows)
+		}
+		stmt, args := multiRowInsert(table, columns, rows[:n], ";")
+		if _, err := dbtx.Exec(stmt, args...); err != nil {
+			return err
+		}
+		rows = rows[n:]
+	}
+	return nil
+}
+
+// eventBatch accumulates events and their indexed attributes so that they can
+// be written with a handful of multi-row INSERT statements rather than one
+// statement per row.
+type eventBatch struct {
+	events []pendingEvent
+}
+
+type pendingEvent struct {
+	blockID int64
+	txID    interface{} // nil for block events
+	event   abci.Event
+}
+
+// add queues evts for insertion. If txID > 0, the events are attributed to the
+// Tendermint transaction with that ID; otherwise they are recorded as block
+// events. Events with an empty type are skipped.
+func (b *eventBatch) add(blockID, txID int64, evts []abci.Event) {
 	// Populate the transaction ID field iff one is defined (> 0).
 	var txIDArg interface{}
 	if txID > 0 {
 		txIDArg = txID
 	}
-
-	// Add each event to the events table, and retrieve its row ID to use when
-	// adding any attributes the event provides.
 	for _, evt := range evts {
-		// Skip events with an empty type.
 		if evt.Type == "" {
 			continue
 		}
+		b.events = append(b.events, pendingEvent{blockID: blockID, txID: txIDArg, event: evt})
+	}
+}
 
-		eid, err := queryWithID(dbtx, `
-INSERT INTO `+tableEvents+` (block_id, tx_id, type) VALUES ($1, $2, $3)
-  RETURNING rowid;
-`, blockID, txIDArg, evt.Type)
-		if err != nil {
-			return err
+// flush writes the queued events and attributes to the database associated
+// with dbtx and resets the batch.
+func (b *eventBatch) flush(es *EventSink, dbtx *sql.Tx) error {
+	if len(b.events) == 0 {
+		return nil
+	}
+
+	// Reserve row IDs for all the events up front, so that attribute rows can
+	// refer to them without reading back each inserted event. The serial row
+	// IDs of CockroachDB are generated by unique_rowid rather than sequences.
+	reserve := `SELECT nextval(pg_get_serial_sequence('` + tableEvents + `', 'rowid')) FROM generate_series(1, $1);`
+	if es.cockroach {
+		reserve = `SELECT unique_rowid() FROM generate_series(1, $1);`
+	}
+	rows, err := dbtx.Query(reserve, len(b.events))
+	if err != nil {
+		return fmt.Errorf("reserving event IDs: %w", err)
+	}
+	ids := make([]int64, 0, len(b.events))
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return fmt.Errorf("reserving event IDs: %w", err)
 		}
+		ids = append(ids, id)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	} else if len(ids) != len(b.events) {
+		return fmt.Errorf("reserved %d event IDs, want %d", len(ids), len(b.events))
+	}
+
+	eventRows := make([][]interface{}, len(b.events))
+	var attrRows [][]interface{}
+	for i, pe := range b.events {
+		eventRows[i] = []interface{}{ids[i], pe.blockID, pe.txID, pe.event.Type}
 
 		// Add any attributes flagged for indexing.
-		for _, attr := range evt.Attributes {
+		for _, attr := range pe.event.Attributes {
 			if !attr.Index {
 				continue
 			}
-			compositeKey := evt.Type + "." + attr.Key
-			if _, err := dbtx.Exec(`
-INSERT INTO `+tableAttributes+` (event_id, key, composite_key, value)
-  VALUES ($1, $2, $3, $4);
-`, eid, attr.Key, compositeKey, attr.Value); err != nil {
-				return err
-			}
+			compositeKey := pe.event.Type + "." + attr.Key
+			attrRows = append(attrRows, []interface{}{ids[i], attr.Key, compositeKey, attr.Value})
 		}
 	}
+
+	if err := es.insertRows(dbtx, tableEvents,
+		[]string{"rowid", "block_id", "tx_id", "type"}, eventRows); err != nil {
+		return fmt.Errorf("inserting events: %w", err)
+	}
+	if err := es.insertRows(dbtx, tableAttributes,
+		[]string{"event_id", "key", "composite_key", "value"}, attrRows); err != nil {
+		return fmt.Errorf("inserting attributes: %w", err)
+	}
+	b.events = b.events[:0]
 	return nil
 }
 
@@ -147,7 +405,7 @@ func makeIndexedEvent(compositeKey, value string) abci.Event {
 func (es *EventSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
 	ts := time.Now().UTC()
 
-	return runInTransaction(es.store, func(dbtx *sql.Tx) error {
+	return es.runTx(func(dbtx *sql.Tx) error {
 		// Add the block to the blocks table and report back its row ID for use
 		// in indexing the events for the block.
 		blockID, err := queryWithID(dbtx, `
@@ -162,15 +420,30 @@ INSERT INTO `+tableBlocks+` (height, chain_id, created_at)
 			return fmt.Errorf("indexing block header: %w", err)
 		}
 
-		// Insert the special block meta-event for height.
-		if err := insertEvents(dbtx, blockID, 0, []abci.Event{
+		// Insert the special block meta-event for height, followed by all
+		// the block events. Order is important here.
+		var batch eventBatch
+		batch.add(blockID, 0, []abci.Event{
 			makeIndexedEvent(types.BlockHeightKey, fmt.Sprint(h.Header.Height)),
-		}); err != nil {
-			return fmt.Errorf("block meta-events: %w", err)
+		})
+		batch.add(blockID, 0, es.filter.Filter(h.ResultFinalizeBlock.Events))
+		batch.add(blockID, 0, es.filter.Filter(h.ValidatorChangeEvents()))
+		if err := batch.flush(es, dbtx); err != nil {
+			return fmt.Errorf("block events: %w", err)
 		}
-		// Insert all the block events. Order is important here,
-		if err := insertEvents(dbtx, blockID, 0, h.ResultFinalizeBlock.Events); err != nil {
-			return fmt.Errorf("finalize-block events: %w", err)
+
+		if params := h.ResultFinalizeBlock.ConsensusParamUpdates; params != nil {
+			paramsData, err := jsonpbMarshaller.MarshalToString(params)
+			if err != nil {
+				return fmt.Errorf("marshaling consensus params: %w", err)
+			}
+			if _, err := dbtx.Exec(`
+INSERT INTO `+tableParams+` (height, chain_id, created_at, params)
+  VALUES ($1, $2, $3, $4)
+  ON CONFLICT DO NOTHING;
+`, h.Header.Height, es.chainID, ts, paramsData); err != nil {
+				return fmt.Errorf("indexing consensus params: %w", err)
+			}
 		}
 		return nil
 	})
@@ -180,60 +453,107 @@ var (
 	jsonpbMarshaller = jsonpb.Marshaler{}
 )
 
+// IndexTxEvents indexes the specified transaction results, part of the
+// indexer.EventSink interface. All of the results are written in a single
+// database transaction, using multi-row inserts bounded by the batch size.
 func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
+	if len(txrs) == 0 {
+		return nil
+	}
 	ts := time.Now().UTC()
 
-	for _, txr := range txrs {
-		// Encode the result message in protobuf wire format for indexing.
-		resultData, err := jsonpbMarshaller.MarshalToString(txr)
-		if err != nil {
-			return fmt.Errorf("marshaling tx_result: %w", err)
-		}
-
-		// Index the hash of the underlying transaction as a hex string.
-		txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).Hash())
+	type txKey struct {
+		blockID int64
+		index   uint32
+	}
+	type txInfo struct {
+		txr     *abci.TxResult
+		blockID int64
+		hash    string
+	}
 
-		if err := runInTransaction(es.store, func(dbtx *sql.Tx) error {
-			// Find the block associated with this transaction. The block header
-			// must have been indexed prior to the transactions belonging to it.
-			blockID, err := queryWithID(dbtx, `
+	return es.runTx(func(dbtx *sql.Tx) error {
+		// Find the blocks associated with these transactions. The block header
+		// must have been indexed prior to the transactions belonging to it.
+		blockIDs := make(map[int64]int64)
+		infos := make(map[txKey]txInfo, len(txrs))
+		rows := make([][]interface{}, 0, len(txrs))
+		for _, txr := range txrs {
+			blockID, ok := blockIDs[txr.Height]
+			if !ok {
+				var err error
+				blockID, err = queryWithID(dbtx, `
 SELECT rowid FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2;
 `, txr.Height, es.chainID)
+				if err != nil {
+					return fmt.Errorf("finding block ID: %w", err)
+				}
+				blockIDs[txr.Height] = blockID
+			}
+
+			// Encode the result message in JSON format for indexing.
+			resultData, err := jsonpbMarshaller.MarshalToString(txr)
 			if err != nil {
-				return fmt.Errorf("finding block ID: %w", err)
+				return fmt.Errorf("marshaling tx_result: %w", err)
 			}
 
-			// Insert a record for this tx_result and capture its ID for indexing events.
-			txID, err := queryWithID(dbtx, `
-INSERT INTO `+tableTxResults+` (block_id, index, created_at, tx_hash, tx_result)
-  VALUES ($1, $2, $3, $4, $5)
+			// Index the hash of the underlying transaction as a hex string.
+			txHash := fmt.Sprintf("%X", types.Tx(txr.Tx).ID())
+
+			key := txKey{blockID: blockID, index: txr.Index}
+			if _, ok := infos[key]; ok {
+				continue // duplicate within this call; the first one wins
+			}
+			infos[key] = txInfo{txr: txr, blockID: blockID, hash: txHash}
+			rows = append(rows, []interface{}{blockID, txr.Index, ts, txHash, resultData})
+		}
+
+		// Insert records for the tx_results, and capture the IDs of those that
+		// were not already present for indexing events. Transactions we have
+		// already seen are quietly skipped.
+		var batch eventBatch
+		columns := []string{"block_id", "index", "created_at", "tx_hash", "tx_result"}
+		n := es.rowsPerInsert(len(columns))
+		for len(rows) > 0 {
+			if n > len(rows) {
+				n = len(rows)
+			}
+			stmt, args := multiRowInsert(tableTxResults, columns, rows[:n], `
   ON CONFLICT DO NOTHING
-  RETURNING rowid;
-`, blockID, txr.Index, ts, txHash, resultData)
-			if err == sql.ErrNoRows {
-				return nil // we already saw this transaction; quietly succeed
-			} else if err != nil {
+  RETURNING rowid, block_id, index;
+`)
+			inserted, err := dbtx.Query(stmt, args...)
+			if err != nil {
 				return fmt.Errorf("indexing tx_result: %w", err)
 			}
+			for inserted.Next() {
+				var txID int64
+				var key txKey
+				if err := inserted.Scan(&txID, &key.blockID, &key.index); err != nil {
+					inserted.Close()
+					return fmt.Errorf("indexing tx_result: %w", err)
+				}
+				info := infos[key]
 
-			// Insert the special transaction meta-events for hash and height.
-			if err := insertEvents(dbtx, blockID, txID, []abci.Event{
-				makeIndexedEvent(types.TxHashKey, txHash),
-				makeIndexedEvent(types.TxHeightKey, fmt.Sprint(txr.Height)),
-			}); err != nil {
-				return fmt.Errorf("indexing transaction meta-events: %w", err)
+				// Queue the special transaction meta-events for hash and height,
+				// followed by any events packaged with the transaction.
+				batch.add(info.blockID, txID, []abci.Event{
+					makeIndexedEvent(types.TxHashKey, info.hash),
+					makeIndexedEvent(types.TxHeightKey, fmt.Sprint(info.txr.Height)),
+				})
+				batch.add(info.blockID, txID, es.filter.Filter(info.txr.Result.Events))
 			}
-			// Index any events packaged with the transaction.
-			if err := insertEvents(dbtx, blockID, txID, txr.Result.Events); err != nil {
-				return fmt.Errorf("indexing transaction events: %w", err)
+			if err := inserted.Close(); err != nil {
+				return fmt.Errorf("indexing tx_result: %w", err)
 			}
-			return nil
+			rows = rows[n:]
+		}
 
-		}); err != nil {
-			return err
+		if err := batch.flush(es, dbtx); err != nil {
+			return fmt.Errorf("indexing transaction events: %w", err)
 		}
-	}
-	return nil
+		return nil
+	})
 }
 
 // SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
@@ -256,5 +576,27 @@ func (es *EventSink) HasBlock(h int64) (bool, error) {
 	return false, errors.New("hasBlock is not supported via the postgres event sink")
 }
 
+// Prune deletes the blocks below retainHeight from the database, along with
+// their transactions, events and attributes. It is part of the
+// indexer.Pruner interface.
+func (es *EventSink) Prune(retainHeight int64) error {
+	const prunedBlocks = `SELECT rowid FROM ` + tableBlocks + ` WHERE height < $1 AND chain_id = $2`
+
+	return es.runTx(func(dbtx *sql.Tx) error {
+		for _, stmt := range []string{
+			`DELETE FROM ` + tableAttributes + ` WHERE event_id IN (SELECT rowid FROM ` + tableEvents +
+				` WHERE block_id IN (` + prunedBlocks + `));`,
+			`DELETE FROM ` + tableEvents + ` WHERE block_id IN (` + prunedBlocks + `);`,
+			`DELETE FROM ` + tableTxResults + ` WHERE block_id IN (` + prunedBlocks + `);`,
+			`DELETE FROM ` + tableBlocks + ` WHERE height < $1 AND chain_id = $2;`,
+		} {
+			if _, err := dbtx.Exec(stmt, retainHeight, es.chainID); err != nil {
+				return fmt.Errorf("pruning below height %d: %w", retainHeight, err)
+			}
+		}
+		return nil
+	})
+}
+
 // Stop closes the underlying PostgreSQL database.
 func (es *EventSink) Stop() error { return es.store.Close() }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -12,23 +13,25 @@ import (
 	"testing"
 	"time"
 
-	"github.com/adlio/schema"
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/lib/pq"
 	"github.com/ory/dockertest"
 	"github.com/ory/dockertest/docker"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/internal/state/indexer"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
-
-	// Register the Postgres database driver.
-	_ "github.com/lib/pq"
 )
 
-// Verify that the type satisfies the EventSink interface.
-var _ indexer.EventSink = (*EventSink)(nil)
+// Verify that the type satisfies the EventSink and Pruner interfaces.
+var (
+	_ indexer.EventSink = (*EventSink)(nil)
+	_ indexer.Pruner    = (*EventSink)(nil)
+)
 
 var (
 	doPauseAtExit = flag.Bool("pause-at-exit", false,
@@ -37,6 +40,9 @@ var (
 	// A hook that test cases can call to obtain the shared database instance
 	// used for testing the sink. This is initialized in TestMain (see below).
 	testDB func() *sql.DB
+
+	// The docker pool of the containers of the tests, initialized in TestMain.
+	testPool *dockertest.Pool
 )
 
 const (
@@ -114,18 +120,13 @@ func TestMain(m *testing.M) {
 	if err := resetDatabase(db); err != nil {
 		log.Fatalf("Flushing database: %v", err)
 	}
-
-	sm, err := readSchema()
-	if err != nil {
-		log.Fatalf("Reading schema: %v", err)
-	}
-	migrator := schema.NewMigrator()
-	if err := migrator.Apply(db, sm); err != nil {
+	if err := Migrate(db); err != nil {
 		log.Fatalf("Applying schema: %v", err)
 	}
 
 	// Set up the hook for tests to get the shared database handle.
 	testDB = func() *sql.DB { return db }
+	testPool = pool
 
 	// Run the selected test cases.
 	code := m.Run()
@@ -179,6 +180,41 @@ func TestIndexing(t *testing.T) {
 		require.NoError(t, indexer.IndexBlockEvents(newTestBlockHeader()))
 	})
 
+	t.Run("IndexValidatorChanges", func(t *testing.T) {
+		indexer := &EventSink{store: testDB(), chainID: chainID}
+		val := types.NewValidator(ed25519.GenPrivKey().PubKey(), 10)
+		header := newTestBlockHeader()
+		header.Header.Height = 6
+		header.ValidatorChanges = []types.ValidatorChange{
+			{Type: types.ValidatorChangeAdded, Validator: val},
+		}
+		require.NoError(t, indexer.IndexBlockEvents(header))
+
+		var height int64
+		require.NoError(t, testDB().QueryRow(`
+SELECT height FROM block_events WHERE composite_key = 'validator_change.address' AND value = $1;
+`, val.Address.String()).Scan(&height))
+		assert.Equal(t, int64(6), height)
+	})
+
+	t.Run("IndexConsensusParams", func(t *testing.T) {
+		indexer := &EventSink{store: testDB(), chainID: chainID}
+		update := &tmproto.ConsensusParams{Block: &tmproto.BlockParams{MaxBytes: 1024, MaxGas: -1}}
+		header := newTestBlockHeader()
+		header.Header.Height = 5
+		header.ResultFinalizeBlock.ConsensusParamUpdates = update
+		require.NoError(t, indexer.IndexBlockEvents(header))
+
+		var data string
+		require.NoError(t, testDB().QueryRow(`
+SELECT params FROM `+tableParams+` WHERE height = $1 AND chain_id = $2;
+`, 5, chainID).Scan(&data))
+		params := new(tmproto.ConsensusParams)
+		require.NoError(t, jsonpbUnmarshaller.Unmarshal(bytes.NewBufferString(data), params))
+		assert.Equal(t, update, params)
+		require.NoError(t, verifyTimeStamp(tableParams))
+	})
+
 	t.Run("IndexTxEvents", func(t *testing.T) {
 		indexer := &EventSink{store: testDB(), chainID: chainID}
 
@@ -213,6 +249,205 @@ func TestIndexing(t *testing.T) {
 	})
 }
 
+func TestBatchedIndexing(t *testing.T) {
+	// Use a tiny batch size so that a modest block spans several statements.
+	indexer := &EventSink{store: testDB(), chainID: chainID, batchSize: 3}
+
+	hdr := newTestBlockHeader()
+	hdr.Header.Height = 100
+	require.NoError(t, indexer.IndexBlockEvents(hdr))
+
+	const numTxs = 10
+	txrs := make([]*abci.TxResult, numTxs)
+	for i := range txrs {
+		txr := txResultWithEvents([]abci.Event{
+			makeIndexedEvent("account.number", fmt.Sprint(i)),
+			makeIndexedEvent("account.owner", "Ivan"),
+		})
+		txr.Height = 100
+		txr.Index = uint32(i)
+		txr.Tx = types.Tx(fmt.Sprintf("BATCH TX %d", i))
+		txrs[i] = txr
+	}
+	require.NoError(t, indexer.IndexTxEvents(txrs))
+
+	for _, txr := range txrs {
+		got, err := loadTxResult(types.Tx(txr.Tx).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, txr, got)
+	}
+
+	// Each transaction has two meta-events plus two application events, and
+	// each event carries exactly one attribute.
+	var count int
+	require.NoError(t, testDB().QueryRow(`
+SELECT COUNT(*) FROM `+viewTxEvents+` WHERE height = $1 AND chain_id = $2;
+`, 100, chainID).Scan(&count))
+	assert.Equal(t, numTxs*4, count)
+
+	// Reindexing the same block must not duplicate anything.
+	require.NoError(t, indexer.IndexTxEvents(txrs))
+	require.NoError(t, testDB().QueryRow(`
+SELECT COUNT(*) FROM `+viewTxEvents+` WHERE height = $1 AND chain_id = $2;
+`, 100, chainID).Scan(&count))
+	assert.Equal(t, numTxs*4, count)
+}
+
+func TestIsSerializationFailure(t *testing.T) {
+	failure := &pq.Error{Code: sqlStateSerializationFailure}
+	assert.True(t, isSerializationFailure(failure))
+	assert.True(t, isSerializationFailure(fmt.Errorf("indexing: %w", failure)))
+	assert.False(t, isSerializationFailure(&pq.Error{Code: "23505"}))
+	assert.False(t, isSerializationFailure(errors.New("serialization failure")))
+}
+
+func TestRunTx(t *testing.T) {
+	es := &EventSink{store: testDB(), chainID: chainID, maxRetries: 2}
+
+	// serialization failures are retried up to the maximum number of retries
+	var attempts int
+	err := es.runTx(func(dbtx *sql.Tx) error {
+		attempts++
+		return &pq.Error{Code: sqlStateSerializationFailure}
+	})
+	require.Error(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	require.NoError(t, es.runTx(func(dbtx *sql.Tx) error {
+		if attempts++; attempts < 2 {
+			return &pq.Error{Code: sqlStateSerializationFailure}
+		}
+		return nil
+	}))
+	assert.Equal(t, 2, attempts)
+
+	// other errors are not
+	attempts = 0
+	require.Error(t, es.runTx(func(dbtx *sql.Tx) error {
+		attempts++
+		return errors.New("boom")
+	}))
+	assert.Equal(t, 1, attempts)
+
+	// the database aborts the statements exceeding the timeout
+	es.statementTimeout = 50 * time.Millisecond
+	require.Error(t, es.runTx(func(dbtx *sql.Tx) error {
+		_, err := dbtx.Exec(`SELECT pg_sleep(1);`)
+		return err
+	}))
+}
+
+func TestUniqueRowIDs(t *testing.T) {
+	// Emulate the unique_rowid function of CockroachDB, from which a sink on
+	// CockroachDB reserves the IDs of the events.
+	_, err := testDB().Exec(`
+CREATE FUNCTION unique_rowid() RETURNS bigint
+  AS $$ SELECT nextval(pg_get_serial_sequence('` + tableEvents + `', 'rowid')) $$ LANGUAGE SQL;
+`)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := testDB().Exec(`DROP FUNCTION unique_rowid();`)
+		require.NoError(t, err)
+	})
+
+	indexer := &EventSink{store: testDB(), chainID: chainID, cockroach: true}
+	indexAndVerifyBlock(t, indexer, testDB(), 200)
+}
+
+func TestCockroachDB(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode")
+	}
+
+	resource, err := testPool.RunWithOptions(&dockertest.RunOptions{
+		Repository:   "cockroachdb/cockroach",
+		Tag:          "v22.1.6",
+		Cmd:          []string{"start-single-node", "--insecure"},
+		ExposedPorts: []string{"26257"},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		t.Skipf("CockroachDB is not available: %v", err)
+	}
+	_ = resource.Expire(60)
+	t.Cleanup(func() { _ = testPool.Purge(resource) })
+
+	conn := fmt.Sprintf("postgres://root@localhost:%s/defaultdb?sslmode=disable", resource.GetPort("26257/tcp"))
+	var sink *EventSink
+	require.NoError(t, testPool.Retry(func() error {
+		sink, err = NewEventSink(conn, chainID, WithMaxRetries(2))
+		return err
+	}))
+	t.Cleanup(func() { _ = sink.Stop() })
+	require.True(t, sink.cockroach)
+
+	indexAndVerifyBlock(t, sink, sink.DB(), 1)
+
+	// CockroachDB aborts the transactions younger than the interval of
+	// force_retry with a serialization failure, which is retried.
+	var attempts int
+	require.NoError(t, sink.runTx(func(dbtx *sql.Tx) error {
+		if attempts++; attempts > 1 {
+			return nil
+		}
+		_, err := dbtx.Exec(`SELECT crdb_internal.force_retry('1h');`)
+		return err
+	}))
+	assert.Equal(t, 2, attempts)
+}
+
+// indexAndVerifyBlock indexes a block at the height, and its transactions,
+// with the sink on db, and verifies their events.
+func indexAndVerifyBlock(t *testing.T, sink *EventSink, db *sql.DB, height int64) {
+	t.Helper()
+
+	hdr := newTestBlockHeader()
+	hdr.Header.Height = height
+	require.NoError(t, sink.IndexBlockEvents(hdr))
+
+	const numTxs = 3
+	txrs := make([]*abci.TxResult, numTxs)
+	for i := range txrs {
+		txr := txResultWithEvents([]abci.Event{
+			makeIndexedEvent("account.number", fmt.Sprint(i)),
+			makeIndexedEvent("account.owner", "Ivan"),
+		})
+		txr.Height = height
+		txr.Index = uint32(i)
+		txr.Tx = types.Tx(fmt.Sprintf("ROWID TX %d %d", height, i))
+		txrs[i] = txr
+	}
+	require.NoError(t, sink.IndexTxEvents(txrs))
+
+	var count int
+	require.NoError(t, db.QueryRow(`
+SELECT COUNT(*) FROM `+viewTxEvents+` WHERE height = $1 AND chain_id = $2;
+`, height, chainID).Scan(&count))
+	assert.Equal(t, numTxs*4, count)
+
+	// the events of the block, and the meta-event of its height
+	require.NoError(t, db.QueryRow(`
+SELECT COUNT(*) FROM block_events WHERE height = $1 AND chain_id = $2;
+`, height, chainID).Scan(&count))
+	assert.Equal(t, len(hdr.ResultFinalizeBlock.Events)+1, count)
+}
+
+func TestMultiRowInsert(t *testing.T) {
+	stmt, args := multiRowInsert("t", []string{"a", "b"}, [][]interface{}{
+		{1, "x"}, {2, "y"},
+	}, ";")
+	assert.Equal(t, "INSERT INTO t (a, b) VALUES ($1, $2), ($3, $4);", stmt)
+	assert.Equal(t, []interface{}{1, "x", 2, "y"}, args)
+
+	es := &EventSink{}
+	assert.Equal(t, DefaultBatchSize, es.rowsPerInsert(4))
+	es.batchSize = 1 << 20
+	assert.Equal(t, maxBindParams/5, es.rowsPerInsert(5))
+}
+
 func TestStop(t *testing.T) {
 	indexer := &EventSink{store: testDB()}
 	require.NoError(t, indexer.Stop())
@@ -234,23 +469,9 @@ func newTestBlockHeader() types.EventDataNewBlockHeader {
 	}
 }
 
-// readSchema loads the indexing database schema file
-func readSchema() ([]*schema.Migration, error) {
-	const filename = "schema.sql"
-	contents, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read sql file from '%s': %w", filename, err)
-	}
-
-	return []*schema.Migration{{
-		ID:     time.Now().Local().String() + " db schema",
-		Script: string(contents),
-	}}, nil
-}
-
 // resetDB drops all the data from the test database.
 func resetDatabase(db *sql.DB) error {
-	_, err := db.Exec(`DROP TABLE IF EXISTS blocks,tx_results,events,attributes CASCADE;`)
+	_, err := db.Exec(`DROP TABLE IF EXISTS blocks,tx_results,events,attributes,consensus_params,schema_version CASCADE;`)
 	if err != nil {
 		return fmt.Errorf("dropping tables: %w", err)
 	}
@@ -0,0 +1,94 @@
+package sink
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+)
+
+// CustomPrefix is the prefix used in the tx-index.indexer configuration to
+// select an event sink from the registry, e.g. "custom:elasticsearch".
+const CustomPrefix = "custom:"
+
+// Factory constructs an event sink from the node configuration. The
+// dbProvider and chainID are the same values the built-in sinks receive.
+type Factory func(cfg *config.Config, dbProvider config.DBProvider, chainID string) (indexer.EventSink, error)
+
+var registry = struct {
+	mtx       sync.RWMutex
+	factories map[string]Factory
+}{factories: make(map[string]Factory)}
+
+// Register makes a custom event sink available under the given name. The
+// sink is enabled by listing "custom:<name>" in tx-index.indexer. Names are
+// case-insensitive. Register is intended to be called from init functions
+// and reports an error if the name is empty or already registered. Programs
+// outside this module register their sinks with node.RegisterEventSink.
+func Register(name string, factory Factory) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return errors.New("event sink name cannot be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("event sink %q: factory cannot be nil", name)
+	}
+
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	if _, ok := registry.factories[name]; ok {
+		return fmt.Errorf("event sink %q is already registered", name)
+	}
+	registry.factories[name] = factory
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func MustRegister(name string, factory Factory) {
+	if err := Register(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// Registered returns the sorted names of all registered custom event sinks.
+func Registered() []string {
+	registry.mtx.RLock()
+	defer registry.mtx.RUnlock()
+
+	names := make([]string, 0, len(registry.factories))
+	for name := range registry.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// unregister removes name from the registry. It is used by tests.
+func unregister(name string) {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	delete(registry.factories, strings.ToLower(name))
+}
+
+// newCustomSink constructs the registered custom sink for the given
+// tx-index.indexer entry, which must carry the CustomPrefix.
+func newCustomSink(entry string, cfg *config.Config, dbProvider config.DBProvider, chainID string) (indexer.EventSink, error) {
+	name := strings.TrimPrefix(entry, CustomPrefix)
+
+	registry.mtx.RLock()
+	factory, ok := registry.factories[name]
+	registry.mtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("custom event sink %q is not registered", name)
+	}
+
+	es, err := factory(cfg, dbProvider, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("creating custom event sink %q: %w", name, err)
+	}
+	return es, nil
+}
@@ -0,0 +1,79 @@
+package sink
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/null"
+)
+
+type customSink struct {
+	indexer.EventSink
+	name string
+}
+
+func (cs customSink) Type() indexer.EventSinkType { return indexer.EventSinkType(cs.name) }
+
+func TestRegister(t *testing.T) {
+	factory := func(*config.Config, config.DBProvider, string) (indexer.EventSink, error) {
+		return customSink{EventSink: null.NewEventSink(), name: "test"}, nil
+	}
+
+	require.NoError(t, Register("TestSink", factory))
+	defer unregister("testsink")
+
+	assert.Error(t, Register("testsink", factory), "duplicate names must be rejected")
+	assert.Error(t, Register("", factory))
+	assert.Error(t, Register("other", nil))
+	assert.Contains(t, Registered(), "testsink")
+}
+
+func TestEventSinksFromConfigCustom(t *testing.T) {
+	require.NoError(t, Register("alpha", func(_ *config.Config, _ config.DBProvider, chainID string) (indexer.EventSink, error) {
+		return customSink{EventSink: null.NewEventSink(), name: "alpha:" + chainID}, nil
+	}))
+	defer unregister("alpha")
+	require.NoError(t, Register("broken", func(*config.Config, config.DBProvider, string) (indexer.EventSink, error) {
+		return nil, errors.New("boom")
+	}))
+	defer unregister("broken")
+
+	testCases := []struct {
+		name    string
+		sinks   []string
+		types   []indexer.EventSinkType
+		wantErr bool
+	}{
+		{"kv and custom", []string{"kv", "custom:alpha"}, []indexer.EventSinkType{indexer.KV, "alpha:test-chain"}, false},
+		{"order preserved", []string{"custom:alpha", "kv"}, []indexer.EventSinkType{"alpha:test-chain", indexer.KV}, false},
+		{"case insensitive", []string{"Custom:ALPHA"}, []indexer.EventSinkType{"alpha:test-chain"}, false},
+		{"unregistered", []string{"custom:missing"}, nil, true},
+		{"factory error", []string{"custom:broken"}, nil, true},
+		{"duplicate", []string{"custom:alpha", "CUSTOM:alpha"}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.TestConfig()
+			cfg.SetRoot(t.TempDir())
+			cfg.TxIndex.Indexer = tc.sinks
+
+			sinks, err := EventSinksFromConfig(cfg, config.DefaultDBProvider, "test-chain")
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, sinks, len(tc.types))
+			for i, es := range sinks {
+				assert.Equal(t, tc.types[i], es.Type())
+			}
+			assert.True(t, indexer.IndexingEnabled(sinks))
+		})
+	}
+}
@@ -18,17 +18,31 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 		return []indexer.EventSink{null.NewEventSink()}, nil
 	}
 
-	// check for duplicated sinks
+	// check for duplicated sinks, preserving the configured order so that
+	// events are fanned out to the sinks in a predictable sequence.
 	sinks := map[string]struct{}{}
+	names := make([]string, 0, len(cfg.TxIndex.Indexer))
 	for _, s := range cfg.TxIndex.Indexer {
-		sl := strings.ToLower(s)
+		sl := strings.ToLower(strings.TrimSpace(s))
 		if _, ok := sinks[sl]; ok {
 			return nil, errors.New("found duplicated sinks, please check the tx-index section in the config.toml")
 		}
 		sinks[sl] = struct{}{}
+		names = append(names, sl)
 	}
+	filter := indexer.NewEventFilter(cfg.TxIndex.IndexEvents, cfg.TxIndex.ExcludeEvents)
+
 	eventSinks := []indexer.EventSink{}
-	for k := range sinks {
+	for _, k := range names {
+		if strings.HasPrefix(k, CustomPrefix) {
+			es, err := newCustomSink(k, cfg, dbProvider, chainID)
+			if err != nil {
+				return nil, err
+			}
+			eventSinks = append(eventSinks, es)
+			continue
+		}
+
 		switch indexer.EventSinkType(k) {
 		case indexer.NULL:
 			// When we see null in the config, the eventsinks will be reset with the
@@ -41,7 +55,7 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 				return nil, err
 			}
 
-			eventSinks = append(eventSinks, kv.NewEventSink(store))
+			eventSinks = append(eventSinks, kv.NewEventSink(store, kv.WithEventFilter(filter)))
 
 		case indexer.PSQL:
 			conn := cfg.TxIndex.PsqlConn
@@ -49,15 +63,28 @@ func EventSinksFromConfig(cfg *config.Config, dbProvider config.DBProvider, chai
 				return nil, errors.New("the psql connection settings cannot be empty")
 			}
 
-			es, err := psql.NewEventSink(conn, chainID)
+			opts := []psql.Option{
+				psql.WithBatchSize(cfg.TxIndex.PsqlBatchSize),
+				psql.WithEventFilter(filter),
+				psql.WithDriver(cfg.TxIndex.PsqlDriver),
+				psql.WithMaxOpenConns(cfg.TxIndex.PsqlMaxOpenConns),
+				psql.WithMaxIdleConns(cfg.TxIndex.PsqlMaxIdleConns),
+				psql.WithConnMaxLifetime(cfg.TxIndex.PsqlConnMaxLifetime),
+				psql.WithStatementTimeout(cfg.TxIndex.PsqlStatementTimeout),
+				psql.WithMaxRetries(cfg.TxIndex.PsqlMaxRetries),
+			}
+			if cfg.TxIndex.PsqlNoMigrate {
+				opts = append(opts, psql.WithoutMigrations())
+			}
+			es, err := psql.NewEventSink(conn, chainID, opts...)
 			if err != nil {
 				return nil, err
 			}
 			eventSinks = append(eventSinks, es)
+
 		default:
 			return nil, errors.New("unsupported event sink type")
 		}
 	}
 	return eventSinks, nil
-
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -25,14 +26,27 @@ var _ indexer.TxIndexer = (*TxIndex)(nil)
 // 1. txhash - result  (primary key)
 // 2. event - txhash   (secondary key)
 type TxIndex struct {
-	store dbm.DB
+	store  dbm.DB
+	filter *indexer.EventFilter
+}
+
+// Option sets an optional parameter on the TxIndex.
+type Option func(*TxIndex)
+
+// WithEventFilter restricts the event attributes that the TxIndex indexes.
+func WithEventFilter(f *indexer.EventFilter) Option {
+	return func(txi *TxIndex) { txi.filter = f }
 }
 
 // NewTxIndex creates new KV indexer.
-func NewTxIndex(store dbm.DB) *TxIndex {
-	return &TxIndex{
+func NewTxIndex(store dbm.DB, opts ...Option) *TxIndex {
+	txi := &TxIndex{
 		store: store,
 	}
+	for _, opt := range opts {
+		opt(txi)
+	}
+	return txi
 }
 
 // Get gets transaction from the TxIndex storage and returns it or nil if the
@@ -68,7 +82,7 @@ func (txi *TxIndex) Index(results []*abci.TxResult) error {
 	defer b.Close()
 
 	for _, result := range results {
-		hash := types.Tx(result.Tx).Hash()
+		hash := types.Tx(result.Tx).ID()
 
 		// index tx by events
 		err := txi.indexEvents(result, hash, b)
@@ -108,13 +122,13 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 				continue
 			}
 
-			// index if `index: true` is set
+			// index if `index: true` is set and the filter allows it
 			compositeTag := fmt.Sprintf("%s.%s", event.Type, attr.Key)
 			// ensure event does not conflict with a reserved prefix key
 			if compositeTag == types.TxHashKey || compositeTag == types.TxHeightKey {
 				return fmt.Errorf("event type and attribute key \"%s\" is reserved; please use a different key", compositeTag)
 			}
-			if attr.GetIndex() {
+			if attr.GetIndex() && txi.filter.IndexAttribute(event.Type, attr.Key) {
 				err := store.Set(keyFromEvent(compositeTag, attr.Value, result), hash)
 				if err != nil {
 					return err
@@ -126,14 +140,119 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 	return nil
 }
 
+// Prune removes the transactions of all blocks below retainHeight from the
+// index, including their event keys. Tx heights are keyed by their decimal
+// string, so the heights of the index are not in order: the first call walks
+// the whole height index, and records the height it pruned up to, from which
+// the next calls visit the entries of each height to prune. The transactions
+// indexed afterwards at a height below it, e.g. by reindexing old blocks, are
+// not pruned.
+func (txi *TxIndex) Prune(retainHeight int64) error {
+	pruned, err := txi.prunedHeight()
+	if err != nil {
+		return err
+	}
+	if pruned >= retainHeight {
+		return nil
+	}
+
+	var refs []txRef
+	var heightKeys [][]byte
+	collect := func(prefix []byte) error {
+		it, err := dbm.IteratePrefix(txi.store, prefix)
+		if err != nil {
+			return err
+		}
+		defer it.Close()
+		for ; it.Valid(); it.Next() {
+			ref := refFromEntry(it.Key(), it.Value())
+			if ref.height <= 0 || ref.height >= retainHeight {
+				continue
+			}
+			refs = append(refs, ref)
+			heightKeys = append(heightKeys, append([]byte(nil), it.Key()...))
+		}
+		return it.Error()
+	}
+	if pruned == 0 {
+		if err := collect(prefixFromCompositeKey(types.TxHeightKey)); err != nil {
+			return err
+		}
+	} else {
+		for height := pruned; height < retainHeight; height++ {
+			if err := collect(prefixFromCompositeKeyAndValue(types.TxHeightKey, strconv.FormatInt(height, 10))); err != nil {
+				return err
+			}
+		}
+	}
+
+	b := txi.store.NewBatch()
+	defer b.Close()
+
+	for i, ref := range refs {
+		if err := b.Delete(heightKeys[i]); err != nil {
+			return err
+		}
+
+		res, err := txi.Get(ref.hash)
+		if err != nil {
+			return fmt.Errorf("failed to get Tx{%X}: %w", ref.hash, err)
+		}
+		// The same transaction may have been included again in a later block,
+		// in which case the stored result and its event keys belong to that
+		// block and must be kept.
+		if res == nil || res.Height >= retainHeight {
+			continue
+		}
+		for _, event := range res.Result.Events {
+			for _, attr := range event.Attributes {
+				if len(event.Type) == 0 || len(attr.Key) == 0 || !attr.GetIndex() {
+					continue
+				}
+				compositeTag := fmt.Sprintf("%s.%s", event.Type, attr.Key)
+				if err := b.Delete(keyFromEvent(compositeTag, attr.Value, res)); err != nil {
+					return err
+				}
+			}
+		}
+		if err := b.Delete(primaryKey(ref.hash)); err != nil {
+			return err
+		}
+	}
+	if err := b.Set(prunedHeightKey(), []byte(strconv.FormatInt(retainHeight, 10))); err != nil {
+		return err
+	}
+
+	return b.WriteSync()
+}
+
+// prunedHeight returns the height up to which Prune pruned the index, or 0 if
+// it was never pruned.
+func (txi *TxIndex) prunedHeight() (int64, error) {
+	bz, err := txi.store.Get(prunedHeightKey())
+	if err != nil || bz == nil {
+		return 0, err
+	}
+	height, err := strconv.ParseInt(string(bz), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid pruned height %q: %w", bz, err)
+	}
+	return height, nil
+}
+
 // Search performs a search using the given query.
 //
-// It breaks the query into conditions (like "tx.height > 5"). For each
+// A query is a disjunction of one or more alternatives separated by OR, each
+// of which is resolved separately; the results are the union of the matches
+// for every alternative.
+//
+// Each alternative is broken into conditions (like "tx.height > 5"). For each
 // condition, it queries the DB index. One special use cases here: (1) if
 // "tx.hash" is found, it returns tx result for it (2) for range queries it is
 // better for the client to provide both lower and upper bounds, so we are not
-// performing a full scan. Results from querying indexes are then intersected
-// and returned to the caller, in no particular order.
+// performing a full scan. Results from querying indexes are then intersected,
+// matches for negated (NOT) conditions are removed, and the remaining results
+// are returned to the caller, in no particular order.
 //
 // Search will exit early and return any result fetched so far,
 // when a message is received on the context chan.
@@ -145,11 +264,107 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 	default:
 	}
 
-	var hashesInitialized bool
-	filteredHashes := make(map[string][]byte)
+	matchedHashes, err := txi.searchExpr(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*abci.TxResult, 0, len(matchedHashes))
+hashes:
+	for _, ref := range matchedHashes {
+		res, err := txi.Get(ref.hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Tx{%X}: %w", ref.hash, err)
+		}
+		results = append(results, res)
+
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break hashes
+		default:
+		}
+	}
+
+	return results, nil
+}
+
+// SearchPage performs a search like Search, but only loads the transactions
+// in the window selected by page. The positions of the matches are known from
+// the index keys, so they are ordered and paginated before any transaction is
+// read from the store.
+func (txi *TxIndex) SearchPage(ctx context.Context, q *query.Query, page indexer.PageRequest) (indexer.TxPage, error) {
+	select {
+	case <-ctx.Done():
+		return indexer.TxPage{}, nil
+
+	default:
+	}
+
+	matchedHashes, err := txi.searchExpr(ctx, q)
+	if err != nil {
+		return indexer.TxPage{}, err
+	}
+
+	refs := make([]txRef, 0, len(matchedHashes))
+	for _, ref := range matchedHashes {
+		refs = append(refs, ref)
+	}
+	before := func(a, b txRef) bool {
+		if page.Descending {
+			return indexer.TxResultLess(b.height, b.index, a.height, a.index)
+		}
+		return indexer.TxResultLess(a.height, a.index, b.height, b.index)
+	}
+	sort.Slice(refs, func(i, j int) bool { return before(refs[i], refs[j]) })
+
+	start := 0
+	if c := page.After; c != nil {
+		pos := txRef{height: c.Height, index: c.Index}
+		start = sort.Search(len(refs), func(i int) bool { return before(pos, refs[i]) })
+	}
+	end := len(refs)
+	if page.Limit > 0 && start+page.Limit < end {
+		end = start + page.Limit
+	}
+
+	tp := indexer.TxPage{Txs: make([]*abci.TxResult, 0, end-start), Total: len(refs)}
+	for _, ref := range refs[start:end] {
+		res, err := txi.Get(ref.hash)
+		if err != nil {
+			return indexer.TxPage{}, fmt.Errorf("failed to get Tx{%X}: %w", ref.hash, err)
+		}
+		tp.Txs = append(tp.Txs, res)
+	}
+	if end < len(refs) && end > start {
+		last := refs[end-1]
+		tp.Next = &indexer.Cursor{Height: last.height, Index: last.index, Descending: page.Descending}
+	}
+
+	return tp, nil
+}
 
-	// get a list of conditions (like "tx.height > 5")
-	conditions := q.Syntax()
+// searchExpr returns the union of the matches for every alternative of q,
+// keyed by transaction hash.
+func (txi *TxIndex) searchExpr(ctx context.Context, q *query.Query) (map[string]txRef, error) {
+	matchedHashes := make(map[string]txRef)
+	for _, conditions := range q.Expr() {
+		hashes, err := txi.searchConditions(ctx, conditions)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range hashes {
+			matchedHashes[k] = v
+		}
+	}
+	return matchedHashes, nil
+}
+
+// searchConditions returns the hashes of the transactions matching all of the
+// given conditions.
+func (txi *TxIndex) searchConditions(ctx context.Context, conditions []syntax.Condition) (map[string]txRef, error) {
+	var hashesInitialized bool
+	filteredHashes := make(map[string]txRef)
 
 	// if there is a hash condition, return the result immediately
 	hash, ok, err := lookForHash(conditions)
@@ -159,12 +374,11 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		res, err := txi.Get(hash)
 		switch {
 		case err != nil:
-			return []*abci.TxResult{}, fmt.Errorf("error while retrieving the result: %w", err)
-		case res == nil:
-			return []*abci.TxResult{}, nil
-		default:
-			return []*abci.TxResult{res}, nil
+			return nil, fmt.Errorf("error while retrieving the result: %w", err)
+		case res != nil:
+			filteredHashes[string(hash)] = txRef{hash: hash, height: res.Height, index: res.Index}
 		}
+		return filteredHashes, nil
 	}
 
 	// conditions to skip because they're handled before "everything else"
@@ -198,7 +412,7 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 
 	// for all other conditions
 	for i, c := range conditions {
-		if intInSlice(i, skipIndexes) {
+		if c.Not || intInSlice(i, skipIndexes) {
 			continue
 		}
 
@@ -216,29 +430,66 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 		}
 	}
 
-	results := make([]*abci.TxResult, 0, len(filteredHashes))
-hashes:
-	for _, h := range filteredHashes {
-		res, err := txi.Get(h)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get Tx{%X}: %w", h, err)
+	// Finally, remove the matches of any negated conditions. If there were no
+	// positive conditions to start from, every indexed transaction is a
+	// candidate.
+	for _, c := range conditions {
+		if !c.Not {
+			continue
+		}
+		if !hashesInitialized {
+			filteredHashes = txi.allHashes(ctx)
+			hashesInitialized = true
+		}
+		if len(filteredHashes) == 0 {
+			break
 		}
-		results = append(results, res)
+
+		var excluded map[string]txRef
+		if indexer.IsRangeOperation(c.Op) {
+			qr := indexer.RangeForCondition(c)
+			excluded = txi.matchRange(ctx, qr, prefixFromCompositeKey(qr.Key), nil, true)
+		} else {
+			excluded = txi.match(ctx, c, prefixForCondition(c, height), nil, true)
+		}
+		for k := range excluded {
+			delete(filteredHashes, k)
+		}
+	}
+
+	return filteredHashes, nil
+}
+
+// allHashes returns the hashes of all indexed transactions.
+func (txi *TxIndex) allHashes(ctx context.Context) map[string]txRef {
+	hashes := make(map[string]txRef)
+
+	it, err := dbm.IteratePrefix(txi.store, prefixFromCompositeKey(types.TxHeightKey))
+	if err != nil {
+		panic(err)
+	}
+	defer it.Close()
+
+iter:
+	for ; it.Valid(); it.Next() {
+		hashes[string(it.Value())] = refFromEntry(it.Key(), it.Value())
 
 		// Potentially exit early.
 		select {
 		case <-ctx.Done():
-			break hashes
+			break iter
 		default:
 		}
 	}
-
-	return results, nil
+	if err := it.Error(); err != nil {
+		panic(err)
+	}
+	return hashes
 }
 
 func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error) {
 	for _, c := range conditions {
-		if c.Tag == types.TxHashKey {
+		if c.Tag == types.TxHashKey && c.Op == syntax.TEq && !c.Not {
 			decoded, err := hex.DecodeString(c.Arg.Value())
 			return decoded, true, err
 		}
@@ -249,7 +500,7 @@ func lookForHash(conditions []syntax.Condition) (hash []byte, ok bool, err error
 // lookForHeight returns a height if there is an "height=X" condition.
 func lookForHeight(conditions []syntax.Condition) (height int64) {
 	for _, c := range conditions {
-		if c.Tag == types.TxHeightKey && c.Op == syntax.TEq {
+		if c.Tag == types.TxHeightKey && c.Op == syntax.TEq && !c.Not {
 			return int64(c.Arg.Number())
 		}
 	}
@@ -265,16 +516,16 @@ func (txi *TxIndex) match(
 	ctx context.Context,
 	c syntax.Condition,
 	startKeyBz []byte,
-	filteredHashes map[string][]byte,
+	filteredHashes map[string]txRef,
 	firstRun bool,
-) map[string][]byte {
+) map[string]txRef {
 	// A previous match was attempted but resulted in no matches, so we return
 	// no matches (assuming AND operand).
 	if !firstRun && len(filteredHashes) == 0 {
 		return filteredHashes
 	}
 
-	tmpHashes := make(map[string][]byte)
+	tmpHashes := make(map[string]txRef)
 
 	switch {
 	case c.Op == syntax.TEq:
@@ -286,7 +537,7 @@ func (txi *TxIndex) match(
 
 	iterEqual:
 		for ; it.Valid(); it.Next() {
-			tmpHashes[string(it.Value())] = it.Value()
+			tmpHashes[string(it.Value())] = refFromEntry(it.Key(), it.Value())
 
 			// Potentially exit early.
 			select {
@@ -310,7 +561,7 @@ func (txi *TxIndex) match(
 
 	iterExists:
 		for ; it.Valid(); it.Next() {
-			tmpHashes[string(it.Value())] = it.Value()
+			tmpHashes[string(it.Value())] = refFromEntry(it.Key(), it.Value())
 
 			// Potentially exit early.
 			select {
@@ -340,7 +591,7 @@ func (txi *TxIndex) match(
 				continue
 			}
 			if strings.Contains(value, c.Arg.Value()) {
-				tmpHashes[string(it.Value())] = it.Value()
+				tmpHashes[string(it.Value())] = refFromEntry(it.Key(), it.Value())
 			}
 
 			// Potentially exit early.
@@ -371,7 +622,7 @@ func (txi *TxIndex) match(
 	// Remove/reduce matches in filteredHashes that were not found in this
 	// match (tmpHashes).
 	for k := range filteredHashes {
-		if tmpHashes[k] == nil {
+		if _, ok := tmpHashes[k]; !ok {
 			delete(filteredHashes, k)
 
 			// Potentially exit early.
@@ -395,16 +646,16 @@ func (txi *TxIndex) matchRange(
 	ctx context.Context,
 	qr indexer.QueryRange,
 	startKey []byte,
-	filteredHashes map[string][]byte,
+	filteredHashes map[string]txRef,
 	firstRun bool,
-) map[string][]byte {
+) map[string]txRef {
 	// A previous match was attempted but resulted in no matches, so we return
 	// no matches (assuming AND operand).
 	if !firstRun && len(filteredHashes) == 0 {
 		return filteredHashes
 	}
 
-	tmpHashes := make(map[string][]byte)
+	tmpHashes := make(map[string]txRef)
 	lowerBound := qr.LowerBoundValue()
 	upperBound := qr.UpperBoundValue()
 
@@ -436,7 +687,7 @@ iter:
 			}
 
 			if include {
-				tmpHashes[string(it.Value())] = it.Value()
+				tmpHashes[string(it.Value())] = refFromEntry(it.Key(), it.Value())
 			}
 
 			// XXX: passing time in a ABCI Events is not yet implemented
@@ -472,7 +723,7 @@ iter:
 	// Remove/reduce matches in filteredHashes that were not found in this
 	// match (tmpHashes).
 	for k := range filteredHashes {
-		if tmpHashes[k] == nil {
+		if _, ok := tmpHashes[k]; !ok {
 			delete(filteredHashes, k)
 
 			// Potentially exit early.
@@ -487,6 +738,27 @@ iter:
 	return filteredHashes
 }
 
+// txRef is a transaction matched by a search: its hash and its position in
+// the chain, as recorded in the secondary key that matched.
+type txRef struct {
+	hash   []byte
+	height int64
+	index  uint32
+}
+
+// refFromEntry builds a txRef from a secondary key and its value (the hash).
+// Keys that cannot be parsed leave the position zeroed.
+func refFromEntry(key, hash []byte) txRef {
+	var (
+		compositeKey, value string
+		height, index       int64
+	)
+	if _, err := orderedcode.Parse(string(key), &compositeKey, &value, &height, &index); err != nil {
+		return txRef{hash: hash}
+	}
+	return txRef{hash: hash, height: height, index: uint32(index)}
+}
+
 // ##########################  Keys  #############################
 //
 // The indexer has two types of kv stores:
@@ -555,6 +827,17 @@ func KeyFromHeight(result *abci.TxResult) []byte {
 	return secondaryKey(types.TxHeightKey, fmt.Sprintf("%d", result.Height), result.Height, result.Index)
 }
 
+// prunedHeightKey is the key of the height up to which the index was pruned.
+// Event keys are composite keys of the form "type.key", so they can't collide
+// with it.
+func prunedHeightKey() []byte {
+	key, err := orderedcode.Append(nil, "tx_pruned_height")
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
 // Prefixes: these represent an initial part of the key and are used by iterators to iterate over a small
 // section of the kv store during searches.
 
@@ -3,6 +3,7 @@ package kv
 import (
 	"context"
 	"fmt"
+	"sort"
 	"testing"
 
 	"github.com/gogo/protobuf/proto"
@@ -316,6 +317,191 @@ func TestTxSearchMultipleTxs(t *testing.T) {
 	require.Len(t, results, 3)
 }
 
+func TestTxSearchCompositeQueries(t *testing.T) {
+	indexer := NewTxIndex(dbm.NewMemDB())
+
+	for i, owner := range []string{"Ivan", "Vlad", "Igor", "Ivan"} {
+		txr := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{
+				{Key: "number", Value: fmt.Sprint(i + 1), Index: true},
+				{Key: "owner", Value: owner, Index: true},
+			}},
+		})
+		txr.Tx = types.Tx(fmt.Sprintf("tx-%d", i))
+		txr.Height = int64(i + 1)
+		require.NoError(t, indexer.Index([]*abci.TxResult{txr}))
+	}
+
+	testCases := []struct {
+		q       string
+		heights []int64
+	}{
+		// OR of exact matches
+		{"account.owner = 'Vlad' OR account.owner = 'Igor'", []int64{2, 3}},
+		// overlapping alternatives are de-duplicated
+		{"account.owner = 'Ivan' OR account.number <= 1", []int64{1, 4}},
+		// AND binds more tightly than OR
+		{"account.owner = 'Ivan' AND tx.height > 2 OR account.number = 2", []int64{2, 4}},
+		// multiple ranges on different keys
+		{"account.number >= 2 AND tx.height <= 3", []int64{2, 3}},
+		// repeated bounds on the same key keep the most restrictive one
+		{"account.number > 1 AND account.number > 2", []int64{3, 4}},
+		{"account.number < 4 AND account.number <= 2", []int64{1, 2}},
+		// negation of an exact match
+		{"account.number >= 1 AND NOT account.owner = 'Ivan'", []int64{2, 3}},
+		// negation without any positive condition
+		{"NOT account.owner = 'Ivan'", []int64{2, 3}},
+		// negation of a range
+		{"account.owner EXISTS AND NOT account.number > 2", []int64{1, 2}},
+		// negation combined with OR
+		{"NOT account.number <= 3 OR account.owner = 'Igor'", []int64{3, 4}},
+		// nothing left after negation
+		{"account.owner = 'Vlad' AND NOT account.number = 2", nil},
+	}
+
+	ctx := context.Background()
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.q, func(t *testing.T) {
+			results, err := indexer.Search(ctx, query.MustCompile(tc.q))
+			require.NoError(t, err)
+
+			var heights []int64
+			for _, txr := range results {
+				heights = append(heights, txr.Height)
+			}
+			sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+			assert.Equal(t, tc.heights, heights)
+		})
+	}
+}
+
+func TestTxSearchPage(t *testing.T) {
+	txIndexer := NewTxIndex(dbm.NewMemDB())
+
+	// two transactions in each of three blocks
+	for i := 0; i < 6; i++ {
+		txr := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{
+				{Key: "number", Value: fmt.Sprint(i), Index: true},
+			}},
+		})
+		txr.Tx = types.Tx(fmt.Sprintf("tx-%d", i))
+		txr.Height = int64(i/2 + 1)
+		txr.Index = uint32(i % 2)
+		require.NoError(t, txIndexer.Index([]*abci.TxResult{txr}))
+	}
+
+	ctx := context.Background()
+	q := query.MustCompile("account.number >= 1")
+
+	for _, desc := range []bool{false, true} {
+		page := indexer.PageRequest{Limit: 2, Descending: desc}
+		var positions []string
+		for {
+			tp, err := txIndexer.SearchPage(ctx, q, page)
+			require.NoError(t, err)
+			require.Equal(t, 5, tp.Total)
+			require.LessOrEqual(t, len(tp.Txs), 2)
+			for _, txr := range tp.Txs {
+				positions = append(positions, fmt.Sprintf("%d/%d", txr.Height, txr.Index))
+			}
+			if tp.Next == nil {
+				break
+			}
+			page.After = tp.Next
+		}
+
+		want := []string{"1/1", "2/0", "2/1", "3/0", "3/1"}
+		if desc {
+			want = []string{"3/1", "3/0", "2/1", "2/0", "1/1"}
+		}
+		assert.Equal(t, want, positions, "descending=%v", desc)
+	}
+}
+
+func TestTxIndexEventFilter(t *testing.T) {
+	txIndexer := NewTxIndex(dbm.NewMemDB(), WithEventFilter(
+		indexer.NewEventFilter(nil, []string{"account.memo"})))
+
+	txResult := txResultWithEvents([]abci.Event{
+		{Type: "account", Attributes: []abci.EventAttribute{
+			{Key: "owner", Value: "Ivan", Index: true},
+			{Key: "memo", Value: "hello", Index: true},
+		}},
+	})
+	require.NoError(t, txIndexer.Index([]*abci.TxResult{txResult}))
+
+	ctx := context.Background()
+	results, err := txIndexer.Search(ctx, query.MustCompile("account.owner = 'Ivan'"))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	// the stored result keeps every event, only the index is filtered
+	assert.True(t, proto.Equal(txResult, results[0]))
+
+	results, err = txIndexer.Search(ctx, query.MustCompile("account.memo = 'hello'"))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestTxIndexPrune(t *testing.T) {
+	txIndexer := NewTxIndex(dbm.NewMemDB())
+
+	hashes := make([][]byte, 0, 10)
+	for i := 1; i <= 10; i++ {
+		txr := txResultWithEvents([]abci.Event{
+			{Type: "account", Attributes: []abci.EventAttribute{
+				{Key: "number", Value: fmt.Sprint(i), Index: true},
+			}},
+		})
+		txr.Tx = types.Tx(fmt.Sprintf("tx-%d", i))
+		txr.Height = int64(i)
+		require.NoError(t, txIndexer.Index([]*abci.TxResult{txr}))
+		hashes = append(hashes, types.Tx(txr.Tx).Hash())
+	}
+
+	require.NoError(t, txIndexer.Prune(8))
+
+	for i, hash := range hashes {
+		res, err := txIndexer.Get(hash)
+		require.NoError(t, err)
+		assert.Equal(t, i+1 >= 8, res != nil, "height %d", i+1)
+	}
+
+	ctx := context.Background()
+	for q, want := range map[string][]int64{
+		"account.number >= 1":    {8, 9, 10},
+		"tx.height >= 1":         {8, 9, 10},
+		"NOT account.number = 9": {8, 10},
+	} {
+		results, err := txIndexer.Search(ctx, query.MustCompile(q))
+		require.NoError(t, err)
+		var heights []int64
+		for _, txr := range results {
+			heights = append(heights, txr.Height)
+		}
+		sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+		assert.Equal(t, want, heights, q)
+	}
+
+	// the next calls only visit the heights from the last pruned one, so a tx
+	// reindexed below it is kept
+	reindexed := txResultWithEvents(nil)
+	reindexed.Tx = types.Tx("tx-reindexed")
+	reindexed.Height = 3
+	require.NoError(t, txIndexer.Index([]*abci.TxResult{reindexed}))
+	require.NoError(t, txIndexer.Prune(5))
+	require.NoError(t, txIndexer.Prune(10))
+	for i, hash := range hashes {
+		res, err := txIndexer.Get(hash)
+		require.NoError(t, err)
+		assert.Equal(t, i+1 >= 10, res != nil, "height %d", i+1)
+	}
+	res, err := txIndexer.Get(types.Tx(reindexed.Tx).Hash())
+	require.NoError(t, err)
+	assert.NotNil(t, res)
+}
+
 func txResultWithEvents(events []abci.Event) *abci.TxResult {
 	tx := types.Tx("HELLO WORLD")
 	return &abci.TxResult{
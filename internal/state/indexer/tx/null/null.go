@@ -32,3 +32,8 @@ func (txi *TxIndex) Index(results []*abci.TxResult) error {
 func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
 	return []*abci.TxResult{}, nil
 }
+
+// Prune is a noop and always returns nil.
+func (txi *TxIndex) Prune(retainHeight int64) error {
+	return nil
+}
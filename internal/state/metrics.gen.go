@@ -34,13 +34,89 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "validator_set_updates",
 			Help:      "Number of validator set updates returned by the application since process start.",
 		}, labels).With(labelsAndValues...),
+		PrepareProposalTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "prepare_proposal_txs",
+			Help:      "Number of transactions added to or removed from proposals by the application in PrepareProposal.",
+		}, append(labels, "action")).With(labelsAndValues...),
+		PruningDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruning_duration",
+			Help:      "Time taken by a run of the pruner, in seconds.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.01, 100, 10),
+		}, labels).With(labelsAndValues...),
+		CompactionDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "compaction_duration",
+			Help:      "Time taken to compact the databases after pruning, in seconds.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.1, 1000, 10),
+		}, labels).With(labelsAndValues...),
+		PrunedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruned_blocks",
+			Help:      "Number of blocks pruned since process start.",
+		}, labels).With(labelsAndValues...),
+		BlockRetainHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_retain_height",
+			Help:      "BlockRetainHeight is the height below which the pruner removes blocks and states.",
+		}, labels).With(labelsAndValues...),
+		BlockResultsRetainHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_results_retain_height",
+			Help:      "BlockResultsRetainHeight is the height below which the pruner removes block results.",
+		}, labels).With(labelsAndValues...),
+		StorageAuditDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "storage_audit_duration",
+			Help:      "Time taken by a run of the storage auditor, in seconds.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.01, 100, 10),
+		}, labels).With(labelsAndValues...),
+		StorageAuditHeights: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "storage_audit_heights",
+			Help:      "Number of heights audited by the storage auditor since process start.",
+		}, labels).With(labelsAndValues...),
+		StorageAuditFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "storage_audit_failures",
+			Help:      "Number of failed checks of the storage auditor since process start.",
+		}, append(labels, "check")).With(labelsAndValues...),
+		StorageCorrupted: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "storage_corrupted",
+			Help:      "Whether the last run of the storage auditor found corruption.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		BlockProcessingTime:   discard.NewHistogram(),
-		ConsensusParamUpdates: discard.NewCounter(),
-		ValidatorSetUpdates:   discard.NewCounter(),
+		BlockProcessingTime:      discard.NewHistogram(),
+		ConsensusParamUpdates:    discard.NewCounter(),
+		ValidatorSetUpdates:      discard.NewCounter(),
+		PrepareProposalTxs:       discard.NewCounter(),
+		PruningDuration:          discard.NewHistogram(),
+		CompactionDuration:       discard.NewHistogram(),
+		PrunedBlocks:             discard.NewCounter(),
+		BlockRetainHeight:        discard.NewGauge(),
+		BlockResultsRetainHeight: discard.NewGauge(),
+		StorageAuditDuration:     discard.NewHistogram(),
+		StorageAuditHeights:      discard.NewCounter(),
+		StorageAuditFailures:     discard.NewCounter(),
+		StorageCorrupted:         discard.NewGauge(),
 	}
 }
@@ -26,4 +26,52 @@ type Metrics struct {
 	// udated the validator set since process start.
 	//metrics:Number of validator set updates returned by the application since process start.
 	ValidatorSetUpdates metrics.Counter
+
+	// PrepareProposalTxs is the total number of transactions that the
+	// application added to or removed from the proposals of this node, by
+	// action.
+	//metrics:Number of transactions added to or removed from proposals by the application in PrepareProposal.
+	PrepareProposalTxs metrics.Counter `metrics_labels:"action"`
+
+	// PruningDuration is the time taken by a run of the pruner, in seconds.
+	//metrics:Time taken by a run of the pruner, in seconds.
+	PruningDuration metrics.Histogram `metrics_buckettype:"exprange" metrics_bucketsizes:"0.01, 100, 10"`
+
+	// CompactionDuration is the time taken to compact the databases after
+	// pruning, in seconds.
+	//metrics:Time taken to compact the databases after pruning, in seconds.
+	CompactionDuration metrics.Histogram `metrics_buckettype:"exprange" metrics_bucketsizes:"0.1, 1000, 10"`
+
+	// PrunedBlocks is the total number of blocks removed by the pruner since
+	// process start.
+	//metrics:Number of blocks pruned since process start.
+	PrunedBlocks metrics.Counter
+
+	// BlockRetainHeight is the height below which the pruner removes blocks
+	// and states.
+	BlockRetainHeight metrics.Gauge
+
+	// BlockResultsRetainHeight is the height below which the pruner removes
+	// block results.
+	BlockResultsRetainHeight metrics.Gauge
+
+	// StorageAuditDuration is the time taken by a run of the storage auditor,
+	// in seconds.
+	//metrics:Time taken by a run of the storage auditor, in seconds.
+	StorageAuditDuration metrics.Histogram `metrics_buckettype:"exprange" metrics_bucketsizes:"0.01, 100, 10"`
+
+	// StorageAuditHeights is the total number of heights audited by the
+	// storage auditor since process start.
+	//metrics:Number of heights audited by the storage auditor since process start.
+	StorageAuditHeights metrics.Counter
+
+	// StorageAuditFailures is the total number of failed checks of the storage
+	// auditor since process start, by check.
+	//metrics:Number of failed checks of the storage auditor since process start.
+	StorageAuditFailures metrics.Counter `metrics_labels:"check"`
+
+	// StorageCorrupted is 1 if the last run of the storage auditor found
+	// corruption in the block or state stores, and 0 otherwise.
+	//metrics:Whether the last run of the storage auditor found corruption.
+	StorageCorrupted metrics.Gauge
 }
@@ -30,6 +30,20 @@ func (_m *Store) Bootstrap(_a0 state.State) error {
 	return r0
 }
 
+// ClearCommitMarker provides a mock function with given fields:
+func (_m *Store) ClearCommitMarker() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Close provides a mock function with given fields:
 func (_m *Store) Close() error {
 	ret := _m.Called()
@@ -65,6 +79,90 @@ func (_m *Store) Load() (state.State, error) {
 	return r0, r1
 }
 
+// LoadApplicationRetainHeight provides a mock function with given fields:
+func (_m *Store) LoadApplicationRetainHeight() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadCommitMarker provides a mock function with given fields:
+func (_m *Store) LoadCommitMarker() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadCompanionResultsRetainHeight provides a mock function with given fields:
+func (_m *Store) LoadCompanionResultsRetainHeight() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadCompanionRetainHeight provides a mock function with given fields:
+func (_m *Store) LoadCompanionRetainHeight() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadConsensusParams provides a mock function with given fields: _a0
 func (_m *Store) LoadConsensusParams(_a0 int64) (types.ConsensusParams, error) {
 	ret := _m.Called(_a0)
@@ -86,6 +184,29 @@ func (_m *Store) LoadConsensusParams(_a0 int64) (types.ConsensusParams, error) {
 	return r0, r1
 }
 
+// LoadConsensusParamsHistory provides a mock function with given fields: from, to
+func (_m *Store) LoadConsensusParamsHistory(from int64, to int64) ([]state.ConsensusParamsChange, error) {
+	ret := _m.Called(from, to)
+
+	var r0 []state.ConsensusParamsChange
+	if rf, ok := ret.Get(0).(func(int64, int64) []state.ConsensusParamsChange); ok {
+		r0 = rf(from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.ConsensusParamsChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadFinalizeBlockResponses provides a mock function with given fields: _a0
 func (_m *Store) LoadFinalizeBlockResponses(_a0 int64) (*abcitypes.ResponseFinalizeBlock, error) {
 	ret := _m.Called(_a0)
@@ -109,6 +230,29 @@ func (_m *Store) LoadFinalizeBlockResponses(_a0 int64) (*abcitypes.ResponseFinal
 	return r0, r1
 }
 
+// LoadValidatorPowers provides a mock function with given fields: address, from, to
+func (_m *Store) LoadValidatorPowers(address []byte, from int64, to int64) ([]state.ValidatorPower, error) {
+	ret := _m.Called(address, from, to)
+
+	var r0 []state.ValidatorPower
+	if rf, ok := ret.Get(0).(func([]byte, int64, int64) []state.ValidatorPower); ok {
+		r0 = rf(address, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.ValidatorPower)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, int64, int64) error); ok {
+		r1 = rf(address, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadValidators provides a mock function with given fields: _a0
 func (_m *Store) LoadValidators(_a0 int64) (*types.ValidatorSet, error) {
 	ret := _m.Called(_a0)
@@ -132,6 +276,41 @@ func (_m *Store) LoadValidators(_a0 int64) (*types.ValidatorSet, error) {
 	return r0, r1
 }
 
+// LoadValidatorsLastHeightChanged provides a mock function with given fields: _a0
+func (_m *Store) LoadValidatorsLastHeightChanged(_a0 int64) (int64, error) {
+	ret := _m.Called(_a0)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64) int64); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PruneFinalizeBlockResponses provides a mock function with given fields: _a0
+func (_m *Store) PruneFinalizeBlockResponses(_a0 int64) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PruneStates provides a mock function with given fields: _a0
 func (_m *Store) PruneStates(_a0 int64) error {
 	ret := _m.Called(_a0)
@@ -160,6 +339,62 @@ func (_m *Store) Save(_a0 state.State) error {
 	return r0
 }
 
+// SaveApplicationRetainHeight provides a mock function with given fields: _a0
+func (_m *Store) SaveApplicationRetainHeight(_a0 int64) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveCommitMarker provides a mock function with given fields: _a0
+func (_m *Store) SaveCommitMarker(_a0 int64) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveCompanionResultsRetainHeight provides a mock function with given fields: _a0
+func (_m *Store) SaveCompanionResultsRetainHeight(_a0 int64) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveCompanionRetainHeight provides a mock function with given fields: _a0
+func (_m *Store) SaveCompanionRetainHeight(_a0 int64) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveFinalizeBlockResponses provides a mock function with given fields: _a0, _a1
 func (_m *Store) SaveFinalizeBlockResponses(_a0 int64, _a1 *abcitypes.ResponseFinalizeBlock) error {
 	ret := _m.Called(_a0, _a1)
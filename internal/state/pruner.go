@@ -0,0 +1,218 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/store/compact"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+)
+
+// pruneBatchSize is the number of heights of blocks and states the Pruner
+// removes at a time, checking whether it is stopped between batches.
+const pruneBatchSize = 1000
+
+// Pruner is a service that prunes the blocks and states below the block
+// retain height, and the block results below the block results retain
+// height, in the background, so that removing many heights does not delay
+// the execution of blocks. Both retain heights are the retain height saved
+// by the BlockExecutor for the application, lowered to the respective retain
+// heights of the data companion with companion pruning.
+type Pruner struct {
+	service.BaseService
+	logger log.Logger
+
+	stateStore Store
+	blockStore BlockStore
+	metrics    *Metrics
+	interval   time.Duration
+
+	// prune only the blocks and block results below the retain heights of the
+	// data companion
+	companionPruning bool
+
+	// the block results retain height of the last run
+	resultsRetainHeight int64
+
+	// the databases compacted after pruning, at most every compactionInterval
+	compactDBs         []dbm.DB
+	compactionInterval time.Duration
+	lastCompaction     time.Time
+	pendingCompaction  bool
+}
+
+// PrunerOption sets an optional parameter on the Pruner.
+type PrunerOption func(*Pruner)
+
+// PrunerWithCompanionPruning makes the Pruner prune blocks and block results
+// only up to the lower of the retain heights of the application and of the
+// data companion, see Store.SaveCompanionRetainHeight and
+// Store.SaveCompanionResultsRetainHeight. Nothing is pruned until the data
+// companion sets a retain height.
+func PrunerWithCompanionPruning() PrunerOption {
+	return func(p *Pruner) { p.companionPruning = true }
+}
+
+// PrunerWithCompaction makes the Pruner compact the given databases after
+// pruning, at most every interval, to reclaim the disk space of the pruned
+// heights. The databases must support compaction, see compact.Supported.
+func PrunerWithCompaction(interval time.Duration, dbs ...dbm.DB) PrunerOption {
+	return func(p *Pruner) {
+		p.compactDBs = dbs
+		p.compactionInterval = interval
+	}
+}
+
+// NewPruner returns a Pruner that prunes the given stores every interval.
+func NewPruner(
+	logger log.Logger,
+	stateStore Store,
+	blockStore BlockStore,
+	interval time.Duration,
+	metrics *Metrics,
+	options ...PrunerOption,
+) *Pruner {
+	p := &Pruner{
+		logger:     logger,
+		stateStore: stateStore,
+		blockStore: blockStore,
+		metrics:    metrics,
+		interval:   interval,
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+	p.BaseService = *service.NewBaseService(logger, "Pruner", p)
+	return p
+}
+
+// OnStart starts pruning in the background. It implements service.Service.
+func (p *Pruner) OnStart(ctx context.Context) error {
+	go p.run(ctx)
+	return nil
+}
+
+// OnStop implements service.Service.
+func (p *Pruner) OnStop() {}
+
+func (p *Pruner) run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.prune(ctx)
+		}
+	}
+}
+
+// prune prunes the stores up to their current retain heights.
+func (p *Pruner) prune(ctx context.Context) {
+	appRetainHeight, err := p.stateStore.LoadApplicationRetainHeight()
+	if err != nil {
+		p.logger.Error("failed to load application retain height", "err", err)
+		return
+	}
+	if appRetainHeight <= 0 {
+		return
+	}
+
+	blockRetainHeight, resultsRetainHeight := appRetainHeight, appRetainHeight
+	if p.companionPruning {
+		blockRetainHeight = p.companionRetainHeight(appRetainHeight, p.stateStore.LoadCompanionRetainHeight)
+		resultsRetainHeight = p.companionRetainHeight(appRetainHeight, p.stateStore.LoadCompanionResultsRetainHeight)
+	}
+	p.metrics.BlockRetainHeight.Set(float64(blockRetainHeight))
+	p.metrics.BlockResultsRetainHeight.Set(float64(resultsRetainHeight))
+
+	start := time.Now()
+	if blockRetainHeight > 0 {
+		pruned, err := p.pruneBlocks(ctx, blockRetainHeight)
+		if err != nil {
+			p.logger.Error("failed to prune blocks", "retain_height", blockRetainHeight, "err", err)
+		} else if pruned > 0 {
+			p.logger.Debug("pruned blocks", "pruned", pruned, "retain_height", blockRetainHeight)
+		}
+		p.pendingCompaction = p.pendingCompaction || pruned > 0
+	}
+	if resultsRetainHeight > p.resultsRetainHeight {
+		if err := p.stateStore.PruneFinalizeBlockResponses(resultsRetainHeight); err != nil {
+			p.logger.Error("failed to prune block results", "retain_height", resultsRetainHeight, "err", err)
+		} else {
+			p.resultsRetainHeight = resultsRetainHeight
+			p.pendingCompaction = true
+		}
+	}
+	p.metrics.PruningDuration.Observe(time.Since(start).Seconds())
+
+	p.compact(ctx)
+}
+
+// compact compacts the databases, if anything was pruned since the last
+// compaction and compactionInterval has passed.
+func (p *Pruner) compact(ctx context.Context) {
+	if len(p.compactDBs) == 0 || !p.pendingCompaction || time.Since(p.lastCompaction) < p.compactionInterval {
+		return
+	}
+	start := time.Now()
+	for _, db := range p.compactDBs {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := compact.Range(db, nil, nil); err != nil {
+			p.logger.Error("failed to compact database", "err", err)
+			return
+		}
+	}
+	p.lastCompaction = time.Now()
+	p.pendingCompaction = false
+	p.metrics.CompactionDuration.Observe(time.Since(start).Seconds())
+	p.logger.Info("compacted databases", "elapsed", time.Since(start))
+}
+
+// companionRetainHeight returns the height to retain given the retain height of
+// the application: the lower of it and the retain height of the data
+// companion loaded by load, or 0 if the companion has not set one.
+func (p *Pruner) companionRetainHeight(appRetainHeight int64, load func() (int64, error)) int64 {
+	companionRetainHeight, err := load()
+	if err != nil {
+		p.logger.Error("failed to load companion retain height", "err", err)
+		return 0
+	}
+	if companionRetainHeight < appRetainHeight {
+		return companionRetainHeight
+	}
+	return appRetainHeight
+}
+
+// pruneBlocks prunes the blocks and states below retainHeight, pruneBatchSize
+// heights at a time, and returns the number of blocks pruned.
+func (p *Pruner) pruneBlocks(ctx context.Context, retainHeight int64) (uint64, error) {
+	var total uint64
+	for base := p.blockStore.Base(); base < retainHeight; {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		next := base + pruneBatchSize
+		if next > retainHeight {
+			next = retainHeight
+		}
+		pruned, err := p.blockStore.PruneBlocks(next)
+		if err != nil {
+			return total, fmt.Errorf("failed to prune block store: %w", err)
+		}
+		total += pruned
+		p.metrics.PrunedBlocks.Add(float64(pruned))
+		if err := p.stateStore.PruneStates(next); err != nil {
+			return total, fmt.Errorf("failed to prune state store: %w", err)
+		}
+		base = next
+	}
+	return total, nil
+}
@@ -0,0 +1,144 @@
+package state_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestPruner(t *testing.T) {
+	testCases := map[string]struct {
+		companionPruning bool
+		appRetainHeight  int64
+		companionHeight  int64
+		companionResults int64
+		expectBlocks     []int64 // the heights blocks are pruned up to, batch by batch
+		expectResults    int64   // 0 if block results are not pruned
+	}{
+		"no retain height":            {false, 0, 0, 0, nil, 0},
+		"application retain height":   {false, 10, 0, 0, []int64{10}, 10},
+		"batches":                     {false, 2500, 0, 0, []int64{1001, 2001, 2500}, 2500},
+		"companion unset":             {true, 10, 0, 0, nil, 0},
+		"companion below application": {true, 10, 4, 6, []int64{4}, 6},
+		"companion above application": {true, 10, 20, 20, []int64{10}, 10},
+		"companion results only":      {true, 10, 0, 6, nil, 6},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			stateStore := &mocks.Store{}
+			stateStore.On("LoadApplicationRetainHeight").Return(tc.appRetainHeight, nil)
+			stateStore.On("LoadCompanionRetainHeight").Return(tc.companionHeight, nil).Maybe()
+			stateStore.On("LoadCompanionResultsRetainHeight").Return(tc.companionResults, nil).Maybe()
+			blockStore := &mocks.BlockStore{}
+			blockStore.On("Base").Return(int64(1)).Maybe()
+			for _, height := range tc.expectBlocks {
+				blockStore.On("PruneBlocks", height).Return(uint64(1), nil).Once()
+				stateStore.On("PruneStates", height).Return(nil).Once()
+			}
+			if tc.expectResults > 0 {
+				stateStore.On("PruneFinalizeBlockResponses", tc.expectResults).Return(nil).Once()
+			}
+
+			var options []sm.PrunerOption
+			if tc.companionPruning {
+				options = append(options, sm.PrunerWithCompanionPruning())
+			}
+			pruner := sm.NewPruner(log.NewNopLogger(), stateStore, blockStore, time.Hour, sm.NopMetrics(), options...)
+			pruner.Prune(ctx)
+
+			stateStore.AssertExpectations(t)
+			blockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPrunerSkipsPrunedBlockResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadApplicationRetainHeight").Return(int64(5), nil)
+	stateStore.On("PruneStates", int64(5)).Return(nil)
+	stateStore.On("PruneFinalizeBlockResponses", int64(5)).Return(nil).Once()
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1)).Once()
+	blockStore.On("PruneBlocks", int64(5)).Return(uint64(4), nil)
+	blockStore.On("Base").Return(int64(5))
+
+	pruner := sm.NewPruner(log.NewNopLogger(), stateStore, blockStore, time.Hour, sm.NopMetrics())
+	pruner.Prune(ctx)
+	pruner.Prune(ctx)
+
+	stateStore.AssertExpectations(t)
+	blockStore.AssertExpectations(t)
+	stateStore.AssertNumberOfCalls(t, "PruneFinalizeBlockResponses", 1)
+	blockStore.AssertNumberOfCalls(t, "PruneBlocks", 1)
+}
+
+func TestPrunerRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pruned := make(chan struct{})
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadApplicationRetainHeight").Return(int64(3), nil)
+	stateStore.On("PruneStates", int64(3)).Return(nil)
+	stateStore.On("PruneFinalizeBlockResponses", int64(3)).Return(nil).Run(func(_ mock.Arguments) { close(pruned) })
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(int64(1)).Once()
+	blockStore.On("Base").Return(int64(3))
+	blockStore.On("PruneBlocks", int64(3)).Return(uint64(2), nil)
+
+	pruner := sm.NewPruner(log.NewNopLogger(), stateStore, blockStore, 10*time.Millisecond, sm.NopMetrics())
+	require.NoError(t, pruner.Start(ctx))
+
+	select {
+	case <-pruned:
+	case <-time.After(5 * time.Second):
+		t.Fatal("pruner did not run")
+	}
+	cancel()
+	pruner.Wait()
+}
+
+func TestPrunerCompaction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := dbm.NewGoLevelDB("state", t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	retainHeight := int64(3)
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadApplicationRetainHeight").Return(func() int64 { return retainHeight }, nil)
+	stateStore.On("PruneStates", mock.Anything).Return(nil)
+	stateStore.On("PruneFinalizeBlockResponses", mock.Anything).Return(nil)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Base").Return(func() int64 { return retainHeight - 2 })
+	blockStore.On("PruneBlocks", mock.Anything).Return(uint64(2), nil)
+
+	pruner := sm.NewPruner(log.NewNopLogger(), stateStore, blockStore, time.Hour, sm.NopMetrics(),
+		sm.PrunerWithCompaction(time.Hour, db))
+	require.True(t, pruner.LastCompaction().IsZero())
+
+	pruner.Prune(ctx)
+	compacted := pruner.LastCompaction()
+	require.False(t, compacted.IsZero(), "compacts after pruning")
+
+	retainHeight = 5
+	pruner.Prune(ctx)
+	require.Equal(t, compacted, pruner.LastCompaction(), "compacts at most every compaction interval")
+}
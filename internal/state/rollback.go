@@ -1,16 +1,20 @@
 package state
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
 	"github.com/tendermint/tendermint/version"
 )
 
-// Rollback overwrites the current Tendermint state (height n) with the most
-// recent previous state (height n - 1).
+// Rollback overwrites the current Tendermint state (height n) with the state
+// at the given height, or with the most recent previous state (height n - 1)
+// if height is 0. If removeBlocks is set, the blocks above that height are
+// deleted from the blockstore as well, so that they are not replayed but
+// decided again by consensus.
 // Note that this function does not affect application state.
-func Rollback(bs BlockStore, ss Store) (int64, []byte, error) {
+func Rollback(bs BlockStore, ss Store, height int64, removeBlocks bool) (int64, []byte, error) {
 	invalidState, err := ss.Load()
 	if err != nil {
 		return -1, nil, err
@@ -19,58 +23,101 @@ func Rollback(bs BlockStore, ss Store) (int64, []byte, error) {
 		return -1, nil, errors.New("no state found")
 	}
 
-	height := bs.Height()
+	storeHeight := bs.Height()
 
 	// NOTE: persistence of state and blocks don't happen atomically. Therefore it is possible that
 	// when the user stopped the node the state wasn't updated but the blockstore was. In this situation
-	// we don't need to rollback any state and can just return early
-	if height == invalidState.LastBlockHeight+1 {
-		return invalidState.LastBlockHeight, invalidState.AppHash, nil
+	// the state is already one height below the blockstore, which is where a rollback of
+	// one height brings it.
+	if storeHeight != invalidState.LastBlockHeight && storeHeight != invalidState.LastBlockHeight+1 {
+		// If the state store isn't one below nor equal to the blockstore height than this violates the
+		// invariant
+		return -1, nil, fmt.Errorf("statestore height (%d) is not one below or equal to blockstore height (%d)",
+			invalidState.LastBlockHeight, storeHeight)
+	}
+	if height == 0 {
+		height = storeHeight - 1
+	}
+	if height > invalidState.LastBlockHeight || height >= storeHeight {
+		return -1, nil, fmt.Errorf("cannot roll back to height %d, above the statestore height (%d) or "+
+			"not below the blockstore height (%d)", height, invalidState.LastBlockHeight, storeHeight)
+	}
+	if height < invalidState.InitialHeight {
+		return -1, nil, fmt.Errorf("cannot roll back to height %d, below the initial height %d",
+			height, invalidState.InitialHeight)
 	}
 
-	// If the state store isn't one below nor equal to the blockstore height than this violates the
-	// invariant
-	if height != invalidState.LastBlockHeight {
-		return -1, nil, fmt.Errorf("statestore height (%d) is not one below or equal to blockstore height (%d)",
-			invalidState.LastBlockHeight, height)
+	// roll the state back one height at a time
+	rolledBackState := invalidState
+	for rolledBackState.LastBlockHeight > height {
+		rolledBackState, err = rollbackState(bs, ss, rolledBackState)
+		if err != nil {
+			return -1, nil, err
+		}
+	}
+	if err := checkRollbackAppHash(ss, rolledBackState); err != nil {
+		return -1, nil, err
 	}
 
-	// state store height is equal to blockstore height. We're good to proceed with rolling back state
+	// persist the new state. This overrides the invalid one. NOTE: this will also
+	// persist the validator set and consensus params over the existing structures,
+	// but both should be the same
+	if rolledBackState.LastBlockHeight != invalidState.LastBlockHeight {
+		if err := ss.Save(rolledBackState); err != nil {
+			return -1, nil, fmt.Errorf("failed to save rolled back state: %w", err)
+		}
+	}
+
+	// delete the blocks only once the state no longer refers to them
+	if removeBlocks {
+		for bs.Height() > height {
+			if err := bs.DeleteLatestBlock(); err != nil {
+				return -1, nil, fmt.Errorf("failed to delete block at height %d: %w", bs.Height(), err)
+			}
+		}
+	}
+
+	return rolledBackState.LastBlockHeight, rolledBackState.AppHash, nil
+}
+
+// rollbackState builds the state at the height below the one of the given
+// state.
+func rollbackState(bs BlockStore, ss Store, invalidState State) (State, error) {
 	rollbackHeight := invalidState.LastBlockHeight - 1
 	rollbackBlock := bs.LoadBlockMeta(rollbackHeight)
 	if rollbackBlock == nil {
-		return -1, nil, fmt.Errorf("block at height %d not found", rollbackHeight)
+		return State{}, fmt.Errorf("block at height %d not found", rollbackHeight)
 	}
 	// we also need to retrieve the latest block because the app hash and last results hash is only agreed upon in the following block
 	latestBlock := bs.LoadBlockMeta(invalidState.LastBlockHeight)
 	if latestBlock == nil {
-		return -1, nil, fmt.Errorf("block at height %d not found", invalidState.LastBlockHeight)
+		return State{}, fmt.Errorf("block at height %d not found", invalidState.LastBlockHeight)
 	}
 
 	previousLastValidatorSet, err := ss.LoadValidators(rollbackHeight)
 	if err != nil {
-		return -1, nil, err
+		return State{}, err
 	}
 
 	previousParams, err := ss.LoadConsensusParams(rollbackHeight + 1)
 	if err != nil {
-		return -1, nil, err
+		return State{}, err
 	}
 
-	valChangeHeight := invalidState.LastHeightValidatorsChanged
-	// this can only happen if the validator set changed since the last block
-	if valChangeHeight > rollbackHeight {
-		valChangeHeight = rollbackHeight + 1
+	// the heights at which the next validators and the params of the rolled
+	// back state last changed are recorded with them when it was saved
+	valChangeHeight, err := ss.LoadValidatorsLastHeightChanged(rollbackHeight + 2)
+	if err != nil {
+		return State{}, err
 	}
-
-	paramsChangeHeight := invalidState.LastHeightConsensusParamsChanged
-	// this can only happen if params changed from the last block
-	if paramsChangeHeight > rollbackHeight {
-		paramsChangeHeight = rollbackHeight + 1
+	paramsChanges, err := ss.LoadConsensusParamsHistory(rollbackHeight+1, rollbackHeight+1)
+	if err != nil {
+		return State{}, err
 	}
+	paramsChangeHeight := paramsChanges[0].Height
 
 	// build the new state from the old state and the prior block
-	rolledBackState := State{
+	return State{
 		Version: Version{
 			Consensus: version.Consensus{
 				Block: version.BlockProtocol,
@@ -96,14 +143,23 @@ func Rollback(bs BlockStore, ss Store) (int64, []byte, error) {
 
 		LastResultsHash: latestBlock.Header.LastResultsHash,
 		AppHash:         latestBlock.Header.AppHash,
-	}
+	}, nil
+}
 
-	// persist the new state. This overrides the invalid one. NOTE: this will also
-	// persist the validator set and consensus params over the existing structures,
-	// but both should be the same
-	if err := ss.Save(rolledBackState); err != nil {
-		return -1, nil, fmt.Errorf("failed to save rolled back state: %w", err)
+// checkRollbackAppHash checks that the app hash of the rolled back state, as
+// agreed upon in the following block, is the one the application returned
+// for the last block of the state, if its responses to FinalizeBlock are
+// still stored.
+func checkRollbackAppHash(ss Store, state State) error {
+	res, err := ss.LoadFinalizeBlockResponses(state.LastBlockHeight)
+	if errors.Is(err, ErrNoFinalizeBlockResponsesForHeight{state.LastBlockHeight}) {
+		return nil
+	} else if err != nil {
+		return err
 	}
-
-	return rolledBackState.LastBlockHeight, rolledBackState.AppHash, nil
+	if !bytes.Equal(res.AppHash, state.AppHash) {
+		return fmt.Errorf("app hash %X of the block at height %d differs from the one returned by the application (%X)",
+			state.AppHash, state.LastBlockHeight, res.AppHash)
+	}
+	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	dbm "github.com/tendermint/tm-db"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/mocks"
 	"github.com/tendermint/tendermint/internal/test/factory"
@@ -67,7 +68,7 @@ func TestRollback(t *testing.T) {
 	blockStore.On("Height").Return(nextHeight)
 
 	// rollback the state
-	rollbackHeight, rollbackHash, err := state.Rollback(blockStore, stateStore)
+	rollbackHeight, rollbackHash, err := state.Rollback(blockStore, stateStore, 0, false)
 	require.NoError(t, err)
 	require.EqualValues(t, height, rollbackHeight)
 	require.EqualValues(t, initialState.AppHash, rollbackHash)
@@ -79,11 +80,161 @@ func TestRollback(t *testing.T) {
 	require.EqualValues(t, initialState, loadedState)
 }
 
+func TestRollbackToHeight(t *testing.T) {
+	const height int64 = 100
+
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+
+	// save the states of the next two heights, and the metas of their blocks
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		BlockID: initialState.LastBlockID,
+		Header:  types.Header{Height: height},
+	})
+	prevState := initialState
+	for nextHeight := height + 1; nextHeight <= height+2; nextHeight++ {
+		nextState := prevState.Copy()
+		nextState.LastBlockHeight = nextHeight
+		nextState.LastBlockID = factory.MakeBlockID()
+		nextState.AppHash = factory.RandomHash()
+		nextState.LastResultsHash = factory.RandomHash()
+		nextState.LastValidators = prevState.Validators
+		nextState.Validators = prevState.NextValidators
+		nextState.NextValidators = prevState.NextValidators.CopyIncrementProposerPriority(1)
+		require.NoError(t, stateStore.Save(nextState))
+
+		blockStore.On("LoadBlockMeta", nextHeight).Return(&types.BlockMeta{
+			BlockID: nextState.LastBlockID,
+			Header: types.Header{
+				Height:          nextHeight,
+				AppHash:         prevState.AppHash,
+				LastResultsHash: prevState.LastResultsHash,
+			},
+		})
+		prevState = nextState
+	}
+
+	// roll back two heights, deleting their blocks
+	blockStore.On("Height").Return(height + 2).Twice()
+	blockStore.On("Height").Return(height + 1).Once()
+	blockStore.On("Height").Return(height)
+	blockStore.On("DeleteLatestBlock").Return(nil).Twice()
+
+	rollbackHeight, rollbackHash, err := state.Rollback(blockStore, stateStore, height, true)
+	require.NoError(t, err)
+	require.EqualValues(t, height, rollbackHeight)
+	require.EqualValues(t, initialState.AppHash, rollbackHash)
+	blockStore.AssertExpectations(t)
+
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.EqualValues(t, initialState, loadedState)
+}
+
+func TestRollbackValidatorSetChange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	const height int64 = 100
+
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+	newValSet, _ := factory.ValidatorSet(ctx, t, 3, 10)
+
+	// the validator set changes in the block at height+1, from height+3 on
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		BlockID: initialState.LastBlockID,
+		Header:  types.Header{Height: height},
+	})
+	states := []state.State{initialState}
+	for nextHeight := height + 1; nextHeight <= height+2; nextHeight++ {
+		prevState := states[len(states)-1]
+		nextState := prevState.Copy()
+		nextState.LastBlockHeight = nextHeight
+		nextState.LastBlockID = factory.MakeBlockID()
+		nextState.AppHash = factory.RandomHash()
+		nextState.LastResultsHash = factory.RandomHash()
+		nextState.LastValidators = prevState.Validators
+		nextState.Validators = prevState.NextValidators
+		if nextHeight == height+1 {
+			nextState.NextValidators = newValSet
+			nextState.LastHeightValidatorsChanged = nextHeight + 2
+		} else {
+			nextState.NextValidators = prevState.NextValidators.CopyIncrementProposerPriority(1)
+		}
+		require.NoError(t, stateStore.Save(nextState))
+		states = append(states, nextState)
+
+		blockStore.On("LoadBlockMeta", nextHeight).Return(&types.BlockMeta{
+			BlockID: nextState.LastBlockID,
+			Header: types.Header{
+				Height:          nextHeight,
+				AppHash:         prevState.AppHash,
+				LastResultsHash: prevState.LastResultsHash,
+			},
+		})
+	}
+	blockStore.On("Height").Return(height + 2)
+
+	// the rolled back states are the ones saved, on both sides of the change
+	for _, expected := range []state.State{states[1], states[0]} {
+		rollbackHeight, _, err := state.Rollback(blockStore, stateStore, expected.LastBlockHeight, false)
+		require.NoError(t, err)
+		require.Equal(t, expected.LastBlockHeight, rollbackHeight)
+
+		loadedState, err := stateStore.Load()
+		require.NoError(t, err)
+		require.EqualValues(t, expected, loadedState)
+	}
+}
+
+func TestRollbackAppHashMismatch(t *testing.T) {
+	const height int64 = 100
+
+	stateStore := setupStateStore(t, height)
+	initialState, err := stateStore.Load()
+	require.NoError(t, err)
+	nextState := initialState.Copy()
+	nextState.LastBlockHeight = height + 1
+	nextState.LastValidators = initialState.Validators
+	nextState.Validators = initialState.NextValidators
+	nextState.NextValidators = initialState.NextValidators.CopyIncrementProposerPriority(1)
+	require.NoError(t, stateStore.Save(nextState))
+
+	// the application returned another app hash for the block than the one
+	// agreed upon in the next block
+	require.NoError(t, stateStore.SaveFinalizeBlockResponses(height, &abci.ResponseFinalizeBlock{
+		AppHash: factory.RandomHash(),
+	}))
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("Height").Return(height + 1)
+	blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{
+		BlockID: initialState.LastBlockID,
+		Header:  types.Header{Height: height},
+	})
+	blockStore.On("LoadBlockMeta", height+1).Return(&types.BlockMeta{
+		Header: types.Header{Height: height + 1, AppHash: initialState.AppHash},
+	})
+
+	_, _, err = state.Rollback(blockStore, stateStore, 0, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "differs from the one returned by the application")
+
+	// the state is left as is
+	loadedState, err := stateStore.Load()
+	require.NoError(t, err)
+	require.EqualValues(t, height+1, loadedState.LastBlockHeight)
+}
+
 func TestRollbackNoState(t *testing.T) {
 	stateStore := state.NewStore(dbm.NewMemDB())
 	blockStore := &mocks.BlockStore{}
 
-	_, _, err := state.Rollback(blockStore, stateStore)
+	_, _, err := state.Rollback(blockStore, stateStore, 0, false)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "no state found")
 }
@@ -97,7 +248,7 @@ func TestRollbackNoBlocks(t *testing.T) {
 	blockStore.On("LoadBlockMeta", height).Return(nil)
 	blockStore.On("LoadBlockMeta", height-1).Return(nil)
 
-	_, _, err := state.Rollback(blockStore, stateStore)
+	_, _, err := state.Rollback(blockStore, stateStore, 0, false)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "block at height 99 not found")
 }
@@ -108,7 +259,7 @@ func TestRollbackDifferentStateHeight(t *testing.T) {
 	blockStore := &mocks.BlockStore{}
 	blockStore.On("Height").Return(height + 2)
 
-	_, _, err := state.Rollback(blockStore, stateStore)
+	_, _, err := state.Rollback(blockStore, stateStore, 0, false)
 	require.Error(t, err)
 	require.Equal(t, err.Error(), "statestore height (100) is not one below or equal to blockstore height (102)")
 }
@@ -139,7 +290,7 @@ func setupStateStore(t *testing.T, height int64) state.Store {
 		LastValidators:                   valSet,
 		Validators:                       valSet.CopyIncrementProposerPriority(1),
 		NextValidators:                   valSet.CopyIncrementProposerPriority(2),
-		LastHeightValidatorsChanged:      height + 1,
+		LastHeightValidatorsChanged:      height + 2, // as recorded by Bootstrap
 		ConsensusParams:                  *params,
 		LastHeightConsensusParamsChanged: height + 1,
 	}
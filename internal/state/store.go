@@ -2,6 +2,7 @@ package state
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
@@ -29,7 +30,7 @@ const (
 // key prefixes
 // NB: Before modifying these, cross-check them with those in
 // * internal/store/store.go    [0..4, 13]
-// * internal/state/store.go    [5..8, 14]
+// * internal/state/store.go    [5..8, 14..20]
 // * internal/evidence/pool.go  [9..10]
 // * light/store/db/db.go       [11..12]
 // TODO(thane): Move all these to their own package.
@@ -43,6 +44,12 @@ const (
 	prefixABCIResponses          = int64(7) // deprecated in v0.36
 	prefixState                  = int64(8)
 	prefixFinalizeBlockResponses = int64(14)
+	prefixCompanionRetainHeight  = int64(15)
+	prefixCompanionResultsRetain = int64(16)
+	prefixAppRetainHeight        = int64(17)
+	prefixCommitMarker           = int64(18)
+	prefixValidatorPower         = int64(19)
+	prefixValidatorPowerBase     = int64(20)
 )
 
 func encodeKey(prefix int64, height int64) []byte {
@@ -69,15 +76,34 @@ func finalizeBlockResponsesKey(height int64) []byte {
 	return encodeKey(prefixFinalizeBlockResponses, height)
 }
 
-// stateKey should never change after being set in init()
-var stateKey []byte
-
-func init() {
-	var err error
-	stateKey, err = orderedcode.Append(nil, prefixState)
+func validatorPowerKey(address []byte, height int64) []byte {
+	res, err := orderedcode.Append(nil, prefixValidatorPower, string(address), height)
 	if err != nil {
 		panic(err)
 	}
+	return res
+}
+
+// stateKey and the retain height keys should never change after being set
+// in init()
+var stateKey, companionRetainHeightKey, companionResultsRetainHeightKey, appRetainHeightKey, commitMarkerKey,
+	validatorPowerBaseKey []byte
+
+func init() {
+	for key, prefix := range map[*[]byte]int64{
+		&stateKey:                        prefixState,
+		&companionRetainHeightKey:        prefixCompanionRetainHeight,
+		&companionResultsRetainHeightKey: prefixCompanionResultsRetain,
+		&appRetainHeightKey:              prefixAppRetainHeight,
+		&commitMarkerKey:                 prefixCommitMarker,
+		&validatorPowerBaseKey:           prefixValidatorPowerBase,
+	} {
+		var err error
+		*key, err = orderedcode.Append(nil, prefix)
+		if err != nil {
+			panic(err)
+		}
+	}
 }
 
 //----------------------
@@ -93,10 +119,19 @@ type Store interface {
 	Load() (State, error)
 	// LoadValidators loads the validator set at a given height
 	LoadValidators(int64) (*types.ValidatorSet, error)
+	// LoadValidatorsLastHeightChanged loads the height at which the validator
+	// set of a given height last changed
+	LoadValidatorsLastHeightChanged(int64) (int64, error)
+	// LoadValidatorPowers loads the voting power of a validator at a height
+	// and its changes up to another height
+	LoadValidatorPowers(address []byte, from, to int64) ([]ValidatorPower, error)
 	// LoadFinalizeBlockResponses loads the responses to FinalizeBlock for a given height
 	LoadFinalizeBlockResponses(int64) (*abci.ResponseFinalizeBlock, error)
 	// LoadConsensusParams loads the consensus params for a given height
 	LoadConsensusParams(int64) (types.ConsensusParams, error)
+	// LoadConsensusParamsHistory loads the consensus params at a height and
+	// their changes up to another height
+	LoadConsensusParamsHistory(from, to int64) ([]ConsensusParamsChange, error)
 	// Save overwrites the previous state with the updated one
 	Save(State) error
 	// SaveFinalizeBlockResponses saves responses to FinalizeBlock for a given height
@@ -107,6 +142,33 @@ type Store interface {
 	Bootstrap(State) error
 	// PruneStates takes the height from which to prune up to (exclusive)
 	PruneStates(int64) error
+	// PruneFinalizeBlockResponses takes the height from which to prune
+	// responses to FinalizeBlock up to (exclusive)
+	PruneFinalizeBlockResponses(int64) error
+	// LoadCompanionRetainHeight loads the retain height set by a data companion,
+	// or 0 if none is set
+	LoadCompanionRetainHeight() (int64, error)
+	// SaveCompanionRetainHeight saves the retain height set by a data companion
+	SaveCompanionRetainHeight(int64) error
+	// LoadCompanionResultsRetainHeight loads the retain height of block results
+	// set by a data companion, or 0 if none is set
+	LoadCompanionResultsRetainHeight() (int64, error)
+	// SaveCompanionResultsRetainHeight saves the retain height of block results
+	// set by a data companion
+	SaveCompanionResultsRetainHeight(int64) error
+	// LoadApplicationRetainHeight loads the last retain height returned by the
+	// application, or 0 if none is set
+	LoadApplicationRetainHeight() (int64, error)
+	// SaveApplicationRetainHeight saves the retain height returned by the application
+	SaveApplicationRetainHeight(int64) error
+	// LoadCommitMarker loads the height of the block being committed, or 0 if
+	// no commit is in progress
+	LoadCommitMarker() (int64, error)
+	// SaveCommitMarker records that the block at the given height is being
+	// committed, until the state of that height is saved
+	SaveCommitMarker(int64) error
+	// ClearCommitMarker removes the commit marker
+	ClearCommitMarker() error
 	// Close closes the connection with the database
 	Close() error
 }
@@ -153,6 +215,7 @@ func (store dbStore) loadState(key []byte) (state State, err error) {
 }
 
 // Save persists the State, the ValidatorsInfo, and the ConsensusParamsInfo to the database.
+// It clears the commit marker in the same batch if the state reaches its height.
 // This flushes the writes (e.g. calls SetSync).
 func (store dbStore) Save(state State) error {
 	return store.save(state, stateKey)
@@ -177,6 +240,9 @@ func (store dbStore) save(state State, key []byte) error {
 	if err != nil {
 		return err
 	}
+	if err := store.saveValidatorPowers(state, nextHeight, batch); err != nil {
+		return err
+	}
 
 	// Save next consensus params.
 	if err := store.saveConsensusParamsInfo(nextHeight,
@@ -193,6 +259,18 @@ func (store dbStore) save(state State, key []byte) error {
 		return err
 	}
 
+	// The state completes the commit of its last block, so the marker must go
+	// with it: a marker left behind would have the block committed twice.
+	marker, err := store.LoadCommitMarker()
+	if err != nil {
+		return err
+	}
+	if marker != 0 && marker <= state.LastBlockHeight {
+		if err := batch.Delete(commitMarkerKey); err != nil {
+			return err
+		}
+	}
+
 	return batch.WriteSync()
 }
 
@@ -220,6 +298,24 @@ func (store dbStore) Bootstrap(state State) error {
 		return err
 	}
 
+	base, err := store.loadHeight(validatorPowerBaseKey)
+	if err != nil {
+		return err
+	}
+	if base == 0 {
+		if err := setValidatorPowers(batch, height, nil, state.Validators); err != nil {
+			return err
+		}
+		if err := setHeight(batch, validatorPowerBaseKey, height); err != nil {
+			return err
+		}
+		if err := setValidatorPowers(batch, height+1, state.Validators, state.NextValidators); err != nil {
+			return err
+		}
+	} else if err := store.reindexValidatorPowers(state, height, base, batch); err != nil {
+		return err
+	}
+
 	if err := store.saveConsensusParamsInfo(height,
 		state.LastHeightConsensusParamsChanged, state.ConsensusParams, batch); err != nil {
 		return err
@@ -237,7 +333,8 @@ func (store dbStore) Bootstrap(state State) error {
 	return batch.WriteSync()
 }
 
-// PruneStates deletes states up to the height specified (exclusive). It is not
+// PruneStates deletes states up to the height specified (exclusive). It does
+// not delete responses to FinalizeBlock, see PruneFinalizeBlockResponses. It is not
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at retain height must also exist.
 // Pruning is done in descending order.
@@ -255,15 +352,16 @@ func (store dbStore) PruneStates(retainHeight int64) error {
 		return err
 	}
 
-	if err := store.pruneValidatorSets(retainHeight); err != nil {
-		return err
-	}
+	return store.pruneValidatorSets(retainHeight)
+}
 
-	if err := store.pruneFinalizeBlockResponses(retainHeight); err != nil {
-		return err
+// PruneFinalizeBlockResponses deletes the responses to FinalizeBlock, and
+// legacy ABCI responses, up to the height specified (exclusive).
+func (store dbStore) PruneFinalizeBlockResponses(retainHeight int64) error {
+	if retainHeight <= 0 {
+		return fmt.Errorf("height %v must be greater than 0", retainHeight)
 	}
-
-	return nil
+	return store.pruneFinalizeBlockResponses(retainHeight)
 }
 
 // pruneValidatorSets calls a reverse iterator from base height to retain height (exclusive), deleting
@@ -551,6 +649,17 @@ func (store dbStore) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return vip, nil
 }
 
+// LoadValidatorsLastHeightChanged loads the height at which the validator set
+// of the given height last changed, as recorded with the set.
+// Returns ErrNoValSetForHeight if the validator set can't be found for this height.
+func (store dbStore) LoadValidatorsLastHeightChanged(height int64) (int64, error) {
+	valInfo, err := loadValidatorsInfo(store.db, height)
+	if err != nil {
+		return 0, ErrNoValSetForHeight{Height: height, Err: err}
+	}
+	return valInfo.LastHeightChanged, nil
+}
+
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
 	checkpointHeight := height - height%valSetCheckpointInterval
 	return tmmath.MaxInt64(checkpointHeight, lastHeightChanged)
@@ -614,6 +723,228 @@ func (store dbStore) saveValidatorsInfo(
 
 //-----------------------------------------------------------------------------
 
+// ValidatorPower is the voting power of a validator from a height on. A power
+// of zero means that the address is not a validator at that height.
+type ValidatorPower struct {
+	Height int64
+	Power  int64
+}
+
+// LoadValidatorPowers returns the voting power of the validator with the given
+// address at the height from, followed by each change of its power up to the
+// height to (inclusive), in order of height.
+//
+// The powers are loaded from an index keyed by address, which only records
+// their changes, so the cost does not depend on the size of the validator sets
+// nor on the number of heights. The index starts from the first validator set
+// saved by a node which maintains it; the heights before are reported with
+// ErrNoValSetForHeight, as are those past the next validator set.
+func (store dbStore) LoadValidatorPowers(address []byte, from, to int64) ([]ValidatorPower, error) {
+	if from > to {
+		return nil, fmt.Errorf("from height %d is greater than to height %d", from, to)
+	}
+	base, err := store.loadHeight(validatorPowerBaseKey)
+	if err != nil {
+		return nil, err
+	}
+	if base == 0 || from < base {
+		return nil, ErrNoValSetForHeight{
+			Height: from,
+			Err:    fmt.Errorf("validator powers are only indexed from height %d", base),
+		}
+	}
+	state, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	lastHeight := state.LastBlockHeight
+	if lastHeight == 0 {
+		lastHeight = state.InitialHeight - 1
+	}
+	if to > lastHeight+2 {
+		return nil, ErrNoValSetForHeight{Height: to}
+	}
+
+	powers := []ValidatorPower{{Height: from}}
+	iter, err := store.db.ReverseIterator(validatorPowerKey(address, base), validatorPowerKey(address, from+1))
+	if err != nil {
+		return nil, err
+	}
+	if iter.Valid() {
+		power, err := decodeValidatorPower(iter.Value())
+		if err != nil {
+			iter.Close()
+			return nil, err
+		}
+		powers[0].Power = power
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+
+	iter, err = store.db.Iterator(validatorPowerKey(address, from+1), validatorPowerKey(address, to+1))
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var (
+			prefix int64
+			addr   string
+			height int64
+		)
+		if _, err := orderedcode.Parse(string(iter.Key()), &prefix, &addr, &height); err != nil {
+			return nil, err
+		}
+		power, err := decodeValidatorPower(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		powers = append(powers, ValidatorPower{Height: height, Power: power})
+	}
+	return powers, iter.Error()
+}
+
+// saveValidatorPowers indexes the changes of the voting powers of the next
+// validators of the state at the height nextHeight+1. The index starts with
+// the validators of the first block, or with the next validators of a state
+// saved before the index existed.
+func (store dbStore) saveValidatorPowers(state State, nextHeight int64, batch dbm.Batch) error {
+	base, err := store.loadHeight(validatorPowerBaseKey)
+	if err != nil {
+		return err
+	}
+	switch {
+	case state.LastBlockHeight == 0:
+		// The validators of the first block are overwritten by those of
+		// InitChain, so the changes are taken from the ones saved last.
+		prev, err := store.LoadValidators(nextHeight)
+		if errNoVals := (ErrNoValSetForHeight{}); errors.As(err, &errNoVals) {
+			prev = nil
+		} else if err != nil {
+			return err
+		}
+		if err := setValidatorPowers(batch, nextHeight, prev, state.Validators); err != nil {
+			return err
+		}
+		if err := setHeight(batch, validatorPowerBaseKey, nextHeight); err != nil {
+			return err
+		}
+	case base == 0:
+		if err := setValidatorPowers(batch, nextHeight+1, nil, state.NextValidators); err != nil {
+			return err
+		}
+		return setHeight(batch, validatorPowerBaseKey, nextHeight+1)
+	}
+	return setValidatorPowers(batch, nextHeight+1, state.Validators, state.NextValidators)
+}
+
+// reindexValidatorPowers indexes the voting powers of the validators of a
+// state bootstrapped at the given height over an existing index. The validator
+// sets saved may replace those indexed before, as when a chain is forked into
+// a testnet, so the entries of the replaced sets are removed, and the changes
+// are taken from the validators saved at the height before.
+func (store dbStore) reindexValidatorPowers(state State, height, base int64, batch dbm.Batch) error {
+	from := height
+	sets := []*types.ValidatorSet{state.Validators, state.NextValidators}
+	if height > 1 && !state.LastValidators.IsNilOrEmpty() {
+		from = height - 1
+		sets = append([]*types.ValidatorSet{state.LastValidators}, sets...)
+	}
+
+	prev, err := store.loadIndexedValidators(from - 1)
+	if err != nil {
+		return err
+	}
+	oldPrev := prev
+	for i, vals := range sets {
+		h := from + int64(i)
+		old, err := store.loadIndexedValidators(h)
+		if err != nil {
+			return err
+		}
+		if h >= base {
+			// The changes indexed at h are those of the validators of the
+			// replaced sets at h or at the height before.
+			for _, oldVals := range []*types.ValidatorSet{oldPrev, old} {
+				if oldVals == nil {
+					continue
+				}
+				for _, val := range oldVals.Validators {
+					if err := batch.Delete(validatorPowerKey(val.Address, h)); err != nil {
+						return err
+					}
+				}
+			}
+			// The index starts with the powers of all the validators at base.
+			if h == base {
+				prev = nil
+			}
+			if err := setValidatorPowers(batch, h, prev, vals); err != nil {
+				return err
+			}
+		}
+		prev, oldPrev = vals, old
+	}
+	return nil
+}
+
+// loadIndexedValidators loads the validators saved at the given height, or nil
+// if there are none.
+func (store dbStore) loadIndexedValidators(height int64) (*types.ValidatorSet, error) {
+	if height <= 0 {
+		return nil, nil
+	}
+	vals, err := store.LoadValidators(height)
+	if errNoVals := (ErrNoValSetForHeight{}); errors.As(err, &errNoVals) {
+		return nil, nil
+	}
+	return vals, err
+}
+
+// setValidatorPowers records the voting power at the given height of each
+// validator of vals whose power differs from the one in prev, and a power of
+// zero for the validators of prev which are not in vals.
+func setValidatorPowers(batch dbm.Batch, height int64, prev, vals *types.ValidatorSet) error {
+	prevPowers := make(map[string]int64)
+	if prev != nil {
+		for _, val := range prev.Validators {
+			prevPowers[string(val.Address)] = val.VotingPower
+		}
+	}
+	for _, val := range vals.Validators {
+		prevPower, ok := prevPowers[string(val.Address)]
+		delete(prevPowers, string(val.Address))
+		if ok && prevPower == val.VotingPower {
+			continue
+		}
+		if err := batch.Set(validatorPowerKey(val.Address, height), encodeValidatorPower(val.VotingPower)); err != nil {
+			return err
+		}
+	}
+	for address := range prevPowers {
+		if err := batch.Set(validatorPowerKey([]byte(address), height), encodeValidatorPower(0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeValidatorPower(power int64) []byte {
+	bz := make([]byte, binary.MaxVarintLen64)
+	return bz[:binary.PutVarint(bz, power)]
+}
+
+func decodeValidatorPower(bz []byte) (int64, error) {
+	power, n := binary.Varint(bz)
+	if n <= 0 {
+		return 0, errors.New("invalid stored validator power")
+	}
+	return power, nil
+}
+
+//-----------------------------------------------------------------------------
+
 // ConsensusParamsInfo represents the latest consensus params, or the last height it changed
 
 // Allocate empty Consensus params at compile time to avoid multiple allocations during runtime
@@ -646,6 +977,57 @@ func (store dbStore) LoadConsensusParams(height int64) (types.ConsensusParams, e
 	return types.ConsensusParamsFromProto(paramsInfo.ConsensusParams), nil
 }
 
+// ConsensusParamsChange is a change of the consensus params: the params which
+// apply from a height on.
+type ConsensusParamsChange struct {
+	Height int64
+	Params types.ConsensusParams
+}
+
+// LoadConsensusParamsHistory returns the consensus params at the height from,
+// with the height at which they last changed, followed by each change of the
+// params up to the height to (inclusive), in order of height.
+//
+// The changes are found by following the last height changed of each record
+// back from the height to, so the cost only depends on the number of changes.
+// The heights pruned by PruneStates are reported with an error.
+func (store dbStore) LoadConsensusParamsHistory(from, to int64) ([]ConsensusParamsChange, error) {
+	if from > to {
+		return nil, fmt.Errorf("from height %d is greater than to height %d", from, to)
+	}
+
+	var changes []ConsensusParamsChange
+	for height := to; height >= from; {
+		paramsInfo, err := store.loadConsensusParamsInfo(height)
+		if err != nil {
+			return nil, fmt.Errorf("could not find consensus params for height #%d: %w", height, err)
+		}
+		changed := paramsInfo.LastHeightChanged
+		if changed <= 0 || changed > height {
+			return nil, fmt.Errorf("invalid last height changed %d of the consensus params for height #%d",
+				changed, height)
+		}
+		if paramsInfo.ConsensusParams.Equal(&emptypb) {
+			if paramsInfo, err = store.loadConsensusParamsInfo(changed); err != nil {
+				return nil, fmt.Errorf(
+					"couldn't find consensus params at height %d (height %d was originally requested): %w",
+					changed, height, err)
+			}
+		}
+		changes = append(changes, ConsensusParamsChange{
+			Height: changed,
+			Params: types.ConsensusParamsFromProto(paramsInfo.ConsensusParams),
+		})
+		height = changed - 1
+	}
+
+	// the changes were found from the last one
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	return changes, nil
+}
+
 func (store dbStore) loadConsensusParamsInfo(height int64) (*tmstate.ConsensusParamsInfo, error) {
 	buf, err := store.db.Get(consensusParamsKey(height))
 	if err != nil {
@@ -689,6 +1071,80 @@ func (store dbStore) saveConsensusParamsInfo(
 	return batch.Set(consensusParamsKey(nextHeight), bz)
 }
 
+// LoadCompanionRetainHeight loads the retain height set by a data companion,
+// or 0 if none is set.
+func (store dbStore) LoadCompanionRetainHeight() (int64, error) {
+	return store.loadHeight(companionRetainHeightKey)
+}
+
+// SaveCompanionRetainHeight saves the retain height set by a data companion.
+func (store dbStore) SaveCompanionRetainHeight(height int64) error {
+	return store.saveHeight(companionRetainHeightKey, height)
+}
+
+// LoadCompanionResultsRetainHeight loads the retain height of block results
+// set by a data companion, or 0 if none is set.
+func (store dbStore) LoadCompanionResultsRetainHeight() (int64, error) {
+	return store.loadHeight(companionResultsRetainHeightKey)
+}
+
+// SaveCompanionResultsRetainHeight saves the retain height of block results
+// set by a data companion.
+func (store dbStore) SaveCompanionResultsRetainHeight(height int64) error {
+	return store.saveHeight(companionResultsRetainHeightKey, height)
+}
+
+// LoadApplicationRetainHeight loads the last retain height returned by the
+// application, or 0 if none is set.
+func (store dbStore) LoadApplicationRetainHeight() (int64, error) {
+	return store.loadHeight(appRetainHeightKey)
+}
+
+// SaveApplicationRetainHeight saves the retain height returned by the
+// application.
+func (store dbStore) SaveApplicationRetainHeight(height int64) error {
+	return store.saveHeight(appRetainHeightKey, height)
+}
+
+// LoadCommitMarker loads the height of the block being committed, or 0 if no
+// commit is in progress.
+func (store dbStore) LoadCommitMarker() (int64, error) {
+	return store.loadHeight(commitMarkerKey)
+}
+
+// SaveCommitMarker records that the block at height is being committed. The
+// marker is cleared when the state of that height is saved.
+func (store dbStore) SaveCommitMarker(height int64) error {
+	return store.saveHeight(commitMarkerKey, height)
+}
+
+// ClearCommitMarker removes the commit marker.
+func (store dbStore) ClearCommitMarker() error {
+	return store.db.DeleteSync(commitMarkerKey)
+}
+
+func (store dbStore) loadHeight(key []byte) (int64, error) {
+	bz, err := store.db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return 0, err
+	}
+	height, n := binary.Varint(bz)
+	if n <= 0 {
+		return 0, errors.New("invalid stored height")
+	}
+	return height, nil
+}
+
+func (store dbStore) saveHeight(key []byte, height int64) error {
+	bz := make([]byte, binary.MaxVarintLen64)
+	return store.db.SetSync(key, bz[:binary.PutVarint(bz, height)])
+}
+
+func setHeight(batch dbm.Batch, key []byte, height int64) error {
+	bz := make([]byte, binary.MaxVarintLen64)
+	return batch.Set(key, bz[:binary.PutVarint(bz, height)])
+}
+
 func (store dbStore) Close() error {
 	return store.db.Close()
 }
@@ -101,6 +101,97 @@ func TestStoreLoadValidators(t *testing.T) {
 	require.NotEqual(t, vals.CopyIncrementProposerPriority(valSetCheckpointInterval), loadedVals)
 }
 
+func TestStoreLoadValidatorPowers(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	keyA, keyB, keyC := ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey(), ed25519.GenPrivKey().PubKey()
+	valSet := func(vals ...*types.Validator) *types.ValidatorSet { return types.NewValidatorSet(vals) }
+
+	_, err := stateStore.LoadValidatorPowers(keyA.Address(), 1, 1)
+	require.Error(t, err, "nothing is indexed")
+
+	// A's power changes at height 3, B is removed and C added at height 4
+	sets := []*types.ValidatorSet{
+		valSet(types.NewValidator(keyA, 10), types.NewValidator(keyB, 20)),
+		valSet(types.NewValidator(keyA, 10), types.NewValidator(keyB, 20)),
+		valSet(types.NewValidator(keyA, 10), types.NewValidator(keyB, 20)),
+		valSet(types.NewValidator(keyA, 15), types.NewValidator(keyB, 20)),
+		valSet(types.NewValidator(keyA, 15), types.NewValidator(keyC, 5)),
+		valSet(types.NewValidator(keyA, 15), types.NewValidator(keyC, 5)),
+	}
+	for height := int64(0); height <= 3; height++ {
+		require.NoError(t, stateStore.Save(sm.State{
+			InitialHeight:               1,
+			LastBlockHeight:             height,
+			LastValidators:              sets[height],
+			Validators:                  sets[height+1],
+			NextValidators:              sets[height+2],
+			LastHeightValidatorsChanged: height + 2,
+			ConsensusParams:             *types.DefaultConsensusParams(),
+		}))
+	}
+
+	testCases := []struct {
+		address  []byte
+		from, to int64
+		expected []sm.ValidatorPower
+	}{
+		{keyA.Address(), 1, 5, []sm.ValidatorPower{{Height: 1, Power: 10}, {Height: 3, Power: 15}}},
+		{keyA.Address(), 3, 5, []sm.ValidatorPower{{Height: 3, Power: 15}}},
+		{keyA.Address(), 4, 4, []sm.ValidatorPower{{Height: 4, Power: 15}}},
+		{keyB.Address(), 1, 5, []sm.ValidatorPower{{Height: 1, Power: 20}, {Height: 4, Power: 0}}},
+		{keyC.Address(), 2, 5, []sm.ValidatorPower{{Height: 2, Power: 0}, {Height: 4, Power: 5}}},
+		{keyC.Address(), 1, 3, []sm.ValidatorPower{{Height: 1, Power: 0}}},
+	}
+	for _, tc := range testCases {
+		powers, err := stateStore.LoadValidatorPowers(tc.address, tc.from, tc.to)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, powers, "%X from %d to %d", tc.address, tc.from, tc.to)
+	}
+
+	_, err = stateStore.LoadValidatorPowers(keyA.Address(), 1, 6)
+	require.Error(t, err, "past the next validators")
+	_, err = stateStore.LoadValidatorPowers(keyA.Address(), 4, 3)
+	require.Error(t, err)
+
+	// replacing the validator sets from height 3 on, as when forking the chain
+	// into a testnet, rewrites their changes
+	keyD := ed25519.GenPrivKey().PubKey()
+	forked := valSet(types.NewValidator(keyD, 100))
+	require.NoError(t, stateStore.Bootstrap(sm.State{
+		InitialHeight:               1,
+		LastBlockHeight:             3,
+		LastValidators:              forked,
+		Validators:                  forked,
+		NextValidators:              forked,
+		LastHeightValidatorsChanged: 4,
+		ConsensusParams:             *types.DefaultConsensusParams(),
+	}))
+	testCases = []struct {
+		address  []byte
+		from, to int64
+		expected []sm.ValidatorPower
+	}{
+		{keyA.Address(), 1, 5, []sm.ValidatorPower{{Height: 1, Power: 10}, {Height: 3, Power: 0}}},
+		{keyB.Address(), 1, 5, []sm.ValidatorPower{{Height: 1, Power: 20}, {Height: 3, Power: 0}}},
+		{keyC.Address(), 1, 5, []sm.ValidatorPower{{Height: 1, Power: 0}}},
+		{keyD.Address(), 1, 5, []sm.ValidatorPower{{Height: 1, Power: 0}, {Height: 3, Power: 100}}},
+	}
+	for _, tc := range testCases {
+		powers, err := stateStore.LoadValidatorPowers(tc.address, tc.from, tc.to)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, powers, "%X from %d to %d", tc.address, tc.from, tc.to)
+	}
+
+	// the index of a bootstrapped store starts from its height
+	stateStore = sm.NewStore(dbm.NewMemDB())
+	require.NoError(t, stateStore.Bootstrap(makeRandomStateFromValidatorSet(sets[4], 100, 100)))
+	powers, err := stateStore.LoadValidatorPowers(keyC.Address(), 100, 101)
+	require.NoError(t, err)
+	require.Equal(t, []sm.ValidatorPower{{Height: 100, Power: 5}}, powers)
+	_, err = stateStore.LoadValidatorPowers(keyC.Address(), 99, 101)
+	require.Error(t, err)
+}
+
 // This benchmarks the speed of loading validators from different heights if there is no validator set change.
 // NOTE: This isn't too indicative of validator retrieval speed as the db is always (regardless of height) only
 // performing two operations: 1) retrieve validator info at height x, which has a last validator set change of 1
@@ -171,6 +262,116 @@ func TestStoreLoadConsensusParams(t *testing.T) {
 	require.NotEqual(t, res, differentParams)
 }
 
+func TestStoreLoadConsensusParamsHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+
+	// the params change at heights 4 and 8
+	paramsAt := func(changed int64) *types.ConsensusParams {
+		params := types.DefaultConsensusParams()
+		params.Block.MaxBytes = 1000 * changed
+		return params
+	}
+	changedAt := func(height int64) int64 {
+		switch {
+		case height >= 8:
+			return 8
+		case height >= 4:
+			return 4
+		default:
+			return 1
+		}
+	}
+	for height := int64(1); height <= 10; height++ {
+		changed := changedAt(height)
+		require.NoError(t, stateStore.Save(makeRandomStateFromConsensusParams(ctx, t, paramsAt(changed), height, changed)))
+	}
+
+	for _, tc := range []struct {
+		from, to int64
+		changes  []int64
+	}{
+		{1, 10, []int64{1, 4, 8}},
+		{5, 10, []int64{4, 8}},
+		{4, 7, []int64{4}},
+		{9, 9, []int64{8}},
+		{3, 8, []int64{1, 4, 8}},
+	} {
+		changes, err := stateStore.LoadConsensusParamsHistory(tc.from, tc.to)
+		require.NoError(t, err)
+		require.Len(t, changes, len(tc.changes), "from %d to %d", tc.from, tc.to)
+		for i, change := range changes {
+			require.Equal(t, tc.changes[i], change.Height)
+			require.Equal(t, *paramsAt(change.Height), change.Params)
+		}
+	}
+
+	_, err := stateStore.LoadConsensusParamsHistory(5, 4)
+	require.Error(t, err)
+	_, err = stateStore.LoadConsensusParamsHistory(1, 11)
+	require.Error(t, err)
+}
+
+func TestStoreRetainHeights(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+
+	for name, load := range map[string]func() (int64, error){
+		"companion":         stateStore.LoadCompanionRetainHeight,
+		"companion results": stateStore.LoadCompanionResultsRetainHeight,
+		"application":       stateStore.LoadApplicationRetainHeight,
+	} {
+		height, err := load()
+		require.NoError(t, err, name)
+		require.Zero(t, height, "%s: no retain height saved", name)
+	}
+
+	require.NoError(t, stateStore.SaveCompanionRetainHeight(42))
+	require.NoError(t, stateStore.SaveCompanionResultsRetainHeight(43))
+	require.NoError(t, stateStore.SaveApplicationRetainHeight(44))
+
+	height, err := stateStore.LoadCompanionRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 42, height)
+	height, err = stateStore.LoadCompanionResultsRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 43, height)
+	height, err = stateStore.LoadApplicationRetainHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 44, height)
+}
+
+func TestStoreCommitMarker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	height, err := stateStore.LoadCommitMarker()
+	require.NoError(t, err)
+	require.Zero(t, height)
+
+	// saving the state of a lower height keeps the marker
+	require.NoError(t, stateStore.SaveCommitMarker(10))
+	params := types.DefaultConsensusParams()
+	require.NoError(t, stateStore.Save(makeRandomStateFromConsensusParams(ctx, t, params, 10, 1)))
+	height, err = stateStore.LoadCommitMarker()
+	require.NoError(t, err)
+	require.EqualValues(t, 10, height)
+
+	// saving the state of its height clears it
+	require.NoError(t, stateStore.Save(makeRandomStateFromConsensusParams(ctx, t, params, 11, 1)))
+	height, err = stateStore.LoadCommitMarker()
+	require.NoError(t, err)
+	require.Zero(t, height)
+
+	require.NoError(t, stateStore.SaveCommitMarker(11))
+	require.NoError(t, stateStore.ClearCommitMarker())
+	height, err = stateStore.LoadCommitMarker()
+	require.NoError(t, err)
+	require.Zero(t, height)
+}
+
 func TestPruneStates(t *testing.T) {
 	testcases := map[string]struct {
 		startHeight           int64
@@ -289,10 +490,29 @@ func TestPruneStates(t *testing.T) {
 					require.Equal(t, emptyParams, params, h)
 				}
 
+				// responses to FinalizeBlock are pruned separately
+				finRes, err := stateStore.LoadFinalizeBlockResponses(h)
+				require.NoError(t, err, h)
+				require.NotNil(t, finRes, h)
+			}
+
+			require.NoError(t, stateStore.PruneFinalizeBlockResponses(tc.pruneHeight))
+			for h := tc.startHeight; h <= tc.endHeight; h++ {
 				finRes, err := stateStore.LoadFinalizeBlockResponses(h)
-				require.Error(t, err, h)
-				require.Nil(t, finRes, h)
+				if h < tc.pruneHeight {
+					require.Error(t, err, h)
+					require.Nil(t, finRes, h)
+				} else {
+					require.NoError(t, err, h)
+					require.NotNil(t, finRes, h)
+				}
 			}
 		})
 	}
 }
+
+func TestPruneFinalizeBlockResponsesInvalidHeight(t *testing.T) {
+	stateStore := sm.NewStore(dbm.NewMemDB())
+	require.Error(t, stateStore.PruneFinalizeBlockResponses(0))
+	require.Error(t, stateStore.PruneFinalizeBlockResponses(-1))
+}
@@ -38,18 +38,24 @@ type chunkQueue struct {
 	waiters        map[uint32][]chan<- uint32 // signals WaitFor() waiters about chunk arrival
 }
 
-// newChunkQueue creates a new chunk queue for a snapshot, using a temp dir for storage.
-// Callers must call Close() when done.
+// newChunkQueue creates a new chunk queue for a snapshot, storing the chunks in a directory
+// of tempDir named after the snapshot. If the directory already holds chunks of the snapshot,
+// left by an interrupted state sync, the queue resumes with them instead of fetching them
+// again. Callers must call Close() or Suspend() when done.
 func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
-	dir, err := os.MkdirTemp(tempDir, "tm-statesync")
-	if err != nil {
-		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
-	}
 	if snapshot.Chunks == 0 {
 		return nil, errors.New("snapshot has no chunks")
 	}
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+	key := snapshot.Key()
+	dir := filepath.Join(tempDir, fmt.Sprintf("%s-%x", chunkDirPrefix, key[:]))
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create temp dir for state sync chunks: %w", err)
+	}
 
-	return &chunkQueue{
+	q := &chunkQueue{
 		snapshot:       snapshot,
 		dir:            dir,
 		chunkFiles:     make(map[uint32]string, snapshot.Chunks),
@@ -57,7 +63,43 @@ func newChunkQueue(snapshot *snapshot, tempDir string) (*chunkQueue, error) {
 		chunkAllocated: make(map[uint32]bool, snapshot.Chunks),
 		chunkReturned:  make(map[uint32]bool, snapshot.Chunks),
 		waiters:        make(map[uint32][]chan<- uint32),
-	}, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state sync chunk dir: %w", err)
+	}
+	for _, e := range entries {
+		index, err := strconv.ParseUint(e.Name(), 10, 32)
+		if err != nil || uint32(index) >= snapshot.Chunks {
+			continue // e.g. a partially written chunk
+		}
+		q.chunkFiles[uint32(index)] = filepath.Join(dir, e.Name())
+		q.chunkAllocated[uint32(index)] = true
+	}
+
+	return q, nil
+}
+
+// chunkDirPrefix prefixes the names of the chunk directories of the snapshots.
+const chunkDirPrefix = "tm-statesync"
+
+// removeStaleChunkDirs removes from tempDir the chunk directories of all snapshots, which are
+// left behind by interrupted state syncs.
+func removeStaleChunkDirs(tempDir string) error {
+	if tempDir == "" {
+		tempDir = os.TempDir()
+	}
+	dirs, err := filepath.Glob(filepath.Join(tempDir, chunkDirPrefix+"-*"))
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to clean up state sync tempdir %v: %w", dir, err)
+		}
+	}
+	return nil
 }
 
 // Add adds a chunk to the queue. It ignores chunks that already exist, returning false.
@@ -85,9 +127,13 @@ func (q *chunkQueue) Add(chunk *chunk) (bool, error) {
 		return false, nil
 	}
 
+	// Write the chunk to a temporary file first, so that an interrupted write never leaves a
+	// partial chunk behind to be resumed with.
 	path := filepath.Join(q.dir, strconv.FormatUint(uint64(chunk.Index), 10))
-	err := os.WriteFile(path, chunk.Chunk, 0600)
-	if err != nil {
+	if err := os.WriteFile(path+".tmp", chunk.Chunk, 0600); err != nil {
+		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
 		return false, fmt.Errorf("failed to save chunk %v to file %v: %w", chunk.Index, path, err)
 	}
 
@@ -137,7 +183,28 @@ func (q *chunkQueue) Close() error {
 	if q.snapshot == nil {
 		return nil
 	}
+	q.close()
+
+	if err := os.RemoveAll(q.dir); err != nil {
+		return fmt.Errorf("failed to clean up state sync tempdir %v: %w", q.dir, err)
+	}
+
+	return nil
+}
+
+// Suspend closes the chunk queue, but keeps the chunks on disk so that a later queue for the
+// same snapshot resumes with them. It does nothing if the queue is already closed.
+func (q *chunkQueue) Suspend() {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.snapshot != nil {
+		q.close()
+	}
+}
 
+// close closes the waiters and marks the queue as closed. The caller must hold the mutex lock.
+func (q *chunkQueue) close() {
 	for _, waiters := range q.waiters {
 		for _, waiter := range waiters {
 			close(waiter)
@@ -146,12 +213,6 @@ func (q *chunkQueue) Close() error {
 
 	q.waiters = nil
 	q.snapshot = nil
-
-	if err := os.RemoveAll(q.dir); err != nil {
-		return fmt.Errorf("failed to clean up state sync tempdir %v: %w", q.dir, err)
-	}
-
-	return nil
 }
 
 // Discard discards a chunk. It will be removed from the queue, available for allocation, and can
@@ -355,6 +416,13 @@ func (q *chunkQueue) WaitFor(index uint32) <-chan uint32 {
 	return ch
 }
 
+// numChunksFetched returns the number of chunks in the queue, fetched or resumed from disk.
+func (q *chunkQueue) numChunksFetched() int {
+	q.Lock()
+	defer q.Unlock()
+	return len(q.chunkFiles)
+}
+
 func (q *chunkQueue) numChunksReturned() int {
 	q.Lock()
 	defer q.Unlock()
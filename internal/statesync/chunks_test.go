@@ -51,6 +51,45 @@ func TestNewChunkQueue_TempDir(t *testing.T) {
 	assert.Len(t, files, 0)
 }
 
+func TestChunkQueue_Resume(t *testing.T) {
+	snapshot := &snapshot{Height: 3, Format: 1, Chunks: 3, Hash: []byte{7}}
+	dir := t.TempDir()
+
+	queue, err := newChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	_, err = queue.Add(&chunk{Height: 3, Format: 1, Index: 1, Chunk: []byte{3, 1, 1}})
+	require.NoError(t, err)
+	queue.Suspend()
+
+	// A queue for the same snapshot resumes with the chunk, and only the others
+	// are allocated for fetching.
+	queue, err = newChunkQueue(snapshot, dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, queue.numChunksFetched())
+	assert.True(t, queue.Has(1))
+	for _, want := range []uint32{0, 2} {
+		index, err := queue.Allocate()
+		require.NoError(t, err)
+		assert.Equal(t, want, index)
+	}
+	_, err = queue.Allocate()
+	assert.Equal(t, errDone, err)
+	queue.Suspend()
+
+	// A queue for another snapshot does not.
+	otherSnapshot := *snapshot
+	otherSnapshot.Hash = []byte{8}
+	other, err := newChunkQueue(&otherSnapshot, dir)
+	require.NoError(t, err)
+	assert.Zero(t, other.numChunksFetched())
+	require.NoError(t, other.Close())
+
+	require.NoError(t, removeStaleChunkDirs(dir))
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
 func TestChunkQueue(t *testing.T) {
 	queue, teardown := setupChunkQueue(t)
 	defer teardown()
@@ -170,6 +170,9 @@ type BlockProvider struct {
 	peer       types.NodeID
 	chainID    string
 	dispatcher *Dispatcher
+
+	// evidencePool receives the reported light client attacks, if not nil.
+	evidencePool EvidencePool
 }
 
 // Creates a block provider which implements the light client Provider interface.
@@ -213,11 +216,14 @@ func (p *BlockProvider) LightBlock(ctx context.Context, height int64) (*types.Li
 	return lb, nil
 }
 
-// ReportEvidence should allow for the light client to report any light client
-// attacks. This is a no op as there currently isn't a way to wire this up to
-// the evidence reactor (we should endeavor to do this in the future but for now
-// it's not critical for backwards verification)
+// ReportEvidence reports the light client attacks detected by the light client
+// to the evidence pool of the node, which verifies them once it has synced and
+// gossips them to its peers. Other evidence is ignored, as are the
+// attacks if the provider has no evidence pool.
 func (p *BlockProvider) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	if lcae, ok := ev.(*types.LightClientAttackEvidence); ok && p.evidencePool != nil {
+		p.evidencePool.ReportLightClientAttack(lcae)
+	}
 	return nil
 }
 
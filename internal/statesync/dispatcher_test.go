@@ -139,6 +139,33 @@ func TestDispatcherProviders(t *testing.T) {
 	}
 }
 
+type testEvidencePool struct {
+	reported []*types.LightClientAttackEvidence
+}
+
+func (p *testEvidencePool) ReportLightClientAttack(ev *types.LightClientAttackEvidence) {
+	p.reported = append(p.reported, ev)
+}
+
+func TestBlockProviderReportEvidence(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peer := createPeerSet(1)[0]
+	ev := &types.LightClientAttackEvidence{CommonHeight: 10}
+
+	// the attacks are dropped without an evidence pool
+	p := NewBlockProvider(peer, "test-chain", nil)
+	require.NoError(t, p.ReportEvidence(ctx, ev))
+
+	evpool := &testEvidencePool{}
+	r := &Reactor{evidencePool: evpool}
+	p = r.newBlockProvider(peer, "test-chain")
+	require.NoError(t, p.ReportEvidence(ctx, ev))
+	require.NoError(t, p.ReportEvidence(ctx, &types.DuplicateVoteEvidence{}))
+	require.Equal(t, []*types.LightClientAttackEvidence{ev}, evpool.reported)
+}
+
 func TestPeerListBasic(t *testing.T) {
 	t.Cleanup(leaktest.Check(t))
 
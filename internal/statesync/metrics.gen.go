@@ -44,6 +44,18 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "snapshot_chunk_total",
 			Help:      "The total number of chunks in the current snapshot.",
 		}, labels).With(labelsAndValues...),
+		SnapshotChunkFetched: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_chunk_fetched",
+			Help:      "The number of chunks of the current snapshot that have been fetched, including those resumed from an interrupted state sync.",
+		}, labels).With(labelsAndValues...),
+		SnapshotRemainingSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "snapshot_remaining_seconds",
+			Help:      "The estimated time in seconds to restore the rest of the current snapshot.",
+		}, labels).With(labelsAndValues...),
 		BackFilledBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -61,12 +73,14 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		TotalSnapshots:      discard.NewCounter(),
-		ChunkProcessAvgTime: discard.NewGauge(),
-		SnapshotHeight:      discard.NewGauge(),
-		SnapshotChunk:       discard.NewCounter(),
-		SnapshotChunkTotal:  discard.NewGauge(),
-		BackFilledBlocks:    discard.NewCounter(),
-		BackFillBlocksTotal: discard.NewGauge(),
+		TotalSnapshots:           discard.NewCounter(),
+		ChunkProcessAvgTime:      discard.NewGauge(),
+		SnapshotHeight:           discard.NewGauge(),
+		SnapshotChunk:            discard.NewCounter(),
+		SnapshotChunkTotal:       discard.NewGauge(),
+		SnapshotChunkFetched:     discard.NewGauge(),
+		SnapshotRemainingSeconds: discard.NewGauge(),
+		BackFilledBlocks:         discard.NewCounter(),
+		BackFillBlocksTotal:      discard.NewGauge(),
 	}
 }
@@ -23,6 +23,11 @@ type Metrics struct {
 	SnapshotChunk metrics.Counter
 	// The total number of chunks in the current snapshot.
 	SnapshotChunkTotal metrics.Gauge
+	// The number of chunks of the current snapshot that have been fetched,
+	// including those resumed from an interrupted state sync.
+	SnapshotChunkFetched metrics.Gauge
+	// The estimated time in seconds to restore the rest of the current snapshot.
+	SnapshotRemainingSeconds metrics.Gauge
 	// The current number of blocks that have been back-filled.
 	BackFilledBlocks metrics.Counter
 	// The total number of blocks that need to be back-filled.
@@ -69,6 +69,20 @@ func (_m *Metricer) SnapshotChunksCount() int64 {
 	return r0
 }
 
+// SnapshotChunksFetched provides a mock function with given fields:
+func (_m *Metricer) SnapshotChunksFetched() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
 // SnapshotChunksTotal provides a mock function with given fields:
 func (_m *Metricer) SnapshotChunksTotal() int64 {
 	ret := _m.Called()
@@ -97,6 +111,20 @@ func (_m *Metricer) SnapshotHeight() int64 {
 	return r0
 }
 
+// SnapshotRemainingTime provides a mock function with given fields:
+func (_m *Metricer) SnapshotRemainingTime() time.Duration {
+	ret := _m.Called()
+
+	var r0 time.Duration
+	if rf, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+
+	return r0
+}
+
 // TotalSnapshots provides a mock function with given fields:
 func (_m *Metricer) TotalSnapshots() int64 {
 	ret := _m.Called()
@@ -117,11 +117,14 @@ func getChannelDescriptors() map[p2p.ChannelID]*p2p.ChannelDescriptor {
 // Metricer defines an interface used for the rpc sync info query, please see statesync.metrics
 // for the details.
 type Metricer interface {
+	IsSyncing() bool
 	TotalSnapshots() int64
 	ChunkProcessAvgTime() time.Duration
 	SnapshotHeight() int64
 	SnapshotChunksCount() int64
 	SnapshotChunksTotal() int64
+	SnapshotChunksFetched() int64
+	SnapshotRemainingTime() time.Duration
 	BackFilledBlocks() int64
 	BackFillBlocksTotal() int64
 }
@@ -162,6 +165,7 @@ type Reactor struct {
 	initSyncer        func() *syncer
 	requestSnaphot    func() error
 	syncer            *syncer
+	syncing           bool // whether Sync is running, from waiting for peers to backfilling
 	providers         map[types.NodeID]*BlockProvider
 	initStateProvider func(ctx context.Context, chainID string, initialHeight int64) error
 	stateProvider     StateProvider
@@ -170,6 +174,26 @@ type Reactor struct {
 	metrics            *Metrics
 	backfillBlockTotal int64
 	backfilledBlocks   int64
+
+	// evidencePool receives the light client attacks detected by the light
+	// client of the p2p state provider, if not nil.
+	evidencePool EvidencePool
+}
+
+// EvidencePool is the evidence pool the reactor reports the light client
+// attacks detected while state syncing to.
+type EvidencePool interface {
+	ReportLightClientAttack(*types.LightClientAttackEvidence)
+}
+
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithEvidencePool reports the light client attacks detected by the light
+// client of the P2P state provider to the evidence pool, which verifies them
+// once the node synced and gossips them.
+func WithEvidencePool(evpool EvidencePool) ReactorOption {
+	return func(r *Reactor) { r.evidencePool = evpool }
 }
 
 // NewReactor returns a reference to a new state sync reactor, which implements
@@ -191,6 +215,7 @@ func NewReactor(
 	eventBus *eventbus.EventBus,
 	postSyncHook func(context.Context, sm.State) error,
 	needsStateSync bool,
+	options ...ReactorOption,
 ) *Reactor {
 	r := &Reactor{
 		logger:         logger,
@@ -210,6 +235,9 @@ func NewReactor(
 		postSyncHook:   postSyncHook,
 		needsStateSync: needsStateSync,
 	}
+	for _, opt := range options {
+		opt(r)
+	}
 
 	r.BaseService = *service.NewBaseService(logger, "StateSync", r)
 	return r
@@ -245,6 +273,11 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 	// references to these channels for use later. This is not
 	// ideal.
 	r.initSyncer = func() *syncer {
+		providers := make(map[types.NodeID]*snapshotProvider, len(r.cfg.SnapshotProviders))
+		for _, url := range r.cfg.SnapshotProviders {
+			provider := newSnapshotProvider(url, r.cfg.ChunkRequestTimeout)
+			providers[provider.ID()] = provider
+		}
 		return &syncer{
 			logger:        r.logger,
 			stateProvider: r.stateProvider,
@@ -255,6 +288,7 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 			tempDir:       r.tempDir,
 			fetchers:      r.cfg.Fetchers,
 			retryTimeout:  r.cfg.ChunkRequestTimeout,
+			providers:     providers,
 			metrics:       r.metrics,
 		}
 	}
@@ -286,7 +320,7 @@ func (r *Reactor) OnStart(ctx context.Context) error {
 			peers := r.peers.All()
 			providers := make([]provider.Provider, len(peers))
 			for idx, p := range peers {
-				providers[idx] = NewBlockProvider(p, chainID, r.dispatcher)
+				providers[idx] = r.newBlockProvider(p, chainID)
 			}
 
 			stateProvider, err := NewP2PStateProvider(ctx, chainID, initialHeight, providers, to, paramsCh, r.logger.With("module", "stateprovider"))
@@ -337,6 +371,15 @@ func (r *Reactor) OnStop() {
 // blocksync can commence. It will then proceed to backfill the necessary amount
 // of historical blocks before participating in consensus
 func (r *Reactor) Sync(ctx context.Context) (sm.State, error) {
+	r.mtx.Lock()
+	r.syncing = true
+	r.mtx.Unlock()
+	defer func() {
+		r.mtx.Lock()
+		r.syncing = false
+		r.mtx.Unlock()
+	}()
+
 	if r.eventBus != nil {
 		if err := r.eventBus.PublishEventStateSyncStatus(types.EventDataStateSyncStatus{
 			Complete: false,
@@ -414,15 +457,7 @@ func (r *Reactor) Sync(ctx context.Context) (sm.State, error) {
 // and time that is less or equal to the stopHeight and stopTime. The
 // trustedBlockID should be of the header at startHeight.
 func (r *Reactor) Backfill(ctx context.Context, state sm.State) error {
-	params := state.ConsensusParams.Evidence
-	stopHeight := state.LastBlockHeight - params.MaxAgeNumBlocks
-	stopTime := state.LastBlockTime.Add(-params.MaxAgeDuration)
-	// ensure that stop height doesn't go below the initial height
-	if stopHeight < state.InitialHeight {
-		stopHeight = state.InitialHeight
-		// this essentially makes stop time a void criteria for termination
-		stopTime = state.LastBlockTime
-	}
+	stopHeight, stopTime := backfillStop(state, r.cfg.BackfillBlocks)
 	return r.backfill(
 		ctx,
 		state.ChainID,
@@ -434,6 +469,26 @@ func (r *Reactor) Backfill(ctx context.Context, state sm.State) error {
 	)
 }
 
+// backfillStop returns the height and time at which to stop backfilling the
+// blocks before the given state. These are the oldest block needed to verify
+// evidence, unless minBlocks requires backfilling more blocks.
+func backfillStop(state sm.State, minBlocks int64) (stopHeight int64, stopTime time.Time) {
+	params := state.ConsensusParams.Evidence
+	blocks := params.MaxAgeNumBlocks
+	if minBlocks > blocks {
+		blocks = minBlocks
+	}
+	stopHeight = state.LastBlockHeight - blocks
+	stopTime = state.LastBlockTime.Add(-params.MaxAgeDuration)
+	// ensure that stop height doesn't go below the initial height
+	if stopHeight < state.InitialHeight {
+		stopHeight = state.InitialHeight
+		// this essentially makes stop time a void criteria for termination
+		stopTime = state.LastBlockTime
+	}
+	return stopHeight, stopTime
+}
+
 func (r *Reactor) backfill(
 	ctx context.Context,
 	chainID string,
@@ -941,6 +996,14 @@ func (r *Reactor) processChannels(ctx context.Context, chanTable map[p2p.Channel
 	}
 }
 
+// newBlockProvider returns a block provider fetching light blocks from the
+// peer, and reporting the light client attacks to the evidence pool.
+func (r *Reactor) newBlockProvider(peer types.NodeID, chainID string) *BlockProvider {
+	p := NewBlockProvider(peer, chainID, r.dispatcher)
+	p.evidencePool = r.evidencePool
+	return p
+}
+
 // processPeerUpdate processes a PeerUpdate, returning an error upon failing to
 // handle the PeerUpdate or if a panic is recovered.
 func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpdate) {
@@ -972,7 +1035,7 @@ func (r *Reactor) processPeerUpdate(ctx context.Context, peerUpdate p2p.PeerUpda
 
 	switch peerUpdate.Status {
 	case p2p.PeerStatusUp:
-		newProvider := NewBlockProvider(peerUpdate.NodeID, r.chainID, r.dispatcher)
+		newProvider := r.newBlockProvider(peerUpdate.NodeID, r.chainID)
 
 		r.providers[peerUpdate.NodeID] = newProvider
 		err := r.syncer.AddPeer(ctx, peerUpdate.NodeID)
@@ -1106,6 +1169,13 @@ func (r *Reactor) waitForEnoughPeers(ctx context.Context, numPeers int) error {
 	return nil
 }
 
+// IsSyncing reports whether a state sync is in progress.
+func (r *Reactor) IsSyncing() bool {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.syncing
+}
+
 func (r *Reactor) TotalSnapshots() int64 {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
@@ -1155,6 +1225,29 @@ func (r *Reactor) SnapshotChunksTotal() int64 {
 	return 0
 }
 
+// SnapshotChunksFetched returns the number of chunks of the snapshot being restored that have
+// been fetched, including those resumed from an interrupted state sync.
+func (r *Reactor) SnapshotChunksFetched() int64 {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if r.syncer != nil && r.syncer.chunks != nil {
+		return int64(r.syncer.chunks.numChunksFetched())
+	}
+	return 0
+}
+
+// SnapshotRemainingTime estimates the time to restore the rest of the snapshot being restored.
+func (r *Reactor) SnapshotRemainingTime() time.Duration {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	if r.syncer != nil && r.syncer.chunks != nil {
+		return r.syncer.remainingTime(r.syncer.chunks)
+	}
+	return 0
+}
+
 func (r *Reactor) BackFilledBlocks() int64 {
 	r.mtx.RLock()
 	defer r.mtx.RUnlock()
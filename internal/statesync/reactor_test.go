@@ -18,6 +18,7 @@ import (
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/proxy"
+	sm "github.com/tendermint/tendermint/internal/state"
 	smmocks "github.com/tendermint/tendermint/internal/state/mocks"
 	"github.com/tendermint/tendermint/internal/statesync/mocks"
 	"github.com/tendermint/tendermint/internal/store"
@@ -715,6 +716,37 @@ func TestReactor_Backfill(t *testing.T) {
 	}
 }
 
+func TestBackfillStop(t *testing.T) {
+	now := time.Now()
+	state := sm.State{
+		InitialHeight:   1,
+		LastBlockHeight: 1000,
+		LastBlockTime:   now,
+		ConsensusParams: *types.DefaultConsensusParams(),
+	}
+	state.ConsensusParams.Evidence.MaxAgeNumBlocks = 100
+	state.ConsensusParams.Evidence.MaxAgeDuration = time.Hour
+
+	testcases := map[string]struct {
+		minBlocks  int64
+		stopHeight int64
+		stopTime   time.Time
+	}{
+		"evidence age":             {0, 900, now.Add(-time.Hour)},
+		"fewer than evidence age":  {10, 900, now.Add(-time.Hour)},
+		"more than evidence age":   {500, 500, now.Add(-time.Hour)},
+		"more than initial height": {5000, 1, now},
+	}
+	for desc, tc := range testcases {
+		tc := tc
+		t.Run(desc, func(t *testing.T) {
+			stopHeight, stopTime := backfillStop(state, tc.minBlocks)
+			require.Equal(t, tc.stopHeight, stopHeight)
+			require.Equal(t, tc.stopTime, stopTime)
+		})
+	}
+}
+
 // retryUntil will continue to evaluate fn and will return successfully when true
 // or fail when the timeout is reached.
 func retryUntil(ctx context.Context, t *testing.T, fn func() bool, timeout time.Duration) {
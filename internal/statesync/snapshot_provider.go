@@ -0,0 +1,108 @@
+package statesync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// snapshotListSize is the maximum size of the list of snapshots of a snapshot
+// provider.
+const snapshotListSize = recentSnapshots * snapshotMsgSize
+
+// snapshotProvider fetches snapshots and their chunks over HTTP from a
+// snapshot provider, such as a file server or CDN hosting the snapshots of a
+// node, in addition to the snapshots served by peers. A provider serves the
+// JSON list of its snapshots, as abci.Snapshot objects, at
+// <url>/snapshots.json, and each chunk at <url>/<height>-<format>/<index>,
+// which is the layout of the abci/snapshots store. See Store.Handler.
+//
+// The snapshots and chunks of a provider are no more trusted than those of a
+// peer: the app hash the application restores must still match the one
+// verified by the light client.
+type snapshotProvider struct {
+	url    string
+	client *http.Client
+}
+
+// newSnapshotProvider returns a provider fetching from the base URL, whose
+// requests time out after timeout.
+func newSnapshotProvider(url string, timeout time.Duration) *snapshotProvider {
+	return &snapshotProvider{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// ID returns the identifier of the provider in the snapshot pool and chunk
+// queue, in place of a peer ID: its URL, which the application sees as the
+// sender of its chunks.
+func (p *snapshotProvider) ID() types.NodeID {
+	return types.NodeID(p.url)
+}
+
+// Snapshots returns the snapshots of the provider.
+func (p *snapshotProvider) Snapshots(ctx context.Context) ([]*snapshot, error) {
+	bz, err := p.get(ctx, "/snapshots.json", snapshotListSize)
+	if err != nil {
+		return nil, err
+	}
+	var list []*abci.Snapshot
+	if err := json.Unmarshal(bz, &list); err != nil {
+		return nil, fmt.Errorf("decoding snapshots from %s: %w", p.url, err)
+	}
+
+	snapshots := make([]*snapshot, 0, len(list))
+	for _, s := range list {
+		if s == nil || s.Chunks == 0 || len(s.Hash) == 0 {
+			return nil, fmt.Errorf("invalid snapshot from %s", p.url)
+		}
+		snapshots = append(snapshots, &snapshot{
+			Height:   s.Height,
+			Format:   s.Format,
+			Chunks:   s.Chunks,
+			Hash:     s.Hash,
+			Metadata: s.Metadata,
+		})
+	}
+	return snapshots, nil
+}
+
+// Chunk returns the given chunk of the snapshot of the given height and
+// format.
+func (p *snapshotProvider) Chunk(ctx context.Context, height uint64, format, index uint32) ([]byte, error) {
+	return p.get(ctx, fmt.Sprintf("/%d-%d/%d", height, format, index), chunkMsgSize)
+}
+
+// get returns the body of the response to a GET request of the given path,
+// which must be at most limit bytes.
+func (p *snapshotProvider) get(ctx context.Context, path string, limit int) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s%s: %s", p.url, path, rsp.Status)
+	}
+
+	bz, err := io.ReadAll(io.LimitReader(rsp.Body, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s%s: %w", p.url, path, err)
+	}
+	if len(bz) > limit {
+		return nil, fmt.Errorf("fetching %s%s: response exceeds %d bytes", p.url, path, limit)
+	}
+	return bz, nil
+}
@@ -0,0 +1,134 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	clientmocks "github.com/tendermint/tendermint/abci/client/mocks"
+	"github.com/tendermint/tendermint/abci/snapshots"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/proxy"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/statesync/mocks"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/version"
+)
+
+// serveSnapshots serves a snapshot store under the /chain prefix, and returns
+// the store and the URL of the snapshot provider.
+func serveSnapshots(t *testing.T) (*snapshots.Store, string) {
+	t.Helper()
+	store, err := snapshots.NewStore(t.TempDir(), snapshots.WithChunkSize(4))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle("/chain/", http.StripPrefix("/chain", store.Handler()))
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return store, srv.URL + "/chain/"
+}
+
+func TestSnapshotProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, url := serveSnapshots(t)
+	provider := newSnapshotProvider(url, 5*time.Second)
+	require.Equal(t, types.NodeID(url[:len(url)-1]), provider.ID())
+
+	list, err := provider.Snapshots(ctx)
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	_, err = store.Create(1, 1, []byte("meta"), bytes.NewReader([]byte("0123456789")))
+	require.NoError(t, err)
+	s2, err := store.Create(2, 1, nil, bytes.NewReader([]byte("abcdef")))
+	require.NoError(t, err)
+
+	list, err = provider.Snapshots(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, toABCI(list[0]), s2)
+	require.EqualValues(t, 1, list[1].Height)
+	require.EqualValues(t, 3, list[1].Chunks)
+
+	chunk, err := provider.Chunk(ctx, 1, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, []byte("89"), chunk)
+	require.NoError(t, snapshots.VerifyChunk(toABCI(list[1]), 2, chunk))
+
+	_, err = provider.Chunk(ctx, 1, 1, 3)
+	require.Error(t, err)
+	_, err = provider.Chunk(ctx, 3, 1, 0)
+	require.Error(t, err)
+
+	_, err = newSnapshotProvider(url+"missing", 5*time.Second).Snapshots(ctx)
+	require.Error(t, err)
+}
+
+func TestSyncer_SyncAny_snapshotProvider(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store, url := serveSnapshots(t)
+	s, err := store.Create(1, 1, nil, bytes.NewReader([]byte("0123456789")))
+	require.NoError(t, err)
+	provider := newSnapshotProvider(url, 5*time.Second)
+
+	state := sm.State{
+		ChainID: "chain",
+		Version: sm.Version{
+			Consensus: version.Consensus{
+				Block: version.BlockProtocol,
+				App:   testAppVersion,
+			},
+		},
+		LastBlockHeight: 1,
+		AppHash:         []byte("app_hash"),
+	}
+	commit := &types.Commit{BlockID: types.BlockID{Hash: []byte("blockhash")}}
+
+	stateProvider := &mocks.StateProvider{}
+	stateProvider.On("AppHash", mock.Anything, uint64(1)).Return(state.AppHash, nil)
+	stateProvider.On("Commit", mock.Anything, uint64(1)).Return(commit, nil)
+	stateProvider.On("State", mock.Anything, uint64(1)).Return(state, nil)
+
+	conn := &clientmocks.Client{}
+	conn.On("OfferSnapshot", mock.Anything, &abci.RequestOfferSnapshot{
+		Snapshot: s,
+		AppHash:  state.AppHash,
+	}).Once().Return(&abci.ResponseOfferSnapshot{Result: abci.ResponseOfferSnapshot_ACCEPT}, nil)
+	for i, chunk := range []string{"0123", "4567", "89"} {
+		conn.On("ApplySnapshotChunk", mock.Anything, &abci.RequestApplySnapshotChunk{
+			Index:  uint32(i),
+			Chunk:  []byte(chunk),
+			Sender: string(provider.ID()),
+		}).Once().Return(&abci.ResponseApplySnapshotChunk{Result: abci.ResponseApplySnapshotChunk_ACCEPT}, nil)
+	}
+	conn.On("Info", mock.Anything, &proxy.RequestInfo).Return(&abci.ResponseInfo{
+		AppVersion:       testAppVersion,
+		LastBlockHeight:  1,
+		LastBlockAppHash: state.AppHash,
+	}, nil)
+
+	rts := setup(ctx, t, conn, stateProvider, 2)
+	rts.syncer.providers = map[types.NodeID]*snapshotProvider{provider.ID(): provider}
+	// close the connections to the provider before checking for leaked goroutines
+	t.Cleanup(provider.client.CloseIdleConnections)
+
+	// The snapshot and its chunks are fetched from the provider only, with
+	// no peer.
+	newState, lastCommit, err := rts.syncer.SyncAny(ctx, 0, func() error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, state, newState)
+	require.Equal(t, commit, lastCommit)
+	require.Empty(t, rts.chunkOutCh)
+	conn.AssertExpectations(t)
+}
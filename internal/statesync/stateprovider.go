@@ -48,6 +48,8 @@ type stateProviderRPC struct {
 }
 
 // NewRPCStateProvider creates a new StateProvider using a light client and RPC clients.
+// If trustOptions has no height, a trusted height and hash are discovered from the
+// RPC servers, see discoverTrustOptions.
 func NewRPCStateProvider(
 	ctx context.Context,
 	chainID string,
@@ -74,6 +76,14 @@ func NewRPCStateProvider(
 		providerRemotes[provider] = server
 	}
 
+	if trustOptions.Height == 0 {
+		var err error
+		trustOptions, err = discoverTrustOptions(ctx, providers, trustOptions.Period, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	lc, err := light.NewClient(ctx, chainID, trustOptions, providers[0], providers[1:],
 		lightdb.New(dbm.NewMemDB()), light.Logger(logger))
 	if err != nil {
@@ -62,6 +62,9 @@ type syncer struct {
 	fetchers      int32
 	retryTimeout  time.Duration
 
+	// providers are the snapshot providers, by their ID in the snapshot pool.
+	providers map[types.NodeID]*snapshotProvider
+
 	mtx     sync.RWMutex
 	chunks  *chunkQueue
 	metrics *Metrics
@@ -84,6 +87,7 @@ func (s *syncer) AddChunk(chunk *chunk) (bool, error) {
 		return false, err
 	}
 	if added {
+		s.metrics.SnapshotChunkFetched.Set(float64(s.chunks.numChunksFetched()))
 		s.logger.Debug("Added chunk to queue", "height", chunk.Height, "format", chunk.Format, "chunk", chunk.Index)
 	} else {
 		s.logger.Debug("Ignoring duplicate chunk in queue", "height", chunk.Height, "format", chunk.Format, "chunk", chunk.Index)
@@ -123,8 +127,26 @@ func (s *syncer) RemovePeer(peerID types.NodeID) {
 	s.snapshots.RemovePeer(peerID)
 }
 
+// discoverProviderSnapshots adds the snapshots of the snapshot providers to the pool.
+func (s *syncer) discoverProviderSnapshots(ctx context.Context) {
+	for id, provider := range s.providers {
+		snapshots, err := provider.Snapshots(ctx)
+		if err != nil {
+			s.logger.Error("Failed to fetch snapshots from snapshot provider", "provider", id, "err", err)
+			continue
+		}
+		for _, snapshot := range snapshots {
+			if _, err := s.AddSnapshot(id, snapshot); err != nil {
+				s.logger.Error("Failed to add snapshot", "provider", id, "height", snapshot.Height,
+					"format", snapshot.Format, "err", err)
+			}
+		}
+	}
+}
+
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to discover further
-// snapshots if none were found and discoveryTime > 0. It returns the latest state and block commit
+// snapshots if none were found and discoveryTime > 0. The snapshots of the snapshot providers
+// are fetched before each attempt to pick one. It returns the latest state and block commit
 // which the caller must use to bootstrap the node.
 func (s *syncer) SyncAny(
 	ctx context.Context,
@@ -164,6 +186,7 @@ func (s *syncer) SyncAny(
 		iters++
 		// If not nil, we're going to retry restoration of the same snapshot.
 		if snapshot == nil {
+			s.discoverProviderSnapshots(ctx)
 			snapshot = s.snapshots.Best()
 			chunks = nil
 		}
@@ -187,20 +210,38 @@ func (s *syncer) SyncAny(
 			if err != nil {
 				return sm.State{}, nil, fmt.Errorf("failed to create chunk queue: %w", err)
 			}
-			defer chunks.Close() // in case we forget to close it elsewhere
+			// Unless the snapshot is discarded, keep its chunks on disk if the sync is
+			// interrupted, so that it resumes with them if retried after a restart.
+			defer chunks.Suspend()
+
+			if n := chunks.numChunksFetched(); n > 0 {
+				s.logger.Info("Resuming snapshot restoration with chunks fetched earlier",
+					"height", snapshot.Height, "format", snapshot.Format, "hash", snapshot.Hash,
+					"chunks", n, "total", snapshot.Chunks)
+			}
 		}
 
 		s.processingSnapshot = snapshot
 		s.metrics.SnapshotChunkTotal.Set(float64(snapshot.Chunks))
+		s.metrics.SnapshotChunkFetched.Set(float64(chunks.numChunksFetched()))
 
 		newState, commit, err := s.Sync(ctx, snapshot, chunks)
 		switch {
 		case err == nil:
 			s.metrics.SnapshotHeight.Set(float64(snapshot.Height))
 			s.lastSyncedSnapshotHeight = int64(snapshot.Height)
+			if err := chunks.Close(); err != nil {
+				s.logger.Error("Failed to clean up chunk queue", "err", err)
+			}
+			if err := removeStaleChunkDirs(s.tempDir); err != nil {
+				s.logger.Error("Failed to clean up chunks of interrupted state syncs", "err", err)
+			}
 			return newState, commit, nil
 
 		case errors.Is(err, errAbort):
+			if err := chunks.Close(); err != nil {
+				s.logger.Error("Failed to clean up chunk queue", "err", err)
+			}
 			return sm.State{}, nil, err
 
 		case errors.Is(err, errRetrySnapshot):
@@ -431,6 +472,7 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue, start time
 			s.metrics.SnapshotChunk.Add(1)
 			s.avgChunkTime = time.Since(start).Nanoseconds() / int64(chunks.numChunksReturned())
 			s.metrics.ChunkProcessAvgTime.Set(float64(s.avgChunkTime))
+			s.metrics.SnapshotRemainingSeconds.Set(s.remainingTime(chunks).Seconds())
 		case abci.ResponseApplySnapshotChunk_ABORT:
 			return errAbort
 		case abci.ResponseApplySnapshotChunk_RETRY:
@@ -445,6 +487,16 @@ func (s *syncer) applyChunks(ctx context.Context, chunks *chunkQueue, start time
 	}
 }
 
+// remainingTime estimates the time to restore the chunks of the queue not applied yet, from
+// the average time per chunk so far.
+func (s *syncer) remainingTime(chunks *chunkQueue) time.Duration {
+	remaining := int64(chunks.Size()) - int64(chunks.numChunksReturned())
+	if remaining < 0 {
+		remaining = 0
+	}
+	return time.Duration(s.avgChunkTime * remaining)
+}
+
 // fetchChunks requests chunks from peers, receiving allocations from the chunk queue. Chunks
 // will be received from the reactor via syncer.AddChunks() to chunkQueue.Add().
 func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *chunkQueue) {
@@ -497,7 +549,7 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 	}
 }
 
-// requestChunk requests a chunk from a peer.
+// requestChunk requests a chunk from a peer, or fetches it from a snapshot provider.
 //
 // returns nil if there are no peers for the given snapshot or the
 // request is successfully made and an error if the request cannot be
@@ -509,6 +561,10 @@ func (s *syncer) requestChunk(ctx context.Context, snapshot *snapshot, chunk uin
 			"format", snapshot.Format, "hash", snapshot.Hash)
 		return nil
 	}
+	if provider, ok := s.providers[peer]; ok {
+		s.fetchProviderChunk(ctx, provider, snapshot, chunk)
+		return nil
+	}
 
 	s.logger.Debug("Requesting snapshot chunk",
 		"height", snapshot.Height,
@@ -531,6 +587,35 @@ func (s *syncer) requestChunk(ctx context.Context, snapshot *snapshot, chunk uin
 	return nil
 }
 
+// fetchProviderChunk fetches a chunk from a snapshot provider and adds it to the chunk queue.
+// Failures are logged, and the chunk is requested again once the retry timeout expires.
+func (s *syncer) fetchProviderChunk(ctx context.Context, provider *snapshotProvider, snapshot *snapshot, index uint32) {
+	s.logger.Debug("Fetching snapshot chunk from snapshot provider",
+		"height", snapshot.Height,
+		"format", snapshot.Format,
+		"chunk", index,
+		"provider", provider.ID())
+
+	bz, err := provider.Chunk(ctx, snapshot.Height, snapshot.Format, index)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error("Failed to fetch snapshot chunk from snapshot provider", "height", snapshot.Height,
+				"format", snapshot.Format, "chunk", index, "provider", provider.ID(), "err", err)
+		}
+		return
+	}
+	if _, err := s.AddChunk(&chunk{
+		Height: snapshot.Height,
+		Format: snapshot.Format,
+		Index:  index,
+		Chunk:  bz,
+		Sender: provider.ID(),
+	}); err != nil {
+		s.logger.Error("Failed to add chunk", "height", snapshot.Height, "format", snapshot.Format,
+			"chunk", index, "provider", provider.ID(), "err", err)
+	}
+}
+
 // verifyApp verifies the sync, checking the app hash, last block height and app version
 func (s *syncer) verifyApp(ctx context.Context, snapshot *snapshot, appVersion uint64) error {
 	resp, err := s.conn.Info(ctx, &proxy.RequestInfo)
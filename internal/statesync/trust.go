@@ -0,0 +1,79 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/light"
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/types"
+)
+
+// discoverTrustOptions finds a recent trusted height and hash for the light
+// client, when none are configured. It fetches the latest height of each
+// provider, and the header at the lowest of these heights from every provider.
+// The header is trusted if a quorum of more than two thirds of the providers,
+// and at least two, return it, and no provider returns a different one.
+func discoverTrustOptions(
+	ctx context.Context,
+	providers []lightprovider.Provider,
+	period time.Duration,
+	logger log.Logger,
+) (light.TrustOptions, error) {
+	to := light.TrustOptions{Period: period}
+	quorum := len(providers)*2/3 + 1
+	if quorum < 2 {
+		quorum = 2
+	}
+
+	var height int64
+	var responses int
+	for _, p := range providers {
+		lb, err := p.LightBlock(ctx, 0)
+		if err != nil {
+			logger.Info("failed to fetch latest light block for trust options", "provider", p, "err", err)
+			continue
+		}
+		if height == 0 || lb.Height < height {
+			height = lb.Height
+		}
+		responses++
+	}
+	if responses < quorum {
+		return to, fmt.Errorf("only %d of %d RPC servers returned their latest block, need %d to discover trust options",
+			responses, len(providers), quorum)
+	}
+
+	var trusted *types.LightBlock
+	var agreed int
+	for _, p := range providers {
+		lb, err := p.LightBlock(ctx, height)
+		if err != nil {
+			logger.Info("failed to fetch light block for trust options", "provider", p, "height", height, "err", err)
+			continue
+		}
+		if trusted == nil {
+			trusted = lb
+		} else if !bytes.Equal(lb.Hash(), trusted.Hash()) {
+			return to, fmt.Errorf("RPC servers disagree on the hash of block %d: %X and %X",
+				height, trusted.Hash(), lb.Hash())
+		}
+		agreed++
+	}
+	if agreed < quorum {
+		return to, fmt.Errorf("only %d of %d RPC servers returned block %d, need %d to discover trust options",
+			agreed, len(providers), height, quorum)
+	}
+	if time.Since(trusted.Time) >= period {
+		return to, errors.New("latest block of the RPC servers is outside the trust period")
+	}
+
+	to.Height = height
+	to.Hash = trusted.Hash()
+	logger.Info("discovered trust options from RPC servers", "height", to.Height, "hash", to.Hash, "servers", agreed)
+	return to, nil
+}
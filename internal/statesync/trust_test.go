@@ -0,0 +1,80 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	lightprovider "github.com/tendermint/tendermint/light/provider"
+	providermocks "github.com/tendermint/tendermint/light/provider/mocks"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestDiscoverTrustOptions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vals, pv := factory.ValidatorSet(ctx, t, 3, 10)
+	now := time.Now()
+	_, _, lb10 := mockLB(ctx, t, 10, now, factory.MakeBlockID(), vals, pv)
+	_, _, lb11 := mockLB(ctx, t, 11, now, factory.MakeBlockID(), vals, pv)
+	_, _, forged := mockLB(ctx, t, 10, now, factory.MakeBlockID(), vals, pv)
+	_, _, old := mockLB(ctx, t, 10, now.Add(-2*time.Hour), factory.MakeBlockID(), vals, pv)
+
+	// newProvider returns a provider with the given latest block, serving the
+	// given block at height 10. A nil latest block makes the provider fail.
+	newProvider := func(latest, block *types.LightBlock) lightprovider.Provider {
+		p := &providermocks.Provider{}
+		if latest == nil {
+			p.On("LightBlock", mock.Anything, mock.Anything).Return(nil, lightprovider.ErrNoResponse)
+			return p
+		}
+		p.On("LightBlock", mock.Anything, int64(0)).Return(latest, nil)
+		p.On("LightBlock", mock.Anything, int64(10)).Return(block, nil)
+		return p
+	}
+
+	testcases := map[string]struct {
+		providers []lightprovider.Provider
+		expectErr bool
+	}{
+		"lowest latest height": {[]lightprovider.Provider{
+			newProvider(lb11, lb10), newProvider(lb10, lb10), newProvider(lb11, lb10),
+		}, false},
+		"quorum without one provider": {[]lightprovider.Provider{
+			newProvider(lb10, lb10), newProvider(lb10, lb10), newProvider(lb10, lb10), newProvider(nil, nil),
+		}, false},
+		"no quorum": {[]lightprovider.Provider{
+			newProvider(lb10, lb10), newProvider(lb10, lb10), newProvider(nil, nil),
+		}, true},
+		"single provider": {[]lightprovider.Provider{
+			newProvider(lb10, lb10), newProvider(nil, nil),
+		}, true},
+		"conflicting hash": {[]lightprovider.Provider{
+			newProvider(lb10, lb10), newProvider(lb10, lb10), newProvider(lb10, forged),
+		}, true},
+		"outside trust period": {[]lightprovider.Provider{
+			newProvider(old, old), newProvider(old, old),
+		}, true},
+	}
+	for desc, tc := range testcases {
+		tc := tc
+		t.Run(desc, func(t *testing.T) {
+			to, err := discoverTrustOptions(ctx, tc.providers, time.Hour, log.NewNopLogger())
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(10), to.Height)
+			require.Equal(t, []byte(lb10.Hash()), to.Hash)
+			require.Equal(t, time.Hour, to.Period)
+			require.NoError(t, to.ValidateBasic())
+		})
+	}
+}
@@ -0,0 +1,433 @@
+// Package archive reads and writes block archives, which hold a range of
+// consecutive blocks with their commits and validator sets, so that nodes can
+// be seeded with blocks without fetching them from peers.
+//
+// An archive is a directory with a manifest.json file, describing the archive,
+// and segment files holding the blocks. Each segment holds a range of
+// consecutive heights, as a sequence of length-delimited protobuf messages:
+// for each height the block, its commit, the validator set that signed it and
+// its extended commit, which is empty if it has none. The manifest records the
+// SHA-256 checksum of each segment, and is written last.
+//
+// Export writes an archive of the blocks of a block store, and the
+// import-blocks command replays the blocks of an archive on a node.
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/tendermint/tendermint/internal/libs/protoio"
+	sm "github.com/tendermint/tendermint/internal/state"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Version is the version of the archive format.
+const Version = 1
+
+// DefaultSegmentSize is the default number of blocks in each segment.
+const DefaultSegmentSize = 1000
+
+const manifestFile = "manifest.json"
+
+// maxMsgSize is the maximum size of a message in a segment.
+const maxMsgSize = types.MaxBlockSizeBytes + 1<<20
+
+// Manifest describes an archive.
+type Manifest struct {
+	Version     int       `json:"version"`
+	ChainID     string    `json:"chain_id"`
+	StartHeight int64     `json:"start_height,string"`
+	EndHeight   int64     `json:"end_height,string"`
+	Segments    []Segment `json:"segments"`
+}
+
+// Segment describes a segment file of an archive.
+type Segment struct {
+	File        string `json:"file"`
+	StartHeight int64  `json:"start_height,string"`
+	EndHeight   int64  `json:"end_height,string"`
+	SHA256      string `json:"sha256"`
+}
+
+// Entry is the content of an archive at a height.
+type Entry struct {
+	Block          *types.Block
+	Commit         *types.Commit
+	ValidatorSet   *types.ValidatorSet
+	ExtendedCommit *types.ExtendedCommit // nil if the block has none
+}
+
+// ValidateBasic checks that the parts of the entry are consistent with each
+// other. It does not verify the commit signatures.
+func (e *Entry) ValidateBasic() error {
+	if e.Block == nil || e.Commit == nil || e.ValidatorSet == nil {
+		return errors.New("incomplete entry")
+	}
+	if err := e.Block.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid block: %w", err)
+	}
+	if err := e.Commit.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+	if e.Commit.Height != e.Block.Height {
+		return fmt.Errorf("commit height %d does not match block height %d", e.Commit.Height, e.Block.Height)
+	}
+	if err := e.ValidatorSet.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid validator set: %w", err)
+	}
+	if !e.ValidatorSet.HasAddress(e.Block.ProposerAddress) || !bytes.Equal(e.ValidatorSet.Hash(), e.Block.ValidatorsHash) {
+		return errors.New("validator set does not match block")
+	}
+	if e.ExtendedCommit != nil && e.ExtendedCommit.Height != e.Block.Height {
+		return fmt.Errorf("extended commit height %d does not match block height %d", e.ExtendedCommit.Height, e.Block.Height)
+	}
+	return nil
+}
+
+// Writer writes an archive.
+type Writer struct {
+	dir         string
+	segmentSize int
+	manifest    Manifest
+
+	file    *os.File
+	buf     *bufio.Writer
+	hash    hash.Hash
+	segment Segment
+}
+
+// Option sets an optional parameter on a Writer.
+type Option func(*Writer)
+
+// WithSegmentSize sets the number of blocks in each segment.
+func WithSegmentSize(size int) Option {
+	return func(w *Writer) { w.segmentSize = size }
+}
+
+// Create creates an archive of blocks of the given chain in the given
+// directory, which must not exist or be empty.
+func Create(dir, chainID string, options ...Option) (*Writer, error) {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("archive directory %s is not empty", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating archive directory: %w", err)
+	}
+	w := &Writer{
+		dir:         dir,
+		segmentSize: DefaultSegmentSize,
+		manifest:    Manifest{Version: Version, ChainID: chainID},
+	}
+	for _, opt := range options {
+		opt(w)
+	}
+	if w.segmentSize <= 0 {
+		return nil, errors.New("segment size must be positive")
+	}
+	return w, nil
+}
+
+// Write appends the given entry to the archive. Entries must be written in
+// order of increasing, consecutive heights.
+func (w *Writer) Write(e *Entry) error {
+	if err := e.ValidateBasic(); err != nil {
+		return err
+	}
+	height := e.Block.Height
+	switch {
+	case e.Block.ChainID != w.manifest.ChainID:
+		return fmt.Errorf("block of chain %q in archive of chain %q", e.Block.ChainID, w.manifest.ChainID)
+	case w.manifest.EndHeight != 0 && height != w.manifest.EndHeight+1:
+		return fmt.Errorf("expected block at height %d, got %d", w.manifest.EndHeight+1, height)
+	}
+
+	if w.file == nil {
+		if err := w.openSegment(height); err != nil {
+			return err
+		}
+	}
+
+	pb, err := e.Block.ToProto()
+	if err != nil {
+		return err
+	}
+	vals, err := e.ValidatorSet.ToProto()
+	if err != nil {
+		return err
+	}
+	extCommit := &tmproto.ExtendedCommit{}
+	if e.ExtendedCommit != nil {
+		extCommit = e.ExtendedCommit.ToProto()
+	}
+	mw := protoio.NewDelimitedWriter(io.MultiWriter(w.buf, w.hash))
+	for _, msg := range []proto.Message{pb, e.Commit.ToProto(), vals, extCommit} {
+		if _, err := mw.WriteMsg(msg); err != nil {
+			return fmt.Errorf("writing block %d: %w", height, err)
+		}
+	}
+
+	if w.manifest.StartHeight == 0 {
+		w.manifest.StartHeight = height
+	}
+	w.manifest.EndHeight = height
+	w.segment.EndHeight = height
+	if height-w.segment.StartHeight+1 >= int64(w.segmentSize) {
+		return w.closeSegment()
+	}
+	return nil
+}
+
+func (w *Writer) openSegment(height int64) error {
+	name := fmt.Sprintf("%020d.blocks", height)
+	file, err := os.Create(filepath.Join(w.dir, name))
+	if err != nil {
+		return fmt.Errorf("creating segment: %w", err)
+	}
+	w.file, w.buf, w.hash = file, bufio.NewWriter(file), sha256.New()
+	w.segment = Segment{File: name, StartHeight: height, EndHeight: height}
+	return nil
+}
+
+func (w *Writer) closeSegment() error {
+	if err := w.buf.Flush(); err != nil {
+		return fmt.Errorf("writing segment: %w", err)
+	}
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("writing segment: %w", err)
+	}
+	w.segment.SHA256 = hex.EncodeToString(w.hash.Sum(nil))
+	w.manifest.Segments = append(w.manifest.Segments, w.segment)
+	w.file = nil
+	return nil
+}
+
+// Close completes the archive by writing its manifest. An archive without
+// entries is invalid.
+func (w *Writer) Close() error {
+	if w.file != nil {
+		if err := w.closeSegment(); err != nil {
+			return err
+		}
+	}
+	if len(w.manifest.Segments) == 0 {
+		return errors.New("archive is empty")
+	}
+	bz, err := json.MarshalIndent(w.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(w.dir, manifestFile), bz, 0644)
+}
+
+// Manifest returns the manifest of the archive written so far.
+func (w *Writer) Manifest() Manifest {
+	return w.manifest
+}
+
+// Export writes the blocks of the given block store from start to end
+// inclusive to a new archive in the given directory, with the validator sets
+// of the given state store, and returns the manifest of the archive.
+func Export(
+	dir string,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	start, end int64,
+	options ...Option,
+) (Manifest, error) {
+	if start < blockStore.Base() || end > blockStore.Height() || start > end {
+		return Manifest{}, fmt.Errorf("heights %d to %d are not in the block store, which has heights %d to %d",
+			start, end, blockStore.Base(), blockStore.Height())
+	}
+	state, err := stateStore.Load()
+	if err != nil {
+		return Manifest{}, err
+	}
+	w, err := Create(dir, state.ChainID, options...)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	for height := start; height <= end; height++ {
+		e := &Entry{
+			Block:          blockStore.LoadBlock(height),
+			ExtendedCommit: blockStore.LoadBlockExtendedCommit(height),
+		}
+		// The commit of the last block is only in the seen commit.
+		if height == blockStore.Height() {
+			if seen := blockStore.LoadSeenCommit(); seen != nil && seen.Height == height {
+				e.Commit = seen
+			}
+		} else {
+			e.Commit = blockStore.LoadBlockCommit(height)
+		}
+		if e.Block == nil || e.Commit == nil {
+			return Manifest{}, fmt.Errorf("block %d or its commit is missing from the block store", height)
+		}
+		if e.ValidatorSet, err = stateStore.LoadValidators(height); err != nil {
+			return Manifest{}, fmt.Errorf("loading validators of block %d: %w", height, err)
+		}
+		if err := w.Write(e); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return Manifest{}, err
+	}
+	return w.Manifest(), nil
+}
+
+// Reader reads the entries of an archive in order.
+type Reader struct {
+	dir      string
+	manifest Manifest
+
+	next   int // index of the next segment to open
+	file   *os.File
+	reader protoio.ReadCloser
+	height int64 // height of the next entry
+}
+
+// Open opens the archive in the given directory.
+func Open(dir string) (*Reader, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("reading archive manifest: %w", err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(bz, &m); err != nil {
+		return nil, fmt.Errorf("decoding archive manifest: %w", err)
+	}
+	if m.Version != Version {
+		return nil, fmt.Errorf("unsupported archive version %d", m.Version)
+	}
+	if len(m.Segments) == 0 || m.StartHeight <= 0 || m.EndHeight < m.StartHeight {
+		return nil, errors.New("invalid archive manifest")
+	}
+	height := m.StartHeight
+	for _, s := range m.Segments {
+		if s.StartHeight != height || s.EndHeight < s.StartHeight || filepath.Base(s.File) != s.File {
+			return nil, fmt.Errorf("invalid segment %q in archive manifest", s.File)
+		}
+		height = s.EndHeight + 1
+	}
+	if height != m.EndHeight+1 {
+		return nil, errors.New("archive segments do not cover its heights")
+	}
+	return &Reader{dir: dir, manifest: m, height: m.StartHeight}, nil
+}
+
+// Manifest returns the manifest of the archive.
+func (r *Reader) Manifest() Manifest {
+	return r.manifest
+}
+
+// Next returns the entry at the next height. It returns io.EOF after the last
+// entry. The checksum of each segment is verified before its first entry is
+// returned.
+func (r *Reader) Next() (*Entry, error) {
+	if r.height > r.manifest.EndHeight {
+		return nil, io.EOF
+	}
+	if r.file == nil {
+		if err := r.openSegment(); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		pb        tmproto.Block
+		commit    tmproto.Commit
+		vals      tmproto.ValidatorSet
+		extCommit tmproto.ExtendedCommit
+	)
+	for _, msg := range []proto.Message{&pb, &commit, &vals, &extCommit} {
+		if _, err := r.reader.ReadMsg(msg); err != nil {
+			return nil, fmt.Errorf("reading block %d: %w", r.height, err)
+		}
+	}
+
+	e := &Entry{}
+	var err error
+	if e.Block, err = types.BlockFromProto(&pb); err != nil {
+		return nil, fmt.Errorf("decoding block %d: %w", r.height, err)
+	}
+	if e.Commit, err = types.CommitFromProto(&commit); err != nil {
+		return nil, fmt.Errorf("decoding commit %d: %w", r.height, err)
+	}
+	if e.ValidatorSet, err = types.ValidatorSetFromProto(&vals); err != nil {
+		return nil, fmt.Errorf("decoding validator set %d: %w", r.height, err)
+	}
+	if extCommit.Height != 0 {
+		if e.ExtendedCommit, err = types.ExtendedCommitFromProto(&extCommit); err != nil {
+			return nil, fmt.Errorf("decoding extended commit %d: %w", r.height, err)
+		}
+	}
+	if e.Block.Height != r.height || e.Block.ChainID != r.manifest.ChainID {
+		return nil, fmt.Errorf("unexpected block %d of chain %q in archive", e.Block.Height, e.Block.ChainID)
+	}
+	if err := e.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("block %d: %w", r.height, err)
+	}
+
+	if r.height == r.manifest.Segments[r.next-1].EndHeight {
+		if err := r.closeSegment(); err != nil {
+			return nil, err
+		}
+	}
+	r.height++
+	return e, nil
+}
+
+func (r *Reader) openSegment() error {
+	s := r.manifest.Segments[r.next]
+	path := filepath.Join(r.dir, s.File)
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening segment: %w", err)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		file.Close()
+		return fmt.Errorf("reading segment: %w", err)
+	}
+	if sum := hex.EncodeToString(h.Sum(nil)); sum != s.SHA256 {
+		file.Close()
+		return fmt.Errorf("checksum mismatch for segment %s: expected %s, got %s", s.File, s.SHA256, sum)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+	r.file = file
+	r.reader = protoio.NewDelimitedReader(bufio.NewReader(file), maxMsgSize)
+	r.next++
+	return nil
+}
+
+func (r *Reader) closeSegment() error {
+	err := r.file.Close()
+	r.file, r.reader = nil, nil
+	return err
+}
+
+// Close closes the archive.
+func (r *Reader) Close() error {
+	if r.file != nil {
+		return r.closeSegment()
+	}
+	return nil
+}
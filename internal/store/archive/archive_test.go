@@ -0,0 +1,153 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/config"
+	sm "github.com/tendermint/tendermint/internal/state"
+	smmocks "github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/types"
+)
+
+// makeEntries returns the entries of a chain of n blocks.
+func makeEntries(ctx context.Context, t *testing.T, n int) (string, []*Entry) {
+	t.Helper()
+
+	cfg, err := config.ResetTestRoot(t.TempDir(), "archive_test")
+	require.NoError(t, err)
+	valSet, privVals := factory.ValidatorSet(ctx, t, 1, 10)
+	genDoc := factory.GenesisDoc(cfg, time.Now(), valSet.Validators, factory.ConsensusParams())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	entries := make([]*Entry, n)
+	lastCommit := &types.Commit{}
+	for i := range entries {
+		height := int64(i + 1)
+		block := state.MakeBlock(height, factory.MakeNTxs(height, 2), lastCommit, nil, valSet.Proposer.Address)
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()}
+		vote, err := factory.MakeVote(ctx, privVals[0], genDoc.ChainID, 0, height, 0, 2, blockID, time.Now())
+		require.NoError(t, err)
+		commit := &types.Commit{
+			Height:     height,
+			BlockID:    blockID,
+			Signatures: []types.CommitSig{vote.CommitSig()},
+		}
+		entries[i] = &Entry{Block: block, Commit: commit, ValidatorSet: valSet}
+
+		state.LastBlockHeight = height
+		state.LastBlockID = blockID
+		lastCommit = commit
+	}
+	return genDoc.ChainID, entries
+}
+
+func TestArchive(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chainID, entries := makeEntries(ctx, t, 5)
+	dir := filepath.Join(t.TempDir(), "archive")
+
+	w, err := Create(dir, chainID, WithSegmentSize(2))
+	require.NoError(t, err)
+	require.NoError(t, w.Write(entries[0]))
+	require.Error(t, w.Write(entries[2]), "heights must be consecutive")
+	for _, e := range entries[1:] {
+		require.NoError(t, w.Write(e))
+	}
+	require.NoError(t, w.Close())
+
+	_, err = Create(dir, chainID)
+	require.Error(t, err, "the directory is not empty")
+
+	r, err := Open(dir)
+	require.NoError(t, err)
+	m := r.Manifest()
+	require.Equal(t, chainID, m.ChainID)
+	require.Equal(t, int64(1), m.StartHeight)
+	require.Equal(t, int64(5), m.EndHeight)
+	require.Len(t, m.Segments, 3)
+
+	for _, e := range entries {
+		got, err := r.Next()
+		require.NoError(t, err)
+		require.Equal(t, e.Block.Hash(), got.Block.Hash())
+		require.Equal(t, e.Commit.Hash(), got.Commit.Hash())
+		require.Equal(t, e.ValidatorSet.Hash(), got.ValidatorSet.Hash())
+		require.Nil(t, got.ExtendedCommit)
+	}
+	_, err = r.Next()
+	require.True(t, errors.Is(err, io.EOF))
+	require.NoError(t, r.Close())
+
+	// A corrupted segment fails its checksum before any of its entries is read.
+	path := filepath.Join(dir, m.Segments[1].File)
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	bz[len(bz)-1]++
+	require.NoError(t, os.WriteFile(path, bz, 0644))
+
+	r, err = Open(dir)
+	require.NoError(t, err)
+	defer r.Close()
+	for i := 0; i < 2; i++ {
+		_, err := r.Next()
+		require.NoError(t, err)
+	}
+	_, err = r.Next()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch")
+}
+
+func TestExport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chainID, entries := makeEntries(ctx, t, 6)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	for _, e := range entries {
+		parts, err := e.Block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockStore.SaveBlock(e.Block, parts, e.Commit)
+	}
+	stateStore := &smmocks.Store{}
+	stateStore.On("Load").Return(sm.State{ChainID: chainID}, nil)
+	stateStore.On("LoadValidators", mock.Anything).Return(entries[0].ValidatorSet, nil)
+
+	_, err := Export(filepath.Join(t.TempDir(), "archive"), blockStore, stateStore, 5, 7)
+	require.Error(t, err, "height 7 is not in the block store")
+
+	dir := filepath.Join(t.TempDir(), "archive")
+	m, err := Export(dir, blockStore, stateStore, 2, 6, WithSegmentSize(4))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), m.StartHeight)
+	require.Equal(t, int64(6), m.EndHeight)
+	require.Len(t, m.Segments, 2)
+
+	r, err := Open(dir)
+	require.NoError(t, err)
+	defer r.Close()
+	for _, e := range entries[1:] {
+		got, err := r.Next()
+		require.NoError(t, err)
+		require.Equal(t, e.Block.Hash(), got.Block.Hash())
+		require.Equal(t, e.Commit.Hash(), got.Commit.Hash())
+	}
+	_, err = r.Next()
+	require.True(t, errors.Is(err, io.EOF))
+}
@@ -0,0 +1,65 @@
+// Package compact reclaims the disk space of the keys deleted from the
+// databases of the node, e.g. by pruning. LevelDB and RocksDB only release
+// the space of deleted keys when the files holding them are compacted, which
+// may never happen on its own for keys that are not overwritten.
+package compact
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/syndtr/goleveldb/leveldb/util"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// ErrNotSupported is returned for databases whose backend can't be compacted.
+var ErrNotSupported = errors.New("compaction is not supported by the database backend")
+
+// compactor compacts the keys of a database in [start, end), if it has the
+// backend of the compactor.
+type compactor interface {
+	supports(db dbm.DB) bool
+	compact(db dbm.DB, start, end []byte) error
+}
+
+// compactors are the compactors of the supported backends. Backends that
+// require build tags add theirs in init.
+var compactors = []compactor{goLevelDBCompactor{}}
+
+// Supported reports whether db can be compacted.
+func Supported(db dbm.DB) bool {
+	for _, c := range compactors {
+		if c.supports(db) {
+			return true
+		}
+	}
+	return false
+}
+
+// Range compacts the keys of db in [start, end). A nil start or end extends
+// the range to the first or last key of db, so Range(db, nil, nil) compacts
+// the whole database. It returns ErrNotSupported if the backend of db can't
+// be compacted.
+func Range(db dbm.DB, start, end []byte) error {
+	for _, c := range compactors {
+		if !c.supports(db) {
+			continue
+		}
+		if err := c.compact(db, start, end); err != nil {
+			return fmt.Errorf("compacting database: %w", err)
+		}
+		return nil
+	}
+	return ErrNotSupported
+}
+
+type goLevelDBCompactor struct{}
+
+func (goLevelDBCompactor) supports(db dbm.DB) bool {
+	_, ok := db.(*dbm.GoLevelDB)
+	return ok
+}
+
+func (goLevelDBCompactor) compact(db dbm.DB, start, end []byte) error {
+	return db.(*dbm.GoLevelDB).DB().CompactRange(util.Range{Start: start, Limit: end})
+}
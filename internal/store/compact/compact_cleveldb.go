@@ -0,0 +1,23 @@
+//go:build cleveldb
+// +build cleveldb
+
+package compact
+
+import (
+	"github.com/jmhodges/levigo"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func init() { compactors = append(compactors, cLevelDBCompactor{}) }
+
+type cLevelDBCompactor struct{}
+
+func (cLevelDBCompactor) supports(db dbm.DB) bool {
+	_, ok := db.(*dbm.CLevelDB)
+	return ok
+}
+
+func (cLevelDBCompactor) compact(db dbm.DB, start, end []byte) error {
+	db.(*dbm.CLevelDB).DB().CompactRange(levigo.Range{Start: start, Limit: end})
+	return nil
+}
@@ -0,0 +1,23 @@
+//go:build rocksdb
+// +build rocksdb
+
+package compact
+
+import (
+	"github.com/tecbot/gorocksdb"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func init() { compactors = append(compactors, rocksDBCompactor{}) }
+
+type rocksDBCompactor struct{}
+
+func (rocksDBCompactor) supports(db dbm.DB) bool {
+	_, ok := db.(*dbm.RocksDB)
+	return ok
+}
+
+func (rocksDBCompactor) compact(db dbm.DB, start, end []byte) error {
+	db.(*dbm.RocksDB).DB().CompactRange(gorocksdb.Range{Start: start, Limit: end})
+	return nil
+}
@@ -0,0 +1,41 @@
+package compact_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/store/compact"
+)
+
+func TestRange(t *testing.T) {
+	db, err := dbm.NewGoLevelDB("test", t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	require.True(t, compact.Supported(db))
+
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("key%04d", i)), make([]byte, 1024)))
+	}
+	for i := 0; i < 500; i++ {
+		require.NoError(t, db.Delete([]byte(fmt.Sprintf("key%04d", i))))
+	}
+
+	require.NoError(t, compact.Range(db, []byte("key0000"), []byte("key0500")))
+	require.NoError(t, compact.Range(db, nil, nil))
+
+	// compaction keeps the remaining keys
+	for i := 0; i < 1000; i++ {
+		has, err := db.Has([]byte(fmt.Sprintf("key%04d", i)))
+		require.NoError(t, err)
+		require.Equal(t, i >= 500, has, i)
+	}
+}
+
+func TestRangeNotSupported(t *testing.T) {
+	db := dbm.NewMemDB()
+	require.False(t, compact.Supported(db))
+	require.ErrorIs(t, compact.Range(db, nil, nil), compact.ErrNotSupported)
+}
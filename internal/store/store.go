@@ -377,6 +377,55 @@ func (bs *BlockStore) PruneBlocks(height int64) (uint64, error) {
 	return pruned, nil
 }
 
+// DeleteLatestBlock removes the block at the latest height, and the commit of
+// the previous height it carries, which becomes the seen commit. It is used to
+// roll back the blockstore along with the state.
+func (bs *BlockStore) DeleteLatestBlock() error {
+	height := bs.Height()
+	if height == 0 {
+		return errors.New("no block to delete")
+	}
+	if height == bs.Base() {
+		return fmt.Errorf("cannot delete the only block, at height %d", height)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+
+	// delete the block meta too if the block is partially stored, so that
+	// the height does not point to it anymore
+	meta := bs.LoadBlockMeta(height)
+	if meta != nil {
+		if err := batch.Delete(blockHashKey(meta.BlockID.Hash)); err != nil {
+			return err
+		}
+		for i := 0; i < int(meta.BlockID.PartSetHeader.Total); i++ {
+			if err := batch.Delete(blockPartKey(height, i)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := batch.Delete(blockMetaKey(height)); err != nil {
+		return err
+	}
+	if err := batch.Delete(extCommitKey(height)); err != nil {
+		return err
+	}
+
+	// the commit of the previous height is only stored as the last commit of
+	// the deleted block from now on
+	if commit := bs.LoadBlockCommit(height - 1); commit != nil {
+		if err := batch.Set(seenCommitKey(), mustEncode(commit.ToProto())); err != nil {
+			return err
+		}
+		if err := batch.Delete(blockCommitKey(height - 1)); err != nil {
+			return err
+		}
+	}
+
+	return batch.WriteSync()
+}
+
 // pruneRange is a generic function for deleting a range of values based on the lowest
 // height up to but excluding retainHeight. For each key/value pair, an optional hook can be
 // executed before the deletion itself is made. pruneRange will use batch delete to delete
@@ -600,6 +649,32 @@ func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) err
 	return bs.db.Set(seenCommitKey(), seenCommitBytes)
 }
 
+// SaveExtendedCommit saves the extended commit of the given height, and its commit as the seen
+// commit, used e.g. when forking a chain in place into a testnet.
+func (bs *BlockStore) SaveExtendedCommit(height int64, extCommit *types.ExtendedCommit) error {
+	if err := extCommit.EnsureExtensions(); err != nil {
+		return err
+	}
+	extCommitBytes, err := proto.Marshal(extCommit.ToProto())
+	if err != nil {
+		return fmt.Errorf("unable to marshal extended commit: %w", err)
+	}
+	seenCommitBytes, err := proto.Marshal(extCommit.ToCommit().ToProto())
+	if err != nil {
+		return fmt.Errorf("unable to marshal commit: %w", err)
+	}
+
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(extCommitKey(height), extCommitBytes); err != nil {
+		return err
+	}
+	if err := batch.Set(seenCommitKey(), seenCommitBytes); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
 func (bs *BlockStore) SaveSignedHeader(sh *types.SignedHeader, blockID types.BlockID) error {
 	// first check that the block store doesn't already have the block
 	bz, err := bs.db.Get(blockMetaKey(sh.Height))
@@ -650,3 +650,39 @@ func newBlock(hdr types.Header, lastCommit *types.Commit) *types.Block {
 		LastCommit: lastCommit,
 	}
 }
+
+func TestDeleteLatestBlock(t *testing.T) {
+	state, bs, cleanup, err := makeStateAndBlockStore(t.TempDir())
+	defer cleanup()
+	require.NoError(t, err)
+
+	require.Error(t, bs.DeleteLatestBlock(), "no block to delete")
+
+	blocks := make(map[int64]*types.Block)
+	for h := int64(1); h <= 3; h++ {
+		blockCommit := makeTestExtCommit(h-1, tmtime.Now()).ToCommit()
+		block := factory.MakeBlock(state, h, blockCommit)
+		partSet, err := block.MakePartSet(2)
+		require.NoError(t, err)
+		bs.SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(h, tmtime.Now()))
+		blocks[h] = block
+	}
+
+	require.NoError(t, bs.DeleteLatestBlock())
+	require.EqualValues(t, 2, bs.Height())
+	require.Nil(t, bs.LoadBlock(3))
+	require.Nil(t, bs.LoadBlockMeta(3))
+	require.Nil(t, bs.LoadBlockByHash(blocks[3].Hash()))
+	require.Nil(t, bs.LoadBlockPart(3, 0))
+	require.Nil(t, bs.LoadBlockExtendedCommit(3))
+	require.NotNil(t, bs.LoadBlock(2))
+
+	// the last commit of the deleted block is the seen commit of the new
+	// latest height
+	require.Nil(t, bs.LoadBlockCommit(2))
+	require.Equal(t, blocks[3].LastCommit.Hash(), bs.LoadSeenCommit().Hash())
+
+	require.NoError(t, bs.DeleteLatestBlock())
+	require.EqualValues(t, 1, bs.Height())
+	require.Error(t, bs.DeleteLatestBlock(), "the only block cannot be deleted")
+}
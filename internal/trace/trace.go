@@ -0,0 +1,289 @@
+// Package trace records spans of the work of the node, such as consensus
+// steps, ABCI calls and RPC requests, and exports them to an OpenTelemetry
+// collector, in the OTLP/HTTP JSON encoding.
+//
+// The spans of a height, started without a parent span but with a "height"
+// attribute, share a trace derived from the chain ID and the height, so the
+// traces of all the nodes of a chain for a height are correlated.
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	// spanQueueSize is the number of ended spans waiting to be exported,
+	// beyond which spans are dropped.
+	spanQueueSize = 2048
+
+	// maxBatchSize is the maximum number of spans exported in a request.
+	maxBatchSize = 512
+
+	// flushInterval is the interval of the exports of the ended spans.
+	flushInterval = 5 * time.Second
+
+	// exportTimeout is the deadline of an export request.
+	exportTimeout = 10 * time.Second
+)
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value interface{} // a string, an int64 or a bool
+}
+
+// String returns a string attribute.
+func String(key, value string) Attribute { return Attribute{Key: key, Value: value} }
+
+// Int64 returns an integer attribute.
+func Int64(key string, value int64) Attribute { return Attribute{Key: key, Value: value} }
+
+// Bool returns a boolean attribute.
+func Bool(key string, value bool) Attribute { return Attribute{Key: key, Value: value} }
+
+// Tracer starts spans and exports them once ended. A nil or nop tracer starts
+// no span. It is safe for concurrent use.
+type Tracer struct {
+	logger     log.Logger
+	endpoint   string
+	sampleRate float64
+	chainID    string
+	resource   []Attribute
+	client     *http.Client
+
+	spans   chan *Span // ended spans to export, nil for a nop tracer
+	dropped uint64     // number of spans dropped since the last export
+}
+
+// NopTracer returns a tracer which starts no span.
+func NopTracer() *Tracer {
+	return &Tracer{}
+}
+
+// NewTracer returns a tracer exporting the sampleRate fraction of the traces
+// to the OTLP/HTTP collector at endpoint, such as
+// "http://localhost:4318/v1/traces", once Run is called. The traces of heights
+// are derived from chainID, and the resource attributes describe the node.
+func NewTracer(logger log.Logger, endpoint string, sampleRate float64, chainID string, resource ...Attribute) *Tracer {
+	return &Tracer{
+		logger:     logger,
+		endpoint:   endpoint,
+		sampleRate: sampleRate,
+		chainID:    chainID,
+		resource:   resource,
+		client:     &http.Client{Timeout: exportTimeout},
+		spans:      make(chan *Span, spanQueueSize),
+	}
+}
+
+func (t *Tracer) enabled() bool { return t != nil && t.spans != nil }
+
+// Start starts a span with the given name and attributes, as a child of the
+// span of ctx if any, and returns it with a context holding it. The span must
+// be ended with End. If the tracer is nop, Start returns ctx and a nil span,
+// whose methods do nothing.
+func (t *Tracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	if !t.enabled() {
+		return ctx, nil
+	}
+
+	span := &Span{
+		tracer: t,
+		name:   name,
+		spanID: newSpanID(),
+		start:  time.Now(),
+		attrs:  attrs,
+	}
+	if parent := SpanFromContext(ctx); parent != nil {
+		span.traceID, span.parentID, span.sampled = parent.traceID, parent.spanID, parent.sampled
+	} else {
+		span.traceID = t.newTraceID(attrs)
+		span.sampled = t.sample(span.traceID)
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+// newTraceID returns the trace of a span without a parent: the trace of its
+// height if it has a "height" attribute, or a new trace otherwise.
+func (t *Tracer) newTraceID(attrs []Attribute) [16]byte {
+	var id [16]byte
+	for _, attr := range attrs {
+		if height, ok := attr.Value.(int64); ok && attr.Key == "height" {
+			var buf [8]byte
+			binary.BigEndian.PutUint64(buf[:], uint64(height))
+			sum := sha256.Sum256(append([]byte(t.chainID+"/"), buf[:]...))
+			copy(id[:], sum[:])
+			return id
+		}
+	}
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// sample reports whether the spans of the given trace are exported. It only
+// depends on the trace, so that all the nodes sample the same traces of
+// heights.
+func (t *Tracer) sample(traceID [16]byte) bool {
+	switch {
+	case t.sampleRate >= 1:
+		return true
+	case t.sampleRate <= 0:
+		return false
+	}
+	return float64(binary.BigEndian.Uint64(traceID[8:])) < t.sampleRate*math.MaxUint64
+}
+
+func newSpanID() [8]byte {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// Run exports the ended spans until ctx is done, and then exports the
+// remaining ones. It returns immediately for a nop tracer.
+func (t *Tracer) Run(ctx context.Context) {
+	if !t.enabled() {
+		return
+	}
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, maxBatchSize)
+	flush := func(ctx context.Context) {
+		if dropped := atomic.SwapUint64(&t.dropped, 0); dropped > 0 {
+			t.logger.Error("dropped trace spans, the collector is too slow", "spans", dropped)
+		}
+		if len(batch) == 0 {
+			return
+		}
+		if err := t.export(ctx, batch); err != nil {
+			t.logger.Error("failed to export trace spans", "spans", len(batch), "err", err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			fctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+			defer cancel()
+			for {
+				select {
+				case span := <-t.spans:
+					batch = append(batch, span)
+					if len(batch) == maxBatchSize {
+						flush(fctx)
+					}
+				default:
+					flush(fctx)
+					return
+				}
+			}
+		case span := <-t.spans:
+			batch = append(batch, span)
+			if len(batch) == maxBatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		}
+	}
+}
+
+// Span is the record of a unit of work. The methods of a nil span do
+// nothing. They are safe for concurrent use.
+type Span struct {
+	tracer   *Tracer
+	name     string
+	traceID  [16]byte
+	spanID   [8]byte
+	parentID [8]byte // zero for a span without parent
+	sampled  bool
+	start    time.Time
+
+	mtx   sync.Mutex
+	attrs []Attribute
+	err   error
+	end   time.Time // zero until the span is ended
+}
+
+// SetAttributes adds attributes to the span.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.attrs = append(s.attrs, attrs...)
+}
+
+// SetError marks the span as failed with err, if it is not nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.err = err
+}
+
+// End ends the span, which is exported if its trace is sampled. Subsequent
+// calls do nothing.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	if !s.end.IsZero() {
+		s.mtx.Unlock()
+		return
+	}
+	s.end = time.Now()
+	s.mtx.Unlock()
+
+	if !s.sampled {
+		return
+	}
+	select {
+	case s.tracer.spans <- s:
+	default:
+		atomic.AddUint64(&s.tracer.dropped, 1)
+	}
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a context holding span, as the parent of the spans
+// started with it. It returns ctx if span is nil.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span held by ctx, or nil.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// ContextWithoutSpan returns a context holding no span, so that the spans
+// started with it have no parent.
+func ContextWithoutSpan(ctx context.Context) context.Context {
+	if SpanFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanKey{}, (*Span)(nil))
+}
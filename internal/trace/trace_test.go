@@ -0,0 +1,154 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// collector is an OTLP/HTTP collector recording the spans it receives.
+type collector struct {
+	mtx   sync.Mutex
+	spans []otlpSpan
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req otlpRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+}
+
+// runTracer runs a tracer exporting to a new collector, and returns a function
+// stopping it and returning the spans received.
+func runTracer(t *testing.T, sampleRate float64) (*Tracer, func() []otlpSpan) {
+	t.Helper()
+	c := &collector{}
+	srv := httptest.NewServer(c)
+	t.Cleanup(srv.Close)
+
+	tracer := NewTracer(log.NewNopLogger(), srv.URL, sampleRate, "test-chain", String("service.name", "tendermint"))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tracer.Run(ctx)
+	}()
+	return tracer, func() []otlpSpan {
+		cancel()
+		<-done
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+		return c.spans
+	}
+}
+
+func TestTracerExport(t *testing.T) {
+	tracer, stop := runTracer(t, 1)
+
+	ctx, parent := tracer.Start(context.Background(), "consensus.commit", Int64("height", 5), Int64("round", 0))
+	_, child := tracer.Start(ctx, "abci.finalize_block")
+	child.SetAttributes(Bool("retried", false))
+	child.SetError(errors.New("app failed"))
+	child.End()
+	parent.End()
+	parent.End() // ending twice exports once
+
+	spans := stop()
+	require.Len(t, spans, 2)
+	childSpan, parentSpan := spans[0], spans[1]
+
+	require.Equal(t, "consensus.commit", parentSpan.Name)
+	require.Empty(t, parentSpan.ParentSpanID)
+	require.Nil(t, parentSpan.Status)
+	require.Len(t, parentSpan.TraceID, 32)
+	require.Len(t, parentSpan.SpanID, 16)
+	require.Equal(t, "height", parentSpan.Attributes[0].Key)
+	require.Equal(t, "5", *parentSpan.Attributes[0].Value.IntValue)
+
+	require.Equal(t, "abci.finalize_block", childSpan.Name)
+	require.Equal(t, parentSpan.TraceID, childSpan.TraceID)
+	require.Equal(t, parentSpan.SpanID, childSpan.ParentSpanID)
+	require.False(t, *childSpan.Attributes[0].Value.BoolValue)
+	require.Equal(t, otlpStatusCodeError, childSpan.Status.Code)
+	require.Equal(t, "app failed", childSpan.Status.Message)
+	require.LessOrEqual(t, parentSpan.StartTimeUnixNano, childSpan.StartTimeUnixNano)
+}
+
+func TestTracerHeightTraces(t *testing.T) {
+	a := NewTracer(log.NewNopLogger(), "http://localhost", 1, "test-chain")
+	b := NewTracer(log.NewNopLogger(), "http://localhost", 1, "test-chain")
+	other := NewTracer(log.NewNopLogger(), "http://localhost", 1, "other-chain")
+
+	_, spanA := a.Start(context.Background(), "consensus.step", Int64("height", 7))
+	_, spanB := b.Start(context.Background(), "consensus.prevote", Int64("height", 7), Int64("round", 1))
+	_, next := a.Start(context.Background(), "consensus.step", Int64("height", 8))
+	_, otherChain := other.Start(context.Background(), "consensus.step", Int64("height", 7))
+	_, noHeight := a.Start(context.Background(), "rpc.status")
+
+	require.Equal(t, spanA.traceID, spanB.traceID)
+	require.NotEqual(t, spanA.spanID, spanB.spanID)
+	require.NotEqual(t, spanA.traceID, next.traceID)
+	require.NotEqual(t, spanA.traceID, otherChain.traceID)
+	require.NotEqual(t, spanA.traceID, noHeight.traceID)
+}
+
+func TestTracerSampling(t *testing.T) {
+	tracer, stop := runTracer(t, 0)
+	ctx, span := tracer.Start(context.Background(), "consensus.step", Int64("height", 1))
+	_, child := tracer.Start(ctx, "abci.commit")
+	child.End()
+	span.End()
+	require.Empty(t, stop())
+
+	half := NewTracer(log.NewNopLogger(), "http://localhost", 0.5, "test-chain")
+	sampled := 0
+	for height := int64(1); height <= 1000; height++ {
+		if half.sample(half.newTraceID([]Attribute{Int64("height", height)})) {
+			sampled++
+		}
+	}
+	require.InDelta(t, 500, sampled, 100)
+}
+
+func TestNopTracer(t *testing.T) {
+	for _, tracer := range []*Tracer{nil, NopTracer()} {
+		ctx := context.Background()
+		sctx, span := tracer.Start(ctx, "consensus.step", Int64("height", 1))
+		require.Equal(t, ctx, sctx)
+		require.Nil(t, span)
+		require.Nil(t, SpanFromContext(sctx))
+
+		// the methods of a nil span do nothing
+		span.SetAttributes(String("step", "propose"))
+		span.SetError(errors.New("error"))
+		span.End()
+		tracer.Run(ctx)
+	}
+}
+
+func TestContextWithoutSpan(t *testing.T) {
+	tracer := NewTracer(log.NewNopLogger(), "http://localhost", 1, "test-chain")
+	ctx, parent := tracer.Start(context.Background(), "consensus.propose", Int64("height", 2))
+
+	_, span := tracer.Start(ContextWithoutSpan(ctx), "consensus.prevote", Int64("height", 2))
+	require.Equal(t, [8]byte{}, span.parentID)
+	require.Equal(t, parent.traceID, span.traceID)
+	require.Nil(t, SpanFromContext(ContextWithoutSpan(ctx)))
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -14,6 +15,27 @@ var _ Logger = (*defaultLogger)(nil)
 
 type defaultLogger struct {
 	zerolog.Logger
+
+	// module is the value of the last module key given to With, if any.
+	module string
+
+	// levels holds the *levels of the messages logged, shared with the
+	// loggers derived with With so that SetLevel applies to all of them. It
+	// is nil if the level is only the one of the zerolog logger.
+	levels *atomic.Value
+}
+
+// Option sets an option of a logger created by NewDefaultLogger.
+type Option func(*loggerOptions)
+
+type loggerOptions struct {
+	output io.Writer
+}
+
+// WithOutput sets the writer the messages are logged to, such as a
+// RotatingFile, instead of stderr.
+func WithOutput(w io.Writer) Option {
+	return func(o *loggerOptions) { o.output = w }
 }
 
 // NewDefaultLogger returns a default logger that can be used within Tendermint
@@ -21,15 +43,27 @@ type defaultLogger struct {
 // zerolog logger that supports typical log levels along with JSON and plain/text
 // log formats.
 //
+// The level is either a level, such as "info", or a comma-separated list of
+// module:level pairs, such as "p2p:debug,consensus:info,*:error", setting the
+// levels of the loggers derived with the module key. The module "*" sets the
+// level of the other modules, which defaults to info.
+//
 // Since zerolog supports typed structured logging and it is difficult to reflect
 // that in a generic interface, all logging methods accept a series of key/value
-// pair tuples, where the key must be a string.
-func NewDefaultLogger(format, level string) (Logger, error) {
+// pair tuples, where the key must be a string. In the JSON format, each message
+// is an object with the "level", "time" and "message" fields, along with the
+// key/value pairs.
+func NewDefaultLogger(format, level string, options ...Option) (Logger, error) {
+	opts := loggerOptions{output: os.Stderr}
+	for _, option := range options {
+		option(&opts)
+	}
+
 	var logWriter io.Writer
 	switch strings.ToLower(format) {
 	case LogFormatPlain, LogFormatText:
 		logWriter = zerolog.ConsoleWriter{
-			Out:        os.Stderr,
+			Out:        opts.output,
 			NoColor:    true,
 			TimeFormat: time.RFC3339,
 			FormatLevel: func(i interface{}) string {
@@ -41,49 +75,77 @@ func NewDefaultLogger(format, level string) (Logger, error) {
 		}
 
 	case LogFormatJSON:
-		logWriter = os.Stderr
+		logWriter = opts.output
 
 	default:
 		return nil, fmt.Errorf("unsupported log format: %s", format)
 	}
 
-	logLevel, err := zerolog.ParseLevel(level)
+	lv, err := parseLevels(level)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse log level (%s): %w", level, err)
+		return nil, err
 	}
 
 	// make the writer thread-safe
 	logWriter = newSyncWriter(logWriter)
 
-	return &defaultLogger{Logger: zerolog.New(logWriter).Level(logLevel).With().Timestamp().Logger()}, nil
+	levels := new(atomic.Value)
+	levels.Store(lv)
+	return &defaultLogger{
+		Logger: zerolog.New(logWriter).With().Timestamp().Logger(),
+		levels: levels,
+	}, nil
+}
+
+// enabled reports whether messages of the given level are logged.
+func (l defaultLogger) enabled(level zerolog.Level) bool {
+	return l.levels == nil || l.levels.Load().(*levels).of(l.module) <= level
 }
 
 func (l defaultLogger) Info(msg string, keyVals ...interface{}) {
-	l.Logger.Info().Fields(keyVals).Msg(msg)
+	if l.enabled(zerolog.InfoLevel) {
+		l.Logger.Info().Fields(keyVals).Msg(msg)
+	}
 }
 
 func (l defaultLogger) Error(msg string, keyVals ...interface{}) {
-	l.Logger.Error().Fields(keyVals).Msg(msg)
+	if l.enabled(zerolog.ErrorLevel) {
+		l.Logger.Error().Fields(keyVals).Msg(msg)
+	}
 }
 
 func (l defaultLogger) Debug(msg string, keyVals ...interface{}) {
-	l.Logger.Debug().Fields(keyVals).Msg(msg)
+	if l.enabled(zerolog.DebugLevel) {
+		l.Logger.Debug().Fields(keyVals).Msg(msg)
+	}
 }
 
 func (l defaultLogger) With(keyVals ...interface{}) Logger {
-	return &defaultLogger{Logger: l.Logger.With().Fields(keyVals).Logger()}
+	module := l.module
+	for i := 0; i+1 < len(keyVals); i += 2 {
+		if key, ok := keyVals[i].(string); ok && key == "module" {
+			if m, ok := keyVals[i+1].(string); ok {
+				module = m
+			}
+		}
+	}
+	return &defaultLogger{
+		Logger: l.Logger.With().Fields(keyVals).Logger(),
+		module: module,
+		levels: l.levels,
+	}
 }
 
 // OverrideWithNewLogger replaces an existing logger's internal with
 // a new logger, and makes it possible to reconfigure an existing
 // logger that has already been propagated to callers.
-func OverrideWithNewLogger(logger Logger, format, level string) error {
+func OverrideWithNewLogger(logger Logger, format, level string, options ...Option) error {
 	ol, ok := logger.(*defaultLogger)
 	if !ok {
 		return fmt.Errorf("logger %T cannot be overridden", logger)
 	}
 
-	newLogger, err := NewDefaultLogger(format, level)
+	newLogger, err := NewDefaultLogger(format, level, options...)
 	if err != nil {
 		return err
 	}
@@ -93,5 +155,27 @@ func OverrideWithNewLogger(logger Logger, format, level string) error {
 	}
 
 	ol.Logger = nl.Logger
+	if ol.levels != nil {
+		ol.levels.Store(nl.levels.Load())
+	} else {
+		ol.levels = nl.levels
+	}
+	return nil
+}
+
+// SetLevel changes the level of an existing logger, and of the loggers
+// derived from it, without replacing it. The level is parsed as by
+// NewDefaultLogger.
+func SetLevel(logger Logger, level string) error {
+	l, ok := logger.(*defaultLogger)
+	if !ok || l.levels == nil {
+		return fmt.Errorf("the level of logger %T cannot be changed", logger)
+	}
+
+	lv, err := parseLevels(level)
+	if err != nil {
+		return err
+	}
+	l.levels.Store(lv)
 	return nil
 }
@@ -1,6 +1,9 @@
 package log_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -44,3 +47,76 @@ func TestNewDefaultLogger(t *testing.T) {
 		})
 	}
 }
+
+func TestSetLevel(t *testing.T) {
+	logger, err := log.NewDefaultLogger(log.LogFormatJSON, log.LogLevelInfo)
+	require.NoError(t, err)
+	derived := logger.With("module", "test")
+
+	require.NoError(t, log.SetLevel(logger, log.LogLevelDebug))
+	require.NoError(t, log.SetLevel(derived, log.LogLevelError))
+	require.Error(t, log.SetLevel(logger, "foo"))
+
+	require.Error(t, log.SetLevel(log.NewNopLogger(), log.LogLevelDebug))
+}
+
+func TestDefaultLoggerModuleLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := log.NewDefaultLogger(log.LogFormatJSON, "p2p:debug,consensus:error,*:info", log.WithOutput(&buf))
+	require.NoError(t, err)
+
+	logged := func() []string {
+		var msgs []string
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var entry map[string]interface{}
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			msgs = append(msgs, entry["message"].(string))
+		}
+		buf.Reset()
+		return msgs
+	}
+
+	p2p := logger.With("module", "p2p")
+	consensus := logger.With("module", "consensus", "height", 1)
+	p2p.Debug("p2p debug")
+	consensus.Info("consensus info")
+	consensus.Error("consensus error")
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.With("module", "mempool").Info("mempool info")
+	require.Equal(t, []string{"p2p debug", "consensus error", "info", "mempool info"}, logged())
+
+	// The levels of the derived loggers change with the one of their parent.
+	require.NoError(t, log.SetLevel(logger, "consensus:debug"))
+	p2p.Debug("p2p debug")
+	consensus.Debug("consensus debug")
+	require.Equal(t, []string{"consensus debug"}, logged())
+}
+
+func TestDefaultLoggerJSONFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := log.NewDefaultLogger(log.LogFormatJSON, log.LogLevelInfo, log.WithOutput(&buf))
+	require.NoError(t, err)
+	logger.With("module", "p2p").Info("hello", "peer", "abc", "height", 5)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "info", entry["level"])
+	require.Equal(t, "hello", entry["message"])
+	require.Equal(t, "p2p", entry["module"])
+	require.Equal(t, "abc", entry["peer"])
+	require.EqualValues(t, 5, entry["height"])
+	require.Contains(t, entry, "time")
+}
+
+func TestValidateLevel(t *testing.T) {
+	for _, level := range []string{"info", "debug", "p2p:debug,*:error", "consensus:info"} {
+		require.NoError(t, log.ValidateLevel(level), level)
+	}
+	for _, level := range []string{"foo", "p2p:foo", "p2p", "p2p:debug,p2p:info", "p2p:debug,", ":debug"} {
+		require.Error(t, log.ValidateLevel(level), level)
+	}
+}
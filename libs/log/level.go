@@ -0,0 +1,70 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// levels are the minimum levels of the messages logged by each module, as
+// set by a log level such as "p2p:debug,consensus:info,*:error".
+type levels struct {
+	// fallback is the level of the modules not listed, and of the messages
+	// of no module.
+	fallback zerolog.Level
+	modules  map[string]zerolog.Level
+}
+
+// parseLevels parses a log level, which is either a level, such as "info",
+// or a comma-separated list of module:level pairs, where the module "*" sets
+// the level of the modules not listed, which defaults to info.
+func parseLevels(level string) (*levels, error) {
+	if !strings.Contains(level, ":") {
+		logLevel, err := zerolog.ParseLevel(level)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log level (%s): %w", level, err)
+		}
+		return &levels{fallback: logLevel}, nil
+	}
+
+	lv := &levels{fallback: zerolog.InfoLevel, modules: make(map[string]zerolog.Level)}
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(level, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid module log level %q, expected module:level", item)
+		}
+		module, name := parts[0], parts[1]
+		if seen[module] {
+			return nil, fmt.Errorf("duplicate log level of module %q", module)
+		}
+		seen[module] = true
+
+		logLevel, err := zerolog.ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse log level (%s) of module %q: %w", name, module, err)
+		}
+		if module == "*" {
+			lv.fallback = logLevel
+		} else {
+			lv.modules[module] = logLevel
+		}
+	}
+	return lv, nil
+}
+
+// ValidateLevel returns an error if level is not a valid log level, as
+// accepted by NewDefaultLogger.
+func ValidateLevel(level string) error {
+	_, err := parseLevels(level)
+	return err
+}
+
+// of returns the minimum level of the messages of the given module.
+func (lv *levels) of(module string) zerolog.Level {
+	if logLevel, ok := lv.modules[module]; ok {
+		return logLevel
+	}
+	return lv.fallback
+}
@@ -0,0 +1,170 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// rotatedFileTimeFormat is the format of the time suffix of rotated files,
+// which sorts them by time.
+const rotatedFileTimeFormat = "20060102T150405.000000000"
+
+// RotatingFile is a log file which is rotated once it reaches a size or an
+// age, renaming it with the time of the rotation as a suffix and opening a
+// new one. It is safe for concurrent use.
+type RotatingFile struct {
+	path     string
+	maxBytes int64
+	maxAge   time.Duration
+	maxFiles int
+	now      func() time.Time
+
+	mtx    sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// OpenRotatingFile opens the log file at path for appending, creating it if
+// needed. The file is rotated before it exceeds maxBytes, or once it was open
+// for maxAge; 0 disables either rotation. Only the maxFiles most recently
+// rotated files are kept, or all of them if maxFiles is 0.
+func OpenRotatingFile(path string, maxBytes int64, maxAge time.Duration, maxFiles int) (*RotatingFile, error) {
+	if maxBytes < 0 || maxAge < 0 || maxFiles < 0 {
+		return nil, errors.New("the log file rotation limits can't be negative")
+	}
+	f := &RotatingFile{
+		path:     path,
+		maxBytes: maxBytes,
+		maxAge:   maxAge,
+		maxFiles: maxFiles,
+		now:      time.Now,
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the log file. The caller must hold f.mtx, unless f is not shared
+// yet.
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	f.file = file
+	f.size = info.Size()
+	f.opened = f.now()
+	return nil
+}
+
+// Write writes p to the log file, rotating it first if p would take it over
+// its maximum size, or if it is too old.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	tooBig := f.maxBytes > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxBytes
+	tooOld := f.maxAge > 0 && f.now().Sub(f.opened) >= f.maxAge
+	var rotateErr error
+	if tooBig || tooOld {
+		if rotateErr = f.rotate(); rotateErr != nil && f.file == nil {
+			return 0, rotateErr
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// rotate renames the log file with the current time as a suffix, opens a new
+// one and removes the oldest rotated files beyond maxFiles. If the log file
+// can't be renamed, it is reopened, so that the logs are still written to it,
+// and the error is reported. The caller must hold f.mtx.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return err
+	}
+	f.file = nil
+	rotated := f.path + "." + f.now().UTC().Format(rotatedFileTimeFormat)
+	if err := os.Rename(f.path, rotated); err != nil {
+		if openErr := f.open(); openErr != nil {
+			return fmt.Errorf("failed to rotate the log file: %v, and to reopen it: %w", err, openErr)
+		}
+		return fmt.Errorf("failed to rotate the log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	return f.prune()
+}
+
+// prune removes the oldest rotated files beyond maxFiles.
+func (f *RotatingFile) prune() error {
+	if f.maxFiles == 0 {
+		return nil
+	}
+	rotated, err := f.Rotated()
+	if err != nil {
+		return err
+	}
+	for len(rotated) > f.maxFiles {
+		if err := os.Remove(rotated[0]); err != nil {
+			return err
+		}
+		rotated = rotated[1:]
+	}
+	return nil
+}
+
+// Rotated returns the paths of the rotated log files, from the oldest to the
+// most recent.
+func (f *RotatingFile) Rotated() ([]string, error) {
+	matches, err := filepath.Glob(f.path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	var rotated []string
+	for _, match := range matches {
+		suffix := match[len(f.path)+1:]
+		if _, err := time.Parse(rotatedFileTimeFormat, suffix); err == nil {
+			rotated = append(rotated, match)
+		}
+	}
+	sort.Strings(rotated)
+	return rotated, nil
+}
+
+// Close closes the log file. Subsequent writes fail.
+func (f *RotatingFile) Close() error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
@@ -0,0 +1,104 @@
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "tendermint.log")
+	f, err := OpenRotatingFile(path, 10, time.Hour, 2)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	f.now = func() time.Time { return now }
+	f.opened = now
+
+	write := func(s string) {
+		t.Helper()
+		n, err := f.Write([]byte(s))
+		require.NoError(t, err)
+		require.Equal(t, len(s), n)
+	}
+	read := func(path string) string {
+		t.Helper()
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	// The file is rotated before it exceeds its maximum size.
+	write("12345")
+	write("67890")
+	write("abc")
+	rotated, err := f.Rotated()
+	require.NoError(t, err)
+	require.Len(t, rotated, 1)
+	require.Equal(t, "1234567890", read(rotated[0]))
+	require.Equal(t, "abc", read(path))
+
+	// The file is rotated once it is too old, keeping the 2 last rotated files.
+	for i := 0; i < 3; i++ {
+		now = now.Add(time.Hour)
+		write("def")
+	}
+	rotated, err = f.Rotated()
+	require.NoError(t, err)
+	require.Len(t, rotated, 2)
+	require.Equal(t, "def", read(rotated[0]))
+	require.Equal(t, "def", read(rotated[1]))
+	require.Equal(t, "def", read(path))
+
+	// Writes to a closed file fail.
+	require.NoError(t, f.Close())
+	_, err = f.Write([]byte("ghi"))
+	require.Error(t, err)
+
+	// Reopening appends to the file.
+	f, err = OpenRotatingFile(path, 0, 0, 0)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("ghi"))
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.Equal(t, "defghi", read(path))
+
+	_, err = OpenRotatingFile(path, -1, 0, 0)
+	require.Error(t, err)
+}
+
+func TestRotatingFileRenameFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tendermint.log")
+	f, err := OpenRotatingFile(path, 5, 0, 0)
+	require.NoError(t, err)
+	defer f.Close()
+	now := time.Unix(1000, 0)
+	f.now = func() time.Time { return now }
+
+	// A non-empty directory at the path of the rotated file fails the rename.
+	rotated := path + "." + now.UTC().Format(rotatedFileTimeFormat)
+	require.NoError(t, os.MkdirAll(filepath.Join(rotated, "dir"), 0700))
+
+	_, err = f.Write([]byte("12345"))
+	require.NoError(t, err)
+	n, err := f.Write([]byte("678"))
+	require.Error(t, err)
+	require.Equal(t, 3, n)
+
+	// The log file was reopened, and the writes go on.
+	_, err = f.Write([]byte("9"))
+	require.Error(t, err)
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "123456789", string(b))
+
+	// Once the rotation succeeds, the errors stop.
+	now = now.Add(time.Second)
+	_, err = f.Write([]byte("abc"))
+	require.NoError(t, err)
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(b))
+}
@@ -20,8 +20,9 @@ import (
 // It takes the target verified header and compares it with the headers of a set of
 // witness providers that the light client is connected to. If a conflicting header
 // is returned it verifies and examines the conflicting header against the verified
-// trace that was produced from the primary. If successful, it produces two sets of evidence
-// and sends them to the opposite provider before halting.
+// trace that was produced from the primary. If successful, it produces two sets of evidence,
+// sends the evidence against the primary to all witnesses and the evidence against the witness
+// to the primary, and halts.
 //
 // If there are no conflictinge headers, the light client deems the verified target header
 // trusted and saves it to the trusted store.
@@ -202,11 +203,14 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 	errc <- nil
 }
 
-// sendEvidence sends evidence to a provider on a best effort basis.
-func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, receiver provider.Provider) {
-	err := receiver.ReportEvidence(ctx, ev)
-	if err != nil {
-		c.logger.Error("failed to report evidence to provider", "ev", ev, "provider", receiver)
+// sendEvidence sends evidence to each of the receivers on a best effort basis.
+// The receivers are full nodes which add the evidence to their evidence pools
+// and gossip it to the rest of the network.
+func (c *Client) sendEvidence(ctx context.Context, ev *types.LightClientAttackEvidence, receivers ...provider.Provider) {
+	for _, receiver := range receivers {
+		if err := receiver.ReportEvidence(ctx, ev); err != nil {
+			c.logger.Error("failed to report evidence to provider", "ev", ev, "provider", receiver, "err", err)
+		}
 	}
 }
 
@@ -233,14 +237,15 @@ func (c *Client) handleConflictingHeaders(
 	}
 
 	// We are suspecting that the primary is faulty, hence we hold the witness as the source of truth
-	// and generate evidence against the primary that we can send to the witness
+	// and generate evidence against the primary that we can send to the witnesses
 	commonBlock, trustedBlock := witnessTrace[0], witnessTrace[len(witnessTrace)-1]
 	evidenceAgainstPrimary := newLightClientAttackEvidence(primaryBlock, trustedBlock, commonBlock)
-	c.logger.Error("ATTEMPTED ATTACK DETECTED. Sending evidence againt primary by witness", "ev", evidenceAgainstPrimary,
-		"primary", c.primary, "witness", supportingWitness)
-	c.sendEvidence(ctx, evidenceAgainstPrimary, supportingWitness)
+	attack := attackType(evidenceAgainstPrimary, trustedBlock)
+	c.logger.Error("ATTEMPTED ATTACK DETECTED. Sending evidence againt primary to witnesses", "ev", evidenceAgainstPrimary,
+		"attack", attack, "primary", c.primary, "witness", supportingWitness)
+	c.sendEvidence(ctx, evidenceAgainstPrimary, c.witnesses...)
 
-	if primaryBlock.Commit.Round != witnessTrace[len(witnessTrace)-1].Commit.Round {
+	if attack == attackAmnesia {
 		c.logger.Info("The light client has detected, and prevented, an attempted amnesia attack." +
 			" We think this attack is pretty unlikely, so if you see it, that's interesting to us." +
 			" Can you let us know by opening an issue through https://github.com/tendermint/tendermint/issues/new?")
@@ -265,7 +270,7 @@ func (c *Client) handleConflictingHeaders(
 	commonBlock, trustedBlock = primaryTrace[0], primaryTrace[len(primaryTrace)-1]
 	evidenceAgainstWitness := newLightClientAttackEvidence(witnessBlock, trustedBlock, commonBlock)
 	c.logger.Error("Sending evidence against witness by primary", "ev", evidenceAgainstWitness,
-		"primary", c.primary, "witness", supportingWitness)
+		"attack", attackType(evidenceAgainstWitness, trustedBlock), "primary", c.primary, "witness", supportingWitness)
 	c.sendEvidence(ctx, evidenceAgainstWitness, c.primary)
 	// We return the error and don't process anymore witnesses
 	return ErrLightClientAttack
@@ -421,3 +426,23 @@ func newLightClientAttackEvidence(conflicted, trusted, common *types.LightBlock)
 	ev.ByzantineValidators = ev.GetByzantineValidators(common.ValidatorSet, trusted.SignedHeader)
 	return ev
 }
+
+// The forms of light client attacks, see types.LightClientAttackEvidence.
+const (
+	attackLunatic      = "lunatic"
+	attackEquivocation = "equivocation"
+	attackAmnesia      = "amnesia"
+)
+
+// attackType returns the form of the attack of the evidence against the
+// trusted block at the height of its conflicting block.
+func attackType(ev *types.LightClientAttackEvidence, trusted *types.LightBlock) string {
+	switch {
+	case ev.ConflictingHeaderIsInvalid(trusted.Header):
+		return attackLunatic
+	case ev.ConflictingBlock.Commit.Round != trusted.Commit.Round:
+		return attackAmnesia
+	default:
+		return attackEquivocation
+	}
+}
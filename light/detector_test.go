@@ -3,6 +3,7 @@ package light_test
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -109,6 +110,86 @@ func TestLightClientAttackEvidence_Lunatic(t *testing.T) {
 	mockPrimary.AssertExpectations(t)
 }
 
+func TestLightClientAttackEvidence_SentToAllWitnesses(t *testing.T) {
+	logger := log.NewNopLogger()
+
+	// primary performs a lunatic attack against a light client with two honest witnesses
+	var (
+		latestHeight      = int64(3)
+		valSize           = 5
+		divergenceHeight  = int64(2)
+		primaryHeaders    = make(map[int64]*types.SignedHeader, latestHeight)
+		primaryValidators = make(map[int64]*types.ValidatorSet, latestHeight)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	witnessHeaders, witnessValidators, chainKeys := genLightBlocksWithKeys(t, latestHeight, valSize, 2, bTime)
+
+	forgedKeys := chainKeys[divergenceHeight-1].ChangeKeys(3)
+	forgedVals := forgedKeys.ToValidators(2, 0)
+
+	for height := int64(1); height <= latestHeight; height++ {
+		if height < divergenceHeight {
+			primaryHeaders[height] = witnessHeaders[height]
+			primaryValidators[height] = witnessValidators[height]
+			continue
+		}
+		primaryHeaders[height] = forgedKeys.GenSignedHeader(t, chainID, height, bTime.Add(time.Duration(height)*time.Minute),
+			nil, forgedVals, forgedVals, hash("app_hash"), hash("cons_hash"), hash("results_hash"), 0, len(forgedKeys))
+		primaryValidators[height] = forgedVals
+	}
+
+	evAgainstPrimary := &types.LightClientAttackEvidence{
+		ConflictingBlock: &types.LightBlock{
+			SignedHeader: primaryHeaders[latestHeight],
+			ValidatorSet: primaryValidators[latestHeight],
+		},
+		CommonHeight: 1,
+	}
+	isEvAgainstPrimary := mock.MatchedBy(func(evidence types.Evidence) bool {
+		return bytes.Equal(evidence.Hash(), evAgainstPrimary.Hash())
+	})
+
+	// only one of the witnesses supports the detection of the attack, but both
+	// receive the evidence against the primary
+	witnesses := make([]*provider_mocks.Provider, 2)
+	for i := range witnesses {
+		witnesses[i] = mockNodeFromHeadersAndVals(witnessHeaders, witnessValidators)
+		witnesses[i].On("ID").Return(fmt.Sprintf("mockWitness%d", i))
+		witnesses[i].On("ReportEvidence", mock.Anything, isEvAgainstPrimary).Return(nil)
+	}
+
+	mockPrimary := mockNodeFromHeadersAndVals(primaryHeaders, primaryValidators)
+	mockPrimary.On("ID").Return("mockPrimary")
+	mockPrimary.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		light.TrustOptions{
+			Period: 4 * time.Hour,
+			Height: 1,
+			Hash:   primaryHeaders[1].Hash(),
+		},
+		mockPrimary,
+		[]provider.Provider{witnesses[0], witnesses[1]},
+		dbs.New(dbm.NewMemDB()),
+		light.Logger(logger),
+	)
+	require.NoError(t, err)
+
+	_, err = c.VerifyLightBlockAtHeight(ctx, latestHeight, bTime.Add(1*time.Hour))
+	if assert.Error(t, err) {
+		assert.Equal(t, light.ErrLightClientAttack, err)
+	}
+
+	for _, witness := range witnesses {
+		witness.AssertCalled(t, "ReportEvidence", mock.Anything, isEvAgainstPrimary)
+	}
+}
+
 func TestLightClientAttackEvidence_Equivocation(t *testing.T) {
 	cases := []struct {
 		name                      string
@@ -313,6 +394,8 @@ func TestLightClientAttackEvidence_ForwardLunatic(t *testing.T) {
 	lastBlock, _ = accomplice.LightBlock(ctx, forgedHeight)
 	accomplice.On("LightBlock", mock.Anything, int64(0)).Return(lastBlock, nil)
 	accomplice.On("LightBlock", mock.Anything, mock.Anything).Return(nil, provider.ErrLightBlockNotFound)
+	// the evidence against the primary is sent to every witness, the accomplice included
+	accomplice.On("ReportEvidence", mock.Anything, mock.Anything).Return(nil)
 
 	c, err := light.NewClient(
 		ctx,
@@ -15,7 +15,9 @@ type proxyService struct {
 	Client *lrpc.Client
 }
 
-func (p proxyService) ABCIInfo(ctx context.Context) (*coretypes.ResultABCIInfo, error) { panic("ok") }
+func (p proxyService) ABCIInfo(ctx context.Context) (*coretypes.ResultABCIInfo, error) {
+	return p.Client.ABCIInfo(ctx)
+}
 
 func (p proxyService) ABCIQuery(ctx context.Context, req *coretypes.RequestABCIQuery) (*coretypes.ResultABCIQuery, error) {
 	return p.Client.ABCIQueryWithOptions(ctx, req.Path, req.Data, rpcclient.ABCIQueryOptions{
@@ -32,12 +34,12 @@ func (p proxyService) BlockByHash(ctx context.Context, req *coretypes.RequestBlo
 	return p.Client.BlockByHash(ctx, req.Hash)
 }
 
-func (p proxyService) BlockResults(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultBlockResults, error) {
-	return p.Client.BlockResults(ctx, (*int64)(req.Height))
+func (p proxyService) BlockResults(ctx context.Context, req *coretypes.RequestBlockResults) (*coretypes.ResultBlockResults, error) {
+	return p.Client.BlockResultsWithArgs(ctx, req)
 }
 
 func (p proxyService) BlockSearch(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error) {
-	return p.Client.BlockSearch(ctx, req.Query, req.Page.IntPtr(), req.PerPage.IntPtr(), req.OrderBy)
+	return p.Client.BlockSearchWithArgs(ctx, req)
 }
 
 func (p proxyService) BlockchainInfo(ctx context.Context, req *coretypes.RequestBlockchainInfo) (*coretypes.ResultBlockchainInfo, error) {
@@ -68,6 +70,10 @@ func (p proxyService) CheckTx(ctx context.Context, req *coretypes.RequestCheckTx
 	return p.Client.CheckTx(ctx, req.Tx)
 }
 
+func (p proxyService) SimulateTx(ctx context.Context, req *coretypes.RequestSimulateTx) (*coretypes.ResultSimulateTx, error) {
+	return p.Client.SimulateTx(ctx, req.Tx)
+}
+
 func (p proxyService) Commit(ctx context.Context, req *coretypes.RequestBlockInfo) (*coretypes.ResultCommit, error) {
 	return p.Client.Commit(ctx, (*int64)(req.Height))
 }
@@ -76,6 +82,10 @@ func (p proxyService) ConsensusParams(ctx context.Context, req *coretypes.Reques
 	return p.Client.ConsensusParams(ctx, (*int64)(req.Height))
 }
 
+func (p proxyService) ConsensusParamsHistory(ctx context.Context, req *coretypes.RequestConsensusParamsHistory) (*coretypes.ResultConsensusParamsHistory, error) {
+	return p.Client.ConsensusParamsHistory(ctx, (*int64)(req.From), (*int64)(req.To))
+}
+
 func (p proxyService) DumpConsensusState(ctx context.Context) (*coretypes.ResultDumpConsensusState, error) {
 	return p.Client.DumpConsensusState(ctx)
 }
@@ -84,6 +94,10 @@ func (p proxyService) Events(ctx context.Context, req *coretypes.RequestEvents)
 	return p.Client.Events(ctx, req)
 }
 
+func (p proxyService) Evidence(ctx context.Context, req *coretypes.RequestEvidence) (*coretypes.ResultEvidence, error) {
+	return p.Client.Evidence(ctx, req.Page.IntPtr(), req.PerPage.IntPtr())
+}
+
 func (p proxyService) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
 	return p.Client.Genesis(ctx)
 }
@@ -112,12 +126,28 @@ func (p proxyService) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, er
 	return p.Client.NetInfo(ctx)
 }
 
+func (p proxyService) AddressBook(ctx context.Context) (*coretypes.ResultAddressBook, error) {
+	return p.Client.AddressBook(ctx)
+}
+
+func (p proxyService) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	return p.Client.NetTopology(ctx)
+}
+
 func (p proxyService) NumUnconfirmedTxs(ctx context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
 	return p.Client.NumUnconfirmedTxs(ctx)
 }
 
-func (p proxyService) RemoveTx(ctx context.Context, req *coretypes.RequestRemoveTx) error {
-	return p.Client.RemoveTx(ctx, req.TxKey)
+func (p proxyService) Ready(ctx context.Context) (*coretypes.ResultReady, error) {
+	return p.Client.Ready(ctx)
+}
+
+func (p proxyService) SigningState(ctx context.Context) (*coretypes.ResultSigningState, error) {
+	return p.Client.SigningState(ctx)
+}
+
+func (p proxyService) StorageHealth(ctx context.Context) (*coretypes.ResultStorageHealth, error) {
+	return p.Client.StorageHealth(ctx)
 }
 
 func (p proxyService) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
@@ -132,8 +162,12 @@ func (p proxyService) Tx(ctx context.Context, req *coretypes.RequestTx) (*corety
 	return p.Client.Tx(ctx, req.Hash, req.Prove)
 }
 
+func (p proxyService) TxStatus(ctx context.Context, req *coretypes.RequestTxStatus) (*coretypes.ResultTxStatus, error) {
+	return p.Client.TxStatus(ctx, req.Hash)
+}
+
 func (p proxyService) TxSearch(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error) {
-	return p.Client.TxSearch(ctx, req.Query, req.Prove, req.Page.IntPtr(), req.PerPage.IntPtr(), req.OrderBy)
+	return p.Client.TxSearchWithArgs(ctx, req)
 }
 
 func (p proxyService) UnconfirmedTxs(ctx context.Context, req *coretypes.RequestUnconfirmedTxs) (*coretypes.ResultUnconfirmedTxs, error) {
@@ -148,6 +182,10 @@ func (p proxyService) UnsubscribeAll(ctx context.Context) (*coretypes.ResultUnsu
 	return p.Client.UnsubscribeAllWS(ctx)
 }
 
+func (p proxyService) ValidatorHistory(ctx context.Context, req *coretypes.RequestValidatorHistory) (*coretypes.ResultValidatorHistory, error) {
+	return p.Client.ValidatorHistory(ctx, req.Address, (*int64)(req.From), (*int64)(req.To))
+}
+
 func (p proxyService) Validators(ctx context.Context, req *coretypes.RequestValidators) (*coretypes.ResultValidators, error) {
 	return p.Client.Validators(ctx, (*int64)(req.Height), req.Page.IntPtr(), req.PerPage.IntPtr())
 }
@@ -23,7 +23,7 @@ import (
 )
 
 // KeyPathFunc builds a merkle path out of the given path and key.
-type KeyPathFunc func(path string, key []byte) (merkle.KeyPath, error)
+type KeyPathFunc = rpcclient.KeyPathFunc
 
 // LightClient is an interface that contains functionality needed by Client from the light client.
 //go:generate ../../scripts/mockery_generate.sh LightClient
@@ -169,17 +169,15 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 
 	// Validate the response.
 	if resp.IsErr() {
-		return nil, fmt.Errorf("err response code: %v", resp.Code)
-	}
-	if len(resp.Key) == 0 {
-		return nil, errors.New("empty key")
-	}
-	if resp.ProofOps == nil || len(resp.ProofOps.Ops) == 0 {
-		return nil, errors.New("no proof ops")
+		return nil, rpcclient.ErrQueryFailed{Code: resp.Code, Log: resp.Log}
 	}
 	if resp.Height <= 0 {
 		return nil, coretypes.ErrZeroOrNegativeHeight
 	}
+	// build a Merkle key path from path and resp.Key
+	if c.keyPathFn == nil {
+		return nil, errors.New("please configure Client with KeyPathFn option")
+	}
 
 	// Update the light client if we're behind.
 	// NOTE: AppHash for height H is in header H+1.
@@ -189,29 +187,9 @@ func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data tmb
 		return nil, err
 	}
 
-	// Validate the value proof against the trusted header.
-
-	// build a Merkle key path from path and resp.Key
-	if c.keyPathFn == nil {
-		return nil, errors.New("please configure Client with KeyPathFn option")
-	}
-
-	kp, err := c.keyPathFn(path, resp.Key)
-	if err != nil {
-		return nil, fmt.Errorf("can't build merkle key path: %w", err)
-	}
-
-	// verify value
-	if resp.Value != nil {
-		err = c.prt.VerifyValue(resp.ProofOps, l.AppHash, kp.String(), resp.Value)
-		if err != nil {
-			return nil, fmt.Errorf("verify value proof: %w", err)
-		}
-	} else { // OR validate the absence proof against the trusted header.
-		err = c.prt.VerifyAbsence(resp.ProofOps, l.AppHash, kp.String())
-		if err != nil {
-			return nil, fmt.Errorf("verify absence proof: %w", err)
-		}
+	// Validate the value proof, or the absence proof, against the trusted header.
+	if err := rpcclient.VerifyABCIQuery(c.prt, path, resp, c.keyPathFn, l.AppHash); err != nil {
+		return nil, err
 	}
 
 	return &coretypes.ResultABCIQuery{Response: resp}, nil
@@ -245,10 +223,20 @@ func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (*coretypes.ResultChe
 	return c.next.CheckTx(ctx, tx)
 }
 
+// SimulateTx calls the primary. The simulation is not verified, as it is not
+// part of the chain.
+func (c *Client) SimulateTx(ctx context.Context, tx types.Tx) (*coretypes.ResultSimulateTx, error) {
+	return c.next.SimulateTx(ctx, tx)
+}
+
 func (c *Client) RemoveTx(ctx context.Context, txKey types.TxKey) error {
 	return c.next.RemoveTx(ctx, txKey)
 }
 
+func (c *Client) TxStatus(ctx context.Context, hash tmbytes.HexBytes) (*coretypes.ResultTxStatus, error) {
+	return c.next.TxStatus(ctx, hash)
+}
+
 func (c *Client) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	return c.next.NetInfo(ctx)
 }
@@ -290,6 +278,36 @@ func (c *Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes
 	return res, nil
 }
 
+// ConsensusParamsHistory verifies each change of the params against the
+// consensus hash of the header at its height. That there are no other changes
+// between them is not verified, since the headers in between are not fetched.
+func (c *Client) ConsensusParamsHistory(ctx context.Context, from, to *int64) (*coretypes.ResultConsensusParamsHistory, error) {
+	res, err := c.next.ConsensusParamsHistory(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range res.Changes {
+		if err := change.ConsensusParams.ValidateConsensusParams(); err != nil {
+			return nil, err
+		}
+		if change.Height <= 0 {
+			return nil, coretypes.ErrZeroOrNegativeHeight
+		}
+		height := change.Height
+		l, err := c.updateLightClientIfNeededTo(ctx, &height)
+		if err != nil {
+			return nil, err
+		}
+		if cH, tH := change.ConsensusParams.HashConsensusParams(), l.ConsensusHash; !bytes.Equal(cH, tH) {
+			return nil, fmt.Errorf("params hash %X at height %d does not match trusted hash %X",
+				cH, change.Height, tH)
+		}
+	}
+
+	return res, nil
+}
+
 func (c *Client) Events(ctx context.Context, req *coretypes.RequestEvents) (*coretypes.ResultEvents, error) {
 	return c.next.Events(ctx, req)
 }
@@ -298,6 +316,26 @@ func (c *Client) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
 	return c.next.Health(ctx)
 }
 
+func (c *Client) Ready(ctx context.Context) (*coretypes.ResultReady, error) {
+	return c.next.Ready(ctx)
+}
+
+func (c *Client) AddressBook(ctx context.Context) (*coretypes.ResultAddressBook, error) {
+	return c.next.AddressBook(ctx)
+}
+
+func (c *Client) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	return c.next.NetTopology(ctx)
+}
+
+func (c *Client) SigningState(ctx context.Context) (*coretypes.ResultSigningState, error) {
+	return c.next.SigningState(ctx)
+}
+
+func (c *Client) StorageHealth(ctx context.Context) (*coretypes.ResultStorageHealth, error) {
+	return c.next.StorageHealth(ctx)
+}
+
 // BlockchainInfo calls rpcclient#BlockchainInfo and then verifies every header
 // returned.
 func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
@@ -353,31 +391,9 @@ func (c *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlo
 	if err != nil {
 		return nil, err
 	}
-
-	// Validate res.
-	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
-	}
-	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
-			bmH, bH)
-	}
-
-	// Update the light client if we're behind.
-	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
-	if err != nil {
+	if err := c.verifyBlock(ctx, res); err != nil {
 		return nil, err
 	}
-
-	// Verify block.
-	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
-			bH, tH)
-	}
-
 	return res, nil
 }
 
@@ -387,32 +403,39 @@ func (c *Client) BlockByHash(ctx context.Context, hash tmbytes.HexBytes) (*coret
 	if err != nil {
 		return nil, err
 	}
+	if err := c.verifyBlock(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
+// verifyBlock verifies the block of res against the trusted header at its
+// height.
+func (c *Client) verifyBlock(ctx context.Context, res *coretypes.ResultBlock) error {
 	// Validate res.
 	if err := res.BlockID.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if err := res.Block.ValidateBasic(); err != nil {
-		return nil, err
+		return err
 	}
 	if bmH, bH := res.BlockID.Hash, res.Block.Hash(); !bytes.Equal(bmH, bH) {
-		return nil, fmt.Errorf("blockID %X does not match with block %X",
+		return fmt.Errorf("blockID %X does not match with block %X",
 			bmH, bH)
 	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Block.Height)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Verify block.
 	if bH, tH := res.Block.Hash(), l.Hash(); !bytes.Equal(bH, tH) {
-		return nil, fmt.Errorf("block header %X does not match with trusted header %X",
+		return fmt.Errorf("block header %X does not match with trusted header %X",
 			bH, tH)
 	}
-
-	return res, nil
+	return nil
 }
 
 // BlockResults returns the block results for the given height. If no height is
@@ -472,6 +495,25 @@ func (c *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.Re
 	return res, nil
 }
 
+// BlockResultsWithArgs returns the block results for the height of req, as
+// BlockResults, with the other arguments of req. The results are verified in
+// full, and only then are the arguments applied to them, so that the events
+// are matched and encoded from the verified results rather than by the
+// primary.
+func (c *Client) BlockResultsWithArgs(
+	ctx context.Context,
+	req *coretypes.RequestBlockResults,
+) (*coretypes.ResultBlockResults, error) {
+	res, err := c.BlockResults(ctx, (*int64)(req.Height))
+	if err != nil {
+		return nil, err
+	}
+	if err := res.ApplyArgs(req); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // Header fetches and verifies the header directly via the light client
 func (c *Client) Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error) {
 	lb, err := c.updateLightClientIfNeededTo(ctx, height)
@@ -527,39 +569,110 @@ func (c *Client) Tx(ctx context.Context, hash tmbytes.HexBytes, prove bool) (*co
 	if err != nil || !prove {
 		return res, err
 	}
+	return res, c.verifyTx(ctx, res)
+}
+
+// TxSearch calls rpcclient#TxSearch and then verifies the proofs of the
+// transactions found if such were requested.
+func (c *Client) TxSearch(
+	ctx context.Context,
+	query string,
+	prove bool,
+	page, perPage *int,
+	orderBy string,
+) (*coretypes.ResultTxSearch, error) {
+	res, err := c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	if err != nil || !prove {
+		return res, err
+	}
+	if err := c.verifyTxs(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// TxSearchWithArgs is TxSearch with the other arguments of the request, e.g.
+// its cursor.
+func (c *Client) TxSearchWithArgs(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error) {
+	res, err := c.next.TxSearchWithArgs(ctx, req)
+	if err != nil || !req.Prove {
+		return res, err
+	}
+	if err := c.verifyTxs(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
 
+// verifyTxs verifies the proofs of the transactions found by a search.
+func (c *Client) verifyTxs(ctx context.Context, res *coretypes.ResultTxSearch) error {
+	for _, tx := range res.Txs {
+		if err := c.verifyTx(ctx, tx); err != nil {
+			return fmt.Errorf("tx %X: %w", tx.Hash, err)
+		}
+	}
+	return nil
+}
+
+// verifyTx verifies the proof of inclusion of the transaction of res against
+// the trusted header at its height.
+func (c *Client) verifyTx(ctx context.Context, res *coretypes.ResultTx) error {
 	// Validate res.
 	if res.Height <= 0 {
-		return nil, coretypes.ErrZeroOrNegativeHeight
+		return coretypes.ErrZeroOrNegativeHeight
+	}
+	if !bytes.Equal(res.Tx, res.Proof.Data) {
+		return errors.New("proof is for a different transaction")
 	}
 
 	// Update the light client if we're behind.
 	l, err := c.updateLightClientIfNeededTo(ctx, &res.Height)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Validate the proof.
-	return res, res.Proof.Validate(l.DataHash)
-}
-
-func (c *Client) TxSearch(
-	ctx context.Context,
-	query string,
-	prove bool,
-	page, perPage *int,
-	orderBy string,
-) (*coretypes.ResultTxSearch, error) {
-	return c.next.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	return res.Proof.Validate(l.DataHash)
 }
 
+// BlockSearch calls rpcclient#BlockSearch and then verifies the blocks found.
 func (c *Client) BlockSearch(
 	ctx context.Context,
 	query string,
 	page, perPage *int,
 	orderBy string,
 ) (*coretypes.ResultBlockSearch, error) {
-	return c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	res, err := c.next.BlockSearch(ctx, query, page, perPage, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyBlocks(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// BlockSearchWithArgs is BlockSearch with the other arguments of the request,
+// e.g. its cursor.
+func (c *Client) BlockSearchWithArgs(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error) {
+	res, err := c.next.BlockSearchWithArgs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.verifyBlocks(ctx, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// verifyBlocks verifies the blocks found by a search.
+func (c *Client) verifyBlocks(ctx context.Context, res *coretypes.ResultBlockSearch) error {
+	for _, b := range res.Blocks {
+		if err := c.verifyBlock(ctx, b); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Validators fetches and verifies validators.
@@ -594,10 +707,24 @@ func (c *Client) Validators(
 	}, nil
 }
 
+// ValidatorHistory calls the primary. The history is not verified, since the
+// validator sets of the heights in between are not fetched.
+func (c *Client) ValidatorHistory(ctx context.Context, address tmbytes.HexBytes, from, to *int64) (*coretypes.ResultValidatorHistory, error) {
+	return c.next.ValidatorHistory(ctx, address, from, to)
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
 
+func (c *Client) Evidence(ctx context.Context, page, perPage *int) (*coretypes.ResultEvidence, error) {
+	return c.next.Evidence(ctx, page, perPage)
+}
+
+func (c *Client) SubmitEvidence(ctx context.Context, voteA, voteB *types.Vote) (*coretypes.ResultBroadcastEvidence, error) {
+	return c.next.SubmitEvidence(ctx, voteA, voteB)
+}
+
 func (c *Client) Subscribe(ctx context.Context, subscriber, query string,
 	outCapacity ...int) (out <-chan coretypes.ResultEvent, err error) {
 	return c.next.Subscribe(ctx, subscriber, query, outCapacity...) //nolint:staticcheck
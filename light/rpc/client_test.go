@@ -0,0 +1,118 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	lcmock "github.com/tendermint/tendermint/light/rpc/mocks"
+	rpcmock "github.com/tendermint/tendermint/rpc/client/mocks"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+func testBlock(t *testing.T, height int64, txs types.Txs) (*types.Block, *types.LightBlock) {
+	t.Helper()
+	block := types.MakeBlock(height, txs, &types.Commit{}, nil)
+	block.ChainID = "test-chain"
+	block.ProposerAddress = crypto.AddressHash([]byte("proposer"))
+	block.ValidatorsHash = crypto.Checksum([]byte("validators"))
+	require.NoError(t, block.ValidateBasic())
+	header := block.Header
+	return block, &types.LightBlock{SignedHeader: &types.SignedHeader{Header: &header}}
+}
+
+func TestTxSearch(t *testing.T) {
+	ctx := context.Background()
+	txs := types.Txs{types.Tx("foo"), types.Tx("bar")}
+	_, lb := testBlock(t, 3, txs)
+
+	txSearch := func(txs types.Txs, proofs ...types.TxProof) *coretypes.ResultTxSearch {
+		res := &coretypes.ResultTxSearch{TotalCount: len(proofs)}
+		for i, proof := range proofs {
+			res.Txs = append(res.Txs, &coretypes.ResultTx{
+				Hash: txs[i].Hash(), Height: 3, Index: uint32(i), Tx: txs[i], Proof: proof,
+			})
+		}
+		return res
+	}
+
+	testCases := map[string]struct {
+		res *coretypes.ResultTxSearch
+		ok  bool
+	}{
+		"ValidProofs":     {res: txSearch(txs, txs.Proof(0), txs.Proof(1)), ok: true},
+		"ProofOfOtherTx":  {res: txSearch(txs, txs.Proof(1), txs.Proof(1))},
+		"ProofOfOtherSet": {res: txSearch(txs, types.Txs{txs[0]}.Proof(0))},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			next := rpcmock.NewClient(t)
+			next.On("TxSearch", mock.Anything, "tx.height=3", true, mock.Anything, mock.Anything, "").
+				Return(tc.res, nil)
+			lc := lcmock.NewLightClient(t)
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(3), mock.Anything).Return(lb, nil).Maybe()
+
+			c := NewClient(log.NewNopLogger(), next, lc)
+			res, err := c.TxSearch(ctx, "tx.height=3", true, nil, nil, "")
+			if tc.ok {
+				require.NoError(t, err)
+				require.Equal(t, tc.res, res)
+			} else {
+				require.Error(t, err)
+				require.Nil(t, res)
+			}
+		})
+	}
+}
+
+func TestBlockSearch(t *testing.T) {
+	ctx := context.Background()
+	block, lb := testBlock(t, 3, types.Txs{types.Tx("foo")})
+	other, _ := testBlock(t, 3, types.Txs{types.Tx("bar")})
+
+	blockSearch := func(block *types.Block) *coretypes.ResultBlockSearch {
+		parts, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		return &coretypes.ResultBlockSearch{
+			Blocks: []*coretypes.ResultBlock{{
+				BlockID: types.BlockID{Hash: block.Hash(), PartSetHeader: parts.Header()},
+				Block:   block,
+			}},
+			TotalCount: 1,
+		}
+	}
+
+	testCases := map[string]struct {
+		res *coretypes.ResultBlockSearch
+		ok  bool
+	}{
+		"TrustedBlock":   {res: blockSearch(block), ok: true},
+		"UntrustedBlock": {res: blockSearch(other)},
+	}
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			next := rpcmock.NewClient(t)
+			next.On("BlockSearch", mock.Anything, "block.height=3", mock.Anything, mock.Anything, "").
+				Return(tc.res, nil)
+			lc := lcmock.NewLightClient(t)
+			lc.On("VerifyLightBlockAtHeight", mock.Anything, int64(3), mock.Anything).Return(lb, nil)
+
+			c := NewClient(log.NewNopLogger(), next, lc)
+			res, err := c.BlockSearch(ctx, "block.height=3", nil, nil, "")
+			if tc.ok {
+				require.NoError(t, err)
+				require.Equal(t, tc.res, res)
+			} else {
+				require.Error(t, err)
+				require.Nil(t, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/config"
+	tmstrings "github.com/tendermint/tendermint/internal/libs/strings"
+	"github.com/tendermint/tendermint/internal/p2p"
+	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// mempoolLimits is the part of the mempool whose limits are reloaded.
+type mempoolLimits interface {
+	SetLimits(maxTxs int, maxTxsBytes int64)
+}
+
+// configReloader reloads the settings of the node which don't take a restart
+// from its config file, on SIGHUP or with the unsafe_reload_config RPC method.
+type configReloader struct {
+	logger      log.Logger // the logger of the node, whose level is reloaded
+	configFile  string
+	peerFilter  *peerFilterReloader
+	peerManager *p2p.PeerManager
+	mempool     mempoolLimits // nil if the node has no mempool
+	rpcEnv      *rpccore.Environment
+
+	// overrides are the keys of the settings given by command-line flags or
+	// environment variables, or otherwise not from the config file, which
+	// keep their values in effect on reload.
+	overrides []string
+
+	mtx    sync.Mutex
+	config *config.Config // the settings in effect
+}
+
+// reloadableSetting is a group of settings applied together on reload.
+type reloadableSetting struct {
+	keys []string
+
+	// apply applies the settings of cfg, and reports false if they can't be
+	// applied without a restart after all.
+	apply func(cfg *config.Config) (bool, error)
+}
+
+func createConfigReloader(
+	logger log.Logger,
+	cfg *config.Config,
+	peerFilter *peerFilterReloader,
+	peerManager *p2p.PeerManager,
+	mp mempoolLimits,
+	rpcEnv *rpccore.Environment,
+) *configReloader {
+	r := &configReloader{
+		logger:      logger,
+		configFile:  cfg.ConfigFile(),
+		peerFilter:  peerFilter,
+		peerManager: peerManager,
+		mempool:     mp,
+		rpcEnv:      rpcEnv,
+		config:      copyConfig(cfg),
+	}
+
+	// The settings which differ from the config file at startup were given
+	// otherwise, and take precedence over the file.
+	fileCfg, err := r.readConfigFile()
+	if err != nil {
+		logger.Error("failed to read config file, settings given by flags or environment variables are not kept on reload",
+			"err", err)
+	} else {
+		r.overrides = fileCfg.ChangedSettings(cfg)
+	}
+	return r
+}
+
+// copyConfig copies the sections of cfg that the reloader updates, so that it
+// never modifies the config shared with the services of the node.
+func copyConfig(cfg *config.Config) *config.Config {
+	c := *cfg
+	rpcCfg, p2pCfg, mempoolCfg := *cfg.RPC, *cfg.P2P, *cfg.Mempool
+	c.RPC, c.P2P, c.Mempool = &rpcCfg, &p2pCfg, &mempoolCfg
+	return &c
+}
+
+// ReloadConfig reads the config file and applies the changed settings which
+// don't take a restart. It returns the keys of the changed settings applied,
+// and of those which only take effect after a restart. Settings given by
+// command-line flags or environment variables keep their values.
+//
+// If a group of settings fails to be applied, the settings after it are not
+// applied either: the settings applied until then are returned with the
+// error, as well as the changed settings which take a restart in any case.
+func (r *configReloader) ReloadConfig() (applied, requireRestart []string, err error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	cfg, err := r.readConfig()
+	if err != nil {
+		r.logger.Error("failed to reload config file", "err", err)
+		return nil, nil, err
+	}
+	changed := r.config.ChangedSettings(cfg)
+
+	settings := r.settings()
+	isApplied := make(map[string]bool)
+	isReloadable := make(map[string]bool)
+	for _, setting := range settings {
+		for _, key := range setting.keys {
+			isReloadable[key] = true
+		}
+	}
+	for _, setting := range settings {
+		if !anyChanged(setting.keys, changed) {
+			continue
+		}
+		ok, applyErr := setting.apply(cfg)
+		if applyErr != nil {
+			err = fmt.Errorf("applying %s: %w", strings.Join(setting.keys, ", "), applyErr)
+			break
+		}
+		for _, key := range setting.keys {
+			isApplied[key] = ok
+			isReloadable[key] = ok
+		}
+	}
+
+	for _, key := range changed {
+		switch {
+		case isApplied[key]:
+			applied = append(applied, key)
+		case !isReloadable[key]:
+			requireRestart = append(requireRestart, key)
+		}
+	}
+	if err != nil {
+		r.logger.Error("failed to reload config file",
+			"applied", applied, "require_restart", requireRestart, "err", err)
+		return applied, requireRestart, err
+	}
+	r.logger.Info("reloaded config file", "applied", applied, "require_restart", requireRestart)
+	return applied, requireRestart, nil
+}
+
+// readConfig reads and validates the config file, keeping the values in
+// effect of the settings given otherwise.
+func (r *configReloader) readConfig() (*config.Config, error) {
+	cfg, err := r.readConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	cfg.SetSettings(r.config, r.overrides)
+	if err := cfg.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("error in config file: %w", err)
+	}
+	if err := cfg.Consensus.ApplyLegacyTimeouts(); err != nil {
+		return nil, fmt.Errorf("error in config file: %w", err)
+	}
+	return cfg, nil
+}
+
+// readConfigFile reads the settings of the config file alone.
+func (r *configReloader) readConfigFile() (*config.Config, error) {
+	v := viper.New()
+	v.SetConfigFile(r.configFile)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", r.configFile, err)
+	}
+
+	cfg := config.DefaultConfig()
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", r.configFile, err)
+	}
+	cfg.SetRoot(r.config.RootDir)
+	return cfg, nil
+}
+
+// settings returns the settings which may be reloaded. Each updates the
+// settings in effect once applied. The caller must hold r.mtx.
+func (r *configReloader) settings() []reloadableSetting {
+	return []reloadableSetting{
+		{
+			keys: []string{"log-level"},
+			apply: func(cfg *config.Config) (bool, error) {
+				// Not all loggers can change their level.
+				if err := log.SetLevel(r.logger, cfg.LogLevel); err != nil {
+					return false, nil
+				}
+				r.config.LogLevel = cfg.LogLevel
+				return true, nil
+			},
+		},
+		{
+			keys: []string{"rpc.rate-limit", "rpc.rate-limit-burst", "rpc.rate-limit-method-costs", "rpc.rate-limit-api-keys"},
+			apply: func(cfg *config.Config) (bool, error) {
+				ok, err := r.rpcEnv.SetRateLimit(cfg.RPC)
+				if !ok || err != nil {
+					return false, err
+				}
+				r.config.RPC.RateLimit = cfg.RPC.RateLimit
+				r.config.RPC.RateLimitBurst = cfg.RPC.RateLimitBurst
+				r.config.RPC.RateLimitMethodCosts = cfg.RPC.RateLimitMethodCosts
+				r.config.RPC.RateLimitAPIKeys = cfg.RPC.RateLimitAPIKeys
+				return true, nil
+			},
+		},
+		{
+			keys: []string{"mempool.size", "mempool.max-txs-bytes"},
+			apply: func(cfg *config.Config) (bool, error) {
+				if r.mempool == nil {
+					return false, nil
+				}
+				r.mempool.SetLimits(cfg.Mempool.Size, cfg.Mempool.MaxTxsBytes)
+				r.config.Mempool.Size = cfg.Mempool.Size
+				r.config.Mempool.MaxTxsBytes = cfg.Mempool.MaxTxsBytes
+				return true, nil
+			},
+		},
+		{
+			keys: []string{"p2p.persistent-peers"},
+			apply: func(cfg *config.Config) (bool, error) {
+				var addresses []p2p.NodeAddress
+				for _, p := range tmstrings.SplitAndTrimEmpty(cfg.P2P.PersistentPeers, ",", " ") {
+					address, err := p2p.ParseNodeAddress(p)
+					if err != nil {
+						return false, fmt.Errorf("invalid peer address %q: %w", p, err)
+					}
+					addresses = append(addresses, address)
+				}
+				if err := r.peerManager.SetPersistentPeers(addresses); err != nil {
+					return false, err
+				}
+				r.config.P2P.PersistentPeers = cfg.P2P.PersistentPeers
+				return true, nil
+			},
+		},
+		{
+			keys: []string{"p2p.allowed-peers", "p2p.blocked-peers"},
+			apply: func(cfg *config.Config) (bool, error) {
+				if err := r.peerFilter.update(cfg.P2P.AllowedPeers, cfg.P2P.BlockedPeers); err != nil {
+					return false, err
+				}
+				r.config.P2P.AllowedPeers = cfg.P2P.AllowedPeers
+				r.config.P2P.BlockedPeers = cfg.P2P.BlockedPeers
+				return true, nil
+			},
+		},
+	}
+}
+
+// anyChanged reports whether any of the keys is in changed.
+func anyChanged(keys, changed []string) bool {
+	for _, key := range keys {
+		for _, c := range changed {
+			if key == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// run reloads the config file on SIGHUP until ctx is done.
+func (r *configReloader) run(ctx context.Context) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			// The outcome, even a failure, is logged by ReloadConfig.
+			_, _, _ = r.ReloadConfig()
+		}
+	}
+}
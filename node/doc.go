@@ -2,5 +2,21 @@
 Package node is the main entry point, where the tendermint node
 service is constructed and the implementation of that service is
 defined.
+
+Programs running the node as a library customize the services of a full node
+with the options of New, instead of copying the construction of the node:
+
+	n, err := node.New(ctx, conf, logger, client, nil,
+		node.WithReactor(func(c node.Components) (service.Service, error) {
+			return newMyReactor(c.Logger, c.OpenChannel, c.PeerEvents, c.EventBus), nil
+		}),
+		node.WithEventSinks(myEventSink),
+	)
+
+The full node implements Node, whose getters return the services of the node,
+e.g. to subscribe to the events of its event bus. The types of the services,
+and of the channels and peer updates of the reactors, are defined by internal
+packages: this package re-exports them, e.g. EventBus, Channel and
+PeerUpdates, for the programs that need to name them.
 */
 package node
@@ -0,0 +1,39 @@
+package node
+
+import (
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink"
+)
+
+// The types a custom event sink implements EventSink with, which are defined
+// by internal packages. Query is the compiled query of SearchBlockEvents and
+// SearchTxEvents: its String and Matches methods let a sink run queries.
+type (
+	EventSinkType    = indexer.EventSinkType
+	EventSinkFactory = sink.Factory
+	Query            = query.Query
+)
+
+// CustomEventSinkPrefix is the prefix that selects a registered event sink in
+// the tx-index.indexer configuration, e.g. "custom:elasticsearch".
+const CustomEventSinkPrefix = sink.CustomPrefix
+
+// RegisterEventSink makes a custom event sink available under the given name:
+// nodes enable it when tx-index.indexer lists "custom:<name>". It is intended
+// to be called from init functions and reports an error if the name is empty
+// or already registered.
+func RegisterEventSink(name string, factory EventSinkFactory) error {
+	return sink.Register(name, factory)
+}
+
+// MustRegisterEventSink is like RegisterEventSink but panics on error.
+func MustRegisterEventSink(name string, factory EventSinkFactory) {
+	sink.MustRegister(name, factory)
+}
+
+// RegisteredEventSinks returns the sorted names of the registered custom
+// event sinks.
+func RegisteredEventSinks() []string {
+	return sink.Registered()
+}
@@ -0,0 +1,82 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/types"
+)
+
+// recordingSink is an event sink defined outside of the module's internal
+// packages, as a third party would define it.
+type recordingSink struct {
+	blocks chan int64
+}
+
+var _ node.EventSink = (*recordingSink)(nil)
+
+func (s *recordingSink) IndexBlockEvents(h types.EventDataNewBlockHeader) error {
+	select {
+	case s.blocks <- h.Header.Height:
+	default:
+	}
+	return nil
+}
+
+func (s *recordingSink) IndexTxEvents([]*abci.TxResult) error { return nil }
+
+func (s *recordingSink) SearchBlockEvents(context.Context, *node.Query) ([]int64, error) {
+	return nil, nil
+}
+
+func (s *recordingSink) SearchTxEvents(context.Context, *node.Query) ([]*abci.TxResult, error) {
+	return nil, nil
+}
+
+func (s *recordingSink) GetTxByHash([]byte) (*abci.TxResult, error) { return nil, nil }
+func (s *recordingSink) HasBlock(int64) (bool, error)               { return false, nil }
+func (s *recordingSink) Type() node.EventSinkType                   { return "recorder" }
+func (s *recordingSink) Stop() error                                { return nil }
+
+func TestNodeCustomEventSink(t *testing.T) {
+	es := &recordingSink{blocks: make(chan int64, 1)}
+	require.NoError(t, node.RegisterEventSink("recorder", func(*config.Config, config.DBProvider, string) (node.EventSink, error) {
+		return es, nil
+	}))
+	require.Contains(t, node.RegisteredEventSinks(), "recorder")
+	require.Error(t, node.RegisterEventSink("Recorder", func(*config.Config, config.DBProvider, string) (node.EventSink, error) {
+		return es, nil
+	}), "names are case-insensitive")
+
+	cfg, err := config.ResetTestRoot(t.TempDir(), "node_custom_event_sink_test")
+	require.NoError(t, err)
+	cfg.TxIndex.Indexer = []string{node.CustomEventSinkPrefix + "recorder"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+	n, err := node.New(ctx, cfg, logger, abciclient.NewLocalClient(logger, kvstore.NewApplication()), nil)
+	require.NoError(t, err)
+	require.NoError(t, n.Start(ctx))
+	t.Cleanup(func() {
+		cancel()
+		n.Wait()
+	})
+
+	select {
+	case height := <-es.blocks:
+		require.Positive(t, height)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the custom event sink indexed no block")
+	}
+}
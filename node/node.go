@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/snapshots"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
@@ -24,14 +26,18 @@ import (
 	"github.com/tendermint/tendermint/internal/evidence"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/internal/p2p/nat"
 	"github.com/tendermint/tendermint/internal/p2p/pex"
 	"github.com/tendermint/tendermint/internal/proxy"
+	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
 	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink"
 	"github.com/tendermint/tendermint/internal/statesync"
 	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/store/compact"
+	"github.com/tendermint/tendermint/internal/trace"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
 	tmtime "github.com/tendermint/tendermint/libs/time"
@@ -43,6 +49,10 @@ import (
 	_ "github.com/lib/pq" // provide the psql db driver
 )
 
+// natMappingTimeout is how long the node looks for a NAT gateway to map its
+// P2P port on at startup.
+const natMappingTimeout = 5 * time.Second
+
 // nodeImpl is the highest level interface to a full Tendermint node.
 // It includes all configuration information and running services.
 type nodeImpl struct {
@@ -59,6 +69,8 @@ type nodeImpl struct {
 	router      *p2p.Router
 	nodeInfo    types.NodeInfo
 	nodeKey     types.NodeKey // our node privkey
+	natDetector *nat.Detector
+	peerFilter  *peerFilterReloader
 
 	// services
 	eventSinks     []indexer.EventSink
@@ -66,12 +78,20 @@ type nodeImpl struct {
 	stateStore     sm.Store
 	blockStore     *store.BlockStore // store the blockchain to disk
 	evPool         *evidence.Pool
+	mempool        mempool.Mempool
+	consensusState *consensus.State
 	indexerService *indexer.Service
 	services       []service.Service
 	rpcListeners   []net.Listener // rpc servers
 	shutdownOps    closer
 	rpcEnv         *rpccore.Environment
+	reloader       *configReloader
+	tracer         *trace.Tracer
 	prometheusSrv  *http.Server
+
+	// cancel cancels the context of the services, to stop them without
+	// waiting for the context of the node, such as when consensus halts.
+	cancel context.CancelFunc
 }
 
 // newDefaultNode returns a Tendermint node with default settings for the
@@ -81,6 +101,7 @@ func newDefaultNode(
 	ctx context.Context,
 	cfg *config.Config,
 	logger log.Logger,
+	opts ...Option,
 ) (service.Service, error) {
 	nodeKey, err := types.LoadOrGenNodeKey(cfg.NodeKeyFile())
 	if err != nil {
@@ -100,20 +121,38 @@ func newDefaultNode(
 		return nil, err
 	}
 
-	appClient, _, err := proxy.ClientFactory(logger, cfg.ProxyApp, cfg.ABCI, cfg.DBDir())
+	grpcOptions, err := proxy.GRPCOptions(cfg.BaseConfig)
+	if err != nil {
+		return nil, err
+	}
+	appClient, _, err := proxy.ClientFactory(logger, cfg.ProxyApp, cfg.ABCI, cfg.DBDir(), grpcOptions...)
 	if err != nil {
 		return nil, err
 	}
 
+	// The calls to a builtin application are already concurrent.
+	var recheckClients []abciclient.Client
+	if !proxy.IsBuiltin(cfg.ProxyApp) {
+		for i := 0; i < cfg.Mempool.RecheckConnections; i++ {
+			client, _, err := proxy.ClientFactory(logger, cfg.ProxyApp, cfg.ABCI, cfg.DBDir(), grpcOptions...)
+			if err != nil {
+				return nil, err
+			}
+			recheckClients = append(recheckClients, client)
+		}
+	}
+
 	return makeNode(
 		ctx,
 		cfg,
 		pval,
 		nodeKey,
 		appClient,
+		recheckClients,
 		defaultGenesisDocProviderFunc(cfg),
 		config.DefaultDBProvider,
 		logger,
+		opts...,
 	)
 }
 
@@ -124,16 +163,27 @@ func makeNode(
 	filePrivval *privval.FilePV,
 	nodeKey types.NodeKey,
 	client abciclient.Client,
+	recheckClients []abciclient.Client,
 	genesisDocProvider genesisDocProvider,
 	dbProvider config.DBProvider,
 	logger log.Logger,
+	opts ...Option,
 ) (service.Service, error) {
+	var options nodeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	var cancel context.CancelFunc
 	ctx, cancel = context.WithCancel(ctx)
 
 	closers := []closer{convertCancelCloser(cancel)}
 
-	blockStore, stateDB, dbCloser, err := initDBs(cfg, dbProvider)
+	if err := types.SetTxHashFunc(cfg.TxHash); err != nil {
+		return nil, combineCloseError(fmt.Errorf("invalid tx-hash: %w", err), makeCloser(closers))
+	}
+
+	blockStore, blockStoreDB, stateDB, dbCloser, err := initDBs(cfg, dbProvider, options.blockStore)
 	if err != nil {
 		return nil, combineCloseError(err, dbCloser)
 	}
@@ -156,9 +206,37 @@ func makeNode(
 	}
 
 	nodeMetrics := defaultMetricsProvider(cfg.Instrumentation)(genDoc.ChainID)
+	tracer := createTracer(logger, cfg.Instrumentation, genDoc.ChainID, nodeKey.ID)
 
-	proxyApp := proxy.New(client, logger.With("module", "proxy"), nodeMetrics.proxy)
-	eventBus := eventbus.NewDefault(logger.With("module", "events"))
+	proxyOptions := []proxy.Option{
+		proxy.WithTimeout(cfg.ABCITimeout),
+		proxy.WithCheckTxTimeout(cfg.ABCICheckTxTimeout),
+		proxy.WithTracer(tracer),
+	}
+	if dir := cfg.StateSync.SnapshotDir; dir != "" {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(cfg.RootDir, dir)
+		}
+		var storeOptions []snapshots.Option
+		if formats := cfg.StateSync.SnapshotFormats; len(formats) > 0 {
+			storeOptions = append(storeOptions, snapshots.WithFormats(formats...))
+		}
+		store, err := snapshots.NewStore(dir, storeOptions...)
+		if err != nil {
+			return nil, combineCloseError(err, makeCloser(closers))
+		}
+		proxyOptions = append(proxyOptions, proxy.WithSnapshotStore(store))
+	}
+	proxyApp := proxy.New(client, logger.With("module", "proxy"), nodeMetrics.proxy, proxyOptions...)
+	var busOptions []tmpubsub.Option
+	if cfg.RPC.SubscriptionReplayWindow > 0 {
+		seq, err := nextEventSeqBase(cfg)
+		if err != nil {
+			return nil, combineCloseError(err, makeCloser(closers))
+		}
+		busOptions = append(busOptions, tmpubsub.InitialSeq(seq))
+	}
+	eventBus := eventbus.NewDefault(logger.With("module", "events"), busOptions...)
 
 	var eventLog *eventlog.Log
 	if w := cfg.RPC.EventLogWindowSize; w > 0 {
@@ -172,18 +250,30 @@ func makeNode(
 			return nil, combineCloseError(fmt.Errorf("initializing event log: %w", err), makeCloser(closers))
 		}
 	}
-	eventSinks, err := sink.EventSinksFromConfig(cfg, dbProvider, genDoc.ChainID)
-	if err != nil {
-		return nil, combineCloseError(err, makeCloser(closers))
+	eventSinks := options.eventSinks
+	if eventSinks == nil {
+		eventSinks, err = sink.EventSinksFromConfig(cfg, dbProvider, genDoc.ChainID)
+		if err != nil {
+			return nil, combineCloseError(err, makeCloser(closers))
+		}
 	}
-	indexerService := indexer.NewService(indexer.ServiceArgs{
+	indexerArgs := indexer.ServiceArgs{
 		Sinks:    eventSinks,
 		EventBus: eventBus,
 		Logger:   logger.With("module", "txindex"),
 		Metrics:  nodeMetrics.indexer,
-	})
 
-	privValidator, err := createPrivval(ctx, logger, cfg, genDoc, filePrivval)
+		Async:     cfg.TxIndex.Async,
+		QueueSize: cfg.TxIndex.QueueSize,
+
+		RetainBlocks: cfg.TxIndex.RetainBlocks,
+	}
+	if cfg.TxIndex.PruneWithBlocks {
+		indexerArgs.BlockStoreBase = blockStore.Base
+	}
+	indexerService := indexer.NewService(indexerArgs)
+
+	privValidator, err := createPrivval(ctx, logger, cfg, genDoc, filePrivval, nodeMetrics.privval)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
@@ -211,6 +301,18 @@ func makeNode(
 			makeCloser(closers))
 	}
 
+	natDetector, err := createNATDetector(logger, cfg, nodeKey.ID, peerManager)
+	if err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+
+	peerFilter, err := createPeerFilter(logger, cfg, peerManager)
+	if err != nil {
+		return nil, combineCloseError(
+			fmt.Errorf("failed to create peer filter: %w", err),
+			makeCloser(closers))
+	}
+
 	// TODO construct node here:
 	node := &nodeImpl{
 		config:        cfg,
@@ -220,6 +322,8 @@ func makeNode(
 
 		peerManager: peerManager,
 		nodeKey:     nodeKey,
+		natDetector: natDetector,
+		peerFilter:  peerFilter,
 
 		eventSinks:     eventSinks,
 		indexerService: indexerService,
@@ -237,18 +341,22 @@ func makeNode(
 			StateStore: stateStore,
 			BlockStore: blockStore,
 
-			PeerManager: peerManager,
+			PeerManager:     peerManager,
+			ExternalAddress: natDetector,
+			PeerFilter:      peerFilter,
 
 			GenDoc:     genDoc,
 			EventSinks: eventSinks,
 			EventBus:   eventBus,
 			EventLog:   eventLog,
 			Logger:     logger.With("module", "rpc"),
+			Tracer:     tracer,
 			Config:     *cfg.RPC,
 		},
+		tracer: tracer,
 	}
 
-	node.router, err = createRouter(logger, nodeMetrics.p2p, node.NodeInfo, nodeKey, peerManager, cfg, proxyApp)
+	node.router, err = createRouter(logger, nodeMetrics.p2p, node.NodeInfo, nodeKey, peerManager, peerFilter.filter, cfg, proxyApp)
 	if err != nil {
 		return nil, combineCloseError(
 			fmt.Errorf("failed to create router: %w", err),
@@ -263,12 +371,55 @@ func makeNode(
 	}
 	node.services = append(node.services, evReactor)
 	node.rpcEnv.EvidencePool = evPool
+	node.rpcEnv.EvidenceAuditor = evPool
 	node.evPool = evPool
 
-	mpReactor, mp := createMempoolReactor(logger, cfg, proxyApp, stateStore, nodeMetrics.mempool,
-		peerManager.Subscribe, node.router.OpenChannel)
+	// The additional connections for rechecks are started with the services,
+	// before the mempool reactor.
+	recheckApps := make([]abciclient.Client, 0, len(recheckClients))
+	for _, client := range recheckClients {
+		recheckApp := proxy.New(client, logger.With("module", "proxy"), nodeMetrics.proxy, proxyOptions...)
+		recheckApps = append(recheckApps, recheckApp)
+		node.services = append(node.services, recheckApp)
+	}
+
+	components := Components{
+		Logger:     logger,
+		Config:     cfg,
+		GenesisDoc: genDoc,
+
+		ProxyApp:   proxyApp,
+		StateStore: stateStore,
+		BlockStore: blockStore,
+		EventBus:   eventBus,
+
+		PeerManager: peerManager,
+		OpenChannel: node.router.OpenChannel,
+		PeerEvents:  peerManager.Subscribe,
+	}
+
+	var (
+		mpReactor service.Service
+		mp        mempool.Mempool
+	)
+	if options.mempool != nil {
+		mp, mpReactor, err = options.mempool(components)
+		if err != nil {
+			return nil, combineCloseError(fmt.Errorf("failed to create mempool: %w", err), makeCloser(closers))
+		}
+	} else {
+		mpReactor, mp = createMempoolReactor(logger, cfg, proxyApp, recheckApps, stateStore, nodeMetrics.mempool,
+			eventBus, peerManager.Subscribe, node.router.OpenChannel)
+	}
 	node.rpcEnv.Mempool = mp
-	node.services = append(node.services, mpReactor)
+	node.mempool = mp
+	if mpReactor != nil {
+		node.services = append(node.services, mpReactor)
+	}
+
+	mpLimits, _ := mp.(mempoolLimits)
+	node.reloader = createConfigReloader(logger, cfg, peerFilter, peerManager, mpLimits, node.rpcEnv)
+	node.rpcEnv.ConfigReloader = node.reloader
 
 	// make block executor for consensus and blockchain reactors to execute blocks
 	blockExec := sm.NewBlockExecutor(
@@ -280,8 +431,56 @@ func makeNode(
 		blockStore,
 		eventBus,
 		nodeMetrics.state,
+		sm.BlockExecutorWithVerificationWorkers(cfg.Consensus.VerificationWorkers()),
+		sm.BlockExecutorWithEventLimits(sm.EventLimits{
+			MaxEvents:             cfg.TxIndex.MaxEvents,
+			MaxAttributeKeySize:   cfg.TxIndex.MaxEventKeySize,
+			MaxAttributeValueSize: cfg.TxIndex.MaxEventValueSize,
+		}),
 	)
 
+	// prune the blocks, states and block results below the retain heights
+	var prunerOptions []sm.PrunerOption
+	if cfg.RPC.GRPCDataCompanion {
+		prunerOptions = append(prunerOptions, sm.PrunerWithCompanionPruning())
+	}
+	if cfg.Storage.Compact {
+		if blockStoreDB == nil {
+			return nil, combineCloseError(
+				errors.New("storage.compact is not supported with the block store of the WithBlockStore option, "+
+					"whose database is not owned by the node"),
+				makeCloser(closers))
+		}
+		if !compact.Supported(blockStoreDB) || !compact.Supported(stateDB) {
+			return nil, combineCloseError(
+				fmt.Errorf("storage.compact is not supported by the %s database backend", cfg.DBBackend),
+				makeCloser(closers))
+		}
+		prunerOptions = append(prunerOptions, sm.PrunerWithCompaction(cfg.Storage.CompactionInterval, blockStoreDB, stateDB))
+	}
+	node.services = append(node.services, sm.NewPruner(
+		logger.With("module", "pruner"),
+		stateStore,
+		blockStore,
+		cfg.Storage.Pruning.Interval,
+		nodeMetrics.state,
+		prunerOptions...,
+	))
+
+	// audit the block and state stores in the background
+	if cfg.Storage.Audit.Interval > 0 {
+		auditor := sm.NewAuditor(
+			logger.With("module", "auditor"),
+			stateStore,
+			blockStore,
+			cfg.Storage.Audit.Interval,
+			cfg.Storage.Audit.SampleSize,
+			nodeMetrics.state,
+		)
+		node.services = append(node.services, auditor)
+		node.rpcEnv.StorageAuditor = auditor
+	}
+
 	// Determine whether we should attempt state sync.
 	stateSync := cfg.StateSync.Enable && !onlyValidatorIsUs(state, pubKey)
 	if stateSync && state.LastBlockHeight > 0 {
@@ -303,12 +502,15 @@ func makeNode(
 		evPool,
 		eventBus,
 		consensus.StateMetrics(nodeMetrics.consensus),
+		consensus.StateTracer(tracer),
 		consensus.SkipStateStoreBootstrap,
+		consensus.StateOnHalt(node.onConsensusHalt),
 	)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
 	}
 	node.rpcEnv.ConsensusState = csState
+	node.consensusState = csState
 
 	csReactor := consensus.NewReactor(
 		logger,
@@ -338,6 +540,7 @@ func makeNode(
 	)
 	node.services = append(node.services, bcReactor)
 	node.rpcEnv.BlockSyncReactor = bcReactor
+	csReactor.SetBlockSyncReactor(bcReactor)
 
 	// Make ConsensusReactor. Don't enable fully if doing a state sync and/or block sync first.
 	// FIXME We need to update metrics here, since other reactors don't have access to them.
@@ -348,14 +551,17 @@ func makeNode(
 	}
 
 	if cfg.P2P.PexReactor {
-		node.services = append(node.services, pex.NewReactor(logger, peerManager, node.router.OpenChannel, peerManager.Subscribe))
+		topology := pex.NewTopology(nodeMetrics.pex)
+		node.services = append(node.services, pex.NewReactor(logger, peerManager, node.router.OpenChannel, peerManager.Subscribe,
+			pex.WithTopology(topology)))
+		node.rpcEnv.Topology = topology
 	}
 
 	// Set up state sync reactor, and schedule a sync if requested.
 	// FIXME The way we do phased startups (e.g. replay -> block sync -> consensus) is very messy,
 	// we should clean this whole thing up. See:
 	// https://github.com/tendermint/tendermint/issues/4644
-	node.services = append(node.services, statesync.NewReactor(
+	ssReactor := statesync.NewReactor(
 		genDoc.ChainID,
 		genDoc.InitialHeight,
 		*cfg.StateSync,
@@ -385,13 +591,27 @@ func makeNode(
 			return nil
 		},
 		stateSync,
-	))
+		statesync.WithEvidencePool(evPool),
+	)
+	node.services = append(node.services, ssReactor)
+	node.rpcEnv.StateSyncMetricer = ssReactor
 
 	if cfg.Mode == config.ModeValidator {
 		if privValidator != nil {
 			csState.SetPrivValidator(ctx, privValidator)
 		}
 		node.rpcEnv.PubKey = pubKey
+		node.rpcEnv.PrivValidator = privValidator
+	}
+
+	components.Mempool = mp
+	components.ConsensusState = csState
+	for _, constructor := range options.reactors {
+		reactor, err := constructor(components)
+		if err != nil {
+			return nil, combineCloseError(fmt.Errorf("failed to create reactor: %w", err), makeCloser(closers))
+		}
+		node.services = append(node.services, reactor)
 	}
 
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
@@ -401,6 +621,10 @@ func makeNode(
 
 // OnStart starts the Node. It implements service.Service.
 func (n *nodeImpl) OnStart(ctx context.Context) error {
+	ctx, n.cancel = context.WithCancel(ctx)
+
+	go n.tracer.Run(ctx)
+
 	if err := n.rpcEnv.ProxyApp.Start(ctx); err != nil {
 		return fmt.Errorf("error starting proxy app connections: %w", err)
 	}
@@ -421,6 +645,7 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 	// and replays any blocks as necessary to sync tendermint with the app.
 	if err := consensus.NewHandshaker(n.logger.With("module", "handshaker"),
 		n.stateStore, n.initialState, n.blockStore, n.rpcEnv.EventBus, n.genesisDoc,
+		consensus.HandshakerInitChainChunkSize(n.config.InitChainChunkSize),
 	).Handshake(ctx, n.rpcEnv.ProxyApp); err != nil {
 		return err
 	}
@@ -435,12 +660,26 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 
 	logNodeStartupInfo(state, n.rpcEnv.PubKey, n.logger, n.config.Mode)
 
+	if n.config.P2P.UPNP && n.config.P2P.ExternalAddress == "" {
+		mctx, mcancel := context.WithTimeout(ctx, natMappingTimeout)
+		err := n.natDetector.MapPort(mctx)
+		mcancel()
+		if err != nil {
+			n.logger.Error("failed to map P2P port on NAT gateway", "err", err)
+		} else {
+			go n.natDetector.Run(ctx)
+		}
+	}
+
 	// TODO: Fetch and provide real options and do proper p2p bootstrapping.
 	// TODO: Use a persistent peer database.
 	n.nodeInfo, err = makeNodeInfo(n.config, n.nodeKey, n.eventSinks, n.genesisDoc, state.Version.Consensus)
 	if err != nil {
 		return err
 	}
+	if addr := n.natDetector.MappedAddress(); addr != "" {
+		n.nodeInfo.ListenAddr = addr
+	}
 	// Start Internal Services
 
 	if n.config.RPC.PprofListenAddress != "" {
@@ -489,7 +728,7 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 	}
 
 	if n.config.Instrumentation.Prometheus && n.config.Instrumentation.PrometheusListenAddr != "" {
-		n.prometheusSrv = n.startPrometheusServer(ctx, n.config.Instrumentation.PrometheusListenAddr)
+		n.prometheusSrv = startPrometheusServer(ctx, n.logger, n.config.Instrumentation)
 	}
 
 	// Start the transport.
@@ -515,12 +754,18 @@ func (n *nodeImpl) OnStart(ctx context.Context) error {
 		}
 	}
 
+	// The config is reloaded once the RPC server has set up its rate limit.
+	go n.reloader.run(ctx)
+
 	return nil
 }
 
 // OnStop stops the Node. It implements service.Service.
 func (n *nodeImpl) OnStop() {
 	n.logger.Info("Stopping Node")
+	if n.cancel != nil {
+		n.cancel()
+	}
 	// stop the listeners / external services first
 	for _, l := range n.rpcListeners {
 		n.logger.Info("Closing rpc listener", "listener", l)
@@ -570,15 +815,27 @@ func (n *nodeImpl) OnStop() {
 	}
 }
 
+// onConsensusHalt stops the node when consensus halts on a consistency
+// violation, which the consensus state only reports if the node should not keep
+// serving RPC, or on a planned halt.
+func (n *nodeImpl) onConsensusHalt(rec consensus.HaltRecord) {
+	if rec.Planned() {
+		n.logger.Info("stopping node on the planned halt", "height", rec.Height)
+	} else {
+		n.logger.Error("stopping node as consensus halted", "code", rec.Code, "height", rec.Height)
+	}
+	go n.Stop()
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
-// collectors on addr.
-func (n *nodeImpl) startPrometheusServer(ctx context.Context, addr string) *http.Server {
+// collectors on the configured address.
+func startPrometheusServer(ctx context.Context, logger log.Logger, cfg *config.InstrumentationConfig) *http.Server {
 	srv := &http.Server{
-		Addr: addr,
+		Addr: cfg.PrometheusListenAddr,
 		Handler: promhttp.InstrumentMetricHandler(
 			prometheus.DefaultRegisterer, promhttp.HandlerFor(
 				prometheus.DefaultGatherer,
-				promhttp.HandlerOpts{MaxRequestsInFlight: n.config.Instrumentation.MaxOpenConnections},
+				promhttp.HandlerOpts{MaxRequestsInFlight: cfg.MaxOpenConnections},
 			),
 		),
 	}
@@ -596,7 +853,7 @@ func (n *nodeImpl) startPrometheusServer(ctx context.Context, addr string) *http
 
 	go func() {
 		if err := srv.ListenAndServe(); err != nil {
-			n.logger.Error("Prometheus HTTP server ListenAndServe", "err", err)
+			logger.Error("Prometheus HTTP server ListenAndServe", "err", err)
 			close(signal)
 		}
 	}()
@@ -623,6 +880,36 @@ func (n *nodeImpl) RPCEnvironment() *rpccore.Environment {
 	return n.rpcEnv
 }
 
+// Router returns the Node's Router.
+func (n *nodeImpl) Router() *p2p.Router {
+	return n.router
+}
+
+// PeerManager returns the Node's PeerManager.
+func (n *nodeImpl) PeerManager() *p2p.PeerManager {
+	return n.peerManager
+}
+
+// Mempool returns the Node's Mempool.
+func (n *nodeImpl) Mempool() mempool.Mempool {
+	return n.mempool
+}
+
+// BlockStore returns the Node's BlockStore.
+func (n *nodeImpl) BlockStore() *store.BlockStore {
+	return n.blockStore
+}
+
+// StateStore returns the Node's StateStore.
+func (n *nodeImpl) StateStore() sm.Store {
+	return n.stateStore
+}
+
+// ConsensusState returns the Node's consensus State.
+func (n *nodeImpl) ConsensusState() *consensus.State {
+	return n.consensusState
+}
+
 //------------------------------------------------------------------------------
 
 // genesisDocProvider returns a GenesisDoc.
@@ -644,10 +931,12 @@ type nodeMetrics struct {
 	indexer   *indexer.Metrics
 	mempool   *mempool.Metrics
 	p2p       *p2p.Metrics
+	pex       *pex.Metrics
 	proxy     *proxy.Metrics
 	state     *sm.Metrics
 	statesync *statesync.Metrics
 	evidence  *evidence.Metrics
+	privval   *privval.Metrics
 }
 
 // metricsProvider returns consensus, p2p, mempool, state, statesync Metrics.
@@ -658,27 +947,39 @@ type metricsProvider func(chainID string) *nodeMetrics
 func defaultMetricsProvider(cfg *config.InstrumentationConfig) metricsProvider {
 	return func(chainID string) *nodeMetrics {
 		if cfg.Prometheus {
-			return &nodeMetrics{
+			m := &nodeMetrics{
 				consensus: consensus.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 				eventlog:  eventlog.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 				indexer:   indexer.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 				mempool:   mempool.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 				p2p:       p2p.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				pex:       pex.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 				proxy:     proxy.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 				state:     sm.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 				statesync: statesync.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 				evidence:  evidence.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				privval:   privval.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+			}
+			if !cfg.PeerMetrics {
+				m.consensus.BlankPeerLabels()
+				m.p2p.BlankPeerLabels()
+			}
+			if !cfg.ValidatorMetrics {
+				m.consensus.DiscardValidatorMetrics()
 			}
+			return m
 		}
 		return &nodeMetrics{
 			consensus: consensus.NopMetrics(),
 			indexer:   indexer.NopMetrics(),
 			mempool:   mempool.NopMetrics(),
 			p2p:       p2p.NopMetrics(),
+			pex:       pex.NopMetrics(),
 			proxy:     proxy.NopMetrics(),
 			state:     sm.NopMetrics(),
 			statesync: statesync.NopMetrics(),
 			evidence:  evidence.NopMetrics(),
+			privval:   privval.NopMetrics(),
 		}
 	}
 }
@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,11 +27,14 @@ import (
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/evidence"
 	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/proxy"
 	"github.com/tendermint/tendermint/internal/pubsub"
+	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink"
+	"github.com/tendermint/tendermint/internal/state/indexer/sink/null"
 	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/internal/test/factory"
 	"github.com/tendermint/tendermint/libs/log"
@@ -82,6 +89,82 @@ func TestNodeStartStop(t *testing.T) {
 	require.False(t, n.IsRunning(), "node must shut down")
 }
 
+// testReactor is a custom reactor recording whether it ran.
+type testReactor struct {
+	service.BaseService
+	started chan struct{}
+}
+
+func (r *testReactor) OnStart(ctx context.Context) error {
+	close(r.started)
+	return nil
+}
+
+func (r *testReactor) OnStop() {}
+
+func TestNodeOptions(t *testing.T) {
+	cfg, err := config.ResetTestRoot(t.TempDir(), "node_options_test")
+	require.NoError(t, err)
+
+	ctx, bcancel := context.WithCancel(context.Background())
+	defer bcancel()
+
+	logger := log.NewNopLogger()
+	blockStore := NewBlockStore(dbm.NewMemDB())
+	eventSink := null.NewEventSink()
+	reactor := &testReactor{started: make(chan struct{})}
+	reactor.BaseService = *service.NewBaseService(logger, "Test", reactor)
+
+	var mp mempool.Mempool
+	ns, err := newDefaultNode(ctx, cfg, logger,
+		WithBlockStore(blockStore),
+		WithEventSinks(eventSink),
+		WithMempool(func(c Components) (mempool.Mempool, service.Service, error) {
+			require.Same(t, blockStore, c.BlockStore)
+			mp = mempool.NewTxMempool(c.Logger, c.Config.Mempool, c.ProxyApp)
+			return mp, nil, nil
+		}),
+		WithReactor(func(c Components) (service.Service, error) {
+			require.Equal(t, mp, c.Mempool)
+			require.NotNil(t, c.ConsensusState)
+			return reactor, nil
+		}),
+	)
+	require.NoError(t, err)
+
+	n, ok := ns.(Node)
+	require.True(t, ok)
+	require.Same(t, blockStore, n.BlockStore())
+	require.Equal(t, mp, n.Mempool())
+	require.Equal(t, []indexer.EventSink{eventSink}, n.RPCEnvironment().EventSinks)
+	require.Same(t, n.ConsensusState(), n.RPCEnvironment().ConsensusState)
+	require.NotNil(t, n.Router())
+
+	t.Cleanup(func() {
+		bcancel()
+		n.Wait()
+	})
+	require.NoError(t, n.Start(ctx))
+	select {
+	case <-reactor.started:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the custom reactor was not started")
+	}
+
+	cfg, err = config.ResetTestRoot(t.TempDir(), "node_options_test")
+	require.NoError(t, err)
+	_, err = newDefaultNode(ctx, cfg, logger,
+		WithReactor(func(Components) (service.Service, error) { return nil, errors.New("no reactor") }))
+	require.ErrorContains(t, err, "no reactor")
+
+	// the node can't compact a block store it does not own
+	cfg, err = config.ResetTestRoot(t.TempDir(), "node_options_test")
+	require.NoError(t, err)
+	cfg.Storage.Compact = true
+	_, err = newDefaultNode(ctx, cfg, logger, WithBlockStore(NewBlockStore(dbm.NewMemDB())))
+	require.ErrorContains(t, err, "WithBlockStore")
+}
+
 func getTestNode(ctx context.Context, t *testing.T, conf *config.Config, logger log.Logger) *nodeImpl {
 	t.Helper()
 	ctx, cancel := context.WithCancel(ctx)
@@ -191,10 +274,10 @@ func TestNodeSetPrivValTCP(t *testing.T) {
 	genDoc, err := defaultGenesisDocProviderFunc(cfg)()
 	require.NoError(t, err)
 
-	pval, err := createPrivval(ctx, logger, cfg, genDoc, nil)
+	pval, err := createPrivval(ctx, logger, cfg, genDoc, nil, privval.NopMetrics())
 	require.NoError(t, err)
 
-	assert.IsType(t, &privval.RetrySignerClient{}, pval)
+	assert.IsType(t, &privval.SignStateChecker{}, pval)
 }
 
 // address without a protocol must result in error
@@ -258,10 +341,10 @@ func TestNodeSetPrivValIPC(t *testing.T) {
 	genDoc, err := defaultGenesisDocProviderFunc(cfg)()
 	require.NoError(t, err)
 
-	pval, err := createPrivval(ctx, logger, cfg, genDoc, nil)
+	pval, err := createPrivval(ctx, logger, cfg, genDoc, nil, privval.NopMetrics())
 	require.NoError(t, err)
 
-	assert.IsType(t, &privval.RetrySignerClient{}, pval)
+	assert.IsType(t, &privval.SignStateChecker{}, pval)
 }
 
 // testFreeAddr claims a free port so we don't block on listener being ready.
@@ -599,6 +682,7 @@ func TestNodeNewSeedNode(t *testing.T) {
 	cfg, err := config.ResetTestRoot(t.TempDir(), "node_new_node_custom_reactors_test")
 	require.NoError(t, err)
 	cfg.Mode = config.ModeSeed
+	cfg.RPC.ListenAddress = "tcp://127.0.0.1:0"
 	defer os.RemoveAll(cfg.RootDir)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -627,6 +711,20 @@ func TestNodeNewSeedNode(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, n.pexReactor.IsRunning())
 
+	// The RPC server of the seed node only serves the network methods.
+	require.Len(t, n.rpcListeners, 1)
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	get := func(method string) string {
+		resp, err := client.Get("http://" + n.rpcListeners[0].Addr().String() + "/" + method)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	assert.Contains(t, get("net_topology"), `"n_nodes":"0"`)
+	assert.Contains(t, get("status"), "404 page not found")
+
 	cancel()
 	n.Wait()
 
@@ -791,3 +889,139 @@ func loadStatefromGenesis(ctx context.Context, t *testing.T) sm.State {
 
 	return state
 }
+
+func TestNextEventSeqBase(t *testing.T) {
+	cfg, err := config.ResetTestRoot(t.TempDir(), "event_seq_base")
+	require.NoError(t, err)
+
+	// each run numbers its events after the ones of the previous runs
+	for epoch := int64(1); epoch <= 3; epoch++ {
+		base, err := nextEventSeqBase(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, epoch<<eventSeqEpochBits, base)
+	}
+
+	require.NoError(t, os.WriteFile(filepath.Join(cfg.DBDir(), "event_seq_epoch"), []byte("garbage"), 0600))
+	_, err = nextEventSeqBase(cfg)
+	assert.Error(t, err)
+}
+
+func TestNodeReloadPeerFilter(t *testing.T) {
+	cfg, err := config.ResetTestRoot(t.TempDir(), "node_peer_filter_test")
+	require.NoError(t, err)
+	logger := log.NewNopLogger()
+
+	a := types.NodeID(strings.Repeat("a", 40))
+	b := types.NodeID(strings.Repeat("b", 40))
+	peerManager, err := p2p.NewPeerManager(types.NodeID(strings.Repeat("f", 40)), dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	require.NoError(t, peerManager.Accepted(a))
+	require.NoError(t, peerManager.Accepted(b))
+
+	reloader, err := createPeerFilter(logger, cfg, peerManager)
+	require.NoError(t, err)
+	require.NoError(t, reloader.filter.Filter(b, nil))
+
+	// Blocking a connected peer in the config file disconnects it on reload.
+	cfg.P2P.BlockedPeers = string(b)
+	require.NoError(t, config.WriteConfigFile(cfg.RootDir, cfg))
+	require.NoError(t, reloader.ReloadPeerFilter())
+	require.Error(t, reloader.filter.Filter(b, nil))
+	require.NoError(t, reloader.filter.Filter(a, nil))
+
+	evict, err := peerManager.TryEvictNext()
+	require.NoError(t, err)
+	require.Equal(t, b, evict)
+
+	// Invalid entries are rejected, keeping the filter unchanged.
+	cfg.P2P.BlockedPeers = "not-a-peer"
+	require.NoError(t, config.WriteConfigFile(cfg.RootDir, cfg))
+	require.Error(t, reloader.ReloadPeerFilter())
+	require.Error(t, reloader.filter.Filter(b, nil))
+}
+
+type testMempoolLimits struct {
+	maxTxs      int
+	maxTxsBytes int64
+}
+
+func (m *testMempoolLimits) SetLimits(maxTxs int, maxTxsBytes int64) {
+	m.maxTxs, m.maxTxsBytes = maxTxs, maxTxsBytes
+}
+
+func TestNodeReloadConfig(t *testing.T) {
+	cfg, err := config.ResetTestRoot(t.TempDir(), "node_config_reload_test")
+	require.NoError(t, err)
+	require.NoError(t, config.WriteConfigFile(cfg.RootDir, cfg))
+	logger, err := log.NewDefaultLogger(log.LogFormatPlain, log.LogLevelError)
+	require.NoError(t, err)
+
+	a := types.NodeID(strings.Repeat("a", 40))
+	peerManager, err := p2p.NewPeerManager(types.NodeID(strings.Repeat("f", 40)), dbm.NewMemDB(), p2p.PeerManagerOptions{})
+	require.NoError(t, err)
+	peerFilter, err := createPeerFilter(logger, cfg, peerManager)
+	require.NoError(t, err)
+	mp := &testMempoolLimits{}
+
+	// The node is given a setting by a flag, not in the config file.
+	nodeCfg := *cfg
+	nodeMempoolCfg := *cfg.Mempool
+	nodeMempoolCfg.MaxTxsBytes = 1 << 20
+	nodeCfg.Mempool = &nodeMempoolCfg
+	reloader := createConfigReloader(logger, &nodeCfg, peerFilter, peerManager, mp, &rpccore.Environment{})
+
+	// Nothing changed.
+	applied, requireRestart, err := reloader.ReloadConfig()
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.Empty(t, requireRestart)
+
+	// The reloadable settings are applied, the others take a restart.
+	logLevel := cfg.LogLevel
+	newCfg := *cfg
+	p2pCfg, mempoolCfg, rpcCfg := *cfg.P2P, *cfg.Mempool, *cfg.RPC
+	newCfg.P2P, newCfg.Mempool, newCfg.RPC = &p2pCfg, &mempoolCfg, &rpcCfg
+	newCfg.LogLevel = log.LogLevelError
+	newCfg.Mempool.Size = 10
+	newCfg.P2P.PersistentPeers = string(a) + "@127.0.0.1:26656"
+	newCfg.P2P.BlockedPeers = "10.0.0.0/8"
+	newCfg.RPC.RateLimit = 10
+	newCfg.Moniker = "reloaded"
+	require.NoError(t, config.WriteConfigFile(cfg.RootDir, &newCfg))
+
+	applied, requireRestart, err = reloader.ReloadConfig()
+	require.NoError(t, err)
+	require.Equal(t, []string{"log-level", "p2p.persistent-peers", "p2p.blocked-peers", "mempool.size"}, applied)
+	require.Equal(t, []string{"moniker", "rpc.rate-limit"}, requireRestart)
+	require.Equal(t, 10, mp.maxTxs)
+	require.Equal(t, int64(1<<20), mp.maxTxsBytes)
+	require.Equal(t, p2p.PeerScorePersistent, peerManager.Scores()[a])
+	require.Error(t, peerFilter.filter.Filter(a, net.ParseIP("10.0.0.1")))
+
+	// The config of the node is left unchanged, and the settings requiring a
+	// restart are reported until then.
+	require.Equal(t, logLevel, cfg.LogLevel)
+	applied, requireRestart, err = reloader.ReloadConfig()
+	require.NoError(t, err)
+	require.Empty(t, applied)
+	require.Equal(t, []string{"moniker", "rpc.rate-limit"}, requireRestart)
+
+	// An invalid config file is rejected.
+	newCfg.Mempool.Size = -1
+	require.NoError(t, config.WriteConfigFile(cfg.RootDir, &newCfg))
+	_, _, err = reloader.ReloadConfig()
+	require.Error(t, err)
+	require.Equal(t, 10, mp.maxTxs)
+
+	// The settings applied before a failure are reported with it.
+	newCfg.Mempool.Size = 10
+	newCfg.LogLevel = log.LogLevelInfo
+	newCfg.P2P.PersistentPeers = "not-a-peer"
+	newCfg.Mempool.MaxTxsBytes = 1 << 10
+	require.NoError(t, config.WriteConfigFile(cfg.RootDir, &newCfg))
+	applied, requireRestart, err = reloader.ReloadConfig()
+	require.Error(t, err)
+	require.Equal(t, []string{"log-level"}, applied)
+	require.Equal(t, []string{"moniker", "rpc.rate-limit"}, requireRestart)
+	require.Equal(t, int64(1<<20), mp.maxTxsBytes)
+}
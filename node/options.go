@@ -0,0 +1,91 @@
+package node
+
+import (
+	"context"
+
+	abciclient "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/internal/p2p"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Option customizes the services of a full node constructed by New.
+type Option func(*nodeOptions)
+
+type nodeOptions struct {
+	reactors   []ReactorConstructor
+	mempool    MempoolConstructor
+	blockStore *store.BlockStore
+	eventSinks []indexer.EventSink
+}
+
+// Components are the services of the node the constructors of the options
+// build on. Mempool and ConsensusState are only set for the constructors of
+// the reactors, which run after the services of the node are constructed.
+type Components struct {
+	Logger     log.Logger
+	Config     *config.Config
+	GenesisDoc *types.GenesisDoc
+
+	ProxyApp   abciclient.Client
+	StateStore sm.Store
+	BlockStore *store.BlockStore
+	EventBus   *eventbus.EventBus
+
+	PeerManager *p2p.PeerManager
+	OpenChannel p2p.ChannelCreator
+	PeerEvents  p2p.PeerEventSubscriber
+
+	Mempool        mempool.Mempool
+	ConsensusState *consensus.State
+}
+
+// ReactorConstructor constructs a reactor of the node, which is started
+// after the reactors of the node and stopped before them. The reactor opens
+// its channels with Components.OpenChannel when it starts.
+type ReactorConstructor func(Components) (service.Service, error)
+
+// MempoolConstructor constructs the mempool of the node instead of the
+// default one, and optionally its reactor. The mempool is responsible for
+// enabling the notifications of available txs if consensus waits for txs.
+type MempoolConstructor func(Components) (mempool.Mempool, service.Service, error)
+
+// WithReactor adds a custom reactor to the node.
+func WithReactor(constructor ReactorConstructor) Option {
+	return func(opts *nodeOptions) { opts.reactors = append(opts.reactors, constructor) }
+}
+
+// WithMempool replaces the mempool of the node.
+func WithMempool(constructor MempoolConstructor) Option {
+	return func(opts *nodeOptions) { opts.mempool = constructor }
+}
+
+// WithBlockStore replaces the block store of the node, which then does not
+// open the blockstore database. The caller owns the database of the block
+// store, which can't be compacted by the node: the option is incompatible with
+// storage.compact. NewBlockStore constructs the block store on a database.
+func WithBlockStore(blockStore *store.BlockStore) Option {
+	return func(opts *nodeOptions) { opts.blockStore = blockStore }
+}
+
+// WithEventSinks replaces the event sinks of the indexer of the node, which
+// are otherwise constructed from the tx-index config.
+func WithEventSinks(sinks ...indexer.EventSink) Option {
+	return func(opts *nodeOptions) { opts.eventSinks = sinks }
+}
+
+// Provider returns a service provider constructing nodes like NewDefault, with
+// the options, e.g. for the start command of a fork.
+func Provider(opts ...Option) config.ServiceProvider {
+	return func(ctx context.Context, conf *config.Config, logger log.Logger) (service.Service, error) {
+		return newDefaultNode(ctx, conf, logger, opts...)
+	}
+}
@@ -0,0 +1,79 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/config"
+	tmstrings "github.com/tendermint/tendermint/internal/libs/strings"
+	"github.com/tendermint/tendermint/internal/p2p"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+// peerFilterReloader reloads the allowed and blocked peers of the node from
+// its config file, and disconnects the connected peers no longer allowed.
+type peerFilterReloader struct {
+	logger      log.Logger
+	configFile  string
+	filter      *p2p.PeerFilter
+	peerManager *p2p.PeerManager
+}
+
+func createPeerFilter(
+	logger log.Logger,
+	cfg *config.Config,
+	peerManager *p2p.PeerManager,
+) (*peerFilterReloader, error) {
+	filter, err := p2p.NewPeerFilter(
+		tmstrings.SplitAndTrimEmpty(cfg.P2P.AllowedPeers, ",", " "),
+		tmstrings.SplitAndTrimEmpty(cfg.P2P.BlockedPeers, ",", " "),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &peerFilterReloader{
+		logger:      logger.With("module", "p2p"),
+		configFile:  cfg.ConfigFile(),
+		filter:      filter,
+		peerManager: peerManager,
+	}, nil
+}
+
+// ReloadPeerFilter reads the allowed-peers and blocked-peers settings from
+// the config file, and disconnects the connected peers they reject.
+func (r *peerFilterReloader) ReloadPeerFilter() error {
+	v := viper.New()
+	v.SetConfigFile(r.configFile)
+	if err := v.ReadInConfig(); err != nil {
+		return fmt.Errorf("reading %s: %w", r.configFile, err)
+	}
+	return r.update(v.GetString("p2p.allowed-peers"), v.GetString("p2p.blocked-peers"))
+}
+
+// update replaces the allowed and blocked peers with the comma-separated
+// lists given, as in the config file, and disconnects the connected peers
+// they reject.
+func (r *peerFilterReloader) update(allowedPeers, blockedPeers string) error {
+	allowed := tmstrings.SplitAndTrimEmpty(allowedPeers, ",", " ")
+	blocked := tmstrings.SplitAndTrimEmpty(blockedPeers, ",", " ")
+	if err := r.filter.Update(allowed, blocked); err != nil {
+		return err
+	}
+	r.logger.Info("reloaded peer filter", "allowed", len(allowed), "blocked", len(blocked))
+
+	for _, id := range r.peerManager.Peers() {
+		if err := r.filterPeer(id); err != nil {
+			r.logger.Info("disconnecting peer rejected by peer filter", "peer", id, "err", err)
+			r.peerManager.Errored(id, err)
+		}
+	}
+	return nil
+}
+
+// filterPeer filters a connected peer by the IP of its connection, rather
+// than by the other addresses it may be known at.
+func (r *peerFilterReloader) filterPeer(id types.NodeID) error {
+	return r.filter.Filter(id, r.peerManager.RemoteIP(id))
+}
@@ -7,9 +7,15 @@ import (
 
 	abciclient "github.com/tendermint/tendermint/abci/client"
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/internal/p2p"
+	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
-	"github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/types"
 )
 
@@ -30,13 +36,15 @@ func NewDefault(
 // process as the tendermint node.  The final option is a pointer to a
 // Genesis document: if the value is nil, the genesis document is read
 // from the file specified in the config, and otherwise the node uses
-// value of the final argument.
+// value of the final argument. The options customize the services of a
+// full node, which implements Node; they are ignored by seed nodes.
 func New(
 	ctx context.Context,
 	conf *config.Config,
 	logger log.Logger,
 	cf abciclient.Client,
 	gen *types.GenesisDoc,
+	opts ...Option,
 ) (service.Service, error) {
 	nodeKey, err := types.LoadOrGenNodeKey(conf.NodeKeyFile())
 	if err != nil {
@@ -53,7 +61,7 @@ func New(
 
 	switch conf.Mode {
 	case config.ModeFull, config.ModeValidator:
-		pval, err := privval.LoadOrGenFilePV(conf.PrivValidator.KeyFile(), conf.PrivValidator.StateFile())
+		pval, err := loadOrGenFilePV(conf.PrivValidator)
 		if err != nil {
 			return nil, err
 		}
@@ -64,12 +72,33 @@ func New(
 			pval,
 			nodeKey,
 			cf,
+			nil,
 			genProvider,
 			config.DefaultDBProvider,
-			logger)
+			logger,
+			opts...)
 	case config.ModeSeed:
 		return makeSeedNode(logger, conf, config.DefaultDBProvider, nodeKey, genProvider)
 	default:
 		return nil, fmt.Errorf("%q is not a valid mode", conf.Mode)
 	}
 }
+
+// Node is a full node constructed by New or NewDefault, with the getters of
+// its services.
+type Node interface {
+	service.Service
+
+	NodeInfo() *types.NodeInfo
+	GenesisDoc() *types.GenesisDoc
+	EventBus() *eventbus.EventBus
+	Router() *p2p.Router
+	PeerManager() *p2p.PeerManager
+	Mempool() mempool.Mempool
+	BlockStore() *store.BlockStore
+	StateStore() sm.Store
+	ConsensusState() *consensus.State
+	RPCEnvironment() *rpccore.Environment
+}
+
+var _ Node = (*nodeImpl)(nil)
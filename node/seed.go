@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/pex"
+	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/libs/service"
@@ -28,13 +30,18 @@ type seedNodeImpl struct {
 
 	// network
 	peerManager *p2p.PeerManager
+	peerFilter  *peerFilterReloader
 	router      *p2p.Router
 	nodeKey     types.NodeKey // our node privkey
 	isListening bool
 
 	// services
-	pexReactor  service.Service // for exchanging peer addresses
-	shutdownOps closer
+	pexReactor    service.Service // for exchanging peer addresses
+	rpcEnv        *rpccore.Environment
+	rpcListeners  []net.Listener
+	reloader      *configReloader
+	prometheusSrv *http.Server
+	shutdownOps   closer
 }
 
 // makeSeedNode returns a new seed node, containing only p2p, pex reactor
@@ -66,6 +73,7 @@ func makeSeedNode(
 
 	// Setup Transport and Switch.
 	p2pMetrics := p2p.PrometheusMetrics(cfg.Instrumentation.Namespace, "chain_id", genDoc.ChainID)
+	pexMetrics := pex.PrometheusMetrics(cfg.Instrumentation.Namespace, "chain_id", genDoc.ChainID)
 
 	peerManager, closer, err := createPeerManager(cfg, dbProvider, nodeKey.ID, p2pMetrics)
 	if err != nil {
@@ -74,13 +82,23 @@ func makeSeedNode(
 			closer)
 	}
 
-	router, err := createRouter(logger, p2pMetrics, func() *types.NodeInfo { return &nodeInfo }, nodeKey, peerManager, cfg, nil)
+	peerFilter, err := createPeerFilter(logger, cfg, peerManager)
+	if err != nil {
+		return nil, combineCloseError(
+			fmt.Errorf("failed to create peer filter: %w", err),
+			closer)
+	}
+
+	router, err := createRouter(logger, p2pMetrics, func() *types.NodeInfo { return &nodeInfo }, nodeKey, peerManager, peerFilter.filter, cfg, nil)
 	if err != nil {
 		return nil, combineCloseError(
 			fmt.Errorf("failed to create router: %w", err),
 			closer)
 	}
 
+	// Seed nodes crawl the network, recording its topology.
+	topology := pex.NewTopology(pexMetrics)
+
 	node := &seedNodeImpl{
 		config:     cfg,
 		logger:     logger,
@@ -88,12 +106,22 @@ func makeSeedNode(
 
 		nodeKey:     nodeKey,
 		peerManager: peerManager,
+		peerFilter:  peerFilter,
 		router:      router,
 
 		shutdownOps: closer,
 
-		pexReactor: pex.NewReactor(logger, peerManager, router.OpenChannel, peerManager.Subscribe),
+		pexReactor: pex.NewReactor(logger, peerManager, router.OpenChannel, peerManager.Subscribe,
+			pex.WithTopology(topology), pex.WithCrawling()),
+		rpcEnv: &rpccore.Environment{
+			PeerManager: peerManager,
+			Topology:    topology,
+			NodeInfo:    nodeInfo,
+			Logger:      logger.With("module", "rpc"),
+			Config:      *cfg.RPC,
+		},
 	}
+	node.reloader = createConfigReloader(logger, cfg, peerFilter, peerManager, nil, node.rpcEnv)
 	node.BaseService = *service.NewBaseService(logger, "SeedNode", node)
 
 	return node, nil
@@ -131,11 +159,16 @@ func (n *seedNodeImpl) OnStart(ctx context.Context) error {
 		time.Sleep(genTime.Sub(now))
 	}
 
+	if n.config.Instrumentation.Prometheus && n.config.Instrumentation.PrometheusListenAddr != "" {
+		n.prometheusSrv = startPrometheusServer(ctx, n.logger, n.config.Instrumentation)
+	}
+
 	// Start the transport.
 	if err := n.router.Start(ctx); err != nil {
 		return err
 	}
 	n.isListening = true
+	n.rpcEnv.IsListening = true
 
 	if n.config.P2P.PexReactor {
 		if err := n.pexReactor.Start(ctx); err != nil {
@@ -143,6 +176,16 @@ func (n *seedNodeImpl) OnStart(ctx context.Context) error {
 		}
 	}
 
+	// The RPC server of seed nodes only serves the network methods.
+	if n.config.RPC.ListenAddress != "" {
+		var err error
+		n.rpcListeners, err = n.rpcEnv.StartNetworkService(ctx, n.config)
+		if err != nil {
+			return err
+		}
+	}
+	go n.reloader.run(ctx)
+
 	return nil
 }
 
@@ -153,6 +196,21 @@ func (n *seedNodeImpl) OnStop() {
 	n.pexReactor.Wait()
 	n.router.Wait()
 	n.isListening = false
+	n.rpcEnv.IsListening = false
+
+	for _, l := range n.rpcListeners {
+		n.logger.Info("Closing rpc listener", "listener", l)
+		if err := l.Close(); err != nil {
+			n.logger.Error("error closing listener", "listener", l, "err", err)
+		}
+	}
+
+	if n.prometheusSrv != nil {
+		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
+			// Error from closing listeners, or context timeout:
+			n.logger.Error("Prometheus HTTP server Shutdown", "err", err)
+		}
+	}
 
 	if err := n.shutdownOps(); err != nil {
 		if strings.TrimSpace(err.Error()) != "" {
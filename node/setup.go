@@ -5,6 +5,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,16 +21,20 @@ import (
 	"github.com/tendermint/tendermint/internal/eventbus"
 	"github.com/tendermint/tendermint/internal/evidence"
 	tmstrings "github.com/tendermint/tendermint/internal/libs/strings"
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/p2p/conn"
+	"github.com/tendermint/tendermint/internal/p2p/nat"
 	"github.com/tendermint/tendermint/internal/p2p/pex"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/statesync"
 	"github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/internal/trace"
 	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
+	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/libs/service"
 	"github.com/tendermint/tendermint/privval"
 	tmgrpc "github.com/tendermint/tendermint/privval/grpc"
@@ -74,24 +81,30 @@ func combineCloseError(err error, cl closer) error {
 func initDBs(
 	cfg *config.Config,
 	dbProvider config.DBProvider,
-) (*store.BlockStore, dbm.DB, closer, error) {
+	blockStore *store.BlockStore,
+) (*store.BlockStore, dbm.DB, dbm.DB, closer, error) {
 
-	blockStoreDB, err := dbProvider(&config.DBContext{ID: "blockstore", Config: cfg})
-	if err != nil {
-		return nil, nil, func() error { return nil }, fmt.Errorf("unable to initialize blockstore: %w", err)
-	}
+	// the database of a block store passed in is owned by the caller
+	var blockStoreDB dbm.DB
 	closers := []closer{}
-	blockStore := store.NewBlockStore(blockStoreDB)
-	closers = append(closers, blockStoreDB.Close)
+	if blockStore == nil {
+		var err error
+		blockStoreDB, err = dbProvider(&config.DBContext{ID: "blockstore", Config: cfg})
+		if err != nil {
+			return nil, nil, nil, func() error { return nil }, fmt.Errorf("unable to initialize blockstore: %w", err)
+		}
+		blockStore = store.NewBlockStore(blockStoreDB)
+		closers = append(closers, blockStoreDB.Close)
+	}
 
 	stateDB, err := dbProvider(&config.DBContext{ID: "state", Config: cfg})
 	if err != nil {
-		return nil, nil, makeCloser(closers), fmt.Errorf("unable to initialize statestore: %w", err)
+		return nil, nil, nil, makeCloser(closers), fmt.Errorf("unable to initialize statestore: %w", err)
 	}
 
 	closers = append(closers, stateDB.Close)
 
-	return blockStore, stateDB, makeCloser(closers), nil
+	return blockStore, blockStoreDB, stateDB, makeCloser(closers), nil
 }
 
 func logNodeStartupInfo(state sm.State, pubKey crypto.PubKey, logger log.Logger, mode string) {
@@ -143,8 +156,10 @@ func createMempoolReactor(
 	logger log.Logger,
 	cfg *config.Config,
 	appClient abciclient.Client,
+	recheckClients []abciclient.Client,
 	store sm.Store,
 	memplMetrics *mempool.Metrics,
+	eventBus *eventbus.EventBus,
 	peerEvents p2p.PeerEventSubscriber,
 	chCreator p2p.ChannelCreator,
 ) (service.Service, mempool.Mempool) {
@@ -157,6 +172,15 @@ func createMempoolReactor(
 		mempool.WithMetrics(memplMetrics),
 		mempool.WithPreCheck(sm.TxPreCheckFromStore(store)),
 		mempool.WithPostCheck(sm.TxPostCheckFromStore(store)),
+		mempool.WithRecheckClients(recheckClients...),
+		mempool.WithEvictedFunc(func(tx types.Tx, reason string) {
+			if err := eventBus.PublishEventEvictedTx(types.EventDataEvictedTx{
+				Tx:     tx,
+				Reason: reason,
+			}); err != nil {
+				logger.Error("failed publishing evicted tx event", "err", err)
+			}
+		}),
 	)
 
 	reactor := mempool.NewReactor(
@@ -192,7 +216,8 @@ func createEvidenceReactor(
 
 	logger = logger.With("module", "evidence")
 
-	evidencePool := evidence.NewPool(logger, evidenceDB, store, blockStore, metrics, eventBus)
+	evidencePool := evidence.NewPool(logger, evidenceDB, store, blockStore, metrics, eventBus,
+		evidence.WithVerificationWorkers(cfg.Consensus.VerificationWorkers()))
 	evidenceReactor := evidence.NewReactor(logger, chCreator, peerEvents, evidencePool)
 
 	return evidenceReactor, evidencePool, evidenceDB.Close, nil
@@ -205,9 +230,13 @@ func createPeerManager(
 	metrics *p2p.Metrics,
 ) (*p2p.PeerManager, closer, error) {
 
-	selfAddr, err := p2p.ParseNodeAddress(nodeID.AddressString(cfg.P2P.ExternalAddress))
-	if err != nil {
-		return nil, func() error { return nil }, fmt.Errorf("couldn't parse ExternalAddress %q: %w", cfg.P2P.ExternalAddress, err)
+	var selfAddrs []p2p.NodeAddress
+	for _, addr := range cfg.P2P.ExternalAddresses() {
+		selfAddr, err := p2p.ParseNodeAddress(nodeID.AddressString(addr))
+		if err != nil {
+			return nil, func() error { return nil }, fmt.Errorf("couldn't parse ExternalAddress %q: %w", addr, err)
+		}
+		selfAddrs = append(selfAddrs, selfAddr)
 	}
 
 	privatePeerIDs := make(map[types.NodeID]struct{})
@@ -235,18 +264,19 @@ func createPeerManager(
 	maxUpgradeConns := uint16(4)
 
 	options := p2p.PeerManagerOptions{
-		SelfAddress:              selfAddr,
-		MaxConnected:             maxConns,
-		MaxOutgoingConnections:   maxOutgoingConns,
-		MaxConnectedUpgrade:      maxUpgradeConns,
-		DisconnectCooldownPeriod: 2 * time.Second,
-		MaxPeers:                 maxUpgradeConns + 4*maxConns,
-		MinRetryTime:             250 * time.Millisecond,
-		MaxRetryTime:             30 * time.Minute,
-		MaxRetryTimePersistent:   5 * time.Minute,
-		RetryTimeJitter:          5 * time.Second,
-		PrivatePeers:             privatePeerIDs,
-		Metrics:                  metrics,
+		SelfAddresses:              selfAddrs,
+		MaxConnected:               maxConns,
+		MaxOutgoingConnections:     maxOutgoingConns,
+		MaxConnectedUpgrade:        maxUpgradeConns,
+		DisconnectCooldownPeriod:   2 * time.Second,
+		MaxPeers:                   maxUpgradeConns + 4*maxConns,
+		MinRetryTime:               250 * time.Millisecond,
+		MaxRetryTime:               30 * time.Minute,
+		MaxRetryTimePersistent:     5 * time.Minute,
+		MaxRetryTimePrivatePeering: 30 * time.Second,
+		RetryTimeJitter:            5 * time.Second,
+		PrivatePeers:               privatePeerIDs,
+		Metrics:                    metrics,
 	}
 
 	peers := []p2p.NodeAddress{}
@@ -260,6 +290,14 @@ func createPeerManager(
 		options.PersistentPeers = append(options.PersistentPeers, address.NodeID)
 	}
 
+	for _, id := range tmstrings.SplitAndTrimEmpty(cfg.P2P.PrivatePeeringIDs, ",", " ") {
+		nodeID, err := types.NewNodeID(id)
+		if err != nil {
+			return nil, func() error { return nil }, fmt.Errorf("invalid private peering ID %q: %w", id, err)
+		}
+		options.PrivatePeeringPeers = append(options.PrivatePeeringPeers, nodeID)
+	}
+
 	for _, p := range tmstrings.SplitAndTrimEmpty(cfg.P2P.BootstrapPeers, ",", " ") {
 		address, err := p2p.ParseNodeAddress(p)
 		if err != nil {
@@ -287,12 +325,61 @@ func createPeerManager(
 	return peerManager, peerDB.Close, nil
 }
 
+// channelSendRates returns the send rates of the channels of the reactors
+// configured with a send rate.
+func channelSendRates(cfg *config.P2PConfig) map[p2p.ChannelID]int64 {
+	rates := map[p2p.ChannelID]int64{}
+	set := func(rate int64, chIDs ...p2p.ChannelID) {
+		if rate == 0 {
+			return
+		}
+		for _, chID := range chIDs {
+			rates[chID] = rate
+		}
+	}
+	set(cfg.ConsensusSendRate,
+		consensus.StateChannel, consensus.DataChannel, consensus.VoteChannel, consensus.VoteSetBitsChannel)
+	set(cfg.MempoolSendRate, mempool.MempoolChannel)
+	set(cfg.BlockSyncSendRate, blocksync.BlockSyncChannel)
+	set(cfg.EvidenceSendRate, evidence.EvidenceChannel)
+	return rates
+}
+
+// createTracer returns the tracer exporting the spans of the node to the
+// configured collector, or a nop tracer if there is none.
+func createTracer(logger log.Logger, cfg *config.InstrumentationConfig, chainID string, nodeID types.NodeID) *trace.Tracer {
+	if cfg.TraceEndpoint == "" {
+		return trace.NopTracer()
+	}
+	return trace.NewTracer(logger.With("module", "trace"), cfg.TraceEndpoint, cfg.TraceSampleRate, chainID,
+		trace.String("service.name", "tendermint"),
+		trace.String("service.version", version.TMVersion),
+		trace.String("service.instance.id", string(nodeID)),
+		trace.String("tendermint.chain_id", chainID),
+	)
+}
+
+func createNATDetector(
+	logger log.Logger,
+	cfg *config.Config,
+	nodeID types.NodeID,
+	peerManager *p2p.PeerManager,
+) (*nat.Detector, error) {
+	// the port mapped on the NAT gateway is the one of the first address
+	ep, err := p2p.NewEndpoint(nodeID.AddressString(firstAddress(cfg.P2P.ListenAddresses())))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse ListenAddress %q: %w", cfg.P2P.ListenAddress, err)
+	}
+	return nat.NewDetector(logger.With("module", "nat"), firstAddress(cfg.P2P.ExternalAddresses()), int(ep.Port), peerManager.ObservedIP), nil
+}
+
 func createRouter(
 	logger log.Logger,
 	p2pMetrics *p2p.Metrics,
 	nodeInfoProducer func() *types.NodeInfo,
 	nodeKey types.NodeKey,
 	peerManager *p2p.PeerManager,
+	peerFilter *p2p.PeerFilter,
 	cfg *config.Config,
 	appClient abciclient.Client,
 ) (*p2p.Router, error) {
@@ -304,6 +391,8 @@ func createRouter(
 	transportConf.SendRate = cfg.P2P.SendRate
 	transportConf.RecvRate = cfg.P2P.RecvRate
 	transportConf.MaxPacketMsgPayloadSize = cfg.P2P.MaxPacketMsgPayloadSize
+	transportConf.ChannelSendRates = channelSendRates(cfg.P2P)
+	transportConf.StrictChannelPriority = cfg.P2P.StrictChannelPriority
 	transport := p2p.NewMConnTransport(
 		p2pLogger, transportConf, []*p2p.ChannelDescriptor{},
 		p2p.MConnTransportOptions{
@@ -311,11 +400,18 @@ func createRouter(
 		},
 	)
 
-	ep, err := p2p.NewEndpoint(nodeKey.ID.AddressString(cfg.P2P.ListenAddress))
-	if err != nil {
-		return nil, err
+	var endpoints []*p2p.Endpoint
+	for _, addr := range cfg.P2P.ListenAddresses() {
+		ep, err := p2p.NewEndpoint(nodeKey.ID.AddressString(addr))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse ListenAddress %q: %w", addr, err)
+		}
+		endpoints = append(endpoints, ep)
 	}
 
+	opts := getRouterConfig(cfg, appClient)
+	opts.PeerFilter = peerFilter
+
 	return p2p.NewRouter(
 		p2pLogger,
 		p2pMetrics,
@@ -323,11 +419,29 @@ func createRouter(
 		peerManager,
 		nodeInfoProducer,
 		transport,
-		ep,
-		getRouterConfig(cfg, appClient),
+		endpoints,
+		opts,
 	)
 }
 
+// nodeListenAddr returns the address of the node info, which has a single one:
+// the first external address, else the first listen address. The other
+// addresses are advertised by PEX.
+func nodeListenAddr(cfg *config.P2PConfig) string {
+	if addr := firstAddress(cfg.ExternalAddresses()); addr != "" {
+		return addr
+	}
+	return firstAddress(cfg.ListenAddresses())
+}
+
+// firstAddress returns the first of the addresses, or "" if there are none.
+func firstAddress(addrs []string) string {
+	if len(addrs) == 0 {
+		return ""
+	}
+	return addrs[0]
+}
+
 func makeNodeInfo(
 	cfg *config.Config,
 	nodeKey types.NodeKey,
@@ -375,10 +489,13 @@ func makeNodeInfo(
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
-	nodeInfo.ListenAddr = cfg.P2P.ExternalAddress
-	if nodeInfo.ListenAddr == "" {
-		nodeInfo.ListenAddr = cfg.P2P.ListenAddress
+	nodeInfo.ListenAddr = nodeListenAddr(cfg.P2P)
+
+	rotation, err := loadNodeKeyRotation(cfg, nodeKey)
+	if err != nil {
+		return nodeInfo, err
 	}
+	nodeInfo.KeyRotation = rotation
 
 	return nodeInfo, nodeInfo.Validate()
 }
@@ -408,21 +525,75 @@ func makeSeedNodeInfo(
 		},
 	}
 
-	nodeInfo.ListenAddr = cfg.P2P.ExternalAddress
-	if nodeInfo.ListenAddr == "" {
-		nodeInfo.ListenAddr = cfg.P2P.ListenAddress
+	nodeInfo.ListenAddr = nodeListenAddr(cfg.P2P)
+
+	rotation, err := loadNodeKeyRotation(cfg, nodeKey)
+	if err != nil {
+		return nodeInfo, err
 	}
+	nodeInfo.KeyRotation = rotation
 
 	return nodeInfo, nodeInfo.Validate()
 }
 
+// loadNodeKeyRotation loads the record of the last change of the node key, if
+// any, for the node to advertise to its peers. The record of a change to an
+// earlier key is not advertised.
+func loadNodeKeyRotation(cfg *config.Config, nodeKey types.NodeKey) (*types.NodeKeyRotation, error) {
+	path := cfg.NodeKeyRotationFile()
+	if !tmos.FileExists(path) {
+		return nil, nil
+	}
+	rotation, err := types.LoadNodeKeyRotation(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the node key rotation: %w", err)
+	}
+	if rotation.NewID() != nodeKey.ID {
+		return nil, nil
+	}
+	return rotation, nil
+}
+
+// eventSeqEpochBits is the number of bits of the event sequence numbers
+// counting the events of a run of the node, below its epoch.
+const eventSeqEpochBits = 40
+
+// nextEventSeqBase increments the epoch persisted in the data directory,
+// counting the runs of the node, and returns the sequence number after which
+// the events of this run are numbered. The sequence numbers then keep
+// increasing across restarts, so that a subscription replay after a sequence
+// number of an earlier run is rejected instead of returning the wrong events.
+func nextEventSeqBase(cfg *config.Config) (int64, error) {
+	path := filepath.Join(cfg.DBDir(), "event_seq_epoch")
+	var epoch int64
+	if bz, err := os.ReadFile(path); err == nil {
+		if epoch, err = strconv.ParseInt(strings.TrimSpace(string(bz)), 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid event sequence epoch in %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return 0, fmt.Errorf("failed to read the event sequence epoch: %w", err)
+	}
+	epoch++
+	if epoch >= 1<<(63-eventSeqEpochBits) {
+		return 0, fmt.Errorf("event sequence epoch %d overflows", epoch)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return 0, err
+	}
+	if err := tempfile.WriteFileAtomic(path, []byte(strconv.FormatInt(epoch, 10)), 0600); err != nil {
+		return 0, fmt.Errorf("failed to write the event sequence epoch: %w", err)
+	}
+	return epoch << eventSeqEpochBits, nil
+}
+
 func createAndStartPrivValidatorSocketClient(
 	ctx context.Context,
-	listenAddr, chainID string,
+	listenAddr, chainID, stateFilePath string,
 	logger log.Logger,
+	metrics *privval.Metrics,
 ) (types.PrivValidator, error) {
 
-	pve, err := privval.NewSignerListener(listenAddr, logger)
+	pve, err := privval.NewSignerListener(listenAddr, logger, privval.SignerListenerEndpointMetrics(metrics))
 	if err != nil {
 		return nil, fmt.Errorf("starting validator listener: %w", err)
 	}
@@ -445,7 +616,17 @@ func createAndStartPrivValidatorSocketClient(
 	)
 	pvscWithRetries := privval.NewRetrySignerClient(pvsc, retries, timeout)
 
-	return pvscWithRetries, nil
+	// refuse to start if the remote signer is behind the last sign state of
+	// the node, as it could then sign conflicting votes
+	pvscWithChecks, err := privval.NewSignStateChecker(pvscWithRetries, stateFilePath, logger, metrics)
+	if err != nil {
+		return nil, fmt.Errorf("can't load sign state: %w", err)
+	}
+	if err := pvscWithChecks.Check(ctx); err != nil {
+		return nil, err
+	}
+
+	return pvscWithChecks, nil
 }
 
 func createAndStartPrivValidatorGRPCClient(
@@ -476,7 +657,7 @@ func createAndStartPrivValidatorGRPCClient(
 
 func makeDefaultPrivval(conf *config.Config) (*privval.FilePV, error) {
 	if conf.Mode == config.ModeValidator {
-		pval, err := privval.LoadOrGenFilePV(conf.PrivValidator.KeyFile(), conf.PrivValidator.StateFile())
+		pval, err := loadOrGenFilePV(conf.PrivValidator)
 		if err != nil {
 			return nil, err
 		}
@@ -486,7 +667,14 @@ func makeDefaultPrivval(conf *config.Config) (*privval.FilePV, error) {
 	return nil, nil
 }
 
-func createPrivval(ctx context.Context, logger log.Logger, conf *config.Config, genDoc *types.GenesisDoc, defaultPV *privval.FilePV) (types.PrivValidator, error) {
+func createPrivval(
+	ctx context.Context,
+	logger log.Logger,
+	conf *config.Config,
+	genDoc *types.GenesisDoc,
+	defaultPV *privval.FilePV,
+	metrics *privval.Metrics,
+) (types.PrivValidator, error) {
 	if conf.PrivValidator.ListenAddr != "" {
 		protocol, _ := tmnet.ProtocolAndAddress(conf.PrivValidator.ListenAddr)
 		// FIXME: we should return un-started services and
@@ -503,7 +691,9 @@ func createPrivval(ctx context.Context, logger log.Logger, conf *config.Config,
 				ctx,
 				conf.PrivValidator.ListenAddr,
 				genDoc.ChainID,
+				conf.PrivValidator.StateFile(),
 				logger,
+				metrics,
 			)
 			if err != nil {
 				return nil, fmt.Errorf("error with private validator socket client: %w", err)
@@ -515,3 +705,24 @@ func createPrivval(ctx context.Context, logger log.Logger, conf *config.Config,
 
 	return defaultPV, nil
 }
+
+// loadOrGenFilePV loads the FilePV of the config, decrypting its key file with
+// the passphrase of the config if it is encrypted, or else generates a new one.
+func loadOrGenFilePV(conf *config.PrivValidatorConfig) (*privval.FilePV, error) {
+	encrypted, err := privval.IsEncryptedKeyFile(conf.KeyFile())
+	if err != nil {
+		return nil, err
+	}
+	if !encrypted {
+		return privval.LoadOrGenFilePV(conf.KeyFile(), conf.StateFile())
+	}
+
+	passphrase, err := privval.ReadKeyPassphrase(conf.PassphraseFile(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return privval.LoadFilePVWithProvider(
+		&privval.EncryptedKeyFile{Path: conf.KeyFile(), Passphrase: passphrase},
+		conf.StateFile(),
+	)
+}
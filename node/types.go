@@ -0,0 +1,52 @@
+package node
+
+import (
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/consensus"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/internal/mempool"
+	"github.com/tendermint/tendermint/internal/p2p"
+	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/indexer"
+	"github.com/tendermint/tendermint/internal/store"
+)
+
+// The types of the services of the node and of their options, which are
+// defined by internal packages: programs running the node as a library, which
+// can't import these packages, name them with the aliases of this package.
+type (
+	EventBus       = eventbus.EventBus
+	Mempool        = mempool.Mempool
+	ConsensusState = consensus.State
+	StateStore     = sm.Store
+	BlockStore     = store.BlockStore
+	EventSink      = indexer.EventSink
+	RPCEnvironment = rpccore.Environment
+
+	Router              = p2p.Router
+	PeerManager         = p2p.PeerManager
+	ChannelCreator      = p2p.ChannelCreator
+	Channel             = p2p.Channel
+	ChannelDescriptor   = p2p.ChannelDescriptor
+	ChannelID           = p2p.ChannelID
+	ChannelIterator     = p2p.ChannelIterator
+	Envelope            = p2p.Envelope
+	PeerError           = p2p.PeerError
+	PeerEventSubscriber = p2p.PeerEventSubscriber
+	PeerUpdates         = p2p.PeerUpdates
+	PeerUpdate          = p2p.PeerUpdate
+	PeerStatus          = p2p.PeerStatus
+)
+
+// The statuses of the peer updates.
+const (
+	PeerStatusUp   = p2p.PeerStatusUp
+	PeerStatusDown = p2p.PeerStatusDown
+)
+
+// NewBlockStore returns a block store on db, e.g. for WithBlockStore.
+func NewBlockStore(db dbm.DB) *BlockStore {
+	return store.NewBlockStore(db)
+}
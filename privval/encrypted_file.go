@@ -0,0 +1,250 @@
+package privval
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/tendermint/tendermint/internal/libs/tempfile"
+)
+
+// SignerProvider stores the key of a FilePV, so that the key can be kept by a
+// key management system rather than in a plaintext file.
+type SignerProvider interface {
+	// LoadKey loads the key.
+	LoadKey() (FilePVKey, error)
+	// SaveKey stores the key.
+	SaveKey(FilePVKey) error
+}
+
+const (
+	kdfArgon2id     = "argon2id"
+	cipherAES256GCM = "aes-256-gcm"
+
+	// the argon2id parameters recommended by RFC 9106 for memory constrained
+	// environments
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024 // KiB
+	argon2Threads = 4
+	argon2KeyLen  = 32
+	argon2SaltLen = 16
+
+	// the bounds of the argon2id parameters of the key files, so that a
+	// corrupted key file can't hang the node or run it out of memory
+	maxArgon2Time    = 64
+	maxArgon2Memory  = 4 * 1024 * 1024 // KiB
+	minArgon2SaltLen = 8
+)
+
+// ErrWrongPassphrase occurs when decrypting an encrypted key file with a
+// wrong passphrase.
+var ErrWrongPassphrase = errors.New("wrong passphrase or corrupted key file")
+
+// EncryptedKeyFile is a SignerProvider storing the key in a file, encrypted
+// with AES-256-GCM under a key derived from a passphrase with argon2id.
+type EncryptedKeyFile struct {
+	Path       string
+	Passphrase []byte
+}
+
+var _ SignerProvider = (*EncryptedKeyFile)(nil)
+
+type encryptedKeyJSON struct {
+	KDF        string `json:"kdf"`
+	Salt       []byte `json:"salt"`
+	Time       uint32 `json:"time"`
+	Memory     uint32 `json:"memory"`
+	Threads    uint8  `json:"threads"`
+	Cipher     string `json:"cipher"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// LoadKey decrypts the key file. It returns ErrWrongPassphrase if the
+// passphrase is wrong.
+func (f *EncryptedKeyFile) LoadKey() (FilePVKey, error) {
+	bz, err := os.ReadFile(f.Path)
+	if err != nil {
+		return FilePVKey{}, err
+	}
+	plaintext, err := DecryptKey(bz, f.Passphrase)
+	if err != nil {
+		return FilePVKey{}, fmt.Errorf("error decrypting PrivValidator key from %v: %w", f.Path, err)
+	}
+	var pvKey FilePVKey
+	if err := json.Unmarshal(plaintext, &pvKey); err != nil {
+		return FilePVKey{}, fmt.Errorf("error reading PrivValidator key from %v: %w", f.Path, err)
+	}
+	return pvKey, nil
+}
+
+// SaveKey encrypts the key to the key file.
+func (f *EncryptedKeyFile) SaveKey(pvKey FilePVKey) error {
+	plaintext, err := json.MarshalIndent(pvKey, "", "  ")
+	if err != nil {
+		return err
+	}
+	bz, err := EncryptKey(plaintext, f.Passphrase)
+	if err != nil {
+		return err
+	}
+	return tempfile.WriteFileAtomic(f.Path, bz, 0600)
+}
+
+// EncryptKey encrypts the plaintext JSON of a key file with the passphrase,
+// and returns the JSON of the encrypted key file.
+func EncryptKey(plaintext, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	ek := encryptedKeyJSON{
+		KDF:     kdfArgon2id,
+		Salt:    make([]byte, argon2SaltLen),
+		Time:    argon2Time,
+		Memory:  argon2Memory,
+		Threads: argon2Threads,
+		Cipher:  cipherAES256GCM,
+	}
+	if _, err := rand.Read(ek.Salt); err != nil {
+		return nil, err
+	}
+	aead, err := ek.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	ek.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(ek.Nonce); err != nil {
+		return nil, err
+	}
+	ek.Ciphertext = aead.Seal(nil, ek.Nonce, plaintext, nil)
+	return json.MarshalIndent(ek, "", "  ")
+}
+
+// DecryptKey decrypts the JSON of an encrypted key file with the passphrase,
+// and returns the plaintext JSON of the key file. It returns
+// ErrWrongPassphrase if the passphrase is wrong.
+func DecryptKey(bz, passphrase []byte) ([]byte, error) {
+	var ek encryptedKeyJSON
+	if err := json.Unmarshal(bz, &ek); err != nil {
+		return nil, err
+	}
+	if ek.KDF != kdfArgon2id {
+		return nil, fmt.Errorf("unsupported key derivation function %q", ek.KDF)
+	}
+	if ek.Cipher != cipherAES256GCM {
+		return nil, fmt.Errorf("unsupported cipher %q", ek.Cipher)
+	}
+	aead, err := ek.aead(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(ek.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce size %d", len(ek.Nonce))
+	}
+	plaintext, err := aead.Open(nil, ek.Nonce, ek.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	return plaintext, nil
+}
+
+// validateKDF returns an error if the argon2id parameters of the key file are
+// out of bounds.
+func (ek encryptedKeyJSON) validateKDF() error {
+	switch {
+	case ek.Time < 1 || ek.Time > maxArgon2Time:
+		return fmt.Errorf("argon2 time %d must be between 1 and %d", ek.Time, maxArgon2Time)
+	case ek.Memory < 1 || ek.Memory > maxArgon2Memory:
+		return fmt.Errorf("argon2 memory %d KiB must be between 1 and %d KiB", ek.Memory, maxArgon2Memory)
+	case ek.Threads < 1:
+		return errors.New("argon2 threads must be at least 1")
+	case len(ek.Salt) < minArgon2SaltLen:
+		return fmt.Errorf("argon2 salt of %d bytes must be at least %d bytes", len(ek.Salt), minArgon2SaltLen)
+	}
+	return nil
+}
+
+// aead returns the cipher of the key file, with the key derived from the
+// passphrase.
+func (ek encryptedKeyJSON) aead(passphrase []byte) (cipher.AEAD, error) {
+	if err := ek.validateKDF(); err != nil {
+		return nil, fmt.Errorf("invalid key file: %w", err)
+	}
+	key := argon2.IDKey(passphrase, ek.Salt, ek.Time, ek.Memory, ek.Threads, argon2KeyLen)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsEncryptedKeyFile reports whether the key file at path is encrypted. It
+// returns false if the file does not exist.
+func IsEncryptedKeyFile(path string) (bool, error) {
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	var ek encryptedKeyJSON
+	if err := json.Unmarshal(bz, &ek); err != nil {
+		return false, fmt.Errorf("error reading PrivValidator key from %v: %w", path, err)
+	}
+	return ek.KDF != "" && ek.Ciphertext != nil, nil
+}
+
+// EncryptKeyFile encrypts the plaintext key file at path in place with the
+// passphrase.
+func EncryptKeyFile(path string, passphrase []byte) error {
+	if encrypted, err := IsEncryptedKeyFile(path); err != nil {
+		return err
+	} else if encrypted {
+		return fmt.Errorf("key file %v is already encrypted", path)
+	}
+	var pvKey FilePVKey
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bz, &pvKey); err != nil {
+		return fmt.Errorf("error reading PrivValidator key from %v: %w", path, err)
+	}
+	return (&EncryptedKeyFile{Path: path, Passphrase: passphrase}).SaveKey(pvKey)
+}
+
+// DecryptKeyFile decrypts the encrypted key file at path in place with the
+// passphrase.
+func DecryptKeyFile(path string, passphrase []byte) error {
+	pvKey, err := (&EncryptedKeyFile{Path: path, Passphrase: passphrase}).LoadKey()
+	if err != nil {
+		return err
+	}
+	pvKey.filePath = path
+	return pvKey.Save()
+}
+
+// LoadFilePVWithProvider loads a FilePV with the key of the provider, which
+// also stores the key when the FilePV is saved, and the last sign state of
+// stateFilePath.
+func LoadFilePVWithProvider(provider SignerProvider, stateFilePath string) (*FilePV, error) {
+	pvKey, err := provider.LoadKey()
+	if err != nil {
+		return nil, err
+	}
+	pvKey.PubKey = pvKey.PrivKey.PubKey()
+	pvKey.Address = pvKey.PubKey.Address()
+	pvKey.provider = provider
+
+	pvState, err := loadFilePVLastSignState(stateFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return &FilePV{Key: pvKey, LastSignState: pvState}, nil
+}
@@ -0,0 +1,158 @@
+package privval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestEncryptKeyFile(t *testing.T) {
+	privVal, keyFile, stateFile := newTestFilePV(t)
+	require.NoError(t, privVal.Save())
+	passphrase := []byte("correct horse battery staple")
+
+	encrypted, err := IsEncryptedKeyFile(keyFile)
+	require.NoError(t, err)
+	assert.False(t, encrypted)
+
+	require.NoError(t, EncryptKeyFile(keyFile, passphrase))
+	encrypted, err = IsEncryptedKeyFile(keyFile)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+	assert.Error(t, EncryptKeyFile(keyFile, passphrase))
+
+	bz, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(bz), "priv_key")
+
+	_, err = LoadFilePVWithProvider(&EncryptedKeyFile{Path: keyFile, Passphrase: []byte("wrong")}, stateFile)
+	assert.True(t, errors.Is(err, ErrWrongPassphrase), err)
+
+	loaded, err := LoadFilePVWithProvider(&EncryptedKeyFile{Path: keyFile, Passphrase: passphrase}, stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.Key.Address, loaded.Key.Address)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+
+	require.NoError(t, DecryptKeyFile(keyFile, passphrase))
+	encrypted, err = IsEncryptedKeyFile(keyFile)
+	require.NoError(t, err)
+	assert.False(t, encrypted)
+	loaded, err = LoadFilePV(keyFile, stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, privVal.Key.PrivKey, loaded.Key.PrivKey)
+}
+
+func TestEncryptedKeyFileSign(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privVal, keyFile, stateFile := newTestFilePV(t)
+	require.NoError(t, privVal.Save())
+	passphrase := []byte("passphrase")
+	require.NoError(t, EncryptKeyFile(keyFile, passphrase))
+
+	provider := &EncryptedKeyFile{Path: keyFile, Passphrase: passphrase}
+	pv, err := LoadFilePVWithProvider(provider, stateFile)
+	require.NoError(t, err)
+
+	chainID := tmrand.Str(12)
+	blockID := types.BlockID{Hash: tmrand.Bytes(crypto.HashSize), PartSetHeader: types.PartSetHeader{}}
+	vote := newVote(privVal.Key.Address, 0, 2, 0, tmproto.PrevoteType, blockID, nil).ToProto()
+	require.NoError(t, pv.SignVote(ctx, chainID, vote))
+
+	// saving the FilePV keeps the key file encrypted
+	require.NoError(t, pv.Save())
+	encrypted, err := IsEncryptedKeyFile(keyFile)
+	require.NoError(t, err)
+	assert.True(t, encrypted)
+
+	loaded, err := LoadFilePVWithProvider(provider, stateFile)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, loaded.LastSignState.Height)
+}
+
+func TestDecryptKeyInvalidKDF(t *testing.T) {
+	passphrase := []byte("passphrase")
+	bz, err := EncryptKey([]byte(`{}`), passphrase)
+	require.NoError(t, err)
+	plaintext, err := DecryptKey(bz, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(plaintext))
+
+	testCases := map[string]func(*encryptedKeyJSON){
+		"zero time":          func(ek *encryptedKeyJSON) { ek.Time = 0 },
+		"huge time":          func(ek *encryptedKeyJSON) { ek.Time = maxArgon2Time + 1 },
+		"zero memory":        func(ek *encryptedKeyJSON) { ek.Memory = 0 },
+		"huge memory":        func(ek *encryptedKeyJSON) { ek.Memory = maxArgon2Memory + 1 },
+		"zero threads":       func(ek *encryptedKeyJSON) { ek.Threads = 0 },
+		"no salt":            func(ek *encryptedKeyJSON) { ek.Salt = nil },
+		"short salt":         func(ek *encryptedKeyJSON) { ek.Salt = ek.Salt[:minArgon2SaltLen-1] },
+		"unsupported kdf":    func(ek *encryptedKeyJSON) { ek.KDF = "scrypt" },
+		"unsupported cipher": func(ek *encryptedKeyJSON) { ek.Cipher = "aes-128-cbc" },
+	}
+	for name, corrupt := range testCases {
+		corrupt := corrupt
+		t.Run(name, func(t *testing.T) {
+			var ek encryptedKeyJSON
+			require.NoError(t, json.Unmarshal(bz, &ek))
+			corrupt(&ek)
+			corrupted, err := json.Marshal(ek)
+			require.NoError(t, err)
+
+			_, err = DecryptKey(corrupted, passphrase)
+			assert.Error(t, err)
+			assert.False(t, errors.Is(err, ErrWrongPassphrase), err)
+		})
+	}
+}
+
+func TestReadKeyPassphrase(t *testing.T) {
+	passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+	require.NoError(t, os.WriteFile(passphraseFile, []byte("from file\n"), 0600))
+	prompt := func() ([]byte, error) { return []byte("from prompt"), nil }
+
+	_, err := ReadKeyPassphrase("", nil)
+	assert.Error(t, err)
+
+	p, err := ReadKeyPassphrase("", prompt)
+	require.NoError(t, err)
+	assert.Equal(t, "from prompt", string(p))
+
+	p, err = ReadKeyPassphrase(passphraseFile, prompt)
+	require.NoError(t, err)
+	assert.Equal(t, "from file", string(p))
+
+	t.Setenv(KeyPassphraseEnv, "from env")
+	p, err = ReadKeyPassphrase(passphraseFile, prompt)
+	require.NoError(t, err)
+	assert.Equal(t, "from env", string(p))
+}
+
+func TestPromptPassphrase(t *testing.T) {
+	in := strings.NewReader("first\r\nsecond")
+	var out bytes.Buffer
+
+	p, err := PromptPassphrase(in, &out, "Enter passphrase: ")
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(p))
+	p, err = PromptPassphrase(in, &out, "Repeat passphrase: ")
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(p))
+	assert.Equal(t, "Enter passphrase: Repeat passphrase: ", out.String())
+
+	_, err = PromptPassphrase(in, &out, "Enter passphrase: ")
+	assert.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -53,6 +54,7 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey
 
 	filePath string
+	provider SignerProvider
 }
 
 type filePVKeyJSON struct {
@@ -90,8 +92,12 @@ func (pvKey *FilePVKey) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// Save persists the FilePVKey to its filePath.
+// Save persists the FilePVKey to its filePath, or stores it with its
+// SignerProvider if it was loaded from one.
 func (pvKey FilePVKey) Save() error {
+	if pvKey.provider != nil {
+		return pvKey.provider.SaveKey(pvKey)
+	}
 	outFile := pvKey.filePath
 	if outFile == "" {
 		return errors.New("cannot save PrivValidator key: filePath not set")
@@ -187,8 +193,13 @@ func (lss *FilePVLastSignState) Save() error {
 // It includes the LastSignature and LastSignBytes so we don't lose the signature
 // if the process crashes after signing but before the resulting consensus message is processed.
 type FilePV struct {
-	Key           FilePVKey
+	Key FilePVKey
+
+	// LastSignState is updated by the signing of votes and proposals, under
+	// mtx: while the validator signs, it must be read with GetSignState.
 	LastSignState FilePVLastSignState
+
+	mtx sync.Mutex
 }
 
 var _ types.PrivValidator = (*FilePV)(nil)
@@ -252,27 +263,45 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, err
 	pvKey.Address = pvKey.PubKey.Address()
 	pvKey.filePath = keyFilePath
 
-	pvState := FilePVLastSignState{}
+	pvState := FilePVLastSignState{filePath: stateFilePath}
 
 	if loadState {
-		stateJSONBytes, err := os.ReadFile(stateFilePath)
+		pvState, err = loadFilePVLastSignState(stateFilePath)
 		if err != nil {
 			return nil, err
 		}
-		err = json.Unmarshal(stateJSONBytes, &pvState)
-		if err != nil {
-			return nil, fmt.Errorf("error reading PrivValidator state from %v: %w", stateFilePath, err)
-		}
 	}
 
-	pvState.filePath = stateFilePath
-
 	return &FilePV{
 		Key:           pvKey,
 		LastSignState: pvState,
 	}, nil
 }
 
+func loadFilePVLastSignState(stateFilePath string) (FilePVLastSignState, error) {
+	pvState := FilePVLastSignState{}
+	stateJSONBytes, err := os.ReadFile(stateFilePath)
+	if err != nil {
+		return pvState, err
+	}
+	err = json.Unmarshal(stateJSONBytes, &pvState)
+	if err != nil {
+		return pvState, fmt.Errorf("error reading PrivValidator state from %v: %w", stateFilePath, err)
+	}
+	pvState.filePath = stateFilePath
+	return pvState, nil
+}
+
+// LoadFilePVSignState loads the last sign state of a FilePV from its state
+// file, without its key.
+func LoadFilePVSignState(stateFilePath string) (SignState, error) {
+	lss, err := loadFilePVLastSignState(stateFilePath)
+	if err != nil {
+		return SignState{}, err
+	}
+	return SignState{Height: lss.Height, Round: lss.Round, Step: lss.Step}, nil
+}
+
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFilePV(keyFilePath, stateFilePath string) (*FilePV, error) {
@@ -310,6 +339,8 @@ func (pv *FilePV) GetPubKey(ctx context.Context) (crypto.PubKey, error) {
 // SignVote signs a canonical representation of the vote, along with the
 // chainID. Implements PrivValidator.
 func (pv *FilePV) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
 	if err := pv.signVote(chainID, vote); err != nil {
 		return fmt.Errorf("error signing vote: %w", err)
 	}
@@ -319,12 +350,23 @@ func (pv *FilePV) SignVote(ctx context.Context, chainID string, vote *tmproto.Vo
 // SignProposal signs a canonical representation of the proposal, along with
 // the chainID. Implements PrivValidator.
 func (pv *FilePV) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
 	if err := pv.signProposal(chainID, proposal); err != nil {
 		return fmt.Errorf("error signing proposal: %w", err)
 	}
 	return nil
 }
 
+// GetSignState returns the height, round and step of the last vote or proposal
+// signed by the validator. Implements SignStateProvider.
+func (pv *FilePV) GetSignState(ctx context.Context) (SignState, error) {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	lss := pv.LastSignState
+	return SignState{Height: lss.Height, Round: lss.Round, Step: lss.Step}, nil
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() error {
 	if err := pv.Key.Save(); err != nil {
@@ -336,6 +378,8 @@ func (pv *FilePV) Save() error {
 // Reset resets all fields in the FilePV.
 // NOTE: Unsafe!
 func (pv *FilePV) Reset() error {
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
 	pv.LastSignState.reset()
 	return pv.Save()
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/provider"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -193,6 +195,63 @@ func TestSignVote(t *testing.T) {
 	assert.Equal(t, sig, vote.Signature)
 }
 
+func TestGetSignStateWhileSigning(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privVal, _, _ := newTestFilePV(t)
+	randbytes := tmrand.Bytes(crypto.HashSize)
+	block := types.BlockID{Hash: randbytes, PartSetHeader: types.PartSetHeader{Total: 5, Hash: randbytes}}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for height := int64(1); height <= 20; height++ {
+			vote := newVote(privVal.Key.Address, 0, height, 0, tmproto.PrevoteType, block, nil)
+			assert.NoError(t, privVal.SignVote(ctx, "mychainid", vote.ToProto()))
+		}
+	}()
+	// the sign state is read under the lock of the signing, which the race
+	// detector checks
+	var last SignState
+	for i := 0; i < 20; i++ {
+		state, err := privVal.GetSignState(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, state.Compare(last), 0)
+		last = state
+	}
+	<-done
+
+	state, err := privVal.GetSignState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, SignState{Height: 20, Step: stepPrevote}, state)
+}
+
+func TestSignVoteWithProviderKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key, err := provider.GenPrivKey(provider.FileProviderName, t.TempDir(), "validator", "")
+	require.NoError(t, err)
+	dir := t.TempDir()
+	keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+	require.NoError(t, NewFilePV(key, keyFile, stateFile).Save())
+
+	// the key file references the key, which is loaded from the provider
+	bz, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	require.Contains(t, string(bz), provider.PrivKeyName)
+	privVal, err := LoadFilePV(keyFile, stateFile)
+	require.NoError(t, err)
+	require.Equal(t, key.PubKey().Address(), privVal.GetAddress())
+
+	blockID := types.BlockID{Hash: tmrand.Bytes(crypto.HashSize),
+		PartSetHeader: types.PartSetHeader{Total: 5, Hash: tmrand.Bytes(crypto.HashSize)}}
+	v := newVote(privVal.Key.Address, 0, 10, 1, tmproto.PrevoteType, blockID, nil).ToProto()
+	require.NoError(t, privVal.SignVote(ctx, "mychainid", v))
+	require.True(t, key.PubKey().VerifySignature(types.VoteSignBytes("mychainid", v), v.Signature))
+}
+
 func TestSignProposal(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
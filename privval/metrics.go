@@ -0,0 +1,24 @@
+package privval
+
+import (
+	"github.com/go-kit/kit/metrics"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "privval"
+)
+
+//go:generate go run ../scripts/metricsgen -struct=Metrics
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of times the node switched to the connection of another remote
+	// signer after losing the connection of the active one.
+	SignerFailovers metrics.Counter
+
+	// Number of sign requests refused because their height, round and step
+	// were not after the last signature of the node.
+	SignStateRejections metrics.Counter
+}
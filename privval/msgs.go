@@ -28,6 +28,10 @@ func mustWrapMsg(pb proto.Message) privvalproto.Message {
 		msg.Sum = &privvalproto.Message_SignedProposalResponse{SignedProposalResponse: pb}
 	case *privvalproto.SignProposalRequest:
 		msg.Sum = &privvalproto.Message_SignProposalRequest{SignProposalRequest: pb}
+	case *privvalproto.SignStateRequest:
+		msg.Sum = &privvalproto.Message_SignStateRequest{SignStateRequest: pb}
+	case *privvalproto.SignStateResponse:
+		msg.Sum = &privvalproto.Message_SignStateResponse{SignStateResponse: pb}
 	case *privvalproto.PingRequest:
 		msg.Sum = &privvalproto.Message_PingRequest{PingRequest: pb}
 	case *privvalproto.PingResponse:
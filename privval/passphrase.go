@@ -0,0 +1,84 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// KeyPassphraseEnv is the environment variable from which ReadKeyPassphrase
+// reads the passphrase of an encrypted key file.
+const KeyPassphraseEnv = "TM_PRIV_VALIDATOR_KEY_PASSPHRASE"
+
+// ReadKeyPassphrase returns the passphrase of an encrypted key file, read from
+// the KeyPassphraseEnv environment variable, or else from passphraseFile if
+// set, or else with prompt if not nil.
+func ReadKeyPassphrase(passphraseFile string, prompt func() ([]byte, error)) ([]byte, error) {
+	if p, ok := os.LookupEnv(KeyPassphraseEnv); ok && p != "" {
+		return []byte(p), nil
+	}
+	if passphraseFile != "" {
+		bz, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading passphrase file: %w", err)
+		}
+		// ignore the line ending of the file
+		bz = bytes.TrimRight(bz, "\r\n")
+		if len(bz) == 0 {
+			return nil, fmt.Errorf("passphrase file %v is empty", passphraseFile)
+		}
+		return bz, nil
+	}
+	if prompt != nil {
+		return prompt()
+	}
+	return nil, fmt.Errorf("the key file is encrypted: set its passphrase with %s or priv-validator.passphrase-file",
+		KeyPassphraseEnv)
+}
+
+// PromptPassphrase writes msg to out and reads a passphrase terminated by a
+// line ending from in. If in is a terminal, its echo is disabled while the
+// passphrase is read.
+func PromptPassphrase(in io.Reader, out io.Writer, msg string) ([]byte, error) {
+	if _, err := fmt.Fprint(out, msg); err != nil {
+		return nil, err
+	}
+	if f, ok := in.(*os.File); ok {
+		restore, err := disableEcho(f)
+		if err != nil {
+			return nil, err
+		}
+		if restore != nil {
+			defer func() {
+				restore()
+				// the line ending was not echoed
+				fmt.Fprintln(out)
+			}()
+		}
+	}
+	// read a byte at a time, so that the following prompts on in can read the
+	// following lines
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		n, err := in.Read(b)
+		if n == 1 {
+			if b[0] == '\n' {
+				break
+			}
+			line = append(line, b[0])
+		}
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("reading passphrase: %w", err)
+		}
+	}
+	line = bytes.TrimRight(line, "\r")
+	if len(line) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	return line, nil
+}
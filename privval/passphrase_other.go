@@ -0,0 +1,12 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd,!windows
+
+package privval
+
+import "os"
+
+// disableEcho is not supported on this platform: the input of f is always
+// echoed.
+func disableEcho(f *os.File) (func(), error) {
+	return nil, nil
+}
@@ -0,0 +1,30 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package privval
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// disableEcho disables the echo of the input of f if f is a terminal, and
+// returns the function restoring it, or nil if f is not a terminal.
+func disableEcho(f *os.File) (func(), error) {
+	fd := int(f.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlReadTermios)
+	if err != nil {
+		// not a terminal
+		return nil, nil
+	}
+	restored := *termios
+	termios.Lflag &^= unix.ECHO
+	termios.Lflag |= unix.ICANON | unix.ISIG
+	termios.Iflag |= unix.ICRNL
+	if err := unix.IoctlSetTermios(fd, ioctlWriteTermios, termios); err != nil {
+		return nil, fmt.Errorf("disabling the echo of the terminal: %w", err)
+	}
+	return func() { _ = unix.IoctlSetTermios(fd, ioctlWriteTermios, &restored) }, nil
+}
@@ -0,0 +1,24 @@
+package privval
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// disableEcho disables the echo of the input of f if f is a console, and
+// returns the function restoring it, or nil if f is not a console.
+func disableEcho(f *os.File) (func(), error) {
+	h := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(h, &mode); err != nil {
+		// not a console
+		return nil, nil
+	}
+	noEcho := mode&^windows.ENABLE_ECHO_INPUT | windows.ENABLE_PROCESSED_INPUT | windows.ENABLE_LINE_INPUT
+	if err := windows.SetConsoleMode(h, noEcho); err != nil {
+		return nil, fmt.Errorf("disabling the echo of the console: %w", err)
+	}
+	return func() { _ = windows.SetConsoleMode(h, mode) }, nil
+}
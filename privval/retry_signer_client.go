@@ -2,6 +2,7 @@ package privval
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -34,6 +35,10 @@ func (sc *RetrySignerClient) IsConnected() bool {
 	return sc.next.IsConnected()
 }
 
+func (sc *RetrySignerClient) Connections() uint64 {
+	return sc.next.Connections()
+}
+
 func (sc *RetrySignerClient) WaitForConnection(ctx context.Context, maxWait time.Duration) error {
 	return sc.next.WaitForConnection(ctx, maxWait)
 }
@@ -102,3 +107,22 @@ func (sc *RetrySignerClient) SignProposal(ctx context.Context, chainID string, p
 	}
 	return fmt.Errorf("exhausted all attempts to sign proposal: %w", err)
 }
+
+func (sc *RetrySignerClient) GetSignState(ctx context.Context) (SignState, error) {
+	var (
+		state SignState
+		err   error
+	)
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		state, err = sc.next.GetSignState(ctx)
+		if err == nil {
+			return state, nil
+		}
+		// If remote signer errors or does not support the request, we don't retry.
+		if _, ok := err.(*RemoteSignerError); ok || errors.Is(err, ErrUnexpectedResponse) {
+			return state, err
+		}
+		time.Sleep(sc.timeout)
+	}
+	return state, fmt.Errorf("exhausted all attempts to get sign state: %w", err)
+}
@@ -0,0 +1,283 @@
+package privval
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/tendermint/tendermint/libs/log"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// SignState is the height, round and step (HRS) of the last vote or proposal
+// signed by a signer.
+type SignState struct {
+	Height int64 `json:"height,string"`
+	Round  int32 `json:"round"`
+	Step   int8  `json:"step"`
+}
+
+// Compare returns -1, 0 or 1 if the sign state is respectively before, equal
+// to or after other.
+func (s SignState) Compare(other SignState) int {
+	switch {
+	case s.Height != other.Height:
+		return compareInt64(s.Height, other.Height)
+	case s.Round != other.Round:
+		return compareInt64(int64(s.Round), int64(other.Round))
+	default:
+		return compareInt64(int64(s.Step), int64(other.Step))
+	}
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (s SignState) String() string {
+	return fmt.Sprintf("%d/%d/%d", s.Height, s.Round, s.Step)
+}
+
+// ToProto converts the sign state to its protobuf representation.
+func (s SignState) ToProto() privvalproto.SignState {
+	return privvalproto.SignState{Height: s.Height, Round: s.Round, Step: int32(s.Step)}
+}
+
+// SignStateFromProto converts a protobuf sign state to a SignState.
+func SignStateFromProto(pb privvalproto.SignState) SignState {
+	return SignState{Height: pb.Height, Round: pb.Round, Step: int8(pb.Step)}
+}
+
+// SignStateProvider is implemented by the signers able to report their last
+// sign state.
+type SignStateProvider interface {
+	GetSignState(ctx context.Context) (SignState, error)
+}
+
+var (
+	_ SignStateProvider = (*FilePV)(nil)
+	_ SignStateProvider = (*SignerClient)(nil)
+	_ SignStateProvider = (*RetrySignerClient)(nil)
+)
+
+// SignStateRegressionError occurs when the last sign state reported by a
+// remote signer is before the last sign state recorded by the node, which
+// means that the signer lost its state and may sign conflicting votes.
+type SignStateRegressionError struct {
+	Local  SignState
+	Remote SignState
+}
+
+func (e SignStateRegressionError) Error() string {
+	return fmt.Sprintf("sign state regression: remote signer is at %v, node last signed at %v", e.Remote, e.Local)
+}
+
+//-------------------------------------------------------------------------------
+
+// SignStateChecker wraps a RetrySignerClient to record the sign state of each
+// vote and proposal signed through it to a state file, and to cross-check it
+// against the last sign state of the remote signer.
+//
+// The sign states of the votes and proposals must strictly increase across
+// the signers the client fails over to: a request before the recorded sign
+// state is refused, as is a request at the recorded sign state if it was
+// signed by another signer. The sign state of a new signer is checked before
+// it signs, and a signature made by a signer the client failed over to while
+// signing is discarded, as that signer was not checked.
+type SignStateChecker struct {
+	*RetrySignerClient
+	logger  log.Logger
+	metrics *Metrics
+
+	mtx   sync.Mutex
+	state FilePVLastSignState
+	// stateConn is the connection of the signer of the recorded sign state,
+	// zero if it was loaded from the state file, and checkedConn the
+	// connection of the last signer whose sign state was checked.
+	stateConn   uint64
+	checkedConn uint64
+}
+
+var _ types.PrivValidator = (*SignStateChecker)(nil)
+
+// NewSignStateChecker returns a SignStateChecker wrapping sc, which records its
+// sign state to stateFilePath, in the format of the state file of FilePV. The
+// state of an existing file is loaded.
+func NewSignStateChecker(
+	sc *RetrySignerClient,
+	stateFilePath string,
+	logger log.Logger,
+	metrics *Metrics,
+) (*SignStateChecker, error) {
+	ssc := &SignStateChecker{
+		RetrySignerClient: sc,
+		logger:            logger,
+		metrics:           metrics,
+		state:             FilePVLastSignState{filePath: stateFilePath},
+	}
+
+	bz, err := os.ReadFile(stateFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return ssc, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bz, &ssc.state); err != nil {
+		return nil, fmt.Errorf("error reading PrivValidator state from %v: %w", stateFilePath, err)
+	}
+	return ssc, nil
+}
+
+// Check returns a SignStateRegressionError if the last sign state of the
+// remote signer is before the sign state recorded by the node. Signers that
+// don't report their sign state are not checked.
+func (ssc *SignStateChecker) Check(ctx context.Context) error {
+	conn := ssc.RetrySignerClient.Connections()
+	local, remote, err := ssc.SigningState(ctx)
+	if err != nil {
+		return err
+	}
+	if remote == nil {
+		ssc.logger.Info("remote signer does not report its sign state, skipping the sign state check")
+	} else if remote.Compare(local) < 0 {
+		return SignStateRegressionError{Local: local, Remote: *remote}
+	}
+
+	ssc.mtx.Lock()
+	ssc.checkedConn = conn
+	ssc.mtx.Unlock()
+	return nil
+}
+
+// SigningState returns the sign state recorded by the node and the last sign
+// state of the remote signer, or nil if the signer doesn't report it.
+func (ssc *SignStateChecker) SigningState(ctx context.Context) (SignState, *SignState, error) {
+	local := ssc.LocalSignState()
+
+	remote, err := ssc.RetrySignerClient.GetSignState(ctx)
+	var rerr *RemoteSignerError
+	switch {
+	case errors.Is(err, ErrUnexpectedResponse), errors.As(err, &rerr):
+		ssc.logger.Debug("remote signer did not report its sign state", "err", err)
+		return local, nil, nil
+	case err != nil:
+		return local, nil, fmt.Errorf("can't get sign state: %w", err)
+	}
+	return local, &remote, nil
+}
+
+// LocalSignState returns the sign state recorded by the node.
+func (ssc *SignStateChecker) LocalSignState() SignState {
+	ssc.mtx.Lock()
+	defer ssc.mtx.Unlock()
+	return SignState{Height: ssc.state.Height, Round: ssc.state.Round, Step: ssc.state.Step}
+}
+
+// SignVote signs the vote with the remote signer and records its sign state.
+func (ssc *SignStateChecker) SignVote(ctx context.Context, chainID string, vote *tmproto.Vote) error {
+	step, err := voteToStep(vote)
+	if err != nil {
+		return err
+	}
+	return ssc.sign(ctx, SignState{Height: vote.Height, Round: vote.Round, Step: step}, func() error {
+		return ssc.RetrySignerClient.SignVote(ctx, chainID, vote)
+	}, func() {
+		vote.Signature, vote.ExtensionSignature = nil, nil
+	})
+}
+
+// SignProposal signs the proposal with the remote signer and records its sign
+// state.
+func (ssc *SignStateChecker) SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error {
+	return ssc.sign(ctx, SignState{Height: proposal.Height, Round: proposal.Round, Step: stepPropose}, func() error {
+		return ssc.RetrySignerClient.SignProposal(ctx, chainID, proposal)
+	}, func() {
+		proposal.Signature = nil
+	})
+}
+
+// sign calls sign to sign at the sign state s if the remote signer may sign at
+// s, and records it. If the client failed over to another signer while
+// signing, the signature of that unchecked signer is discarded with discard,
+// and the new signer is checked for the next request.
+func (ssc *SignStateChecker) sign(ctx context.Context, s SignState, sign func() error, discard func()) error {
+	conn, err := ssc.checkSign(ctx, s)
+	if err != nil {
+		ssc.metrics.SignStateRejections.Add(1)
+		return err
+	}
+	if err := sign(); err != nil {
+		return err
+	}
+	if newConn := ssc.RetrySignerClient.Connections(); newConn != conn {
+		discard()
+		ssc.metrics.SignStateRejections.Add(1)
+		err := fmt.Errorf("discarded the signature at %v: the remote signer changed while signing", s)
+		if checkErr := ssc.Check(ctx); checkErr != nil {
+			return fmt.Errorf("%v: %w", err, checkErr)
+		}
+		return err
+	}
+	return ssc.record(s, conn)
+}
+
+// checkSign returns an error if the remote signer may not sign at the sign
+// state s, checking the sign state of the signer first if it is a new one. It
+// returns the connection of the signer checked.
+func (ssc *SignStateChecker) checkSign(ctx context.Context, s SignState) (uint64, error) {
+	conn := ssc.RetrySignerClient.Connections()
+	ssc.mtx.Lock()
+	checked := ssc.checkedConn == conn
+	ssc.mtx.Unlock()
+	if !checked {
+		if err := ssc.Check(ctx); err != nil {
+			return 0, err
+		}
+		// The signer may have changed again while being checked.
+		ssc.mtx.Lock()
+		conn = ssc.checkedConn
+		ssc.mtx.Unlock()
+	}
+
+	ssc.mtx.Lock()
+	defer ssc.mtx.Unlock()
+	local := SignState{Height: ssc.state.Height, Round: ssc.state.Round, Step: ssc.state.Step}
+	switch cmp := s.Compare(local); {
+	case cmp < 0:
+		return 0, fmt.Errorf("refusing to sign at %v: already signed at %v", s, local)
+	case cmp == 0 && ssc.stateConn != conn:
+		return 0, fmt.Errorf("refusing to sign at %v: already signed by another signer", s)
+	}
+	return conn, nil
+}
+
+// record persists the sign state signed on the connection conn, unless it is
+// before the recorded one.
+func (ssc *SignStateChecker) record(s SignState, conn uint64) error {
+	ssc.mtx.Lock()
+	defer ssc.mtx.Unlock()
+
+	prev := SignState{Height: ssc.state.Height, Round: ssc.state.Round, Step: ssc.state.Step}
+	if s.Compare(prev) <= 0 {
+		return nil
+	}
+	ssc.stateConn = conn
+	ssc.state.Height, ssc.state.Round, ssc.state.Step = s.Height, s.Round, s.Step
+	ssc.state.Signature, ssc.state.SignBytes = nil, nil
+	if err := ssc.state.Save(); err != nil {
+		return fmt.Errorf("can't save sign state: %w", err)
+	}
+	return nil
+}
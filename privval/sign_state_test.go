@@ -0,0 +1,313 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fortytw2/leaktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// newSignStateTestClient returns a RetrySignerClient connected to a signer
+// server signing with privVal.
+func newSignStateTestClient(
+	ctx context.Context,
+	t *testing.T,
+	privVal types.PrivValidator,
+) (*RetrySignerClient, string) {
+	t.Helper()
+
+	logger := log.NewNopLogger()
+	chainID := tmrand.Str(12)
+	dtc := getDialerTestCases(t)[0]
+
+	ctx, cancel := context.WithCancel(ctx)
+	sl, sd := getMockEndpoints(ctx, t, logger, dtc.addr, dtc.dialer)
+	sc, err := NewSignerClient(ctx, sl, chainID)
+	require.NoError(t, err)
+	ss := NewSignerServer(sd, chainID, privVal)
+	require.NoError(t, ss.Start(ctx))
+	t.Cleanup(ss.Wait)
+	t.Cleanup(sc.endpoint.Wait)
+	t.Cleanup(cancel)
+
+	return NewRetrySignerClient(sc, 1, 10*time.Millisecond), chainID
+}
+
+func TestSignStateCompare(t *testing.T) {
+	s := SignState{Height: 2, Round: 1, Step: stepPrevote}
+	assert.Equal(t, 0, s.Compare(s))
+	assert.Equal(t, 1, s.Compare(SignState{Height: 1, Round: 5, Step: stepPrecommit}))
+	assert.Equal(t, 1, s.Compare(SignState{Height: 2, Round: 0, Step: stepPrecommit}))
+	assert.Equal(t, 1, s.Compare(SignState{Height: 2, Round: 1, Step: stepPropose}))
+	assert.Equal(t, -1, s.Compare(SignState{Height: 2, Round: 1, Step: stepPrecommit}))
+	assert.Equal(t, -1, s.Compare(SignState{Height: 3}))
+}
+
+func TestSignerGetSignState(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("FilePV", func(t *testing.T) {
+		privVal, _, _ := newTestFilePV(t)
+		sc, chainID := newSignStateTestClient(ctx, t, privVal)
+
+		state, err := sc.GetSignState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, SignState{}, state)
+
+		blockID := types.BlockID{Hash: tmrand.Bytes(crypto.HashSize), PartSetHeader: types.PartSetHeader{}}
+		vote := newVote(privVal.Key.Address, 0, 5, 1, tmproto.PrevoteType, blockID, nil).ToProto()
+		require.NoError(t, sc.SignVote(ctx, chainID, vote))
+
+		state, err = sc.GetSignState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, SignState{Height: 5, Round: 1, Step: stepPrevote}, state)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		sc, _ := newSignStateTestClient(ctx, t, types.NewMockPV())
+
+		_, err := sc.GetSignState(ctx)
+		var rerr *RemoteSignerError
+		assert.True(t, errors.As(err, &rerr), err)
+	})
+}
+
+func TestSignStateChecker(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+	blockID := types.BlockID{Hash: tmrand.Bytes(crypto.HashSize), PartSetHeader: types.PartSetHeader{}}
+
+	t.Run("RecordAndCheck", func(t *testing.T) {
+		privVal, _, _ := newTestFilePV(t)
+		sc, chainID := newSignStateTestClient(ctx, t, privVal)
+		stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+
+		ssc, err := NewSignStateChecker(sc, stateFile, logger, NopMetrics())
+		require.NoError(t, err)
+		require.NoError(t, ssc.Check(ctx))
+
+		proposal := newProposal(3, 0, blockID, time.Now()).ToProto()
+		require.NoError(t, ssc.SignProposal(ctx, chainID, proposal))
+		vote := newVote(privVal.Key.Address, 0, 3, 0, tmproto.PrecommitType, blockID, nil).ToProto()
+		require.NoError(t, ssc.SignVote(ctx, chainID, vote))
+
+		want := SignState{Height: 3, Round: 0, Step: stepPrecommit}
+		local, remote, err := ssc.SigningState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, local)
+		require.NotNil(t, remote)
+		assert.Equal(t, want, *remote)
+		require.NoError(t, ssc.Check(ctx))
+
+		// the recorded state is loaded from the state file
+		reloaded, err := NewSignStateChecker(sc, stateFile, logger, NopMetrics())
+		require.NoError(t, err)
+		assert.Equal(t, want, reloaded.LocalSignState())
+	})
+
+	t.Run("Regression", func(t *testing.T) {
+		privVal, _, _ := newTestFilePV(t)
+		sc, _ := newSignStateTestClient(ctx, t, privVal)
+		stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+
+		local := FilePVLastSignState{Height: 10, Round: 2, Step: stepPrevote, filePath: stateFile}
+		require.NoError(t, local.Save())
+
+		ssc, err := NewSignStateChecker(sc, stateFile, logger, NopMetrics())
+		require.NoError(t, err)
+		err = ssc.Check(ctx)
+		var regression SignStateRegressionError
+		require.True(t, errors.As(err, &regression), err)
+		assert.Equal(t, SignState{Height: 10, Round: 2, Step: stepPrevote}, regression.Local)
+		assert.Equal(t, SignState{}, regression.Remote)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		sc, _ := newSignStateTestClient(ctx, t, types.NewMockPV())
+		stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+
+		local := FilePVLastSignState{Height: 10, filePath: stateFile}
+		require.NoError(t, local.Save())
+
+		ssc, err := NewSignStateChecker(sc, stateFile, logger, NopMetrics())
+		require.NoError(t, err)
+		require.NoError(t, ssc.Check(ctx))
+		_, remote, err := ssc.SigningState(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, remote)
+	})
+}
+
+func TestSignStateCheckerFailover(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+	chainID := tmrand.Str(12)
+	dtc := getDialerTestCases(t)[0]
+	blockID := types.BlockID{Hash: tmrand.Bytes(crypto.HashSize), PartSetHeader: types.PartSetHeader{}}
+
+	newSigner := func(ctx context.Context, privVal types.PrivValidator) *SignerServer {
+		de := NewSignerDialerEndpoint(logger, dtc.dialer)
+		SignerDialerEndpointTimeoutReadWrite(testTimeoutReadWrite)(de)
+		SignerDialerEndpointConnRetries(1e6)(de)
+		ss := NewSignerServer(de, chainID, privVal)
+		require.NoError(t, ss.Start(ctx))
+		t.Cleanup(ss.Wait)
+		return ss
+	}
+
+	lctx, lcancel := context.WithCancel(ctx)
+	sl := newSignerListenerEndpoint(t, logger, dtc.addr, testTimeoutReadWrite)
+	isOpenCh := make(chan struct{})
+	startListenerEndpointAsync(lctx, t, sl, isOpenCh)
+
+	// the first signer is the active one
+	privVal1, _, _ := newTestFilePV(t)
+	sctx1, scancel1 := context.WithCancel(ctx)
+	newSigner(sctx1, privVal1)
+	<-isOpenCh
+	sc, err := NewSignerClient(lctx, sl, chainID)
+	require.NoError(t, err)
+	t.Cleanup(sl.Wait)
+	t.Cleanup(lcancel)
+
+	ssc, err := NewSignStateChecker(
+		NewRetrySignerClient(sc, 50, 20*time.Millisecond),
+		filepath.Join(t.TempDir(), "priv_validator_state.json"),
+		logger,
+		NopMetrics(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, ssc.Check(ctx))
+	vote := newVote(privVal1.Key.Address, 0, 5, 0, tmproto.PrevoteType, blockID, nil).ToProto()
+	require.NoError(t, ssc.SignVote(ctx, chainID, vote))
+	assert.EqualValues(t, 1, ssc.Connections())
+
+	// the second signer takes over, behind the first one
+	privVal2, _, _ := newTestFilePV(t)
+	newSigner(ctx, privVal2)
+	scancel1()
+	require.Eventually(t, func() bool { return ssc.Connections() > 1 }, 5*time.Second, 10*time.Millisecond)
+
+	vote = newVote(privVal1.Key.Address, 0, 5, 0, tmproto.PrecommitType, blockID, nil).ToProto()
+	err = ssc.SignVote(ctx, chainID, vote)
+	var regression SignStateRegressionError
+	require.True(t, errors.As(err, &regression), err)
+	assert.Equal(t, SignState{Height: 5, Round: 0, Step: stepPrevote}, regression.Local)
+
+	// once the second signer is past the sign state of the node, it may sign
+	ahead := newVote(privVal2.Key.Address, 0, 6, 0, tmproto.PrevoteType, blockID, nil).ToProto()
+	require.NoError(t, privVal2.SignVote(ctx, chainID, ahead))
+	vote = newVote(privVal1.Key.Address, 0, 6, 0, tmproto.PrecommitType, blockID, nil).ToProto()
+	require.NoError(t, ssc.SignVote(ctx, chainID, vote))
+	assert.Equal(t, SignState{Height: 6, Round: 0, Step: stepPrecommit}, ssc.LocalSignState())
+
+	// requests before the last signature are refused
+	vote = newVote(privVal1.Key.Address, 0, 6, 0, tmproto.PrevoteType, blockID, nil).ToProto()
+	assert.Error(t, ssc.SignVote(ctx, chainID, vote))
+}
+
+func TestSignStateCheckerFailoverWhileSigning(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := log.NewNopLogger()
+	chainID := tmrand.Str(12)
+	dtc := getDialerTestCases(t)[0]
+	blockID := types.BlockID{Hash: tmrand.Bytes(crypto.HashSize), PartSetHeader: types.PartSetHeader{}}
+
+	newSigner := func(privVal types.PrivValidator) *SignerServer {
+		de := NewSignerDialerEndpoint(logger, dtc.dialer)
+		SignerDialerEndpointTimeoutReadWrite(testTimeoutReadWrite)(de)
+		SignerDialerEndpointConnRetries(1e6)(de)
+		return NewSignerServer(de, chainID, privVal)
+	}
+
+	lctx, lcancel := context.WithCancel(ctx)
+	sl := newSignerListenerEndpoint(t, logger, dtc.addr, testTimeoutReadWrite)
+	isOpenCh := make(chan struct{})
+	startListenerEndpointAsync(lctx, t, sl, isOpenCh)
+
+	// the first signer drops its connection when asked to sign a vote, so
+	// that the client fails over to the second one in the middle of the
+	// request
+	privVal1, _, _ := newTestFilePV(t)
+	sctx1, scancel1 := context.WithCancel(ctx)
+	ss1 := newSigner(privVal1)
+	ss1.SetRequestHandler(func(
+		ctx context.Context,
+		privVal types.PrivValidator,
+		req privvalproto.Message,
+		chainID string,
+	) (privvalproto.Message, error) {
+		if _, ok := req.Sum.(*privvalproto.Message_SignVoteRequest); ok {
+			scancel1()
+			_ = ss1.endpoint.Close()
+			return privvalproto.Message{}, errors.New("signer stopped")
+		}
+		return DefaultValidationRequestHandler(ctx, privVal, req, chainID)
+	})
+	require.NoError(t, ss1.Start(sctx1))
+	t.Cleanup(ss1.Wait)
+	<-isOpenCh
+	sc, err := NewSignerClient(lctx, sl, chainID)
+	require.NoError(t, err)
+	t.Cleanup(sl.Wait)
+	t.Cleanup(lcancel)
+
+	stateFile := filepath.Join(t.TempDir(), "priv_validator_state.json")
+	local := FilePVLastSignState{Height: 5, Round: 0, Step: stepPrevote, filePath: stateFile}
+	require.NoError(t, local.Save())
+	require.NoError(t, privVal1.SignVote(ctx, chainID,
+		newVote(privVal1.Key.Address, 0, 5, 0, tmproto.PrevoteType, blockID, nil).ToProto()))
+
+	ssc, err := NewSignStateChecker(NewRetrySignerClient(sc, 50, 20*time.Millisecond), stateFile, logger, NopMetrics())
+	require.NoError(t, err)
+	require.NoError(t, ssc.Check(ctx))
+
+	// the second signer, behind the sign state of the node, takes over and
+	// signs without being checked first
+	privVal2, _, _ := newTestFilePV(t)
+	ss2 := newSigner(privVal2)
+	require.NoError(t, ss2.Start(ctx))
+	t.Cleanup(ss2.Wait)
+
+	vote := newVote(privVal1.Key.Address, 0, 5, 0, tmproto.PrecommitType, blockID, nil).ToProto()
+	err = ssc.SignVote(ctx, chainID, vote)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remote signer changed while signing")
+	assert.Nil(t, vote.Signature, "the signature of the unchecked signer is discarded")
+	assert.True(t, ssc.Connections() > 1)
+	assert.Equal(t, SignState{Height: 5, Round: 0, Step: stepPrevote}, ssc.LocalSignState())
+
+	// the second signer was checked after signing, past the sign state of the
+	// node, so it signs the next requests
+	vote = newVote(privVal1.Key.Address, 0, 6, 0, tmproto.PrevoteType, blockID, nil).ToProto()
+	require.NoError(t, ssc.SignVote(ctx, chainID, vote))
+	assert.NotNil(t, vote.Signature)
+	assert.Equal(t, SignState{Height: 6, Round: 0, Step: stepPrevote}, ssc.LocalSignState())
+}
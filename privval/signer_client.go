@@ -54,6 +54,12 @@ func (sc *SignerClient) IsConnected() bool {
 	return sc.endpoint.IsConnected()
 }
 
+// Connections returns the number of connections of signers used by the client.
+// It changes each time the client fails over to another signer.
+func (sc *SignerClient) Connections() uint64 {
+	return sc.endpoint.Connections()
+}
+
 // WaitForConnection waits maxWait for a connection or returns a timeout error
 func (sc *SignerClient) WaitForConnection(ctx context.Context, maxWait time.Duration) error {
 	return sc.endpoint.WaitForConnection(ctx, maxWait)
@@ -143,3 +149,21 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// GetSignState requests the last sign state of a remote signer
+func (sc *SignerClient) GetSignState(ctx context.Context) (SignState, error) {
+	response, err := sc.endpoint.SendRequest(ctx, mustWrapMsg(&privvalproto.SignStateRequest{ChainId: sc.chainID}))
+	if err != nil {
+		return SignState{}, err
+	}
+
+	resp := response.GetSignStateResponse()
+	if resp == nil {
+		return SignState{}, ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return SignState{}, &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	return SignStateFromProto(resp.State), nil
+}
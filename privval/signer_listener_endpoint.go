@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -23,11 +24,20 @@ func SignerListenerEndpointTimeoutReadWrite(timeout time.Duration) SignerListene
 	return func(sl *SignerListenerEndpoint) { sl.signerEndpoint.timeoutReadWrite = timeout }
 }
 
+// SignerListenerEndpointMetrics sets the metrics of the endpoint.
+func SignerListenerEndpointMetrics(metrics *Metrics) SignerListenerEndpointOption {
+	return func(sl *SignerListenerEndpoint) { sl.metrics = metrics }
+}
+
 // SignerListenerEndpoint listens for an external process to dial in and keeps
 // the connection alive by dropping and reconnecting.
 //
 // The process will send pings every ~3s (read/write timeout * 2/3) to keep the
 // connection alive.
+//
+// Several signers, such as the instances of a highly available signer cluster,
+// may dial in: the requests are only sent to the connection of the active
+// signer, and the connection of another signer is used when it is lost.
 type SignerListenerEndpoint struct {
 	signerEndpoint
 
@@ -40,6 +50,9 @@ type SignerListenerEndpoint struct {
 	pingInterval  time.Duration
 
 	instanceMtx sync.Mutex // Ensures instance public methods access, i.e. SendRequest
+
+	connections uint64 // number of connections used, accessed atomically
+	metrics     *Metrics
 }
 
 // NewSignerListenerEndpoint returns an instance of SignerListenerEndpoint.
@@ -51,6 +64,7 @@ func NewSignerListenerEndpoint(
 	sl := &SignerListenerEndpoint{
 		listener:      listener,
 		timeoutAccept: defaultTimeoutAcceptSeconds * time.Second,
+		metrics:       NopMetrics(),
 	}
 
 	sl.signerEndpoint.logger = logger
@@ -66,7 +80,9 @@ func NewSignerListenerEndpoint(
 
 // OnStart implements service.Service.
 func (sl *SignerListenerEndpoint) OnStart(ctx context.Context) error {
-	sl.connectRequestCh = make(chan struct{})
+	// buffered so that the service loop can request to accept the connection
+	// of another signer, while the current one is used
+	sl.connectRequestCh = make(chan struct{}, 1)
 	sl.connectionAvailableCh = make(chan net.Conn)
 
 	// NOTE: ping timeout must be less than read/write timeout
@@ -131,6 +147,13 @@ func (sl *SignerListenerEndpoint) SendRequest(ctx context.Context, request privv
 	return &res, nil
 }
 
+// Connections returns the number of connections of signers used by the
+// endpoint, which changes each time the endpoint switches to the connection of
+// another signer.
+func (sl *SignerListenerEndpoint) Connections() uint64 {
+	return atomic.LoadUint64(&sl.connections)
+}
+
 func (sl *SignerListenerEndpoint) ensureConnection(ctx context.Context, maxWait time.Duration) error {
 	if sl.IsConnected() {
 		return nil
@@ -138,13 +161,27 @@ func (sl *SignerListenerEndpoint) ensureConnection(ctx context.Context, maxWait
 
 	// Is there a connection ready? then use it
 	if sl.GetAvailableConnection(sl.connectionAvailableCh) {
+		sl.useNewConnection()
 		return nil
 	}
 
 	// block until connected or timeout
 	sl.logger.Info("SignerListener: Blocking for connection")
 	sl.triggerConnect()
-	return sl.WaitConnection(ctx, sl.connectionAvailableCh, maxWait)
+	if err := sl.WaitConnection(ctx, sl.connectionAvailableCh, maxWait); err != nil {
+		return err
+	}
+	sl.useNewConnection()
+	return nil
+}
+
+// useNewConnection counts the use of a new connection, which is a failover
+// from the previous one if any.
+func (sl *SignerListenerEndpoint) useNewConnection() {
+	if n := atomic.AddUint64(&sl.connections, 1); n > 1 {
+		sl.logger.Info("SignerListener: Failed over to a new signer connection", "connections", n)
+		sl.metrics.SignerFailovers.Add(1)
+	}
 }
 
 func (sl *SignerListenerEndpoint) acceptNewConnection() (net.Conn, error) {
@@ -189,6 +226,8 @@ func (sl *SignerListenerEndpoint) serviceLoop(ctx context.Context) {
 					case <-ctx.Done():
 						return
 					}
+				} else if !sl.IsRunning() {
+					return
 				}
 
 				select {
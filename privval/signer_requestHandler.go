@@ -85,6 +85,30 @@ func DefaultValidationRequestHandler(
 		} else {
 			res = mustWrapMsg(&privvalproto.SignedProposalResponse{Proposal: *proposal, Error: nil})
 		}
+	case *privvalproto.Message_SignStateRequest:
+		if r.SignStateRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.SignStateResponse{
+				Error: &privvalproto.RemoteSignerError{
+					Code:        0,
+					Description: "unable to provide sign state"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s", r.SignStateRequest.GetChainId(), chainID)
+		}
+
+		ssp, ok := privVal.(SignStateProvider)
+		if !ok {
+			res = mustWrapMsg(&privvalproto.SignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: "sign state not supported"}})
+			break
+		}
+
+		state, err := ssp.GetSignState(ctx)
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.SignStateResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(&privvalproto.SignStateResponse{State: state.ToProto()})
+		}
+
 	case *privvalproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&privvalproto.PingResponse{})
 
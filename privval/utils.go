@@ -26,7 +26,11 @@ func IsConnTimeout(err error) bool {
 }
 
 // NewSignerListener creates a new SignerListenerEndpoint using the corresponding listen address
-func NewSignerListener(listenAddr string, logger log.Logger) (*SignerListenerEndpoint, error) {
+func NewSignerListener(
+	listenAddr string,
+	logger log.Logger,
+	options ...SignerListenerEndpointOption,
+) (*SignerListenerEndpoint, error) {
 	protocol, address := tmnet.ProtocolAndAddress(listenAddr)
 	if protocol != "unix" && protocol != "tcp" { //nolint:goconst
 		return nil, fmt.Errorf("unsupported address family %q, want unix or tcp", protocol)
@@ -48,5 +52,5 @@ func NewSignerListener(listenAddr string, logger log.Logger) (*SignerListenerEnd
 		panic("invalid protocol: " + protocol) // semantically unreachable
 	}
 
-	return NewSignerListenerEndpoint(logger.With("module", "privval"), listener), nil
+	return NewSignerListenerEndpoint(logger.With("module", "privval"), listener, options...), nil
 }
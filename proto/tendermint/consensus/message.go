@@ -36,6 +36,12 @@ func (m *Message) Wrap(pb proto.Message) error {
 	case *VoteSetBits:
 		m.Sum = &Message_VoteSetBits{VoteSetBits: msg}
 
+	case *CompactBlock:
+		m.Sum = &Message_CompactBlock{CompactBlock: msg}
+
+	case *CompactBlockResult:
+		m.Sum = &Message_CompactBlockResult{CompactBlockResult: msg}
+
 	default:
 		return fmt.Errorf("unknown message: %T", msg)
 	}
@@ -74,6 +80,12 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_VoteSetBits:
 		return m.GetVoteSetBits(), nil
 
+	case *Message_CompactBlock:
+		return m.GetCompactBlock(), nil
+
+	case *Message_CompactBlockResult:
+		return m.GetCompactBlockResult(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -612,6 +612,147 @@ func (m *VoteSetBits) GetVotes() bits.BitArray {
 	return bits.BitArray{}
 }
 
+// CompactBlock is sent instead of the parts of a proposed block to peers which
+// are likely to hold its transactions in their mempools. The block is sent
+// without its transactions, which are identified by their keys instead.
+type CompactBlock struct {
+	Height             int64               `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round              int32               `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	BlockPartSetHeader types.PartSetHeader `protobuf:"bytes,3,opt,name=block_part_set_header,json=blockPartSetHeader,proto3" json:"block_part_set_header"`
+	Block              types.Block         `protobuf:"bytes,4,opt,name=block,proto3" json:"block"`
+	TxKeys             [][]byte            `protobuf:"bytes,5,rep,name=tx_keys,json=txKeys,proto3" json:"tx_keys,omitempty"`
+}
+
+func (m *CompactBlock) Reset()         { *m = CompactBlock{} }
+func (m *CompactBlock) String() string { return proto.CompactTextString(m) }
+func (*CompactBlock) ProtoMessage()    {}
+func (*CompactBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{9}
+}
+func (m *CompactBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlock.Merge(m, src)
+}
+func (m *CompactBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlock proto.InternalMessageInfo
+
+func (m *CompactBlock) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CompactBlock) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CompactBlock) GetBlockPartSetHeader() types.PartSetHeader {
+	if m != nil {
+		return m.BlockPartSetHeader
+	}
+	return types.PartSetHeader{}
+}
+
+func (m *CompactBlock) GetBlock() types.Block {
+	if m != nil {
+		return m.Block
+	}
+	return types.Block{}
+}
+
+func (m *CompactBlock) GetTxKeys() [][]byte {
+	if m != nil {
+		return m.TxKeys
+	}
+	return nil
+}
+
+// CompactBlockResult is sent in reply to a CompactBlock to indicate whether the
+// block was reconstructed from it. If not, the block parts are sent instead.
+type CompactBlockResult struct {
+	Height        int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round         int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Reconstructed bool  `protobuf:"varint,3,opt,name=reconstructed,proto3" json:"reconstructed,omitempty"`
+}
+
+func (m *CompactBlockResult) Reset()         { *m = CompactBlockResult{} }
+func (m *CompactBlockResult) String() string { return proto.CompactTextString(m) }
+func (*CompactBlockResult) ProtoMessage()    {}
+func (*CompactBlockResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_81a22d2efc008981, []int{10}
+}
+func (m *CompactBlockResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactBlockResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactBlockResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactBlockResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlockResult.Merge(m, src)
+}
+func (m *CompactBlockResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactBlockResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlockResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlockResult proto.InternalMessageInfo
+
+func (m *CompactBlockResult) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *CompactBlockResult) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CompactBlockResult) GetReconstructed() bool {
+	if m != nil {
+		return m.Reconstructed
+	}
+	return false
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_NewRoundStep
@@ -623,6 +764,8 @@ type Message struct {
 	//	*Message_HasVote
 	//	*Message_VoteSetMaj23
 	//	*Message_VoteSetBits
+	//	*Message_CompactBlock
+	//	*Message_CompactBlockResult
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -630,7 +773,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_81a22d2efc008981, []int{9}
+	return fileDescriptor_81a22d2efc008981, []int{11}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -692,16 +835,24 @@ type Message_VoteSetMaj23 struct {
 type Message_VoteSetBits struct {
 	VoteSetBits *VoteSetBits `protobuf:"bytes,9,opt,name=vote_set_bits,json=voteSetBits,proto3,oneof" json:"vote_set_bits,omitempty"`
 }
+type Message_CompactBlock struct {
+	CompactBlock *CompactBlock `protobuf:"bytes,10,opt,name=compact_block,json=compactBlock,proto3,oneof" json:"compact_block,omitempty"`
+}
+type Message_CompactBlockResult struct {
+	CompactBlockResult *CompactBlockResult `protobuf:"bytes,11,opt,name=compact_block_result,json=compactBlockResult,proto3,oneof" json:"compact_block_result,omitempty"`
+}
 
-func (*Message_NewRoundStep) isMessage_Sum()  {}
-func (*Message_NewValidBlock) isMessage_Sum() {}
-func (*Message_Proposal) isMessage_Sum()      {}
-func (*Message_ProposalPol) isMessage_Sum()   {}
-func (*Message_BlockPart) isMessage_Sum()     {}
-func (*Message_Vote) isMessage_Sum()          {}
-func (*Message_HasVote) isMessage_Sum()       {}
-func (*Message_VoteSetMaj23) isMessage_Sum()  {}
-func (*Message_VoteSetBits) isMessage_Sum()   {}
+func (*Message_NewRoundStep) isMessage_Sum()       {}
+func (*Message_NewValidBlock) isMessage_Sum()      {}
+func (*Message_Proposal) isMessage_Sum()           {}
+func (*Message_ProposalPol) isMessage_Sum()        {}
+func (*Message_BlockPart) isMessage_Sum()          {}
+func (*Message_Vote) isMessage_Sum()               {}
+func (*Message_HasVote) isMessage_Sum()            {}
+func (*Message_VoteSetMaj23) isMessage_Sum()       {}
+func (*Message_VoteSetBits) isMessage_Sum()        {}
+func (*Message_CompactBlock) isMessage_Sum()       {}
+func (*Message_CompactBlockResult) isMessage_Sum() {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -773,6 +924,20 @@ func (m *Message) GetVoteSetBits() *VoteSetBits {
 	return nil
 }
 
+func (m *Message) GetCompactBlock() *CompactBlock {
+	if x, ok := m.GetSum().(*Message_CompactBlock); ok {
+		return x.CompactBlock
+	}
+	return nil
+}
+
+func (m *Message) GetCompactBlockResult() *CompactBlockResult {
+	if x, ok := m.GetSum().(*Message_CompactBlockResult); ok {
+		return x.CompactBlockResult
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -785,6 +950,8 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_HasVote)(nil),
 		(*Message_VoteSetMaj23)(nil),
 		(*Message_VoteSetBits)(nil),
+		(*Message_CompactBlock)(nil),
+		(*Message_CompactBlockResult)(nil),
 	}
 }
 
@@ -798,67 +965,76 @@ func init() {
 	proto.RegisterType((*HasVote)(nil), "tendermint.consensus.HasVote")
 	proto.RegisterType((*VoteSetMaj23)(nil), "tendermint.consensus.VoteSetMaj23")
 	proto.RegisterType((*VoteSetBits)(nil), "tendermint.consensus.VoteSetBits")
+	proto.RegisterType((*CompactBlock)(nil), "tendermint.consensus.CompactBlock")
+	proto.RegisterType((*CompactBlockResult)(nil), "tendermint.consensus.CompactBlockResult")
 	proto.RegisterType((*Message)(nil), "tendermint.consensus.Message")
 }
 
 func init() { proto.RegisterFile("tendermint/consensus/types.proto", fileDescriptor_81a22d2efc008981) }
 
 var fileDescriptor_81a22d2efc008981 = []byte{
-	// 853 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0x4f, 0x8f, 0xdb, 0x44,
-	0x14, 0xb7, 0x59, 0x67, 0x93, 0x7d, 0xde, 0xec, 0xc2, 0x68, 0x5b, 0x85, 0x00, 0x49, 0x30, 0x97,
-	0x15, 0x42, 0x0e, 0xca, 0x1e, 0x90, 0x0a, 0x12, 0x60, 0xfe, 0xd4, 0xad, 0x9a, 0x36, 0x72, 0x4a,
-	0x85, 0xb8, 0x58, 0x4e, 0x3c, 0x4a, 0x86, 0xc6, 0x1e, 0xcb, 0x33, 0xc9, 0xb2, 0x57, 0x3e, 0x01,
-	0x1f, 0x80, 0xaf, 0x81, 0xc4, 0x47, 0xe8, 0xb1, 0x47, 0x4e, 0x15, 0xca, 0x7e, 0x04, 0x04, 0x67,
-	0x34, 0xe3, 0x49, 0x3c, 0xa1, 0xde, 0x85, 0xbd, 0x20, 0xf5, 0x36, 0xe3, 0xf7, 0xde, 0x6f, 0xde,
-	0xfc, 0xde, 0x7b, 0x3f, 0x0f, 0xf4, 0x38, 0x4e, 0x63, 0x9c, 0x27, 0x24, 0xe5, 0xfd, 0x29, 0x4d,
-	0x19, 0x4e, 0xd9, 0x92, 0xf5, 0xf9, 0x45, 0x86, 0x99, 0x9b, 0xe5, 0x94, 0x53, 0x74, 0x52, 0x7a,
-	0xb8, 0x5b, 0x8f, 0xf6, 0xc9, 0x8c, 0xce, 0xa8, 0x74, 0xe8, 0x8b, 0x55, 0xe1, 0xdb, 0x7e, 0x5b,
-	0x43, 0x93, 0x18, 0x3a, 0x52, 0x5b, 0x3f, 0x6b, 0x41, 0x26, 0xac, 0x3f, 0x21, 0x7c, 0xc7, 0xc3,
-	0xf9, 0xc5, 0x84, 0xc3, 0x87, 0xf8, 0x3c, 0xa0, 0xcb, 0x34, 0x1e, 0x73, 0x9c, 0xa1, 0xdb, 0xb0,
-	0x3f, 0xc7, 0x64, 0x36, 0xe7, 0x2d, 0xb3, 0x67, 0x9e, 0xee, 0x05, 0x6a, 0x87, 0x4e, 0xa0, 0x96,
-	0x0b, 0xa7, 0xd6, 0x6b, 0x3d, 0xf3, 0xb4, 0x16, 0x14, 0x1b, 0x84, 0xc0, 0x62, 0x1c, 0x67, 0xad,
-	0xbd, 0x9e, 0x79, 0xda, 0x0c, 0xe4, 0x1a, 0x7d, 0x04, 0x2d, 0x86, 0xa7, 0x34, 0x8d, 0x59, 0xc8,
-	0x48, 0x3a, 0xc5, 0x21, 0xe3, 0x51, 0xce, 0x43, 0x4e, 0x12, 0xdc, 0xb2, 0x24, 0xe6, 0x2d, 0x65,
-	0x1f, 0x0b, 0xf3, 0x58, 0x58, 0x1f, 0x93, 0x04, 0xa3, 0xf7, 0xe1, 0x8d, 0x45, 0xc4, 0x78, 0x38,
-	0xa5, 0x49, 0x42, 0x78, 0x58, 0x1c, 0x57, 0x93, 0xc7, 0x1d, 0x0b, 0xc3, 0x17, 0xf2, 0xbb, 0x4c,
-	0xd5, 0xf9, 0xd3, 0x84, 0xe6, 0x43, 0x7c, 0xfe, 0x24, 0x5a, 0x90, 0xd8, 0x5b, 0xd0, 0xe9, 0xd3,
-	0x1b, 0x26, 0xfe, 0x2d, 0xdc, 0x9a, 0x88, 0xb0, 0x30, 0x13, 0xb9, 0x31, 0xcc, 0xc3, 0x39, 0x8e,
-	0x62, 0x9c, 0xcb, 0x9b, 0xd8, 0x83, 0xae, 0xab, 0xd5, 0xa0, 0xe0, 0x6b, 0x14, 0xe5, 0x7c, 0x8c,
-	0xb9, 0x2f, 0xdd, 0x3c, 0xeb, 0xd9, 0x8b, 0xae, 0x11, 0x20, 0x89, 0xb1, 0x63, 0x41, 0x9f, 0x82,
-	0x5d, 0x22, 0x33, 0x79, 0x63, 0x7b, 0xd0, 0xd1, 0xf1, 0x44, 0x25, 0x5c, 0x51, 0x09, 0xd7, 0x23,
-	0xfc, 0xf3, 0x3c, 0x8f, 0x2e, 0x02, 0xd8, 0x02, 0x31, 0xf4, 0x16, 0x1c, 0x10, 0xa6, 0x48, 0x90,
-	0xd7, 0x6f, 0x04, 0x0d, 0xc2, 0x8a, 0xcb, 0x3b, 0x3e, 0x34, 0x46, 0x39, 0xcd, 0x28, 0x8b, 0x16,
-	0xe8, 0x13, 0x68, 0x64, 0x6a, 0x2d, 0xef, 0x6c, 0x0f, 0xda, 0x15, 0x69, 0x2b, 0x0f, 0x95, 0xf1,
-	0x36, 0xc2, 0xf9, 0xd9, 0x04, 0x7b, 0x63, 0x1c, 0x3d, 0x7a, 0x70, 0x25, 0x7f, 0x1f, 0x00, 0xda,
-	0xc4, 0x84, 0x19, 0x5d, 0x84, 0x3a, 0x99, 0xaf, 0x6f, 0x2c, 0x23, 0xba, 0x90, 0x75, 0x41, 0x77,
-	0xe1, 0x50, 0xf7, 0x56, 0x74, 0xfe, 0xcb, 0xf5, 0x55, 0x6e, 0xb6, 0x86, 0xe6, 0x3c, 0x85, 0x03,
-	0x6f, 0xc3, 0xc9, 0x0d, 0x6b, 0xfb, 0x21, 0x58, 0x82, 0x7b, 0x75, 0xf6, 0xed, 0xea, 0x52, 0xaa,
-	0x33, 0xa5, 0xa7, 0x33, 0x00, 0xeb, 0x09, 0xe5, 0xa2, 0x03, 0xad, 0x15, 0xe5, 0x58, 0xb1, 0x59,
-	0x11, 0x29, 0xbc, 0x02, 0xe9, 0xe3, 0xfc, 0x68, 0x42, 0xdd, 0x8f, 0x98, 0x8c, 0xbb, 0x59, 0x7e,
-	0x67, 0x60, 0x09, 0x34, 0x99, 0xdf, 0x51, 0x55, 0xab, 0x8d, 0xc9, 0x2c, 0xc5, 0xf1, 0x90, 0xcd,
-	0x1e, 0x5f, 0x64, 0x38, 0x90, 0xce, 0x02, 0x8a, 0xa4, 0x31, 0xfe, 0x41, 0x36, 0x54, 0x2d, 0x28,
-	0x36, 0xce, 0xaf, 0x26, 0x1c, 0x8a, 0x0c, 0xc6, 0x98, 0x0f, 0xa3, 0xef, 0x07, 0x67, 0xff, 0x47,
-	0x26, 0x5f, 0x41, 0xa3, 0x68, 0x70, 0x12, 0xab, 0xee, 0x7e, 0xf3, 0xe5, 0x40, 0x59, 0xbb, 0x7b,
-	0x5f, 0x7a, 0xc7, 0x82, 0xe5, 0xf5, 0x8b, 0x6e, 0x5d, 0x7d, 0x08, 0xea, 0x32, 0xf6, 0x5e, 0xec,
-	0xfc, 0x61, 0x82, 0xad, 0x52, 0xf7, 0x08, 0x67, 0xaf, 0x4e, 0xe6, 0xe8, 0x0e, 0xd4, 0x44, 0x07,
-	0x30, 0x39, 0x9c, 0xff, 0xb5, 0xb9, 0x8b, 0x10, 0xe7, 0x2f, 0x0b, 0xea, 0x43, 0xcc, 0x58, 0x34,
-	0xc3, 0xe8, 0x3e, 0x1c, 0xa5, 0xf8, 0xbc, 0x18, 0xa8, 0x50, 0xca, 0x68, 0xd1, 0x77, 0x8e, 0x5b,
-	0xf5, 0x03, 0x70, 0x75, 0x99, 0xf6, 0x8d, 0xe0, 0x30, 0xd5, 0x65, 0x7b, 0x08, 0xc7, 0x02, 0x6b,
-	0x25, 0xf4, 0x30, 0x94, 0x89, 0x4a, 0xbe, 0xec, 0xc1, 0x7b, 0x57, 0x82, 0x95, 0xda, 0xe9, 0x1b,
-	0x41, 0x33, 0xdd, 0x11, 0x53, 0x5d, 0x5a, 0x2a, 0x46, 0xb8, 0xc4, 0xd9, 0x28, 0x88, 0xaf, 0x49,
-	0x0b, 0xfa, 0xfa, 0x1f, 0x22, 0x50, 0x70, 0xfd, 0xee, 0xf5, 0x08, 0xa3, 0x47, 0x0f, 0xfc, 0x5d,
-	0x0d, 0x40, 0x9f, 0x01, 0x94, 0x52, 0xaa, 0xd8, 0xee, 0x56, 0xa3, 0x6c, 0xb5, 0xc2, 0x37, 0x82,
-	0x83, 0xad, 0x98, 0x0a, 0x29, 0x90, 0x03, 0xbd, 0xff, 0xb2, 0x3c, 0x96, 0xb1, 0xa2, 0x0b, 0x7d,
-	0xa3, 0x18, 0x6b, 0x74, 0x07, 0x1a, 0xf3, 0x88, 0x85, 0x32, 0xaa, 0x2e, 0xa3, 0xde, 0xa9, 0x8e,
-	0x52, 0xb3, 0xef, 0x1b, 0x41, 0x7d, 0xae, 0x64, 0xe0, 0x3e, 0x1c, 0x89, 0x38, 0xf9, 0x3b, 0x49,
-	0xc4, 0x38, 0xb6, 0x1a, 0xd7, 0x15, 0x54, 0x1f, 0x5c, 0x51, 0xd0, 0x95, 0x3e, 0xc8, 0x77, 0xa1,
-	0xb9, 0xc5, 0x12, 0xfd, 0xd4, 0x3a, 0xb8, 0x8e, 0x44, 0x6d, 0x90, 0x04, 0x89, 0xab, 0x72, 0xeb,
-	0xd5, 0x60, 0x8f, 0x2d, 0x13, 0xef, 0x9b, 0x67, 0xeb, 0x8e, 0xf9, 0x7c, 0xdd, 0x31, 0x7f, 0x5f,
-	0x77, 0xcc, 0x9f, 0x2e, 0x3b, 0xc6, 0xf3, 0xcb, 0x8e, 0xf1, 0xdb, 0x65, 0xc7, 0xf8, 0xee, 0xe3,
-	0x19, 0xe1, 0xf3, 0xe5, 0xc4, 0x9d, 0xd2, 0xa4, 0xaf, 0xbf, 0x26, 0xca, 0x65, 0xf1, 0xea, 0xa8,
-	0x7a, 0xb7, 0x4c, 0xf6, 0xa5, 0xed, 0xec, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc7, 0x5c, 0x91,
-	0x04, 0xd6, 0x08, 0x00, 0x00,
+	// 970 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x56, 0xdd, 0x6e, 0xe3, 0x44,
+	0x14, 0xb6, 0x37, 0x71, 0x92, 0x9e, 0x24, 0x5b, 0x18, 0x75, 0x77, 0x4d, 0x81, 0xb4, 0x18, 0x2e,
+	0x22, 0x84, 0x12, 0x94, 0x5e, 0x20, 0x2d, 0x48, 0x40, 0x16, 0x58, 0x17, 0xb6, 0xbb, 0xd1, 0x64,
+	0x59, 0x21, 0x84, 0x64, 0x39, 0xf6, 0x28, 0x31, 0x8d, 0x7f, 0xe4, 0x99, 0xb4, 0xcd, 0x2d, 0x4f,
+	0xc0, 0x03, 0x20, 0xf1, 0x14, 0x48, 0x3c, 0xc2, 0x5e, 0xee, 0x25, 0x57, 0x15, 0x6a, 0x79, 0x03,
+	0xc4, 0x3d, 0x9a, 0x63, 0x27, 0x9e, 0xd0, 0xb4, 0x90, 0x1b, 0x04, 0x77, 0x33, 0x73, 0xce, 0xf9,
+	0xfc, 0x9d, 0x9f, 0xf9, 0xc6, 0xb0, 0x2f, 0x58, 0xe4, 0xb3, 0x34, 0x0c, 0x22, 0xd1, 0xf5, 0xe2,
+	0x88, 0xb3, 0x88, 0xcf, 0x78, 0x57, 0xcc, 0x13, 0xc6, 0x3b, 0x49, 0x1a, 0x8b, 0x98, 0xec, 0x14,
+	0x1e, 0x9d, 0xa5, 0xc7, 0xee, 0xce, 0x38, 0x1e, 0xc7, 0xe8, 0xd0, 0x95, 0xab, 0xcc, 0x77, 0xf7,
+	0x35, 0x05, 0x0d, 0x31, 0x54, 0xa4, 0x35, 0xd6, 0xd1, 0x34, 0xf6, 0x8e, 0x73, 0xab, 0xca, 0x64,
+	0x1a, 0x8c, 0x78, 0x77, 0x14, 0x88, 0x95, 0x78, 0xeb, 0x27, 0x1d, 0x1a, 0x8f, 0xd9, 0x29, 0x8d,
+	0x67, 0x91, 0x3f, 0x14, 0x2c, 0x21, 0x77, 0xa1, 0x32, 0x61, 0xc1, 0x78, 0x22, 0x4c, 0x7d, 0x5f,
+	0x6f, 0x97, 0x68, 0xbe, 0x23, 0x3b, 0x60, 0xa4, 0xd2, 0xc9, 0xbc, 0xb5, 0xaf, 0xb7, 0x0d, 0x9a,
+	0x6d, 0x08, 0x81, 0x32, 0x17, 0x2c, 0x31, 0x4b, 0xfb, 0x7a, 0xbb, 0x49, 0x71, 0x4d, 0xde, 0x03,
+	0x93, 0x33, 0x2f, 0x8e, 0x7c, 0xee, 0xf0, 0x20, 0xf2, 0x98, 0xc3, 0x85, 0x9b, 0x0a, 0x47, 0x04,
+	0x21, 0x33, 0xcb, 0x88, 0x79, 0x27, 0xb7, 0x0f, 0xa5, 0x79, 0x28, 0xad, 0x4f, 0x83, 0x90, 0x91,
+	0xb7, 0xe1, 0xe5, 0xa9, 0xcb, 0x85, 0xe3, 0xc5, 0x61, 0x18, 0x08, 0x27, 0xfb, 0x9c, 0x81, 0x9f,
+	0xdb, 0x96, 0x86, 0x07, 0x78, 0x8e, 0x54, 0xad, 0x3f, 0x74, 0x68, 0x3e, 0x66, 0xa7, 0xcf, 0xdc,
+	0x69, 0xe0, 0xf7, 0x65, 0xc6, 0x1b, 0x12, 0xff, 0x0a, 0xee, 0x60, 0xa1, 0x9c, 0x44, 0x72, 0xe3,
+	0x4c, 0x38, 0x13, 0xe6, 0xfa, 0x2c, 0xc5, 0x4c, 0xea, 0xbd, 0xbd, 0x8e, 0xd2, 0xa1, 0xac, 0x5e,
+	0x03, 0x37, 0x15, 0x43, 0x26, 0x6c, 0x74, 0xeb, 0x97, 0x9f, 0x9f, 0xef, 0x69, 0x94, 0x20, 0xc6,
+	0x8a, 0x85, 0x7c, 0x08, 0xf5, 0x02, 0x99, 0x63, 0xc6, 0xf5, 0x5e, 0x4b, 0xc5, 0x93, 0x9d, 0xe8,
+	0xc8, 0x4e, 0x74, 0xfa, 0x81, 0xf8, 0x38, 0x4d, 0xdd, 0x39, 0x85, 0x25, 0x10, 0x27, 0xaf, 0xc2,
+	0x56, 0xc0, 0xf3, 0x22, 0x60, 0xfa, 0x35, 0x5a, 0x0b, 0x78, 0x96, 0xbc, 0x65, 0x43, 0x6d, 0x90,
+	0xc6, 0x49, 0xcc, 0xdd, 0x29, 0xf9, 0x00, 0x6a, 0x49, 0xbe, 0xc6, 0x9c, 0xeb, 0xbd, 0xdd, 0x35,
+	0xb4, 0x73, 0x8f, 0x9c, 0xf1, 0x32, 0xc2, 0xfa, 0x41, 0x87, 0xfa, 0xc2, 0x38, 0x78, 0xf2, 0xe8,
+	0xda, 0xfa, 0xbd, 0x03, 0x64, 0x11, 0xe3, 0x24, 0xf1, 0xd4, 0x51, 0x8b, 0xf9, 0xd2, 0xc2, 0x32,
+	0x88, 0xa7, 0xd8, 0x17, 0xf2, 0x10, 0x1a, 0xaa, 0xb7, 0x59, 0xfa, 0x27, 0xe9, 0xe7, 0xdc, 0xea,
+	0x0a, 0x9a, 0x75, 0x0c, 0x5b, 0xfd, 0x45, 0x4d, 0x36, 0xec, 0xed, 0xbb, 0x50, 0x96, 0xb5, 0xcf,
+	0xbf, 0x7d, 0x77, 0x7d, 0x2b, 0xf3, 0x6f, 0xa2, 0xa7, 0xd5, 0x83, 0xf2, 0xb3, 0x58, 0xc8, 0x09,
+	0x2c, 0x9f, 0xc4, 0x82, 0x99, 0xfa, 0x75, 0x91, 0xd2, 0x8b, 0xa2, 0x8f, 0xf5, 0x9d, 0x0e, 0x55,
+	0xdb, 0xe5, 0x18, 0xb7, 0x19, 0xbf, 0x03, 0x28, 0x4b, 0x34, 0xe4, 0x77, 0x7b, 0xdd, 0xa8, 0x0d,
+	0x83, 0x71, 0xc4, 0xfc, 0x23, 0x3e, 0x7e, 0x3a, 0x4f, 0x18, 0x45, 0x67, 0x09, 0x15, 0x44, 0x3e,
+	0x3b, 0xc3, 0x81, 0x32, 0x68, 0xb6, 0xb1, 0x7e, 0xd6, 0xa1, 0x21, 0x19, 0x0c, 0x99, 0x38, 0x72,
+	0xbf, 0xed, 0x1d, 0xfc, 0x1b, 0x4c, 0x3e, 0x85, 0x5a, 0x36, 0xe0, 0x81, 0x9f, 0x4f, 0xf7, 0x2b,
+	0x57, 0x03, 0xb1, 0x77, 0x87, 0x9f, 0xf4, 0xb7, 0x65, 0x95, 0x2f, 0xce, 0xf7, 0xaa, 0xf9, 0x01,
+	0xad, 0x62, 0xec, 0xa1, 0x6f, 0xfd, 0xae, 0x43, 0x3d, 0xa7, 0xde, 0x0f, 0x04, 0xff, 0xff, 0x30,
+	0x27, 0xf7, 0xc1, 0x90, 0x13, 0xc0, 0x4d, 0x63, 0x83, 0xe1, 0xce, 0x42, 0xac, 0xdf, 0x74, 0x68,
+	0x3c, 0x88, 0xc3, 0xc4, 0xf5, 0xc4, 0x7f, 0x4b, 0xb6, 0x0e, 0xc0, 0xc0, 0xd3, 0xbc, 0x30, 0xf7,
+	0xae, 0x29, 0xcc, 0x22, 0x1b, 0xf4, 0x25, 0xf7, 0xa0, 0x2a, 0xce, 0x9c, 0x63, 0x36, 0x97, 0xb5,
+	0x28, 0xb5, 0x1b, 0xb4, 0x22, 0xce, 0xbe, 0x60, 0x73, 0x6e, 0x4d, 0x80, 0xa8, 0x59, 0x52, 0xc6,
+	0x67, 0xd3, 0x4d, 0xaf, 0xf1, 0x5b, 0xd0, 0x4c, 0xe5, 0x3b, 0xc1, 0x45, 0x3a, 0xf3, 0x04, 0xf3,
+	0x31, 0xc7, 0x1a, 0x5d, 0x3d, 0xb4, 0x7e, 0xac, 0x40, 0xf5, 0x88, 0x71, 0xee, 0x8e, 0x19, 0xf9,
+	0x1c, 0x6e, 0x47, 0xec, 0x34, 0x53, 0x28, 0x07, 0xdf, 0xa5, 0xec, 0x22, 0x5b, 0x9d, 0x75, 0xef,
+	0x6d, 0x47, 0x7d, 0xf7, 0x6c, 0x8d, 0x36, 0x22, 0x65, 0x4f, 0x8e, 0x60, 0x5b, 0x62, 0x9d, 0xc8,
+	0x07, 0xc6, 0xc9, 0x2a, 0x73, 0x0b, 0xc1, 0xde, 0xbc, 0x16, 0xac, 0x78, 0x8c, 0x6c, 0x8d, 0x36,
+	0x23, 0xf5, 0x60, 0x45, 0xab, 0xd7, 0x68, 0x62, 0x81, 0xb3, 0x90, 0x64, 0x5b, 0xd1, 0x6a, 0xf2,
+	0xd9, 0x5f, 0x54, 0x35, 0xeb, 0xd1, 0x1b, 0x37, 0x23, 0x0c, 0x9e, 0x3c, 0xb2, 0x57, 0x45, 0x95,
+	0x7c, 0x04, 0x50, 0x8c, 0x8f, 0x69, 0x5c, 0x9d, 0x99, 0x02, 0x65, 0x29, 0xbe, 0xb6, 0x46, 0xb7,
+	0x96, 0xf3, 0x22, 0xb5, 0x15, 0x15, 0xb2, 0x72, 0xf5, 0xbd, 0x29, 0x62, 0xe5, 0xb5, 0xb6, 0xb5,
+	0x4c, 0x27, 0xc9, 0x7d, 0xa8, 0x4d, 0x5c, 0xee, 0x60, 0x54, 0x15, 0xa3, 0x5e, 0x5f, 0x1f, 0x95,
+	0x8b, 0xa9, 0xad, 0xd1, 0xea, 0x24, 0x5b, 0xca, 0x86, 0xca, 0x38, 0x1c, 0xf4, 0x50, 0xea, 0x9b,
+	0x59, 0xbb, 0xa9, 0xa1, 0xaa, 0x12, 0xca, 0x86, 0x9e, 0x28, 0x7b, 0xf2, 0x10, 0x9a, 0x4b, 0x2c,
+	0x79, 0x41, 0xcd, 0xad, 0x9b, 0x8a, 0xa8, 0x28, 0x93, 0x2c, 0xe2, 0x49, 0xb1, 0x25, 0x87, 0xd0,
+	0xf4, 0xb2, 0xd9, 0xce, 0xe7, 0x02, 0x6e, 0xe2, 0xa4, 0x5e, 0x03, 0xc9, 0xc9, 0x53, 0x2f, 0xff,
+	0x37, 0xb0, 0xb3, 0x02, 0xe5, 0xa4, 0x78, 0x51, 0xcc, 0x3a, 0x22, 0xb6, 0xff, 0x1e, 0x31, 0xbb,
+	0x58, 0xb6, 0x46, 0x89, 0x77, 0xe5, 0xb4, 0x6f, 0x40, 0x89, 0xcf, 0xc2, 0xfe, 0x97, 0xcf, 0x2f,
+	0x5a, 0xfa, 0x8b, 0x8b, 0x96, 0xfe, 0xeb, 0x45, 0x4b, 0xff, 0xfe, 0xb2, 0xa5, 0xbd, 0xb8, 0x6c,
+	0x69, 0xbf, 0x5c, 0xb6, 0xb4, 0xaf, 0xdf, 0x1f, 0x07, 0x62, 0x32, 0x1b, 0x75, 0xbc, 0x38, 0xec,
+	0xaa, 0xff, 0x91, 0xc5, 0x32, 0xfb, 0x1b, 0x5d, 0xf7, 0x3f, 0x3b, 0xaa, 0xa0, 0xed, 0xe0, 0xcf,
+	0x01, 0x00, 0x2b, 0x82, 0xa3, 0xd1, 0xee, 0x0a, 0x00, 0x00,
 }
 
 func (m *NewRoundStep) Marshal() (dAtA []byte, err error) {
@@ -1277,6 +1453,111 @@ func (m *VoteSetBits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *CompactBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.TxKeys) > 0 {
+		for iNdEx := len(m.TxKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TxKeys[iNdEx])
+			copy(dAtA[i:], m.TxKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.TxKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	{
+		size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size, err := m.BlockPartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactBlockResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactBlockResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactBlockResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Reconstructed {
+		i--
+		if m.Reconstructed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *Message) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1498,6 +1779,48 @@ func (m *Message_VoteSetBits) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_CompactBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CompactBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CompactBlock != nil {
+		{
+			size, err := m.CompactBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CompactBlockResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CompactBlockResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CompactBlockResult != nil {
+		{
+			size, err := m.CompactBlockResult.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -1678,44 +2001,87 @@ func (m *VoteSetBits) Size() (n int) {
 	return n
 }
 
-func (m *Message) Size() (n int) {
+func (m *CompactBlock) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Sum != nil {
-		n += m.Sum.Size()
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	l = m.BlockPartSetHeader.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.Block.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if len(m.TxKeys) > 0 {
+		for _, b := range m.TxKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
 	}
 	return n
 }
 
-func (m *Message_NewRoundStep) Size() (n int) {
+func (m *CompactBlockResult) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.NewRoundStep != nil {
-		l = m.NewRoundStep.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if m.Reconstructed {
+		n += 2
 	}
 	return n
 }
-func (m *Message_NewValidBlock) Size() (n int) {
+
+func (m *Message) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.NewValidBlock != nil {
-		l = m.NewValidBlock.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Sum != nil {
+		n += m.Sum.Size()
 	}
 	return n
 }
-func (m *Message_Proposal) Size() (n int) {
-	if m == nil {
+
+func (m *Message_NewRoundStep) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NewRoundStep != nil {
+		l = m.NewRoundStep.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_NewValidBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NewValidBlock != nil {
+		l = m.NewValidBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_Proposal) Size() (n int) {
+	if m == nil {
 		return 0
 	}
 	var l int
@@ -1798,6 +2164,30 @@ func (m *Message_VoteSetBits) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_CompactBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactBlock != nil {
+		l = m.CompactBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_CompactBlockResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactBlockResult != nil {
+		l = m.CompactBlockResult.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
@@ -2977,6 +3367,300 @@ func (m *VoteSetBits) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CompactBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPartSetHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BlockPartSetHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxKeys = append(m.TxKeys, make([]byte, postIndex-iNdEx))
+			copy(m.TxKeys[len(m.TxKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompactBlockResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactBlockResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactBlockResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reconstructed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reconstructed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Message) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3321,6 +4005,76 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_VoteSetBits{v}
 			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CompactBlock{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CompactBlock{v}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactBlockResult", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &CompactBlockResult{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_CompactBlockResult{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
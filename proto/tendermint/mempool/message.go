@@ -12,6 +12,18 @@ func (m *Message) Wrap(pb proto.Message) error {
 	case *Txs:
 		m.Sum = &Message_Txs{Txs: msg}
 
+	case *HaveTx:
+		m.Sum = &Message_HaveTx{HaveTx: msg}
+
+	case *ResetRoute:
+		m.Sum = &Message_ResetRoute{ResetRoute: msg}
+
+	case *AnnounceTxs:
+		m.Sum = &Message_AnnounceTxs{AnnounceTxs: msg}
+
+	case *WantTxs:
+		m.Sum = &Message_WantTxs{WantTxs: msg}
+
 	default:
 		return fmt.Errorf("unknown message: %T", msg)
 	}
@@ -26,6 +38,18 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_Txs:
 		return m.GetTxs(), nil
 
+	case *Message_HaveTx:
+		return m.GetHaveTx(), nil
+
+	case *Message_ResetRoute:
+		return m.GetResetRoute(), nil
+
+	case *Message_AnnounceTxs:
+		return m.GetAnnounceTxs(), nil
+
+	case *Message_WantTxs:
+		return m.GetWantTxs(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -105,6 +105,8 @@ var xxx_messageInfo_PexRequest proto.InternalMessageInfo
 
 type PexResponse struct {
 	Addresses []PexAddress `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses"`
+	// the IP the responding peer sees the requesting peer at, if known
+	ObservedIP string `protobuf:"bytes,2,opt,name=observed_ip,json=observedIp,proto3" json:"observed_ip,omitempty"`
 }
 
 func (m *PexResponse) Reset()         { *m = PexResponse{} }
@@ -147,6 +149,13 @@ func (m *PexResponse) GetAddresses() []PexAddress {
 	return nil
 }
 
+func (m *PexResponse) GetObservedIP() string {
+	if m != nil {
+		return m.ObservedIP
+	}
+	return ""
+}
+
 type PexMessage struct {
 	// Types that are valid to be assigned to Sum:
 	//	*PexMessage_PexRequest
@@ -242,27 +251,27 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/pex.proto", fileDescriptor_81c2f011fd13be57) }
 
 var fileDescriptor_81c2f011fd13be57 = []byte{
-	// 311 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x28, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x30, 0x2a, 0xd0, 0x2f, 0x48, 0xad, 0xd0, 0x2b,
-	0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x43, 0xc8, 0xe8, 0x15, 0x18, 0x15, 0x48, 0x89, 0xa4, 0xe7,
-	0xa7, 0xe7, 0x83, 0xa5, 0xf4, 0x41, 0x2c, 0x88, 0x2a, 0x25, 0x63, 0x2e, 0xae, 0x80, 0xd4, 0x0a,
-	0xc7, 0x94, 0x94, 0xa2, 0xd4, 0xe2, 0x62, 0x21, 0x49, 0x2e, 0xe6, 0xd2, 0xa2, 0x1c, 0x09, 0x46,
-	0x05, 0x46, 0x0d, 0x4e, 0x27, 0xf6, 0x47, 0xf7, 0xe4, 0x99, 0x43, 0x83, 0x7c, 0x82, 0x40, 0x62,
-	0x5e, 0x2c, 0x1c, 0x4c, 0x02, 0xcc, 0x5e, 0x2c, 0x1c, 0xcc, 0x02, 0x2c, 0x4a, 0x3c, 0x60, 0x4d,
-	0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5, 0x25, 0x4a, 0xbe, 0x5c, 0xdc, 0x60, 0x5e, 0x71, 0x41, 0x7e,
-	0x5e, 0x71, 0xaa, 0x90, 0x1d, 0x17, 0x67, 0x22, 0xc4, 0xb8, 0xd4, 0x62, 0x09, 0x46, 0x05, 0x66,
-	0x0d, 0x6e, 0x23, 0x29, 0x3d, 0x54, 0xb7, 0xe8, 0x21, 0xac, 0x74, 0x62, 0x39, 0x71, 0x4f, 0x9e,
-	0x21, 0x08, 0xa1, 0x45, 0x69, 0x01, 0x23, 0xd8, 0x74, 0xdf, 0xd4, 0xe2, 0xe2, 0xc4, 0xf4, 0x54,
-	0x21, 0x5b, 0x2e, 0xee, 0x82, 0xd4, 0x8a, 0xf8, 0x22, 0x88, 0x65, 0x12, 0xcc, 0x0a, 0x8c, 0x38,
-	0x0c, 0x84, 0x3a, 0xc7, 0x83, 0x21, 0x88, 0xab, 0x00, 0xce, 0x13, 0x72, 0xe0, 0xe2, 0x81, 0x68,
-	0x87, 0xb8, 0x4e, 0x82, 0x05, 0xac, 0x5f, 0x1a, 0xab, 0x7e, 0x88, 0x12, 0x0f, 0x86, 0x20, 0xee,
-	0x02, 0x04, 0xd7, 0x89, 0x95, 0x8b, 0xb9, 0xb8, 0x34, 0xd7, 0x8b, 0x85, 0x83, 0x51, 0x80, 0x09,
-	0x12, 0x0a, 0x4e, 0xfe, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c,
-	0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x10, 0x65, 0x9a,
-	0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x8f, 0x14, 0x33, 0xc8, 0x91, 0x04,
-	0x8e, 0x01, 0xd4, 0x58, 0x4b, 0x62, 0x03, 0x8b, 0x1a, 0x03, 0x02, 0x00, 0x00, 0xff, 0xff, 0xa7,
-	0x1d, 0xdd, 0x6f, 0xce, 0x01, 0x00, 0x00,
+	// 319 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x51, 0x41, 0x4f, 0xf2, 0x40,
+	0x10, 0xed, 0x7e, 0xe5, 0xd3, 0x30, 0x25, 0x1c, 0x1a, 0x0f, 0x15, 0x93, 0x42, 0x7a, 0x91, 0x53,
+	0x9b, 0x60, 0x3c, 0x6a, 0xb4, 0x27, 0x48, 0x34, 0x90, 0x4d, 0xbc, 0x78, 0x21, 0x60, 0x27, 0x95,
+	0x44, 0xd8, 0x71, 0xb7, 0x35, 0x9c, 0xfc, 0x0d, 0x1e, 0xfc, 0x51, 0x1c, 0x39, 0x7a, 0x22, 0xa6,
+	0xfc, 0x11, 0xc3, 0x2e, 0x5a, 0x48, 0xf4, 0x36, 0xf3, 0xe6, 0xbd, 0x7d, 0x6f, 0xf3, 0xc0, 0xcb,
+	0x70, 0x96, 0xa0, 0x9c, 0x4e, 0x66, 0x59, 0x44, 0x1d, 0x8a, 0x08, 0xe7, 0x21, 0x49, 0x91, 0x09,
+	0xb7, 0x5e, 0x5e, 0x42, 0xea, 0x50, 0xe3, 0x28, 0x15, 0xa9, 0xd0, 0xa7, 0x68, 0x33, 0x19, 0x56,
+	0x70, 0x0a, 0x30, 0xc0, 0xf9, 0x75, 0x92, 0x48, 0x54, 0xca, 0x3d, 0x06, 0x3b, 0x97, 0x4f, 0x1e,
+	0x6b, 0xb1, 0x76, 0x35, 0x3e, 0x2c, 0x56, 0x4d, 0xfb, 0x8e, 0xdf, 0xf0, 0x0d, 0x16, 0xd4, 0x34,
+	0x91, 0xe3, 0x73, 0x8e, 0x2a, 0x0b, 0x5e, 0xc1, 0xd1, 0x9b, 0x22, 0x31, 0x53, 0xe8, 0x5e, 0x42,
+	0x75, 0x64, 0x9e, 0x40, 0xe5, 0xb1, 0x96, 0xdd, 0x76, 0x3a, 0x8d, 0x70, 0xdf, 0x3f, 0x2c, 0x6d,
+	0xe2, 0xca, 0x62, 0xd5, 0xb4, 0x78, 0x29, 0x71, 0x23, 0x70, 0xc4, 0x58, 0xa1, 0x7c, 0xc1, 0x64,
+	0x38, 0x21, 0xef, 0x9f, 0xf6, 0xaf, 0x17, 0xab, 0x26, 0xf4, 0xb7, 0x70, 0x6f, 0xc0, 0xe1, 0x9b,
+	0xd2, 0xa3, 0xe0, 0x9d, 0xe9, 0x38, 0xb7, 0xa8, 0xd4, 0x28, 0x45, 0xf7, 0x02, 0x1c, 0xc2, 0xf9,
+	0x50, 0x9a, 0x74, 0x9e, 0xdd, 0x62, 0x7f, 0x24, 0xd8, 0xe6, 0xef, 0x5a, 0x1c, 0xe8, 0x67, 0x73,
+	0xaf, 0xa0, 0x66, 0xe4, 0xe6, 0x3b, 0x5e, 0x45, 0xeb, 0x4f, 0x7e, 0xd5, 0x1b, 0x4a, 0xd7, 0xe2,
+	0x0e, 0x95, 0x6b, 0xfc, 0x1f, 0x6c, 0x95, 0x4f, 0xe3, 0xfe, 0xa2, 0xf0, 0xd9, 0xb2, 0xf0, 0xd9,
+	0x67, 0xe1, 0xb3, 0xb7, 0xb5, 0x6f, 0x2d, 0xd7, 0xbe, 0xf5, 0xb1, 0xf6, 0xad, 0xfb, 0xf3, 0x74,
+	0x92, 0x3d, 0xe6, 0xe3, 0xf0, 0x41, 0x4c, 0xa3, 0x9d, 0xca, 0x76, 0x46, 0x53, 0xcd, 0x7e, 0x9d,
+	0xe3, 0x03, 0x8d, 0x9e, 0x7d, 0x0d, 0x00, 0xf1, 0x16, 0x30, 0x37, 0xe7, 0x01, 0x00, 0x00,
 }
 
 func (m *PexAddress) Marshal() (dAtA []byte, err error) {
@@ -338,6 +347,13 @@ func (m *PexResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ObservedIP) > 0 {
+		i -= len(m.ObservedIP)
+		copy(dAtA[i:], m.ObservedIP)
+		i = encodeVarintPex(dAtA, i, uint64(len(m.ObservedIP)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if len(m.Addresses) > 0 {
 		for iNdEx := len(m.Addresses) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -474,6 +490,10 @@ func (m *PexResponse) Size() (n int) {
 			n += 1 + l + sovPex(uint64(l))
 		}
 	}
+	l = len(m.ObservedIP)
+	if l > 0 {
+		n += 1 + l + sovPex(uint64(l))
+	}
 	return n
 }
 
@@ -715,6 +735,38 @@ func (m *PexResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ObservedIP", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ObservedIP = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPex(dAtA[iNdEx:])
@@ -9,6 +9,8 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	_ "github.com/golang/protobuf/ptypes/duration"
+	crypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	io "io"
 	math "math"
 	math_bits "math/bits"
@@ -88,14 +90,16 @@ func (m *ProtocolVersion) GetApp() uint64 {
 }
 
 type NodeInfo struct {
-	ProtocolVersion ProtocolVersion `protobuf:"bytes,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version"`
-	NodeID          string          `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
-	ListenAddr      string          `protobuf:"bytes,3,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
-	Network         string          `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
-	Version         string          `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
-	Channels        []byte          `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
-	Moniker         string          `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
-	Other           NodeInfoOther   `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	ProtocolVersion ProtocolVersion   `protobuf:"bytes,1,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version"`
+	NodeID          string            `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ListenAddr      string            `protobuf:"bytes,3,opt,name=listen_addr,json=listenAddr,proto3" json:"listen_addr,omitempty"`
+	Network         string            `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	Version         string            `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Channels        []byte            `protobuf:"bytes,6,opt,name=channels,proto3" json:"channels,omitempty"`
+	Moniker         string            `protobuf:"bytes,7,opt,name=moniker,proto3" json:"moniker,omitempty"`
+	Other           NodeInfoOther     `protobuf:"bytes,8,opt,name=other,proto3" json:"other"`
+	ChannelVersions []ChannelVersions `protobuf:"bytes,9,rep,name=channel_versions,json=channelVersions,proto3" json:"channel_versions"`
+	KeyRotation     *NodeKeyRotation  `protobuf:"bytes,10,opt,name=key_rotation,json=keyRotation,proto3" json:"key_rotation,omitempty"`
 }
 
 func (m *NodeInfo) Reset()         { *m = NodeInfo{} }
@@ -187,6 +191,159 @@ func (m *NodeInfo) GetOther() NodeInfoOther {
 	return NodeInfoOther{}
 }
 
+func (m *NodeInfo) GetChannelVersions() []ChannelVersions {
+	if m != nil {
+		return m.ChannelVersions
+	}
+	return nil
+}
+
+func (m *NodeInfo) GetKeyRotation() *NodeKeyRotation {
+	if m != nil {
+		return m.KeyRotation
+	}
+	return nil
+}
+
+// NodeKeyRotation records a node changing its node key, and so its node ID.
+// It is signed by both the old and the new node key.
+type NodeKeyRotation struct {
+	OldPubKey    crypto.PublicKey `protobuf:"bytes,1,opt,name=old_pub_key,json=oldPubKey,proto3" json:"old_pub_key"`
+	NewPubKey    crypto.PublicKey `protobuf:"bytes,2,opt,name=new_pub_key,json=newPubKey,proto3" json:"new_pub_key"`
+	Network      string           `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	Time         time.Time        `protobuf:"bytes,4,opt,name=time,proto3,stdtime" json:"time"`
+	OldSignature []byte           `protobuf:"bytes,5,opt,name=old_signature,json=oldSignature,proto3" json:"old_signature,omitempty"`
+	NewSignature []byte           `protobuf:"bytes,6,opt,name=new_signature,json=newSignature,proto3" json:"new_signature,omitempty"`
+}
+
+func (m *NodeKeyRotation) Reset()         { *m = NodeKeyRotation{} }
+func (m *NodeKeyRotation) String() string { return proto.CompactTextString(m) }
+func (*NodeKeyRotation) ProtoMessage()    {}
+func (*NodeKeyRotation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{2}
+}
+func (m *NodeKeyRotation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeKeyRotation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeKeyRotation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeKeyRotation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeKeyRotation.Merge(m, src)
+}
+func (m *NodeKeyRotation) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeKeyRotation) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeKeyRotation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeKeyRotation proto.InternalMessageInfo
+
+func (m *NodeKeyRotation) GetOldPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.OldPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *NodeKeyRotation) GetNewPubKey() crypto.PublicKey {
+	if m != nil {
+		return m.NewPubKey
+	}
+	return crypto.PublicKey{}
+}
+
+func (m *NodeKeyRotation) GetNetwork() string {
+	if m != nil {
+		return m.Network
+	}
+	return ""
+}
+
+func (m *NodeKeyRotation) GetTime() time.Time {
+	if m != nil {
+		return m.Time
+	}
+	return time.Time{}
+}
+
+func (m *NodeKeyRotation) GetOldSignature() []byte {
+	if m != nil {
+		return m.OldSignature
+	}
+	return nil
+}
+
+func (m *NodeKeyRotation) GetNewSignature() []byte {
+	if m != nil {
+		return m.NewSignature
+	}
+	return nil
+}
+
+// ChannelVersions are the wire format versions of a channel a node supports.
+type ChannelVersions struct {
+	ChannelID uint32   `protobuf:"varint,1,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	Versions  []uint32 `protobuf:"varint,2,rep,packed,name=versions,proto3" json:"versions,omitempty"`
+}
+
+func (m *ChannelVersions) Reset()         { *m = ChannelVersions{} }
+func (m *ChannelVersions) String() string { return proto.CompactTextString(m) }
+func (*ChannelVersions) ProtoMessage()    {}
+func (*ChannelVersions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c8a29e659aeca578, []int{3}
+}
+func (m *ChannelVersions) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelVersions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelVersions.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChannelVersions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelVersions.Merge(m, src)
+}
+func (m *ChannelVersions) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelVersions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelVersions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChannelVersions proto.InternalMessageInfo
+
+func (m *ChannelVersions) GetChannelID() uint32 {
+	if m != nil {
+		return m.ChannelID
+	}
+	return 0
+}
+
+func (m *ChannelVersions) GetVersions() []uint32 {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
 type NodeInfoOther struct {
 	TxIndex    string `protobuf:"bytes,1,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
 	RPCAddress string `protobuf:"bytes,2,opt,name=rpc_address,json=rpcAddress,proto3" json:"rpc_address,omitempty"`
@@ -196,7 +353,7 @@ func (m *NodeInfoOther) Reset()         { *m = NodeInfoOther{} }
 func (m *NodeInfoOther) String() string { return proto.CompactTextString(m) }
 func (*NodeInfoOther) ProtoMessage()    {}
 func (*NodeInfoOther) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{2}
+	return fileDescriptor_c8a29e659aeca578, []int{4}
 }
 func (m *NodeInfoOther) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -244,13 +401,16 @@ type PeerInfo struct {
 	AddressInfo   []*PeerAddressInfo `protobuf:"bytes,2,rep,name=address_info,json=addressInfo,proto3" json:"address_info,omitempty"`
 	LastConnected *time.Time         `protobuf:"bytes,3,opt,name=last_connected,json=lastConnected,proto3,stdtime" json:"last_connected,omitempty"`
 	Inactive      bool               `protobuf:"varint,4,opt,name=inactive,proto3" json:"inactive,omitempty"`
+	MutableScore  int64              `protobuf:"varint,5,opt,name=mutable_score,json=mutableScore,proto3" json:"mutable_score,omitempty"`
+	Uptime        time.Duration      `protobuf:"bytes,6,opt,name=uptime,proto3,stdduration" json:"uptime"`
+	Latency       time.Duration      `protobuf:"bytes,7,opt,name=latency,proto3,stdduration" json:"latency"`
 }
 
 func (m *PeerInfo) Reset()         { *m = PeerInfo{} }
 func (m *PeerInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerInfo) ProtoMessage()    {}
 func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{3}
+	return fileDescriptor_c8a29e659aeca578, []int{5}
 }
 func (m *PeerInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -307,6 +467,27 @@ func (m *PeerInfo) GetInactive() bool {
 	return false
 }
 
+func (m *PeerInfo) GetMutableScore() int64 {
+	if m != nil {
+		return m.MutableScore
+	}
+	return 0
+}
+
+func (m *PeerInfo) GetUptime() time.Duration {
+	if m != nil {
+		return m.Uptime
+	}
+	return 0
+}
+
+func (m *PeerInfo) GetLatency() time.Duration {
+	if m != nil {
+		return m.Latency
+	}
+	return 0
+}
+
 type PeerAddressInfo struct {
 	Address         string     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	LastDialSuccess *time.Time `protobuf:"bytes,2,opt,name=last_dial_success,json=lastDialSuccess,proto3,stdtime" json:"last_dial_success,omitempty"`
@@ -318,7 +499,7 @@ func (m *PeerAddressInfo) Reset()         { *m = PeerAddressInfo{} }
 func (m *PeerAddressInfo) String() string { return proto.CompactTextString(m) }
 func (*PeerAddressInfo) ProtoMessage()    {}
 func (*PeerAddressInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c8a29e659aeca578, []int{4}
+	return fileDescriptor_c8a29e659aeca578, []int{6}
 }
 func (m *PeerAddressInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -378,6 +559,8 @@ func (m *PeerAddressInfo) GetDialFailures() uint32 {
 func init() {
 	proto.RegisterType((*ProtocolVersion)(nil), "tendermint.p2p.ProtocolVersion")
 	proto.RegisterType((*NodeInfo)(nil), "tendermint.p2p.NodeInfo")
+	proto.RegisterType((*NodeKeyRotation)(nil), "tendermint.p2p.NodeKeyRotation")
+	proto.RegisterType((*ChannelVersions)(nil), "tendermint.p2p.ChannelVersions")
 	proto.RegisterType((*NodeInfoOther)(nil), "tendermint.p2p.NodeInfoOther")
 	proto.RegisterType((*PeerInfo)(nil), "tendermint.p2p.PeerInfo")
 	proto.RegisterType((*PeerAddressInfo)(nil), "tendermint.p2p.PeerAddressInfo")
@@ -386,46 +569,64 @@ func init() {
 func init() { proto.RegisterFile("tendermint/p2p/types.proto", fileDescriptor_c8a29e659aeca578) }
 
 var fileDescriptor_c8a29e659aeca578 = []byte{
-	// 621 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x41, 0x4f, 0xdb, 0x30,
-	0x14, 0x6e, 0xda, 0xd2, 0x96, 0x57, 0x4a, 0x99, 0x85, 0xa6, 0x50, 0x69, 0x0d, 0x2a, 0x17, 0x4e,
-	0x89, 0xd4, 0x69, 0x87, 0x1d, 0x09, 0x68, 0x53, 0xa5, 0x69, 0x54, 0x1e, 0xda, 0x61, 0x3b, 0x44,
-	0x69, 0xec, 0x16, 0x8b, 0xd4, 0xb6, 0x12, 0x97, 0xb1, 0x7f, 0xc1, 0xbf, 0x1a, 0xd2, 0x2e, 0x1c,
-	0x77, 0xea, 0xa6, 0x70, 0xdd, 0x8f, 0x98, 0xec, 0x24, 0xd0, 0x56, 0x3b, 0x70, 0xf3, 0xf7, 0x9e,
-	0xbf, 0xcf, 0xdf, 0x7b, 0xcf, 0x7a, 0xd0, 0x53, 0x94, 0x13, 0x9a, 0xcc, 0x19, 0x57, 0x9e, 0x1c,
-	0x4a, 0x4f, 0x7d, 0x97, 0x34, 0x75, 0x65, 0x22, 0x94, 0x40, 0xbb, 0x4f, 0x39, 0x57, 0x0e, 0x65,
-	0x6f, 0x7f, 0x26, 0x66, 0xc2, 0xa4, 0x3c, 0x7d, 0xca, 0x6f, 0xf5, 0x9c, 0x99, 0x10, 0xb3, 0x98,
-	0x7a, 0x06, 0x4d, 0x16, 0x53, 0x4f, 0xb1, 0x39, 0x4d, 0x55, 0x38, 0x97, 0xf9, 0x85, 0xc1, 0x05,
-	0x74, 0xc7, 0xfa, 0x10, 0x89, 0xf8, 0x33, 0x4d, 0x52, 0x26, 0x38, 0x3a, 0x80, 0x9a, 0x1c, 0x4a,
-	0xdb, 0x3a, 0xb4, 0x8e, 0xeb, 0x7e, 0x33, 0x5b, 0x3a, 0xb5, 0xf1, 0x70, 0x8c, 0x75, 0x0c, 0xed,
-	0xc3, 0xd6, 0x24, 0x16, 0xd1, 0x95, 0x5d, 0xd5, 0x49, 0x9c, 0x03, 0xb4, 0x07, 0xb5, 0x50, 0x4a,
-	0xbb, 0x66, 0x62, 0xfa, 0x38, 0xf8, 0x51, 0x85, 0xd6, 0x47, 0x41, 0xe8, 0x88, 0x4f, 0x05, 0x1a,
-	0xc3, 0x9e, 0x2c, 0x9e, 0x08, 0xae, 0xf3, 0x37, 0x8c, 0x78, 0x7b, 0xe8, 0xb8, 0xeb, 0x45, 0xb8,
-	0x1b, 0x56, 0xfc, 0xfa, 0xdd, 0xd2, 0xa9, 0xe0, 0xae, 0xdc, 0x70, 0x78, 0x04, 0x4d, 0x2e, 0x08,
-	0x0d, 0x18, 0x31, 0x46, 0xb6, 0x7d, 0xc8, 0x96, 0x4e, 0xc3, 0x3c, 0x78, 0x86, 0x1b, 0x3a, 0x35,
-	0x22, 0xc8, 0x81, 0x76, 0xcc, 0x52, 0x45, 0x79, 0x10, 0x12, 0x92, 0x18, 0x77, 0xdb, 0x18, 0xf2,
-	0xd0, 0x09, 0x21, 0x09, 0xb2, 0xa1, 0xc9, 0xa9, 0xfa, 0x26, 0x92, 0x2b, 0xbb, 0x6e, 0x92, 0x25,
-	0xd4, 0x99, 0xd2, 0xe8, 0x56, 0x9e, 0x29, 0x20, 0xea, 0x41, 0x2b, 0xba, 0x0c, 0x39, 0xa7, 0x71,
-	0x6a, 0x37, 0x0e, 0xad, 0xe3, 0x1d, 0xfc, 0x88, 0x35, 0x6b, 0x2e, 0x38, 0xbb, 0xa2, 0x89, 0xdd,
-	0xcc, 0x59, 0x05, 0x44, 0x6f, 0x61, 0x4b, 0xa8, 0x4b, 0x9a, 0xd8, 0x2d, 0x53, 0xf6, 0xab, 0xcd,
-	0xb2, 0xcb, 0x56, 0x9d, 0xeb, 0x4b, 0x45, 0xd1, 0x39, 0x63, 0xf0, 0x15, 0x3a, 0x6b, 0x59, 0x74,
-	0x00, 0x2d, 0x75, 0x13, 0x30, 0x4e, 0xe8, 0x8d, 0xe9, 0xe2, 0x36, 0x6e, 0xaa, 0x9b, 0x91, 0x86,
-	0xc8, 0x83, 0x76, 0x22, 0x23, 0x53, 0x2e, 0x4d, 0xd3, 0xa2, 0x35, 0xbb, 0xd9, 0xd2, 0x01, 0x3c,
-	0x3e, 0x3d, 0xc9, 0xa3, 0x18, 0x12, 0x19, 0x15, 0xe7, 0xc1, 0x4f, 0x0b, 0x5a, 0x63, 0x4a, 0x13,
-	0x33, 0xa6, 0x97, 0x50, 0x65, 0x24, 0x97, 0xf4, 0x1b, 0xd9, 0xd2, 0xa9, 0x8e, 0xce, 0x70, 0x95,
-	0x11, 0xe4, 0xc3, 0x4e, 0xa1, 0x18, 0x30, 0x3e, 0x15, 0x76, 0xf5, 0xb0, 0xf6, 0xdf, 0xd1, 0x51,
-	0x9a, 0x14, 0xba, 0x5a, 0x0e, 0xb7, 0xc3, 0x27, 0x80, 0xde, 0xc3, 0x6e, 0x1c, 0xa6, 0x2a, 0x88,
-	0x04, 0xe7, 0x34, 0x52, 0x94, 0x98, 0x71, 0xb4, 0x87, 0x3d, 0x37, 0xff, 0x9f, 0x6e, 0xf9, 0x3f,
-	0xdd, 0x8b, 0xf2, 0x7f, 0xfa, 0xf5, 0xdb, 0xdf, 0x8e, 0x85, 0x3b, 0x9a, 0x77, 0x5a, 0xd2, 0x74,
-	0xff, 0x19, 0x0f, 0x23, 0xc5, 0xae, 0xa9, 0x19, 0x5a, 0x0b, 0x3f, 0xe2, 0xc1, 0x5f, 0x0b, 0xba,
-	0x1b, 0x2e, 0xf4, 0x4c, 0xca, 0x76, 0x14, 0xcd, 0x2a, 0x20, 0xfa, 0x00, 0x2f, 0x8c, 0x25, 0xc2,
-	0xc2, 0x38, 0x48, 0x17, 0x51, 0x54, 0xb6, 0xec, 0x39, 0xae, 0xba, 0x9a, 0x7a, 0xc6, 0xc2, 0xf8,
-	0x53, 0x4e, 0x5c, 0x57, 0x9b, 0x86, 0x2c, 0x5e, 0x24, 0xf4, 0xd9, 0x35, 0x3e, 0xaa, 0xbd, 0xcb,
-	0x89, 0xe8, 0x08, 0x3a, 0xab, 0x42, 0xa9, 0x29, 0xb5, 0x83, 0x77, 0xc8, 0xd3, 0x9d, 0xd4, 0x3f,
-	0xbf, 0xcb, 0xfa, 0xd6, 0x7d, 0xd6, 0xb7, 0xfe, 0x64, 0x7d, 0xeb, 0xf6, 0xa1, 0x5f, 0xb9, 0x7f,
-	0xe8, 0x57, 0x7e, 0x3d, 0xf4, 0x2b, 0x5f, 0xde, 0xcc, 0x98, 0xba, 0x5c, 0x4c, 0xdc, 0x48, 0xcc,
-	0xbd, 0x95, 0x0d, 0xb2, 0xba, 0x4c, 0xcc, 0x9e, 0x58, 0xdf, 0x2e, 0x93, 0x86, 0x89, 0xbe, 0xfe,
-	0x17, 0x00, 0x00, 0xff, 0xff, 0x42, 0xcb, 0x37, 0x26, 0x76, 0x04, 0x00, 0x00,
+	// 900 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xcb, 0x6e, 0xe3, 0x36,
+	0x14, 0x8d, 0xad, 0xc4, 0x8f, 0x6b, 0x3b, 0x9e, 0x12, 0x83, 0x42, 0x31, 0xa6, 0x56, 0xe0, 0xd9,
+	0x64, 0x51, 0xd8, 0x80, 0x8b, 0x02, 0x2d, 0x8a, 0x2e, 0xc6, 0x31, 0x5a, 0x18, 0x29, 0x3a, 0x06,
+	0x33, 0xe8, 0xa2, 0xb3, 0x10, 0x64, 0x91, 0x71, 0x08, 0xcb, 0x24, 0x21, 0x51, 0x93, 0xe8, 0x2f,
+	0x66, 0xd9, 0x3f, 0xe9, 0x2f, 0xcc, 0x72, 0x96, 0xdd, 0xd4, 0x2d, 0x9c, 0xed, 0x7c, 0x44, 0x41,
+	0x8a, 0xf2, 0xab, 0x01, 0x9a, 0xee, 0x78, 0x5f, 0x87, 0x87, 0xf7, 0xdc, 0x2b, 0x41, 0x47, 0x51,
+	0x4e, 0x68, 0xbc, 0x64, 0x5c, 0x0d, 0xe4, 0x50, 0x0e, 0x54, 0x26, 0x69, 0xd2, 0x97, 0xb1, 0x50,
+	0x02, 0x9d, 0x6e, 0x63, 0x7d, 0x39, 0x94, 0x9d, 0xe7, 0x73, 0x31, 0x17, 0x26, 0x34, 0xd0, 0xa7,
+	0x3c, 0xab, 0xe3, 0xcd, 0x85, 0x98, 0x47, 0x74, 0x60, 0xac, 0x59, 0x7a, 0x33, 0x50, 0x6c, 0x49,
+	0x13, 0x15, 0x2c, 0xa5, 0x4d, 0xe8, 0x1e, 0x26, 0x90, 0x34, 0x0e, 0x14, 0x13, 0xdc, 0xc6, 0x5f,
+	0xec, 0x50, 0x08, 0xe3, 0x4c, 0x2a, 0x31, 0x58, 0xd0, 0xcc, 0x92, 0xe8, 0xbd, 0x81, 0xf6, 0x54,
+	0x1f, 0x42, 0x11, 0xfd, 0x42, 0xe3, 0x84, 0x09, 0x8e, 0xce, 0xc0, 0x91, 0x43, 0xe9, 0x96, 0xce,
+	0x4b, 0x17, 0xc7, 0xa3, 0xea, 0x7a, 0xe5, 0x39, 0xd3, 0xe1, 0x14, 0x6b, 0x1f, 0x7a, 0x0e, 0x27,
+	0xb3, 0x48, 0x84, 0x0b, 0xb7, 0xac, 0x83, 0x38, 0x37, 0xd0, 0x33, 0x70, 0x02, 0x29, 0x5d, 0xc7,
+	0xf8, 0xf4, 0xb1, 0xf7, 0xa7, 0x03, 0xb5, 0x9f, 0x05, 0xa1, 0x13, 0x7e, 0x23, 0xd0, 0x14, 0x9e,
+	0x49, 0x7b, 0x85, 0xff, 0x2e, 0xbf, 0xc3, 0x80, 0x37, 0x86, 0x5e, 0x7f, 0xbf, 0x05, 0xfd, 0x03,
+	0x2a, 0xa3, 0xe3, 0x0f, 0x2b, 0xef, 0x08, 0xb7, 0xe5, 0x01, 0xc3, 0x97, 0x50, 0xe5, 0x82, 0x50,
+	0x9f, 0x11, 0x43, 0xa4, 0x3e, 0x82, 0xf5, 0xca, 0xab, 0x98, 0x0b, 0xc7, 0xb8, 0xa2, 0x43, 0x13,
+	0x82, 0x3c, 0x68, 0x44, 0x2c, 0x51, 0x94, 0xfb, 0x01, 0x21, 0xb1, 0x61, 0x57, 0xc7, 0x90, 0xbb,
+	0x5e, 0x11, 0x12, 0x23, 0x17, 0xaa, 0x9c, 0xaa, 0x3b, 0x11, 0x2f, 0xdc, 0x63, 0x13, 0x2c, 0x4c,
+	0x1d, 0x29, 0x88, 0x9e, 0xe4, 0x11, 0x6b, 0xa2, 0x0e, 0xd4, 0xc2, 0xdb, 0x80, 0x73, 0x1a, 0x25,
+	0x6e, 0xe5, 0xbc, 0x74, 0xd1, 0xc4, 0x1b, 0x5b, 0x57, 0x2d, 0x05, 0x67, 0x0b, 0x1a, 0xbb, 0xd5,
+	0xbc, 0xca, 0x9a, 0xe8, 0x5b, 0x38, 0x11, 0xea, 0x96, 0xc6, 0x6e, 0xcd, 0x3c, 0xfb, 0x8b, 0xc3,
+	0x67, 0x17, 0xad, 0x7a, 0xad, 0x93, 0xec, 0xa3, 0xf3, 0x0a, 0xdd, 0x3c, 0x7b, 0x41, 0xd1, 0xbb,
+	0xc4, 0xad, 0x9f, 0x3b, 0x8f, 0x35, 0xef, 0x32, 0xcf, 0xb3, 0x4d, 0x4a, 0x8a, 0xe6, 0x85, 0xfb,
+	0x6e, 0x34, 0x82, 0xe6, 0x82, 0x66, 0x7e, 0x2c, 0x94, 0x99, 0x12, 0x17, 0x1e, 0x97, 0x42, 0x73,
+	0xba, 0xa2, 0x19, 0xb6, 0x69, 0xb8, 0xb1, 0xd8, 0x1a, 0xbd, 0xdf, 0xcb, 0xd0, 0x3e, 0x48, 0x40,
+	0x23, 0x68, 0x88, 0x88, 0xf8, 0x32, 0x9d, 0xf9, 0x0b, 0x9a, 0x59, 0x85, 0x5f, 0xec, 0xc2, 0xe6,
+	0xd3, 0xd7, 0x9f, 0xa6, 0xb3, 0x88, 0x85, 0x57, 0x34, 0xb3, 0x0c, 0xeb, 0x22, 0x22, 0xd3, 0x74,
+	0x76, 0x45, 0x33, 0x8d, 0xc1, 0xe9, 0xdd, 0x06, 0xa3, 0xfc, 0x74, 0x0c, 0x4e, 0xef, 0x2c, 0xc6,
+	0x8e, 0xac, 0xce, 0xbe, 0xac, 0xdf, 0xc0, 0xb1, 0x5e, 0x1e, 0xa3, 0x76, 0x63, 0xd8, 0xe9, 0xe7,
+	0x8b, 0xd3, 0x2f, 0x16, 0xa7, 0xff, 0xa6, 0xd8, 0xac, 0x51, 0x4d, 0x83, 0xbe, 0xff, 0xcb, 0x2b,
+	0x61, 0x53, 0x81, 0x5e, 0x42, 0x4b, 0xbf, 0x2d, 0x61, 0x73, 0x1e, 0xa8, 0x34, 0xa6, 0x66, 0x2c,
+	0x9a, 0xb8, 0x29, 0x22, 0x72, 0x5d, 0xf8, 0x74, 0x92, 0x26, 0xbf, 0x4d, 0xca, 0x07, 0xa4, 0xc9,
+	0xe9, 0xdd, 0x26, 0xa9, 0xf7, 0x16, 0xda, 0x07, 0x3a, 0xa1, 0x2f, 0x01, 0x0a, 0x89, 0x19, 0x31,
+	0x7d, 0x6b, 0x8d, 0x5a, 0xeb, 0x95, 0x57, 0xb7, 0x89, 0x93, 0x31, 0xae, 0xdb, 0x84, 0x09, 0xd1,
+	0x13, 0xb8, 0x19, 0x84, 0xf2, 0xb9, 0x73, 0xd1, 0xc2, 0x1b, 0xbb, 0xf7, 0x16, 0x5a, 0x7b, 0xa3,
+	0x84, 0xce, 0xa0, 0xa6, 0xee, 0x7d, 0xc6, 0x09, 0xbd, 0x37, 0xc0, 0x75, 0x5c, 0x55, 0xf7, 0x13,
+	0x6d, 0xa2, 0x01, 0x34, 0x62, 0x19, 0x9a, 0xdd, 0xa0, 0x49, 0x62, 0xf7, 0xe8, 0x74, 0xbd, 0xf2,
+	0x00, 0x4f, 0x2f, 0x5f, 0xe5, 0x5e, 0x0c, 0xb1, 0x0c, 0xed, 0xb9, 0xf7, 0xa9, 0x0c, 0xb5, 0x29,
+	0xa5, 0xb1, 0xd9, 0xe9, 0xcf, 0xa1, 0x6c, 0xb9, 0xd6, 0x47, 0x95, 0xf5, 0xca, 0x2b, 0x4f, 0xc6,
+	0xb8, 0xcc, 0x88, 0x1e, 0x2e, 0x8b, 0xe8, 0x33, 0x7e, 0x23, 0x0c, 0xc3, 0xc7, 0xf6, 0x9c, 0xd2,
+	0xd8, 0xe2, 0x6a, 0x38, 0xdc, 0x08, 0xb6, 0x06, 0xfa, 0x11, 0x4e, 0xa3, 0x20, 0x51, 0x7e, 0x28,
+	0x38, 0xa7, 0xa1, 0xa2, 0xc4, 0x75, 0xfe, 0x53, 0xb0, 0x63, 0x23, 0x56, 0x4b, 0xd7, 0x5d, 0x16,
+	0x65, 0xba, 0x55, 0x8c, 0x07, 0xa1, 0x62, 0xef, 0x72, 0xcd, 0x6b, 0x78, 0x63, 0x6b, 0xb1, 0x96,
+	0xa9, 0x0a, 0x66, 0x11, 0xf5, 0x93, 0x50, 0x58, 0x45, 0x1d, 0xdc, 0xb4, 0xce, 0x6b, 0xed, 0x43,
+	0xdf, 0x41, 0x25, 0x95, 0x66, 0x64, 0x2a, 0x86, 0xc1, 0xd9, 0xbf, 0x18, 0x8c, 0xed, 0xb7, 0x36,
+	0x9f, 0x98, 0xdf, 0x34, 0x09, 0x5b, 0x82, 0xbe, 0x87, 0x6a, 0x14, 0x28, 0xca, 0xc3, 0xcc, 0xad,
+	0x3e, 0xbd, 0xba, 0xa8, 0xe9, 0x7d, 0x2a, 0x41, 0xfb, 0xa0, 0x4d, 0x7a, 0xb4, 0x0b, 0xbd, 0xac,
+	0x9a, 0xd6, 0x44, 0x3f, 0xc1, 0x67, 0xa6, 0x67, 0x84, 0x05, 0x91, 0x9f, 0xa4, 0x61, 0x58, 0x68,
+	0xfa, 0x94, 0xb6, 0xb5, 0x75, 0xe9, 0x98, 0x05, 0xd1, 0x75, 0x5e, 0xb8, 0x8f, 0x76, 0x13, 0xb0,
+	0x48, 0x4f, 0xb3, 0xf3, 0x7f, 0xd1, 0x7e, 0xc8, 0x0b, 0x75, 0xab, 0x77, 0x81, 0x12, 0xa3, 0x45,
+	0x0b, 0x37, 0xc9, 0x36, 0x27, 0x19, 0xbd, 0xfe, 0xb0, 0xee, 0x96, 0x3e, 0xae, 0xbb, 0xa5, 0xbf,
+	0xd7, 0xdd, 0xd2, 0xfb, 0x87, 0xee, 0xd1, 0xc7, 0x87, 0xee, 0xd1, 0x1f, 0x0f, 0xdd, 0xa3, 0x5f,
+	0xbf, 0x9e, 0x33, 0x75, 0x9b, 0xce, 0xfa, 0xa1, 0x58, 0x0e, 0x76, 0x7e, 0x65, 0x3b, 0xc7, 0xfc,
+	0x9f, 0xb9, 0xff, 0xa7, 0x9d, 0x55, 0x8c, 0xf7, 0xab, 0x7f, 0x06, 0x00, 0x72, 0x55, 0xde, 0x96,
+	0x82, 0x07, 0x00, 0x00,
 }
 
 func (m *ProtocolVersion) Marshal() (dAtA []byte, err error) {
@@ -486,6 +687,32 @@ func (m *NodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.KeyRotation != nil {
+		{
+			size, err := m.KeyRotation.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.ChannelVersions) > 0 {
+		for iNdEx := len(m.ChannelVersions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ChannelVersions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
 	{
 		size, err := m.Other.MarshalToSizedBuffer(dAtA[:i])
 		if err != nil {
@@ -551,6 +778,124 @@ func (m *NodeInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *NodeKeyRotation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeKeyRotation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeKeyRotation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NewSignature) > 0 {
+		i -= len(m.NewSignature)
+		copy(dAtA[i:], m.NewSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.NewSignature)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.OldSignature) > 0 {
+		i -= len(m.OldSignature)
+		copy(dAtA[i:], m.OldSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.OldSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintTypes(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x22
+	if len(m.Network) > 0 {
+		i -= len(m.Network)
+		copy(dAtA[i:], m.Network)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Network)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	{
+		size, err := m.NewPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.OldPubKey.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *ChannelVersions) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelVersions) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChannelVersions) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Versions) > 0 {
+		dAtA8 := make([]byte, len(m.Versions)*10)
+		var j7 int
+		for _, num := range m.Versions {
+			for num >= 1<<7 {
+				dAtA8[j7] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j7++
+			}
+			dAtA8[j7] = uint8(num)
+			j7++
+		}
+		i -= j7
+		copy(dAtA[i:], dAtA8[:j7])
+		i = encodeVarintTypes(dAtA, i, uint64(j7))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ChannelID != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ChannelID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *NodeInfoOther) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -608,6 +953,27 @@ func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	n9, err9 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Latency, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Latency):])
+	if err9 != nil {
+		return 0, err9
+	}
+	i -= n9
+	i = encodeVarintTypes(dAtA, i, uint64(n9))
+	i--
+	dAtA[i] = 0x3a
+	n10, err10 := github_com_gogo_protobuf_types.StdDurationMarshalTo(m.Uptime, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdDuration(m.Uptime):])
+	if err10 != nil {
+		return 0, err10
+	}
+	i -= n10
+	i = encodeVarintTypes(dAtA, i, uint64(n10))
+	i--
+	dAtA[i] = 0x32
+	if m.MutableScore != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MutableScore))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.Inactive {
 		i--
 		if m.Inactive {
@@ -619,12 +985,12 @@ func (m *PeerInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x20
 	}
 	if m.LastConnected != nil {
-		n3, err3 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastConnected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected):])
-		if err3 != nil {
-			return 0, err3
+		n11, err11 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastConnected, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastConnected):])
+		if err11 != nil {
+			return 0, err11
 		}
-		i -= n3
-		i = encodeVarintTypes(dAtA, i, uint64(n3))
+		i -= n11
+		i = encodeVarintTypes(dAtA, i, uint64(n11))
 		i--
 		dAtA[i] = 0x1a
 	}
@@ -678,22 +1044,22 @@ func (m *PeerAddressInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x20
 	}
 	if m.LastDialFailure != nil {
-		n4, err4 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialFailure, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialFailure):])
-		if err4 != nil {
-			return 0, err4
+		n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialFailure, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialFailure):])
+		if err12 != nil {
+			return 0, err12
 		}
-		i -= n4
-		i = encodeVarintTypes(dAtA, i, uint64(n4))
+		i -= n12
+		i = encodeVarintTypes(dAtA, i, uint64(n12))
 		i--
 		dAtA[i] = 0x1a
 	}
 	if m.LastDialSuccess != nil {
-		n5, err5 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialSuccess, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialSuccess):])
-		if err5 != nil {
-			return 0, err5
+		n13, err13 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.LastDialSuccess, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.LastDialSuccess):])
+		if err13 != nil {
+			return 0, err13
 		}
-		i -= n5
-		i = encodeVarintTypes(dAtA, i, uint64(n5))
+		i -= n13
+		i = encodeVarintTypes(dAtA, i, uint64(n13))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -770,6 +1136,62 @@ func (m *NodeInfo) Size() (n int) {
 	}
 	l = m.Other.Size()
 	n += 1 + l + sovTypes(uint64(l))
+	if len(m.ChannelVersions) > 0 {
+		for _, e := range m.ChannelVersions {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.KeyRotation != nil {
+		l = m.KeyRotation.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *NodeKeyRotation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.OldPubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = m.NewPubKey.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.Network)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Time)
+	n += 1 + l + sovTypes(uint64(l))
+	l = len(m.OldSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.NewSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ChannelVersions) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChannelID != 0 {
+		n += 1 + sovTypes(uint64(m.ChannelID))
+	}
+	if len(m.Versions) > 0 {
+		l = 0
+		for _, e := range m.Versions {
+			l += sovTypes(uint64(e))
+		}
+		n += 1 + sovTypes(uint64(l)) + l
+	}
 	return n
 }
 
@@ -813,6 +1235,13 @@ func (m *PeerInfo) Size() (n int) {
 	if m.Inactive {
 		n += 2
 	}
+	if m.MutableScore != 0 {
+		n += 1 + sovTypes(uint64(m.MutableScore))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Uptime)
+	n += 1 + l + sovTypes(uint64(l))
+	l = github_com_gogo_protobuf_types.SizeOfStdDuration(m.Latency)
+	n += 1 + l + sovTypes(uint64(l))
 	return n
 }
 
@@ -1242,10 +1671,474 @@ func (m *NodeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelVersions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChannelVersions = append(m.ChannelVersions, ChannelVersions{})
+			if err := m.ChannelVersions[len(m.ChannelVersions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyRotation", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.KeyRotation == nil {
+				m.KeyRotation = &NodeKeyRotation{}
+			}
+			if err := m.KeyRotation.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NodeKeyRotation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodeKeyRotation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodeKeyRotation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.OldPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewPubKey", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.NewPubKey.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Network", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Network = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Time", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Time, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OldSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OldSignature = append(m.OldSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.OldSignature == nil {
+				m.OldSignature = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NewSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NewSignature = append(m.NewSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.NewSignature == nil {
+				m.NewSignature = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelVersions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelVersions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelVersions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelID", wireType)
+			}
+			m.ChannelID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ChannelID |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Versions = append(m.Versions, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowTypes
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthTypes
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthTypes
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Versions) == 0 {
+					m.Versions = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowTypes
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Versions = append(m.Versions, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Versions", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
 				return err
 			}
 			if (skippy < 0) || (iNdEx+skippy) < 0 {
@@ -1528,6 +2421,91 @@ func (m *PeerInfo) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.Inactive = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MutableScore", wireType)
+			}
+			m.MutableScore = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MutableScore |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uptime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Uptime, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Latency", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdDurationUnmarshal(&m.Latency, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -498,6 +498,167 @@ func (m *PingResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingResponse proto.InternalMessageInfo
 
+// SignStateRequest is a request for the last sign state of the remote signer.
+type SignStateRequest struct {
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+}
+
+func (m *SignStateRequest) Reset()         { *m = SignStateRequest{} }
+func (m *SignStateRequest) String() string { return proto.CompactTextString(m) }
+func (*SignStateRequest) ProtoMessage()    {}
+func (*SignStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{9}
+}
+func (m *SignStateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignStateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignStateRequest.Merge(m, src)
+}
+func (m *SignStateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignStateRequest proto.InternalMessageInfo
+
+func (m *SignStateRequest) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+// SignState is the height, round and step of the last vote or proposal signed
+// by a signer.
+type SignState struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Step   int32 `protobuf:"varint,3,opt,name=step,proto3" json:"step,omitempty"`
+}
+
+func (m *SignState) Reset()         { *m = SignState{} }
+func (m *SignState) String() string { return proto.CompactTextString(m) }
+func (*SignState) ProtoMessage()    {}
+func (*SignState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{10}
+}
+func (m *SignState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignState.Merge(m, src)
+}
+func (m *SignState) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignState) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignState proto.InternalMessageInfo
+
+func (m *SignState) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *SignState) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *SignState) GetStep() int32 {
+	if m != nil {
+		return m.Step
+	}
+	return 0
+}
+
+// SignStateResponse is a response containing the last sign state of the remote
+// signer or an error
+type SignStateResponse struct {
+	State SignState          `protobuf:"bytes,1,opt,name=state,proto3" json:"state"`
+	Error *RemoteSignerError `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (m *SignStateResponse) Reset()         { *m = SignStateResponse{} }
+func (m *SignStateResponse) String() string { return proto.CompactTextString(m) }
+func (*SignStateResponse) ProtoMessage()    {}
+func (*SignStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cb4e437a5328cf9c, []int{11}
+}
+func (m *SignStateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignStateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignStateResponse.Merge(m, src)
+}
+func (m *SignStateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignStateResponse proto.InternalMessageInfo
+
+func (m *SignStateResponse) GetState() SignState {
+	if m != nil {
+		return m.State
+	}
+	return SignState{}
+}
+
+func (m *SignStateResponse) GetError() *RemoteSignerError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_PubKeyRequest
@@ -508,6 +669,8 @@ type Message struct {
 	//	*Message_SignedProposalResponse
 	//	*Message_PingRequest
 	//	*Message_PingResponse
+	//	*Message_SignStateRequest
+	//	*Message_SignStateResponse
 	Sum isMessage_Sum `protobuf_oneof:"sum"`
 }
 
@@ -515,7 +678,7 @@ func (m *Message) Reset()         { *m = Message{} }
 func (m *Message) String() string { return proto.CompactTextString(m) }
 func (*Message) ProtoMessage()    {}
 func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{9}
+	return fileDescriptor_cb4e437a5328cf9c, []int{12}
 }
 func (m *Message) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -574,6 +737,12 @@ type Message_PingRequest struct {
 type Message_PingResponse struct {
 	PingResponse *PingResponse `protobuf:"bytes,8,opt,name=ping_response,json=pingResponse,proto3,oneof" json:"ping_response,omitempty"`
 }
+type Message_SignStateRequest struct {
+	SignStateRequest *SignStateRequest `protobuf:"bytes,9,opt,name=sign_state_request,json=signStateRequest,proto3,oneof" json:"sign_state_request,omitempty"`
+}
+type Message_SignStateResponse struct {
+	SignStateResponse *SignStateResponse `protobuf:"bytes,10,opt,name=sign_state_response,json=signStateResponse,proto3,oneof" json:"sign_state_response,omitempty"`
+}
 
 func (*Message_PubKeyRequest) isMessage_Sum()          {}
 func (*Message_PubKeyResponse) isMessage_Sum()         {}
@@ -583,6 +752,8 @@ func (*Message_SignProposalRequest) isMessage_Sum()    {}
 func (*Message_SignedProposalResponse) isMessage_Sum() {}
 func (*Message_PingRequest) isMessage_Sum()            {}
 func (*Message_PingResponse) isMessage_Sum()           {}
+func (*Message_SignStateRequest) isMessage_Sum()       {}
+func (*Message_SignStateResponse) isMessage_Sum()      {}
 
 func (m *Message) GetSum() isMessage_Sum {
 	if m != nil {
@@ -647,6 +818,20 @@ func (m *Message) GetPingResponse() *PingResponse {
 	return nil
 }
 
+func (m *Message) GetSignStateRequest() *SignStateRequest {
+	if x, ok := m.GetSum().(*Message_SignStateRequest); ok {
+		return x.SignStateRequest
+	}
+	return nil
+}
+
+func (m *Message) GetSignStateResponse() *SignStateResponse {
+	if x, ok := m.GetSum().(*Message_SignStateResponse); ok {
+		return x.SignStateResponse
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Message) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -658,6 +843,8 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 		(*Message_SignedProposalResponse)(nil),
 		(*Message_PingRequest)(nil),
 		(*Message_PingResponse)(nil),
+		(*Message_SignStateRequest)(nil),
+		(*Message_SignStateResponse)(nil),
 	}
 }
 
@@ -673,7 +860,7 @@ func (m *AuthSigMessage) Reset()         { *m = AuthSigMessage{} }
 func (m *AuthSigMessage) String() string { return proto.CompactTextString(m) }
 func (*AuthSigMessage) ProtoMessage()    {}
 func (*AuthSigMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cb4e437a5328cf9c, []int{10}
+	return fileDescriptor_cb4e437a5328cf9c, []int{13}
 }
 func (m *AuthSigMessage) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -727,6 +914,9 @@ func init() {
 	proto.RegisterType((*SignedProposalResponse)(nil), "tendermint.privval.SignedProposalResponse")
 	proto.RegisterType((*PingRequest)(nil), "tendermint.privval.PingRequest")
 	proto.RegisterType((*PingResponse)(nil), "tendermint.privval.PingResponse")
+	proto.RegisterType((*SignStateRequest)(nil), "tendermint.privval.SignStateRequest")
+	proto.RegisterType((*SignState)(nil), "tendermint.privval.SignState")
+	proto.RegisterType((*SignStateResponse)(nil), "tendermint.privval.SignStateResponse")
 	proto.RegisterType((*Message)(nil), "tendermint.privval.Message")
 	proto.RegisterType((*AuthSigMessage)(nil), "tendermint.privval.AuthSigMessage")
 }
@@ -734,56 +924,63 @@ func init() {
 func init() { proto.RegisterFile("tendermint/privval/types.proto", fileDescriptor_cb4e437a5328cf9c) }
 
 var fileDescriptor_cb4e437a5328cf9c = []byte{
-	// 779 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4b, 0x4f, 0xdb, 0x4a,
-	0x14, 0xb6, 0xc9, 0x0b, 0x4e, 0x1e, 0x84, 0x81, 0xcb, 0x0d, 0x11, 0xd7, 0xe4, 0xfa, 0xea, 0xb6,
-	0x28, 0x8b, 0xa4, 0xa2, 0x52, 0xa5, 0x8a, 0x6e, 0x78, 0x58, 0x4d, 0x14, 0xe1, 0xa4, 0x93, 0x50,
-	0x10, 0x52, 0x65, 0xe5, 0x31, 0x75, 0x2c, 0x88, 0xed, 0x7a, 0x1c, 0xa4, 0xac, 0xbb, 0xeb, 0xaa,
-	0x52, 0xff, 0x44, 0xd7, 0xfd, 0x15, 0x2c, 0x59, 0x76, 0x55, 0x55, 0xf0, 0x47, 0xaa, 0x8c, 0x27,
-	0x8e, 0xf3, 0x42, 0xad, 0xd8, 0xcd, 0x9c, 0x73, 0xe6, 0x3b, 0xdf, 0x37, 0xf3, 0xd9, 0x07, 0x24,
-	0x97, 0x98, 0x1d, 0xe2, 0xf4, 0x0c, 0xd3, 0x2d, 0xda, 0x8e, 0x71, 0x7d, 0xdd, 0xbc, 0x2a, 0xba,
-	0x03, 0x9b, 0xd0, 0x82, 0xed, 0x58, 0xae, 0x85, 0xd0, 0x38, 0x5f, 0xe0, 0xf9, 0xec, 0x76, 0xe0,
-	0x4c, 0xdb, 0x19, 0xd8, 0xae, 0x55, 0xbc, 0x24, 0x03, 0x7e, 0x62, 0x22, 0xcb, 0x90, 0x82, 0x78,
-	0xd9, 0x0d, 0xdd, 0xd2, 0x2d, 0xb6, 0x2c, 0x0e, 0x57, 0x5e, 0x54, 0x2e, 0xc3, 0x1a, 0x26, 0x3d,
-	0xcb, 0x25, 0x75, 0x43, 0x37, 0x89, 0xa3, 0x38, 0x8e, 0xe5, 0x20, 0x04, 0xe1, 0xb6, 0xd5, 0x21,
-	0x19, 0x31, 0x27, 0xee, 0x46, 0x30, 0x5b, 0xa3, 0x1c, 0xc4, 0x3b, 0x84, 0xb6, 0x1d, 0xc3, 0x76,
-	0x0d, 0xcb, 0xcc, 0x2c, 0xe5, 0xc4, 0xdd, 0x15, 0x1c, 0x0c, 0xc9, 0x79, 0x48, 0xd6, 0xfa, 0xad,
-	0x0a, 0x19, 0x60, 0xf2, 0xa1, 0x4f, 0xa8, 0x8b, 0xb6, 0x60, 0xb9, 0xdd, 0x6d, 0x1a, 0xa6, 0x66,
-	0x74, 0x18, 0xd4, 0x0a, 0x8e, 0xb1, 0x7d, 0xb9, 0x23, 0x7f, 0x12, 0x21, 0x35, 0x2a, 0xa6, 0xb6,
-	0x65, 0x52, 0x82, 0xf6, 0x21, 0x66, 0xf7, 0x5b, 0xda, 0x25, 0x19, 0xb0, 0xe2, 0xf8, 0xde, 0x76,
-	0x21, 0x70, 0x03, 0x9e, 0xda, 0x42, 0xad, 0xdf, 0xba, 0x32, 0xda, 0x15, 0x32, 0x38, 0x0c, 0xdf,
-	0xfc, 0xd8, 0x11, 0x70, 0xd4, 0x66, 0x20, 0x68, 0x1f, 0x22, 0x64, 0x48, 0x9d, 0xf1, 0x8a, 0xef,
-	0xfd, 0x5f, 0x98, 0xbd, 0xbc, 0xc2, 0x8c, 0x4e, 0xec, 0x9d, 0x91, 0xcf, 0x61, 0x75, 0x18, 0x7d,
-	0x6b, 0xb9, 0x64, 0x44, 0x3d, 0x0f, 0xe1, 0x6b, 0xcb, 0x25, 0x9c, 0xc9, 0x66, 0x10, 0xce, 0xbb,
-	0x53, 0x56, 0xcc, 0x6a, 0x26, 0x64, 0x2e, 0x4d, 0xca, 0xfc, 0x28, 0x02, 0x62, 0x0d, 0x3b, 0x1e,
-	0x38, 0x97, 0xfa, 0xec, 0x77, 0xd0, 0xb9, 0x42, 0xaf, 0xc7, 0xa3, 0xf4, 0x75, 0x61, 0x7d, 0x18,
-	0xad, 0x39, 0x96, 0x6d, 0xd1, 0xe6, 0xd5, 0x48, 0xe3, 0x0b, 0x58, 0xb6, 0x79, 0x88, 0x33, 0xc9,
-	0xce, 0x32, 0xf1, 0x0f, 0xf9, 0xb5, 0x0f, 0xe9, 0xfd, 0x22, 0xc2, 0xa6, 0xa7, 0x77, 0xdc, 0x8c,
-	0x6b, 0x7e, 0xf5, 0x27, 0xdd, 0xb8, 0xf6, 0x71, 0xcf, 0x47, 0xe9, 0x4f, 0x42, 0xbc, 0x66, 0x98,
-	0x3a, 0xd7, 0x2d, 0xa7, 0x20, 0xe1, 0x6d, 0x3d, 0x66, 0xf2, 0xb7, 0x08, 0xc4, 0x4e, 0x08, 0xa5,
-	0x4d, 0x9d, 0xa0, 0x0a, 0xac, 0x72, 0x13, 0x6a, 0x8e, 0x57, 0xce, 0xc9, 0xfe, 0x3b, 0xaf, 0xe3,
-	0x84, 0xdd, 0x4b, 0x02, 0x4e, 0xda, 0x13, 0xfe, 0x57, 0x21, 0x3d, 0x06, 0xf3, 0x9a, 0x71, 0xfe,
-	0xf2, 0x43, 0x68, 0x5e, 0x65, 0x49, 0xc0, 0x29, 0x7b, 0xf2, 0x0b, 0x79, 0x03, 0x6b, 0xd4, 0xd0,
-	0x4d, 0x6d, 0xe8, 0x08, 0x9f, 0x5e, 0x88, 0x01, 0xfe, 0x37, 0x0f, 0x70, 0xca, 0xd4, 0x25, 0x01,
-	0xaf, 0xd2, 0x29, 0x9f, 0x5f, 0xc0, 0x06, 0x65, 0xef, 0x35, 0x02, 0xe5, 0x34, 0xc3, 0x0c, 0xf5,
-	0xc9, 0x22, 0xd4, 0x49, 0x3f, 0x97, 0x04, 0x8c, 0xe8, 0xac, 0xcb, 0xdf, 0xc1, 0x5f, 0x8c, 0xee,
-	0xe8, 0x11, 0x7d, 0xca, 0x11, 0x06, 0xfe, 0x74, 0x11, 0xf8, 0x94, 0x4f, 0x4b, 0x02, 0x5e, 0xa7,
-	0x73, 0xec, 0xfb, 0x1e, 0x32, 0x9c, 0x7a, 0xa0, 0x01, 0xa7, 0x1f, 0x65, 0x1d, 0xf2, 0x8b, 0xe9,
-	0x4f, 0xdb, 0xb3, 0x24, 0xe0, 0x4d, 0x3a, 0xdf, 0xb8, 0xc7, 0x90, 0xb0, 0x0d, 0x53, 0xf7, 0xd9,
-	0xc7, 0x18, 0xf6, 0xce, 0xdc, 0x17, 0x1c, 0xbb, 0xac, 0x24, 0xe0, 0xb8, 0x3d, 0xde, 0xa2, 0xd7,
-	0x90, 0xe4, 0x28, 0x9c, 0xe2, 0x32, 0x83, 0xc9, 0x2d, 0x86, 0xf1, 0x89, 0x25, 0xec, 0xc0, 0xfe,
-	0x30, 0x02, 0x21, 0xda, 0xef, 0xc9, 0x1a, 0xa4, 0x0e, 0xfa, 0x6e, 0xb7, 0x6e, 0xe8, 0x23, 0xeb,
-	0x3e, 0xea, 0xff, 0x99, 0x86, 0x10, 0x35, 0x74, 0xe6, 0xce, 0x04, 0x1e, 0x2e, 0xf3, 0x5f, 0x45,
-	0x88, 0xb2, 0xaf, 0x88, 0x22, 0x04, 0x29, 0x05, 0xe3, 0x2a, 0xae, 0x6b, 0xa7, 0x6a, 0x45, 0xad,
-	0x9e, 0xa9, 0x69, 0x01, 0x49, 0x90, 0xf5, 0x63, 0xca, 0x79, 0x4d, 0x39, 0x6a, 0x28, 0xc7, 0x1a,
-	0x56, 0xea, 0xb5, 0xaa, 0x5a, 0x57, 0xd2, 0x22, 0xca, 0xc0, 0x06, 0xcf, 0xab, 0x55, 0xed, 0xa8,
-	0xaa, 0xaa, 0xca, 0x51, 0xa3, 0x5c, 0x55, 0xd3, 0x4b, 0xe8, 0x1f, 0xd8, 0xe2, 0x99, 0x71, 0x58,
-	0x6b, 0x94, 0x4f, 0x94, 0xea, 0x69, 0x23, 0x1d, 0x42, 0x7f, 0xc3, 0x3a, 0x4f, 0x63, 0xe5, 0xe0,
-	0xd8, 0x4f, 0x84, 0x03, 0x88, 0x67, 0xb8, 0xdc, 0x50, 0xfc, 0x4c, 0xe4, 0xb0, 0x7e, 0x73, 0x27,
-	0x89, 0xb7, 0x77, 0x92, 0xf8, 0xf3, 0x4e, 0x12, 0x3f, 0xdf, 0x4b, 0xc2, 0xed, 0xbd, 0x24, 0x7c,
-	0xbf, 0x97, 0x84, 0x8b, 0x97, 0xba, 0xe1, 0x76, 0xfb, 0xad, 0x42, 0xdb, 0xea, 0x15, 0x83, 0xc3,
-	0x31, 0x38, 0x79, 0x87, 0x03, 0x71, 0x76, 0x14, 0xb7, 0xa2, 0x2c, 0xf3, 0xfc, 0x57, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x46, 0x64, 0xeb, 0xa4, 0xa7, 0x07, 0x00, 0x00,
+	// 893 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0x4d, 0x4f, 0xe3, 0x46,
+	0x18, 0xb6, 0xc9, 0x07, 0xf0, 0x06, 0x42, 0x18, 0x28, 0xcd, 0xa2, 0x5d, 0x2f, 0x75, 0xbf, 0x56,
+	0x48, 0x4d, 0xaa, 0xad, 0x54, 0x69, 0xb5, 0xbd, 0x2c, 0x60, 0xd5, 0x11, 0xc2, 0x49, 0x27, 0xd9,
+	0xb2, 0x5a, 0xa9, 0xb2, 0xf2, 0x31, 0x75, 0xac, 0x05, 0xcf, 0xd4, 0x63, 0x23, 0xe5, 0xdc, 0x5b,
+	0x7b, 0xa9, 0xd4, 0x3f, 0xd1, 0x9f, 0xb2, 0x47, 0x8e, 0x3d, 0x55, 0x2d, 0xfc, 0x91, 0xca, 0x33,
+	0x13, 0xdb, 0x49, 0x08, 0x6a, 0xc5, 0x6d, 0xe6, 0xfd, 0x78, 0xde, 0xe7, 0x79, 0x33, 0x8f, 0x62,
+	0x30, 0x22, 0x12, 0x8c, 0x48, 0x78, 0xe9, 0x07, 0x51, 0x93, 0x85, 0xfe, 0xd5, 0x55, 0xff, 0xa2,
+	0x19, 0x4d, 0x18, 0xe1, 0x0d, 0x16, 0xd2, 0x88, 0x22, 0x94, 0xe5, 0x1b, 0x2a, 0xbf, 0xff, 0x38,
+	0xd7, 0x33, 0x0c, 0x27, 0x2c, 0xa2, 0xcd, 0x77, 0x64, 0xa2, 0x3a, 0x66, 0xb2, 0x02, 0x29, 0x8f,
+	0xb7, 0xbf, 0xeb, 0x51, 0x8f, 0x8a, 0x63, 0x33, 0x39, 0xc9, 0xa8, 0xd9, 0x82, 0x6d, 0x4c, 0x2e,
+	0x69, 0x44, 0xba, 0xbe, 0x17, 0x90, 0xd0, 0x0a, 0x43, 0x1a, 0x22, 0x04, 0xc5, 0x21, 0x1d, 0x91,
+	0xba, 0x7e, 0xa0, 0x3f, 0x2b, 0x61, 0x71, 0x46, 0x07, 0x50, 0x19, 0x11, 0x3e, 0x0c, 0x7d, 0x16,
+	0xf9, 0x34, 0xa8, 0xaf, 0x1c, 0xe8, 0xcf, 0xd6, 0x71, 0x3e, 0x64, 0x1e, 0xc2, 0x66, 0x27, 0x1e,
+	0x9c, 0x92, 0x09, 0x26, 0x3f, 0xc5, 0x84, 0x47, 0xe8, 0x11, 0xac, 0x0d, 0xc7, 0x7d, 0x3f, 0x70,
+	0xfd, 0x91, 0x80, 0x5a, 0xc7, 0xab, 0xe2, 0xde, 0x1a, 0x99, 0xbf, 0xe8, 0x50, 0x9d, 0x16, 0x73,
+	0x46, 0x03, 0x4e, 0xd0, 0x4b, 0x58, 0x65, 0xf1, 0xc0, 0x7d, 0x47, 0x26, 0xa2, 0xb8, 0xf2, 0xfc,
+	0x71, 0x23, 0xb7, 0x01, 0xa9, 0xb6, 0xd1, 0x89, 0x07, 0x17, 0xfe, 0xf0, 0x94, 0x4c, 0x8e, 0x8a,
+	0xef, 0xff, 0x7a, 0xaa, 0xe1, 0x32, 0x13, 0x20, 0xe8, 0x25, 0x94, 0x48, 0x42, 0x5d, 0xf0, 0xaa,
+	0x3c, 0xff, 0xb4, 0xb1, 0xb8, 0xbc, 0xc6, 0x82, 0x4e, 0x2c, 0x7b, 0xcc, 0x37, 0xb0, 0x95, 0x44,
+	0xbf, 0xa7, 0x11, 0x99, 0x52, 0x3f, 0x84, 0xe2, 0x15, 0x8d, 0x88, 0x62, 0xb2, 0x97, 0x87, 0x93,
+	0x3b, 0x15, 0xc5, 0xa2, 0x66, 0x46, 0xe6, 0xca, 0xac, 0xcc, 0x9f, 0x75, 0x40, 0x62, 0xe0, 0x48,
+	0x82, 0x2b, 0xa9, 0x5f, 0xfe, 0x17, 0x74, 0xa5, 0x50, 0xce, 0x78, 0x90, 0xbe, 0x31, 0xec, 0x24,
+	0xd1, 0x4e, 0x48, 0x19, 0xe5, 0xfd, 0x8b, 0xa9, 0xc6, 0xaf, 0x61, 0x8d, 0xa9, 0x90, 0x62, 0xb2,
+	0xbf, 0xc8, 0x24, 0x6d, 0x4a, 0x6b, 0xef, 0xd3, 0xfb, 0xbb, 0x0e, 0x7b, 0x52, 0x6f, 0x36, 0x4c,
+	0x69, 0xfe, 0xe6, 0xff, 0x4c, 0x53, 0xda, 0xb3, 0x99, 0x0f, 0xd2, 0xbf, 0x09, 0x95, 0x8e, 0x1f,
+	0x78, 0x4a, 0xb7, 0x59, 0x85, 0x0d, 0x79, 0x95, 0xcc, 0xcc, 0x2f, 0xa0, 0x96, 0x34, 0x75, 0xa3,
+	0x7e, 0xf6, 0xfb, 0xdf, 0xf3, 0x74, 0xcf, 0x60, 0x3d, 0x2d, 0x47, 0x7b, 0x50, 0x1e, 0x13, 0xdf,
+	0x1b, 0x47, 0xa2, 0xaa, 0x80, 0xd5, 0x0d, 0xed, 0x42, 0x29, 0xa4, 0x71, 0x20, 0x17, 0x54, 0xc2,
+	0xf2, 0x92, 0xf8, 0x8a, 0x47, 0x84, 0xd5, 0x0b, 0xd2, 0x57, 0xc9, 0xd9, 0xfc, 0x55, 0x87, 0xed,
+	0xdc, 0x78, 0xb5, 0xad, 0x17, 0x50, 0xe2, 0x49, 0x40, 0xad, 0xea, 0xc9, 0x5d, 0x7a, 0xd3, 0x2e,
+	0xb5, 0x2d, 0xd9, 0xf1, 0xb0, 0x55, 0xfd, 0x53, 0x86, 0xd5, 0x33, 0xc2, 0x79, 0xdf, 0x23, 0xe8,
+	0x14, 0xb6, 0x94, 0x21, 0xdd, 0x50, 0xae, 0x45, 0xb1, 0xf9, 0xe8, 0x2e, 0xc8, 0x19, 0xeb, 0xdb,
+	0x1a, 0xde, 0x64, 0xf9, 0x00, 0x72, 0xa0, 0x96, 0x81, 0x49, 0x91, 0x8a, 0xa0, 0x79, 0x1f, 0x9a,
+	0xac, 0xb4, 0x35, 0x5c, 0x65, 0x33, 0x11, 0xf4, 0x1d, 0x6c, 0x73, 0xdf, 0x0b, 0xdc, 0xc4, 0x1d,
+	0x29, 0xbd, 0x82, 0x00, 0xfc, 0x78, 0xd9, 0xb2, 0x72, 0x06, 0xb7, 0x35, 0xbc, 0xc5, 0xe7, 0x3c,
+	0xff, 0x16, 0x76, 0xb9, 0x78, 0xbb, 0x53, 0x50, 0x45, 0xb3, 0x28, 0x50, 0x3f, 0x5b, 0x86, 0x3a,
+	0xeb, 0x6d, 0x5b, 0xc3, 0x88, 0x2f, 0x3a, 0xfe, 0x07, 0xf8, 0x40, 0xd0, 0x9d, 0x3e, 0xe8, 0x94,
+	0x72, 0x49, 0x80, 0x7f, 0xbe, 0x0c, 0x7c, 0xce, 0xb3, 0xb6, 0x86, 0x77, 0xf8, 0x62, 0x18, 0xfd,
+	0x08, 0x75, 0x45, 0x3d, 0x37, 0x40, 0xd1, 0x2f, 0x8b, 0x09, 0x87, 0xcb, 0xe9, 0xcf, 0x5b, 0xd5,
+	0xd6, 0xf0, 0x1e, 0xbf, 0xdb, 0xc4, 0x27, 0xb0, 0xc1, 0xfc, 0xc0, 0x4b, 0xd9, 0xaf, 0x0a, 0xec,
+	0xa7, 0x77, 0xfe, 0x82, 0x99, 0xe3, 0x6c, 0x0d, 0x57, 0x58, 0x76, 0x45, 0xdf, 0xc2, 0xa6, 0x42,
+	0x51, 0x14, 0xd7, 0x04, 0xcc, 0xc1, 0x72, 0x98, 0x94, 0xd8, 0x06, 0xcb, 0xdd, 0x51, 0x0f, 0xc4,
+	0xae, 0x5d, 0xf1, 0xf0, 0x53, 0x52, 0xeb, 0x02, 0xed, 0x93, 0x7b, 0x2d, 0x93, 0x31, 0xab, 0xf1,
+	0x79, 0xef, 0x9f, 0xc3, 0xce, 0x0c, 0xaa, 0x22, 0x09, 0xcb, 0xed, 0xb4, 0xe0, 0x5f, 0x5b, 0xc3,
+	0xdb, 0x7c, 0x3e, 0x78, 0x54, 0x82, 0x02, 0x8f, 0x2f, 0x4d, 0x17, 0xaa, 0xaf, 0xe2, 0x68, 0xdc,
+	0xf5, 0xbd, 0xa9, 0xd3, 0x1e, 0xf4, 0xd7, 0x57, 0x83, 0x02, 0xf7, 0x3d, 0x61, 0xa6, 0x0d, 0x9c,
+	0x1c, 0x0f, 0xff, 0xd0, 0xa1, 0x2c, 0x5c, 0xcd, 0x11, 0x82, 0xaa, 0x85, 0x71, 0x1b, 0x77, 0xdd,
+	0xd7, 0xce, 0xa9, 0xd3, 0x3e, 0x77, 0x6a, 0x1a, 0x32, 0x60, 0x3f, 0x8d, 0x59, 0x6f, 0x3a, 0xd6,
+	0x71, 0xcf, 0x3a, 0x71, 0xb1, 0xd5, 0xed, 0xb4, 0x9d, 0xae, 0x55, 0xd3, 0x51, 0x1d, 0x76, 0x55,
+	0xde, 0x69, 0xbb, 0xc7, 0x6d, 0xc7, 0xb1, 0x8e, 0x7b, 0xad, 0xb6, 0x53, 0x5b, 0x41, 0x4f, 0xe0,
+	0x91, 0xca, 0x64, 0x61, 0xb7, 0xd7, 0x3a, 0xb3, 0xda, 0xaf, 0x7b, 0xb5, 0x02, 0xfa, 0x10, 0x76,
+	0x54, 0x1a, 0x5b, 0xaf, 0x4e, 0xd2, 0x44, 0x31, 0x87, 0x78, 0x8e, 0x5b, 0x3d, 0x2b, 0xcd, 0x94,
+	0x8e, 0xba, 0xef, 0x6f, 0x0c, 0xfd, 0xfa, 0xc6, 0xd0, 0xff, 0xbe, 0x31, 0xf4, 0xdf, 0x6e, 0x0d,
+	0xed, 0xfa, 0xd6, 0xd0, 0xfe, 0xbc, 0x35, 0xb4, 0xb7, 0x2f, 0x3c, 0x3f, 0x1a, 0xc7, 0x83, 0xc6,
+	0x90, 0x5e, 0x36, 0xf3, 0xdf, 0x35, 0xd9, 0x51, 0x7e, 0xcb, 0x2c, 0x7e, 0x45, 0x0d, 0xca, 0x22,
+	0xf3, 0xd5, 0xbf, 0x03, 0x00, 0xab, 0x7e, 0xb9, 0xad, 0x62, 0x09, 0x00, 0x00,
 }
 
 func (m *RemoteSignerError) Marshal() (dAtA []byte, err error) {
@@ -1116,6 +1313,119 @@ func (m *PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *SignStateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChainId) > 0 {
+		i -= len(m.ChainId)
+		copy(dAtA[i:], m.ChainId)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ChainId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Step != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Step))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Round != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignStateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Error != nil {
+		{
+			size, err := m.Error.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.State.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func (m *Message) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1316,6 +1626,48 @@ func (m *Message_PingResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Message_SignStateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignStateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignStateRequest != nil {
+		{
+			size, err := m.SignStateRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_SignStateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_SignStateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.SignStateResponse != nil {
+		{
+			size, err := m.SignStateResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
 func (m *AuthSigMessage) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1493,38 +1845,84 @@ func (m *PingResponse) Size() (n int) {
 	return n
 }
 
-func (m *Message) Size() (n int) {
+func (m *SignStateRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.Sum != nil {
-		n += m.Sum.Size()
+	l = len(m.ChainId)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
 }
 
-func (m *Message_PubKeyRequest) Size() (n int) {
+func (m *SignState) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.PubKeyRequest != nil {
-		l = m.PubKeyRequest.Size()
-		n += 1 + l + sovTypes(uint64(l))
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovTypes(uint64(m.Round))
+	}
+	if m.Step != 0 {
+		n += 1 + sovTypes(uint64(m.Step))
 	}
 	return n
 }
-func (m *Message_PubKeyResponse) Size() (n int) {
+
+func (m *SignStateResponse) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if m.PubKeyResponse != nil {
-		l = m.PubKeyResponse.Size()
+	l = m.State.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *Message) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sum != nil {
+		n += m.Sum.Size()
+	}
+	return n
+}
+
+func (m *Message_PubKeyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PubKeyRequest != nil {
+		l = m.PubKeyRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_PubKeyResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PubKeyResponse != nil {
+		l = m.PubKeyResponse.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
 	return n
@@ -1601,6 +1999,30 @@ func (m *Message_PingResponse) Size() (n int) {
 	}
 	return n
 }
+func (m *Message_SignStateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignStateRequest != nil {
+		l = m.SignStateRequest.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *Message_SignStateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SignStateResponse != nil {
+		l = m.SignStateResponse.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
 func (m *AuthSigMessage) Size() (n int) {
 	if m == nil {
 		return 0
@@ -2498,6 +2920,314 @@ func (m *PingResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SignStateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignStateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignStateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Step", wireType)
+			}
+			m.Step = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Step |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignStateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignStateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignStateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Error == nil {
+				m.Error = &RemoteSignerError{}
+			}
+			if err := m.Error.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *Message) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2807,6 +3537,76 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			}
 			m.Sum = &Message_PingResponse{v}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignStateRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignStateRequest{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignStateRequest{v}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignStateResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &SignStateResponse{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &Message_SignStateResponse{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,3756 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: tendermint/rpc/grpc/types.proto
+
+package coregrpc
+
+import (
+	context "context"
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	types "github.com/tendermint/tendermint/abci/types"
+	types1 "github.com/tendermint/tendermint/proto/tendermint/types"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// RequestStreamEvents subscribes to events as they are published by the node.
+type RequestStreamEvents struct {
+	// query is an event query, as accepted by tx_search. An empty query
+	// matches every transaction and block.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (m *RequestStreamEvents) Reset()         { *m = RequestStreamEvents{} }
+func (m *RequestStreamEvents) String() string { return proto.CompactTextString(m) }
+func (*RequestStreamEvents) ProtoMessage()    {}
+func (*RequestStreamEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{0}
+}
+func (m *RequestStreamEvents) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestStreamEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestStreamEvents.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestStreamEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestStreamEvents.Merge(m, src)
+}
+func (m *RequestStreamEvents) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestStreamEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestStreamEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestStreamEvents proto.InternalMessageInfo
+
+func (m *RequestStreamEvents) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+// RequestGetBlock requests the block at a height. A height of 0 requests the
+// latest block.
+type RequestGetBlock struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *RequestGetBlock) Reset()         { *m = RequestGetBlock{} }
+func (m *RequestGetBlock) String() string { return proto.CompactTextString(m) }
+func (*RequestGetBlock) ProtoMessage()    {}
+func (*RequestGetBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{1}
+}
+func (m *RequestGetBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestGetBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestGetBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestGetBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestGetBlock.Merge(m, src)
+}
+func (m *RequestGetBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestGetBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestGetBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestGetBlock proto.InternalMessageInfo
+
+func (m *RequestGetBlock) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// RequestGetBlockResults requests the results of executing the block at a
+// height. A height of 0 requests the results of the latest block.
+type RequestGetBlockResults struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *RequestGetBlockResults) Reset()         { *m = RequestGetBlockResults{} }
+func (m *RequestGetBlockResults) String() string { return proto.CompactTextString(m) }
+func (*RequestGetBlockResults) ProtoMessage()    {}
+func (*RequestGetBlockResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{2}
+}
+func (m *RequestGetBlockResults) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestGetBlockResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestGetBlockResults.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestGetBlockResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestGetBlockResults.Merge(m, src)
+}
+func (m *RequestGetBlockResults) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestGetBlockResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestGetBlockResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestGetBlockResults proto.InternalMessageInfo
+
+func (m *RequestGetBlockResults) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// RequestNewBlocks subscribes to the blocks committed by the node.
+type RequestNewBlocks struct {
+}
+
+func (m *RequestNewBlocks) Reset()         { *m = RequestNewBlocks{} }
+func (m *RequestNewBlocks) String() string { return proto.CompactTextString(m) }
+func (*RequestNewBlocks) ProtoMessage()    {}
+func (*RequestNewBlocks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{3}
+}
+func (m *RequestNewBlocks) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestNewBlocks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestNewBlocks.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestNewBlocks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestNewBlocks.Merge(m, src)
+}
+func (m *RequestNewBlocks) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestNewBlocks) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestNewBlocks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestNewBlocks proto.InternalMessageInfo
+
+// RequestSetRetainHeight sets the height of the oldest block the data
+// companion still needs.
+type RequestSetRetainHeight struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *RequestSetRetainHeight) Reset()         { *m = RequestSetRetainHeight{} }
+func (m *RequestSetRetainHeight) String() string { return proto.CompactTextString(m) }
+func (*RequestSetRetainHeight) ProtoMessage()    {}
+func (*RequestSetRetainHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{4}
+}
+func (m *RequestSetRetainHeight) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestSetRetainHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestSetRetainHeight.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestSetRetainHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestSetRetainHeight.Merge(m, src)
+}
+func (m *RequestSetRetainHeight) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestSetRetainHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestSetRetainHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestSetRetainHeight proto.InternalMessageInfo
+
+func (m *RequestSetRetainHeight) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type RequestGetRetainHeight struct {
+}
+
+func (m *RequestGetRetainHeight) Reset()         { *m = RequestGetRetainHeight{} }
+func (m *RequestGetRetainHeight) String() string { return proto.CompactTextString(m) }
+func (*RequestGetRetainHeight) ProtoMessage()    {}
+func (*RequestGetRetainHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{5}
+}
+func (m *RequestGetRetainHeight) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestGetRetainHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestGetRetainHeight.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestGetRetainHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestGetRetainHeight.Merge(m, src)
+}
+func (m *RequestGetRetainHeight) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestGetRetainHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestGetRetainHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestGetRetainHeight proto.InternalMessageInfo
+
+// RequestSetBlockResultsRetainHeight sets the height of the oldest block
+// results the data companion still needs.
+type RequestSetBlockResultsRetainHeight struct {
+	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (m *RequestSetBlockResultsRetainHeight) Reset()         { *m = RequestSetBlockResultsRetainHeight{} }
+func (m *RequestSetBlockResultsRetainHeight) String() string { return proto.CompactTextString(m) }
+func (*RequestSetBlockResultsRetainHeight) ProtoMessage()    {}
+func (*RequestSetBlockResultsRetainHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{6}
+}
+func (m *RequestSetBlockResultsRetainHeight) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestSetBlockResultsRetainHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestSetBlockResultsRetainHeight.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestSetBlockResultsRetainHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestSetBlockResultsRetainHeight.Merge(m, src)
+}
+func (m *RequestSetBlockResultsRetainHeight) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestSetBlockResultsRetainHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestSetBlockResultsRetainHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestSetBlockResultsRetainHeight proto.InternalMessageInfo
+
+func (m *RequestSetBlockResultsRetainHeight) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type RequestGetBlockResultsRetainHeight struct {
+}
+
+func (m *RequestGetBlockResultsRetainHeight) Reset()         { *m = RequestGetBlockResultsRetainHeight{} }
+func (m *RequestGetBlockResultsRetainHeight) String() string { return proto.CompactTextString(m) }
+func (*RequestGetBlockResultsRetainHeight) ProtoMessage()    {}
+func (*RequestGetBlockResultsRetainHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{7}
+}
+func (m *RequestGetBlockResultsRetainHeight) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestGetBlockResultsRetainHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestGetBlockResultsRetainHeight.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestGetBlockResultsRetainHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestGetBlockResultsRetainHeight.Merge(m, src)
+}
+func (m *RequestGetBlockResultsRetainHeight) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestGetBlockResultsRetainHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestGetBlockResultsRetainHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestGetBlockResultsRetainHeight proto.InternalMessageInfo
+
+// BlockEvents holds the events emitted by FinalizeBlock for a block.
+type BlockEvents struct {
+	Height int64         `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Events []types.Event `protobuf:"bytes,2,rep,name=events,proto3" json:"events"`
+}
+
+func (m *BlockEvents) Reset()         { *m = BlockEvents{} }
+func (m *BlockEvents) String() string { return proto.CompactTextString(m) }
+func (*BlockEvents) ProtoMessage()    {}
+func (*BlockEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{8}
+}
+func (m *BlockEvents) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockEvents.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockEvents.Merge(m, src)
+}
+func (m *BlockEvents) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockEvents proto.InternalMessageInfo
+
+func (m *BlockEvents) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockEvents) GetEvents() []types.Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type ResponseStreamEvents struct {
+	// Types that are valid to be assigned to Value:
+	//	*ResponseStreamEvents_Tx
+	//	*ResponseStreamEvents_Block
+	Value isResponseStreamEvents_Value `protobuf_oneof:"value"`
+}
+
+func (m *ResponseStreamEvents) Reset()         { *m = ResponseStreamEvents{} }
+func (m *ResponseStreamEvents) String() string { return proto.CompactTextString(m) }
+func (*ResponseStreamEvents) ProtoMessage()    {}
+func (*ResponseStreamEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{9}
+}
+func (m *ResponseStreamEvents) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseStreamEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseStreamEvents.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseStreamEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseStreamEvents.Merge(m, src)
+}
+func (m *ResponseStreamEvents) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseStreamEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseStreamEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseStreamEvents proto.InternalMessageInfo
+
+type isResponseStreamEvents_Value interface {
+	isResponseStreamEvents_Value()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type ResponseStreamEvents_Tx struct {
+	Tx *types.TxResult `protobuf:"bytes,1,opt,name=tx,proto3,oneof" json:"tx,omitempty"`
+}
+type ResponseStreamEvents_Block struct {
+	Block *BlockEvents `protobuf:"bytes,2,opt,name=block,proto3,oneof" json:"block,omitempty"`
+}
+
+func (*ResponseStreamEvents_Tx) isResponseStreamEvents_Value()    {}
+func (*ResponseStreamEvents_Block) isResponseStreamEvents_Value() {}
+
+func (m *ResponseStreamEvents) GetValue() isResponseStreamEvents_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *ResponseStreamEvents) GetTx() *types.TxResult {
+	if x, ok := m.GetValue().(*ResponseStreamEvents_Tx); ok {
+		return x.Tx
+	}
+	return nil
+}
+
+func (m *ResponseStreamEvents) GetBlock() *BlockEvents {
+	if x, ok := m.GetValue().(*ResponseStreamEvents_Block); ok {
+		return x.Block
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ResponseStreamEvents) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ResponseStreamEvents_Tx)(nil),
+		(*ResponseStreamEvents_Block)(nil),
+	}
+}
+
+type ResponseGetBlock struct {
+	BlockId *types1.BlockID `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Block   *types1.Block   `protobuf:"bytes,2,opt,name=block,proto3" json:"block,omitempty"`
+}
+
+func (m *ResponseGetBlock) Reset()         { *m = ResponseGetBlock{} }
+func (m *ResponseGetBlock) String() string { return proto.CompactTextString(m) }
+func (*ResponseGetBlock) ProtoMessage()    {}
+func (*ResponseGetBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{10}
+}
+func (m *ResponseGetBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseGetBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseGetBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseGetBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseGetBlock.Merge(m, src)
+}
+func (m *ResponseGetBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseGetBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseGetBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseGetBlock proto.InternalMessageInfo
+
+func (m *ResponseGetBlock) GetBlockId() *types1.BlockID {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *ResponseGetBlock) GetBlock() *types1.Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type ResponseGetBlockResults struct {
+	Height                int64                   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	TxResults             []*types.ExecTxResult   `protobuf:"bytes,2,rep,name=tx_results,json=txResults,proto3" json:"tx_results,omitempty"`
+	FinalizeBlockEvents   []types.Event           `protobuf:"bytes,3,rep,name=finalize_block_events,json=finalizeBlockEvents,proto3" json:"finalize_block_events"`
+	ValidatorUpdates      []types.ValidatorUpdate `protobuf:"bytes,4,rep,name=validator_updates,json=validatorUpdates,proto3" json:"validator_updates"`
+	ConsensusParamUpdates *types1.ConsensusParams `protobuf:"bytes,5,opt,name=consensus_param_updates,json=consensusParamUpdates,proto3" json:"consensus_param_updates,omitempty"`
+	AppHash               []byte                  `protobuf:"bytes,6,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+}
+
+func (m *ResponseGetBlockResults) Reset()         { *m = ResponseGetBlockResults{} }
+func (m *ResponseGetBlockResults) String() string { return proto.CompactTextString(m) }
+func (*ResponseGetBlockResults) ProtoMessage()    {}
+func (*ResponseGetBlockResults) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{11}
+}
+func (m *ResponseGetBlockResults) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseGetBlockResults) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseGetBlockResults.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseGetBlockResults) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseGetBlockResults.Merge(m, src)
+}
+func (m *ResponseGetBlockResults) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseGetBlockResults) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseGetBlockResults.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseGetBlockResults proto.InternalMessageInfo
+
+func (m *ResponseGetBlockResults) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ResponseGetBlockResults) GetTxResults() []*types.ExecTxResult {
+	if m != nil {
+		return m.TxResults
+	}
+	return nil
+}
+
+func (m *ResponseGetBlockResults) GetFinalizeBlockEvents() []types.Event {
+	if m != nil {
+		return m.FinalizeBlockEvents
+	}
+	return nil
+}
+
+func (m *ResponseGetBlockResults) GetValidatorUpdates() []types.ValidatorUpdate {
+	if m != nil {
+		return m.ValidatorUpdates
+	}
+	return nil
+}
+
+func (m *ResponseGetBlockResults) GetConsensusParamUpdates() *types1.ConsensusParams {
+	if m != nil {
+		return m.ConsensusParamUpdates
+	}
+	return nil
+}
+
+func (m *ResponseGetBlockResults) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
+type ResponseSetRetainHeight struct {
+}
+
+func (m *ResponseSetRetainHeight) Reset()         { *m = ResponseSetRetainHeight{} }
+func (m *ResponseSetRetainHeight) String() string { return proto.CompactTextString(m) }
+func (*ResponseSetRetainHeight) ProtoMessage()    {}
+func (*ResponseSetRetainHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{12}
+}
+func (m *ResponseSetRetainHeight) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseSetRetainHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseSetRetainHeight.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseSetRetainHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseSetRetainHeight.Merge(m, src)
+}
+func (m *ResponseSetRetainHeight) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseSetRetainHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseSetRetainHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseSetRetainHeight proto.InternalMessageInfo
+
+type ResponseGetRetainHeight struct {
+	// companion_retain_height is the retain height set by the data companion,
+	// or 0 if none is set.
+	CompanionRetainHeight int64 `protobuf:"varint,1,opt,name=companion_retain_height,json=companionRetainHeight,proto3" json:"companion_retain_height,omitempty"`
+	// base_height is the height of the oldest block stored by the node.
+	BaseHeight int64 `protobuf:"varint,2,opt,name=base_height,json=baseHeight,proto3" json:"base_height,omitempty"`
+	// app_retain_height is the highest retain height returned by the
+	// application, or 0 if it has returned none.
+	AppRetainHeight int64 `protobuf:"varint,3,opt,name=app_retain_height,json=appRetainHeight,proto3" json:"app_retain_height,omitempty"`
+}
+
+func (m *ResponseGetRetainHeight) Reset()         { *m = ResponseGetRetainHeight{} }
+func (m *ResponseGetRetainHeight) String() string { return proto.CompactTextString(m) }
+func (*ResponseGetRetainHeight) ProtoMessage()    {}
+func (*ResponseGetRetainHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{13}
+}
+func (m *ResponseGetRetainHeight) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseGetRetainHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseGetRetainHeight.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseGetRetainHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseGetRetainHeight.Merge(m, src)
+}
+func (m *ResponseGetRetainHeight) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseGetRetainHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseGetRetainHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseGetRetainHeight proto.InternalMessageInfo
+
+func (m *ResponseGetRetainHeight) GetCompanionRetainHeight() int64 {
+	if m != nil {
+		return m.CompanionRetainHeight
+	}
+	return 0
+}
+
+func (m *ResponseGetRetainHeight) GetBaseHeight() int64 {
+	if m != nil {
+		return m.BaseHeight
+	}
+	return 0
+}
+
+func (m *ResponseGetRetainHeight) GetAppRetainHeight() int64 {
+	if m != nil {
+		return m.AppRetainHeight
+	}
+	return 0
+}
+
+type ResponseSetBlockResultsRetainHeight struct {
+}
+
+func (m *ResponseSetBlockResultsRetainHeight) Reset()         { *m = ResponseSetBlockResultsRetainHeight{} }
+func (m *ResponseSetBlockResultsRetainHeight) String() string { return proto.CompactTextString(m) }
+func (*ResponseSetBlockResultsRetainHeight) ProtoMessage()    {}
+func (*ResponseSetBlockResultsRetainHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{14}
+}
+func (m *ResponseSetBlockResultsRetainHeight) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseSetBlockResultsRetainHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseSetBlockResultsRetainHeight.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseSetBlockResultsRetainHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseSetBlockResultsRetainHeight.Merge(m, src)
+}
+func (m *ResponseSetBlockResultsRetainHeight) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseSetBlockResultsRetainHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseSetBlockResultsRetainHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseSetBlockResultsRetainHeight proto.InternalMessageInfo
+
+type ResponseGetBlockResultsRetainHeight struct {
+	// companion_retain_height is the block results retain height set by the
+	// data companion, or 0 if none is set.
+	CompanionRetainHeight int64 `protobuf:"varint,1,opt,name=companion_retain_height,json=companionRetainHeight,proto3" json:"companion_retain_height,omitempty"`
+	// app_retain_height is the highest retain height returned by the
+	// application, or 0 if it has returned none.
+	AppRetainHeight int64 `protobuf:"varint,2,opt,name=app_retain_height,json=appRetainHeight,proto3" json:"app_retain_height,omitempty"`
+}
+
+func (m *ResponseGetBlockResultsRetainHeight) Reset()         { *m = ResponseGetBlockResultsRetainHeight{} }
+func (m *ResponseGetBlockResultsRetainHeight) String() string { return proto.CompactTextString(m) }
+func (*ResponseGetBlockResultsRetainHeight) ProtoMessage()    {}
+func (*ResponseGetBlockResultsRetainHeight) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0ffff5682c662b95, []int{15}
+}
+func (m *ResponseGetBlockResultsRetainHeight) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseGetBlockResultsRetainHeight) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseGetBlockResultsRetainHeight.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseGetBlockResultsRetainHeight) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseGetBlockResultsRetainHeight.Merge(m, src)
+}
+func (m *ResponseGetBlockResultsRetainHeight) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseGetBlockResultsRetainHeight) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseGetBlockResultsRetainHeight.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseGetBlockResultsRetainHeight proto.InternalMessageInfo
+
+func (m *ResponseGetBlockResultsRetainHeight) GetCompanionRetainHeight() int64 {
+	if m != nil {
+		return m.CompanionRetainHeight
+	}
+	return 0
+}
+
+func (m *ResponseGetBlockResultsRetainHeight) GetAppRetainHeight() int64 {
+	if m != nil {
+		return m.AppRetainHeight
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*RequestStreamEvents)(nil), "tendermint.rpc.grpc.RequestStreamEvents")
+	proto.RegisterType((*RequestGetBlock)(nil), "tendermint.rpc.grpc.RequestGetBlock")
+	proto.RegisterType((*RequestGetBlockResults)(nil), "tendermint.rpc.grpc.RequestGetBlockResults")
+	proto.RegisterType((*RequestNewBlocks)(nil), "tendermint.rpc.grpc.RequestNewBlocks")
+	proto.RegisterType((*RequestSetRetainHeight)(nil), "tendermint.rpc.grpc.RequestSetRetainHeight")
+	proto.RegisterType((*RequestGetRetainHeight)(nil), "tendermint.rpc.grpc.RequestGetRetainHeight")
+	proto.RegisterType((*RequestSetBlockResultsRetainHeight)(nil), "tendermint.rpc.grpc.RequestSetBlockResultsRetainHeight")
+	proto.RegisterType((*RequestGetBlockResultsRetainHeight)(nil), "tendermint.rpc.grpc.RequestGetBlockResultsRetainHeight")
+	proto.RegisterType((*BlockEvents)(nil), "tendermint.rpc.grpc.BlockEvents")
+	proto.RegisterType((*ResponseStreamEvents)(nil), "tendermint.rpc.grpc.ResponseStreamEvents")
+	proto.RegisterType((*ResponseGetBlock)(nil), "tendermint.rpc.grpc.ResponseGetBlock")
+	proto.RegisterType((*ResponseGetBlockResults)(nil), "tendermint.rpc.grpc.ResponseGetBlockResults")
+	proto.RegisterType((*ResponseSetRetainHeight)(nil), "tendermint.rpc.grpc.ResponseSetRetainHeight")
+	proto.RegisterType((*ResponseGetRetainHeight)(nil), "tendermint.rpc.grpc.ResponseGetRetainHeight")
+	proto.RegisterType((*ResponseSetBlockResultsRetainHeight)(nil), "tendermint.rpc.grpc.ResponseSetBlockResultsRetainHeight")
+	proto.RegisterType((*ResponseGetBlockResultsRetainHeight)(nil), "tendermint.rpc.grpc.ResponseGetBlockResultsRetainHeight")
+}
+
+func init() { proto.RegisterFile("tendermint/rpc/grpc/types.proto", fileDescriptor_0ffff5682c662b95) }
+
+var fileDescriptor_0ffff5682c662b95 = []byte{
+	// 859 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x5d, 0x6f, 0xfa, 0x54,
+	0x1c, 0xa6, 0x30, 0xd8, 0xf6, 0x63, 0xf9, 0x6f, 0xff, 0xb2, 0x17, 0xc6, 0x1c, 0xc3, 0x6e, 0x4b,
+	0x98, 0xd3, 0xb2, 0xe0, 0xa2, 0x4b, 0xdc, 0x8d, 0x4c, 0x03, 0xbb, 0x31, 0xa4, 0xf8, 0x12, 0x5d,
+	0x4c, 0x73, 0x28, 0x47, 0x68, 0x84, 0xb6, 0xeb, 0x39, 0x65, 0xcc, 0x5b, 0x13, 0x13, 0x13, 0x2f,
+	0x16, 0x3f, 0x80, 0x9f, 0xc6, 0x8b, 0x5d, 0xee, 0xd2, 0x2b, 0x63, 0xb6, 0x2f, 0x62, 0x7a, 0xda,
+	0x42, 0x5b, 0x5a, 0xc0, 0xc5, 0x1b, 0x52, 0xce, 0x79, 0x9e, 0xe7, 0xf7, 0xfc, 0x5e, 0xce, 0x69,
+	0xe1, 0x80, 0x62, 0xad, 0x83, 0xcd, 0x81, 0xaa, 0xd1, 0x8a, 0x69, 0x28, 0x95, 0xae, 0xfd, 0x43,
+	0xef, 0x0d, 0x4c, 0x44, 0xc3, 0xd4, 0xa9, 0xce, 0xe7, 0x26, 0x00, 0xd1, 0x34, 0x14, 0xd1, 0x06,
+	0x14, 0xf6, 0x7c, 0x2c, 0xd4, 0x56, 0x54, 0x3f, 0xa3, 0xf0, 0x8e, 0x6f, 0x93, 0xad, 0x57, 0xda,
+	0x7d, 0x5d, 0xf9, 0xd1, 0xdd, 0xdd, 0x9f, 0xda, 0x35, 0x90, 0x89, 0x06, 0xf1, 0x64, 0xbf, 0xf4,
+	0x66, 0x57, 0xef, 0xea, 0xec, 0xb1, 0x62, 0x3f, 0x39, 0xab, 0xc2, 0x29, 0xe4, 0x24, 0x7c, 0x6b,
+	0x61, 0x42, 0x5b, 0xd4, 0xc4, 0x68, 0xf0, 0xf9, 0x10, 0x6b, 0x94, 0xf0, 0x9b, 0x90, 0xbe, 0xb5,
+	0xb0, 0x79, 0x9f, 0xe7, 0x4a, 0x5c, 0x79, 0x55, 0x72, 0xfe, 0x08, 0x27, 0xb0, 0xee, 0x82, 0xeb,
+	0x98, 0xd6, 0x6c, 0x63, 0xfc, 0x36, 0x64, 0x7a, 0x58, 0xed, 0xf6, 0x28, 0x43, 0xa6, 0x24, 0xf7,
+	0x9f, 0x70, 0x06, 0xdb, 0x21, 0xa8, 0x84, 0x89, 0xd5, 0xa7, 0x24, 0x96, 0xc1, 0xc3, 0x86, 0xcb,
+	0xf8, 0x02, 0xdf, 0x31, 0x06, 0xf1, 0xa9, 0xb4, 0x30, 0x95, 0x30, 0x45, 0xaa, 0xd6, 0x60, 0xe8,
+	0x58, 0x95, 0xbc, 0x3f, 0xae, 0x9f, 0x21, 0x5c, 0x82, 0x30, 0xd1, 0xf2, 0x3b, 0x5a, 0x48, 0xf7,
+	0x08, 0x84, 0xe8, 0x7c, 0x02, 0x31, 0x6e, 0x20, 0xcb, 0xf6, 0xdc, 0x2a, 0xc6, 0x88, 0xf1, 0xe7,
+	0x90, 0xc1, 0x0c, 0x91, 0x4f, 0x96, 0x52, 0xe5, 0x6c, 0x75, 0x5b, 0xf4, 0x0d, 0x8a, 0x3d, 0x13,
+	0x22, 0x13, 0xa8, 0x2d, 0x3d, 0xfe, 0x7d, 0x90, 0x90, 0x5c, 0xac, 0xf0, 0x0b, 0x07, 0x9b, 0x12,
+	0x26, 0x86, 0xae, 0x11, 0x1c, 0x68, 0xd6, 0x29, 0x24, 0xe9, 0x88, 0x85, 0xc8, 0x56, 0x77, 0xa7,
+	0xa4, 0xbe, 0x1c, 0x39, 0x4e, 0x1b, 0x09, 0x29, 0x49, 0x47, 0xfc, 0x05, 0xa4, 0xd9, 0x48, 0xe5,
+	0x93, 0x0c, 0x5f, 0x12, 0x23, 0x66, 0x54, 0xf4, 0x25, 0xd1, 0x48, 0x48, 0x0e, 0xa1, 0xb6, 0x0c,
+	0xe9, 0x21, 0xea, 0x5b, 0x58, 0xb8, 0x83, 0x0d, 0xcf, 0xc7, 0x78, 0x0e, 0xce, 0x61, 0x85, 0xa1,
+	0x64, 0xb5, 0x13, 0xe5, 0xc4, 0x19, 0x44, 0x06, 0xbd, 0xfe, 0x4c, 0x5a, 0x66, 0xd0, 0xeb, 0x0e,
+	0xff, 0x41, 0xd0, 0xcc, 0x4e, 0x0c, 0xc5, 0x75, 0x20, 0xfc, 0x96, 0x82, 0x9d, 0x70, 0xe4, 0x39,
+	0x63, 0xc5, 0x5f, 0x02, 0xd0, 0x91, 0x6c, 0x3a, 0x28, 0xb7, 0xde, 0xfb, 0xd3, 0xf5, 0x1e, 0x61,
+	0xc5, 0x2b, 0x94, 0xb4, 0x4a, 0x47, 0x9e, 0x6a, 0x13, 0xb6, 0x7e, 0x50, 0x35, 0xd4, 0x57, 0x7f,
+	0xc2, 0xb2, 0x93, 0x9f, 0xdb, 0xb8, 0xd4, 0x02, 0x8d, 0xcb, 0x79, 0x54, 0xff, 0x4c, 0xb4, 0xe0,
+	0xed, 0x10, 0xf5, 0xd5, 0x0e, 0xa2, 0xba, 0x29, 0x5b, 0x46, 0x07, 0x51, 0x4c, 0xf2, 0x4b, 0xa5,
+	0x54, 0xb8, 0x17, 0x4c, 0xed, 0x6b, 0x0f, 0xf9, 0x15, 0x03, 0xba, 0xba, 0x1b, 0xc3, 0xe0, 0x32,
+	0xe1, 0xbf, 0x85, 0x1d, 0xc5, 0x2e, 0x8a, 0x46, 0x2c, 0x22, 0xb3, 0x3b, 0x61, 0x2c, 0x9d, 0x66,
+	0x95, 0x7d, 0x77, 0xba, 0xb2, 0x57, 0x1e, 0xa1, 0x69, 0xe3, 0x89, 0xb4, 0xa5, 0x04, 0x16, 0x3c,
+	0xe9, 0x5d, 0x58, 0x41, 0x86, 0x21, 0xf7, 0x10, 0xe9, 0xe5, 0x33, 0x25, 0xae, 0xbc, 0x26, 0x2d,
+	0x23, 0xc3, 0x68, 0x20, 0xd2, 0x13, 0x76, 0x27, 0xdd, 0x08, 0x1d, 0x4f, 0xe1, 0x0f, 0x2e, 0xd0,
+	0xa9, 0xc0, 0x11, 0xfb, 0xc8, 0x36, 0x3b, 0x30, 0x90, 0xa6, 0xea, 0x9a, 0x6c, 0xb2, 0x1d, 0x39,
+	0xd0, 0xba, 0xad, 0xf1, 0x76, 0x80, 0x77, 0x00, 0xd9, 0x36, 0x22, 0xd8, 0xc3, 0x26, 0x19, 0x16,
+	0xec, 0x25, 0x17, 0xf0, 0x1e, 0xbc, 0xb5, 0xad, 0x06, 0x25, 0x53, 0x0c, 0xb6, 0x8e, 0x0c, 0x23,
+	0x60, 0xf0, 0x18, 0x0e, 0x7d, 0xde, 0x63, 0x0f, 0xf4, 0xaf, 0x1c, 0x1c, 0xc6, 0x4c, 0xdc, 0xff,
+	0x92, 0x53, 0xa4, 0xe5, 0x64, 0xa4, 0xe5, 0xea, 0x1d, 0xbc, 0x61, 0x33, 0xe4, 0x9c, 0xfd, 0x4f,
+	0x9b, 0xd7, 0x3c, 0x86, 0xb5, 0xc0, 0x45, 0x50, 0x8e, 0x3c, 0xcc, 0x11, 0xf7, 0x7b, 0xe1, 0x24,
+	0x06, 0x39, 0x7d, 0xbb, 0x9c, 0x71, 0xd5, 0x3f, 0x39, 0x58, 0x63, 0x99, 0xb7, 0xb0, 0x39, 0x54,
+	0x15, 0xcc, 0x7f, 0x03, 0x2b, 0xe3, 0x83, 0x7f, 0x34, 0x2b, 0xa6, 0x87, 0x2a, 0x1c, 0xcf, 0x8c,
+	0x37, 0x16, 0xbb, 0x81, 0xd5, 0xf1, 0xe5, 0xcf, 0x1f, 0xcf, 0x52, 0x1e, 0xc3, 0x16, 0x94, 0x3e,
+	0xe3, 0xaa, 0x3f, 0x73, 0x90, 0xf3, 0x37, 0xd0, 0xcb, 0xa6, 0x0f, 0xeb, 0xe1, 0xcb, 0xe4, 0x74,
+	0x91, 0xa4, 0x5c, 0x70, 0xe1, 0xfd, 0x85, 0x0c, 0xb8, 0xe8, 0xea, 0xef, 0x4b, 0xf0, 0xa6, 0x69,
+	0x5a, 0x9a, 0xaa, 0x75, 0x7d, 0x06, 0xc2, 0xaf, 0xb7, 0x99, 0x06, 0x42, 0xe0, 0x39, 0x06, 0xc2,
+	0xd2, 0x4e, 0xba, 0x8b, 0x47, 0xab, 0xff, 0xa7, 0x68, 0x61, 0xe9, 0x07, 0x0e, 0xf6, 0x66, 0xbd,
+	0x6f, 0x3f, 0x9e, 0x93, 0x68, 0x1c, 0xb1, 0x70, 0x31, 0x2f, 0xe9, 0xd8, 0x90, 0xb6, 0xa5, 0xfa,
+	0x6b, 0x2d, 0xd5, 0x5f, 0x6d, 0x69, 0x06, 0xb3, 0xf6, 0xfd, 0xe3, 0x73, 0x91, 0x7b, 0x7a, 0x2e,
+	0x72, 0xff, 0x3c, 0x17, 0xb9, 0x87, 0x97, 0x62, 0xe2, 0xe9, 0xa5, 0x98, 0xf8, 0xeb, 0xa5, 0x98,
+	0xf8, 0xee, 0xaa, 0xab, 0xd2, 0x9e, 0xd5, 0x16, 0x15, 0x7d, 0x50, 0xf1, 0x7f, 0xde, 0x4d, 0x1e,
+	0x9d, 0x4f, 0xba, 0x88, 0x4f, 0xd1, 0x4f, 0x14, 0xdd, 0xc4, 0xf6, 0x43, 0x3b, 0xc3, 0x30, 0x1f,
+	0xfe, 0x3b, 0x00, 0xa9, 0x95, 0x95, 0xed, 0xb1, 0x0a, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// EventStreamAPIClient is the client API for EventStreamAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type EventStreamAPIClient interface {
+	// StreamEvents streams the transactions and blocks matching the query, in
+	// the order they are committed, until the client cancels the call.
+	StreamEvents(ctx context.Context, in *RequestStreamEvents, opts ...grpc.CallOption) (EventStreamAPI_StreamEventsClient, error)
+}
+
+type eventStreamAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewEventStreamAPIClient(cc *grpc.ClientConn) EventStreamAPIClient {
+	return &eventStreamAPIClient{cc}
+}
+
+func (c *eventStreamAPIClient) StreamEvents(ctx context.Context, in *RequestStreamEvents, opts ...grpc.CallOption) (EventStreamAPI_StreamEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_EventStreamAPI_serviceDesc.Streams[0], "/tendermint.rpc.grpc.EventStreamAPI/StreamEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &eventStreamAPIStreamEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EventStreamAPI_StreamEventsClient interface {
+	Recv() (*ResponseStreamEvents, error)
+	grpc.ClientStream
+}
+
+type eventStreamAPIStreamEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *eventStreamAPIStreamEventsClient) Recv() (*ResponseStreamEvents, error) {
+	m := new(ResponseStreamEvents)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EventStreamAPIServer is the server API for EventStreamAPI service.
+type EventStreamAPIServer interface {
+	// StreamEvents streams the transactions and blocks matching the query, in
+	// the order they are committed, until the client cancels the call.
+	StreamEvents(*RequestStreamEvents, EventStreamAPI_StreamEventsServer) error
+}
+
+// UnimplementedEventStreamAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedEventStreamAPIServer struct {
+}
+
+func (*UnimplementedEventStreamAPIServer) StreamEvents(req *RequestStreamEvents, srv EventStreamAPI_StreamEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEvents not implemented")
+}
+
+func RegisterEventStreamAPIServer(s *grpc.Server, srv EventStreamAPIServer) {
+	s.RegisterService(&_EventStreamAPI_serviceDesc, srv)
+}
+
+func _EventStreamAPI_StreamEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestStreamEvents)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EventStreamAPIServer).StreamEvents(m, &eventStreamAPIStreamEventsServer{stream})
+}
+
+type EventStreamAPI_StreamEventsServer interface {
+	Send(*ResponseStreamEvents) error
+	grpc.ServerStream
+}
+
+type eventStreamAPIStreamEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *eventStreamAPIStreamEventsServer) Send(m *ResponseStreamEvents) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _EventStreamAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.rpc.grpc.EventStreamAPI",
+	HandlerType: (*EventStreamAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEvents",
+			Handler:       _EventStreamAPI_StreamEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tendermint/rpc/grpc/types.proto",
+}
+
+// BlockServiceClient is the client API for BlockService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlockServiceClient interface {
+	// GetBlock returns the block at the requested height.
+	GetBlock(ctx context.Context, in *RequestGetBlock, opts ...grpc.CallOption) (*ResponseGetBlock, error)
+	// NewBlocks streams the blocks committed by the node, until the client
+	// cancels the call.
+	NewBlocks(ctx context.Context, in *RequestNewBlocks, opts ...grpc.CallOption) (BlockService_NewBlocksClient, error)
+}
+
+type blockServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBlockServiceClient(cc *grpc.ClientConn) BlockServiceClient {
+	return &blockServiceClient{cc}
+}
+
+func (c *blockServiceClient) GetBlock(ctx context.Context, in *RequestGetBlock, opts ...grpc.CallOption) (*ResponseGetBlock, error) {
+	out := new(ResponseGetBlock)
+	err := c.cc.Invoke(ctx, "/tendermint.rpc.grpc.BlockService/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockServiceClient) NewBlocks(ctx context.Context, in *RequestNewBlocks, opts ...grpc.CallOption) (BlockService_NewBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BlockService_serviceDesc.Streams[0], "/tendermint.rpc.grpc.BlockService/NewBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockServiceNewBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BlockService_NewBlocksClient interface {
+	Recv() (*ResponseGetBlock, error)
+	grpc.ClientStream
+}
+
+type blockServiceNewBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockServiceNewBlocksClient) Recv() (*ResponseGetBlock, error) {
+	m := new(ResponseGetBlock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlockServiceServer is the server API for BlockService service.
+type BlockServiceServer interface {
+	// GetBlock returns the block at the requested height.
+	GetBlock(context.Context, *RequestGetBlock) (*ResponseGetBlock, error)
+	// NewBlocks streams the blocks committed by the node, until the client
+	// cancels the call.
+	NewBlocks(*RequestNewBlocks, BlockService_NewBlocksServer) error
+}
+
+// UnimplementedBlockServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedBlockServiceServer struct {
+}
+
+func (*UnimplementedBlockServiceServer) GetBlock(ctx context.Context, req *RequestGetBlock) (*ResponseGetBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (*UnimplementedBlockServiceServer) NewBlocks(req *RequestNewBlocks, srv BlockService_NewBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method NewBlocks not implemented")
+}
+
+func RegisterBlockServiceServer(s *grpc.Server, srv BlockServiceServer) {
+	s.RegisterService(&_BlockService_serviceDesc, srv)
+}
+
+func _BlockService_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetBlock)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockServiceServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.rpc.grpc.BlockService/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockServiceServer).GetBlock(ctx, req.(*RequestGetBlock))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BlockService_NewBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestNewBlocks)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockServiceServer).NewBlocks(m, &blockServiceNewBlocksServer{stream})
+}
+
+type BlockService_NewBlocksServer interface {
+	Send(*ResponseGetBlock) error
+	grpc.ServerStream
+}
+
+type blockServiceNewBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockServiceNewBlocksServer) Send(m *ResponseGetBlock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _BlockService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.rpc.grpc.BlockService",
+	HandlerType: (*BlockServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _BlockService_GetBlock_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "NewBlocks",
+			Handler:       _BlockService_NewBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tendermint/rpc/grpc/types.proto",
+}
+
+// BlockResultsServiceClient is the client API for BlockResultsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlockResultsServiceClient interface {
+	// GetBlockResults returns the results of the block at the requested height.
+	GetBlockResults(ctx context.Context, in *RequestGetBlockResults, opts ...grpc.CallOption) (*ResponseGetBlockResults, error)
+}
+
+type blockResultsServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBlockResultsServiceClient(cc *grpc.ClientConn) BlockResultsServiceClient {
+	return &blockResultsServiceClient{cc}
+}
+
+func (c *blockResultsServiceClient) GetBlockResults(ctx context.Context, in *RequestGetBlockResults, opts ...grpc.CallOption) (*ResponseGetBlockResults, error) {
+	out := new(ResponseGetBlockResults)
+	err := c.cc.Invoke(ctx, "/tendermint.rpc.grpc.BlockResultsService/GetBlockResults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BlockResultsServiceServer is the server API for BlockResultsService service.
+type BlockResultsServiceServer interface {
+	// GetBlockResults returns the results of the block at the requested height.
+	GetBlockResults(context.Context, *RequestGetBlockResults) (*ResponseGetBlockResults, error)
+}
+
+// UnimplementedBlockResultsServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedBlockResultsServiceServer struct {
+}
+
+func (*UnimplementedBlockResultsServiceServer) GetBlockResults(ctx context.Context, req *RequestGetBlockResults) (*ResponseGetBlockResults, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockResults not implemented")
+}
+
+func RegisterBlockResultsServiceServer(s *grpc.Server, srv BlockResultsServiceServer) {
+	s.RegisterService(&_BlockResultsService_serviceDesc, srv)
+}
+
+func _BlockResultsService_GetBlockResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetBlockResults)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockResultsServiceServer).GetBlockResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.rpc.grpc.BlockResultsService/GetBlockResults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockResultsServiceServer).GetBlockResults(ctx, req.(*RequestGetBlockResults))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _BlockResultsService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.rpc.grpc.BlockResultsService",
+	HandlerType: (*BlockResultsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlockResults",
+			Handler:    _BlockResultsService_GetBlockResults_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tendermint/rpc/grpc/types.proto",
+}
+
+// PruningServiceClient is the client API for PruningService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PruningServiceClient interface {
+	// SetRetainHeight allows the node to prune the blocks below the given
+	// height, once the application allows it too.
+	SetRetainHeight(ctx context.Context, in *RequestSetRetainHeight, opts ...grpc.CallOption) (*ResponseSetRetainHeight, error)
+	// GetRetainHeight returns the block retain height set by the companion.
+	GetRetainHeight(ctx context.Context, in *RequestGetRetainHeight, opts ...grpc.CallOption) (*ResponseGetRetainHeight, error)
+	// SetBlockResultsRetainHeight allows the node to prune the block results
+	// below the given height, once the application allows it too.
+	SetBlockResultsRetainHeight(ctx context.Context, in *RequestSetBlockResultsRetainHeight, opts ...grpc.CallOption) (*ResponseSetBlockResultsRetainHeight, error)
+	// GetBlockResultsRetainHeight returns the block results retain height set
+	// by the companion.
+	GetBlockResultsRetainHeight(ctx context.Context, in *RequestGetBlockResultsRetainHeight, opts ...grpc.CallOption) (*ResponseGetBlockResultsRetainHeight, error)
+}
+
+type pruningServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPruningServiceClient(cc *grpc.ClientConn) PruningServiceClient {
+	return &pruningServiceClient{cc}
+}
+
+func (c *pruningServiceClient) SetRetainHeight(ctx context.Context, in *RequestSetRetainHeight, opts ...grpc.CallOption) (*ResponseSetRetainHeight, error) {
+	out := new(ResponseSetRetainHeight)
+	err := c.cc.Invoke(ctx, "/tendermint.rpc.grpc.PruningService/SetRetainHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pruningServiceClient) GetRetainHeight(ctx context.Context, in *RequestGetRetainHeight, opts ...grpc.CallOption) (*ResponseGetRetainHeight, error) {
+	out := new(ResponseGetRetainHeight)
+	err := c.cc.Invoke(ctx, "/tendermint.rpc.grpc.PruningService/GetRetainHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pruningServiceClient) SetBlockResultsRetainHeight(ctx context.Context, in *RequestSetBlockResultsRetainHeight, opts ...grpc.CallOption) (*ResponseSetBlockResultsRetainHeight, error) {
+	out := new(ResponseSetBlockResultsRetainHeight)
+	err := c.cc.Invoke(ctx, "/tendermint.rpc.grpc.PruningService/SetBlockResultsRetainHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pruningServiceClient) GetBlockResultsRetainHeight(ctx context.Context, in *RequestGetBlockResultsRetainHeight, opts ...grpc.CallOption) (*ResponseGetBlockResultsRetainHeight, error) {
+	out := new(ResponseGetBlockResultsRetainHeight)
+	err := c.cc.Invoke(ctx, "/tendermint.rpc.grpc.PruningService/GetBlockResultsRetainHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PruningServiceServer is the server API for PruningService service.
+type PruningServiceServer interface {
+	// SetRetainHeight allows the node to prune the blocks below the given
+	// height, once the application allows it too.
+	SetRetainHeight(context.Context, *RequestSetRetainHeight) (*ResponseSetRetainHeight, error)
+	// GetRetainHeight returns the block retain height set by the companion.
+	GetRetainHeight(context.Context, *RequestGetRetainHeight) (*ResponseGetRetainHeight, error)
+	// SetBlockResultsRetainHeight allows the node to prune the block results
+	// below the given height, once the application allows it too.
+	SetBlockResultsRetainHeight(context.Context, *RequestSetBlockResultsRetainHeight) (*ResponseSetBlockResultsRetainHeight, error)
+	// GetBlockResultsRetainHeight returns the block results retain height set
+	// by the companion.
+	GetBlockResultsRetainHeight(context.Context, *RequestGetBlockResultsRetainHeight) (*ResponseGetBlockResultsRetainHeight, error)
+}
+
+// UnimplementedPruningServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedPruningServiceServer struct {
+}
+
+func (*UnimplementedPruningServiceServer) SetRetainHeight(ctx context.Context, req *RequestSetRetainHeight) (*ResponseSetRetainHeight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRetainHeight not implemented")
+}
+func (*UnimplementedPruningServiceServer) GetRetainHeight(ctx context.Context, req *RequestGetRetainHeight) (*ResponseGetRetainHeight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRetainHeight not implemented")
+}
+func (*UnimplementedPruningServiceServer) SetBlockResultsRetainHeight(ctx context.Context, req *RequestSetBlockResultsRetainHeight) (*ResponseSetBlockResultsRetainHeight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetBlockResultsRetainHeight not implemented")
+}
+func (*UnimplementedPruningServiceServer) GetBlockResultsRetainHeight(ctx context.Context, req *RequestGetBlockResultsRetainHeight) (*ResponseGetBlockResultsRetainHeight, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockResultsRetainHeight not implemented")
+}
+
+func RegisterPruningServiceServer(s *grpc.Server, srv PruningServiceServer) {
+	s.RegisterService(&_PruningService_serviceDesc, srv)
+}
+
+func _PruningService_SetRetainHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSetRetainHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PruningServiceServer).SetRetainHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.rpc.grpc.PruningService/SetRetainHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PruningServiceServer).SetRetainHeight(ctx, req.(*RequestSetRetainHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PruningService_GetRetainHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetRetainHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PruningServiceServer).GetRetainHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.rpc.grpc.PruningService/GetRetainHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PruningServiceServer).GetRetainHeight(ctx, req.(*RequestGetRetainHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PruningService_SetBlockResultsRetainHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSetBlockResultsRetainHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PruningServiceServer).SetBlockResultsRetainHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.rpc.grpc.PruningService/SetBlockResultsRetainHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PruningServiceServer).SetBlockResultsRetainHeight(ctx, req.(*RequestSetBlockResultsRetainHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PruningService_GetBlockResultsRetainHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetBlockResultsRetainHeight)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PruningServiceServer).GetBlockResultsRetainHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.rpc.grpc.PruningService/GetBlockResultsRetainHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PruningServiceServer).GetBlockResultsRetainHeight(ctx, req.(*RequestGetBlockResultsRetainHeight))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PruningService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "tendermint.rpc.grpc.PruningService",
+	HandlerType: (*PruningServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetRetainHeight",
+			Handler:    _PruningService_SetRetainHeight_Handler,
+		},
+		{
+			MethodName: "GetRetainHeight",
+			Handler:    _PruningService_GetRetainHeight_Handler,
+		},
+		{
+			MethodName: "SetBlockResultsRetainHeight",
+			Handler:    _PruningService_SetBlockResultsRetainHeight_Handler,
+		},
+		{
+			MethodName: "GetBlockResultsRetainHeight",
+			Handler:    _PruningService_GetBlockResultsRetainHeight_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "tendermint/rpc/grpc/types.proto",
+}
+
+func (m *RequestStreamEvents) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestStreamEvents) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestStreamEvents) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestGetBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestGetBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestGetBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestGetBlockResults) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestGetBlockResults) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestGetBlockResults) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestNewBlocks) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestNewBlocks) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestNewBlocks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestSetRetainHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestSetRetainHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestSetRetainHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestGetRetainHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestGetRetainHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestGetRetainHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestSetBlockResultsRetainHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestSetBlockResultsRetainHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestSetBlockResultsRetainHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestGetBlockResultsRetainHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestGetBlockResultsRetainHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestGetBlockResultsRetainHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockEvents) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockEvents) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockEvents) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for iNdEx := len(m.Events) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Events[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseStreamEvents) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseStreamEvents) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseStreamEvents) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Value != nil {
+		{
+			size := m.Value.Size()
+			i -= size
+			if _, err := m.Value.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseStreamEvents_Tx) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseStreamEvents_Tx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Tx != nil {
+		{
+			size, err := m.Tx.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ResponseStreamEvents_Block) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseStreamEvents_Block) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *ResponseGetBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseGetBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseGetBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.BlockId != nil {
+		{
+			size, err := m.BlockId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseGetBlockResults) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseGetBlockResults) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseGetBlockResults) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AppHash) > 0 {
+		i -= len(m.AppHash)
+		copy(dAtA[i:], m.AppHash)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AppHash)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.ConsensusParamUpdates != nil {
+		{
+			size, err := m.ConsensusParamUpdates.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for iNdEx := len(m.ValidatorUpdates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ValidatorUpdates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.FinalizeBlockEvents) > 0 {
+		for iNdEx := len(m.FinalizeBlockEvents) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.FinalizeBlockEvents[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.TxResults) > 0 {
+		for iNdEx := len(m.TxResults) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.TxResults[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTypes(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseSetRetainHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseSetRetainHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseSetRetainHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseGetRetainHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseGetRetainHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseGetRetainHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AppRetainHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppRetainHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.BaseHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.BaseHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.CompanionRetainHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CompanionRetainHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseSetBlockResultsRetainHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseSetBlockResultsRetainHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseSetBlockResultsRetainHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseGetBlockResultsRetainHeight) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseGetBlockResultsRetainHeight) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseGetBlockResultsRetainHeight) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AppRetainHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.AppRetainHeight))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.CompanionRetainHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.CompanionRetainHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *RequestStreamEvents) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *RequestGetBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *RequestGetBlockResults) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *RequestNewBlocks) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RequestSetRetainHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *RequestGetRetainHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *RequestSetBlockResultsRetainHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *RequestGetBlockResultsRetainHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *BlockEvents) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ResponseStreamEvents) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Value != nil {
+		n += m.Value.Size()
+	}
+	return n
+}
+
+func (m *ResponseStreamEvents_Tx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Tx != nil {
+		l = m.Tx.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *ResponseStreamEvents_Block) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+func (m *ResponseGetBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockId != nil {
+		l = m.BlockId.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseGetBlockResults) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if len(m.TxResults) > 0 {
+		for _, e := range m.TxResults {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.FinalizeBlockEvents) > 0 {
+		for _, e := range m.FinalizeBlockEvents {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if len(m.ValidatorUpdates) > 0 {
+		for _, e := range m.ValidatorUpdates {
+			l = e.Size()
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.ConsensusParamUpdates != nil {
+		l = m.ConsensusParamUpdates.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
+func (m *ResponseSetRetainHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ResponseGetRetainHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompanionRetainHeight != 0 {
+		n += 1 + sovTypes(uint64(m.CompanionRetainHeight))
+	}
+	if m.BaseHeight != 0 {
+		n += 1 + sovTypes(uint64(m.BaseHeight))
+	}
+	if m.AppRetainHeight != 0 {
+		n += 1 + sovTypes(uint64(m.AppRetainHeight))
+	}
+	return n
+}
+
+func (m *ResponseSetBlockResultsRetainHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ResponseGetBlockResultsRetainHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompanionRetainHeight != 0 {
+		n += 1 + sovTypes(uint64(m.CompanionRetainHeight))
+	}
+	if m.AppRetainHeight != 0 {
+		n += 1 + sovTypes(uint64(m.AppRetainHeight))
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *RequestStreamEvents) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestStreamEvents: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestStreamEvents: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestGetBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestGetBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestGetBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestGetBlockResults) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestGetBlockResults: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestGetBlockResults: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestNewBlocks) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestNewBlocks: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestNewBlocks: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestSetRetainHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestSetRetainHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestSetRetainHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestGetRetainHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestGetRetainHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestGetRetainHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestSetBlockResultsRetainHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestSetBlockResultsRetainHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestSetBlockResultsRetainHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestGetBlockResultsRetainHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestGetBlockResultsRetainHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestGetBlockResultsRetainHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockEvents) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockEvents: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockEvents: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, types.Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseStreamEvents) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseStreamEvents: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseStreamEvents: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &types.TxResult{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &ResponseStreamEvents_Tx{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BlockEvents{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Value = &ResponseStreamEvents_Block{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseGetBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseGetBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseGetBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockId == nil {
+				m.BlockId = &types1.BlockID{}
+			}
+			if err := m.BlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &types1.Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseGetBlockResults) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseGetBlockResults: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseGetBlockResults: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxResults", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxResults = append(m.TxResults, &types.ExecTxResult{})
+			if err := m.TxResults[len(m.TxResults)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FinalizeBlockEvents", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FinalizeBlockEvents = append(m.FinalizeBlockEvents, types.Event{})
+			if err := m.FinalizeBlockEvents[len(m.FinalizeBlockEvents)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValidatorUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValidatorUpdates = append(m.ValidatorUpdates, types.ValidatorUpdate{})
+			if err := m.ValidatorUpdates[len(m.ValidatorUpdates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParamUpdates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParamUpdates == nil {
+				m.ConsensusParamUpdates = &types1.ConsensusParams{}
+			}
+			if err := m.ConsensusParamUpdates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseSetRetainHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseSetRetainHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseSetRetainHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseGetRetainHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseGetRetainHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseGetRetainHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompanionRetainHeight", wireType)
+			}
+			m.CompanionRetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompanionRetainHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseHeight", wireType)
+			}
+			m.BaseHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BaseHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppRetainHeight", wireType)
+			}
+			m.AppRetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppRetainHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseSetBlockResultsRetainHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseSetBlockResultsRetainHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseSetBlockResultsRetainHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseGetBlockResultsRetainHeight) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseGetBlockResultsRetainHeight: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseGetBlockResultsRetainHeight: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompanionRetainHeight", wireType)
+			}
+			m.CompanionRetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompanionRetainHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppRetainHeight", wireType)
+			}
+			m.AppRetainHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppRetainHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTypes(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTypes
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTypes
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTypes
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTypes        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTypes          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTypes = fmt.Errorf("proto: unexpected end of group")
+)
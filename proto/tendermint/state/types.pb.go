@@ -9,6 +9,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	_ "github.com/gogo/protobuf/types"
 	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
+	types2 "github.com/tendermint/tendermint/abci/types"
 	types "github.com/tendermint/tendermint/proto/tendermint/types"
 	version "github.com/tendermint/tendermint/proto/tendermint/version"
 	io "io"
@@ -347,59 +348,119 @@ func (m *State) GetAppHash() []byte {
 	return nil
 }
 
+// ExportedState is the dump of the state at a height written by the
+// export-state command, from which import-state constructs the genesis doc of
+// a new chain starting at the next height.
+type ExportedState struct {
+	State       State                         `protobuf:"bytes,1,opt,name=state,proto3" json:"state"`
+	LastResults *types2.ResponseFinalizeBlock `protobuf:"bytes,2,opt,name=last_results,json=lastResults,proto3" json:"last_results,omitempty"`
+}
+
+func (m *ExportedState) Reset()         { *m = ExportedState{} }
+func (m *ExportedState) String() string { return proto.CompactTextString(m) }
+func (*ExportedState) ProtoMessage()    {}
+func (*ExportedState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ccfacf933f22bf93, []int{4}
+}
+func (m *ExportedState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportedState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportedState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportedState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportedState.Merge(m, src)
+}
+func (m *ExportedState) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportedState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportedState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportedState proto.InternalMessageInfo
+
+func (m *ExportedState) GetState() State {
+	if m != nil {
+		return m.State
+	}
+	return State{}
+}
+
+func (m *ExportedState) GetLastResults() *types2.ResponseFinalizeBlock {
+	if m != nil {
+		return m.LastResults
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ValidatorsInfo)(nil), "tendermint.state.ValidatorsInfo")
 	proto.RegisterType((*ConsensusParamsInfo)(nil), "tendermint.state.ConsensusParamsInfo")
 	proto.RegisterType((*Version)(nil), "tendermint.state.Version")
 	proto.RegisterType((*State)(nil), "tendermint.state.State")
+	proto.RegisterType((*ExportedState)(nil), "tendermint.state.ExportedState")
 }
 
 func init() { proto.RegisterFile("tendermint/state/types.proto", fileDescriptor_ccfacf933f22bf93) }
 
 var fileDescriptor_ccfacf933f22bf93 = []byte{
-	// 662 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4f, 0x6f, 0xd3, 0x30,
-	0x1c, 0x6d, 0xd8, 0x9f, 0xb6, 0xee, 0xda, 0x0e, 0x8f, 0x43, 0x56, 0x58, 0x5a, 0x26, 0x40, 0x13,
-	0x87, 0x54, 0x82, 0x03, 0xe2, 0x82, 0x44, 0x3b, 0x89, 0x55, 0x9a, 0x10, 0x64, 0x68, 0x07, 0x2e,
-	0x91, 0xdb, 0x78, 0x89, 0x45, 0x1a, 0x47, 0xb1, 0x3b, 0xe0, 0x03, 0x70, 0xdf, 0x95, 0x6f, 0xb4,
-	0xe3, 0x8e, 0x9c, 0x06, 0x74, 0x5f, 0x04, 0xf9, 0x4f, 0x12, 0xb7, 0xe5, 0x30, 0xc4, 0xad, 0xfe,
-	0xbd, 0xf7, 0x7b, 0xbf, 0x67, 0xfb, 0x39, 0x05, 0x0f, 0x38, 0x4e, 0x02, 0x9c, 0x4d, 0x49, 0xc2,
-	0xfb, 0x8c, 0x23, 0x8e, 0xfb, 0xfc, 0x6b, 0x8a, 0x99, 0x9b, 0x66, 0x94, 0x53, 0xb8, 0x5d, 0xa2,
-	0xae, 0x44, 0x3b, 0xf7, 0x42, 0x1a, 0x52, 0x09, 0xf6, 0xc5, 0x2f, 0xc5, 0xeb, 0x98, 0x2a, 0xb2,
-	0xdf, 0x54, 0xe9, 0xf4, 0x56, 0xd0, 0x73, 0x14, 0x93, 0x00, 0x71, 0x9a, 0x69, 0xc6, 0xde, 0x0a,
-	0x23, 0x45, 0x19, 0x9a, 0xe6, 0x02, 0x8e, 0x01, 0x9f, 0xe3, 0x8c, 0x11, 0x9a, 0x2c, 0x0c, 0xe8,
-	0x86, 0x94, 0x86, 0x31, 0xee, 0xcb, 0xd5, 0x78, 0x76, 0xd6, 0xe7, 0x64, 0x8a, 0x19, 0x47, 0xd3,
-	0x54, 0x11, 0xf6, 0xbf, 0x59, 0xa0, 0x75, 0x9a, 0xcf, 0x64, 0xa3, 0xe4, 0x8c, 0xc2, 0x21, 0x68,
-	0x16, 0x2e, 0x7c, 0x86, 0xb9, 0x6d, 0xf5, 0xac, 0x83, 0xc6, 0x33, 0xc7, 0x35, 0xb6, 0xac, 0x66,
-	0x14, 0x8d, 0x27, 0x98, 0x7b, 0x5b, 0xe7, 0xc6, 0x0a, 0xba, 0x60, 0x27, 0x46, 0x8c, 0xfb, 0x11,
-	0x26, 0x61, 0xc4, 0xfd, 0x49, 0x84, 0x92, 0x10, 0x07, 0xf6, 0x9d, 0x9e, 0x75, 0xb0, 0xe6, 0xdd,
-	0x15, 0xd0, 0x91, 0x44, 0x86, 0x0a, 0xd8, 0xff, 0x6e, 0x81, 0x9d, 0x21, 0x4d, 0x18, 0x4e, 0xd8,
-	0x8c, 0xbd, 0x93, 0x5b, 0x94, 0x66, 0x3c, 0xb0, 0x3d, 0xc9, 0xcb, 0xbe, 0xda, 0xba, 0xf6, 0xf3,
-	0x70, 0xd5, 0xcf, 0x92, 0xc0, 0x60, 0xfd, 0xf2, 0xba, 0x5b, 0xf1, 0xda, 0x93, 0xc5, 0xf2, 0x3f,
-	0x7b, 0x8b, 0x40, 0xf5, 0x54, 0x9d, 0x2d, 0x7c, 0x0d, 0xea, 0x85, 0x9a, 0xf6, 0xb1, 0x67, 0xfa,
-	0xd0, 0x77, 0x50, 0x3a, 0xd1, 0x1e, 0xca, 0x2e, 0xd8, 0x01, 0x35, 0x46, 0xcf, 0xf8, 0x67, 0x94,
-	0x61, 0x39, 0xb2, 0xee, 0x15, 0xeb, 0xfd, 0xdf, 0x9b, 0x60, 0xe3, 0x44, 0xa4, 0x09, 0xbe, 0x04,
-	0x55, 0xad, 0xa5, 0xc7, 0xec, 0xba, 0xcb, 0x89, 0x73, 0xb5, 0x29, 0x3d, 0x22, 0xe7, 0xc3, 0x27,
-	0xa0, 0x36, 0x89, 0x10, 0x49, 0x7c, 0xa2, 0xf6, 0x54, 0x1f, 0x34, 0xe6, 0xd7, 0xdd, 0xea, 0x50,
-	0xd4, 0x46, 0x87, 0x5e, 0x55, 0x82, 0xa3, 0x00, 0x3e, 0x06, 0x2d, 0x92, 0x10, 0x4e, 0x50, 0xac,
-	0x4f, 0xc2, 0x6e, 0xc9, 0x13, 0x68, 0xea, 0xaa, 0x3a, 0x04, 0xf8, 0x14, 0xc8, 0x23, 0xf1, 0xc7,
-	0x31, 0x9d, 0x7c, 0xca, 0x99, 0x6b, 0x92, 0xd9, 0x16, 0xc0, 0x40, 0xd4, 0x35, 0xd7, 0x03, 0x4d,
-	0x83, 0x4b, 0x02, 0x7b, 0x7d, 0xd5, 0xbb, 0xba, 0x2a, 0xd9, 0x35, 0x3a, 0x1c, 0xec, 0x08, 0xef,
-	0xf3, 0xeb, 0x6e, 0xe3, 0x38, 0x97, 0x1a, 0x1d, 0x7a, 0x8d, 0x42, 0x77, 0x14, 0xc0, 0x63, 0xd0,
-	0x36, 0x34, 0x45, 0x7e, 0xed, 0x0d, 0xa9, 0xda, 0x71, 0x55, 0xb8, 0xdd, 0x3c, 0xdc, 0xee, 0x87,
-	0x3c, 0xdc, 0x83, 0x9a, 0x90, 0xbd, 0xf8, 0xd9, 0xb5, 0xbc, 0x66, 0xa1, 0x25, 0x50, 0xf8, 0x06,
-	0xb4, 0x13, 0xfc, 0x85, 0xfb, 0x45, 0x58, 0x99, 0xbd, 0x79, 0xab, 0x78, 0xb7, 0x44, 0x5b, 0xf9,
-	0x52, 0xe0, 0x2b, 0x00, 0x0c, 0x8d, 0xea, 0xad, 0x34, 0x8c, 0x0e, 0x61, 0x44, 0x6e, 0xcb, 0x10,
-	0xa9, 0xdd, 0xce, 0x88, 0x68, 0x33, 0x8c, 0x0c, 0x81, 0x63, 0xa6, 0xb9, 0xd4, 0x2b, 0x82, 0x5d,
-	0x97, 0x97, 0x75, 0xbf, 0x0c, 0x76, 0xd9, 0xad, 0x23, 0xfe, 0xd7, 0x67, 0x06, 0xfe, 0xf3, 0x99,
-	0xbd, 0x05, 0x8f, 0x16, 0x9e, 0xd9, 0x92, 0x7e, 0x61, 0xaf, 0x21, 0xed, 0xf5, 0x8c, 0x77, 0xb7,
-	0x28, 0x94, 0x7b, 0xcc, 0x83, 0x98, 0x61, 0x36, 0x8b, 0x39, 0xf3, 0x23, 0xc4, 0x22, 0x7b, 0xab,
-	0x67, 0x1d, 0x6c, 0xa9, 0x20, 0x7a, 0xaa, 0x7e, 0x84, 0x58, 0x04, 0x77, 0x41, 0x0d, 0xa5, 0xa9,
-	0xa2, 0x34, 0x25, 0xa5, 0x8a, 0xd2, 0x54, 0x40, 0x83, 0xf7, 0x97, 0x73, 0xc7, 0xba, 0x9a, 0x3b,
-	0xd6, 0xaf, 0xb9, 0x63, 0x5d, 0xdc, 0x38, 0x95, 0xab, 0x1b, 0xa7, 0xf2, 0xe3, 0xc6, 0xa9, 0x7c,
-	0x7c, 0x11, 0x12, 0x1e, 0xcd, 0xc6, 0xee, 0x84, 0x4e, 0xfb, 0xe6, 0x67, 0xb7, 0xfc, 0xa9, 0x3e,
-	0xef, 0xcb, 0x7f, 0x0c, 0xe3, 0x4d, 0x59, 0x7f, 0xfe, 0x27, 0x00, 0x00, 0xff, 0xff, 0x3f, 0xca,
-	0x73, 0xb6, 0x33, 0x06, 0x00, 0x00,
+	// 730 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x4d, 0x6f, 0xd3, 0x4a,
+	0x14, 0x8d, 0x5f, 0x3f, 0x92, 0x4c, 0xbe, 0xfa, 0xa6, 0x4f, 0x7a, 0x69, 0x4a, 0x9d, 0x10, 0x41,
+	0x55, 0xb1, 0x70, 0xa4, 0x76, 0x81, 0xd8, 0x20, 0x91, 0x14, 0x68, 0xa4, 0x0a, 0x81, 0x8b, 0xba,
+	0x60, 0x63, 0x4d, 0x92, 0x69, 0x3c, 0xc2, 0xf1, 0x58, 0x9e, 0x49, 0x29, 0xec, 0x59, 0xd3, 0x2d,
+	0xff, 0xa8, 0xcb, 0x2e, 0x59, 0x15, 0x48, 0xff, 0x08, 0x9a, 0x0f, 0xdb, 0x93, 0x84, 0x45, 0x11,
+	0x3b, 0xfb, 0x9e, 0x73, 0xcf, 0x3d, 0x33, 0x3e, 0x57, 0x06, 0xf7, 0x38, 0x0e, 0x47, 0x38, 0x9e,
+	0x90, 0x90, 0x77, 0x18, 0x47, 0x1c, 0x77, 0xf8, 0xc7, 0x08, 0x33, 0x27, 0x8a, 0x29, 0xa7, 0x70,
+	0x23, 0x43, 0x1d, 0x89, 0x36, 0xfe, 0x1b, 0xd3, 0x31, 0x95, 0x60, 0x47, 0x3c, 0x29, 0x5e, 0x63,
+	0xdb, 0x50, 0x41, 0x83, 0x21, 0x31, 0x45, 0x1a, 0xe6, 0x08, 0x59, 0x9f, 0x43, 0x5b, 0x4b, 0xe8,
+	0x39, 0x0a, 0xc8, 0x08, 0x71, 0x1a, 0x6b, 0xc6, 0xce, 0x12, 0x23, 0x42, 0x31, 0x9a, 0x24, 0x02,
+	0xb6, 0x01, 0x9f, 0xe3, 0x98, 0x11, 0x1a, 0xce, 0x0d, 0x68, 0x8e, 0x29, 0x1d, 0x07, 0xb8, 0x23,
+	0xdf, 0x06, 0xd3, 0xb3, 0x0e, 0x27, 0x13, 0xcc, 0x38, 0x9a, 0x44, 0x8a, 0xd0, 0xfe, 0x6c, 0x81,
+	0xea, 0x69, 0x32, 0x93, 0xf5, 0xc3, 0x33, 0x0a, 0x7b, 0xa0, 0x92, 0xba, 0xf0, 0x18, 0xe6, 0x75,
+	0xab, 0x65, 0xed, 0x95, 0xf6, 0x6d, 0xc7, 0xb8, 0x0f, 0x35, 0x23, 0x6d, 0x3c, 0xc1, 0xdc, 0x2d,
+	0x9f, 0x1b, 0x6f, 0xd0, 0x01, 0x9b, 0x01, 0x62, 0xdc, 0xf3, 0x31, 0x19, 0xfb, 0xdc, 0x1b, 0xfa,
+	0x28, 0x1c, 0xe3, 0x51, 0xfd, 0x9f, 0x96, 0xb5, 0xb7, 0xe2, 0xfe, 0x2b, 0xa0, 0x23, 0x89, 0xf4,
+	0x14, 0xd0, 0xfe, 0x6a, 0x81, 0xcd, 0x1e, 0x0d, 0x19, 0x0e, 0xd9, 0x94, 0xbd, 0x96, 0x47, 0x94,
+	0x66, 0x5c, 0xb0, 0x31, 0x4c, 0xca, 0x9e, 0x3a, 0xba, 0xf6, 0x73, 0x7f, 0xd9, 0xcf, 0x82, 0x40,
+	0x77, 0xf5, 0xea, 0xa6, 0x99, 0x73, 0x6b, 0xc3, 0xf9, 0xf2, 0x1f, 0x7b, 0xf3, 0x41, 0xfe, 0x54,
+	0xdd, 0x2d, 0x7c, 0x06, 0x8a, 0xa9, 0x9a, 0xf6, 0xb1, 0x63, 0xfa, 0xd0, 0xdf, 0x20, 0x73, 0xa2,
+	0x3d, 0x64, 0x5d, 0xb0, 0x01, 0x0a, 0x8c, 0x9e, 0xf1, 0x0f, 0x28, 0xc6, 0x72, 0x64, 0xd1, 0x4d,
+	0xdf, 0xdb, 0x3f, 0xd7, 0xc1, 0xda, 0x09, 0x47, 0x1c, 0xc3, 0x27, 0x20, 0xaf, 0xb5, 0xf4, 0x98,
+	0x2d, 0x67, 0x31, 0x8e, 0x8e, 0x36, 0xa5, 0x47, 0x24, 0x7c, 0xb8, 0x0b, 0x0a, 0x43, 0x1f, 0x91,
+	0xd0, 0x23, 0xea, 0x4c, 0xc5, 0x6e, 0x69, 0x76, 0xd3, 0xcc, 0xf7, 0x44, 0xad, 0x7f, 0xe8, 0xe6,
+	0x25, 0xd8, 0x1f, 0xc1, 0x87, 0xa0, 0x4a, 0x42, 0xc2, 0x09, 0x0a, 0xf4, 0x4d, 0xd4, 0xab, 0xf2,
+	0x06, 0x2a, 0xba, 0xaa, 0x2e, 0x01, 0x3e, 0x02, 0xf2, 0x4a, 0xbc, 0x41, 0x40, 0x87, 0xef, 0x13,
+	0xe6, 0x8a, 0x64, 0xd6, 0x04, 0xd0, 0x15, 0x75, 0xcd, 0x75, 0x41, 0xc5, 0xe0, 0x92, 0x51, 0x7d,
+	0x75, 0xd9, 0xbb, 0xfa, 0x54, 0xb2, 0xab, 0x7f, 0xd8, 0xdd, 0x14, 0xde, 0x67, 0x37, 0xcd, 0xd2,
+	0x71, 0x22, 0xd5, 0x3f, 0x74, 0x4b, 0xa9, 0x6e, 0x7f, 0x04, 0x8f, 0x41, 0xcd, 0xd0, 0x14, 0xf9,
+	0xad, 0xaf, 0x49, 0xd5, 0x86, 0xa3, 0xc2, 0xed, 0x24, 0xe1, 0x76, 0xde, 0x26, 0xe1, 0xee, 0x16,
+	0x84, 0xec, 0xe5, 0xf7, 0xa6, 0xe5, 0x56, 0x52, 0x2d, 0x81, 0xc2, 0x97, 0xa0, 0x16, 0xe2, 0x0b,
+	0xee, 0xa5, 0x61, 0x65, 0xf5, 0xf5, 0x3b, 0xc5, 0xbb, 0x2a, 0xda, 0xd2, 0x0a, 0x83, 0x4f, 0x01,
+	0x30, 0x34, 0xf2, 0x77, 0xd2, 0x30, 0x3a, 0x84, 0x11, 0x79, 0x2c, 0x43, 0xa4, 0x70, 0x37, 0x23,
+	0xa2, 0xcd, 0x30, 0xd2, 0x03, 0xb6, 0x99, 0xe6, 0x4c, 0x2f, 0x0d, 0x76, 0x51, 0x7e, 0xac, 0xed,
+	0x2c, 0xd8, 0x59, 0xb7, 0x8e, 0xf8, 0x6f, 0xd7, 0x0c, 0xfc, 0xe5, 0x9a, 0xbd, 0x02, 0x0f, 0xe6,
+	0xd6, 0x6c, 0x41, 0x3f, 0xb5, 0x57, 0x92, 0xf6, 0x5a, 0xc6, 0xde, 0xcd, 0x0b, 0x25, 0x1e, 0x93,
+	0x20, 0xc6, 0x98, 0x4d, 0x03, 0xce, 0x3c, 0x1f, 0x31, 0xbf, 0x5e, 0x6e, 0x59, 0x7b, 0x65, 0x15,
+	0x44, 0x57, 0xd5, 0x8f, 0x10, 0xf3, 0xe1, 0x16, 0x28, 0xa0, 0x28, 0x52, 0x94, 0x8a, 0xa4, 0xe4,
+	0x51, 0x14, 0x09, 0xa8, 0xfd, 0xc5, 0x02, 0x95, 0xe7, 0x17, 0x11, 0x8d, 0x39, 0x1e, 0xa9, 0x5d,
+	0x3b, 0x00, 0x6b, 0x72, 0xa1, 0xf4, 0xa6, 0xfd, 0xbf, 0xbc, 0x69, 0x92, 0xa7, 0xcf, 0xa9, 0xb8,
+	0xb0, 0x0f, 0xca, 0xa6, 0x1b, 0xb9, 0x69, 0xa5, 0xfd, 0x5d, 0xb3, 0x57, 0xfc, 0x0c, 0x1c, 0x17,
+	0xb3, 0x48, 0x9c, 0xe7, 0x05, 0x09, 0x51, 0x40, 0x3e, 0x61, 0x19, 0x45, 0xb7, 0x64, 0x18, 0xee,
+	0xbe, 0xb9, 0x9a, 0xd9, 0xd6, 0xf5, 0xcc, 0xb6, 0x7e, 0xcc, 0x6c, 0xeb, 0xf2, 0xd6, 0xce, 0x5d,
+	0xdf, 0xda, 0xb9, 0x6f, 0xb7, 0x76, 0xee, 0xdd, 0xe3, 0x31, 0xe1, 0xfe, 0x74, 0xe0, 0x0c, 0xe9,
+	0xa4, 0x63, 0xfe, 0x08, 0xb2, 0x47, 0xf5, 0x37, 0x5a, 0xfc, 0x8f, 0x0d, 0xd6, 0x65, 0xfd, 0xe0,
+	0xd7, 0x00, 0xde, 0x19, 0xb9, 0xbf, 0xe2, 0x06, 0x00, 0x00,
 }
 
 func (m *ValidatorsInfo) Marshal() (dAtA []byte, err error) {
@@ -658,6 +719,51 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ExportedState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportedState) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExportedState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastResults != nil {
+		{
+			size, err := m.LastResults.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	{
+		size, err := m.State.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintTypes(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
@@ -767,6 +873,21 @@ func (m *State) Size() (n int) {
 	return n
 }
 
+func (m *ExportedState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.State.Size()
+	n += 1 + l + sovTypes(uint64(l))
+	if m.LastResults != nil {
+		l = m.LastResults.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1561,6 +1682,125 @@ func (m *State) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ExportedState) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportedState: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportedState: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.State.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastResults", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LastResults == nil {
+				m.LastResults = &types2.ResponseFinalizeBlock{}
+			}
+			if err := m.LastResults.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
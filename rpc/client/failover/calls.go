@@ -0,0 +1,379 @@
+package failover
+
+import (
+	"context"
+
+	"github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+func (c *Client) Status(ctx context.Context) (res *coretypes.ResultStatus, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Status(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) ABCIInfo(ctx context.Context) (res *coretypes.ResultABCIInfo, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.ABCIInfo(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (res *coretypes.ResultABCIQuery, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.ABCIQuery(ctx, path, data)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) ABCIQueryWithOptions(ctx context.Context, path string, data bytes.HexBytes, opts rpcclient.ABCIQueryOptions) (res *coretypes.ResultABCIQuery, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.ABCIQueryWithOptions(ctx, path, data, opts)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BroadcastTx(ctx context.Context, tx types.Tx) (res *coretypes.ResultBroadcastTx, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BroadcastTx(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (res *coretypes.ResultBroadcastTxCommit, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BroadcastTxCommit(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BroadcastTxAsync(ctx context.Context, tx types.Tx) (res *coretypes.ResultBroadcastTx, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BroadcastTxAsync(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BroadcastTxSync(ctx context.Context, tx types.Tx) (res *coretypes.ResultBroadcastTx, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BroadcastTxSync(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Events(ctx context.Context, req *coretypes.RequestEvents) (res *coretypes.ResultEvents, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Events(ctx, req)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (res *coretypes.ResultBroadcastEvidence, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BroadcastEvidence(ctx, ev)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Evidence(ctx context.Context, page, perPage *int) (res *coretypes.ResultEvidence, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Evidence(ctx, page, perPage)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) SubmitEvidence(ctx context.Context, voteA, voteB *types.Vote) (res *coretypes.ResultBroadcastEvidence, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.SubmitEvidence(ctx, voteA, voteB)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Genesis(ctx context.Context) (res *coretypes.ResultGenesis, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Genesis(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) GenesisChunked(ctx context.Context, id uint) (res *coretypes.ResultGenesisChunk, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.GenesisChunked(ctx, id)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (res *coretypes.ResultBlockchainInfo, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BlockchainInfo(ctx, minHeight, maxHeight)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) UnconfirmedTxs(ctx context.Context, page, perPage *int) (res *coretypes.ResultUnconfirmedTxs, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.UnconfirmedTxs(ctx, page, perPage)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) NumUnconfirmedTxs(ctx context.Context) (res *coretypes.ResultUnconfirmedTxs, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.NumUnconfirmedTxs(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) CheckTx(ctx context.Context, tx types.Tx) (res *coretypes.ResultCheckTx, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.CheckTx(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) SimulateTx(ctx context.Context, tx types.Tx) (res *coretypes.ResultSimulateTx, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.SimulateTx(ctx, tx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) RemoveTx(ctx context.Context, txKey types.TxKey) error {
+	return c.call(ctx, func(e rpcclient.Client) error {
+		return e.RemoveTx(ctx, txKey)
+	})
+}
+
+func (c *Client) TxStatus(ctx context.Context, hash bytes.HexBytes) (res *coretypes.ResultTxStatus, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.TxStatus(ctx, hash)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) NetInfo(ctx context.Context) (res *coretypes.ResultNetInfo, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.NetInfo(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) DumpConsensusState(ctx context.Context) (res *coretypes.ResultDumpConsensusState, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.DumpConsensusState(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) ConsensusState(ctx context.Context) (res *coretypes.ResultConsensusState, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.ConsensusState(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) ConsensusParams(ctx context.Context, height *int64) (res *coretypes.ResultConsensusParams, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.ConsensusParams(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) ConsensusParamsHistory(ctx context.Context, from, to *int64) (res *coretypes.ResultConsensusParamsHistory, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.ConsensusParamsHistory(ctx, from, to)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Health(ctx context.Context) (res *coretypes.ResultHealth, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Health(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Ready(ctx context.Context) (res *coretypes.ResultReady, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Ready(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) AddressBook(ctx context.Context) (res *coretypes.ResultAddressBook, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.AddressBook(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) NetTopology(ctx context.Context) (res *coretypes.ResultNetTopology, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.NetTopology(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) SigningState(ctx context.Context) (res *coretypes.ResultSigningState, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.SigningState(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) StorageHealth(ctx context.Context) (res *coretypes.ResultStorageHealth, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.StorageHealth(ctx)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Block(ctx context.Context, height *int64) (res *coretypes.ResultBlock, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Block(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BlockByHash(ctx context.Context, hash bytes.HexBytes) (res *coretypes.ResultBlock, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BlockByHash(ctx, hash)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BlockResults(ctx context.Context, height *int64) (res *coretypes.ResultBlockResults, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BlockResults(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BlockResultsWithArgs(
+	ctx context.Context,
+	req *coretypes.RequestBlockResults,
+) (res *coretypes.ResultBlockResults, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BlockResultsWithArgs(ctx, req)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Header(ctx context.Context, height *int64) (res *coretypes.ResultHeader, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Header(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) HeaderByHash(ctx context.Context, hash bytes.HexBytes) (res *coretypes.ResultHeader, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.HeaderByHash(ctx, hash)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Commit(ctx context.Context, height *int64) (res *coretypes.ResultCommit, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Commit(ctx, height)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Validators(ctx context.Context, height *int64, page, perPage *int) (res *coretypes.ResultValidators, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Validators(ctx, height, page, perPage)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) ValidatorHistory(ctx context.Context, address bytes.HexBytes, from, to *int64) (res *coretypes.ResultValidatorHistory, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.ValidatorHistory(ctx, address, from, to)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (res *coretypes.ResultTx, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.Tx(ctx, hash, prove)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (res *coretypes.ResultTxSearch, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.TxSearch(ctx, query, prove, page, perPage, orderBy)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) TxSearchWithArgs(ctx context.Context, req *coretypes.RequestTxSearch) (res *coretypes.ResultTxSearch, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.TxSearchWithArgs(ctx, req)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BlockSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (res *coretypes.ResultBlockSearch, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BlockSearch(ctx, query, page, perPage, orderBy)
+		return err
+	})
+	return res, err
+}
+
+func (c *Client) BlockSearchWithArgs(ctx context.Context, req *coretypes.RequestBlockSearch) (res *coretypes.ResultBlockSearch, err error) {
+	err = c.call(ctx, func(e rpcclient.Client) (err error) {
+		res, err = e.BlockSearchWithArgs(ctx, req)
+		return err
+	})
+	return res, err
+}
@@ -0,0 +1,392 @@
+// Package failover implements an RPC client backed by several full nodes of
+// the same network. It checks the health of the nodes in the background, and
+// fails over to another node when the node it calls can't be reached or has
+// fallen behind the others.
+//
+// Calls are sent to one node at a time, chosen by a Policy among the healthy
+// nodes. A call is retried on the next node only when it fails without a
+// response from the node, e.g. on connection errors or timeouts; errors
+// returned by the node, such as an invalid transaction, are returned as is.
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+const (
+	// DefaultHealthCheckInterval is the default interval between two health
+	// checks of the endpoints.
+	DefaultHealthCheckInterval = 10 * time.Second
+
+	// DefaultMaxHeightLag is the default number of blocks an endpoint may be
+	// behind the highest endpoint before it is considered stale.
+	DefaultMaxHeightLag = 5
+)
+
+var (
+	errCatchingUp    = errors.New("node is catching up")
+	errNotStarted    = errors.New("client must be started to subscribe to events")
+	errNoSubscriber  = errors.New("subscriber has no subscriptions")
+	errAllEndpoints  = errors.New("all endpoints failed")
+	errNoEndpoints   = errors.New("no endpoints")
+	errDuplicateAddr = errors.New("duplicate endpoint")
+)
+
+// Client is an rpcclient.Client for several full nodes, which fails over
+// between them. It is safe for concurrent use.
+type Client struct {
+	logger       log.Logger
+	policy       Policy
+	interval     time.Duration
+	maxHeightLag int64
+
+	endpoints []*endpoint
+
+	mtx sync.Mutex
+	ctx context.Context // the context of Start, for the event subscriptions
+
+	subMtx      sync.Mutex
+	subscribers map[string]*endpoint // the endpoint of the subscriptions of each subscriber
+}
+
+type endpoint struct {
+	remote string
+	client rpcclient.Client
+
+	state   Endpoint // guarded by Client.mtx
+	started bool     // guarded by Client.subMtx
+}
+
+var _ rpcclient.Client = (*Client)(nil)
+
+// Option sets an optional parameter on the Client.
+type Option func(*Client)
+
+// SelectionPolicy sets the policy choosing the endpoint of each call. The
+// default is RoundRobin.
+func SelectionPolicy(p Policy) Option {
+	return func(c *Client) { c.policy = p }
+}
+
+// HealthCheckInterval sets the interval between two health checks of the
+// endpoints. The default is DefaultHealthCheckInterval.
+func HealthCheckInterval(d time.Duration) Option {
+	return func(c *Client) { c.interval = d }
+}
+
+// MaxHeightLag sets the number of blocks an endpoint may be behind the highest
+// endpoint before it is considered stale. The default is DefaultMaxHeightLag.
+func MaxHeightLag(n int64) Option {
+	return func(c *Client) { c.maxHeightLag = n }
+}
+
+// Logger sets the logger of the Client. Failovers and changes of the health of
+// the endpoints are logged at info level.
+func Logger(logger log.Logger) Option {
+	return func(c *Client) { c.logger = logger }
+}
+
+// New returns a Client for the full nodes at the given remotes, each in the
+// form <protocol>://<host>:<port>. The remotes are tried in the given order
+// when none of them is healthy.
+func New(remotes []string, options ...Option) (*Client, error) {
+	clients := make([]rpcclient.Client, len(remotes))
+	for i, remote := range remotes {
+		client, err := rpchttp.New(remote)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %q: %w", remote, err)
+		}
+		clients[i] = client
+	}
+	return newClient(remotes, clients, options...)
+}
+
+func newClient(remotes []string, clients []rpcclient.Client, options ...Option) (*Client, error) {
+	if len(remotes) == 0 {
+		return nil, errNoEndpoints
+	}
+	c := &Client{
+		logger:       log.NewNopLogger(),
+		policy:       RoundRobin(),
+		interval:     DefaultHealthCheckInterval,
+		maxHeightLag: DefaultMaxHeightLag,
+		subscribers:  make(map[string]*endpoint),
+	}
+	for _, opt := range options {
+		opt(c)
+	}
+
+	seen := make(map[string]bool, len(remotes))
+	for i, remote := range remotes {
+		if seen[remote] {
+			return nil, fmt.Errorf("%w %q", errDuplicateAddr, remote)
+		}
+		seen[remote] = true
+		c.endpoints = append(c.endpoints, &endpoint{
+			remote: remote,
+			client: clients[i],
+			// endpoints are presumed healthy until checked
+			state: Endpoint{Remote: remote, Healthy: true},
+		})
+	}
+	return c, nil
+}
+
+// Start checks the health of the endpoints, and keeps checking it in the
+// background every health check interval until ctx ends. The client can be
+// used without being started, but then it only learns about unhealthy
+// endpoints from failed calls, and it can't subscribe to events.
+func (c *Client) Start(ctx context.Context) error {
+	c.mtx.Lock()
+	c.ctx = ctx
+	c.mtx.Unlock()
+
+	c.checkHealth(ctx)
+	go c.run(ctx)
+	return nil
+}
+
+func (c *Client) run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkHealth(ctx)
+		}
+	}
+}
+
+// Endpoints returns the state of the endpoints, in the order they were given.
+func (c *Client) Endpoints() []Endpoint {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	out := make([]Endpoint, len(c.endpoints))
+	for i, e := range c.endpoints {
+		out[i] = e.state
+	}
+	return out
+}
+
+// checkHealth gets the status of all endpoints, and marks the endpoints that
+// don't respond, are catching up or are more than maxHeightLag blocks behind
+// the highest endpoint as unhealthy.
+func (c *Client) checkHealth(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, c.interval)
+	defer cancel()
+
+	states := make([]Endpoint, len(c.endpoints))
+	var wg sync.WaitGroup
+	for i, e := range c.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			start := time.Now()
+			res, err := e.client.Status(checkCtx)
+			states[i] = Endpoint{Remote: e.remote, Latency: time.Since(start), Err: err}
+			if err == nil {
+				states[i].Height = res.SyncInfo.LatestBlockHeight
+				if res.SyncInfo.CatchingUp {
+					states[i].Err = errCatchingUp
+				}
+			}
+		}(i, e)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	var maxHeight int64
+	for _, st := range states {
+		if st.Err == nil && st.Height > maxHeight {
+			maxHeight = st.Height
+		}
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for i, e := range c.endpoints {
+		st := states[i]
+		if st.Err == nil && st.Height < maxHeight-c.maxHeightLag {
+			st.Err = fmt.Errorf("height %d is more than %d blocks behind the highest endpoint at %d",
+				st.Height, c.maxHeightLag, maxHeight)
+		}
+		st.Healthy = st.Err == nil
+		if st.Healthy != e.state.Healthy {
+			c.logger.Info("endpoint health changed", "remote", e.remote, "healthy", st.Healthy, "err", st.Err)
+		}
+		e.state = st
+	}
+}
+
+// order returns the endpoints in the order to try them for a call: the healthy
+// ones ordered by the policy, and then the unhealthy ones.
+func (c *Client) order() []*endpoint {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	byRemote := make(map[string]*endpoint, len(c.endpoints))
+	var healthy []Endpoint
+	for _, e := range c.endpoints {
+		byRemote[e.remote] = e
+		if e.state.Healthy {
+			healthy = append(healthy, e.state)
+		}
+	}
+
+	out := make([]*endpoint, 0, len(c.endpoints))
+	for _, st := range c.policy.Order(healthy) {
+		if e, ok := byRemote[st.Remote]; ok && e.state.Healthy {
+			out = append(out, e)
+			delete(byRemote, st.Remote)
+		}
+	}
+	for _, e := range c.endpoints {
+		if _, ok := byRemote[e.remote]; ok {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// markUnhealthy marks e as unhealthy after a failed call, until its next
+// health check.
+func (c *Client) markUnhealthy(e *endpoint, err error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if e.state.Healthy {
+		c.logger.Info("failing over from endpoint", "remote", e.remote, "err", err)
+	}
+	e.state.Healthy = false
+	e.state.Err = err
+}
+
+// call calls fn with the client of each endpoint in turn, until one of them
+// returns a response.
+func (c *Client) call(ctx context.Context, fn func(rpcclient.Client) error) error {
+	var lastErr error
+	for _, e := range c.order() {
+		err := fn(e.client)
+		if !shouldFailover(ctx, err) {
+			return err
+		}
+		c.markUnhealthy(e, err)
+		lastErr = err
+	}
+	return fmt.Errorf("%w, last error: %v", errAllEndpoints, lastErr)
+}
+
+// shouldFailover reports whether a call that returned err should be retried on
+// another endpoint: if the endpoint failed without responding, and the context
+// of the call has not ended.
+func shouldFailover(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var rpcErr *rpctypes.RPCError
+	return !errors.As(err, &rpcErr)
+}
+
+// Subscribe subscribes to the events of the first endpoint that accepts the
+// subscription. All the subscriptions of a subscriber are made on the same
+// endpoint, and are not moved to another endpoint if it fails.
+//
+// Deprecated: This method will be removed in Tendermint v0.37, use Events
+// instead.
+func (c *Client) Subscribe(
+	ctx context.Context,
+	subscriber, query string,
+	outCapacity ...int,
+) (<-chan coretypes.ResultEvent, error) {
+	c.mtx.Lock()
+	startCtx := c.ctx
+	c.mtx.Unlock()
+	if startCtx == nil {
+		return nil, errNotStarted
+	}
+
+	c.subMtx.Lock()
+	e := c.subscribers[subscriber]
+	c.subMtx.Unlock()
+
+	endpoints := c.order()
+	if e != nil {
+		endpoints = []*endpoint{e}
+	}
+	var lastErr error
+	for _, e := range endpoints {
+		out, err := c.subscribe(ctx, startCtx, e, subscriber, query, outCapacity...)
+		if !shouldFailover(ctx, err) {
+			return out, err
+		}
+		c.markUnhealthy(e, err)
+		lastErr = err
+	}
+	return nil, fmt.Errorf("%w, last error: %v", errAllEndpoints, lastErr)
+}
+
+func (c *Client) subscribe(
+	ctx, startCtx context.Context,
+	e *endpoint,
+	subscriber, query string,
+	outCapacity ...int,
+) (<-chan coretypes.ResultEvent, error) {
+	c.subMtx.Lock()
+	defer c.subMtx.Unlock()
+	if !e.started {
+		if err := e.client.Start(startCtx); err != nil {
+			return nil, err
+		}
+		e.started = true
+	}
+	out, err := e.client.Subscribe(ctx, subscriber, query, outCapacity...) //nolint:staticcheck
+	if err != nil {
+		return nil, err
+	}
+	c.subscribers[subscriber] = e
+	return out, nil
+}
+
+// Unsubscribe unsubscribes the subscriber from the query on the endpoint of
+// its subscriptions.
+//
+// Deprecated: This method will be removed in Tendermint v0.37, use Events
+// instead.
+func (c *Client) Unsubscribe(ctx context.Context, subscriber, query string) error {
+	c.subMtx.Lock()
+	e := c.subscribers[subscriber]
+	c.subMtx.Unlock()
+	if e == nil {
+		return fmt.Errorf("%w: %q", errNoSubscriber, subscriber)
+	}
+	return e.client.Unsubscribe(ctx, subscriber, query) //nolint:staticcheck
+}
+
+// UnsubscribeAll unsubscribes the subscriber from all queries on the endpoint
+// of its subscriptions.
+//
+// Deprecated: This method will be removed in Tendermint v0.37, use Events
+// instead.
+func (c *Client) UnsubscribeAll(ctx context.Context, subscriber string) error {
+	c.subMtx.Lock()
+	e := c.subscribers[subscriber]
+	delete(c.subscribers, subscriber)
+	c.subMtx.Unlock()
+	if e == nil {
+		return fmt.Errorf("%w: %q", errNoSubscriber, subscriber)
+	}
+	return e.client.UnsubscribeAll(ctx, subscriber) //nolint:staticcheck
+}
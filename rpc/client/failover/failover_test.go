@@ -0,0 +1,176 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/rpc/client/mocks"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func newTestClient(t *testing.T, n int, options ...Option) (*Client, []*mocks.Client) {
+	t.Helper()
+	remotes := []string{"tcp://node0:26657", "tcp://node1:26657", "tcp://node2:26657"}[:n]
+	nodes := make([]*mocks.Client, n)
+	clients := make([]rpcclient.Client, n)
+	for i := range nodes {
+		nodes[i] = mocks.NewClient(t)
+		clients[i] = nodes[i]
+	}
+	c, err := newClient(remotes, clients, options...)
+	require.NoError(t, err)
+	return c, nodes
+}
+
+func status(height int64, catchingUp bool) *coretypes.ResultStatus {
+	return &coretypes.ResultStatus{SyncInfo: coretypes.SyncInfo{LatestBlockHeight: height, CatchingUp: catchingUp}}
+}
+
+func TestClientFailover(t *testing.T) {
+	ctx := context.Background()
+	c, nodes := newTestClient(t, 2, SelectionPolicy(LowestLatency()))
+
+	// connection errors fail over to the next endpoint
+	nodes[0].On("Health", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	nodes[1].On("Health", mock.Anything).Return(&coretypes.ResultHealth{}, nil)
+	_, err := c.Health(ctx)
+	require.NoError(t, err)
+
+	endpoints := c.Endpoints()
+	assert.False(t, endpoints[0].Healthy)
+	assert.EqualError(t, endpoints[0].Err, "connection refused")
+	assert.True(t, endpoints[1].Healthy)
+
+	// the unhealthy endpoint is not tried while a healthy one answers
+	_, err = c.Health(ctx)
+	require.NoError(t, err)
+	nodes[1].AssertNumberOfCalls(t, "Health", 2)
+
+	// errors returned by the node don't fail over
+	rpcErr := &rpctypes.RPCError{Code: -32603, Message: "Internal error", Data: "tx already exists in cache"}
+	nodes[1].On("CheckTx", mock.Anything, mock.Anything).Return(nil, rpcErr)
+	_, err = c.CheckTx(ctx, []byte("tx"))
+	assert.Equal(t, rpcErr, err)
+	assert.True(t, c.Endpoints()[1].Healthy)
+
+	// the unhealthy endpoints are tried last
+	nodes[1].On("NetInfo", mock.Anything).Return(nil, errors.New("timeout"))
+	nodes[0].On("NetInfo", mock.Anything).Return(&coretypes.ResultNetInfo{NPeers: 3}, nil)
+	res, err := c.NetInfo(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.NPeers)
+
+	// and an error is returned if all of them fail
+	nodes[0].On("Genesis", mock.Anything).Return(nil, errors.New("connection refused"))
+	nodes[1].On("Genesis", mock.Anything).Return(nil, errors.New("timeout"))
+	_, err = c.Genesis(ctx)
+	assert.ErrorIs(t, err, errAllEndpoints)
+
+	// a canceled call doesn't fail over
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	nodes[0].On("ABCIInfo", mock.Anything).Return(nil, context.Canceled).Maybe()
+	nodes[1].On("ABCIInfo", mock.Anything).Return(nil, context.Canceled).Maybe()
+	_, err = c.ABCIInfo(canceled)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClientCheckHealth(t *testing.T) {
+	ctx := context.Background()
+	c, nodes := newTestClient(t, 3, MaxHeightLag(2))
+
+	nodes[0].On("Status", mock.Anything).Return(status(100, false), nil).Once()
+	nodes[1].On("Status", mock.Anything).Return(status(97, false), nil).Once()
+	nodes[2].On("Status", mock.Anything).Return(status(50, true), nil).Once()
+	c.checkHealth(ctx)
+
+	endpoints := c.Endpoints()
+	assert.True(t, endpoints[0].Healthy)
+	assert.EqualValues(t, 100, endpoints[0].Height)
+	assert.False(t, endpoints[1].Healthy, "stale endpoint")
+	assert.False(t, endpoints[2].Healthy, "catching up endpoint")
+	assert.Equal(t, errCatchingUp, endpoints[2].Err)
+
+	// the endpoints become healthy again once they catch up
+	nodes[0].On("Status", mock.Anything).Return(nil, errors.New("connection refused")).Once()
+	nodes[1].On("Status", mock.Anything).Return(status(101, false), nil).Once()
+	nodes[2].On("Status", mock.Anything).Return(status(100, false), nil).Once()
+	c.checkHealth(ctx)
+
+	endpoints = c.Endpoints()
+	assert.False(t, endpoints[0].Healthy)
+	assert.True(t, endpoints[1].Healthy)
+	assert.True(t, endpoints[2].Healthy)
+
+	// calls go to the healthy endpoints only
+	nodes[1].On("Status", mock.Anything).Return(status(101, false), nil)
+	nodes[2].On("Status", mock.Anything).Return(status(100, false), nil)
+	for i := 0; i < 4; i++ {
+		_, err := c.Status(ctx)
+		require.NoError(t, err)
+	}
+	nodes[0].AssertNumberOfCalls(t, "Status", 2)
+}
+
+func TestClientStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, nodes := newTestClient(t, 2, HealthCheckInterval(10*time.Millisecond))
+
+	nodes[0].On("Status", mock.Anything).Return(nil, errors.New("connection refused"))
+	var checks int32
+	nodes[1].On("Status", mock.Anything).Return(status(10, false), nil).
+		Run(func(mock.Arguments) { atomic.AddInt32(&checks, 1) })
+	require.NoError(t, c.Start(ctx))
+	assert.False(t, c.Endpoints()[0].Healthy)
+
+	// the health checks keep running in the background
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&checks) > 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestPolicies(t *testing.T) {
+	endpoints := []Endpoint{
+		{Remote: "a", Latency: 30 * time.Millisecond},
+		{Remote: "b", Latency: 10 * time.Millisecond},
+		{Remote: "c", Latency: 20 * time.Millisecond},
+	}
+	remotes := func(endpoints []Endpoint) (out []string) {
+		for _, e := range endpoints {
+			out = append(out, e.Remote)
+		}
+		return out
+	}
+
+	p := RoundRobin()
+	for _, want := range [][]string{{"a", "b", "c"}, {"b", "c", "a"}, {"c", "a", "b"}, {"a", "b", "c"}} {
+		order := p.Order(append([]Endpoint(nil), endpoints...))
+		assert.Equal(t, want, remotes(order))
+	}
+	assert.Empty(t, p.Order(nil))
+
+	order := LowestLatency().Order(append([]Endpoint(nil), endpoints...))
+	assert.Equal(t, []string{"b", "c", "a"}, remotes(order))
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := New(nil)
+	assert.Equal(t, errNoEndpoints, err)
+
+	_, err = New([]string{"tcp://node0:26657", "tcp://node0:26657"})
+	assert.ErrorIs(t, err, errDuplicateAddr)
+
+	c, err := New([]string{"tcp://node0:26657", "tcp://node1:26657"})
+	require.NoError(t, err)
+	assert.Len(t, c.Endpoints(), 2)
+}
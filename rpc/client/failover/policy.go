@@ -0,0 +1,61 @@
+package failover
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// Endpoint is the state of an endpoint of a Client, as of its last health
+// check or call.
+type Endpoint struct {
+	// Remote is the address of the endpoint.
+	Remote string
+	// Healthy reports whether the endpoint answered its last health check and
+	// call, and its latest height is not stale.
+	Healthy bool
+	// Height is the latest block height of the endpoint at its last health
+	// check.
+	Height int64
+	// Latency is the duration of the last health check of the endpoint.
+	Latency time.Duration
+	// Err is the reason the endpoint is unhealthy, if it is.
+	Err error
+}
+
+// Policy selects the order in which a Client tries its healthy endpoints for a
+// call. The Client tries the unhealthy endpoints after them, in the order they
+// were given.
+type Policy interface {
+	// Order returns the given healthy endpoints in the order to try them.
+	Order(endpoints []Endpoint) []Endpoint
+}
+
+type roundRobin struct {
+	next uint64 // atomic
+}
+
+// RoundRobin returns a policy that spreads the calls in turn across the
+// healthy endpoints. It is the default policy.
+func RoundRobin() Policy { return &roundRobin{} }
+
+func (p *roundRobin) Order(endpoints []Endpoint) []Endpoint {
+	if len(endpoints) == 0 {
+		return endpoints
+	}
+	start := int((atomic.AddUint64(&p.next, 1) - 1) % uint64(len(endpoints)))
+	return append(endpoints[start:len(endpoints):len(endpoints)], endpoints[:start]...)
+}
+
+type lowestLatency struct{}
+
+// LowestLatency returns a policy that tries the healthy endpoints by increasing
+// latency of their last health check.
+func LowestLatency() Policy { return lowestLatency{} }
+
+func (lowestLatency) Order(endpoints []Endpoint) []Endpoint {
+	sort.SliceStable(endpoints, func(i, j int) bool {
+		return endpoints[i].Latency < endpoints[j].Latency
+	})
+	return endpoints
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -166,6 +167,39 @@ func (c *HTTP) NewBatch() *BatchHTTP {
 	}
 }
 
+// Blocks fetches the blocks at the given heights with batch requests of at
+// most batchSize blocks each, and returns them in the order of heights. A
+// batchSize <= 0 fetches all the blocks in a single batch request. batchSize
+// should not exceed the max-request-batch-size of the node.
+func (c *HTTP) Blocks(ctx context.Context, heights []int64, batchSize int) ([]*coretypes.ResultBlock, error) {
+	if batchSize <= 0 {
+		batchSize = len(heights)
+	}
+
+	blocks := make([]*coretypes.ResultBlock, 0, len(heights))
+	for start := 0; start < len(heights); start += batchSize {
+		end := start + batchSize
+		if end > len(heights) {
+			end = len(heights)
+		}
+
+		batch := c.NewBatch()
+		results := make([]*coretypes.ResultBlock, 0, end-start)
+		for i := start; i < end; i++ {
+			res, err := batch.Block(ctx, &heights[i])
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, res)
+		}
+		if _, err := batch.Send(ctx); err != nil {
+			return nil, fmt.Errorf("fetching blocks %d to %d: %w", heights[start], heights[end-1], err)
+		}
+		blocks = append(blocks, results...)
+	}
+	return blocks, nil
+}
+
 //-----------------------------------------------------------------------------
 // BatchHTTP
 
@@ -282,6 +316,14 @@ func (c *baseRPCClient) CheckTx(ctx context.Context, tx types.Tx) (*coretypes.Re
 	return result, nil
 }
 
+func (c *baseRPCClient) SimulateTx(ctx context.Context, tx types.Tx) (*coretypes.ResultSimulateTx, error) {
+	result := new(coretypes.ResultSimulateTx)
+	if err := c.caller.Call(ctx, "simulate_tx", &coretypes.RequestSimulateTx{Tx: tx}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) RemoveTx(ctx context.Context, txKey types.TxKey) error {
 	if err := c.caller.Call(ctx, "remove_tx", &coretypes.RequestRemoveTx{TxKey: txKey}, nil); err != nil {
 		return err
@@ -289,6 +331,14 @@ func (c *baseRPCClient) RemoveTx(ctx context.Context, txKey types.TxKey) error {
 	return nil
 }
 
+func (c *baseRPCClient) TxStatus(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxStatus, error) {
+	result := new(coretypes.ResultTxStatus)
+	if err := c.caller.Call(ctx, "tx_status", &coretypes.RequestTxStatus{Hash: hash}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	result := new(coretypes.ResultNetInfo)
 	if err := c.caller.Call(ctx, "net_info", nil, result); err != nil {
@@ -323,6 +373,17 @@ func (c *baseRPCClient) ConsensusParams(ctx context.Context, height *int64) (*co
 	return result, nil
 }
 
+func (c *baseRPCClient) ConsensusParamsHistory(ctx context.Context, from, to *int64) (*coretypes.ResultConsensusParamsHistory, error) {
+	result := new(coretypes.ResultConsensusParamsHistory)
+	if err := c.caller.Call(ctx, "consensus_params_history", &coretypes.RequestConsensusParamsHistory{
+		From: (*coretypes.Int64)(from),
+		To:   (*coretypes.Int64)(to),
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Events(ctx context.Context, req *coretypes.RequestEvents) (*coretypes.ResultEvents, error) {
 	result := new(coretypes.ResultEvents)
 	if err := c.caller.Call(ctx, "events", req, result); err != nil {
@@ -339,6 +400,46 @@ func (c *baseRPCClient) Health(ctx context.Context) (*coretypes.ResultHealth, er
 	return result, nil
 }
 
+func (c *baseRPCClient) Ready(ctx context.Context) (*coretypes.ResultReady, error) {
+	result := new(coretypes.ResultReady)
+	if err := c.caller.Call(ctx, "ready", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) AddressBook(ctx context.Context) (*coretypes.ResultAddressBook, error) {
+	result := new(coretypes.ResultAddressBook)
+	if err := c.caller.Call(ctx, "address_book", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	result := new(coretypes.ResultNetTopology)
+	if err := c.caller.Call(ctx, "net_topology", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) SigningState(ctx context.Context) (*coretypes.ResultSigningState, error) {
+	result := new(coretypes.ResultSigningState)
+	if err := c.caller.Call(ctx, "signing_state", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) StorageHealth(ctx context.Context) (*coretypes.ResultStorageHealth, error) {
+	result := new(coretypes.ResultStorageHealth)
+	if err := c.caller.Call(ctx, "storage_health", nil, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
 	result := new(coretypes.ResultBlockchainInfo)
 	if err := c.caller.Call(ctx, "blockchain", &coretypes.RequestBlockchainInfo{
@@ -396,6 +497,17 @@ func (c *baseRPCClient) BlockResults(ctx context.Context, height *int64) (*coret
 	return result, nil
 }
 
+func (c *baseRPCClient) BlockResultsWithArgs(
+	ctx context.Context,
+	req *coretypes.RequestBlockResults,
+) (*coretypes.ResultBlockResults, error) {
+	result := new(coretypes.ResultBlockResults)
+	if err := c.caller.Call(ctx, "block_results", req, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error) {
 	result := new(coretypes.ResultHeader)
 	if err := c.caller.Call(ctx, "header", &coretypes.RequestBlockInfo{
@@ -435,14 +547,18 @@ func (c *baseRPCClient) Tx(ctx context.Context, hash bytes.HexBytes, prove bool)
 }
 
 func (c *baseRPCClient) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error) {
-	result := new(coretypes.ResultTxSearch)
-	if err := c.caller.Call(ctx, "tx_search", &coretypes.RequestTxSearch{
+	return c.TxSearchWithArgs(ctx, &coretypes.RequestTxSearch{
 		Query:   query,
 		Prove:   prove,
 		OrderBy: orderBy,
 		Page:    coretypes.Int64Ptr(page),
 		PerPage: coretypes.Int64Ptr(perPage),
-	}, result); err != nil {
+	})
+}
+
+func (c *baseRPCClient) TxSearchWithArgs(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error) {
+	result := new(coretypes.ResultTxSearch)
+	if err := c.caller.Call(ctx, "tx_search", req, result); err != nil {
 		return nil, err
 	}
 
@@ -450,13 +566,17 @@ func (c *baseRPCClient) TxSearch(ctx context.Context, query string, prove bool,
 }
 
 func (c *baseRPCClient) BlockSearch(ctx context.Context, query string, page, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
-	result := new(coretypes.ResultBlockSearch)
-	if err := c.caller.Call(ctx, "block_search", &coretypes.RequestBlockSearch{
+	return c.BlockSearchWithArgs(ctx, &coretypes.RequestBlockSearch{
 		Query:   query,
 		OrderBy: orderBy,
 		Page:    coretypes.Int64Ptr(page),
 		PerPage: coretypes.Int64Ptr(perPage),
-	}, result); err != nil {
+	})
+}
+
+func (c *baseRPCClient) BlockSearchWithArgs(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error) {
+	result := new(coretypes.ResultBlockSearch)
+	if err := c.caller.Call(ctx, "block_search", req, result); err != nil {
 		return nil, err
 	}
 
@@ -475,6 +595,18 @@ func (c *baseRPCClient) Validators(ctx context.Context, height *int64, page, per
 	return result, nil
 }
 
+func (c *baseRPCClient) ValidatorHistory(ctx context.Context, address bytes.HexBytes, from, to *int64) (*coretypes.ResultValidatorHistory, error) {
+	result := new(coretypes.ResultValidatorHistory)
+	if err := c.caller.Call(ctx, "validator_history", &coretypes.RequestValidatorHistory{
+		Address: address,
+		From:    (*coretypes.Int64)(from),
+		To:      (*coretypes.Int64)(to),
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	result := new(coretypes.ResultBroadcastEvidence)
 	if err := c.caller.Call(ctx, "broadcast_evidence", &coretypes.RequestBroadcastEvidence{
@@ -484,3 +616,25 @@ func (c *baseRPCClient) BroadcastEvidence(ctx context.Context, ev types.Evidence
 	}
 	return result, nil
 }
+
+func (c *baseRPCClient) Evidence(ctx context.Context, page, perPage *int) (*coretypes.ResultEvidence, error) {
+	result := new(coretypes.ResultEvidence)
+	if err := c.caller.Call(ctx, "evidence", &coretypes.RequestEvidence{
+		Page:    coretypes.Int64Ptr(page),
+		PerPage: coretypes.Int64Ptr(perPage),
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) SubmitEvidence(ctx context.Context, voteA, voteB *types.Vote) (*coretypes.ResultBroadcastEvidence, error) {
+	result := new(coretypes.ResultBroadcastEvidence)
+	if err := c.caller.Call(ctx, "submit_evidence", &coretypes.RequestSubmitEvidence{
+		VoteA: voteA,
+		VoteB: voteB,
+	}, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
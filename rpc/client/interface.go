@@ -75,10 +75,14 @@ type SignClient interface {
 	Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error)
 	BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultBlock, error)
 	BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error)
+	// BlockResultsWithArgs is BlockResults with the other arguments of the
+	// method, e.g. to request only the matching events, or their encodings.
+	BlockResultsWithArgs(ctx context.Context, req *coretypes.RequestBlockResults) (*coretypes.ResultBlockResults, error)
 	Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error)
 	HeaderByHash(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultHeader, error)
 	Commit(ctx context.Context, height *int64) (*coretypes.ResultCommit, error)
 	Validators(ctx context.Context, height *int64, page, perPage *int) (*coretypes.ResultValidators, error)
+	ValidatorHistory(ctx context.Context, address bytes.HexBytes, from, to *int64) (*coretypes.ResultValidatorHistory, error)
 	Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error)
 
 	// TxSearch defines a method to search for a paginated set of transactions by
@@ -90,6 +94,9 @@ type SignClient interface {
 		page, perPage *int,
 		orderBy string,
 	) (*coretypes.ResultTxSearch, error)
+	// TxSearchWithArgs is TxSearch with the other arguments of the method,
+	// e.g. the cursor of the page to return, as returned by the previous page.
+	TxSearchWithArgs(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error)
 
 	// BlockSearch defines a method to search for a paginated set of blocks by
 	// FinalizeBlock event search criteria.
@@ -99,6 +106,9 @@ type SignClient interface {
 		page, perPage *int,
 		orderBy string,
 	) (*coretypes.ResultBlockSearch, error)
+	// BlockSearchWithArgs is BlockSearch with the other arguments of the
+	// method, e.g. the cursor of the page to return.
+	BlockSearchWithArgs(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error)
 }
 
 // HistoryClient provides access to data from genesis to now in large chunks.
@@ -120,7 +130,13 @@ type NetworkClient interface {
 	DumpConsensusState(context.Context) (*coretypes.ResultDumpConsensusState, error)
 	ConsensusState(context.Context) (*coretypes.ResultConsensusState, error)
 	ConsensusParams(ctx context.Context, height *int64) (*coretypes.ResultConsensusParams, error)
+	ConsensusParamsHistory(ctx context.Context, from, to *int64) (*coretypes.ResultConsensusParamsHistory, error)
 	Health(context.Context) (*coretypes.ResultHealth, error)
+	Ready(context.Context) (*coretypes.ResultReady, error)
+	SigningState(context.Context) (*coretypes.ResultSigningState, error)
+	StorageHealth(context.Context) (*coretypes.ResultStorageHealth, error)
+	AddressBook(context.Context) (*coretypes.ResultAddressBook, error)
+	NetTopology(context.Context) (*coretypes.ResultNetTopology, error)
 }
 
 // EventsClient exposes the methods to retrieve events from the consensus engine.
@@ -166,13 +182,17 @@ type MempoolClient interface {
 	UnconfirmedTxs(ctx context.Context, page, perPage *int) (*coretypes.ResultUnconfirmedTxs, error)
 	NumUnconfirmedTxs(context.Context) (*coretypes.ResultUnconfirmedTxs, error)
 	CheckTx(context.Context, types.Tx) (*coretypes.ResultCheckTx, error)
+	SimulateTx(context.Context, types.Tx) (*coretypes.ResultSimulateTx, error)
 	RemoveTx(context.Context, types.TxKey) error
+	TxStatus(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxStatus, error)
 }
 
 // EvidenceClient is used for submitting an evidence of the malicious
 // behavior.
 type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*coretypes.ResultBroadcastEvidence, error)
+	Evidence(ctx context.Context, page, perPage *int) (*coretypes.ResultEvidence, error)
+	SubmitEvidence(ctx context.Context, voteA, voteB *types.Vote) (*coretypes.ResultBroadcastEvidence, error)
 }
 
 // RemoteClient is a Client, which can also return the remote network address.
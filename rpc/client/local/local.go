@@ -114,10 +114,18 @@ func (c *Local) CheckTx(ctx context.Context, tx types.Tx) (*coretypes.ResultChec
 	return c.env.CheckTx(ctx, &coretypes.RequestCheckTx{Tx: tx})
 }
 
+func (c *Local) SimulateTx(ctx context.Context, tx types.Tx) (*coretypes.ResultSimulateTx, error) {
+	return c.env.SimulateTx(ctx, &coretypes.RequestSimulateTx{Tx: tx})
+}
+
 func (c *Local) RemoveTx(ctx context.Context, txKey types.TxKey) error {
 	return c.env.Mempool.RemoveTxByKey(txKey)
 }
 
+func (c *Local) TxStatus(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxStatus, error) {
+	return c.env.TxStatus(ctx, &coretypes.RequestTxStatus{Hash: hash})
+}
+
 func (c *Local) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	return c.env.NetInfo(ctx)
 }
@@ -134,6 +142,13 @@ func (c *Local) ConsensusParams(ctx context.Context, height *int64) (*coretypes.
 	return c.env.ConsensusParams(ctx, &coretypes.RequestConsensusParams{Height: (*coretypes.Int64)(height)})
 }
 
+func (c *Local) ConsensusParamsHistory(ctx context.Context, from, to *int64) (*coretypes.ResultConsensusParamsHistory, error) {
+	return c.env.ConsensusParamsHistory(ctx, &coretypes.RequestConsensusParamsHistory{
+		From: (*coretypes.Int64)(from),
+		To:   (*coretypes.Int64)(to),
+	})
+}
+
 func (c *Local) Events(ctx context.Context, req *coretypes.RequestEvents) (*coretypes.ResultEvents, error) {
 	return c.env.Events(ctx, req)
 }
@@ -142,6 +157,26 @@ func (c *Local) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
 	return c.env.Health(ctx)
 }
 
+func (c *Local) Ready(ctx context.Context) (*coretypes.ResultReady, error) {
+	return c.env.Ready(ctx)
+}
+
+func (c *Local) AddressBook(ctx context.Context) (*coretypes.ResultAddressBook, error) {
+	return c.env.AddressBook(ctx)
+}
+
+func (c *Local) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	return c.env.NetTopology(ctx)
+}
+
+func (c *Local) SigningState(ctx context.Context) (*coretypes.ResultSigningState, error) {
+	return c.env.SigningState(ctx)
+}
+
+func (c *Local) StorageHealth(ctx context.Context) (*coretypes.ResultStorageHealth, error) {
+	return c.env.StorageHealth(ctx)
+}
+
 func (c *Local) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
 	return c.env.BlockchainInfo(ctx, &coretypes.RequestBlockchainInfo{
 		MinHeight: coretypes.Int64(minHeight),
@@ -166,7 +201,14 @@ func (c *Local) BlockByHash(ctx context.Context, hash bytes.HexBytes) (*coretype
 }
 
 func (c *Local) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
-	return c.env.BlockResults(ctx, &coretypes.RequestBlockInfo{Height: (*coretypes.Int64)(height)})
+	return c.env.BlockResults(ctx, &coretypes.RequestBlockResults{Height: (*coretypes.Int64)(height)})
+}
+
+func (c *Local) BlockResultsWithArgs(
+	ctx context.Context,
+	req *coretypes.RequestBlockResults,
+) (*coretypes.ResultBlockResults, error) {
+	return c.env.BlockResults(ctx, req)
 }
 
 func (c *Local) Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error) {
@@ -189,6 +231,14 @@ func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *in
 	})
 }
 
+func (c *Local) ValidatorHistory(ctx context.Context, address bytes.HexBytes, from, to *int64) (*coretypes.ResultValidatorHistory, error) {
+	return c.env.ValidatorHistory(ctx, &coretypes.RequestValidatorHistory{
+		Address: address,
+		From:    (*coretypes.Int64)(from),
+		To:      (*coretypes.Int64)(to),
+	})
+}
+
 func (c *Local) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error) {
 	return c.env.Tx(ctx, &coretypes.RequestTx{Hash: hash, Prove: prove})
 }
@@ -203,6 +253,10 @@ func (c *Local) TxSearch(ctx context.Context, queryString string, prove bool, pa
 	})
 }
 
+func (c *Local) TxSearchWithArgs(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error) {
+	return c.env.TxSearch(ctx, req)
+}
+
 func (c *Local) BlockSearch(ctx context.Context, queryString string, page, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
 	return c.env.BlockSearch(ctx, &coretypes.RequestBlockSearch{
 		Query:   queryString,
@@ -212,10 +266,25 @@ func (c *Local) BlockSearch(ctx context.Context, queryString string, page, perPa
 	})
 }
 
+func (c *Local) BlockSearchWithArgs(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error) {
+	return c.env.BlockSearch(ctx, req)
+}
+
 func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(ctx, &coretypes.RequestBroadcastEvidence{Evidence: ev})
 }
 
+func (c *Local) Evidence(ctx context.Context, page, perPage *int) (*coretypes.ResultEvidence, error) {
+	return c.env.Evidence(ctx, &coretypes.RequestEvidence{
+		Page:    coretypes.Int64Ptr(page),
+		PerPage: coretypes.Int64Ptr(perPage),
+	})
+}
+
+func (c *Local) SubmitEvidence(ctx context.Context, voteA, voteB *types.Vote) (*coretypes.ResultBroadcastEvidence, error) {
+	return c.env.SubmitEvidence(ctx, &coretypes.RequestSubmitEvidence{VoteA: voteA, VoteB: voteB})
+}
+
 func (c *Local) Subscribe(ctx context.Context, subscriber, queryString string, capacity ...int) (<-chan coretypes.ResultEvent, error) {
 	q, err := query.New(queryString)
 	if err != nil {
@@ -269,6 +338,7 @@ func (c *Local) eventsRoutine(ctx context.Context, sub eventbus.Subscription, su
 		case outc <- coretypes.ResultEvent{
 			SubscriptionID: msg.SubscriptionID(),
 			Query:          qstr,
+			Seq:            msg.Seq(),
 			Data:           msg.Data(),
 			Events:         msg.Events(),
 		}:
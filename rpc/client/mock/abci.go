@@ -89,7 +89,7 @@ func (a ABCIApp) BroadcastTxAsync(ctx context.Context, tx types.Tx) (*coretypes.
 		Code:      c.Code,
 		Data:      c.Data,
 		Codespace: c.Codespace,
-		Hash:      tx.Hash(),
+		Hash:      tx.ID(),
 	}, nil
 }
 
@@ -111,7 +111,7 @@ func (a ABCIApp) BroadcastTx(ctx context.Context, tx types.Tx) (*coretypes.Resul
 		Code:      c.Code,
 		Data:      c.Data,
 		Codespace: c.Codespace,
-		Hash:      tx.Hash(),
+		Hash:      tx.ID(),
 	}, nil
 }
 
@@ -112,6 +112,10 @@ func (c Client) CheckTx(ctx context.Context, tx types.Tx) (*coretypes.ResultChec
 	return c.env.CheckTx(ctx, &coretypes.RequestCheckTx{Tx: tx})
 }
 
+func (c Client) SimulateTx(ctx context.Context, tx types.Tx) (*coretypes.ResultSimulateTx, error) {
+	return c.env.SimulateTx(ctx, &coretypes.RequestSimulateTx{Tx: tx})
+}
+
 func (c Client) NetInfo(ctx context.Context) (*coretypes.ResultNetInfo, error) {
 	return c.env.NetInfo(ctx)
 }
@@ -128,10 +132,37 @@ func (c Client) ConsensusParams(ctx context.Context, height *int64) (*coretypes.
 	return c.env.ConsensusParams(ctx, &coretypes.RequestConsensusParams{Height: (*coretypes.Int64)(height)})
 }
 
+func (c Client) ConsensusParamsHistory(ctx context.Context, from, to *int64) (*coretypes.ResultConsensusParamsHistory, error) {
+	return c.env.ConsensusParamsHistory(ctx, &coretypes.RequestConsensusParamsHistory{
+		From: (*coretypes.Int64)(from),
+		To:   (*coretypes.Int64)(to),
+	})
+}
+
 func (c Client) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
 	return c.env.Health(ctx)
 }
 
+func (c Client) Ready(ctx context.Context) (*coretypes.ResultReady, error) {
+	return c.env.Ready(ctx)
+}
+
+func (c Client) AddressBook(ctx context.Context) (*coretypes.ResultAddressBook, error) {
+	return c.env.AddressBook(ctx)
+}
+
+func (c Client) NetTopology(ctx context.Context) (*coretypes.ResultNetTopology, error) {
+	return c.env.NetTopology(ctx)
+}
+
+func (c Client) SigningState(ctx context.Context) (*coretypes.ResultSigningState, error) {
+	return c.env.SigningState(ctx)
+}
+
+func (c Client) StorageHealth(ctx context.Context) (*coretypes.ResultStorageHealth, error) {
+	return c.env.StorageHealth(ctx)
+}
+
 func (c Client) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
 	return c.env.BlockchainInfo(ctx, &coretypes.RequestBlockchainInfo{
 		MinHeight: coretypes.Int64(minHeight),
@@ -163,6 +194,33 @@ func (c Client) Validators(ctx context.Context, height *int64, page, perPage *in
 	})
 }
 
+func (c Client) ValidatorHistory(ctx context.Context, address bytes.HexBytes, from, to *int64) (*coretypes.ResultValidatorHistory, error) {
+	return c.env.ValidatorHistory(ctx, &coretypes.RequestValidatorHistory{
+		Address: address,
+		From:    (*coretypes.Int64)(from),
+		To:      (*coretypes.Int64)(to),
+	})
+}
+
+func (c Client) TxSearchWithArgs(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error) {
+	return c.env.TxSearch(ctx, req)
+}
+
+func (c Client) BlockSearchWithArgs(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error) {
+	return c.env.BlockSearch(ctx, req)
+}
+
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(ctx, &coretypes.RequestBroadcastEvidence{Evidence: ev})
 }
+
+func (c Client) Evidence(ctx context.Context, page, perPage *int) (*coretypes.ResultEvidence, error) {
+	return c.env.Evidence(ctx, &coretypes.RequestEvidence{
+		Page:    coretypes.Int64Ptr(page),
+		PerPage: coretypes.Int64Ptr(perPage),
+	})
+}
+
+func (c Client) SubmitEvidence(ctx context.Context, voteA, voteB *types.Vote) (*coretypes.ResultBroadcastEvidence, error) {
+	return c.env.SubmitEvidence(ctx, &coretypes.RequestSubmitEvidence{VoteA: voteA, VoteB: voteB})
+}
@@ -89,6 +89,29 @@ func (_m *Client) ABCIQueryWithOptions(ctx context.Context, path string, data by
 	return r0, r1
 }
 
+// AddressBook provides a mock function with given fields: _a0
+func (_m *Client) AddressBook(_a0 context.Context) (*coretypes.ResultAddressBook, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultAddressBook
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultAddressBook); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultAddressBook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Block provides a mock function with given fields: ctx, height
 func (_m *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
 	ret := _m.Called(ctx, height)
@@ -158,6 +181,29 @@ func (_m *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.R
 	return r0, r1
 }
 
+// BlockResultsWithArgs provides a mock function with given fields: ctx, req
+func (_m *Client) BlockResultsWithArgs(ctx context.Context, req *coretypes.RequestBlockResults) (*coretypes.ResultBlockResults, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *coretypes.ResultBlockResults
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.RequestBlockResults) *coretypes.ResultBlockResults); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlockResults)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.RequestBlockResults) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockSearch provides a mock function with given fields: ctx, query, page, perPage, orderBy
 func (_m *Client) BlockSearch(ctx context.Context, query string, page *int, perPage *int, orderBy string) (*coretypes.ResultBlockSearch, error) {
 	ret := _m.Called(ctx, query, page, perPage, orderBy)
@@ -181,6 +227,29 @@ func (_m *Client) BlockSearch(ctx context.Context, query string, page *int, perP
 	return r0, r1
 }
 
+// BlockSearchWithArgs provides a mock function with given fields: ctx, req
+func (_m *Client) BlockSearchWithArgs(ctx context.Context, req *coretypes.RequestBlockSearch) (*coretypes.ResultBlockSearch, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *coretypes.ResultBlockSearch
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.RequestBlockSearch) *coretypes.ResultBlockSearch); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBlockSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.RequestBlockSearch) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockchainInfo provides a mock function with given fields: ctx, minHeight, maxHeight
 func (_m *Client) BlockchainInfo(ctx context.Context, minHeight int64, maxHeight int64) (*coretypes.ResultBlockchainInfo, error) {
 	ret := _m.Called(ctx, minHeight, maxHeight)
@@ -388,6 +457,29 @@ func (_m *Client) ConsensusParams(ctx context.Context, height *int64) (*coretype
 	return r0, r1
 }
 
+// ConsensusParamsHistory provides a mock function with given fields: ctx, from, to
+func (_m *Client) ConsensusParamsHistory(ctx context.Context, from *int64, to *int64) (*coretypes.ResultConsensusParamsHistory, error) {
+	ret := _m.Called(ctx, from, to)
+
+	var r0 *coretypes.ResultConsensusParamsHistory
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, *int64) *coretypes.ResultConsensusParamsHistory); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultConsensusParamsHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, *int64) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConsensusState provides a mock function with given fields: _a0
 func (_m *Client) ConsensusState(_a0 context.Context) (*coretypes.ResultConsensusState, error) {
 	ret := _m.Called(_a0)
@@ -457,6 +549,29 @@ func (_m *Client) Events(ctx context.Context, req *coretypes.RequestEvents) (*co
 	return r0, r1
 }
 
+// Evidence provides a mock function with given fields: ctx, page, perPage
+func (_m *Client) Evidence(ctx context.Context, page *int, perPage *int) (*coretypes.ResultEvidence, error) {
+	ret := _m.Called(ctx, page, perPage)
+
+	var r0 *coretypes.ResultEvidence
+	if rf, ok := ret.Get(0).(func(context.Context, *int, *int) *coretypes.ResultEvidence); ok {
+		r0 = rf(ctx, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int, *int) error); ok {
+		r1 = rf(ctx, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Genesis provides a mock function with given fields: _a0
 func (_m *Client) Genesis(_a0 context.Context) (*coretypes.ResultGenesis, error) {
 	ret := _m.Called(_a0)
@@ -595,6 +710,29 @@ func (_m *Client) NetInfo(_a0 context.Context) (*coretypes.ResultNetInfo, error)
 	return r0, r1
 }
 
+// NetTopology provides a mock function with given fields: _a0
+func (_m *Client) NetTopology(_a0 context.Context) (*coretypes.ResultNetTopology, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultNetTopology
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultNetTopology); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultNetTopology)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NumUnconfirmedTxs provides a mock function with given fields: _a0
 func (_m *Client) NumUnconfirmedTxs(_a0 context.Context) (*coretypes.ResultUnconfirmedTxs, error) {
 	ret := _m.Called(_a0)
@@ -618,6 +756,29 @@ func (_m *Client) NumUnconfirmedTxs(_a0 context.Context) (*coretypes.ResultUncon
 	return r0, r1
 }
 
+// Ready provides a mock function with given fields: _a0
+func (_m *Client) Ready(_a0 context.Context) (*coretypes.ResultReady, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultReady
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultReady); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultReady)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveTx provides a mock function with given fields: _a0, _a1
 func (_m *Client) RemoveTx(_a0 context.Context, _a1 types.TxKey) error {
 	ret := _m.Called(_a0, _a1)
@@ -632,6 +793,75 @@ func (_m *Client) RemoveTx(_a0 context.Context, _a1 types.TxKey) error {
 	return r0
 }
 
+// SigningState provides a mock function with given fields: _a0
+func (_m *Client) SigningState(_a0 context.Context) (*coretypes.ResultSigningState, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultSigningState
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultSigningState); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSigningState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StorageHealth provides a mock function with given fields: _a0
+func (_m *Client) StorageHealth(_a0 context.Context) (*coretypes.ResultStorageHealth, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *coretypes.ResultStorageHealth
+	if rf, ok := ret.Get(0).(func(context.Context) *coretypes.ResultStorageHealth); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultStorageHealth)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitEvidence provides a mock function with given fields: ctx, voteA, voteB
+func (_m *Client) SubmitEvidence(ctx context.Context, voteA *types.Vote, voteB *types.Vote) (*coretypes.ResultBroadcastEvidence, error) {
+	ret := _m.Called(ctx, voteA, voteB)
+
+	var r0 *coretypes.ResultBroadcastEvidence
+	if rf, ok := ret.Get(0).(func(context.Context, *types.Vote, *types.Vote) *coretypes.ResultBroadcastEvidence); ok {
+		r0 = rf(ctx, voteA, voteB)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultBroadcastEvidence)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *types.Vote, *types.Vote) error); ok {
+		r1 = rf(ctx, voteA, voteB)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields: _a0
 func (_m *Client) Start(_a0 context.Context) error {
 	ret := _m.Called(_a0)
@@ -646,6 +876,29 @@ func (_m *Client) Start(_a0 context.Context) error {
 	return r0
 }
 
+// SimulateTx provides a mock function with given fields: _a0, _a1
+func (_m *Client) SimulateTx(_a0 context.Context, _a1 types.Tx) (*coretypes.ResultSimulateTx, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *coretypes.ResultSimulateTx
+	if rf, ok := ret.Get(0).(func(context.Context, types.Tx) *coretypes.ResultSimulateTx); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSimulateTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, types.Tx) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Status provides a mock function with given fields: _a0
 func (_m *Client) Status(_a0 context.Context) (*coretypes.ResultStatus, error) {
 	ret := _m.Called(_a0)
@@ -745,6 +998,52 @@ func (_m *Client) TxSearch(ctx context.Context, query string, prove bool, page *
 	return r0, r1
 }
 
+// TxSearchWithArgs provides a mock function with given fields: ctx, req
+func (_m *Client) TxSearchWithArgs(ctx context.Context, req *coretypes.RequestTxSearch) (*coretypes.ResultTxSearch, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *coretypes.ResultTxSearch
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.RequestTxSearch) *coretypes.ResultTxSearch); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxSearch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.RequestTxSearch) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TxStatus provides a mock function with given fields: ctx, hash
+func (_m *Client) TxStatus(ctx context.Context, hash bytes.HexBytes) (*coretypes.ResultTxStatus, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 *coretypes.ResultTxStatus
+	if rf, ok := ret.Get(0).(func(context.Context, bytes.HexBytes) *coretypes.ResultTxStatus); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultTxStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bytes.HexBytes) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UnconfirmedTxs provides a mock function with given fields: ctx, page, perPage
 func (_m *Client) UnconfirmedTxs(ctx context.Context, page *int, perPage *int) (*coretypes.ResultUnconfirmedTxs, error) {
 	ret := _m.Called(ctx, page, perPage)
@@ -796,6 +1095,29 @@ func (_m *Client) UnsubscribeAll(ctx context.Context, subscriber string) error {
 	return r0
 }
 
+// ValidatorHistory provides a mock function with given fields: ctx, address, from, to
+func (_m *Client) ValidatorHistory(ctx context.Context, address bytes.HexBytes, from *int64, to *int64) (*coretypes.ResultValidatorHistory, error) {
+	ret := _m.Called(ctx, address, from, to)
+
+	var r0 *coretypes.ResultValidatorHistory
+	if rf, ok := ret.Get(0).(func(context.Context, bytes.HexBytes, *int64, *int64) *coretypes.ResultValidatorHistory); ok {
+		r0 = rf(ctx, address, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultValidatorHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, bytes.HexBytes, *int64, *int64) error); ok {
+		r1 = rf(ctx, address, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Validators provides a mock function with given fields: ctx, height, page, perPage
 func (_m *Client) Validators(ctx context.Context, height *int64, page *int, perPage *int) (*coretypes.ResultValidators, error) {
 	ret := _m.Called(ctx, height, page, perPage)
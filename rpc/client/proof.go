@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+// Errors returned by VerifyABCIQuery for responses that can't be verified.
+var (
+	ErrNoProof       = errors.New("abci query response has no proof ops")
+	ErrEmptyQueryKey = errors.New("abci query response has an empty key")
+)
+
+// ErrQueryFailed is returned by VerifyABCIQuery for an error response of the
+// application.
+type ErrQueryFailed struct {
+	Code uint32
+	Log  string
+}
+
+func (e ErrQueryFailed) Error() string {
+	return fmt.Sprintf("abci query failed with code %d: %s", e.Code, e.Log)
+}
+
+// ErrInvalidProof is returned by VerifyABCIQuery when the proof of the value,
+// or of the absence of a value, of a key does not verify against the app hash.
+type ErrInvalidProof struct {
+	Key    bytes.HexBytes
+	Height int64
+	Err    error
+}
+
+func (e ErrInvalidProof) Error() string {
+	return fmt.Sprintf("invalid proof of key %v at height %d: %v", e.Key, e.Height, e.Err)
+}
+
+func (e ErrInvalidProof) Unwrap() error { return e.Err }
+
+// KeyPathFunc builds the Merkle key path of a key returned by a query of the
+// given path, e.g. "/{store name}/{key}" for applications built with the
+// Cosmos SDK.
+type KeyPathFunc func(path string, key []byte) (merkle.KeyPath, error)
+
+// VerifyABCIQuery verifies the proof ops of resp, the response to a query of
+// path, against appHash with prt. appHash must be the app hash of a trusted
+// header at height resp.Height+1, such as the ones returned by a light client,
+// as the header at a height includes the app hash of the previous height. A
+// response with a value proves the value, and one without proves the absence
+// of the key.
+func VerifyABCIQuery(
+	prt *merkle.ProofRuntime,
+	path string,
+	resp abci.ResponseQuery,
+	keyPathFn KeyPathFunc,
+	appHash []byte,
+) error {
+	switch {
+	case resp.IsErr():
+		return ErrQueryFailed{Code: resp.Code, Log: resp.Log}
+	case len(resp.Key) == 0:
+		return ErrEmptyQueryKey
+	case resp.ProofOps == nil || len(resp.ProofOps.Ops) == 0:
+		return ErrNoProof
+	case resp.Height <= 0:
+		return coretypes.ErrZeroOrNegativeHeight
+	}
+
+	kp, err := keyPathFn(path, resp.Key)
+	if err != nil {
+		return fmt.Errorf("can't build merkle key path: %w", err)
+	}
+	if resp.Value != nil {
+		err = prt.VerifyValue(resp.ProofOps, appHash, kp.String(), resp.Value)
+	} else {
+		err = prt.VerifyAbsence(resp.ProofOps, appHash, kp.String())
+	}
+	if err != nil {
+		return ErrInvalidProof{Key: resp.Key, Height: resp.Height, Err: err}
+	}
+	return nil
+}
+
+// ProofClient is the part of Client used by ABCIQueryWithProof.
+type ProofClient interface {
+	ABCIClient
+	Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error)
+}
+
+// ABCIQueryWithProof queries path with data at the given height of c, with a
+// proof, and verifies the response with VerifyABCIQuery and
+// merkle.DefaultProofRuntime against the app hash of the header of c at the
+// next height. The height must be below the latest height of c, so that the
+// header with its app hash exists.
+//
+// The response is only as trustworthy as the headers of c: use a client that
+// verifies the headers it returns, such as the client of the light/rpc
+// package, rather than a client of an untrusted full node.
+func ABCIQueryWithProof(
+	ctx context.Context,
+	c ProofClient,
+	path string,
+	data bytes.HexBytes,
+	height int64,
+	keyPathFn KeyPathFunc,
+) (*coretypes.ResultABCIQuery, error) {
+	res, err := c.ABCIQueryWithOptions(ctx, path, data, ABCIQueryOptions{Height: height, Prove: true})
+	if err != nil {
+		return nil, err
+	}
+	if res.Response.Height <= 0 {
+		return nil, coretypes.ErrZeroOrNegativeHeight
+	}
+
+	nextHeight := res.Response.Height + 1
+	header, err := c.Header(ctx, &nextHeight)
+	if err != nil {
+		return nil, fmt.Errorf("can't get header %d: %w", nextHeight, err)
+	}
+	err = VerifyABCIQuery(merkle.DefaultProofRuntime(), path, res.Response, keyPathFn, header.Header.AppHash)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,118 @@
+package client_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/bytes"
+	tmcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
+	"github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/rpc/client/mocks"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+// proveValues returns the root hash of a simple map of the given values, and
+// the proof ops of the value of each key.
+func proveValues(t *testing.T, values map[string]string, keys ...string) ([]byte, map[string]*abci.ResponseQuery) {
+	t.Helper()
+	var items [][]byte
+	for _, key := range keys {
+		vhash := crypto.Checksum([]byte(values[key]))
+		items = append(items, append(encodeByteSlice([]byte(key)), encodeByteSlice(vhash)...))
+	}
+	root, proofs := merkle.ProofsFromByteSlices(items)
+
+	responses := make(map[string]*abci.ResponseQuery, len(keys))
+	for i, key := range keys {
+		op := merkle.NewValueOp([]byte(key), proofs[i]).ProofOp()
+		responses[key] = &abci.ResponseQuery{
+			Key:    []byte(key),
+			Value:  []byte(values[key]),
+			Height: 4,
+		}
+		responses[key].ProofOps = &tmcrypto.ProofOps{Ops: []tmcrypto.ProofOp{op}}
+	}
+	return root, responses
+}
+
+// encodeByteSlice encodes bz with its length prefix, as the leaves of a simple
+// map are.
+func encodeByteSlice(bz []byte) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, uint64(len(bz)))
+	return append(buf[:n], bz...)
+}
+
+func keyPath(path string, key []byte) (merkle.KeyPath, error) {
+	return new(merkle.KeyPath).AppendKey(key, merkle.KeyEncodingURL), nil
+}
+
+func TestVerifyABCIQuery(t *testing.T) {
+	values := map[string]string{"foo": "bar", "baz": "qux"}
+	appHash, responses := proveValues(t, values, "foo", "baz")
+	prt := merkle.DefaultProofRuntime()
+
+	require.NoError(t, client.VerifyABCIQuery(prt, "/store", *responses["foo"], keyPath, appHash))
+	require.NoError(t, client.VerifyABCIQuery(prt, "/store", *responses["baz"], keyPath, appHash))
+
+	forged := *responses["foo"]
+	forged.Value = []byte("forged")
+	err := client.VerifyABCIQuery(prt, "/store", forged, keyPath, appHash)
+	var invalid client.ErrInvalidProof
+	require.True(t, errors.As(err, &invalid), err)
+	assert.EqualValues(t, "foo", invalid.Key)
+	assert.EqualValues(t, 4, invalid.Height)
+
+	err = client.VerifyABCIQuery(prt, "/store", *responses["foo"], keyPath, crypto.Checksum([]byte("other")))
+	require.True(t, errors.As(err, &invalid), err)
+
+	noProof := *responses["foo"]
+	noProof.ProofOps = nil
+	assert.Equal(t, client.ErrNoProof, client.VerifyABCIQuery(prt, "/store", noProof, keyPath, appHash))
+
+	noKey := *responses["foo"]
+	noKey.Key = nil
+	assert.Equal(t, client.ErrEmptyQueryKey, client.VerifyABCIQuery(prt, "/store", noKey, keyPath, appHash))
+
+	failed := abci.ResponseQuery{Code: 1, Log: "not found"}
+	assert.Equal(t, client.ErrQueryFailed{Code: 1, Log: "not found"},
+		client.VerifyABCIQuery(prt, "/store", failed, keyPath, appHash))
+}
+
+func TestABCIQueryWithProof(t *testing.T) {
+	ctx := context.Background()
+	values := map[string]string{"foo": "bar", "baz": "qux"}
+	appHash, responses := proveValues(t, values, "foo", "baz")
+
+	c := mocks.NewClient(t)
+	c.On("ABCIQueryWithOptions", mock.Anything, "/store", mock.Anything, client.ABCIQueryOptions{Height: 4, Prove: true}).
+		Return(func(_ context.Context, _ string, data bytes.HexBytes, _ client.ABCIQueryOptions) *coretypes.ResultABCIQuery {
+			res := responses[string(data)]
+			if string(data) == "baz" {
+				forged := *res
+				forged.Value = []byte("forged")
+				res = &forged
+			}
+			return &coretypes.ResultABCIQuery{Response: *res}
+		}, nil)
+	c.On("Header", mock.Anything, mock.MatchedBy(func(height *int64) bool { return *height == 5 })).
+		Return(&coretypes.ResultHeader{Header: &types.Header{Height: 5, AppHash: appHash}}, nil)
+
+	res, err := client.ABCIQueryWithProof(ctx, c, "/store", []byte("foo"), 4, keyPath)
+	require.NoError(t, err)
+	assert.EqualValues(t, "bar", res.Response.Value)
+
+	_, err = client.ABCIQueryWithProof(ctx, c, "/store", []byte("baz"), 4, keyPath)
+	var invalid client.ErrInvalidProof
+	require.True(t, errors.As(err, &invalid), err)
+}
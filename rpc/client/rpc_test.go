@@ -118,6 +118,26 @@ func TestClientOperations(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, status)
 	})
+	t.Run("VersionedRoutes", func(t *testing.T) {
+		c, err := rpchttp.New(conf.RPC.ListenAddress + "/v1")
+		require.NoError(t, err)
+		status, err := c.Status(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, status)
+
+		remote := strings.ReplaceAll(conf.RPC.ListenAddress, "tcp", "http")
+		req, err := http.NewRequestWithContext(ctx, "GET", remote+"/openapi.json", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var doc struct {
+			Paths map[string]interface{} `json:"paths"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+		assert.Contains(t, doc.Paths, "/v1/status")
+	})
 	t.Run("CorsEnabled", func(t *testing.T) {
 		origin := conf.RPC.CORSAllowedOrigins[0]
 		remote := strings.ReplaceAll(conf.RPC.ListenAddress, "tcp", "http")
@@ -174,6 +194,23 @@ func TestClientOperations(t *testing.T) {
 			batch := c.NewBatch()
 			require.Zero(t, batch.Clear(), "clearing an empty batch of JSON RPC requests should result in a 0 result")
 		})
+		t.Run("Blocks", func(t *testing.T) {
+			logger := log.NewTestingLogger(t)
+			c := getHTTPClient(t, logger, conf)
+			require.NoError(t, client.WaitForHeight(ctx, c, 3, nil))
+
+			heights := []int64{3, 1, 2}
+			blocks, err := c.Blocks(ctx, heights, 2)
+			require.NoError(t, err)
+			require.Len(t, blocks, len(heights))
+			for i, block := range blocks {
+				assert.Equal(t, heights[i], block.Block.Height)
+			}
+
+			// more blocks than the maximum batch size of the node
+			_, err = c.Blocks(ctx, make([]int64, conf.RPC.MaxRequestBatchSize+1), 0)
+			require.Error(t, err)
+		})
 		t.Run("ConcurrentJSONRPC", func(t *testing.T) {
 			if testing.Short() {
 				t.Skip("skipping test in short mode")
@@ -242,6 +279,7 @@ func TestClientMethodCalls(t *testing.T) {
 				cons, err := nc.DumpConsensusState(ctx)
 				require.NoError(t, err, "%d: %+v", i, err)
 				assert.NotEmpty(t, cons.RoundState)
+				assert.NotEmpty(t, cons.RoundVotes)
 				assert.Empty(t, cons.Peers)
 			})
 			t.Run("ConsensusState", func(t *testing.T) {
@@ -251,12 +289,33 @@ func TestClientMethodCalls(t *testing.T) {
 				cons, err := nc.ConsensusState(ctx)
 				require.NoError(t, err, "%d: %+v", i, err)
 				assert.NotEmpty(t, cons.RoundState)
+
+				var votes struct {
+					Validators []struct {
+						Address string `json:"address"`
+					} `json:"validators"`
+				}
+				require.NoError(t, json.Unmarshal(cons.RoundVotes, &votes))
+				require.Len(t, votes.Validators, 1)
 			})
 			t.Run("Health", func(t *testing.T) {
 				nc, ok := c.(client.NetworkClient)
 				require.True(t, ok, "%d", i)
-				_, err := nc.Health(ctx)
+				health, err := nc.Health(ctx)
 				require.NoError(t, err, "%d: %+v", i, err)
+				require.Equal(t, coretypes.HealthOK, health.Status, "%+v", health.Reasons)
+
+				ready, err := nc.Ready(ctx)
+				require.NoError(t, err, "%d: %+v", i, err)
+				require.True(t, ready.Ready, "%+v", ready.Reasons)
+			})
+			t.Run("StorageHealth", func(t *testing.T) {
+				nc, ok := c.(client.NetworkClient)
+				require.True(t, ok, "%d", i)
+				res, err := nc.StorageHealth(ctx)
+				require.NoError(t, err, "%d: %+v", i, err)
+				assert.True(t, res.Enabled)
+				assert.True(t, res.Healthy, "%+v", res.Failures)
 			})
 			t.Run("GenesisAndValidators", func(t *testing.T) {
 				// make sure this is the right genesis file
@@ -279,6 +338,49 @@ func TestClientMethodCalls(t *testing.T) {
 				assert.Equal(t, gval.Power, val.VotingPower)
 				assert.Equal(t, gval.PubKey, val.PubKey)
 			})
+			t.Run("ValidatorHistory", func(t *testing.T) {
+				gen, err := c.Genesis(ctx)
+				require.NoError(t, err, "%d: %+v", i, err)
+				gval := gen.Genesis.Validators[0]
+
+				from := int64(1)
+				history, err := c.ValidatorHistory(ctx, gval.Address, &from, nil)
+				require.NoError(t, err, "%d: %+v", i, err)
+				require.EqualValues(t, 1, history.FromHeight)
+				// other tests may have changed the power since
+				require.Equal(t, coretypes.ValidatorPower{Height: 1, Power: gval.Power}, history.Powers[0])
+				require.Positive(t, history.ActiveHeights)
+
+				// the validators of the block after the uncommitted one are known
+				vals, err := c.Validators(ctx, nil, nil, nil)
+				require.NoError(t, err, "%d: %+v", i, err)
+				next := vals.BlockHeight + 1
+				_, err = c.Validators(ctx, &next, nil, nil)
+				require.NoError(t, err, "%d: %+v", i, err)
+
+				_, err = c.ValidatorHistory(ctx, gval.Address[1:], &from, nil)
+				require.Error(t, err)
+			})
+			t.Run("ConsensusParamsHistory", func(t *testing.T) {
+				gen, err := c.Genesis(ctx)
+				require.NoError(t, err, "%d: %+v", i, err)
+
+				from := int64(1)
+				history, err := c.ConsensusParamsHistory(ctx, &from, nil)
+				require.NoError(t, err, "%d: %+v", i, err)
+				require.EqualValues(t, 1, history.FromHeight)
+				require.NotEmpty(t, history.Changes)
+				require.EqualValues(t, 1, history.Changes[0].Height)
+				require.Equal(t, gen.Genesis.ConsensusParams.Block, history.Changes[0].ConsensusParams.Block)
+
+				params, err := c.ConsensusParams(ctx, &history.ToHeight)
+				require.NoError(t, err, "%d: %+v", i, err)
+				require.Equal(t, params.ConsensusParams, history.Changes[len(history.Changes)-1].ConsensusParams)
+
+				to := from - 1
+				_, err = c.ConsensusParamsHistory(ctx, &from, &to)
+				require.Error(t, err)
+			})
 			t.Run("GenesisChunked", func(t *testing.T) {
 				first, err := c.GenesisChunked(ctx, 0)
 				require.NoError(t, err)
@@ -390,6 +492,30 @@ func TestClientMethodCalls(t *testing.T) {
 					// check success code
 					assert.EqualValues(t, 0, blockResults.TxsResults[0].Code)
 				}
+				assert.Nil(t, blockResults.Events, "the encoded events are opt-in")
+
+				// only the matching events are returned, encoded if requested
+				matched, err := c.BlockResultsWithArgs(ctx, &coretypes.RequestBlockResults{
+					Height:      (*coretypes.Int64)(&txh),
+					MatchEvents: "app.creator = 'none'",
+					Events:      true,
+				})
+				require.NoError(t, err)
+				require.Len(t, matched.TxsResults, 1)
+				assert.Empty(t, matched.TxsResults[0].Events)
+				require.NotNil(t, matched.Events)
+				assert.Equal(t, coretypes.NewBlockEvents(matched.FinalizeBlockEvents, matched.TxsResults), matched.Events)
+				assert.Nil(t, matched.CompositeEvents)
+
+				// the events are returned by composite key if requested
+				composite, err := c.BlockResultsWithArgs(ctx, &coretypes.RequestBlockResults{
+					Height:          (*coretypes.Int64)(&txh),
+					CompositeEvents: true,
+				})
+				require.NoError(t, err)
+				require.NotNil(t, composite.CompositeEvents)
+				require.Len(t, composite.CompositeEvents.Txs, 1)
+				assert.Equal(t, []string{"Cosmoshi Netowoko"}, composite.CompositeEvents.Txs[0]["app.creator"])
 
 				// check blockchain info, now that we know there is info
 				info, err := c.BlockchainInfo(ctx, apph, apph)
@@ -493,6 +619,17 @@ func TestClientMethodCalls(t *testing.T) {
 
 				assert.Equal(t, 0, pool.Size(), "mempool must be empty")
 			})
+			t.Run("SimulateTx", func(t *testing.T) {
+				_, _, tx := MakeTxKV()
+
+				res, err := c.SimulateTx(ctx, tx)
+				require.NoError(t, err)
+				assert.Equal(t, abci.CodeTypeOK, res.CheckTx.Code)
+				// no simulate-query-path is configured
+				assert.Nil(t, res.Simulation)
+
+				assert.Equal(t, 0, pool.Size(), "mempool must be empty")
+			})
 			t.Run("Events", func(t *testing.T) {
 				t.Run("Header", func(t *testing.T) {
 					ctx, cancel := context.WithTimeout(ctx, waitForEventTimeout)
@@ -594,6 +731,30 @@ func TestClientMethodCalls(t *testing.T) {
 					_, err := c.BroadcastEvidence(ctx, nil)
 					require.Error(t, err)
 				})
+				t.Run("List", func(t *testing.T) {
+					result, err := c.Evidence(ctx, nil, nil)
+					require.NoError(t, err)
+					require.Equal(t, result.Count, len(result.Evidence))
+					require.LessOrEqual(t, result.Count, result.Total)
+				})
+				t.Run("SubmitDuplicateVote", func(t *testing.T) {
+					if _, ok := c.(*rpclocal.Local); !ok {
+						t.Skip("submit_evidence is an unsafe method")
+					}
+					evidenceHeight := int64(1)
+					block, err := c.Block(ctx, &evidenceHeight)
+					require.NoError(t, err)
+					correct, fakes := makeEvidences(t, pv, conf.ChainID(), block.Block.Time)
+
+					result, err := c.SubmitEvidence(ctx, correct.VoteA, correct.VoteB)
+					require.NoError(t, err)
+					assert.Equal(t, correct.Hash(), result.Hash)
+
+					for _, fake := range fakes {
+						_, err := c.SubmitEvidence(ctx, fake.VoteA, fake.VoteB)
+						require.Error(t, err, "SubmitEvidence(%s) succeeded, but the votes were fake", fake)
+					}
+				})
 			})
 		})
 	}
@@ -695,6 +856,37 @@ func TestClientMethodCallsAdvanced(t *testing.T) {
 
 		pool.Flush()
 	})
+	t.Run("TxStatus", func(t *testing.T) {
+		_, _, raw := MakeTxKV()
+		tx := types.Tx(raw)
+		for _, c := range GetClients(t, n, conf) {
+			res, err := c.TxStatus(ctx, tx.Hash())
+			require.NoError(t, err)
+			assert.Equal(t, coretypes.TxStatusNotFound, res.Status)
+		}
+
+		c := getHTTPClient(t, logger, conf)
+		_, err := c.BroadcastTxSync(ctx, tx)
+		require.NoError(t, err)
+
+		// the transaction is in the mempool until it is committed
+		require.Eventually(t, func() bool {
+			res, err := c.TxStatus(ctx, tx.Hash())
+			require.NoError(t, err)
+			if res.Status == coretypes.TxStatusMempool {
+				require.NotNil(t, res.Mempool)
+				return false
+			}
+			require.Equal(t, coretypes.TxStatusCommitted, res.Status)
+			require.NotNil(t, res.Committed)
+			assert.Positive(t, res.Committed.Height)
+			assert.Equal(t, abci.CodeTypeOK, res.Committed.Code)
+			return true
+		}, 10*time.Second, 50*time.Millisecond)
+
+		_, err = c.TxStatus(ctx, []byte("short"))
+		assert.Error(t, err)
+	})
 	t.Run("Tx", func(t *testing.T) {
 		logger := log.NewTestingLogger(t)
 
@@ -771,6 +963,65 @@ func TestClientMethodCallsAdvanced(t *testing.T) {
 		require.NoError(t, err)
 		require.Greater(t, len(result.Txs), 0, "expected a lot of transactions")
 	})
+	t.Run("SearchCursor", func(t *testing.T) {
+		c := getHTTPClient(t, log.NewTestingLogger(t), conf)
+
+		var first int64
+		for i := 0; i < 3; i++ {
+			_, _, tx := MakeTxKV()
+			res, err := c.BroadcastTxCommit(ctx, tx)
+			require.NoError(t, err)
+			if first == 0 {
+				first = res.Height
+			}
+		}
+		txQuery := fmt.Sprintf("tx.height >= %d", first)
+		all, err := c.TxSearch(ctx, txQuery, false, nil, nil, "asc")
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(all.Txs), 3)
+
+		// walking the pages of one tx by their cursors returns all the txs
+		var paged []*coretypes.ResultTx
+		perPage := coretypes.Int64(1)
+		req := &coretypes.RequestTxSearch{Query: txQuery, PerPage: &perPage, OrderBy: "asc"}
+		for {
+			res, err := c.TxSearchWithArgs(ctx, req)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(res.Txs), 1)
+			paged = append(paged, res.Txs...)
+			if res.NextCursor == "" {
+				break
+			}
+			require.Less(t, len(paged), len(all.Txs)+1, "the cursors must end")
+			req.Cursor = res.NextCursor
+		}
+		require.Len(t, paged, len(all.Txs))
+		for i, tx := range paged {
+			assert.Equal(t, all.Txs[i].Hash, tx.Hash, i)
+		}
+
+		// the cursor of a page can't be used with another order
+		req.OrderBy = "desc"
+		_, err = c.TxSearchWithArgs(ctx, req)
+		assert.Error(t, err)
+
+		blockQuery := fmt.Sprintf("block.height >= %d AND block.height <= %d", first, first+1)
+		breq := &coretypes.RequestBlockSearch{Query: blockQuery, PerPage: &perPage, OrderBy: "asc"}
+		var heights []int64
+		for {
+			res, err := c.BlockSearchWithArgs(ctx, breq)
+			require.NoError(t, err)
+			for _, b := range res.Blocks {
+				heights = append(heights, b.Block.Height)
+			}
+			if res.NextCursor == "" {
+				break
+			}
+			require.Less(t, len(heights), 3, "the cursors must end")
+			breq.Cursor = res.NextCursor
+		}
+		assert.Equal(t, []int64{first, first + 1}, heights)
+	})
 	t.Run("TxSearch", func(t *testing.T) {
 		t.Skip("Test Asserts Non-Deterministic Results")
 		logger := log.NewTestingLogger(t)
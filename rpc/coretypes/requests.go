@@ -12,6 +12,10 @@ import (
 
 type RequestSubscribe struct {
 	Query string `json:"query"`
+
+	// If positive, resume a previous subscription by first delivering the
+	// retained events with a sequence number greater than AfterSeq.
+	AfterSeq Int64 `json:"after_seq"`
 }
 
 type RequestUnsubscribe struct {
@@ -31,6 +35,13 @@ type RequestBlockInfo struct {
 	Height *Int64 `json:"height"`
 }
 
+type RequestBlockResults struct {
+	Height          *Int64 `json:"height"`
+	MatchEvents     string `json:"match_events"`
+	Events          bool   `json:"events"`
+	CompositeEvents bool   `json:"composite_events"`
+}
+
 type RequestBlockByHash struct {
 	Hash bytes.HexBytes `json:"hash"`
 }
@@ -39,6 +50,10 @@ type RequestCheckTx struct {
 	Tx types.Tx `json:"tx"`
 }
 
+type RequestSimulateTx struct {
+	Tx types.Tx `json:"tx"`
+}
+
 type RequestRemoveTx struct {
 	TxKey types.TxKey `json:"txkey"`
 }
@@ -48,12 +63,17 @@ type RequestTx struct {
 	Prove bool           `json:"prove"`
 }
 
+type RequestTxStatus struct {
+	Hash bytes.HexBytes `json:"hash"`
+}
+
 type RequestTxSearch struct {
 	Query   string `json:"query"`
 	Prove   bool   `json:"prove"`
 	Page    *Int64 `json:"page"`
 	PerPage *Int64 `json:"per_page"`
 	OrderBy string `json:"order_by"`
+	Cursor  string `json:"cursor"`
 }
 
 type RequestBlockSearch struct {
@@ -61,6 +81,7 @@ type RequestBlockSearch struct {
 	Page    *Int64 `json:"page"`
 	PerPage *Int64 `json:"per_page"`
 	OrderBy string `json:"order_by"`
+	Cursor  string `json:"cursor"`
 }
 
 type RequestValidators struct {
@@ -69,10 +90,21 @@ type RequestValidators struct {
 	PerPage *Int64 `json:"per_page"`
 }
 
+type RequestValidatorHistory struct {
+	Address bytes.HexBytes `json:"address"`
+	From    *Int64         `json:"from"`
+	To      *Int64         `json:"to"`
+}
+
 type RequestConsensusParams struct {
 	Height *Int64 `json:"height"`
 }
 
+type RequestConsensusParamsHistory struct {
+	From *Int64 `json:"from"`
+	To   *Int64 `json:"to"`
+}
+
 type RequestUnconfirmedTxs struct {
 	Page    *Int64 `json:"page"`
 	PerPage *Int64 `json:"per_page"`
@@ -93,6 +125,21 @@ type RequestBroadcastEvidence struct {
 	Evidence types.Evidence
 }
 
+type RequestEvidence struct {
+	Page    *Int64 `json:"page"`
+	PerPage *Int64 `json:"per_page"`
+}
+
+type RequestSetHalt struct {
+	Height Int64 `json:"height"`
+	Time   Int64 `json:"time"`
+}
+
+type RequestSubmitEvidence struct {
+	VoteA *types.Vote `json:"vote_a"`
+	VoteB *types.Vote `json:"vote_b"`
+}
+
 type requestBroadcastEvidenceJSON struct {
 	Evidence json.RawMessage `json:"evidence"`
 }
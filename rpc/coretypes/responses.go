@@ -3,14 +3,17 @@ package coretypes
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"time"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/jsontypes"
+	tmquery "github.com/tendermint/tendermint/internal/pubsub/query"
 	"github.com/tendermint/tendermint/libs/bytes"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/types/events"
 )
 
 // List of standardized errors used across RPC
@@ -71,6 +74,152 @@ type ResultBlockResults struct {
 	FinalizeBlockEvents   []abci.Event             `json:"finalize_block_events"`
 	ValidatorUpdates      []abci.ValidatorUpdate   `json:"validator_updates"`
 	ConsensusParamUpdates *tmproto.ConsensusParams `json:"consensus_param_updates"`
+
+	// Events are FinalizeBlockEvents and the events of TxsResults, in the
+	// versioned encoding of BlockEvents, if requested with
+	// RequestBlockResults.Events.
+	Events *BlockEvents `json:"events,omitempty"`
+
+	// CompositeEvents are FinalizeBlockEvents and the events of TxsResults by
+	// composite key, if requested with RequestBlockResults.CompositeEvents.
+	CompositeEvents *CompositeBlockEvents `json:"composite_events,omitempty"`
+}
+
+// ApplyArgs applies the arguments of req other than the height to the
+// results: it removes the events that don't match req.MatchEvents, unless it
+// is empty, and sets Events and CompositeEvents if requested, or else clears
+// them. Each event is matched on its own, so that a query on the attributes
+// of several event types matches none.
+func (r *ResultBlockResults) ApplyArgs(req *RequestBlockResults) error {
+	if req.MatchEvents != "" {
+		q, err := tmquery.New(req.MatchEvents)
+		if err != nil {
+			return fmt.Errorf("invalid match_events: %w", err)
+		}
+		r.FinalizeBlockEvents = matchingEvents(q, r.FinalizeBlockEvents)
+		txsResults := make([]*abci.ExecTxResult, len(r.TxsResults))
+		for i, txRes := range r.TxsResults {
+			matched := *txRes
+			matched.Events = matchingEvents(q, txRes.Events)
+			txsResults[i] = &matched
+		}
+		r.TxsResults = txsResults
+	}
+	r.Events, r.CompositeEvents = nil, nil
+	if req.Events {
+		r.Events = NewBlockEvents(r.FinalizeBlockEvents, r.TxsResults)
+	}
+	if req.CompositeEvents {
+		r.CompositeEvents = NewCompositeBlockEvents(r.FinalizeBlockEvents, r.TxsResults)
+	}
+	return nil
+}
+
+func matchingEvents(q *tmquery.Query, events []abci.Event) []abci.Event {
+	matched := make([]abci.Event, 0, len(events))
+	for _, event := range events {
+		if q.Matches([]abci.Event{event}) {
+			matched = append(matched, event)
+		}
+	}
+	return matched
+}
+
+// DecodeEvents returns the values decoded from FinalizeBlockEvents whose
+// event types have a Go type registered with the types/events package.
+func (r *ResultBlockResults) DecodeEvents() ([]interface{}, error) {
+	return events.DecodeAll(r.FinalizeBlockEvents)
+}
+
+// DecodeTxEvents returns the values decoded from the events of the i-th
+// transaction result whose event types have a Go type registered with the
+// types/events package.
+func (r *ResultBlockResults) DecodeTxEvents(i int) ([]interface{}, error) {
+	if i < 0 || i >= len(r.TxsResults) {
+		return nil, fmt.Errorf("no result for transaction %d of %d", i, len(r.TxsResults))
+	}
+	return events.DecodeAll(r.TxsResults[i].GetEvents())
+}
+
+// CompositeBlockEvents are the events of the results of a block, as the
+// values of their attributes by composite key, "<type>.<key>", the keys of
+// event queries.
+type CompositeBlockEvents struct {
+	FinalizeBlock map[string][]string   `json:"finalize_block"`
+	Txs           []map[string][]string `json:"txs"` // the events of each transaction
+}
+
+// NewCompositeBlockEvents returns the events of the results of a block by
+// composite key.
+func NewCompositeBlockEvents(finalizeBlockEvents []abci.Event, txsResults []*abci.ExecTxResult) *CompositeBlockEvents {
+	txs := make([]map[string][]string, len(txsResults))
+	for i, res := range txsResults {
+		txs[i] = events.CompositeKeys(res.GetEvents())
+	}
+	return &CompositeBlockEvents{
+		FinalizeBlock: events.CompositeKeys(finalizeBlockEvents),
+		Txs:           txs,
+	}
+}
+
+// BlockEventsVersion is the version of the encoding of BlockEvents.
+const BlockEventsVersion = 1
+
+// BlockEvents are the events of the results of a block, in an encoding that
+// doesn't change within a version: every field is always present, and the
+// keys and values of attributes are given both as strings and as raw bytes,
+// as the JSON encoding of strings replaces invalid UTF-8.
+type BlockEvents struct {
+	Version       int              `json:"version"`
+	FinalizeBlock []EncodedEvent   `json:"finalize_block"`
+	Txs           [][]EncodedEvent `json:"txs"` // the events of each transaction
+}
+
+// EncodedEvent is an event in the encoding of BlockEvents.
+type EncodedEvent struct {
+	Type       string                  `json:"type"`
+	Attributes []EncodedEventAttribute `json:"attributes"`
+}
+
+// EncodedEventAttribute is an event attribute in the encoding of BlockEvents.
+type EncodedEventAttribute struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	KeyRaw   []byte `json:"key_raw"`
+	ValueRaw []byte `json:"value_raw"`
+	Index    bool   `json:"index"`
+}
+
+// NewBlockEvents returns the events of the results of a block in the encoding
+// of BlockEvents.
+func NewBlockEvents(finalizeBlockEvents []abci.Event, txsResults []*abci.ExecTxResult) *BlockEvents {
+	txs := make([][]EncodedEvent, len(txsResults))
+	for i, res := range txsResults {
+		txs[i] = encodeEvents(res.GetEvents())
+	}
+	return &BlockEvents{
+		Version:       BlockEventsVersion,
+		FinalizeBlock: encodeEvents(finalizeBlockEvents),
+		Txs:           txs,
+	}
+}
+
+func encodeEvents(events []abci.Event) []EncodedEvent {
+	out := make([]EncodedEvent, len(events))
+	for i, event := range events {
+		attrs := make([]EncodedEventAttribute, len(event.Attributes))
+		for j, attr := range event.Attributes {
+			attrs[j] = EncodedEventAttribute{
+				Key:      attr.Key,
+				Value:    attr.Value,
+				KeyRaw:   []byte(attr.Key),
+				ValueRaw: []byte(attr.Value),
+				Index:    attr.Index,
+			}
+		}
+		out[i] = EncodedEvent{Type: event.Type, Attributes: attrs}
+	}
+	return out
 }
 
 // NewResultCommit is a helper to initialize the ResultCommit with
@@ -106,19 +255,60 @@ type SyncInfo struct {
 	TotalSyncedTime time.Duration `json:"total_synced_time,string"`
 	RemainingTime   time.Duration `json:"remaining_time,string"`
 
-	TotalSnapshots      int64         `json:"total_snapshots,string"`
-	ChunkProcessAvgTime time.Duration `json:"chunk_process_avg_time,string"`
-	SnapshotHeight      int64         `json:"snapshot_height,string"`
-	SnapshotChunksCount int64         `json:"snapshot_chunks_count,string"`
-	SnapshotChunksTotal int64         `json:"snapshot_chunks_total,string"`
-	BackFilledBlocks    int64         `json:"backfilled_blocks,string"`
-	BackFillBlocksTotal int64         `json:"backfill_blocks_total,string"`
+	TotalSnapshots        int64         `json:"total_snapshots,string"`
+	ChunkProcessAvgTime   time.Duration `json:"chunk_process_avg_time,string"`
+	SnapshotHeight        int64         `json:"snapshot_height,string"`
+	SnapshotChunksCount   int64         `json:"snapshot_chunks_count,string"`
+	SnapshotChunksTotal   int64         `json:"snapshot_chunks_total,string"`
+	SnapshotChunksFetched int64         `json:"snapshot_chunks_fetched,string"`
+	SnapshotRemainingTime time.Duration `json:"snapshot_remaining_time,string"`
+	BackFilledBlocks      int64         `json:"backfilled_blocks,string"`
+	BackFillBlocksTotal   int64         `json:"backfill_blocks_total,string"`
+
+	// The scores of the peers blocks are requested from, while block syncing
+	BlockSyncPeers []BlockSyncPeerInfo `json:"block_sync_peers,omitempty"`
+}
+
+// Info about a peer blocks are requested from while block syncing: the
+// average time it took to deliver a block, the number of blocks it delivered,
+// and did not have, and the number of blocks pending from it
+type BlockSyncPeerInfo struct {
+	NodeID    types.NodeID  `json:"node_id"`
+	Latency   time.Duration `json:"latency,string"`
+	Delivered int64         `json:"delivered,string"`
+	Failures  int64         `json:"failures,string"`
+	Pending   int32         `json:"pending"`
 }
 
 type ApplicationInfo struct {
 	Version string `json:"version"`
 }
 
+// Info about the halt of consensus on a consistency violation, with one of
+// the codes "invalid_block", "conflicting_commit", "wal" and "internal", or
+// the planned halt with the code "upgrade"
+type HaltInfo struct {
+	Code   string    `json:"code"`
+	Height int64     `json:"height,string"`
+	Round  int32     `json:"round"`
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+}
+
+// Info about the planned halt of consensus once the block at the height, or
+// a block of at least the time, is committed
+type HaltPlanInfo struct {
+	Height int64      `json:"height,string"`
+	Time   *time.Time `json:"time,omitempty"`
+}
+
+// Info about the external address of the node, with the source it was
+// detected from: "config", "upnp", "nat-pmp" or "peers"
+type ExternalAddressInfo struct {
+	Address string `json:"address"`
+	Source  string `json:"source"`
+}
+
 // Info about the node's validator
 type ValidatorInfo struct {
 	Address     bytes.HexBytes
@@ -162,6 +352,45 @@ type ResultStatus struct {
 	SyncInfo        SyncInfo              `json:"sync_info"`
 	ValidatorInfo   ValidatorInfo         `json:"validator_info"`
 	LightClientInfo types.LightClientInfo `json:"light_client_info,omitempty"`
+	ConsensusHalt   *HaltInfo             `json:"consensus_halt,omitempty"`
+	HaltPlan        *HaltPlanInfo         `json:"halt_plan,omitempty"`
+	ExternalAddress *ExternalAddressInfo  `json:"external_address,omitempty"`
+}
+
+// The height, round and step of the last vote or proposal signed by a
+// validator, with step 1 for proposals, 2 for prevotes and 3 for precommits
+type SignState struct {
+	Height int64 `json:"height,string"`
+	Round  int32 `json:"round"`
+	Step   int8  `json:"step"`
+}
+
+// Signing state of the node's validator. Local is the last sign state
+// recorded by the node, and Remote the last sign state reported by its remote
+// signer, if any. Regression is true if the remote signer is behind the node.
+type ResultSigningState struct {
+	Local      SignState  `json:"local"`
+	Remote     *SignState `json:"remote,omitempty"`
+	Regression bool       `json:"regression"`
+}
+
+// A check of the storage auditor that failed at a height, or for the latest
+// state with the "state" check
+type StorageAuditFailure struct {
+	Height int64  `json:"height,string"`
+	Check  string `json:"check"`
+	Error  string `json:"error"`
+}
+
+// Health of the block and state stores found by the last run of the storage
+// auditor, at LastAudit: the audited heights and the failed checks. Enabled is
+// false if the auditor is disabled, and LastAudit is nil until its first run.
+type ResultStorageHealth struct {
+	Enabled        bool                  `json:"enabled"`
+	Healthy        bool                  `json:"healthy"`
+	LastAudit      *time.Time            `json:"last_audit,omitempty"`
+	AuditedHeights []Int64               `json:"audited_heights,omitempty"`
+	Failures       []StorageAuditFailure `json:"failures,omitempty"`
 }
 
 // Is TxIndexing enabled
@@ -192,8 +421,70 @@ type ResultDialPeers struct {
 
 // A peer
 type Peer struct {
-	ID  types.NodeID `json:"node_id"`
-	URL string       `json:"url"`
+	ID              types.NodeID         `json:"node_id"`
+	URL             string               `json:"url"`
+	ChannelVersions []PeerChannelVersion `json:"channel_versions,omitempty"`
+}
+
+// The wire format version of a channel negotiated with a peer
+type PeerChannelVersion struct {
+	ChannelID uint16 `json:"channel_id"`
+	Version   uint32 `json:"version"`
+}
+
+// Peers of the address book, ordered by score
+type ResultAddressBook struct {
+	NPeers int               `json:"n_peers,string"`
+	Peers  []AddressBookPeer `json:"peers"`
+}
+
+// A peer of the address book. MisbehaviorScore is adjusted on good or bad
+// behavior of the peer, Uptime is the total time the peer was connected and
+// Latency the average latency of its dials.
+type AddressBookPeer struct {
+	ID               types.NodeID         `json:"node_id"`
+	Addresses        []AddressBookAddress `json:"addresses"`
+	Score            int                  `json:"score"`
+	MisbehaviorScore int64                `json:"misbehavior_score,string"`
+	Uptime           time.Duration        `json:"uptime,string"`
+	Latency          time.Duration        `json:"latency,string"`
+	LastConnected    time.Time            `json:"last_connected"`
+	Connected        bool                 `json:"connected"`
+	Persistent       bool                 `json:"persistent"`
+	Inactive         bool                 `json:"inactive"`
+}
+
+// An address of a peer of the address book, with its dial statistics
+type AddressBookAddress struct {
+	URL             string    `json:"url"`
+	LastDialSuccess time.Time `json:"last_dial_success"`
+	LastDialFailure time.Time `json:"last_dial_failure"`
+	DialFailures    uint32    `json:"dial_failures"`
+}
+
+// The network topology crawled by the node: the nodes it connected to or
+// heard of, the peers they advertise, and the number of nodes running each
+// software version
+type ResultNetTopology struct {
+	NNodes   int            `json:"n_nodes,string"`
+	NEdges   int            `json:"n_edges,string"`
+	Nodes    []TopologyNode `json:"nodes"`
+	Versions []VersionNodes `json:"versions"`
+}
+
+// A node of the network topology. Version is empty if the node was not
+// connected to.
+type TopologyNode struct {
+	ID       types.NodeID   `json:"node_id"`
+	Version  string         `json:"version,omitempty"`
+	LastSeen time.Time      `json:"last_seen"`
+	Peers    []types.NodeID `json:"peers"`
+}
+
+// The number of nodes of the network topology running a software version
+type VersionNodes struct {
+	Version string `json:"version"`
+	NNodes  int    `json:"n_nodes,string"`
 }
 
 // Validators for a height.
@@ -205,16 +496,53 @@ type ResultValidators struct {
 	Total int `json:"total,string"` // Total number of validators
 }
 
+// Voting power of a validator over a range of heights.
+type ResultValidatorHistory struct {
+	Address    bytes.HexBytes `json:"address"`
+	FromHeight int64          `json:"from_height,string"`
+	ToHeight   int64          `json:"to_height,string"`
+
+	// The power at from_height, followed by each change of the power
+	Powers []ValidatorPower `json:"powers"`
+
+	ActiveHeights int64 `json:"active_heights,string"` // Heights of the range with a non-zero power
+	MinPower      int64 `json:"min_power,string"`
+	MaxPower      int64 `json:"max_power,string"`
+}
+
+// Voting power of a validator from a height on. A power of zero means that
+// the address is not a validator.
+type ValidatorPower struct {
+	Height int64 `json:"height,string"`
+	Power  int64 `json:"power,string"`
+}
+
 // ConsensusParams for given height
 type ResultConsensusParams struct {
 	BlockHeight     int64                 `json:"block_height,string"`
 	ConsensusParams types.ConsensusParams `json:"consensus_params"`
 }
 
+// Consensus params over a range of heights.
+type ResultConsensusParamsHistory struct {
+	FromHeight int64 `json:"from_height,string"`
+	ToHeight   int64 `json:"to_height,string"`
+
+	// The params at from_height, followed by each change of the params
+	Changes []ConsensusParamsChange `json:"changes"`
+}
+
+// Consensus params which apply from a height on.
+type ConsensusParamsChange struct {
+	Height          int64                 `json:"height,string"`
+	ConsensusParams types.ConsensusParams `json:"consensus_params"`
+}
+
 // Info about the consensus state.
 // UNSTABLE
 type ResultDumpConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
+	RoundVotes json.RawMessage `json:"round_votes"`
 	Peers      []PeerStateInfo `json:"peers"`
 }
 
@@ -227,6 +555,9 @@ type PeerStateInfo struct {
 // UNSTABLE
 type ResultConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
+	// The votes of each validator in the current round, with the addresses
+	// of the validators whose votes are missing
+	RoundVotes json.RawMessage `json:"round_votes"`
 }
 
 // CheckTx result
@@ -250,6 +581,14 @@ type ResultCheckTx struct {
 	abci.ResponseCheckTx
 }
 
+// Result of simulating a tx: the response of CheckTx, and the response of the
+// application to the simulation query, if one is configured and the tx passed
+// CheckTx.
+type ResultSimulateTx struct {
+	CheckTx    abci.ResponseCheckTx `json:"check_tx"`
+	Simulation *abci.ResponseQuery  `json:"simulation,omitempty"`
+}
+
 // Result of querying for a tx
 type ResultTx struct {
 	Hash     bytes.HexBytes    `json:"hash"`
@@ -260,16 +599,67 @@ type ResultTx struct {
 	Proof    types.TxProof     `json:"proof,omitempty"`
 }
 
+// DecodeEvents returns the values decoded from the events of the transaction
+// result whose event types have a Go type registered with the types/events
+// package.
+func (r *ResultTx) DecodeEvents() ([]interface{}, error) {
+	return events.DecodeAll(r.TxResult.Events)
+}
+
+// The statuses of a transaction reported by tx_status.
+const (
+	TxStatusNotFound  = "not_found" // neither known to the mempool nor committed
+	TxStatusMempool   = "mempool"   // in the mempool, see TxMempoolStatus
+	TxStatusEvicted   = "evicted"   // evicted from the mempool, see TxEvictedStatus
+	TxStatusCommitted = "committed" // committed, see TxCommittedStatus
+)
+
+// ResultTxStatus is the status of a transaction in its lifecycle, with the
+// details of that status.
+type ResultTxStatus struct {
+	Hash      bytes.HexBytes     `json:"hash"`
+	Status    string             `json:"status"`
+	Mempool   *TxMempoolStatus   `json:"mempool,omitempty"`
+	Evicted   *TxEvictedStatus   `json:"evicted,omitempty"`
+	Committed *TxCommittedStatus `json:"committed,omitempty"`
+}
+
+// TxMempoolStatus is the status of a transaction in the mempool, at Position
+// in the order in which transactions are included in blocks, since Time.
+type TxMempoolStatus struct {
+	Position int       `json:"position"`
+	Time     time.Time `json:"time"`
+	Height   int64     `json:"height,string"`
+}
+
+// TxEvictedStatus is the status of a transaction evicted from the mempool,
+// at Time for Reason, e.g. "ttl-duration", "low-priority", "sender-quota" or "invalid".
+type TxEvictedStatus struct {
+	Reason string    `json:"reason"`
+	Time   time.Time `json:"time"`
+	Height int64     `json:"height,string"`
+}
+
+// TxCommittedStatus is the status of a transaction committed in the block
+// at Height, with the result code of its execution.
+type TxCommittedStatus struct {
+	Height int64  `json:"height,string"`
+	Index  uint32 `json:"index"`
+	Code   uint32 `json:"code"`
+}
+
 // Result of searching for txs
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
 	TotalCount int         `json:"total_count,string"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
 
 // ResultBlockSearch defines the RPC response type for a block search by events.
 type ResultBlockSearch struct {
 	Blocks     []*ResultBlock `json:"blocks"`
 	TotalCount int            `json:"total_count,string"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 // List of mempool txs
@@ -295,26 +685,90 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// List of pending evidence
+type ResultEvidence struct {
+	Count    int                `json:"n_evidence,string"`
+	Total    int                `json:"total,string"`
+	Evidence types.EvidenceList `json:"evidence"`
+}
+
+// Result of setting the planned halt
+type ResultSetHalt struct {
+	HaltPlan HaltPlanInfo `json:"halt_plan"`
+}
+
+// Result of reloading the config file
+type ResultReloadConfig struct {
+	// The changed settings applied without a restart.
+	Applied []string `json:"applied"`
+	// The changed settings which only take effect after a restart.
+	RequireRestart []string `json:"require_restart"`
+}
+
 // empty results
 type (
-	ResultUnsafeFlushMempool struct{}
-	ResultUnsafeProfile      struct{}
-	ResultSubscribe          struct{}
-	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
+	ResultUnsafeFlushMempool     struct{}
+	ResultUnsafeReloadPeerFilter struct{}
+	ResultUnsafeProfile          struct{}
+	ResultSubscribe              struct{}
+	ResultUnsubscribe            struct{}
 )
 
+// The health statuses of the node
+const (
+	HealthOK        = "ok"
+	HealthDegraded  = "degraded"
+	HealthUnhealthy = "unhealthy"
+)
+
+// The codes of the reasons why the node is degraded, or unhealthy for
+// HealthReasonConsensusHalted and HealthReasonABCIUnresponsive
+const (
+	HealthReasonCatchingUp       = "catching_up"
+	HealthReasonStateSync        = "state_sync"
+	HealthReasonConsensusStalled = "consensus_stalled"
+	HealthReasonConsensusHalted  = "consensus_halted"
+	HealthReasonABCIUnresponsive = "abci_unresponsive"
+	HealthReasonStorageCorrupted = "storage_corrupted"
+)
+
+// A reason why the node is not healthy, with one of the HealthReason codes
+type HealthReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Health of the node: "ok", or "degraded" or "unhealthy" with the reasons
+type ResultHealth struct {
+	Status  string         `json:"status"`
+	Reasons []HealthReason `json:"reasons,omitempty"`
+}
+
+// Readiness of the node to serve requests, with the reasons it is not ready
+type ResultReady struct {
+	Ready   bool           `json:"ready"`
+	Reasons []HealthReason `json:"reasons,omitempty"`
+}
+
 // Event data from a subscription
 type ResultEvent struct {
 	SubscriptionID string
 	Query          string
+	Seq            int64 // assigned by the node, see RequestSubscribe.AfterSeq
 	Data           types.EventData
 	Events         []abci.Event
 }
 
+// DecodeEvents returns the values decoded from the events whose event types
+// have a Go type registered with the types/events package.
+func (r ResultEvent) DecodeEvents() ([]interface{}, error) {
+	return events.DecodeAll(r.Events)
+}
+
 type resultEventJSON struct {
 	SubscriptionID string          `json:"subscription_id"`
 	Query          string          `json:"query"`
+	Seq            int64           `json:"seq,string,omitempty"`
 	Data           json.RawMessage `json:"data"`
 	Events         []abci.Event    `json:"events"`
 }
@@ -327,6 +781,7 @@ func (r ResultEvent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(resultEventJSON{
 		SubscriptionID: r.SubscriptionID,
 		Query:          r.Query,
+		Seq:            r.Seq,
 		Data:           evt,
 		Events:         r.Events,
 	})
@@ -342,6 +797,7 @@ func (r *ResultEvent) UnmarshalJSON(data []byte) error {
 	}
 	r.SubscriptionID = res.SubscriptionID
 	r.Query = res.Query
+	r.Seq = res.Seq
 	r.Events = res.Events
 	return nil
 }
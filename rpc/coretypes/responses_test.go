@@ -14,6 +14,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	pbcrypto "github.com/tendermint/tendermint/proto/tendermint/crypto"
 	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tendermint/types/events"
 )
 
 func TestStatusIndexer(t *testing.T) {
@@ -91,3 +92,55 @@ func TestResultBlockResults_regression8583(t *testing.T) {
 		t.Errorf("Unmarshaled result (-want, +got):\n%s", diff)
 	}
 }
+
+func TestBlockEventsJSON(t *testing.T) {
+	events := NewBlockEvents(
+		[]abci.Event{{Type: "fees", Attributes: []abci.EventAttribute{{Key: "amount", Value: "1", Index: true}}}},
+		[]*abci.ExecTxResult{
+			{Events: []abci.Event{{Type: "transfer", Attributes: []abci.EventAttribute{{Key: "memo", Value: "\xff"}}}}},
+			{},
+		},
+	)
+	bz, err := json.Marshal(events)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"finalize_block": [{"type": "fees", "attributes": [
+			{"key": "amount", "value": "1", "key_raw": "YW1vdW50", "value_raw": "MQ==", "index": true}
+		]}],
+		"txs": [
+			[{"type": "transfer", "attributes": [
+				{"key": "memo", "value": "�", "key_raw": "bWVtbw==", "value_raw": "/w==", "index": false}
+			]}],
+			[]
+		]
+	}`, string(bz))
+}
+
+func TestResultBlockResultsDecodeTxEvents(t *testing.T) {
+	type transfer struct {
+		Sender string `event:"sender"`
+		Amount int64  `event:"amount"`
+	}
+	events.MustRegister("test_transfer", transfer{})
+	res := &ResultBlockResults{
+		TxsResults: []*abci.ExecTxResult{{Events: []abci.Event{
+			{Type: "test_transfer", Attributes: []abci.EventAttribute{
+				{Key: "sender", Value: "alice"},
+				{Key: "amount", Value: "5"},
+			}},
+			{Type: "other"},
+		}}},
+	}
+
+	values, err := res.DecodeTxEvents(0)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{&transfer{Sender: "alice", Amount: 5}}, values)
+
+	values, err = res.DecodeEvents()
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	_, err = res.DecodeTxEvents(1)
+	require.Error(t, err)
+}
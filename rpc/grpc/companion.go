@@ -0,0 +1,213 @@
+package coregrpc
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/log"
+	grpcproto "github.com/tendermint/tendermint/proto/tendermint/rpc/grpc"
+	"github.com/tendermint/tendermint/types"
+)
+
+// DataCompanionServer implements the data companion services, which let an
+// external process fetch the blocks and block results of the node, and
+// control how far the node prunes them.
+type DataCompanionServer struct {
+	logger     log.Logger
+	blockStore sm.BlockStore
+	stateStore sm.Store
+	eventBus   EventBus
+	bufferSize int
+
+	nextID uint64 // atomic; used to assign subscriber IDs
+}
+
+// NewDataCompanionServer constructs a server for the blocks and block results
+// of the given stores, streaming the new blocks published to eventBus.
+func NewDataCompanionServer(
+	logger log.Logger,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	eventBus EventBus,
+) *DataCompanionServer {
+	return &DataCompanionServer{
+		logger:     logger,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		eventBus:   eventBus,
+		bufferSize: DefaultBufferSize,
+	}
+}
+
+var (
+	_ grpcproto.BlockServiceServer        = (*DataCompanionServer)(nil)
+	_ grpcproto.BlockResultsServiceServer = (*DataCompanionServer)(nil)
+	_ grpcproto.PruningServiceServer      = (*DataCompanionServer)(nil)
+)
+
+// height returns the requested height, or the latest height for 0. It returns
+// NotFound if the block store does not have the height.
+func (s *DataCompanionServer) height(height int64) (int64, error) {
+	base, latest := s.blockStore.Base(), s.blockStore.Height()
+	if height == 0 {
+		height = latest
+	}
+	if height < base || height > latest || height <= 0 {
+		return 0, status.Errorf(codes.NotFound, "height %d is not available, the node has heights %d to %d",
+			height, base, latest)
+	}
+	return height, nil
+}
+
+// GetBlock returns the block at the requested height.
+func (s *DataCompanionServer) GetBlock(_ context.Context, req *grpcproto.RequestGetBlock) (*grpcproto.ResponseGetBlock, error) {
+	height, err := s.height(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	block := s.blockStore.LoadBlock(height)
+	meta := s.blockStore.LoadBlockMeta(height)
+	if block == nil || meta == nil {
+		return nil, status.Errorf(codes.NotFound, "block %d not found", height)
+	}
+	return blockResponse(meta.BlockID, block)
+}
+
+func blockResponse(blockID types.BlockID, block *types.Block) (*grpcproto.ResponseGetBlock, error) {
+	pb, err := block.ToProto()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encoding block: %v", err)
+	}
+	pbID := blockID.ToProto()
+	return &grpcproto.ResponseGetBlock{BlockId: &pbID, Block: pb}, nil
+}
+
+// NewBlocks streams the blocks committed by the node, until the client
+// cancels the call. A client that falls more than DefaultBufferSize blocks
+// behind is disconnected with ResourceExhausted.
+func (s *DataCompanionServer) NewBlocks(_ *grpcproto.RequestNewBlocks, stream grpcproto.BlockService_NewBlocksServer) error {
+	clientID := fmt.Sprintf("grpc-new-blocks-%d", atomic.AddUint64(&s.nextID, 1))
+	return subscribe(stream.Context(), s.logger, s.eventBus, clientID, types.EventQueryNewBlock, s.bufferSize,
+		func(data types.EventData) error {
+			d, ok := data.(types.EventDataNewBlock)
+			if !ok {
+				return nil
+			}
+			resp, err := blockResponse(d.BlockID, d.Block)
+			if err != nil {
+				return err
+			}
+			return stream.Send(resp)
+		})
+}
+
+// GetBlockResults returns the results of the block at the requested height.
+func (s *DataCompanionServer) GetBlockResults(
+	_ context.Context,
+	req *grpcproto.RequestGetBlockResults,
+) (*grpcproto.ResponseGetBlockResults, error) {
+	height, err := s.height(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.stateStore.LoadFinalizeBlockResponses(height)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "results of block %d not found: %v", height, err)
+	}
+	return &grpcproto.ResponseGetBlockResults{
+		Height:                height,
+		TxResults:             res.TxResults,
+		FinalizeBlockEvents:   res.Events,
+		ValidatorUpdates:      res.ValidatorUpdates,
+		ConsensusParamUpdates: res.ConsensusParamUpdates,
+		AppHash:               res.AppHash,
+	}, nil
+}
+
+// SetRetainHeight sets the block retain height of the data companion, which
+// must not be above the latest height of the node.
+func (s *DataCompanionServer) SetRetainHeight(
+	_ context.Context,
+	req *grpcproto.RequestSetRetainHeight,
+) (*grpcproto.ResponseSetRetainHeight, error) {
+	if err := s.setRetainHeight(req.Height, s.stateStore.SaveCompanionRetainHeight); err != nil {
+		return nil, err
+	}
+	s.logger.Info("data companion set retain height", "height", req.Height)
+	return &grpcproto.ResponseSetRetainHeight{}, nil
+}
+
+// GetRetainHeight returns the block retain height of the data companion.
+func (s *DataCompanionServer) GetRetainHeight(
+	_ context.Context,
+	_ *grpcproto.RequestGetRetainHeight,
+) (*grpcproto.ResponseGetRetainHeight, error) {
+	companionHeight, appHeight, err := s.retainHeights(s.stateStore.LoadCompanionRetainHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcproto.ResponseGetRetainHeight{
+		CompanionRetainHeight: companionHeight,
+		BaseHeight:            s.blockStore.Base(),
+		AppRetainHeight:       appHeight,
+	}, nil
+}
+
+// SetBlockResultsRetainHeight sets the block results retain height of the
+// data companion, which must not be above the latest height of the node.
+func (s *DataCompanionServer) SetBlockResultsRetainHeight(
+	_ context.Context,
+	req *grpcproto.RequestSetBlockResultsRetainHeight,
+) (*grpcproto.ResponseSetBlockResultsRetainHeight, error) {
+	if err := s.setRetainHeight(req.Height, s.stateStore.SaveCompanionResultsRetainHeight); err != nil {
+		return nil, err
+	}
+	s.logger.Info("data companion set block results retain height", "height", req.Height)
+	return &grpcproto.ResponseSetBlockResultsRetainHeight{}, nil
+}
+
+// GetBlockResultsRetainHeight returns the block results retain height of the
+// data companion.
+func (s *DataCompanionServer) GetBlockResultsRetainHeight(
+	_ context.Context,
+	_ *grpcproto.RequestGetBlockResultsRetainHeight,
+) (*grpcproto.ResponseGetBlockResultsRetainHeight, error) {
+	companionHeight, appHeight, err := s.retainHeights(s.stateStore.LoadCompanionResultsRetainHeight)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcproto.ResponseGetBlockResultsRetainHeight{
+		CompanionRetainHeight: companionHeight,
+		AppRetainHeight:       appHeight,
+	}, nil
+}
+
+func (s *DataCompanionServer) setRetainHeight(height int64, save func(int64) error) error {
+	if latest := s.blockStore.Height(); height <= 0 || height > latest {
+		return status.Errorf(codes.InvalidArgument, "retain height %d must be between 1 and the latest height %d",
+			height, latest)
+	}
+	if err := save(height); err != nil {
+		return status.Errorf(codes.Internal, "saving retain height: %v", err)
+	}
+	return nil
+}
+
+// retainHeights returns the retain height of the data companion loaded by
+// load, and the retain height of the application.
+func (s *DataCompanionServer) retainHeights(load func() (int64, error)) (int64, int64, error) {
+	companionHeight, err := load()
+	if err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "loading retain height: %v", err)
+	}
+	appHeight, err := s.stateStore.LoadApplicationRetainHeight()
+	if err != nil {
+		return 0, 0, status.Errorf(codes.Internal, "loading application retain height: %v", err)
+	}
+	return companionHeight, appHeight, nil
+}
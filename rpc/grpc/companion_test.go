@@ -0,0 +1,189 @@
+package coregrpc_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	sm "github.com/tendermint/tendermint/internal/state"
+	smmocks "github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/libs/log"
+	grpcproto "github.com/tendermint/tendermint/proto/tendermint/rpc/grpc"
+	coregrpc "github.com/tendermint/tendermint/rpc/grpc"
+	"github.com/tendermint/tendermint/types"
+)
+
+type companionTestSuite struct {
+	eventBus   *eventbus.EventBus
+	blockStore *smmocks.BlockStore
+	stateStore sm.Store
+	conn       *grpc.ClientConn
+}
+
+// startCompanion starts a server with the data companion services, for a node
+// with the blocks 3 to 5.
+func startCompanion(ctx context.Context, t *testing.T) *companionTestSuite {
+	t.Helper()
+
+	logger := log.NewTestingLogger(t)
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	blockStore := &smmocks.BlockStore{}
+	blockStore.On("Base").Return(int64(3))
+	blockStore.On("Height").Return(int64(5))
+	for height := int64(3); height <= 5; height++ {
+		block := types.MakeBlock(height, []types.Tx{types.Tx("tx")}, &types.Commit{}, nil)
+		blockStore.On("LoadBlock", height).Return(block)
+		blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{BlockID: types.BlockID{Hash: block.Hash()}})
+	}
+	stateStore := sm.NewStore(dbm.NewMemDB())
+
+	listener := bufconn.Listen(1024 * 1024)
+	srv := coregrpc.NewEventStreamServer(logger, eventBus)
+	companion := coregrpc.NewDataCompanionServer(logger, blockStore, stateStore, eventBus)
+	go func() { assert.NoError(t, coregrpc.Serve(ctx, listener, srv, coregrpc.WithDataCompanion(companion))) }()
+
+	return &companionTestSuite{
+		eventBus:   eventBus,
+		blockStore: blockStore,
+		stateStore: stateStore,
+		conn:       dial(ctx, t, listener),
+	}
+}
+
+func TestDataCompanion_GetBlock(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := startCompanion(ctx, t)
+	client := grpcproto.NewBlockServiceClient(s.conn)
+
+	resp, err := client.GetBlock(ctx, &grpcproto.RequestGetBlock{Height: 4})
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, resp.Block.Header.Height)
+	assert.Equal(t, []byte(s.blockStore.LoadBlock(4).Hash()), resp.BlockId.Hash)
+
+	resp, err = client.GetBlock(ctx, &grpcproto.RequestGetBlock{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, resp.Block.Header.Height, "height 0 is the latest block")
+
+	for _, height := range []int64{2, 6, -1} {
+		_, err = client.GetBlock(ctx, &grpcproto.RequestGetBlock{Height: height})
+		assert.Equal(t, codes.NotFound, status.Code(err), "height %d", height)
+	}
+}
+
+func TestDataCompanion_NewBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := startCompanion(ctx, t)
+	client := grpcproto.NewBlockServiceClient(s.conn)
+
+	stream, err := client.NewBlocks(ctx, &grpcproto.RequestNewBlocks{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return s.eventBus.NumClients() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	block := types.MakeBlock(6, []types.Tx{types.Tx("tx")}, &types.Commit{}, nil)
+	require.NoError(t, s.eventBus.PublishEventNewBlock(types.EventDataNewBlock{
+		Block:   block,
+		BlockID: types.BlockID{Hash: block.Hash()},
+	}))
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, resp.Block.Header.Height)
+	assert.Equal(t, []byte(block.Hash()), resp.BlockId.Hash)
+}
+
+func TestDataCompanion_GetBlockResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := startCompanion(ctx, t)
+	client := grpcproto.NewBlockResultsServiceClient(s.conn)
+
+	require.NoError(t, s.stateStore.SaveFinalizeBlockResponses(4, &abci.ResponseFinalizeBlock{
+		TxResults: []*abci.ExecTxResult{{Code: 1, Log: "failed", Events: transfer("alice")}},
+		Events:    transfer("bob"),
+		AppHash:   []byte("app hash"),
+	}))
+
+	resp, err := client.GetBlockResults(ctx, &grpcproto.RequestGetBlockResults{Height: 4})
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, resp.Height)
+	require.Len(t, resp.TxResults, 1)
+	assert.EqualValues(t, 1, resp.TxResults[0].Code)
+	assert.Equal(t, transfer("bob"), resp.FinalizeBlockEvents)
+	assert.Equal(t, []byte("app hash"), resp.AppHash)
+
+	_, err = client.GetBlockResults(ctx, &grpcproto.RequestGetBlockResults{Height: 3})
+	assert.Equal(t, codes.NotFound, status.Code(err), "no results saved")
+	_, err = client.GetBlockResults(ctx, &grpcproto.RequestGetBlockResults{Height: 6})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestDataCompanion_RetainHeight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := startCompanion(ctx, t)
+	client := grpcproto.NewPruningServiceClient(s.conn)
+
+	resp, err := client.GetRetainHeight(ctx, &grpcproto.RequestGetRetainHeight{})
+	require.NoError(t, err)
+	assert.Zero(t, resp.CompanionRetainHeight)
+	assert.EqualValues(t, 3, resp.BaseHeight)
+
+	_, err = client.SetRetainHeight(ctx, &grpcproto.RequestSetRetainHeight{Height: 4})
+	require.NoError(t, err)
+	resp, err = client.GetRetainHeight(ctx, &grpcproto.RequestGetRetainHeight{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, resp.CompanionRetainHeight)
+
+	for _, height := range []int64{0, 6} {
+		_, err = client.SetRetainHeight(ctx, &grpcproto.RequestSetRetainHeight{Height: height})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "height %d", height)
+	}
+
+	require.NoError(t, s.stateStore.SaveApplicationRetainHeight(5))
+	resp, err = client.GetRetainHeight(ctx, &grpcproto.RequestGetRetainHeight{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, resp.AppRetainHeight)
+}
+
+func TestDataCompanion_BlockResultsRetainHeight(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := startCompanion(ctx, t)
+	client := grpcproto.NewPruningServiceClient(s.conn)
+	require.NoError(t, s.stateStore.SaveApplicationRetainHeight(5))
+
+	_, err := client.SetBlockResultsRetainHeight(ctx, &grpcproto.RequestSetBlockResultsRetainHeight{Height: 3})
+	require.NoError(t, err)
+	resp, err := client.GetBlockResultsRetainHeight(ctx, &grpcproto.RequestGetBlockResultsRetainHeight{})
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, resp.CompanionRetainHeight)
+	assert.EqualValues(t, 5, resp.AppRetainHeight)
+
+	blockResp, err := client.GetRetainHeight(ctx, &grpcproto.RequestGetRetainHeight{})
+	require.NoError(t, err)
+	assert.Zero(t, blockResp.CompanionRetainHeight, "block retain height is set separately")
+
+	for _, height := range []int64{0, 6} {
+		_, err = client.SetBlockResultsRetainHeight(ctx, &grpcproto.RequestSetBlockResultsRetainHeight{Height: height})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "height %d", height)
+	}
+}
@@ -0,0 +1,175 @@
+// Package coregrpc implements a gRPC service that streams the transactions
+// and block events committed by a node, as an alternative to polling
+// tx_search or subscribing over the WebSocket RPC. With the data companion
+// services enabled, it also serves the blocks and block results of the node,
+// and lets an external data companion hold back their pruning.
+package coregrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tendermint/tendermint/internal/eventbus"
+	tmpubsub "github.com/tendermint/tendermint/internal/pubsub"
+	"github.com/tendermint/tendermint/internal/pubsub/query"
+	"github.com/tendermint/tendermint/libs/log"
+	grpcproto "github.com/tendermint/tendermint/proto/tendermint/rpc/grpc"
+	"github.com/tendermint/tendermint/types"
+)
+
+// DefaultBufferSize is the number of events buffered for each stream before a
+// client that does not keep up is disconnected.
+const DefaultBufferSize = 1000
+
+// EventBus is the subset of the node's event bus used by the server.
+type EventBus interface {
+	SubscribeWithArgs(context.Context, tmpubsub.SubscribeArgs) (eventbus.Subscription, error)
+	UnsubscribeAll(context.Context, string) error
+}
+
+// EventStreamServer implements EventStreamAPIServer (generated via protobuf
+// services) on top of the node's event bus.
+type EventStreamServer struct {
+	logger     log.Logger
+	eventBus   EventBus
+	bufferSize int
+
+	nextID uint64 // atomic; used to assign subscriber IDs
+}
+
+// NewEventStreamServer constructs a server that streams events published to
+// eventBus.
+func NewEventStreamServer(logger log.Logger, eventBus EventBus) *EventStreamServer {
+	return &EventStreamServer{
+		logger:     logger,
+		eventBus:   eventBus,
+		bufferSize: DefaultBufferSize,
+	}
+}
+
+var _ grpcproto.EventStreamAPIServer = (*EventStreamServer)(nil)
+
+// StreamEvents sends every committed transaction and block whose events match
+// the requested query, until the client cancels the call. A client that falls
+// more than DefaultBufferSize events behind is disconnected with
+// ResourceExhausted.
+func (s *EventStreamServer) StreamEvents(req *grpcproto.RequestStreamEvents, stream grpcproto.EventStreamAPI_StreamEventsServer) error {
+	q := query.All
+	if req.Query != "" {
+		var err error
+		if q, err = query.New(req.Query); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+		}
+	}
+
+	clientID := fmt.Sprintf("grpc-event-stream-%d", atomic.AddUint64(&s.nextID, 1))
+	return subscribe(stream.Context(), s.logger, s.eventBus, clientID, q, s.bufferSize, func(data types.EventData) error {
+		if resp := responseFor(data); resp != nil {
+			return stream.Send(resp)
+		}
+		return nil
+	})
+}
+
+// subscribe subscribes to the events matching q and passes their data to send,
+// until ctx ends or send fails. A client that falls more than limit events
+// behind is disconnected with ResourceExhausted.
+func subscribe(
+	ctx context.Context,
+	logger log.Logger,
+	eventBus EventBus,
+	clientID string,
+	q *query.Query,
+	limit int,
+	send func(types.EventData) error,
+) error {
+	sub, err := eventBus.SubscribeWithArgs(ctx, tmpubsub.SubscribeArgs{
+		ClientID: clientID,
+		Query:    q,
+		Limit:    limit,
+	})
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "subscribing to events: %v", err)
+	}
+	// N.B. Use background for unsubscribe, ctx may already be terminated.
+	defer eventBus.UnsubscribeAll(context.Background(), clientID) // nolint:errcheck
+
+	logger.Debug("Event stream opened", "subscriber", clientID, "query", q)
+	for {
+		msg, err := sub.Next(ctx)
+		switch {
+		case err == nil:
+		case ctx.Err() != nil:
+			return status.FromContextError(ctx.Err()).Err()
+		case errors.Is(err, tmpubsub.ErrTerminated):
+			return status.Error(codes.ResourceExhausted, "client is not pulling events fast enough")
+		default:
+			return status.Errorf(codes.Unavailable, "event subscription terminated: %v", err)
+		}
+
+		if err := send(msg.Data()); err != nil {
+			return err
+		}
+	}
+}
+
+// responseFor converts the data of an event bus message to a stream response,
+// or returns nil for events that are not streamed.
+func responseFor(data types.EventData) *grpcproto.ResponseStreamEvents {
+	switch d := data.(type) {
+	case types.EventDataTx:
+		tx := d.TxResult
+		return &grpcproto.ResponseStreamEvents{
+			Value: &grpcproto.ResponseStreamEvents_Tx{Tx: &tx},
+		}
+	case types.EventDataNewBlockHeader:
+		return &grpcproto.ResponseStreamEvents{
+			Value: &grpcproto.ResponseStreamEvents_Block{Block: &grpcproto.BlockEvents{
+				Height: d.Header.Height,
+				Events: d.ResultFinalizeBlock.Events,
+			}},
+		}
+	}
+	return nil
+}
+
+// ServeOption sets an optional parameter on the gRPC server.
+type ServeOption func(*grpc.Server)
+
+// WithDataCompanion also serves the data companion services, BlockService,
+// BlockResultsService and PruningService, with the given server.
+func WithDataCompanion(srv *DataCompanionServer) ServeOption {
+	return func(grpcServer *grpc.Server) {
+		grpcproto.RegisterBlockServiceServer(grpcServer, srv)
+		grpcproto.RegisterBlockResultsServiceServer(grpcServer, srv)
+		grpcproto.RegisterPruningServiceServer(grpcServer, srv)
+	}
+}
+
+// Serve serves the event stream API on listener until ctx ends, at which point
+// the gRPC server is stopped.
+func Serve(ctx context.Context, listener net.Listener, srv grpcproto.EventStreamAPIServer, options ...ServeOption) error {
+	grpcServer := grpc.NewServer()
+	grpcproto.RegisterEventStreamAPIServer(grpcServer, srv)
+	for _, opt := range options {
+		opt(grpcServer)
+	}
+
+	go func() {
+		<-ctx.Done()
+		grpcServer.Stop()
+	}()
+
+	err := grpcServer.Serve(listener)
+	if err != nil && ctx.Err() == nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package coregrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/eventbus"
+	"github.com/tendermint/tendermint/libs/log"
+	grpcproto "github.com/tendermint/tendermint/proto/tendermint/rpc/grpc"
+	coregrpc "github.com/tendermint/tendermint/rpc/grpc"
+	"github.com/tendermint/tendermint/types"
+)
+
+func startServer(ctx context.Context, t *testing.T) (*eventbus.EventBus, grpcproto.EventStreamAPIClient) {
+	t.Helper()
+
+	logger := log.NewTestingLogger(t)
+	eventBus := eventbus.NewDefault(logger)
+	require.NoError(t, eventBus.Start(ctx))
+
+	listener := bufconn.Listen(1024 * 1024)
+	srv := coregrpc.NewEventStreamServer(logger, eventBus)
+	go func() { assert.NoError(t, coregrpc.Serve(ctx, listener, srv)) }()
+
+	return eventBus, grpcproto.NewEventStreamAPIClient(dial(ctx, t, listener))
+}
+
+func dial(ctx context.Context, t *testing.T, listener *bufconn.Listener) *grpc.ClientConn {
+	t.Helper()
+
+	conn, err := grpc.DialContext(ctx, "",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func transfer(sender string) []abci.Event {
+	return []abci.Event{{
+		Type:       "transfer",
+		Attributes: []abci.EventAttribute{{Key: "sender", Value: sender, Index: true}},
+	}}
+}
+
+func TestStreamEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventBus, client := startServer(ctx, t)
+
+	stream, err := client.StreamEvents(ctx, &grpcproto.RequestStreamEvents{Query: "transfer.sender = 'alice'"})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return eventBus.NumClients() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	for i, sender := range []string{"bob", "alice"} {
+		require.NoError(t, eventBus.PublishEventTx(types.EventDataTx{TxResult: abci.TxResult{
+			Height: 5,
+			Index:  uint32(i),
+			Tx:     types.Tx(sender),
+			Result: abci.ExecTxResult{Events: transfer(sender)},
+		}}))
+	}
+	require.NoError(t, eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header:              types.Header{Height: 5},
+		ResultFinalizeBlock: abci.ResponseFinalizeBlock{Events: transfer("alice")},
+	}))
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	tx := resp.GetTx()
+	require.NotNil(t, tx, "expected a transaction, got %v", resp)
+	assert.EqualValues(t, 5, tx.Height)
+	assert.EqualValues(t, 1, tx.Index)
+	assert.Equal(t, []byte("alice"), tx.Tx)
+
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	block := resp.GetBlock()
+	require.NotNil(t, block, "expected a block, got %v", resp)
+	assert.EqualValues(t, 5, block.Height)
+	assert.Equal(t, transfer("alice"), block.Events)
+}
+
+func TestStreamEventsInvalidQuery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventBus, client := startServer(ctx, t)
+
+	stream, err := client.StreamEvents(ctx, &grpcproto.RequestStreamEvents{Query: "tx.height >"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Zero(t, eventBus.NumClients())
+}
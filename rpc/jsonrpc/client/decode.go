@@ -33,6 +33,12 @@ func unmarshalResponseBytes(responseBytes []byte, expectedID string, result inte
 func unmarshalResponseBytesArray(responseBytes []byte, expectedIDs []string, results []interface{}) error {
 	var responses []rpctypes.RPCResponse
 	if err := json.Unmarshal(responseBytes, &responses); err != nil {
+		// A batch rejected as a whole, e.g. for exceeding the maximum batch
+		// size of the server, gets a single error response.
+		var response rpctypes.RPCResponse
+		if json.Unmarshal(responseBytes, &response) == nil && response.Error != nil {
+			return response.Error
+		}
 		return fmt.Errorf("unmarshaling responses: %w", err)
 	} else if len(responses) != len(results) {
 		return fmt.Errorf("got %d results, wanted %d", len(responses), len(results))
@@ -48,6 +54,9 @@ func unmarshalResponseBytesArray(responseBytes []byte, expectedIDs []string, res
 	}
 
 	for i, resp := range responses {
+		if resp.Error != nil {
+			return fmt.Errorf("request %d: %w", i, resp.Error)
+		}
 		if err := json.Unmarshal(resp.Result, results[i]); err != nil {
 			return fmt.Errorf("unmarshaling result %d: %w", i, err)
 		}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireBearerToken(t *testing.T) {
+	handler := RequireBearerToken("secret", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for auth, want := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"secret":        http.StatusUnauthorized,
+		"Basic secret":  http.StatusUnauthorized,
+		"Bearer other":  http.StatusUnauthorized,
+		"Bearer secre":  http.StatusUnauthorized,
+		"Bearer secret": http.StatusNoContent,
+		"bearer secret": http.StatusNoContent,
+	} {
+		req := httptest.NewRequest("GET", "/status", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, want, rec.Code, "%q", auth)
+		if want == http.StatusUnauthorized {
+			assert.Equal(t, "Bearer", rec.Header().Get("WWW-Authenticate"))
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// minCompressSize is the minimum size of a response body to compress, below
+// which compression saves little if any bandwidth.
+const minCompressSize = 1024
+
+const (
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+)
+
+var (
+	gzipPool = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+	zstdPool = sync.Pool{New: func() interface{} {
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			panic(err) // only fails for invalid options
+		}
+		return enc
+	}}
+)
+
+// compressHandler wraps next to compress the bodies of its responses with the
+// zstd or gzip encoding, if the client accepts either of them.
+func compressHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := acceptedEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.close()
+		next(cw, r)
+	}
+}
+
+// acceptedEncoding returns the preferred encoding of the ones accepted by the
+// Accept-Encoding header, or "" if neither zstd nor gzip is accepted.
+func acceptedEncoding(header string) string {
+	var gzipOK bool
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if refused(fields[1:]) {
+			continue
+		}
+		switch name {
+		case encodingZstd:
+			return encodingZstd
+		case encodingGzip:
+			gzipOK = true
+		}
+	}
+	if gzipOK {
+		return encodingGzip
+	}
+	return ""
+}
+
+// refused reports whether the parameters of an encoding of an Accept-Encoding
+// header refuse it, with a zero quality value.
+func refused(params []string) bool {
+	for _, param := range params {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) == 2 && strings.TrimSpace(kv[0]) == "q" {
+			q, err := strconv.ParseFloat(strings.TrimSpace(kv[1]), 64)
+			return err == nil && q == 0
+		}
+	}
+	return false
+}
+
+// compressWriter is an http.ResponseWriter compressing the response body with
+// its encoding, unless the response is not a success or its body is smaller
+// than minCompressSize. The decision is made on the first write, as the
+// handlers of this package write the whole body at once.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+
+	status  int
+	started bool
+	enc     io.WriteCloser
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start(len(p))
+	}
+	if w.enc != nil {
+		return w.enc.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// start writes the header of the response, and sets up the encoder if the
+// first write of size n should be compressed.
+func (w *compressWriter) start(n int) {
+	w.started = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	h := w.Header()
+	if w.status == http.StatusOK && n >= minCompressSize && h.Get("Content-Encoding") == "" {
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		switch w.encoding {
+		case encodingZstd:
+			enc := zstdPool.Get().(*zstd.Encoder)
+			enc.Reset(w.ResponseWriter)
+			w.enc = enc
+		case encodingGzip:
+			enc := gzipPool.Get().(*gzip.Writer)
+			enc.Reset(w.ResponseWriter)
+			w.enc = enc
+		}
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// close writes the header of a response without a body, or flushes the
+// compressed body and returns the encoder to its pool.
+func (w *compressWriter) close() {
+	if !w.started {
+		if w.status != 0 {
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+		return
+	}
+	if w.enc == nil {
+		return
+	}
+	_ = w.enc.Close()
+	switch enc := w.enc.(type) {
+	case *zstd.Encoder:
+		enc.Reset(nil)
+		zstdPool.Put(enc)
+	case *gzip.Writer:
+		enc.Reset(nil)
+		gzipPool.Put(enc)
+	}
+	w.enc = nil
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestAcceptedEncoding(t *testing.T) {
+	for header, want := range map[string]string{
+		"":                         "",
+		"identity":                 "",
+		"gzip":                     encodingGzip,
+		"gzip, deflate, br":        encodingGzip,
+		"gzip;q=0.5, zstd":         encodingZstd,
+		"ZSTD":                     encodingZstd,
+		"zstd;q=0, gzip":           encodingGzip,
+		"zstd; q=0.0, gzip;q=0.00": "",
+	} {
+		assert.Equal(t, want, acceptedEncoding(header), "%q", header)
+	}
+}
+
+func TestCompressResponses(t *testing.T) {
+	type args struct {
+		N int `json:"n"`
+	}
+	type result struct {
+		Data string `json:"data"`
+	}
+	funcMap := map[string]*RPCFunc{
+		"data": NewRPCFunc(func(_ context.Context, arg *args) (*result, error) {
+			return &result{Data: strings.Repeat("a", arg.N)}, nil
+		}),
+	}
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, funcMap, log.NewNopLogger(), CompressResponses(true))
+
+	get := func(url, acceptEncoding string) (*http.Response, string) {
+		req := httptest.NewRequest("GET", url, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		defer res.Body.Close()
+
+		var body io.Reader = res.Body
+		switch res.Header.Get("Content-Encoding") {
+		case encodingGzip:
+			zr, err := gzip.NewReader(res.Body)
+			require.NoError(t, err)
+			body = zr
+		case encodingZstd:
+			zr, err := zstd.NewReader(res.Body)
+			require.NoError(t, err)
+			defer zr.Close()
+			body = zr
+		}
+		bz, err := io.ReadAll(body)
+		require.NoError(t, err)
+		return res, string(bz)
+	}
+
+	want := `{"data":"` + strings.Repeat("a", 2000) + `"}`
+	for _, encoding := range []string{encodingGzip, encodingZstd} {
+		res, body := get("/data?n=2000", encoding)
+		assert.Equal(t, encoding, res.Header.Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", res.Header.Get("Vary"))
+		assert.Equal(t, want, body)
+
+		// JSON-RPC responses are compressed too
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"data","params":{"n":2000}}`))
+		req.Header.Set("Accept-Encoding", encoding)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		assert.Equal(t, encoding, rec.Header().Get("Content-Encoding"))
+	}
+
+	// small responses, and the responses to clients that don't accept a
+	// compressed encoding, are not compressed
+	res, body := get("/data?n=10", encodingGzip)
+	assert.Empty(t, res.Header.Get("Content-Encoding"))
+	assert.Equal(t, `{"data":"aaaaaaaaaa"}`, body)
+
+	res, body = get("/data?n=2000", "")
+	assert.Empty(t, res.Header.Get("Content-Encoding"))
+	assert.Equal(t, want, body)
+}
@@ -16,11 +16,11 @@ import (
 // HTTP + JSON handler
 
 // jsonrpc calls grab the given method's function info and runs reflect.Call
-func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.HandlerFunc {
+func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger, opts handlerOptions) http.HandlerFunc {
 	return func(w http.ResponseWriter, hreq *http.Request) {
 		// For POST requests, reject a non-root URL path. This should not happen
 		// in the standard configuration, since the wrapper checks the path.
-		if hreq.URL.Path != "/" {
+		if !opts.isRoot(hreq.URL.Path) {
 			writeRPCResponse(w, logger, rpctypes.RPCRequest{}.MakeErrorf(
 				rpctypes.CodeInvalidRequest, "invalid path: %q", hreq.URL.Path))
 			return
@@ -46,6 +46,30 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				rpctypes.CodeParseError, "decoding request: %v", err))
 			return
 		}
+		if opts.maxBatchSize > 0 && len(requests) > opts.maxBatchSize {
+			writeRPCResponse(w, logger, rpctypes.RPCRequest{}.MakeErrorf(
+				rpctypes.CodeInvalidRequest, "batch of %d requests exceeds the maximum of %d",
+				len(requests), opts.maxBatchSize))
+			return
+		}
+
+		if l := opts.limiter; l != nil {
+			var methods []string
+			for _, req := range requests {
+				if !req.IsNotification() {
+					methods = append(methods, req.Method)
+				}
+			}
+			if ok, retryAfter := l.allow(l.clientKey(hreq), methods...); !ok {
+				// Reject a batch as a whole, with a single error response.
+				var req rpctypes.RPCRequest
+				if len(requests) == 1 {
+					req = requests[0]
+				}
+				writeLimitExceeded(w, logger, limitExceededError(req, retryAfter), retryAfter, false)
+				return
+			}
+		}
 
 		var responses []rpctypes.RPCResponse
 		for _, req := range requests {
@@ -66,7 +90,9 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 				RPCRequest:  &req,
 				HTTPRequest: hreq,
 			})
+			ctx, done := opts.onCall.start(ctx, req.Method)
 			result, err := rpcFunc.Call(ctx, req.Params)
+			done(err)
 			if err != nil {
 				responses = append(responses, req.MakeError(err))
 			} else {
@@ -77,6 +103,11 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, logger log.Logger) http.Han
 		if len(responses) == 0 {
 			return
 		}
+		// The responses to a batch are an array, even for a single request.
+		if isBatch(b) {
+			writeJSONResponse(w, logger, responses)
+			return
+		}
 		writeRPCResponse(w, logger, responses...)
 	}
 }
@@ -88,10 +119,10 @@ func ensureBodyClose(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
+func handleInvalidJSONRPCPaths(opts handlerOptions, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		//  we check whether the path is indeed "/", otherwise return a 404 error
-		if r.URL.Path != "/" {
+		//  we check whether the path is indeed the root, otherwise return a 404 error
+		if !opts.isRoot(r.URL.Path) {
 			http.NotFound(w, r)
 			return
 		}
@@ -105,8 +136,7 @@ func parseRequests(data []byte) ([]rpctypes.RPCRequest, error) {
 	var reqs []rpctypes.RPCRequest
 	var err error
 
-	isArray := bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
-	if isArray {
+	if isBatch(data) {
 		err = json.Unmarshal(data, &reqs)
 	} else {
 		reqs = append(reqs, rpctypes.RPCRequest{})
@@ -118,6 +148,11 @@ func parseRequests(data []byte) ([]rpctypes.RPCRequest, error) {
 	return reqs, nil
 }
 
+// isBatch reports whether data is a JSON-RPC request batch.
+func isBatch(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
+}
+
 // writes a list of available rpc endpoints as an html page
 func writeListOfEndpoints(w http.ResponseWriter, r *http.Request, funcMap map[string]*RPCFunc) {
 	hasArgs := make(map[string]string)
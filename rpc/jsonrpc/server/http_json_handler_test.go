@@ -16,7 +16,7 @@ import (
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
-func testMux() *http.ServeMux {
+func testMux(options ...HandlerOption) *http.ServeMux {
 	type testArgs struct {
 		S string      `json:"s"`
 		I json.Number `json:"i"`
@@ -30,7 +30,7 @@ func testMux() *http.ServeMux {
 	}
 	mux := http.NewServeMux()
 	logger := log.NewNopLogger()
-	RegisterRPCFuncs(mux, funcMap, logger)
+	RegisterRPCFuncs(mux, funcMap, logger, options...)
 
 	return mux
 }
@@ -221,6 +221,47 @@ func TestRPCNotificationInBatch(t *testing.T) {
 	}
 }
 
+func TestRPCBatchMaxSize(t *testing.T) {
+	mux := testMux(MaxBatchSize(2))
+	post := func(payload string) []byte {
+		req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader(payload))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		defer res.Body.Close()
+		require.True(t, statusOK(res.StatusCode))
+		blob, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return blob
+	}
+
+	var responses []rpctypes.RPCResponse
+	require.NoError(t, json.Unmarshal(post(`[
+		{"jsonrpc": "2.0","method":"block","id":1,"params":["1"]},
+		{"jsonrpc": "2.0","method":"block","id":2,"params":["2"]}
+	]`), &responses))
+	require.Len(t, responses, 2)
+	for _, response := range responses {
+		assert.Nil(t, response.Error)
+	}
+
+	// a batch of a single request still gets an array of responses
+	require.NoError(t, json.Unmarshal(post(`[
+		{"jsonrpc": "2.0","method":"block","id":1,"params":["1"]}
+	]`), &responses))
+	require.Len(t, responses, 1)
+
+	var response rpctypes.RPCResponse
+	require.NoError(t, json.Unmarshal(post(`[
+		{"jsonrpc": "2.0","method":"block","id":1,"params":["1"]},
+		{"jsonrpc": "2.0","method":"block","id":2,"params":["2"]},
+		{"jsonrpc": "2.0","method":"block","id":3,"params":["3"]}
+	]`), &response))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, int(rpctypes.CodeInvalidRequest), response.Error.Code)
+	assert.Contains(t, response.Error.Data, "batch of 3 requests exceeds the maximum of 2")
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", strings.NewReader(""))
@@ -233,6 +274,62 @@ func TestUnknownRPCPath(t *testing.T) {
 	res.Body.Close()
 }
 
+func TestRPCPathPrefix(t *testing.T) {
+	mux := testMux(PathPrefix("/v1"))
+	call := func(method, url, body string) *http.Response {
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	res := call("GET", "http://localhost/v1/block?h=1", "")
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	res.Body.Close()
+
+	for _, url := range []string{"http://localhost/v1", "http://localhost/v1/"} {
+		res := call("POST", url, `{"jsonrpc": "2.0","method":"block","id":0,"params":["1"]}`)
+		require.Equal(t, http.StatusOK, res.StatusCode, url)
+		var response rpctypes.RPCResponse
+		require.NoError(t, json.NewDecoder(res.Body).Decode(&response))
+		assert.Nil(t, response.Error, url)
+		res.Body.Close()
+	}
+
+	for _, url := range []string{"http://localhost/block?h=1", "http://localhost/v1/unknownrpcpath"} {
+		res := call("GET", url, "")
+		require.Equal(t, http.StatusNotFound, res.StatusCode, url)
+		res.Body.Close()
+	}
+}
+
+func TestRPCOnCall(t *testing.T) {
+	var methods []string
+	var errs []error
+	mux := testMux(OnCall(func(ctx context.Context, method string) (context.Context, func(error)) {
+		methods = append(methods, method)
+		return ctx, func(err error) { errs = append(errs, err) }
+	}))
+	call := func(method, url, body string) {
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	}
+
+	call("GET", "http://localhost/block?h=1", "")
+	call("POST", "http://localhost/", `[{"jsonrpc": "2.0","method":"c","id":0,"params":["a", "10"]},`+
+		`{"jsonrpc": "2.0","method":"c","id":1,"params":[1, 1]},`+
+		`{"jsonrpc": "2.0","method":"unknown","id":2}]`)
+
+	// the hook is called around the calls of known methods only
+	require.Equal(t, []string{"block", "c", "c"}, methods)
+	require.Len(t, errs, 3)
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+	require.Error(t, errs[2])
+}
+
 func TestRPCResponseCache(t *testing.T) {
 	mux := testMux()
 	body := strings.NewReader(`{"jsonrpc": "2.0","method":"block","id": 0, "params": ["1"]}`)
@@ -264,3 +361,40 @@ func TestRPCResponseCache(t *testing.T) {
 	res.Body.Close()
 	require.NoError(t, err, "reading from the body should not give back an error")
 }
+
+func TestRPCHTTPStatus(t *testing.T) {
+	type healthResult struct {
+		OK bool `json:"ok"`
+	}
+	healthy := true
+	mux := http.NewServeMux()
+	RegisterRPCFuncs(mux, map[string]*RPCFunc{
+		"health": NewRPCFunc(func(ctx context.Context) (*healthResult, error) {
+			return &healthResult{OK: healthy}, nil
+		}).HTTPStatus(func(result interface{}) int {
+			if !result.(*healthResult).OK {
+				return http.StatusServiceUnavailable
+			}
+			return http.StatusOK
+		}),
+	}, log.NewNopLogger())
+
+	get := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest("GET", "/health", nil))
+		return rec
+	}
+	rec := get()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"ok":true}`, rec.Body.String())
+
+	healthy = false
+	rec = get()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, `{"ok":false}`, rec.Body.String())
+
+	// the JSON-RPC responses are 200 OK
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("POST", "/", strings.NewReader(`{"jsonrpc":"2.0","method":"health","id":0}`)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
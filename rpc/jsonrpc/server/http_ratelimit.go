@@ -0,0 +1,250 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// APIKeyHeader is the header of the API key of a client, see RateLimitConfig.
+const APIKeyHeader = "X-API-Key"
+
+// rateLimitPruneInterval is the interval between the removals of the buckets
+// of the clients which are back to a full bucket.
+const rateLimitPruneInterval = time.Minute
+
+// RateLimitConfig is the configuration of a RateLimiter.
+type RateLimitConfig struct {
+	// Rate is the number of requests per second that each client may make on
+	// average. It must be positive.
+	Rate float64
+
+	// Burst is the number of requests that each client may make at once.
+	Burst int
+
+	// MethodCosts are the costs, in requests, of the methods that don't cost
+	// a single request.
+	MethodCosts map[string]int
+
+	// APIKeys are the keys identifying clients, sent in the X-API-Key header,
+	// which are then limited per key rather than per IP address.
+	APIKeys []string
+}
+
+// RateLimiter limits the rate of the requests of each client of an RPC
+// server, by IP address or API key, with a token bucket each.
+type RateLimiter struct {
+	now func() time.Time
+
+	mtx       sync.Mutex
+	rate      float64
+	burst     float64
+	costs     map[string]int
+	apiKeys   map[string]bool
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter with the given configuration, to set
+// on the handlers of a server with the RateLimit and WSRateLimit options.
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	l := &RateLimiter{
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+	l.SetConfig(cfg)
+	return l
+}
+
+// SetConfig replaces the configuration of the limiter. The clients keep the
+// tokens left in their buckets, up to the new burst.
+func (l *RateLimiter) SetConfig(cfg RateLimitConfig) {
+	apiKeys := make(map[string]bool, len(cfg.APIKeys))
+	for _, key := range cfg.APIKeys {
+		apiKeys[key] = true
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.rate = cfg.Rate
+	l.burst = math.Max(float64(cfg.Burst), 1)
+	l.costs = cfg.MethodCosts
+	l.apiKeys = apiKeys
+}
+
+// clientKey returns the key of the bucket of the client of req.
+func (l *RateLimiter) clientKey(req *http.Request) string {
+	if key := req.Header.Get(APIKeyHeader); key != "" && l.isAPIKey(key) {
+		return "key:" + key
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// isAPIKey reports whether key is one of the configured API keys.
+func (l *RateLimiter) isAPIKey(key string) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	return l.apiKeys[key]
+}
+
+// allow reports whether the client with the given key may call the methods
+// now, and takes their cost from its bucket if so. Otherwise, it returns how
+// long the client should wait before retrying, or zero if the cost of the
+// methods exceeds the burst, so that they can never be allowed together.
+func (l *RateLimiter) allow(key string, methods ...string) (bool, time.Duration) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	var cost float64
+	for _, method := range methods {
+		if c, ok := l.costs[method]; ok {
+			cost += float64(c)
+		} else {
+			cost++
+		}
+	}
+	if cost > l.burst {
+		return false, 0
+	}
+
+	now := l.now()
+	if now.Sub(l.lastPrune) >= rateLimitPruneInterval {
+		l.prune(now)
+	}
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens >= cost {
+		b.tokens -= cost
+		return true, 0
+	}
+	return false, time.Duration((cost - b.tokens) / l.rate * float64(time.Second))
+}
+
+// refill returns the tokens of b at now.
+func (l *RateLimiter) refill(b *tokenBucket, now time.Time) float64 {
+	return math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+}
+
+// prune removes the buckets that are full at now, which are the same as no
+// bucket.
+func (l *RateLimiter) prune(now time.Time) {
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPrune = now
+}
+
+// limitExceededError returns the error of a call rejected by a RateLimiter,
+// which may be retried after retryAfter, or never if retryAfter is zero.
+func limitExceededError(req rpctypes.RPCRequest, retryAfter time.Duration) rpctypes.RPCResponse {
+	if retryAfter == 0 {
+		return req.MakeErrorf(rpctypes.CodeLimitExceeded, "rate limit exceeded, the cost of the calls exceeds the burst")
+	}
+	return req.MakeErrorf(rpctypes.CodeLimitExceeded, "rate limit exceeded, retry after %v", retryAfter.Round(time.Millisecond))
+}
+
+// writeLimitExceeded writes the response to a request rejected by a
+// RateLimiter, with status 429 Too Many Requests and the Retry-After header,
+// unless retryAfter is zero.
+// If errorOnly is true, the body is the error object of rsp, as in the
+// responses to GET requests; otherwise it is rsp.
+func writeLimitExceeded(w http.ResponseWriter, log log.Logger, rsp rpctypes.RPCResponse, retryAfter time.Duration, errorOnly bool) {
+	var v interface{} = rsp
+	if errorOnly {
+		v = rsp.Error
+	}
+	body, err := json.Marshal(v)
+	if err != nil {
+		log.Error("Error encoding RPC response: %w", err)
+		writeInternalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = w.Write(body)
+}
+
+// LimitListenerPerIP returns a listener that accepts at most n simultaneous
+// connections from each IP address of TCP clients. The connections over the
+// limit are closed as soon as they are accepted.
+func LimitListenerPerIP(l net.Listener, n int) net.Listener {
+	return &perIPListener{Listener: l, n: n, conns: make(map[string]int)}
+}
+
+type perIPListener struct {
+	net.Listener
+	n int
+
+	mtx   sync.Mutex
+	conns map[string]int
+}
+
+func (l *perIPListener) Accept() (net.Conn, error) {
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		addr, ok := c.RemoteAddr().(*net.TCPAddr)
+		if !ok {
+			return c, nil
+		}
+		ip := addr.IP.String()
+
+		l.mtx.Lock()
+		if l.conns[ip] >= l.n {
+			l.mtx.Unlock()
+			_ = c.Close()
+			continue
+		}
+		l.conns[ip]++
+		l.mtx.Unlock()
+		return &perIPConn{Conn: c, release: func() { l.release(ip) }}, nil
+	}
+}
+
+func (l *perIPListener) release(ip string) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.conns[ip]--; l.conns[ip] <= 0 {
+		delete(l.conns, ip)
+	}
+}
+
+type perIPConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *perIPConn) Close() error {
+	c.once.Do(c.release)
+	return c.Conn.Close()
+}
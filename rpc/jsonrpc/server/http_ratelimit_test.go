@@ -0,0 +1,221 @@
+package server
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+func newTestRateLimiter(cfg RateLimitConfig) (*RateLimiter, *time.Time) {
+	now := time.Unix(1000, 0)
+	l := NewRateLimiter(cfg)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	l, now := newTestRateLimiter(RateLimitConfig{
+		Rate:        2,
+		Burst:       4,
+		MethodCosts: map[string]int{"tx_search": 3, "health": 0},
+	})
+
+	// the burst is allowed at once
+	for i := 0; i < 4; i++ {
+		ok, _ := l.allow("ip:a", "status")
+		require.True(t, ok, i)
+	}
+	ok, retryAfter := l.allow("ip:a", "status")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// free methods are always allowed, and other clients have their own bucket
+	ok, _ = l.allow("ip:a", "health")
+	assert.True(t, ok)
+	ok, _ = l.allow("ip:b", "status")
+	assert.True(t, ok)
+
+	// the bucket refills at the rate, and costs are weighted
+	*now = now.Add(time.Second)
+	ok, retryAfter = l.allow("ip:a", "tx_search")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+	ok, _ = l.allow("ip:a", "status", "status")
+	assert.True(t, ok)
+
+	// the cost of a batch is the sum of its methods
+	*now = now.Add(time.Hour)
+	ok, _ = l.allow("ip:a", "tx_search", "status")
+	assert.True(t, ok)
+	ok, retryAfter = l.allow("ip:a", "tx_search")
+	assert.False(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, retryAfter)
+
+	// a batch costing more than the burst is never allowed, even with a full
+	// bucket, and takes no tokens
+	*now = now.Add(time.Hour)
+	ok, retryAfter = l.allow("ip:a", "tx_search", "tx_search")
+	assert.False(t, ok)
+	assert.Zero(t, retryAfter)
+	for i := 0; i < 4; i++ {
+		ok, _ := l.allow("ip:a", "status")
+		require.True(t, ok, i)
+	}
+
+	// full buckets are pruned
+	*now = now.Add(time.Hour)
+	ok, _ = l.allow("ip:c", "status")
+	assert.True(t, ok)
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestRateLimiterClientKey(t *testing.T) {
+	l := NewRateLimiter(RateLimitConfig{Rate: 1, APIKeys: []string{"key1"}})
+
+	req := httptest.NewRequest("GET", "/status", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "ip:10.0.0.1", l.clientKey(req))
+
+	req.Header.Set(APIKeyHeader, "other")
+	assert.Equal(t, "ip:10.0.0.1", l.clientKey(req))
+
+	req.Header.Set(APIKeyHeader, "key1")
+	assert.Equal(t, "key:key1", l.clientKey(req))
+}
+
+func TestRateLimiterSetConfig(t *testing.T) {
+	l, now := newTestRateLimiter(RateLimitConfig{Rate: 1, Burst: 2})
+	ok, _ := l.allow("ip:a", "status", "status")
+	require.True(t, ok)
+
+	// the bucket refills at the new rate, up to the new burst
+	l.SetConfig(RateLimitConfig{Rate: 4, Burst: 1, APIKeys: []string{"key1"}})
+	ok, retryAfter := l.allow("ip:a", "status")
+	assert.False(t, ok)
+	assert.Equal(t, 250*time.Millisecond, retryAfter)
+	*now = now.Add(time.Hour)
+	ok, _ = l.allow("ip:a", "status", "status")
+	assert.False(t, ok)
+	ok, _ = l.allow("ip:a", "status")
+	assert.True(t, ok)
+	ok, _ = l.allow("ip:a", "status")
+	assert.False(t, ok)
+
+	req := httptest.NewRequest("GET", "/status", nil)
+	req.Header.Set(APIKeyHeader, "key1")
+	assert.Equal(t, "key:key1", l.clientKey(req))
+}
+
+func TestRateLimitHandlers(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimitConfig{Rate: 1, Burst: 2})
+	mux := testMux(RateLimit(l))
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 2; i++ {
+		rec := serve(httptest.NewRequest("GET", "/block?h=1", nil))
+		require.Equal(t, http.StatusOK, rec.Code, i)
+	}
+	rec := serve(httptest.NewRequest("GET", "/block?h=1", nil))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	var rpcErr rpctypes.RPCError
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rpcErr))
+	assert.Equal(t, int(rpctypes.CodeLimitExceeded), rpcErr.Code)
+
+	// JSON-RPC requests share the same bucket
+	rec = serve(httptest.NewRequest("POST", "/", strings.NewReader(
+		`{"jsonrpc": "2.0","method":"block","id":7,"params":["1"]}`)))
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	var response rpctypes.RPCResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, int(rpctypes.CodeLimitExceeded), response.Error.Code)
+	assert.Equal(t, `7`, response.ID())
+
+	// other clients are not limited
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`[
+		{"jsonrpc": "2.0","method":"block","id":1,"params":["1"]},
+		{"jsonrpc": "2.0","method":"block","id":2,"params":["2"]}
+	]`))
+	req.RemoteAddr = "10.0.0.2:1234"
+	rec = serve(req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// a batch costing more than the burst is rejected without a retry time
+	req = httptest.NewRequest("POST", "/", strings.NewReader(`[
+		{"jsonrpc": "2.0","method":"block","id":1,"params":["1"]},
+		{"jsonrpc": "2.0","method":"block","id":2,"params":["2"]},
+		{"jsonrpc": "2.0","method":"block","id":3,"params":["3"]}
+	]`))
+	req.RemoteAddr = "10.0.0.3:1234"
+	rec = serve(req)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Empty(t, rec.Header().Get("Retry-After"))
+	response = rpctypes.RPCResponse{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	require.NotNil(t, response.Error)
+	assert.Equal(t, int(rpctypes.CodeLimitExceeded), response.Error.Code)
+}
+
+func TestLimitListenerPerIP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	l := LimitListenerPerIP(ln, 1)
+	defer l.Close()
+
+	accepted := make(chan net.Conn)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				close(accepted)
+				return
+			}
+			accepted <- c
+		}
+	}()
+
+	c1, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer c1.Close()
+	s1 := <-accepted
+
+	// the second connection is closed by the listener
+	c2, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer c2.Close()
+	require.NoError(t, c2.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = c2.Read(make([]byte, 1))
+	assert.Error(t, err)
+	select {
+	case <-accepted:
+		t.Fatal("connection over the limit accepted")
+	default:
+	}
+
+	// and a new connection is accepted once the first one is closed
+	require.NoError(t, s1.Close())
+	c3, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	defer c3.Close()
+	select {
+	case s3 := <-accepted:
+		s3.Close()
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection not accepted")
+	}
+}
@@ -2,7 +2,11 @@
 package server
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +16,8 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
 	"golang.org/x/net/netutil"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -42,8 +48,28 @@ type Config struct {
 	// Controls the maximum size of a request header.
 	// See https://godoc.org/net/http#Server.MaxHeaderBytes
 	MaxHeaderBytes int
+
+	// If set, ServeTLS requires the clients to present a certificate signed
+	// by one of these certificate authorities.
+	ClientCAs *x509.CertPool
+
+	// If set, ServeTLS uses it as the base of its TLS configuration, e.g. for
+	// the certificates obtained from an ACME certificate authority, in which
+	// case its certFile and keyFile may be empty.
+	TLSConfig *tls.Config
+
+	// If set, Serve also accepts HTTP/2 connections without TLS (h2c), with
+	// prior knowledge or by upgrading an HTTP/1.1 connection.
+	H2C bool
+
+	// If set, ServeTLS only serves HTTP/1.1 and does not negotiate HTTP/2.
+	DisableHTTP2 bool
 }
 
+// maxHTTP2ConcurrentStreams is the maximum number of concurrent streams, and
+// thus of requests being handled, of an HTTP/2 connection.
+const maxHTTP2ConcurrentStreams = 100
+
 // DefaultConfig returns a default configuration.
 func DefaultConfig() *Config {
 	return &Config{
@@ -60,6 +86,9 @@ func DefaultConfig() *Config {
 func Serve(ctx context.Context, listener net.Listener, handler http.Handler, logger log.Logger, config *Config) error {
 	logger.Info("Starting RPC HTTP server on", "addr", listener.Addr())
 	h := recoverAndLogHandler(MaxBytesHandler(handler, config.MaxBodyBytes), logger)
+	if config.H2C {
+		h = h2c.NewHandler(h, http2Server(config))
+	}
 	s := &http.Server{
 		Handler:        h,
 		ReadTimeout:    config.ReadTimeout,
@@ -96,10 +125,17 @@ func ServeTLS(ctx context.Context, listener net.Listener, handler http.Handler,
 
 	s := &http.Server{
 		Handler:        recoverAndLogHandler(MaxBytesHandler(handler, config.MaxBodyBytes), logger),
+		TLSConfig:      tlsConfig(config),
 		ReadTimeout:    config.ReadTimeout,
 		WriteTimeout:   config.WriteTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
 	}
+	if config.DisableHTTP2 {
+		// a non-nil TLSNextProto disables the automatic HTTP/2 support
+		s.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+	} else if err := http2.ConfigureServer(s, http2Server(config)); err != nil {
+		return fmt.Errorf("configuring HTTP/2: %w", err)
+	}
 	sig := make(chan struct{})
 	go func() {
 		select {
@@ -119,6 +155,46 @@ func ServeTLS(ctx context.Context, listener net.Listener, handler http.Handler,
 	return nil
 }
 
+// http2Server returns the HTTP/2 configuration of the servers for config. The
+// h2c connections are not served by an http.Server, so that its timeouts don't
+// apply: the concurrent streams of a connection are limited, and the idle
+// connections are closed after the ReadTimeout, as for HTTP/1.1.
+func http2Server(config *Config) *http2.Server {
+	return &http2.Server{
+		MaxConcurrentStreams: maxHTTP2ConcurrentStreams,
+		IdleTimeout:          config.ReadTimeout,
+	}
+}
+
+// tlsConfig returns the TLS configuration of ServeTLS for config, or nil for
+// the default one.
+func tlsConfig(config *Config) *tls.Config {
+	if config.ClientCAs == nil && config.TLSConfig == nil {
+		return nil
+	}
+	tc := &tls.Config{}
+	if config.TLSConfig != nil {
+		tc = config.TLSConfig.Clone()
+	}
+	if tc.MinVersion < tls.VersionTLS12 {
+		tc.MinVersion = tls.VersionTLS12
+	}
+	if config.ClientCAs != nil {
+		tc.ClientAuth = tls.RequireAndVerifyClientCert
+		tc.ClientCAs = config.ClientCAs
+	}
+	if config.DisableHTTP2 {
+		protos := make([]string, 0, len(tc.NextProtos))
+		for _, proto := range tc.NextProtos {
+			if proto != http2.NextProtoTLS {
+				protos = append(protos, proto)
+			}
+		}
+		tc.NextProtos = protos
+	}
+	return tc
+}
+
 // writeInternalError writes an internal server error (500) to w with the text
 // of err in the body. This is a fallback used when a handler is unable to
 // write the expected response.
@@ -133,6 +209,12 @@ func writeInternalError(w http.ResponseWriter, err error) {
 //
 // Unless there is an error encoding the response, the status is 200 OK.
 func writeHTTPResponse(w http.ResponseWriter, log log.Logger, rsp rpctypes.RPCResponse) {
+	writeHTTPResponseStatus(w, log, rsp, http.StatusOK)
+}
+
+// writeHTTPResponseStatus writes a JSON-RPC response to w as writeHTTPResponse
+// does, with the given status.
+func writeHTTPResponseStatus(w http.ResponseWriter, log log.Logger, rsp rpctypes.RPCResponse, status int) {
 	var body []byte
 	var err error
 	if rsp.Error != nil {
@@ -146,23 +228,53 @@ func writeHTTPResponse(w http.ResponseWriter, log log.Logger, rsp rpctypes.RPCRe
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
+	w.WriteHeader(status)
 	_, _ = w.Write(body)
 }
 
+// immutableMaxAge is the max-age of the Cache-Control header of immutable
+// responses.
+const immutableMaxAge = 24 * time.Hour
+
+// writeCacheableHTTPResponse writes a JSON-RPC response to req, as
+// writeHTTPResponse does, marking it cacheable with the Cache-Control and ETag
+// headers. A response matching the If-None-Match header of req has status 304
+// Not Modified, and no body.
+func writeCacheableHTTPResponse(w http.ResponseWriter, req *http.Request, log log.Logger, rsp rpctypes.RPCResponse) {
+	if rsp.Error != nil {
+		writeHTTPResponse(w, log, rsp)
+		return
+	}
+	etag := fmt.Sprintf(`"%x"`, sha256.Sum256(rsp.Result))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", int(immutableMaxAge.Seconds())))
+	w.Header().Set("ETag", etag)
+	if match := req.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			if tag = strings.TrimSpace(tag); tag == etag || tag == "W/"+etag || tag == "*" {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+	}
+	writeHTTPResponse(w, log, rsp)
+}
+
 // writeRPCResponse writes one or more JSON-RPC responses to w. A single
 // response is encoded as an object, otherwise the response is sent as a batch
 // (array) of response objects.
 //
 // Unless there is an error encoding the responses, the status is 200 OK.
 func writeRPCResponse(w http.ResponseWriter, log log.Logger, rsps ...rpctypes.RPCResponse) {
-	var body []byte
-	var err error
 	if len(rsps) == 1 {
-		body, err = json.Marshal(rsps[0])
+		writeJSONResponse(w, log, rsps[0])
 	} else {
-		body, err = json.Marshal(rsps)
+		writeJSONResponse(w, log, rsps)
 	}
+}
+
+// writeJSONResponse writes v as the JSON body of a response with status 200.
+func writeJSONResponse(w http.ResponseWriter, log log.Logger, v interface{}) {
+	body, err := json.Marshal(v)
 	if err != nil {
 		log.Error("Error encoding RPC response: %w", err)
 		writeInternalError(w, err)
@@ -249,18 +361,26 @@ func (h maxBytesHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 func newStatusWriter(w http.ResponseWriter, code *int) statusWriter {
 	return statusWriter{
 		ResponseWriter: w,
-		Hijacker:       w.(http.Hijacker),
 		code:           code,
 	}
 }
 
 type statusWriter struct {
 	http.ResponseWriter
-	http.Hijacker // to support websocket upgrade
 
 	code *int
 }
 
+// Hijack implements http.Hijacker, to support websocket upgrade, for the
+// wrapped writers supporting it. HTTP/2 writers don't.
+func (w statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("the connection can't be hijacked, e.g. over HTTP/2")
+	}
+	return h.Hijack()
+}
+
 // WriteHeader implements part of http.ResponseWriter. It delegates to the
 // wrapped writer, and as a side effect captures the written code.
 //
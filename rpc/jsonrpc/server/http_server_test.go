@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"net"
@@ -16,6 +17,7 @@ import (
 	"github.com/fortytw2/leaktest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
 
 	"github.com/tendermint/tendermint/libs/log"
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
@@ -170,3 +172,139 @@ func TestWriteHTTPResponse(t *testing.T) {
 	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
 	assert.Equal(t, `{"code":-32603,"message":"Internal error","data":"foo"}`, string(body))
 }
+
+func TestWriteCacheableHTTPResponse(t *testing.T) {
+	logger := log.NewNopLogger()
+	rsp := rpctypes.NewRequest(-1).MakeResponse(&sampleResult{"hello"})
+
+	w := httptest.NewRecorder()
+	writeCacheableHTTPResponse(w, httptest.NewRequest("GET", "/block?height=1", nil), logger, rsp)
+	resp := w.Result()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=86400, immutable", resp.Header.Get("Cache-Control"))
+	assert.Equal(t, `{"value":"hello"}`, string(body))
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// a request with the etag of the response is not modified
+	req := httptest.NewRequest("GET", "/block?height=1", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	w = httptest.NewRecorder()
+	writeCacheableHTTPResponse(w, req, logger, rsp)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	// errors are not cacheable
+	w = httptest.NewRecorder()
+	writeCacheableHTTPResponse(w, req, logger, rpctypes.NewRequest(-1).MakeErrorf(rpctypes.CodeInternalError, "foo"))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+	assert.Empty(t, w.Header().Get("ETag"))
+}
+
+func TestTLSConfig(t *testing.T) {
+	assert.Nil(t, tlsConfig(DefaultConfig()))
+
+	cfg := DefaultConfig()
+	cfg.ClientCAs = x509.NewCertPool()
+	tc := tlsConfig(cfg)
+	require.NotNil(t, tc)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, tc.ClientAuth)
+	assert.Equal(t, cfg.ClientCAs, tc.ClientCAs)
+
+	// the base configuration is copied, without HTTP/2 if disabled
+	cfg = DefaultConfig()
+	cfg.TLSConfig = &tls.Config{NextProtos: []string{"h2", "http/1.1", "acme-tls/1"}}
+	cfg.DisableHTTP2 = true
+	tc = tlsConfig(cfg)
+	require.NotNil(t, tc)
+	assert.Equal(t, []string{"http/1.1", "acme-tls/1"}, tc.NextProtos)
+	assert.Equal(t, []string{"h2", "http/1.1", "acme-tls/1"}, cfg.TLSConfig.NextProtos)
+	assert.Equal(t, uint16(tls.VersionTLS12), tc.MinVersion)
+	assert.Equal(t, tls.NoClientCert, tc.ClientAuth)
+}
+
+func TestHTTP2Server(t *testing.T) {
+	cfg := DefaultConfig()
+	s := http2Server(cfg)
+	assert.Equal(t, uint32(maxHTTP2ConcurrentStreams), s.MaxConcurrentStreams)
+	assert.Equal(t, cfg.ReadTimeout, s.IdleTimeout)
+}
+
+func TestServeHTTP2(t *testing.T) {
+	t.Cleanup(leaktest.Check(t))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Proto)
+	})
+	get := func(t *testing.T, c *http.Client, url string) string {
+		res, err := c.Get(url)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+	serve := func(t *testing.T, cfg *Config, useTLS bool) string {
+		ln, err := net.Listen("tcp", "localhost:0")
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if useTLS {
+				_ = ServeTLS(ctx, ln, mux, "test.crt", "test.key", log.NewNopLogger(), cfg)
+			} else {
+				_ = Serve(ctx, ln, mux, log.NewNopLogger(), cfg)
+			}
+		}()
+		t.Cleanup(func() {
+			cancel()
+			<-done
+		})
+		return ln.Addr().String()
+	}
+
+	t.Run("TLS", func(t *testing.T) {
+		cfg := DefaultConfig()
+		addr := serve(t, cfg, true)
+		tr := &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			ForceAttemptHTTP2: true,
+		}
+		defer tr.CloseIdleConnections()
+		assert.Equal(t, "HTTP/2.0", get(t, &http.Client{Transport: tr}, "https://"+addr))
+	})
+	t.Run("TLSDisabled", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.DisableHTTP2 = true
+		addr := serve(t, cfg, true)
+		tr := &http.Transport{
+			TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			ForceAttemptHTTP2: true,
+		}
+		defer tr.CloseIdleConnections()
+		assert.Equal(t, "HTTP/1.1", get(t, &http.Client{Transport: tr}, "https://"+addr))
+	})
+	t.Run("H2C", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.H2C = true
+		addr := serve(t, cfg, false)
+		// HTTP/2 with prior knowledge, over a cleartext connection
+		tr := &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		}
+		defer tr.CloseIdleConnections()
+		assert.Equal(t, "HTTP/2.0", get(t, &http.Client{Transport: tr}, "http://"+addr))
+		// HTTP/1.1 clients are still served
+		assert.Equal(t, "HTTP/1.1", get(t, http.DefaultClient, "http://"+addr))
+	})
+}
@@ -18,8 +18,15 @@ import (
 const uriReqID = -1
 
 // convert from a function name to the http handler
-func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(name string, rpcFunc *RPCFunc, logger log.Logger, opts handlerOptions) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, req *http.Request) {
+		if l := opts.limiter; l != nil {
+			if ok, retryAfter := l.allow(l.clientKey(req), name); !ok {
+				jreq := rpctypes.NewRequest(uriReqID)
+				writeLimitExceeded(w, logger, limitExceededError(jreq, retryAfter), retryAfter, true)
+				return
+			}
+		}
 		ctx := rpctypes.WithCallInfo(req.Context(), &rpctypes.CallInfo{
 			HTTPRequest: req,
 		})
@@ -31,11 +38,17 @@ func makeHTTPHandler(rpcFunc *RPCFunc, logger log.Logger) func(http.ResponseWrit
 			return
 		}
 		jreq := rpctypes.NewRequest(uriReqID)
-		result, err := rpcFunc.Call(ctx, args)
-		if err == nil {
-			writeHTTPResponse(w, logger, jreq.MakeResponse(result))
-		} else {
+		ctx, done := opts.onCall.start(ctx, name)
+		param, result, err := rpcFunc.call(ctx, args)
+		done(err)
+		if err != nil {
 			writeHTTPResponse(w, logger, jreq.MakeError(err))
+		} else if rpcFunc.immutable != nil && rpcFunc.immutable(param, result) {
+			writeCacheableHTTPResponse(w, req, logger, jreq.MakeResponse(result))
+		} else if rpcFunc.status != nil {
+			writeHTTPResponseStatus(w, logger, jreq.MakeResponse(result), rpcFunc.status(result))
+		} else {
+			writeHTTPResponse(w, logger, jreq.MakeResponse(result))
 		}
 	}
 }
@@ -0,0 +1,269 @@
+package server
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// OpenAPIInfo describes the API documented by the handler of NewOpenAPIHandler.
+type OpenAPIInfo struct {
+	Title   string
+	Version string
+
+	// PathPrefix is the prefix of the documented routes, as set with the
+	// PathPrefix option of RegisterRPCFuncs.
+	PathPrefix string
+}
+
+// NewOpenAPIHandler returns a handler serving an OpenAPI 3.0 document, in
+// JSON, of the routes registered by RegisterRPCFuncs for funcMap. The
+// parameters and results of each route are described by the JSON schemas of
+// the parameter and result types of its function, and its tag and summary are
+// the ones set with the Doc method of the function.
+func NewOpenAPIHandler(funcMap map[string]*RPCFunc, info OpenAPIInfo) (http.HandlerFunc, error) {
+	doc, err := json.Marshal(newOpenAPIDocument(funcMap, info))
+	if err != nil {
+		return nil, fmt.Errorf("encoding openapi document: %w", err)
+	}
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(doc)
+	}, nil
+}
+
+// schema is a JSON schema, as used by the OpenAPI document.
+type schema map[string]interface{}
+
+func newOpenAPIDocument(funcMap map[string]*RPCFunc, info OpenAPIInfo) schema {
+	g := &schemaGenerator{names: make(map[reflect.Type]string), schemas: make(map[string]schema)}
+	rpcError := g.schemaFor(reflect.TypeOf(rpctypes.RPCError{}))
+	response := func(result schema) schema {
+		return schema{
+			"description": "JSON-RPC response",
+			"content": schema{"application/json": schema{"schema": schema{
+				"type": "object",
+				"properties": schema{
+					"jsonrpc": schema{"type": "string"},
+					"id":      schema{},
+					"result":  result,
+					"error":   rpcError,
+				},
+			}}},
+		}
+	}
+
+	var methods []string
+	for name, rf := range funcMap {
+		if !rf.ws {
+			methods = append(methods, name)
+		}
+	}
+	sort.Strings(methods)
+
+	paths := make(schema)
+	for _, name := range methods {
+		rf := funcMap[name]
+		params := make([]schema, 0, len(rf.args))
+		for _, arg := range rf.args {
+			params = append(params, schema{
+				"name":   arg.name,
+				"in":     "query",
+				"schema": g.paramSchemaFor(arg),
+			})
+		}
+		result := schema{}
+		if rf.result != nil {
+			result = g.schemaFor(rf.result)
+		}
+		op := schema{
+			"operationId": name,
+			"parameters":  params,
+			"responses":   schema{"200": response(result)},
+		}
+		if rf.summary != "" {
+			op["summary"] = rf.summary
+		}
+		if rf.tag != "" {
+			op["tags"] = []string{rf.tag}
+		}
+		paths[info.PathPrefix+"/"+name] = schema{"get": op}
+	}
+	paths[info.PathPrefix+"/"] = schema{"post": schema{
+		"operationId": "jsonrpc",
+		"summary":     "Call a method, or a batch of methods, with a JSON-RPC request",
+		"requestBody": schema{
+			"required": true,
+			"content": schema{"application/json": schema{"schema": schema{
+				"type": "object",
+				"properties": schema{
+					"jsonrpc": schema{"type": "string"},
+					"id":      schema{},
+					"method":  schema{"type": "string", "enum": methods},
+					"params":  schema{},
+				},
+			}}},
+		},
+		"responses": schema{"200": response(schema{})},
+	}}
+
+	return schema{
+		"openapi":    "3.0.0",
+		"info":       schema{"title": info.Title, "version": info.Version},
+		"paths":      paths,
+		"components": schema{"schemas": g.schemas},
+	}
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemaGenerator generates the JSON schemas of Go types, as encoded by the
+// encoding/json package. The schemas of named struct types are added to the
+// components of the document, and referred to by name.
+type schemaGenerator struct {
+	names   map[reflect.Type]string
+	schemas map[string]schema
+}
+
+func (g *schemaGenerator) schemaFor(t reflect.Type) schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return schema{"type": "string", "format": "date-time"}
+	case implements(t, jsonMarshalerType) || implements(t, textMarshalerType):
+		// The encoding is custom: assume that the ones of non-struct types
+		// are strings, such as the hex encoding of byte slices.
+		if t.Kind() == reflect.Struct || t.Kind() == reflect.Interface {
+			return schema{}
+		}
+		return schema{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Slice:
+		if isByteArray(t) {
+			return schema{"type": "string", "format": "byte"}
+		}
+		return schema{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Array:
+		return schema{"type": "array", "items": g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return schema{"type": "object", "additionalProperties": g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchemaFor(t)
+		}
+		name, ok := g.names[t]
+		if !ok {
+			name = g.nameFor(t)
+			g.names[t] = name
+			g.schemas[name] = nil // reserve the name for recursive types
+			g.schemas[name] = g.structSchemaFor(t)
+		}
+		return schema{"$ref": "#/components/schemas/" + name}
+	default:
+		return schema{}
+	}
+}
+
+// paramSchemaFor returns the schema of the query parameter of arg. Integers
+// are accepted unquoted in query parameters, whatever their JSON encoding.
+func (g *schemaGenerator) paramSchemaFor(arg argInfo) schema {
+	t := arg.typ
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema{"type": "integer"}
+	}
+	if arg.isBinary {
+		return schema{"type": "string"}
+	}
+	return g.schemaFor(t)
+}
+
+// structSchemaFor returns the schema of the struct type t, with the properties
+// of its JSON encoding.
+func (g *schemaGenerator) structSchemaFor(t reflect.Type) schema {
+	props := make(schema)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		parts := strings.Split(field.Tag.Get("json"), ",")
+		name := parts[0]
+		if name == "-" && len(parts) == 1 {
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if field.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			// The fields of embedded structs are promoted.
+			embedded := g.structSchemaFor(ft)
+			for k, v := range embedded["properties"].(schema) {
+				props[k] = v
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		s := g.schemaFor(field.Type)
+		for _, opt := range parts[1:] {
+			if opt == "string" {
+				s = schema{"type": "string"}
+			}
+		}
+		props[name] = s
+	}
+	return schema{"type": "object", "properties": props}
+}
+
+// nameFor returns a unique component name for the named type t, qualified by
+// the name of its package.
+func (g *schemaGenerator) nameFor(t reflect.Type) string {
+	base := path.Base(t.PkgPath()) + "." + t.Name()
+	name := base
+	for i := 2; ; i++ {
+		if _, taken := g.schemas[name]; !taken {
+			return name
+		}
+		name = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+// implements reports whether t, or a pointer to t, implements iface.
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
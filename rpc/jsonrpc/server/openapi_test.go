@@ -0,0 +1,95 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPITestResult struct {
+	Height int64             `json:"height,string"`
+	Time   time.Time         `json:"time"`
+	Data   []byte            `json:"data"`
+	Tags   map[string]string `json:"tags,omitempty"`
+	Next   *openAPITestResult
+	Skip   string `json:"-"`
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	type args struct {
+		Height *int64 `json:"height"`
+		Hash   []byte `json:"hash"`
+		Query  string
+	}
+	funcMap := map[string]*RPCFunc{
+		"result": NewRPCFunc(func(context.Context, *args) (*openAPITestResult, error) { return nil, nil }).
+			Doc("Info", "Get a result"),
+		"health":    NewRPCFunc(func(context.Context) error { return nil }),
+		"subscribe": NewWSRPCFunc(func(context.Context) error { return nil }),
+	}
+	handler, err := NewOpenAPIHandler(funcMap, OpenAPIInfo{Title: "Test", Version: "1.0", PathPrefix: "/v1"})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var doc struct {
+		OpenAPI string `json:"openapi"`
+		Info    struct {
+			Title   string `json:"title"`
+			Version string `json:"version"`
+		} `json:"info"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]json.RawMessage `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.0", doc.OpenAPI)
+	assert.Equal(t, "Test", doc.Info.Title)
+
+	// websocket functions are not served over HTTP
+	assert.Len(t, doc.Paths, 3)
+	assert.Contains(t, doc.Paths, "/v1/health")
+	assert.Contains(t, doc.Paths["/v1/"], "post")
+
+	var op struct {
+		Summary    string   `json:"summary"`
+		Tags       []string `json:"tags"`
+		Parameters []struct {
+			Name   string                 `json:"name"`
+			Schema map[string]interface{} `json:"schema"`
+		} `json:"parameters"`
+	}
+	require.NoError(t, json.Unmarshal(doc.Paths["/v1/result"]["get"], &op))
+	assert.Equal(t, "Get a result", op.Summary)
+	assert.Equal(t, []string{"Info"}, op.Tags)
+	require.Len(t, op.Parameters, 3)
+	assert.Equal(t, "height", op.Parameters[0].Name)
+	assert.Equal(t, "integer", op.Parameters[0].Schema["type"])
+	assert.Equal(t, "hash", op.Parameters[1].Name)
+	assert.Equal(t, "string", op.Parameters[1].Schema["type"])
+	assert.Equal(t, "query", op.Parameters[2].Name)
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(doc.Components.Schemas["server.openAPITestResult"], &result))
+	assert.Equal(t, map[string]interface{}{
+		"height": map[string]interface{}{"type": "string"},
+		"time":   map[string]interface{}{"type": "string", "format": "date-time"},
+		"data":   map[string]interface{}{"type": "string", "format": "byte"},
+		"tags": map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": map[string]interface{}{"type": "string"},
+		},
+		"Next": map[string]interface{}{"$ref": "#/components/schemas/server.openAPITestResult"},
+	}, result["properties"])
+	assert.Contains(t, doc.Components.Schemas, "types.RPCError")
+}
@@ -21,16 +21,98 @@ const DefaultRPCTimeout = 60 * time.Second
 
 // RegisterRPCFuncs adds a route to mux for each non-websocket function in the
 // funcMap, and also a root JSON-RPC POST handler.
-func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger) {
+func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, logger log.Logger, options ...HandlerOption) {
+	var opts handlerOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	wrap := func(h http.HandlerFunc) http.HandlerFunc {
+		if opts.compress {
+			h = compressHandler(h)
+		}
+		return ensureBodyClose(h)
+	}
+
 	for name, fn := range funcMap {
 		if fn.ws {
 			continue // skip websocket endpoints, not usable via GET calls
 		}
-		mux.HandleFunc("/"+name, ensureBodyClose(makeHTTPHandler(fn, logger)))
+		mux.HandleFunc(opts.pathPrefix+"/"+name, wrap(makeHTTPHandler(name, fn, logger, opts)))
 	}
 
 	// Endpoints for POST.
-	mux.HandleFunc("/", ensureBodyClose(handleInvalidJSONRPCPaths(makeJSONRPCHandler(funcMap, logger))))
+	root := wrap(handleInvalidJSONRPCPaths(opts, makeJSONRPCHandler(funcMap, logger, opts)))
+	mux.HandleFunc(opts.pathPrefix+"/", root)
+	if opts.pathPrefix != "" {
+		mux.HandleFunc(opts.pathPrefix, root)
+	}
+}
+
+// HandlerOption sets an optional parameter of the handlers registered by
+// RegisterRPCFuncs.
+type HandlerOption func(*handlerOptions)
+
+type handlerOptions struct {
+	maxBatchSize int
+	pathPrefix   string
+	compress     bool
+	limiter      *RateLimiter
+	onCall       CallHook
+}
+
+// isRoot reports whether path is the path of the JSON-RPC POST handler.
+func (o handlerOptions) isRoot(path string) bool {
+	return path == o.pathPrefix+"/" || (o.pathPrefix != "" && path == o.pathPrefix)
+}
+
+// MaxBatchSize sets the maximum number of requests in a JSON-RPC batch
+// request. Larger batches are rejected as a whole with an error response. The
+// default, 0, does not limit the size of batches.
+func MaxBatchSize(n int) HandlerOption {
+	return func(o *handlerOptions) { o.maxBatchSize = n }
+}
+
+// PathPrefix registers the routes under prefix, e.g. "/v1" for "/v1/status"
+// and a JSON-RPC POST handler at "/v1". The prefix must start with a slash
+// and not end with one. The default, "", registers the routes at the root.
+func PathPrefix(prefix string) HandlerOption {
+	return func(o *handlerOptions) { o.pathPrefix = prefix }
+}
+
+// RateLimit sets the rate limiter of the requests of each client. Requests over
+// the limit are rejected with status 429 Too Many Requests. The Retry-After
+// header of the response is the number of seconds to wait before retrying. The
+// default, nil, does not limit the rate of requests.
+func RateLimit(l *RateLimiter) HandlerOption {
+	return func(o *handlerOptions) { o.limiter = l }
+}
+
+// CompressResponses sets whether to compress the response bodies of at least
+// 1KiB with the zstd or gzip encoding, for clients that accept either of them.
+// The default is false.
+func CompressResponses(enabled bool) HandlerOption {
+	return func(o *handlerOptions) { o.compress = enabled }
+}
+
+// CallHook is called before each call of an RPC function, with the context
+// of the call and the name of the method. It returns the context to make the
+// call with, and a function to call with the error of the call, if any, once
+// it returns. It may be used to trace or time calls.
+type CallHook func(ctx context.Context, method string) (context.Context, func(error))
+
+// start calls the hook, if not nil.
+func (h CallHook) start(ctx context.Context, method string) (context.Context, func(error)) {
+	if h == nil {
+		return ctx, func(error) {}
+	}
+	return h(ctx, method)
+}
+
+// OnCall sets the hook called around each call of an RPC function. The
+// default, nil, calls none.
+func OnCall(hook CallHook) HandlerOption {
+	return func(o *handlerOptions) { o.onCall = hook }
 }
 
 // Function introspection
@@ -43,6 +125,14 @@ type RPCFunc struct {
 	args    []argInfo     // names and type information (for URL decoding)
 	timeout time.Duration // default request timeout, 0 means none
 	ws      bool          // websocket only
+	tag     string        // group of the function in the OpenAPI document
+	summary string        // description of the function in the OpenAPI document
+
+	// reports whether the result of a call never changes, or nil
+	immutable func(param, result interface{}) bool
+
+	// the HTTP status of the GET response of a result, or nil for 200 OK
+	status func(result interface{}) int
 }
 
 // argInfo records the name of a field, along with a bit to tell whether the
@@ -51,13 +141,21 @@ type RPCFunc struct {
 // to be passed for either argument type.
 type argInfo struct {
 	name     string
-	isBinary bool // value wants binary data
+	isBinary bool         // value wants binary data
+	typ      reflect.Type // type of the field
 }
 
 // Call parses the given JSON parameters and calls the function wrapped by rf
 // with the resulting argument value. It reports an error if parameter parsing
 // fails, otherwise it returns the result from the wrapped function.
 func (rf *RPCFunc) Call(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	_, result, err := rf.call(ctx, params)
+	return result, err
+}
+
+// call behaves as Call, and also returns the parsed parameter value, or nil if
+// rf does not accept parameters.
+func (rf *RPCFunc) call(ctx context.Context, params json.RawMessage) (param, result interface{}, err error) {
 	// If ctx has its own deadline we will respect it; otherwise use rf.timeout.
 	if _, ok := ctx.Deadline(); !ok && rf.timeout > 0 {
 		var cancel context.CancelFunc
@@ -66,24 +164,27 @@ func (rf *RPCFunc) Call(ctx context.Context, params json.RawMessage) (interface{
 	}
 	args, err := rf.parseParams(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if len(args) > 1 {
+		param = args[1].Interface()
 	}
 	returns := rf.f.Call(args)
 
 	// Case 1: There is no non-error result type.
 	if rf.result == nil {
 		if oerr := returns[0].Interface(); oerr != nil {
-			return nil, oerr.(error)
+			return param, nil, oerr.(error)
 		}
-		return nil, nil
+		return param, nil, nil
 	}
 
 	// Case 2: There is a non-error result.
 	if oerr := returns[1].Interface(); oerr != nil {
 		// In case of error, report the error and ignore the result.
-		return nil, oerr.(error)
+		return param, nil, oerr.(error)
 	}
-	return returns[0].Interface(), nil
+	return param, returns[0].Interface(), nil
 }
 
 // Timeout updates rf to include a default timeout for calls to rf. This
@@ -91,6 +192,32 @@ func (rf *RPCFunc) Call(ctx context.Context, params json.RawMessage) (interface{
 // Setting d == 0 means there will be no timeout. Returns rf to allow chaining.
 func (rf *RPCFunc) Timeout(d time.Duration) *RPCFunc { rf.timeout = d; return rf }
 
+// Immutable updates rf to mark the responses of its GET route as cacheable by
+// HTTP caches, with the Cache-Control and ETag headers, when isImmutable
+// reports that the result of a call with the given parameter never changes,
+// e.g. for a block at an explicit height. The parameter is nil if rf does not
+// accept one. Returns rf to allow chaining.
+func (rf *RPCFunc) Immutable(isImmutable func(param, result interface{}) bool) *RPCFunc {
+	rf.immutable = isImmutable
+	return rf
+}
+
+// HTTPStatus updates rf to respond to its GET route with the HTTP status that
+// status returns for the result of a call, rather than 200 OK, e.g. for a
+// health check to fail with 503 Service Unavailable. The JSON-RPC responses
+// still have status 200 OK. Returns rf to allow chaining.
+func (rf *RPCFunc) HTTPStatus(status func(result interface{}) int) *RPCFunc {
+	rf.status = status
+	return rf
+}
+
+// Doc updates rf to include the tag grouping it and a one-line summary of it,
+// for the OpenAPI document of its routes. Returns rf to allow chaining.
+func (rf *RPCFunc) Doc(tag, summary string) *RPCFunc {
+	rf.tag, rf.summary = tag, summary
+	return rf
+}
+
 // parseParams parses the parameters of a JSON-RPC request and returns the
 // corresponding argument values. On success, the first argument value will be
 // the value of ctx.
@@ -216,6 +343,7 @@ func newRPCFunc(f interface{}) (*RPCFunc, error) {
 				args = append(args, argInfo{
 					name:     tag,
 					isBinary: isByteArray(field.Type),
+					typ:      field.Type,
 				})
 			} else if tag == "-" {
 				// If the tag is "-" the field should explicitly be ignored, even
@@ -228,6 +356,7 @@ func newRPCFunc(f interface{}) (*RPCFunc, error) {
 				args = append(args, argInfo{
 					name:     name,
 					isBinary: isByteArray(field.Type),
+					typ:      field.Type,
 				})
 			}
 		}
@@ -77,6 +77,9 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 	// register connection
 	logger := wm.logger.With("remote", wsConn.RemoteAddr())
 	conn := newWSConnection(wsConn, wm.funcMap, logger, wm.wsConnOptions...)
+	if conn.limiter != nil {
+		conn.clientKey = conn.limiter.clientKey(r)
+	}
 	wm.logger.Info("New websocket connection", "remote", conn.remoteAddr)
 
 	// starting the conn is blocking
@@ -123,6 +126,13 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// hook called around the calls, or nil
+	onCall CallHook
+
+	// rate limiter of the calls, and key of the client in it, or nil
+	limiter   *RateLimiter
+	clientKey string
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -182,6 +192,25 @@ func ReadLimit(readLimit int64) func(*wsConnection) {
 	}
 }
 
+// WSRateLimit sets the rate limiter of the calls of each client, as the
+// RateLimit option of RegisterRPCFuncs does for HTTP requests. Calls over the
+// limit get an error response. It should only be used in the constructor - not
+// Goroutine-safe.
+func WSRateLimit(l *RateLimiter) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.limiter = l
+	}
+}
+
+// WSOnCall sets the hook called around each call, as the OnCall option of
+// RegisterRPCFuncs does for HTTP requests. It should only be used in the
+// constructor - not Goroutine-safe.
+func WSOnCall(hook CallHook) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.onCall = hook
+	}
+}
+
 // Start starts the client service routines and blocks until there is an error.
 func (wsc *wsConnection) Start(ctx context.Context) error {
 	wsc.writeChan = make(chan rpctypes.RPCResponse, defaultWSWriteChanCapacity)
@@ -327,12 +356,23 @@ func (wsc *wsConnection) readRoutine(ctx context.Context) {
 				continue
 			}
 
+			if wsc.limiter != nil {
+				if ok, retryAfter := wsc.limiter.allow(wsc.clientKey, request.Method); !ok {
+					if err := wsc.WriteRPCResponse(writeCtx, limitExceededError(request, retryAfter)); err != nil {
+						wsc.Logger.Error("error writing RPC response", "err", err)
+					}
+					continue
+				}
+			}
+
 			fctx := rpctypes.WithCallInfo(wsc.Context(), &rpctypes.CallInfo{
 				RPCRequest: &request,
 				WSConn:     wsc,
 			})
 			var resp rpctypes.RPCResponse
+			fctx, done := wsc.onCall.start(fctx, request.Method)
 			result, err := rpcFunc.Call(fctx, request.Params)
+			done(err)
 			if err == nil {
 				resp = request.MakeResponse(result)
 			} else {
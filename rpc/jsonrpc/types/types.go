@@ -31,6 +31,10 @@ const (
 	CodeMethodNotFound ErrorCode = -32601 // The method does not exist or is unavailable
 	CodeInvalidParams  ErrorCode = -32602 // Invalid method parameters
 	CodeInternalError  ErrorCode = -32603 // Internal JSON-RPC error
+
+	// Implementation-defined server errors, from -32000 to -32099.
+
+	CodeLimitExceeded ErrorCode = -32005 // The client exceeded its rate limit
 )
 
 var errorCodeString = map[ErrorCode]string{
@@ -39,6 +43,7 @@ var errorCodeString = map[ErrorCode]string{
 	CodeMethodNotFound: "Method not found",
 	CodeInvalidParams:  "Invalid params",
 	CodeInternalError:  "Internal error",
+	CodeLimitExceeded:  "Limit exceeded",
 }
 
 //----------------------------------------
@@ -68,6 +68,7 @@ func CreateConfig(t *testing.T, testName string) (*config.Config, error) {
 	c.P2P.ListenAddress = p2pAddr
 	c.RPC.ListenAddress = rpcAddr
 	c.RPC.EventLogWindowSize = 5 * time.Minute
+	c.RPC.SimulateTx = true
 	c.Consensus.WalPath = "rpc-test"
 	c.RPC.CORSAllowedOrigins = []string{"https://tendermint.com/"}
 	return c, nil
@@ -15,6 +15,7 @@ import (
 	"math/rand"
 	"runtime"
 	"strconv"
+	"sync"
 
 	"github.com/creachadair/taskgroup"
 	"github.com/google/orderedcode"
@@ -403,7 +404,6 @@ var migrations = []migrationDefinition{
 
 // checkKeyType classifies a candidate key based on its structure.
 func checkKeyType(key keyID, storeName string) (keyType, error) {
-	var migrations []migrationDefinition
 	for _, m := range migrations {
 		if m.storeName != storeName {
 			continue
@@ -553,7 +553,7 @@ func getMigrationFunc(storeName string, key keyID) (*migrationDefinition, error)
 			if migration.prefix == nil {
 				return &migration, nil
 			}
-			if bytes.HasPrefix(migration.prefix, key) {
+			if bytes.HasPrefix(key, migration.prefix) {
 				return &migration, nil
 			}
 		}
@@ -614,6 +614,20 @@ func replaceKey(db dbm.DB, storeName string, key keyID) error {
 	return nil
 }
 
+// Option sets an optional parameter of Migrate.
+type Option func(*migrateOptions)
+
+type migrateOptions struct {
+	progress func(migrated, total int)
+}
+
+// WithProgress makes Migrate call fn after each key it processes, with the
+// number of keys processed so far and the total number of legacy keys in the
+// database. The calls come from several goroutines, but are never concurrent.
+func WithProgress(fn func(migrated, total int)) Option {
+	return func(o *migrateOptions) { o.progress = fn }
+}
+
 // Migrate converts all legacy key formats to new key formats. The
 // operation is idempotent, so it's safe to resume a failed
 // operation. The operation is somewhat parallelized, relying on the
@@ -626,12 +640,31 @@ func replaceKey(db dbm.DB, storeName string, key keyID) error {
 // The context allows for a safe termination of the operation
 // (e.g connected to a singal handler,) to abort the operation
 // in-between migration operations.
-func Migrate(ctx context.Context, storeName string, db dbm.DB) error {
+func Migrate(ctx context.Context, storeName string, db dbm.DB, options ...Option) error {
+	var opts migrateOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+
 	keys, err := getAllLegacyKeys(db, storeName)
 	if err != nil {
 		return err
 	}
 
+	var (
+		mtx      sync.Mutex
+		migrated int
+	)
+	report := func() {
+		if opts.progress == nil {
+			return
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		migrated++
+		opts.progress(migrated, len(keys))
+	}
+
 	var errs []string
 	g, start := taskgroup.New(func(err error) error {
 		errs = append(errs, err.Error())
@@ -644,6 +677,7 @@ func Migrate(ctx context.Context, storeName string, db dbm.DB) error {
 			if err := ctx.Err(); err != nil {
 				return err
 			}
+			defer report()
 			return replaceKey(db, storeName, key)
 		})
 	}
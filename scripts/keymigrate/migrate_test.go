@@ -1,12 +1,14 @@
 package keymigrate
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/google/orderedcode"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 )
 
 func makeKey(t *testing.T, elems ...interface{}) []byte {
@@ -128,6 +130,29 @@ func TestMigration(t *testing.T) {
 	})
 }
 
+func TestMigrateProgress(t *testing.T) {
+	db := dbm.NewMemDB()
+	for i := 1; i <= 10; i++ {
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("H:%d", i)), []byte("meta")))
+		require.NoError(t, db.Set([]byte(fmt.Sprintf("C:%d", i)), []byte("commit")))
+	}
+
+	var calls, last int
+	err := Migrate(context.Background(), "blockstore", db, WithProgress(func(migrated, total int) {
+		calls++
+		require.Equal(t, 20, total)
+		require.Equal(t, last+1, migrated)
+		last = migrated
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 20, calls)
+
+	// a second run finds no legacy keys left to migrate
+	calls = 0
+	require.NoError(t, Migrate(context.Background(), "blockstore", db, WithProgress(func(int, int) { calls++ })))
+	require.Zero(t, calls)
+}
+
 func TestGlobalDataStructuresForRefactor(t *testing.T) {
 	defer func() {
 		if t.Failed() {
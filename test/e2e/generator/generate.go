@@ -60,6 +60,8 @@ var (
 		"pause":      0.1,
 		"kill":       0.1,
 		"restart":    0.1,
+		"partition":  0.1,
+		"latency":    0.1,
 	}
 
 	// the following specify random chosen values for the entire testnet
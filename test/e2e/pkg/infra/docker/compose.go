@@ -33,18 +33,23 @@ networks:
       - subnet: {{ .IP }}
 
 services:
-{{- range .Nodes }}
+{{- range .Nodes }}{{ template "service" . }}{{ end }}
+{{- range .Twins }}{{ template "service" . }}{{ end }}
+{{- define "service" }}
   {{ .Name }}:
     labels:
       e2e: true
     container_name: {{ .Name }}
     image: tendermint/e2e-node
-{{- if isBuiltin $.ABCIProtocol .Mode }}
+{{- if isBuiltin .Testnet.ABCIProtocol .Mode }}
     entrypoint: /usr/bin/entrypoint-builtin
 {{- else if .LogLevel }}
     command: start --log-level {{ .LogLevel }}
 {{- end }}
     init: true
+    # NET_ADMIN lets the runner partition the network and delay traffic
+    cap_add:
+    - NET_ADMIN
     ports:
     - 26656
     - {{ if .ProxyPort }}{{ addUint32 .ProxyPort 1000 }}:{{ end }}26660
@@ -53,10 +58,9 @@ services:
     volumes:
     - ./{{ .Name }}:/tendermint
     networks:
-      {{ $.Name }}:
-        ipv{{ if $.IPv6 }}6{{ else }}4{{ end}}_address: {{ .IP }}
-
-{{end}}`)
+      {{ .Testnet.Name }}:
+        ipv{{ if .Testnet.IPv6 }}6{{ else }}4{{ end}}_address: {{ .IP }}
+{{ end }}`)
 	if err != nil {
 		return nil, err
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
@@ -56,6 +57,48 @@ func (ti *testnetInfra) ConnectNode(ctx context.Context, node *e2e.Node) error {
 	return execDocker(ctx, "network", "connect", ti.testnet.Name+"_"+ti.testnet.Name, node.Name)
 }
 
+func (ti *testnetInfra) PartitionNodes(ctx context.Context, a, b []*e2e.Node) error {
+	// each side drops the packets from the other, so that no connection can
+	// be established nor kept in either direction
+	for _, sides := range [][2][]*e2e.Node{{a, b}, {b, a}} {
+		for _, node := range sides[0] {
+			for _, peer := range sides[1] {
+				err := execDocker(ctx, "exec", node.Name, ti.iptables(), "-A", "INPUT", "-s", peer.IP.String(), "-j", "DROP")
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (ti *testnetInfra) HealPartition(ctx context.Context, nodes []*e2e.Node) error {
+	for _, node := range nodes {
+		if err := execDocker(ctx, "exec", node.Name, ti.iptables(), "-F", "INPUT"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iptables returns the iptables command for the IP version of the testnet.
+func (ti *testnetInfra) iptables() string {
+	if ti.testnet.IPv6() {
+		return "ip6tables"
+	}
+	return "iptables"
+}
+
+func (ti *testnetInfra) DelayNode(ctx context.Context, node *e2e.Node, latency time.Duration) error {
+	return execDocker(ctx, "exec", node.Name, "tc", "qdisc", "add", "dev", "eth0", "root", "netem",
+		"delay", fmt.Sprintf("%dms", latency.Milliseconds()), fmt.Sprintf("%dms", latency.Milliseconds()/4))
+}
+
+func (ti *testnetInfra) UndelayNode(ctx context.Context, node *e2e.Node) error {
+	return execDocker(ctx, "exec", node.Name, "tc", "qdisc", "del", "dev", "eth0", "root")
+}
+
 func (ti *testnetInfra) KillNodeProcess(ctx context.Context, node *e2e.Node) error {
 	return execCompose(ctx, ti.testnet.Dir, "kill", "-s", "SIGKILL", node.Name)
 }
@@ -2,6 +2,7 @@ package infra
 
 import (
 	"context"
+	"time"
 
 	e2e "github.com/tendermint/tendermint/test/e2e/pkg"
 )
@@ -54,6 +55,24 @@ type TestnetInfra interface {
 	// that it can become bidirectionally connected.
 	ConnectNode(ctx context.Context, node *e2e.Node) error
 
+	// PartitionNodes modifies the network configuration of the given nodes
+	// such that the nodes of a and the nodes of b cannot see each other, while
+	// nodes on the same side remain connected.
+	PartitionNodes(ctx context.Context, a, b []*e2e.Node) error
+
+	// HealPartition reverts the changes to the network configuration of the
+	// given nodes made by PartitionNodes.
+	HealPartition(ctx context.Context, nodes []*e2e.Node) error
+
+	// DelayNode modifies the specified node's network configuration such that
+	// its outgoing traffic is delayed by the given latency, with a jitter of up
+	// to a quarter of it.
+	DelayNode(ctx context.Context, node *e2e.Node, latency time.Duration) error
+
+	// UndelayNode reverts the changes to the network configuration of the node
+	// made by DelayNode.
+	UndelayNode(ctx context.Context, node *e2e.Node) error
+
 	// ShowNodeLogs prints all logs for the node with the give ID to stdout.
 	ShowNodeLogs(ctx context.Context, node *e2e.Node) error
 
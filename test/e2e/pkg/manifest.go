@@ -145,9 +145,18 @@ type ManifestNode struct {
 	// started and synced with the network:
 	//
 	// disconnect: temporarily disconnects the node from the network
+	// doublesign: temporarily runs a twin of the validator node, signing with
+	//             its key, and partitions the network between the two so that
+	//             they sign conflicting votes
 	// kill:       kills the node with SIGKILL then restarts it
+	// latency:    temporarily delays the network traffic of the node
+	// partition:  temporarily partitions the network into two random halves,
+	//             one including the node
 	// pause:      temporarily pauses (freezes) the node
 	// restart:    restarts the node, shutting it down with SIGTERM
+	//
+	// The perturbations are applied in the order of the nodes, then of the
+	// list, and their random choices are made with the seed of the runner.
 	Perturb []string `toml:"perturb"`
 
 	// Log level sets the log level of the specific node i.e. "info".
@@ -43,7 +43,10 @@ const (
 	ProtocolUNIX    Protocol = "unix"
 
 	PerturbationDisconnect Perturbation = "disconnect"
+	PerturbationDoubleSign Perturbation = "doublesign"
 	PerturbationKill       Perturbation = "kill"
+	PerturbationLatency    Perturbation = "latency"
+	PerturbationPartition  Perturbation = "partition"
 	PerturbationPause      Perturbation = "pause"
 	PerturbationRestart    Perturbation = "restart"
 
@@ -102,6 +105,10 @@ type Node struct {
 	LogLevel         string
 	QueueType        string
 	HasStarted       bool
+
+	// Twin is a second node signing with the validator key of the node, which
+	// is only started by doublesign perturbations. It is not part of Nodes.
+	Twin *Node
 }
 
 // LoadTestnet loads a testnet from a manifest file, using the filename to
@@ -256,6 +263,25 @@ func LoadTestnet(file string) (*Testnet, error) {
 		}
 	}
 
+	// Set up the twins of the nodes which double-sign, once all nodes have their
+	// IPs, ports and keys, so that these are the same with or without twins.
+	for _, node := range testnet.Nodes {
+		if !node.HasPerturbation(PerturbationDoubleSign) {
+			continue
+		}
+		twin := *node
+		twin.Name = node.Name + "-twin"
+		twin.NodeKey = keyGen.Generate("ed25519")
+		twin.IP = ipGen.Next()
+		twin.ProxyPort = proxyPortGen.Next()
+		twin.StartAt = 0
+		twin.StateSync = StateSyncDisabled
+		twin.SnapshotInterval = 0
+		twin.RetainBlocks = 0
+		twin.Perturbations = []Perturbation{}
+		node.Twin = &twin
+	}
+
 	// Set up genesis validators. If not specified explicitly, use all validator nodes.
 	if manifest.Validators != nil {
 		for validatorName, power := range *manifest.Validators {
@@ -393,7 +419,12 @@ func (n Node) Validate(testnet Testnet) error {
 
 	for _, perturbation := range n.Perturbations {
 		switch perturbation {
-		case PerturbationDisconnect, PerturbationKill, PerturbationPause, PerturbationRestart:
+		case PerturbationDisconnect, PerturbationKill, PerturbationLatency, PerturbationPartition,
+			PerturbationPause, PerturbationRestart:
+		case PerturbationDoubleSign:
+			if n.Mode != ModeValidator {
+				return fmt.Errorf("perturbation %q requires a validator", perturbation)
+			}
 		default:
 			return fmt.Errorf("invalid perturbation %q", perturbation)
 		}
@@ -440,6 +471,27 @@ func (t Testnet) HasPerturbations() bool {
 	return false
 }
 
+// Twins returns the twins of the nodes which double-sign.
+func (t Testnet) Twins() []*Node {
+	twins := []*Node{}
+	for _, node := range t.Nodes {
+		if node.Twin != nil {
+			twins = append(twins, node.Twin)
+		}
+	}
+	return twins
+}
+
+// HasPerturbation returns whether the node has the given perturbation.
+func (n Node) HasPerturbation(perturbation Perturbation) bool {
+	for _, p := range n.Perturbations {
+		if p == perturbation {
+			return true
+		}
+	}
+	return false
+}
+
 // Address returns a P2P endpoint address for the node.
 func (n Node) AddressP2P(withID bool) string {
 	ip := n.IP.String()
@@ -37,6 +37,7 @@ type CLI struct {
 	testnet  *e2e.Testnet
 	infra    infra.TestnetInfra
 	preserve bool
+	seed     int64
 }
 
 // NewCLI sets up the CLI.
@@ -89,7 +90,7 @@ func NewCLI(logger log.Logger) *CLI {
 				return err
 			}
 
-			r := rand.New(rand.NewSource(randomSeed)) // nolint: gosec
+			r := rand.New(rand.NewSource(cli.seed)) // nolint: gosec
 
 			chLoadResult := make(chan error)
 			ctx, cancel := context.WithCancel(cmd.Context())
@@ -110,7 +111,7 @@ func NewCLI(logger log.Logger) *CLI {
 			}
 
 			if cli.testnet.HasPerturbations() {
-				if err = Perturb(ctx, logger, cli.testnet, cli.infra); err != nil {
+				if err = Perturb(ctx, logger, r, cli.testnet, cli.infra); err != nil {
 					return err
 				}
 				if err = Wait(ctx, logger, cli.testnet, 5); err != nil { // allow some txs to go through
@@ -160,6 +161,9 @@ func NewCLI(logger log.Logger) *CLI {
 
 	cli.root.PersistentFlags().String("provider", "docker", "Which infrastructure provider to use")
 
+	cli.root.PersistentFlags().Int64Var(&cli.seed, "seed", randomSeed,
+		"Seed of the random choices of the transaction load, evidence and perturbations")
+
 	cli.root.Flags().BoolVarP(&cli.preserve, "preserve", "p", false,
 		"Preserves the running of the test net after tests are completed")
 
@@ -195,7 +199,13 @@ func NewCLI(logger log.Logger) *CLI {
 		Use:   "perturb",
 		Short: "Perturbs the Docker testnet, e.g. by restarting or disconnecting nodes",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return Perturb(cmd.Context(), logger, cli.testnet, cli.infra)
+			return Perturb(
+				cmd.Context(),
+				logger,
+				rand.New(rand.NewSource(cli.seed)), // nolint: gosec
+				cli.testnet,
+				cli.infra,
+			)
 		},
 	})
 
@@ -241,7 +251,7 @@ func NewCLI(logger log.Logger) *CLI {
 			return Load(
 				cmd.Context(),
 				logger,
-				rand.New(rand.NewSource(randomSeed)), // nolint: gosec
+				rand.New(rand.NewSource(cli.seed)), // nolint: gosec
 				cli.testnet,
 			)
 		},
@@ -264,7 +274,7 @@ func NewCLI(logger log.Logger) *CLI {
 			return InjectEvidence(
 				cmd.Context(),
 				logger,
-				rand.New(rand.NewSource(randomSeed)), // nolint: gosec
+				rand.New(rand.NewSource(cli.seed)), // nolint: gosec
 				cli.testnet,
 				amount,
 			)
@@ -350,7 +360,7 @@ Does not run any perbutations.
 			ctx, cancel := context.WithCancel(cmd.Context())
 			defer cancel()
 
-			r := rand.New(rand.NewSource(randomSeed)) // nolint: gosec
+			r := rand.New(rand.NewSource(cli.seed)) // nolint: gosec
 
 			lctx, loadCancel := context.WithCancel(ctx)
 			defer loadCancel()
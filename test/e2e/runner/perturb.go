@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"time"
 
 	"github.com/tendermint/tendermint/libs/log"
@@ -11,8 +12,13 @@ import (
 	"github.com/tendermint/tendermint/test/e2e/pkg/infra"
 )
 
-// Perturbs a running testnet.
-func Perturb(ctx context.Context, logger log.Logger, testnet *e2e.Testnet, ti infra.TestnetInfra) error {
+// perturbationLatency is the latency added to the traffic of a node by the
+// latency perturbation.
+const perturbationLatency = 500 * time.Millisecond
+
+// Perturbs a running testnet. The random choices of the perturbations, such as
+// the halves of partitions, are made with r.
+func Perturb(ctx context.Context, logger log.Logger, r *rand.Rand, testnet *e2e.Testnet, ti infra.TestnetInfra) error {
 	timer := time.NewTimer(0) // first tick fires immediately; reset below
 	defer timer.Stop()
 
@@ -22,7 +28,7 @@ func Perturb(ctx context.Context, logger log.Logger, testnet *e2e.Testnet, ti in
 			case <-ctx.Done():
 				return ctx.Err()
 			case <-timer.C:
-				_, err := PerturbNode(ctx, logger, node, perturbation, ti)
+				_, err := PerturbNode(ctx, logger, r, node, perturbation, ti)
 				if err != nil {
 					return err
 				}
@@ -37,7 +43,7 @@ func Perturb(ctx context.Context, logger log.Logger, testnet *e2e.Testnet, ti in
 
 // PerturbNode perturbs a node with a given perturbation, returning its status
 // after recovering.
-func PerturbNode(ctx context.Context, logger log.Logger, node *e2e.Node, perturbation e2e.Perturbation, ti infra.TestnetInfra) (*rpctypes.ResultStatus, error) {
+func PerturbNode(ctx context.Context, logger log.Logger, r *rand.Rand, node *e2e.Node, perturbation e2e.Perturbation, ti infra.TestnetInfra) (*rpctypes.ResultStatus, error) {
 	switch perturbation {
 	case e2e.PerturbationDisconnect:
 		logger.Info(fmt.Sprintf("Disconnecting node %v...", node.Name))
@@ -49,6 +55,34 @@ func PerturbNode(ctx context.Context, logger log.Logger, node *e2e.Node, perturb
 			return nil, err
 		}
 
+	case e2e.PerturbationDoubleSign:
+		logger.Info(fmt.Sprintf("Starting twin %v of node %v...", node.Twin.Name, node.Name))
+		status, err := waitForNode(ctx, logger, node, 0)
+		if err != nil {
+			return nil, err
+		}
+		if err := ti.StartNode(ctx, node.Twin); err != nil {
+			return nil, err
+		}
+		if _, err := waitForNode(ctx, logger, node.Twin, status.SyncInfo.LatestBlockHeight); err != nil {
+			return nil, err
+		}
+		// The twin and the node sign for different proposals, or none, on
+		// their side of the partition, and the votes conflict once healed.
+		a, b := splitNetwork(r, node.Testnet, node)
+		logger.Info(fmt.Sprintf("Partitioning node %v from its twin...", node.Name))
+		if err := ti.PartitionNodes(ctx, a, append(b, node.Twin)); err != nil {
+			return nil, err
+		}
+		time.Sleep(10 * time.Second)
+		if err := ti.HealPartition(ctx, append([]*e2e.Node{node.Twin}, node.Testnet.Nodes...)); err != nil {
+			return nil, err
+		}
+		time.Sleep(10 * time.Second)
+		if err := ti.KillNodeProcess(ctx, node.Twin); err != nil {
+			return nil, err
+		}
+
 	case e2e.PerturbationKill:
 		logger.Info(fmt.Sprintf("Killing node %v...", node.Name))
 		if err := ti.KillNodeProcess(ctx, node); err != nil {
@@ -59,6 +93,27 @@ func PerturbNode(ctx context.Context, logger log.Logger, node *e2e.Node, perturb
 			return nil, err
 		}
 
+	case e2e.PerturbationLatency:
+		logger.Info(fmt.Sprintf("Delaying the traffic of node %v by %v...", node.Name, perturbationLatency))
+		if err := ti.DelayNode(ctx, node, perturbationLatency); err != nil {
+			return nil, err
+		}
+		time.Sleep(20 * time.Second)
+		if err := ti.UndelayNode(ctx, node); err != nil {
+			return nil, err
+		}
+
+	case e2e.PerturbationPartition:
+		a, b := splitNetwork(r, node.Testnet, node)
+		logger.Info(fmt.Sprintf("Partitioning network between %v and %v...", nodeNames(a), nodeNames(b)))
+		if err := ti.PartitionNodes(ctx, a, b); err != nil {
+			return nil, err
+		}
+		time.Sleep(10 * time.Second)
+		if err := ti.HealPartition(ctx, node.Testnet.Nodes); err != nil {
+			return nil, err
+		}
+
 	case e2e.PerturbationPause:
 		logger.Info(fmt.Sprintf("Pausing node %v...", node.Name))
 		if err := ti.PauseNodeProcess(ctx, node); err != nil {
@@ -98,3 +153,27 @@ func PerturbNode(ctx context.Context, logger log.Logger, node *e2e.Node, perturb
 	logger.Info(fmt.Sprintf("Node %v recovered at height %v", node.Name, status.SyncInfo.LatestBlockHeight))
 	return status, nil
 }
+
+// splitNetwork splits the nodes of the testnet into two random halves, the
+// first one including the given node.
+func splitNetwork(r *rand.Rand, testnet *e2e.Testnet, node *e2e.Node) ([]*e2e.Node, []*e2e.Node) {
+	others := make([]*e2e.Node, 0, len(testnet.Nodes))
+	for _, other := range testnet.Nodes {
+		if other != node {
+			others = append(others, other)
+		}
+	}
+	r.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })
+
+	half := len(others) / 2
+	a := append([]*e2e.Node{node}, others[:half]...)
+	return a, others[half:]
+}
+
+func nodeNames(nodes []*e2e.Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
@@ -52,7 +52,8 @@ func Setup(ctx context.Context, logger log.Logger, testnet *e2e.Testnet, ti infr
 		return err
 	}
 
-	for _, node := range testnet.Nodes {
+	// the twins of the nodes are set up as the nodes, with the same validator key
+	for _, node := range append(testnet.Twins(), testnet.Nodes...) {
 		nodeDir := filepath.Join(testnet.Dir, node.Name)
 
 		dirs := []string{
@@ -21,6 +21,13 @@ func TestEvidence_Misbehavior(t *testing.T) {
 			seenEvidence += len(block.Evidence)
 		}
 	}
+	// the votes of the double-signing validators are expected to conflict, but
+	// how many times they do depends on timing
+	if len(testnet.Twins()) > 0 {
+		require.GreaterOrEqual(t, seenEvidence, testnet.Evidence,
+			"less evidence committed than produced")
+		return
+	}
 	require.Equal(t, testnet.Evidence, seenEvidence,
 		"difference between the amount of evidence produced and committed")
 }
@@ -126,6 +126,12 @@ func (b *Block) Hash() tmbytes.HexBytes {
 // This is the form in which the block is gossipped to peers.
 // CONTRACT: partSize is greater than zero.
 func (b *Block) MakePartSet(partSize uint32) (*PartSet, error) {
+	return b.MakePartSetParallel(partSize, 1)
+}
+
+// MakePartSetParallel is MakePartSet hashing the parts across up to workers
+// goroutines.
+func (b *Block) MakePartSetParallel(partSize uint32, workers int) (*PartSet, error) {
 	if b == nil {
 		return nil, errors.New("nil block")
 	}
@@ -140,7 +146,7 @@ func (b *Block) MakePartSet(partSize uint32) (*PartSet, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewPartSetFromData(bz, partSize), nil
+	return NewPartSetFromDataParallel(bz, partSize, workers), nil
 }
 
 // HashesTo is a convenience function that checks if a block hashes to the given argument.
@@ -228,6 +234,12 @@ func (b *Block) ToProto() (*tmproto.Block, error) {
 // FromProto sets a protobuf Block to the given pointer.
 // It returns an error if the block is invalid.
 func BlockFromProto(bp *tmproto.Block) (*Block, error) {
+	return BlockFromProtoParallel(bp, 1)
+}
+
+// BlockFromProtoParallel is BlockFromProto hashing the txs of the block
+// across up to workers goroutines.
+func BlockFromProtoParallel(bp *tmproto.Block, workers int) (*Block, error) {
 	if bp == nil {
 		return nil, errors.New("nil block")
 	}
@@ -255,6 +267,8 @@ func BlockFromProto(bp *tmproto.Block) (*Block, error) {
 		b.LastCommit = lc
 	}
 
+	// the hash of the txs is cached for ValidateBasic
+	b.Data.HashParallel(workers)
 	return b, b.ValidateBasic()
 }
 
@@ -1301,6 +1315,17 @@ func (data *Data) Hash() tmbytes.HexBytes {
 	return data.hash
 }
 
+// HashParallel is Hash hashing the txs across up to workers goroutines.
+func (data *Data) HashParallel(workers int) tmbytes.HexBytes {
+	if data == nil {
+		return (Txs{}).Hash()
+	}
+	if data.hash == nil {
+		data.hash = data.Txs.HashParallel(workers)
+	}
+	return data.hash
+}
+
 // StringIndented returns an indented string representation of the transactions.
 func (data *Data) StringIndented(indent string) string {
 	if data == nil {
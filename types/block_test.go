@@ -842,6 +842,26 @@ func TestBlockProtoBuf(t *testing.T) {
 	}
 }
 
+func TestBlockFromProtoParallel(t *testing.T) {
+	txs := make([]Tx, 20)
+	for i := range txs {
+		txs[i] = tmrand.Bytes(100)
+	}
+	b := MakeBlock(mrand.Int63(), txs, &Commit{Signatures: []CommitSig{}}, []Evidence{})
+	b.ProposerAddress = tmrand.Bytes(crypto.AddressSize)
+
+	pb, err := b.ToProto()
+	require.NoError(t, err)
+	block, err := BlockFromProtoParallel(pb, 4)
+	require.NoError(t, err)
+	assert.Equal(t, b.Data, block.Data)
+
+	// txs not matching the data hash fail
+	pb.Data.Txs[3] = []byte("wrong tx")
+	_, err = BlockFromProtoParallel(pb, 4)
+	assert.Error(t, err)
+}
+
 func TestDataProtoBuf(t *testing.T) {
 	data := &Data{Txs: Txs{Tx([]byte{1}), Tx([]byte{2}), Tx([]byte{3})}}
 	data2 := &Data{Txs: Txs{}}
@@ -43,6 +43,10 @@ const (
 	// Events emitted by the evidence reactor when evidence is validated
 	// and before it is committed
 	EventEvidenceValidatedValue = "EvidenceValidated"
+
+	// Emitted by the mempool when a transaction is evicted before it was
+	// committed.
+	EventEvictedTxValue = "EvictedTx"
 )
 
 // Pre-populated ABCI Tendermint-reserved events
@@ -86,6 +90,16 @@ var (
 			},
 		},
 	}
+
+	EventValidatorSetUpdates = abci.Event{
+		Type: strings.Split(EventTypeKey, ".")[0],
+		Attributes: []abci.EventAttribute{
+			{
+				Key:   strings.Split(EventTypeKey, ".")[1],
+				Value: EventValidatorSetUpdatesValue,
+			},
+		},
+	}
 )
 
 // ENCODING / DECODING
@@ -114,6 +128,7 @@ func init() {
 	jsontypes.MustRegister(EventDataValidatorSetUpdates{})
 	jsontypes.MustRegister(EventDataVote{})
 	jsontypes.MustRegister(EventDataEvidenceValidated{})
+	jsontypes.MustRegister(EventDataEvictedTx{})
 	jsontypes.MustRegister(EventDataString(""))
 }
 
@@ -141,6 +156,11 @@ type EventDataNewBlockHeader struct {
 
 	NumTxs              int64                      `json:"num_txs,string"` // Number of txs in a block
 	ResultFinalizeBlock abci.ResponseFinalizeBlock `json:"result_finalize_block"`
+
+	// ValidatorChanges are the changes of the validator set made by the
+	// validator updates of ResultFinalizeBlock, which are indexed with the
+	// events of the block.
+	ValidatorChanges []ValidatorChange `json:"validator_changes,omitempty"`
 }
 
 // TypeTag implements the required method of jsontypes.Tagged.
@@ -149,7 +169,17 @@ func (EventDataNewBlockHeader) TypeTag() string { return "tendermint/event/NewBl
 // ABCIEvents implements the eventlog.ABCIEventer interface.
 func (e EventDataNewBlockHeader) ABCIEvents() []abci.Event {
 	base := []abci.Event{eventWithAttr(BlockHeightKey, fmt.Sprint(e.Header.Height))}
-	return append(base, e.ResultFinalizeBlock.Events...)
+	return append(append(base, e.ResultFinalizeBlock.Events...), e.ValidatorChangeEvents()...)
+}
+
+// ValidatorChangeEvents returns the events of type EventTypeValidatorChange
+// of the ValidatorChanges, in their order.
+func (e EventDataNewBlockHeader) ValidatorChangeEvents() []abci.Event {
+	var events []abci.Event
+	for _, change := range e.ValidatorChanges {
+		events = append(events, change.ABCIEvent())
+	}
+	return events
 }
 
 type EventDataNewEvidence struct {
@@ -172,7 +202,7 @@ func (EventDataTx) TypeTag() string { return "tendermint/event/Tx" }
 // ABCIEvents implements the eventlog.ABCIEventer interface.
 func (e EventDataTx) ABCIEvents() []abci.Event {
 	base := []abci.Event{
-		eventWithAttr(TxHashKey, fmt.Sprintf("%X", Tx(e.Tx).Hash())),
+		eventWithAttr(TxHashKey, fmt.Sprintf("%X", Tx(e.Tx).ID())),
 		eventWithAttr(TxHeightKey, fmt.Sprintf("%d", e.Height)),
 	}
 	return append(base, e.Result.Events...)
@@ -229,11 +259,78 @@ func (EventDataString) TypeTag() string { return "tendermint/event/ProposalStrin
 
 type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
+
+	// Height is the height of the block whose results updated the validator
+	// set. The updates take effect at Height+2.
+	Height int64 `json:"height,string"`
+
+	// Changes are the changes of the validator set made by the updates, in
+	// their order.
+	Changes []ValidatorChange `json:"changes"`
 }
 
 // TypeTag implements the required method of jsontypes.Tagged.
 func (EventDataValidatorSetUpdates) TypeTag() string { return "tendermint/event/ValidatorSetUpdates" }
 
+// ABCIEvents implements the eventlog.ABCIEventer interface. Each change is an
+// event of type EventTypeValidatorChange, so that the changes of a validator
+// can be queried by its address or public key, e.g.
+// "validator_change.address='<hex>'".
+func (e EventDataValidatorSetUpdates) ABCIEvents() []abci.Event {
+	events := []abci.Event{eventWithAttr(BlockHeightKey, fmt.Sprint(e.Height))}
+	for _, change := range e.Changes {
+		events = append(events, change.ABCIEvent())
+	}
+	return events
+}
+
+// The types of ValidatorChange.
+const (
+	ValidatorChangeAdded        = "added"
+	ValidatorChangeRemoved      = "removed"
+	ValidatorChangePowerChanged = "power_changed"
+)
+
+// EventTypeValidatorChange is the type of the ABCI events of the changes of a
+// EventDataValidatorSetUpdates.
+const EventTypeValidatorChange = "validator_change"
+
+// ValidatorChange is the addition, removal, or change of the voting power of
+// a validator of the validator set.
+type ValidatorChange struct {
+	// Type is ValidatorChangeAdded, ValidatorChangeRemoved or
+	// ValidatorChangePowerChanged.
+	Type string `json:"type"`
+
+	// Validator is the validator, with its new voting power: zero if it is
+	// removed.
+	Validator *Validator `json:"validator"`
+
+	// PreviousPower is the voting power of the validator before the change:
+	// zero if it is added.
+	PreviousPower int64 `json:"previous_power,string"`
+}
+
+// ABCIEvent returns the change as an ABCI event of type
+// EventTypeValidatorChange, with the type of the change, the address and
+// public key of the validator in hex, and its new and previous powers.
+func (c ValidatorChange) ABCIEvent() abci.Event {
+	var pubKey string
+	if c.Validator.PubKey != nil {
+		pubKey = fmt.Sprintf("%X", c.Validator.PubKey.Bytes())
+	}
+	return abci.Event{
+		Type: EventTypeValidatorChange,
+		Attributes: []abci.EventAttribute{
+			{Key: "type", Value: c.Type, Index: true},
+			{Key: "address", Value: c.Validator.Address.String(), Index: true},
+			{Key: "pub_key", Value: pubKey, Index: true},
+			{Key: "power", Value: fmt.Sprint(c.Validator.VotingPower), Index: true},
+			{Key: "previous_power", Value: fmt.Sprint(c.PreviousPower), Index: true},
+		},
+	}
+}
+
 // EventDataBlockSyncStatus shows the fastsync status and the
 // height when the node state sync mechanism changes.
 type EventDataBlockSyncStatus struct {
@@ -263,6 +360,21 @@ type EventDataEvidenceValidated struct {
 // TypeTag implements the required method of jsontypes.Tagged.
 func (EventDataEvidenceValidated) TypeTag() string { return "tendermint/event/EvidenceValidated" }
 
+// EventDataEvictedTx is published when a transaction is evicted from the
+// mempool without being committed, e.g. because its TTL expired.
+type EventDataEvictedTx struct {
+	Tx     Tx     `json:"tx"`
+	Reason string `json:"reason"`
+}
+
+// TypeTag implements the required method of jsontypes.Tagged.
+func (EventDataEvictedTx) TypeTag() string { return "tendermint/event/EvictedTx" }
+
+// ABCIEvents implements the eventlog.ABCIEventer interface.
+func (e EventDataEvictedTx) ABCIEvents() []abci.Event {
+	return []abci.Event{eventWithAttr(TxHashKey, fmt.Sprintf("%X", e.Tx.ID()))}
+}
+
 // PUBSUB
 
 const (
@@ -298,12 +410,13 @@ var (
 	EventQueryValidBlock          = QueryForEvent(EventValidBlockValue)
 	EventQueryVote                = QueryForEvent(EventVoteValue)
 	EventQueryBlockSyncStatus     = QueryForEvent(EventBlockSyncStatusValue)
+	EventQueryEvictedTx           = QueryForEvent(EventEvictedTxValue)
 	EventQueryStateSyncStatus     = QueryForEvent(EventStateSyncStatusValue)
 	EventQueryEvidenceValidated   = QueryForEvent(EventEvidenceValidatedValue)
 )
 
 func EventQueryTxFor(tx Tx) *tmquery.Query {
-	return tmquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTxValue, TxHashKey, tx.Hash()))
+	return tmquery.MustCompile(fmt.Sprintf("%s='%s' AND %s='%X'", EventTypeKey, EventTxValue, TxHashKey, tx.ID()))
 }
 
 func QueryForEvent(eventValue string) *tmquery.Query {
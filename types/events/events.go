@@ -0,0 +1,201 @@
+// Package events decodes the ABCI events of the results of transactions and
+// blocks into Go values, so that clients don't have to parse the key and value
+// strings of their attributes by hand.
+//
+// A value is decoded from an event either into a tagged struct, whose fields
+// are tagged with the keys of the attributes they are decoded from:
+//
+//	type Transfer struct {
+//		Sender    string   `event:"sender"`
+//		Recipient string   `event:"recipient"`
+//		Amount    uint64   `event:"amount"`
+//		Memos     []string `event:"memo"` // each memo attribute
+//		Fees      Coins    `event:"fees,json"`
+//	}
+//
+// or into a protobuf message, whose fields are the attributes of the event,
+// keyed by their JSON names and with JSON values, as the typed events of the
+// Cosmos SDK.
+//
+// The types of the values of event types can be registered with Register, to
+// decode the events of the registered types with DecodeRegistered and
+// DecodeAll.
+package events
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// TagName is the name of the struct tag of the fields of tagged structs.
+const TagName = "event"
+
+// Decode decodes the attributes of the event into v, which must be a pointer
+// to a tagged struct or a protobuf message.
+//
+// The field of a tagged struct tagged with the key of an attribute is set to
+// the value of the attribute: as is for strings and byte slices, parsed for
+// booleans and numbers, with UnmarshalText for encoding.TextUnmarshaler
+// types, and as JSON for other types or when the tag has the json option, e.g.
+// `event:"fees,json"`. A slice field other than a byte slice is set to the
+// values of all the attributes with its key, in order, and other fields to
+// the value of the last one. Attributes without a field and fields without an
+// attribute are left out.
+func Decode(event abci.Event, v interface{}) error {
+	if msg, ok := v.(proto.Message); ok {
+		return decodeProto(event, msg)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot decode event %q into %T: not a pointer to a struct", event.Type, v)
+	}
+	return decodeStruct(event, rv.Elem())
+}
+
+// CompositeKeys returns the values of the attributes of the events by their
+// composite keys, "<type>.<key>", as used by event queries.
+func CompositeKeys(events []abci.Event) map[string][]string {
+	keys := make(map[string][]string)
+	for _, event := range events {
+		for _, attr := range event.Attributes {
+			key := event.Type + "." + attr.Key
+			keys[key] = append(keys[key], attr.Value)
+		}
+	}
+	return keys
+}
+
+func decodeStruct(event abci.Event, rv reflect.Value) error {
+	fields := make(map[string]int)
+	jsonFields := make(map[int]bool)
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		tag, ok := field.Tag.Lookup(TagName)
+		if !ok || tag == "-" || field.PkgPath != "" {
+			continue
+		}
+		key, options := tag, ""
+		if comma := strings.Index(tag, ","); comma >= 0 {
+			key, options = tag[:comma], tag[comma+1:]
+		}
+		fields[key] = i
+		jsonFields[i] = options == "json"
+	}
+
+	for _, attr := range event.Attributes {
+		i, ok := fields[attr.Key]
+		if !ok {
+			continue
+		}
+		field := rv.Field(i)
+		err := decodeValue(field, attr.Value, jsonFields[i])
+		if err != nil {
+			return fmt.Errorf("decoding attribute %q of event %q into %s: %w",
+				attr.Key, event.Type, rv.Type().Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// decodeValue sets the value v from the attribute value s.
+func decodeValue(v reflect.Value, s string, asJSON bool) error {
+	if asJSON {
+		return json.Unmarshal([]byte(s), v.Addr().Interface())
+	}
+	if reflect.PtrTo(v.Type()).Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(s))
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := decodeValue(elem, s, false); err != nil {
+			return err
+		}
+		v.Set(reflect.Append(v, elem))
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decodeValue(v.Elem(), s, false)
+	default:
+		return json.Unmarshal([]byte(s), v.Addr().Interface())
+	}
+	return nil
+}
+
+// decodeProto decodes the event into msg from the JSON object of its
+// attributes. The values which are not JSON are taken as strings.
+func decodeProto(event abci.Event, msg proto.Message) error {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, attr := range event.Attributes {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(attr.Key)
+		if err != nil {
+			return err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		if json.Valid([]byte(attr.Value)) {
+			buf.WriteString(attr.Value)
+		} else {
+			value, err := json.Marshal(attr.Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(value)
+		}
+	}
+	buf.WriteByte('}')
+
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := unmarshaler.Unmarshal(&buf, msg); err != nil {
+		return fmt.Errorf("decoding event %q into %T: %w", event.Type, msg, err)
+	}
+	return nil
+}
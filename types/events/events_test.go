@@ -0,0 +1,162 @@
+package events
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+type coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+type transfer struct {
+	Sender   string   `event:"sender"`
+	Amount   uint64   `event:"amount"`
+	Delta    int32    `event:"delta"`
+	Rate     float64  `event:"rate"`
+	Success  bool     `event:"success"`
+	Memos    []string `event:"memo"`
+	Raw      []byte   `event:"raw"`
+	Big      *big.Int `event:"big"`
+	Fees     []coin   `event:"fees,json"`
+	Ignored  string   `event:"-"`
+	Untagged string
+}
+
+func attrs(kvs ...string) []abci.EventAttribute {
+	attrs := make([]abci.EventAttribute, 0, len(kvs)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		attrs = append(attrs, abci.EventAttribute{Key: kvs[i], Value: kvs[i+1], Index: true})
+	}
+	return attrs
+}
+
+func TestDecode(t *testing.T) {
+	event := abci.Event{Type: "transfer", Attributes: attrs(
+		"sender", "alice",
+		"amount", "100",
+		"delta", "-5",
+		"rate", "0.5",
+		"success", "true",
+		"memo", "first",
+		"memo", "second",
+		"raw", "bytes",
+		"big", "123456789012345678901234567890",
+		"fees", `[{"denom":"swth","amount":"1"}]`,
+		"-", "ignored",
+		"Untagged", "ignored",
+		"unknown", "ignored",
+	)}
+
+	var v transfer
+	require.NoError(t, Decode(event, &v))
+	expected, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	require.Equal(t, transfer{
+		Sender:  "alice",
+		Amount:  100,
+		Delta:   -5,
+		Rate:    0.5,
+		Success: true,
+		Memos:   []string{"first", "second"},
+		Raw:     []byte("bytes"),
+		Big:     expected,
+		Fees:    []coin{{Denom: "swth", Amount: "1"}},
+	}, v)
+
+	// missing attributes are left out
+	v = transfer{}
+	require.NoError(t, Decode(abci.Event{Type: "transfer", Attributes: attrs("sender", "bob")}, &v))
+	require.Equal(t, transfer{Sender: "bob"}, v)
+
+	for _, invalid := range []abci.Event{
+		{Type: "transfer", Attributes: attrs("amount", "-1")},
+		{Type: "transfer", Attributes: attrs("delta", "3000000000")},
+		{Type: "transfer", Attributes: attrs("success", "yes")},
+		{Type: "transfer", Attributes: attrs("big", "1.5")},
+		{Type: "transfer", Attributes: attrs("fees", "swth")},
+	} {
+		err := Decode(invalid, &v)
+		require.Error(t, err, "%v", invalid)
+		require.Contains(t, err.Error(), `of event "transfer"`)
+	}
+
+	require.Error(t, Decode(event, v))
+	require.Error(t, Decode(event, new(string)))
+}
+
+func TestDecodeProto(t *testing.T) {
+	// EventAttribute stands in for a typed event, with a string, a JSON
+	// string and a JSON boolean attribute
+	event := abci.Event{Type: "tendermint.abci.EventAttribute", Attributes: attrs(
+		"key", "plain",
+		"value", `"quoted"`,
+		"index", "true",
+		"unknown", "1",
+	)}
+	var msg abci.EventAttribute
+	require.NoError(t, Decode(event, &msg))
+	require.Equal(t, abci.EventAttribute{Key: "plain", Value: "quoted", Index: true}, msg)
+
+	event.Attributes = attrs("index", `"not a bool"`)
+	require.Error(t, Decode(event, &msg))
+}
+
+func TestCompositeKeys(t *testing.T) {
+	require.Empty(t, CompositeKeys(nil))
+	require.Equal(t, map[string][]string{
+		"transfer.sender": {"alice", "bob"},
+		"transfer.amount": {"1"},
+		"message.action":  {"send"},
+	}, CompositeKeys([]abci.Event{
+		{Type: "transfer", Attributes: attrs("sender", "alice", "amount", "1")},
+		{Type: "message", Attributes: attrs("action", "send")},
+		{Type: "transfer", Attributes: attrs("sender", "bob")},
+		{Type: "empty"},
+	}))
+}
+
+func TestRegistry(t *testing.T) {
+	require.NoError(t, Register("transfer", transfer{}))
+	t.Cleanup(func() { unregister("transfer") })
+	require.NoError(t, RegisterProto(&abci.EventAttribute{}))
+	t.Cleanup(func() { unregister("tendermint.abci.EventAttribute") })
+
+	require.Error(t, Register("transfer", &transfer{}), "already registered")
+	require.Error(t, Register("", transfer{}))
+	require.Error(t, Register("string", ""))
+	require.Error(t, Register("nil", nil))
+	require.Equal(t, []string{"tendermint.abci.EventAttribute", "transfer"}, Registered())
+
+	events := []abci.Event{
+		{Type: "transfer", Attributes: attrs("sender", "alice", "amount", "1")},
+		{Type: "unregistered", Attributes: attrs("sender", "alice")},
+		{Type: "tendermint.abci.EventAttribute", Attributes: attrs("key", "k")},
+	}
+	values, err := DecodeAll(events)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		&transfer{Sender: "alice", Amount: 1},
+		&abci.EventAttribute{Key: "k"},
+	}, values)
+
+	_, err = DecodeRegistered(events[1])
+	require.True(t, errors.Is(err, ErrNotRegistered))
+
+	_, err = DecodeAll([]abci.Event{{Type: "transfer", Attributes: attrs("amount", "a lot")}})
+	require.Error(t, err)
+
+	var v transfer
+	found, err := DecodeFirst(events, "transfer", &v)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "alice", v.Sender)
+	found, err = DecodeFirst(events, "missing", &v)
+	require.NoError(t, err)
+	require.False(t, found)
+}
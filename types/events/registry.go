@@ -0,0 +1,127 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// ErrNotRegistered is returned by DecodeRegistered for the events of a type
+// whose Go type is not registered.
+var ErrNotRegistered = errors.New("event type not registered")
+
+var registry = struct {
+	mtx   sync.RWMutex
+	types map[string]reflect.Type
+}{types: make(map[string]reflect.Type)}
+
+// Register registers the Go type of the values decoded from the events of the
+// given type: the type of prototype, which must be a tagged struct, a pointer
+// to one, or a protobuf message. Register is intended to be called from init
+// functions and reports an error if the event type is empty or already
+// registered.
+func Register(eventType string, prototype interface{}) error {
+	if eventType == "" {
+		return errors.New("event type cannot be empty")
+	}
+	t := reflect.TypeOf(prototype)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return fmt.Errorf("event type %q: %T is not a struct or a pointer to one", eventType, prototype)
+	}
+
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	if registered, ok := registry.types[eventType]; ok {
+		return fmt.Errorf("event type %q is already registered to %v", eventType, registered)
+	}
+	registry.types[eventType] = t
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func MustRegister(eventType string, prototype interface{}) {
+	if err := Register(eventType, prototype); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterProto registers the type of the protobuf message for the events of
+// its full name, e.g. "cosmos.bank.v1beta1.EventTransfer", as the typed
+// events of the Cosmos SDK.
+func RegisterProto(msg proto.Message) error {
+	return Register(proto.MessageName(msg), msg)
+}
+
+// Registered returns the sorted event types whose Go types are registered.
+func Registered() []string {
+	registry.mtx.RLock()
+	defer registry.mtx.RUnlock()
+
+	eventTypes := make([]string, 0, len(registry.types))
+	for eventType := range registry.types {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+	return eventTypes
+}
+
+// unregister removes the event type from the registry. It is used by tests.
+func unregister(eventType string) {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	delete(registry.types, eventType)
+}
+
+// DecodeRegistered returns a pointer to a new value of the Go type registered
+// for the type of the event, decoded from it. It returns ErrNotRegistered if
+// no Go type is registered for the event type.
+func DecodeRegistered(event abci.Event) (interface{}, error) {
+	registry.mtx.RLock()
+	t, ok := registry.types[event.Type]
+	registry.mtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotRegistered, event.Type)
+	}
+
+	v := reflect.New(t).Interface()
+	if err := Decode(event, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// DecodeAll returns the values decoded from the events whose types have a
+// registered Go type, in order, leaving out the other events.
+func DecodeAll(events []abci.Event) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		v, err := DecodeRegistered(event)
+		if errors.Is(err, ErrNotRegistered) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+// DecodeFirst decodes the first event of the given type into v, as Decode,
+// and reports whether there is one.
+func DecodeFirst(events []abci.Event, eventType string, v interface{}) (bool, error) {
+	for _, event := range events {
+		if event.Type == eventType {
+			return true, Decode(event, v)
+		}
+	}
+	return false, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -124,6 +125,10 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		if v.Power == 0 {
 			return fmt.Errorf("the genesis file cannot contain validators with no voting power: %v", v)
 		}
+		if !genDoc.ConsensusParams.Validator.IsValidPubkeyType(v.PubKey.Type()) {
+			return fmt.Errorf("validator %v in the genesis file is using pubkey %s, which is not in the pub_key_types %v of the consensus params",
+				v, v.PubKey.Type(), genDoc.ConsensusParams.Validator.PubKeyTypes)
+		}
 		if len(v.Address) > 0 && !bytes.Equal(v.PubKey.Address(), v.Address) {
 			return fmt.Errorf("incorrect address for validator %v in the genesis file, should be %v", v, v.PubKey.Address())
 		}
@@ -158,14 +163,25 @@ func GenesisDocFromJSON(jsonBlob []byte) (*GenesisDoc, error) {
 }
 
 // GenesisDocFromFile reads JSON data from a file and unmarshalls it into a GenesisDoc.
+// The file is decoded as it is read, rather than read whole first.
 func GenesisDocFromFile(genDocFile string) (*GenesisDoc, error) {
-	jsonBlob, err := os.ReadFile(genDocFile)
+	f, err := os.Open(genDocFile)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't read GenesisDoc file: %w", err)
 	}
-	genDoc, err := GenesisDocFromJSON(jsonBlob)
-	if err != nil {
+	defer f.Close()
+
+	genDoc := GenesisDoc{}
+	dec := json.NewDecoder(f)
+	if err := dec.Decode(&genDoc); err != nil {
+		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error reading GenesisDoc at %s: unexpected data after the JSON object", genDocFile)
+	}
+
+	if err := genDoc.ValidateAndComplete(); err != nil {
 		return nil, fmt.Errorf("error reading GenesisDoc at %s: %w", genDocFile, err)
 	}
-	return genDoc, nil
+	return &genDoc, nil
 }
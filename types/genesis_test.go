@@ -3,12 +3,14 @@ package types
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	tmtime "github.com/tendermint/tendermint/libs/time"
 )
 
@@ -48,6 +50,14 @@ func TestGenesisBad(t *testing.T) {
 				`},"power":"10","name":""}` +
 				`]}`,
 		),
+		// pub_key type not allowed by the consensus params
+		[]byte(
+			`{"chain_id":"mychain", "validators":[` +
+				`{"pub_key":{` +
+				`"type":"tendermint/PubKeyEd25519","value":"AT/+aaL1eB0477Mud9JMm8Sh8BIvOYlPGC9KkIUmFaE="` +
+				`},"power":"10","name":""}` +
+				`],"consensus_params":{"validator":{"pub_key_types":["secp256k1"]}}}`,
+		),
 	}
 
 	for _, testCase := range testCases {
@@ -159,6 +169,44 @@ func TestGenesisSaveAs(t *testing.T) {
 	assert.Equal(t, genDoc2.Validators, genDoc.Validators)
 }
 
+func TestGenesisDocFromFile(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	genDoc.AppState = json.RawMessage(`{"accounts":[{"name":"alice","coins":10}]}`)
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	require.NoError(t, genDoc.SaveAs(path))
+
+	loaded, err := GenesisDocFromFile(path)
+	require.NoError(t, err)
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	fromJSON, err := GenesisDocFromJSON(bz)
+	require.NoError(t, err)
+	assert.Equal(t, fromJSON, loaded)
+	assert.JSONEq(t, string(genDoc.AppState), string(loaded.AppState))
+
+	// the file is validated like JSON data
+	require.NoError(t, os.WriteFile(path, []byte(`{"chain_id":"","app_state":{}}`), 0600))
+	_, err = GenesisDocFromFile(path)
+	require.Error(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(`{"chain_id":"abc","app_state":{`), 0600))
+	_, err = GenesisDocFromFile(path)
+	require.Error(t, err)
+	require.NoError(t, os.WriteFile(path, append(bz, []byte(`{}`)...), 0600))
+	_, err = GenesisDocFromFile(path)
+	require.Error(t, err)
+}
+
+func TestGenesisValidatorPubKeyTypes(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	secpKey := secp256k1.GenPrivKey().PubKey()
+	genDoc.Validators = append(genDoc.Validators, GenesisValidator{PubKey: secpKey, Power: 10})
+	assert.Error(t, genDoc.ValidateAndComplete())
+
+	genDoc.ConsensusParams.Validator.PubKeyTypes = []string{ABCIPubKeyTypeEd25519, ABCIPubKeyTypeSecp256k1}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	assert.Equal(t, secpKey.Address(), genDoc.Validators[1].Address)
+}
+
 func TestGenesisValidatorHash(t *testing.T) {
 	genDoc := randomGenesisDoc()
 	assert.NotEmpty(t, genDoc.ValidatorHash())
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,8 +16,14 @@ import (
 const (
 	maxNodeInfoSize = 10240 // 10KB
 	maxNumChannels  = 16    // plenty of room for upgrades, for now
+
+	maxNumChannelVersions = 16
 )
 
+// DefaultChannelVersion is the wire format version of the channels a node does
+// not advertise versions for.
+const DefaultChannelVersion uint32 = 1
+
 // Max size of the NodeInfo struct
 func MaxNodeInfoSize() int {
 	return maxNodeInfoSize
@@ -46,10 +53,17 @@ type NodeInfo struct {
 	Version string `json:"version"` // major.minor.revision
 	// FIXME: This should be changed to uint16 to be consistent with the updated channel type
 	Channels bytes.HexBytes `json:"channels"` // channels this node knows about
+	// ChannelVersions are the wire format versions of the channels of the
+	// node, for the channels supporting other versions than the default one.
+	ChannelVersions []ChannelVersions `json:"channel_versions,omitempty"`
 
 	// ASCIIText fields
 	Moniker string        `json:"moniker"` // arbitrary moniker
 	Other   NodeInfoOther `json:"other"`   // other application specific data
+
+	// KeyRotation, if set, records the node changing its node key to the one
+	// of NodeID, for its peers to move their records of its old node ID.
+	KeyRotation *NodeKeyRotation `json:"key_rotation,omitempty"`
 }
 
 // NodeInfoOther is the misc. applcation specific data
@@ -58,6 +72,13 @@ type NodeInfoOther struct {
 	RPCAddress string `json:"rpc_address"`
 }
 
+// ChannelVersions are the wire format versions of a channel a node supports,
+// in increasing order.
+type ChannelVersions struct {
+	ChannelID uint16   `json:"channel_id"`
+	Versions  []uint32 `json:"versions"`
+}
+
 // ID returns the node's peer ID.
 func (info NodeInfo) ID() NodeID {
 	return info.NodeID
@@ -101,6 +122,29 @@ func (info NodeInfo) Validate() error {
 		channels[ch] = struct{}{}
 	}
 
+	// Validate ChannelVersions - ensure they are for known channels, without
+	// duplicates, and in increasing order.
+	versionChannels := make(map[uint16]struct{})
+	for _, cv := range info.ChannelVersions {
+		if _, ok := channels[byte(cv.ChannelID)]; cv.ChannelID > 0xff || !ok {
+			return fmt.Errorf("info.ChannelVersions contains unknown channel id %v", cv.ChannelID)
+		}
+		if _, ok := versionChannels[cv.ChannelID]; ok {
+			return fmt.Errorf("info.ChannelVersions contains duplicate channel id %v", cv.ChannelID)
+		}
+		versionChannels[cv.ChannelID] = struct{}{}
+		if len(cv.Versions) == 0 || len(cv.Versions) > maxNumChannelVersions {
+			return fmt.Errorf("info.ChannelVersions for channel %v must have between 1 and %v versions, got %v",
+				cv.ChannelID, maxNumChannelVersions, len(cv.Versions))
+		}
+		for i, v := range cv.Versions {
+			if v == 0 || (i > 0 && v <= cv.Versions[i-1]) {
+				return fmt.Errorf("info.ChannelVersions for channel %v must be non-zero and increasing, got %v",
+					cv.ChannelID, cv.Versions)
+			}
+		}
+	}
+
 	if m, err := tmstrings.ASCIITrim(info.Moniker); err != nil || m == "" {
 		return fmt.Errorf("info.Moniker must be valid non-empty ASCII text without tabs, but got %v", info.Moniker)
 	}
@@ -121,6 +165,15 @@ func (info NodeInfo) Validate() error {
 		}
 	}
 
+	if info.KeyRotation != nil {
+		if err := info.KeyRotation.Verify(info.Network); err != nil {
+			return fmt.Errorf("info.KeyRotation is invalid: %w", err)
+		}
+		if info.KeyRotation.NewID() != info.NodeID {
+			return fmt.Errorf("info.KeyRotation is to node ID %v, expected %v", info.KeyRotation.NewID(), info.NodeID)
+		}
+	}
+
 	return nil
 }
 
@@ -172,6 +225,77 @@ func (info *NodeInfo) AddChannel(channel uint16) {
 	info.Channels = append(info.Channels, byte(channel))
 }
 
+// SetChannelVersions adds the channel to the node info if it's not already
+// there, with the wire format versions it supports.
+func (info *NodeInfo) SetChannelVersions(channel uint16, versions []uint32) {
+	info.AddChannel(channel)
+
+	sorted := make([]uint32, 0, len(versions))
+	sorted = append(sorted, versions...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	unique := sorted[:0]
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			unique = append(unique, v)
+		}
+	}
+
+	channelVersions := make([]ChannelVersions, 0, len(info.ChannelVersions)+1)
+	for _, cv := range info.ChannelVersions {
+		if cv.ChannelID != channel {
+			channelVersions = append(channelVersions, cv)
+		}
+	}
+	if len(unique) > 0 {
+		channelVersions = append(channelVersions, ChannelVersions{ChannelID: channel, Versions: unique})
+	}
+	info.ChannelVersions = channelVersions
+}
+
+// SupportedChannelVersions returns the wire format versions of the channel
+// the node supports, or nil if the node doesn't have the channel.
+func (info NodeInfo) SupportedChannelVersions(channel uint16) []uint32 {
+	found := false
+	for _, ch := range info.Channels {
+		if uint16(ch) == channel {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	for _, cv := range info.ChannelVersions {
+		if cv.ChannelID == channel {
+			return cv.Versions
+		}
+	}
+	return []uint32{DefaultChannelVersion}
+}
+
+// NegotiateChannelVersions returns the highest wire format version both nodes
+// support for each of their common channels. Common channels without a
+// version supported by both nodes are left out.
+func (info NodeInfo) NegotiateChannelVersions(other NodeInfo) map[uint16]uint32 {
+	negotiated := make(map[uint16]uint32)
+	for _, ch := range info.Channels {
+		ours := info.SupportedChannelVersions(uint16(ch))
+		theirs := other.SupportedChannelVersions(uint16(ch))
+		for i, j := len(ours)-1, len(theirs)-1; i >= 0 && j >= 0; {
+			switch {
+			case ours[i] == theirs[j]:
+				negotiated[uint16(ch)] = ours[i]
+				i, j = -1, -1
+			case ours[i] > theirs[j]:
+				i--
+			default:
+				j--
+			}
+		}
+	}
+	return negotiated
+}
+
 func (info NodeInfo) Copy() NodeInfo {
 	return NodeInfo{
 		ProtocolVersion: info.ProtocolVersion,
@@ -180,8 +304,10 @@ func (info NodeInfo) Copy() NodeInfo {
 		Network:         info.Network,
 		Version:         info.Version,
 		Channels:        info.Channels,
+		ChannelVersions: info.ChannelVersions,
 		Moniker:         info.Moniker,
 		Other:           info.Other,
+		KeyRotation:     info.KeyRotation,
 	}
 }
 
@@ -199,11 +325,23 @@ func (info NodeInfo) ToProto() *tmp2p.NodeInfo {
 	dni.Network = info.Network
 	dni.Version = info.Version
 	dni.Channels = info.Channels
+	for _, cv := range info.ChannelVersions {
+		dni.ChannelVersions = append(dni.ChannelVersions, tmp2p.ChannelVersions{
+			ChannelID: uint32(cv.ChannelID),
+			Versions:  cv.Versions,
+		})
+	}
 	dni.Moniker = info.Moniker
 	dni.Other = tmp2p.NodeInfoOther{
 		TxIndex:    info.Other.TxIndex,
 		RPCAddress: info.Other.RPCAddress,
 	}
+	if info.KeyRotation != nil {
+		// an invalid rotation fails Validate, and is not sent
+		if rotation, err := info.KeyRotation.toProto(); err == nil {
+			dni.KeyRotation = rotation
+		}
+	}
 
 	return dni
 }
@@ -229,6 +367,22 @@ func NodeInfoFromProto(pb *tmp2p.NodeInfo) (NodeInfo, error) {
 			RPCAddress: pb.Other.RPCAddress,
 		},
 	}
+	for _, cv := range pb.ChannelVersions {
+		if cv.ChannelID > 0xffff {
+			return NodeInfo{}, fmt.Errorf("invalid channel id %v", cv.ChannelID)
+		}
+		dni.ChannelVersions = append(dni.ChannelVersions, ChannelVersions{
+			ChannelID: uint16(cv.ChannelID),
+			Versions:  cv.Versions,
+		})
+	}
+	if pb.KeyRotation != nil {
+		rotation, err := NodeKeyRotationFromProto(pb.KeyRotation)
+		if err != nil {
+			return NodeInfo{}, err
+		}
+		dni.KeyRotation = rotation
+	}
 
 	return dni, nil
 }
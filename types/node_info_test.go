@@ -70,6 +70,25 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *NodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Unknown ChannelVersions channel", func(ni *NodeInfo) {
+			ni.ChannelVersions = []ChannelVersions{{ChannelID: 0x99, Versions: []uint32{1}}}
+		}, true},
+		{"Duplicate ChannelVersions channel", func(ni *NodeInfo) {
+			ni.ChannelVersions = []ChannelVersions{
+				{ChannelID: testCh, Versions: []uint32{1}},
+				{ChannelID: testCh, Versions: []uint32{2}},
+			}
+		}, true},
+		{"Empty ChannelVersions", func(ni *NodeInfo) {
+			ni.ChannelVersions = []ChannelVersions{{ChannelID: testCh}}
+		}, true},
+		{"Unordered ChannelVersions", func(ni *NodeInfo) {
+			ni.ChannelVersions = []ChannelVersions{{ChannelID: testCh, Versions: []uint32{2, 1}}}
+		}, true},
+		{"Good ChannelVersions", func(ni *NodeInfo) {
+			ni.ChannelVersions = []ChannelVersions{{ChannelID: testCh, Versions: []uint32{1, 2}}}
+		}, false},
 	}
 
 	nodeKeyID := testNodeID()
@@ -178,6 +197,52 @@ func TestNodeInfoAddChannel(t *testing.T) {
 	require.Contains(t, nodeInfo.Channels, byte(0x02))
 }
 
+func TestNodeInfoSetChannelVersions(t *testing.T) {
+	nodeInfo := testNodeInfo(t, testNodeID(), "testing")
+	nodeInfo.Channels = []byte{}
+
+	nodeInfo.SetChannelVersions(2, []uint32{3, 1, 3})
+	require.Contains(t, nodeInfo.Channels, byte(0x02))
+	require.Equal(t, []uint32{1, 3}, nodeInfo.SupportedChannelVersions(2))
+	require.NoError(t, nodeInfo.Validate())
+
+	// setting the versions again replaces them
+	nodeInfo.SetChannelVersions(2, []uint32{2})
+	require.Equal(t, []ChannelVersions{{ChannelID: 2, Versions: []uint32{2}}}, nodeInfo.ChannelVersions)
+
+	// channels without versions only support the default one
+	nodeInfo.SetChannelVersions(2, nil)
+	require.Empty(t, nodeInfo.ChannelVersions)
+	require.Equal(t, []uint32{DefaultChannelVersion}, nodeInfo.SupportedChannelVersions(2))
+	require.Nil(t, nodeInfo.SupportedChannelVersions(3))
+
+	nodeInfo.SetChannelVersions(2, []uint32{1, 2})
+	fromProto, err := NodeInfoFromProto(nodeInfo.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, nodeInfo.ChannelVersions, fromProto.ChannelVersions)
+}
+
+func TestNodeInfoNegotiateChannelVersions(t *testing.T) {
+	ni1 := testNodeInfo(t, testNodeID(), "testing")
+	ni1.Channels = []byte{1, 2, 3, 4}
+	ni1.ChannelVersions = []ChannelVersions{
+		{ChannelID: 2, Versions: []uint32{1, 2, 3}},
+		{ChannelID: 3, Versions: []uint32{2}},
+		{ChannelID: 4, Versions: []uint32{1, 2}},
+	}
+
+	ni2 := testNodeInfo(t, testNodeID(), "testing")
+	ni2.Channels = []byte{1, 2, 3, 4, 5}
+	ni2.ChannelVersions = []ChannelVersions{
+		{ChannelID: 2, Versions: []uint32{1, 2, 4}},
+		{ChannelID: 4, Versions: []uint32{1}},
+	}
+
+	expected := map[uint16]uint32{1: 1, 2: 2, 4: 1}
+	require.Equal(t, expected, ni1.NegotiateChannelVersions(ni2))
+	require.Equal(t, expected, ni2.NegotiateChannelVersions(ni1))
+}
+
 func TestParseAddressString(t *testing.T) {
 	testCases := []struct {
 		name     string
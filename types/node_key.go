@@ -6,6 +6,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	_ "github.com/tendermint/tendermint/crypto/provider" // decodes the keys of crypto providers
 	"github.com/tendermint/tendermint/internal/jsontypes"
 	tmos "github.com/tendermint/tendermint/libs/os"
 )
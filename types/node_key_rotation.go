@@ -0,0 +1,218 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/internal/jsontypes"
+	tmp2p "github.com/tendermint/tendermint/proto/tendermint/p2p"
+)
+
+// NodeKeyRotation records a node changing its node key, and so its node ID,
+// on a network. It is signed by the old node key, authorizing the change, and
+// by the new one, proving its possession. The node advertises it in its
+// NodeInfo, and its peers then move their records of the old node ID, such as
+// its score and addresses, to the new node ID.
+type NodeKeyRotation struct {
+	OldPubKey    crypto.PubKey
+	NewPubKey    crypto.PubKey
+	Network      string
+	Time         time.Time
+	OldSignature []byte
+	NewSignature []byte
+}
+
+type nodeKeyRotationJSON struct {
+	OldID        NodeID          `json:"old_id"`
+	NewID        NodeID          `json:"new_id"`
+	OldPubKey    json.RawMessage `json:"old_pub_key"`
+	NewPubKey    json.RawMessage `json:"new_pub_key"`
+	Network      string          `json:"network"`
+	Time         time.Time       `json:"time"`
+	OldSignature []byte          `json:"old_signature"`
+	NewSignature []byte          `json:"new_signature"`
+}
+
+// NewNodeKeyRotation creates the record of the node changing its node key
+// from oldKey to newKey on the network at the time, signed by both keys.
+func NewNodeKeyRotation(oldKey, newKey NodeKey, network string, t time.Time) (*NodeKeyRotation, error) {
+	r := &NodeKeyRotation{
+		OldPubKey: oldKey.PubKey(),
+		NewPubKey: newKey.PubKey(),
+		Network:   network,
+		Time:      t.UTC(),
+	}
+	signBytes, err := r.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	if r.OldSignature, err = oldKey.PrivKey.Sign(signBytes); err != nil {
+		return nil, fmt.Errorf("failed to sign with the old node key: %w", err)
+	}
+	if r.NewSignature, err = newKey.PrivKey.Sign(signBytes); err != nil {
+		return nil, fmt.Errorf("failed to sign with the new node key: %w", err)
+	}
+	return r, nil
+}
+
+// OldID returns the node ID of the old node key.
+func (r *NodeKeyRotation) OldID() NodeID {
+	return NodeIDFromPubKey(r.OldPubKey)
+}
+
+// NewID returns the node ID of the new node key.
+func (r *NodeKeyRotation) NewID() NodeID {
+	return NodeIDFromPubKey(r.NewPubKey)
+}
+
+// SignBytes returns the bytes signed by both node keys: the encoded record
+// without its signatures.
+func (r *NodeKeyRotation) SignBytes() ([]byte, error) {
+	pb, err := r.toProto()
+	if err != nil {
+		return nil, err
+	}
+	pb.OldSignature, pb.NewSignature = nil, nil
+	return pb.Marshal()
+}
+
+// Verify checks that the record is for the network, changes the node ID and
+// is signed by both node keys.
+func (r *NodeKeyRotation) Verify(network string) error {
+	if r.OldPubKey == nil || r.NewPubKey == nil {
+		return errors.New("node key rotation must have the old and the new public keys")
+	}
+	if r.Network != network {
+		return fmt.Errorf("node key rotation is for network %q, expected %q", r.Network, network)
+	}
+	if r.OldID() == r.NewID() {
+		return errors.New("node key rotation must change the node ID")
+	}
+	signBytes, err := r.SignBytes()
+	if err != nil {
+		return err
+	}
+	if !r.OldPubKey.VerifySignature(signBytes, r.OldSignature) {
+		return errors.New("invalid signature of the old node key")
+	}
+	if !r.NewPubKey.VerifySignature(signBytes, r.NewSignature) {
+		return errors.New("invalid signature of the new node key")
+	}
+	return nil
+}
+
+func (r *NodeKeyRotation) toProto() (*tmp2p.NodeKeyRotation, error) {
+	if r.OldPubKey == nil || r.NewPubKey == nil {
+		return nil, errors.New("node key rotation must have the old and the new public keys")
+	}
+	oldPubKey, err := encoding.PubKeyToProto(r.OldPubKey)
+	if err != nil {
+		return nil, err
+	}
+	newPubKey, err := encoding.PubKeyToProto(r.NewPubKey)
+	if err != nil {
+		return nil, err
+	}
+	return &tmp2p.NodeKeyRotation{
+		OldPubKey:    oldPubKey,
+		NewPubKey:    newPubKey,
+		Network:      r.Network,
+		Time:         r.Time,
+		OldSignature: r.OldSignature,
+		NewSignature: r.NewSignature,
+	}, nil
+}
+
+// NodeKeyRotationFromProto converts the Protobuf record to a NodeKeyRotation.
+func NodeKeyRotationFromProto(pb *tmp2p.NodeKeyRotation) (*NodeKeyRotation, error) {
+	if pb == nil {
+		return nil, errors.New("nil node key rotation")
+	}
+	oldPubKey, err := encoding.PubKeyFromProto(pb.OldPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid old public key: %w", err)
+	}
+	newPubKey, err := encoding.PubKeyFromProto(pb.NewPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid new public key: %w", err)
+	}
+	return &NodeKeyRotation{
+		OldPubKey:    oldPubKey,
+		NewPubKey:    newPubKey,
+		Network:      pb.Network,
+		Time:         pb.Time,
+		OldSignature: pb.OldSignature,
+		NewSignature: pb.NewSignature,
+	}, nil
+}
+
+func (r NodeKeyRotation) MarshalJSON() ([]byte, error) {
+	oldPubKey, err := jsontypes.Marshal(r.OldPubKey)
+	if err != nil {
+		return nil, err
+	}
+	newPubKey, err := jsontypes.Marshal(r.NewPubKey)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(nodeKeyRotationJSON{
+		OldID:        r.OldID(),
+		NewID:        r.NewID(),
+		OldPubKey:    oldPubKey,
+		NewPubKey:    newPubKey,
+		Network:      r.Network,
+		Time:         r.Time,
+		OldSignature: r.OldSignature,
+		NewSignature: r.NewSignature,
+	})
+}
+
+func (r *NodeKeyRotation) UnmarshalJSON(data []byte) error {
+	var rjson nodeKeyRotationJSON
+	if err := json.Unmarshal(data, &rjson); err != nil {
+		return err
+	}
+	var oldPubKey, newPubKey crypto.PubKey
+	if err := jsontypes.Unmarshal(rjson.OldPubKey, &oldPubKey); err != nil {
+		return err
+	}
+	if err := jsontypes.Unmarshal(rjson.NewPubKey, &newPubKey); err != nil {
+		return err
+	}
+	*r = NodeKeyRotation{
+		OldPubKey:    oldPubKey,
+		NewPubKey:    newPubKey,
+		Network:      rjson.Network,
+		Time:         rjson.Time,
+		OldSignature: rjson.OldSignature,
+		NewSignature: rjson.NewSignature,
+	}
+	return nil
+}
+
+// SaveAs persists the NodeKeyRotation to filePath.
+func (r NodeKeyRotation) SaveAs(filePath string) error {
+	jsonBytes, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, jsonBytes, 0600)
+}
+
+// LoadNodeKeyRotation loads the NodeKeyRotation located in filePath.
+func LoadNodeKeyRotation(filePath string) (*NodeKeyRotation, error) {
+	jsonBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	r := new(NodeKeyRotation)
+	if err := json.Unmarshal(jsonBytes, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeKeyRotation(t *testing.T) {
+	oldKey, newKey := GenNodeKey(), GenNodeKey()
+	rotation, err := NewNodeKeyRotation(oldKey, newKey, "test-chain", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, oldKey.ID, rotation.OldID())
+	assert.Equal(t, newKey.ID, rotation.NewID())
+	require.NoError(t, rotation.Verify("test-chain"))
+	assert.Error(t, rotation.Verify("other-chain"))
+
+	pb, err := rotation.toProto()
+	require.NoError(t, err)
+	decoded, err := NodeKeyRotationFromProto(pb)
+	require.NoError(t, err)
+	assert.Equal(t, rotation, decoded)
+
+	path := filepath.Join(t.TempDir(), "node_key_rotation.json")
+	require.NoError(t, rotation.SaveAs(path))
+	loaded, err := LoadNodeKeyRotation(path)
+	require.NoError(t, err)
+	require.NoError(t, loaded.Verify("test-chain"))
+	assert.Equal(t, rotation.NewID(), loaded.NewID())
+
+	// the record must be signed by both keys, over all of its fields
+	for name, tamper := range map[string]func(r *NodeKeyRotation){
+		"old signature": func(r *NodeKeyRotation) { r.OldSignature = r.NewSignature },
+		"new signature": func(r *NodeKeyRotation) { r.NewSignature = r.OldSignature },
+		"time":          func(r *NodeKeyRotation) { r.Time = r.Time.Add(time.Second) },
+		"new key":       func(r *NodeKeyRotation) { r.NewPubKey = GenNodeKey().PubKey() },
+		"same key":      func(r *NodeKeyRotation) { r.NewPubKey = r.OldPubKey },
+	} {
+		tampered := *rotation
+		tamper(&tampered)
+		assert.Error(t, tampered.Verify("test-chain"), name)
+	}
+}
+
+func TestNodeInfoKeyRotation(t *testing.T) {
+	oldKey, newKey := GenNodeKey(), GenNodeKey()
+	rotation, err := NewNodeKeyRotation(oldKey, newKey, "testing", time.Now())
+	require.NoError(t, err)
+
+	ni := testNodeInfo(t, newKey.ID, "testing")
+	ni.KeyRotation = rotation
+	require.NoError(t, ni.Validate())
+
+	decoded, err := NodeInfoFromProto(ni.ToProto())
+	require.NoError(t, err)
+	require.NotNil(t, decoded.KeyRotation)
+	assert.Equal(t, oldKey.ID, decoded.KeyRotation.OldID())
+	require.NoError(t, decoded.Validate())
+
+	// the rotation must be to the node ID, on the network
+	ni = testNodeInfo(t, oldKey.ID, "testing")
+	ni.KeyRotation = rotation
+	assert.Error(t, ni.Validate())
+	ni = testNodeInfoWithNetwork(t, newKey.ID, "testing", "other")
+	ni.KeyRotation = rotation
+	assert.Error(t, ni.Validate())
+}
@@ -170,6 +170,12 @@ type PartSet struct {
 // The data bytes are split into "partSize" chunks, and merkle tree computed.
 // CONTRACT: partSize is greater than zero.
 func NewPartSetFromData(data []byte, partSize uint32) *PartSet {
+	return NewPartSetFromDataParallel(data, partSize, 1)
+}
+
+// NewPartSetFromDataParallel is NewPartSetFromData hashing the parts across up
+// to workers goroutines.
+func NewPartSetFromDataParallel(data []byte, partSize uint32, workers int) *PartSet {
 	// divide data into 4kb parts.
 	total := (uint32(len(data)) + partSize - 1) / partSize
 	parts := make([]*Part, total)
@@ -185,7 +191,7 @@ func NewPartSetFromData(data []byte, partSize uint32) *PartSet {
 		partsBitArray.SetIndex(int(i), true)
 	}
 	// Compute merkle proofs
-	root, proofs := merkle.ProofsFromByteSlices(partsBytes)
+	root, proofs := merkle.ProofsFromByteSlicesParallel(partsBytes, workers)
 	for i := uint32(0); i < total; i++ {
 		parts[i].Proof = *proofs[i]
 	}
@@ -67,6 +67,17 @@ func TestBasicPartSet(t *testing.T) {
 	assert.Equal(t, data, data2)
 }
 
+func TestPartSetParallel(t *testing.T) {
+	data := tmrand.Bytes(testPartSize*10 + 100)
+	partSet := NewPartSetFromData(data, testPartSize)
+	partSet2 := NewPartSetFromDataParallel(data, testPartSize, 4)
+
+	assert.Equal(t, partSet.Header(), partSet2.Header())
+	for i := 0; i < int(partSet.Total()); i++ {
+		assert.Equal(t, partSet.GetPart(i), partSet2.GetPart(i))
+	}
+}
+
 func TestWrongProof(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping test in short mode")
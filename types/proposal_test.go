@@ -255,6 +255,37 @@ func TestIsTimely(t *testing.T) {
 			msgDelay:     time.Nanosecond,
 			expectTimely: false,
 		},
+		{
+			// Checking that the lower bound is inclusive:
+			// 2 - 2 <= 0 <= 2 + 1 + 2
+			name:         "local time at lower bound",
+			proposalTime: genesisTime.Add(2 * time.Nanosecond),
+			recvTime:     genesisTime,
+			precision:    time.Nanosecond * 2,
+			msgDelay:     time.Nanosecond,
+			expectTimely: true,
+		},
+		{
+			// Checking that the upper bound is inclusive:
+			// 0 - 2 <= 3 <= 0 + 1 + 2
+			name:         "local time at upper bound",
+			proposalTime: genesisTime,
+			recvTime:     genesisTime.Add(3 * time.Nanosecond),
+			precision:    time.Nanosecond * 2,
+			msgDelay:     time.Nanosecond,
+			expectTimely: true,
+		},
+		{
+			// Checking that the message delay is not adapted before 10 rounds:
+			// 0 - 2 <= 4 <= 0 + 1 + 2
+			name:         "message delay not adapted before 10 rounds",
+			proposalTime: genesisTime,
+			recvTime:     genesisTime.Add(4 * time.Nanosecond),
+			precision:    time.Nanosecond * 2,
+			msgDelay:     time.Nanosecond,
+			expectTimely: false,
+			round:        9,
+		},
 		{
 			// Checking that the following inequality evaluates to true:
 			// 0 - (2 * 2)  <= 4 <= 0 + (1 * 2) + 2
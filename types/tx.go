@@ -10,6 +10,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/merkle"
+	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -38,6 +39,15 @@ func (txs Txs) Hash() []byte {
 	return merkle.HashFromByteSlices(hl)
 }
 
+// HashParallel is Hash hashing the txs across up to workers goroutines.
+func (txs Txs) HashParallel(workers int) []byte {
+	hl := make([][]byte, len(txs))
+	tmsync.ForEach(len(txs), workers, func(i int) {
+		hl[i] = txs[i].Hash()
+	})
+	return merkle.HashFromByteSlices(hl)
+}
+
 // Index returns the index of this transaction in the list, or -1 if not found
 func (txs Txs) Index(tx Tx) int {
 	for i := range txs {
@@ -159,6 +169,12 @@ func (t TxRecordSet) IncludedTxs() []Tx {
 	return t.included
 }
 
+// AddedTxs returns the transactions marked as added by the application, in
+// the order they were present in the list of TxRecords.
+func (t TxRecordSet) AddedTxs() []Tx {
+	return t.added
+}
+
 // RemovedTxs returns the transactions marked for removal by the application.
 func (t TxRecordSet) RemovedTxs() []Tx {
 	return t.removed
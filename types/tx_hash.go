@@ -0,0 +1,101 @@
+package types
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// TxHashSHA256 is the name of the default tx hash function, the SHA256 hash
+// of the tx bytes.
+const TxHashSHA256 = "sha256"
+
+// TxHashFunc computes the ID of a tx, such as the native tx ID of the
+// application. It must not return an empty ID, and it must be collision
+// resistant: the indexer keeps only the last tx indexed under an ID, and the
+// mempool and the RPC look up a tx by its ID. If it returns an empty ID, the
+// SHA256 hash of the tx is used instead.
+type TxHashFunc func(tx Tx) []byte
+
+// The tx hash functions are registered by name with RegisterTxHashFunc,
+// usually from the init function of a package of the application, and the one
+// of the node is selected with SetTxHashFunc from the tx-hash of the config.
+var txHashes = struct {
+	mtx      sync.RWMutex
+	funcs    map[string]TxHashFunc
+	name     string
+	selected TxHashFunc
+}{
+	funcs: map[string]TxHashFunc{TxHashSHA256: nil},
+	name:  TxHashSHA256,
+}
+
+// RegisterTxHashFunc registers the tx hash function with the name. It reports
+// an error if the name is empty or already registered.
+func RegisterTxHashFunc(name string, f TxHashFunc) error {
+	if name == "" {
+		return errors.New("tx hash function name cannot be empty")
+	}
+	if f == nil {
+		return fmt.Errorf("tx hash function %q is nil", name)
+	}
+	txHashes.mtx.Lock()
+	defer txHashes.mtx.Unlock()
+	if _, ok := txHashes.funcs[name]; ok {
+		return fmt.Errorf("tx hash function %q is already registered", name)
+	}
+	txHashes.funcs[name] = f
+	return nil
+}
+
+// SetTxHashFunc selects the registered tx hash function with the name as the
+// one of the IDs of the txs. It must be called before the node starts, as the
+// txs are indexed by their IDs.
+func SetTxHashFunc(name string) error {
+	txHashes.mtx.Lock()
+	defer txHashes.mtx.Unlock()
+	f, ok := txHashes.funcs[name]
+	if !ok {
+		return fmt.Errorf("tx hash function %q is not registered, must be one of %v", name, registeredTxHashFuncs())
+	}
+	txHashes.name, txHashes.selected = name, f
+	return nil
+}
+
+// TxHashFuncName returns the name of the selected tx hash function.
+func TxHashFuncName() string {
+	txHashes.mtx.RLock()
+	defer txHashes.mtx.RUnlock()
+	return txHashes.name
+}
+
+// The caller must hold txHashes.mtx.
+func registeredTxHashFuncs() []string {
+	names := make([]string, 0, len(txHashes.funcs))
+	for name := range txHashes.funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ID returns the ID of the tx, by the selected tx hash function: the hash the
+// mempool, the indexer and the RPC identify the tx by. By default it is the
+// SHA256 hash of the tx, equal to Hash. Unlike Hash, it is not part of the
+// hash of the block.
+func (tx Tx) ID() []byte {
+	txHashes.mtx.RLock()
+	f := txHashes.selected
+	txHashes.mtx.RUnlock()
+	if f == nil {
+		h := sha256.Sum256(tx)
+		return h[:]
+	}
+	if id := f(tx); len(id) > 0 {
+		return id
+	}
+	h := sha256.Sum256(tx)
+	return h[:]
+}
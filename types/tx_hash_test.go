@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxHashFunc(t *testing.T) {
+	tx := Tx("key=value")
+	require.Equal(t, TxHashSHA256, TxHashFuncName())
+	assert.Equal(t, tx.Hash(), tx.ID())
+
+	require.Error(t, RegisterTxHashFunc("", func(tx Tx) []byte { return tx }))
+	require.Error(t, RegisterTxHashFunc("nil", nil))
+	require.Error(t, RegisterTxHashFunc(TxHashSHA256, func(tx Tx) []byte { return tx }))
+	require.Error(t, SetTxHashFunc("unregistered"))
+
+	// registered once per process, e.g. with -count
+	_ = RegisterTxHashFunc("test-identity", func(tx Tx) []byte { return tx })
+	require.Error(t, RegisterTxHashFunc("test-identity", func(tx Tx) []byte { return tx }))
+	require.NoError(t, SetTxHashFunc("test-identity"))
+	t.Cleanup(func() { require.NoError(t, SetTxHashFunc(TxHashSHA256)) })
+
+	assert.Equal(t, "test-identity", TxHashFuncName())
+	assert.Equal(t, []byte(tx), tx.ID())
+	// the hash of the tx, which is part of the hash of the block, is unchanged
+	assert.NotEqual(t, tx.Hash(), tx.ID())
+	assert.Equal(t, tx.Key(), Tx("key=value").Key())
+
+	// an empty ID falls back to the SHA256 hash of the tx
+	assert.Equal(t, Tx("").Hash(), Tx("").ID())
+	require.NoError(t, SetTxHashFunc(TxHashSHA256))
+	assert.Equal(t, tx.Hash(), tx.ID())
+}
@@ -35,6 +35,13 @@ func TestTxIndex(t *testing.T) {
 	}
 }
 
+func TestTxsHashParallel(t *testing.T) {
+	for _, cnt := range []int{0, 1, 15} {
+		txs := makeTxs(cnt, 60)
+		assert.Equal(t, txs.Hash(), txs.HashParallel(4))
+	}
+}
+
 func TestTxIndexByHash(t *testing.T) {
 	for i := 0; i < 20; i++ {
 		txs := makeTxs(15, 60)
@@ -218,6 +225,7 @@ func TestValidateTxRecordSet(t *testing.T) {
 		for i, tx := range txrSet.IncludedTxs() {
 			require.Equal(t, Tx(trs[i].Tx), tx)
 		}
+		require.Equal(t, txrSet.IncludedTxs(), txrSet.AddedTxs())
 	})
 }
 
@@ -11,8 +11,20 @@ import (
 
 const batchVerifyThreshold = 2
 
+// shouldBatchVerify reports whether the commit can be batch verified, which
+// requires all the validators to have the same key type, supporting batch
+// verification.
 func shouldBatchVerify(vals *ValidatorSet, commit *Commit) bool {
-	return len(commit.Signatures) >= batchVerifyThreshold && batch.SupportsBatchVerifier(vals.GetProposer().PubKey)
+	if len(commit.Signatures) < batchVerifyThreshold || !batch.SupportsBatchVerifier(vals.GetProposer().PubKey) {
+		return false
+	}
+	keyType := vals.GetProposer().PubKey.Type()
+	for _, val := range vals.Validators {
+		if val.PubKey.Type() != keyType {
+			return false
+		}
+	}
+	return true
 }
 
 // TODO(wbanfield): determine if the following comment is still true regarding Gaia.
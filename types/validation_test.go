@@ -2,12 +2,15 @@ package types
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
@@ -171,6 +174,56 @@ func TestValidatorSet_VerifyCommit_CheckAllSignatures(t *testing.T) {
 	}
 }
 
+func TestValidatorSet_VerifyCommit_MixedKeyTypes(t *testing.T) {
+	var (
+		chainID = "test_chain_id"
+		h       = int64(3)
+		blockID = makeBlockIDRandom()
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vals := []PrivValidator{
+		MockPV{PrivKey: ed25519.GenPrivKey()},
+		MockPV{PrivKey: secp256k1.GenPrivKey()},
+		MockPV{PrivKey: ed25519.GenPrivKey()},
+		MockPV{PrivKey: secp256k1.GenPrivKey()},
+	}
+	sort.Sort(PrivValidatorsByAddress(vals))
+	validators := make([]*Validator, len(vals))
+	for i, val := range vals {
+		pubKey, err := val.GetPubKey(ctx)
+		require.NoError(t, err)
+		validators[i] = NewValidator(pubKey, 10)
+	}
+	valSet := NewValidatorSet(validators)
+
+	voteSet := NewExtendedVoteSet(chainID, h, 0, tmproto.PrecommitType, valSet)
+	extCommit, err := makeExtCommit(ctx, blockID, h, 0, voteSet, vals, time.Now())
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+	require.NoError(t, valSet.VerifyCommitLight(chainID, blockID, h, commit))
+
+	// malleate the signature of a secp256k1 validator
+	for i, val := range vals {
+		pubKey, err := val.GetPubKey(ctx)
+		require.NoError(t, err)
+		if pubKey.Type() != ABCIPubKeyTypeSecp256k1 {
+			continue
+		}
+		vote := voteSet.GetByIndex(int32(i))
+		v := vote.ToProto()
+		require.NoError(t, val.SignVote(ctx, "CentaurusA", v))
+		vote.Signature = v.Signature
+		commit.Signatures[i] = vote.CommitSig()
+		break
+	}
+	assert.Error(t, valSet.VerifyCommit(chainID, blockID, h, commit))
+}
+
 func TestValidatorSet_VerifyCommitLight_ReturnsAsSoonAsMajorityOfVotingPowerSigned(t *testing.T) {
 	var (
 		chainID = "test_chain_id"
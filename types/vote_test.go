@@ -476,6 +476,7 @@ func TestInvalidPrecommitExtensions(t *testing.T) {
 			v.ExtensionSignature = nil
 		}},
 		{"oversized vote extension signature", func(v *Vote) { v.ExtensionSignature = make([]byte, MaxSignatureSize+1) }},
+		{"oversized vote extension", func(v *Vote) { v.Extension = make([]byte, MaxVoteExtensionSize+1) }},
 	}
 	for _, tc := range testCases {
 		precommit := examplePrecommit(t)